@@ -90,7 +90,9 @@ func (cl *commandline) Register(rootCmd *cobra.Command) *cobra.Command {
 	cl.status(rootCmd)
 	cl.stats(rootCmd)
 	cl.serverConfig(rootCmd)
+	cl.tls(rootCmd)
 	cl.database(rootCmd)
+	cl.query(rootCmd)
 	return rootCmd
 }
 
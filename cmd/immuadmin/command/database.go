@@ -18,6 +18,8 @@ package immuadmin
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	c "github.com/codenotary/immudb/cmd/helper"
 	"github.com/codenotary/immudb/pkg/api/schema"
@@ -78,6 +80,11 @@ func (cl *commandline) database(cmd *cobra.Command) {
 				return err
 			}
 
+			settings.Collation, err = cmd.Flags().GetString("collation")
+			if err != nil {
+				return err
+			}
+
 			if err := cl.immuClient.CreateDatabase(cl.context, settings); err != nil {
 				return err
 			}
@@ -90,6 +97,9 @@ func (cl *commandline) database(cmd *cobra.Command) {
 	}
 	cc.Flags().Bool("exclude-commit-time", false,
 		"do not include server-side timestamps in commit checksums, useful when reproducibility is a desired feature")
+	cc.Flags().String("collation", "", "set the order of keys in the index (bytewise, case-insensitive), it can not be changed afterwards")
+	cc.Flags().Duration("retention-period", 0, "truncate the values of transactions older than the period e.g. 17520h (0 keeps them all)")
+	cc.Flags().Bool("retention-dry-run", false, "only report what the retention period would truncate")
 	cc.Flags().Bool("replication-enabled", false, "set database as a replica")
 	cc.Flags().String("replication-master-database", "", "set master database to be replicated")
 	cc.Flags().String("replication-master-address", "127.0.0.1", "set master address")
@@ -121,6 +131,8 @@ func (cl *commandline) database(cmd *cobra.Command) {
 	}
 	cu.Flags().Bool("exclude-commit-time", false,
 		"do not include server-side timestamps in commit checksums, useful when reproducibility is a desired feature")
+	cu.Flags().Duration("retention-period", 0, "truncate the values of transactions older than the period e.g. 17520h (0 keeps them all)")
+	cu.Flags().Bool("retention-dry-run", false, "only report what the retention period would truncate")
 	cu.Flags().Bool("replication-enabled", false, "set database as a replica")
 	cu.Flags().String("replication-master-database", "", "set master database to be replicated")
 	cu.Flags().String("replication-master-address", "127.0.0.1", "set master address")
@@ -128,6 +140,36 @@ func (cl *commandline) database(cmd *cobra.Command) {
 	cu.Flags().String("replication-follower-username", "", "set username used for replication")
 	cu.Flags().String("replication-follower-password", "", "set password used for replication")
 
+	cr := &cobra.Command{
+		Use:               "restore",
+		Short:             "Create a new database with the state of an existing one as of the specified transaction",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		Example:           "restore {source_database_name} {target_database_name} --tx {tx_id}",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txID, err := cmd.Flags().GetUint64("tx")
+			if err != nil {
+				return err
+			}
+
+			err = cl.immuClient.RestoreDatabaseAt(cl.context, &schema.RestoreDatabaseAtRequest{
+				SourceDatabase: args[0],
+				TargetDatabase: args[1],
+				TxId:           txID,
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(),
+				"database '%s' successfully restored from '%s' at tx %d\n", args[1], args[0], txID)
+			return nil
+		},
+		Args: cobra.ExactArgs(2),
+	}
+	cr.Flags().Uint64("tx", 0, "transaction id the database state is restored at")
+	cr.MarkFlagRequired("tx")
+
 	ccu := &cobra.Command{
 		Use:               "use command",
 		Short:             "Select database",
@@ -178,11 +220,68 @@ func (cl *commandline) database(cmd *cobra.Command) {
 		Args: cobra.ExactArgs(0),
 	}
 
+	cci := &cobra.Command{
+		Use:               "index-suggestions",
+		Short:             "Suggest indexes which would have avoided full scans on the selected database",
+		Example:           "index-suggestions",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immuClient.IndexSuggestions(cl.context)
+			if err != nil {
+				cl.quit(err)
+				return err
+			}
+			c.PrintTable(
+				cmd.OutOrStdout(),
+				[]string{"Full scans", "Statement"},
+				len(resp.Suggestions),
+				func(i int) []string {
+					s := resp.Suggestions[i]
+					return []string{strconv.FormatUint(s.FullScans, 10), s.Stmt}
+				},
+				fmt.Sprintf("%d suggestion(s)", len(resp.Suggestions)),
+			)
+			return nil
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cip := &cobra.Command{
+		Use:               "indexing-progress",
+		Short:             "Show how far the indexing of the selected database is",
+		Example:           "indexing-progress",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immuClient.IndexingProgress(cl.context)
+			if err != nil {
+				cl.quit(err)
+				return err
+			}
+
+			checkpointedAt := "-"
+			if resp.CheckpointedAt > 0 {
+				checkpointedAt = time.Unix(resp.CheckpointedAt, 0).String()
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Committed tx:\t%d\n", resp.CommittedTxId)
+			fmt.Fprintf(cmd.OutOrStdout(), "Indexed tx:\t%d\n", resp.IndexedTxId)
+			fmt.Fprintf(cmd.OutOrStdout(), "Checkpoint tx:\t%d (%s)\n", resp.CheckpointTxId, checkpointedAt)
+			fmt.Fprintf(cmd.OutOrStdout(), "Resumed from tx:\t%d\n", resp.ResumedFromTxId)
+			return nil
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
 	ccmd.AddCommand(ccc)
+	ccmd.AddCommand(cci)
+	ccmd.AddCommand(cip)
 	ccmd.AddCommand(ccu)
 	ccmd.AddCommand(ccd)
 	ccmd.AddCommand(cc)
 	ccmd.AddCommand(cu)
+	ccmd.AddCommand(cr)
 	cmd.AddCommand(ccmd)
 }
 
@@ -192,6 +291,19 @@ func prepareDatabaseSettings(db string, flags *pflag.FlagSet) (*schema.DatabaseS
 		return nil, err
 	}
 
+	retentionPeriod, err := flags.GetDuration("retention-period")
+	if err != nil {
+		return nil, err
+	}
+	if retentionPeriod < 0 {
+		return nil, fmt.Errorf("invalid retention period %s", retentionPeriod)
+	}
+
+	retentionDryRun, err := flags.GetBool("retention-dry-run")
+	if err != nil {
+		return nil, err
+	}
+
 	replicationEnabled, err := flags.GetBool("replication-enabled")
 	if err != nil {
 		return nil, err
@@ -201,6 +313,8 @@ func prepareDatabaseSettings(db string, flags *pflag.FlagSet) (*schema.DatabaseS
 		return &schema.DatabaseSettings{
 			DatabaseName:      db,
 			ExcludeCommitTime: excludeCommitTime,
+			RetentionPeriod:   uint64(retentionPeriod.Milliseconds()),
+			RetentionDryRun:   retentionDryRun,
 		}, nil
 	}
 
@@ -238,5 +352,7 @@ func prepareDatabaseSettings(db string, flags *pflag.FlagSet) (*schema.DatabaseS
 		MasterPort:        masterPort,
 		FollowerUsername:  followerUsername,
 		FollowerPassword:  followerPassword,
+		RetentionPeriod:   uint64(retentionPeriod.Milliseconds()),
+		RetentionDryRun:   retentionDryRun,
 	}, nil
 }
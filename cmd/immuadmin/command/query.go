@@ -0,0 +1,96 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuadmin
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	c "github.com/codenotary/immudb/cmd/helper"
+	"github.com/spf13/cobra"
+)
+
+func (cl *commandline) query(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:               "query",
+		Short:             "Issue all query commands",
+		Aliases:           []string{"q"},
+		PersistentPostRun: cl.disconnect,
+		ValidArgs:         []string{"list", "kill"},
+	}
+
+	ccl := &cobra.Command{
+		Use:               "list",
+		Short:             "List queries running on the selected database",
+		Aliases:           []string{"l"},
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immuClient.ListQueries(cl.context)
+			if err != nil {
+				cl.quit(err)
+				return err
+			}
+			c.PrintTable(
+				cmd.OutOrStdout(),
+				[]string{"ID", "User", "Elapsed", "SQL"},
+				len(resp.Queries),
+				func(i int) []string {
+					q := resp.Queries[i]
+					return []string{
+						strconv.FormatUint(q.Id, 10),
+						q.User,
+						(time.Duration(q.Elapsed) * time.Millisecond).String(),
+						q.Sql,
+					}
+				},
+				fmt.Sprintf("%d query(ies)", len(resp.Queries)),
+			)
+			return nil
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cck := &cobra.Command{
+		Use:               "kill",
+		Short:             "Cancel a running query",
+		Example:           "kill {query_id}",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				cl.quit(fmt.Errorf("invalid query id '%s'", args[0]))
+				return err
+			}
+
+			if err := cl.immuClient.CancelQuery(cl.context, id); err != nil {
+				cl.quit(err)
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "query %d successfully cancelled\n", id)
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	ccmd.AddCommand(ccl)
+	ccmd.AddCommand(cck)
+	cmd.AddCommand(ccmd)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuadmin
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func (cl *commandline) tls(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:               "tls command",
+		Short:             "Issue all server tls commands",
+		PersistentPreRunE: cl.ConfigChain(cl.checkLoggedInAndConnect),
+		PersistentPostRun: cl.disconnect,
+	}
+	rotateCmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotate the server tls certificate without restarting it",
+		Long: "Replace the certificate the server presents on new connections, established connections are kept alive until closed. " +
+			"The provided certificate and private key are pushed to the server, when none is provided the server reloads them " +
+			"from the files it was started with. The certificate chain is verified by the server before taking it.",
+		Example: `immuadmin tls rotate
+immuadmin tls rotate --cert-file server.pem --key-file server.key`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			certFile, err := cmd.Flags().GetString("cert-file")
+			if err != nil {
+				return err
+			}
+			keyFile, err := cmd.Flags().GetString("key-file")
+			if err != nil {
+				return err
+			}
+
+			if (certFile == "") != (keyFile == "") {
+				return errors.New("both the certificate and the private key files are required")
+			}
+
+			var cert, key []byte
+
+			if certFile != "" {
+				cert, err = ioutil.ReadFile(certFile)
+				if err != nil {
+					return err
+				}
+				key, err = ioutil.ReadFile(keyFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			res, err := cl.immuClient.RotateTLSCertificate(cl.context, cert, key)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Server tls certificate rotated\n")
+			fmt.Fprintf(cmd.OutOrStdout(), "Subject:\t%s\n", res.Subject)
+			fmt.Fprintf(cmd.OutOrStdout(), "Serial number:\t%s\n", res.SerialNumber)
+			fmt.Fprintf(cmd.OutOrStdout(), "Valid until:\t%s\n", time.Unix(res.NotAfter, 0).UTC().Format(time.RFC3339))
+			return nil
+		},
+		Args: cobra.NoArgs,
+	}
+	rotateCmd.Flags().String("cert-file", "", "PEM encoded certificate chain to be pushed to the server")
+	rotateCmd.Flags().String("key-file", "", "PEM encoded private key of the certificate to be pushed to the server")
+
+	ccmd.AddCommand(rotateCmd)
+	cmd.AddCommand(ccmd)
+}
@@ -58,9 +58,14 @@ func (cl *commandline) user(cmd *cobra.Command) {
 		Long:  "Create a new user inside a database with permissions",
 		Example: `immuadmin user create user1 read mydb
 immuadmin user create user1 readwrite mydb
-immuadmin user create user1 admin mydb`,
+immuadmin user create user1 admin mydb
+immuadmin user create user1 readwrite mydb --namespace tenant1`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			resp, err := cl.userCreate(args)
+			namespace, err := cmd.Flags().GetString("namespace")
+			if err != nil {
+				return err
+			}
+			resp, err := cl.namespacedUserCreate(args, namespace)
 			if err != nil {
 				c.QuitToStdErr(err)
 			}
@@ -69,6 +74,7 @@ immuadmin user create user1 admin mydb`,
 		},
 		Args: cobra.RangeArgs(2, 3),
 	}
+	userCreate.Flags().String("namespace", "", "confine all key-value and SQL operations of the user under the namespace")
 	userChangePassword := &cobra.Command{
 		Use:     "changepassword",
 		Short:   "Change user password",
@@ -126,12 +132,27 @@ immuadmin user create user1 admin mydb`,
 		},
 		Args: cobra.ExactValidArgs(4),
 	}
+	userDefaultDatabase := &cobra.Command{
+		Use:     "defaultdb {username} [{database}]",
+		Short:   "Set the database the user sessions start with",
+		Long:    "Set the database the user sessions start with, no USE being needed. The default database is cleared if none is specified",
+		Example: "immuadmin user defaultdb user1 mydb",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			var resp string
+			if resp, err = cl.setUserDefaultDatabase(args); err == nil {
+				fmt.Fprint(cmd.OutOrStdout(), resp)
+			}
+			return err
+		},
+		Args: cobra.RangeArgs(1, 2),
+	}
 	ccmd.AddCommand(userListCmd)
 	ccmd.AddCommand(userCreate)
 	ccmd.AddCommand(userChangePassword)
 	ccmd.AddCommand(userActivate)
 	ccmd.AddCommand(userDeactivate)
 	ccmd.AddCommand(userPermission)
+	ccmd.AddCommand(userDefaultDatabase)
 	cmd.AddCommand(ccmd)
 }
 
@@ -232,6 +253,10 @@ func permissionToString(permission uint32) string {
 }
 
 func (cl *commandline) userCreate(args []string) (string, error) {
+	return cl.namespacedUserCreate(args, "")
+}
+
+func (cl *commandline) namespacedUserCreate(args []string, namespace string) (string, error) {
 	username := args[0]
 	permissionStr := args[1]
 	var databasename string
@@ -276,7 +301,11 @@ func (cl *commandline) userCreate(args []string) (string, error) {
 		return "", fmt.Errorf("Passwords don't match")
 	}
 
-	err = cl.immuClient.CreateUser(cl.context, []byte(username), pass, permission, databasename)
+	if namespace == "" {
+		err = cl.immuClient.CreateUser(cl.context, []byte(username), pass, permission, databasename)
+	} else {
+		err = cl.immuClient.CreateNamespacedUser(cl.context, []byte(username), pass, permission, databasename, namespace)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -295,6 +324,22 @@ func (cl *commandline) setActiveUser(args []string, active bool) (string, error)
 	return "User status changed successfully", nil
 }
 
+func (cl *commandline) setUserDefaultDatabase(args []string) (string, error) {
+	username := args[0]
+	var database string
+	if len(args) > 1 {
+		database = args[1]
+	}
+	err := cl.immuClient.SetUserDefaultDatabase(cl.context, username, database)
+	if err != nil {
+		return "", err
+	}
+	if database == "" {
+		return "Default database cleared successfully", nil
+	}
+	return "Default database set successfully", nil
+}
+
 func (cl *commandline) setUserPermission(args []string) (resp string, err error) {
 	var permissionAction schema.PermissionAction
 	switch args[0] {
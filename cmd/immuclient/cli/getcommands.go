@@ -27,3 +27,11 @@ func (cli *cli) getKey(args []string) (string, error) {
 func (cli *cli) safeGetKey(args []string) (string, error) {
 	return cli.immucl.VerifiedGet(args)
 }
+
+func (cli *cli) exportEvidence(args []string) (string, error) {
+	return cli.immucl.ExportEvidence(args)
+}
+
+func (cli *cli) verifyEvidence(args []string) (string, error) {
+	return cli.immucl.VerifyEvidence(args)
+}
@@ -65,4 +65,9 @@ func (cli *cli) initCommands() {
 	cli.Register(&command{"query", "Query sql statement", cli.sqlQuery, []string{"statement"}, true})
 	cli.Register(&command{"describe", "Describe table", cli.describeTable, []string{"table"}, false})
 	cli.Register(&command{"tables", "List tables", cli.listTables, nil, false})
+	cli.Register(&command{"export-parquet", "Write the result of a sql query to a parquet file", cli.exportParquet, []string{"file", "statement"}, true})
+
+	// Evidence
+	cli.Register(&command{"evidence-export", "Write an evidence archive of the specified keys", cli.exportEvidence, []string{"file", "key"}, true})
+	cli.Register(&command{"evidence-verify", "Verify an evidence archive", cli.verifyEvidence, []string{"file"}, false})
 }
@@ -24,6 +24,10 @@ func (cli *cli) sqlQuery(args []string) (string, error) {
 	return cli.immucl.SQLQuery(args)
 }
 
+func (cli *cli) exportParquet(args []string) (string, error) {
+	return cli.immucl.ExportParquet(args)
+}
+
 func (cli *cli) describeTable(args []string) (string, error) {
 	return cli.immucl.DescribeTable(args)
 }
@@ -72,6 +72,9 @@ func (cl *commandline) Register(rootCmd *cobra.Command) *cobra.Command {
 	cl.sqlQuery(rootCmd)
 	cl.listTables(rootCmd)
 	cl.describeTable(rootCmd)
+	cl.exportParquet(rootCmd)
+
+	cl.evidence(rootCmd)
 
 	return rootCmd
 }
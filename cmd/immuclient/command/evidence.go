@@ -0,0 +1,63 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuclient
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func (cl *commandline) evidence(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:   "evidence",
+		Short: "Create and verify evidence archives",
+	}
+
+	ce := &cobra.Command{
+		Use:               "export file key [key...]",
+		Short:             "Write an archive with the specified keys and the proofs linking them to the current signed state",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immucl.ExportEvidence(args)
+			if err != nil {
+				cl.quit(err)
+			}
+			fprintln(cmd.OutOrStdout(), resp)
+			return nil
+		},
+		Args: cobra.MinimumNArgs(2),
+	}
+
+	cv := &cobra.Command{
+		Use:               "verify file",
+		Short:             "Verify an evidence archive, no connection to the server is required",
+		PersistentPreRunE: cl.ConfigChain(nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immucl.VerifyEvidence(args)
+			if err != nil {
+				cl.quit(err)
+			}
+			fprintln(cmd.OutOrStdout(), resp)
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	ccmd.AddCommand(ce)
+	ccmd.AddCommand(cv)
+	cmd.AddCommand(ccmd)
+}
@@ -100,3 +100,22 @@ func (cl *commandline) describeTable(cmd *cobra.Command) {
 	}
 	cmd.AddCommand(ccmd)
 }
+
+func (cl *commandline) exportParquet(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:               "export-parquet file statement",
+		Short:             "Write the result of a sql query to a parquet file",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immucl.ExportParquet(args)
+			if err != nil {
+				cl.quit(err)
+			}
+			fprintln(cmd.OutOrStdout(), resp)
+			return nil
+		},
+		Args: cobra.MinimumNArgs(2),
+	}
+	cmd.AddCommand(ccmd)
+}
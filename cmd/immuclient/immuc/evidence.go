@@ -0,0 +1,113 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/signer"
+)
+
+// ExportEvidence writes an evidence archive of the specified keys into the file given as first argument
+func (i *immuc) ExportEvidence(args []string) (string, error) {
+	fileName := args[0]
+
+	keys := make([][]byte, len(args)-1)
+	for j, key := range args[1:] {
+		keys[j] = []byte(key)
+	}
+
+	ctx := context.Background()
+	response, err := i.Execute(func(immuClient client.ImmuClient) (interface{}, error) {
+		return immuClient.ExportEvidence(ctx, keys, nil, "")
+	})
+	if err != nil {
+		return "", err
+	}
+
+	archive := response.(*schema.EvidenceArchive)
+
+	data, err := client.MarshalEvidence(archive)
+	if err != nil {
+		return "", err
+	}
+
+	err = ioutil.WriteFile(fileName, data, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("evidence of %d entries at tx %d written to %s", len(archive.Entries), archive.State.TxId, fileName), nil
+}
+
+// VerifyEvidence checks the evidence archive stored in the file given as first argument, no connection is required
+func (i *immuc) VerifyEvidence(args []string) (string, error) {
+	data, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	archive, err := client.UnmarshalEvidence(data)
+	if err != nil {
+		return "", err
+	}
+
+	var pk *ecdsa.PublicKey
+
+	if i.options.ServerSigningPubKey != "" {
+		pk, err = signer.ParsePublicKeyFile(i.options.ServerSigningPubKey)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = client.VerifyEvidence(archive, pk)
+	if err != nil {
+		return "", err
+	}
+
+	str := strings.Builder{}
+
+	str.WriteString(fmt.Sprintf("db:\t\t%s\n", archive.State.Db))
+	str.WriteString(PrintState(archive.State))
+	str.WriteString(fmt.Sprintf("signed:\t\t%v\n", pk != nil))
+
+	if archive.Metadata != nil {
+		str.WriteString(fmt.Sprintf("producer:\t%s\n", archive.Metadata.Producer))
+		str.WriteString(fmt.Sprintf("server:\t\t%s\n", archive.Metadata.ServerUUID))
+		str.WriteString(fmt.Sprintf("user:\t\t%s\n", archive.Metadata.User))
+		str.WriteString(fmt.Sprintf("created:\t%s\n", time.Unix(archive.Metadata.CreatedAt, 0)))
+	}
+
+	for _, e := range archive.Entries {
+		str.WriteString(PrintKV(e.Entry.Key, e.Entry.Metadata, e.Entry.Value, e.Entry.Tx, true, i.valueOnly))
+		str.WriteString("\n")
+	}
+
+	for _, row := range archive.Rows {
+		str.WriteString(fmt.Sprintf("row:\t\t%s (tx %d)\n", row.SqlGetRequest.Table, row.VerifiableSQLEntry.SqlEntry.Tx))
+	}
+
+	return str.String(), nil
+}
@@ -75,6 +75,9 @@ type Client interface {
 	SQLQuery(args []string) (string, error)
 	ListTables() (string, error)
 	DescribeTable(args []string) (string, error)
+	ExportParquet(args []string) (string, error)
+	ExportEvidence(args []string) (string, error)
+	VerifyEvidence(args []string) (string, error)
 
 	WithFileTokenService(tkns tokenservice.TokenService) Client
 }
@@ -62,6 +62,9 @@ func (i *immuc) Login(args []string) (string, error) {
 
 	i.isLoggedin = true
 	successMsg := "Successfully logged in\n"
+	if response.Database != "" {
+		successMsg += fmt.Sprintf("Using database %s\n", response.Database)
+	}
 	if len(response.Warning) != 0 {
 		successMsg += string(response.Warning)
 	}
@@ -16,14 +16,16 @@ limitations under the License.
 package immuc
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/codenotary/immudb/pkg/client"
-	"github.com/olekukonko/tablewriter"
+	"github.com/codenotary/immudb/pkg/client/parquet"
+	"github.com/codenotary/immudb/pkg/repl"
 )
 
 func (i *immuc) SQLExec(args []string) (string, error) {
@@ -55,7 +57,7 @@ func (i *immuc) SQLQuery(args []string) (string, error) {
 		if err != nil {
 			return nil, err
 		}
-		return renderTableResult(resp), nil
+		return repl.FormatResult(resp), nil
 	})
 	if err != nil {
 		return "", err
@@ -71,7 +73,7 @@ func (i *immuc) ListTables() (string, error) {
 		if err != nil {
 			return nil, err
 		}
-		return renderTableResult(resp), nil
+		return repl.FormatResult(resp), nil
 	})
 	if err != nil {
 		return "", err
@@ -89,7 +91,7 @@ func (i *immuc) DescribeTable(args []string) (string, error) {
 		if err != nil {
 			return nil, err
 		}
-		return renderTableResult(resp), nil
+		return repl.FormatResult(resp), nil
 	})
 	if err != nil {
 		return "", err
@@ -97,28 +99,32 @@ func (i *immuc) DescribeTable(args []string) (string, error) {
 	return response.(string), nil
 }
 
-func renderTableResult(resp *schema.SQLQueryResult) string {
-	if resp == nil {
-		return ""
-	}
-	result := bytes.NewBuffer([]byte{})
-	consoleTable := tablewriter.NewWriter(result)
-	cols := make([]string, len(resp.Columns))
-	for i, c := range resp.Columns {
-		cols[i] = c.Name
+// ExportParquet writes the result of the sql query following the file name into a parquet file
+func (i *immuc) ExportParquet(args []string) (string, error) {
+	fileName := args[0]
+	sqlStmt := strings.Join(args[1:], " ")
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return "", err
 	}
-	consoleTable.SetHeader(cols)
+	defer f.Close()
 
-	for _, r := range resp.Rows {
-		row := make([]string, len(r.Values))
+	w := bufio.NewWriter(f)
 
-		for i, v := range r.Values {
-			row[i] = schema.RenderValue(v.Value)
-		}
+	ctx := context.Background()
+	response, err := i.Execute(func(immuClient client.ImmuClient) (interface{}, error) {
+		return parquet.ExportQuery(ctx, immuClient, w, sqlStmt, nil)
+	})
+	if err != nil {
+		os.Remove(fileName)
+		return "", err
+	}
 
-		consoleTable.Append(row)
+	err = w.Flush()
+	if err != nil {
+		return "", err
 	}
 
-	consoleTable.Render()
-	return result.String()
+	return fmt.Sprintf("%d rows written to %s", response.(int64), fileName), nil
 }
@@ -24,11 +24,12 @@ import (
 )
 
 type ImmuServerMock struct {
-	Options     *server.Options
-	Logger      logger.Logger
-	StateSigner server.StateSigner
-	Ssf         stream.ServiceFactory
-	PgsqlSrv    pgsqlsrv.Server
+	Options        *server.Options
+	Logger         logger.Logger
+	StateSigner    server.StateSigner
+	WriteValidator server.WriteValidator
+	Ssf            stream.ServiceFactory
+	PgsqlSrv       pgsqlsrv.Server
 }
 
 func (s ImmuServerMock) WithPgsqlServer(psrv pgsqlsrv.Server) server.ImmuServerIf {
@@ -50,6 +51,11 @@ func (s ImmuServerMock) WithStateSigner(stateSigner server.StateSigner) server.I
 	return s
 }
 
+func (s ImmuServerMock) WithWriteValidator(writeValidator server.WriteValidator) server.ImmuServerIf {
+	s.WriteValidator = writeValidator
+	return s
+}
+
 func (s ImmuServerMock) WithStreamServiceFactory(ssf stream.ServiceFactory) server.ImmuServerIf {
 	s.Ssf = ssf
 	return s
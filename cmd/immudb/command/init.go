@@ -55,6 +55,18 @@ func (cl *Commandline) setupFlags(cmd *cobra.Command, options *server.Options) {
 	cmd.Flags().Int("web-server-port", options.WebServerPort, "web/console server port")
 	cmd.Flags().Bool("pgsql-server", true, "enable or disable pgsql server")
 	cmd.Flags().Int("pgsql-server-port", 5432, "pgsql server port")
+	cmd.Flags().Bool("prom-remote-write", options.PromRemoteWriteServer, "enable or disable the Prometheus remote-write server")
+	cmd.Flags().Int("prom-remote-write-port", options.PromRemoteWritePort, "Prometheus remote-write server port")
+	cmd.Flags().String("prom-remote-write-database", options.PromRemoteWriteDatabase, "database storing the samples received through Prometheus remote-write")
+	cmd.Flags().Bool("query-server", options.QueryServer, "enable or disable the read-only HTTP query server")
+	cmd.Flags().Int("query-server-port", options.QueryServerPort, "read-only HTTP query server port")
+	cmd.Flags().String("query-api-keys", "", "API keys accepted by the query server with the databases or db.table names each one can read e.g. \"key1=defaultdb,key2=sales.orders;sales.customers\"")
+	cmd.Flags().String("grafana-tables", "", "tables exposed to Grafana through the query server with the column holding the time of their rows e.g. \"defaultdb.metrics=ts\"")
+	cmd.Flags().Bool("syslog-server", options.SyslogServer, "enable or disable the syslog server (tcp and udp)")
+	cmd.Flags().Int("syslog-port", options.SyslogPort, "syslog server port")
+	cmd.Flags().Bool("fluent-forward-server", options.FluentForwardServer, "enable or disable the Fluent Forward server")
+	cmd.Flags().Int("fluent-forward-port", options.FluentForwardPort, "Fluent Forward server port")
+	cmd.Flags().String("log-ingest-database", options.LogIngestDatabase, "database storing the records received through syslog and Fluent Forward")
 	cmd.Flags().Bool("s3-storage", false, "enable or disable s3 storage")
 	cmd.Flags().String("s3-endpoint", "", "s3 endpoint")
 	cmd.Flags().String("s3-access-key-id", "", "s3 access key id")
@@ -66,6 +78,22 @@ func (cl *Commandline) setupFlags(cmd *cobra.Command, options *server.Options) {
 	cmd.Flags().Duration("max-session-age-time", 0, "the current default value is infinity. max session age time is a duration after which session will be forcibly closed")
 	cmd.Flags().Duration("session-timeout", 2*time.Minute, "session timeout is a duration after which an inactive session is forcibly closed by the server")
 	cmd.Flags().Duration("sessions-guard-check-interval", 1*time.Minute, "sessions guard check interval")
+	cmd.Flags().Duration("min-tx-wait-timeout", options.MinTxWaitTimeout, "max time a read-your-writes request waits for the database to reach the last transaction written by the client")
+	cmd.Flags().Int("default-page-size", options.DefaultPageSize, "number of results returned by scans, histories and SQL queries when no limit is requested")
+	cmd.Flags().Int("max-page-size", options.MaxPageSize, "max number of results returned at once, larger requests are truncated and a continuation token is returned")
+	cmd.Flags().Int("self-check-txs", options.SelfCheckTxs, "number of last transactions of each database verified on startup (0 disables the self-check)")
+	cmd.Flags().Bool("self-check-full", options.SelfCheckFull, "verify all the transactions of each database on startup")
+	cmd.Flags().String("self-check-database", options.SelfCheckDatabase, "database self-check reports are written into")
+	cmd.Flags().Int("index-buffer-pool-size", options.IndexBufferPoolSize, "max number of index nodes kept in memory by all databases, each one taking up to max-node-size bytes (0 keeps a cache per database)")
+	cmd.Flags().Uint64("min-free-disk-space", options.MinFreeDiskSpace, "free disk space in bytes under which databases are switched to read-only (0 disables the disk space watchdog)")
+	cmd.Flags().Duration("disk-space-check-interval", options.DiskSpaceCheckInterval, "how often the free disk space is checked")
+	cmd.Flags().Duration("retention-check-interval", options.RetentionCheckInterval, "how often databases are truncated as their retention periods require (0 disables the truncation of databases)")
+	cmd.Flags().Bool("audit-log", options.AuditLogOptions.Enabled, "enable or disable the audit log of the requests served")
+	cmd.Flags().Float64("audit-sample-rate", options.AuditLogOptions.SampleRate, "fraction of the successful requests being audited, failed ones are always audited")
+	cmd.Flags().String("audit-method-sample-rates", "", "sample rates of specific methods, overriding the default one e.g. \"Set=0.01,Login=1\"")
+	cmd.Flags().String("audit-scrub-rules", "", "how the request fields are scrubbed before being audited, by field name e.g. \"key=hash,value=drop\" (passwords are never audited)")
+	cmd.Flags().Duration("idempotency-key-ttl", options.IdempotencyKeyTTL, "for how long the result of a write is returned to the retries sharing its idempotency key (0 keeps results until evicted)")
+	cmd.Flags().Int("idempotency-cache-size", options.IdempotencyCacheSize, "max number of write results remembered by idempotency key (0 ignores idempotency keys)")
 	cmd.Flags().MarkHidden("sessions-guard-check-interval")
 }
 
@@ -93,6 +121,18 @@ func setupDefaults(options *server.Options) {
 	viper.SetDefault("web-server-port", options.WebServerPort)
 	viper.SetDefault("pgsql-server", true)
 	viper.SetDefault("pgsql-server-port", 5432)
+	viper.SetDefault("prom-remote-write", options.PromRemoteWriteServer)
+	viper.SetDefault("prom-remote-write-port", options.PromRemoteWritePort)
+	viper.SetDefault("prom-remote-write-database", options.PromRemoteWriteDatabase)
+	viper.SetDefault("query-server", options.QueryServer)
+	viper.SetDefault("query-server-port", options.QueryServerPort)
+	viper.SetDefault("query-api-keys", "")
+	viper.SetDefault("grafana-tables", "")
+	viper.SetDefault("syslog-server", options.SyslogServer)
+	viper.SetDefault("syslog-port", options.SyslogPort)
+	viper.SetDefault("fluent-forward-server", options.FluentForwardServer)
+	viper.SetDefault("fluent-forward-port", options.FluentForwardPort)
+	viper.SetDefault("log-ingest-database", options.LogIngestDatabase)
 	viper.SetDefault("s3-storage", false)
 	viper.SetDefault("s3-endpoint", "")
 	viper.SetDefault("s3-access-key-id", "")
@@ -104,4 +144,20 @@ func setupDefaults(options *server.Options) {
 	viper.SetDefault("max-session-age-time", 0)
 	viper.SetDefault("session-timeout", 2*time.Minute)
 	viper.SetDefault("sessions-guard-check-interval", 1*time.Minute)
+	viper.SetDefault("min-tx-wait-timeout", options.MinTxWaitTimeout)
+	viper.SetDefault("default-page-size", options.DefaultPageSize)
+	viper.SetDefault("max-page-size", options.MaxPageSize)
+	viper.SetDefault("self-check-txs", options.SelfCheckTxs)
+	viper.SetDefault("self-check-full", options.SelfCheckFull)
+	viper.SetDefault("self-check-database", options.SelfCheckDatabase)
+	viper.SetDefault("index-buffer-pool-size", options.IndexBufferPoolSize)
+	viper.SetDefault("min-free-disk-space", options.MinFreeDiskSpace)
+	viper.SetDefault("audit-log", options.AuditLogOptions.Enabled)
+	viper.SetDefault("audit-sample-rate", options.AuditLogOptions.SampleRate)
+	viper.SetDefault("audit-method-sample-rates", "")
+	viper.SetDefault("audit-scrub-rules", "")
+	viper.SetDefault("disk-space-check-interval", options.DiskSpaceCheckInterval)
+	viper.SetDefault("retention-check-interval", options.RetentionCheckInterval)
+	viper.SetDefault("idempotency-key-ttl", options.IdempotencyKeyTTL)
+	viper.SetDefault("idempotency-cache-size", options.IdempotencyCacheSize)
 }
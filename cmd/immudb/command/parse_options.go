@@ -17,6 +17,8 @@ limitations under the License.
 package immudb
 
 import (
+	"errors"
+
 	"github.com/codenotary/immudb/pkg/server"
 	"github.com/codenotary/immudb/pkg/server/sessions"
 	"github.com/spf13/viper"
@@ -73,6 +75,32 @@ func parseOptions() (options *server.Options, err error) {
 	s3Location := viper.GetString("s3-location")
 	s3PathPrefix := viper.GetString("s3-path-prefix")
 
+	auditSampleRates, err := server.ParseAuditSampleRates(viper.GetString("audit-method-sample-rates"))
+	if err != nil {
+		return nil, err
+	}
+
+	auditScrubRules, err := server.ParseAuditScrubRules(viper.GetString("audit-scrub-rules"))
+	if err != nil {
+		return nil, err
+	}
+
+	queryAPIKeys, err := server.ParseQueryAPIKeys(viper.GetString("query-api-keys"))
+	if err != nil {
+		return nil, err
+	}
+
+	grafanaTables, err := server.ParseGrafanaTables(viper.GetString("grafana-tables"))
+	if err != nil {
+		return nil, err
+	}
+
+	auditLogOptions := server.DefaultAuditLogOptions().
+		WithEnabled(viper.GetBool("audit-log")).
+		WithSampleRate(viper.GetFloat64("audit-sample-rate")).
+		WithMethodSampleRates(auditSampleRates).
+		WithScrubRules(auditScrubRules)
+
 	remoteStorageOptions := server.DefaultRemoteStorageOptions().
 		WithS3Storage(s3Storage).
 		WithS3Endpoint(s3Endpoint).
@@ -88,6 +116,13 @@ func parseOptions() (options *server.Options, err error) {
 		WithMaxSessionAgeTime(viper.GetDuration("max-session-age-time")).
 		WithTimeout(viper.GetDuration("session-timeout"))
 
+	defaultPageSize := viper.GetInt("default-page-size")
+	maxPageSize := viper.GetInt("max-page-size")
+
+	if maxPageSize < 1 || defaultPageSize < 1 || defaultPageSize > maxPageSize {
+		return options, errors.New("invalid page size, the default page size must be between 1 and the max page size")
+	}
+
 	tlsConfig, err := setUpTLS(pkey, certificate, clientcas, mtls)
 	if err != nil {
 		return options, err
@@ -102,6 +137,7 @@ func parseOptions() (options *server.Options, err error) {
 		WithPidfile(pidfile).
 		WithLogfile(logfile).
 		WithTLS(tlsConfig).
+		WithTLSCertificateFiles(certificate, pkey).
 		WithAuth(auth).
 		WithMaxRecvMsgSize(maxRecvMsgSize).
 		WithNoHistograms(noHistograms).
@@ -117,7 +153,32 @@ func parseOptions() (options *server.Options, err error) {
 		WithWebServerPort(webServerPort).
 		WithPgsqlServer(pgsqlServer).
 		WithPgsqlServerPort(pgsqlServerPort).
-		WithSessionOptions(sessionOptions)
+		WithPromRemoteWriteServer(viper.GetBool("prom-remote-write")).
+		WithPromRemoteWritePort(viper.GetInt("prom-remote-write-port")).
+		WithPromRemoteWriteDatabase(viper.GetString("prom-remote-write-database")).
+		WithQueryServer(viper.GetBool("query-server")).
+		WithQueryServerPort(viper.GetInt("query-server-port")).
+		WithQueryAPIKeys(queryAPIKeys).
+		WithGrafanaTables(grafanaTables).
+		WithSyslogServer(viper.GetBool("syslog-server")).
+		WithSyslogPort(viper.GetInt("syslog-port")).
+		WithFluentForwardServer(viper.GetBool("fluent-forward-server")).
+		WithFluentForwardPort(viper.GetInt("fluent-forward-port")).
+		WithLogIngestDatabase(viper.GetString("log-ingest-database")).
+		WithSessionOptions(sessionOptions).
+		WithMinTxWaitTimeout(viper.GetDuration("min-tx-wait-timeout")).
+		WithDefaultPageSize(defaultPageSize).
+		WithMaxPageSize(maxPageSize).
+		WithSelfCheckTxs(viper.GetInt("self-check-txs")).
+		WithSelfCheckFull(viper.GetBool("self-check-full")).
+		WithSelfCheckDatabase(viper.GetString("self-check-database")).
+		WithIndexBufferPoolSize(viper.GetInt("index-buffer-pool-size")).
+		WithMinFreeDiskSpace(viper.GetUint64("min-free-disk-space")).
+		WithDiskSpaceCheckInterval(viper.GetDuration("disk-space-check-interval")).
+		WithRetentionCheckInterval(viper.GetDuration("retention-check-interval")).
+		WithAuditLogOptions(auditLogOptions).
+		WithIdempotencyKeyTTL(viper.GetDuration("idempotency-key-ttl")).
+		WithIdempotencyCacheSize(viper.GetInt("idempotency-cache-size"))
 
 	return options, nil
 }
@@ -0,0 +1,103 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtree
+
+import (
+	"crypto/sha256"
+	"testing"
+)
+
+// digestsFrom splits b into digests, trailing bytes not filling a digest are discarded
+func digestsFrom(b []byte) [][sha256.Size]byte {
+	digests := make([][sha256.Size]byte, len(b)/sha256.Size)
+
+	for i := range digests {
+		copy(digests[i][:], b[i*sha256.Size:])
+	}
+
+	return digests
+}
+
+func digestFrom(b []byte) (d [sha256.Size]byte) {
+	copy(d[:], b)
+	return
+}
+
+func fuzzSeeds(f *testing.F) {
+	tree, err := Open(f.TempDir(), DefaultOptions())
+	if err != nil {
+		f.Fatal(err)
+	}
+	defer tree.Close()
+
+	for i := 0; i < 8; i++ {
+		_, _, err = tree.Append([]byte{byte(i)})
+		if err != nil {
+			f.Fatal(err)
+		}
+	}
+
+	root, err := tree.RootAt(8)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	iproof, err := tree.InclusionProof(3, 8)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	cproof, err := tree.ConsistencyProof(3, 8)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	var b []byte
+	for _, d := range append(iproof, cproof...) {
+		b = append(b, d[:]...)
+	}
+
+	f.Add(b, uint64(3), uint64(8), root[:], root[:])
+	f.Add([]byte{}, uint64(0), uint64(0), []byte{}, []byte{})
+}
+
+func FuzzVerifyInclusion(f *testing.F) {
+	fuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, proof []byte, i, j uint64, leaf, root []byte) {
+		VerifyInclusion(digestsFrom(proof), i, j, digestFrom(leaf), digestFrom(root))
+	})
+}
+
+func FuzzVerifyConsistency(f *testing.F) {
+	fuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, proof []byte, i, j uint64, iRoot, jRoot []byte) {
+		VerifyConsistency(digestsFrom(proof), i, j, digestFrom(iRoot), digestFrom(jRoot))
+	})
+}
+
+func FuzzVerifyLastInclusion(f *testing.F) {
+	fuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, proof []byte, i, _ uint64, leaf, root []byte) {
+		VerifyLastInclusion(digestsFrom(proof), i, digestFrom(leaf), digestFrom(root))
+	})
+}
@@ -36,6 +36,7 @@ var ErrorPathIsNotADirectory = errors.New("path is not a directory")
 var ErrIllegalArguments = errors.New("illegal arguments")
 var ErrAlreadyClosed = errors.New("multi-appendable already closed")
 var ErrReadOnly = errors.New("cannot append when opened in read-only mode")
+var ErrDiscarded = errors.New("data was discarded")
 
 const (
 	metaFileSize    = "FILE_SIZE"
@@ -96,6 +97,9 @@ type MultiFileAppendable struct {
 	currAppID int64
 	currApp   appendable.Appendable
 
+	// chunks before it were discarded
+	firstAppID int64
+
 	path     string
 	readOnly bool
 	synced   bool
@@ -111,9 +115,39 @@ type MultiFileAppendable struct {
 }
 
 func Open(path string, opts *Options) (*MultiFileAppendable, error) {
-	return OpenWithHooks(path, &DefaultMultiFileAppendableHooks{
+	mf, err := OpenWithHooks(path, &DefaultMultiFileAppendableHooks{
 		path: path,
 	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	mf.firstAppID, err = firstAppendableID(path, opts.fileExt)
+	if err != nil {
+		mf.Close()
+		return nil, err
+	}
+
+	return mf, nil
+}
+
+// firstAppendableID returns the id of the first chunk found in the directory,
+// chunks before it were discarded
+func firstAppendableID(path, ext string) (int64, error) {
+	fis, err := ioutil.ReadDir(path)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, fi := range fis {
+		if filepath.Ext(fi.Name()) != "."+ext {
+			continue
+		}
+
+		return strconv.ParseInt(strings.TrimSuffix(fi.Name(), filepath.Ext(fi.Name())), 10, 64)
+	}
+
+	return 0, nil
 }
 
 func OpenWithHooks(path string, hooks MultiFileAppendableHooks, opts *Options) (*MultiFileAppendable, error) {
@@ -417,6 +451,10 @@ func (mf *MultiFileAppendable) appendableFor(off int64) (appendable.Appendable,
 		return mf.currApp, nil
 	}
 
+	if appID < mf.firstAppID {
+		return nil, ErrDiscarded
+	}
+
 	app, err := mf.appendables.Get(appID)
 
 	if err != nil {
@@ -560,6 +598,70 @@ func (mf *MultiFileAppendable) Close() error {
 	return mf.currApp.Close()
 }
 
+// DiscardUpto removes the chunks holding data strictly before the chunk the offset belongs to,
+// the chunk being written is never removed. Reading discarded data fails with ErrDiscarded
+func (mf *MultiFileAppendable) DiscardUpto(off int64) error {
+	mf.mutex.Lock()
+	defer mf.mutex.Unlock()
+
+	if mf.closed {
+		return ErrAlreadyClosed
+	}
+
+	if mf.readOnly {
+		return ErrReadOnly
+	}
+
+	appID := mf.discardableAppID(off)
+
+	for ; mf.firstAppID < appID; mf.firstAppID++ {
+		app, err := mf.appendables.Pop(mf.firstAppID)
+		if err == nil {
+			err = app.Close()
+		}
+		if err != nil && err != cache.ErrKeyNotFound {
+			return err
+		}
+
+		err = os.Remove(filepath.Join(mf.path, appendableName(mf.firstAppID, mf.fileExt)))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DiscardableUpto returns the offset data would be discarded up to by DiscardUpto(off)
+func (mf *MultiFileAppendable) DiscardableUpto(off int64) int64 {
+	mf.mutex.Lock()
+	defer mf.mutex.Unlock()
+
+	appID := mf.discardableAppID(off)
+	if appID < mf.firstAppID {
+		appID = mf.firstAppID
+	}
+
+	return appID * int64(mf.fileSize)
+}
+
+// DiscardedUpto returns the offset data was discarded up to
+func (mf *MultiFileAppendable) DiscardedUpto() int64 {
+	mf.mutex.Lock()
+	defer mf.mutex.Unlock()
+
+	return mf.firstAppID * int64(mf.fileSize)
+}
+
+func (mf *MultiFileAppendable) discardableAppID(off int64) int64 {
+	appID := appendableID(off, mf.fileSize)
+	if appID > mf.currAppID {
+		return mf.currAppID
+	}
+
+	return appID
+}
+
 func (mf *MultiFileAppendable) CurrApp() (appendable.Appendable, int64) {
 	mf.mutex.Lock()
 	defer mf.mutex.Unlock()
@@ -16,7 +16,6 @@ limitations under the License.
 package multiapp
 
 import (
-	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -142,9 +141,10 @@ func TestMultiAppClosedAndDeletedFiles(t *testing.T) {
 	a, err = Open("testdata", DefaultOptions().WithFileSize(1).WithMaxOpenedFiles(1))
 	require.NoError(t, err)
 
+	// leading chunks missing are taken as discarded
 	b := make([]byte, n)
 	_, err = a.ReadAt(b, 0)
-	require.Equal(t, io.EOF, err)
+	require.Equal(t, ErrDiscarded, err)
 }
 
 func TestMultiAppClosedFiles(t *testing.T) {
@@ -215,6 +215,73 @@ func TestMultiAppReOpening(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMultiAppDiscardUpto(t *testing.T) {
+	a, err := Open("testdata", DefaultOptions().WithFileSize(2).WithMaxOpenedFiles(2))
+	defer os.RemoveAll("testdata")
+	require.NoError(t, err)
+
+	_, _, err = a.Append([]byte{0, 1, 2, 3, 4, 5, 6})
+	require.NoError(t, err)
+
+	err = a.Flush()
+	require.NoError(t, err)
+
+	require.Equal(t, int64(0), a.DiscardedUpto())
+	require.Equal(t, int64(2), a.DiscardableUpto(3))
+
+	// the chunk being written is never discarded
+	require.Equal(t, int64(6), a.DiscardableUpto(100))
+
+	err = a.DiscardUpto(3)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), a.DiscardedUpto())
+
+	b := make([]byte, 1)
+	_, err = a.ReadAt(b, 1)
+	require.Equal(t, ErrDiscarded, err)
+
+	_, err = a.ReadAt(b, 2)
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, b)
+
+	// discarding is not undone
+	err = a.DiscardUpto(0)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), a.DiscardedUpto())
+	require.Equal(t, int64(2), a.DiscardableUpto(0))
+
+	err = a.DiscardUpto(100)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), a.DiscardedUpto())
+
+	_, err = a.ReadAt(b, 5)
+	require.Equal(t, ErrDiscarded, err)
+
+	_, err = a.ReadAt(b, 6)
+	require.NoError(t, err)
+	require.Equal(t, []byte{6}, b)
+
+	err = a.Close()
+	require.NoError(t, err)
+
+	err = a.DiscardUpto(100)
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	a, err = Open("testdata", DefaultOptions().WithFileSize(2))
+	require.NoError(t, err)
+	require.Equal(t, int64(6), a.DiscardedUpto())
+
+	_, err = a.ReadAt(b, 0)
+	require.Equal(t, ErrDiscarded, err)
+
+	sz, err := a.Size()
+	require.NoError(t, err)
+	require.Equal(t, int64(7), sz)
+
+	err = a.Close()
+	require.NoError(t, err)
+}
+
 func TestMultiAppEdgeCases(t *testing.T) {
 	_, err := Open("testdata", nil)
 	require.Equal(t, ErrIllegalArguments, err)
@@ -27,4 +27,5 @@ var (
 	ErrCompressionNotSupported = errors.New("compression is currently not supported")
 	ErrCantDownload            = errors.New("can not download chunk")
 	ErrCorruptedMetadata       = errors.New("corrupted metadata in a remote chunk")
+	ErrDiscardNotSupported     = errors.New("discarding data is currently not supported")
 )
@@ -16,9 +16,12 @@ limitations under the License.
 package remoteapp
 
 import (
+	"os"
 	"time"
 
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
+	"github.com/codenotary/immudb/embedded/logger"
+	pkglogger "github.com/codenotary/immudb/pkg/logger"
 )
 
 type Options struct {
@@ -29,6 +32,8 @@ type Options struct {
 	retryMaxDelay    time.Duration
 	retryDelayExp    float64
 	retryDelayJitter float64
+
+	log logger.Logger
 }
 
 func DefaultOptions() *Options {
@@ -39,6 +44,7 @@ func DefaultOptions() *Options {
 		retryMaxDelay:    2 * time.Minute,
 		retryDelayExp:    2,
 		retryDelayJitter: 0.1,
+		log:              logger.NewPrintfLogger(pkglogger.NewSimpleLogger("immudb ", os.Stderr)),
 	}
 }
 
@@ -67,6 +73,12 @@ func (opts *Options) WithRetryDelayJitter(retryDelayJitter float64) *Options {
 	return opts
 }
 
+// WithLogger sets the logger receiving the events of chunks being uploaded or downloaded
+func (opts *Options) WithLogger(log logger.Logger) *Options {
+	opts.log = log
+	return opts
+}
+
 func (opts *Options) Valid() bool {
 	// TODO: Compression is not supported ATM, this must be disabled
 	return opts != nil &&
@@ -75,5 +87,6 @@ func (opts *Options) Valid() bool {
 		opts.parallelUploads < 100000 &&
 		opts.retryMinDelay > 0 &&
 		opts.retryMaxDelay > 0 &&
-		opts.retryDelayExp > 1
+		opts.retryDelayExp > 1 &&
+		opts.log != nil
 }
@@ -40,5 +40,10 @@ func TestValidOptions(t *testing.T) {
 	require.Equal(t, 1.3, opts.WithRetryDelayExp(1.3).retryDelayExp)
 	require.Equal(t, 0.2, opts.WithRetryDelayJitter(0.2).retryDelayJitter)
 
+	require.Nil(t, opts.WithLogger(nil).log)
+	require.False(t, opts.Valid())
+
+	opts.WithLogger(DefaultOptions().log)
+
 	require.True(t, opts.Valid())
 }
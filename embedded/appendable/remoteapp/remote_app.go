@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"os"
 	"path"
 	"path/filepath"
@@ -34,6 +33,7 @@ import (
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
 	"github.com/codenotary/immudb/embedded/appendable/singleapp"
 	"github.com/codenotary/immudb/embedded/cache"
+	"github.com/codenotary/immudb/embedded/logger"
 	"github.com/codenotary/immudb/embedded/remotestorage"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -68,6 +68,8 @@ type RemoteStorageAppendable struct {
 	chunkDownloadFinished *sync.Cond
 
 	statsUpdaterWaitGroup sync.WaitGroup
+
+	log logger.Logger
 }
 
 func Open(path string, remotePath string, storage remotestorage.Storage, opts *Options) (*RemoteStorageAppendable, error) {
@@ -85,7 +87,7 @@ func Open(path string, remotePath string, storage remotestorage.Storage, opts *O
 		return nil, ErrIllegalArguments
 	}
 
-	log.Printf("Opening remote storage at %s%s", storage, remotePath)
+	opts.log.Info("Opening remote storage", logger.F("storage", storage), logger.F("path", remotePath))
 
 	mainContext, mainCancelFunc := context.WithCancel(context.Background())
 
@@ -102,6 +104,7 @@ func Open(path string, remotePath string, storage remotestorage.Storage, opts *O
 		mainContext:     mainContext,
 		mainCancelFunc:  mainCancelFunc,
 		uploadThrottler: make(chan struct{}, opts.parallelUploads),
+		log:             opts.log,
 	}
 	ret.chunkUploadFinished = sync.NewCond(&ret.mutex)
 	ret.chunkDownloadFinished = sync.NewCond(&ret.mutex)
@@ -145,7 +148,7 @@ func (r *RemoteStorageAppendable) uploadFinished(chunkID int64, state chunkState
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	log.Printf("Uploadnig of chunk %d finished in state: %v", chunkID, state)
+	r.log.Info("Uploading of chunk finished", logger.F("chunk", chunkID), logger.F("state", state))
 
 	r.chunkInfos[chunkID].state = state
 	r.chunkInfos[chunkID].cancelUpload = nil
@@ -275,14 +278,14 @@ func (r *RemoteStorageAppendable) uploadChunk(chunkID int64, dontRemoveFile bool
 
 		if ctx.Err() != nil {
 			// Context has been cancelled
-			log.Printf("Uploading chunk %d cancelled", chunkID)
+			r.log.Info("Uploading chunk cancelled", logger.F("chunk", chunkID))
 			r.uploadFinished(chunkID, chunkState_Local)
 			metricsUploadCancelled.Inc()
 			return
 		}
 
 		if cp.Err() != nil {
-			log.Printf("Uploading chunk %d failed: %v", chunkID, cp.Err())
+			r.log.Warn("Uploading chunk failed", logger.F("chunk", chunkID), logger.F("error", cp.Err()))
 			r.uploadFinished(chunkID, chunkState_UploadError)
 			metricsUploadFailed.Inc()
 			return
@@ -298,7 +301,7 @@ func (r *RemoteStorageAppendable) downloadFinished(chunkID int64, state chunkSta
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	log.Printf("Downloading of chunk %d finished in state: %v", chunkID, state)
+	r.log.Info("Downloading of chunk finished", logger.F("chunk", chunkID), logger.F("state", state))
 
 	r.chunkInfos[chunkID].state = state
 	r.chunkInfos[chunkID].cancelUpload = nil
@@ -364,14 +367,14 @@ func (r *RemoteStorageAppendable) downloadChunk(chunkID int64) {
 		})
 
 		if ctx.Err() != nil {
-			log.Printf("Downloading chunk %d cancelled", chunkID)
+			r.log.Info("Downloading chunk cancelled", logger.F("chunk", chunkID))
 			metricsDownloadCancelled.Inc()
 			r.downloadFinished(chunkID, chunkState_DownloadError)
 			return
 		}
 
 		if cp.Err() != nil {
-			log.Printf("Downloading chunk %d failed: %v", chunkID, cp.Err())
+			r.log.Warn("Downloading chunk failed", logger.F("chunk", chunkID), logger.F("error", cp.Err()))
 			metricsDownloadFailed.Inc()
 			r.downloadFinished(chunkID, chunkState_DownloadError)
 			return
@@ -407,6 +410,16 @@ func (r *RemoteStorageAppendable) Close() error {
 	return nil
 }
 
+// DiscardUpto is not supported, chunks would be discarded locally but kept on the remote storage
+func (r *RemoteStorageAppendable) DiscardUpto(off int64) error {
+	return ErrDiscardNotSupported
+}
+
+// DiscardableUpto returns the offset data was already discarded up to, as nothing else can be discarded
+func (r *RemoteStorageAppendable) DiscardableUpto(off int64) int64 {
+	return r.DiscardedUpto()
+}
+
 func (r *RemoteStorageAppendable) OpenAppendable(options *singleapp.Options, appname string, activeChunk bool) (appendable.Appendable, error) {
 	if options.GetCompressionFormat() != appendable.NoCompression {
 		return nil, ErrCompressionNotSupported
@@ -562,7 +575,7 @@ func (r *RemoteStorageAppendable) OpenInitialAppendable(opts *multiapp.Options,
 				// Chunk size can only grow in size,
 				// if the local file is smaller than the remote object,
 				// there must have been some corruption of local file
-				log.Printf("Chunk validation failed, remote chunk %d has more data than the local file", id)
+				r.log.Error("Chunk validation failed, remote chunk has more data than the local file", logger.F("chunk", id))
 				return nil, 0, ErrInvalidRemoteStorage
 			}
 		} else {
@@ -575,7 +588,7 @@ func (r *RemoteStorageAppendable) OpenInitialAppendable(opts *multiapp.Options,
 	for id, info := range chunkInfos {
 		if info.state == chunkState_Invalid {
 			// Chunk was not found in neither local nor remote storage
-			log.Printf("Chunk validation failed, missing chunk %d", id)
+			r.log.Error("Chunk validation failed, missing chunk", logger.F("chunk", id))
 			return nil, 0, ErrMissingRemoteChunk
 		}
 	}
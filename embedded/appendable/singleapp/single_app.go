@@ -24,6 +24,7 @@ import (
 	"compress/zlib"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"sync"
@@ -36,16 +37,32 @@ var ErrIllegalArguments = errors.New("illegal arguments")
 var ErrAlreadyClosed = errors.New("single-file appendable already closed")
 var ErrReadOnly = errors.New("cannot append when opened in read-only mode")
 var ErrCorruptedMetadata = errors.New("corrupted metadata")
+var ErrUnsupportedFormatVersion = errors.New("file created with a newer format version")
+
+// FormatVersion is the version of the layout of the files created by this package.
+// Files created before the version was recorded in the header are reported with version 0
+const FormatVersion = 1
 
 const (
+	metaFormatVersion     = "FORMAT_VERSION"
 	metaCompressionFormat = "COMPRESSION_FORMAT"
 	metaCompressionLevel  = "COMPRESSION_LEVEL"
 	metaWrappedMeta       = "WRAPPED_METADATA"
 )
 
+// headerUpgrades holds, indexed by the format version being upgraded, the changes a header
+// requires to be read as the next version. Headers of previous versions are upgraded in memory when
+// files are opened, the format version itself is only updated on disk by Upgrade
+var headerUpgrades = []func(m *appendable.Metadata) error{
+	// 0 -> 1: the layout is unchanged, only the format version gets recorded
+	func(m *appendable.Metadata) error { return nil },
+}
+
 type AppendableFile struct {
 	f *os.File
 
+	formatVersion int
+
 	compressionFormat int
 	compressionLevel  int
 
@@ -90,12 +107,14 @@ func Open(fileName string, opts *Options) (*AppendableFile, error) {
 	}
 
 	var metadata []byte
+	var formatVersion int
 	var compressionFormat int
 	var compressionLevel int
 	var baseOffset int64
 
 	if notExist {
 		m := appendable.NewMetadata(nil)
+		m.PutInt(metaFormatVersion, FormatVersion)
 		m.PutInt(metaCompressionFormat, opts.compressionFormat)
 		m.PutInt(metaCompressionLevel, opts.compressionLevel)
 		m.Put(metaWrappedMeta, opts.metadata)
@@ -121,27 +140,31 @@ func Open(fileName string, opts *Options) (*AppendableFile, error) {
 			return nil, err
 		}
 
+		formatVersion = FormatVersion
 		compressionFormat = opts.compressionFormat
 		compressionLevel = opts.compressionLevel
 		metadata = opts.metadata
 
 		baseOffset = int64(4 + len(mBs))
 	} else {
-		r := bufio.NewReader(f)
-
-		mLenBs := make([]byte, 4)
-		_, err := r.Read(mLenBs)
+		mBs, err := readHeader(bufio.NewReader(f))
 		if err != nil {
-			return nil, ErrCorruptedMetadata
+			return nil, err
 		}
 
-		mBs := make([]byte, binary.BigEndian.Uint32(mLenBs))
-		_, err = r.Read(mBs)
+		m := appendable.NewMetadata(mBs)
+
+		formatVersion, err = headerFormatVersion(m)
 		if err != nil {
-			return nil, ErrCorruptedMetadata
+			return nil, err
 		}
 
-		m := appendable.NewMetadata(mBs)
+		for v := formatVersion; v < FormatVersion; v++ {
+			err = headerUpgrades[v](m)
+			if err != nil {
+				return nil, err
+			}
+		}
 
 		cf, ok := m.GetInt(metaCompressionFormat)
 		if !ok {
@@ -175,6 +198,7 @@ func Open(fileName string, opts *Options) (*AppendableFile, error) {
 
 	return &AppendableFile{
 		f:                 f,
+		formatVersion:     formatVersion,
 		compressionFormat: compressionFormat,
 		compressionLevel:  compressionLevel,
 		metadata:          metadata,
@@ -219,6 +243,155 @@ func (aof *AppendableFile) Copy(dstPath string) error {
 	return dstFile.Sync()
 }
 
+func readHeader(r io.Reader) ([]byte, error) {
+	mLenBs := make([]byte, 4)
+	_, err := io.ReadFull(r, mLenBs)
+	if err != nil {
+		return nil, ErrCorruptedMetadata
+	}
+
+	mBs := make([]byte, binary.BigEndian.Uint32(mLenBs))
+	_, err = io.ReadFull(r, mBs)
+	if err != nil {
+		return nil, ErrCorruptedMetadata
+	}
+
+	return mBs, nil
+}
+
+func headerFormatVersion(m *appendable.Metadata) (int, error) {
+	version, ok := m.GetInt(metaFormatVersion)
+	if !ok {
+		return 0, nil
+	}
+
+	if version < 0 {
+		return 0, ErrCorruptedMetadata
+	}
+
+	if version > FormatVersion {
+		return 0, fmt.Errorf("%w: %d", ErrUnsupportedFormatVersion, version)
+	}
+
+	return version, nil
+}
+
+// Upgrade rewrites the header of the file so it's recorded with the current format version, the content of
+// the file is kept as is. The upgraded file is written as tmpFileName, which must be in the same file system,
+// and is renamed over the original one once the superseded header was handed to backup.
+// It returns false without changing anything if the file already has the current format version
+func Upgrade(fileName, tmpFileName string, backup func(header []byte) error) (upgraded bool, err error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+
+	mBs, err := readHeader(r)
+	if err != nil {
+		return false, err
+	}
+
+	m := appendable.NewMetadata(mBs)
+
+	_, ok := m.GetInt(metaCompressionFormat)
+	if !ok {
+		return false, ErrCorruptedMetadata
+	}
+
+	version, err := headerFormatVersion(m)
+	if err != nil {
+		return false, err
+	}
+
+	if version == FormatVersion {
+		return false, nil
+	}
+
+	for v := version; v < FormatVersion; v++ {
+		err = headerUpgrades[v](m)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	m.PutInt(metaFormatVersion, FormatVersion)
+
+	finfo, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	tmpFile, err := os.OpenFile(tmpFileName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, finfo.Mode())
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmpFileName)
+
+	err = writeUpgradedFile(tmpFile, m.Bytes(), r)
+	if err != nil {
+		tmpFile.Close()
+		return false, err
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		return false, err
+	}
+
+	supersededHeader := make([]byte, 4+len(mBs))
+	binary.BigEndian.PutUint32(supersededHeader, uint32(len(mBs)))
+	copy(supersededHeader[4:], mBs)
+
+	err = backup(supersededHeader)
+	if err != nil {
+		return false, err
+	}
+
+	err = os.Rename(tmpFileName, fileName)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func writeUpgradedFile(f *os.File, mBs []byte, content io.Reader) error {
+	mLenBs := make([]byte, 4)
+	binary.BigEndian.PutUint32(mLenBs, uint32(len(mBs)))
+
+	w := bufio.NewWriter(f)
+
+	_, err := w.Write(mLenBs)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(mBs)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, content)
+	if err != nil {
+		return err
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
+
+// FormatVersion returns the format version recorded in the header of the file
+func (aof *AppendableFile) FormatVersion() int {
+	return aof.formatVersion
+}
+
 func (aof *AppendableFile) CompressionFormat() int {
 	return aof.compressionFormat
 }
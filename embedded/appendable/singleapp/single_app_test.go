@@ -393,3 +393,149 @@ func TestSingleAppCantCreateFile(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "exists")
 }
+
+func writeHeader(t *testing.T, fileName string, m *appendable.Metadata, content []byte) {
+	mBs := m.Bytes()
+
+	bs := make([]byte, 4+len(mBs))
+	binary.BigEndian.PutUint32(bs, uint32(len(mBs)))
+	copy(bs[4:], mBs)
+
+	err := ioutil.WriteFile(fileName, append(bs, content...), 0644)
+	require.NoError(t, err)
+}
+
+func TestSingleAppFormatVersion(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "00000000.aof")
+
+	// header written before the format version was recorded
+	m := appendable.NewMetadata(nil)
+	m.PutInt(metaCompressionFormat, appendable.NoCompression)
+	m.PutInt(metaCompressionLevel, appendable.DefaultCompression)
+	m.Put(metaWrappedMeta, []byte("wrapped"))
+
+	writeHeader(t, fileName, m, []byte("content"))
+
+	a, err := Open(fileName, DefaultOptions().WithReadOnly(true))
+	require.NoError(t, err)
+	require.Equal(t, 0, a.FormatVersion())
+	require.Equal(t, []byte("wrapped"), a.Metadata())
+
+	err = a.Close()
+	require.NoError(t, err)
+
+	var superseded []byte
+
+	upgraded, err := Upgrade(fileName, filepath.Join(dir, "upgrading"), func(header []byte) error {
+		superseded = header
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, upgraded)
+	require.Equal(t, len(superseded)-4, int(binary.BigEndian.Uint32(superseded)))
+
+	supersededMeta := appendable.NewMetadata(superseded[4:])
+	_, versioned := supersededMeta.GetInt(metaFormatVersion)
+	require.False(t, versioned)
+	wrapped, _ := supersededMeta.Get(metaWrappedMeta)
+	require.Equal(t, []byte("wrapped"), wrapped)
+	require.NoFileExists(t, filepath.Join(dir, "upgrading"))
+
+	a, err = Open(fileName, DefaultOptions().WithReadOnly(true))
+	require.NoError(t, err)
+	require.Equal(t, FormatVersion, a.FormatVersion())
+	require.Equal(t, []byte("wrapped"), a.Metadata())
+
+	bs := make([]byte, 7)
+	_, err = a.ReadAt(bs, 0)
+	require.NoError(t, err)
+	require.Equal(t, []byte("content"), bs)
+
+	err = a.Close()
+	require.NoError(t, err)
+
+	upgraded, err = Upgrade(fileName, filepath.Join(dir, "upgrading"), func(header []byte) error {
+		require.Fail(t, "an upgraded file should not be upgraded again")
+		return nil
+	})
+	require.NoError(t, err)
+	require.False(t, upgraded)
+
+	t.Run("headers of previous versions should be upgraded when opened", func(t *testing.T) {
+		legacyFileName := filepath.Join(dir, "00000003.aof")
+		writeHeader(t, legacyFileName, m, []byte("content"))
+
+		headerUpgrades[0] = func(m *appendable.Metadata) error {
+			m.Put(metaWrappedMeta, []byte("upgraded"))
+			return nil
+		}
+		defer func() { headerUpgrades[0] = func(m *appendable.Metadata) error { return nil } }()
+
+		a, err := Open(legacyFileName, DefaultOptions().WithReadOnly(true))
+		require.NoError(t, err)
+		require.Equal(t, 0, a.FormatVersion())
+		require.Equal(t, []byte("upgraded"), a.Metadata())
+
+		err = a.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("a failing backup should leave the file untouched", func(t *testing.T) {
+		legacyFileName := filepath.Join(dir, "00000001.aof")
+		writeHeader(t, legacyFileName, m, []byte("content"))
+
+		_, err := Upgrade(legacyFileName, filepath.Join(dir, "upgrading"), func(header []byte) error {
+			return io.ErrShortWrite
+		})
+		require.ErrorIs(t, err, io.ErrShortWrite)
+		require.NoFileExists(t, filepath.Join(dir, "upgrading"))
+
+		a, err := Open(legacyFileName, DefaultOptions().WithReadOnly(true))
+		require.NoError(t, err)
+		require.Equal(t, 0, a.FormatVersion())
+
+		err = a.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("files with a newer format version should be rejected", func(t *testing.T) {
+		newerFileName := filepath.Join(dir, "00000002.aof")
+
+		m.PutInt(metaFormatVersion, FormatVersion+1)
+		writeHeader(t, newerFileName, m, nil)
+
+		_, err := Open(newerFileName, DefaultOptions())
+		require.ErrorIs(t, err, ErrUnsupportedFormatVersion)
+
+		_, err = Upgrade(newerFileName, filepath.Join(dir, "upgrading"), func(header []byte) error { return nil })
+		require.ErrorIs(t, err, ErrUnsupportedFormatVersion)
+	})
+
+	t.Run("files without an appendable header should not be upgraded", func(t *testing.T) {
+		otherFileName := filepath.Join(dir, "other")
+
+		writeHeader(t, otherFileName, appendable.NewMetadata(nil), nil)
+
+		_, err := Upgrade(otherFileName, filepath.Join(dir, "upgrading"), func(header []byte) error { return nil })
+		require.ErrorIs(t, err, ErrCorruptedMetadata)
+	})
+}
+
+func TestSingleAppCreatedWithFormatVersion(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "00000000.aof")
+
+	a, err := Open(fileName, DefaultOptions())
+	require.NoError(t, err)
+	require.Equal(t, FormatVersion, a.FormatVersion())
+
+	err = a.Close()
+	require.NoError(t, err)
+
+	a, err = Open(fileName, DefaultOptions())
+	require.NoError(t, err)
+	require.Equal(t, FormatVersion, a.FormatVersion())
+
+	err = a.Close()
+	require.NoError(t, err)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+var ErrIllegalArguments = errors.New("illegal arguments")
+
+// Result holds the measurements of a benchmark run
+type Result struct {
+	Name     string
+	Txs      int // number of committed transactions
+	Ops      int // number of completed operations e.g. committed entries, executed statements or generated proofs
+	Duration time.Duration
+
+	Conflicts int // number of transactions retried due to conflicts with concurrent ones, only reported by SQLMix
+
+	// indexing measurements, only reported by IndexingLag
+	MaxIndexingLag  uint64        // greatest number of committed but not yet indexed transactions
+	IndexingCatchUp time.Duration // time taken to index pending transactions once the load is completed
+}
+
+func (r *Result) OpsPerSecond() float64 {
+	if r.Duration == 0 {
+		return 0
+	}
+
+	return float64(r.Ops) / r.Duration.Seconds()
+}
+
+func (r *Result) TxsPerSecond() float64 {
+	if r.Duration == 0 {
+		return 0
+	}
+
+	return float64(r.Txs) / r.Duration.Seconds()
+}
+
+func (r *Result) String() string {
+	s := fmt.Sprintf("%s: %d txs, %d ops in %s (%.2f txs/s, %.2f ops/s)",
+		r.Name, r.Txs, r.Ops, r.Duration, r.TxsPerSecond(), r.OpsPerSecond())
+
+	if r.Conflicts > 0 {
+		s += fmt.Sprintf(", %d conflicts", r.Conflicts)
+	}
+
+	if r.MaxIndexingLag > 0 || r.IndexingCatchUp > 0 {
+		s += fmt.Sprintf(", max indexing lag: %d txs, indexing catch-up: %s", r.MaxIndexingLag, r.IndexingCatchUp)
+	}
+
+	return s
+}
+
+// KVCommit measures the throughput of concurrent committers, each one committing
+// its share of transactions with the configured number of entries
+func KVCommit(st *store.ImmuStore, opts *Options) (*Result, error) {
+	if st == nil || !validOptions(opts) {
+		return nil, ErrIllegalArguments
+	}
+
+	start := time.Now()
+
+	txs, err := commitLoad(st, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Name:     "kv-commit",
+		Txs:      txs,
+		Ops:      txs * opts.entriesPerTx,
+		Duration: time.Since(start),
+	}, nil
+}
+
+// IndexingLag runs the same load as KVCommit while sampling how far behind
+// the indexer is from the committed transactions
+func IndexingLag(st *store.ImmuStore, opts *Options) (*Result, error) {
+	if st == nil || !validOptions(opts) {
+		return nil, ErrIllegalArguments
+	}
+
+	var maxLag uint64
+
+	done := make(chan struct{})
+	sampled := make(chan struct{})
+
+	go func() {
+		defer close(sampled)
+
+		ticker := time.NewTicker(opts.samplingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				committed, indexed := st.TxCount(), st.IndexInfo()
+
+				if committed > indexed && committed-indexed > maxLag {
+					maxLag = committed - indexed
+				}
+			}
+		}
+	}()
+
+	start := time.Now()
+
+	txs, err := commitLoad(st, opts)
+
+	duration := time.Since(start)
+
+	close(done)
+	<-sampled
+
+	if err != nil {
+		return nil, err
+	}
+
+	catchUpStart := time.Now()
+
+	err = st.WaitForIndexingUpto(st.TxCount(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Name:            "indexing-lag",
+		Txs:             txs,
+		Ops:             txs * opts.entriesPerTx,
+		Duration:        duration,
+		MaxIndexingLag:  maxLag,
+		IndexingCatchUp: time.Since(catchUpStart),
+	}, nil
+}
+
+// commitLoad commits txsPerWorker transactions from each worker and returns the number of committed transactions.
+// Keys are unique across workers and follow the ones written by previous runs on the same store
+func commitLoad(st *store.ImmuStore, opts *Options) (int, error) {
+	var wg sync.WaitGroup
+	wg.Add(opts.workers)
+
+	errs := make(chan error, opts.workers)
+
+	base := st.TxCount() * uint64(opts.entriesPerTx)
+
+	for w := 0; w < opts.workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+
+			rnd := rand.New(rand.NewSource(opts.seed + int64(w)))
+
+			for t := 0; t < opts.txsPerWorker; t++ {
+				tx, err := st.NewWriteOnlyTx()
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				for e := 0; e < opts.entriesPerTx; e++ {
+					seq := base + uint64((w*opts.txsPerWorker+t)*opts.entriesPerTx+e)
+
+					err = tx.Set(key(seq, opts.keyLen), nil, value(rnd, opts.valueLen))
+					if err != nil {
+						tx.Cancel()
+						errs <- err
+						return
+					}
+				}
+
+				_, err = tx.Commit()
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	err, failed := <-errs
+	if failed {
+		return 0, err
+	}
+
+	return opts.workers * opts.txsPerWorker, nil
+}
+
+func key(seq uint64, keyLen int) []byte {
+	k := make([]byte, keyLen)
+	binary.BigEndian.PutUint64(k, seq)
+	return k
+}
+
+func value(rnd *rand.Rand, valueLen int) []byte {
+	v := make([]byte, valueLen)
+	rnd.Read(v)
+	return v
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func openStore(t testing.TB) *store.ImmuStore {
+	st, err := store.Open(t.TempDir(), store.DefaultOptions().
+		WithSynced(false).
+		WithLog(logger.NewSimpleLoggerWithLevel("benchmark", os.Stderr, logger.LogWarn)))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { st.Close() })
+
+	return st
+}
+
+func openEngine(t testing.TB) *sql.Engine {
+	engine, err := sql.NewEngine(openStore(t), sql.DefaultOptions().WithPrefix([]byte("sql")))
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE benchmark", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("benchmark")
+	require.NoError(t, err)
+
+	return engine
+}
+
+func testOptions() *Options {
+	return DefaultOptions().
+		WithWorkers(2).
+		WithTxsPerWorker(10).
+		WithEntriesPerTx(5).
+		WithProofs(10).
+		WithSeed(1)
+}
+
+func TestInvalidArguments(t *testing.T) {
+	st := openStore(t)
+
+	_, err := KVCommit(nil, testOptions())
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = KVCommit(st, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = IndexingLag(st, testOptions().WithSamplingInterval(0))
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = SQLMix(nil, testOptions())
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = SQLMix(openEngine(t), testOptions().WithSelectRatio(2))
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = ProofGeneration(st, testOptions().WithKeyLen(4))
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	// there are no transactions to be proven
+	_, err = ProofGeneration(st, testOptions())
+	require.ErrorIs(t, err, ErrIllegalArguments)
+}
+
+func TestKVCommit(t *testing.T) {
+	st := openStore(t)
+
+	for run := 1; run <= 2; run++ {
+		res, err := KVCommit(st, testOptions())
+		require.NoError(t, err)
+		require.Equal(t, 20, res.Txs)
+		require.Equal(t, 100, res.Ops)
+		require.Positive(t, res.TxsPerSecond())
+		require.Positive(t, res.OpsPerSecond())
+		require.Contains(t, res.String(), "kv-commit: 20 txs, 100 ops")
+
+		require.Equal(t, uint64(20*run), st.TxCount())
+	}
+
+	// keys written by each run are kept
+	err := st.WaitForIndexingUpto(st.TxCount(), nil)
+	require.NoError(t, err)
+
+	for seq := uint64(0); seq < 200; seq++ {
+		_, err = st.Get(key(seq, DefaultKeyLen))
+		require.NoError(t, err)
+	}
+}
+
+func TestIndexingLag(t *testing.T) {
+	st := openStore(t)
+
+	res, err := IndexingLag(st, testOptions())
+	require.NoError(t, err)
+	require.Equal(t, 20, res.Txs)
+	require.Equal(t, 100, res.Ops)
+	require.LessOrEqual(t, res.MaxIndexingLag, uint64(20))
+
+	require.Equal(t, st.TxCount(), st.IndexInfo())
+}
+
+func TestSQLMix(t *testing.T) {
+	engine := openEngine(t)
+
+	res, err := SQLMix(engine, testOptions())
+	require.NoError(t, err)
+	require.Equal(t, 20, res.Ops)
+	require.Positive(t, res.Txs)
+	require.Less(t, res.Txs, res.Ops)
+
+	res, err = SQLMix(engine, testOptions().WithSelectRatio(0))
+	require.NoError(t, err)
+	require.Equal(t, 20, res.Txs)
+
+	res, err = SQLMix(engine, testOptions().WithSelectRatio(1))
+	require.NoError(t, err)
+	require.Zero(t, res.Txs)
+}
+
+func TestProofGeneration(t *testing.T) {
+	st := openStore(t)
+
+	_, err := KVCommit(st, testOptions())
+	require.NoError(t, err)
+
+	res, err := ProofGeneration(st, testOptions())
+	require.NoError(t, err)
+	require.Equal(t, 10, res.Ops)
+	require.Zero(t, res.Txs)
+	require.Positive(t, res.OpsPerSecond())
+}
+
+func BenchmarkKVCommit(b *testing.B) {
+	st := openStore(b)
+
+	opts := DefaultOptions().WithTxsPerWorker(b.N)
+
+	b.ResetTimer()
+
+	res, err := KVCommit(st, opts)
+	require.NoError(b, err)
+
+	b.ReportMetric(res.TxsPerSecond(), "txs/s")
+	b.ReportMetric(res.OpsPerSecond(), "kvs/s")
+}
+
+func BenchmarkIndexingLag(b *testing.B) {
+	st := openStore(b)
+
+	opts := DefaultOptions().WithTxsPerWorker(b.N)
+
+	b.ResetTimer()
+
+	res, err := IndexingLag(st, opts)
+	require.NoError(b, err)
+
+	b.ReportMetric(float64(res.MaxIndexingLag), "max-lag-txs")
+	b.ReportMetric(res.IndexingCatchUp.Seconds(), "catch-up-s")
+}
+
+func BenchmarkSQLMix(b *testing.B) {
+	engine := openEngine(b)
+
+	opts := DefaultOptions().WithTxsPerWorker(b.N).WithEntriesPerTx(10)
+
+	b.ResetTimer()
+
+	res, err := SQLMix(engine, opts)
+	require.NoError(b, err)
+
+	b.ReportMetric(res.OpsPerSecond(), "stmts/s")
+	b.ReportMetric(float64(res.Conflicts), "conflicts")
+}
+
+func BenchmarkProofGeneration(b *testing.B) {
+	st := openStore(b)
+
+	_, err := KVCommit(st, DefaultOptions())
+	require.NoError(b, err)
+
+	opts := DefaultOptions().WithProofs(b.N)
+
+	b.ResetTimer()
+
+	res, err := ProofGeneration(st, opts)
+	require.NoError(b, err)
+
+	b.ReportMetric(res.OpsPerSecond(), "proofs/s")
+}
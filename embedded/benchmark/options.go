@@ -0,0 +1,114 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import "time"
+
+const DefaultWorkers = 10
+const DefaultTxsPerWorker = 100
+const DefaultEntriesPerTx = 100
+const DefaultKeyLen = 8
+const DefaultValueLen = 32
+const DefaultSelectRatio = 0.5
+const DefaultProofs = 1000
+const DefaultSamplingInterval = 10 * time.Millisecond
+
+type Options struct {
+	workers          int // number of concurrent committers
+	txsPerWorker     int
+	entriesPerTx     int
+	keyLen           int
+	valueLen         int
+	selectRatio      float64       // fraction of the SQL operations being selects, the rest being upserts
+	proofs           int           // number of proofs to be generated
+	samplingInterval time.Duration // interval at which the indexing lag is sampled
+	seed             int64
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		workers:          DefaultWorkers,
+		txsPerWorker:     DefaultTxsPerWorker,
+		entriesPerTx:     DefaultEntriesPerTx,
+		keyLen:           DefaultKeyLen,
+		valueLen:         DefaultValueLen,
+		selectRatio:      DefaultSelectRatio,
+		proofs:           DefaultProofs,
+		samplingInterval: DefaultSamplingInterval,
+		seed:             time.Now().UnixNano(),
+	}
+}
+
+func validOptions(opts *Options) bool {
+	return opts != nil &&
+		opts.workers > 0 &&
+		opts.txsPerWorker > 0 &&
+		opts.entriesPerTx > 0 &&
+		opts.keyLen >= 8 &&
+		opts.valueLen >= 0 &&
+		opts.selectRatio >= 0 &&
+		opts.selectRatio <= 1 &&
+		opts.proofs > 0 &&
+		opts.samplingInterval > 0
+}
+
+func (opts *Options) WithWorkers(workers int) *Options {
+	opts.workers = workers
+	return opts
+}
+
+func (opts *Options) WithTxsPerWorker(txsPerWorker int) *Options {
+	opts.txsPerWorker = txsPerWorker
+	return opts
+}
+
+func (opts *Options) WithEntriesPerTx(entriesPerTx int) *Options {
+	opts.entriesPerTx = entriesPerTx
+	return opts
+}
+
+// WithKeyLen sets the length of the generated keys, being at least 8 bytes long so to hold a unique sequence number
+func (opts *Options) WithKeyLen(keyLen int) *Options {
+	opts.keyLen = keyLen
+	return opts
+}
+
+func (opts *Options) WithValueLen(valueLen int) *Options {
+	opts.valueLen = valueLen
+	return opts
+}
+
+func (opts *Options) WithSelectRatio(selectRatio float64) *Options {
+	opts.selectRatio = selectRatio
+	return opts
+}
+
+func (opts *Options) WithProofs(proofs int) *Options {
+	opts.proofs = proofs
+	return opts
+}
+
+func (opts *Options) WithSamplingInterval(samplingInterval time.Duration) *Options {
+	opts.samplingInterval = samplingInterval
+	return opts
+}
+
+// WithSeed sets the seed of the random generator, so the same workload can be run across releases
+func (opts *Options) WithSeed(seed int64) *Options {
+	opts.seed = seed
+	return opts
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// ProofGeneration measures the cost of generating dual proofs between randomly chosen pairs
+// of the transactions already committed into the store, as done when serving verified reads
+func ProofGeneration(st *store.ImmuStore, opts *Options) (*Result, error) {
+	if st == nil || !validOptions(opts) {
+		return nil, ErrIllegalArguments
+	}
+
+	lastTxID := st.TxCount()
+	if lastTxID == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	rnd := rand.New(rand.NewSource(opts.seed))
+
+	sourceTx := st.NewTxHolder()
+	targetTx := st.NewTxHolder()
+
+	start := time.Now()
+
+	for i := 0; i < opts.proofs; i++ {
+		sourceTxID := 1 + uint64(rnd.Int63n(int64(lastTxID)))
+		targetTxID := sourceTxID + uint64(rnd.Int63n(int64(lastTxID-sourceTxID+1)))
+
+		err := st.ReadTx(sourceTxID, sourceTx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = st.ReadTx(targetTxID, targetTx)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = st.DualProof(sourceTx, targetTx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Result{
+		Name:     "proof-generation",
+		Ops:      opts.proofs,
+		Duration: time.Since(start),
+	}, nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+const sqlTable = "benchmark_rows"
+
+// SQLMix measures the throughput of concurrent workers executing a mix of upserts and selects by primary key
+// against the default database of the engine. Every upsert writes entriesPerTx rows of the id range owned by
+// the worker in a single transaction, being retried when conflicting with concurrent transactions.
+// The ratio of selects is set by the select ratio option
+func SQLMix(engine *sql.Engine, opts *Options) (*Result, error) {
+	if engine == nil || !validOptions(opts) {
+		return nil, ErrIllegalArguments
+	}
+
+	_, _, err := engine.Exec(context.Background(), fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s(id INTEGER, payload BLOB, PRIMARY KEY id)", sqlTable), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	upsert := upsertStmt(opts.entriesPerTx)
+	query := fmt.Sprintf("SELECT id, payload FROM %s WHERE id = @id", sqlTable)
+
+	workerRows := int64(opts.txsPerWorker * opts.entriesPerTx)
+	rows := int64(opts.workers) * workerRows
+
+	var wg sync.WaitGroup
+	wg.Add(opts.workers)
+
+	errs := make(chan error, opts.workers)
+	upserts := make([]int, opts.workers)
+	conflicts := make([]int, opts.workers)
+
+	start := time.Now()
+
+	for w := 0; w < opts.workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+
+			rnd := rand.New(rand.NewSource(opts.seed + int64(w)))
+
+			for i := 0; i < opts.txsPerWorker; i++ {
+				if rnd.Float64() < opts.selectRatio {
+					err := selectRow(engine, query, rnd.Int63n(rows))
+					if err != nil {
+						errs <- err
+						return
+					}
+
+					continue
+				}
+
+				params := make(map[string]interface{}, 2*opts.entriesPerTx)
+
+				for e := 0; e < opts.entriesPerTx; e++ {
+					params[fmt.Sprintf("id%d", e)] = int64(w)*workerRows + rnd.Int63n(workerRows)
+					params[fmt.Sprintf("payload%d", e)] = value(rnd, opts.valueLen)
+				}
+
+				for {
+					_, _, err := engine.Exec(context.Background(), upsert, params, nil)
+					if errors.Is(err, store.ErrTxReadConflict) {
+						conflicts[w]++
+						continue
+					}
+					if err != nil {
+						errs <- err
+						return
+					}
+
+					break
+				}
+
+				upserts[w]++
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	err, failed := <-errs
+	if failed {
+		return nil, err
+	}
+
+	res := &Result{
+		Name:     "sql-mix",
+		Ops:      opts.workers * opts.txsPerWorker,
+		Duration: time.Since(start),
+	}
+
+	for w := range upserts {
+		res.Txs += upserts[w]
+		res.Conflicts += conflicts[w]
+	}
+
+	return res, nil
+}
+
+func upsertStmt(rows int) string {
+	values := make([]string, rows)
+
+	for i := range values {
+		values[i] = fmt.Sprintf("(@id%d, @payload%d)", i, i)
+	}
+
+	return fmt.Sprintf("UPSERT INTO %s(id, payload) VALUES %s", sqlTable, strings.Join(values, ", "))
+}
+
+func selectRow(engine *sql.Engine, query string, id int64) error {
+	r, err := engine.Query(context.Background(), query, map[string]interface{}{"id": id}, nil)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for {
+		_, err = r.Read()
+		if err == sql.ErrNoMoreRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
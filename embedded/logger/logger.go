@@ -0,0 +1,112 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field is a key-value pair attached to a logged event
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F returns a field with the specified key and value
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Logger receives the internal events of the embedded packages.
+// Implementations may route them into any logging stack
+type Logger interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+}
+
+// PrintfLogger is implemented by loggers formatting messages, as the ones provided by pkg/logger
+type PrintfLogger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warningf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+type nopLogger struct{}
+
+// NewNopLogger returns a logger discarding all the events
+func NewNopLogger() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Debug(msg string, fields ...Field) {}
+func (nopLogger) Info(msg string, fields ...Field)  {}
+func (nopLogger) Warn(msg string, fields ...Field)  {}
+func (nopLogger) Error(msg string, fields ...Field) {}
+
+type printfLogger struct {
+	l PrintfLogger
+}
+
+// NewPrintfLogger returns a logger writing each event as a single message through l,
+// fields are appended to the message as {key1=value1, key2=value2}
+func NewPrintfLogger(l PrintfLogger) Logger {
+	return &printfLogger{l: l}
+}
+
+func (pl *printfLogger) Debug(msg string, fields ...Field) {
+	pl.l.Debugf("%s", Format(msg, fields...))
+}
+
+func (pl *printfLogger) Info(msg string, fields ...Field) {
+	pl.l.Infof("%s", Format(msg, fields...))
+}
+
+func (pl *printfLogger) Warn(msg string, fields ...Field) {
+	pl.l.Warningf("%s", Format(msg, fields...))
+}
+
+func (pl *printfLogger) Error(msg string, fields ...Field) {
+	pl.l.Errorf("%s", Format(msg, fields...))
+}
+
+// Format returns the message followed by its fields
+func Format(msg string, fields ...Field) string {
+	if len(fields) == 0 {
+		return msg
+	}
+
+	var b strings.Builder
+
+	b.WriteString(msg)
+	b.WriteString(" {")
+
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		fmt.Fprintf(&b, "%s=%v", f.Key, f.Value)
+	}
+
+	b.WriteString("}")
+
+	return b.String()
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPrintfLogger struct {
+	lines []string
+}
+
+func (l *recordingPrintfLogger) Debugf(format string, args ...interface{}) {
+	l.lines = append(l.lines, "DEBUG: "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingPrintfLogger) Infof(format string, args ...interface{}) {
+	l.lines = append(l.lines, "INFO: "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingPrintfLogger) Warningf(format string, args ...interface{}) {
+	l.lines = append(l.lines, "WARNING: "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingPrintfLogger) Errorf(format string, args ...interface{}) {
+	l.lines = append(l.lines, "ERROR: "+fmt.Sprintf(format, args...))
+}
+
+func TestFormat(t *testing.T) {
+	require.Equal(t, "Index flushed", Format("Index flushed"))
+	require.Equal(t, "Index flushed {path=data/index, ts=10}", Format("Index flushed", F("path", "data/index"), F("ts", 10)))
+}
+
+func TestPrintfLogger(t *testing.T) {
+	pl := &recordingPrintfLogger{}
+
+	l := NewPrintfLogger(pl)
+
+	l.Debug("debug event")
+	l.Info("info event", F("ts", 1))
+	l.Warn("50% done", F("path", "data"))
+	l.Error("failure", F("error", errors.New("disk full")))
+
+	require.Equal(t, []string{
+		"DEBUG: debug event",
+		"INFO: info event {ts=1}",
+		"WARNING: 50% done {path=data}",
+		"ERROR: failure {error=disk full}",
+	}, pl.lines)
+}
+
+func TestNopLogger(t *testing.T) {
+	l := NewNopLogger()
+
+	l.Debug("event", F("k", "v"))
+	l.Info("event")
+	l.Warn("event")
+	l.Error("event")
+}
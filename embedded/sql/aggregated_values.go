@@ -16,6 +16,8 @@ limitations under the License.
 
 package sql
 
+import "math/big"
+
 type AggregatedValue interface {
 	TypedValue
 	updateWith(val TypedValue) error
@@ -92,7 +94,7 @@ func (v *CountValue) substitute(params map[string]interface{}) (ValueExp, error)
 	return nil, ErrUnexpected
 }
 
-func (v *CountValue) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *CountValue) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return nil, ErrUnexpected
 }
 
@@ -109,7 +111,10 @@ func (v *CountValue) selectorRanges(table *Table, asTable string, params map[str
 }
 
 type SumValue struct {
+	t   SQLValueType
 	s   int64
+	f   float64
+	d   *big.Rat
 	sel string
 }
 
@@ -122,7 +127,7 @@ func (v *SumValue) ColBounded() bool {
 }
 
 func (v *SumValue) Type() SQLValueType {
-	return IntegerType
+	return v.t
 }
 
 func (v *SumValue) IsNull() bool {
@@ -130,45 +135,91 @@ func (v *SumValue) IsNull() bool {
 }
 
 func (v *SumValue) Value() interface{} {
-	return v.s
+	return v.sum().Value()
+}
+
+// sum returns the accumulated value, of the type of the column
+func (v *SumValue) sum() TypedValue {
+	switch v.t {
+	case FloatType:
+		return &Float{val: v.f}
+	case DecimalType:
+		if v.d == nil {
+			return &Decimal{val: new(big.Rat)}
+		}
+		return &Decimal{val: v.d}
+	}
+
+	return &Number{val: v.s}
 }
 
 func (v *SumValue) Compare(val TypedValue) (int, error) {
-	if val.Type() != IntegerType {
-		return 0, ErrNotComparableValues
+	return v.sum().Compare(val)
+}
+
+func (v *SumValue) updateWith(val TypedValue) error {
+	if val.IsNull() {
+		// NULL values are not taken into account
+		return nil
 	}
 
-	nv := val.Value().(int64)
+	if val.Type() != v.t || !isNumericType(v.t) {
+		return ErrNotComparableValues
+	}
 
-	if v.s == nv {
-		return 0, nil
+	switch v.t {
+	case FloatType:
+		{
+			v.f += val.Value().(float64)
+			return nil
+		}
+	case DecimalType:
+		{
+			d, err := addDecimal(v.d, val.Value().(*big.Rat))
+			if err != nil {
+				return err
+			}
+
+			v.d = d
+
+			return nil
+		}
 	}
 
-	if v.s > nv {
-		return 1, nil
+	nv := val.Value().(int64)
+
+	s := v.s + nv
+	if (nv > 0 && s < v.s) || (nv < 0 && s > v.s) {
+		return ErrNumericOverflow
 	}
 
-	return -1, nil
+	v.s = s
+
+	return nil
 }
 
-func (v *SumValue) updateWith(val TypedValue) error {
-	if val.Type() != IntegerType {
-		return ErrNotComparableValues
+// addDecimal returns the sum of the decimals, a nil one being zero
+func addDecimal(d1, d2 *big.Rat) (*big.Rat, error) {
+	if d1 == nil {
+		return d2, nil
 	}
 
-	v.s += val.Value().(int64)
+	d, err := NewDecimal(new(big.Rat).Add(d1, d2))
+	if err != nil {
+		return nil, ErrNumericOverflow
+	}
 
-	return nil
+	return d, nil
 }
 
 // ValueExp
 
 func (v *SumValue) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
-	return IntegerType, nil
+	return v.t, nil
 }
 
 func (v *SumValue) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
-	if t != IntegerType {
+	if t != v.t {
 		return ErrNotComparableValues
 	}
 	return nil
@@ -182,7 +233,7 @@ func (v *SumValue) substitute(params map[string]interface{}) (ValueExp, error) {
 	return nil, ErrUnexpected
 }
 
-func (v *SumValue) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *SumValue) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return nil, ErrUnexpected
 }
 
@@ -216,7 +267,7 @@ func (v *MinValue) Type() SQLValueType {
 }
 
 func (v *MinValue) IsNull() bool {
-	return false
+	return v.val != nil && v.val.IsNull()
 }
 
 func (v *MinValue) Value() interface{} {
@@ -228,11 +279,16 @@ func (v *MinValue) Compare(val TypedValue) (int, error) {
 }
 
 func (v *MinValue) updateWith(val TypedValue) error {
-	if v.val == nil {
+	if v.val == nil || v.val.IsNull() {
+		// NULL is kept only until a value is found
 		v.val = val
 		return nil
 	}
 
+	if val.IsNull() {
+		return nil
+	}
+
 	cmp, err := v.val.Compare(val)
 	if err != nil {
 		return err
@@ -275,7 +331,7 @@ func (v *MinValue) substitute(params map[string]interface{}) (ValueExp, error) {
 	return nil, ErrUnexpected
 }
 
-func (v *MinValue) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *MinValue) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return nil, ErrUnexpected
 }
 
@@ -309,7 +365,7 @@ func (v *MaxValue) Type() SQLValueType {
 }
 
 func (v *MaxValue) IsNull() bool {
-	return false
+	return v.val != nil && v.val.IsNull()
 }
 
 func (v *MaxValue) Value() interface{} {
@@ -321,11 +377,16 @@ func (v *MaxValue) Compare(val TypedValue) (int, error) {
 }
 
 func (v *MaxValue) updateWith(val TypedValue) error {
-	if v.val == nil {
+	if v.val == nil || v.val.IsNull() {
+		// NULL is kept only until a value is found
 		v.val = val
 		return nil
 	}
 
+	if val.IsNull() {
+		return nil
+	}
+
 	cmp, err := v.val.Compare(val)
 	if err != nil {
 		return err
@@ -368,7 +429,7 @@ func (v *MaxValue) substitute(params map[string]interface{}) (ValueExp, error) {
 	return nil, ErrUnexpected
 }
 
-func (v *MaxValue) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *MaxValue) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return nil, ErrUnexpected
 }
 
@@ -385,7 +446,10 @@ func (v *MaxValue) selectorRanges(table *Table, asTable string, params map[strin
 }
 
 type AVGValue struct {
-	s   int64
+	t   SQLValueType
+	s   *big.Int // accumulated in arbitrary precision, the average of integers always fits in an int64
+	f   float64
+	d   *big.Rat
 	c   int64
 	sel string
 }
@@ -399,7 +463,7 @@ func (v *AVGValue) ColBounded() bool {
 }
 
 func (v *AVGValue) Type() SQLValueType {
-	return IntegerType
+	return v.t
 }
 
 func (v *AVGValue) IsNull() bool {
@@ -407,34 +471,71 @@ func (v *AVGValue) IsNull() bool {
 }
 
 func (v *AVGValue) Value() interface{} {
-	return v.s / v.c
-}
-
-func (v *AVGValue) Compare(val TypedValue) (int, error) {
-	if val.Type() != IntegerType {
-		return 0, ErrNotComparableValues
+	return v.avg().Value()
+}
+
+// avg returns the average of the accumulated values, of the type of the column
+func (v *AVGValue) avg() TypedValue {
+	switch v.t {
+	case FloatType:
+		if v.c == 0 {
+			return &Float{}
+		}
+		return &Float{val: v.f / float64(v.c)}
+	case DecimalType:
+		if v.c == 0 {
+			return &Decimal{val: new(big.Rat)}
+		}
+		// the average of decimals is within their range
+		d, _ := NewDecimal(new(big.Rat).Quo(v.d, new(big.Rat).SetInt64(v.c)))
+		return &Decimal{val: d}
 	}
 
-	avg := v.s / v.c
-	nv := val.Value().(int64)
-
-	if avg == nv {
-		return 0, nil
+	if v.c == 0 {
+		return &Number{}
 	}
 
-	if avg > nv {
-		return 1, nil
-	}
+	return &Number{val: new(big.Int).Quo(v.s, big.NewInt(v.c)).Int64()}
+}
 
-	return -1, nil
+func (v *AVGValue) Compare(val TypedValue) (int, error) {
+	return v.avg().Compare(val)
 }
 
 func (v *AVGValue) updateWith(val TypedValue) error {
-	if val.Type() != IntegerType {
+	if val.IsNull() {
+		// NULL values are not taken into account
+		return nil
+	}
+
+	if val.Type() != v.t || !isNumericType(v.t) {
 		return ErrNotComparableValues
 	}
 
-	v.s += val.Value().(int64)
+	switch v.t {
+	case FloatType:
+		{
+			v.f += val.Value().(float64)
+		}
+	case DecimalType:
+		{
+			if v.d == nil {
+				v.d = new(big.Rat)
+			}
+
+			// not rounded, the sum may be out of the range of decimals
+			v.d.Add(v.d, val.Value().(*big.Rat))
+		}
+	default:
+		{
+			if v.s == nil {
+				v.s = new(big.Int)
+			}
+
+			v.s.Add(v.s, big.NewInt(val.Value().(int64)))
+		}
+	}
+
 	v.c++
 
 	return nil
@@ -443,11 +544,11 @@ func (v *AVGValue) updateWith(val TypedValue) error {
 // ValueExp
 
 func (v *AVGValue) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
-	return IntegerType, nil
+	return v.t, nil
 }
 
 func (v *AVGValue) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
-	if t != IntegerType {
+	if t != v.t {
 		return ErrNotComparableValues
 	}
 
@@ -462,7 +563,7 @@ func (v *AVGValue) substitute(params map[string]interface{}) (ValueExp, error) {
 	return nil, ErrUnexpected
 }
 
-func (v *AVGValue) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *AVGValue) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return nil, ErrUnexpected
 }
 
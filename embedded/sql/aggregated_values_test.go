@@ -17,6 +17,8 @@ limitations under the License.
 package sql
 
 import (
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -80,7 +82,7 @@ func TestCountValue(t *testing.T) {
 }
 
 func TestSumValue(t *testing.T) {
-	cval := &SumValue{sel: "db1.table1.amount"}
+	cval := &SumValue{t: IntegerType, sel: "db1.table1.amount"}
 	require.Equal(t, "db1.table1.amount", cval.Selector())
 	require.True(t, cval.ColBounded())
 	require.False(t, cval.IsNull())
@@ -111,6 +113,14 @@ func TestSumValue(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, -1, cmp)
 
+	err = cval.updateWith(&NullValue{t: IntegerType})
+	require.NoError(t, err)
+	require.Equal(t, int64(11), cval.Value())
+
+	err = cval.updateWith(&Number{val: math.MaxInt64})
+	require.ErrorIs(t, err, ErrNumericOverflow)
+	require.Equal(t, int64(11), cval.Value())
+
 	// ValueExp
 
 	sqlt, err := cval.inferType(nil, nil, "db1", "table1")
@@ -272,7 +282,7 @@ func TestMaxValue(t *testing.T) {
 }
 
 func TestAVGValue(t *testing.T) {
-	cval := &AVGValue{sel: "db1.table1.amount"}
+	cval := &AVGValue{t: IntegerType, sel: "db1.table1.amount"}
 	require.Equal(t, "db1.table1.amount", cval.Selector())
 	require.True(t, cval.ColBounded())
 	require.False(t, cval.IsNull())
@@ -303,6 +313,18 @@ func TestAVGValue(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, -1, cmp)
 
+	err = cval.updateWith(&NullValue{t: IntegerType})
+	require.NoError(t, err)
+	require.Equal(t, int64(6), cval.Value())
+
+	err = cval.updateWith(&Number{val: math.MaxInt64})
+	require.NoError(t, err)
+
+	err = cval.updateWith(&Number{val: math.MaxInt64})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(math.MaxInt64/2+3), cval.Value())
+
 	// ValueExp
 
 	sqlt, err := cval.inferType(nil, nil, "db1", "table1")
@@ -330,3 +352,72 @@ func TestAVGValue(t *testing.T) {
 
 	require.Nil(t, cval.selectorRanges(nil, "", nil, nil))
 }
+
+func TestSumAndAVGValuesOfFloatsAndDecimals(t *testing.T) {
+	fsum := &SumValue{t: FloatType, sel: "db1.table1.temp"}
+	favg := &AVGValue{t: FloatType, sel: "db1.table1.temp"}
+
+	for _, f := range []float64{0.5, 1.25, -0.25} {
+		require.NoError(t, fsum.updateWith(&Float{val: f}))
+		require.NoError(t, favg.updateWith(&Float{val: f}))
+	}
+
+	require.Equal(t, FloatType, fsum.Type())
+	require.Equal(t, 1.5, fsum.Value())
+	require.Equal(t, 0.5, favg.Value())
+
+	cmp, err := favg.Compare(&Float{val: 0.5})
+	require.NoError(t, err)
+	require.Equal(t, 0, cmp)
+
+	err = fsum.updateWith(&Number{val: 1})
+	require.ErrorIs(t, err, ErrNotComparableValues)
+
+	dsum := &SumValue{t: DecimalType, sel: "db1.table1.amount"}
+	davg := &AVGValue{t: DecimalType, sel: "db1.table1.amount"}
+
+	for _, d := range []*big.Rat{big.NewRat(1, 10), big.NewRat(2, 10), big.NewRat(-4, 10)} {
+		require.NoError(t, dsum.updateWith(&Decimal{val: d}))
+		require.NoError(t, davg.updateWith(&Decimal{val: d}))
+	}
+
+	require.Equal(t, DecimalType, dsum.Type())
+	require.Equal(t, "-0.1", FormatDecimal(dsum.Value().(*big.Rat)))
+	require.Equal(t, "-0.033333333333333333", FormatDecimal(davg.Value().(*big.Rat)))
+
+	cmp, err = dsum.Compare(&Decimal{val: big.NewRat(-1, 10)})
+	require.NoError(t, err)
+	require.Equal(t, 0, cmp)
+
+	err = dsum.updateWith(&Decimal{val: big.NewRat(math.MaxInt64, 1)})
+	require.NoError(t, err)
+
+	err = dsum.updateWith(&Decimal{val: big.NewRat(2, 1)})
+	require.ErrorIs(t, err, ErrNumericOverflow)
+
+	sqlt, err := davg.inferType(nil, nil, "db1", "table1")
+	require.NoError(t, err)
+	require.Equal(t, DecimalType, sqlt)
+
+	err = davg.requiresType(IntegerType, nil, nil, "db1", "table1")
+	require.ErrorIs(t, err, ErrNotComparableValues)
+}
+
+func TestMinMaxValuesWithNull(t *testing.T) {
+	for _, cval := range []AggregatedValue{&MinValue{}, &MaxValue{}} {
+		err := cval.updateWith(&NullValue{t: IntegerType})
+		require.NoError(t, err)
+		require.True(t, cval.IsNull())
+		require.Equal(t, IntegerType, cval.Type())
+		require.Nil(t, cval.Value())
+
+		err = cval.updateWith(&Number{val: 10})
+		require.NoError(t, err)
+		require.False(t, cval.IsNull())
+
+		err = cval.updateWith(&NullValue{t: IntegerType})
+		require.NoError(t, err)
+		require.False(t, cval.IsNull())
+		require.Equal(t, int64(10), cval.Value())
+	}
+}
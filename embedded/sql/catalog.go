@@ -15,9 +15,15 @@ limitations under the License.
 */
 package sql
 
+import (
+	"fmt"
+	"sort"
+)
+
 type Catalog struct {
 	dbsByID   map[uint32]*Database
 	dbsByName map[string]*Database
+	maxDBID   uint32
 }
 
 type Database struct {
@@ -26,6 +32,8 @@ type Database struct {
 	name         string
 	tablesByID   map[uint32]*Table
 	tablesByName map[string]*Table
+	maxTableID   uint32
+	synonyms     map[string]uint32 // synonym name -> table id
 }
 
 type Table struct {
@@ -35,11 +43,15 @@ type Table struct {
 	cols            []*Column
 	colsByID        map[uint32]*Column
 	colsByName      map[string]*Column
+	droppedColsByID map[uint32]*Column
+	maxColID        uint32
 	indexes         map[string]*Index
 	indexesByColID  map[uint32][]*Index
 	primaryIndex    *Index
 	autoIncrementPK bool
 	maxPK           int64
+	view            *materializedView // set when the rows of the table are derived from a query
+	checks          []*checkConstraint
 }
 
 type Index struct {
@@ -58,6 +70,7 @@ type Column struct {
 	maxLen        int
 	autoIncrement bool
 	notNull       bool
+	dropped       bool
 }
 
 func newCatalog() *Catalog {
@@ -84,11 +97,28 @@ func (c *Catalog) newDatabase(id uint32, name string) (*Database, error) {
 		name:         name,
 		tablesByID:   map[uint32]*Table{},
 		tablesByName: map[string]*Table{},
+		synonyms:     map[string]uint32{},
 	}
 
 	c.dbsByID[db.id] = db
 	c.dbsByName[db.name] = db
 
+	if id > c.maxDBID {
+		c.maxDBID = id
+	}
+
+	return db, nil
+}
+
+func (c *Catalog) dropDatabase(name string) (*Database, error) {
+	db, exists := c.dbsByName[name]
+	if !exists {
+		return nil, ErrDatabaseDoesNotExist
+	}
+
+	delete(c.dbsByID, db.id)
+	delete(c.dbsByName, db.name)
+
 	return db, nil
 }
 
@@ -153,12 +183,62 @@ func (db *Database) GetTables() []*Table {
 	return ts
 }
 
+// GetTableByName returns the table with the given name, synonyms are transparently resolved
 func (db *Database) GetTableByName(name string) (*Table, error) {
 	table, exists := db.tablesByName[name]
-	if !exists {
-		return nil, ErrTableDoesNotExist
+	if exists {
+		return table, nil
 	}
-	return table, nil
+
+	tableID, exists := db.synonyms[name]
+	if exists {
+		return db.GetTableByID(tableID)
+	}
+
+	return nil, ErrTableDoesNotExist
+}
+
+func (db *Database) ExistSynonym(name string) bool {
+	_, exists := db.synonyms[name]
+	return exists
+}
+
+// GetSynonyms returns the name of the table referenced by each synonym
+func (db *Database) GetSynonyms() map[string]string {
+	synonyms := make(map[string]string, len(db.synonyms))
+
+	for name, tableID := range db.synonyms {
+		table, exists := db.tablesByID[tableID]
+		if exists {
+			synonyms[name] = table.name
+		}
+	}
+
+	return synonyms
+}
+
+func (db *Database) newSynonym(name string, table *Table) error {
+	if db.ExistTable(name) {
+		return ErrTableAlreadyExists
+	}
+
+	if db.ExistSynonym(name) {
+		return ErrSynonymAlreadyExists
+	}
+
+	db.synonyms[name] = table.id
+
+	return nil
+}
+
+func (db *Database) dropSynonym(name string) error {
+	if !db.ExistSynonym(name) {
+		return ErrSynonymDoesNotExist
+	}
+
+	delete(db.synonyms, name)
+
+	return nil
 }
 
 func (db *Database) GetTableByID(id uint32) (*Table, error) {
@@ -173,6 +253,11 @@ func (t *Table) ID() uint32 {
 	return t.id
 }
 
+// IsMaterializedView returns true when the rows of the table are derived from a query
+func (t *Table) IsMaterializedView() bool {
+	return t.view != nil
+}
+
 func (t *Table) Database() *Database {
 	return t.db
 }
@@ -208,6 +293,19 @@ func (t *Table) IndexesByColID(colID uint32) []*Index {
 	return t.indexesByColID[colID]
 }
 
+// GetIndexes returns the indexes of the table in creation order, the primary index being the first one
+func (t *Table) GetIndexes() []*Index {
+	idxs := make([]*Index, 0, len(t.indexes))
+
+	for _, idx := range t.indexes {
+		idxs = append(idxs, idx)
+	}
+
+	sort.Slice(idxs, func(i, j int) bool { return idxs[i].id < idxs[j].id })
+
+	return idxs
+}
+
 func (t *Table) GetColumnByName(name string) (*Column, error) {
 	col, exists := t.colsByName[name]
 	if !exists {
@@ -241,11 +339,24 @@ func (i *Index) IncludesCol(colID uint32) bool {
 	return ok
 }
 
-func (i *Index) sortableUsing(colID uint32, rangesByColID map[uint32]*typedValueRange) bool {
-	// all columns before colID must be fixedValues otherwise the index can not be used
+// sortableUsing returns true when entries of the index are ordered by the specified columns,
+// i.e. the columns are included in the same order and the ones in between are fixed values
+func (i *Index) sortableUsing(colIDs []uint32, rangesByColID map[uint32]*typedValueRange) bool {
+	if len(colIDs) == 0 {
+		return false
+	}
+
+	matched := 0
+
 	for _, col := range i.cols {
-		if col.id == colID {
-			return true
+		if col.id == colIDs[matched] {
+			matched++
+
+			if matched == len(colIDs) {
+				return true
+			}
+
+			continue
 		}
 
 		colRange, ok := rangesByColID[col.id]
@@ -258,6 +369,50 @@ func (i *Index) sortableUsing(colID uint32, rangesByColID map[uint32]*typedValue
 	return false
 }
 
+// indexOn returns the index on the specified columns, being the primary index when no column is specified
+func (t *Table) indexOn(colNames []string) (*Index, error) {
+	if len(colNames) == 0 {
+		return t.primaryIndex, nil
+	}
+
+	cols := make([]*Column, len(colNames))
+
+	for i, colName := range colNames {
+		col, err := t.GetColumnByName(colName)
+		if err != nil {
+			return nil, err
+		}
+
+		cols[i] = col
+	}
+
+	index, ok := t.indexes[indexKeyFrom(cols)]
+	if !ok {
+		return nil, ErrNoAvailableIndex
+	}
+
+	return index, nil
+}
+
+// orderingCols returns the columns entries of the index are sorted by. Entries of non-unique indexes
+// are keyed by the primary key as well, so rows with equal indexed values are read in primary key order
+func (i *Index) orderingCols() []*Column {
+	if i.unique {
+		return i.cols
+	}
+
+	cols := make([]*Column, len(i.cols), len(i.cols)+len(i.table.primaryIndex.cols))
+	copy(cols, i.cols)
+
+	for _, col := range i.table.primaryIndex.cols {
+		if !i.IncludesCol(col.id) {
+			cols = append(cols, col)
+		}
+	}
+
+	return cols
+}
+
 func (i *Index) prefix() string {
 	if i.IsPrimary() {
 		return PIndexPrefix
@@ -280,56 +435,145 @@ func (db *Database) newTable(name string, colsSpec []*ColSpec) (table *Table, er
 		return nil, ErrTableAlreadyExists
 	}
 
-	id := len(db.tablesByID) + 1
+	if db.ExistSynonym(name) {
+		return nil, ErrSynonymAlreadyExists
+	}
 
+	// ids of dropped tables are never reused
 	table = &Table{
-		id:             uint32(id),
-		db:             db,
-		name:           name,
-		cols:           make([]*Column, len(colsSpec)),
-		colsByID:       make(map[uint32]*Column),
-		colsByName:     make(map[string]*Column),
-		indexes:        make(map[string]*Index),
-		indexesByColID: make(map[uint32][]*Index),
-	}
-
-	for i, cs := range colsSpec {
-		_, colExists := table.colsByName[cs.colName]
-		if colExists {
-			return nil, ErrDuplicatedColumn
-		}
+		id:              db.maxTableID + 1,
+		db:              db,
+		name:            name,
+		cols:            make([]*Column, 0, len(colsSpec)),
+		colsByID:        make(map[uint32]*Column),
+		colsByName:      make(map[string]*Column),
+		droppedColsByID: make(map[uint32]*Column),
+		indexes:         make(map[string]*Index),
+		indexesByColID:  make(map[uint32][]*Index),
+	}
 
-		if cs.autoIncrement && cs.colType != IntegerType {
-			return nil, ErrLimitedAutoIncrement
+	for _, cs := range colsSpec {
+		col, err := table.newColumn(cs)
+		if err != nil {
+			return nil, err
 		}
 
-		if !validMaxLenForType(cs.maxLen, cs.colType) {
-			return nil, ErrLimitedMaxLen
+		if cs.dropped {
+			table.removeColumn(col)
 		}
+	}
 
-		id := len(table.colsByID) + 1
+	db.tablesByID[table.id] = table
+	db.tablesByName[table.name] = table
+	db.maxTableID = table.id
 
-		col := &Column{
-			id:            uint32(id),
-			table:         table,
-			colName:       cs.colName,
-			colType:       cs.colType,
-			maxLen:        cs.maxLen,
-			autoIncrement: cs.autoIncrement,
-			notNull:       cs.notNull,
-		}
+	return table, nil
+}
 
-		table.cols[i] = col
-		table.colsByID[col.id] = col
-		table.colsByName[col.colName] = col
+func (db *Database) dropTable(name string) (*Table, error) {
+	table, exists := db.tablesByName[name]
+	if !exists {
+		return nil, ErrTableDoesNotExist
 	}
 
-	db.tablesByID[table.id] = table
-	db.tablesByName[table.name] = table
+	delete(db.tablesByID, table.id)
+	delete(db.tablesByName, table.name)
 
 	return table, nil
 }
 
+func (t *Table) newColumn(spec *ColSpec) (*Column, error) {
+	_, colExists := t.colsByName[spec.colName]
+	if colExists {
+		return nil, ErrDuplicatedColumn
+	}
+
+	if spec.autoIncrement && spec.colType != IntegerType {
+		return nil, ErrLimitedAutoIncrement
+	}
+
+	if !validMaxLenForType(spec.maxLen, spec.colType) {
+		return nil, ErrLimitedMaxLen
+	}
+
+	// ids of dropped columns are never reused
+	t.maxColID++
+
+	col := &Column{
+		id:            t.maxColID,
+		table:         t,
+		colName:       spec.colName,
+		colType:       spec.colType,
+		maxLen:        spec.maxLen,
+		autoIncrement: spec.autoIncrement,
+		notNull:       spec.notNull,
+	}
+
+	t.cols = append(t.cols, col)
+	t.colsByID[col.id] = col
+	t.colsByName[col.colName] = col
+
+	return col, nil
+}
+
+func (t *Table) dropColumn(colName string) (*Column, error) {
+	col, exists := t.colsByName[colName]
+	if !exists {
+		return nil, ErrColumnDoesNotExist
+	}
+
+	_, indexed := t.indexesByColID[col.id]
+	if indexed {
+		return nil, ErrIndexedColumnCanNotBeDropped
+	}
+
+	if t.referencedByChecks(col) {
+		return nil, ErrColumnReferencedByCheck
+	}
+
+	t.removeColumn(col)
+
+	return col, nil
+}
+
+func (t *Table) removeColumn(col *Column) {
+	for i, c := range t.cols {
+		if c.id == col.id {
+			t.cols = append(t.cols[:i], t.cols[i+1:]...)
+			break
+		}
+	}
+
+	delete(t.colsByID, col.id)
+	delete(t.colsByName, col.colName)
+
+	col.dropped = true
+	t.droppedColsByID[col.id] = col
+}
+
+func (t *Table) renameColumn(oldName, newName string) (*Column, error) {
+	col, exists := t.colsByName[oldName]
+	if !exists {
+		return nil, ErrColumnDoesNotExist
+	}
+
+	_, exists = t.colsByName[newName]
+	if exists {
+		return nil, ErrDuplicatedColumn
+	}
+
+	if t.referencedByChecks(col) {
+		return nil, ErrColumnReferencedByCheck
+	}
+
+	delete(t.colsByName, oldName)
+
+	col.colName = newName
+	t.colsByName[newName] = col
+
+	return col, nil
+}
+
 func (t *Table) newIndex(unique bool, colIDs []uint32) (index *Index, err error) {
 	if len(colIDs) < 1 {
 		return nil, ErrIllegalArguments
@@ -404,10 +648,67 @@ func (c *Column) MaxLen() int {
 		return 8
 	case TimestampType:
 		return 8
+	case FloatType:
+		return 8
+	case DecimalType:
+		return 16
 	}
 	return c.maxLen
 }
 
+// checkMaxLen validates the length in bytes of VARCHAR and BLOB values against the max length of the column, if any
+func (c *Column) checkMaxLen(val TypedValue) error {
+	// values of other types are rejected when encoded
+	if c.maxLen == 0 || val.Type() != c.colType {
+		return nil
+	}
+
+	var l int
+
+	switch v := val.Value().(type) {
+	case string:
+		l = len(v)
+	case []byte:
+		l = len(v)
+	default:
+		return nil
+	}
+
+	if l > c.maxLen {
+		return fmt.Errorf("%w: value of column '%s' is %d bytes long while up to %d are allowed", ErrMaxLengthExceeded, c.colName, l, c.maxLen)
+	}
+
+	return nil
+}
+
+// coerce converts numeric values assigned to FLOAT or DECIMAL columns into the type of the column,
+// decimals may also be given as strings e.g. '2.25'. Values of other types are returned unchanged
+func (c *Column) coerce(val TypedValue) (TypedValue, error) {
+	if val.IsNull() || val.Type() == c.colType {
+		return val, nil
+	}
+
+	switch c.colType {
+	case FloatType:
+		if val.Type() != IntegerType && val.Type() != DecimalType {
+			return val, nil
+		}
+	case DecimalType:
+		if !isNumericType(val.Type()) && val.Type() != VarcharType {
+			return val, nil
+		}
+	default:
+		return val, nil
+	}
+
+	conv, err := (&Cast{t: c.colType}).getConverter(val.Type(), c.colType)
+	if err != nil {
+		return nil, err
+	}
+
+	return conv(val)
+}
+
 func (c *Column) IsNullable() bool {
 	return !c.notNull
 }
@@ -424,6 +725,10 @@ func validMaxLenForType(maxLen int, sqlType SQLValueType) bool {
 		return maxLen == 0 || maxLen == 8
 	case TimestampType:
 		return maxLen == 0 || maxLen == 8
+	case FloatType:
+		return maxLen == 0 || maxLen == 8
+	case DecimalType:
+		return maxLen == 0 || maxLen == 16
 	}
 
 	return maxLen >= 0
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"bytes"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+const catalogPrefix = "CTL."
+
+// CopyCatalogs rewrites the catalog entries of the SQL engines whose prefix starts with sqlPrefix,
+// e.g. the catalogs of SQL namespaces, so they outlive the truncation of the transactions they were
+// written in. Entries are copied as they are, deleted ones included, in as many transactions as needed.
+// Copying fails with store.ErrTxReadConflict if other transactions are concurrently committed
+func CopyCatalogs(st *store.ImmuStore, sqlPrefix []byte) (copied int, err error) {
+	prefixes, err := enginePrefixes(st, sqlPrefix)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, prefix := range prefixes {
+		n, err := copyEntries(st, mapKey(prefix, catalogPrefix))
+		copied += n
+		if err != nil {
+			return copied, err
+		}
+	}
+
+	return copied, nil
+}
+
+// enginePrefixes returns the prefixes of the SQL engines found under sqlPrefix,
+// an engine is found by the catalog entries of its databases
+func enginePrefixes(st *store.ImmuStore, sqlPrefix []byte) ([][]byte, error) {
+	tx, err := st.NewTx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Cancel()
+
+	reader, err := tx.NewKeyReader(&store.KeyReaderSpec{Prefix: sqlPrefix})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var prefixes [][]byte
+
+	dbSuffixLen := len(catalogDatabasePrefix) + EncIDLen
+
+	for {
+		mkey, _, err := reader.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(mkey) < len(sqlPrefix)+dbSuffixLen ||
+			!bytes.Equal(mkey[len(mkey)-dbSuffixLen:len(mkey)-EncIDLen], []byte(catalogDatabasePrefix)) {
+			continue
+		}
+
+		prefix := append([]byte{}, mkey[:len(mkey)-dbSuffixLen]...)
+
+		if len(prefixes) == 0 || !bytes.Equal(prefixes[len(prefixes)-1], prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	return prefixes, nil
+}
+
+// copyEntries rewrites the entries under the prefix, each transaction holds as many entries as allowed
+func copyEntries(st *store.ImmuStore, prefix []byte) (copied int, err error) {
+	var seekKey []byte
+
+	for {
+		tx, err := st.NewTx()
+		if err != nil {
+			return copied, err
+		}
+
+		n, lastKey, err := copyEntriesInTx(tx, prefix, seekKey, st.MaxTxEntries())
+		if err != nil || n == 0 {
+			tx.Cancel()
+			return copied, err
+		}
+
+		_, err = tx.Commit()
+		if err != nil {
+			return copied, err
+		}
+
+		copied += n
+
+		if n < st.MaxTxEntries() {
+			return copied, nil
+		}
+
+		seekKey = lastKey
+	}
+}
+
+func copyEntriesInTx(tx *store.OngoingTx, prefix, seekKey []byte, maxEntries int) (n int, lastKey []byte, err error) {
+	reader, err := tx.NewKeyReader(&store.KeyReaderSpec{
+		Prefix:  prefix,
+		SeekKey: seekKey,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	defer reader.Close()
+
+	type entry struct {
+		key   []byte
+		md    *store.KVMetadata
+		value []byte
+	}
+
+	var entries []*entry
+
+	for len(entries) < maxEntries {
+		mkey, vref, err := reader.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+
+		value, err := vref.Resolve()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		entries = append(entries, &entry{key: append([]byte{}, mkey...), md: vref.KVMetadata(), value: value})
+	}
+
+	// entries are set once read, as setting them updates the snapshot being read
+	for _, e := range entries {
+		err = tx.Set(e.key, e.md, e.value)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		lastKey = e.key
+	}
+
+	return len(entries), lastKey, nil
+}
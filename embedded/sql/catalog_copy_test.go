@@ -0,0 +1,132 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyCatalogs(t *testing.T) {
+	st, err := store.Open(t.TempDir(), store.DefaultOptions().WithFileSize(256))
+	require.NoError(t, err)
+	defer st.Close()
+
+	nsPrefix := append(append([]byte{}, sqlPrefix...), []byte("ns1")...)
+
+	for _, prefix := range [][]byte{sqlPrefix, nsPrefix} {
+		engine, err := NewEngine(st, DefaultOptions().WithPrefix(prefix))
+		require.NoError(t, err)
+
+		_, _, err = engine.Exec(context.Background(), `
+			CREATE DATABASE db1;
+			CREATE DATABASE db2;
+			DROP DATABASE db2;
+			USE DATABASE db1;
+			CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id);
+			CREATE TABLE table2 (id INTEGER, PRIMARY KEY id);
+			DROP TABLE table2;
+		`, nil, nil)
+		require.NoError(t, err)
+
+		err = engine.SetDefaultDatabase("db1")
+		require.NoError(t, err)
+
+		for i := 0; i < 20; i++ {
+			_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(title) VALUES('a title long enough to fill chunks')", nil, nil)
+			require.NoError(t, err)
+		}
+
+		err = engine.Close()
+		require.NoError(t, err)
+	}
+
+	firstCopyTxID := st.TxCount() + 1
+
+	copied, err := CopyCatalogs(st, sqlPrefix)
+	require.NoError(t, err)
+	require.Positive(t, copied)
+
+	discarded, err := st.TruncateUptoTx(firstCopyTxID)
+	require.NoError(t, err)
+	require.Positive(t, discarded)
+
+	for _, prefix := range [][]byte{sqlPrefix, nsPrefix} {
+		engine, err := NewEngine(st, DefaultOptions().WithPrefix(prefix))
+		require.NoError(t, err)
+
+		err = engine.SetDefaultDatabase("db1")
+		require.NoError(t, err)
+
+		err = engine.SetDefaultDatabase("db2")
+		require.ErrorIs(t, err, ErrDatabaseDoesNotExist)
+
+		// the ids of the dropped database and table are not reused
+		_, _, err = engine.Exec(context.Background(), `
+			CREATE DATABASE db3;
+			USE DATABASE db3;
+			CREATE TABLE table3 (id INTEGER, PRIMARY KEY id);
+		`, nil, nil)
+		require.NoError(t, err)
+
+		catalog, err := engine.Catalog(context.Background(), nil)
+		require.NoError(t, err)
+
+		db3, err := catalog.GetDatabaseByName("db3")
+		require.NoError(t, err)
+		require.Equal(t, uint32(3), db3.ID())
+
+		err = engine.SetDefaultDatabase("db1")
+		require.NoError(t, err)
+
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(title) VALUES('a title')", nil, nil)
+		require.NoError(t, err)
+
+		// rows whose values were discarded are skipped
+		r, err := engine.Query(context.Background(), "SELECT id FROM table1", nil, nil)
+		require.NoError(t, err)
+
+		rows := 0
+
+		for {
+			_, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+			rows++
+		}
+
+		err = r.Close()
+		require.NoError(t, err)
+
+		require.Positive(t, rows)
+		require.Less(t, rows, 21)
+
+		db1, err := catalog.GetDatabaseByName("db1")
+		require.NoError(t, err)
+
+		_, err = db1.GetTableByName("table2")
+		require.ErrorIs(t, err, ErrTableDoesNotExist)
+
+		err = engine.Close()
+		require.NoError(t, err)
+	}
+}
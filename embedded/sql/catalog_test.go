@@ -136,4 +136,13 @@ func TestFromEmptyCatalog(t *testing.T) {
 	_, err = table.newIndex(true, []uint32{1, 2, 1})
 	require.ErrorIs(t, err, ErrDuplicatedColumn)
 
+	_, err = table.newIndex(false, []uint32{2})
+	require.NoError(t, err)
+
+	indexes := table.GetIndexes()
+	require.Len(t, indexes, 2)
+	require.True(t, indexes[0].IsPrimary())
+	require.False(t, indexes[1].IsPrimary())
+	require.False(t, indexes[1].IsUnique())
+	require.Equal(t, "title", indexes[1].Cols()[0].Name())
 }
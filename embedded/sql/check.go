@@ -0,0 +1,212 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+)
+
+// checkConstraint is a predicate every row of a table must satisfy,
+// its source text is kept so it can be persisted in the catalog
+type checkConstraint struct {
+	exp ValueExp
+	sql string
+}
+
+// addCheck validates the predicate against the columns of the table, it must be a boolean expression without parameters
+func (t *Table) addCheck(check *checkConstraint) error {
+	params := make(map[string]SQLValueType)
+
+	err := check.exp.requiresType(BooleanType, t.colDescriptors(nil), params, t.db.name, t.name)
+	if err != nil {
+		return fmt.Errorf("%w (%s): %v", ErrInvalidCheckConstraint, check.sql, err)
+	}
+
+	if len(params) > 0 {
+		return fmt.Errorf("%w (%s): parameters are not allowed", ErrInvalidCheckConstraint, check.sql)
+	}
+
+	t.checks = append(t.checks, check)
+
+	return nil
+}
+
+// referencedByChecks returns true when the column is used by any of the check constraints of the table
+func (t *Table) referencedByChecks(col *Column) bool {
+	cols := t.colDescriptors(col)
+
+	for _, check := range t.checks {
+		err := check.exp.requiresType(BooleanType, cols, make(map[string]SQLValueType), t.db.name, t.name)
+		if errors.Is(err, ErrColumnDoesNotExist) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *Table) colDescriptors(excluded *Column) map[string]ColDescriptor {
+	cols := make(map[string]ColDescriptor, len(t.cols))
+
+	for _, c := range t.cols {
+		if c == excluded {
+			continue
+		}
+
+		desc := ColDescriptor{
+			Database: t.db.name,
+			Table:    t.name,
+			Column:   c.colName,
+			Type:     c.colType,
+		}
+
+		cols[desc.Selector()] = desc
+	}
+
+	return cols
+}
+
+// checkRow evaluates the check constraints of the table against the values of a row,
+// as in standard SQL a predicate evaluated to unknown (NULL) is not a violation
+func (t *Table) checkRow(tx *SQLTx, valuesByColID map[uint32]TypedValue) error {
+	if len(t.checks) == 0 {
+		return nil
+	}
+
+	row := &Row{Values: make(map[string]TypedValue, len(t.cols))}
+
+	for _, col := range t.cols {
+		val, ok := valuesByColID[col.id]
+		if !ok {
+			val = &NullValue{t: col.colType}
+		}
+
+		row.Values[EncodeSelector("", t.db.name, t.name, col.colName)] = val
+	}
+
+	for _, check := range t.checks {
+		val, err := reduceCheck(tx, check.exp, row, t.db.name, t.name)
+		if err != nil {
+			return err
+		}
+
+		if val.IsNull() {
+			continue
+		}
+
+		satisfied, ok := val.Value().(bool)
+		if !ok || !satisfied {
+			return fmt.Errorf("%w (%s)", ErrCheckConstraintViolated, check.sql)
+		}
+	}
+
+	return nil
+}
+
+func persistCheck(tx *SQLTx, table *Table, checkID uint32, check *checkConstraint) error {
+	mappedKey := mapKey(tx.sqlPrefix(), catalogCheckPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(checkID))
+
+	return tx.set(mappedKey, nil, []byte(check.sql))
+}
+
+// reduceCheck reduces a check predicate following three-valued logic. Unlike in filters,
+// where comparisons involving NULL are false, they are unknown here so they don't cause a violation
+func reduceCheck(tx *SQLTx, exp ValueExp, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	unknown := &NullValue{t: BooleanType}
+
+	switch e := exp.(type) {
+	case *CmpBoolExp:
+		{
+			_, isNullLeft := e.left.(*NullValue)
+			_, isNullRight := e.right.(*NullValue)
+
+			if isNullLeft || isNullRight {
+				// IS [NOT] NULL
+				return e.reduce(tx, row, implicitDB, implicitTable)
+			}
+
+			vl, err := e.left.reduce(tx, row, implicitDB, implicitTable)
+			if err != nil {
+				return nil, err
+			}
+
+			vr, err := e.right.reduce(tx, row, implicitDB, implicitTable)
+			if err != nil {
+				return nil, err
+			}
+
+			if vl.IsNull() || vr.IsNull() {
+				return unknown, nil
+			}
+
+			r, err := vl.Compare(vr)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Bool{val: cmpSatisfiesOp(r, e.op)}, nil
+		}
+	case *BinBoolExp:
+		{
+			vl, err := reduceCheck(tx, e.left, row, implicitDB, implicitTable)
+			if err != nil {
+				return nil, err
+			}
+
+			vr, err := reduceCheck(tx, e.right, row, implicitDB, implicitTable)
+			if err != nil {
+				return nil, err
+			}
+
+			// a definite operand decides the result regardless of the other one being unknown
+			decisive := e.op == OR
+
+			for _, v := range []TypedValue{vl, vr} {
+				if !v.IsNull() && v.Value() == decisive {
+					return &Bool{val: decisive}, nil
+				}
+			}
+
+			if vl.IsNull() || vr.IsNull() {
+				return unknown, nil
+			}
+
+			return &Bool{val: !decisive}, nil
+		}
+	case *NotBoolExp:
+		{
+			v, err := reduceCheck(tx, e.exp, row, implicitDB, implicitTable)
+			if err != nil {
+				return nil, err
+			}
+
+			if v.IsNull() {
+				return unknown, nil
+			}
+
+			b, isBool := v.Value().(bool)
+			if !isBool {
+				return nil, fmt.Errorf("%w (expecting boolean value)", ErrInvalidValue)
+			}
+
+			return &Bool{val: !b}, nil
+		}
+	}
+
+	return exp.reduce(tx, row, implicitDB, implicitTable)
+}
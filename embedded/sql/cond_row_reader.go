@@ -21,6 +21,10 @@ type conditionalRowReader struct {
 	condition ValueExp
 
 	params map[string]interface{}
+
+	// condition with parameters substituted, it's substituted once as it may hold state
+	// built for the whole read e.g. hashed values of IN clauses
+	substituted ValueExp
 }
 
 func newConditionalRowReader(rowReader RowReader, condition ValueExp, params map[string]interface{}) (*conditionalRowReader, error) {
@@ -54,6 +58,7 @@ func (cr *conditionalRowReader) SetParameters(params map[string]interface{}) err
 	}
 
 	cr.params, err = normalizeParams(params)
+	cr.substituted = nil
 
 	return err
 }
@@ -97,12 +102,14 @@ func (cr *conditionalRowReader) Read() (*Row, error) {
 			return nil, err
 		}
 
-		cond, err := cr.condition.substitute(cr.params)
-		if err != nil {
-			return nil, err
+		if cr.substituted == nil {
+			cr.substituted, err = cr.condition.substitute(cr.params)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		r, err := cond.reduce(cr.Tx().catalog, row, cr.rowReader.Database().Name(), cr.rowReader.TableAlias())
+		r, err := cr.substituted.reduce(cr.Tx(), row, cr.rowReader.Database().Name(), cr.rowReader.TableAlias())
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DecimalScale is the number of fractional digits kept by DECIMAL values.
+// Values are fixed-point numbers with an int64 integer part, rounded half away from zero
+const DecimalScale = 18
+
+var decimalUnit = new(big.Int).Exp(big.NewInt(10), big.NewInt(DecimalScale), nil)
+
+// NewDecimal returns r rounded to DecimalScale fractional digits
+func NewDecimal(r *big.Rat) (*big.Rat, error) {
+	ip, fp, err := decimalToFixed(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return decimalFromFixed(ip, fp), nil
+}
+
+// ParseDecimal parses a decimal number such as "-123.45"
+func ParseDecimal(s string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		if len(s) > 30 {
+			s = s[:30] + "..."
+		}
+
+		return nil, fmt.Errorf("%w: '%s' is not a decimal number", ErrInvalidValue, s)
+	}
+
+	return NewDecimal(r)
+}
+
+// FormatDecimal renders the decimal without trailing zeros e.g. 10.5
+func FormatDecimal(r *big.Rat) string {
+	s := r.FloatString(DecimalScale)
+
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+
+	if s == "-0" {
+		return "0"
+	}
+
+	return s
+}
+
+// decimalToFixed splits r as ip + fp/10^DecimalScale with 0 <= fp < 10^DecimalScale
+func decimalToFixed(r *big.Rat) (ip int64, fp uint64, err error) {
+	scaled := new(big.Int).Mul(r.Num(), decimalUnit)
+
+	q, m := new(big.Int).QuoRem(scaled, r.Denom(), new(big.Int))
+
+	// round half away from zero
+	if m.Sign() != 0 && new(big.Int).Lsh(new(big.Int).Abs(m), 1).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+
+	i, f := new(big.Int).DivMod(q, decimalUnit, new(big.Int))
+	if !i.IsInt64() {
+		return 0, 0, fmt.Errorf("%w: decimal value out of range", ErrInvalidValue)
+	}
+
+	return i.Int64(), f.Uint64(), nil
+}
+
+func decimalFromFixed(ip int64, fp uint64) *big.Rat {
+	n := new(big.Int).Mul(big.NewInt(ip), decimalUnit)
+	n.Add(n, new(big.Int).SetUint64(fp))
+
+	return new(big.Rat).SetFrac(n, decimalUnit)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal(t *testing.T) {
+	for _, d := range []struct {
+		in  string
+		out string
+	}{
+		{"0", "0"},
+		{"-0.0", "0"},
+		{"10.50", "10.5"},
+		{"-123.456", "-123.456"},
+		{"1/3", "0.333333333333333333"},
+		{"2/3", "0.666666666666666667"},
+		{"-2/3", "-0.666666666666666667"},
+		{"0.0000000000000000005", "0.000000000000000001"},
+		{"-0.0000000000000000004", "0"},
+		{"9223372036854775807.999999999999999999", "9223372036854775807.999999999999999999"},
+		{"-9223372036854775808", "-9223372036854775808"},
+	} {
+		r, err := ParseDecimal(d.in)
+		require.NoError(t, err, d.in)
+		require.Equal(t, d.out, FormatDecimal(r), d.in)
+		require.Equal(t, d.out, (&Decimal{val: r}).String(), d.in)
+
+		ip, fp, err := decimalToFixed(r)
+		require.NoError(t, err)
+		require.Zero(t, r.Cmp(decimalFromFixed(ip, fp)))
+	}
+
+	_, err := ParseDecimal("1.2.3")
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	_, err = ParseDecimal("9223372036854775808")
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	_, err = NewDecimal(big.NewRat(-1, 1).SetFrac64(-9223372036854775807, 1).Sub(big.NewRat(-9223372036854775807, 1), big.NewRat(2, 1)))
+	require.ErrorIs(t, err, ErrInvalidValue)
+}
@@ -17,15 +17,20 @@ package sql
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/codenotary/immudb/embedded/logger"
 	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/embedded/watchers"
 )
 
 var ErrNoSupported = errors.New("not yet supported")
@@ -36,12 +41,17 @@ var ErrDatabaseAlreadyExists = errors.New("database already exists")
 var ErrNoDatabaseSelected = errors.New("no database selected")
 var ErrTableAlreadyExists = errors.New("table already exists")
 var ErrTableDoesNotExist = errors.New("table does not exist")
+var ErrSynonymAlreadyExists = errors.New("synonym already exists")
+var ErrSynonymDoesNotExist = errors.New("synonym does not exist")
 var ErrColumnDoesNotExist = errors.New("column does not exist")
 var ErrColumnNotIndexed = errors.New("column is not indexed")
 var ErrLimitedKeyType = errors.New("indexed key of invalid type. Supported types are: INTEGER, VARCHAR[256] OR BLOB[256]")
 var ErrLimitedAutoIncrement = errors.New("only INTEGER single-column primary keys can be set as auto incremental")
 var ErrLimitedMaxLen = errors.New("only VARCHAR and BLOB types support max length")
 var ErrDuplicatedColumn = errors.New("duplicated column")
+var ErrNewColumnMustBeNullable = errors.New("new columns must be nullable")
+var ErrIndexedColumnCanNotBeDropped = errors.New("indexed column can not be dropped")
+var ErrDatabaseInUse = errors.New("database in use can not be dropped")
 var ErrInvalidColumn = errors.New("invalid column")
 var ErrPKCanNotBeNull = errors.New("primary key can not be null")
 var ErrPKCanNotBeUpdated = errors.New("primary key can not be updated")
@@ -53,7 +63,6 @@ var ErrInvalidNumberOfValues = errors.New("invalid number of values provided")
 var ErrInvalidValue = errors.New("invalid value provided")
 var ErrInferredMultipleTypes = errors.New("inferred multiple types")
 var ErrExpectingDQLStmt = errors.New("illegal statement. DQL statement expected")
-var ErrLimitedOrderBy = errors.New("order is limit to one indexed column")
 var ErrLimitedGroupBy = errors.New("group by requires ordering by the grouping column")
 var ErrIllegalMappedKey = errors.New("error illegal mapped key")
 var ErrCorruptedData = store.ErrCorruptedData
@@ -76,10 +85,30 @@ var ErrMissingParameter = errors.New("missing parameter")
 var ErrUnsupportedParameter = errors.New("unsupported parameter")
 var ErrDuplicatedParameters = errors.New("duplicated parameters")
 var ErrLimitedIndexCreation = errors.New("index creation is only supported on empty tables")
+var ErrDuplicateUniqueValue = fmt.Errorf("%w: duplicate value in unique index", store.ErrKeyAlreadyExists)
 var ErrTooManyRows = errors.New("too many rows")
 var ErrAlreadyClosed = store.ErrAlreadyClosed
 var ErrAmbiguousSelector = errors.New("ambiguous selector")
 var ErrUnsupportedCast = errors.New("unsupported cast")
+var ErrUnsupportedRowFormat = errors.New("unsupported row format")
+var ErrTxStmtNotAllowed = errors.New("transaction statements are not allowed within scripts")
+var ErrMaxStmtLengthExceeded = errors.New("max statement length exceeded")
+var ErrMaxJoinsExceeded = errors.New("max number of joins exceeded")
+var ErrMaxInListSizeExceeded = errors.New("max IN list size exceeded")
+var ErrMaxSubqueryDepthExceeded = errors.New("max subquery depth exceeded")
+var ErrSnapshotNotAvailable = errors.New("snapshot not available")
+var ErrHistoricalSnapshotIsReadOnly = errors.New("historical snapshots are read-only")
+var ErrNumericOverflow = errors.New("numeric overflow")
+var ErrCancellationRequested = watchers.ErrCancellationRequested
+var ErrCloseTimeout = errors.New("timeout waiting for open transactions to be closed")
+var ErrTableNotInScope = errors.New("table not in scope")
+var ErrMaterializedViewDoesNotExist = errors.New("materialized view does not exist")
+var ErrMaterializedViewIsReadOnly = errors.New("materialized views are only updated by refreshing them")
+var ErrLimitedMaterializedView = errors.New("materialized views are limited to single-table queries either grouped by a non-nullable column or selecting the primary key")
+var ErrTableAccessDenied = errors.New("access to table denied")
+var ErrInvalidCheckConstraint = errors.New("invalid check constraint")
+var ErrCheckConstraintViolated = errors.New("check constraint violated")
+var ErrColumnReferencedByCheck = errors.New("column is referenced by a check constraint")
 
 var maxKeyLen = 256
 
@@ -95,8 +124,31 @@ type Engine struct {
 	distinctLimit int
 	autocommit    bool
 
+	log logger.Logger
+
+	sortBufferSize int
+	sortDir        string
+	joinBufferSize int
+
+	maxStmtLength    int
+	maxJoins         int
+	maxInListSize    int
+	maxSubqueryDepth int
+
 	defaultDatabase string
 
+	fullScans      map[fullScanKey]uint64
+	fullScansMutex sync.Mutex
+
+	closeTimeout time.Duration
+
+	// transactions not yet committed or cancelled, including the ones created to read rows
+	openTxs      map[*SQLTx]struct{}
+	txsReleased  chan struct{} // set while closing, closed once all the open transactions are released
+	openTxsMutex sync.Mutex
+
+	closed bool
+
 	mutex sync.RWMutex
 }
 
@@ -117,6 +169,16 @@ type SQLTx struct {
 
 	txHeader *store.TxHeader // header is set once tx is committed
 
+	snapshotAsBefore uint64 // set by USE SNAPSHOT, rows are read as they were before this tx
+
+	snapshotTx uint64 // last committed tx reflected by the snapshot, writes of this tx are not accounted
+
+	tableFilter TableFilter // when set, only the rows of the tables accepted by the filter can be read
+
+	startedAt time.Time // NOW() is evaluated to this time along the whole tx
+
+	ctx context.Context // row reading is interrupted once it's done
+
 	committed bool
 	closed    bool
 }
@@ -131,6 +193,23 @@ func NewEngine(store *store.ImmuStore, opts *Options) (*Engine, error) {
 		prefix:        make([]byte, len(opts.prefix)),
 		distinctLimit: opts.distinctLimit,
 		autocommit:    opts.autocommit,
+
+		log: opts.log,
+
+		sortBufferSize: opts.sortBufferSize,
+		sortDir:        opts.sortDir,
+		joinBufferSize: opts.joinBufferSize,
+
+		maxStmtLength:    opts.maxStmtLength,
+		maxJoins:         opts.maxJoins,
+		maxInListSize:    opts.maxInListSize,
+		maxSubqueryDepth: opts.maxSubqueryDepth,
+
+		fullScans: make(map[fullScanKey]uint64),
+
+		closeTimeout: opts.closeTimeout,
+
+		openTxs: make(map[*SQLTx]struct{}),
 	}
 
 	copy(e.prefix, opts.prefix)
@@ -142,7 +221,7 @@ func NewEngine(store *store.ImmuStore, opts *Options) (*Engine, error) {
 }
 
 func (e *Engine) SetDefaultDatabase(dbName string) error {
-	tx, err := e.newTx(false)
+	tx, err := e.newTx(context.Background(), false)
 	if err != nil {
 		return err
 	}
@@ -168,11 +247,38 @@ func (e *Engine) DefaultDatabase() string {
 	return e.defaultDatabase
 }
 
-func (e *Engine) newTx(explicitClose bool) (*SQLTx, error) {
+// NewTx creates an explicitly closed transaction which can be used to run several queries
+// on the same snapshot. When waitForIndexing is false, the freshest already indexed snapshot
+// is used instead of waiting for all the committed transactions to be indexed.
+// Waiting for indexing and reading rows fail with ErrCancellationRequested once ctx is done
+func (e *Engine) NewTx(ctx context.Context, waitForIndexing bool) (*SQLTx, error) {
+	return e.beginTx(ctx, true, waitForIndexing)
+}
+
+func (e *Engine) newTx(ctx context.Context, explicitClose bool) (*SQLTx, error) {
+	return e.beginTx(ctx, explicitClose, true)
+}
+
+func (e *Engine) beginTx(ctx context.Context, explicitClose, waitForIndexing bool) (*SQLTx, error) {
+	if ctx == nil {
+		return nil, ErrIllegalArguments
+	}
+
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
 
-	tx, err := e.store.NewTx()
+	if e.closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	var tx *store.OngoingTx
+	var err error
+
+	if waitForIndexing {
+		tx, err = e.store.NewTxWithCancellation(ctx.Done())
+	} else {
+		tx, err = e.store.NewTxNoWait()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +287,7 @@ func (e *Engine) newTx(explicitClose bool) (*SQLTx, error) {
 
 	err = catalog.load(e.prefix, tx)
 	if err != nil {
+		tx.Cancel()
 		return nil, err
 	}
 
@@ -189,13 +296,14 @@ func (e *Engine) newTx(explicitClose bool) (*SQLTx, error) {
 	if e.defaultDatabase != "" {
 		defaultDatabase, exists := catalog.dbsByName[e.defaultDatabase]
 		if !exists {
+			tx.Cancel()
 			return nil, ErrDatabaseDoesNotExist
 		}
 
 		currentDB = defaultDatabase
 	}
 
-	return &SQLTx{
+	sqlTx := &SQLTx{
 		engine:           e,
 		tx:               tx,
 		catalog:          catalog,
@@ -203,7 +311,98 @@ func (e *Engine) newTx(explicitClose bool) (*SQLTx, error) {
 		lastInsertedPKs:  make(map[string]int64),
 		firstInsertedPKs: make(map[string]int64),
 		explicitClose:    explicitClose,
-	}, nil
+		snapshotTx:       tx.SnapshotTs(),
+		startedAt:        time.Now().UTC().Truncate(time.Microsecond),
+		ctx:              ctx,
+	}
+
+	e.openTxsMutex.Lock()
+	e.openTxs[sqlTx] = struct{}{}
+	e.openTxsMutex.Unlock()
+
+	return sqlTx, nil
+}
+
+func (e *Engine) releaseTx(tx *SQLTx) {
+	e.openTxsMutex.Lock()
+	defer e.openTxsMutex.Unlock()
+
+	delete(e.openTxs, tx)
+
+	if e.txsReleased != nil && len(e.openTxs) == 0 {
+		close(e.txsReleased)
+		e.txsReleased = nil
+	}
+}
+
+// Close waits for the open transactions to be committed or cancelled, including the ones created
+// to read rows, for as long as the close timeout. Transactions still open by then are cancelled,
+// releasing their snapshots, and ErrCloseTimeout is returned.
+// Once closed, any further call to the engine fails with ErrAlreadyClosed
+func (e *Engine) Close() error {
+	e.mutex.Lock()
+
+	if e.closed {
+		e.mutex.Unlock()
+		return ErrAlreadyClosed
+	}
+
+	e.closed = true
+
+	e.mutex.Unlock()
+
+	e.openTxsMutex.Lock()
+
+	if len(e.openTxs) == 0 {
+		e.openTxsMutex.Unlock()
+		return nil
+	}
+
+	txsReleased := make(chan struct{})
+	e.txsReleased = txsReleased
+
+	e.openTxsMutex.Unlock()
+
+	timer := time.NewTimer(e.closeTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-txsReleased:
+		return nil
+	case <-timer.C:
+	}
+
+	e.openTxsMutex.Lock()
+
+	openTxs := make([]*SQLTx, 0, len(e.openTxs))
+	for tx := range e.openTxs {
+		openTxs = append(openTxs, tx)
+	}
+
+	e.openTxsMutex.Unlock()
+
+	for _, tx := range openTxs {
+		tx.Cancel()
+	}
+
+	e.log.Warn("Open transactions cancelled on close", logger.F("txs", len(openTxs)))
+
+	return ErrCloseTimeout
+}
+
+func (sqlTx *SQLTx) cancelled() bool {
+	return sqlTx.ctx.Err() != nil
+}
+
+// withContext makes ctx the one honored by the transaction until the returned function is called,
+// thus an explicitly closed transaction can be used by calls made with different contexts
+func (sqlTx *SQLTx) withContext(ctx context.Context) (restore func()) {
+	prev := sqlTx.ctx
+	sqlTx.ctx = ctx
+
+	return func() {
+		sqlTx.ctx = prev
+	}
 }
 
 func (sqlTx *SQLTx) useDatabase(dbName string) error {
@@ -221,6 +420,15 @@ func (sqlTx *SQLTx) Database() *Database {
 	return sqlTx.currentDB
 }
 
+// TableFilter decides whether the rows of a table can be read, given the names of its database and the table
+type TableFilter func(db, table string) bool
+
+// SetTableFilter restricts the tables read by the transaction to the ones accepted by the filter,
+// reading any other table fails with ErrTableAccessDenied
+func (sqlTx *SQLTx) SetTableFilter(filter TableFilter) {
+	sqlTx.tableFilter = filter
+}
+
 func (sqlTx *SQLTx) UpdatedRows() int {
 	return sqlTx.updatedRows
 }
@@ -233,6 +441,11 @@ func (sqlTx *SQLTx) FirstInsertedPKs() map[string]int64 {
 	return sqlTx.firstInsertedPKs
 }
 
+// Timestamp returns the time the transaction was started at, being the one NOW() is evaluated to
+func (sqlTx *SQLTx) Timestamp() time.Time {
+	return sqlTx.startedAt
+}
+
 func (sqlTx *SQLTx) TxHeader() *store.TxHeader {
 	return sqlTx.txHeader
 }
@@ -245,6 +458,22 @@ func (sqlTx *SQLTx) distinctLimit() int {
 	return sqlTx.engine.distinctLimit
 }
 
+func (sqlTx *SQLTx) sortBufferSize() int {
+	return sqlTx.engine.sortBufferSize
+}
+
+func (sqlTx *SQLTx) sortDir() string {
+	return sqlTx.engine.sortDir
+}
+
+func (sqlTx *SQLTx) joinBufferSize() int {
+	return sqlTx.engine.joinBufferSize
+}
+
+func (sqlTx *SQLTx) log() logger.Logger {
+	return sqlTx.engine.log
+}
+
 func (sqlTx *SQLTx) newKeyReader(rSpec *store.KeyReaderSpec) (*store.KeyReader, error) {
 	return sqlTx.tx.NewKeyReader(rSpec)
 }
@@ -254,6 +483,10 @@ func (sqlTx *SQLTx) get(key []byte) (store.ValueRef, error) {
 }
 
 func (sqlTx *SQLTx) set(key []byte, metadata *store.KVMetadata, value []byte) error {
+	if sqlTx.snapshotAsBefore > 0 {
+		return ErrHistoricalSnapshotIsReadOnly
+	}
+
 	return sqlTx.tx.Set(key, metadata, value)
 }
 
@@ -268,6 +501,8 @@ func (sqlTx *SQLTx) Cancel() error {
 
 	sqlTx.closed = true
 
+	sqlTx.engine.releaseTx(sqlTx)
+
 	return sqlTx.tx.Cancel()
 }
 
@@ -279,6 +514,8 @@ func (sqlTx *SQLTx) commit() error {
 	sqlTx.committed = true
 	sqlTx.closed = true
 
+	sqlTx.engine.releaseTx(sqlTx)
+
 	hdr, err := sqlTx.tx.Commit()
 	if err != nil && err != store.ErrorNoEntriesProvided {
 		return err
@@ -294,9 +531,9 @@ func (sqlTx *SQLTx) Closed() bool {
 }
 
 func (c *Catalog) load(sqlPrefix []byte, tx *store.OngoingTx) error {
+	// dropped databases are read as well so their ids are not reused
 	dbReaderSpec := &store.KeyReaderSpec{
 		Prefix: mapKey(sqlPrefix, catalogDatabasePrefix),
-		Filter: store.IgnoreDeleted,
 	}
 
 	dbReader, err := tx.NewKeyReader(dbReaderSpec)
@@ -319,6 +556,11 @@ func (c *Catalog) load(sqlPrefix []byte, tx *store.OngoingTx) error {
 			return err
 		}
 
+		if isDeleted(vref) {
+			c.maxDBID = id
+			continue
+		}
+
 		v, err := vref.Resolve()
 		if err != nil {
 			return err
@@ -333,15 +575,129 @@ func (c *Catalog) load(sqlPrefix []byte, tx *store.OngoingTx) error {
 		if err != nil {
 			return err
 		}
+
+		err = db.loadSynonyms(sqlPrefix, tx)
+		if err != nil {
+			return err
+		}
+
+		err = db.loadMaterializedViews(sqlPrefix, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) loadSynonyms(sqlPrefix []byte, tx *store.OngoingTx) error {
+	synonymReaderSpec := &store.KeyReaderSpec{
+		Prefix: mapKey(sqlPrefix, catalogSynonymPrefix, EncodeID(db.id)),
+	}
+
+	synonymReader, err := tx.NewKeyReader(synonymReaderSpec)
+	if err != nil {
+		return err
+	}
+	defer synonymReader.Close()
+
+	for {
+		mkey, vref, err := synonymReader.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if isDeleted(vref) {
+			continue
+		}
+
+		dbID, name, err := unmapSynonym(sqlPrefix, mkey)
+		if err != nil {
+			return err
+		}
+
+		if dbID != db.id {
+			return ErrCorruptedData
+		}
+
+		v, err := vref.Resolve()
+		if err != nil {
+			return err
+		}
+
+		if len(v) != EncIDLen {
+			return ErrCorruptedData
+		}
+
+		db.synonyms[name] = binary.BigEndian.Uint32(v)
+	}
+
+	return nil
+}
+
+func (db *Database) loadMaterializedViews(sqlPrefix []byte, tx *store.OngoingTx) error {
+	viewReaderSpec := &store.KeyReaderSpec{
+		Prefix: mapKey(sqlPrefix, catalogViewPrefix, EncodeID(db.id)),
+	}
+
+	viewReader, err := tx.NewKeyReader(viewReaderSpec)
+	if err != nil {
+		return err
+	}
+	defer viewReader.Close()
+
+	for {
+		mkey, vref, err := viewReader.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if isDeleted(vref) {
+			continue
+		}
+
+		dbID, tableID, err := unmapViewID(sqlPrefix, mkey)
+		if err != nil {
+			return err
+		}
+
+		if dbID != db.id {
+			return ErrCorruptedData
+		}
+
+		table, err := db.GetTableByID(tableID)
+		if err != nil {
+			return ErrCorruptedData
+		}
+
+		v, err := vref.Resolve()
+		if err != nil {
+			return err
+		}
+
+		if len(v) <= 8 {
+			return ErrCorruptedData
+		}
+
+		table.view = &materializedView{
+			refreshedAt: binary.BigEndian.Uint64(v),
+			sql:         string(v[8:]),
+		}
 	}
 
 	return nil
 }
 
 func (db *Database) loadTables(sqlPrefix []byte, tx *store.OngoingTx) error {
+	// dropped tables are read as well so their ids are not reused
 	dbReaderSpec := &store.KeyReaderSpec{
 		Prefix: mapKey(sqlPrefix, catalogTablePrefix, EncodeID(db.id)),
-		Filter: store.IgnoreDeleted,
 	}
 
 	tableReader, err := tx.NewKeyReader(dbReaderSpec)
@@ -368,6 +724,11 @@ func (db *Database) loadTables(sqlPrefix []byte, tx *store.OngoingTx) error {
 			return ErrCorruptedData
 		}
 
+		if isDeleted(vref) {
+			db.maxTableID = tableID
+			continue
+		}
+
 		colSpecs, err := loadColSpecs(db.id, tableID, tx, sqlPrefix)
 		if err != nil {
 			return err
@@ -392,6 +753,11 @@ func (db *Database) loadTables(sqlPrefix []byte, tx *store.OngoingTx) error {
 			return err
 		}
 
+		err = table.loadChecks(sqlPrefix, tx)
+		if err != nil {
+			return err
+		}
+
 		if table.autoIncrementPK {
 			encMaxPK, err := loadMaxPK(sqlPrefix, tx, table)
 			if err == store.ErrNoMoreEntries {
@@ -419,6 +785,11 @@ func (db *Database) loadTables(sqlPrefix []byte, tx *store.OngoingTx) error {
 	return nil
 }
 
+func isDeleted(vref store.ValueRef) bool {
+	md := vref.KVMetadata()
+	return md != nil && md.Deleted()
+}
+
 func indexKeyFrom(cols []*Column) string {
 	var buf bytes.Buffer
 
@@ -497,6 +868,7 @@ func loadColSpecs(dbID, tableID uint32, tx *store.OngoingTx, sqlPrefix []byte) (
 			maxLen:        int(binary.BigEndian.Uint32(v[1:])),
 			autoIncrement: v[0]&autoIncrementFlag != 0,
 			notNull:       v[0]&nullableFlag != 0,
+			dropped:       v[0]&droppedFlag != 0,
 		}
 
 		specs = append(specs, spec)
@@ -579,6 +951,55 @@ func (table *Table) loadIndexes(sqlPrefix []byte, tx *store.OngoingTx) error {
 	return nil
 }
 
+func (table *Table) loadChecks(sqlPrefix []byte, tx *store.OngoingTx) error {
+	checkReaderSpec := &store.KeyReaderSpec{
+		Prefix: mapKey(sqlPrefix, catalogCheckPrefix, EncodeID(table.db.id), EncodeID(table.id)),
+		Filter: store.IgnoreDeleted,
+	}
+
+	checkReader, err := tx.NewKeyReader(checkReaderSpec)
+	if err != nil {
+		return err
+	}
+	defer checkReader.Close()
+
+	for {
+		mkey, vref, err := checkReader.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		dbID, tableID, _, err := unmapCheck(sqlPrefix, mkey)
+		if err != nil {
+			return err
+		}
+
+		if table.id != tableID || table.db.id != dbID {
+			return ErrCorruptedData
+		}
+
+		v, err := vref.Resolve()
+		if err != nil {
+			return err
+		}
+
+		exp, err := parseCheckExp(string(v))
+		if err != nil {
+			return ErrCorruptedData
+		}
+
+		err = table.addCheck(&checkConstraint{exp: exp, sql: string(v)})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func trimPrefix(prefix, mkey []byte, mappingPrefix []byte) ([]byte, error) {
 	if len(prefix)+len(mappingPrefix) > len(mkey) ||
 		!bytes.Equal(prefix, mkey[:len(prefix)]) ||
@@ -618,6 +1039,35 @@ func unmapTableID(prefix, mkey []byte) (dbID, tableID uint32, err error) {
 	return
 }
 
+func unmapViewID(prefix, mkey []byte) (dbID, tableID uint32, err error) {
+	encID, err := trimPrefix(prefix, mkey, []byte(catalogViewPrefix))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(encID) != EncIDLen*2 {
+		return 0, 0, ErrCorruptedData
+	}
+
+	dbID = binary.BigEndian.Uint32(encID)
+	tableID = binary.BigEndian.Uint32(encID[EncIDLen:])
+
+	return
+}
+
+func unmapSynonym(prefix, mkey []byte) (dbID uint32, name string, err error) {
+	enc, err := trimPrefix(prefix, mkey, []byte(catalogSynonymPrefix))
+	if err != nil {
+		return 0, "", err
+	}
+
+	if len(enc) <= EncIDLen {
+		return 0, "", ErrCorruptedData
+	}
+
+	return binary.BigEndian.Uint32(enc), string(enc[EncIDLen:]), nil
+}
+
 func unmapColSpec(prefix, mkey []byte) (dbID, tableID, colID uint32, colType SQLValueType, err error) {
 	encID, err := trimPrefix(prefix, mkey, []byte(catalogColumnPrefix))
 	if err != nil {
@@ -645,7 +1095,9 @@ func asType(t string) (SQLValueType, error) {
 		t == BooleanType ||
 		t == VarcharType ||
 		t == BLOBType ||
-		t == TimestampType {
+		t == TimestampType ||
+		t == FloatType ||
+		t == DecimalType {
 		return t, nil
 	}
 
@@ -669,6 +1121,23 @@ func unmapIndex(sqlPrefix, mkey []byte) (dbID, tableID, indexID uint32, err erro
 	return
 }
 
+func unmapCheck(sqlPrefix, mkey []byte) (dbID, tableID, checkID uint32, err error) {
+	encID, err := trimPrefix(sqlPrefix, mkey, []byte(catalogCheckPrefix))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	if len(encID) != EncIDLen*3 {
+		return 0, 0, 0, ErrCorruptedData
+	}
+
+	dbID = binary.BigEndian.Uint32(encID)
+	tableID = binary.BigEndian.Uint32(encID[EncIDLen:])
+	checkID = binary.BigEndian.Uint32(encID[EncIDLen*2:])
+
+	return
+}
+
 func unmapIndexEntry(index *Index, sqlPrefix, mkey []byte) (encPKVals []byte, err error) {
 	if index == nil {
 		return nil, ErrIllegalArguments
@@ -730,6 +1199,34 @@ func unmapIndexEntry(index *Index, sqlPrefix, mkey []byte) (encPKVals []byte, er
 	return enc[off:], nil
 }
 
+// DecodePrimaryKey returns the primary key values of the row stored under the specified primary index key
+func (t *Table) DecodePrimaryKey(sqlPrefix, mkey []byte) ([]TypedValue, error) {
+	encPKVals, err := unmapIndexEntry(t.primaryIndex, sqlPrefix, mkey)
+	if err != nil {
+		return nil, err
+	}
+
+	pkVals := make([]TypedValue, len(t.primaryIndex.cols))
+
+	off := 0
+
+	for i, col := range t.primaryIndex.cols {
+		val, n, err := DecodeValueAsKey(encPKVals[off:], col.colType, col.MaxLen())
+		if err != nil {
+			return nil, err
+		}
+
+		pkVals[i] = val
+		off += n
+	}
+
+	if len(encPKVals)-off > 0 {
+		return nil, ErrCorruptedData
+	}
+
+	return pkVals, nil
+}
+
 func variableSized(sqlType SQLValueType) bool {
 	return sqlType == VarcharType || sqlType == BLOBType
 }
@@ -865,6 +1362,44 @@ func EncodeValue(val interface{}, colType SQLValueType, maxLen int) ([]byte, err
 			binary.BigEndian.PutUint32(encv[:], uint32(8))
 			binary.BigEndian.PutUint64(encv[EncLenLen:], uint64(TimeToInt64(timeVal)))
 
+			return encv[:], nil
+		}
+	case FloatType:
+		{
+			floatVal, ok := val.(float64)
+			if !ok || math.IsNaN(floatVal) {
+				return nil, fmt.Errorf(
+					"value is not a float: %w", ErrInvalidValue,
+				)
+			}
+
+			// len(v) + v
+			var encv [EncLenLen + 8]byte
+			binary.BigEndian.PutUint32(encv[:], uint32(8))
+			binary.BigEndian.PutUint64(encv[EncLenLen:], math.Float64bits(floatVal))
+
+			return encv[:], nil
+		}
+	case DecimalType:
+		{
+			decVal, ok := val.(*big.Rat)
+			if !ok {
+				return nil, fmt.Errorf(
+					"value is not a decimal: %w", ErrInvalidValue,
+				)
+			}
+
+			ip, fp, err := decimalToFixed(decVal)
+			if err != nil {
+				return nil, err
+			}
+
+			// len(v) + integer part + fractional part
+			var encv [EncLenLen + 16]byte
+			binary.BigEndian.PutUint32(encv[:], uint32(16))
+			binary.BigEndian.PutUint64(encv[EncLenLen:], uint64(ip))
+			binary.BigEndian.PutUint64(encv[EncLenLen+8:], fp)
+
 			return encv[:], nil
 		}
 	}
@@ -999,12 +1534,180 @@ func EncodeAsKey(val interface{}, colType SQLValueType, maxLen int) ([]byte, err
 
 			return encv[:], nil
 		}
+	case FloatType:
+		{
+			if maxLen != 8 {
+				return nil, ErrCorruptedData
+			}
+
+			floatVal, ok := val.(float64)
+			if !ok || math.IsNaN(floatVal) {
+				return nil, fmt.Errorf(
+					"value is not a float: %w", ErrInvalidValue,
+				)
+			}
 
+			// -0 and +0 are the same key
+			if floatVal == 0 {
+				floatVal = 0
+			}
+
+			// map to unsigned integer space for lexical sorting order,
+			// negative values have all their bits flipped so their order gets reversed
+			bits := math.Float64bits(floatVal)
+			if bits&(1<<63) != 0 {
+				bits = ^bits
+			} else {
+				bits |= 1 << 63
+			}
+
+			// v
+			var encv [9]byte
+			encv[0] = KeyValPrefixNotNull
+			binary.BigEndian.PutUint64(encv[1:], bits)
+
+			return encv[:], nil
+		}
+	case DecimalType:
+		{
+			if maxLen != 16 {
+				return nil, ErrCorruptedData
+			}
+
+			decVal, ok := val.(*big.Rat)
+			if !ok {
+				return nil, fmt.Errorf(
+					"value is not a decimal: %w", ErrInvalidValue,
+				)
+			}
+
+			ip, fp, err := decimalToFixed(decVal)
+			if err != nil {
+				return nil, err
+			}
+
+			// integer part + fractional part, the fractional part is never negative
+			var encv [17]byte
+			encv[0] = KeyValPrefixNotNull
+			binary.BigEndian.PutUint64(encv[1:], uint64(ip))
+			binary.BigEndian.PutUint64(encv[9:], fp)
+			// map to unsigned integer space for lexical sorting order
+			encv[1] ^= 0x80
+
+			return encv[:], nil
+		}
 	}
 
 	return nil, ErrInvalidValue
 }
 
+// DecodeValueAsKey decodes a value encoded with EncodeAsKey, returning the number of bytes read
+func DecodeValueAsKey(b []byte, colType SQLValueType, maxLen int) (TypedValue, int, error) {
+	if len(b) < 1 {
+		return nil, 0, ErrCorruptedData
+	}
+
+	if b[0] == KeyValPrefixNull {
+		return &NullValue{t: colType}, 1, nil
+	}
+
+	if b[0] != KeyValPrefixNotNull {
+		return nil, 0, ErrCorruptedData
+	}
+
+	switch colType {
+	case VarcharType, BLOBType:
+		{
+			if len(b) < 1+maxLen+EncLenLen {
+				return nil, 0, ErrCorruptedData
+			}
+
+			vlen := int(binary.BigEndian.Uint32(b[1+maxLen:]))
+			if vlen > maxLen {
+				return nil, 0, ErrCorruptedData
+			}
+
+			v := make([]byte, vlen)
+			copy(v, b[1:])
+
+			if colType == VarcharType {
+				return &Varchar{val: string(v)}, 1 + maxLen + EncLenLen, nil
+			}
+
+			return &Blob{val: v}, 1 + maxLen + EncLenLen, nil
+		}
+	case IntegerType, TimestampType:
+		{
+			if len(b) < 9 {
+				return nil, 0, ErrCorruptedData
+			}
+
+			var encv [8]byte
+			copy(encv[:], b[1:])
+
+			// map to signed integer space
+			encv[0] ^= 0x80
+
+			v := int64(binary.BigEndian.Uint64(encv[:]))
+
+			if colType == IntegerType {
+				return &Number{val: v}, 9, nil
+			}
+
+			return &Timestamp{val: time.Unix(0, v).UTC()}, 9, nil
+		}
+	case BooleanType:
+		{
+			if len(b) < 2 {
+				return nil, 0, ErrCorruptedData
+			}
+
+			return &Bool{val: b[1] == 1}, 2, nil
+		}
+	case FloatType:
+		{
+			if len(b) < 9 {
+				return nil, 0, ErrCorruptedData
+			}
+
+			bits := binary.BigEndian.Uint64(b[1:])
+			if bits&(1<<63) != 0 {
+				bits &^= 1 << 63
+			} else {
+				bits = ^bits
+			}
+
+			floatVal := math.Float64frombits(bits)
+
+			// NaN values are never encoded
+			if math.IsNaN(floatVal) {
+				return nil, 0, ErrCorruptedData
+			}
+
+			return &Float{val: floatVal}, 9, nil
+		}
+	case DecimalType:
+		{
+			if len(b) < 17 {
+				return nil, 0, ErrCorruptedData
+			}
+
+			var encv [8]byte
+			copy(encv[:], b[1:])
+
+			// map to signed integer space
+			encv[0] ^= 0x80
+
+			ip := int64(binary.BigEndian.Uint64(encv[:]))
+			fp := binary.BigEndian.Uint64(b[9:])
+
+			return &Decimal{val: decimalFromFixed(ip, fp)}, 17, nil
+		}
+	}
+
+	return nil, 0, ErrCorruptedData
+}
+
 func DecodeValue(b []byte, colType SQLValueType) (TypedValue, int, error) {
 	if len(b) < EncLenLen {
 		return nil, 0, ErrCorruptedData
@@ -1017,57 +1720,81 @@ func DecodeValue(b []byte, colType SQLValueType) (TypedValue, int, error) {
 		return nil, 0, ErrCorruptedData
 	}
 
+	v, err := decodeValuePayload(b[voff:voff+vlen], colType)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return v, voff + vlen, nil
+}
+
+func decodeValuePayload(b []byte, colType SQLValueType) (TypedValue, error) {
 	switch colType {
 	case VarcharType:
 		{
-			v := string(b[voff : voff+vlen])
-			voff += vlen
-
-			return &Varchar{val: v}, voff, nil
+			return &Varchar{val: string(b)}, nil
 		}
 	case IntegerType:
 		{
-			if vlen != 8 {
-				return nil, 0, ErrCorruptedData
+			if len(b) != 8 {
+				return nil, ErrCorruptedData
 			}
 
-			v := binary.BigEndian.Uint64(b[voff:])
-			voff += vlen
+			v := binary.BigEndian.Uint64(b)
 
-			return &Number{val: int64(v)}, voff, nil
+			return &Number{val: int64(v)}, nil
 		}
 	case BooleanType:
 		{
-			if vlen != 1 {
-				return nil, 0, ErrCorruptedData
+			if len(b) != 1 {
+				return nil, ErrCorruptedData
 			}
 
-			v := b[voff] == 1
-			voff += 1
-
-			return &Bool{val: v}, voff, nil
+			return &Bool{val: b[0] == 1}, nil
 		}
 	case BLOBType:
 		{
-			v := b[voff : voff+vlen]
-			voff += vlen
-
-			return &Blob{val: v}, voff, nil
+			return &Blob{val: b}, nil
 		}
 	case TimestampType:
 		{
-			if vlen != 8 {
-				return nil, 0, ErrCorruptedData
+			if len(b) != 8 {
+				return nil, ErrCorruptedData
+			}
+
+			v := binary.BigEndian.Uint64(b)
+
+			return &Timestamp{val: TimeFromInt64(int64(v))}, nil
+		}
+	case FloatType:
+		{
+			if len(b) != 8 {
+				return nil, ErrCorruptedData
 			}
 
-			v := binary.BigEndian.Uint64(b[voff:])
-			voff += vlen
+			floatVal := math.Float64frombits(binary.BigEndian.Uint64(b))
+
+			// NaN values are never encoded
+			if math.IsNaN(floatVal) {
+				return nil, ErrCorruptedData
+			}
 
-			return &Timestamp{val: TimeFromInt64(int64(v))}, voff, nil
+			return &Float{val: floatVal}, nil
+		}
+	case DecimalType:
+		{
+			if len(b) != 16 {
+				return nil, ErrCorruptedData
+			}
+
+			ip := int64(binary.BigEndian.Uint64(b))
+			fp := binary.BigEndian.Uint64(b[8:])
+
+			return &Decimal{val: decimalFromFixed(ip, fp)}, nil
 		}
 	}
 
-	return nil, 0, ErrCorruptedData
+	return nil, ErrCorruptedData
 }
 
 func normalizeParams(params map[string]interface{}) (map[string]interface{}, error) {
@@ -1087,20 +1814,48 @@ func normalizeParams(params map[string]interface{}) (map[string]interface{}, err
 	return nparams, nil
 }
 
-func (e *Engine) Exec(sql string, params map[string]interface{}, tx *SQLTx) (ntx *SQLTx, committedTxs []*SQLTx, err error) {
-	stmts, err := Parse(strings.NewReader(sql))
+func (e *Engine) Exec(ctx context.Context, sql string, params map[string]interface{}, tx *SQLTx) (ntx *SQLTx, committedTxs []*SQLTx, err error) {
+	stmts, err := e.parse(sql)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return e.ExecPreparedStmts(stmts, params, tx)
+	return e.ExecPreparedStmts(ctx, stmts, params, tx)
 }
 
-func (e *Engine) ExecPreparedStmts(stmts []SQLStmt, params map[string]interface{}, tx *SQLTx) (ntx *SQLTx, committedTxs []*SQLTx, err error) {
-	if len(stmts) == 0 {
+func (e *Engine) ExecPreparedStmts(ctx context.Context, stmts []SQLStmt, params map[string]interface{}, tx *SQLTx) (ntx *SQLTx, committedTxs []*SQLTx, err error) {
+	return e.ExecPreparedStmtsWithMetadata(ctx, stmts, params, nil, tx)
+}
+
+// ExecStmt executes the statement once the supplied parameters are checked against the types
+// inferred from it, thus a missing or mistyped parameter is reported before anything gets executed
+func (e *Engine) ExecStmt(ctx context.Context, stmt SQLStmt, params map[string]interface{}, tx *SQLTx) (ntx *SQLTx, committedTxs []*SQLTx, err error) {
+	if stmt == nil {
+		return nil, nil, ErrIllegalArguments
+	}
+
+	err = e.checkParameters(ctx, []SQLStmt{stmt}, params, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return e.ExecPreparedStmts(ctx, []SQLStmt{stmt}, params, tx)
+}
+
+// ExecPreparedStmtsWithMetadata is the same as ExecPreparedStmts but the metadata
+// is attached to every transaction the statements are executed in
+func (e *Engine) ExecPreparedStmtsWithMetadata(ctx context.Context, stmts []SQLStmt, params map[string]interface{}, txmd *store.TxMetadata, tx *SQLTx) (ntx *SQLTx, committedTxs []*SQLTx, err error) {
+	if ctx == nil || len(stmts) == 0 {
 		return nil, nil, ErrIllegalArguments
 	}
 
+	for _, stmt := range stmts {
+		err = e.checkLimits(stmt)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// TODO: eval params at once
 	nparams, err := normalizeParams(params)
 	if err != nil {
@@ -1116,13 +1871,20 @@ func (e *Engine) ExecPreparedStmts(stmts []SQLStmt, params map[string]interface{
 
 		if currTx == nil || currTx.closed {
 			// begin tx with implicit commit
-			currTx, err = e.newTx(false)
+			currTx, err = e.newTx(ctx, false)
 			if err != nil {
 				return nil, committedTxs, err
 			}
 		}
 
+		if txmd != nil {
+			currTx.tx.WithMetadata(txmd)
+		}
+
+		restore := currTx.withContext(ctx)
+
 		ntx, err := stmt.execAt(currTx, nparams)
+		restore()
 		if err != nil {
 			currTx.Cancel()
 			return nil, committedTxs, err
@@ -1156,8 +1918,8 @@ func (e *Engine) ExecPreparedStmts(stmts []SQLStmt, params map[string]interface{
 	return currTx, committedTxs, nil
 }
 
-func (e *Engine) Query(sql string, params map[string]interface{}, tx *SQLTx) (RowReader, error) {
-	stmts, err := Parse(strings.NewReader(sql))
+func (e *Engine) Query(ctx context.Context, sql string, params map[string]interface{}, tx *SQLTx) (RowReader, error) {
+	stmts, err := e.parse(sql)
 	if err != nil {
 		return nil, err
 	}
@@ -1170,23 +1932,58 @@ func (e *Engine) Query(sql string, params map[string]interface{}, tx *SQLTx) (Ro
 		return nil, ErrExpectingDQLStmt
 	}
 
-	return e.QueryPreparedStmt(stmt, params, tx)
+	return e.QueryPreparedStmt(ctx, stmt, params, tx)
 }
 
-func (e *Engine) QueryPreparedStmt(stmt *SelectStmt, params map[string]interface{}, tx *SQLTx) (rowReader RowReader, err error) {
+// QueryStmt is the same as ExecStmt but for queries, the parameters are checked before the reader is built
+func (e *Engine) QueryStmt(ctx context.Context, stmt *SelectStmt, params map[string]interface{}, tx *SQLTx) (rowReader RowReader, err error) {
 	if stmt == nil {
 		return nil, ErrIllegalArguments
 	}
 
+	err = e.checkParameters(ctx, []SQLStmt{stmt}, params, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.QueryPreparedStmt(ctx, stmt, params, tx)
+}
+
+// QueryPreparedStmt returns a reader which fails with ErrCancellationRequested as soon as ctx is done
+func (e *Engine) QueryPreparedStmt(ctx context.Context, stmt *SelectStmt, params map[string]interface{}, tx *SQLTx) (rowReader RowReader, err error) {
+	if ctx == nil || stmt == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	err = e.checkLimits(stmt)
+	if err != nil {
+		return nil, err
+	}
+
 	qtx := tx
 
 	if qtx == nil {
-		qtx, err = e.newTx(false)
+		qtx, err = e.newTx(ctx, false)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	restore := qtx.withContext(ctx)
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		restore()
+
+		// the transaction created to read the rows is not released by any reader
+		if tx == nil {
+			qtx.Cancel()
+		}
+	}()
+
 	// TODO: eval params at once
 	nparams, err := normalizeParams(params)
 	if err != nil {
@@ -1207,16 +2004,18 @@ func (e *Engine) QueryPreparedStmt(stmt *SelectStmt, params map[string]interface
 		r.onClose(func() {
 			qtx.Cancel()
 		})
+	} else {
+		r.onClose(restore)
 	}
 
 	return r, nil
 }
 
-func (e *Engine) Catalog(tx *SQLTx) (catalog *Catalog, err error) {
+func (e *Engine) Catalog(ctx context.Context, tx *SQLTx) (catalog *Catalog, err error) {
 	qtx := tx
 
 	if qtx == nil {
-		qtx, err = e.newTx(false)
+		qtx, err = e.newTx(ctx, false)
 		if err != nil {
 			return nil, err
 		}
@@ -1226,16 +2025,16 @@ func (e *Engine) Catalog(tx *SQLTx) (catalog *Catalog, err error) {
 	return qtx.catalog, nil
 }
 
-func (e *Engine) InferParameters(sql string, tx *SQLTx) (params map[string]SQLValueType, err error) {
-	stmts, err := Parse(strings.NewReader(sql))
+func (e *Engine) InferParameters(ctx context.Context, sql string, tx *SQLTx) (params map[string]SQLValueType, err error) {
+	stmts, err := e.parse(sql)
 	if err != nil {
 		return nil, err
 	}
 
-	return e.InferParametersPreparedStmts(stmts, tx)
+	return e.InferParametersPreparedStmts(ctx, stmts, tx)
 }
 
-func (e *Engine) InferParametersPreparedStmts(stmts []SQLStmt, tx *SQLTx) (params map[string]SQLValueType, err error) {
+func (e *Engine) InferParametersPreparedStmts(ctx context.Context, stmts []SQLStmt, tx *SQLTx) (params map[string]SQLValueType, err error) {
 	if len(stmts) == 0 {
 		return nil, ErrIllegalArguments
 	}
@@ -1243,7 +2042,7 @@ func (e *Engine) InferParametersPreparedStmts(stmts []SQLStmt, tx *SQLTx) (param
 	qtx := tx
 
 	if qtx == nil {
-		qtx, err = e.newTx(false)
+		qtx, err = e.newTx(ctx, false)
 		if err != nil {
 			return nil, err
 		}
@@ -1261,3 +2060,38 @@ func (e *Engine) InferParametersPreparedStmts(stmts []SQLStmt, tx *SQLTx) (param
 
 	return params, nil
 }
+
+// checkParameters fails when a parameter used by the statements is not supplied or when
+// its value is not of the inferred type. Null values are accepted for any type
+func (e *Engine) checkParameters(ctx context.Context, stmts []SQLStmt, params map[string]interface{}, tx *SQLTx) error {
+	types, err := e.InferParametersPreparedStmts(ctx, stmts, tx)
+	if err != nil {
+		return err
+	}
+
+	nparams, err := normalizeParams(params)
+	if err != nil {
+		return err
+	}
+
+	for name, t := range types {
+		val, err := (&Param{id: name}).substitute(nparams)
+		if errors.Is(err, ErrMissingParameter) {
+			return fmt.Errorf("%w (%s)", ErrMissingParameter, name)
+		}
+		if err != nil {
+			return err
+		}
+
+		tval, ok := val.(TypedValue)
+		if !ok || tval.IsNull() || t == AnyType {
+			continue
+		}
+
+		if tval.Type() != t {
+			return fmt.Errorf("%w (parameter '%s' expecting %s value)", ErrInvalidValue, name, t)
+		}
+	}
+
+	return nil
+}
@@ -16,15 +16,19 @@ limitations under the License.
 package sql
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/codenotary/immudb/embedded/logger"
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/stretchr/testify/require"
 )
@@ -38,14 +42,15 @@ func TestCreateDatabase(t *testing.T) {
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.Equal(t, ErrDatabaseAlreadyExists, err)
 
-	_, _, err = engine.Exec("CREATE DATABASE db2", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db2", nil, nil)
 	require.NoError(t, err)
 }
 
@@ -56,14 +61,15 @@ func TestUseDatabase(t *testing.T) {
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("USE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "USE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("USE DATABASE db2", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "USE DATABASE db2", nil, nil)
 	require.Equal(t, ErrDatabaseDoesNotExist, err)
 }
 
@@ -74,38 +80,39 @@ func TestCreateTable(t *testing.T) {
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", nil, nil)
 	require.Equal(t, ErrNoDatabaseSelected, err)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("USE DATABASE db1; CREATE TABLE table1 (name VARCHAR, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "USE DATABASE db1; CREATE TABLE table1 (name VARCHAR, PRIMARY KEY id)", nil, nil)
 	require.Equal(t, ErrColumnDoesNotExist, err)
 
-	_, _, err = engine.Exec("USE DATABASE db1; CREATE TABLE table1 (name VARCHAR, PRIMARY KEY name)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "USE DATABASE db1; CREATE TABLE table1 (name VARCHAR, PRIMARY KEY name)", nil, nil)
 	require.ErrorIs(t, err, ErrLimitedKeyType)
 
-	_, _, err = engine.Exec("USE DATABASE db1; CREATE TABLE table1 (name VARCHAR[512], PRIMARY KEY name)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "USE DATABASE db1; CREATE TABLE table1 (name VARCHAR[512], PRIMARY KEY name)", nil, nil)
 	require.ErrorIs(t, err, ErrLimitedKeyType)
 
-	_, _, err = engine.Exec("USE DATABASE db1; CREATE TABLE table1 (name VARCHAR[32], PRIMARY KEY name)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "USE DATABASE db1; CREATE TABLE table1 (name VARCHAR[32], PRIMARY KEY name)", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table2 (id INTEGER, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table2 (id INTEGER, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", nil, nil)
 	require.Equal(t, ErrTableAlreadyExists, err)
 
-	_, _, err = engine.Exec("CREATE TABLE IF NOT EXISTS table1 (id INTEGER, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE IF NOT EXISTS table1 (id INTEGER, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE IF NOT EXISTS blob_table (id BLOB[2], PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE IF NOT EXISTS blob_table (id BLOB[2], PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 }
 
@@ -117,14 +124,15 @@ func TestTimestampType(t *testing.T) {
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE IF NOT EXISTS timestamp_table (id INTEGER AUTO_INCREMENT, ts TIMESTAMP, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE IF NOT EXISTS timestamp_table (id INTEGER AUTO_INCREMENT, ts TIMESTAMP, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
 	sel := EncodeSelector("", "db1", "timestamp_table", "ts")
@@ -132,15 +140,15 @@ func TestTimestampType(t *testing.T) {
 	t.Run("must accept NOW() as a timestamp", func(t *testing.T) {
 		tsBefore := time.Now().UTC()
 
-		_, _, err = engine.Exec("INSERT INTO timestamp_table(ts) VALUES(NOW())", nil, nil)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO timestamp_table(ts) VALUES(NOW())", nil, nil)
 		require.NoError(t, err)
 
 		tsAfter := time.Now().UTC()
 
-		_, err := engine.InferParameters("SELECT ts FROM timestamp_table WHERE ts < 1 + NOW()", nil)
+		_, err := engine.InferParameters(context.Background(), "SELECT ts FROM timestamp_table WHERE ts < 1 + NOW()", nil)
 		require.ErrorIs(t, err, ErrInvalidTypes)
 
-		r, err := engine.Query("SELECT ts FROM timestamp_table WHERE ts < NOW() ORDER BY id DESC LIMIT 1", nil, nil)
+		r, err := engine.Query(context.Background(), "SELECT ts FROM timestamp_table WHERE ts < NOW() ORDER BY id DESC LIMIT 1", nil, nil)
 		require.NoError(t, err)
 		defer r.Close()
 
@@ -152,7 +160,7 @@ func TestTimestampType(t *testing.T) {
 	})
 
 	t.Run("must accept time.Time as timestamp parameter", func(t *testing.T) {
-		_, _, err = engine.Exec(
+		_, _, err = engine.Exec(context.Background(),
 			"INSERT INTO timestamp_table(ts) VALUES(@ts)", map[string]interface{}{
 				"ts": time.Date(2021, 12, 1, 18, 06, 14, 0, time.UTC),
 			},
@@ -160,7 +168,7 @@ func TestTimestampType(t *testing.T) {
 		)
 		require.NoError(t, err)
 
-		r, err := engine.Query("SELECT ts FROM timestamp_table ORDER BY id DESC LIMIT 1", nil, nil)
+		r, err := engine.Query(context.Background(), "SELECT ts FROM timestamp_table ORDER BY id DESC LIMIT 1", nil, nil)
 		require.NoError(t, err)
 		defer r.Close()
 
@@ -171,7 +179,7 @@ func TestTimestampType(t *testing.T) {
 	})
 
 	t.Run("must correctly validate timestamp equality", func(t *testing.T) {
-		_, _, err = engine.Exec(
+		_, _, err = engine.Exec(context.Background(),
 			"INSERT INTO timestamp_table(ts) VALUES(@ts)", map[string]interface{}{
 				"ts": time.Date(2021, 12, 6, 10, 14, 0, 0, time.UTC),
 			},
@@ -179,7 +187,7 @@ func TestTimestampType(t *testing.T) {
 		)
 		require.NoError(t, err)
 
-		r, err := engine.Query("SELECT ts FROM timestamp_table WHERE ts = @ts ORDER BY id", map[string]interface{}{
+		r, err := engine.Query(context.Background(), "SELECT ts FROM timestamp_table WHERE ts = @ts ORDER BY id", map[string]interface{}{
 			"ts": time.Date(2021, 12, 6, 10, 14, 0, 0, time.UTC),
 		}, nil)
 		require.NoError(t, err)
@@ -195,7 +203,7 @@ func TestTimestampType(t *testing.T) {
 		err = r.Close()
 		require.NoError(t, err)
 
-		r, err = engine.Query("SELECT ts FROM timestamp_table WHERE ts = @ts ORDER BY id", map[string]interface{}{
+		r, err = engine.Query(context.Background(), "SELECT ts FROM timestamp_table WHERE ts = @ts ORDER BY id", map[string]interface{}{
 			"ts": "2021-12-06 10:14",
 		}, nil)
 		require.NoError(t, err)
@@ -216,25 +224,26 @@ func TestTimestampIndex(t *testing.T) {
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE IF NOT EXISTS timestamp_index (id INTEGER AUTO_INCREMENT, ts TIMESTAMP, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE IF NOT EXISTS timestamp_index (id INTEGER AUTO_INCREMENT, ts TIMESTAMP, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON timestamp_index(ts)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON timestamp_index(ts)", nil, nil)
 	require.NoError(t, err)
 
 	for i := 100; i > 0; i-- {
-		_, _, err = engine.Exec("INSERT INTO timestamp_index(ts) VALUES(@ts)", map[string]interface{}{"ts": time.Unix(int64(i), 0)}, nil)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO timestamp_index(ts) VALUES(@ts)", map[string]interface{}{"ts": time.Unix(int64(i), 0)}, nil)
 		require.NoError(t, err)
 	}
 
-	r, err := engine.Query("SELECT * FROM timestamp_index ORDER BY ts", nil, nil)
+	r, err := engine.Query(context.Background(), "SELECT * FROM timestamp_index ORDER BY ts", nil, nil)
 	require.NoError(t, err)
 	defer r.Close()
 
@@ -248,6 +257,90 @@ func TestTimestampIndex(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoMoreRows)
 }
 
+func TestTimestampLiterals(t *testing.T) {
+	st, err := store.Open("timestamp_literals", store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
+	defer os.RemoveAll("timestamp_literals")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE events (ts TIMESTAMP, name VARCHAR[32], at TIMESTAMP, PRIMARY KEY ts);
+		CREATE INDEX ON events(at);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		INSERT INTO events(ts, name, at) VALUES
+			(TIMESTAMP '2021-12-03 16:14:21.1234', 'second', TIMESTAMP '2021-12-03T16:14:21Z'),
+			(TIMESTAMP '2021-12-03', 'first', TIMESTAMP '2021-12-05 10:00'),
+			(TIMESTAMP '2021-12-04 09:00', 'third', TIMESTAMP '2021-12-01'),
+			(TIMESTAMP '1969-12-31 23:59:59', 'before epoch', TIMESTAMP '2021-12-02T01:00:00+02:00')
+	`, nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO events(ts, name) VALUES (TIMESTAMP '2021-13-01', 'invalid')", nil, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	queryNames := func(t *testing.T, q string) []string {
+		r, err := engine.Query(context.Background(), q, nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var names []string
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			names = append(names, row.Values[EncodeSelector("", "db1", "events", "name")].Value().(string))
+		}
+
+		return names
+	}
+
+	t.Run("primary key must be ordered by time", func(t *testing.T) {
+		require.Equal(t, []string{"before epoch", "first", "second", "third"}, queryNames(t, "SELECT ts, name FROM events"))
+		require.Equal(t, []string{"third", "second", "first", "before epoch"}, queryNames(t, "SELECT ts, name FROM events ORDER BY ts DESC"))
+	})
+
+	t.Run("primary key must be filtered by time", func(t *testing.T) {
+		require.Equal(t, []string{"first", "second"}, queryNames(t,
+			"SELECT ts, name FROM events WHERE ts >= TIMESTAMP '2021-12-03' AND ts < TIMESTAMP '2021-12-04'"))
+
+		require.Equal(t, []string{"second"}, queryNames(t,
+			"SELECT ts, name FROM events WHERE ts = TIMESTAMP '2021-12-03 16:14:21.1234'"))
+
+		require.Equal(t, []string{"before epoch"}, queryNames(t,
+			"SELECT ts, name FROM events WHERE ts < TIMESTAMP '1970-01-01'"))
+	})
+
+	t.Run("index must be ordered and filtered by time", func(t *testing.T) {
+		require.Equal(t, []string{"third", "before epoch", "second", "first"}, queryNames(t,
+			"SELECT at, name FROM events ORDER BY at"))
+
+		require.Equal(t, []string{"before epoch", "second"}, queryNames(t,
+			"SELECT at, name FROM events WHERE at > TIMESTAMP '2021-12-01' AND at <= TIMESTAMP '2021-12-03 16:14:21' ORDER BY at"))
+	})
+
+	t.Run("timestamp literals must not be compared with other types", func(t *testing.T) {
+		_, err := engine.InferParameters(context.Background(), "SELECT ts FROM events WHERE name = TIMESTAMP '2021-12-03'", nil)
+		require.ErrorIs(t, err, ErrInvalidTypes)
+	})
+}
+
 func TestTimestampCasts(t *testing.T) {
 	st, err := store.Open("timestamp_casts", store.DefaultOptions())
 	require.NoError(t, err)
@@ -256,14 +349,15 @@ func TestTimestampCasts(t *testing.T) {
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE IF NOT EXISTS timestamp_table (id INTEGER AUTO_INCREMENT, ts TIMESTAMP, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE IF NOT EXISTS timestamp_table (id INTEGER AUTO_INCREMENT, ts TIMESTAMP, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
 	sel := EncodeSelector("", "db1", "timestamp_table", "ts")
@@ -277,11 +371,11 @@ func TestTimestampCasts(t *testing.T) {
 		{"2021-12-03", time.Date(2021, 12, 03, 0, 0, 0, 0, time.UTC)},
 	} {
 		t.Run(fmt.Sprintf("insert a timestamp value using a cast from '%s'", d.str), func(t *testing.T) {
-			_, _, err = engine.Exec(
+			_, _, err = engine.Exec(context.Background(),
 				fmt.Sprintf("INSERT INTO timestamp_table(ts) VALUES(CAST('%s' AS TIMESTAMP))", d.str), nil, nil)
 			require.NoError(t, err)
 
-			r, err := engine.Query("SELECT ts FROM timestamp_table ORDER BY id DESC LIMIT 1", nil, nil)
+			r, err := engine.Query(context.Background(), "SELECT ts FROM timestamp_table ORDER BY id DESC LIMIT 1", nil, nil)
 			require.NoError(t, err)
 			defer r.Close()
 
@@ -293,11 +387,11 @@ func TestTimestampCasts(t *testing.T) {
 	}
 
 	t.Run("insert a timestamp value using a cast from INTEGER", func(t *testing.T) {
-		_, _, err = engine.Exec(
+		_, _, err = engine.Exec(context.Background(),
 			"INSERT INTO timestamp_table(ts) VALUES(CAST(123456 AS TIMESTAMP))", nil, nil)
 		require.NoError(t, err)
 
-		r, err := engine.Query("SELECT ts FROM timestamp_table ORDER BY id DESC LIMIT 1", nil, nil)
+		r, err := engine.Query(context.Background(), "SELECT ts FROM timestamp_table ORDER BY id DESC LIMIT 1", nil, nil)
 		require.NoError(t, err)
 		defer r.Close()
 
@@ -308,29 +402,29 @@ func TestTimestampCasts(t *testing.T) {
 	})
 
 	t.Run("test casting from null values", func(t *testing.T) {
-		_, _, err = engine.Exec(`
+		_, _, err = engine.Exec(context.Background(), `
 			CREATE TABLE IF NOT EXISTS values_table (id INTEGER AUTO_INCREMENT, ts TIMESTAMP, str VARCHAR, i INTEGER, PRIMARY KEY id);
 			INSERT INTO values_table(ts, str,i) VALUES(NOW(), NULL, NULL);
 		`, nil, nil)
 		require.NoError(t, err)
 
-		_, _, err = engine.Exec(`
+		_, _, err = engine.Exec(context.Background(), `
 			UPDATE values_table SET ts = CAST(str AS TIMESTAMP);
 		`, nil, nil)
 		require.NoError(t, err)
 
-		_, _, err = engine.Exec(`
+		_, _, err = engine.Exec(context.Background(), `
 			UPDATE values_table SET ts = CAST(i AS TIMESTAMP);
 		`, nil, nil)
 		require.NoError(t, err)
 	})
 
 	t.Run("test casting invalid string", func(t *testing.T) {
-		_, _, err = engine.Exec("INSERT INTO timestamp_table(ts) VALUES(CAST('not a datetime' AS TIMESTAMP))", nil, nil)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO timestamp_table(ts) VALUES(CAST('not a datetime' AS TIMESTAMP))", nil, nil)
 		require.ErrorIs(t, err, ErrIllegalArguments)
 		require.Contains(t, err.Error(), "can not cast")
 
-		_, _, err = engine.Exec("INSERT INTO timestamp_table(ts) VALUES(CAST(@ts AS TIMESTAMP))", map[string]interface{}{
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO timestamp_table(ts) VALUES(CAST(@ts AS TIMESTAMP))", map[string]interface{}{
 			"ts": strings.Repeat("long string ", 1000),
 		}, nil)
 		require.ErrorIs(t, err, ErrIllegalArguments)
@@ -339,2234 +433,2138 @@ func TestTimestampCasts(t *testing.T) {
 		require.Contains(t, err.Error(), "...")
 	})
 
-}
-
-func TestAddColumn(t *testing.T) {
-	st, err := store.Open("sqldata_add_column", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_add_column")
-
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
-
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", nil, nil)
-	require.Equal(t, ErrNoDatabaseSelected, err)
-
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+	t.Run("project casted values", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT CAST(id AS VARCHAR), CAST(ts AS VARCHAR) AS ts_str FROM timestamp_table WHERE id = 1", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 2)
+		require.Equal(t, VarcharType, cols[0].Type)
+		require.Equal(t, "ts_str", cols[1].Column)
+		require.Equal(t, VarcharType, cols[1].Type)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (name VARCHAR, PRIMARY KEY id)", nil, nil)
-	require.Equal(t, ErrColumnDoesNotExist, err)
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, "1", row.Values[EncodeSelector("", "db1", "timestamp_table", "col0")].Value())
+		require.Equal(t, "2021-12-03 16:14:21.1234", row.Values[EncodeSelector("", "db1", "timestamp_table", "ts_str")].Value())
 
-	_, _, err = engine.Exec("ALTER TABLE table1 ADD COLUMN surname VARCHAR", nil, nil)
-	require.Equal(t, ErrNoSupported, err)
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
 }
 
-func TestCreateIndex(t *testing.T) {
-	st, err := store.Open("sqldata_create_index", store.DefaultOptions())
+func TestFloatAndDecimalTypes(t *testing.T) {
+	st, err := store.Open("float_decimal", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_create_index")
+	defer st.Close()
+	defer os.RemoveAll("float_decimal")
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, name VARCHAR[256], age INTEGER, active BOOLEAN, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE readings (id INTEGER AUTO_INCREMENT, temp FLOAT, amount DECIMAL, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(name)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON readings(temp)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX IF NOT EXISTS ON table1(name)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON readings(amount)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(name)", nil, nil)
-	require.Equal(t, ErrIndexAlreadyExists, err)
-
-	_, _, err = engine.Exec("CREATE INDEX ON table1(id)", nil, nil)
-	require.Equal(t, ErrIndexAlreadyExists, err)
+	tempSel := EncodeSelector("", "db1", "readings", "temp")
+	amountSel := EncodeSelector("", "db1", "readings", "amount")
 
-	_, _, err = engine.Exec("CREATE UNIQUE INDEX IF NOT EXISTS ON table1(id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(temp, amount) VALUES (21.5, CAST('10.25' AS DECIMAL))", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(age)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(temp, amount) VALUES (-3.75, CAST(-0.1 AS DECIMAL))", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(name)", nil, nil)
-	require.Equal(t, ErrIndexAlreadyExists, err)
-
-	_, _, err = engine.Exec("CREATE INDEX ON table2(name)", nil, nil)
-	require.Equal(t, ErrTableDoesNotExist, err)
-
-	_, _, err = engine.Exec("CREATE INDEX ON table1(title)", nil, nil)
-	require.Equal(t, ErrColumnDoesNotExist, err)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(temp, amount) VALUES (@temp, @amount)", map[string]interface{}{
+		"temp":   float64(0),
+		"amount": big.NewRat(-21, 2),
+	}, nil)
+	require.NoError(t, err)
 
-	_, _, err = engine.Exec("INSERT INTO table1(id, name, age) VALUES (1, 'name1', 50)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(temp, amount) VALUES (@temp, @amount)", map[string]interface{}{
+		"temp":   float32(100.25),
+		"amount": big.NewRat(1, 3),
+	}, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("INSERT INTO table1(name, age) VALUES ('name2', 10)", nil, nil)
-	require.ErrorIs(t, err, ErrPKCanNotBeNull)
+	t.Run("float index must be scanned in order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT temp FROM readings ORDER BY temp", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(active)", nil, nil)
-	require.Equal(t, ErrLimitedIndexCreation, err)
-}
+		for _, expected := range []float64{-3.75, 0, 21.5, 100.25} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, FloatType, row.Values[tempSel].Type())
+			require.Equal(t, expected, row.Values[tempSel].Value())
+		}
 
-func TestUpsertInto(t *testing.T) {
-	st, err := store.Open("sqldata_upsert", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_upsert")
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+	t.Run("decimal index must be scanned in order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT amount FROM readings ORDER BY amount DESC", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+		for _, expected := range []string{"10.25", "0.333333333333333333", "-0.1", "-10.5"} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, DecimalType, row.Values[amountSel].Type())
+			require.Equal(t, expected, FormatDecimal(row.Values[amountSel].Value().(*big.Rat)))
+		}
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, nil)
-	require.ErrorIs(t, err, ErrNoDatabaseSelected)
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+	t.Run("range scans must be supported", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id FROM readings WHERE temp >= 0.0 AND temp < 50.0 ORDER BY temp", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, nil)
-	require.Equal(t, ErrTableDoesNotExist, err)
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(3), row.Values[EncodeSelector("", "db1", "readings", "id")].Value())
 
-	_, _, err = engine.Exec(`CREATE TABLE table1 (
-								id INTEGER,
-								title VARCHAR,
-								amount INTEGER,
-								active BOOLEAN NOT NULL,
-								PRIMARY KEY id)`, nil, nil)
-	require.NoError(t, err)
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "readings", "id")].Value())
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(active)", nil, nil)
-	require.NoError(t, err)
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
 
-	_, _, err = engine.Exec("CREATE UNIQUE INDEX ON table1(amount, active)", nil, nil)
-	require.NoError(t, err)
+		r, err = engine.Query(context.Background(), "SELECT id FROM readings WHERE amount < @upper ORDER BY amount", map[string]interface{}{"upper": big.NewRat(0, 1)}, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, nil)
-	require.ErrorIs(t, err, ErrNotNullableColumnCannotBeNull)
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(3), row.Values[EncodeSelector("", "db1", "readings", "id")].Value())
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, age) VALUES (1, 50)", nil, nil)
-	require.Equal(t, ErrColumnDoesNotExist, err)
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(2), row.Values[EncodeSelector("", "db1", "readings", "id")].Value())
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title, active) VALUES (@id, 'title1', true)", nil, nil)
-	require.Equal(t, ErrMissingParameter, err)
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
 
-	params := make(map[string]interface{}, 1)
-	params["id"] = [4]byte{1, 2, 3, 4}
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title, active) VALUES (@id, 'title1', true)", params, nil)
-	require.Equal(t, ErrUnsupportedParameter, err)
+	idSel := EncodeSelector("", "db1", "readings", "id")
 
-	params = make(map[string]interface{}, 1)
-	params["id"] = []byte{1, 2, 3}
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title, active) VALUES (@id, 'title1', true)", params, nil)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Contains(t, err.Error(), "is not an integer")
+	queryIDs := func(q string) []int64 {
+		r, err := engine.Query(context.Background(), q, nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title, active) VALUES (1, @title, false)", nil, nil)
-	require.Equal(t, ErrMissingParameter, err)
+		var ids []int64
 
-	params = make(map[string]interface{}, 1)
-	params["title"] = uint64(1)
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title, active) VALUES (1, @title, true)", params, nil)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Contains(t, err.Error(), "is not a string")
+		for {
+			row, err := r.Read()
+			if errors.Is(err, ErrNoMoreRows) {
+				break
+			}
+			require.NoError(t, err)
 
-	params = make(map[string]interface{}, 1)
-	params["title"] = uint64(1)
-	params["Title"] = uint64(2)
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title, active) VALUES (1, @title, true)", params, nil)
-	require.Equal(t, ErrDuplicatedParameters, err)
+			ids = append(ids, row.Values[idSel].Value().(int64))
+		}
 
-	_, ctxs, err := engine.Exec("UPSERT INTO table1 (id, amount, active) VALUES (1, 10, true)", nil, nil)
-	require.NoError(t, err)
-	require.Len(t, ctxs, 1)
-	require.Equal(t, ctxs[0].UpdatedRows(), 1)
+		return ids
+	}
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, amount, active) VALUES (2, 10, true)", nil, nil)
-	require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
+	t.Run("arithmetic must promote integer operands", func(t *testing.T) {
+		require.Equal(t, []int64{1}, queryIDs("SELECT id FROM readings WHERE temp * 2 = 43.0"))
+		require.Equal(t, []int64{1}, queryIDs("SELECT id FROM readings WHERE amount + 1 = CAST('11.25' AS DECIMAL)"))
+		require.Equal(t, []int64{1}, queryIDs("SELECT id FROM readings WHERE amount / 3 = CAST('3.416666666666666667' AS DECIMAL)"))
 
-	t.Run("row with pk 1 should have active in false", func(t *testing.T) {
-		_, ctxs, err = engine.Exec("UPSERT INTO table1 (id, amount, active) VALUES (1, 20, false)", nil, nil)
-		require.NoError(t, err)
-		require.Len(t, ctxs, 1)
-		require.Equal(t, ctxs[0].UpdatedRows(), 1)
+		_, err = engine.InferParameters(context.Background(), "SELECT id FROM readings WHERE temp + amount > 0", nil)
+		require.ErrorIs(t, err, ErrInvalidTypes)
 
-		r, err := engine.Query("SELECT amount, active FROM table1 WHERE id = 1", nil, nil)
+		params, err := engine.InferParameters(context.Background(), "SELECT id FROM readings WHERE temp * @factor > 0.0", nil)
 		require.NoError(t, err)
+		require.Equal(t, FloatType, params["factor"])
 
-		row, err := r.Read()
+		r, err := engine.Query(context.Background(), "SELECT id FROM readings WHERE temp / 0.0 > 0.0", nil, nil)
 		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 2)
-		require.Equal(t, int64(20), row.Values[EncodeSelector("", "db1", "table1", "amount")].Value())
-		require.False(t, row.Values[EncodeSelector("", "db1", "table1", "active")].Value().(bool))
+		defer r.Close()
 
-		err = r.Close()
-		require.NoError(t, err)
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrDivisionByZero)
 	})
 
-	t.Run("row with pk 1 should have active in true", func(t *testing.T) {
-		_, ctxs, err = engine.Exec("UPSERT INTO table1 (id, amount, active) VALUES (1, 10, true)", nil, nil)
-		require.NoError(t, err)
-		require.Len(t, ctxs, 1)
-		require.Equal(t, ctxs[0].UpdatedRows(), 1)
+	t.Run("casts between numeric types", func(t *testing.T) {
+		require.Equal(t, []int64{2}, queryIDs("SELECT id FROM readings WHERE CAST(temp AS INTEGER) = -3"))
+		require.Equal(t, []int64{2}, queryIDs("SELECT id FROM readings WHERE CAST(amount AS FLOAT) = -0.1"))
+		require.Equal(t, []int64{2}, queryIDs("SELECT id FROM readings WHERE CAST(temp AS DECIMAL) = CAST('-3.75' AS DECIMAL)"))
 
-		r, err := engine.Query("SELECT amount, active FROM table1 WHERE id = 1", nil, nil)
-		require.NoError(t, err)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(temp) VALUES (CAST('not a float' AS FLOAT))", nil, nil)
+		require.ErrorIs(t, err, ErrIllegalArguments)
 
-		row, err := r.Read()
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(amount) VALUES (CAST('1.2.3' AS DECIMAL))", nil, nil)
+		require.ErrorIs(t, err, ErrIllegalArguments)
+
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(amount) VALUES (CAST(true AS DECIMAL))", nil, nil)
+		require.ErrorIs(t, err, ErrUnsupportedCast)
+	})
+
+	t.Run("numeric values must be coerced into the type of the column", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(temp, amount) VALUES (1, 2.25)", nil, nil)
 		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 2)
-		require.Equal(t, int64(10), row.Values[EncodeSelector("", "db1", "table1", "amount")].Value())
-		require.True(t, row.Values[EncodeSelector("", "db1", "table1", "active")].Value().(bool))
 
-		err = r.Close()
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(temp, amount) VALUES (@temp, @amount)", map[string]interface{}{
+			"temp":   2,
+			"amount": "2.25",
+		}, nil)
 		require.NoError(t, err)
-	})
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (Id, Title, Active) VALUES (1, 'some title', false)", nil, nil)
-	require.NoError(t, err)
+		_, _, err = engine.Exec(context.Background(), "UPDATE readings SET amount = 3 WHERE id = 6", nil, nil)
+		require.NoError(t, err)
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (Id, Title, Amount, Active) VALUES (1, 'some title', 100, false)", nil, nil)
-	require.NoError(t, err)
+		r, err := engine.Query(context.Background(), "SELECT temp, amount FROM readings WHERE id >= 5 ORDER BY id", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title, amount, active) VALUES (2, 'another title', 200, true)", nil, nil)
-	require.NoError(t, err)
+		for _, expected := range []struct {
+			temp   float64
+			amount string
+		}{{1, "2.25"}, {2, "3"}} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, expected.temp, row.Values[tempSel].Value())
+			require.Equal(t, expected.amount, FormatDecimal(row.Values[amountSel].Value().(*big.Rat)))
+		}
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id) VALUES (1, 'yat')", nil, nil)
-	require.Equal(t, ErrInvalidNumberOfValues, err)
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, id) VALUES (1, 2)", nil, nil)
-	require.ErrorIs(t, err, ErrDuplicatedColumn)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(amount) VALUES ('a lot')", nil, nil)
+		require.ErrorIs(t, err, ErrIllegalArguments)
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, active) VALUES ('1', true)", nil, nil)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Contains(t, err.Error(), "is not an integer")
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(id, temp) VALUES (10.5, 1)", nil, nil)
+		require.ErrorIs(t, err, ErrInvalidValue)
+	})
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, active) VALUES (NULL, false)", nil, nil)
-	require.Equal(t, ErrPKCanNotBeNull, err)
+	t.Run("comparisons must promote integer operands", func(t *testing.T) {
+		require.Equal(t, []int64{1, 4, 5, 6}, queryIDs("SELECT id FROM readings WHERE temp > 0 ORDER BY id"))
+		require.Equal(t, []int64{5, 6}, queryIDs("SELECT id FROM readings WHERE temp >= 1 AND temp <= 2 ORDER BY temp"))
+		require.Equal(t, []int64{5, 6}, queryIDs("SELECT id FROM readings WHERE amount > 2 AND amount <= 3 ORDER BY amount"))
+		require.Equal(t, []int64{2, 3}, queryIDs("SELECT id FROM readings WHERE 0 > amount ORDER BY id"))
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title, active) VALUES (2, NULL, true)", nil, nil)
-	require.NoError(t, err)
+		_, err = engine.InferParameters(context.Background(), "SELECT id FROM readings WHERE temp > amount", nil)
+		require.ErrorIs(t, err, ErrInvalidTypes)
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (title, active) VALUES ('interesting title', true)", nil, nil)
-	require.Equal(t, ErrPKCanNotBeNull, err)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO readings(amount) VALUES (0)", nil, nil)
+		require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE IF NOT EXISTS blob_table (id BLOB[2], PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
+		require.Equal(t, []int64{1, 4, 5, 6}, queryIDs("SELECT id FROM readings WHERE temp > 0 ORDER BY id"))
+		require.Equal(t, []int64{2, 3, 8}, queryIDs("SELECT id FROM readings WHERE temp <= 0 OR temp = NULL ORDER BY id"))
+	})
 }
 
-func TestInsertIntoEdgeCases(t *testing.T) {
-	st, err := store.Open("sqldata_insert", store.DefaultOptions())
+func TestVarcharMaxLen(t *testing.T) {
+	st, err := store.Open("sqldata_varchar_max_len", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_insert")
+	defer st.Close()
+	defer os.RemoveAll("sqldata_varchar_max_len")
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR[10], active BOOLEAN, payload BLOB[2], PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE codes (id INTEGER, code VARCHAR(4), descr VARCHAR(10), PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1 (title)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON codes(code)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1 (active)", nil, nil)
+	catalog, err := engine.Catalog(context.Background(), nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1 (payload)", nil, nil)
+	table, err := catalog.GetTableByName("db1", "codes")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("INSERT INTO table1 (id, title, active, payload) VALUES (1, 'title1', true, x'00A1')", nil, nil)
+	col, err := table.GetColumnByName("descr")
 	require.NoError(t, err)
+	require.Equal(t, 10, col.MaxLen())
 
-	t.Run("on conflict cases", func(t *testing.T) {
-		_, _, err = engine.Exec("INSERT INTO table1 (id, title, active, payload) VALUES (1, 'title1', true, x'00A1')", nil, nil)
-		require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
-
-		ntx, ctxs, err := engine.Exec("INSERT INTO table1 (id, title, active, payload) VALUES (1, 'title1', true, x'00A1') ON CONFLICT DO NOTHING", nil, nil)
-		require.NoError(t, err)
-		require.Nil(t, ntx)
-		require.Len(t, ctxs, 1)
-		require.Zero(t, ctxs[0].UpdatedRows())
-		require.Nil(t, ctxs[0].TxHeader())
-	})
-
-	t.Run("varchar key cases", func(t *testing.T) {
-		_, _, err = engine.Exec("INSERT INTO table1 (id, title, active, payload) VALUES (2, 'title123456789', true, x'00A1')", nil, nil)
+	t.Run("values longer than the max length must be rejected", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO codes (id, code, descr) VALUES (1, 'a', 'description')", nil, nil)
 		require.ErrorIs(t, err, ErrMaxLengthExceeded)
+		require.Contains(t, err.Error(), "descr")
 
-		_, _, err = engine.Exec("INSERT INTO table1 (id, title, active, payload) VALUES (2, 10, true, '00A1')", nil, nil)
-		require.ErrorIs(t, err, ErrInvalidValue)
+		_, _, err = engine.Exec(context.Background(), "UPSERT INTO codes (id, code, descr) VALUES (1, @code, 'd')", map[string]interface{}{"code": "abcde"}, nil)
+		require.ErrorIs(t, err, ErrMaxLengthExceeded)
+		require.Contains(t, err.Error(), "code")
 	})
 
-	t.Run("boolean key cases", func(t *testing.T) {
-		_, _, err = engine.Exec("INSERT INTO table1 (id, title, active, payload) VALUES (2, 'title1', 'true', x'00A1')", nil, nil)
-		require.ErrorIs(t, err, ErrInvalidValue)
-	})
+	for i, code := range []string{"b", "ab", "a", "aa", "a\x00", "abcd"} {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO codes (id, code) VALUES (@id, @code)", map[string]interface{}{"id": i, "code": strings.ReplaceAll(code, "\\x00", "\x00")}, nil)
+		require.NoError(t, err)
+	}
 
-	t.Run("blob key cases", func(t *testing.T) {
-		_, _, err = engine.Exec("INSERT INTO table1 (id, title, active, payload) VALUES (2, 'title1', true, x'00A100A2')", nil, nil)
+	t.Run("updated values must be validated", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "UPDATE codes SET descr = 'description' WHERE id = 1", nil, nil)
 		require.ErrorIs(t, err, ErrMaxLengthExceeded)
 
-		_, _, err = engine.Exec("INSERT INTO table1 (id, title, active, payload) VALUES (2, 'title1', true, '00A100A2')", nil, nil)
-		require.ErrorIs(t, err, ErrInvalidValue)
-	})
-
-	t.Run("insertion in table with varchar pk", func(t *testing.T) {
-		_, _, err = engine.Exec("CREATE TABLE languages (code VARCHAR[255],name VARCHAR[255],PRIMARY KEY code)", nil, nil)
+		_, _, err = engine.Exec(context.Background(), "UPDATE codes SET descr = 'short' WHERE id = 1", nil, nil)
 		require.NoError(t, err)
+	})
 
-		_, _, err = engine.Exec("INSERT INTO languages (code,name) VALUES ('code1', 'name1')", nil, nil)
+	t.Run("padded index keys must keep the order of the values", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT code FROM codes ORDER BY code", nil, nil)
 		require.NoError(t, err)
-	})
-}
+		defer r.Close()
 
-func TestAutoIncrementPK(t *testing.T) {
-	st, err := store.Open("sqldata_auto_inc", store.DefaultOptions())
+		for _, expected := range []string{"a", "a\x00", "aa", "ab", "abcd", "b"} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, expected, row.Values[EncodeSelector("", "db1", "codes", "code")].Value())
+		}
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+
+		r, err = engine.Query(context.Background(), "SELECT id FROM codes WHERE code >= 'a' AND code < 'ab' ORDER BY code", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		for _, expected := range []int64{2, 4, 3} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, expected, row.Values[EncodeSelector("", "db1", "codes", "id")].Value())
+		}
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
+}
+
+func TestAddColumn(t *testing.T) {
+	st, err := store.Open("sqldata_add_column", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_auto_inc")
+	defer os.RemoveAll("sqldata_add_column")
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", nil, nil)
+	require.Equal(t, ErrNoDatabaseSelected, err)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	t.Run("invalid use of auto-increment", func(t *testing.T) {
-		_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR AUTO_INCREMENT, PRIMARY KEY id)", nil, nil)
-		require.ErrorIs(t, err, ErrLimitedAutoIncrement)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (name VARCHAR, PRIMARY KEY id)", nil, nil)
+	require.Equal(t, ErrColumnDoesNotExist, err)
 
-		_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER AUTO_INCREMENT, PRIMARY KEY id)", nil, nil)
-		require.ErrorIs(t, err, ErrLimitedAutoIncrement)
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 ADD COLUMN surname VARCHAR", nil, nil)
+	require.Equal(t, ErrTableDoesNotExist, err)
 
-		_, _, err = engine.Exec("CREATE TABLE table1 (id VARCHAR AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id)", nil, nil)
-		require.ErrorIs(t, err, ErrLimitedAutoIncrement)
-	})
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, name VARCHAR, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER NOT NULL AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(name) VALUES('John'), ('Jane')", nil, nil)
 	require.NoError(t, err)
 
-	_, ctxs, err := engine.Exec("INSERT INTO table1(title) VALUES ('name1')", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 ADD COLUMN name VARCHAR", nil, nil)
+	require.Equal(t, ErrDuplicatedColumn, err)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 ADD COLUMN surname VARCHAR NOT NULL", nil, nil)
+	require.Equal(t, ErrNewColumnMustBeNullable, err)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 ADD COLUMN counter INTEGER AUTO_INCREMENT", nil, nil)
+	require.Equal(t, ErrLimitedAutoIncrement, err)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 ADD COLUMN age INTEGER[10]", nil, nil)
+	require.Equal(t, ErrLimitedMaxLen, err)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 ADD COLUMN surname VARCHAR[64]", nil, nil)
 	require.NoError(t, err)
-	require.Len(t, ctxs, 1)
-	require.True(t, ctxs[0].closed)
-	require.Equal(t, int64(1), ctxs[0].LastInsertedPKs()["table1"])
-	require.Equal(t, int64(1), ctxs[0].FirstInsertedPKs()["table1"])
-	require.Equal(t, 1, ctxs[0].UpdatedRows())
 
-	_, _, err = engine.Exec("INSERT INTO table1(id, title) VALUES (1, 'name2')", nil, nil)
-	require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(name, surname) VALUES('Mary', 'Smith')", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(name, surname) VALUES('Mike', @surname)", map[string]interface{}{"surname": strings.Repeat("x", 65)}, nil)
+	require.ErrorIs(t, err, ErrMaxLengthExceeded)
+
+	checkRows := func(engine *Engine) {
+		r, err := engine.Query(context.Background(), "SELECT id, name, surname FROM table1", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 3)
+
+		for _, expectedSurname := range []interface{}{nil, nil, "Smith"} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, expectedSurname, row.Values[EncodeSelector("", "db1", "table1", "surname")].Value())
+		}
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	}
+
+	checkRows(engine)
+
+	_, _, err = engine.Exec(context.Background(), "UPDATE table1 SET surname = 'Doe' WHERE name = 'John'", nil, nil)
+	require.NoError(t, err)
 
-	_, _, err = engine.Exec("INSERT INTO table1(id, title) VALUES (1, 'name2') ON CONFLICT DO NOTHING", nil, nil)
+	r, err := engine.Query(context.Background(), "SELECT COUNT(*) FROM table1 WHERE surname = 'Doe'", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("UPSERT INTO table1(id, title) VALUES (1, 'name11')", nil, nil)
+	row, err := r.Read()
 	require.NoError(t, err)
+	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
 
-	_, _, err = engine.Exec("INSERT INTO table1(id, title) VALUES (2, 'name2')", nil, nil)
+	err = r.Close()
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("UPSERT INTO table1(id, title) VALUES (3, 'name3')", nil, nil)
+	// the new column must be loaded from the catalog when reopening the engine
+	engine, err = NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
 
-	_, ctxs, err = engine.Exec("INSERT INTO table1(title) VALUES ('name4')", nil, nil)
+	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
-	require.Len(t, ctxs, 1)
-	require.True(t, ctxs[0].closed)
-	require.Equal(t, int64(4), ctxs[0].FirstInsertedPKs()["table1"])
-	require.Equal(t, int64(4), ctxs[0].LastInsertedPKs()["table1"])
-	require.Equal(t, 1, ctxs[0].UpdatedRows())
 
-	_, ctxs, err = engine.Exec(`
-		BEGIN TRANSACTION;
-			INSERT INTO table1(title) VALUES ('name5');
-			INSERT INTO table1(title) VALUES ('name6');
-		COMMIT;
-	`, nil, nil)
+	r, err = engine.Query(context.Background(), "SELECT surname FROM table1 WHERE id = 3", nil, nil)
+	require.NoError(t, err)
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.Equal(t, "Smith", row.Values[EncodeSelector("", "db1", "table1", "surname")].Value())
+
+	err = r.Close()
 	require.NoError(t, err)
-	require.Len(t, ctxs, 1)
-	require.True(t, ctxs[0].closed)
-	require.Equal(t, int64(5), ctxs[0].FirstInsertedPKs()["table1"])
-	require.Equal(t, int64(6), ctxs[0].LastInsertedPKs()["table1"])
-	require.Equal(t, 2, ctxs[0].UpdatedRows())
 }
 
-func TestDelete(t *testing.T) {
-	st, err := store.Open("sqldata_delete", store.DefaultOptions())
+func TestDropColumn(t *testing.T) {
+	st, err := store.Open("sqldata_drop_column", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_delete")
+	defer os.RemoveAll("sqldata_drop_column")
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 DROP COLUMN title", nil, nil)
+	require.Equal(t, ErrNoDatabaseSelected, err)
 
-	_, _, err = engine.Exec("DELETE FROM table1", nil, nil)
-	require.ErrorIs(t, err, ErrNoDatabaseSelected)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(`CREATE TABLE table1 (
-		id INTEGER,
-		title VARCHAR[50],
-		active BOOLEAN,
-		PRIMARY KEY id
-	)`, nil, nil)
-	require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 DROP COLUMN title", nil, nil)
+	require.Equal(t, ErrTableDoesNotExist, err)
 
-	_, _, err = engine.Exec("CREATE UNIQUE INDEX ON table1(title)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, title VARCHAR, amount INTEGER, active BOOLEAN, PRIMARY KEY id);
+		CREATE INDEX ON table1(active);
+		INSERT INTO table1(title, amount, active) VALUES('title1', 10, true), ('title2', 20, false);
+	`, nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(active)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 DROP COLUMN missing", nil, nil)
+	require.Equal(t, ErrColumnDoesNotExist, err)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 DROP COLUMN id", nil, nil)
+	require.Equal(t, ErrIndexedColumnCanNotBeDropped, err)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 DROP COLUMN active", nil, nil)
+	require.Equal(t, ErrIndexedColumnCanNotBeDropped, err)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 DROP COLUMN amount", nil, nil)
 	require.NoError(t, err)
 
-	params, err := engine.InferParameters("DELETE FROM table1 WHERE active = @active", nil)
+	r, err := engine.Query(context.Background(), "SELECT amount FROM table1", nil, nil)
 	require.NoError(t, err)
-	require.NotNil(t, params)
-	require.Len(t, params, 1)
-	require.Equal(t, params["active"], BooleanType)
 
-	_, _, err = engine.Exec("DELETE FROM table2", nil, nil)
-	require.ErrorIs(t, err, ErrTableDoesNotExist)
+	_, err = r.Read()
+	require.ErrorIs(t, err, ErrColumnDoesNotExist)
+
+	err = r.Close()
+	require.NoError(t, err)
 
-	_, _, err = engine.Exec("DELETE FROM table1 WHERE name = 'name1'", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(title, amount) VALUES('title3', 30)", nil, nil)
 	require.ErrorIs(t, err, ErrColumnDoesNotExist)
 
-	t.Run("delete on empty table should complete without issues", func(t *testing.T) {
-		_, ctxs, err := engine.Exec("DELETE FROM table1", nil, nil)
-		require.NoError(t, err)
-		require.Len(t, ctxs, 1)
-		require.Zero(t, ctxs[0].UpdatedRows())
-	})
+	// a new column with the same name does not expose the values of the dropped one
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 ADD COLUMN amount VARCHAR", nil, nil)
+	require.NoError(t, err)
 
-	rowCount := 10
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(title, amount) VALUES('title3', 'thirty')", nil, nil)
+	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		_, _, err = engine.Exec(fmt.Sprintf(`
-			INSERT INTO table1 (id, title, active) VALUES (%d, 'title%d', %v)`, i, i, i%2 == 0), nil, nil)
-		require.NoError(t, err)
-	}
+	_, _, err = engine.Exec(context.Background(), "UPDATE table1 SET title = 'updated title1' WHERE id = 1", nil, nil)
+	require.NoError(t, err)
 
-	t.Run("deleting with contradiction should not produce any change", func(t *testing.T) {
-		_, ctxs, err := engine.Exec("DELETE FROM table1 WHERE false", nil, nil)
+	checkRows := func(engine *Engine) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1", nil, nil)
 		require.NoError(t, err)
-		require.Len(t, ctxs, 1)
-		require.Zero(t, ctxs[0].UpdatedRows())
-	})
+		defer r.Close()
 
-	t.Run("deleting active rows should remove half of the rows", func(t *testing.T) {
-		_, ctxs, err := engine.Exec("DELETE FROM table1 WHERE active = @active", map[string]interface{}{"active": true}, nil)
+		cols, err := r.Columns()
 		require.NoError(t, err)
-		require.Len(t, ctxs, 1)
-		require.Equal(t, rowCount/2, ctxs[0].UpdatedRows())
+		require.Len(t, cols, 4)
 
-		r, err := engine.Query("SELECT COUNT(*) FROM table1", nil, nil)
-		require.NoError(t, err)
+		for _, expected := range []struct {
+			title  string
+			amount interface{}
+		}{
+			{"updated title1", nil},
+			{"title2", nil},
+			{"title3", "thirty"},
+		} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Len(t, row.Values, 4)
+			require.Equal(t, expected.title, row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+			require.Equal(t, expected.amount, row.Values[EncodeSelector("", "db1", "table1", "amount")].Value())
+		}
 
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.Equal(t, int64(rowCount/2), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	}
 
-		err = r.Close()
-		require.NoError(t, err)
+	checkRows(engine)
 
-		r, err = engine.Query("SELECT COUNT(*) FROM table1 WHERE active", nil, nil)
-		require.NoError(t, err)
+	// dropped columns must remain tombstoned after reopening the engine
+	engine, err = NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
 
-		row, err = r.Read()
-		require.NoError(t, err)
-		require.Equal(t, int64(0), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
+	checkRows(engine)
 }
 
-func TestErrorDuringDelete(t *testing.T) {
-	st, err := store.Open("err_during_delete", store.DefaultOptions())
+func TestRenameColumn(t *testing.T) {
+	st, err := store.Open("sqldata_rename_column", store.DefaultOptions())
 	require.NoError(t, err)
-	defer st.Close()
-	defer os.RemoveAll("err_during_delete")
+	defer os.RemoveAll("sqldata_rename_column")
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(`
-		create table mytable(name varchar[30], primary key name);
-		insert into mytable(name) values('name1');
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 RENAME COLUMN title TO name", nil, nil)
+	require.Equal(t, ErrTableDoesNotExist, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER, title VARCHAR[64], active BOOLEAN, PRIMARY KEY id);
+		CREATE INDEX ON table1(title);
+		INSERT INTO table1(id, title, active) VALUES(1, 'title1', true);
 	`, nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("delete FROM mytable where name=name1", nil, nil)
-	require.ErrorIs(t, err, ErrColumnDoesNotExist)
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 RENAME COLUMN missing TO name", nil, nil)
+	require.Equal(t, ErrColumnDoesNotExist, err)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 RENAME COLUMN title TO active", nil, nil)
+	require.Equal(t, ErrDuplicatedColumn, err)
 
-	_, _, err = engine.Exec("delete FROM mytable where name='name1'", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 RENAME COLUMN title TO name", nil, nil)
 	require.NoError(t, err)
-}
 
-func TestUpdate(t *testing.T) {
-	st, err := store.Open("sqldata_update", store.DefaultOptions())
+	r, err := engine.Query(context.Background(), "SELECT title FROM table1", nil, nil)
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_update")
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	_, err = r.Read()
+	require.ErrorIs(t, err, ErrColumnDoesNotExist)
+
+	err = r.Close()
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(id, name) VALUES(2, 'title2')", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("UPDATE table1 SET title = 'title11' WHERE title = 'title'", nil, nil)
-	require.ErrorIs(t, err, ErrNoDatabaseSelected)
+	checkRows := func(engine *Engine) {
+		r, err := engine.Query(context.Background(), "SELECT id, name FROM table1 ORDER BY name DESC", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		for _, expected := range []string{"title2", "title1"} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, expected, row.Values[EncodeSelector("", "db1", "table1", "name")].Value())
+		}
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	}
+
+	checkRows(engine)
+
+	engine, err = NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(`CREATE TABLE table1 (
-		id INTEGER,
-		title VARCHAR[50],
-		active BOOLEAN,
-		PRIMARY KEY id
-	)`, nil, nil)
+	checkRows(engine)
+}
+
+func TestDropTable(t *testing.T) {
+	st, err := store.Open("sqldata_drop_table", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_drop_table")
 
-	_, _, err = engine.Exec("CREATE UNIQUE INDEX ON table1(title)", nil, nil)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(active)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "DROP TABLE table1", nil, nil)
+	require.Equal(t, ErrNoDatabaseSelected, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
-	params, err := engine.InferParameters("UPDATE table1 SET active = @active", nil)
+	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
-	require.NotNil(t, params)
-	require.Len(t, params, 1)
-	require.Equal(t, params["active"], BooleanType)
 
-	_, _, err = engine.Exec("UPDATE table2 SET active = false", nil, nil)
-	require.ErrorIs(t, err, ErrTableDoesNotExist)
+	_, _, err = engine.Exec(context.Background(), "DROP TABLE table1", nil, nil)
+	require.Equal(t, ErrTableDoesNotExist, err)
 
-	_, _, err = engine.Exec("UPDATE table1 SET name = 'name1'", nil, nil)
-	require.ErrorIs(t, err, ErrColumnDoesNotExist)
+	_, _, err = engine.Exec(context.Background(), "DROP TABLE IF EXISTS table1", nil, nil)
+	require.NoError(t, err)
 
-	t.Run("update on empty table should complete without issues", func(t *testing.T) {
-		_, ctxs, err := engine.Exec("UPDATE table1 SET active = false", nil, nil)
-		require.NoError(t, err)
-		require.Len(t, ctxs, 1)
-		require.Zero(t, ctxs[0].UpdatedRows())
-	})
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id);
+		INSERT INTO table1(id, title) VALUES(1, 'title1');
+	`, nil, nil)
+	require.NoError(t, err)
 
-	rowCount := 10
+	_, ctxs, err := engine.Exec(context.Background(), "DROP TABLE table1", nil, nil)
+	require.NoError(t, err)
+	require.Len(t, ctxs, 1)
 
-	for i := 0; i < rowCount; i++ {
-		_, _, err = engine.Exec(fmt.Sprintf(`
-			INSERT INTO table1 (id, title, active) VALUES (%d, 'title%d', %v)`, i, i, i%2 == 0), nil, nil)
-		require.NoError(t, err)
-	}
+	catalogKey := mapKey(sqlPrefix, catalogTablePrefix, EncodeID(1), EncodeID(1))
 
-	t.Run("updating with contradiction should not produce any change", func(t *testing.T) {
-		_, ctxs, err := engine.Exec("UPDATE table1 SET active = false WHERE false", nil, nil)
-		require.NoError(t, err)
-		require.Len(t, ctxs, 1)
-		require.Zero(t, ctxs[0].UpdatedRows())
-	})
+	_, err = st.Get(catalogKey)
+	require.ErrorIs(t, err, store.ErrKeyNotFound)
 
-	t.Run("updating specific row should update only one row", func(t *testing.T) {
-		_, ctxs, err := engine.Exec("UPDATE table1 SET active = true WHERE title = @title", map[string]interface{}{"title": "title1"}, nil)
-		require.NoError(t, err)
-		require.Len(t, ctxs, 1)
-		require.Equal(t, 1, ctxs[0].UpdatedRows())
+	// the table definition remains in the history
+	txs, err := st.History(catalogKey, 0, false, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, ctxs[0].TxHeader().ID, txs[1])
 
-		r, err := engine.Query("SELECT COUNT(*) FROM table1", nil, nil)
-		require.NoError(t, err)
+	_, err = engine.Query(context.Background(), "SELECT id FROM table1", nil, nil)
+	require.ErrorIs(t, err, ErrTableDoesNotExist)
 
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.Equal(t, int64(rowCount), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(id, title) VALUES(2, 'title2')", nil, nil)
+	require.ErrorIs(t, err, ErrTableDoesNotExist)
 
-		err = r.Close()
+	// a table created with the same name does not expose the rows of the dropped one
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, amount INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	checkTable := func(engine *Engine) {
+		catalog, err := engine.Catalog(context.Background(), nil)
 		require.NoError(t, err)
 
-		r, err = engine.Query("SELECT COUNT(*) FROM table1 WHERE active", nil, nil)
+		table, err := catalog.GetTableByName("db1", "table1")
 		require.NoError(t, err)
+		require.Equal(t, uint32(2), table.ID())
 
-		row, err = r.Read()
+		r, err := engine.Query(context.Background(), "SELECT id FROM table1", nil, nil)
 		require.NoError(t, err)
-		require.Equal(t, int64(rowCount/2+1), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
 
 		err = r.Close()
 		require.NoError(t, err)
-	})
-}
-
-func TestTransactions(t *testing.T) {
-	st, err := store.Open("sqldata_tx", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_tx")
+	}
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+	checkTable(engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	engine, err = NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(`CREATE TABLE table1 (
-									id INTEGER,
-									title VARCHAR,
-									PRIMARY KEY id
-								)`, nil, nil)
-	require.NoError(t, err)
-
-	_, _, err = engine.Exec(`
-		BEGIN TRANSACTION;
-			CREATE INDEX ON table2(title);
-		COMMIT;
-		`, nil, nil)
-	require.Equal(t, ErrTableDoesNotExist, err)
+	checkTable(engine)
 
-	_, _, err = engine.Exec(`
-		BEGIN TRANSACTION;
-			UPSERT INTO table1 (id, title) VALUES (1, 'title1');
-			UPSERT INTO table1 (id, title) VALUES (2, 'title2');
-		COMMIT;
-		`, nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table2 (id INTEGER, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(`
-		BEGIN TRANSACTION;
-			CREATE TABLE table2 (id INTEGER, title VARCHAR[100], age INTEGER, PRIMARY KEY id);
-			CREATE INDEX ON table2(title);
-		COMMIT;
-		`, nil, nil)
+	catalog, err := engine.Catalog(context.Background(), nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(`
-		BEGIN TRANSACTION;
-			CREATE INDEX ON table2(age);
-			INSERT INTO table2 (id, title, age) VALUES (1, 'title1', 40);
-		COMMIT;
-		`, nil, nil)
+	table, err := catalog.GetTableByName("db1", "table2")
 	require.NoError(t, err)
+	require.Equal(t, uint32(3), table.ID())
 }
 
-func TestUseSnapshot(t *testing.T) {
-	st, err := store.Open("sqldata_snap", store.DefaultOptions())
+func TestDropDatabase(t *testing.T) {
+	st, err := store.Open("sqldata_drop_database", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_snap")
+	defer os.RemoveAll("sqldata_drop_database")
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "DROP DATABASE db1", nil, nil)
+	require.Equal(t, ErrDatabaseDoesNotExist, err)
 
-	err = engine.SetDefaultDatabase("db1")
+	_, _, err = engine.Exec(context.Background(), "DROP DATABASE IF EXISTS db1", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1; CREATE DATABASE db2", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("USE SNAPSHOT SINCE TX 1", nil, nil)
-	require.Equal(t, ErrNoSupported, err)
-
-	_, _, err = engine.Exec(`
-		BEGIN TRANSACTION;
-			UPSERT INTO table1 (id, title) VALUES (1, 'title1');
-			UPSERT INTO table1 (id, title) VALUES (2, 'title2');
-		COMMIT;
-		`, nil, nil)
+	err = engine.SetDefaultDatabase("db2")
 	require.NoError(t, err)
-}
 
-func TestEncodeRawValue(t *testing.T) {
-	b, err := EncodeValue(int64(1), IntegerType, 0)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1}, b)
 
-	b, err = EncodeValue(true, IntegerType, 0)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
+	_, _, err = engine.Exec(context.Background(), "DROP DATABASE db2", nil, nil)
+	require.Equal(t, ErrDatabaseInUse, err)
 
-	b, err = EncodeValue(true, BooleanType, 0)
+	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 1, 1}, b)
-
-	b, err = EncodeValue(uint64(1), BooleanType, 0)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
 
-	b, err = EncodeValue("title", VarcharType, 0)
+	_, _, err = engine.Exec(context.Background(), "DROP DATABASE db2", nil, nil)
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 5, 't', 'i', 't', 'l', 'e'}, b)
 
-	b, err = EncodeValue(uint64(1), VarcharType, 0)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
+	_, _, err = engine.Exec(context.Background(), "USE DATABASE db2", nil, nil)
+	require.Equal(t, ErrDatabaseDoesNotExist, err)
 
-	b, err = EncodeValue([]byte{}, BLOBType, 0)
+	// a database created with the same name does not expose the tables of the dropped one
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db2", nil, nil)
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 0}, b)
 
-	b, err = EncodeValue(nil, BLOBType, 0)
+	engine, err = NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 0}, b)
-
-	b, err = EncodeValue(uint64(1), BLOBType, 0)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
-
-	b, err = EncodeValue(uint64(1), "invalid type", 0)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
-
-	// Max allowed key size is 32 bytes
-	b, err = EncodeValue("012345678901234567890123456789012", VarcharType, 32)
-	require.ErrorIs(t, err, ErrMaxLengthExceeded)
-	require.Nil(t, b)
 
-	_, err = EncodeValue("01234567890123456789012345678902", VarcharType, 0)
+	catalog, err := engine.Catalog(context.Background(), nil)
 	require.NoError(t, err)
+	require.Len(t, catalog.Databases(), 2)
 
-	_, err = EncodeValue("012345678901234567890123456789012", VarcharType, 0)
+	db, err := catalog.GetDatabaseByName("db2")
 	require.NoError(t, err)
+	require.Equal(t, uint32(3), db.ID())
+	require.Empty(t, db.GetTables())
+}
 
-	b, err = EncodeValue([]byte{
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2,
-	}, BLOBType, 32)
-	require.ErrorIs(t, err, ErrMaxLengthExceeded)
-	require.Nil(t, b)
-
-	_, err = EncodeValue([]byte{
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1,
-	}, BLOBType, 0)
+func TestCreateIndex(t *testing.T) {
+	st, err := store.Open("sqldata_create_index", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_create_index")
 
-	_, err = EncodeValue([]byte{
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2,
-	}, BLOBType, 0)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
-}
+	defer requireNoOpenTxs(t, engine)
 
-func TestEncodeValue(t *testing.T) {
-	b, err := EncodeValue((&Number{val: 1}).Value(), IntegerType, 0)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1}, b)
-
-	b, err = EncodeValue((&Bool{val: true}).Value(), IntegerType, 0)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
 
-	b, err = EncodeValue((&Bool{val: true}).Value(), BooleanType, 0)
+	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 1, 1}, b)
 
-	b, err = EncodeValue((&Number{val: 1}).Value(), BooleanType, 0)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
-
-	b, err = EncodeValue((&Varchar{val: "title"}).Value(), VarcharType, 0)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, name VARCHAR[256], age INTEGER, active BOOLEAN, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 5, 't', 'i', 't', 'l', 'e'}, b)
-
-	b, err = EncodeValue((&Number{val: 1}).Value(), VarcharType, 0)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
 
-	b, err = EncodeValue((&Blob{val: []byte{}}).Value(), BLOBType, 50)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(name)", nil, nil)
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 0}, b)
 
-	b, err = EncodeValue((&Blob{val: nil}).Value(), BLOBType, 50)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX IF NOT EXISTS ON table1(name)", nil, nil)
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 0}, b)
-
-	b, err = EncodeValue((&Number{val: 1}).Value(), BLOBType, 50)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
 
-	b, err = EncodeValue((&Number{val: 1}).Value(), "invalid type", 50)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(name)", nil, nil)
+	require.Equal(t, ErrIndexAlreadyExists, err)
 
-	// Max allowed key size is 32 bytes
-	b, err = EncodeValue((&Varchar{val: "012345678901234567890123456789012"}).Value(), VarcharType, 32)
-	require.ErrorIs(t, err, ErrMaxLengthExceeded)
-	require.Nil(t, b)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(id)", nil, nil)
+	require.Equal(t, ErrIndexAlreadyExists, err)
 
-	_, err = EncodeValue((&Varchar{val: "01234567890123456789012345678902"}).Value(), VarcharType, 256)
+	_, _, err = engine.Exec(context.Background(), "CREATE UNIQUE INDEX IF NOT EXISTS ON table1(id)", nil, nil)
 	require.NoError(t, err)
 
-	_, err = EncodeValue((&Varchar{val: "012345678901234567890123456789012"}).Value(), VarcharType, 256)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(age)", nil, nil)
 	require.NoError(t, err)
 
-	b, err = EncodeValue((&Blob{val: []byte{
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2,
-	}}).Value(), BLOBType, 32)
-	require.ErrorIs(t, err, ErrMaxLengthExceeded)
-	require.Nil(t, b)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(name)", nil, nil)
+	require.Equal(t, ErrIndexAlreadyExists, err)
 
-	_, err = EncodeValue((&Blob{val: []byte{
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1,
-	}}).Value(), BLOBType, 256)
-	require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table2(name)", nil, nil)
+	require.Equal(t, ErrTableDoesNotExist, err)
 
-	_, err = EncodeValue((&Blob{val: []byte{
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2,
-	}}).Value(), BLOBType, 256)
-	require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(title)", nil, nil)
+	require.Equal(t, ErrColumnDoesNotExist, err)
 
-	b, err = EncodeValue((&Timestamp{val: time.Unix(0, 1000)}).Value(), TimestampType, 0)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(id, name, age) VALUES (1, 'name1', 50)", nil, nil)
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1}, b)
 
-	b, err = EncodeValue((&Number{val: 1}).Value(), TimestampType, 0)
-	require.ErrorIs(t, err, ErrInvalidValue)
-	require.Nil(t, b)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(name, age) VALUES ('name2', 10)", nil, nil)
+	require.ErrorIs(t, err, ErrPKCanNotBeNull)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(active)", nil, nil)
+	require.Equal(t, ErrLimitedIndexCreation, err)
 }
 
-func TestQuery(t *testing.T) {
-	st, err := store.Open("sqldata_q", store.DefaultOptions())
+func TestUpsertInto(t *testing.T) {
+	st, err := store.Open("sqldata_upsert", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_q")
+	defer os.RemoveAll("sqldata_upsert")
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, err = engine.Query("SELECT id FROM table1", nil, nil)
-	require.Equal(t, ErrNoDatabaseSelected, err)
-
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
-	_, err = engine.Query("SELECT id FROM table1", nil, nil)
-	require.Equal(t, ErrNoDatabaseSelected, err)
-
-	_, err = engine.Query("SELECT * FROM table1", nil, nil)
-	require.Equal(t, ErrNoDatabaseSelected, err)
-
-	_, _, err = engine.Exec("SELECT id FROM table1", nil, nil)
-	require.Equal(t, ErrNoDatabaseSelected, err)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, nil)
+	require.ErrorIs(t, err, ErrNoDatabaseSelected)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, err = engine.Query("SELECT id FROM db2.table1", nil, nil)
-	require.Equal(t, ErrDatabaseDoesNotExist, err)
-
-	_, err = engine.Query("SELECT id FROM table1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, nil)
 	require.Equal(t, ErrTableDoesNotExist, err)
 
-	_, _, err = engine.Exec(`CREATE TABLE table1 (
+	_, _, err = engine.Exec(context.Background(), `CREATE TABLE table1 (
 								id INTEGER,
-								ts TIMESTAMP,
 								title VARCHAR,
-								active BOOLEAN,
-								payload BLOB,
+								amount INTEGER,
+								active BOOLEAN NOT NULL,
 								PRIMARY KEY id)`, nil, nil)
 	require.NoError(t, err)
 
-	params := make(map[string]interface{})
-	params["id"] = 0
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(active)", nil, nil)
+	require.NoError(t, err)
 
-	r, err := engine.Query("SELECT id FROM db1.table1 WHERE id >= @id", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE UNIQUE INDEX ON table1(amount, active)", nil, nil)
 	require.NoError(t, err)
 
-	orderBy := r.OrderBy()
-	require.NotNil(t, orderBy)
-	require.Len(t, orderBy, 1)
-	require.Equal(t, "id", orderBy[0].Column)
-	require.Equal(t, "table1", orderBy[0].Table)
-	require.Equal(t, "db1", orderBy[0].Database)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, nil)
+	require.ErrorIs(t, err, ErrNotNullableColumnCannotBeNull)
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, age) VALUES (1, 50)", nil, nil)
+	require.Equal(t, ErrColumnDoesNotExist, err)
 
-	err = r.Close()
-	require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title, active) VALUES (@id, 'title1', true)", nil, nil)
+	require.Equal(t, ErrMissingParameter, err)
 
-	r, err = engine.Query("SELECT * FROM db1.table1", nil, nil)
-	require.NoError(t, err)
+	params := make(map[string]interface{}, 1)
+	params["id"] = [4]byte{1, 2, 3, 4}
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title, active) VALUES (@id, 'title1', true)", params, nil)
+	require.Equal(t, ErrUnsupportedParameter, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	params = make(map[string]interface{}, 1)
+	params["id"] = []byte{1, 2, 3}
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title, active) VALUES (@id, 'title1', true)", params, nil)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Contains(t, err.Error(), "is not an integer")
 
-	err = r.Close()
-	require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title, active) VALUES (1, @title, false)", nil, nil)
+	require.Equal(t, ErrMissingParameter, err)
 
-	rowCount := 10
+	params = make(map[string]interface{}, 1)
+	params["title"] = uint64(1)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title, active) VALUES (1, @title, true)", params, nil)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Contains(t, err.Error(), "is not a string")
 
-	start := time.Now()
+	params = make(map[string]interface{}, 1)
+	params["title"] = uint64(1)
+	params["Title"] = uint64(2)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title, active) VALUES (1, @title, true)", params, nil)
+	require.Equal(t, ErrDuplicatedParameters, err)
 
-	for i := 0; i < rowCount; i++ {
-		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
-		_, _, err = engine.Exec(fmt.Sprintf(`
-			UPSERT INTO table1 (id, ts, title, active, payload)
-			VALUES (%d, NOW(), 'title%d', %v, x'%s')
-		`, i, i, i%2 == 0, encPayload), nil, nil)
-		require.NoError(t, err)
-	}
+	_, ctxs, err := engine.Exec(context.Background(), "UPSERT INTO table1 (id, amount, active) VALUES (1, 10, true)", nil, nil)
+	require.NoError(t, err)
+	require.Len(t, ctxs, 1)
+	require.Equal(t, ctxs[0].UpdatedRows(), 1)
 
-	t.Run("should resolve every row", func(t *testing.T) {
-		r, err = engine.Query("SELECT * FROM table1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, amount, active) VALUES (2, 10, true)", nil, nil)
+	require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
+
+	t.Run("row with pk 1 should have active in false", func(t *testing.T) {
+		_, ctxs, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, amount, active) VALUES (1, 20, false)", nil, nil)
 		require.NoError(t, err)
+		require.Len(t, ctxs, 1)
+		require.Equal(t, ctxs[0].UpdatedRows(), 1)
 
-		colsBySel, err := r.colsBySelector()
-		require.NoError(t, err)
-		require.Len(t, colsBySel, 5)
-
-		require.Equal(t, "db1", r.Database().Name())
-		require.Equal(t, "table1", r.TableAlias())
-
-		cols, err := r.Columns()
-		require.NoError(t, err)
-		require.Len(t, cols, 5)
-
-		for i := 0; i < rowCount; i++ {
-			row, err := r.Read()
-			require.NoError(t, err)
-			require.NotNil(t, row)
-			require.Len(t, row.Values, 5)
-			require.False(t, start.After(row.Values[EncodeSelector("", "db1", "table1", "ts")].Value().(time.Time)))
-			require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-			require.Equal(t, i%2 == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
-
-			encPayload := []byte(fmt.Sprintf("blob%d", i))
-			require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "table1", "payload")].Value())
-		}
-
-		_, err = r.Read()
-		require.Equal(t, ErrNoMoreRows, err)
-
-		err = r.Close()
-		require.NoError(t, err)
-	})
-
-	t.Run("should fail reading due to non-existent column", func(t *testing.T) {
-		r, err := engine.Query("SELECT id1 FROM table1", nil, nil)
+		r, err := engine.Query(context.Background(), "SELECT amount, active FROM table1 WHERE id = 1", nil, nil)
 		require.NoError(t, err)
 
 		row, err := r.Read()
-		require.Equal(t, ErrColumnDoesNotExist, err)
-		require.Nil(t, row)
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 2)
+		require.Equal(t, int64(20), row.Values[EncodeSelector("", "db1", "table1", "amount")].Value())
+		require.False(t, row.Values[EncodeSelector("", "db1", "table1", "active")].Value().(bool))
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
 
-	t.Run("should resolve every row with two-time table aliasing", func(t *testing.T) {
-		r, err = engine.Query(fmt.Sprintf(`
-			SELECT * FROM table1 AS mytable1 WHERE mytable1.id >= 0 LIMIT %d
-		`, rowCount), nil, nil)
+	t.Run("row with pk 1 should have active in true", func(t *testing.T) {
+		_, ctxs, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, amount, active) VALUES (1, 10, true)", nil, nil)
 		require.NoError(t, err)
+		require.Len(t, ctxs, 1)
+		require.Equal(t, ctxs[0].UpdatedRows(), 1)
 
-		colsBySel, err := r.colsBySelector()
+		r, err := engine.Query(context.Background(), "SELECT amount, active FROM table1 WHERE id = 1", nil, nil)
 		require.NoError(t, err)
-		require.Len(t, colsBySel, 5)
 
-		require.Equal(t, "db1", r.Database().Name())
-		require.Equal(t, "mytable1", r.TableAlias())
-
-		cols, err := r.Columns()
+		row, err := r.Read()
 		require.NoError(t, err)
-		require.Len(t, cols, 5)
-
-		for i := 0; i < rowCount; i++ {
-			row, err := r.Read()
-			require.NoError(t, err)
-			require.NotNil(t, row)
-			require.Len(t, row.Values, 5)
-			require.False(t, start.After(row.Values[EncodeSelector("", "db1", "mytable1", "ts")].Value().(time.Time)))
-			require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "mytable1", "id")].Value())
-			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "mytable1", "title")].Value())
-			require.Equal(t, i%2 == 0, row.Values[EncodeSelector("", "db1", "mytable1", "active")].Value())
-
-			encPayload := []byte(fmt.Sprintf("blob%d", i))
-			require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "mytable1", "payload")].Value())
-		}
-
-		_, err = r.Read()
-		require.Equal(t, ErrNoMoreRows, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 2)
+		require.Equal(t, int64(10), row.Values[EncodeSelector("", "db1", "table1", "amount")].Value())
+		require.True(t, row.Values[EncodeSelector("", "db1", "table1", "active")].Value().(bool))
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
 
-	t.Run("should resolve every row with column and two-time table aliasing", func(t *testing.T) {
-		r, err = engine.Query(fmt.Sprintf(`
-			SELECT mytable1.id AS D, ts, Title, payload, Active FROM table1 mytable1 WHERE mytable1.id >= 0 LIMIT %d
-		`, rowCount), nil, nil)
-		require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, amount, active) VALUES (1, 10, NULL)", nil, nil)
+	require.ErrorIs(t, err, ErrNotNullableColumnCannotBeNull)
 
-		colsBySel, err := r.colsBySelector()
-		require.NoError(t, err)
-		require.Len(t, colsBySel, 5)
+	_, _, err = engine.Exec(context.Background(), "UPDATE table1 SET active = NULL WHERE id = 1", nil, nil)
+	require.ErrorIs(t, err, ErrNotNullableColumnCannotBeNull)
 
-		require.Equal(t, "db1", r.Database().Name())
-		require.Equal(t, "mytable1", r.TableAlias())
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (Id, Title, Active) VALUES (1, 'some title', false)", nil, nil)
+	require.NoError(t, err)
 
-		cols, err := r.Columns()
-		require.NoError(t, err)
-		require.Len(t, cols, 5)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (Id, Title, Amount, Active) VALUES (1, 'some title', 100, false)", nil, nil)
+	require.NoError(t, err)
 
-		for i := 0; i < rowCount; i++ {
-			row, err := r.Read()
-			require.NoError(t, err)
-			require.NotNil(t, row)
-			require.Len(t, row.Values, 5)
-			require.False(t, start.After(row.Values[EncodeSelector("", "db1", "mytable1", "ts")].Value().(time.Time)))
-			require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "mytable1", "d")].Value())
-			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "mytable1", "title")].Value())
-			require.Equal(t, i%2 == 0, row.Values[EncodeSelector("", "db1", "mytable1", "active")].Value())
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title, amount, active) VALUES (2, 'another title', 200, true)", nil, nil)
+	require.NoError(t, err)
 
-			encPayload := []byte(fmt.Sprintf("blob%d", i))
-			require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "mytable1", "payload")].Value())
-		}
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id) VALUES (1, 'yat')", nil, nil)
+	require.Equal(t, ErrInvalidNumberOfValues, err)
 
-		_, err = r.Read()
-		require.Equal(t, ErrNoMoreRows, err)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, id) VALUES (1, 2)", nil, nil)
+	require.ErrorIs(t, err, ErrDuplicatedColumn)
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, active) VALUES ('1', true)", nil, nil)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Contains(t, err.Error(), "is not an integer")
 
-	r, err = engine.Query("SELECT id, title, active, payload FROM table1 ORDER BY title", nil, nil)
-	require.Equal(t, ErrLimitedOrderBy, err)
-	require.Nil(t, r)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, active) VALUES (NULL, false)", nil, nil)
+	require.Equal(t, ErrPKCanNotBeNull, err)
 
-	r, err = engine.Query("SELECT Id, Title, Active, payload FROM Table1 ORDER BY Id DESC", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title, active) VALUES (2, NULL, true)", nil, nil)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 4)
-
-		require.Equal(t, int64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, (rowCount-1-i)%2 == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
-
-		encPayload := []byte(fmt.Sprintf("blob%d", rowCount-1-i))
-		require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "table1", "payload")].Value())
-	}
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (title, active) VALUES ('interesting title', true)", nil, nil)
+	require.Equal(t, ErrPKCanNotBeNull, err)
 
-	err = r.Close()
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE IF NOT EXISTS blob_table (id BLOB[2], PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
+}
 
-	r, err = engine.Query("SELECT id FROM table1 WHERE id", nil, nil)
+func TestInsertIntoEdgeCases(t *testing.T) {
+	st, err := store.Open("sqldata_insert", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_insert")
 
-	_, err = r.Read()
-	require.Equal(t, ErrInvalidCondition, err)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	err = r.Close()
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
-	params = make(map[string]interface{})
-	params["some_param1"] = true
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-	r, err = engine.Query("SELECT id FROM table1 WHERE active = @some_param1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR[10], active BOOLEAN, payload BLOB[2], PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrMissingParameter, err)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1 (title)", nil, nil)
+	require.NoError(t, err)
 
-	r.SetParameters(params)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1 (active)", nil, nil)
+	require.NoError(t, err)
 
-	row, err := r.Read()
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1 (payload)", nil, nil)
 	require.NoError(t, err)
-	require.Equal(t, int64(2), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
 
-	err = r.Close()
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, active, payload) VALUES (1, 'title1', true, x'00A1')", nil, nil)
 	require.NoError(t, err)
 
-	params = make(map[string]interface{})
-	params["some_param"] = true
+	t.Run("on conflict cases", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, active, payload) VALUES (1, 'title1', true, x'00A1')", nil, nil)
+		require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
 
-	encPayloadPrefix := hex.EncodeToString([]byte("blob"))
+		ntx, ctxs, err := engine.Exec(context.Background(), "INSERT INTO table1 (id, title, active, payload) VALUES (1, 'title1', true, x'00A1') ON CONFLICT DO NOTHING", nil, nil)
+		require.NoError(t, err)
+		require.Nil(t, ntx)
+		require.Len(t, ctxs, 1)
+		require.Zero(t, ctxs[0].UpdatedRows())
+		require.Nil(t, ctxs[0].TxHeader())
+	})
 
-	r, err = engine.Query(fmt.Sprintf(`
-		SELECT id, title, active
-		FROM table1
-		WHERE active = @some_param AND title > 'title' AND payload >= x'%s' AND title LIKE 't'`, encPayloadPrefix), params, nil)
-	require.NoError(t, err)
+	t.Run("varchar key cases", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, active, payload) VALUES (2, 'title123456789', true, x'00A1')", nil, nil)
+		require.ErrorIs(t, err, ErrMaxLengthExceeded)
 
-	for i := 0; i < rowCount/2; i += 2 {
-		row, err := r.Read()
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, active, payload) VALUES (2, 10, true, '00A1')", nil, nil)
+		require.ErrorIs(t, err, ErrInvalidValue)
+	})
+
+	t.Run("boolean key cases", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, active, payload) VALUES (2, 'title1', 'true', x'00A1')", nil, nil)
+		require.ErrorIs(t, err, ErrInvalidValue)
+	})
+
+	t.Run("blob key cases", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, active, payload) VALUES (2, 'title1', true, x'00A100A2')", nil, nil)
+		require.ErrorIs(t, err, ErrMaxLengthExceeded)
+
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, active, payload) VALUES (2, 'title1', true, '00A100A2')", nil, nil)
+		require.ErrorIs(t, err, ErrInvalidValue)
+	})
+
+	t.Run("insertion in table with varchar pk", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "CREATE TABLE languages (code VARCHAR[255],name VARCHAR[255],PRIMARY KEY code)", nil, nil)
 		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 3)
 
-		require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, params["some_param"], row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
-	}
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO languages (code,name) VALUES ('code1', 'name1')", nil, nil)
+		require.NoError(t, err)
+	})
+}
 
-	err = r.Close()
+func TestAutoIncrementPK(t *testing.T) {
+	st, err := store.Open("sqldata_auto_inc", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_auto_inc")
 
-	r, err = engine.Query("SELECT * FROM table1 WHERE id = 0", nil, nil)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	cols, err := r.Columns()
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
-	require.Len(t, cols, 5)
 
-	row, err = r.Read()
+	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
-	require.Len(t, row.Values, 5)
 
-	err = r.Close()
+	t.Run("invalid use of auto-increment", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR AUTO_INCREMENT, PRIMARY KEY id)", nil, nil)
+		require.ErrorIs(t, err, ErrLimitedAutoIncrement)
+
+		_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER AUTO_INCREMENT, PRIMARY KEY id)", nil, nil)
+		require.ErrorIs(t, err, ErrLimitedAutoIncrement)
+
+		_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id VARCHAR AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id)", nil, nil)
+		require.ErrorIs(t, err, ErrLimitedAutoIncrement)
+	})
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER NOT NULL AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
-	r, err = engine.Query("SELECT id, title, active FROM table1 WHERE id / 0", nil, nil)
+	_, ctxs, err := engine.Exec(context.Background(), "INSERT INTO table1(title) VALUES ('name1')", nil, nil)
 	require.NoError(t, err)
+	require.Len(t, ctxs, 1)
+	require.True(t, ctxs[0].closed)
+	require.Equal(t, int64(1), ctxs[0].LastInsertedPKs()["table1"])
+	require.Equal(t, int64(1), ctxs[0].FirstInsertedPKs()["table1"])
+	require.Equal(t, 1, ctxs[0].UpdatedRows())
 
-	_, err = r.Read()
-	require.Equal(t, ErrDivisionByZero, err)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(id, title) VALUES (1, 'name2')", nil, nil)
+	require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
 
-	err = r.Close()
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(id, title) VALUES (1, 'name2') ON CONFLICT DO NOTHING", nil, nil)
 	require.NoError(t, err)
 
-	r, err = engine.Query("SELECT id, title, active FROM table1 WHERE id + 1/1 > 1 * (1 - 0)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1(id, title) VALUES (1, 'name11')", nil, nil)
 	require.NoError(t, err)
 
-	_, err = r.Read()
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1(id, title) VALUES (2, 'name2')", nil, nil)
 	require.NoError(t, err)
 
-	err = r.Close()
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1(id, title) VALUES (3, 'name3')", nil, nil)
 	require.NoError(t, err)
 
-	r, err = engine.Query("SELECT id, title, active FROM table1 WHERE id = 0 AND NOT active OR active", nil, nil)
+	_, ctxs, err = engine.Exec(context.Background(), "INSERT INTO table1(title) VALUES ('name4')", nil, nil)
 	require.NoError(t, err)
+	require.Len(t, ctxs, 1)
+	require.True(t, ctxs[0].closed)
+	require.Equal(t, int64(4), ctxs[0].FirstInsertedPKs()["table1"])
+	require.Equal(t, int64(4), ctxs[0].LastInsertedPKs()["table1"])
+	require.Equal(t, 1, ctxs[0].UpdatedRows())
 
-	_, err = r.Read()
+	_, ctxs, err = engine.Exec(context.Background(), `
+		BEGIN TRANSACTION;
+			INSERT INTO table1(title) VALUES ('name5');
+			INSERT INTO table1(title) VALUES ('name6');
+		COMMIT;
+	`, nil, nil)
 	require.NoError(t, err)
+	require.Len(t, ctxs, 1)
+	require.True(t, ctxs[0].closed)
+	require.Equal(t, int64(5), ctxs[0].FirstInsertedPKs()["table1"])
+	require.Equal(t, int64(6), ctxs[0].LastInsertedPKs()["table1"])
+	require.Equal(t, 2, ctxs[0].UpdatedRows())
+}
 
-	err = r.Close()
+func TestInsertConflicts(t *testing.T) {
+	st, err := store.Open("sqldata_insert_conflicts", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_insert_conflicts")
 
-	r, err = engine.Query("INVALID QUERY", nil, nil)
-	require.EqualError(t, err, "syntax error: unexpected IDENTIFIER at position 7")
-	require.Nil(t, r)
-
-	r, err = engine.Query("UPSERT INTO table1 (id) VALUES(1)", nil, nil)
-	require.ErrorIs(t, err, ErrExpectingDQLStmt)
-	require.Nil(t, r)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	r, err = engine.Query("UPSERT INTO table1 (id) VALUES(1); UPSERT INTO table1 (id) VALUES(1)", nil, nil)
-	require.ErrorIs(t, err, ErrExpectingDQLStmt)
-	require.Nil(t, r)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE DATABASE db1;
+		USE DATABASE db1;
+		CREATE TABLE accounts (id INTEGER, owner VARCHAR[64] NOT NULL, balance INTEGER, PRIMARY KEY id);
+		CREATE INDEX ON accounts(owner);
+		INSERT INTO accounts(id, owner, balance) VALUES (1, 'alice', 10);
+	`, nil, nil)
+	require.NoError(t, err)
 
-	r, err = engine.QueryPreparedStmt(nil, nil, nil)
-	require.ErrorIs(t, err, ErrIllegalArguments)
-	require.Nil(t, r)
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-	params = make(map[string]interface{})
-	params["null_param"] = nil
+	requireAccounts := func(expected map[int64]string) {
+		r, err := engine.Query(context.Background(), "SELECT id, owner, balance FROM accounts", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	r, err = engine.Query("SELECT id FROM table1 WHERE active = @null_param", params, nil)
-	require.NoError(t, err)
+		accounts := make(map[int64]string)
 
-	_, err = r.Read()
-	require.ErrorIs(t, err, ErrNoMoreRows)
-
-	err = r.Close()
-	require.NoError(t, err)
-}
-
-func TestQueryDistinct(t *testing.T) {
-	st, err := store.Open("sqldata_qd", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_qd")
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
 
-	opts := DefaultOptions().WithPrefix(sqlPrefix).WithDistinctLimit(4)
-	engine, err := NewEngine(st, opts)
-	require.NoError(t, err)
+			accounts[row.Values[EncodeSelector("", "db1", "accounts", "id")].Value().(int64)] = fmt.Sprintf("%s:%d",
+				row.Values[EncodeSelector("", "db1", "accounts", "owner")].Value(),
+				row.Values[EncodeSelector("", "db1", "accounts", "balance")].Value())
+		}
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+		require.Equal(t, expected, accounts)
+	}
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+	t.Run("strict insert should fail on existing rows", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO accounts(id, owner, balance) VALUES (2, 'bob', 20), (1, 'bob', 20)", nil, nil)
+		require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
 
-	_, _, err = engine.Exec(`CREATE TABLE table1 (
-								id INTEGER AUTO_INCREMENT,
-								title VARCHAR,
-								amount INTEGER,
-								active BOOLEAN,
-								PRIMARY KEY id)`, nil, nil)
-	require.NoError(t, err)
+		requireAccounts(map[int64]string{1: "alice:10"})
+	})
 
-	_, _, err = engine.Exec(`INSERT INTO table1 (title, amount, active) VALUES
-								('title1', 100, NULL),
-								('title2', 200, false),
-								('title3', 200, true),
-								('title4', 300, NULL)`, nil, nil)
-	require.NoError(t, err)
+	t.Run("insert or ignore should skip existing rows", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT OR IGNORE INTO accounts(id, owner, balance) VALUES (1, 'bob', 20), (2, 'bob', 20)", nil, nil)
+		require.NoError(t, err)
 
-	t.Run("should return all titles", func(t *testing.T) {
-		params := make(map[string]interface{})
-		params["id"] = 3
+		requireAccounts(map[int64]string{1: "alice:10", 2: "bob:20"})
+	})
 
-		r, err := engine.Query("SELECT DISTINCT title FROM table1 WHERE id <= @id", nil, nil)
+	t.Run("existing rows should be updated on conflict", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), `
+			INSERT INTO accounts(id, owner, balance) VALUES (1, 'carol', 5), (3, 'dave', 1)
+			ON CONFLICT DO UPDATE SET balance = balance + excluded.balance, owner = excluded.owner
+		`, nil, nil)
 		require.NoError(t, err)
 
-		r.SetParameters(params)
+		requireAccounts(map[int64]string{1: "carol:15", 2: "bob:20", 3: "dave:1"})
 
-		cols, err := r.Columns()
+		r, err := engine.Query(context.Background(), "SELECT id FROM accounts WHERE owner = 'alice'", nil, nil)
 		require.NoError(t, err)
-		require.Len(t, cols, 1)
-		require.Equal(t, "(db1.table1.title)", cols[0].Selector())
-
-		for i := 1; i <= 3; i++ {
-			row, err := r.Read()
-			require.NoError(t, err)
-			require.Len(t, row.Values, 1)
-			require.Equal(t, fmt.Sprintf("title%d", i), row.Values["(db1.table1.title)"].Value())
-		}
 
 		_, err = r.Read()
 		require.ErrorIs(t, err, ErrNoMoreRows)
 
 		err = r.Close()
 		require.NoError(t, err)
-	})
 
-	t.Run("should return two titles", func(t *testing.T) {
-		params := make(map[string]interface{})
-		params["id"] = 3
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO accounts(id, owner) VALUES (1, 'erin') ON CONFLICT DO UPDATE SET id = 4", nil, nil)
+		require.ErrorIs(t, err, ErrPKCanNotBeUpdated)
 
-		r, err := engine.Query("SELECT DISTINCT title FROM table1 WHERE id <= @id LIMIT 2", nil, nil)
-		require.NoError(t, err)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO accounts(id, owner) VALUES (2, 'erin') ON CONFLICT DO UPDATE SET owner = excluded.balance", nil, nil)
+		require.ErrorIs(t, err, ErrNotNullableColumnCannotBeNull)
 
-		r.SetParameters(params)
+		requireAccounts(map[int64]string{1: "carol:15", 2: "bob:20", 3: "dave:1"})
+	})
 
-		cols, err := r.Columns()
+	t.Run("concurrent inserts of the same row should conflict at commit time", func(t *testing.T) {
+		tx1, _, err := engine.Exec(context.Background(), "BEGIN TRANSACTION; INSERT INTO accounts(id, owner, balance) VALUES (4, 'frank', 1);", nil, nil)
 		require.NoError(t, err)
-		require.Len(t, cols, 1)
-		require.Equal(t, "(db1.table1.title)", cols[0].Selector())
-
-		for i := 1; i <= 2; i++ {
-			row, err := r.Read()
-			require.NoError(t, err)
-			require.Len(t, row.Values, 1)
-			require.Equal(t, fmt.Sprintf("title%d", i), row.Values["(db1.table1.title)"].Value())
-		}
 
-		_, err = r.Read()
-		require.ErrorIs(t, err, ErrNoMoreRows)
+		tx2, _, err := engine.Exec(context.Background(), "BEGIN TRANSACTION; INSERT INTO accounts(id, owner, balance) VALUES (4, 'grace', 2);", nil, nil)
+		require.NoError(t, err)
 
-		err = r.Close()
+		_, _, err = engine.Exec(context.Background(), "COMMIT;", nil, tx1)
 		require.NoError(t, err)
-	})
 
-	t.Run("should return two distinct amounts", func(t *testing.T) {
-		params := make(map[string]interface{})
-		params["id"] = 3
+		_, _, err = engine.Exec(context.Background(), "COMMIT;", nil, tx2)
+		require.ErrorIs(t, err, store.ErrTxReadConflict)
 
-		r, err := engine.Query("SELECT DISTINCT amount FROM table1 WHERE id <= @id", params, nil)
-		require.NoError(t, err)
+		requireAccounts(map[int64]string{1: "carol:15", 2: "bob:20", 3: "dave:1", 4: "frank:1"})
+	})
+}
 
-		cols, err := r.Columns()
-		require.NoError(t, err)
-		require.Len(t, cols, 1)
-		require.Equal(t, "(db1.table1.amount)", cols[0].Selector())
+func TestCheckConstraints(t *testing.T) {
+	st, err := store.Open("sqldata_check_constraints", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_check_constraints")
 
-		for i := 1; i <= 2; i++ {
-			row, err := r.Read()
-			require.NoError(t, err)
-			require.Len(t, row.Values, 1)
-			require.Equal(t, int64(i*100), row.Values["(db1.table1.amount)"].Value())
-		}
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
 
-		_, err = r.Read()
-		require.ErrorIs(t, err, ErrNoMoreRows)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE DATABASE db1;
+		USE DATABASE db1;
+		CREATE TABLE accounts (
+			id INTEGER AUTO_INCREMENT,
+			owner VARCHAR[64],
+			balance INTEGER NOT NULL,
+			CHECK (balance >= 0),
+			CHECK (owner IS NULL OR owner != 'root'),
+			PRIMARY KEY id
+		);
+		INSERT INTO accounts(owner, balance) VALUES ('alice', 10), (NULL, 0);
+	`, nil, nil)
+	require.NoError(t, err)
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-	t.Run("should return rows with null, false and true", func(t *testing.T) {
-		params := make(map[string]interface{})
-		params["id"] = 3
+	t.Run("invalid checks should be rejected", func(t *testing.T) {
+		_, _, err := engine.Exec(context.Background(), "CREATE TABLE t1 (id INTEGER, CHECK (id + 1), PRIMARY KEY id)", nil, nil)
+		require.ErrorIs(t, err, ErrInvalidCheckConstraint)
 
-		r, err := engine.Query("SELECT DISTINCT active FROM table1 WHERE id <= @id", params, nil)
-		require.NoError(t, err)
+		_, _, err = engine.Exec(context.Background(), "CREATE TABLE t1 (id INTEGER, CHECK (amount > 0), PRIMARY KEY id)", nil, nil)
+		require.ErrorIs(t, err, ErrInvalidCheckConstraint)
 
-		cols, err := r.Columns()
-		require.NoError(t, err)
-		require.Len(t, cols, 1)
-		require.Equal(t, "(db1.table1.active)", cols[0].Selector())
+		_, _, err = engine.Exec(context.Background(), "CREATE TABLE t1 (id INTEGER, CHECK (id > @lower), PRIMARY KEY id)", map[string]interface{}{"lower": 0}, nil)
+		require.ErrorIs(t, err, ErrInvalidCheckConstraint)
+	})
 
-		for i := 0; i <= 2; i++ {
-			row, err := r.Read()
-			require.NoError(t, err)
-			require.Len(t, row.Values, 1)
+	t.Run("rows violating checks should be rejected", func(t *testing.T) {
+		_, _, err := engine.Exec(context.Background(), "INSERT INTO accounts(owner, balance) VALUES ('bob', -1)", nil, nil)
+		require.ErrorIs(t, err, ErrCheckConstraintViolated)
 
-			if i == 0 {
-				require.Nil(t, row.Values["(db1.table1.active)"].Value())
-				continue
-			}
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO accounts(owner, balance) VALUES ('root', 1)", nil, nil)
+		require.ErrorIs(t, err, ErrCheckConstraintViolated)
 
-			require.Equal(t, i == 2, row.Values["(db1.table1.active)"].Value())
-		}
+		_, _, err = engine.Exec(context.Background(), "UPSERT INTO accounts(id, owner, balance) VALUES (1, 'alice', -5)", nil, nil)
+		require.ErrorIs(t, err, ErrCheckConstraintViolated)
 
-		_, err = r.Read()
-		require.ErrorIs(t, err, ErrNoMoreRows)
+		_, _, err = engine.Exec(context.Background(), "UPDATE accounts SET balance = balance - 5", nil, nil)
+		require.ErrorIs(t, err, ErrCheckConstraintViolated)
 
-		err = r.Close()
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO accounts(id, owner, balance) VALUES (1, 'alice', 1) ON CONFLICT DO UPDATE SET balance = balance - 20", nil, nil)
+		require.ErrorIs(t, err, ErrCheckConstraintViolated)
+
+		_, _, err = engine.Exec(context.Background(), "UPDATE accounts SET balance = balance - 5 WHERE id = 1", nil, nil)
 		require.NoError(t, err)
 	})
 
-	t.Run("should return three rows", func(t *testing.T) {
-		params := make(map[string]interface{})
-		params["id"] = 3
+	t.Run("checks evaluated to unknown should not be violated", func(t *testing.T) {
+		_, _, err := engine.Exec(context.Background(), `
+			CREATE TABLE people (id INTEGER, PRIMARY KEY id, age INTEGER, CHECK (age > 0));
+			INSERT INTO people(id, age) VALUES (1, NULL), (2, 18);
+			INSERT INTO people(id) VALUES (3);
+		`, nil, nil)
+		require.NoError(t, err)
 
-		r, err := engine.Query("SELECT DISTINCT amount, active FROM table1 WHERE id <= @id", params, nil)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO people(id, age) VALUES (4, 0)", nil, nil)
+		require.ErrorIs(t, err, ErrCheckConstraintViolated)
+
+		_, _, err = engine.Exec(context.Background(), "CREATE TABLE t2 (id INTEGER, age INTEGER, CHECK (NOT (age > 0) OR id > 0), PRIMARY KEY id)", nil, nil)
 		require.NoError(t, err)
 
-		cols, err := r.Columns()
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO t2(id, age) VALUES (-1, NULL)", nil, nil)
 		require.NoError(t, err)
-		require.Len(t, cols, 2)
-		require.Equal(t, "(db1.table1.amount)", cols[0].Selector())
-		require.Equal(t, "(db1.table1.active)", cols[1].Selector())
 
-		for i := 0; i <= 2; i++ {
-			row, err := r.Read()
-			require.NoError(t, err)
-			require.Len(t, row.Values, 2)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO t2(id, age) VALUES (-2, 1)", nil, nil)
+		require.ErrorIs(t, err, ErrCheckConstraintViolated)
+	})
 
-			if i == 0 {
-				require.Equal(t, int64(100), row.Values["(db1.table1.amount)"].Value())
-				require.Nil(t, row.Values["(db1.table1.active)"].Value())
-				continue
-			}
+	t.Run("checks declared along with a column should be enforced", func(t *testing.T) {
+		_, _, err := engine.Exec(context.Background(), `
+			CREATE TABLE items (id INTEGER, qty INTEGER NOT NULL CHECK (qty > 0), PRIMARY KEY id);
+			INSERT INTO items(id, qty) VALUES (1, 5);
+		`, nil, nil)
+		require.NoError(t, err)
 
-			require.Equal(t, int64(200), row.Values["(db1.table1.amount)"].Value())
-			require.Equal(t, i == 2, row.Values["(db1.table1.active)"].Value())
-		}
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO items(id, qty) VALUES (2, 0)", nil, nil)
+		require.ErrorIs(t, err, ErrCheckConstraintViolated)
 
-		_, err = r.Read()
-		require.ErrorIs(t, err, ErrNoMoreRows)
+		_, _, err = engine.Exec(context.Background(), "ALTER TABLE items DROP COLUMN qty", nil, nil)
+		require.ErrorIs(t, err, ErrColumnReferencedByCheck)
 
-		err = r.Close()
-		require.NoError(t, err)
+		_, _, err = engine.Exec(context.Background(), "ALTER TABLE items ADD COLUMN price INTEGER CHECK (price > 0)", nil, nil)
+		require.ErrorIs(t, err, ErrInvalidCheckConstraint)
 	})
 
-	t.Run("should return too many rows error", func(t *testing.T) {
-		r, err := engine.Query("SELECT DISTINCT id FROM table1", nil, nil)
-		require.NoError(t, err)
+	t.Run("columns referenced by checks should not be altered", func(t *testing.T) {
+		_, _, err := engine.Exec(context.Background(), "ALTER TABLE accounts DROP COLUMN owner", nil, nil)
+		require.ErrorIs(t, err, ErrColumnReferencedByCheck)
 
-		cols, err := r.Columns()
+		_, _, err = engine.Exec(context.Background(), "ALTER TABLE accounts RENAME COLUMN balance TO amount", nil, nil)
+		require.ErrorIs(t, err, ErrColumnReferencedByCheck)
+
+		_, _, err = engine.Exec(context.Background(), "ALTER TABLE accounts ADD COLUMN notes VARCHAR", nil, nil)
 		require.NoError(t, err)
-		require.Len(t, cols, 1)
-		require.Equal(t, "(db1.table1.id)", cols[0].Selector())
+	})
 
-		for i := 0; i < engine.distinctLimit; i++ {
-			row, err := r.Read()
-			require.NoError(t, err)
-			require.Len(t, row.Values, 1)
+	t.Run("checks should be loaded from the catalog", func(t *testing.T) {
+		engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+		require.NoError(t, err)
 
-			require.Equal(t, int64(i+1), row.Values["(db1.table1.id)"].Value())
-		}
+		err = engine.SetDefaultDatabase("db1")
+		require.NoError(t, err)
 
-		_, err = r.Read()
-		require.ErrorIs(t, err, ErrTooManyRows)
+		_, _, err = engine.Exec(context.Background(), "UPDATE accounts SET balance = -1 WHERE id = 2", nil, nil)
+		require.ErrorIs(t, err, ErrCheckConstraintViolated)
 
-		err = r.Close()
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO accounts(owner, balance) VALUES ('carol', 3)", nil, nil)
 		require.NoError(t, err)
 	})
 }
 
-func TestIndexing(t *testing.T) {
-	st, err := store.Open("sqldata_indexing", store.DefaultOptions())
+func TestDelete(t *testing.T) {
+	st, err := store.Open("sqldata_delete", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_indexing")
+	defer os.RemoveAll("sqldata_delete")
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
+	_, _, err = engine.Exec(context.Background(), "DELETE FROM table1", nil, nil)
+	require.ErrorIs(t, err, ErrNoDatabaseSelected)
+
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(`CREATE TABLE table1 (
-								id INTEGER AUTO_INCREMENT,
-								ts INTEGER,
-								title VARCHAR[20],
-								active BOOLEAN,
-								amount INTEGER,
-								payload BLOB,
-								PRIMARY KEY id
-							)`, nil, nil)
+	_, _, err = engine.Exec(context.Background(), `CREATE TABLE table1 (
+		id INTEGER,
+		title VARCHAR[50],
+		active BOOLEAN,
+		PRIMARY KEY id
+	)`, nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1 (ts)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE UNIQUE INDEX ON table1(title)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE UNIQUE INDEX ON table1 (title, amount)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(active)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1 (active, title)", nil, nil)
+	params, err := engine.InferParameters(context.Background(), "DELETE FROM table1 WHERE active = @active", nil)
 	require.NoError(t, err)
+	require.NotNil(t, params)
+	require.Len(t, params, 1)
+	require.Equal(t, params["active"], BooleanType)
 
-	_, _, err = engine.Exec("CREATE UNIQUE INDEX ON table1 (title)", nil, nil)
-	require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "DELETE FROM table2", nil, nil)
+	require.ErrorIs(t, err, ErrTableDoesNotExist)
 
-	t.Run("should fail due to unique index", func(t *testing.T) {
-		_, _, err = engine.Exec("INSERT INTO table1 (ts, title, amount, active) VALUES (1, 'title1', 10, true), (2, 'title1', 10, false)", nil, nil)
-		require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
+	_, _, err = engine.Exec(context.Background(), "DELETE FROM table1 WHERE name = 'name1'", nil, nil)
+	require.ErrorIs(t, err, ErrColumnDoesNotExist)
+
+	t.Run("delete on empty table should complete without issues", func(t *testing.T) {
+		_, ctxs, err := engine.Exec(context.Background(), "DELETE FROM table1", nil, nil)
+		require.NoError(t, err)
+		require.Len(t, ctxs, 1)
+		require.Zero(t, ctxs[0].UpdatedRows())
 	})
 
-	t.Run("should fail due non-available index", func(t *testing.T) {
-		_, err = engine.Query("SELECT * FROM table1 ORDER BY amount DESC", nil, nil)
-		require.ErrorIs(t, err, ErrNoAvailableIndex)
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf(`
+			INSERT INTO table1 (id, title, active) VALUES (%d, 'title%d', %v)`, i, i, i%2 == 0), nil, nil)
+		require.NoError(t, err)
+	}
+
+	t.Run("deleting with contradiction should not produce any change", func(t *testing.T) {
+		_, ctxs, err := engine.Exec(context.Background(), "DELETE FROM table1 WHERE false", nil, nil)
+		require.NoError(t, err)
+		require.Len(t, ctxs, 1)
+		require.Zero(t, ctxs[0].UpdatedRows())
 	})
 
-	t.Run("should use primary index by default", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1", nil, nil)
+	t.Run("deleting active rows should remove half of the rows", func(t *testing.T) {
+		_, ctxs, err := engine.Exec(context.Background(), "DELETE FROM table1 WHERE active = @active", map[string]interface{}{"active": true}, nil)
 		require.NoError(t, err)
+		require.Len(t, ctxs, 1)
+		require.Equal(t, rowCount/2, ctxs[0].UpdatedRows())
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "id", orderBy[0].Column)
+		r, err := engine.Query(context.Background(), "SELECT COUNT(*) FROM table1", nil, nil)
+		require.NoError(t, err)
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.True(t, scanSpecs.index.IsPrimary())
-		require.Empty(t, scanSpecs.rangesByColID)
-		require.False(t, scanSpecs.descOrder)
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(rowCount/2), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
 
 		err = r.Close()
 		require.NoError(t, err)
-	})
 
-	t.Run("should use primary index in descending order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 ORDER BY id DESC", nil, nil)
+		r, err = engine.Query(context.Background(), "SELECT COUNT(*) FROM table1 WHERE active", nil, nil)
 		require.NoError(t, err)
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "id", orderBy[0].Column)
-
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.True(t, scanSpecs.index.IsPrimary())
-		require.Empty(t, scanSpecs.rangesByColID)
-		require.True(t, scanSpecs.descOrder)
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(0), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
 
-	t.Run("should use index on `ts` ascending order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 ORDER BY ts", nil, nil)
+	t.Run("deleted rows should remain in the history", func(t *testing.T) {
+		_, ctxs, err := engine.Exec(context.Background(), "DELETE FROM table1", nil, nil)
 		require.NoError(t, err)
+		require.Len(t, ctxs, 1)
+		require.Equal(t, rowCount/2, ctxs[0].UpdatedRows())
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "ts", orderBy[0].Column)
+		r, err := engine.Query(context.Background(), fmt.Sprintf("SELECT COUNT(*) FROM table1 BEFORE TX %d", ctxs[0].TxHeader().ID), nil, nil)
+		require.NoError(t, err)
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.False(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Empty(t, scanSpecs.rangesByColID)
-		require.False(t, scanSpecs.descOrder)
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(rowCount/2), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
 
 		err = r.Close()
 		require.NoError(t, err)
-	})
 
-	t.Run("should use index on `ts` descending order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 ORDER BY ts DESC", nil, nil)
+		r, err = engine.Query(context.Background(), "SELECT id FROM table1", nil, nil)
 		require.NoError(t, err)
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "ts", orderBy[0].Column)
-
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.False(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Empty(t, scanSpecs.rangesByColID)
-		require.True(t, scanSpecs.descOrder)
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
+}
 
-	t.Run("should use index on `ts` with specific value", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 WHERE ts = 1629902962 OR ts < 1629902963 ORDER BY ts", nil, nil)
-		require.NoError(t, err)
+func TestQueryBeforeTimestamp(t *testing.T) {
+	st, err := store.Open("before_timestamp", store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
+	defer os.RemoveAll("before_timestamp")
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "ts", orderBy[0].Column)
+	now := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.False(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Len(t, scanSpecs.rangesByColID, 1)
+	err = st.UseTimeFunc(func() time.Time { return now })
+	require.NoError(t, err)
 
-		tsRange := scanSpecs.rangesByColID[2]
-		require.Nil(t, tsRange.lRange)
-		require.NotNil(t, tsRange.hRange)
-		require.False(t, tsRange.hRange.inclusive)
-		require.Equal(t, int64(1629902963), tsRange.hRange.val.Value())
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-		require.False(t, scanSpecs.descOrder)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-	t.Run("should use index on `ts` with specific value", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 AS t WHERE t.ts = 1629902962 AND t.ts = 1629902963 ORDER BY t.ts", nil, nil)
-		require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "ts", orderBy[0].Column)
+	// one row per hour, the title being updated at the end
+	for i := 0; i < 5; i++ {
+		now = now.Add(time.Hour)
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.False(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Len(t, scanSpecs.rangesByColID, 1)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title) VALUES (@id, 'title')", map[string]interface{}{"id": i}, nil)
+		require.NoError(t, err)
+	}
 
-		tsRange := scanSpecs.rangesByColID[2]
-		require.NotNil(t, tsRange.lRange)
-		require.True(t, tsRange.lRange.inclusive)
-		require.Equal(t, int64(1629902963), tsRange.lRange.val.Value())
-		require.NotNil(t, tsRange.hRange)
-		require.True(t, tsRange.hRange.inclusive)
-		require.Equal(t, int64(1629902962), tsRange.hRange.val.Value())
+	now = now.Add(time.Hour)
 
-		require.False(t, scanSpecs.descOrder)
+	_, _, err = engine.Exec(context.Background(), "UPDATE table1 SET title = 'updated'", nil, nil)
+	require.NoError(t, err)
 
-		err = r.Close()
+	countBefore := func(t *testing.T, q string, params map[string]interface{}) int64 {
+		r, err := engine.Query(context.Background(), q, params, nil)
 		require.NoError(t, err)
-	})
+		defer r.Close()
 
-	t.Run("should use index on `ts` with specific value", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 WHERE ts > 1629902962 AND ts < 1629902963 ORDER BY ts", nil, nil)
+		row, err := r.Read()
 		require.NoError(t, err)
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "ts", orderBy[0].Column)
-
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.False(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Len(t, scanSpecs.rangesByColID, 1)
+		return row.Values[EncodeSelector("", "db1", "table1", "col0")].Value().(int64)
+	}
 
-		tsRange := scanSpecs.rangesByColID[2]
-		require.NotNil(t, tsRange.lRange)
-		require.False(t, tsRange.lRange.inclusive)
-		require.Equal(t, int64(1629902962), tsRange.lRange.val.Value())
-		require.NotNil(t, tsRange.hRange)
-		require.False(t, tsRange.hRange.inclusive)
-		require.Equal(t, int64(1629902963), tsRange.hRange.val.Value())
+	require.Equal(t, int64(0), countBefore(t, "SELECT COUNT(*) FROM table1 BEFORE TIMESTAMP '2021-12-01 11:00'", nil))
+	require.Equal(t, int64(1), countBefore(t, "SELECT COUNT(*) FROM table1 BEFORE TIMESTAMP '2021-12-01 11:30'", nil))
+	require.Equal(t, int64(3), countBefore(t, "SELECT COUNT(*) FROM table1 BEFORE @ts", map[string]interface{}{
+		"ts": time.Date(2021, 12, 1, 14, 0, 0, 0, time.UTC),
+	}))
+	require.Equal(t, int64(5), countBefore(t, "SELECT COUNT(*) FROM table1 BEFORE NOW()", nil))
+	require.Equal(t, int64(0), countBefore(t, "SELECT COUNT(*) FROM table1 BEFORE TIMESTAMP '2021-12-01 16:00' WHERE title = 'updated'", nil))
+	require.Equal(t, int64(5), countBefore(t, "SELECT COUNT(*) FROM table1 BEFORE TIMESTAMP '2021-12-01 16:01' WHERE title = 'updated'", nil))
 
-		require.False(t, scanSpecs.descOrder)
+	params, err := engine.InferParameters(context.Background(), "SELECT id FROM table1 BEFORE @ts", nil)
+	require.NoError(t, err)
+	require.Equal(t, TimestampType, params["ts"])
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
+	_, err = engine.InferParameters(context.Background(), "SELECT id FROM table1 BEFORE 'not a timestamp'", nil)
+	require.ErrorIs(t, err, ErrInvalidTypes)
 
-	t.Run("should use index on `title, amount` in asc order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 USE INDEX ON (title, amount) ORDER BY title", nil, nil)
-		require.NoError(t, err)
+	r, err := engine.Query(context.Background(), "SELECT id FROM table1 BEFORE 'not a timestamp'", nil, nil)
+	require.NoError(t, err)
+	defer r.Close()
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 2)
-		require.Equal(t, "title", orderBy[0].Column)
-		require.Equal(t, "amount", orderBy[1].Column)
+	_, err = r.Read()
+	require.ErrorIs(t, err, ErrInvalidTypes)
+}
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.True(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 2)
-		require.Empty(t, scanSpecs.rangesByColID)
-		require.False(t, scanSpecs.descOrder)
+func TestPerTableTemporalQualifiers(t *testing.T) {
+	st, err := store.Open("per_table_temporal", store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
+	defer os.RemoveAll("per_table_temporal")
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	t.Run("should use index on `title` in asc order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 USE INDEX ON (title) ORDER BY title", nil, nil)
-		require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "title", orderBy[0].Column)
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.True(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Empty(t, scanSpecs.rangesByColID)
-		require.False(t, scanSpecs.descOrder)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE customers (id INTEGER, name VARCHAR, country VARCHAR[2], PRIMARY KEY id);
+		CREATE INDEX ON customers(country);
+		CREATE TABLE orders (id INTEGER, customer_id INTEGER, amount INTEGER, PRIMARY KEY id);
+		`, nil, nil)
+	require.NoError(t, err)
 
-		err = r.Close()
+	exec := func(sql string) uint64 {
+		_, ctxs, err := engine.Exec(context.Background(), sql, nil, nil)
 		require.NoError(t, err)
-	})
+		require.Len(t, ctxs, 1)
+		return ctxs[0].TxHeader().ID
+	}
 
-	t.Run("should use index on `ts` in default order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 USE INDEX ON (ts)", nil, nil)
+	exec("INSERT INTO customers (id, name, country) VALUES (1, 'alice', 'IT'), (2, 'bob', 'ES')")
+	ordersTx := exec("INSERT INTO orders (id, customer_id, amount) VALUES (1, 1, 10)")
+	renameTx := exec("UPDATE customers SET name = 'alice2' WHERE id = 1")
+	amendTx := exec(`
+		BEGIN TRANSACTION;
+			UPDATE orders SET amount = 20 WHERE id = 1;
+			INSERT INTO orders (id, customer_id, amount) VALUES (2, 2, 5);
+		COMMIT;
+		`)
+
+	query := func(t *testing.T, q string, cols ...string) [][]interface{} {
+		r, err := engine.Query(context.Background(), q, nil, nil)
 		require.NoError(t, err)
+		defer r.Close()
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "ts", orderBy[0].Column)
+		var rows [][]interface{}
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.False(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Empty(t, scanSpecs.rangesByColID)
-		require.False(t, scanSpecs.descOrder)
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
 
-		err = r.Close()
-		require.NoError(t, err)
+			var vals []interface{}
+			for _, c := range cols {
+				vals = append(vals, row.Values[c].Value())
+			}
+
+			rows = append(rows, vals)
+		}
+
+		return rows
+	}
+
+	t.Run("joined tables should be read at their own point in history", func(t *testing.T) {
+		rows := query(t,
+			fmt.Sprintf(`
+				SELECT o.id, c.name, o.amount
+				FROM orders BEFORE TX %d AS o
+				INNER JOIN customers BEFORE TX %d AS c ON c.id = o.customer_id`, amendTx, renameTx),
+			EncodeSelector("", "db1", "o", "id"),
+			EncodeSelector("", "db1", "c", "name"),
+			EncodeSelector("", "db1", "o", "amount"),
+		)
+		require.Equal(t, [][]interface{}{{int64(1), "alice", int64(10)}}, rows)
+
+		rows = query(t,
+			fmt.Sprintf(`
+				SELECT o.id, c.name, o.amount
+				FROM orders AS o
+				INNER JOIN customers BEFORE TX %d AS c ON c.id = o.customer_id`, renameTx),
+			EncodeSelector("", "db1", "o", "id"),
+			EncodeSelector("", "db1", "c", "name"),
+			EncodeSelector("", "db1", "o", "amount"),
+		)
+		require.Equal(t, [][]interface{}{{int64(1), "alice", int64(20)}, {int64(2), "bob", int64(5)}}, rows)
 	})
 
-	t.Run("should fail using index on `ts` when ordering by `title`", func(t *testing.T) {
-		_, err := engine.Query("SELECT * FROM table1 USE INDEX ON (ts) ORDER BY title", nil, nil)
-		require.ErrorIs(t, err, ErrNoAvailableIndex)
+	t.Run("rows read through a secondary index should be read at the same point in history", func(t *testing.T) {
+		rows := query(t,
+			fmt.Sprintf("SELECT id, name FROM customers BEFORE TX %d WHERE country = 'IT' ORDER BY country", renameTx),
+			EncodeSelector("", "db1", "customers", "name"),
+		)
+		require.Equal(t, [][]interface{}{{"alice"}}, rows)
 	})
 
-	t.Run("should use index on `title` with max value in desc order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 USE INDEX ON (title) WHERE title < 'title10' ORDER BY title DESC", nil, nil)
-		require.NoError(t, err)
+	t.Run("only rows written since the given tx should be read", func(t *testing.T) {
+		rows := query(t,
+			fmt.Sprintf("SELECT id FROM customers SINCE TX %d", renameTx),
+			EncodeSelector("", "db1", "customers", "id"),
+		)
+		require.Equal(t, [][]interface{}{{int64(1)}}, rows)
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "title", orderBy[0].Column)
+		rows = query(t,
+			fmt.Sprintf("SELECT id, amount FROM orders SINCE TX %d BEFORE TX %d", ordersTx, amendTx),
+			EncodeSelector("", "db1", "orders", "id"),
+			EncodeSelector("", "db1", "orders", "amount"),
+		)
+		require.Equal(t, [][]interface{}{{int64(1), int64(10)}}, rows)
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.True(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Len(t, scanSpecs.rangesByColID, 1)
+		rows = query(t,
+			fmt.Sprintf("SELECT id FROM customers SINCE TX %d ORDER BY country", renameTx),
+			EncodeSelector("", "db1", "customers", "id"),
+		)
+		require.Equal(t, [][]interface{}{{int64(1)}}, rows)
 
-		titleRange := scanSpecs.rangesByColID[3]
-		require.Nil(t, titleRange.lRange)
-		require.NotNil(t, titleRange.hRange)
-		require.False(t, titleRange.hRange.inclusive)
-		require.Equal(t, "title10", titleRange.hRange.val.Value())
+		rows = query(t,
+			fmt.Sprintf("SELECT id FROM orders SINCE TX %d", amendTx+1),
+			EncodeSelector("", "db1", "orders", "id"),
+		)
+		require.Empty(t, rows)
+	})
+}
 
-		require.True(t, scanSpecs.descOrder)
+func TestErrorDuringDelete(t *testing.T) {
+	st, err := store.Open("err_during_delete", store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
+	defer os.RemoveAll("err_during_delete")
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	t.Run("should use index on `title,amount` in desc order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 WHERE title = 'title1' ORDER BY amount DESC", nil, nil)
-		require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 2)
-		require.Equal(t, "title", orderBy[0].Column)
-		require.Equal(t, "amount", orderBy[1].Column)
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.True(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 2)
-		require.Len(t, scanSpecs.rangesByColID, 1)
+	_, _, err = engine.Exec(context.Background(), `
+		create table mytable(name varchar[30], primary key name);
+		insert into mytable(name) values('name1');
+	`, nil, nil)
+	require.NoError(t, err)
 
-		titleRange := scanSpecs.rangesByColID[3]
-		require.NotNil(t, titleRange.lRange)
-		require.True(t, titleRange.lRange.inclusive)
-		require.Equal(t, "title1", titleRange.lRange.val.Value())
-		require.NotNil(t, titleRange.hRange)
-		require.True(t, titleRange.hRange.inclusive)
-		require.Equal(t, "title1", titleRange.hRange.val.Value())
-
-		require.True(t, scanSpecs.descOrder)
+	_, _, err = engine.Exec(context.Background(), "delete FROM mytable where name=name1", nil, nil)
+	require.ErrorIs(t, err, ErrColumnDoesNotExist)
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
+	_, _, err = engine.Exec(context.Background(), "delete FROM mytable where name='name1'", nil, nil)
+	require.NoError(t, err)
+}
 
-	t.Run("should use index on `ts` ascending order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 WHERE title > 'title10' ORDER BY ts ASC", nil, nil)
-		require.NoError(t, err)
+func TestUpdate(t *testing.T) {
+	st, err := store.Open("sqldata_update", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_update")
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "ts", orderBy[0].Column)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.False(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Len(t, scanSpecs.rangesByColID, 1)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
 
-		titleRange := scanSpecs.rangesByColID[3]
-		require.NotNil(t, titleRange.lRange)
-		require.False(t, titleRange.lRange.inclusive)
-		require.Equal(t, "title10", titleRange.lRange.val.Value())
-		require.Nil(t, titleRange.hRange)
+	_, _, err = engine.Exec(context.Background(), "UPDATE table1 SET title = 'title11' WHERE title = 'title'", nil, nil)
+	require.ErrorIs(t, err, ErrNoDatabaseSelected)
 
-		require.False(t, scanSpecs.descOrder)
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
+	_, _, err = engine.Exec(context.Background(), `CREATE TABLE table1 (
+		id INTEGER,
+		title VARCHAR[50],
+		active BOOLEAN,
+		PRIMARY KEY id
+	)`, nil, nil)
+	require.NoError(t, err)
 
-	t.Run("should use index on `ts` descending order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 WHERE title > 'title10' or title = 'title1' ORDER BY ts DESC", nil, nil)
-		require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), "CREATE UNIQUE INDEX ON table1(title)", nil, nil)
+	require.NoError(t, err)
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "ts", orderBy[0].Column)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(active)", nil, nil)
+	require.NoError(t, err)
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.False(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Len(t, scanSpecs.rangesByColID, 1)
+	params, err := engine.InferParameters(context.Background(), "UPDATE table1 SET active = @active", nil)
+	require.NoError(t, err)
+	require.NotNil(t, params)
+	require.Len(t, params, 1)
+	require.Equal(t, params["active"], BooleanType)
 
-		titleRange := scanSpecs.rangesByColID[3]
-		require.NotNil(t, titleRange.lRange)
-		require.True(t, titleRange.lRange.inclusive)
-		require.Equal(t, "title1", titleRange.lRange.val.Value())
-		require.Nil(t, titleRange.hRange)
+	_, _, err = engine.Exec(context.Background(), "UPDATE table2 SET active = false", nil, nil)
+	require.ErrorIs(t, err, ErrTableDoesNotExist)
 
-		require.True(t, scanSpecs.descOrder)
+	_, _, err = engine.Exec(context.Background(), "UPDATE table1 SET name = 'name1'", nil, nil)
+	require.ErrorIs(t, err, ErrColumnDoesNotExist)
 
-		err = r.Close()
+	t.Run("update on empty table should complete without issues", func(t *testing.T) {
+		_, ctxs, err := engine.Exec(context.Background(), "UPDATE table1 SET active = false", nil, nil)
 		require.NoError(t, err)
+		require.Len(t, ctxs, 1)
+		require.Zero(t, ctxs[0].UpdatedRows())
 	})
 
-	t.Run("should use index on `title` descending order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 WHERE title > 'title10' or title = 'title1' ORDER BY title DESC", nil, nil)
-		require.NoError(t, err)
-
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 2)
-		require.Equal(t, "title", orderBy[0].Column)
-		require.Equal(t, "amount", orderBy[1].Column)
-
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.True(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 2)
-		require.Len(t, scanSpecs.rangesByColID, 1)
-
-		titleRange := scanSpecs.rangesByColID[3]
-		require.NotNil(t, titleRange.lRange)
-		require.True(t, titleRange.lRange.inclusive)
-		require.Equal(t, "title1", titleRange.lRange.val.Value())
-		require.Nil(t, titleRange.hRange)
+	rowCount := 10
 
-		require.True(t, scanSpecs.descOrder)
+	for i := 0; i < rowCount; i++ {
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf(`
+			INSERT INTO table1 (id, title, active) VALUES (%d, 'title%d', %v)`, i, i, i%2 == 0), nil, nil)
+		require.NoError(t, err)
+	}
 
-		err = r.Close()
+	t.Run("updating with contradiction should not produce any change", func(t *testing.T) {
+		_, ctxs, err := engine.Exec(context.Background(), "UPDATE table1 SET active = false WHERE false", nil, nil)
 		require.NoError(t, err)
+		require.Len(t, ctxs, 1)
+		require.Zero(t, ctxs[0].UpdatedRows())
 	})
 
-	t.Run("should use index on `title` ascending order starting with 'title1'", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 USE INDEX ON (title) WHERE title > 'title10' or title = 'title1' ORDER BY title", nil, nil)
+	t.Run("updating specific row should update only one row", func(t *testing.T) {
+		_, ctxs, err := engine.Exec(context.Background(), "UPDATE table1 SET active = true WHERE title = @title", map[string]interface{}{"title": "title1"}, nil)
 		require.NoError(t, err)
+		require.Len(t, ctxs, 1)
+		require.Equal(t, 1, ctxs[0].UpdatedRows())
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "title", orderBy[0].Column)
-
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.True(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Len(t, scanSpecs.rangesByColID, 1)
-
-		titleRange := scanSpecs.rangesByColID[3]
-		require.NotNil(t, titleRange.lRange)
-		require.True(t, titleRange.lRange.inclusive)
-		require.Equal(t, "title1", titleRange.lRange.val.Value())
-		require.Nil(t, titleRange.hRange)
+		r, err := engine.Query(context.Background(), "SELECT COUNT(*) FROM table1", nil, nil)
+		require.NoError(t, err)
 
-		require.False(t, scanSpecs.descOrder)
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(rowCount), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
 
 		err = r.Close()
 		require.NoError(t, err)
-	})
 
-	t.Run("should use index on `title` ascending order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 USE INDEX ON (title) WHERE title < 'title10' or title = 'title1' ORDER BY title", nil, nil)
+		r, err = engine.Query(context.Background(), "SELECT COUNT(*) FROM table1 WHERE active", nil, nil)
 		require.NoError(t, err)
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "title", orderBy[0].Column)
-
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.True(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Len(t, scanSpecs.rangesByColID, 1)
-
-		titleRange := scanSpecs.rangesByColID[3]
-		require.Nil(t, titleRange.lRange)
-		require.NotNil(t, titleRange.hRange)
-		require.False(t, titleRange.hRange.inclusive)
-		require.Equal(t, "title10", titleRange.hRange.val.Value())
-
-		require.False(t, scanSpecs.descOrder)
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(rowCount/2+1), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
+}
 
-	t.Run("should use index on `title` descending order", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 USE INDEX ON (title) WHERE title < 'title10' and title = 'title1' ORDER BY title DESC", nil, nil)
-		require.NoError(t, err)
+func TestTransactions(t *testing.T) {
+	st, err := store.Open("sqldata_tx", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_tx")
 
-		orderBy := r.OrderBy()
-		require.NotNil(t, orderBy)
-		require.Len(t, orderBy, 1)
-		require.Equal(t, "title", orderBy[0].Column)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-		scanSpecs := r.ScanSpecs()
-		require.NotNil(t, scanSpecs)
-		require.NotNil(t, scanSpecs.index)
-		require.False(t, scanSpecs.index.IsPrimary())
-		require.True(t, scanSpecs.index.IsUnique())
-		require.Len(t, scanSpecs.index.cols, 1)
-		require.Len(t, scanSpecs.rangesByColID, 1)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
 
-		titleRange := scanSpecs.rangesByColID[3]
-		require.NotNil(t, titleRange.lRange)
-		require.True(t, titleRange.lRange.inclusive)
-		require.Equal(t, "title1", titleRange.lRange.val.Value())
-		require.NotNil(t, titleRange.hRange)
-		require.True(t, titleRange.hRange.inclusive)
-		require.Equal(t, "title1", titleRange.hRange.val.Value())
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-		require.True(t, scanSpecs.descOrder)
+	_, _, err = engine.Exec(context.Background(), `CREATE TABLE table1 (
+									id INTEGER,
+									title VARCHAR,
+									PRIMARY KEY id
+								)`, nil, nil)
+	require.NoError(t, err)
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
-}
+	_, _, err = engine.Exec(context.Background(), `
+		BEGIN TRANSACTION;
+			CREATE INDEX ON table2(title);
+		COMMIT;
+		`, nil, nil)
+	require.Equal(t, ErrTableDoesNotExist, err)
 
-func TestExecCornerCases(t *testing.T) {
-	st, err := store.Open("sqldata_q", store.DefaultOptions())
+	_, _, err = engine.Exec(context.Background(), `
+		BEGIN TRANSACTION;
+			UPSERT INTO table1 (id, title) VALUES (1, 'title1');
+			UPSERT INTO table1 (id, title) VALUES (2, 'title2');
+		COMMIT;
+		`, nil, nil)
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_q")
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	_, _, err = engine.Exec(context.Background(), `
+		BEGIN TRANSACTION;
+			CREATE TABLE table2 (id INTEGER, title VARCHAR[100], age INTEGER, PRIMARY KEY id);
+			CREATE INDEX ON table2(title);
+		COMMIT;
+		`, nil, nil)
 	require.NoError(t, err)
 
-	tx, _, err := engine.Exec("INVALID STATEMENT", nil, nil)
-	require.EqualError(t, err, "syntax error: unexpected IDENTIFIER at position 7")
-	require.Nil(t, tx)
+	_, _, err = engine.Exec(context.Background(), `
+		BEGIN TRANSACTION;
+			CREATE INDEX ON table2(age);
+			INSERT INTO table2 (id, title, age) VALUES (1, 'title1', 40);
+		COMMIT;
+		`, nil, nil)
+	require.NoError(t, err)
 }
 
-func TestQueryWithNullables(t *testing.T) {
-	st, err := store.Open("sqldata_nullable", store.DefaultOptions())
+func TestUseSnapshot(t *testing.T) {
+	st, err := store.Open("sqldata_snap", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_nullable")
+	defer os.RemoveAll("sqldata_snap")
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, ts TIMESTAMP, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("INSERT INTO table1 (id, ts, title) VALUES (1, TIME(), 'title1')", nil, nil)
-	require.ErrorIs(t, err, ErrIllegalArguments)
+	_, _, err = engine.Exec(context.Background(), "USE SNAPSHOT SINCE TX 1", nil, nil)
+	require.NoError(t, err)
 
-	rowCount := 10
+	_, _, err = engine.Exec(context.Background(), "USE SNAPSHOT SINCE TX 1000", nil, nil)
+	require.ErrorIs(t, err, ErrSnapshotNotAvailable)
 
-	start := time.Now()
+	_, _, err = engine.Exec(context.Background(), "USE SNAPSHOT UP TO TX 1000", nil, nil)
+	require.ErrorIs(t, err, ErrSnapshotNotAvailable)
 
-	for i := 0; i < rowCount; i++ {
-		_, _, err = engine.Exec(fmt.Sprintf("UPSERT INTO table1 (id, ts, title) VALUES (%d, NOW(), 'title%d')", i, i), nil, nil)
-		require.NoError(t, err)
-	}
+	_, _, err = engine.Exec(context.Background(), "USE SNAPSHOT SINCE TX 2 BEFORE TX 2", nil, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
 
-	r, err := engine.Query("SELECT id, ts, title, active FROM table1 WHERE NOT(active != NULL)", nil, nil)
+	_, ctxs, err := engine.Exec(context.Background(), `
+		BEGIN TRANSACTION;
+			UPSERT INTO table1 (id, title) VALUES (1, 'title1');
+			UPSERT INTO table1 (id, title) VALUES (2, 'title2');
+		COMMIT;
+		`, nil, nil)
 	require.NoError(t, err)
 
-	cols, err := r.Columns()
-	require.NoError(t, err)
-	require.Len(t, cols, 4)
+	firstTx := ctxs[0].TxHeader().ID
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
+	_, _, err = engine.Exec(context.Background(), `
+		UPDATE table1 SET title = 'updated' WHERE id = 1;
+		UPSERT INTO table1 (id, title) VALUES (3, 'title3');
+		DELETE FROM table1 WHERE id = 2;
+		`, nil, nil)
+	require.NoError(t, err)
+
+	titles := func(t *testing.T, q string, tx *SQLTx) []string {
+		r, err := engine.Query(context.Background(), q, nil, tx)
 		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 4)
-		require.False(t, start.After(row.Values[EncodeSelector("", "db1", "table1", "ts")].Value().(time.Time)))
-		require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, &NullValue{t: BooleanType}, row.Values[EncodeSelector("", "db1", "table1", "active")])
-	}
+		defer r.Close()
 
-	err = r.Close()
-	require.NoError(t, err)
-}
+		var titles []string
 
-func TestOrderBy(t *testing.T) {
-	st, err := store.Open("sqldata_orderby", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_orderby")
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+			titles = append(titles, row.Values[EncodeSelector("", "db1", "table1", "title")].Value().(string))
+		}
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+		return titles
+	}
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(title)", nil, nil)
-	require.Equal(t, ErrNoDatabaseSelected, err)
+	t.Run("queries must read from the selected historical snapshot", func(t *testing.T) {
+		tx, _, err := engine.Exec(context.Background(), fmt.Sprintf("BEGIN TRANSACTION; USE SNAPSHOT SINCE TX 1 UP TO TX %d;", firstTx), nil, nil)
+		require.NoError(t, err)
+		defer tx.Cancel()
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+		require.Equal(t, []string{"title1", "title2"}, titles(t, "SELECT id, title FROM table1", tx))
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR[100], age INTEGER, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
+		// explicit temporal clauses take precedence over the snapshot
+		require.Equal(t, []string{"updated", "title3"}, titles(t, "SELECT id, title FROM table1 BEFORE TX 1000", tx))
 
-	_, err = engine.Query("SELECT id, title, age FROM table1 ORDER BY id, title DESC", nil, nil)
-	require.Equal(t, ErrLimitedOrderBy, err)
+		_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title) VALUES (4, 'title4')", nil, tx)
+		require.ErrorIs(t, err, ErrHistoricalSnapshotIsReadOnly)
+	})
 
-	_, err = engine.Query("SELECT id, title, age FROM (SELECT id, title, age FROM table1) ORDER BY id", nil, nil)
-	require.Equal(t, ErrLimitedOrderBy, err)
+	t.Run("queries must read the table as it was before the given tx", func(t *testing.T) {
+		tx, _, err := engine.Exec(context.Background(), fmt.Sprintf("BEGIN TRANSACTION; USE SNAPSHOT BEFORE TX %d;", firstTx), nil, nil)
+		require.NoError(t, err)
+		defer tx.Cancel()
 
-	_, err = engine.Query("SELECT id, title, age FROM (SELECT id, title, age FROM table1 AS t1) ORDER BY age DESC", nil, nil)
-	require.Equal(t, ErrLimitedOrderBy, err)
+		require.Empty(t, titles(t, "SELECT id, title FROM table1", tx))
+	})
 
-	_, err = engine.Query("SELECT id, title, age FROM table2 ORDER BY title", nil, nil)
-	require.Equal(t, ErrTableDoesNotExist, err)
+	t.Run("the latest snapshot must remain writable", func(t *testing.T) {
+		_, _, err := engine.Exec(context.Background(), `
+			BEGIN TRANSACTION;
+				USE SNAPSHOT SINCE TX 1;
+				UPSERT INTO table1 (id, title) VALUES (4, 'title4');
+			COMMIT;
+			`, nil, nil)
+		require.NoError(t, err)
 
-	_, err = engine.Query("SELECT id, title, age FROM table1 ORDER BY amount", nil, nil)
-	require.Equal(t, ErrColumnDoesNotExist, err)
+		require.Equal(t, []string{"updated", "title3", "title4"}, titles(t, "SELECT id, title FROM table1", nil))
+	})
+}
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(title)", nil, nil)
+func TestEncodeRawValue(t *testing.T) {
+	b, err := EncodeValue(int64(1), IntegerType, 0)
 	require.NoError(t, err)
+	require.EqualValues(t, []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1}, b)
 
-	_, err = engine.Query("SELECT id, title, age FROM table1 ORDER BY age", nil, nil)
-	require.Equal(t, ErrLimitedOrderBy, err)
+	b, err = EncodeValue(true, IntegerType, 0)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(age)", nil, nil)
+	b, err = EncodeValue(true, BooleanType, 0)
 	require.NoError(t, err)
+	require.EqualValues(t, []byte{0, 0, 0, 1, 1}, b)
 
-	params := make(map[string]interface{}, 1)
-	params["age"] = nil
-	_, _, err = engine.Exec("INSERT INTO table1 (id, title, age) VALUES (1, 'title', @age)", params, nil)
-	require.NoError(t, err)
+	b, err = EncodeValue(uint64(1), BooleanType, 0)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 
-	_, _, err = engine.Exec("INSERT INTO table1 (id, title) VALUES (2, 'title')", nil, nil)
+	b, err = EncodeValue("title", VarcharType, 0)
 	require.NoError(t, err)
+	require.EqualValues(t, []byte{0, 0, 0, 5, 't', 'i', 't', 'l', 'e'}, b)
 
-	rowCount := 1
-
-	for i := 0; i < rowCount; i++ {
-		params := make(map[string]interface{}, 3)
-		params["id"] = i + 3
-		params["title"] = fmt.Sprintf("title%d", i)
-		params["age"] = 40 + i
+	b, err = EncodeValue(uint64(1), VarcharType, 0)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 
-		_, _, err = engine.Exec("INSERT INTO table1 (id, title, age) VALUES (@id, @title, @age)", params, nil)
-		require.NoError(t, err)
-	}
+	b, err = EncodeValue([]byte{}, BLOBType, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, []byte{0, 0, 0, 0}, b)
 
-	r, err := engine.Query("SELECT id, title, age FROM table1 ORDER BY title", nil, nil)
+	b, err = EncodeValue(nil, BLOBType, 0)
 	require.NoError(t, err)
+	require.EqualValues(t, []byte{0, 0, 0, 0}, b)
 
-	orderBy := r.OrderBy()
-	require.NotNil(t, orderBy)
-	require.Len(t, orderBy, 1)
-	require.Equal(t, "title", orderBy[0].Column)
-	require.Equal(t, "table1", orderBy[0].Table)
-	require.Equal(t, "db1", orderBy[0].Database)
+	b, err = EncodeValue(uint64(1), BLOBType, 0)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 
-	row, err := r.Read()
-	require.NoError(t, err)
-	require.Len(t, row.Values, 3)
+	b, err = EncodeValue(uint64(1), "invalid type", 0)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 
-	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	// Max allowed key size is 32 bytes
+	b, err = EncodeValue("012345678901234567890123456789012", VarcharType, 32)
+	require.ErrorIs(t, err, ErrMaxLengthExceeded)
+	require.Nil(t, b)
 
-	row, err = r.Read()
+	_, err = EncodeValue("01234567890123456789012345678902", VarcharType, 0)
 	require.NoError(t, err)
-	require.Len(t, row.Values, 3)
-
-	require.Equal(t, int64(2), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 3)
+	_, err = EncodeValue("012345678901234567890123456789012", VarcharType, 0)
+	require.NoError(t, err)
 
-		require.Equal(t, int64(i+3), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, int64(40+i), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
-	}
+	b, err = EncodeValue([]byte{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2,
+	}, BLOBType, 32)
+	require.ErrorIs(t, err, ErrMaxLengthExceeded)
+	require.Nil(t, b)
 
-	err = r.Close()
+	_, err = EncodeValue([]byte{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1,
+	}, BLOBType, 0)
 	require.NoError(t, err)
 
-	r, err = engine.Query("SELECT id, title, age FROM table1 ORDER BY age", nil, nil)
+	_, err = EncodeValue([]byte{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2,
+	}, BLOBType, 0)
 	require.NoError(t, err)
+}
 
-	row, err = r.Read()
+func TestEncodeValue(t *testing.T) {
+	b, err := EncodeValue((&Number{val: 1}).Value(), IntegerType, 0)
 	require.NoError(t, err)
-	require.Len(t, row.Values, 3)
+	require.EqualValues(t, []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1}, b)
 
-	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	b, err = EncodeValue((&Bool{val: true}).Value(), IntegerType, 0)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 
-	row, err = r.Read()
+	b, err = EncodeValue((&Bool{val: true}).Value(), BooleanType, 0)
 	require.NoError(t, err)
-	require.Len(t, row.Values, 3)
+	require.EqualValues(t, []byte{0, 0, 0, 1, 1}, b)
 
-	require.Equal(t, int64(2), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	b, err = EncodeValue((&Number{val: 1}).Value(), BooleanType, 0)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 3)
+	b, err = EncodeValue((&Varchar{val: "title"}).Value(), VarcharType, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, []byte{0, 0, 0, 5, 't', 'i', 't', 'l', 'e'}, b)
 
-		require.Equal(t, int64(i+3), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, int64(40+i), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
-	}
+	b, err = EncodeValue((&Number{val: 1}).Value(), VarcharType, 0)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 
-	err = r.Close()
+	b, err = EncodeValue((&Blob{val: []byte{}}).Value(), BLOBType, 50)
 	require.NoError(t, err)
+	require.EqualValues(t, []byte{0, 0, 0, 0}, b)
 
-	r, err = engine.Query("SELECT id, title, age FROM table1 ORDER BY age DESC", nil, nil)
+	b, err = EncodeValue((&Blob{val: nil}).Value(), BLOBType, 50)
 	require.NoError(t, err)
+	require.EqualValues(t, []byte{0, 0, 0, 0}, b)
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 3)
+	b, err = EncodeValue((&Number{val: 1}).Value(), BLOBType, 50)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 
-		require.Equal(t, int64(rowCount-1-i+3), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, int64(40-(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
-	}
+	b, err = EncodeValue((&Number{val: 1}).Value(), "invalid type", 50)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 
-	row, err = r.Read()
+	// Max allowed key size is 32 bytes
+	b, err = EncodeValue((&Varchar{val: "012345678901234567890123456789012"}).Value(), VarcharType, 32)
+	require.ErrorIs(t, err, ErrMaxLengthExceeded)
+	require.Nil(t, b)
+
+	_, err = EncodeValue((&Varchar{val: "01234567890123456789012345678902"}).Value(), VarcharType, 256)
 	require.NoError(t, err)
-	require.Len(t, row.Values, 3)
 
-	require.Equal(t, int64(2), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	_, err = EncodeValue((&Varchar{val: "012345678901234567890123456789012"}).Value(), VarcharType, 256)
+	require.NoError(t, err)
 
-	row, err = r.Read()
+	b, err = EncodeValue((&Blob{val: []byte{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2,
+	}}).Value(), BLOBType, 32)
+	require.ErrorIs(t, err, ErrMaxLengthExceeded)
+	require.Nil(t, b)
+
+	_, err = EncodeValue((&Blob{val: []byte{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1,
+	}}).Value(), BLOBType, 256)
 	require.NoError(t, err)
-	require.Len(t, row.Values, 3)
 
-	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	_, err = EncodeValue((&Blob{val: []byte{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2,
+	}}).Value(), BLOBType, 256)
+	require.NoError(t, err)
 
-	err = r.Close()
+	b, err = EncodeValue((&Timestamp{val: time.Unix(0, 1000)}).Value(), TimestampType, 0)
 	require.NoError(t, err)
+	require.EqualValues(t, []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1}, b)
+
+	b, err = EncodeValue((&Number{val: 1}).Value(), TimestampType, 0)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.Nil(t, b)
 }
 
-func TestQueryWithRowFiltering(t *testing.T) {
-	st, err := store.Open("sqldata_where", store.DefaultOptions())
+func TestQuery(t *testing.T) {
+	st, err := store.Open("sqldata_q", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_where")
+	defer os.RemoveAll("sqldata_q")
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+	_, err = engine.Query(context.Background(), "SELECT id FROM table1", nil, nil)
+	require.Equal(t, ErrNoDatabaseSelected, err)
 
-	err = engine.SetDefaultDatabase("db1")
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
+	_, err = engine.Query(context.Background(), "SELECT id FROM table1", nil, nil)
+	require.Equal(t, ErrNoDatabaseSelected, err)
 
-	rowCount := 10
+	_, err = engine.Query(context.Background(), "SELECT * FROM table1", nil, nil)
+	require.Equal(t, ErrNoDatabaseSelected, err)
 
-	for i := 0; i < rowCount; i++ {
-		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
-		_, _, err = engine.Exec(fmt.Sprintf(`
-			UPSERT INTO table1 (id, title, active, payload) VALUES (%d, 'title%d', %v, x'%s')
-		`, i, i, i%2 == 0, encPayload), nil, nil)
-		require.NoError(t, err)
-	}
+	_, _, err = engine.Exec(context.Background(), "SELECT id FROM table1", nil, nil)
+	require.Equal(t, ErrNoDatabaseSelected, err)
 
-	r, err := engine.Query("SELECT id, title, active FROM table1 WHERE false", nil, nil)
+	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	_, err = engine.Query(context.Background(), "SELECT id FROM db2.table1", nil, nil)
+	require.Equal(t, ErrDatabaseDoesNotExist, err)
 
-	err = r.Close()
-	require.NoError(t, err)
+	_, err = engine.Query(context.Background(), "SELECT id FROM table1", nil, nil)
+	require.Equal(t, ErrTableDoesNotExist, err)
 
-	r, err = engine.Query("SELECT id, title, active FROM table1 WHERE false OR true", nil, nil)
-	require.NoError(t, err)
-
-	for i := 0; i < rowCount; i++ {
-		_, err := r.Read()
-		require.NoError(t, err)
-	}
-
-	err = r.Close()
-	require.NoError(t, err)
-
-	r, err = engine.Query("SELECT id, title, active FROM table1 WHERE 1 < 2", nil, nil)
-	require.NoError(t, err)
-
-	for i := 0; i < rowCount; i++ {
-		_, err := r.Read()
-		require.NoError(t, err)
-	}
-
-	err = r.Close()
-	require.NoError(t, err)
-
-	r, err = engine.Query("SELECT id, title, active FROM table1 WHERE 1 >= 2", nil, nil)
-	require.NoError(t, err)
-
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
-
-	err = r.Close()
-	require.NoError(t, err)
-
-	r, err = engine.Query("SELECT id, title, active FROM table1 WHERE 1 = true", nil, nil)
+	_, _, err = engine.Exec(context.Background(), `CREATE TABLE table1 (
+								id INTEGER,
+								ts TIMESTAMP,
+								title VARCHAR,
+								active BOOLEAN,
+								payload BLOB,
+								PRIMARY KEY id)`, nil, nil)
 	require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrNotComparableValues, err)
-
-	err = r.Close()
-	require.NoError(t, err)
+	params := make(map[string]interface{})
+	params["id"] = 0
 
-	r, err = engine.Query("SELECT id, title, active FROM table1 WHERE NOT table1.active", nil, nil)
+	r, err := engine.Query(context.Background(), "SELECT id FROM db1.table1 WHERE id >= @id", nil, nil)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount/2; i++ {
-		_, err := r.Read()
-		require.NoError(t, err)
-	}
+	orderBy := r.OrderBy()
+	require.NotNil(t, orderBy)
+	require.Len(t, orderBy, 1)
+	require.Equal(t, "id", orderBy[0].Column)
+	require.Equal(t, "table1", orderBy[0].Table)
+	require.Equal(t, "db1", orderBy[0].Database)
 
 	_, err = r.Read()
 	require.Equal(t, ErrNoMoreRows, err)
@@ -2574,257 +2572,232 @@ func TestQueryWithRowFiltering(t *testing.T) {
 	err = r.Close()
 	require.NoError(t, err)
 
-	r, err = engine.Query("SELECT id, title, active FROM table1 WHERE table1.id > 4", nil, nil)
+	r, err = engine.Query(context.Background(), "SELECT * FROM db1.table1", nil, nil)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount/2; i++ {
-		_, err := r.Read()
-		require.NoError(t, err)
-	}
-
 	_, err = r.Read()
 	require.Equal(t, ErrNoMoreRows, err)
 
 	err = r.Close()
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(fmt.Sprintf("UPSERT INTO table1 (id, title) VALUES (%d, 'title%d')", rowCount, rowCount), nil, nil)
-	require.NoError(t, err)
-
-	r, err = engine.Query("SELECT id, title FROM table1 WHERE active = null AND payload = null", nil, nil)
-	require.NoError(t, err)
-
-	_, err = r.Read()
-	require.NoError(t, err)
-
-	err = r.Close()
-	require.NoError(t, err)
-
-	r, err = engine.Query("SELECT id, title FROM table1 WHERE active = null AND payload = null AND active = payload", nil, nil)
-	require.NoError(t, err)
-
-	_, err = r.Read()
-	require.Equal(t, ErrNotComparableValues, err)
-
-	err = r.Close()
-	require.NoError(t, err)
-}
-
-func TestQueryWithInClause(t *testing.T) {
-	st, err := store.Open("sqldata_where_in", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_where_in")
-
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
-
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
-
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
-
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR[50], active BOOLEAN, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
-
-	_, _, err = engine.Exec("CREATE INDEX ON table1(title)", nil, nil)
-	require.NoError(t, err)
-
 	rowCount := 10
 
+	start := time.Now()
+
 	for i := 0; i < rowCount; i++ {
-		_, _, err = engine.Exec(fmt.Sprintf(`
-			INSERT INTO table1 (id, title, active) VALUES (%d, 'title%d', %v)
-		`, i, i, i%2 == 0), nil, nil)
+		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf(`
+			UPSERT INTO table1 (id, ts, title, active, payload)
+			VALUES (%d, NOW(), 'title%d', %v, x'%s')
+		`, i, i, i%2 == 0, encPayload), nil, nil)
 		require.NoError(t, err)
 	}
 
-	inListExp := &InListExp{}
-	require.False(t, inListExp.isConstant())
-
-	t.Run("infer parameters without parameters should return an empty list", func(t *testing.T) {
-		params, err := engine.InferParameters("SELECT id, title, active FROM table1 WHERE title IN ('title0', 'title1')", nil)
+	t.Run("should resolve every row", func(t *testing.T) {
+		r, err = engine.Query(context.Background(), "SELECT * FROM table1", nil, nil)
 		require.NoError(t, err)
-		require.Empty(t, params)
-	})
-
-	t.Run("infer inference with wrong types should return an error", func(t *testing.T) {
-		_, err := engine.InferParameters("SELECT id, title, active FROM table1 WHERE 100 + title IN ('title0', 'title1')", nil)
-		require.ErrorIs(t, err, ErrInvalidTypes)
-	})
 
-	t.Run("infer inference with valid types should succeed", func(t *testing.T) {
-		params, err := engine.InferParameters("SELECT id, title, active FROM table1 WHERE active AND title IN ('title0', 'title1')", nil)
+		colsBySel, err := r.colsBySelector()
 		require.NoError(t, err)
-		require.Empty(t, params)
-	})
+		require.Len(t, colsBySel, 5)
 
-	t.Run("infer parameters should return matching type", func(t *testing.T) {
-		params, err := engine.InferParameters("SELECT id, title, active FROM table1 WHERE title IN (@param0, @param1)", nil)
-		require.NoError(t, err)
-		require.Len(t, params, 2)
-		require.Equal(t, VarcharType, params["param0"])
-		require.Equal(t, VarcharType, params["param1"])
-	})
+		require.Equal(t, "db1", r.Database().Name())
+		require.Equal(t, "table1", r.TableAlias())
 
-	t.Run("infer parameters with type conflicts should return an error", func(t *testing.T) {
-		_, err := engine.InferParameters("SELECT id, title, active FROM table1 WHERE active = @param1 and title IN (@param0, @param1)", nil)
-		require.ErrorIs(t, err, ErrInferredMultipleTypes)
-	})
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 5)
 
-	t.Run("infer parameters with unexistent column should return an error", func(t *testing.T) {
-		_, err := engine.InferParameters("SELECT id, title, active FROM table1 WHERE invalidColumn IN ('title1', 'title2')", nil)
-		require.ErrorIs(t, err, ErrColumnDoesNotExist)
-	})
+		for i := 0; i < rowCount; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.NotNil(t, row)
+			require.Len(t, row.Values, 5)
+			require.False(t, start.After(row.Values[EncodeSelector("", "db1", "table1", "ts")].Value().(time.Time)))
+			require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+			require.Equal(t, i%2 == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
 
-	t.Run("in clause with invalid column should return an error", func(t *testing.T) {
-		r, err := engine.Query("SELECT id, title, active FROM table1 WHERE invalidColumn IN (1, 2)", nil, nil)
-		require.NoError(t, err)
+			encPayload := []byte(fmt.Sprintf("blob%d", i))
+			require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "table1", "payload")].Value())
+		}
 
 		_, err = r.Read()
-		require.ErrorIs(t, err, ErrColumnDoesNotExist)
+		require.Equal(t, ErrNoMoreRows, err)
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
 
-	t.Run("in clause with invalid type should return an error", func(t *testing.T) {
-		r, err := engine.Query("SELECT id, title, active FROM table1 WHERE title IN (1, 2)", nil, nil)
+	t.Run("should fail reading due to non-existent column", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id1 FROM table1", nil, nil)
 		require.NoError(t, err)
 
-		_, err = r.Read()
-		require.ErrorIs(t, err, ErrNotComparableValues)
+		row, err := r.Read()
+		require.Equal(t, ErrColumnDoesNotExist, err)
+		require.Nil(t, row)
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
 
-	t.Run("in clause should succeed reading two rows", func(t *testing.T) {
-		r, err := engine.Query("SELECT id, title, active FROM table1 WHERE title IN ('title0', 'title1')", nil, nil)
+	t.Run("should resolve every row with two-time table aliasing", func(t *testing.T) {
+		r, err = engine.Query(context.Background(), fmt.Sprintf(`
+			SELECT * FROM table1 AS mytable1 WHERE mytable1.id >= 0 LIMIT %d
+		`, rowCount), nil, nil)
 		require.NoError(t, err)
 
-		for i := 0; i < 2; i++ {
+		colsBySel, err := r.colsBySelector()
+		require.NoError(t, err)
+		require.Len(t, colsBySel, 5)
+
+		require.Equal(t, "db1", r.Database().Name())
+		require.Equal(t, "mytable1", r.TableAlias())
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 5)
+
+		for i := 0; i < rowCount; i++ {
 			row, err := r.Read()
 			require.NoError(t, err)
 			require.NotNil(t, row)
-			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		}
-
-		err = r.Close()
-		require.NoError(t, err)
-	})
+			require.Len(t, row.Values, 5)
+			require.False(t, start.After(row.Values[EncodeSelector("", "db1", "mytable1", "ts")].Value().(time.Time)))
+			require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "mytable1", "id")].Value())
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "mytable1", "title")].Value())
+			require.Equal(t, i%2 == 0, row.Values[EncodeSelector("", "db1", "mytable1", "active")].Value())
 
-	t.Run("in clause with invalid values should return an error", func(t *testing.T) {
-		r, err := engine.Query("SELECT id, title, active FROM table1 WHERE title IN ('title0', true + 'title1')", nil, nil)
-		require.NoError(t, err)
+			encPayload := []byte(fmt.Sprintf("blob%d", i))
+			require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "mytable1", "payload")].Value())
+		}
 
 		_, err = r.Read()
-		require.ErrorIs(t, err, ErrInvalidValue)
+		require.Equal(t, ErrNoMoreRows, err)
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
 
-	t.Run("in clause should succeed reading rows NOT included in 'IN' clause", func(t *testing.T) {
-		r, err := engine.Query("SELECT id, title, active FROM table1 WHERE title NOT IN ('title1', 'title0')", nil, nil)
+	t.Run("should resolve every row with column and two-time table aliasing", func(t *testing.T) {
+		r, err = engine.Query(context.Background(), fmt.Sprintf(`
+			SELECT mytable1.id AS D, ts, Title, payload, Active FROM table1 mytable1 WHERE mytable1.id >= 0 LIMIT %d
+		`, rowCount), nil, nil)
 		require.NoError(t, err)
 
-		for i := 2; i < rowCount; i++ {
-			row, err := r.Read()
-			require.NoError(t, err)
-			require.NotNil(t, row)
-			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		}
-
-		err = r.Close()
+		colsBySel, err := r.colsBySelector()
 		require.NoError(t, err)
-	})
+		require.Len(t, colsBySel, 5)
 
-	t.Run("in clause should succeed reading using 'IN' clause in join condition", func(t *testing.T) {
-		r, err := engine.Query("SELECT * FROM table1 as t1 INNER JOIN table1 as t2 ON t1.title IN (t2.title) ORDER BY title", nil, nil)
+		require.Equal(t, "db1", r.Database().Name())
+		require.Equal(t, "mytable1", r.TableAlias())
+
+		cols, err := r.Columns()
 		require.NoError(t, err)
+		require.Len(t, cols, 5)
 
 		for i := 0; i < rowCount; i++ {
 			row, err := r.Read()
 			require.NoError(t, err)
 			require.NotNil(t, row)
-			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "t1", "title")].Value())
+			require.Len(t, row.Values, 5)
+			require.False(t, start.After(row.Values[EncodeSelector("", "db1", "mytable1", "ts")].Value().(time.Time)))
+			require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "mytable1", "d")].Value())
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "mytable1", "title")].Value())
+			require.Equal(t, i%2 == 0, row.Values[EncodeSelector("", "db1", "mytable1", "active")].Value())
+
+			encPayload := []byte(fmt.Sprintf("blob%d", i))
+			require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "mytable1", "payload")].Value())
 		}
 
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
 		err = r.Close()
 		require.NoError(t, err)
 	})
-}
 
-func TestAggregations(t *testing.T) {
-	st, err := store.Open("sqldata_agg", store.DefaultOptions())
+	r, err = engine.Query(context.Background(), "SELECT id, title, active, payload FROM table1 ORDER BY title", nil, nil)
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_agg")
+	require.True(t, r.ScanSpecs().sortRequired)
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	err = r.Close()
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	r, err = engine.Query(context.Background(), "SELECT Id, Title, Active, payload FROM Table1 ORDER BY Id DESC", nil, nil)
 	require.NoError(t, err)
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 4)
+
+		require.Equal(t, int64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, (rowCount-1-i)%2 == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, nil)
+		encPayload := []byte(fmt.Sprintf("blob%d", rowCount-1-i))
+		require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "table1", "payload")].Value())
+	}
+
+	err = r.Close()
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(age)", nil, nil)
+	r, err = engine.Query(context.Background(), "SELECT id FROM table1 WHERE id", nil, nil)
 	require.NoError(t, err)
 
-	rowCount := 10
-	base := 30
+	_, err = r.Read()
+	require.Equal(t, ErrInvalidCondition, err)
 
-	for i := 1; i <= rowCount; i++ {
-		params := make(map[string]interface{}, 3)
-		params["id"] = i
-		params["title"] = fmt.Sprintf("title%d", i)
-		params["age"] = base + i
+	err = r.Close()
+	require.NoError(t, err)
 
-		_, _, err = engine.Exec("INSERT INTO table1 (id, title, age) VALUES (@id, @title, @age)", params, nil)
-		require.NoError(t, err)
-	}
+	params = make(map[string]interface{})
+	params["some_param1"] = true
 
-	r, err := engine.Query("SELECT COUNT(*) FROM table1 WHERE id < i", nil, nil)
+	r, err = engine.Query(context.Background(), "SELECT id FROM table1 WHERE active = @some_param1", nil, nil)
 	require.NoError(t, err)
 
 	_, err = r.Read()
-	require.ErrorIs(t, err, ErrColumnDoesNotExist)
-
-	err = r.Close()
-	require.NoError(t, err)
+	require.Equal(t, ErrMissingParameter, err)
 
-	r, err = engine.Query("SELECT id FROM table1 WHERE false", nil, nil)
-	require.NoError(t, err)
+	r.SetParameters(params)
 
 	row, err := r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
-	require.Nil(t, row)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
 
 	err = r.Close()
 	require.NoError(t, err)
 
-	r, err = engine.Query(`
-		SELECT COUNT(*), SUM(age), MIN(title), MAX(age), AVG(age), MIN(active), MAX(active), MIN(payload)
-		FROM table1 WHERE false`, nil, nil)
-	require.NoError(t, err)
+	params = make(map[string]interface{})
+	params["some_param"] = true
 
-	row, err = r.Read()
+	encPayloadPrefix := hex.EncodeToString([]byte("blob"))
+
+	r, err = engine.Query(context.Background(), fmt.Sprintf(`
+		SELECT id, title, active
+		FROM table1
+		WHERE active = @some_param AND title > 'title' AND payload >= x'%s' AND title LIKE 't%%'`, encPayloadPrefix), params, nil)
 	require.NoError(t, err)
-	require.Equal(t, int64(0), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
-	require.Equal(t, int64(0), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
-	require.Equal(t, "", row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
-	require.Equal(t, int64(0), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
-	require.Equal(t, int64(0), row.Values[EncodeSelector("", "db1", "table1", "col4")].Value())
+
+	for i := 0; i < rowCount/2; i += 2 {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 3)
+
+		require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, params["some_param"], row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
+	}
 
 	err = r.Close()
 	require.NoError(t, err)
 
-	r, err = engine.Query("SELECT COUNT(*) AS c, SUM(age), MIN(age), MAX(age), AVG(age) FROM table1 AS t1", nil, nil)
+	r, err = engine.Query(context.Background(), "SELECT * FROM table1 WHERE id = 0", nil, nil)
 	require.NoError(t, err)
 
 	cols, err := r.Columns()
@@ -2833,75 +2806,60 @@ func TestAggregations(t *testing.T) {
 
 	row, err = r.Read()
 	require.NoError(t, err)
-	require.NotNil(t, row)
 	require.Len(t, row.Values, 5)
 
-	require.Equal(t, int64(rowCount), row.Values[EncodeSelector("", "db1", "t1", "c")].Value())
-
-	require.Equal(t, int64((1+2*base+rowCount)*rowCount/2), row.Values[EncodeSelector("", "db1", "t1", "col1")].Value())
-
-	require.Equal(t, int64(1+base), row.Values[EncodeSelector("", "db1", "t1", "col2")].Value())
-
-	require.Equal(t, int64(base+rowCount), row.Values[EncodeSelector("", "db1", "t1", "col3")].Value())
+	err = r.Close()
+	require.NoError(t, err)
 
-	require.Equal(t, int64(base+rowCount/2), row.Values[EncodeSelector("", "db1", "t1", "col4")].Value())
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE id / 0", nil, nil)
+	require.NoError(t, err)
 
 	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	require.Equal(t, ErrDivisionByZero, err)
 
 	err = r.Close()
 	require.NoError(t, err)
-}
 
-func TestCount(t *testing.T) {
-	st, err := store.Open("sqldata_agg", store.DefaultOptions())
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE id + 1/1 > 1 * (1 - 0)", nil, nil)
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_agg")
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	_, err = r.Read()
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	err = r.Close()
 	require.NoError(t, err)
 
-	err = engine.SetDefaultDatabase("db1")
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE id = 0 AND NOT active OR active", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE t1(id INTEGER AUTO_INCREMENT, val1 INTEGER, PRIMARY KEY id)", nil, nil)
+	_, err = r.Read()
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON t1(val1)", nil, nil)
+	err = r.Close()
 	require.NoError(t, err)
 
-	for i := 0; i < 10; i++ {
-		for j := 0; j < 3; j++ {
-			_, _, err = engine.Exec("INSERT INTO t1(val1) VALUES($1)", map[string]interface{}{"param1": j}, nil)
-			require.NoError(t, err)
-		}
-	}
+	r, err = engine.Query(context.Background(), "INVALID QUERY", nil, nil)
+	require.EqualError(t, err, "syntax error: unexpected IDENTIFIER at position 7")
+	require.Nil(t, r)
 
-	r, err := engine.Query("SELECT COUNT(*) as c FROM t1", nil, nil)
-	require.NoError(t, err)
+	r, err = engine.Query(context.Background(), "UPSERT INTO table1 (id) VALUES(1)", nil, nil)
+	require.ErrorIs(t, err, ErrExpectingDQLStmt)
+	require.Nil(t, r)
 
-	row, err := r.Read()
-	require.NoError(t, err)
-	require.EqualValues(t, uint64(30), row.Values["(db1.t1.c)"].Value())
+	r, err = engine.Query(context.Background(), "UPSERT INTO table1 (id) VALUES(1); UPSERT INTO table1 (id) VALUES(1)", nil, nil)
+	require.ErrorIs(t, err, ErrExpectingDQLStmt)
+	require.Nil(t, r)
 
-	err = r.Close()
-	require.NoError(t, err)
+	r, err = engine.QueryPreparedStmt(context.Background(), nil, nil, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+	require.Nil(t, r)
 
-	_, err = engine.Query("SELECT COUNT(*) as c FROM t1 GROUP BY val1", nil, nil)
-	require.ErrorIs(t, err, ErrLimitedGroupBy)
+	params = make(map[string]interface{})
+	params["null_param"] = nil
 
-	r, err = engine.Query("SELECT COUNT(*) as c FROM t1 GROUP BY val1 ORDER BY val1", nil, nil)
+	r, err = engine.Query(context.Background(), "SELECT id FROM table1 WHERE active = @null_param", params, nil)
 	require.NoError(t, err)
 
-	for j := 0; j < 3; j++ {
-		row, err = r.Read()
-		require.NoError(t, err)
-		require.EqualValues(t, uint64(10), row.Values["(db1.t1.c)"].Value())
-	}
-
 	_, err = r.Read()
 	require.ErrorIs(t, err, ErrNoMoreRows)
 
@@ -2909,1503 +2867,5874 @@ func TestCount(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestGroupByHaving(t *testing.T) {
-	st, err := store.Open("sqldata_having", store.DefaultOptions())
+func TestQueryDistinct(t *testing.T) {
+	st, err := store.Open("sqldata_qd", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_having")
+	defer os.RemoveAll("sqldata_qd")
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	opts := DefaultOptions().WithPrefix(sqlPrefix).WithDistinctLimit(4)
+	engine, err := NewEngine(st, opts)
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, active BOOLEAN, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), `CREATE TABLE table1 (
+								id INTEGER AUTO_INCREMENT,
+								title VARCHAR,
+								amount INTEGER,
+								active BOOLEAN,
+								PRIMARY KEY id)`, nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(active)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), `INSERT INTO table1 (title, amount, active) VALUES
+								('title1', 100, NULL),
+								('title2', 200, false),
+								('title3', 200, true),
+								('title4', 300, NULL)`, nil, nil)
 	require.NoError(t, err)
 
-	rowCount := 10
-	base := 40
-
-	for i := 0; i < rowCount; i++ {
-		params := make(map[string]interface{}, 4)
-		params["id"] = i
-		params["title"] = fmt.Sprintf("title%d", i)
-		params["age"] = base + i
-		params["active"] = i%2 == 0
+	t.Run("should return all titles", func(t *testing.T) {
+		params := make(map[string]interface{})
+		params["id"] = 3
 
-		_, _, err = engine.Exec("INSERT INTO table1 (id, title, age, active) VALUES (@id, @title, @age, @active)", params, nil)
+		r, err := engine.Query(context.Background(), "SELECT DISTINCT title FROM table1 WHERE id <= @id", nil, nil)
 		require.NoError(t, err)
-	}
-
-	_, err = engine.Query("SELECT active, COUNT(*), SUM(age1) FROM table1 WHERE active != null HAVING AVG(age) >= MIN(age)", nil, nil)
-	require.Equal(t, ErrHavingClauseRequiresGroupClause, err)
-
-	r, err := engine.Query(`
-		SELECT active, COUNT(*), SUM(age1)
-		FROM table1
-		WHERE active != null
-		GROUP BY active
-		HAVING AVG(age) >= MIN(age)
-		ORDER BY active`, nil, nil)
-	require.NoError(t, err)
-
-	r.SetParameters(nil)
 
-	_, err = r.Read()
-	require.Equal(t, ErrColumnDoesNotExist, err)
+		r.SetParameters(params)
 
-	err = r.Close()
-	require.NoError(t, err)
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 1)
+		require.Equal(t, "(db1.table1.title)", cols[0].Selector())
 
-	r, err = engine.Query(`
-		SELECT active, COUNT(*), SUM(age1)
-		FROM table1
-		WHERE AVG(age) >= MIN(age)
-		GROUP BY active
-		ORDER BY active`, nil, nil)
-	require.NoError(t, err)
+		for i := 1; i <= 3; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Len(t, row.Values, 1)
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values["(db1.table1.title)"].Value())
+		}
 
-	err = r.Close()
-	require.NoError(t, err)
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
 
-	r, err = engine.Query("SELECT active, COUNT(id) FROM table1 GROUP BY active ORDER BY active", nil, nil)
-	require.NoError(t, err)
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	_, err = r.Read()
-	require.Equal(t, ErrLimitedCount, err)
+	t.Run("should return two titles", func(t *testing.T) {
+		params := make(map[string]interface{})
+		params["id"] = 3
 
-	err = r.Close()
-	require.NoError(t, err)
+		r, err := engine.Query(context.Background(), "SELECT DISTINCT title FROM table1 WHERE id <= @id LIMIT 2", nil, nil)
+		require.NoError(t, err)
 
-	r, err = engine.Query(`
-		SELECT active, COUNT(*)
-		FROM table1
-		GROUP BY active
-		HAVING AVG(age) >= MIN(age1)
-		ORDER BY active`, nil, nil)
-	require.NoError(t, err)
+		r.SetParameters(params)
 
-	_, err = r.Read()
-	require.ErrorIs(t, err, ErrColumnDoesNotExist)
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 1)
+		require.Equal(t, "(db1.table1.title)", cols[0].Selector())
 
-	err = r.Close()
-	require.NoError(t, err)
+		for i := 1; i <= 2; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Len(t, row.Values, 1)
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values["(db1.table1.title)"].Value())
+		}
 
-	r, err = engine.Query(`
-		SELECT active, COUNT(*) as c, MIN(age), MAX(age), AVG(age), SUM(age)
-		FROM table1
-		GROUP BY active
-		HAVING COUNT(*) <= SUM(age)   AND
-				MIN(age) <= MAX(age) AND
-				AVG(age) <= MAX(age) AND
-				MAX(age) < SUM(age)  AND
-				AVG(age) >= MIN(age) AND
-				SUM(age) > 0
-		ORDER BY active DESC`, nil, nil)
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
 
-	require.NoError(t, err)
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	_, err = r.Columns()
-	require.NoError(t, err)
+	t.Run("should return two distinct amounts", func(t *testing.T) {
+		params := make(map[string]interface{})
+		params["id"] = 3
 
-	for i := 0; i < 2; i++ {
-		row, err := r.Read()
+		r, err := engine.Query(context.Background(), "SELECT DISTINCT amount FROM table1 WHERE id <= @id", params, nil)
 		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 6)
-
-		require.Equal(t, i == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
 
-		require.Equal(t, int64(rowCount/2), row.Values[EncodeSelector("", "db1", "table1", "c")].Value())
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 1)
+		require.Equal(t, "(db1.table1.amount)", cols[0].Selector())
 
-		if i%2 == 0 {
-			require.Equal(t, int64(base), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
-			require.Equal(t, int64(base+rowCount-2), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
-		} else {
-			require.Equal(t, int64(base+1), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
-			require.Equal(t, int64(base+rowCount-1), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+		for i := 1; i <= 2; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Len(t, row.Values, 1)
+			require.Equal(t, int64(i*100), row.Values["(db1.table1.amount)"].Value())
 		}
-	}
 
-	err = r.Close()
-	require.NoError(t, err)
-}
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
 
-func TestJoins(t *testing.T) {
-	st, err := store.Open("sqldata_innerjoin", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_innerjoin")
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+	t.Run("should return rows with null, false and true", func(t *testing.T) {
+		params := make(map[string]interface{})
+		params["id"] = 3
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
-
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
-
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR, fkid1 INTEGER, fkid2 INTEGER, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
-
-	_, _, err = engine.Exec("CREATE TABLE table2 (id INTEGER, amount INTEGER, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
-
-	_, _, err = engine.Exec("CREATE TABLE table3 (id INTEGER, age INTEGER, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
-
-	rowCount := 10
-
-	for i := 0; i < rowCount; i++ {
-		_, _, err = engine.Exec(fmt.Sprintf(`
-			UPSERT INTO table1 (id, title, fkid1, fkid2) VALUES (%d, 'title%d', %d, %d)`, i, i, rowCount-1-i, i), nil, nil)
+		r, err := engine.Query(context.Background(), "SELECT DISTINCT active FROM table1 WHERE id <= @id", params, nil)
 		require.NoError(t, err)
 
-		_, _, err = engine.Exec(fmt.Sprintf("UPSERT INTO table2 (id, amount) VALUES (%d, %d)", rowCount-1-i, i*i), nil, nil)
+		cols, err := r.Columns()
 		require.NoError(t, err)
+		require.Len(t, cols, 1)
+		require.Equal(t, "(db1.table1.active)", cols[0].Selector())
 
-		_, _, err = engine.Exec(fmt.Sprintf("UPSERT INTO table3 (id, age) VALUES (%d, %d)", i, 30+i), nil, nil)
-		require.NoError(t, err)
-	}
+		for i := 0; i <= 2; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Len(t, row.Values, 1)
 
-	t.Run("should not find any matching row", func(t *testing.T) {
-		r, err := engine.Query(`
-		SELECT table1.title, table2.amount, table3.age
-		FROM (SELECT * FROM table2 WHERE amount = 1)
-		INNER JOIN table1 ON table2.id = table1.fkid1 AND (table2.amount > 0 OR table2.amount > 0+1)
-		INNER JOIN table3 ON table1.fkid2 = table3.id AND table3.age < 30`, nil, nil)
-		require.NoError(t, err)
+			if i == 0 {
+				require.Nil(t, row.Values["(db1.table1.active)"].Value())
+				continue
+			}
+
+			require.Equal(t, i == 2, row.Values["(db1.table1.active)"].Value())
+		}
 
 		_, err = r.Read()
-		require.Equal(t, ErrNoMoreRows, err)
+		require.ErrorIs(t, err, ErrNoMoreRows)
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
 
-	t.Run("should find one matching row", func(t *testing.T) {
-		r, err := engine.Query(`
-		SELECT t1.title, t2.amount, t3.age
-		FROM (SELECT id, amount FROM table2 WHERE amount = 1) AS t2
-		INNER JOIN table1 AS t1 ON t2.id = t1.fkid1 AND t2.amount > 0
-		INNER JOIN table3 AS t3 ON t1.fkid2 = t3.id AND t3.age > 30`, nil, nil)
+	t.Run("should return three rows", func(t *testing.T) {
+		params := make(map[string]interface{})
+		params["id"] = 3
+
+		r, err := engine.Query(context.Background(), "SELECT DISTINCT amount, active FROM table1 WHERE id <= @id", params, nil)
 		require.NoError(t, err)
 
-		row, err := r.Read()
+		cols, err := r.Columns()
 		require.NoError(t, err)
-		require.Len(t, row.Values, 3)
+		require.Len(t, cols, 2)
+		require.Equal(t, "(db1.table1.amount)", cols[0].Selector())
+		require.Equal(t, "(db1.table1.active)", cols[1].Selector())
+
+		for i := 0; i <= 2; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Len(t, row.Values, 2)
+
+			if i == 0 {
+				require.Equal(t, int64(100), row.Values["(db1.table1.amount)"].Value())
+				require.Nil(t, row.Values["(db1.table1.active)"].Value())
+				continue
+			}
+
+			require.Equal(t, int64(200), row.Values["(db1.table1.amount)"].Value())
+			require.Equal(t, i == 2, row.Values["(db1.table1.active)"].Value())
+		}
 
 		_, err = r.Read()
-		require.Equal(t, ErrNoMoreRows, err)
+		require.ErrorIs(t, err, ErrNoMoreRows)
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
 
-	t.Run("should resolve every inserted row", func(t *testing.T) {
-		r, err := engine.Query(`
-			SELECT id, title, table2.amount, table3.age
-			FROM table1 INNER JOIN table2 ON table1.fkid1 = table2.id
-			INNER JOIN table3 ON table1.fkid2 = table3.id
-			WHERE table1.id >= 0 AND table3.age >= 30
-			ORDER BY id DESC`, nil, nil)
+	t.Run("should return too many rows error", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT DISTINCT id FROM table1", nil, nil)
 		require.NoError(t, err)
 
-		r.SetParameters(nil)
-
 		cols, err := r.Columns()
 		require.NoError(t, err)
-		require.Len(t, cols, 4)
+		require.Len(t, cols, 1)
+		require.Equal(t, "(db1.table1.id)", cols[0].Selector())
 
-		for i := 0; i < rowCount; i++ {
+		for i := 0; i < engine.distinctLimit; i++ {
 			row, err := r.Read()
 			require.NoError(t, err)
-			require.NotNil(t, row)
-			require.Len(t, row.Values, 4)
+			require.Len(t, row.Values, 1)
 
-			require.Equal(t, int64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-			require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-			require.Equal(t, int64((rowCount-1-i)*(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table2", "amount")].Value())
-			require.Equal(t, int64(30+(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table3", "age")].Value())
+			require.Equal(t, int64(i+1), row.Values["(db1.table1.id)"].Value())
 		}
 
-		err = r.Close()
-		require.NoError(t, err)
-	})
-
-	t.Run("should return error when joining nonexistent table", func(t *testing.T) {
-		r, err := engine.Query(`
-		SELECT title
-		FROM table1
-		INNER JOIN table22 ON table1.id = table11.fkid1`, nil, nil)
-		require.NoError(t, err)
-
 		_, err = r.Read()
-		require.Equal(t, ErrTableDoesNotExist, err)
+		require.ErrorIs(t, err, ErrTooManyRows)
 
 		err = r.Close()
 		require.NoError(t, err)
 	})
 }
 
-func TestJoinsWithNullIndexes(t *testing.T) {
-	st, err := store.Open("sqldata_join_nullable_index", store.DefaultOptions())
+func TestQuerySetOperations(t *testing.T) {
+	st, err := store.Open(t.TempDir(), store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_join_nullable_index")
+	defer st.Close()
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(`
-		CREATE TABLE table1 (id INTEGER, fkid2 INTEGER, PRIMARY KEY id);
-		CREATE TABLE table2 (id INTEGER, id2 INTEGER, val INTEGER, PRIMARY KEY id);
-		CREATE INDEX ON table2(id2);
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, title VARCHAR, amount INTEGER, PRIMARY KEY id);
+		CREATE TABLE table2 (id INTEGER AUTO_INCREMENT, amount INTEGER, PRIMARY KEY id);
 
-		INSERT INTO table2(id, id2, val) VALUES (1, 1, 100), (2, null, 200);
-		INSERT INTO table1(id, fkid2) VALUES (10, 1), (20, null);
+		INSERT INTO table1 (title, amount) VALUES ('title1', 100), ('title2', 200), ('title3', 200), ('title4', 300);
+		INSERT INTO table2 (amount) VALUES (200), (300), (300), (400);
 	`, nil, nil)
 	require.NoError(t, err)
 
-	r, err := engine.Query(`
-			SELECT table2.val
-			FROM table1 INNER JOIN table2 ON table1.fkid2 = table2.id2
-			ORDER BY table1.id`, nil, nil)
-	require.NoError(t, err)
+	readValues := func(t *testing.T, query string) []interface{} {
+		r, err := engine.Query(context.Background(), query, nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	cols, err := r.Columns()
-	require.NoError(t, err)
-	require.Len(t, cols, 1)
+		cols, err := r.Columns()
+		require.NoError(t, err)
 
-	row, err := r.Read()
-	require.NoError(t, err)
-	require.NotNil(t, row)
-	require.Len(t, row.Values, 1)
-	require.EqualValues(t, 100, row.Values[EncodeSelector("", "db1", "table2", "val")].Value())
+		var values []interface{}
 
-	row, err = r.Read()
-	require.NoError(t, err)
-	require.NotNil(t, row)
-	require.Len(t, row.Values, 1)
-	require.EqualValues(t, 200, row.Values[EncodeSelector("", "db1", "table2", "val")].Value())
+		for {
+			row, err := r.Read()
+			if errors.Is(err, ErrNoMoreRows) {
+				return values
+			}
+			require.NoError(t, err)
 
-	_, err = r.Read()
-	require.ErrorIs(t, err, ErrNoMoreRows)
+			values = append(values, row.Values[cols[0].Selector()].Value())
+		}
+	}
 
-	err = r.Close()
-	require.NoError(t, err)
-}
+	for _, c := range []struct {
+		query    string
+		expected []interface{}
+	}{
+		{"SELECT amount FROM table1 UNION SELECT amount FROM table2 ORDER BY amount", []interface{}{int64(100), int64(200), int64(300), int64(400)}},
+		{"SELECT amount FROM table1 UNION ALL SELECT amount FROM table2 ORDER BY amount DESC LIMIT 3", []interface{}{int64(400), int64(300), int64(300)}},
+		{"SELECT amount FROM table1 INTERSECT SELECT amount FROM table2", []interface{}{int64(200), int64(300)}},
+		{"SELECT amount FROM table1 INTERSECT ALL SELECT amount FROM table2", []interface{}{int64(200), int64(300)}},
+		{"SELECT amount FROM table1 EXCEPT SELECT amount FROM table2", []interface{}{int64(100)}},
+		{"SELECT amount FROM table1 EXCEPT ALL SELECT amount FROM table2", []interface{}{int64(100), int64(200)}},
+		{"SELECT amount FROM table2 EXCEPT SELECT amount FROM table1 WHERE amount < 300", []interface{}{int64(300), int64(400)}},
+		{"SELECT amount FROM table1 EXCEPT SELECT amount FROM table2 INTERSECT SELECT amount FROM table2 WHERE amount > 300", []interface{}{int64(100), int64(200), int64(300)}},
+		{"SELECT COUNT(*) FROM (SELECT amount FROM table1 UNION SELECT amount FROM table2) AS amounts", []interface{}{int64(4)}},
+		{"SELECT id FROM table1 WHERE amount IN (SELECT amount FROM table1 INTERSECT SELECT amount FROM table2)", []interface{}{int64(2), int64(3), int64(4)}},
+	} {
+		t.Run(c.query, func(t *testing.T) {
+			require.Equal(t, c.expected, readValues(t, c.query))
+		})
+	}
 
-func TestJoinsWithJointTable(t *testing.T) {
-	st, err := store.Open("sqldata_innerjoin_joint", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_innerjoin_joint")
+	t.Run("rows deleted since a tx should be found", func(t *testing.T) {
+		_, ctxs, err := engine.Exec(context.Background(), "DELETE FROM table1 WHERE id = 2", nil, nil)
+		require.NoError(t, err)
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+		query := fmt.Sprintf("SELECT id, title FROM table1 BEFORE TX %d EXCEPT SELECT id, title FROM table1", ctxs[0].TxHeader().ID)
+		require.Equal(t, []interface{}{int64(2)}, readValues(t, query))
+	})
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+	t.Run("queries should return the same columns", func(t *testing.T) {
+		_, err := engine.Query(context.Background(), "SELECT id, amount FROM table1 UNION SELECT amount FROM table2", nil, nil)
+		require.ErrorIs(t, err, ErrInvalidNumberOfValues)
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+		_, err = engine.Query(context.Background(), "SELECT title FROM table1 UNION SELECT amount FROM table2", nil, nil)
+		require.ErrorIs(t, err, ErrInvalidTypes)
+	})
+}
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, name VARCHAR, PRIMARY KEY id)", nil, nil)
+func TestQueryOuterJoins(t *testing.T) {
+	st, err := store.Open(t.TempDir(), store.DefaultOptions())
 	require.NoError(t, err)
+	defer st.Close()
 
-	_, _, err = engine.Exec("CREATE TABLE table2 (id INTEGER AUTO_INCREMENT, amount INTEGER, PRIMARY KEY id)", nil, nil)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE TABLE table12 (id INTEGER AUTO_INCREMENT, fkid1 INTEGER, fkid2 INTEGER, active BOOLEAN, PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("INSERT INTO table1 (name) VALUES ('name1'), ('name2'), ('name3')", nil, nil)
+	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("INSERT INTO table2 (amount) VALUES (10), (20), (30)", nil, nil)
-	require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE customers (id INTEGER, name VARCHAR, PRIMARY KEY id);
+		CREATE TABLE orders (id INTEGER, customer_id INTEGER, amount INTEGER, PRIMARY KEY id);
 
-	_, _, err = engine.Exec("INSERT INTO table12 (fkid1, fkid2, active) VALUES (1,1,false),(1,2,true),(1,3,true)", nil, nil)
+		INSERT INTO customers (id, name) VALUES (1, 'Alice'), (2, 'Bob'), (3, 'Carol');
+		INSERT INTO orders (id, customer_id, amount) VALUES (1, 1, 100), (2, 1, 50), (3, 2, 10), (4, 4, 70);
+	`, nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("INSERT INTO table12 (fkid1, fkid2, active) VALUES (2,1,false),(2,2,false),(2,3,true)", nil, nil)
-	require.NoError(t, err)
+	readRows := func(t *testing.T, query string) [][]interface{} {
+		r, err := engine.Query(context.Background(), query, nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	_, _, err = engine.Exec("INSERT INTO table12 (fkid1, fkid2, active) VALUES (3,1,false),(3,2,false),(3,3,false)", nil, nil)
-	require.NoError(t, err)
+		cols, err := r.Columns()
+		require.NoError(t, err)
 
-	r, err := engine.Query(`
-		SELECT q.name, t2.amount, t12.active
-		FROM (SELECT * FROM table1 where name = 'name1') q
-		INNER JOIN table12 t12 on t12.fkid1 = q.id
-		INNER JOIN table2 t2  on t12.fkid2 = t2.id
-		WHERE t12.active = true`, nil, nil)
-	require.NoError(t, err)
+		var rows [][]interface{}
 
-	cols, err := r.Columns()
-	require.NoError(t, err)
-	require.Len(t, cols, 3)
+		for {
+			row, err := r.Read()
+			if errors.Is(err, ErrNoMoreRows) {
+				return rows
+			}
+			require.NoError(t, err)
 
-	for i := 0; i < 2; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 3)
+			values := make([]interface{}, len(cols))
+			for i, col := range cols {
+				values[i] = row.Values[col.Selector()].Value()
+			}
 
-		require.Equal(t, "name1", row.Values[EncodeSelector("", "db1", "q", "name")].Value())
-		require.Equal(t, int64(20+i*10), row.Values[EncodeSelector("", "db1", "t2", "amount")].Value())
-		require.Equal(t, true, row.Values[EncodeSelector("", "db1", "t12", "active")].Value())
+			rows = append(rows, values)
+		}
 	}
 
-	err = r.Close()
-	require.NoError(t, err)
+	for _, c := range []struct {
+		query    string
+		expected [][]interface{}
+	}{
+		{
+			"SELECT c.id, o.id FROM customers AS c LEFT JOIN orders AS o ON o.customer_id = c.id",
+			[][]interface{}{{int64(1), int64(1)}, {int64(1), int64(2)}, {int64(2), int64(3)}, {int64(3), nil}},
+		},
+		{
+			"SELECT c.id, o.id FROM customers AS c LEFT OUTER JOIN orders AS o ON o.customer_id = c.id WHERE o.id IS NULL",
+			[][]interface{}{{int64(3), nil}},
+		},
+		{
+			"SELECT c.id, o.id FROM customers AS c LEFT JOIN orders AS o ON o.customer_id = c.id AND o.amount > 60",
+			[][]interface{}{{int64(1), int64(1)}, {int64(2), nil}, {int64(3), nil}},
+		},
+		{
+			"SELECT c.id, o.id FROM customers AS c LEFT JOIN orders AS o ON o.customer_id = c.id WHERE o.amount > 60",
+			[][]interface{}{{int64(1), int64(1)}},
+		},
+		{
+			"SELECT c.id, o.id FROM customers AS c RIGHT JOIN orders AS o ON o.customer_id = c.id",
+			[][]interface{}{{int64(1), int64(1)}, {int64(1), int64(2)}, {int64(2), int64(3)}, {nil, int64(4)}},
+		},
+		{
+			"SELECT c.id, o.id FROM customers AS c FULL OUTER JOIN orders AS o ON o.customer_id = c.id",
+			[][]interface{}{{int64(1), int64(1)}, {int64(1), int64(2)}, {int64(2), int64(3)}, {int64(3), nil}, {nil, int64(4)}},
+		},
+		{
+			"SELECT c.id, o.id FROM customers AS c FULL JOIN orders AS o ON o.customer_id = c.id WHERE c.id > 1",
+			[][]interface{}{{int64(2), int64(3)}, {int64(3), nil}},
+		},
+		{
+			"SELECT c.id, o2.amount FROM customers AS c FULL JOIN orders AS o ON o.customer_id = c.id INNER JOIN orders AS o2 ON o2.id = o.id",
+			[][]interface{}{{int64(1), int64(100)}, {int64(1), int64(50)}, {int64(2), int64(10)}, {nil, int64(70)}},
+		},
+		{
+			"SELECT COUNT(*) FROM customers AS c RIGHT JOIN orders AS o ON o.customer_id = c.id",
+			[][]interface{}{{int64(4)}},
+		},
+	} {
+		t.Run(c.query, func(t *testing.T) {
+			require.Equal(t, c.expected, readRows(t, c.query))
+		})
+	}
+
+	_, err = engine.Query(context.Background(), "SELECT c.id FROM customers AS c INNER OUTER JOIN orders AS o ON o.customer_id = c.id", nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected OUTER")
 }
 
-func TestNestedJoins(t *testing.T) {
-	st, err := store.Open("sqldata_nestedjoins", store.DefaultOptions())
+func TestQueryCrossAndNonEquiJoins(t *testing.T) {
+	st, err := store.Open(t.TempDir(), store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_nestedjoins")
+	defer st.Close()
 
 	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR, fkid1 INTEGER, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
-
-	_, _, err = engine.Exec("CREATE TABLE table2 (id INTEGER, amount INTEGER, fkid1 INTEGER, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE sizes (id INTEGER, name VARCHAR, PRIMARY KEY id);
+		CREATE TABLE colors (id INTEGER, name VARCHAR, PRIMARY KEY id);
+		CREATE TABLE ranges (id INTEGER, low INTEGER, high INTEGER, PRIMARY KEY id);
 
-	_, _, err = engine.Exec("CREATE TABLE table3 (id INTEGER, age INTEGER, PRIMARY KEY id)", nil, nil)
+		INSERT INTO sizes (id, name) VALUES (1, 'S'), (2, 'M');
+		INSERT INTO colors (id, name) VALUES (1, 'red'), (2, 'green'), (3, 'blue');
+		INSERT INTO ranges (id, low, high) VALUES (1, 0, 1), (2, 2, 3);
+	`, nil, nil)
 	require.NoError(t, err)
 
-	rowCount := 10
-
-	for i := 0; i < rowCount; i++ {
-		_, _, err = engine.Exec(fmt.Sprintf("UPSERT INTO table1 (id, title, fkid1) VALUES (%d, 'title%d', %d)", i, i, rowCount-1-i), nil, nil)
+	readRows := func(t *testing.T, query string) [][]interface{} {
+		r, err := engine.Query(context.Background(), query, nil, nil)
 		require.NoError(t, err)
+		defer r.Close()
 
-		_, _, err = engine.Exec(fmt.Sprintf("UPSERT INTO table2 (id, amount, fkid1) VALUES (%d, %d, %d)", rowCount-1-i, i*i, i), nil, nil)
+		cols, err := r.Columns()
 		require.NoError(t, err)
 
-		_, _, err = engine.Exec(fmt.Sprintf("UPSERT INTO table3 (id, age) VALUES (%d, %d)", i, 30+i), nil, nil)
-		require.NoError(t, err)
-	}
+		var rows [][]interface{}
 
-	r, err := engine.Query(`
-		SELECT id, title, t2.amount AS total_amount, t3.age
-		FROM table1 t1
-		INNER JOIN table2 t2 ON (fkid1 = t2.id AND title != NULL)
-		INNER JOIN table3 t3 ON t2.fkid1 = t3.id
-		ORDER BY id DESC`, nil, nil)
-	require.NoError(t, err)
+		for {
+			row, err := r.Read()
+			if errors.Is(err, ErrNoMoreRows) {
+				return rows
+			}
+			require.NoError(t, err)
 
-	cols, err := r.Columns()
-	require.NoError(t, err)
-	require.Len(t, cols, 4)
+			values := make([]interface{}, len(cols))
+			for i, col := range cols {
+				values[i] = row.Values[col.Selector()].Value()
+			}
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 4)
+			rows = append(rows, values)
+		}
+	}
 
-		require.Equal(t, int64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "t1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "t1", "title")].Value())
-		require.Equal(t, int64((rowCount-1-i)*(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "t2", "total_amount")].Value())
-		require.Equal(t, int64(30+(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "t3", "age")].Value())
+	for _, c := range []struct {
+		query    string
+		expected [][]interface{}
+	}{
+		{
+			"SELECT s.name, c.name FROM sizes AS s CROSS JOIN colors AS c",
+			[][]interface{}{{"S", "red"}, {"S", "green"}, {"S", "blue"}, {"M", "red"}, {"M", "green"}, {"M", "blue"}},
+		},
+		{
+			"SELECT s.name, c.name FROM sizes AS s CROSS JOIN colors AS c WHERE c.id > 1 AND s.id = 2",
+			[][]interface{}{{"M", "green"}, {"M", "blue"}},
+		},
+		{
+			"SELECT COUNT(*) FROM sizes CROSS JOIN colors CROSS JOIN ranges",
+			[][]interface{}{{int64(12)}},
+		},
+		{
+			"SELECT s.id, c.id FROM sizes AS s JOIN colors AS c ON c.id > s.id",
+			[][]interface{}{{int64(1), int64(2)}, {int64(1), int64(3)}, {int64(2), int64(3)}},
+		},
+		{
+			"SELECT s.id, c.id FROM sizes AS s LEFT JOIN colors AS c ON c.id > s.id + 1",
+			[][]interface{}{{int64(1), int64(3)}, {int64(2), nil}},
+		},
+		{
+			"SELECT c.id, r.id FROM colors AS c JOIN ranges AS r ON c.id BETWEEN r.low AND r.high",
+			[][]interface{}{{int64(1), int64(1)}, {int64(2), int64(2)}, {int64(3), int64(2)}},
+		},
+		{
+			"SELECT c.id, r.id FROM colors AS c INNER JOIN ranges AS r ON r.low <= c.id AND c.id < r.high",
+			[][]interface{}{{int64(2), int64(2)}},
+		},
+		{
+			"SELECT c.id, s.id FROM colors AS c JOIN sizes AS s ON c.name = 'red' OR s.id = 2",
+			[][]interface{}{{int64(1), int64(1)}, {int64(1), int64(2)}, {int64(2), int64(2)}, {int64(3), int64(2)}},
+		},
+	} {
+		t.Run(c.query, func(t *testing.T) {
+			require.Equal(t, c.expected, readRows(t, c.query))
+		})
 	}
 
-	err = r.Close()
-	require.NoError(t, err)
+	_, err = engine.Query(context.Background(), "SELECT s.id FROM sizes AS s CROSS JOIN colors AS c ON c.id = s.id", nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected ON")
+
+	_, err = engine.Query(context.Background(), "SELECT s.id FROM sizes AS s INNER JOIN colors AS c", nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expecting ON")
+
+	_, err = engine.Query(context.Background(), "SELECT s.id FROM sizes AS s CROSS OUTER JOIN colors AS c", nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected OUTER")
 }
 
-func TestReOpening(t *testing.T) {
-	st, err := store.Open("sqldata_reopening", store.DefaultOptions())
+func TestQueryHashJoins(t *testing.T) {
+	st, err := store.Open(t.TempDir(), store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_reopening")
+	defer st.Close()
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	sortDir := t.TempDir()
+
+	// a tiny buffer so joined rows get spilled into temporary files
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix).WithJoinBufferSize(256).WithSortDir(sortDir))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, name VARCHAR[30], PRIMARY KEY id)", nil, nil)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE customers (id INTEGER, name VARCHAR, PRIMARY KEY id);
+		CREATE TABLE orders (id INTEGER, customer_id INTEGER, amount INTEGER, PRIMARY KEY id);
+		CREATE TABLE indexed_orders (id INTEGER, customer_id INTEGER, amount INTEGER, PRIMARY KEY id);
+		CREATE INDEX ON indexed_orders(customer_id);
+	`, nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(name)", nil, nil)
-	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO customers (id, name) VALUES (@id, @name)",
+			map[string]interface{}{"id": i, "name": fmt.Sprintf("customer%d", i)}, nil)
+		require.NoError(t, err)
+	}
 
-	engine, err = NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		params := map[string]interface{}{"id": i, "customer_id": (i * 7) % 25, "amount": i * 10}
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+		if i%30 == 0 {
+			params["customer_id"] = nil
+		}
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, name VARCHAR[30], PRIMARY KEY id)", nil, nil)
-	require.ErrorIs(t, err, ErrTableAlreadyExists)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO orders (id, customer_id, amount) VALUES (@id, @customer_id, @amount)", params, nil)
+		require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE INDEX ON table1(name)", nil, nil)
-	require.ErrorIs(t, err, ErrIndexAlreadyExists)
-}
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO indexed_orders (id, customer_id, amount) VALUES (@id, @customer_id, @amount)", params, nil)
+		require.NoError(t, err)
+	}
 
-func TestSubQuery(t *testing.T) {
-	st, err := store.Open("sqldata_subq", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_subq")
+	readRows := func(t *testing.T, query string, params map[string]interface{}) ([][]interface{}, bool) {
+		r, err := engine.Query(context.Background(), query, params, nil)
+		require.NoError(t, err)
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+		cols, err := r.Columns()
+		require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+		var rows [][]interface{}
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+		for {
+			row, err := r.Read()
+			if errors.Is(err, ErrNoMoreRows) {
+				break
+			}
+			require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
+			values := make([]interface{}, len(cols))
+			for i, col := range cols {
+				values[i] = row.Values[col.Selector()].Value()
+			}
 
-	rowCount := 10
+			rows = append(rows, values)
+		}
 
-	for i := 0; i < rowCount; i++ {
-		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
-		_, _, err = engine.Exec(fmt.Sprintf(`
-			UPSERT INTO table1 (id, title, active, payload) VALUES (%d, 'title%d', %v, x'%s')
-		`, i, i, i%2 == 0, encPayload), nil, nil)
+		entries, err := os.ReadDir(sortDir)
 		require.NoError(t, err)
-	}
-
-	r, err := engine.Query(`
-		SELECT id, title t
-		FROM (SELECT id, title, active FROM table1) t2
-		WHERE active AND t2.id >= 0`, nil, nil)
-	require.NoError(t, err)
 
-	cols, err := r.Columns()
-	require.NoError(t, err)
-	require.Len(t, cols, 2)
+		err = r.Close()
+		require.NoError(t, err)
 
-	for i := 0; i < rowCount; i += 2 {
-		row, err := r.Read()
+		// temporary files are removed once the reader is closed
+		remaining, err := os.ReadDir(sortDir)
 		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 2)
+		require.Empty(t, remaining)
 
-		require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "t2", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "t2", "t")].Value())
+		return rows, len(entries) > 0
 	}
 
-	err = r.Close()
-	require.NoError(t, err)
+	// queries are run against both tables of orders
+	queries := []string{
+		"SELECT c.id, o.id FROM customers AS c INNER JOIN %s AS o ON o.customer_id = c.id",
+		"SELECT c.id, o.id FROM customers AS c INNER JOIN %s AS o ON c.id = o.customer_id AND o.amount > 300",
+		"SELECT c.id, o.id FROM customers AS c LEFT JOIN %s AS o ON o.customer_id = c.id",
+		"SELECT c.id, o.id FROM customers AS c RIGHT JOIN %s AS o ON o.customer_id = c.id",
+		"SELECT c.id, o.id FROM customers AS c FULL JOIN %s AS o ON o.customer_id = c.id WHERE c.id > @id",
+		"SELECT COUNT(*) FROM customers AS c INNER JOIN %s AS o ON o.customer_id = c.id",
+	}
 
-	_, _, err = engine.Exec("UPSERT INTO table1 (id, title) VALUES (0, 'title0')", nil, nil)
-	require.NoError(t, err)
+	params := map[string]interface{}{"id": 10}
 
-	r, err = engine.Query("SELECT id, title, active FROM (SELECT id, title, active FROM table1) WHERE active", nil, nil)
-	require.NoError(t, err)
+	hashJoined := make([][][]interface{}, len(queries))
 
-	_, err = r.Read()
-	require.NoError(t, err)
+	t.Run("rows should be hash joined when the joined column is not indexed", func(t *testing.T) {
+		for i, query := range queries {
+			rows, spilled := readRows(t, fmt.Sprintf(query, "orders"), params)
+			require.NotEmpty(t, rows)
+			require.True(t, spilled)
 
-	err = r.Close()
-	require.NoError(t, err)
+			hashJoined[i] = rows
+		}
+	})
 
-	r, err = engine.Query("SELECT id, title, active FROM (SELECT id, title, active FROM table1) WHERE title", nil, nil)
-	require.NoError(t, err)
+	t.Run("rows should be joined by index lookups when the joined column is indexed", func(t *testing.T) {
+		for i, query := range queries {
+			rows, spilled := readRows(t, fmt.Sprintf(query, "indexed_orders"), params)
+			require.False(t, spilled)
+			require.Equal(t, hashJoined[i], rows)
+		}
+	})
 
-	_, err = r.Read()
-	require.Equal(t, ErrInvalidCondition, err)
+	t.Run("hash joined subqueries should be read with the query parameters", func(t *testing.T) {
+		query := "SELECT o.id FROM orders AS o INNER JOIN (SELECT id FROM customers WHERE id < @id) AS c ON c.id = o.customer_id"
 
-	err = r.Close()
-	require.NoError(t, err)
+		rows, spilled := readRows(t, query, params)
+		require.True(t, spilled)
+		require.NotEmpty(t, rows)
+
+		for _, row := range rows {
+			id := row[0].(int64)
+			require.Less(t, (id*7)%25, int64(10))
+		}
+	})
 }
 
-func TestJoinsWithSubquery(t *testing.T) {
-	st, err := store.Open("sqldata_subq", store.DefaultOptions())
+func TestQuerySelfJoins(t *testing.T) {
+	st, err := store.Open(t.TempDir(), store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_subq")
+	defer st.Close()
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix).WithAutocommit(true))
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(`
-		CREATE TABLE IF NOT EXISTS customers (
-			id            INTEGER,
-			customer_name VARCHAR[60],
-			email         VARCHAR[150],
-			address       VARCHAR,
-			city          VARCHAR,
-			ip            VARCHAR[40],
-			country       VARCHAR[15],
-			age           INTEGER,
-			active        BOOLEAN,
-			PRIMARY KEY (id)
-		);
-
-		CREATE TABLE customer_review(
-			customerid INTEGER,
-			productid  INTEGER,
-			review     VARCHAR,
-			PRIMARY KEY (customerid, productid)
-		);
-
-		INSERT INTO customers (
-			id, customer_name, email, address,
-			city, ip, country, age, active
-		)
-		VALUES (
-			1,
-			'Isidro Behnen',
-			'ibehnen0@mail.ru',
-			'ibehnen0@chronoengine.com',
-			'Arvika',
-			'127.0.0.15',
-			'SE',
-			24,
-			true
-		);
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE employees (id INTEGER, name VARCHAR, manager_id INTEGER, PRIMARY KEY id);
 
-		INSERT INTO customer_review (customerid, productid, review)
-		VALUES(1, 1, 'Nice Juice!');
+		INSERT INTO employees (id, name, manager_id) VALUES (1, 'ada', NULL), (2, 'bob', 1), (3, 'eve', 1), (4, 'joe', 2);
 	`, nil, nil)
 	require.NoError(t, err)
 
-	r, err := engine.Query(`
-		SELECT * FROM (
-			SELECT id, customer_name, age
-			FROM customers
-			AS c
-		)
-		INNER JOIN (
-			SELECT MAX(customerid) as customerid, COUNT(*) as review_count
-			FROM customer_review
-			AS r
-		) ON r.customerid = c.id
-		WHERE c.age < 30
-		`,
-		nil, nil)
-	require.NoError(t, err)
+	readRows := func(t *testing.T, query string) [][]interface{} {
+		r, err := engine.Query(context.Background(), query, nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	row, err := r.Read()
-	require.NoError(t, err)
+		cols, err := r.Columns()
+		require.NoError(t, err)
 
-	require.Len(t, row.Values, 5)
-	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "c", "id")].Value())
-	require.Equal(t, "Isidro Behnen", row.Values[EncodeSelector("", "db1", "c", "customer_name")].Value())
-	require.Equal(t, int64(24), row.Values[EncodeSelector("", "db1", "c", "age")].Value())
-	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "r", "customerid")].Value())
-	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "r", "review_count")].Value())
+		var rows [][]interface{}
 
-	err = r.Close()
-	require.NoError(t, err)
-}
+		for {
+			row, err := r.Read()
+			if errors.Is(err, ErrNoMoreRows) {
+				return rows
+			}
+			require.NoError(t, err)
 
-func TestInferParameters(t *testing.T) {
-	st, err := store.Open("catalog_infer_params", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("catalog_infer_params")
+			values := make([]interface{}, len(cols))
+			for i, col := range cols {
+				values[i] = row.Values[col.Selector()].Value()
+			}
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+			rows = append(rows, values)
+		}
+	}
 
-	stmt := "CREATE DATABASE db1"
+	for _, c := range []struct {
+		query    string
+		expected [][]interface{}
+	}{
+		{
+			"SELECT e.name, m.name FROM employees e JOIN employees m ON e.manager_id = m.id",
+			[][]interface{}{{"bob", "ada"}, {"eve", "ada"}, {"joe", "bob"}},
+		},
+		{
+			"SELECT e.name, m.name FROM employees AS e LEFT JOIN employees AS m ON e.manager_id = m.id WHERE e.id < 3",
+			[][]interface{}{{"ada", nil}, {"bob", "ada"}},
+		},
+		{
+			"SELECT e.name, g.name FROM employees AS e JOIN employees AS m ON e.manager_id = m.id JOIN employees AS g ON m.manager_id = g.id",
+			[][]interface{}{{"joe", "ada"}},
+		},
+		{
+			"SELECT m.name, COUNT(*) AS reports FROM employees AS e JOIN employees AS m ON e.manager_id = m.id GROUP BY m.name ORDER BY m.name",
+			[][]interface{}{{"ada", int64(2)}, {"bob", int64(1)}},
+		},
+		{
+			"SELECT m.name FROM employees AS m WHERE EXISTS (SELECT id FROM employees AS e WHERE e.manager_id = m.id) ORDER BY m.name DESC",
+			[][]interface{}{{"bob"}, {"ada"}},
+		},
+	} {
+		t.Run(c.query, func(t *testing.T) {
+			require.Equal(t, c.expected, readRows(t, c.query))
+		})
+	}
 
-	params, err := engine.InferParameters(stmt, nil)
-	require.NoError(t, err)
-	require.Empty(t, params)
+	t.Run("tables read more than once should be aliased", func(t *testing.T) {
+		_, err := engine.Query(context.Background(), "SELECT id FROM employees JOIN employees ON employees.manager_id = employees.id", nil, nil)
+		require.ErrorIs(t, err, ErrAmbiguousSelector)
 
-	params, err = engine.InferParametersPreparedStmts([]SQLStmt{&CreateDatabaseStmt{}}, nil)
-	require.NoError(t, err)
-	require.Empty(t, params)
+		_, err = engine.Query(context.Background(), "SELECT e.id FROM employees AS e JOIN employees AS e ON e.manager_id = e.id", nil, nil)
+		require.ErrorIs(t, err, ErrAmbiguousSelector)
+	})
 
-	params, err = engine.InferParameters(stmt, nil)
-	require.NoError(t, err)
-	require.Empty(t, params)
+	t.Run("columns should be referenced by the aliases of the tables in scope", func(t *testing.T) {
+		for _, query := range []string{
+			"SELECT employees.name FROM employees AS e",
+			"SELECT e.name FROM employees AS e WHERE m.id = 1",
+			"SELECT e.name FROM employees AS e ORDER BY m.name",
+			"SELECT e.name FROM employees AS e JOIN employees AS m ON e.manager_id = g.id",
+			"SELECT e.name FROM employees AS e JOIN employees AS m ON m.manager_id = g.id JOIN employees AS g ON g.id = e.id",
+			"SELECT m.name FROM employees AS m WHERE EXISTS (SELECT id FROM employees AS e WHERE e.manager_id = x.id)",
+		} {
+			r, err := engine.Query(context.Background(), query, nil, nil)
+			if err == nil {
+				_, err = r.Read()
+				r.Close()
+			}
+			require.ErrorIs(t, err, ErrTableNotInScope, query)
+		}
+	})
+}
 
-	params, err = engine.InferParametersPreparedStmts([]SQLStmt{&CreateDatabaseStmt{}}, nil)
+func TestIndexing(t *testing.T) {
+	st, err := store.Open("sqldata_indexing", store.DefaultOptions())
 	require.NoError(t, err)
-	require.Empty(t, params)
+	defer os.RemoveAll("sqldata_indexing")
 
-	_, _, err = engine.Exec(stmt, nil, nil)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
 	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	_, err = engine.InferParameters("INSERT INTO mytable(id, title) VALUES (@id, @title);", nil)
-	require.ErrorIs(t, err, ErrNoDatabaseSelected)
-
-	err = engine.SetDefaultDatabase("db1")
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
-	_, err = engine.InferParameters("invalid sql stmt", nil)
-	require.EqualError(t, err, "syntax error: unexpected IDENTIFIER at position 7")
-
-	_, err = engine.InferParametersPreparedStmts(nil, nil)
-	require.ErrorIs(t, err, ErrIllegalArguments)
-
-	params, err = engine.InferParameters(stmt, nil)
+	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
-	require.Len(t, params, 0)
 
-	params, err = engine.InferParameters("USE DATABASE db1", nil)
+	_, _, err = engine.Exec(context.Background(), `CREATE TABLE table1 (
+								id INTEGER AUTO_INCREMENT,
+								ts INTEGER,
+								title VARCHAR[20],
+								active BOOLEAN,
+								amount INTEGER,
+								payload BLOB,
+								PRIMARY KEY id
+							)`, nil, nil)
 	require.NoError(t, err)
-	require.Len(t, params, 0)
 
-	params, err = engine.InferParameters("USE SNAPSHOT BEFORE TX 10", nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1 (ts)", nil, nil)
 	require.NoError(t, err)
-	require.Len(t, params, 0)
-
-	stmt = "CREATE TABLE mytable(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)"
 
-	params, err = engine.InferParameters(stmt, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE UNIQUE INDEX ON table1 (title, amount)", nil, nil)
 	require.NoError(t, err)
-	require.Len(t, params, 0)
 
-	pstmt, err := Parse(strings.NewReader(stmt))
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1 (active, title)", nil, nil)
 	require.NoError(t, err)
-	require.Len(t, pstmt, 1)
 
-	_, err = engine.InferParametersPreparedStmts(pstmt, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE UNIQUE INDEX ON table1 (title)", nil, nil)
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec(stmt, nil, nil)
-	require.NoError(t, err)
+	t.Run("should fail due to unique index", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (ts, title, amount, active) VALUES (1, 'title1', 10, true), (2, 'title1', 10, false)", nil, nil)
+		require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
+	})
 
-	params, err = engine.InferParameters("ALTER TABLE mytableSE ADD COLUMN note VARCHAR", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 0)
+	t.Run("should sort rows when no index is available", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 ORDER BY amount DESC", nil, nil)
+		require.NoError(t, err)
 
-	stmt = "CREATE INDEX ON mytable(active)"
+		orderBy := r.OrderBy()
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "amount", orderBy[0].Column)
+		require.Equal(t, "id", orderBy[1].Column)
 
-	params, err = engine.InferParameters(stmt, nil)
-	require.NoError(t, err)
-	require.Len(t, params, 0)
+		scanSpecs := r.ScanSpecs()
+		require.True(t, scanSpecs.index.IsPrimary())
+		require.True(t, scanSpecs.sortRequired)
+		require.False(t, scanSpecs.descOrder)
 
-	_, _, err = engine.Exec(stmt, nil, nil)
-	require.NoError(t, err)
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	params, err = engine.InferParameters("BEGIN TRANSACTION; INSERT INTO mytable(id, title) VALUES (@id, @title); COMMIT;", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 2)
-	require.Equal(t, IntegerType, params["id"])
-	require.Equal(t, VarcharType, params["title"])
+	t.Run("should use primary index by default", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1", nil, nil)
+		require.NoError(t, err)
 
-	params, err = engine.InferParameters("INSERT INTO mytable(id, title) VALUES (1, 'title1')", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 0)
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 1)
+		require.Equal(t, "id", orderBy[0].Column)
 
-	params, err = engine.InferParameters("INSERT INTO mytable(id, title) VALUES (1, 'title1'), (@id2, @title2)", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 2)
-	require.Equal(t, IntegerType, params["id2"])
-	require.Equal(t, VarcharType, params["title2"])
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.True(t, scanSpecs.index.IsPrimary())
+		require.Empty(t, scanSpecs.rangesByColID)
+		require.False(t, scanSpecs.descOrder)
 
-	params, err = engine.InferParameters("SELECT * FROM mytable WHERE (id - 1) > (@id + (@id+1))", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 1)
-	require.Equal(t, IntegerType, params["id"])
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	params, err = engine.InferParameters("SELECT * FROM mytable t1 INNER JOIN mytable t2 ON t1.id = t2.id WHERE id > @id", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 1)
-	require.Equal(t, IntegerType, params["id"])
+	t.Run("should use primary index in descending order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 ORDER BY id DESC", nil, nil)
+		require.NoError(t, err)
 
-	params, err = engine.InferParameters("SELECT * FROM mytable WHERE id > @id AND (NOT @active OR active)", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 2)
-	require.Equal(t, IntegerType, params["id"])
-	require.Equal(t, BooleanType, params["active"])
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 1)
+		require.Equal(t, "id", orderBy[0].Column)
 
-	params, err = engine.InferParameters("SELECT * FROM mytable WHERE id > ? AND (NOT ? OR active)", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 2)
-	require.Equal(t, IntegerType, params["param1"])
-	require.Equal(t, BooleanType, params["param2"])
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.True(t, scanSpecs.index.IsPrimary())
+		require.Empty(t, scanSpecs.rangesByColID)
+		require.True(t, scanSpecs.descOrder)
 
-	params, err = engine.InferParameters("SELECT * FROM mytable WHERE id > $2 AND (NOT $1 OR active)", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 2)
-	require.Equal(t, BooleanType, params["param1"])
-	require.Equal(t, IntegerType, params["param2"])
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	params, err = engine.InferParameters("SELECT COUNT(*) FROM mytable GROUP BY active HAVING @param1 = COUNT(*) ORDER BY active", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 1)
-	require.Equal(t, IntegerType, params["param1"])
+	t.Run("should use index on `ts` ascending order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 ORDER BY ts", nil, nil)
+		require.NoError(t, err)
 
-	params, err = engine.InferParameters("SELECT COUNT(*), MIN(id) FROM mytable GROUP BY active HAVING @param1 < MIN(id) ORDER BY active", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 1)
-	require.Equal(t, IntegerType, params["param1"])
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "ts", orderBy[0].Column)
+		require.Equal(t, "id", orderBy[1].Column)
 
-	params, err = engine.InferParameters("SELECT * FROM mytable WHERE @active AND title LIKE 't+'", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 1)
-	require.Equal(t, BooleanType, params["active"])
-}
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.False(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Empty(t, scanSpecs.rangesByColID)
+		require.False(t, scanSpecs.descOrder)
 
-func TestInferParametersPrepared(t *testing.T) {
-	st, err := store.Open("catalog_infer_params_prepared", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("catalog_infer_params_prepared")
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+	t.Run("should use index on `ts` descending order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 ORDER BY ts DESC", nil, nil)
+		require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "ts", orderBy[0].Column)
+		require.Equal(t, "id", orderBy[1].Column)
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.False(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Empty(t, scanSpecs.rangesByColID)
+		require.True(t, scanSpecs.descOrder)
 
-	stmts, err := Parse(strings.NewReader("CREATE TABLE mytable(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)"))
-	require.NoError(t, err)
-	require.Len(t, stmts, 1)
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	params, err := engine.InferParametersPreparedStmts(stmts, nil)
-	require.NoError(t, err)
-	require.Len(t, params, 0)
+	t.Run("should use index on `ts` with specific value", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 WHERE ts = 1629902962 OR ts < 1629902963 ORDER BY ts", nil, nil)
+		require.NoError(t, err)
 
-	_, _, err = engine.ExecPreparedStmts(stmts, nil, nil)
-	require.NoError(t, err)
-}
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "ts", orderBy[0].Column)
+		require.Equal(t, "id", orderBy[1].Column)
 
-func TestInferParametersUnbounded(t *testing.T) {
-	st, err := store.Open("catalog_infer_params_unbounded", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("catalog_infer_params_unbounded")
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.False(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Len(t, scanSpecs.rangesByColID, 1)
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+		tsRange := scanSpecs.rangesByColID[2]
+		require.Nil(t, tsRange.lRange)
+		require.NotNil(t, tsRange.hRange)
+		require.False(t, tsRange.hRange.inclusive)
+		require.Equal(t, int64(1629902963), tsRange.hRange.val.Value())
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+		require.False(t, scanSpecs.descOrder)
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	_, _, err = engine.Exec("CREATE TABLE mytable(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
+	t.Run("should use index on `ts` with specific value", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 AS t WHERE t.ts = 1629902962 AND t.ts = 1629902963 ORDER BY t.ts", nil, nil)
+		require.NoError(t, err)
 
-	params, err := engine.InferParameters("SELECT * FROM mytable WHERE @param1 = @param2", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 2)
-	require.Equal(t, AnyType, params["param1"])
-	require.Equal(t, AnyType, params["param2"])
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "ts", orderBy[0].Column)
+		require.Equal(t, "id", orderBy[1].Column)
 
-	params, err = engine.InferParameters("SELECT * FROM mytable WHERE @param1 AND @param2", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 2)
-	require.Equal(t, BooleanType, params["param1"])
-	require.Equal(t, BooleanType, params["param2"])
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.False(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Len(t, scanSpecs.rangesByColID, 1)
 
-	params, err = engine.InferParameters("SELECT * FROM mytable WHERE @param1 != NULL", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 1)
-	require.Equal(t, AnyType, params["param1"])
+		tsRange := scanSpecs.rangesByColID[2]
+		require.NotNil(t, tsRange.lRange)
+		require.True(t, tsRange.lRange.inclusive)
+		require.Equal(t, int64(1629902963), tsRange.lRange.val.Value())
+		require.NotNil(t, tsRange.hRange)
+		require.True(t, tsRange.hRange.inclusive)
+		require.Equal(t, int64(1629902962), tsRange.hRange.val.Value())
 
-	params, err = engine.InferParameters("SELECT * FROM mytable WHERE @param1 != NOT NULL", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 1)
-	require.Equal(t, BooleanType, params["param1"])
+		require.False(t, scanSpecs.descOrder)
 
-	params, err = engine.InferParameters("SELECT * FROM mytable WHERE @param1 != NULL AND (@param1 AND active)", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 1)
-	require.Equal(t, BooleanType, params["param1"])
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	params, err = engine.InferParameters("SELECT * FROM mytable WHERE @param1 != NULL AND (@param1 <= mytable.id)", nil)
-	require.NoError(t, err)
-	require.Len(t, params, 1)
-	require.Equal(t, IntegerType, params["param1"])
-}
+	t.Run("should use index on `ts` with specific value", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 WHERE ts > 1629902962 AND ts < 1629902963 ORDER BY ts", nil, nil)
+		require.NoError(t, err)
 
-func TestInferParametersInvalidCases(t *testing.T) {
-	st, err := store.Open("catalog_infer_params_invalid", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("catalog_infer_params_invalid")
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "ts", orderBy[0].Column)
+		require.Equal(t, "id", orderBy[1].Column)
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
-	require.NoError(t, err)
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.False(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Len(t, scanSpecs.rangesByColID, 1)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
-	require.NoError(t, err)
+		tsRange := scanSpecs.rangesByColID[2]
+		require.NotNil(t, tsRange.lRange)
+		require.False(t, tsRange.lRange.inclusive)
+		require.Equal(t, int64(1629902962), tsRange.lRange.val.Value())
+		require.NotNil(t, tsRange.hRange)
+		require.False(t, tsRange.hRange.inclusive)
+		require.Equal(t, int64(1629902963), tsRange.hRange.val.Value())
 
-	err = engine.SetDefaultDatabase("db1")
-	require.NoError(t, err)
+		require.False(t, scanSpecs.descOrder)
 
-	_, _, err = engine.Exec("CREATE TABLE mytable(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil, nil)
-	require.NoError(t, err)
+		err = r.Close()
+		require.NoError(t, err)
+	})
 
-	_, err = engine.InferParameters("INSERT INTO mytable(id, title) VALUES (@param1, @param1)", nil)
-	require.Equal(t, ErrInferredMultipleTypes, err)
+	t.Run("should use index on `title, amount` in asc order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 USE INDEX ON (title, amount) ORDER BY title", nil, nil)
+		require.NoError(t, err)
 
-	_, err = engine.InferParameters("INSERT INTO mytable(id, title) VALUES (@param1)", nil)
-	require.Equal(t, ErrInvalidNumberOfValues, err)
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "title", orderBy[0].Column)
+		require.Equal(t, "amount", orderBy[1].Column)
 
-	_, err = engine.InferParameters("INSERT INTO mytable1(id, title) VALUES (@param1, @param2)", nil)
-	require.Equal(t, ErrTableDoesNotExist, err)
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.True(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 2)
+		require.Empty(t, scanSpecs.rangesByColID)
+		require.False(t, scanSpecs.descOrder)
 
-	_, err = engine.InferParameters("INSERT INTO mytable(id, note) VALUES (@param1, @param2)", nil)
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should use index on `title` in asc order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 USE INDEX ON (title) ORDER BY title", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 1)
+		require.Equal(t, "title", orderBy[0].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.True(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Empty(t, scanSpecs.rangesByColID)
+		require.False(t, scanSpecs.descOrder)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should use index on `ts` in default order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 USE INDEX ON (ts)", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "ts", orderBy[0].Column)
+		require.Equal(t, "id", orderBy[1].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.False(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Empty(t, scanSpecs.rangesByColID)
+		require.False(t, scanSpecs.descOrder)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should sort rows read using index on `ts` when ordering by `title`", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 USE INDEX ON (ts) ORDER BY title", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.Len(t, orderBy, 3)
+		require.Equal(t, "title", orderBy[0].Column)
+		require.Equal(t, "ts", orderBy[1].Column)
+		require.Equal(t, "id", orderBy[2].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.Equal(t, "ts", scanSpecs.index.cols[0].colName)
+		require.True(t, scanSpecs.sortRequired)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should use index on `title` with max value in desc order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 USE INDEX ON (title) WHERE title < 'title10' ORDER BY title DESC", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 1)
+		require.Equal(t, "title", orderBy[0].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.True(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Len(t, scanSpecs.rangesByColID, 1)
+
+		titleRange := scanSpecs.rangesByColID[3]
+		require.Nil(t, titleRange.lRange)
+		require.NotNil(t, titleRange.hRange)
+		require.False(t, titleRange.hRange.inclusive)
+		require.Equal(t, "title10", titleRange.hRange.val.Value())
+
+		require.True(t, scanSpecs.descOrder)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should use index on `title,amount` in desc order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 WHERE title = 'title1' ORDER BY amount DESC", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "title", orderBy[0].Column)
+		require.Equal(t, "amount", orderBy[1].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.True(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 2)
+		require.Len(t, scanSpecs.rangesByColID, 1)
+
+		titleRange := scanSpecs.rangesByColID[3]
+		require.NotNil(t, titleRange.lRange)
+		require.True(t, titleRange.lRange.inclusive)
+		require.Equal(t, "title1", titleRange.lRange.val.Value())
+		require.NotNil(t, titleRange.hRange)
+		require.True(t, titleRange.hRange.inclusive)
+		require.Equal(t, "title1", titleRange.hRange.val.Value())
+
+		require.True(t, scanSpecs.descOrder)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should use index on `ts` ascending order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 WHERE title > 'title10' ORDER BY ts ASC", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "ts", orderBy[0].Column)
+		require.Equal(t, "id", orderBy[1].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.False(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Len(t, scanSpecs.rangesByColID, 1)
+
+		titleRange := scanSpecs.rangesByColID[3]
+		require.NotNil(t, titleRange.lRange)
+		require.False(t, titleRange.lRange.inclusive)
+		require.Equal(t, "title10", titleRange.lRange.val.Value())
+		require.Nil(t, titleRange.hRange)
+
+		require.False(t, scanSpecs.descOrder)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should use index on `ts` descending order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 WHERE title > 'title10' or title = 'title1' ORDER BY ts DESC", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "ts", orderBy[0].Column)
+		require.Equal(t, "id", orderBy[1].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.False(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Len(t, scanSpecs.rangesByColID, 1)
+
+		titleRange := scanSpecs.rangesByColID[3]
+		require.NotNil(t, titleRange.lRange)
+		require.True(t, titleRange.lRange.inclusive)
+		require.Equal(t, "title1", titleRange.lRange.val.Value())
+		require.Nil(t, titleRange.hRange)
+
+		require.True(t, scanSpecs.descOrder)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should use index on `title` descending order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 WHERE title > 'title10' or title = 'title1' ORDER BY title DESC", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 2)
+		require.Equal(t, "title", orderBy[0].Column)
+		require.Equal(t, "amount", orderBy[1].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.True(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 2)
+		require.Len(t, scanSpecs.rangesByColID, 1)
+
+		titleRange := scanSpecs.rangesByColID[3]
+		require.NotNil(t, titleRange.lRange)
+		require.True(t, titleRange.lRange.inclusive)
+		require.Equal(t, "title1", titleRange.lRange.val.Value())
+		require.Nil(t, titleRange.hRange)
+
+		require.True(t, scanSpecs.descOrder)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should use index on `title` ascending order starting with 'title1'", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 USE INDEX ON (title) WHERE title > 'title10' or title = 'title1' ORDER BY title", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 1)
+		require.Equal(t, "title", orderBy[0].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.True(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Len(t, scanSpecs.rangesByColID, 1)
+
+		titleRange := scanSpecs.rangesByColID[3]
+		require.NotNil(t, titleRange.lRange)
+		require.True(t, titleRange.lRange.inclusive)
+		require.Equal(t, "title1", titleRange.lRange.val.Value())
+		require.Nil(t, titleRange.hRange)
+
+		require.False(t, scanSpecs.descOrder)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should use index on `title` ascending order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 USE INDEX ON (title) WHERE title < 'title10' or title = 'title1' ORDER BY title", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 1)
+		require.Equal(t, "title", orderBy[0].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.True(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Len(t, scanSpecs.rangesByColID, 1)
+
+		titleRange := scanSpecs.rangesByColID[3]
+		require.Nil(t, titleRange.lRange)
+		require.NotNil(t, titleRange.hRange)
+		require.False(t, titleRange.hRange.inclusive)
+		require.Equal(t, "title10", titleRange.hRange.val.Value())
+
+		require.False(t, scanSpecs.descOrder)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should use index on `title` descending order", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 USE INDEX ON (title) WHERE title < 'title10' and title = 'title1' ORDER BY title DESC", nil, nil)
+		require.NoError(t, err)
+
+		orderBy := r.OrderBy()
+		require.NotNil(t, orderBy)
+		require.Len(t, orderBy, 1)
+		require.Equal(t, "title", orderBy[0].Column)
+
+		scanSpecs := r.ScanSpecs()
+		require.NotNil(t, scanSpecs)
+		require.NotNil(t, scanSpecs.index)
+		require.False(t, scanSpecs.index.IsPrimary())
+		require.True(t, scanSpecs.index.IsUnique())
+		require.Len(t, scanSpecs.index.cols, 1)
+		require.Len(t, scanSpecs.rangesByColID, 1)
+
+		titleRange := scanSpecs.rangesByColID[3]
+		require.NotNil(t, titleRange.lRange)
+		require.True(t, titleRange.lRange.inclusive)
+		require.Equal(t, "title1", titleRange.lRange.val.Value())
+		require.NotNil(t, titleRange.hRange)
+		require.True(t, titleRange.hRange.inclusive)
+		require.Equal(t, "title1", titleRange.hRange.val.Value())
+
+		require.True(t, scanSpecs.descOrder)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+}
+
+func TestUniqueColumn(t *testing.T) {
+	st, err := store.Open("sqldata_unique_column", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_unique_column")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER UNIQUE AUTO_INCREMENT, email VARCHAR UNIQUE, PRIMARY KEY id)", nil, nil)
+	require.ErrorIs(t, err, ErrLimitedKeyType)
+
+	_, _, err = engine.Exec(context.Background(), `CREATE TABLE table1 (
+								id INTEGER UNIQUE AUTO_INCREMENT,
+								email VARCHAR[64] NOT NULL UNIQUE,
+								name VARCHAR,
+								PRIMARY KEY id
+							)`, nil, nil)
+	require.NoError(t, err)
+
+	catalog, err := engine.Catalog(context.Background(), nil)
+	require.NoError(t, err)
+
+	table, err := catalog.GetTableByName("db1", "table1")
+	require.NoError(t, err)
+	require.Len(t, table.indexes, 2)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (email, name) VALUES ('jane@example.com', 'Jane'), ('john@example.com', 'John')", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (email, name) VALUES ('jane@example.com', 'Jane Doe')", nil, nil)
+	require.ErrorIs(t, err, ErrDuplicateUniqueValue)
+	require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
+
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, email, name) VALUES (2, 'jane@example.com', 'John')", nil, nil)
+	require.ErrorIs(t, err, ErrDuplicateUniqueValue)
+
+	// rows keep their own unique values
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, email, name) VALUES (1, 'jane@example.com', 'Jane Doe')", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "UPDATE table1 SET email = 'jane@example.com' WHERE id = 2", nil, nil)
+	require.ErrorIs(t, err, ErrDuplicateUniqueValue)
+
+	// released values can be taken by other rows
+	_, _, err = engine.Exec(context.Background(), "UPDATE table1 SET email = 'jane.doe@example.com' WHERE id = 1", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "UPDATE table1 SET email = 'jane@example.com' WHERE id = 2", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 ADD COLUMN phone VARCHAR[16] UNIQUE", nil, nil)
+	require.ErrorIs(t, err, ErrLimitedIndexCreation)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table2 (id INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table2 ADD COLUMN code VARCHAR[16] UNIQUE", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table2 (id, code) VALUES (1, 'a'), (2, 'a')", nil, nil)
+	require.ErrorIs(t, err, ErrDuplicateUniqueValue)
+}
+
+func TestExecCornerCases(t *testing.T) {
+	st, err := store.Open("sqldata_q", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_q")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	tx, _, err := engine.Exec(context.Background(), "INVALID STATEMENT", nil, nil)
+	require.EqualError(t, err, "syntax error: unexpected IDENTIFIER at position 7")
+	require.Nil(t, tx)
+}
+
+func TestQueryWithNullables(t *testing.T) {
+	st, err := store.Open("sqldata_nullable", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_nullable")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, ts TIMESTAMP, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, ts, title) VALUES (1, TIME(), 'title1')", nil, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	rowCount := 10
+
+	start := time.Now()
+
+	for i := 0; i < rowCount; i++ {
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf("UPSERT INTO table1 (id, ts, title) VALUES (%d, NOW(), 'title%d')", i, i), nil, nil)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.Query(context.Background(), "SELECT id, ts, title, active FROM table1 WHERE NOT(active != NULL)", nil, nil)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 4)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 4)
+		require.False(t, start.After(row.Values[EncodeSelector("", "db1", "table1", "ts")].Value().(time.Time)))
+		require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, &NullValue{t: BooleanType}, row.Values[EncodeSelector("", "db1", "table1", "active")])
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestOrderBy(t *testing.T) {
+	st, err := store.Open("sqldata_orderby", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_orderby")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(title)", nil, nil)
+	require.Equal(t, ErrNoDatabaseSelected, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR[100], age INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, err = engine.Query(context.Background(), "SELECT id, title, age FROM table2 ORDER BY title", nil, nil)
+	require.Equal(t, ErrTableDoesNotExist, err)
+
+	_, err = engine.Query(context.Background(), "SELECT id, title, age FROM table1 ORDER BY amount", nil, nil)
+	require.Equal(t, ErrColumnDoesNotExist, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(title)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(age)", nil, nil)
+	require.NoError(t, err)
+
+	params := make(map[string]interface{}, 1)
+	params["age"] = nil
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, age) VALUES (1, 'title', @age)", params, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title) VALUES (2, 'title')", nil, nil)
+	require.NoError(t, err)
+
+	rowCount := 1
+
+	for i := 0; i < rowCount; i++ {
+		params := make(map[string]interface{}, 3)
+		params["id"] = i + 3
+		params["title"] = fmt.Sprintf("title%d", i)
+		params["age"] = 40 + i
+
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, age) VALUES (@id, @title, @age)", params, nil)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.Query(context.Background(), "SELECT id, title, age FROM table1 ORDER BY title", nil, nil)
+	require.NoError(t, err)
+
+	orderBy := r.OrderBy()
+	require.NotNil(t, orderBy)
+	require.Len(t, orderBy, 2)
+	require.Equal(t, "title", orderBy[0].Column)
+	require.Equal(t, "id", orderBy[1].Column)
+	require.Equal(t, "table1", orderBy[0].Table)
+	require.Equal(t, "db1", orderBy[0].Database)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Len(t, row.Values, 3)
+
+	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.Len(t, row.Values, 3)
+
+	require.Equal(t, int64(2), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 3)
+
+		require.Equal(t, int64(i+3), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, int64(40+i), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title, age FROM table1 ORDER BY age", nil, nil)
+	require.NoError(t, err)
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.Len(t, row.Values, 3)
+
+	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.Len(t, row.Values, 3)
+
+	require.Equal(t, int64(2), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 3)
+
+		require.Equal(t, int64(i+3), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, int64(40+i), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title, age FROM table1 ORDER BY age DESC", nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 3)
+
+		require.Equal(t, int64(rowCount-1-i+3), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, int64(40-(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	}
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.Len(t, row.Values, 3)
+
+	require.Equal(t, int64(2), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.Len(t, row.Values, 3)
+
+	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+	require.Equal(t, "title", row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+	require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestOrderByWithoutIndex(t *testing.T) {
+	st, err := store.Open("sqldata_orderby_sort", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_orderby_sort")
+
+	sortDir := t.TempDir()
+
+	// a tiny buffer so rows get spilled into temporary files
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix).WithSortBufferSize(256).WithSortDir(sortDir))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table2 (id INTEGER, label VARCHAR, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	rowCount := 100
+
+	for i := 0; i < rowCount; i++ {
+		params := map[string]interface{}{
+			"id":    i,
+			"title": fmt.Sprintf("title%d", i%10),
+			"age":   (i * 37) % rowCount,
+		}
+
+		if i%25 == 0 {
+			params["age"] = nil
+		}
+
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, age) VALUES (@id, @title, @age)", params, nil)
+		require.NoError(t, err)
+
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table2 (id, label) VALUES (@id, @label)",
+			map[string]interface{}{"id": i, "label": fmt.Sprintf("label%d", rowCount-i)}, nil)
+		require.NoError(t, err)
+	}
+
+	readAll := func(t *testing.T, query string) []*Row {
+		r, err := engine.Query(context.Background(), query, nil, nil)
+		require.NoError(t, err)
+
+		var rows []*Row
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			rows = append(rows, row)
+		}
+
+		err = r.Close()
+		require.NoError(t, err)
+
+		// temporary files are removed once the reader is closed
+		entries, err := os.ReadDir(sortDir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+
+		return rows
+	}
+
+	idSel := EncodeSelector("", "db1", "table1", "id")
+	titleSel := EncodeSelector("", "db1", "table1", "title")
+	ageSel := EncodeSelector("", "db1", "table1", "age")
+
+	t.Run("rows should be sorted in asc order with nulls first", func(t *testing.T) {
+		rows := readAll(t, "SELECT id, title, age FROM table1 ORDER BY age")
+		require.Len(t, rows, rowCount)
+
+		for i := 0; i < 4; i++ {
+			require.True(t, rows[i].Values[ageSel].IsNull())
+			require.Equal(t, int64(i*25), rows[i].Values[idSel].Value())
+		}
+
+		for i := 5; i < rowCount; i++ {
+			cmp, err := rows[i-1].Values[ageSel].Compare(rows[i].Values[ageSel])
+			require.NoError(t, err)
+			require.Less(t, cmp, 0)
+		}
+	})
+
+	t.Run("rows should be sorted by multiple columns", func(t *testing.T) {
+		rows := readAll(t, "SELECT id, title FROM table1 ORDER BY title DESC, id")
+		require.Len(t, rows, rowCount)
+
+		for i, row := range rows {
+			require.Equal(t, fmt.Sprintf("title%d", 9-i/10), row.Values[titleSel].Value())
+			require.Equal(t, int64(9-i/10+(i%10)*10), row.Values[idSel].Value())
+		}
+	})
+
+	t.Run("rows from a subquery should be sorted", func(t *testing.T) {
+		rows := readAll(t, "SELECT id, age FROM (SELECT id, age FROM table1 WHERE age > 0 AND age < 10) AS t ORDER BY age DESC")
+		require.Len(t, rows, 9)
+
+		for i, row := range rows {
+			require.Equal(t, int64(9-i), row.Values[EncodeSelector("", "db1", "t", "age")].Value())
+		}
+	})
+
+	t.Run("joint rows should be sorted by a column of the joint table", func(t *testing.T) {
+		rows := readAll(t, "SELECT table1.id, t2.label FROM table1 INNER JOIN table2 AS t2 ON table1.id = t2.id ORDER BY t2.label LIMIT 3")
+		require.Len(t, rows, 3)
+
+		require.Equal(t, "label1", rows[0].Values[EncodeSelector("", "db1", "t2", "label")].Value())
+		require.Equal(t, "label10", rows[1].Values[EncodeSelector("", "db1", "t2", "label")].Value())
+		require.Equal(t, "label100", rows[2].Values[EncodeSelector("", "db1", "t2", "label")].Value())
+	})
+
+	t.Run("sorted rows should be grouped", func(t *testing.T) {
+		rows := readAll(t, "SELECT title, COUNT(*) AS c FROM table1 GROUP BY title ORDER BY title")
+		require.Len(t, rows, 10)
+
+		for i, row := range rows {
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[titleSel].Value())
+			require.Equal(t, int64(10), row.Values[EncodeSelector("", "db1", "table1", "c")].Value())
+		}
+	})
+
+	t.Run("limit and offset should be applied to sorted rows", func(t *testing.T) {
+		rows := readAll(t, "SELECT id, age FROM table1 WHERE age >= 0 ORDER BY age DESC LIMIT 2 OFFSET 1")
+		require.Len(t, rows, 2)
+
+		require.Equal(t, int64(98), rows[0].Values[ageSel].Value())
+		require.Equal(t, int64(97), rows[1].Values[ageSel].Value())
+	})
+
+	t.Run("ordering by an unknown column should fail", func(t *testing.T) {
+		_, err := engine.Query(context.Background(), "SELECT id FROM table1 ORDER BY id, amount", nil, nil)
+		require.ErrorIs(t, err, ErrColumnDoesNotExist)
+	})
+}
+
+func TestQueryWithRowFiltering(t *testing.T) {
+	st, err := store.Open("sqldata_where", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_where")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf(`
+			UPSERT INTO table1 (id, title, active, payload) VALUES (%d, 'title%d', %v, x'%s')
+		`, i, i, i%2 == 0, encPayload), nil, nil)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE false", nil, nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE false OR true", nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount; i++ {
+		_, err := r.Read()
+		require.NoError(t, err)
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE 1 < 2", nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount; i++ {
+		_, err := r.Read()
+		require.NoError(t, err)
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE 1 >= 2", nil, nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE 1 = true", nil, nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNotComparableValues, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE NOT table1.active", nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount/2; i++ {
+		_, err := r.Read()
+		require.NoError(t, err)
+	}
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE table1.id > 4", nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount/2; i++ {
+		_, err := r.Read()
+		require.NoError(t, err)
+	}
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), fmt.Sprintf("UPSERT INTO table1 (id, title) VALUES (%d, 'title%d')", rowCount, rowCount), nil, nil)
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title FROM table1 WHERE active = null AND payload = null", nil, nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title FROM table1 WHERE active = null AND payload = null AND active = payload", nil, nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNotComparableValues, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestQueryWithInClause(t *testing.T) {
+	st, err := store.Open("sqldata_where_in", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_where_in")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR[50], active BOOLEAN, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(title)", nil, nil)
+	require.NoError(t, err)
+
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf(`
+			INSERT INTO table1 (id, title, active) VALUES (%d, 'title%d', %v)
+		`, i, i, i%2 == 0), nil, nil)
+		require.NoError(t, err)
+	}
+
+	inListExp := &InListExp{}
+	require.False(t, inListExp.isConstant())
+
+	t.Run("infer parameters without parameters should return an empty list", func(t *testing.T) {
+		params, err := engine.InferParameters(context.Background(), "SELECT id, title, active FROM table1 WHERE title IN ('title0', 'title1')", nil)
+		require.NoError(t, err)
+		require.Empty(t, params)
+	})
+
+	t.Run("infer inference with wrong types should return an error", func(t *testing.T) {
+		_, err := engine.InferParameters(context.Background(), "SELECT id, title, active FROM table1 WHERE 100 + title IN ('title0', 'title1')", nil)
+		require.ErrorIs(t, err, ErrInvalidTypes)
+	})
+
+	t.Run("infer inference with valid types should succeed", func(t *testing.T) {
+		params, err := engine.InferParameters(context.Background(), "SELECT id, title, active FROM table1 WHERE active AND title IN ('title0', 'title1')", nil)
+		require.NoError(t, err)
+		require.Empty(t, params)
+	})
+
+	t.Run("infer parameters should return matching type", func(t *testing.T) {
+		params, err := engine.InferParameters(context.Background(), "SELECT id, title, active FROM table1 WHERE title IN (@param0, @param1)", nil)
+		require.NoError(t, err)
+		require.Len(t, params, 2)
+		require.Equal(t, VarcharType, params["param0"])
+		require.Equal(t, VarcharType, params["param1"])
+	})
+
+	t.Run("infer parameters with type conflicts should return an error", func(t *testing.T) {
+		_, err := engine.InferParameters(context.Background(), "SELECT id, title, active FROM table1 WHERE active = @param1 and title IN (@param0, @param1)", nil)
+		require.ErrorIs(t, err, ErrInferredMultipleTypes)
+	})
+
+	t.Run("infer parameters with unexistent column should return an error", func(t *testing.T) {
+		_, err := engine.InferParameters(context.Background(), "SELECT id, title, active FROM table1 WHERE invalidColumn IN ('title1', 'title2')", nil)
+		require.ErrorIs(t, err, ErrColumnDoesNotExist)
+	})
+
+	t.Run("in clause with invalid column should return an error", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE invalidColumn IN (1, 2)", nil, nil)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrColumnDoesNotExist)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("in clause with invalid type should return an error", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE title IN (1, 2)", nil, nil)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNotComparableValues)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("in clause should succeed reading two rows", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE title IN ('title0', 'title1')", nil, nil)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.NotNil(t, row)
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		}
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("in clause with invalid values should return an error", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE title IN ('title0', true + 'title1')", nil, nil)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrInvalidValue)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("in clause should succeed reading rows NOT included in 'IN' clause", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id, title, active FROM table1 WHERE title NOT IN ('title1', 'title0')", nil, nil)
+		require.NoError(t, err)
+
+		for i := 2; i < rowCount; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.NotNil(t, row)
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		}
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("in clause over the primary key should bound the scanned range", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id FROM table1 WHERE id IN (@id0, 3, 5) AND NOT active", map[string]interface{}{"id0": 7}, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		idRange := r.ScanSpecs().rangesByColID[1]
+		require.NotNil(t, idRange)
+		require.Equal(t, &Number{val: 3}, idRange.lRange.val)
+		require.Equal(t, &Number{val: 7}, idRange.hRange.val)
+
+		for _, id := range []int64{3, 5, 7} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, id, row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		}
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
+
+	t.Run("in clause should compare values which can not be hashed one by one", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id FROM table1 WHERE id IN (id - 1, 4 + 0, 6)", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		for _, id := range []int64{4, 6} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, id, row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		}
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
+
+	t.Run("in clause should succeed reading using 'IN' clause in join condition", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1 as t1 INNER JOIN table1 as t2 ON t1.title IN (t2.title) ORDER BY title", nil, nil)
+		require.NoError(t, err)
+
+		for i := 0; i < rowCount; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.NotNil(t, row)
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "t1", "title")].Value())
+		}
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+}
+
+func TestUnorderedQueries(t *testing.T) {
+	st, err := store.Open("sqldata_unordered_queries", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_unordered_queries")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE accounts (id INTEGER, owner VARCHAR[50], PRIMARY KEY id);
+		CREATE TABLE movements (account INTEGER, seq INTEGER, amount INTEGER, PRIMARY KEY (account, seq));
+		CREATE INDEX ON movements(amount);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	// rows are inserted in an order unrelated to their primary keys
+	for _, id := range []int{3, 1, 4, 2} {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO accounts (id, owner) VALUES (@id, @owner)",
+			map[string]interface{}{"id": id, "owner": fmt.Sprintf("owner%d", id)}, nil)
+		require.NoError(t, err)
+	}
+
+	for _, seq := range []int{2, 0, 1} {
+		for _, account := range []int{2, 1, 3} {
+			_, _, err = engine.Exec(context.Background(), "INSERT INTO movements (account, seq, amount) VALUES (@account, @seq, 10)",
+				map[string]interface{}{"account": account, "seq": seq}, nil)
+			require.NoError(t, err)
+		}
+	}
+
+	readAll := func(t *testing.T, query string, cols ...string) ([][]int64, []ColDescriptor) {
+		r, err := engine.Query(context.Background(), query, nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var rows [][]int64
+
+		for {
+			row, err := r.Read()
+			if errors.Is(err, ErrNoMoreRows) {
+				break
+			}
+			require.NoError(t, err)
+
+			vals := make([]int64, len(cols))
+			for i, col := range cols {
+				vals[i] = row.Values[col].Value().(int64)
+			}
+
+			rows = append(rows, vals)
+		}
+
+		return rows, r.OrderBy()
+	}
+
+	orderedBy := func(orderBy []ColDescriptor) []string {
+		cols := make([]string, len(orderBy))
+		for i, col := range orderBy {
+			cols[i] = col.Table + "." + col.Column
+		}
+		return cols
+	}
+
+	t.Run("rows of a table should be read in primary key order", func(t *testing.T) {
+		rows, orderBy := readAll(t, "SELECT id FROM accounts", "(db1.accounts.id)")
+		require.Equal(t, [][]int64{{1}, {2}, {3}, {4}}, rows)
+		require.Equal(t, []string{"accounts.id"}, orderedBy(orderBy))
+
+		rows, orderBy = readAll(t, "SELECT account, seq FROM movements WHERE account > 1",
+			"(db1.movements.account)", "(db1.movements.seq)")
+		require.Equal(t, [][]int64{{2, 0}, {2, 1}, {2, 2}, {3, 0}, {3, 1}, {3, 2}}, rows)
+		require.Equal(t, []string{"movements.account", "movements.seq"}, orderedBy(orderBy))
+	})
+
+	t.Run("rows with equal indexed values should be read in primary key order", func(t *testing.T) {
+		rows, orderBy := readAll(t, "SELECT account, seq FROM movements USE INDEX ON (amount) WHERE account < 3",
+			"(db1.movements.account)", "(db1.movements.seq)")
+		require.Equal(t, [][]int64{{1, 0}, {1, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}}, rows)
+		require.Equal(t, []string{"movements.amount", "movements.account", "movements.seq"}, orderedBy(orderBy))
+	})
+
+	t.Run("sorted rows with equal values should be kept in primary key order", func(t *testing.T) {
+		rows, orderBy := readAll(t, "SELECT account, seq FROM movements WHERE seq < 2 ORDER BY seq DESC",
+			"(db1.movements.account)", "(db1.movements.seq)")
+		require.Equal(t, [][]int64{{1, 1}, {2, 1}, {3, 1}, {1, 0}, {2, 0}, {3, 0}}, rows)
+		require.Equal(t, []string{"movements.seq", "movements.account"}, orderedBy(orderBy))
+	})
+
+	t.Run("joint rows should be read in the order of each table", func(t *testing.T) {
+		rows, orderBy := readAll(t, `
+			SELECT a.id, m.seq
+			FROM accounts AS a
+			INNER JOIN movements AS m ON m.account = a.id
+			WHERE m.seq > 0`,
+			"(db1.a.id)", "(db1.m.seq)")
+		require.Equal(t, [][]int64{{1, 1}, {1, 2}, {2, 1}, {2, 2}, {3, 1}, {3, 2}}, rows)
+		require.Equal(t, []string{"a.id", "m.account", "m.seq"}, orderedBy(orderBy))
+	})
+}
+
+func TestQueryWithBetween(t *testing.T) {
+	st, err := store.Open("sqldata_where_between", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_where_between")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER, title VARCHAR[50], amount INTEGER, PRIMARY KEY id);
+		CREATE INDEX ON table1(amount);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, amount) VALUES (@id, @title, @amount)",
+			map[string]interface{}{"id": i, "title": fmt.Sprintf("title%d", i), "amount": 100 - i*10}, nil)
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		query       string
+		params      map[string]interface{}
+		expectedIDs []int64
+		rangedColID uint32
+	}{
+		{
+			query:       "SELECT id FROM table1 WHERE id BETWEEN 3 AND 5",
+			expectedIDs: []int64{3, 4, 5},
+			rangedColID: 1,
+		},
+		{
+			query:       "SELECT id FROM table1 WHERE id BETWEEN @lower AND @upper AND title <> 'title4'",
+			params:      map[string]interface{}{"lower": 3, "upper": 5},
+			expectedIDs: []int64{3, 5},
+			rangedColID: 1,
+		},
+		{
+			query:       "SELECT id FROM table1 WHERE amount BETWEEN 40 AND 60 ORDER BY amount",
+			expectedIDs: []int64{6, 5, 4},
+			rangedColID: 3,
+		},
+		{
+			query:       "SELECT id FROM table1 WHERE title BETWEEN 'title7' AND 'title9'",
+			expectedIDs: []int64{7, 8, 9},
+		},
+		{
+			query:       "SELECT id FROM table1 WHERE id NOT BETWEEN 1 AND 8",
+			expectedIDs: []int64{0, 9},
+		},
+		{
+			query:       "SELECT id FROM table1 WHERE id BETWEEN 5 AND 3",
+			expectedIDs: nil,
+			rangedColID: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			r, err := engine.Query(context.Background(), tc.query, tc.params, nil)
+			require.NoError(t, err)
+			defer r.Close()
+
+			if tc.rangedColID > 0 {
+				require.Contains(t, r.ScanSpecs().rangesByColID, tc.rangedColID)
+			}
+
+			for _, id := range tc.expectedIDs {
+				row, err := r.Read()
+				require.NoError(t, err)
+				require.Equal(t, id, row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+			}
+
+			_, err = r.Read()
+			require.ErrorIs(t, err, ErrNoMoreRows)
+		})
+	}
+
+	t.Run("bounds should be inferred with the type of the value", func(t *testing.T) {
+		params, err := engine.InferParameters(context.Background(), "SELECT id FROM table1 WHERE title BETWEEN @lower AND @upper", nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]SQLValueType{"lower": VarcharType, "upper": VarcharType}, params)
+	})
+
+	t.Run("bounds of another type should fail", func(t *testing.T) {
+		_, err := engine.InferParameters(context.Background(), "SELECT id FROM table1 WHERE id BETWEEN 'a' AND 'b'", nil)
+		require.ErrorIs(t, err, ErrInvalidValue)
+	})
+}
+
+func TestQueryWithLike(t *testing.T) {
+	st, err := store.Open("sqldata_where_like", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_where_like")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, title VARCHAR[50], PRIMARY KEY id);
+		CREATE INDEX ON table1(title);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	for _, title := range []string{"apple", "apricot", "banana", "100%", "1000", "a_b", "axb"} {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (title) VALUES (@title)", map[string]interface{}{"title": title}, nil)
+		require.NoError(t, err)
+	}
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (title) VALUES (NULL)", nil, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		query          string
+		params         map[string]interface{}
+		expectedTitles []string
+		prefixRange    bool
+	}{
+		{
+			query:          "SELECT title FROM table1 WHERE title LIKE 'ap%' ORDER BY title",
+			expectedTitles: []string{"apple", "apricot"},
+			prefixRange:    true,
+		},
+		{
+			query:          "SELECT title FROM table1 WHERE title LIKE @pattern ORDER BY title",
+			params:         map[string]interface{}{"pattern": "ap_le"},
+			expectedTitles: []string{"apple"},
+			prefixRange:    true,
+		},
+		{
+			query:          "SELECT title FROM table1 WHERE title LIKE '%an%'",
+			expectedTitles: []string{"banana"},
+		},
+		{
+			query:          "SELECT title FROM table1 WHERE title LIKE '100\\%' ORDER BY title",
+			expectedTitles: []string{"100%"},
+			prefixRange:    true,
+		},
+		{
+			query:          "SELECT title FROM table1 WHERE title LIKE 'a\\_b'",
+			expectedTitles: []string{"a_b"},
+			prefixRange:    true,
+		},
+		{
+			query:          "SELECT title FROM table1 WHERE title NOT LIKE 'a%' ORDER BY title",
+			expectedTitles: []string{"100%", "1000", "banana"},
+		},
+		{
+			query:          "SELECT title FROM table1 WHERE title ~ '^a.*t$'",
+			expectedTitles: []string{"apricot"},
+		},
+		{
+			query:          "SELECT title FROM table1 WHERE title !~ '[a-z]' ORDER BY title",
+			expectedTitles: []string{"100%", "1000"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			r, err := engine.Query(context.Background(), tc.query, tc.params, nil)
+			require.NoError(t, err)
+			defer r.Close()
+
+			_, ranged := r.ScanSpecs().rangesByColID[2]
+			require.Equal(t, tc.prefixRange, ranged)
+
+			for _, title := range tc.expectedTitles {
+				row, err := r.Read()
+				require.NoError(t, err)
+				require.Equal(t, title, row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+			}
+
+			_, err = r.Read()
+			require.ErrorIs(t, err, ErrNoMoreRows)
+		})
+	}
+
+	t.Run("prefix scan should be bounded by the pattern prefix", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT title FROM table1 WHERE title LIKE 'ap%' ORDER BY title", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		titleRange := r.ScanSpecs().rangesByColID[2]
+		require.Equal(t, &Varchar{val: "ap"}, titleRange.lRange.val)
+		require.True(t, titleRange.lRange.inclusive)
+		require.Equal(t, &Varchar{val: "aq"}, titleRange.hRange.val)
+		require.False(t, titleRange.hRange.inclusive)
+	})
+
+	t.Run("invalid regular expressions should fail", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT title FROM table1 WHERE title ~ '('", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		_, err = r.Read()
+		require.Error(t, err)
+	})
+}
+
+func TestQueryWithCaseWhen(t *testing.T) {
+	st, err := store.Open("sqldata_case_when", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_case_when")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER, amount INTEGER, active BOOLEAN, PRIMARY KEY id);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, amount, active) VALUES (@id, @amount, @active)",
+			map[string]interface{}{"id": i, "amount": i * 10, "active": i%2 == 0}, nil)
+		require.NoError(t, err)
+	}
+
+	t.Run("conditional projection", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), `
+			SELECT id, CASE WHEN amount >= 70 THEN 'high' WHEN amount >= 30 THEN 'medium' ELSE 'low' END AS level
+			FROM table1`, nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 2)
+		require.Equal(t, "level", cols[1].Column)
+		require.Equal(t, VarcharType, cols[1].Type)
+
+		for i := 0; i < 10; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+
+			expected := "low"
+			if i >= 7 {
+				expected = "high"
+			} else if i >= 3 {
+				expected = "medium"
+			}
+
+			require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+			require.Equal(t, expected, row.Values[EncodeSelector("", "db1", "table1", "level")].Value())
+		}
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
+
+	t.Run("unaliased projection without else should be null when no condition holds", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT CASE WHEN active THEN @bonus + amount END FROM table1 WHERE id < 2",
+			map[string]interface{}{"bonus": 5}, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(5), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.True(t, row.Values[EncodeSelector("", "db1", "table1", "col0")].IsNull())
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
+
+	t.Run("conditional predicate", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), `
+			SELECT id FROM table1
+			WHERE CASE WHEN active THEN amount ELSE amount * 2 END > @threshold`,
+			map[string]interface{}{"threshold": 100}, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		for _, id := range []int64{7, 9} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, id, row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		}
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
+
+	t.Run("conditional projection of aggregations", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT COUNT(*), CASE WHEN COUNT(*) > 5 THEN 'many' ELSE 'few' END AS volume FROM table1", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(10), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+		require.Equal(t, "many", row.Values[EncodeSelector("", "db1", "table1", "volume")].Value())
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
+
+	t.Run("parameters should be inferred from the other results", func(t *testing.T) {
+		params, err := engine.InferParameters(context.Background(), "SELECT CASE WHEN active THEN @label ELSE 'inactive' END FROM table1", nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]SQLValueType{"label": VarcharType}, params)
+	})
+
+	t.Run("results of different types should fail", func(t *testing.T) {
+		_, err := engine.InferParameters(context.Background(), "SELECT CASE WHEN active THEN amount ELSE 'none' END FROM table1", nil)
+		require.ErrorIs(t, err, ErrInvalidTypes)
+	})
+
+	t.Run("non-boolean conditions should fail", func(t *testing.T) {
+		_, err := engine.InferParameters(context.Background(), "SELECT id FROM table1 WHERE CASE WHEN amount THEN true END", nil)
+		require.ErrorIs(t, err, ErrInvalidTypes)
+	})
+}
+
+func TestQueryWithStringFunctions(t *testing.T) {
+	st, err := store.Open("sqldata_string_fns", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_string_fns")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER, name VARCHAR, PRIMARY KEY id);
+		INSERT INTO table1 (id, name) VALUES (1, '  Alice '), (2, 'bob'), (3, 'Ñandú'), (4, NULL);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	t.Run("functions should be evaluated in the selected values", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), `
+			SELECT id, UPPER(TRIM(name)) AS upper_name, lower(name), LENGTH(name) AS len,
+				SUBSTRING(TRIM(name), 2, 3) AS sub, SUBSTRING(name, 3), CONCAT(TRIM(name), '-', @suffix) AS tagged
+			FROM table1`, map[string]interface{}{"suffix": "x"}, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 7)
+		require.Equal(t, "upper_name", cols[1].Column)
+		require.Equal(t, "col2", cols[2].Column)
+		require.Equal(t, IntegerType, cols[3].Type)
+		require.Equal(t, VarcharType, cols[6].Type)
+
+		expected := []struct {
+			upper  interface{}
+			lower  interface{}
+			length interface{}
+			sub    interface{}
+			from3  interface{}
+			tagged interface{}
+		}{
+			{"ALICE", "  alice ", int64(8), "lic", "Alice ", "Alice-x"},
+			{"BOB", "bob", int64(3), "ob", "b", "bob-x"},
+			{"ÑANDÚ", "ñandú", int64(5), "and", "ndú", "Ñandú-x"},
+			{nil, nil, nil, nil, nil, nil},
+		}
+
+		for _, e := range expected {
+			row, err := r.Read()
+			require.NoError(t, err)
+
+			require.Equal(t, e.upper, row.Values[EncodeSelector("", "db1", "table1", "upper_name")].Value())
+			require.Equal(t, e.lower, row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+			require.Equal(t, e.length, row.Values[EncodeSelector("", "db1", "table1", "len")].Value())
+			require.Equal(t, e.sub, row.Values[EncodeSelector("", "db1", "table1", "sub")].Value())
+			require.Equal(t, e.from3, row.Values[EncodeSelector("", "db1", "table1", "col5")].Value())
+			require.Equal(t, e.tagged, row.Values[EncodeSelector("", "db1", "table1", "tagged")].Value())
+		}
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
+
+	t.Run("functions should be evaluated in conditions", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id FROM table1 WHERE LOWER(TRIM(name)) = @name OR LENGTH(name) = 5", map[string]interface{}{"name": "alice"}, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		for _, id := range []int64{1, 3} {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, id, row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		}
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
+
+	t.Run("parameters should be typed by the functions", func(t *testing.T) {
+		params, err := engine.InferParameters(context.Background(), "SELECT SUBSTRING(name, @pos, @n) FROM table1 WHERE UPPER(@name) = name", nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]SQLValueType{"pos": IntegerType, "n": IntegerType, "name": VarcharType}, params)
+	})
+
+	t.Run("invalid calls should fail", func(t *testing.T) {
+		for _, q := range []string{
+			"SELECT UPPER(id) FROM table1",
+			"SELECT SUBSTRING(name) FROM table1",
+			"SELECT id FROM table1 WHERE UNKNOWN(name) = 'x'",
+		} {
+			_, err := engine.InferParameters(context.Background(), q, nil)
+			require.Error(t, err, q)
+		}
+
+		r, err := engine.Query(context.Background(), "SELECT SUBSTRING(name, 1, -1) FROM table1", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrIllegalArguments)
+	})
+}
+
+func TestTimestampArithmetic(t *testing.T) {
+	st, err := store.Open("sqldata_ts_arith", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_ts_arith")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE logs (id INTEGER AUTO_INCREMENT, ts TIMESTAMP, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	for _, age := range []time.Duration{72 * time.Hour, 36 * time.Hour, 12 * time.Hour, time.Hour} {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO logs (ts) VALUES (@ts)", map[string]interface{}{"ts": now.Add(-age)}, nil)
+		require.NoError(t, err)
+	}
+
+	count := func(where string) int64 {
+		r, err := engine.Query(context.Background(), "SELECT COUNT(*) AS c FROM logs WHERE "+where, nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		row, err := r.Read()
+		require.NoError(t, err)
+
+		return row.Values[EncodeSelector("", "db1", "logs", "c")].Value().(int64)
+	}
+
+	t.Run("intervals should be subtracted from and added to timestamps", func(t *testing.T) {
+		require.Equal(t, int64(2), count("ts > NOW() - INTERVAL '1 day'"))
+		require.Equal(t, int64(1), count("ts > NOW() - INTERVAL '2 hours 30 minutes'"))
+		require.Equal(t, int64(3), count("INTERVAL '2 days' + ts > NOW()"))
+		require.Equal(t, int64(4), count("ts > NOW() - INTERVAL '1 week'"))
+		require.Equal(t, int64(0), count("ts > NOW() + INTERVAL '-1 month'  AND ts > NOW()"))
+	})
+
+	t.Run("invalid operations should be rejected", func(t *testing.T) {
+		for _, where := range []string{
+			"ts > INTERVAL '1 day' - NOW()",
+			"ts > NOW() * INTERVAL '1 day'",
+			"id > NOW() - INTERVAL '1 day'",
+		} {
+			_, err := engine.InferParameters(context.Background(), "SELECT id FROM logs WHERE "+where, nil)
+			require.ErrorIs(t, err, ErrInvalidTypes, where)
+		}
+
+		// constant expressions are already reduced
+		_, err := engine.InferParameters(context.Background(), "SELECT id FROM logs WHERE ts > 1 + INTERVAL '1 day'", nil)
+		require.ErrorIs(t, err, ErrInvalidValue)
+
+		_, err = engine.InferParameters(context.Background(), "SELECT id FROM logs WHERE ts > NOW() - INTERVAL '1 fortnight'", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid interval unit")
+	})
+
+	t.Run("NOW() should be evaluated to the same timestamp along the transaction", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), `
+			BEGIN TRANSACTION;
+				INSERT INTO logs (ts) VALUES (NOW());
+				INSERT INTO logs (ts) VALUES (NOW() + INTERVAL '1 day');
+			COMMIT;
+		`, nil, nil)
+		require.NoError(t, err)
+
+		r, err := engine.Query(context.Background(), "SELECT ts FROM logs ORDER BY id DESC LIMIT 2", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		row1, err := r.Read()
+		require.NoError(t, err)
+
+		row2, err := r.Read()
+		require.NoError(t, err)
+
+		ts1 := row1.Values[EncodeSelector("", "db1", "logs", "ts")].Value().(time.Time)
+		ts2 := row2.Values[EncodeSelector("", "db1", "logs", "ts")].Value().(time.Time)
+		require.Equal(t, ts2.AddDate(0, 0, 1), ts1)
+	})
+
+	t.Run("interval arithmetic should be projected", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT ts, ts + INTERVAL '1 day' AS next_day, NOW() - INTERVAL '1 day' FROM logs LIMIT 1", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 3)
+		require.Equal(t, TimestampType, cols[1].Type)
+		require.Equal(t, TimestampType, cols[2].Type)
+
+		row, err := r.Read()
+		require.NoError(t, err)
+
+		ts := row.Values[EncodeSelector("", "db1", "logs", "ts")].Value().(time.Time)
+		require.Equal(t, ts.AddDate(0, 0, 1), row.Values[EncodeSelector("", "db1", "logs", "next_day")].Value())
+
+		yesterday := row.Values[EncodeSelector("", "db1", "logs", "col2")].Value().(time.Time)
+		require.WithinDuration(t, time.Now().AddDate(0, 0, -1), yesterday, time.Minute)
+	})
+
+	t.Run("retention should be enforced by deleting old rows", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "DELETE FROM logs WHERE ts < NOW() - INTERVAL '2 days'", nil, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, int64(5), count("id > 0"))
+	})
+}
+
+func TestProjectedArithmeticWithNullValues(t *testing.T) {
+	st, err := store.Open("sqldata_arith_null", store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
+	defer os.RemoveAll("sqldata_arith_null")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE items (id INTEGER AUTO_INCREMENT, amount INTEGER, price FLOAT, ts TIMESTAMP, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	ts := time.Date(2022, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO items (amount, price, ts) VALUES (10, 1.5, @ts), (NULL, NULL, NULL)", map[string]interface{}{"ts": ts}, nil)
+	require.NoError(t, err)
+
+	t.Run("arithmetic over NULL values should be projected as NULL", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT amount + 1 AS next, price * 2 AS double, ts + INTERVAL '1 day' AS next_day FROM items ORDER BY id", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		nextSel := EncodeSelector("", "db1", "items", "next")
+		doubleSel := EncodeSelector("", "db1", "items", "double")
+		nextDaySel := EncodeSelector("", "db1", "items", "next_day")
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(11), row.Values[nextSel].Value())
+		require.Equal(t, 3.0, row.Values[doubleSel].Value())
+		require.Equal(t, ts.AddDate(0, 0, 1), row.Values[nextDaySel].Value())
+
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.True(t, row.Values[nextSel].IsNull())
+		require.Equal(t, IntegerType, row.Values[nextSel].Type())
+		require.True(t, row.Values[doubleSel].IsNull())
+		require.Equal(t, FloatType, row.Values[doubleSel].Type())
+		require.True(t, row.Values[nextDaySel].IsNull())
+		require.Equal(t, TimestampType, row.Values[nextDaySel].Type())
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+	})
+
+	t.Run("arithmetic over NULL values should not be compared", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id FROM items WHERE amount + 1 < 20 ORDER BY id", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "items", "id")].Value())
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrInvalidValue)
+	})
+}
+
+func TestAggregations(t *testing.T) {
+	st, err := store.Open("sqldata_agg", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_agg")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(age)", nil, nil)
+	require.NoError(t, err)
+
+	rowCount := 10
+	base := 30
+
+	for i := 1; i <= rowCount; i++ {
+		params := make(map[string]interface{}, 3)
+		params["id"] = i
+		params["title"] = fmt.Sprintf("title%d", i)
+		params["age"] = base + i
+
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, age) VALUES (@id, @title, @age)", params, nil)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.Query(context.Background(), "SELECT COUNT(*) FROM table1 WHERE id < i", nil, nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.ErrorIs(t, err, ErrColumnDoesNotExist)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id FROM table1 WHERE false", nil, nil)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+	require.Nil(t, row)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), `
+		SELECT COUNT(*), SUM(age), MIN(title), MAX(age), AVG(age), MIN(active), MAX(active), MIN(payload)
+		FROM table1 WHERE false`, nil, nil)
+	require.NoError(t, err)
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+	require.Equal(t, int64(0), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
+	require.Equal(t, "", row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+	require.Equal(t, int64(0), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+	require.Equal(t, int64(0), row.Values[EncodeSelector("", "db1", "table1", "col4")].Value())
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT COUNT(*) AS c, SUM(age), MIN(age), MAX(age), AVG(age) FROM table1 AS t1", nil, nil)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 5)
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.NotNil(t, row)
+	require.Len(t, row.Values, 5)
+
+	require.Equal(t, int64(rowCount), row.Values[EncodeSelector("", "db1", "t1", "c")].Value())
+
+	require.Equal(t, int64((1+2*base+rowCount)*rowCount/2), row.Values[EncodeSelector("", "db1", "t1", "col1")].Value())
+
+	require.Equal(t, int64(1+base), row.Values[EncodeSelector("", "db1", "t1", "col2")].Value())
+
+	require.Equal(t, int64(base+rowCount), row.Values[EncodeSelector("", "db1", "t1", "col3")].Value())
+
+	require.Equal(t, int64(base+rowCount/2), row.Values[EncodeSelector("", "db1", "t1", "col4")].Value())
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestAggregationsWithNullValues(t *testing.T) {
+	st, err := store.Open("sqldata_agg_null", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_agg_null")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, amount INTEGER, title VARCHAR, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (amount, title) VALUES (NULL, NULL), (10, NULL), (NULL, 'title1'), (20, NULL)", nil, nil)
+	require.NoError(t, err)
+
+	r, err := engine.Query(context.Background(), "SELECT COUNT(*), SUM(amount), MIN(amount), MAX(amount), AVG(amount), MIN(title) FROM table1", nil, nil)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, int64(4), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+	require.Equal(t, int64(30), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
+	require.Equal(t, int64(10), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+	require.Equal(t, int64(20), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+	require.Equal(t, int64(15), row.Values[EncodeSelector("", "db1", "table1", "col4")].Value())
+	require.Equal(t, "title1", row.Values[EncodeSelector("", "db1", "table1", "col5")].Value())
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT MIN(amount), MAX(amount) FROM table1 WHERE title = 'title1'", nil, nil)
+	require.NoError(t, err)
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.True(t, row.Values[EncodeSelector("", "db1", "table1", "col0")].IsNull())
+	require.True(t, row.Values[EncodeSelector("", "db1", "table1", "col1")].IsNull())
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	t.Run("sum should fail on overflow", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (amount) VALUES (@amount)", map[string]interface{}{"amount": math.MaxInt64}, nil)
+		require.NoError(t, err)
+
+		r, err = engine.Query(context.Background(), "SELECT SUM(amount) FROM table1", nil, nil)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNumericOverflow)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("average should be calculated without overflow", func(t *testing.T) {
+		r, err = engine.Query(context.Background(), "SELECT AVG(amount) FROM table1 WHERE amount > 10", nil, nil)
+		require.NoError(t, err)
+
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(math.MaxInt64/2+10), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+}
+
+func TestAggregationsOfFloatsAndDecimals(t *testing.T) {
+	st, err := store.Open("sqldata_agg_float_decimal", store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
+	defer os.RemoveAll("sqldata_agg_float_decimal")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE readings (id INTEGER AUTO_INCREMENT, temp FLOAT, amount DECIMAL, note VARCHAR, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO readings (temp, amount, note) VALUES (1.5, '0.1', 'first'), (2.25, '0.2', NULL), (NULL, NULL, NULL), (-0.75, '0.4', NULL)", nil, nil)
+	require.NoError(t, err)
+
+	r, err := engine.Query(context.Background(), "SELECT SUM(temp), AVG(temp), SUM(amount), AVG(amount) FROM readings", nil, nil)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Equal(t, FloatType, cols[0].Type)
+	require.Equal(t, FloatType, cols[1].Type)
+	require.Equal(t, DecimalType, cols[2].Type)
+	require.Equal(t, DecimalType, cols[3].Type)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, 3.0, row.Values[EncodeSelector("", "db1", "readings", "col0")].Value())
+	require.Equal(t, 1.0, row.Values[EncodeSelector("", "db1", "readings", "col1")].Value())
+	require.Equal(t, DecimalType, row.Values[EncodeSelector("", "db1", "readings", "col2")].Type())
+	require.Equal(t, "0.7", FormatDecimal(row.Values[EncodeSelector("", "db1", "readings", "col2")].Value().(*big.Rat)))
+	require.Equal(t, "0.233333333333333333", FormatDecimal(row.Values[EncodeSelector("", "db1", "readings", "col3")].Value().(*big.Rat)))
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	t.Run("aggregations of no rows should be zeros of the type of the column", func(t *testing.T) {
+		r, err = engine.Query(context.Background(), "SELECT SUM(temp), AVG(amount) FROM readings WHERE id > 10", nil, nil)
+		require.NoError(t, err)
+
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, 0.0, row.Values[EncodeSelector("", "db1", "readings", "col0")].Value())
+		require.Equal(t, "0", FormatDecimal(row.Values[EncodeSelector("", "db1", "readings", "col1")].Value().(*big.Rat)))
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("sum of decimals should fail on overflow", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO readings (amount) VALUES (9223372036854775807), (9223372036854775807)", nil, nil)
+		require.NoError(t, err)
+
+		r, err = engine.Query(context.Background(), "SELECT SUM(amount) FROM readings", nil, nil)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNumericOverflow)
+
+		err = r.Close()
+		require.NoError(t, err)
+
+		r, err = engine.Query(context.Background(), "SELECT AVG(amount) FROM readings WHERE amount > CAST('0.3' AS DECIMAL)", nil, nil)
+		require.NoError(t, err)
+
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, "6148914691236517204.8", FormatDecimal(row.Values[EncodeSelector("", "db1", "readings", "col0")].Value().(*big.Rat)))
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("only numeric values should be summed", func(t *testing.T) {
+		r, err = engine.Query(context.Background(), "SELECT SUM(note) FROM readings", nil, nil)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNotComparableValues)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+}
+
+func TestCount(t *testing.T) {
+	st, err := store.Open("sqldata_agg", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_agg")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE t1(id INTEGER AUTO_INCREMENT, val1 INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON t1(val1)", nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 3; j++ {
+			_, _, err = engine.Exec(context.Background(), "INSERT INTO t1(val1) VALUES($1)", map[string]interface{}{"param1": j}, nil)
+			require.NoError(t, err)
+		}
+	}
+
+	r, err := engine.Query(context.Background(), "SELECT COUNT(*) as c FROM t1", nil, nil)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.EqualValues(t, uint64(30), row.Values["(db1.t1.c)"].Value())
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	_, err = engine.Query(context.Background(), "SELECT COUNT(*) as c FROM t1 GROUP BY val1", nil, nil)
+	require.ErrorIs(t, err, ErrLimitedGroupBy)
+
+	r, err = engine.Query(context.Background(), "SELECT COUNT(*) as c FROM t1 GROUP BY val1 ORDER BY val1", nil, nil)
+	require.NoError(t, err)
+
+	for j := 0; j < 3; j++ {
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.EqualValues(t, uint64(10), row.Values["(db1.t1.c)"].Value())
+	}
+
+	_, err = r.Read()
+	require.ErrorIs(t, err, ErrNoMoreRows)
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestLimitAndOffset(t *testing.T) {
+	st, err := store.Open("sqldata_limit", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_limit")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE t1(id INTEGER AUTO_INCREMENT, val1 INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO t1(val1) VALUES($1)", map[string]interface{}{"param1": i % 3}, nil)
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		query          string
+		col            string
+		expectedValues []int64
+		scanLimit      int
+	}{
+		{query: "SELECT id FROM t1 LIMIT 3", col: "id", expectedValues: []int64{1, 2, 3}, scanLimit: 3},
+		{query: "SELECT id FROM t1 LIMIT 3 OFFSET 2", col: "id", expectedValues: []int64{3, 4, 5}, scanLimit: 5},
+		{query: "SELECT id FROM t1 LIMIT 3 OFFSET 8", col: "id", expectedValues: []int64{9, 10}, scanLimit: 11},
+		{query: "SELECT id FROM t1 OFFSET 7", col: "id", expectedValues: []int64{8, 9, 10}},
+		{query: "SELECT id FROM t1 LIMIT 3 OFFSET 10", col: "id", scanLimit: 13},
+		{query: "SELECT id FROM t1 ORDER BY id DESC LIMIT 2 OFFSET 1", col: "id", expectedValues: []int64{9, 8}, scanLimit: 3},
+		{query: "SELECT id FROM t1 WHERE val1 = 0 LIMIT 2 OFFSET 1", col: "id", expectedValues: []int64{4, 7}},
+		{query: "SELECT DISTINCT val1 FROM t1 LIMIT 2 OFFSET 1", col: "val1", expectedValues: []int64{1, 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			r, err := engine.Query(context.Background(), tc.query, nil, nil)
+			require.NoError(t, err)
+			defer r.Close()
+
+			require.Equal(t, tc.scanLimit, r.ScanSpecs().limit)
+
+			for _, val := range tc.expectedValues {
+				row, err := r.Read()
+				require.NoError(t, err)
+				require.Equal(t, val, row.Values[EncodeSelector("", "db1", "t1", tc.col)].Value())
+			}
+
+			_, err = r.Read()
+			require.ErrorIs(t, err, ErrNoMoreRows)
+		})
+	}
+
+	r, err := engine.Query(context.Background(), "SELECT COUNT(*) as c FROM t1 LIMIT 1 OFFSET 0", nil, nil)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.EqualValues(t, uint64(10), row.Values["(db1.t1.c)"].Value())
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestMultiColumnOrderBy(t *testing.T) {
+	st, err := store.Open("sqldata_multi_col_orderby", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_multi_col_orderby")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE t1(id INTEGER AUTO_INCREMENT, val1 INTEGER, val2 INTEGER, PRIMARY KEY id);
+		CREATE INDEX ON t1(val1, val2);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO t1(val1, val2) VALUES (0, 1), (1, 0), (0, 0), (1, 1), (0, 1), (1, 0)", nil, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		query          string
+		expectedValues []int64
+		sortRequired   bool
+	}{
+		{query: "SELECT id FROM t1 ORDER BY val1, val2", expectedValues: []int64{3, 1, 5, 2, 6, 4}},
+		{query: "SELECT id FROM t1 ORDER BY t1.val1 DESC, t1.val2 DESC", expectedValues: []int64{4, 6, 2, 5, 1, 3}},
+		{query: "SELECT id FROM t1 WHERE val1 = 1 ORDER BY val2", expectedValues: []int64{2, 6, 4}},
+		{query: "SELECT id FROM t1 WHERE val1 = 1 ORDER BY val1, val2", expectedValues: []int64{2, 6, 4}},
+		{query: "SELECT id FROM t1 ORDER BY val1, val2 DESC", expectedValues: []int64{1, 5, 3, 4, 2, 6}, sortRequired: true},
+		{query: "SELECT id FROM t1 ORDER BY val2, val1", expectedValues: []int64{3, 2, 6, 1, 5, 4}, sortRequired: true},
+		{query: "SELECT id FROM t1 ORDER BY val1, val2, id DESC", expectedValues: []int64{3, 5, 1, 6, 2, 4}, sortRequired: true},
+		{query: "SELECT id FROM t1 ORDER BY val1, id", expectedValues: []int64{1, 3, 5, 2, 4, 6}, sortRequired: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			r, err := engine.Query(context.Background(), tc.query, nil, nil)
+			require.NoError(t, err)
+			defer r.Close()
+
+			require.Equal(t, tc.sortRequired, r.ScanSpecs().sortRequired)
+
+			if !tc.sortRequired {
+				require.Len(t, r.ScanSpecs().index.cols, 2)
+			}
+
+			for _, val := range tc.expectedValues {
+				row, err := r.Read()
+				require.NoError(t, err)
+				require.Equal(t, val, row.Values[EncodeSelector("", "db1", "t1", "id")].Value())
+			}
+
+			_, err = r.Read()
+			require.ErrorIs(t, err, ErrNoMoreRows)
+		})
+	}
+
+	_, err = engine.Query(context.Background(), "SELECT id FROM t1 ORDER BY val1, val3", nil, nil)
+	require.ErrorIs(t, err, ErrColumnDoesNotExist)
+}
+
+func TestGroupByHaving(t *testing.T) {
+	st, err := store.Open("sqldata_having", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_having")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, active BOOLEAN, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(active)", nil, nil)
+	require.NoError(t, err)
+
+	rowCount := 10
+	base := 40
+
+	for i := 0; i < rowCount; i++ {
+		params := make(map[string]interface{}, 4)
+		params["id"] = i
+		params["title"] = fmt.Sprintf("title%d", i)
+		params["age"] = base + i
+		params["active"] = i%2 == 0
+
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, age, active) VALUES (@id, @title, @age, @active)", params, nil)
+		require.NoError(t, err)
+	}
+
+	_, err = engine.Query(context.Background(), "SELECT active, COUNT(*), SUM(age1) FROM table1 WHERE active != null HAVING AVG(age) >= MIN(age)", nil, nil)
+	require.Equal(t, ErrHavingClauseRequiresGroupClause, err)
+
+	r, err := engine.Query(context.Background(), `
+		SELECT active, COUNT(*), SUM(age1)
+		FROM table1
+		WHERE active != null
+		GROUP BY active
+		HAVING AVG(age) >= MIN(age)
+		ORDER BY active`, nil, nil)
+	require.NoError(t, err)
+
+	r.SetParameters(nil)
+
+	_, err = r.Read()
+	require.Equal(t, ErrColumnDoesNotExist, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), `
+		SELECT active, COUNT(*), SUM(age1)
+		FROM table1
+		WHERE AVG(age) >= MIN(age)
+		GROUP BY active
+		ORDER BY active`, nil, nil)
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT active, COUNT(id) FROM table1 GROUP BY active ORDER BY active", nil, nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrLimitedCount, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), `
+		SELECT active, COUNT(*)
+		FROM table1
+		GROUP BY active
+		HAVING AVG(age) >= MIN(age1)
+		ORDER BY active`, nil, nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.ErrorIs(t, err, ErrColumnDoesNotExist)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), `
+		SELECT active, COUNT(*) as c, MIN(age), MAX(age), AVG(age), SUM(age)
+		FROM table1
+		GROUP BY active
+		HAVING COUNT(*) <= SUM(age)   AND
+				MIN(age) <= MAX(age) AND
+				AVG(age) <= MAX(age) AND
+				MAX(age) < SUM(age)  AND
+				AVG(age) >= MIN(age) AND
+				SUM(age) > 0
+		ORDER BY active DESC`, nil, nil)
+
+	require.NoError(t, err)
+
+	_, err = r.Columns()
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 6)
+
+		require.Equal(t, i == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
+
+		require.Equal(t, int64(rowCount/2), row.Values[EncodeSelector("", "db1", "table1", "c")].Value())
+
+		if i%2 == 0 {
+			require.Equal(t, int64(base), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+			require.Equal(t, int64(base+rowCount-2), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+		} else {
+			require.Equal(t, int64(base+1), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+			require.Equal(t, int64(base+rowCount-1), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+		}
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestJoins(t *testing.T) {
+	st, err := store.Open("sqldata_innerjoin", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_innerjoin")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, fkid1 INTEGER, fkid2 INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table2 (id INTEGER, amount INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table3 (id INTEGER, age INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf(`
+			UPSERT INTO table1 (id, title, fkid1, fkid2) VALUES (%d, 'title%d', %d, %d)`, i, i, rowCount-1-i, i), nil, nil)
+		require.NoError(t, err)
+
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf("UPSERT INTO table2 (id, amount) VALUES (%d, %d)", rowCount-1-i, i*i), nil, nil)
+		require.NoError(t, err)
+
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf("UPSERT INTO table3 (id, age) VALUES (%d, %d)", i, 30+i), nil, nil)
+		require.NoError(t, err)
+	}
+
+	t.Run("should not find any matching row", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), `
+		SELECT table1.title, table2.amount, table3.age
+		FROM (SELECT * FROM table2 WHERE amount = 1)
+		INNER JOIN table1 ON table2.id = table1.fkid1 AND (table2.amount > 0 OR table2.amount > 0+1)
+		INNER JOIN table3 ON table1.fkid2 = table3.id AND table3.age < 30`, nil, nil)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should find one matching row", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), `
+		SELECT t1.title, t2.amount, t3.age
+		FROM (SELECT id, amount FROM table2 WHERE amount = 1) AS t2
+		INNER JOIN table1 AS t1 ON t2.id = t1.fkid1 AND t2.amount > 0
+		INNER JOIN table3 AS t3 ON t1.fkid2 = t3.id AND t3.age > 30`, nil, nil)
+		require.NoError(t, err)
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Len(t, row.Values, 3)
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should resolve every inserted row", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), `
+			SELECT id, title, table2.amount, table3.age
+			FROM table1 INNER JOIN table2 ON table1.fkid1 = table2.id
+			INNER JOIN table3 ON table1.fkid2 = table3.id
+			WHERE table1.id >= 0 AND table3.age >= 30
+			ORDER BY id DESC`, nil, nil)
+		require.NoError(t, err)
+
+		r.SetParameters(nil)
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 4)
+
+		for i := 0; i < rowCount; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.NotNil(t, row)
+			require.Len(t, row.Values, 4)
+
+			require.Equal(t, int64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+			require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+			require.Equal(t, int64((rowCount-1-i)*(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table2", "amount")].Value())
+			require.Equal(t, int64(30+(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table3", "age")].Value())
+		}
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("should return error when joining nonexistent table", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), `
+		SELECT title
+		FROM table1
+		INNER JOIN table22 ON table1.id = table22.fkid1`, nil, nil)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.Equal(t, ErrTableDoesNotExist, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+}
+
+func TestJoinsWithNullIndexes(t *testing.T) {
+	st, err := store.Open("sqldata_join_nullable_index", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_join_nullable_index")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER, fkid2 INTEGER, PRIMARY KEY id);
+		CREATE TABLE table2 (id INTEGER, id2 INTEGER, val INTEGER, PRIMARY KEY id);
+		CREATE INDEX ON table2(id2);
+
+		INSERT INTO table2(id, id2, val) VALUES (1, 1, 100), (2, null, 200);
+		INSERT INTO table1(id, fkid2) VALUES (10, 1), (20, null);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	r, err := engine.Query(context.Background(), `
+			SELECT table2.val
+			FROM table1 INNER JOIN table2 ON table1.fkid2 = table2.id2
+			ORDER BY table1.id`, nil, nil)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 1)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.NotNil(t, row)
+	require.Len(t, row.Values, 1)
+	require.EqualValues(t, 100, row.Values[EncodeSelector("", "db1", "table2", "val")].Value())
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.NotNil(t, row)
+	require.Len(t, row.Values, 1)
+	require.EqualValues(t, 200, row.Values[EncodeSelector("", "db1", "table2", "val")].Value())
+
+	_, err = r.Read()
+	require.ErrorIs(t, err, ErrNoMoreRows)
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestJoinsWithJointTable(t *testing.T) {
+	st, err := store.Open("sqldata_innerjoin_joint", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_innerjoin_joint")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, name VARCHAR, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table2 (id INTEGER AUTO_INCREMENT, amount INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table12 (id INTEGER AUTO_INCREMENT, fkid1 INTEGER, fkid2 INTEGER, active BOOLEAN, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (name) VALUES ('name1'), ('name2'), ('name3')", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table2 (amount) VALUES (10), (20), (30)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table12 (fkid1, fkid2, active) VALUES (1,1,false),(1,2,true),(1,3,true)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table12 (fkid1, fkid2, active) VALUES (2,1,false),(2,2,false),(2,3,true)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table12 (fkid1, fkid2, active) VALUES (3,1,false),(3,2,false),(3,3,false)", nil, nil)
+	require.NoError(t, err)
+
+	r, err := engine.Query(context.Background(), `
+		SELECT q.name, t2.amount, t12.active
+		FROM (SELECT * FROM table1 where name = 'name1') q
+		INNER JOIN table12 t12 on t12.fkid1 = q.id
+		INNER JOIN table2 t2  on t12.fkid2 = t2.id
+		WHERE t12.active = true`, nil, nil)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 3)
+
+	for i := 0; i < 2; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 3)
+
+		require.Equal(t, "name1", row.Values[EncodeSelector("", "db1", "q", "name")].Value())
+		require.Equal(t, int64(20+i*10), row.Values[EncodeSelector("", "db1", "t2", "amount")].Value())
+		require.Equal(t, true, row.Values[EncodeSelector("", "db1", "t12", "active")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestNestedJoins(t *testing.T) {
+	st, err := store.Open("sqldata_nestedjoins", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_nestedjoins")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, fkid1 INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table2 (id INTEGER, amount INTEGER, fkid1 INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table3 (id INTEGER, age INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf("UPSERT INTO table1 (id, title, fkid1) VALUES (%d, 'title%d', %d)", i, i, rowCount-1-i), nil, nil)
+		require.NoError(t, err)
+
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf("UPSERT INTO table2 (id, amount, fkid1) VALUES (%d, %d, %d)", rowCount-1-i, i*i, i), nil, nil)
+		require.NoError(t, err)
+
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf("UPSERT INTO table3 (id, age) VALUES (%d, %d)", i, 30+i), nil, nil)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.Query(context.Background(), `
+		SELECT id, title, t2.amount AS total_amount, t3.age
+		FROM table1 t1
+		INNER JOIN table2 t2 ON (fkid1 = t2.id AND title != NULL)
+		INNER JOIN table3 t3 ON t2.fkid1 = t3.id
+		ORDER BY id DESC`, nil, nil)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 4)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 4)
+
+		require.Equal(t, int64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "t1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "t1", "title")].Value())
+		require.Equal(t, int64((rowCount-1-i)*(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "t2", "total_amount")].Value())
+		require.Equal(t, int64(30+(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "t3", "age")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestReOpening(t *testing.T) {
+	st, err := store.Open("sqldata_reopening", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_reopening")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, name VARCHAR[30], PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(name)", nil, nil)
+	require.NoError(t, err)
+
+	engine, err = NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, name VARCHAR[30], PRIMARY KEY id)", nil, nil)
+	require.ErrorIs(t, err, ErrTableAlreadyExists)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE INDEX ON table1(name)", nil, nil)
+	require.ErrorIs(t, err, ErrIndexAlreadyExists)
+}
+
+func TestSubQuery(t *testing.T) {
+	st, err := store.Open("sqldata_subq", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_subq")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
+		_, _, err = engine.Exec(context.Background(), fmt.Sprintf(`
+			UPSERT INTO table1 (id, title, active, payload) VALUES (%d, 'title%d', %v, x'%s')
+		`, i, i, i%2 == 0, encPayload), nil, nil)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.Query(context.Background(), `
+		SELECT id, title t
+		FROM (SELECT id, title, active FROM table1) t2
+		WHERE active AND t2.id >= 0`, nil, nil)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 2)
+
+	for i := 0; i < rowCount; i += 2 {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 2)
+
+		require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "t2", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "t2", "t")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "UPSERT INTO table1 (id, title) VALUES (0, 'title0')", nil, nil)
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM (SELECT id, title, active FROM table1) WHERE active", nil, nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.Query(context.Background(), "SELECT id, title, active FROM (SELECT id, title, active FROM table1) WHERE title", nil, nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrInvalidCondition, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestJoinsWithSubquery(t *testing.T) {
+	st, err := store.Open("sqldata_subq", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_subq")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix).WithAutocommit(true))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS customers (
+			id            INTEGER,
+			customer_name VARCHAR[60],
+			email         VARCHAR[150],
+			address       VARCHAR,
+			city          VARCHAR,
+			ip            VARCHAR[40],
+			country       VARCHAR[15],
+			age           INTEGER,
+			active        BOOLEAN,
+			PRIMARY KEY (id)
+		);
+
+		CREATE TABLE customer_review(
+			customerid INTEGER,
+			productid  INTEGER,
+			review     VARCHAR,
+			PRIMARY KEY (customerid, productid)
+		);
+
+		INSERT INTO customers (
+			id, customer_name, email, address,
+			city, ip, country, age, active
+		)
+		VALUES (
+			1,
+			'Isidro Behnen',
+			'ibehnen0@mail.ru',
+			'ibehnen0@chronoengine.com',
+			'Arvika',
+			'127.0.0.15',
+			'SE',
+			24,
+			true
+		);
+
+		INSERT INTO customer_review (customerid, productid, review)
+		VALUES(1, 1, 'Nice Juice!');
+	`, nil, nil)
+	require.NoError(t, err)
+
+	r, err := engine.Query(context.Background(), `
+		SELECT * FROM (
+			SELECT id, customer_name, age
+			FROM customers
+			AS c
+		)
+		INNER JOIN (
+			SELECT MAX(customerid) as customerid, COUNT(*) as review_count
+			FROM customer_review
+			AS r
+		) ON r.customerid = c.id
+		WHERE c.age < 30
+		`,
+		nil, nil)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+
+	require.Len(t, row.Values, 5)
+	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "c", "id")].Value())
+	require.Equal(t, "Isidro Behnen", row.Values[EncodeSelector("", "db1", "c", "customer_name")].Value())
+	require.Equal(t, int64(24), row.Values[EncodeSelector("", "db1", "c", "age")].Value())
+	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "r", "customerid")].Value())
+	require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "r", "review_count")].Value())
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestDerivedTables(t *testing.T) {
+	st, err := store.Open("sqldata_derived_tables", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_derived_tables")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE customers(id INTEGER AUTO_INCREMENT, name VARCHAR, PRIMARY KEY id);
+		CREATE TABLE orders(id INTEGER AUTO_INCREMENT, customer INTEGER, amount INTEGER, PRIMARY KEY id);
+		CREATE INDEX ON orders(customer);
+
+		INSERT INTO customers(name) VALUES ('name1'), ('name2'), ('name3');
+		INSERT INTO orders(customer, amount) VALUES (1, 10), (2, 20), (1, 30), (2, 40), (1, 50);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		query          string
+		selectors      []string
+		expectedValues [][]interface{}
+	}{
+		{
+			query:          "SELECT o.total FROM (SELECT id, amount AS total FROM orders) AS o WHERE o.total > 20",
+			selectors:      []string{"o.total"},
+			expectedValues: [][]interface{}{{int64(30)}, {int64(40)}, {int64(50)}},
+		},
+		{
+			query:          "SELECT total FROM (SELECT amount AS total FROM orders WHERE customer = 2) AS o ORDER BY total DESC",
+			selectors:      []string{"o.total"},
+			expectedValues: [][]interface{}{{int64(40)}, {int64(20)}},
+		},
+		{
+			query:          "SELECT o.customer, COUNT(*) AS c FROM (SELECT customer FROM orders ORDER BY customer) AS o GROUP BY o.customer",
+			selectors:      []string{"o.customer", "o.c"},
+			expectedValues: [][]interface{}{{int64(1), int64(3)}, {int64(2), int64(2)}},
+		},
+		{
+			query:          "SELECT o.k, COUNT(*) AS c FROM (SELECT id AS k FROM orders WHERE amount < 30) AS o GROUP BY o.k",
+			selectors:      []string{"o.k", "o.c"},
+			expectedValues: [][]interface{}{{int64(1), int64(1)}, {int64(2), int64(1)}},
+		},
+		{
+			query: `
+				SELECT c.name, o.total
+				FROM customers AS c
+				INNER JOIN (SELECT customer, SUM(amount) AS total FROM orders GROUP BY customer ORDER BY customer) AS o
+				ON o.customer = c.id`,
+			selectors:      []string{"c.name", "o.total"},
+			expectedValues: [][]interface{}{{"name1", int64(90)}, {"name2", int64(60)}},
+		},
+		{
+			query: `
+				SELECT c.n, o.amount
+				FROM (SELECT id, name AS n FROM customers) AS c
+				INNER JOIN (SELECT customer, amount FROM orders) AS o ON o.customer = c.id
+				WHERE o.amount >= 40`,
+			selectors:      []string{"c.n", "o.amount"},
+			expectedValues: [][]interface{}{{"name1", int64(50)}, {"name2", int64(40)}},
+		},
+		{
+			query:          "SELECT y.name FROM (SELECT * FROM (SELECT id, name FROM customers WHERE id > 1) AS x) AS y",
+			selectors:      []string{"y.name"},
+			expectedValues: [][]interface{}{{"name2"}, {"name3"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			r, err := engine.Query(context.Background(), tc.query, nil, nil)
+			require.NoError(t, err)
+			defer r.Close()
+
+			cols, err := r.Columns()
+			require.NoError(t, err)
+			require.Len(t, cols, len(tc.selectors))
+
+			for i, sel := range tc.selectors {
+				require.Equal(t, EncodeSelector("", "db1", strings.Split(sel, ".")[0], strings.Split(sel, ".")[1]), cols[i].Selector())
+			}
+
+			for _, vals := range tc.expectedValues {
+				row, err := r.Read()
+				require.NoError(t, err)
+
+				for i, val := range vals {
+					require.Equal(t, val, row.Values[cols[i].Selector()].Value())
+				}
+			}
+
+			_, err = r.Read()
+			require.ErrorIs(t, err, ErrNoMoreRows)
+		})
+	}
+
+	t.Run("grouping by a column the derived table is not ordered by should fail", func(t *testing.T) {
+		_, err = engine.Query(context.Background(), "SELECT o.amount, COUNT(*) AS c FROM (SELECT amount FROM orders) AS o GROUP BY o.amount", nil, nil)
+		require.ErrorIs(t, err, ErrLimitedGroupBy)
+	})
+
+	t.Run("columns not projected by the derived table should not be resolved", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT o.amount FROM (SELECT id FROM orders) AS o", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrColumnDoesNotExist)
+	})
+
+	t.Run("parameters should be inferred across derived tables", func(t *testing.T) {
+		params, err := engine.InferParameters(context.Background(), `
+			SELECT c.name
+			FROM customers AS c
+			INNER JOIN (SELECT customer, amount FROM orders WHERE amount > @amount) AS o ON o.customer = c.id
+			WHERE c.name = @name`, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]SQLValueType{"amount": IntegerType, "name": VarcharType}, params)
+	})
+}
+
+func TestExistsSubquery(t *testing.T) {
+	st, err := store.Open("sqldata_exists", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_exists")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE customers(id INTEGER AUTO_INCREMENT, name VARCHAR, PRIMARY KEY id);
+		CREATE TABLE orders(id INTEGER AUTO_INCREMENT, customer INTEGER, amount INTEGER, PRIMARY KEY id);
+		CREATE INDEX ON orders(customer);
+
+		INSERT INTO customers(name) VALUES ('name1'), ('name2'), ('name3');
+		INSERT INTO orders(customer, amount) VALUES (1, 10), (2, 20), (1, 30);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		query       string
+		params      map[string]interface{}
+		expectedIDs []int64
+	}{
+		{
+			query:       "SELECT id FROM customers WHERE EXISTS (SELECT id FROM orders WHERE orders.customer = customers.id)",
+			expectedIDs: []int64{1, 2},
+		},
+		{
+			query:       "SELECT id FROM customers WHERE NOT EXISTS (SELECT id FROM orders WHERE customer = customers.id)",
+			expectedIDs: []int64{3},
+		},
+		{
+			query:       "SELECT id FROM customers WHERE EXISTS (SELECT id FROM orders WHERE customer = customers.id AND amount > @amount)",
+			params:      map[string]interface{}{"amount": 15},
+			expectedIDs: []int64{1, 2},
+		},
+		{
+			query:       "SELECT id FROM customers WHERE id > 1 AND EXISTS (SELECT id FROM orders WHERE customer = customers.id)",
+			expectedIDs: []int64{2},
+		},
+		{
+			query:       "SELECT id FROM customers WHERE EXISTS (SELECT id FROM orders WHERE amount > 100)",
+			expectedIDs: nil,
+		},
+		{
+			query:       "SELECT id FROM customers WHERE EXISTS (SELECT id FROM customers WHERE id = 3)",
+			expectedIDs: []int64{1, 2, 3},
+		},
+		{
+			query: `
+				SELECT c.id FROM customers AS c
+				WHERE EXISTS (
+					SELECT id FROM orders AS o
+					WHERE o.customer = c.id AND EXISTS (SELECT id FROM orders WHERE customer = o.customer AND amount > o.amount)
+				)`,
+			expectedIDs: []int64{1},
+		},
+		{
+			query: `
+				SELECT c.id FROM customers AS c
+				INNER JOIN orders AS o ON o.customer = c.id AND NOT EXISTS (SELECT id FROM orders WHERE customer = c.id AND amount > o.amount)`,
+			expectedIDs: []int64{1, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			r, err := engine.Query(context.Background(), tc.query, tc.params, nil)
+			require.NoError(t, err)
+			defer r.Close()
+
+			cols, err := r.Columns()
+			require.NoError(t, err)
+			require.Len(t, cols, 1)
+
+			for _, id := range tc.expectedIDs {
+				row, err := r.Read()
+				require.NoError(t, err)
+				require.Equal(t, id, row.Values[cols[0].Selector()].Value())
+			}
+
+			_, err = r.Read()
+			require.ErrorIs(t, err, ErrNoMoreRows)
+		})
+	}
+
+	t.Run("parameters outside the subquery should be inferred", func(t *testing.T) {
+		params, err := engine.InferParameters(context.Background(), `
+			SELECT id FROM customers WHERE name = @name AND EXISTS (SELECT id FROM orders WHERE customer = customers.id)`, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]SQLValueType{"name": VarcharType}, params)
+	})
+
+	t.Run("missing parameters within the subquery should fail when reading rows", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id FROM customers WHERE EXISTS (SELECT id FROM orders WHERE amount > @amount)", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrMissingParameter)
+	})
+}
+
+func TestInSubquery(t *testing.T) {
+	st, err := store.Open("sqldata_in_subquery", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_in_subquery")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE customers(id INTEGER AUTO_INCREMENT, name VARCHAR, PRIMARY KEY id);
+		CREATE TABLE orders(id INTEGER AUTO_INCREMENT, customer INTEGER, amount INTEGER, PRIMARY KEY id);
+
+		INSERT INTO customers(name) VALUES ('name1'), ('name2'), ('name3'), ('name4');
+		INSERT INTO orders(customer, amount) VALUES (1, 10), (2, 20), (1, 30), (4, 40), (NULL, 50);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		query       string
+		params      map[string]interface{}
+		expectedIDs []int64
+	}{
+		{
+			query:       "SELECT id FROM customers WHERE id IN (SELECT customer FROM orders)",
+			expectedIDs: []int64{1, 2, 4},
+		},
+		{
+			query:       "SELECT id FROM customers WHERE id NOT IN (SELECT customer FROM orders)",
+			expectedIDs: []int64{3},
+		},
+		{
+			query:       "SELECT id FROM customers WHERE id IN (SELECT customer FROM orders WHERE amount > @amount)",
+			params:      map[string]interface{}{"amount": 15},
+			expectedIDs: []int64{1, 2, 4},
+		},
+		{
+			query:       "SELECT id FROM customers WHERE name <> 'name4' AND id IN (SELECT customer FROM orders WHERE amount >= 20)",
+			expectedIDs: []int64{1, 2},
+		},
+		{
+			query:       "SELECT id FROM customers WHERE id IN (SELECT customer FROM orders WHERE amount > 100)",
+			expectedIDs: nil,
+		},
+		{
+			query:       "SELECT id FROM customers WHERE 30 IN (SELECT amount FROM orders WHERE customer = customers.id)",
+			expectedIDs: []int64{1},
+		},
+		{
+			query:       "SELECT id FROM customers WHERE id IN (SELECT customer FROM orders WHERE amount > 20 AND customer IN (SELECT id FROM customers WHERE name <> 'name1'))",
+			expectedIDs: []int64{4},
+		},
+		{
+			query: `
+				SELECT c.id FROM customers AS c
+				INNER JOIN orders AS o ON o.customer = c.id AND o.amount IN (SELECT MAX(amount) FROM orders WHERE customer = c.id)`,
+			expectedIDs: []int64{1, 2, 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			r, err := engine.Query(context.Background(), tc.query, tc.params, nil)
+			require.NoError(t, err)
+			defer r.Close()
+
+			cols, err := r.Columns()
+			require.NoError(t, err)
+			require.Len(t, cols, 1)
+
+			for _, id := range tc.expectedIDs {
+				row, err := r.Read()
+				require.NoError(t, err)
+				require.Equal(t, id, row.Values[cols[0].Selector()].Value())
+			}
+
+			_, err = r.Read()
+			require.ErrorIs(t, err, ErrNoMoreRows)
+		})
+	}
+
+	t.Run("subqueries returning several columns should fail", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id FROM customers WHERE id IN (SELECT id, customer FROM orders)", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrInvalidNumberOfValues)
+	})
+
+	t.Run("subqueries returning values of another type should fail", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT id FROM customers WHERE name IN (SELECT customer FROM orders)", nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		_, err = r.Read()
+		require.ErrorIs(t, err, ErrNotComparableValues)
+	})
+
+	t.Run("parameters outside the subquery should be inferred", func(t *testing.T) {
+		params, err := engine.InferParameters(context.Background(), "SELECT id FROM customers WHERE @id IN (SELECT customer FROM orders)", nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]SQLValueType{"id": AnyType}, params)
+	})
+}
+
+func TestInferParameters(t *testing.T) {
+	st, err := store.Open("catalog_infer_params", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_infer_params")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	stmt := "CREATE DATABASE db1"
+
+	params, err := engine.InferParameters(context.Background(), stmt, nil)
+	require.NoError(t, err)
+	require.Empty(t, params)
+
+	params, err = engine.InferParametersPreparedStmts(context.Background(), []SQLStmt{&CreateDatabaseStmt{}}, nil)
+	require.NoError(t, err)
+	require.Empty(t, params)
+
+	params, err = engine.InferParameters(context.Background(), stmt, nil)
+	require.NoError(t, err)
+	require.Empty(t, params)
+
+	params, err = engine.InferParametersPreparedStmts(context.Background(), []SQLStmt{&CreateDatabaseStmt{}}, nil)
+	require.NoError(t, err)
+	require.Empty(t, params)
+
+	_, _, err = engine.Exec(context.Background(), stmt, nil, nil)
+	require.NoError(t, err)
+
+	_, err = engine.InferParameters(context.Background(), "INSERT INTO mytable(id, title) VALUES (@id, @title);", nil)
+	require.ErrorIs(t, err, ErrNoDatabaseSelected)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, err = engine.InferParameters(context.Background(), "invalid sql stmt", nil)
+	require.EqualError(t, err, "syntax error: unexpected IDENTIFIER at position 7")
+
+	_, err = engine.InferParametersPreparedStmts(context.Background(), nil, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	params, err = engine.InferParameters(context.Background(), stmt, nil)
+	require.NoError(t, err)
+	require.Len(t, params, 0)
+
+	params, err = engine.InferParameters(context.Background(), "USE DATABASE db1", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 0)
+
+	params, err = engine.InferParameters(context.Background(), "USE SNAPSHOT BEFORE TX 10", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 0)
+
+	stmt = "CREATE TABLE mytable(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)"
+
+	params, err = engine.InferParameters(context.Background(), stmt, nil)
+	require.NoError(t, err)
+	require.Len(t, params, 0)
+
+	pstmt, err := Parse(strings.NewReader(stmt))
+	require.NoError(t, err)
+	require.Len(t, pstmt, 1)
+
+	_, err = engine.InferParametersPreparedStmts(context.Background(), pstmt, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), stmt, nil, nil)
+	require.NoError(t, err)
+
+	params, err = engine.InferParameters(context.Background(), "ALTER TABLE mytableSE ADD COLUMN note VARCHAR", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 0)
+
+	stmt = "CREATE INDEX ON mytable(active)"
+
+	params, err = engine.InferParameters(context.Background(), stmt, nil)
+	require.NoError(t, err)
+	require.Len(t, params, 0)
+
+	_, _, err = engine.Exec(context.Background(), stmt, nil, nil)
+	require.NoError(t, err)
+
+	params, err = engine.InferParameters(context.Background(), "BEGIN TRANSACTION; INSERT INTO mytable(id, title) VALUES (@id, @title); COMMIT;", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 2)
+	require.Equal(t, IntegerType, params["id"])
+	require.Equal(t, VarcharType, params["title"])
+
+	params, err = engine.InferParameters(context.Background(), "INSERT INTO mytable(id, title) VALUES (1, 'title1')", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 0)
+
+	params, err = engine.InferParameters(context.Background(), "INSERT INTO mytable(id, title) VALUES (1, 'title1'), (@id2, @title2)", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 2)
+	require.Equal(t, IntegerType, params["id2"])
+	require.Equal(t, VarcharType, params["title2"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE (id - 1) > (@id + (@id+1))", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 1)
+	require.Equal(t, IntegerType, params["id"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable t1 INNER JOIN mytable t2 ON t1.id = t2.id WHERE id > @id", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 1)
+	require.Equal(t, IntegerType, params["id"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE id > @id AND (NOT @active OR active)", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 2)
+	require.Equal(t, IntegerType, params["id"])
+	require.Equal(t, BooleanType, params["active"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE id > ? AND (NOT ? OR active)", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 2)
+	require.Equal(t, IntegerType, params["param1"])
+	require.Equal(t, BooleanType, params["param2"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE id > $2 AND (NOT $1 OR active)", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 2)
+	require.Equal(t, BooleanType, params["param1"])
+	require.Equal(t, IntegerType, params["param2"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT COUNT(*) FROM mytable GROUP BY active HAVING @param1 = COUNT(*) ORDER BY active", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 1)
+	require.Equal(t, IntegerType, params["param1"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT COUNT(*), MIN(id) FROM mytable GROUP BY active HAVING @param1 < MIN(id) ORDER BY active", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 1)
+	require.Equal(t, IntegerType, params["param1"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE @active AND title LIKE 't+'", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 1)
+	require.Equal(t, BooleanType, params["active"])
+}
+
+func TestInferParametersPrepared(t *testing.T) {
+	st, err := store.Open("catalog_infer_params_prepared", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_infer_params_prepared")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	stmts, err := Parse(strings.NewReader("CREATE TABLE mytable(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)"))
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+
+	params, err := engine.InferParametersPreparedStmts(context.Background(), stmts, nil)
+	require.NoError(t, err)
+	require.Len(t, params, 0)
+
+	_, _, err = engine.ExecPreparedStmts(context.Background(), stmts, nil, nil)
+	require.NoError(t, err)
+}
+
+func TestInferParametersUnbounded(t *testing.T) {
+	st, err := store.Open("catalog_infer_params_unbounded", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_infer_params_unbounded")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE mytable(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	params, err := engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE @param1 = @param2", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 2)
+	require.Equal(t, AnyType, params["param1"])
+	require.Equal(t, AnyType, params["param2"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE @param1 AND @param2", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 2)
+	require.Equal(t, BooleanType, params["param1"])
+	require.Equal(t, BooleanType, params["param2"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE @param1 != NULL", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 1)
+	require.Equal(t, AnyType, params["param1"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE @param1 != NOT NULL", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 1)
+	require.Equal(t, BooleanType, params["param1"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE @param1 != NULL AND (@param1 AND active)", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 1)
+	require.Equal(t, BooleanType, params["param1"])
+
+	params, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE @param1 != NULL AND (@param1 <= mytable.id)", nil)
+	require.NoError(t, err)
+	require.Len(t, params, 1)
+	require.Equal(t, IntegerType, params["param1"])
+}
+
+func TestInferParametersInvalidCases(t *testing.T) {
+	st, err := store.Open("catalog_infer_params_invalid", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_infer_params_invalid")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE mytable(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, err = engine.InferParameters(context.Background(), "INSERT INTO mytable(id, title) VALUES (@param1, @param1)", nil)
+	require.Equal(t, ErrInferredMultipleTypes, err)
+
+	_, err = engine.InferParameters(context.Background(), "INSERT INTO mytable(id, title) VALUES (@param1)", nil)
+	require.Equal(t, ErrInvalidNumberOfValues, err)
+
+	_, err = engine.InferParameters(context.Background(), "INSERT INTO mytable1(id, title) VALUES (@param1, @param2)", nil)
+	require.Equal(t, ErrTableDoesNotExist, err)
+
+	_, err = engine.InferParameters(context.Background(), "INSERT INTO mytable(id, note) VALUES (@param1, @param2)", nil)
 	require.Equal(t, ErrColumnDoesNotExist, err)
 
-	_, err = engine.InferParameters("SELECT * FROM mytable WHERE id > @param1 AND (@param1 OR active)", nil)
-	require.Equal(t, ErrInferredMultipleTypes, err)
+	_, err = engine.InferParameters(context.Background(), "SELECT * FROM mytable WHERE id > @param1 AND (@param1 OR active)", nil)
+	require.Equal(t, ErrInferredMultipleTypes, err)
+
+	_, err = engine.InferParameters(context.Background(), "BEGIN TRANSACTION; INSERT INTO mytable(id, title) VALUES (@param1, @param1); COMMIT;", nil)
+	require.Equal(t, ErrInferredMultipleTypes, err)
+}
+
+func TestExecAndQueryStmtWithParameters(t *testing.T) {
+	st, err := store.Open("catalog_exec_stmt_params", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_exec_stmt_params")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(context.Background(), nil, nil, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = engine.QueryStmt(context.Background(), nil, nil, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	stmts, err := Parse(strings.NewReader("CREATE TABLE mytable(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)"))
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(context.Background(), stmts[0], nil, nil)
+	require.NoError(t, err)
+
+	stmts, err = Parse(strings.NewReader("INSERT INTO mytable(id, title, active) VALUES (@id, @title, @active)"))
+	require.NoError(t, err)
+
+	insertStmt := stmts[0]
+
+	_, _, err = engine.ExecStmt(context.Background(), insertStmt, map[string]interface{}{"id": 1, "title": "title1"}, nil)
+	require.ErrorIs(t, err, ErrMissingParameter)
+
+	_, _, err = engine.ExecStmt(context.Background(), insertStmt, map[string]interface{}{"id": 1, "title": 10, "active": true}, nil)
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	_, _, err = engine.ExecStmt(context.Background(), insertStmt, map[string]interface{}{"id": 1, "title": "title1", "active": struct{}{}}, nil)
+	require.ErrorIs(t, err, ErrUnsupportedParameter)
+
+	_, _, err = engine.ExecStmt(context.Background(), insertStmt, map[string]interface{}{"id": 1, "title": "title1", "ID": 2, "active": true}, nil)
+	require.ErrorIs(t, err, ErrDuplicatedParameters)
+
+	_, _, err = engine.ExecStmt(context.Background(), insertStmt, map[string]interface{}{"ID": 1, "title": nil, "active": true}, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(context.Background(), insertStmt, map[string]interface{}{"id": 2, "title": "title2", "active": false}, nil)
+	require.NoError(t, err)
+
+	stmts, err = Parse(strings.NewReader("SELECT id, title FROM mytable WHERE active = @active AND id >= @minID"))
+	require.NoError(t, err)
+
+	queryStmt := stmts[0].(*SelectStmt)
+
+	_, err = engine.QueryStmt(context.Background(), queryStmt, map[string]interface{}{"active": "true", "minID": 1}, nil)
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	_, err = engine.QueryStmt(context.Background(), queryStmt, map[string]interface{}{"active": true}, nil)
+	require.ErrorIs(t, err, ErrMissingParameter)
+
+	r, err := engine.QueryStmt(context.Background(), queryStmt, map[string]interface{}{"active": false, "minID": 1}, nil)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), row.Values[EncodeSelector("", "db1", "mytable", "id")].Value())
+
+	_, err = r.Read()
+	require.ErrorIs(t, err, ErrNoMoreRows)
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestDecodeValueFailures(t *testing.T) {
+	for _, d := range []struct {
+		n string
+		b []byte
+		t SQLValueType
+	}{
+		{
+			"Empty data", []byte{}, IntegerType,
+		},
+		{
+			"Not enough bytes for length", []byte{1, 2}, IntegerType,
+		},
+		{
+			"Not enough data", []byte{0, 0, 0, 3, 1, 2}, VarcharType,
+		},
+		{
+			"Negative length", []byte{0x80, 0, 0, 0, 0}, VarcharType,
+		},
+		{
+			"Too large integer", []byte{0, 0, 0, 9, 1, 2, 3, 4, 5, 6, 7, 8, 9}, IntegerType,
+		},
+		{
+			"Too large timestamp", []byte{0, 0, 0, 9, 1, 2, 3, 4, 5, 6, 7, 8, 9}, TimestampType,
+		},
+		{
+			"Zero-length boolean", []byte{0, 0, 0, 0}, BooleanType,
+		},
+		{
+			"Too large boolean", []byte{0, 0, 0, 2, 0, 0}, BooleanType,
+		},
+		{
+			"Any type", []byte{0, 0, 0, 1, 1}, AnyType,
+		},
+	} {
+		t.Run(d.n, func(t *testing.T) {
+			_, _, err := DecodeValue(d.b, d.t)
+			require.True(t, errors.Is(err, ErrCorruptedData))
+		})
+	}
+}
+
+func TestDecodeValueSuccess(t *testing.T) {
+	for _, d := range []struct {
+		n string
+		b []byte
+		t SQLValueType
+
+		v    TypedValue
+		offs int
+	}{
+		{
+			"varchar",
+			[]byte{0, 0, 0, 2, 'H', 'i'},
+			VarcharType,
+			&Varchar{val: "Hi"},
+			6,
+		},
+		{
+			"varchar padded",
+			[]byte{0, 0, 0, 2, 'H', 'i', 1, 2, 3},
+			VarcharType,
+			&Varchar{val: "Hi"},
+			6,
+		},
+		{
+			"empty varchar",
+			[]byte{0, 0, 0, 0},
+			VarcharType,
+			&Varchar{val: ""},
+			4,
+		},
+		{
+			"zero integer",
+			[]byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 0},
+			IntegerType,
+			&Number{val: 0},
+			12,
+		},
+		{
+			"large integer",
+			[]byte{0, 0, 0, 8, 0, 0, 0, 0, 127, 255, 255, 255},
+			IntegerType,
+			&Number{val: math.MaxInt32},
+			12,
+		},
+		{
+			"large integer padded",
+			[]byte{0, 0, 0, 8, 0, 0, 0, 0, 127, 255, 255, 255, 1, 1, 1},
+			IntegerType,
+			&Number{val: math.MaxInt32},
+			12,
+		},
+		{
+			"boolean false",
+			[]byte{0, 0, 0, 1, 0},
+			BooleanType,
+			&Bool{val: false},
+			5,
+		},
+		{
+			"boolean true",
+			[]byte{0, 0, 0, 1, 1},
+			BooleanType,
+			&Bool{val: true},
+			5,
+		},
+		{
+			"boolean padded",
+			[]byte{0, 0, 0, 1, 0, 1},
+			BooleanType,
+			&Bool{val: false},
+			5,
+		},
+		{
+			"blob",
+			[]byte{0, 0, 0, 2, 'H', 'i'},
+			BLOBType,
+			&Blob{val: []byte{'H', 'i'}},
+			6,
+		},
+		{
+			"blob padded",
+			[]byte{0, 0, 0, 2, 'H', 'i', 1, 2, 3},
+			BLOBType,
+			&Blob{val: []byte{'H', 'i'}},
+			6,
+		},
+		{
+			"empty blob",
+			[]byte{0, 0, 0, 0},
+			BLOBType,
+			&Blob{val: []byte{}},
+			4,
+		},
+	} {
+		t.Run(d.n, func(t *testing.T) {
+			v, offs, err := DecodeValue(d.b, d.t)
+			require.NoError(t, err)
+			require.EqualValues(t, d.offs, offs)
+
+			cmp, err := d.v.Compare(v)
+			require.NoError(t, err)
+			require.Zero(t, cmp)
+		})
+	}
+}
+
+func TestTrimPrefix(t *testing.T) {
+	e := Engine{prefix: []byte("e-prefix")}
+
+	for _, d := range []struct {
+		n string
+		k string
+	}{
+		{"empty key", ""},
+		{"no engine prefix", "no-e-prefix)"},
+		{"no mapping prefix", "e-prefix-no-mapping-prefix"},
+		{"short mapping prefix", "e-prefix-mapping"},
+	} {
+		t.Run(d.n, func(t *testing.T) {
+			prefix, err := trimPrefix(e.prefix, []byte(d.k), []byte("-mapping-prefix"))
+			require.Nil(t, prefix)
+			require.ErrorIs(t, err, ErrIllegalMappedKey)
+		})
+	}
+
+	for _, d := range []struct {
+		n string
+		k string
+		p string
+	}{
+		{"correct prefix", "e-prefix-mapping-prefix-key", "-key"},
+		{"exact prefix", "e-prefix-mapping-prefix", ""},
+	} {
+		t.Run(d.n, func(t *testing.T) {
+			prefix, err := trimPrefix(e.prefix, []byte(d.k), []byte("-mapping-prefix"))
+			require.NoError(t, err)
+			require.NotNil(t, prefix)
+			require.EqualValues(t, []byte(d.p), prefix)
+		})
+	}
+}
+
+func TestUnmapDatabaseId(t *testing.T) {
+	e := Engine{prefix: []byte("e-prefix.")}
+
+	id, err := unmapDatabaseID(e.prefix, nil)
+	require.ErrorIs(t, err, ErrIllegalMappedKey)
+	require.Zero(t, id)
+
+	id, err = unmapDatabaseID(e.prefix, []byte{})
+	require.ErrorIs(t, err, ErrIllegalMappedKey)
+	require.Zero(t, id)
+
+	id, err = unmapDatabaseID(e.prefix, []byte("pref"))
+	require.ErrorIs(t, err, ErrIllegalMappedKey)
+	require.Zero(t, id)
+
+	id, err = unmapDatabaseID(e.prefix, []byte("e-prefix.a"))
+	require.ErrorIs(t, err, ErrIllegalMappedKey)
+	require.Zero(t, id)
+
+	id, err = unmapDatabaseID(e.prefix, []byte(
+		"e-prefix.CTL.DATABASE.a",
+	))
+	require.ErrorIs(t, err, ErrCorruptedData)
+	require.Zero(t, id)
+
+	id, err = unmapDatabaseID(e.prefix, append(
+		[]byte("e-prefix.CTL.DATABASE."),
+		1, 2, 3, 4,
+	))
+	require.NoError(t, err)
+	require.EqualValues(t, 0x01020304, id)
+}
+
+func TestUnmapTableId(t *testing.T) {
+	e := Engine{prefix: []byte("e-prefix.")}
+
+	dbID, tableID, err := unmapTableID(e.prefix, nil)
+	require.ErrorIs(t, err, ErrIllegalMappedKey)
+	require.Zero(t, dbID)
+	require.Zero(t, tableID)
+
+	dbID, tableID, err = unmapTableID(e.prefix, []byte(
+		"e-prefix.CTL.TABLE.a",
+	))
+	require.ErrorIs(t, err, ErrCorruptedData)
+	require.Zero(t, dbID)
+	require.Zero(t, tableID)
+
+	dbID, tableID, err = unmapTableID(e.prefix, append(
+		[]byte("e-prefix.CTL.TABLE."),
+		0x01, 0x02, 0x03, 0x04,
+		0x11, 0x12, 0x13, 0x14,
+	))
+	require.NoError(t, err)
+	require.EqualValues(t, 0x01020304, dbID)
+	require.EqualValues(t, 0x11121314, tableID)
+}
+
+func TestUnmapColSpec(t *testing.T) {
+	e := Engine{prefix: []byte("e-prefix.")}
+
+	dbID, tableID, colID, colType, err := unmapColSpec(e.prefix, nil)
+	require.ErrorIs(t, err, ErrIllegalMappedKey)
+	require.Zero(t, dbID)
+	require.Zero(t, tableID)
+	require.Zero(t, colID)
+	require.Zero(t, colType)
+
+	dbID, tableID, colID, colType, err = unmapColSpec(e.prefix, []byte(
+		"e-prefix.CTL.COLUMN.a",
+	))
+	require.ErrorIs(t, err, ErrCorruptedData)
+	require.Zero(t, dbID)
+	require.Zero(t, tableID)
+	require.Zero(t, colID)
+	require.Zero(t, colType)
+
+	dbID, tableID, colID, colType, err = unmapColSpec(e.prefix, append(
+		[]byte("e-prefix.CTL.COLUMN."),
+		0x01, 0x02, 0x03, 0x04,
+		0x11, 0x12, 0x13, 0x14,
+		0x21, 0x22, 0x23, 0x24,
+		0x00,
+	))
+	require.ErrorIs(t, err, ErrCorruptedData)
+	require.Zero(t, dbID)
+	require.Zero(t, tableID)
+	require.Zero(t, colID)
+	require.Zero(t, colType)
+
+	dbID, tableID, colID, colType, err = unmapColSpec(e.prefix, append(
+		[]byte("e-prefix.CTL.COLUMN."),
+		0x01, 0x02, 0x03, 0x04,
+		0x11, 0x12, 0x13, 0x14,
+		0x21, 0x22, 0x23, 0x24,
+		'I', 'N', 'T', 'E', 'G', 'E', 'R',
+	))
+
+	require.NoError(t, err)
+	require.EqualValues(t, 0x01020304, dbID)
+	require.EqualValues(t, 0x11121314, tableID)
+	require.EqualValues(t, 0x21222324, colID)
+	require.Equal(t, "INTEGER", colType)
+}
+
+func TestUnmapIndex(t *testing.T) {
+	e := Engine{prefix: []byte("e-prefix.")}
+
+	dbID, tableID, colID, err := unmapIndex(e.prefix, nil)
+	require.ErrorIs(t, err, ErrIllegalMappedKey)
+	require.Zero(t, dbID)
+	require.Zero(t, tableID)
+	require.Zero(t, colID)
+
+	dbID, tableID, colID, err = unmapIndex(e.prefix, []byte(
+		"e-prefix.CTL.INDEX.a",
+	))
+	require.ErrorIs(t, err, ErrCorruptedData)
+	require.Zero(t, dbID)
+	require.Zero(t, tableID)
+	require.Zero(t, colID)
+
+	dbID, tableID, colID, err = unmapIndex(e.prefix, append(
+		[]byte("e-prefix.CTL.INDEX."),
+		0x01, 0x02, 0x03, 0x04,
+		0x11, 0x12, 0x13, 0x14,
+		0x21, 0x22, 0x23, 0x24,
+	))
+
+	require.NoError(t, err)
+	require.EqualValues(t, 0x01020304, dbID)
+	require.EqualValues(t, 0x11121314, tableID)
+	require.EqualValues(t, 0x21222324, colID)
+}
+
+func TestUnmapIndexEntry(t *testing.T) {
+	e := Engine{prefix: []byte("e-prefix.")}
+
+	encPKVals, err := unmapIndexEntry(&Index{id: PKIndexID, unique: true}, e.prefix, nil)
+	require.ErrorIs(t, err, ErrCorruptedData)
+	require.Nil(t, encPKVals)
+
+	encPKVals, err = unmapIndexEntry(&Index{id: PKIndexID, unique: true}, e.prefix, []byte(
+		"e-prefix.R.\x80a",
+	))
+	require.ErrorIs(t, err, ErrCorruptedData)
+	require.Nil(t, encPKVals)
+
+	fullValue := append(
+		[]byte("e-prefix.E."),
+		0x01, 0x02, 0x03, 0x04,
+		0x11, 0x12, 0x13, 0x14,
+		0x00, 0x00, 0x00, 0x02,
+		0x80,
+		'a', 'b', 'c', 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 3,
+		0x80,
+		'w', 'x', 'y', 'z',
+		0, 0, 0, 4,
+	)
+
+	sIndex := &Index{
+		table: &Table{
+			db: &Database{
+				id: 0x01020304,
+			},
+			id: 0x11121314,
+		},
+		id:     2,
+		unique: false,
+		cols: []*Column{
+			{id: 3, colType: VarcharType, maxLen: 10},
+		},
+	}
+
+	encPKLen := 8
+
+	for i := 13; i < len(fullValue)-encPKLen; i++ {
+		encPKVals, err = unmapIndexEntry(sIndex, e.prefix, fullValue[:i])
+		require.ErrorIs(t, err, ErrCorruptedData)
+		require.Nil(t, encPKVals)
+	}
+
+	encPKVals, err = unmapIndexEntry(sIndex, e.prefix, fullValue)
+	require.NoError(t, err)
+	require.EqualValues(t, []byte{0x80, 'w', 'x', 'y', 'z', 0, 0, 0, 4}, encPKVals)
+}
+
+func TestEncodeAsKeyEdgeCases(t *testing.T) {
+	_, err := EncodeAsKey(nil, IntegerType, 0)
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	_, err = EncodeAsKey("a", VarcharType, maxKeyLen+1)
+	require.ErrorIs(t, err, ErrMaxKeyLengthExceeded)
+
+	_, err = EncodeAsKey("a", "NOTATYPE", maxKeyLen)
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	t.Run("varchar cases", func(t *testing.T) {
+		_, err = EncodeAsKey(true, VarcharType, 10)
+		require.ErrorIs(t, err, ErrInvalidValue)
+
+		_, err = EncodeAsKey("abc", VarcharType, 1)
+		require.ErrorIs(t, err, ErrMaxLengthExceeded)
+	})
+
+	t.Run("integer cases", func(t *testing.T) {
+		_, err = EncodeAsKey(true, IntegerType, 8)
+		require.ErrorIs(t, err, ErrInvalidValue)
+
+		_, err = EncodeAsKey(int64(10), IntegerType, 4)
+		require.ErrorIs(t, err, ErrCorruptedData)
+	})
+
+	t.Run("boolean cases", func(t *testing.T) {
+		_, err = EncodeAsKey("abc", BooleanType, 1)
+		require.ErrorIs(t, err, ErrInvalidValue)
+
+		_, err = EncodeAsKey(true, BooleanType, 2)
+		require.ErrorIs(t, err, ErrCorruptedData)
+	})
+
+	t.Run("blob cases", func(t *testing.T) {
+		_, err = EncodeAsKey("abc", BLOBType, 3)
+		require.ErrorIs(t, err, ErrInvalidValue)
+
+		_, err = EncodeAsKey([]byte{1, 2, 3}, BLOBType, 2)
+		require.ErrorIs(t, err, ErrMaxLengthExceeded)
+	})
+
+	t.Run("timestamp cases", func(t *testing.T) {
+		_, err = EncodeAsKey(true, TimestampType, 8)
+		require.ErrorIs(t, err, ErrInvalidValue)
+
+		_, err = EncodeAsKey(int64(10), TimestampType, 4)
+		require.ErrorIs(t, err, ErrCorruptedData)
+	})
+}
+
+func TestDecodeValueAsKey(t *testing.T) {
+	ts := time.Date(2021, 12, 8, 13, 46, 23, 12345000, time.UTC)
+
+	for _, d := range []struct {
+		val     interface{}
+		colType SQLValueType
+		maxLen  int
+	}{
+		{int64(-10), IntegerType, 8},
+		{int64(10), IntegerType, 8},
+		{"title", VarcharType, 10},
+		{[]byte{1, 2, 3}, BLOBType, 3},
+		{true, BooleanType, 1},
+		{ts, TimestampType, 8},
+		{nil, VarcharType, 10},
+	} {
+		encVal, err := EncodeAsKey(d.val, d.colType, d.maxLen)
+		require.NoError(t, err)
+
+		val, n, err := DecodeValueAsKey(encVal, d.colType, d.maxLen)
+		require.NoError(t, err)
+		require.Equal(t, len(encVal), n)
+		require.Equal(t, d.val, val.Value())
+	}
+
+	for _, d := range []struct {
+		val     interface{}
+		colType SQLValueType
+		maxLen  int
+	}{
+		{-1.5, FloatType, 8},
+		{0.0, FloatType, 8},
+		{math.Inf(1), FloatType, 8},
+		{big.NewRat(-3, 2), DecimalType, 16},
+		{big.NewRat(0, 1), DecimalType, 16},
+		{big.NewRat(12345, 100), DecimalType, 16},
+	} {
+		encVal, err := EncodeAsKey(d.val, d.colType, d.maxLen)
+		require.NoError(t, err)
+
+		val, n, err := DecodeValueAsKey(encVal, d.colType, d.maxLen)
+		require.NoError(t, err)
+		require.Equal(t, len(encVal), n)
+
+		if d.colType == DecimalType {
+			require.Zero(t, d.val.(*big.Rat).Cmp(val.Value().(*big.Rat)))
+		} else {
+			require.Equal(t, d.val, val.Value())
+		}
+	}
+
+	_, _, err := DecodeValueAsKey(nil, IntegerType, 8)
+	require.ErrorIs(t, err, ErrCorruptedData)
+
+	_, _, err = DecodeValueAsKey([]byte{0}, IntegerType, 8)
+	require.ErrorIs(t, err, ErrCorruptedData)
+
+	_, _, err = DecodeValueAsKey([]byte{KeyValPrefixNotNull, 1}, IntegerType, 8)
+	require.ErrorIs(t, err, ErrCorruptedData)
+
+	_, _, err = DecodeValueAsKey([]byte{KeyValPrefixNotNull, 'a', 0, 0, 0, 2}, VarcharType, 1)
+	require.ErrorIs(t, err, ErrCorruptedData)
+
+	_, _, err = DecodeValueAsKey([]byte{KeyValPrefixNotNull}, BooleanType, 1)
+	require.ErrorIs(t, err, ErrCorruptedData)
+
+	_, _, err = DecodeValueAsKey([]byte{KeyValPrefixNotNull}, "NOTATYPE", 1)
+	require.ErrorIs(t, err, ErrCorruptedData)
+}
+
+func TestDecodePrimaryKey(t *testing.T) {
+	db, err := newCatalog().newDatabase(1, "db1")
+	require.NoError(t, err)
+
+	table, err := db.newTable("table1", []*ColSpec{
+		{colName: "id", colType: IntegerType},
+		{colName: "title", colType: VarcharType, maxLen: 10},
+	})
+	require.NoError(t, err)
+
+	_, err = table.newIndex(true, []uint32{1, 2})
+	require.NoError(t, err)
+
+	encID, err := EncodeAsKey(int64(1), IntegerType, 8)
+	require.NoError(t, err)
+
+	encTitle, err := EncodeAsKey("title1", VarcharType, 10)
+	require.NoError(t, err)
+
+	mkey := mapKey(sqlPrefix, PIndexPrefix, EncodeID(db.id), EncodeID(table.id), EncodeID(PKIndexID), encID, encTitle)
+
+	pkVals, err := table.DecodePrimaryKey(sqlPrefix, mkey)
+	require.NoError(t, err)
+	require.Len(t, pkVals, 2)
+	require.Equal(t, int64(1), pkVals[0].Value())
+	require.Equal(t, "title1", pkVals[1].Value())
+
+	_, err = table.DecodePrimaryKey(sqlPrefix, mkey[:len(mkey)-1])
+	require.ErrorIs(t, err, ErrCorruptedData)
+
+	_, err = table.DecodePrimaryKey(sqlPrefix, append(mkey, 0))
+	require.ErrorIs(t, err, ErrCorruptedData)
+}
+
+func TestIndexingNullableColumns(t *testing.T) {
+	st, err := store.Open("catalog_indexing_nullable", store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
+	defer os.RemoveAll("catalog_indexing_nullable")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	exec := func(t *testing.T, stmt string) *SQLTx {
+		ret, _, err := engine.Exec(context.Background(), stmt, nil, nil)
+		require.NoError(t, err)
+		return ret
+	}
+	query := func(t *testing.T, stmt string, expectedRows ...*Row) {
+		reader, err := engine.Query(context.Background(), stmt, nil, nil)
+		require.NoError(t, err)
+
+		for _, expectedRow := range expectedRows {
+			row, err := reader.Read()
+			require.NoError(t, err)
+
+			require.EqualValues(t, expectedRow, row)
+		}
+
+		_, err = reader.Read()
+		require.ErrorIs(t, err, ErrNoMoreRows)
+
+		err = reader.Close()
+		require.NoError(t, err)
+	}
+
+	colVal := func(t *testing.T, v interface{}, tp SQLValueType) TypedValue {
+		switch v := v.(type) {
+		case nil:
+			return &NullValue{t: tp}
+		case int:
+			return &Number{val: int64(v)}
+		case string:
+			return &Varchar{val: v}
+		case []byte:
+			return &Blob{val: v}
+		case bool:
+			return &Bool{val: v}
+		}
+		require.Fail(t, "Unknown type of value")
+		return nil
+	}
+
+	t1Row := func(id int64, v1, v2 interface{}) *Row {
+		return &Row{
+			Values: map[string]TypedValue{
+				EncodeSelector("", "db1", "table1", "id"): &Number{val: id},
+				EncodeSelector("", "db1", "table1", "v1"): colVal(t, v1, IntegerType),
+				EncodeSelector("", "db1", "table1", "v2"): colVal(t, v2, VarcharType),
+			},
+		}
+	}
+
+	t2Row := func(id int64, v1, v2, v3, v4 interface{}) *Row {
+		return &Row{
+			Values: map[string]TypedValue{
+				EncodeSelector("", "db1", "table2", "id"): &Number{val: id},
+				EncodeSelector("", "db1", "table2", "v1"): colVal(t, v1, IntegerType),
+				EncodeSelector("", "db1", "table2", "v2"): colVal(t, v2, VarcharType),
+				EncodeSelector("", "db1", "table2", "v3"): colVal(t, v3, BooleanType),
+				EncodeSelector("", "db1", "table2", "v4"): colVal(t, v4, BLOBType),
+			},
+		}
+	}
+
+	exec(t, `
+		CREATE TABLE table1 (
+			id INTEGER AUTO_INCREMENT,
+			v1 INTEGER,
+			v2 VARCHAR[16],
+			PRIMARY KEY(id)
+		)
+	`)
+	exec(t, "CREATE INDEX ON table1 (v1, v2)")
+	query(t, "SELECT * FROM table1 USE INDEX ON(v1,v2)")
+
+	t.Run("succeed adding non-null columns", func(t *testing.T) {
+		exec(t, "INSERT INTO table1(v1,v2) VALUES(1, '2')")
+		query(t,
+			"SELECT * FROM table1 USE INDEX ON(v1,v2)",
+			t1Row(1, 1, "2"),
+		)
+
+		exec(t, "INSERT INTO table1(v1,v2) VALUES(1, '3')")
+		query(t,
+			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
+			t1Row(1, 1, "2"),
+			t1Row(2, 1, "3"),
+		)
+	})
+
+	t.Run("succeed adding null columns as the second indexed column", func(t *testing.T) {
+
+		exec(t, "INSERT INTO table1(v1,v2) VALUES(1, null)")
+		query(t,
+			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
+			t1Row(3, 1, nil),
+			t1Row(1, 1, "2"),
+			t1Row(2, 1, "3"),
+		)
+
+		exec(t, "INSERT INTO table1(v1,v2) VALUES(1, null)")
+		query(t,
+			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
+			t1Row(3, 1, nil),
+			t1Row(4, 1, nil),
+			t1Row(1, 1, "2"),
+			t1Row(2, 1, "3"),
+		)
+
+		exec(t, "INSERT INTO table1(v1,v2) VALUES(2, null)")
+		query(t,
+			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
+			t1Row(3, 1, nil),
+			t1Row(4, 1, nil),
+			t1Row(1, 1, "2"),
+			t1Row(2, 1, "3"),
+		)
+	})
+
+	t.Run("succeed adding null columns as the first indexed column", func(t *testing.T) {
+		exec(t, "INSERT INTO table1(v1,v2) VALUES(null, '4')")
+		query(t,
+			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
+			t1Row(3, 1, nil),
+			t1Row(4, 1, nil),
+			t1Row(1, 1, "2"),
+			t1Row(2, 1, "3"),
+		)
+
+		query(t,
+			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
+			t1Row(3, 1, nil),
+			t1Row(4, 1, nil),
+			t1Row(1, 1, "2"),
+			t1Row(2, 1, "3"),
+		)
+	})
+
+	t.Run("succeed querying null columns using index", func(t *testing.T) {
+		query(t,
+			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=null",
+			t1Row(6, nil, "4"),
+		)
+	})
+
+	t.Run("suceed creating table with two indexes", func(t *testing.T) {
+
+		exec(t, `
+			CREATE TABLE table2 (
+				id INTEGER AUTO_INCREMENT,
+				v1 INTEGER,
+				v2 VARCHAR[16],
+				v3 BOOLEAN,
+				v4 BLOB[15],
+				PRIMARY KEY(id)
+			)
+		`)
 
-	_, err = engine.InferParameters("BEGIN TRANSACTION; INSERT INTO mytable(id, title) VALUES (@param1, @param1); COMMIT;", nil)
-	require.Equal(t, ErrInferredMultipleTypes, err)
-}
+		exec(t, "CREATE INDEX ON table2(v1, v2)")
+		exec(t, "CREATE UNIQUE INDEX ON table2(v3, v4)")
 
-func TestDecodeValueFailures(t *testing.T) {
-	for _, d := range []struct {
-		n string
-		b []byte
-		t SQLValueType
-	}{
-		{
-			"Empty data", []byte{}, IntegerType,
-		},
-		{
-			"Not enough bytes for length", []byte{1, 2}, IntegerType,
-		},
-		{
-			"Not enough data", []byte{0, 0, 0, 3, 1, 2}, VarcharType,
-		},
-		{
-			"Negative length", []byte{0x80, 0, 0, 0, 0}, VarcharType,
-		},
-		{
-			"Too large integer", []byte{0, 0, 0, 9, 1, 2, 3, 4, 5, 6, 7, 8, 9}, IntegerType,
-		},
-		{
-			"Too large timestamp", []byte{0, 0, 0, 9, 1, 2, 3, 4, 5, 6, 7, 8, 9}, TimestampType,
-		},
-		{
-			"Zero-length boolean", []byte{0, 0, 0, 0}, BooleanType,
-		},
-		{
-			"Too large boolean", []byte{0, 0, 0, 2, 0, 0}, BooleanType,
-		},
-		{
-			"Any type", []byte{0, 0, 0, 1, 1}, AnyType,
-		},
-	} {
-		t.Run(d.n, func(t *testing.T) {
-			_, _, err := DecodeValue(d.b, d.t)
-			require.True(t, errors.Is(err, ErrCorruptedData))
-		})
-	}
+		query(t, "SELECT * FROM table2 USE INDEX ON(v3,v4)")
+
+	})
+
+	t.Run("succeed inserting data on table with two indexes", func(t *testing.T) {
+		exec(t, "INSERT INTO table2(v1, v2, v3, v4) VALUES(null, null, null, null)")
+		query(t, "SELECT * FROM table2 USE INDEX ON(v1, v2)", t2Row(1, nil, nil, nil, nil))
+		query(t, "SELECT * FROM table2 USE INDEX ON(v3, v4)", t2Row(1, nil, nil, nil, nil))
+	})
+
+	t.Run("fail adding entries with duplicate with nulls", func(t *testing.T) {
+		_, _, err := engine.Exec(context.Background(), "INSERT INTO table2(v1, v2, v3, v4) VALUES(1, '2', null, null)", nil, nil)
+		require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
+	})
+
+	t.Run("succeed scanning multiple rows on table with two indexes", func(t *testing.T) {
+		exec(t, `
+			INSERT INTO table2(v1,v2,v3,v4) VALUES
+			(1,'2',true, null),
+			(3,'4',null, x'1234'),
+			(5,'6',false, x'5678')
+		`)
+
+		// Order for boolean must be null -> false -> true
+		query(t, "SELECT * FROM table2 USE INDEX ON(v3, v4)",
+			t2Row(1, nil, nil, nil, nil),
+			t2Row(3, 3, "4", nil, []byte{0x12, 0x34}),
+			t2Row(4, 5, "6", false, []byte{0x56, 0x78}),
+			t2Row(2, 1, "2", true, nil),
+		)
+	})
 }
 
-func TestDecodeValueSuccess(t *testing.T) {
-	for _, d := range []struct {
-		n string
-		b []byte
-		t SQLValueType
+func TestEngineNewTx(t *testing.T) {
+	st, err := store.Open(t.TempDir(), store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
 
-		v    TypedValue
-		offs int
-	}{
-		{
-			"varchar",
-			[]byte{0, 0, 0, 2, 'H', 'i'},
-			VarcharType,
-			&Varchar{val: "Hi"},
-			6,
-		},
-		{
-			"varchar padded",
-			[]byte{0, 0, 0, 2, 'H', 'i', 1, 2, 3},
-			VarcharType,
-			&Varchar{val: "Hi"},
-			6,
-		},
-		{
-			"empty varchar",
-			[]byte{0, 0, 0, 0},
-			VarcharType,
-			&Varchar{val: ""},
-			4,
-		},
-		{
-			"zero integer",
-			[]byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 0},
-			IntegerType,
-			&Number{val: 0},
-			12,
-		},
-		{
-			"large integer",
-			[]byte{0, 0, 0, 8, 0, 0, 0, 0, 127, 255, 255, 255},
-			IntegerType,
-			&Number{val: math.MaxInt32},
-			12,
-		},
-		{
-			"large integer padded",
-			[]byte{0, 0, 0, 8, 0, 0, 0, 0, 127, 255, 255, 255, 1, 1, 1},
-			IntegerType,
-			&Number{val: math.MaxInt32},
-			12,
-		},
-		{
-			"boolean false",
-			[]byte{0, 0, 0, 1, 0},
-			BooleanType,
-			&Bool{val: false},
-			5,
-		},
-		{
-			"boolean true",
-			[]byte{0, 0, 0, 1, 1},
-			BooleanType,
-			&Bool{val: true},
-			5,
-		},
-		{
-			"boolean padded",
-			[]byte{0, 0, 0, 1, 0, 1},
-			BooleanType,
-			&Bool{val: false},
-			5,
-		},
-		{
-			"blob",
-			[]byte{0, 0, 0, 2, 'H', 'i'},
-			BLOBType,
-			&Blob{val: []byte{'H', 'i'}},
-			6,
-		},
-		{
-			"blob padded",
-			[]byte{0, 0, 0, 2, 'H', 'i', 1, 2, 3},
-			BLOBType,
-			&Blob{val: []byte{'H', 'i'}},
-			6,
-		},
-		{
-			"empty blob",
-			[]byte{0, 0, 0, 0},
-			BLOBType,
-			&Blob{val: []byte{}},
-			4,
-		},
-	} {
-		t.Run(d.n, func(t *testing.T) {
-			v, offs, err := DecodeValue(d.b, d.t)
-			require.NoError(t, err)
-			require.EqualValues(t, d.offs, offs)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-			cmp, err := d.v.Compare(v)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE DATABASE db1;
+		USE DATABASE db1;
+		CREATE TABLE table1 (id INTEGER, PRIMARY KEY id);
+		INSERT INTO table1 (id) VALUES (1);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	countRows := func(tx *SQLTx) int {
+		r, err := engine.Query(context.Background(), "SELECT id FROM table1", nil, tx)
+		require.NoError(t, err)
+		defer r.Close()
+
+		n := 0
+		for {
+			_, err = r.Read()
+			if err == ErrNoMoreRows {
+				return n
+			}
 			require.NoError(t, err)
-			require.Zero(t, cmp)
-		})
+			n++
+		}
 	}
-}
 
-func TestTrimPrefix(t *testing.T) {
-	e := Engine{prefix: []byte("e-prefix")}
+	for _, waitForIndexing := range []bool{true, false} {
+		tx, err := engine.NewTx(context.Background(), waitForIndexing)
+		require.NoError(t, err)
 
-	for _, d := range []struct {
-		n string
-		k string
-	}{
-		{"empty key", ""},
-		{"no engine prefix", "no-e-prefix)"},
-		{"no mapping prefix", "e-prefix-no-mapping-prefix"},
-		{"short mapping prefix", "e-prefix-mapping"},
-	} {
-		t.Run(d.n, func(t *testing.T) {
-			prefix, err := trimPrefix(e.prefix, []byte(d.k), []byte("-mapping-prefix"))
-			require.Nil(t, prefix)
-			require.ErrorIs(t, err, ErrIllegalMappedKey)
-		})
+		before := countRows(nil)
+
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id) VALUES (@id)", map[string]interface{}{"id": before + 1}, nil)
+		require.NoError(t, err)
+
+		// queries on the transaction keep reading from its snapshot
+		require.Equal(t, before, countRows(tx))
+		require.Equal(t, before, countRows(tx))
+		require.Equal(t, before+1, countRows(nil))
+
+		err = tx.Cancel()
+		require.NoError(t, err)
 	}
+}
 
-	for _, d := range []struct {
-		n string
-		k string
-		p string
-	}{
-		{"correct prefix", "e-prefix-mapping-prefix-key", "-key"},
-		{"exact prefix", "e-prefix-mapping-prefix", ""},
-	} {
-		t.Run(d.n, func(t *testing.T) {
-			prefix, err := trimPrefix(e.prefix, []byte(d.k), []byte("-mapping-prefix"))
+func TestSynonyms(t *testing.T) {
+	dir := t.TempDir()
+
+	st, err := store.Open(dir, store.DefaultOptions())
+	require.NoError(t, err)
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE SYNONYM syn1 FOR table1", nil, nil)
+	require.ErrorIs(t, err, ErrNoDatabaseSelected)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE DATABASE db1;
+		USE DATABASE db1;
+		CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id);
+		INSERT INTO table1 (id, title) VALUES (1, 'title1');
+	`, nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE SYNONYM syn1 FOR table2", nil, nil)
+	require.ErrorIs(t, err, ErrTableDoesNotExist)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE SYNONYM syn1 FOR table1", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE SYNONYM syn1 FOR table1", nil, nil)
+	require.ErrorIs(t, err, ErrSynonymAlreadyExists)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE SYNONYM table1 FOR table1", nil, nil)
+	require.ErrorIs(t, err, ErrTableAlreadyExists)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE syn1 (id INTEGER, PRIMARY KEY id)", nil, nil)
+	require.ErrorIs(t, err, ErrSynonymAlreadyExists)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO syn1 (id, title) VALUES (2, 'title2')", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "UPDATE syn1 SET title = 'title3' WHERE id = 2", nil, nil)
+	require.NoError(t, err)
+
+	queryTitles := func(src string) []string {
+		r, err := engine.Query(context.Background(), src, nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var titles []string
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				return titles
+			}
 			require.NoError(t, err)
-			require.NotNil(t, prefix)
-			require.EqualValues(t, []byte(d.p), prefix)
-		})
+
+			for _, v := range row.Values {
+				titles = append(titles, v.Value().(string))
+			}
+		}
 	}
-}
 
-func TestUnmapDatabaseId(t *testing.T) {
-	e := Engine{prefix: []byte("e-prefix.")}
+	require.Equal(t, []string{"title1", "title3"}, queryTitles("SELECT title FROM table1"))
+	require.Equal(t, []string{"title1", "title3"}, queryTitles("SELECT syn1.title FROM syn1"))
+	require.Equal(t, []string{"title3"}, queryTitles("SELECT s.title FROM syn1 AS s WHERE s.id > 1"))
 
-	id, err := unmapDatabaseID(e.prefix, nil)
-	require.ErrorIs(t, err, ErrIllegalMappedKey)
-	require.Zero(t, id)
+	err = st.Close()
+	require.NoError(t, err)
 
-	id, err = unmapDatabaseID(e.prefix, []byte{})
-	require.ErrorIs(t, err, ErrIllegalMappedKey)
-	require.Zero(t, id)
+	st, err = store.Open(dir, store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
 
-	id, err = unmapDatabaseID(e.prefix, []byte("pref"))
-	require.ErrorIs(t, err, ErrIllegalMappedKey)
-	require.Zero(t, id)
+	engine, err = NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
 
-	id, err = unmapDatabaseID(e.prefix, []byte("e-prefix.a"))
-	require.ErrorIs(t, err, ErrIllegalMappedKey)
-	require.Zero(t, id)
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-	id, err = unmapDatabaseID(e.prefix, []byte(
-		"e-prefix.CTL.DATABASE.a",
-	))
-	require.ErrorIs(t, err, ErrCorruptedData)
-	require.Zero(t, id)
+	require.Equal(t, []string{"title1", "title3"}, queryTitles("SELECT title FROM syn1"))
 
-	id, err = unmapDatabaseID(e.prefix, append(
-		[]byte("e-prefix.CTL.DATABASE."),
-		1, 2, 3, 4,
-	))
+	catalog, err := engine.Catalog(context.Background(), nil)
 	require.NoError(t, err)
-	require.EqualValues(t, 0x01020304, id)
-}
 
-func TestUnmapTableId(t *testing.T) {
-	e := Engine{prefix: []byte("e-prefix.")}
+	db, err := catalog.GetDatabaseByName("db1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"syn1": "table1"}, db.GetSynonyms())
 
-	dbID, tableID, err := unmapTableID(e.prefix, nil)
-	require.ErrorIs(t, err, ErrIllegalMappedKey)
-	require.Zero(t, dbID)
-	require.Zero(t, tableID)
+	_, _, err = engine.Exec(context.Background(), "DROP SYNONYM syn2", nil, nil)
+	require.ErrorIs(t, err, ErrSynonymDoesNotExist)
 
-	dbID, tableID, err = unmapTableID(e.prefix, []byte(
-		"e-prefix.CTL.TABLE.a",
-	))
-	require.ErrorIs(t, err, ErrCorruptedData)
-	require.Zero(t, dbID)
-	require.Zero(t, tableID)
+	_, _, err = engine.Exec(context.Background(), "DROP SYNONYM IF EXISTS syn2", nil, nil)
+	require.NoError(t, err)
 
-	dbID, tableID, err = unmapTableID(e.prefix, append(
-		[]byte("e-prefix.CTL.TABLE."),
-		0x01, 0x02, 0x03, 0x04,
-		0x11, 0x12, 0x13, 0x14,
-	))
+	_, _, err = engine.Exec(context.Background(), "DROP SYNONYM syn1", nil, nil)
+	require.NoError(t, err)
+
+	_, err = engine.Query(context.Background(), "SELECT title FROM syn1", nil, nil)
+	require.ErrorIs(t, err, ErrTableDoesNotExist)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE syn1 (id INTEGER, PRIMARY KEY id)", nil, nil)
 	require.NoError(t, err)
-	require.EqualValues(t, 0x01020304, dbID)
-	require.EqualValues(t, 0x11121314, tableID)
 }
 
-func TestUnmapColSpec(t *testing.T) {
-	e := Engine{prefix: []byte("e-prefix.")}
+func TestTableFilter(t *testing.T) {
+	st, err := store.Open(t.TempDir(), store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
 
-	dbID, tableID, colID, colType, err := unmapColSpec(e.prefix, nil)
-	require.ErrorIs(t, err, ErrIllegalMappedKey)
-	require.Zero(t, dbID)
-	require.Zero(t, tableID)
-	require.Zero(t, colID)
-	require.Zero(t, colType)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	dbID, tableID, colID, colType, err = unmapColSpec(e.prefix, []byte(
-		"e-prefix.CTL.COLUMN.a",
-	))
-	require.ErrorIs(t, err, ErrCorruptedData)
-	require.Zero(t, dbID)
-	require.Zero(t, tableID)
-	require.Zero(t, colID)
-	require.Zero(t, colType)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE DATABASE db1;
+		USE DATABASE db1;
+		CREATE TABLE table1 (id INTEGER, PRIMARY KEY id);
+		CREATE TABLE table2 (id INTEGER, PRIMARY KEY id);
+		INSERT INTO table1 (id) VALUES (1), (2);
+		INSERT INTO table2 (id) VALUES (1);
+		CREATE SYNONYM syn2 FOR table2;
+	`, nil, nil)
+	require.NoError(t, err)
 
-	dbID, tableID, colID, colType, err = unmapColSpec(e.prefix, append(
-		[]byte("e-prefix.CTL.COLUMN."),
-		0x01, 0x02, 0x03, 0x04,
-		0x11, 0x12, 0x13, 0x14,
-		0x21, 0x22, 0x23, 0x24,
-		0x00,
-	))
-	require.ErrorIs(t, err, ErrCorruptedData)
-	require.Zero(t, dbID)
-	require.Zero(t, tableID)
-	require.Zero(t, colID)
-	require.Zero(t, colType)
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
 
-	dbID, tableID, colID, colType, err = unmapColSpec(e.prefix, append(
-		[]byte("e-prefix.CTL.COLUMN."),
-		0x01, 0x02, 0x03, 0x04,
-		0x11, 0x12, 0x13, 0x14,
-		0x21, 0x22, 0x23, 0x24,
-		'I', 'N', 'T', 'E', 'G', 'E', 'R',
-	))
+	tx, err := engine.NewTx(context.Background(), true)
+	require.NoError(t, err)
+	defer tx.Cancel()
+
+	tx.SetTableFilter(func(db, table string) bool {
+		return db == "db1" && table == "table1"
+	})
 
+	r, err := engine.Query(context.Background(), "SELECT COUNT(*) FROM table1", nil, tx)
 	require.NoError(t, err)
-	require.EqualValues(t, 0x01020304, dbID)
-	require.EqualValues(t, 0x11121314, tableID)
-	require.EqualValues(t, 0x21222324, colID)
-	require.Equal(t, "INTEGER", colType)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Len(t, row.Values, 1)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	for _, q := range []string{
+		"SELECT id FROM table2",
+		"SELECT id FROM syn2",
+		"SELECT t1.id FROM table1 t1 INNER JOIN table2 t2 ON t1.id = t2.id",
+		"SELECT id FROM table1 WHERE id IN (SELECT id FROM table2)",
+	} {
+		r, err := engine.Query(context.Background(), q, nil, tx)
+		if err == nil {
+			_, err = r.Read()
+			r.Close()
+		}
+		require.ErrorIs(t, err, ErrTableAccessDenied, q)
+	}
 }
 
-func TestUnmapIndex(t *testing.T) {
-	e := Engine{prefix: []byte("e-prefix.")}
+func TestMaterializedViews(t *testing.T) {
+	dir := t.TempDir()
 
-	dbID, tableID, colID, err := unmapIndex(e.prefix, nil)
-	require.ErrorIs(t, err, ErrIllegalMappedKey)
-	require.Zero(t, dbID)
-	require.Zero(t, tableID)
-	require.Zero(t, colID)
+	st, err := store.Open(dir, store.DefaultOptions())
+	require.NoError(t, err)
 
-	dbID, tableID, colID, err = unmapIndex(e.prefix, []byte(
-		"e-prefix.CTL.INDEX.a",
-	))
-	require.ErrorIs(t, err, ErrCorruptedData)
-	require.Zero(t, dbID)
-	require.Zero(t, tableID)
-	require.Zero(t, colID)
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	dbID, tableID, colID, err = unmapIndex(e.prefix, append(
-		[]byte("e-prefix.CTL.INDEX."),
-		0x01, 0x02, 0x03, 0x04,
-		0x11, 0x12, 0x13, 0x14,
-		0x21, 0x22, 0x23, 0x24,
-	))
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE DATABASE db1;
+		USE DATABASE db1;
+		CREATE TABLE orders (id INTEGER AUTO_INCREMENT, category VARCHAR[20] NOT NULL, amount INTEGER, PRIMARY KEY id);
+		INSERT INTO orders (category, amount) VALUES ('books', 10), ('books', 20), ('games', 5), ('music', 100);
+	`, nil, nil)
+	require.NoError(t, err)
 
+	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
-	require.EqualValues(t, 0x01020304, dbID)
-	require.EqualValues(t, 0x11121314, tableID)
-	require.EqualValues(t, 0x21222324, colID)
-}
 
-func TestUnmapIndexEntry(t *testing.T) {
-	e := Engine{prefix: []byte("e-prefix.")}
+	queryRows := func(src string) [][]interface{} {
+		r, err := engine.Query(context.Background(), src, nil, nil)
+		require.NoError(t, err)
+		defer r.Close()
 
-	encPKVals, err := unmapIndexEntry(&Index{id: PKIndexID, unique: true}, e.prefix, nil)
-	require.ErrorIs(t, err, ErrCorruptedData)
-	require.Nil(t, encPKVals)
+		cols, err := r.Columns()
+		require.NoError(t, err)
 
-	encPKVals, err = unmapIndexEntry(&Index{id: PKIndexID, unique: true}, e.prefix, []byte(
-		"e-prefix.R.\x80a",
-	))
-	require.ErrorIs(t, err, ErrCorruptedData)
-	require.Nil(t, encPKVals)
+		var rows [][]interface{}
 
-	fullValue := append(
-		[]byte("e-prefix.E."),
-		0x01, 0x02, 0x03, 0x04,
-		0x11, 0x12, 0x13, 0x14,
-		0x00, 0x00, 0x00, 0x02,
-		0x80,
-		'a', 'b', 'c', 0, 0, 0, 0, 0, 0, 0,
-		0, 0, 0, 3,
-		0x80,
-		'w', 'x', 'y', 'z',
-		0, 0, 0, 4,
-	)
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				return rows
+			}
+			require.NoError(t, err)
 
-	sIndex := &Index{
-		table: &Table{
-			db: &Database{
-				id: 0x01020304,
-			},
-			id: 0x11121314,
-		},
-		id:     2,
-		unique: false,
-		cols: []*Column{
-			{id: 3, colType: VarcharType, maxLen: 10},
-		},
+			vals := make([]interface{}, len(cols))
+			for i, col := range cols {
+				vals[i] = row.Values[col.Selector()].Value()
+			}
+
+			rows = append(rows, vals)
+		}
 	}
 
-	encPKLen := 8
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE MATERIALIZED VIEW totals AS
+			SELECT category, COUNT(*) AS orders, SUM(amount) AS amount FROM orders WHERE amount > 5 GROUP BY category ORDER BY category;
+		CREATE MATERIALIZED VIEW large_orders AS SELECT id, amount FROM orders WHERE amount >= 20;
+	`, nil, nil)
+	require.NoError(t, err)
 
-	for i := 13; i < len(fullValue)-encPKLen; i++ {
-		encPKVals, err = unmapIndexEntry(sIndex, e.prefix, fullValue[:i])
-		require.ErrorIs(t, err, ErrCorruptedData)
-		require.Nil(t, encPKVals)
-	}
+	require.Equal(t, [][]interface{}{
+		{"books", int64(2), int64(30)},
+		{"music", int64(1), int64(100)},
+	}, queryRows("SELECT category, orders, amount FROM totals"))
+
+	require.Equal(t, [][]interface{}{{int64(2), int64(20)}, {int64(4), int64(100)}}, queryRows("SELECT * FROM large_orders"))
+
+	t.Run("views should only be updated when refreshed", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), `
+			INSERT INTO orders (category, amount) VALUES ('games', 50);
+			UPDATE orders SET amount = 1 WHERE id = 4;
+			UPDATE orders SET category = 'games' WHERE id = 2;
+			DELETE FROM orders WHERE id = 1;
+		`, nil, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, [][]interface{}{
+			{"books", int64(2), int64(30)},
+			{"music", int64(1), int64(100)},
+		}, queryRows("SELECT category, orders, amount FROM totals"))
+
+		_, _, err = engine.Exec(context.Background(), "REFRESH MATERIALIZED VIEW totals; REFRESH MATERIALIZED VIEW large_orders", nil, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, [][]interface{}{
+			{"games", int64(2), int64(70)},
+		}, queryRows("SELECT category, orders, amount FROM totals"))
+
+		require.Equal(t, [][]interface{}{{int64(2), int64(20)}, {int64(5), int64(50)}}, queryRows("SELECT * FROM large_orders"))
+	})
+
+	t.Run("refreshing an up to date view should not write rows", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO orders (category, amount) VALUES ('books', 1)", nil, nil)
+		require.NoError(t, err)
+
+		_, ctxs, err := engine.Exec(context.Background(), "REFRESH MATERIALIZED VIEW totals", nil, nil)
+		require.NoError(t, err)
+		require.Len(t, ctxs, 1)
+		require.Zero(t, ctxs[0].UpdatedRows())
+	})
+
+	t.Run("views should be read-only", func(t *testing.T) {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO totals (category, orders, amount) VALUES ('toys', 1, 1)", nil, nil)
+		require.ErrorIs(t, err, ErrMaterializedViewIsReadOnly)
+
+		_, _, err = engine.Exec(context.Background(), "UPDATE totals SET amount = 0", nil, nil)
+		require.ErrorIs(t, err, ErrMaterializedViewIsReadOnly)
+
+		_, _, err = engine.Exec(context.Background(), "DELETE FROM large_orders WHERE id = 2", nil, nil)
+		require.ErrorIs(t, err, ErrMaterializedViewIsReadOnly)
+
+		_, _, err = engine.Exec(context.Background(), "ALTER TABLE totals ADD COLUMN note VARCHAR", nil, nil)
+		require.ErrorIs(t, err, ErrMaterializedViewIsReadOnly)
+
+		_, _, err = engine.Exec(context.Background(), "REFRESH MATERIALIZED VIEW orders", nil, nil)
+		require.ErrorIs(t, err, ErrMaterializedViewDoesNotExist)
+
+		_, _, err = engine.Exec(context.Background(), "DROP MATERIALIZED VIEW orders", nil, nil)
+		require.ErrorIs(t, err, ErrMaterializedViewDoesNotExist)
+	})
+
+	t.Run("unsupported queries should be rejected", func(t *testing.T) {
+		for _, q := range []string{
+			"SELECT amount FROM orders",
+			"SELECT DISTINCT id FROM orders",
+			"SELECT id FROM orders LIMIT 1",
+			"SELECT COUNT(*) FROM orders",
+			"SELECT amount, COUNT(*) FROM orders GROUP BY amount",
+			"SELECT id FROM orders WHERE amount IN (SELECT amount FROM orders)",
+			"SELECT id FROM totals",
+		} {
+			_, _, err = engine.Exec(context.Background(), "CREATE MATERIALIZED VIEW v AS "+q, nil, nil)
+			require.ErrorIs(t, err, ErrLimitedMaterializedView, q)
+		}
+	})
+
+	err = st.Close()
+	require.NoError(t, err)
+
+	st, err = store.Open(dir, store.DefaultOptions())
+	require.NoError(t, err)
+	defer st.Close()
+
+	engine, err = NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "REFRESH MATERIALIZED VIEW totals", nil, nil)
+	require.NoError(t, err)
 
-	encPKVals, err = unmapIndexEntry(sIndex, e.prefix, fullValue)
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO orders (category, amount) VALUES ('books', 15)", nil, nil)
 	require.NoError(t, err)
-	require.EqualValues(t, []byte{0x80, 'w', 'x', 'y', 'z', 0, 0, 0, 4}, encPKVals)
-}
 
-func TestEncodeAsKeyEdgeCases(t *testing.T) {
-	_, err := EncodeAsKey(nil, IntegerType, 0)
-	require.ErrorIs(t, err, ErrInvalidValue)
+	_, _, err = engine.Exec(context.Background(), "REFRESH MATERIALIZED VIEW totals", nil, nil)
+	require.NoError(t, err)
 
-	_, err = EncodeAsKey("a", VarcharType, maxKeyLen+1)
-	require.ErrorIs(t, err, ErrMaxKeyLengthExceeded)
+	require.Equal(t, [][]interface{}{
+		{"books", int64(1), int64(15)},
+		{"games", int64(2), int64(70)},
+	}, queryRows("SELECT category, orders, amount FROM totals WHERE orders > 0"))
 
-	_, err = EncodeAsKey("a", "NOTATYPE", maxKeyLen)
-	require.ErrorIs(t, err, ErrInvalidValue)
+	_, _, err = engine.Exec(context.Background(), "CREATE MATERIALIZED VIEW IF NOT EXISTS totals AS SELECT id FROM orders", nil, nil)
+	require.NoError(t, err)
 
-	t.Run("varchar cases", func(t *testing.T) {
-		_, err = EncodeAsKey(true, VarcharType, 10)
-		require.ErrorIs(t, err, ErrInvalidValue)
+	_, _, err = engine.Exec(context.Background(), "DROP MATERIALIZED VIEW totals", nil, nil)
+	require.NoError(t, err)
 
-		_, err = EncodeAsKey("abc", VarcharType, 1)
-		require.ErrorIs(t, err, ErrMaxLengthExceeded)
-	})
+	_, _, err = engine.Exec(context.Background(), "DROP MATERIALIZED VIEW IF EXISTS totals", nil, nil)
+	require.NoError(t, err)
 
-	t.Run("integer cases", func(t *testing.T) {
-		_, err = EncodeAsKey(true, IntegerType, 8)
-		require.ErrorIs(t, err, ErrInvalidValue)
+	_, err = engine.Query(context.Background(), "SELECT * FROM totals", nil, nil)
+	require.ErrorIs(t, err, ErrTableDoesNotExist)
+}
 
-		_, err = EncodeAsKey(int64(10), IntegerType, 4)
-		require.ErrorIs(t, err, ErrCorruptedData)
-	})
+type recordingLogger struct {
+	mutex  sync.Mutex
+	events []string
+}
 
-	t.Run("boolean cases", func(t *testing.T) {
-		_, err = EncodeAsKey("abc", BooleanType, 1)
-		require.ErrorIs(t, err, ErrInvalidValue)
+func (l *recordingLogger) record(level, msg string, fields ...logger.Field) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
 
-		_, err = EncodeAsKey(true, BooleanType, 2)
-		require.ErrorIs(t, err, ErrCorruptedData)
-	})
+	l.events = append(l.events, level+": "+logger.Format(msg, fields...))
+}
 
-	t.Run("blob cases", func(t *testing.T) {
-		_, err = EncodeAsKey("abc", BLOBType, 3)
-		require.ErrorIs(t, err, ErrInvalidValue)
+func (l *recordingLogger) Debug(msg string, fields ...logger.Field) {
+	l.record("DEBUG", msg, fields...)
+}
 
-		_, err = EncodeAsKey([]byte{1, 2, 3}, BLOBType, 2)
-		require.ErrorIs(t, err, ErrMaxLengthExceeded)
-	})
+func (l *recordingLogger) Info(msg string, fields ...logger.Field) {
+	l.record("INFO", msg, fields...)
+}
 
-	t.Run("timestamp cases", func(t *testing.T) {
-		_, err = EncodeAsKey(true, TimestampType, 8)
-		require.ErrorIs(t, err, ErrInvalidValue)
+func (l *recordingLogger) Warn(msg string, fields ...logger.Field) {
+	l.record("WARN", msg, fields...)
+}
 
-		_, err = EncodeAsKey(int64(10), TimestampType, 4)
-		require.ErrorIs(t, err, ErrCorruptedData)
-	})
+func (l *recordingLogger) Error(msg string, fields ...logger.Field) {
+	l.record("ERROR", msg, fields...)
 }
 
-func TestIndexingNullableColumns(t *testing.T) {
-	st, err := store.Open("catalog_indexing_nullable", store.DefaultOptions())
+func TestEngineLogger(t *testing.T) {
+	st, err := store.Open("sqldata_logger", store.DefaultOptions().WithLogger(logger.NewNopLogger()))
 	require.NoError(t, err)
-	defer st.Close()
-	defer os.RemoveAll("catalog_indexing_nullable")
+	defer os.RemoveAll("sqldata_logger")
 
-	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	log := &recordingLogger{}
+
+	engine, err := NewEngine(st, DefaultOptions().
+		WithPrefix(sqlPrefix).
+		WithLogger(log).
+		WithSortBufferSize(64).
+		WithSortDir(t.TempDir()))
 	require.NoError(t, err)
 
-	_, _, err = engine.Exec("CREATE DATABASE db1", nil, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
 	require.NoError(t, err)
 
 	err = engine.SetDefaultDatabase("db1")
 	require.NoError(t, err)
 
-	exec := func(t *testing.T, stmt string) *SQLTx {
-		ret, _, err := engine.Exec(stmt, nil, nil)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id);
+		INSERT INTO table1 (id, title) VALUES (1, 'title1'), (2, 'title2'), (3, 'title3');
+	`, nil, nil)
+	require.NoError(t, err)
+
+	r, err := engine.Query(context.Background(), "SELECT id FROM table1 WHERE title > 'title0' ORDER BY title DESC", nil, nil)
+	require.NoError(t, err)
+
+	for {
+		_, err = r.Read()
+		if errors.Is(err, ErrNoMoreRows) {
+			break
+		}
 		require.NoError(t, err)
-		return ret
 	}
-	query := func(t *testing.T, stmt string, expectedRows ...*Row) {
-		reader, err := engine.Query(stmt, nil, nil)
-		require.NoError(t, err)
 
-		for _, expectedRow := range expectedRows {
-			row, err := reader.Read()
-			require.NoError(t, err)
+	err = r.Close()
+	require.NoError(t, err)
 
-			require.EqualValues(t, expectedRow, row)
-		}
+	_, err = engine.UpgradeRowFormat(context.Background(), "db1", "table1", DefaultRowFormatUpgradeBatchSize)
+	require.NoError(t, err)
 
-		_, err = reader.Read()
-		require.ErrorIs(t, err, ErrNoMoreRows)
+	require.Contains(t, log.events, "DEBUG: Rows filtered by a full scan {database=db1, table=table1}")
+	require.Contains(t, log.events, "INFO: Row format successfully upgraded {database=db1, table=table1, rows=0}")
 
-		err = reader.Close()
-		require.NoError(t, err)
-	}
+	spilled := false
 
-	colVal := func(t *testing.T, v interface{}, tp SQLValueType) TypedValue {
-		switch v := v.(type) {
-		case nil:
-			return &NullValue{t: tp}
-		case int:
-			return &Number{val: int64(v)}
-		case string:
-			return &Varchar{val: v}
-		case []byte:
-			return &Blob{val: v}
-		case bool:
-			return &Bool{val: v}
+	for _, event := range log.events {
+		if strings.HasPrefix(event, "DEBUG: Sorted rows spilled into a temporary file") {
+			spilled = true
+			break
 		}
-		require.Fail(t, "Unknown type of value")
-		return nil
 	}
 
-	t1Row := func(id int64, v1, v2 interface{}) *Row {
-		return &Row{
-			Values: map[string]TypedValue{
-				EncodeSelector("", "db1", "table1", "id"): &Number{val: id},
-				EncodeSelector("", "db1", "table1", "v1"): colVal(t, v1, IntegerType),
-				EncodeSelector("", "db1", "table1", "v2"): colVal(t, v2, VarcharType),
-			},
-		}
-	}
+	require.True(t, spilled)
+}
 
-	t2Row := func(id int64, v1, v2, v3, v4 interface{}) *Row {
-		return &Row{
-			Values: map[string]TypedValue{
-				EncodeSelector("", "db1", "table2", "id"): &Number{val: id},
-				EncodeSelector("", "db1", "table2", "v1"): colVal(t, v1, IntegerType),
-				EncodeSelector("", "db1", "table2", "v2"): colVal(t, v2, VarcharType),
-				EncodeSelector("", "db1", "table2", "v3"): colVal(t, v3, BooleanType),
-				EncodeSelector("", "db1", "table2", "v4"): colVal(t, v4, BLOBType),
-			},
-		}
+// requireNoOpenTxs fails the test if any transaction was left open, rows readers not being closed
+// leave open the transaction created to read them
+func requireNoOpenTxs(t *testing.T, engine *Engine) {
+	t.Helper()
+
+	engine.openTxsMutex.Lock()
+	defer engine.openTxsMutex.Unlock()
+
+	require.Empty(t, engine.openTxs)
+}
+
+func TestEngineClose(t *testing.T) {
+	st, err := store.Open("sqldata_engine_close", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_engine_close")
+
+	newEngine := func(closeTimeout time.Duration) *Engine {
+		engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix).WithCloseTimeout(closeTimeout))
+		require.NoError(t, err)
+
+		err = engine.SetDefaultDatabase("db1")
+		require.NoError(t, err)
+
+		return engine
 	}
 
-	exec(t, `
-		CREATE TABLE table1 (
-			id INTEGER AUTO_INCREMENT,
-			v1 INTEGER,
-			v2 VARCHAR[16],
-			PRIMARY KEY(id)
-		)
-	`)
-	exec(t, "CREATE INDEX ON table1 (v1, v2)")
-	query(t, "SELECT * FROM table1 USE INDEX ON(v1,v2)")
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+	defer requireNoOpenTxs(t, engine)
 
-	t.Run("succeed adding non-null columns", func(t *testing.T) {
-		exec(t, "INSERT INTO table1(v1,v2) VALUES(1, '2')")
-		query(t,
-			"SELECT * FROM table1 USE INDEX ON(v1,v2)",
-			t1Row(1, 1, "2"),
-		)
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE DATABASE db1;
+		USE DATABASE db1;
+		CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id);
+		INSERT INTO table1 (title) VALUES ('title1'), ('title2');
+	`, nil, nil)
+	require.NoError(t, err)
 
-		exec(t, "INSERT INTO table1(v1,v2) VALUES(1, '3')")
-		query(t,
-			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
-			t1Row(1, 1, "2"),
-			t1Row(2, 1, "3"),
-		)
-	})
+	requireNoOpenTxs(t, engine)
 
-	t.Run("succeed adding null columns as the second indexed column", func(t *testing.T) {
+	t.Run("a closed engine should not be usable", func(t *testing.T) {
+		engine := newEngine(time.Second)
 
-		exec(t, "INSERT INTO table1(v1,v2) VALUES(1, null)")
-		query(t,
-			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
-			t1Row(3, 1, nil),
-			t1Row(1, 1, "2"),
-			t1Row(2, 1, "3"),
-		)
+		err := engine.Close()
+		require.NoError(t, err)
 
-		exec(t, "INSERT INTO table1(v1,v2) VALUES(1, null)")
-		query(t,
-			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
-			t1Row(3, 1, nil),
-			t1Row(4, 1, nil),
-			t1Row(1, 1, "2"),
-			t1Row(2, 1, "3"),
-		)
+		err = engine.Close()
+		require.ErrorIs(t, err, ErrAlreadyClosed)
 
-		exec(t, "INSERT INTO table1(v1,v2) VALUES(2, null)")
-		query(t,
-			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
-			t1Row(3, 1, nil),
-			t1Row(4, 1, nil),
-			t1Row(1, 1, "2"),
-			t1Row(2, 1, "3"),
-		)
-	})
+		_, err = engine.NewTx(context.Background(), true)
+		require.ErrorIs(t, err, ErrAlreadyClosed)
 
-	t.Run("succeed adding null columns as the first indexed column", func(t *testing.T) {
-		exec(t, "INSERT INTO table1(v1,v2) VALUES(null, '4')")
-		query(t,
-			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
-			t1Row(3, 1, nil),
-			t1Row(4, 1, nil),
-			t1Row(1, 1, "2"),
-			t1Row(2, 1, "3"),
-		)
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (title) VALUES ('title3')", nil, nil)
+		require.ErrorIs(t, err, ErrAlreadyClosed)
 
-		query(t,
-			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=1 ORDER BY v2",
-			t1Row(3, 1, nil),
-			t1Row(4, 1, nil),
-			t1Row(1, 1, "2"),
-			t1Row(2, 1, "3"),
-		)
-	})
+		_, err = engine.Query(context.Background(), "SELECT id FROM table1", nil, nil)
+		require.ErrorIs(t, err, ErrAlreadyClosed)
 
-	t.Run("succeed querying null columns using index", func(t *testing.T) {
-		query(t,
-			"SELECT * FROM table1 USE INDEX ON(v1,v2) WHERE v1=null",
-			t1Row(6, nil, "4"),
-		)
+		err = engine.SetDefaultDatabase("db1")
+		require.ErrorIs(t, err, ErrAlreadyClosed)
 	})
 
-	t.Run("suceed creating table with two indexes", func(t *testing.T) {
+	t.Run("close should wait for rows being read", func(t *testing.T) {
+		engine := newEngine(time.Minute)
 
-		exec(t, `
-			CREATE TABLE table2 (
-				id INTEGER AUTO_INCREMENT,
-				v1 INTEGER,
-				v2 VARCHAR[16],
-				v3 BOOLEAN,
-				v4 BLOB[15],
-				PRIMARY KEY(id)
-			)
-		`)
+		r, err := engine.Query(context.Background(), "SELECT id FROM table1", nil, nil)
+		require.NoError(t, err)
 
-		exec(t, "CREATE INDEX ON table2(v1, v2)")
-		exec(t, "CREATE UNIQUE INDEX ON table2(v3, v4)")
+		_, err = r.Read()
+		require.NoError(t, err)
 
-		query(t, "SELECT * FROM table2 USE INDEX ON(v3,v4)")
+		closed := make(chan error)
 
-	})
+		go func() {
+			closed <- engine.Close()
+		}()
 
-	t.Run("succeed inserting data on table with two indexes", func(t *testing.T) {
-		exec(t, "INSERT INTO table2(v1, v2, v3, v4) VALUES(null, null, null, null)")
-		query(t, "SELECT * FROM table2 USE INDEX ON(v1, v2)", t2Row(1, nil, nil, nil, nil))
-		query(t, "SELECT * FROM table2 USE INDEX ON(v3, v4)", t2Row(1, nil, nil, nil, nil))
-	})
+		select {
+		case <-closed:
+			require.Fail(t, "engine closed while rows were being read")
+		case <-time.After(100 * time.Millisecond):
+		}
 
-	t.Run("fail adding entries with duplicate with nulls", func(t *testing.T) {
-		_, _, err := engine.Exec("INSERT INTO table2(v1, v2, v3, v4) VALUES(1, '2', null, null)", nil, nil)
-		require.ErrorIs(t, err, store.ErrKeyAlreadyExists)
+		_, err = r.Read()
+		require.NoError(t, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+
+		require.NoError(t, <-closed)
+
+		requireNoOpenTxs(t, engine)
 	})
 
-	t.Run("succeed scanning multiple rows on table with two indexes", func(t *testing.T) {
-		exec(t, `
-			INSERT INTO table2(v1,v2,v3,v4) VALUES
-			(1,'2',true, null),
-			(3,'4',null, x'1234'),
-			(5,'6',false, x'5678')
-		`)
+	t.Run("transactions still open after the timeout should be cancelled", func(t *testing.T) {
+		engine := newEngine(10 * time.Millisecond)
 
-		// Order for boolean must be null -> false -> true
-		query(t, "SELECT * FROM table2 USE INDEX ON(v3, v4)",
-			t2Row(1, nil, nil, nil, nil),
-			t2Row(3, 3, "4", nil, []byte{0x12, 0x34}),
-			t2Row(4, 5, "6", false, []byte{0x56, 0x78}),
-			t2Row(2, 1, "2", true, nil),
-		)
+		tx, err := engine.NewTx(context.Background(), true)
+		require.NoError(t, err)
+
+		err = engine.Close()
+		require.ErrorIs(t, err, ErrCloseTimeout)
+		require.True(t, tx.Closed())
+
+		requireNoOpenTxs(t, engine)
+
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (title) VALUES ('title3')", nil, tx)
+		require.ErrorIs(t, err, ErrAlreadyClosed)
 	})
+
+	err = engine.Close()
+	require.NoError(t, err)
+
+	err = st.Close()
+	require.NoError(t, err)
 }
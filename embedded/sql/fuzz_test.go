@@ -0,0 +1,141 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"testing"
+)
+
+var fuzzedTypes = []SQLValueType{
+	IntegerType,
+	BooleanType,
+	VarcharType,
+	BLOBType,
+	TimestampType,
+	FloatType,
+	DecimalType,
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"CREATE DATABASE db1",
+		"CREATE TABLE IF NOT EXISTS table1 (id INTEGER AUTO_INCREMENT, title VARCHAR[50] NOT NULL, amount DECIMAL, PRIMARY KEY id)",
+		"CREATE UNIQUE INDEX ON table1(title, amount)",
+		"ALTER TABLE table1 ADD COLUMN active BOOLEAN",
+		"BEGIN TRANSACTION; UPSERT INTO table1 (id, title) VALUES (1, 'title1'), (@id, $1); COMMIT;",
+		"SELECT DISTINCT t.id, COUNT(*) AS c FROM table1 AS t INNER JOIN table2 ON t.id = table2.id WHERE t.title LIKE 't%' GROUP BY t.id HAVING COUNT(*) > 1 ORDER BY t.id DESC LIMIT 10 OFFSET 2",
+		"SELECT id FROM (SELECT id FROM table1 SINCE TX 10) WHERE id IN (1, 2, 3) AND NOT active OR amount >= 1.5",
+		"SELECT * FROM table1 BEFORE NOW() USE INDEX ON (title)",
+		"DELETE FROM table1 WHERE CAST(title AS INTEGER) IS NULL LIMIT 1",
+		"UPDATE table1 SET title = 'title' WHERE id = 1",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, sql string) {
+		ParseString(sql)
+	})
+}
+
+func FuzzDecodeValue(f *testing.F) {
+	for _, seed := range [][]byte{
+		{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1},
+		{0, 0, 0, 1, 1},
+		{0, 0, 0, 5, 't', 'i', 't', 'l', 'e'},
+		{},
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		for _, colType := range fuzzedTypes {
+			val, n, err := DecodeValue(b, colType)
+			if err != nil {
+				continue
+			}
+
+			if n > len(b) {
+				t.Fatalf("decoded %d bytes out of %d", n, len(b))
+			}
+
+			encVal, err := EncodeValue(val.Value(), colType, 0)
+			if err != nil {
+				t.Fatalf("decoded %s value can not be encoded: %v", colType, err)
+			}
+
+			_, _, err = DecodeValue(encVal, colType)
+			if err != nil {
+				t.Fatalf("encoded %s value can not be decoded: %v", colType, err)
+			}
+		}
+	})
+}
+
+func FuzzDecodeValueAsKey(f *testing.F) {
+	for _, colType := range fuzzedTypes {
+		var val interface{}
+
+		switch colType {
+		case VarcharType:
+			val = "title"
+		case BLOBType:
+			val = []byte{1, 2, 3}
+		default:
+			continue
+		}
+
+		encVal, err := EncodeAsKey(val, colType, 16)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(encVal, 16)
+	}
+
+	f.Add([]byte{KeyValPrefixNull}, 0)
+
+	f.Fuzz(func(t *testing.T, b []byte, maxLen int) {
+		if maxLen < 0 || maxLen > 1<<16 {
+			return
+		}
+
+		for _, colType := range fuzzedTypes {
+			_, n, err := DecodeValueAsKey(b, colType, maxLen)
+			if err == nil && n > len(b) {
+				t.Fatalf("decoded %d bytes out of %d", n, len(b))
+			}
+		}
+	})
+}
+
+func FuzzDecodeRow(f *testing.F) {
+	colTypes := make(map[uint32]SQLValueType, len(fuzzedTypes))
+
+	for i, colType := range fuzzedTypes {
+		colTypes[uint32(i+1)] = colType
+	}
+
+	f.Add([]byte{0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1})
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		DecodeRow(b, colTypes)
+	})
+}
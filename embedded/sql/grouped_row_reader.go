@@ -15,7 +15,11 @@ limitations under the License.
 */
 package sql
 
-import "github.com/codenotary/immudb/embedded/store"
+import (
+	"math/big"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
 
 type groupedRowReader struct {
 	rowReader RowReader
@@ -122,7 +126,8 @@ func (gr *groupedRowReader) colsBySelector() (map[string]ColDescriptor, error) {
 		if aggFn == MAX || aggFn == MIN {
 			colDescriptors[encSel] = colDesc
 		} else {
-			// SUM, AVG
+			// SUM, AVG are of the type of the column
+			des.Type = colDesc.Type
 			colDescriptors[encSel] = des
 		}
 	}
@@ -158,6 +163,14 @@ func zeroForType(t SQLValueType) TypedValue {
 		{
 			return &Blob{}
 		}
+	case FloatType:
+		{
+			return &Float{}
+		}
+	case DecimalType:
+		{
+			return &Decimal{val: new(big.Rat)}
+		}
 		/*case TimestampType:
 		{
 			return &Number{}
@@ -191,14 +204,7 @@ func (gr *groupedRowReader) Read() (*Row, error) {
 					aggFn, db, table, col := sel.resolve(gr.rowReader.Database().Name(), gr.rowReader.TableAlias())
 					encSel := EncodeSelector(aggFn, db, table, col)
 
-					var zero TypedValue
-					if aggFn == COUNT || aggFn == SUM || aggFn == AVG {
-						zero = zeroForType(IntegerType)
-					} else {
-						zero = zeroForType(colsBySelector[encSel].Type)
-					}
-
-					zeroRow.Values[encSel] = zero
+					zeroRow.Values[encSel] = zeroForType(colsBySelector[encSel].Type)
 				}
 
 				gr.nonEmpty = true
@@ -276,6 +282,11 @@ func (gr *groupedRowReader) Read() (*Row, error) {
 }
 
 func (gr *groupedRowReader) initAggregations() error {
+	colsBySelector, err := gr.colsBySelector()
+	if err != nil {
+		return err
+	}
+
 	// augment row with aggregated values
 	for _, sel := range gr.selectors {
 		aggFn, db, table, col := sel.resolve(gr.rowReader.Database().Name(), gr.rowReader.TableAlias())
@@ -293,7 +304,7 @@ func (gr *groupedRowReader) initAggregations() error {
 			}
 		case SUM:
 			{
-				gr.currRow.Values[encSel] = &SumValue{sel: EncodeSelector("", db, table, col)}
+				gr.currRow.Values[encSel] = &SumValue{t: colsBySelector[encSel].Type, sel: EncodeSelector("", db, table, col)}
 			}
 		case MIN:
 			{
@@ -305,7 +316,7 @@ func (gr *groupedRowReader) initAggregations() error {
 			}
 		case AVG:
 			{
-				gr.currRow.Values[encSel] = &AVGValue{sel: EncodeSelector("", db, table, col)}
+				gr.currRow.Values[encSel] = &AVGValue{t: colsBySelector[encSel].Type, sel: EncodeSelector("", db, table, col)}
 			}
 		}
 	}
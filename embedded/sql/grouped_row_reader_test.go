@@ -16,6 +16,7 @@ limitations under the License.
 package sql
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -34,7 +35,7 @@ func TestGroupedRowReader(t *testing.T) {
 	_, err = newGroupedRowReader(nil, nil, nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	tx, err := engine.newTx(false)
+	tx, err := engine.newTx(context.Background(), false)
 	require.NoError(t, err)
 
 	db, err := tx.catalog.newDatabase(1, "db1")
@@ -0,0 +1,595 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"bufio"
+	"errors"
+	"hash/fnv"
+	"io"
+	"os"
+
+	"github.com/codenotary/immudb/embedded/logger"
+	"github.com/codenotary/immudb/embedded/multierr"
+)
+
+// number of partitions rows of the joined data source are hashed into, partitions are spilled one by one
+const hashJoinPartitions = 16
+
+// hashJoin resolves the rows of a joined data source by looking up a hash table keyed by the columns
+// the rows are joined by, instead of querying the data source for every row they're joined to.
+// The data source is read once, rows are kept in memory while they fit into the join buffer,
+// otherwise the largest partitions are written into temporary files and loaded back one at a time while probing
+type hashJoin struct {
+	jspec *JoinSpec
+
+	innerSels []string // encoded selectors of the joined columns
+	outerSels []string // encoded selectors of the columns the joined columns are equal to
+
+	bufferSize int
+	dir        string
+
+	log logger.Logger
+
+	// set once the data source is read
+	built      bool
+	db         *Database
+	alias      string
+	cols       []ColDescriptor
+	colsBySel  map[string]ColDescriptor
+	partitions []*hashPartition
+	bufferUsed int // approximated size of the rows kept in memory
+
+	loaded *hashPartition // spilled partition currently loaded in memory
+}
+
+type hashPartition struct {
+	rows map[string][]*Row
+	size int // approximated size of the rows of the partition
+
+	// set once the partition is spilled
+	f *os.File
+	w *bufio.Writer
+}
+
+func newHashJoin(tx *SQLTx, jspec *JoinSpec, innerSels, outerSels []string) *hashJoin {
+	hj := &hashJoin{
+		jspec:      jspec,
+		innerSels:  innerSels,
+		outerSels:  outerSels,
+		bufferSize: defaultJoinBufferSize,
+		log:        logger.NewNopLogger(),
+	}
+
+	if tx != nil {
+		hj.bufferSize = tx.joinBufferSize()
+		hj.dir = tx.sortDir()
+		hj.log = tx.log()
+	}
+
+	return hj
+}
+
+// planHashJoin returns the hash join resolving the rows of the join, nil is returned when the rows are better
+// resolved by index lookups: the join is not an equi-join, an index is explicitly requested,
+// or the joined columns lead an index of the joined table.
+// outerCols are the columns of the rows being joined and innerCols the ones of the joined data source
+func planHashJoin(tx *SQLTx, jspec *JoinSpec, outerCols, innerCols map[string]ColDescriptor, implicitDB, implicitTable string) (*hashJoin, error) {
+	if jspec.joinType == CrossJoin || len(jspec.indexOn) > 0 {
+		return nil, nil
+	}
+
+	var innerSels, outerSels []string
+	var innerColNames []string
+
+	for _, eq := range equiJoinConds(jspec.cond) {
+		lsel := EncodeSelector(eq[0].resolve(implicitDB, implicitTable))
+		rsel := EncodeSelector(eq[1].resolve(implicitDB, implicitTable))
+
+		_, lInner := innerCols[lsel]
+		_, rInner := innerCols[rsel]
+
+		if rInner && !lInner {
+			lsel, rsel = rsel, lsel
+		} else if !lInner || rInner {
+			continue
+		}
+
+		outerCol, ok := outerCols[rsel]
+		if !ok {
+			continue
+		}
+
+		innerCol := innerCols[lsel]
+
+		if innerCol.Type != outerCol.Type || !hashableType(innerCol.Type) {
+			continue
+		}
+
+		innerSels = append(innerSels, lsel)
+		outerSels = append(outerSels, rsel)
+		innerColNames = append(innerColNames, innerCol.Column)
+	}
+
+	if len(innerSels) == 0 {
+		return nil, nil
+	}
+
+	tableRef, isTableRef := jspec.ds.(*tableRef)
+	if isTableRef {
+		table, err := tableRef.referencedTable(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, colName := range innerColNames {
+			col, err := table.GetColumnByName(colName)
+			if err != nil {
+				return nil, err
+			}
+
+			if leadsIndex(table, col.id) {
+				return nil, nil
+			}
+		}
+	}
+
+	return newHashJoin(tx, jspec, innerSels, outerSels), nil
+}
+
+// equiJoinConds returns the pairs of columns compared for equality by the condition, or by any of its conjuncts
+func equiJoinConds(cond ValueExp) [][2]*ColSelector {
+	switch exp := cond.(type) {
+	case *BinBoolExp:
+		if exp.op != AND {
+			return nil
+		}
+
+		return append(equiJoinConds(exp.left), equiJoinConds(exp.right)...)
+	case *CmpBoolExp:
+		if exp.op != EQ {
+			return nil
+		}
+
+		lsel, lIsSel := exp.left.(*ColSelector)
+		rsel, rIsSel := exp.right.(*ColSelector)
+
+		if lIsSel && rIsSel {
+			return [][2]*ColSelector{{lsel, rsel}}
+		}
+	}
+
+	return nil
+}
+
+// hashableType returns true when equal values of the type are equally encoded
+func hashableType(t SQLValueType) bool {
+	switch t {
+	case IntegerType, BooleanType, VarcharType, BLOBType, TimestampType:
+		return true
+	}
+
+	return false
+}
+
+// hashJoinKey returns the encoded values of the columns, false is returned when any of them is NULL as it's never equal to a value
+func hashJoinKey(row *Row, sels []string) (string, bool, error) {
+	var b []byte
+
+	for _, sel := range sels {
+		val, ok := row.Values[sel]
+		if !ok {
+			return "", false, ErrInvalidColumn
+		}
+
+		if val.IsNull() {
+			return "", false, nil
+		}
+
+		encVal, err := EncodeValue(val.Value(), val.Type(), 0)
+		if err != nil {
+			return "", false, err
+		}
+
+		b = append(b, encVal...)
+	}
+
+	return string(b), true, nil
+}
+
+func hashJoinPartition(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+
+	return int(h.Sum32() % hashJoinPartitions)
+}
+
+// build reads all the rows of the joined data source into the hash table
+func (hj *hashJoin) build(tx *SQLTx, params map[string]interface{}) error {
+	jointq := &SelectStmt{
+		ds:      hj.jspec.ds,
+		indexOn: hj.jspec.indexOn,
+	}
+
+	reader, err := jointq.Resolve(tx, params, nil)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	hj.db = reader.Database()
+	hj.alias = reader.TableAlias()
+
+	hj.cols, err = reader.Columns()
+	if err != nil {
+		return err
+	}
+
+	hj.colsBySel, err = reader.colsBySelector()
+	if err != nil {
+		return err
+	}
+
+	err = hj.trackFullScan(tx)
+	if err != nil {
+		return err
+	}
+
+	hj.partitions = make([]*hashPartition, hashJoinPartitions)
+
+	for i := range hj.partitions {
+		hj.partitions[i] = &hashPartition{rows: make(map[string][]*Row)}
+	}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, ErrNoMoreRows) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		key, ok, err := hashJoinKey(row, hj.innerSels)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		p := hj.partitions[hashJoinPartition(key)]
+
+		if p.f != nil {
+			err = writeSortedRow(p.w, row)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		size := rowSize(row)
+
+		p.rows[key] = append(p.rows[key], row)
+		p.size += size
+		hj.bufferUsed += size
+
+		if hj.bufferUsed >= hj.bufferSize {
+			err = hj.spill()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, p := range hj.partitions {
+		if p.f == nil {
+			continue
+		}
+
+		err = p.w.Flush()
+		if err != nil {
+			return err
+		}
+
+		p.w = nil
+	}
+
+	hj.built = true
+
+	return nil
+}
+
+// trackFullScan records the joined columns as the ones constraining a full scan of the joined table,
+// as an index on them would let rows be resolved by index lookups
+func (hj *hashJoin) trackFullScan(tx *SQLTx) error {
+	tableRef, isTableRef := hj.jspec.ds.(*tableRef)
+	if !isTableRef {
+		return nil
+	}
+
+	table, err := tableRef.referencedTable(tx)
+	if err != nil {
+		return err
+	}
+
+	rangesByColID := make(map[uint32]*typedValueRange, len(hj.innerSels))
+
+	for _, sel := range hj.innerSels {
+		col, err := table.GetColumnByName(hj.colsBySel[sel].Column)
+		if err != nil {
+			return err
+		}
+
+		rangesByColID[col.id] = nil
+	}
+
+	tx.engine.trackFullScan(table, table.primaryIndex, rangesByColID)
+
+	return nil
+}
+
+// spill writes the rows of the largest partition kept in memory into a new temporary file
+func (hj *hashJoin) spill() error {
+	var p *hashPartition
+
+	for _, candidate := range hj.partitions {
+		if candidate.f == nil && (p == nil || candidate.size > p.size) {
+			p = candidate
+		}
+	}
+
+	if p == nil {
+		return nil
+	}
+
+	f, err := os.CreateTemp(hj.dir, "immudb_join_")
+	if err != nil {
+		return err
+	}
+
+	p.f = f
+	p.w = bufio.NewWriter(f)
+
+	rows := 0
+
+	for _, keyRows := range p.rows {
+		for _, row := range keyRows {
+			err = writeSortedRow(p.w, row)
+			if err != nil {
+				return err
+			}
+		}
+
+		rows += len(keyRows)
+	}
+
+	hj.log.Debug("Joined rows spilled into a temporary file", logger.F("file", f.Name()), logger.F("rows", rows))
+
+	hj.bufferUsed -= p.size
+	p.rows = nil
+	p.size = 0
+
+	return nil
+}
+
+// lookup returns the rows of the joined data source with the same key as the row
+func (hj *hashJoin) lookup(row *Row) ([]*Row, error) {
+	key, ok, err := hashJoinKey(row, hj.outerSels)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	p := hj.partitions[hashJoinPartition(key)]
+
+	if p.f != nil && hj.loaded != p {
+		err = hj.load(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p.rows[key], nil
+}
+
+// load reads the rows of the spilled partition back into memory, releasing the previously loaded one.
+// Rows are written in the order they were read, thus rows with the same key keep that order
+func (hj *hashJoin) load(p *hashPartition) error {
+	if hj.loaded != nil {
+		hj.loaded.rows = nil
+		hj.loaded = nil
+	}
+
+	_, err := p.f.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(p.f)
+
+	rows := make(map[string][]*Row)
+
+	for {
+		row, err := readSortedRow(r)
+		if errors.Is(err, ErrNoMoreRows) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		key, _, err := hashJoinKey(row, hj.innerSels)
+		if err != nil {
+			return err
+		}
+
+		rows[key] = append(rows[key], row)
+	}
+
+	p.rows = rows
+	hj.loaded = p
+
+	return nil
+}
+
+// reset releases the hash table, so it's built again with the rows read with new parameters
+func (hj *hashJoin) reset() error {
+	err := hj.close()
+
+	hj.built = false
+	hj.partitions = nil
+	hj.bufferUsed = 0
+	hj.loaded = nil
+
+	return err
+}
+
+func (hj *hashJoin) close() error {
+	merr := multierr.NewMultiErr()
+
+	for _, p := range hj.partitions {
+		if p.f == nil {
+			continue
+		}
+
+		merr.Append(p.f.Close())
+		merr.Append(os.Remove(p.f.Name()))
+
+		p.f = nil
+	}
+
+	return merr.Reduce()
+}
+
+// hashJoinRowReader returns the rows of the joined data source found in the hash table
+// for the row they're joined to, and satisfying the join condition
+type hashJoinRowReader struct {
+	hj *hashJoin
+
+	tx   *SQLTx
+	cond ValueExp // join condition with the values of the row being joined substituted
+
+	rows []*Row
+	read int
+
+	onCloseCallback func()
+}
+
+func (hj *hashJoin) newRowReader(tx *SQLTx, row *Row, cond ValueExp, params map[string]interface{}) (*hashJoinRowReader, error) {
+	if !hj.built {
+		err := hj.build(tx, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := hj.lookup(row)
+	if err != nil {
+		return nil, err
+	}
+
+	cond, err = cond.substitute(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hashJoinRowReader{
+		hj:   hj,
+		tx:   tx,
+		cond: cond,
+		rows: rows,
+	}, nil
+}
+
+func (hr *hashJoinRowReader) onClose(callback func()) {
+	hr.onCloseCallback = callback
+}
+
+func (hr *hashJoinRowReader) Tx() *SQLTx {
+	return hr.tx
+}
+
+func (hr *hashJoinRowReader) Database() *Database {
+	return hr.hj.db
+}
+
+func (hr *hashJoinRowReader) TableAlias() string {
+	return hr.hj.alias
+}
+
+func (hr *hashJoinRowReader) SetParameters(params map[string]interface{}) error {
+	return nil
+}
+
+// OrderBy returns no ordering, even though rows with the same key are kept in the order they were read
+func (hr *hashJoinRowReader) OrderBy() []ColDescriptor {
+	return nil
+}
+
+func (hr *hashJoinRowReader) ScanSpecs() *ScanSpecs {
+	return nil
+}
+
+func (hr *hashJoinRowReader) Columns() ([]ColDescriptor, error) {
+	return hr.hj.cols, nil
+}
+
+func (hr *hashJoinRowReader) colsBySelector() (map[string]ColDescriptor, error) {
+	return hr.hj.colsBySel, nil
+}
+
+func (hr *hashJoinRowReader) InferParameters(params map[string]SQLValueType) error {
+	return nil
+}
+
+func (hr *hashJoinRowReader) Read() (*Row, error) {
+	for hr.read < len(hr.rows) {
+		row := hr.rows[hr.read]
+		hr.read++
+
+		r, err := hr.cond.reduce(hr.tx, row, hr.hj.db.Name(), hr.hj.alias)
+		if err != nil {
+			return nil, err
+		}
+
+		nval, isNull := r.(*NullValue)
+		if isNull && nval.Type() == BooleanType {
+			continue
+		}
+
+		satisfies, boolExp := r.(*Bool)
+		if !boolExp {
+			return nil, ErrInvalidCondition
+		}
+
+		if satisfies.val {
+			return row, nil
+		}
+	}
+
+	return nil, ErrNoMoreRows
+}
+
+// Close doesn't release the hash table, as it's shared by the readers of all the rows being joined
+func (hr *hashJoinRowReader) Close() error {
+	if hr.onCloseCallback != nil {
+		hr.onCloseCallback()
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/logger"
+)
+
+// IndexSuggestion is an index which would have avoided full scans on already executed queries
+type IndexSuggestion struct {
+	Database  string
+	Table     string
+	Column    string
+	FullScans uint64
+}
+
+func (s *IndexSuggestion) Stmt() string {
+	return fmt.Sprintf("CREATE INDEX ON %s(%s)", s.Table, s.Column)
+}
+
+type fullScanKey struct {
+	dbID    uint32
+	tableID uint32
+	colID   uint32
+}
+
+// trackFullScan records the columns constrained by the query when the selected index
+// can not narrow the scan i.e. all the rows of the table need to be read
+func (e *Engine) trackFullScan(table *Table, index *Index, rangesByColID map[uint32]*typedValueRange) {
+	if len(rangesByColID) == 0 {
+		return
+	}
+
+	_, ranged := rangesByColID[index.cols[0].id]
+	if ranged {
+		return
+	}
+
+	e.log.Debug("Rows filtered by a full scan", logger.F("database", table.db.name), logger.F("table", table.name))
+
+	e.fullScansMutex.Lock()
+	defer e.fullScansMutex.Unlock()
+
+	for colID := range rangesByColID {
+		if leadsIndex(table, colID) {
+			continue
+		}
+
+		col, err := table.GetColumnByID(colID)
+		if err != nil || (variableSized(col.colType) && (col.MaxLen() == 0 || col.MaxLen() > maxKeyLen)) {
+			// column can not be indexed
+			continue
+		}
+
+		e.fullScans[fullScanKey{dbID: table.db.id, tableID: table.id, colID: colID}]++
+	}
+}
+
+func leadsIndex(table *Table, colID uint32) bool {
+	for _, idx := range table.indexesByColID[colID] {
+		if idx.cols[0].id == colID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IndexSuggestions returns the indexes which would have avoided the full scans performed so far,
+// ranked by the number of full scans. Columns which are no longer available or got indexed are not suggested.
+func (e *Engine) IndexSuggestions(ctx context.Context) ([]*IndexSuggestion, error) {
+	tx, err := e.newTx(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Cancel()
+
+	e.fullScansMutex.Lock()
+	defer e.fullScansMutex.Unlock()
+
+	var suggestions []*IndexSuggestion
+
+	for k, fullScans := range e.fullScans {
+		db, err := tx.catalog.GetDatabaseByID(k.dbID)
+		if err != nil {
+			continue
+		}
+
+		table, err := db.GetTableByID(k.tableID)
+		if err != nil {
+			continue
+		}
+
+		col, err := table.GetColumnByID(k.colID)
+		if err != nil || leadsIndex(table, k.colID) {
+			continue
+		}
+
+		suggestions = append(suggestions, &IndexSuggestion{
+			Database:  db.name,
+			Table:     table.name,
+			Column:    col.colName,
+			FullScans: fullScans,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		si, sj := suggestions[i], suggestions[j]
+
+		if si.FullScans != sj.FullScans {
+			return si.FullScans > sj.FullScans
+		}
+		if si.Database != sj.Database {
+			return si.Database < sj.Database
+		}
+		if si.Table != sj.Table {
+			return si.Table < sj.Table
+		}
+		return si.Column < sj.Column
+	})
+
+	return suggestions, nil
+}
+
+// ResetIndexSuggestions discards the collected query statistics
+func (e *Engine) ResetIndexSuggestions() {
+	e.fullScansMutex.Lock()
+	defer e.fullScansMutex.Unlock()
+
+	e.fullScans = make(map[fullScanKey]uint64)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexSuggestions(t *testing.T) {
+	st, err := store.Open("sqldata_index_advisor", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_index_advisor")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (id INTEGER, amount INTEGER, title VARCHAR[64], active BOOLEAN, note VARCHAR, PRIMARY KEY id);
+		CREATE INDEX ON table1(active);
+		CREATE TABLE table2 (id INTEGER, table1_amount INTEGER, PRIMARY KEY id);
+		INSERT INTO table1 (id, amount, title, active, note) VALUES (1, 10, 'title1', true, 'note1'), (2, 20, 'title2', false, 'note2');
+		INSERT INTO table2 (id, table1_amount) VALUES (1, 10), (2, 20);
+	`, nil, nil)
+	require.NoError(t, err)
+
+	suggestions, err := engine.IndexSuggestions(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, suggestions)
+
+	queryAll := func(sql string) {
+		r, err := engine.Query(context.Background(), sql, nil, nil)
+		require.NoError(t, err)
+
+		for {
+			_, err = r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+		}
+
+		err = r.Close()
+		require.NoError(t, err)
+	}
+
+	queryAll("SELECT id FROM table1 WHERE id = 1")
+	queryAll("SELECT id FROM table1 WHERE active = true")
+	queryAll("SELECT id FROM table1 WHERE note = 'note1'")
+
+	suggestions, err = engine.IndexSuggestions(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, suggestions)
+
+	queryAll("SELECT id FROM table1 WHERE title = 'title1'")
+	queryAll("SELECT id FROM table1 WHERE title = 'title2'")
+	queryAll("SELECT id FROM table1 WHERE amount > 10 AND active = false")
+	queryAll("SELECT table2.id FROM table2 INNER JOIN table1 ON table1.amount = table2.table1_amount")
+
+	suggestions, err = engine.IndexSuggestions(context.Background())
+	require.NoError(t, err)
+	require.Len(t, suggestions, 2)
+
+	require.Equal(t, "db1", suggestions[0].Database)
+	require.Equal(t, "table1", suggestions[0].Table)
+	require.Equal(t, "amount", suggestions[0].Column)
+	require.Equal(t, uint64(2), suggestions[0].FullScans)
+	require.Equal(t, "CREATE INDEX ON table1(amount)", suggestions[0].Stmt())
+
+	require.Equal(t, "title", suggestions[1].Column)
+	require.Equal(t, uint64(2), suggestions[1].FullScans)
+
+	_, _, err = engine.Exec(context.Background(), "ALTER TABLE table1 DROP COLUMN title", nil, nil)
+	require.NoError(t, err)
+
+	suggestions, err = engine.IndexSuggestions(context.Background())
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	require.Equal(t, "amount", suggestions[0].Column)
+
+	engine.ResetIndexSuggestions()
+
+	suggestions, err = engine.IndexSuggestions(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, suggestions)
+}
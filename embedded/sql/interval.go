@@ -0,0 +1,191 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval is a span of time to be added to or subtracted from timestamps e.g. NOW() - INTERVAL '1 day'.
+// Months and days are kept apart from the rest as their length depends on the timestamp they're applied to
+type Interval struct {
+	months int64
+	days   int64
+	dur    time.Duration
+}
+
+var intervalUnits = map[string]func(i *Interval, n int64){
+	"MICROSECOND": func(i *Interval, n int64) { i.dur += time.Duration(n) * time.Microsecond },
+	"MILLISECOND": func(i *Interval, n int64) { i.dur += time.Duration(n) * time.Millisecond },
+	"SECOND":      func(i *Interval, n int64) { i.dur += time.Duration(n) * time.Second },
+	"MINUTE":      func(i *Interval, n int64) { i.dur += time.Duration(n) * time.Minute },
+	"HOUR":        func(i *Interval, n int64) { i.dur += time.Duration(n) * time.Hour },
+	"DAY":         func(i *Interval, n int64) { i.days += n },
+	"WEEK":        func(i *Interval, n int64) { i.days += 7 * n },
+	"MONTH":       func(i *Interval, n int64) { i.months += n },
+	"YEAR":        func(i *Interval, n int64) { i.months += 12 * n },
+}
+
+// parseInterval parses a sequence of quantities and units e.g. '1 day', '2 hours 30 minutes' or '-1 week'
+func parseInterval(s string) (*Interval, error) {
+	fields := strings.Fields(s)
+
+	if len(fields) == 0 || len(fields)%2 != 0 {
+		return nil, fmt.Errorf("%w: invalid interval '%s'", ErrIllegalArguments, s)
+	}
+
+	i := &Interval{}
+
+	for f := 0; f < len(fields); f += 2 {
+		n, err := strconv.ParseInt(fields[f], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid interval '%s'", ErrIllegalArguments, s)
+		}
+
+		unit := strings.TrimSuffix(strings.ToUpper(fields[f+1]), "S")
+
+		add, ok := intervalUnits[unit]
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid interval unit '%s'", ErrIllegalArguments, fields[f+1])
+		}
+
+		add(i, n)
+	}
+
+	return i, nil
+}
+
+func (v *Interval) addTo(t time.Time) time.Time {
+	return t.AddDate(0, int(v.months), int(v.days)).Add(v.dur)
+}
+
+func (v *Interval) negate() *Interval {
+	return &Interval{months: -v.months, days: -v.days, dur: -v.dur}
+}
+
+func (v *Interval) String() string {
+	var parts []string
+
+	if v.months != 0 {
+		parts = append(parts, fmt.Sprintf("%d months", v.months))
+	}
+	if v.days != 0 {
+		parts = append(parts, fmt.Sprintf("%d days", v.days))
+	}
+	if v.dur != 0 || len(parts) == 0 {
+		parts = append(parts, v.dur.String())
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (v *Interval) Type() SQLValueType {
+	return IntervalType
+}
+
+func (v *Interval) IsNull() bool {
+	return false
+}
+
+func (v *Interval) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
+	return IntervalType, nil
+}
+
+func (v *Interval) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
+	if t != IntervalType {
+		return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, IntervalType, t)
+	}
+
+	return nil
+}
+
+func (v *Interval) substitute(params map[string]interface{}) (ValueExp, error) {
+	return v, nil
+}
+
+func (v *Interval) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	return v, nil
+}
+
+func (v *Interval) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
+	return v
+}
+
+func (v *Interval) isConstant() bool {
+	return true
+}
+
+func (v *Interval) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
+	return nil
+}
+
+func (v *Interval) Value() interface{} {
+	return v.String()
+}
+
+// Compare only tells whether intervals are equal, as their ordering may depend on the calendar e.g. 1 month and 30 days
+func (v *Interval) Compare(val TypedValue) (int, error) {
+	if val.IsNull() {
+		return 1, nil
+	}
+
+	rval, ok := val.(*Interval)
+	if !ok || *v != *rval {
+		return 0, ErrNotComparableValues
+	}
+
+	return 0, nil
+}
+
+// timestampArithType returns the type of arithmetic operations involving timestamps, only intervals
+// can be added to or subtracted from them
+func timestampArithType(op NumOperator, tleft, tright SQLValueType) (SQLValueType, error) {
+	if tleft == TimestampType && tright == IntervalType && (op == ADDOP || op == SUBSOP) {
+		return TimestampType, nil
+	}
+
+	if tleft == IntervalType && tright == TimestampType && op == ADDOP {
+		return TimestampType, nil
+	}
+
+	return AnyType, fmt.Errorf("%w: unsupported operation between %v and %v", ErrInvalidTypes, tleft, tright)
+}
+
+func isTimestampArith(tleft, tright SQLValueType) bool {
+	return tleft == TimestampType || tright == TimestampType || tleft == IntervalType || tright == IntervalType
+}
+
+func (bexp *NumExp) reduceTimestamp(vl, vr TypedValue) (TypedValue, error) {
+	_, err := timestampArithType(bexp.op, vl.Type(), vr.Type())
+	if err != nil {
+		return nil, fmt.Errorf("%w (expecting a timestamp and an interval)", ErrInvalidValue)
+	}
+
+	if vl.Type() == IntervalType {
+		vl, vr = vr, vl
+	}
+
+	interval := vr.(*Interval)
+	if bexp.op == SUBSOP {
+		interval = interval.negate()
+	}
+
+	return &Timestamp{val: interval.addTo(vl.Value().(time.Time))}, nil
+}
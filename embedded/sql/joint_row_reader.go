@@ -17,6 +17,7 @@ limitations under the License.
 package sql
 
 import (
+	"crypto/sha256"
 	"fmt"
 
 	"github.com/codenotary/immudb/embedded/multierr"
@@ -27,10 +28,25 @@ type jointRowReader struct {
 
 	joins []*JoinSpec
 
+	// readers by level, the first level is the one of rowReader and each join adds a level
 	rowReaders       []RowReader
 	rowReadersValues []map[string]TypedValue
+	nullExtended     []bool // the reader at the level returned no row and its columns were set to NULL
 
 	params map[string]interface{}
+
+	// rows returned by the data sources of RIGHT and FULL joins, by join
+	matchedRows []map[[sha256.Size]byte]struct{}
+
+	// once all the rows are joined, the rows not matched by RIGHT and FULL joins are read
+	// starting at the level of each of these joins, columns of lower levels are set to NULL
+	baseLevel     int
+	unmatchedJoin int
+
+	// joins resolved by looking up a hash table instead of querying the joined data source for every row,
+	// strategies are picked once rows are first read
+	hashJoins []*hashJoin
+	planned   bool
 }
 
 func newJointRowReader(rowReader RowReader, joins []*JoinSpec, params map[string]interface{}) (*jointRowReader, error) {
@@ -38,8 +54,14 @@ func newJointRowReader(rowReader RowReader, joins []*JoinSpec, params map[string
 		return nil, ErrIllegalArguments
 	}
 
-	for _, jspec := range joins {
-		if jspec.joinType != InnerJoin {
+	matchedRows := make([]map[[sha256.Size]byte]struct{}, len(joins))
+
+	for i, jspec := range joins {
+		switch jspec.joinType {
+		case InnerJoin, LeftJoin, CrossJoin:
+		case RightJoin, FullJoin:
+			matchedRows[i] = make(map[[sha256.Size]byte]struct{})
+		default:
 			return nil, ErrUnsupportedJoinType
 		}
 	}
@@ -50,9 +72,24 @@ func newJointRowReader(rowReader RowReader, joins []*JoinSpec, params map[string
 		joins:            joins,
 		rowReaders:       []RowReader{rowReader},
 		rowReadersValues: make([]map[string]TypedValue, 1+len(joins)),
+		nullExtended:     make([]bool, 1+len(joins)),
+		matchedRows:      matchedRows,
+		unmatchedJoin:    -1,
+		hashJoins:        make([]*hashJoin, len(joins)),
 	}, nil
 }
 
+// hasUnmatchedRows returns true when rows not matched by the joined rows are read i.e. there are RIGHT or FULL joins
+func hasUnmatchedRows(joins []*JoinSpec) bool {
+	for _, jspec := range joins {
+		if jspec.joinType == RightJoin || jspec.joinType == FullJoin {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (jointr *jointRowReader) onClose(callback func()) {
 	jointr.rowReader.onClose(callback)
 }
@@ -69,8 +106,43 @@ func (jointr *jointRowReader) TableAlias() string {
 	return jointr.rowReader.TableAlias()
 }
 
+// OrderBy returns the ordering of the first reader followed by the ordering of each joined table,
+// as rows of joined tables are read in the order of the index in use for each row they are joining.
+// Rows are not ordered when rows not matched by RIGHT or FULL joins are read after the joined ones
 func (jointr *jointRowReader) OrderBy() []ColDescriptor {
-	return jointr.rowReader.OrderBy()
+	if hasUnmatchedRows(jointr.joins) {
+		return nil
+	}
+
+	cols := jointr.rowReader.OrderBy()
+
+	for _, jspec := range jointr.joins {
+		tableRef, isTableRef := jspec.ds.(*tableRef)
+		if !isTableRef {
+			break
+		}
+
+		table, err := tableRef.referencedTable(jointr.Tx())
+		if err != nil {
+			break
+		}
+
+		index, err := table.indexOn(jspec.indexOn)
+		if err != nil {
+			break
+		}
+
+		for _, col := range index.orderingCols() {
+			cols = append(cols, ColDescriptor{
+				Database: table.db.name,
+				Table:    tableRef.Alias(),
+				Column:   col.colName,
+				Type:     col.colType,
+			})
+		}
+	}
+
+	return cols
 }
 
 func (jointr *jointRowReader) ScanSpecs() *ScanSpecs {
@@ -183,21 +255,129 @@ func (jointr *jointRowReader) SetParameters(params map[string]interface{}) error
 	}
 
 	jointr.params, err = normalizeParams(params)
+	if err != nil {
+		return err
+	}
 
-	return err
+	// hash tables hold the rows read with the previous parameters
+	for _, hj := range jointr.hashJoins {
+		if hj == nil {
+			continue
+		}
+
+		err = hj.reset()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (jointr *jointRowReader) Read() (row *Row, err error) {
+// plan picks the hash join strategy for the joins not efficiently resolved by index lookups,
+// the rows of the remaining joins are resolved by querying the joined data source for every row
+func (jointr *jointRowReader) plan() error {
+	candidates := false
+
+	for _, jspec := range jointr.joins {
+		if jspec.joinType != CrossJoin && len(jspec.indexOn) == 0 && len(equiJoinConds(jspec.cond)) > 0 {
+			candidates = true
+			break
+		}
+	}
+
+	if !candidates {
+		return nil
+	}
+
+	cols, err := jointr.rowReader.colsBySelector()
+	if err != nil {
+		return err
+	}
+
+	outerCols := make(map[string]ColDescriptor, len(cols))
+
+	for sel, des := range cols {
+		outerCols[sel] = des
+	}
+
+	for i, jspec := range jointr.joins {
+		rr, err := jspec.ds.Resolve(jointr.Tx(), nil, &ScanSpecs{index: &Index{}})
+		if err != nil {
+			return err
+		}
+
+		innerCols, err := rr.colsBySelector()
+		rr.Close()
+		if err != nil {
+			return err
+		}
+
+		jointr.hashJoins[i], err = planHashJoin(jointr.Tx(), jspec, outerCols, innerCols, jointr.Database().Name(), jointr.TableAlias())
+		if err != nil {
+			return err
+		}
+
+		for sel, des := range innerCols {
+			outerCols[sel] = des
+		}
+	}
+
+	return nil
+}
+
+func (jointr *jointRowReader) Read() (*Row, error) {
+	for {
+		row, err := jointr.read()
+		if err == ErrNoMoreRows {
+			more, err := jointr.nextUnmatchedJoin()
+			if err != nil {
+				return nil, err
+			}
+
+			if more {
+				continue
+			}
+		}
+
+		return row, err
+	}
+}
+
+func (jointr *jointRowReader) read() (*Row, error) {
+	if !jointr.planned {
+		err := jointr.plan()
+		if err != nil {
+			return nil, err
+		}
+
+		jointr.planned = true
+	}
+
 	for {
 		row := &Row{Values: make(map[string]TypedValue)}
 
-		for len(jointr.rowReaders) > 0 {
-			lastReader := jointr.rowReaders[len(jointr.rowReaders)-1]
+		for len(jointr.rowReaders) > jointr.baseLevel {
+			level := len(jointr.rowReaders) - 1
+			lastReader := jointr.rowReaders[level]
 
-			r, err := lastReader.Read()
+			var r *Row
+			var err error
+
+			if jointr.nullExtended[level] {
+				err = ErrNoMoreRows
+			} else {
+				r, err = lastReader.Read()
+			}
 			if err == ErrNoMoreRows {
 				// previous reader will need to read next row
-				jointr.rowReaders = jointr.rowReaders[:len(jointr.rowReaders)-1]
+				jointr.rowReaders = jointr.rowReaders[:level]
+				jointr.nullExtended[level] = false
+
+				if lastReader == jointr.rowReader {
+					// it's closed with the joint reader as its rows may still be needed to be null-extended
+					continue
+				}
 
 				err = lastReader.Close()
 				if err != nil {
@@ -210,13 +390,20 @@ func (jointr *jointRowReader) Read() (row *Row, err error) {
 				return nil, err
 			}
 
+			if level > jointr.baseLevel {
+				err = jointr.match(level-1, lastReader, r)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			// override row data
-			jointr.rowReadersValues[len(jointr.rowReaders)-1] = r.Values
+			jointr.rowReadersValues[level] = r.Values
 
 			break
 		}
 
-		if len(jointr.rowReaders) == 0 {
+		if len(jointr.rowReaders) == jointr.baseLevel {
 			return nil, ErrNoMoreRows
 		}
 
@@ -232,19 +419,36 @@ func (jointr *jointRowReader) Read() (row *Row, err error) {
 		for i := len(jointr.rowReaders) - 1; i < len(jointr.joins); i++ {
 			jspec := jointr.joins[i]
 
-			jointq := &SelectStmt{
-				ds:      jspec.ds,
-				where:   jspec.cond.reduceSelectors(row, jointr.Database().Name(), jointr.TableAlias()),
-				indexOn: jspec.indexOn,
-			}
+			cond := jspec.cond.reduceSelectors(row, jointr.Database().Name(), jointr.TableAlias())
+
+			var reader RowReader
+			var err error
 
-			reader, err := jointq.Resolve(jointr.Tx(), jointr.params, nil)
+			if jointr.hashJoins[i] != nil {
+				reader, err = jointr.hashJoins[i].newRowReader(jointr.Tx(), row, cond, jointr.params)
+			} else {
+				jointq := &SelectStmt{
+					ds:      jspec.ds,
+					where:   cond,
+					indexOn: jspec.indexOn,
+				}
+
+				reader, err = jointq.Resolve(jointr.Tx(), jointr.params, nil)
+			}
 			if err != nil {
 				return nil, err
 			}
 
 			r, err := reader.Read()
-			if err == ErrNoMoreRows {
+			if err == ErrNoMoreRows && (jspec.joinType == LeftJoin || jspec.joinType == FullJoin) {
+				// the row is kept with the columns of the joined data source set to NULL
+				var values map[string]TypedValue
+
+				values, err = nullValues(reader)
+				r = &Row{Values: values}
+
+				jointr.nullExtended[i+1] = true
+			} else if err == ErrNoMoreRows {
 				// previous reader will need to read next row
 				unsolvedFK = true
 
@@ -254,8 +458,11 @@ func (jointr *jointRowReader) Read() (row *Row, err error) {
 				}
 
 				break
+			} else if err == nil {
+				err = jointr.match(i, reader, r)
 			}
 			if err != nil {
+				reader.Close()
 				return nil, err
 			}
 
@@ -276,13 +483,168 @@ func (jointr *jointRowReader) Read() (row *Row, err error) {
 	}
 }
 
+// match keeps track of the rows of RIGHT and FULL joins matched by the rows they were joined to
+func (jointr *jointRowReader) match(join int, reader RowReader, row *Row) error {
+	matched := jointr.matchedRows[join]
+	if matched == nil {
+		return nil
+	}
+
+	cols, err := reader.Columns()
+	if err != nil {
+		return err
+	}
+
+	digest, err := row.digest(cols)
+	if err != nil {
+		return err
+	}
+
+	_, ok := matched[digest]
+	if ok {
+		return nil
+	}
+
+	if len(matched) == jointr.Tx().distinctLimit() {
+		return ErrTooManyRows
+	}
+
+	matched[digest] = struct{}{}
+
+	return nil
+}
+
+// nextUnmatchedJoin starts reading the rows not matched by the next RIGHT or FULL join,
+// false is returned when there are no more joins of these types
+func (jointr *jointRowReader) nextUnmatchedJoin() (bool, error) {
+	for i := jointr.unmatchedJoin + 1; i < len(jointr.joins); i++ {
+		if jointr.matchedRows[i] == nil {
+			continue
+		}
+
+		// columns of all the lower levels are set to NULL
+		for level := 0; level <= i; level++ {
+			var values map[string]TypedValue
+			var err error
+
+			if level == 0 {
+				values, err = nullValues(jointr.rowReader)
+			} else {
+				values, err = jointr.joinNullValues(level - 1)
+			}
+			if err != nil {
+				return false, err
+			}
+
+			jointr.rowReadersValues[level] = values
+		}
+
+		jspec := jointr.joins[i]
+
+		jointq := &SelectStmt{
+			ds:      jspec.ds,
+			indexOn: jspec.indexOn,
+		}
+
+		reader, err := jointq.Resolve(jointr.Tx(), jointr.params, nil)
+		if err != nil {
+			return false, err
+		}
+
+		cols, err := reader.Columns()
+		if err != nil {
+			reader.Close()
+			return false, err
+		}
+
+		jointr.unmatchedJoin = i
+		jointr.baseLevel = i + 1
+		jointr.rowReaders = make([]RowReader, i+1, i+2)
+		jointr.rowReaders = append(jointr.rowReaders, &unmatchedRowReader{
+			RowReader: reader,
+			cols:      cols,
+			matched:   jointr.matchedRows[i],
+		})
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (jointr *jointRowReader) joinNullValues(join int) (map[string]TypedValue, error) {
+	rr, err := jointr.joins[join].ds.Resolve(jointr.Tx(), nil, &ScanSpecs{index: &Index{}})
+	if err != nil {
+		return nil, err
+	}
+	defer rr.Close()
+
+	return nullValues(rr)
+}
+
+// nullValues returns the columns of the reader set to NULL
+func nullValues(reader RowReader) (map[string]TypedValue, error) {
+	cols, err := reader.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]TypedValue, len(cols))
+
+	for _, col := range cols {
+		values[col.Selector()] = &NullValue{t: col.Type}
+	}
+
+	return values, nil
+}
+
+// unmatchedRowReader returns the rows of a RIGHT or FULL joined data source not matched by the rows they were joined to
+type unmatchedRowReader struct {
+	RowReader
+
+	cols    []ColDescriptor
+	matched map[[sha256.Size]byte]struct{}
+}
+
+func (r *unmatchedRowReader) Read() (*Row, error) {
+	for {
+		row, err := r.RowReader.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		digest, err := row.digest(r.cols)
+		if err != nil {
+			return nil, err
+		}
+
+		_, ok := r.matched[digest]
+		if !ok {
+			return row, nil
+		}
+	}
+}
+
 func (jointr *jointRowReader) Close() error {
 	merr := multierr.NewMultiErr()
 
 	for _, rowReader := range jointr.rowReaders {
+		if rowReader == nil || rowReader == jointr.rowReader {
+			continue
+		}
+
 		err := rowReader.Close()
 		merr.Append(err)
 	}
 
+	for _, hj := range jointr.hashJoins {
+		if hj != nil {
+			merr.Append(hj.close())
+		}
+	}
+
+	// the first reader is closed last as it may release the resources shared with the other readers
+	merr.Append(jointr.rowReader.Close())
+
 	return merr.Reduce()
 }
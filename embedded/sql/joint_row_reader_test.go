@@ -17,6 +17,7 @@ limitations under the License.
 package sql
 
 import (
+	"context"
 	"errors"
 	"os"
 	"testing"
@@ -36,7 +37,7 @@ func TestJointRowReader(t *testing.T) {
 	_, err = newJointRowReader(nil, nil, nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	tx, err := engine.newTx(false)
+	tx, err := engine.newTx(context.Background(), false)
 	require.NoError(t, err)
 
 	db, err := tx.catalog.newDatabase(1, "db1")
@@ -56,7 +57,7 @@ func TestJointRowReader(t *testing.T) {
 	r, err := newRawRowReader(tx, table, 0, "", &ScanSpecs{index: table.primaryIndex})
 	require.NoError(t, err)
 
-	_, err = newJointRowReader(r, []*JoinSpec{{joinType: LeftJoin}}, nil)
+	_, err = newJointRowReader(r, []*JoinSpec{{joinType: CrossJoin + 1}}, nil)
 	require.Equal(t, ErrUnsupportedJoinType, err)
 
 	_, err = newJointRowReader(r, []*JoinSpec{{joinType: InnerJoin, ds: &SelectStmt{}}}, nil)
@@ -67,9 +68,11 @@ func TestJointRowReader(t *testing.T) {
 
 	orderBy := jr.OrderBy()
 	require.NotNil(t, orderBy)
-	require.Len(t, orderBy, 1)
+	require.Len(t, orderBy, 2)
 	require.Equal(t, "id", orderBy[0].Column)
 	require.Equal(t, "table1", orderBy[0].Table)
+	require.Equal(t, "id", orderBy[1].Column)
+	require.Equal(t, "table2", orderBy[1].Table)
 
 	cols, err := jr.Columns()
 	require.NoError(t, err)
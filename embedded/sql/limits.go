@@ -0,0 +1,213 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (e *Engine) parse(sql string) ([]SQLStmt, error) {
+	if e.maxStmtLength > 0 && len(sql) > e.maxStmtLength {
+		return nil, fmt.Errorf("%w (%d)", ErrMaxStmtLengthExceeded, e.maxStmtLength)
+	}
+
+	return Parse(strings.NewReader(sql))
+}
+
+// limitsChecker walks the statement tree validating it against the engine limits
+type limitsChecker struct {
+	e     *Engine
+	joins int
+}
+
+func (e *Engine) checkLimits(stmt SQLStmt) error {
+	if e.maxJoins == 0 && e.maxInListSize == 0 && e.maxSubqueryDepth == 0 {
+		return nil
+	}
+
+	c := &limitsChecker{e: e}
+
+	return c.checkStmt(stmt)
+}
+
+func (c *limitsChecker) checkStmt(stmt SQLStmt) error {
+	switch s := stmt.(type) {
+	case *SelectStmt:
+		return c.checkSelect(s, 0)
+	case *UpsertIntoStmt:
+		for _, row := range s.rows {
+			for _, v := range row.Values {
+				err := c.checkExp(v, 0)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	case *UpdateStmt:
+		for _, u := range s.updates {
+			err := c.checkExp(u.val, 0)
+			if err != nil {
+				return err
+			}
+		}
+		return c.checkExp(s.where, 0)
+	case *DeleteFromStmt:
+		return c.checkExp(s.where, 0)
+	}
+
+	return nil
+}
+
+func (c *limitsChecker) checkSelect(stmt *SelectStmt, depth int) error {
+	if c.e.maxSubqueryDepth > 0 && depth > c.e.maxSubqueryDepth {
+		return fmt.Errorf("%w (%d)", ErrMaxSubqueryDepthExceeded, c.e.maxSubqueryDepth)
+	}
+
+	c.joins += len(stmt.joins)
+
+	if c.e.maxJoins > 0 && c.joins > c.e.maxJoins {
+		return fmt.Errorf("%w (%d)", ErrMaxJoinsExceeded, c.e.maxJoins)
+	}
+
+	err := c.checkDataSource(stmt.ds, depth)
+	if err != nil {
+		return err
+	}
+
+	for _, sel := range stmt.selectors {
+		err = c.checkExp(sel, depth)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, j := range stmt.joins {
+		err = c.checkDataSource(j.ds, depth)
+		if err != nil {
+			return err
+		}
+
+		err = c.checkExp(j.cond, depth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.checkExp(stmt.where, depth)
+	if err != nil {
+		return err
+	}
+
+	return c.checkExp(stmt.having, depth)
+}
+
+func (c *limitsChecker) checkDataSource(ds DataSource, depth int) error {
+	switch q := ds.(type) {
+	case *SelectStmt:
+		return c.checkSelect(q, depth+1)
+	case *setOperation:
+		// both queries are at the same level of the one combining them
+		err := c.checkSelect(q.left, depth)
+		if err != nil {
+			return err
+		}
+
+		return c.checkSelect(q.right, depth)
+	}
+
+	return nil
+}
+
+func (c *limitsChecker) checkExp(exp ValueExp, depth int) error {
+	switch e := exp.(type) {
+	case *NumExp:
+		err := c.checkExp(e.left, depth)
+		if err != nil {
+			return err
+		}
+		return c.checkExp(e.right, depth)
+	case *CmpBoolExp:
+		err := c.checkExp(e.left, depth)
+		if err != nil {
+			return err
+		}
+		return c.checkExp(e.right, depth)
+	case *BinBoolExp:
+		err := c.checkExp(e.left, depth)
+		if err != nil {
+			return err
+		}
+		return c.checkExp(e.right, depth)
+	case *NotBoolExp:
+		return c.checkExp(e.exp, depth)
+	case *LikeBoolExp:
+		return c.checkExp(e.val, depth)
+	case *RegexpBoolExp:
+		return c.checkExp(e.val, depth)
+	case *Cast:
+		return c.checkExp(e.val, depth)
+	case *SysFn:
+		for _, p := range e.params {
+			err := c.checkExp(p, depth)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case *CaseWhenExp:
+		for _, w := range e.whens {
+			err := c.checkExp(w.cond, depth)
+			if err != nil {
+				return err
+			}
+
+			err = c.checkExp(w.then, depth)
+			if err != nil {
+				return err
+			}
+		}
+
+		return c.checkExp(e.elseExp, depth)
+	case *ExistsBoolExp:
+		return c.checkSelect(e.q, depth+1)
+	case *InSubQueryExp:
+		err := c.checkExp(e.val, depth)
+		if err != nil {
+			return err
+		}
+		return c.checkSelect(e.q, depth+1)
+	case *InListExp:
+		if c.e.maxInListSize > 0 && len(e.values) > c.e.maxInListSize {
+			return fmt.Errorf("%w (%d)", ErrMaxInListSizeExceeded, c.e.maxInListSize)
+		}
+
+		err := c.checkExp(e.val, depth)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range e.values {
+			err = c.checkExp(v, depth)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
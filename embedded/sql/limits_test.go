@@ -0,0 +1,114 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStmtLimits(t *testing.T) {
+	st, err := store.Open("sqldata_stmt_limits", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_stmt_limits")
+
+	_, err = NewEngine(st, DefaultOptions().WithMaxJoins(-1))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	opts := DefaultOptions().
+		WithPrefix(sqlPrefix).
+		WithMaxStmtLength(200).
+		WithMaxJoins(1).
+		WithMaxInListSize(3).
+		WithMaxSubqueryDepth(1)
+
+	engine, err := NewEngine(st, opts)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title) VALUES (1, 'title1'), (2, 'title2'), (3, 'title3')", nil, nil)
+	require.NoError(t, err)
+
+	t.Run("statements within limits should be accepted", func(t *testing.T) {
+		r, err := engine.Query(context.Background(), "SELECT t1.id FROM table1 AS t1 INNER JOIN table1 AS t2 ON t1.id = t2.id WHERE t1.id IN (1, 2, 3)", nil, nil)
+		require.NoError(t, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+
+		r, err = engine.Query(context.Background(), "SELECT id FROM (SELECT id FROM table1)", nil, nil)
+		require.NoError(t, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("statement length should be limited", func(t *testing.T) {
+		longStmt := "SELECT id FROM table1 WHERE " + string(make([]byte, 200))
+
+		_, err := engine.Query(context.Background(), longStmt, nil, nil)
+		require.True(t, errors.Is(err, ErrMaxStmtLengthExceeded))
+
+		_, _, err = engine.Exec(context.Background(), longStmt, nil, nil)
+		require.True(t, errors.Is(err, ErrMaxStmtLengthExceeded))
+
+		_, err = engine.ExecScript(context.Background(), longStmt, nil)
+		require.True(t, errors.Is(err, ErrMaxStmtLengthExceeded))
+	})
+
+	t.Run("number of joins should be limited", func(t *testing.T) {
+		_, err := engine.Query(context.Background(), `
+			SELECT t1.id FROM table1 AS t1
+			INNER JOIN table1 AS t2 ON t1.id = t2.id
+			INNER JOIN table1 AS t3 ON t1.id = t3.id`, nil, nil)
+		require.True(t, errors.Is(err, ErrMaxJoinsExceeded))
+	})
+
+	t.Run("IN list size should be limited", func(t *testing.T) {
+		_, err := engine.Query(context.Background(), "SELECT id FROM table1 WHERE id IN (1, 2, 3, 4)", nil, nil)
+		require.True(t, errors.Is(err, ErrMaxInListSizeExceeded))
+
+		_, _, err = engine.Exec(context.Background(), "UPDATE table1 SET title = 'title' WHERE id NOT IN (1, 2, 3, 4)", nil, nil)
+		require.True(t, errors.Is(err, ErrMaxInListSizeExceeded))
+
+		_, _, err = engine.Exec(context.Background(), "DELETE FROM table1 WHERE id IN (1, 2, 3, 4)", nil, nil)
+		require.True(t, errors.Is(err, ErrMaxInListSizeExceeded))
+
+		_, err = engine.ExecScript(context.Background(), "DELETE FROM table1 WHERE id IN (1, 2, 3, 4)", nil)
+		require.True(t, errors.Is(err, ErrMaxInListSizeExceeded))
+	})
+
+	t.Run("subquery depth should be limited", func(t *testing.T) {
+		_, err := engine.Query(context.Background(), "SELECT id FROM (SELECT id FROM (SELECT id FROM table1))", nil, nil)
+		require.True(t, errors.Is(err, ErrMaxSubqueryDepthExceeded))
+
+		_, err = engine.Query(context.Background(), "SELECT id FROM table1 WHERE EXISTS (SELECT id FROM (SELECT id FROM table1))", nil, nil)
+		require.True(t, errors.Is(err, ErrMaxSubqueryDepthExceeded))
+	})
+}
@@ -0,0 +1,638 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// materializedView holds the definition of a table whose rows are the result of a query,
+// rows are only updated when the view is refreshed
+type materializedView struct {
+	sql         string
+	refreshedAt uint64 // last tx reflected by the rows of the view
+}
+
+// viewKey is a column of the query identifying the rows of the view
+type viewKey struct {
+	pos int          // position of the column in the result of the query
+	sel *ColSelector // source column the key is read from
+	col *Column
+}
+
+// viewQuery is a query supported by materialized views: a single table is read and each row of the result
+// is identified by the values of its key columns, so rows can be individually recomputed on refresh
+type viewQuery struct {
+	stmt   *SelectStmt
+	source *tableRef
+	table  *Table
+	keys   []*viewKey
+}
+
+func analyzeViewQuery(tx *SQLTx, stmt *SelectStmt) (*viewQuery, error) {
+	_, err := stmt.execAt(tx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	source, ok := stmt.ds.(*tableRef)
+	if !ok || source.sinceTx > 0 || source.asBefore > 0 || source.asBeforeTs != nil {
+		return nil, ErrLimitedMaterializedView
+	}
+
+	if stmt.distinct || len(stmt.joins) > 0 || stmt.limit > 0 || stmt.offset > 0 {
+		return nil, ErrLimitedMaterializedView
+	}
+
+	table, err := source.referencedTable(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if table.IsMaterializedView() {
+		return nil, ErrLimitedMaterializedView
+	}
+
+	// subqueries are not supported as they may read other tables
+	tables := make(map[string]struct{})
+
+	complete := referencedTables(stmt.where, tables) && referencedTables(stmt.having, tables)
+
+	aggregated := false
+
+	for _, sel := range stmt.selectors {
+		complete = referencedTables(sel, tables) && complete
+
+		_, isAgg := sel.(*AggColSelector)
+		aggregated = aggregated || isAgg
+	}
+
+	if !complete {
+		return nil, ErrLimitedMaterializedView
+	}
+
+	var keyCols []*ColSelector
+
+	if aggregated || len(stmt.groupBy) > 0 {
+		if !aggregated || len(stmt.groupBy) != 1 {
+			return nil, ErrLimitedMaterializedView
+		}
+
+		keyCols = stmt.groupBy
+	} else {
+		for _, col := range table.primaryIndex.cols {
+			keyCols = append(keyCols, &ColSelector{table: source.Alias(), col: col.colName})
+		}
+	}
+
+	query := &viewQuery{
+		stmt:   stmt,
+		source: source,
+		table:  table,
+	}
+
+	for _, keyCol := range keyCols {
+		col, err := table.GetColumnByName(keyCol.col)
+		if err != nil {
+			return nil, err
+		}
+
+		if !col.notNull && !table.primaryIndex.IncludesCol(col.id) {
+			return nil, ErrLimitedMaterializedView
+		}
+
+		pos := -1
+
+		if len(stmt.selectors) == 0 {
+			for i, c := range table.cols {
+				if c.id == col.id {
+					pos = i
+				}
+			}
+		}
+
+		for i, sel := range stmt.selectors {
+			colSel, ok := sel.(*ColSelector)
+			if ok && colSel.col == col.colName {
+				pos = i
+				break
+			}
+		}
+
+		if pos < 0 {
+			return nil, fmt.Errorf("%w: column '%s' must be selected", ErrLimitedMaterializedView, col.colName)
+		}
+
+		query.keys = append(query.keys, &viewKey{
+			pos: pos,
+			sel: &ColSelector{db: keyCol.db, table: keyCol.table, col: keyCol.col},
+			col: col,
+		})
+	}
+
+	return query, nil
+}
+
+// restricted returns the query narrowed to the rows matching all the given conditions
+func (q *viewQuery) restricted(conds []ValueExp) *SelectStmt {
+	stmt := *q.stmt
+
+	for _, cond := range conds {
+		if stmt.where == nil {
+			stmt.where = cond
+			continue
+		}
+
+		stmt.where = &BinBoolExp{op: AND, left: stmt.where, right: cond}
+	}
+
+	return &stmt
+}
+
+// keyConds returns the conditions selecting the rows of the view identified by the given key values
+func (q *viewQuery) keyConds(keyVals []TypedValue) []ValueExp {
+	conds := make([]ValueExp, len(q.keys))
+
+	for i, k := range q.keys {
+		conds[i] = &CmpBoolExp{op: EQ, left: k.sel, right: keyVals[i]}
+	}
+
+	return conds
+}
+
+// keysOf returns the key values of the rows of the view derived from the source row with the given primary key,
+// as read before the given tx or from the current snapshot when asBefore is zero
+func (q *viewQuery) keysOf(tx *SQLTx, pkVals []TypedValue, asBefore uint64) ([][]TypedValue, error) {
+	selectors := make([]Selector, len(q.keys))
+	for i, k := range q.keys {
+		selectors[i] = k.sel
+	}
+
+	var where ValueExp
+
+	for i, col := range q.table.primaryIndex.cols {
+		cond := &CmpBoolExp{op: EQ, left: &ColSelector{table: q.source.Alias(), col: col.colName}, right: pkVals[i]}
+
+		if where == nil {
+			where = cond
+			continue
+		}
+
+		where = &BinBoolExp{op: AND, left: where, right: cond}
+	}
+
+	source := *q.source
+	source.asBefore = asBefore
+
+	stmt := &SelectStmt{
+		ds:        &source,
+		selectors: selectors,
+		where:     where,
+	}
+
+	rowReader, err := stmt.Resolve(tx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rowReader.Close()
+
+	cols, err := rowReader.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys [][]TypedValue
+
+	for {
+		row, err := rowReader.Read()
+		if err == ErrNoMoreRows {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		keyVals := make([]TypedValue, len(cols))
+		for i, col := range cols {
+			keyVals[i] = row.Values[col.Selector()]
+		}
+
+		keys = append(keys, keyVals)
+	}
+
+	return keys, nil
+}
+
+// writeRows upserts a row into the view for each row returned by the query,
+// returns true if any row was returned
+func (q *viewQuery) writeRows(tx *SQLTx, view *Table, stmt *SelectStmt, checkChanges bool) (bool, error) {
+	rowReader, err := stmt.Resolve(tx, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	defer rowReader.Close()
+
+	cols, err := rowReader.Columns()
+	if err != nil {
+		return false, err
+	}
+
+	found := false
+
+	for {
+		row, err := rowReader.Read()
+		if err == ErrNoMoreRows {
+			break
+		}
+		if err != nil {
+			return false, err
+		}
+
+		found = true
+
+		valuesByColID := make(map[uint32]TypedValue, len(cols))
+
+		for i, col := range cols {
+			val := row.Values[col.Selector()]
+			if val == nil || val.IsNull() {
+				continue
+			}
+
+			valuesByColID[view.cols[i].id] = val
+		}
+
+		if checkChanges {
+			currRow, err := tx.fetchPKRow(view, valuesByColID)
+			if err != nil && err != ErrNoMoreRows {
+				return false, err
+			}
+
+			if err == nil {
+				changed, err := rowChanged(view, currRow, valuesByColID)
+				if err != nil {
+					return false, err
+				}
+
+				if !changed {
+					continue
+				}
+			}
+		}
+
+		pkEncVals, err := encodedPK(view, valuesByColID)
+		if err != nil {
+			return false, err
+		}
+
+		err = tx.doUpsert(pkEncVals, valuesByColID, view, checkChanges)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return found, nil
+}
+
+func rowChanged(table *Table, row *Row, valuesByColID map[uint32]TypedValue) (bool, error) {
+	for _, col := range table.cols {
+		currVal := row.Values[EncodeSelector("", table.db.name, table.name, col.colName)]
+		val, specified := valuesByColID[col.id]
+
+		if !specified || currVal.IsNull() {
+			if specified != !currVal.IsNull() {
+				return true, nil
+			}
+
+			continue
+		}
+
+		cmp, err := currVal.Compare(val)
+		if err != nil {
+			return false, err
+		}
+
+		if cmp != 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func persistMaterializedView(tx *SQLTx, view *Table) error {
+	v := make([]byte, 8+len(view.view.sql))
+	binary.BigEndian.PutUint64(v, view.view.refreshedAt)
+	copy(v[8:], view.view.sql)
+
+	return tx.set(mapKey(tx.sqlPrefix(), catalogViewPrefix, EncodeID(view.db.id), EncodeID(view.id)), nil, v)
+}
+
+type CreateMaterializedViewStmt struct {
+	view        string
+	ifNotExists bool
+	query       *SelectStmt
+	sql         string
+}
+
+func (stmt *CreateMaterializedViewStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	return nil
+}
+
+// execAt creates a table holding the rows returned by the query, the rows of the view are identified by
+// the grouping column or by the primary key of the source table when the query is not aggregated
+func (stmt *CreateMaterializedViewStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
+	if tx.currentDB == nil {
+		return nil, ErrNoDatabaseSelected
+	}
+
+	if stmt.ifNotExists && tx.currentDB.ExistTable(stmt.view) {
+		return tx, nil
+	}
+
+	query, err := analyzeViewQuery(tx, stmt.query)
+	if err != nil {
+		return nil, err
+	}
+
+	rowReader, err := stmt.query.Resolve(tx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cols, err := rowReader.Columns()
+	rowReader.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	colsSpec := make([]*ColSpec, len(cols))
+
+	for i, col := range cols {
+		colsSpec[i] = &ColSpec{colName: col.Column, colType: col.Type}
+	}
+
+	pkColNames := make([]string, len(query.keys))
+
+	for i, k := range query.keys {
+		colsSpec[k.pos].notNull = true
+		colsSpec[k.pos].maxLen = k.col.MaxLen()
+
+		pkColNames[i] = cols[k.pos].Column
+	}
+
+	createTableStmt := &CreateTableStmt{
+		table:      stmt.view,
+		colsSpec:   colsSpec,
+		pkColNames: pkColNames,
+	}
+
+	_, err = createTableStmt.execAt(tx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := tx.currentDB.GetTableByName(stmt.view)
+	if err != nil {
+		return nil, err
+	}
+
+	view.view = &materializedView{
+		sql:         stmt.sql,
+		refreshedAt: tx.snapshotTx,
+	}
+
+	_, err = query.writeRows(tx, view, stmt.query, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = persistMaterializedView(tx, view)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+type RefreshMaterializedViewStmt struct {
+	view string
+}
+
+func (stmt *RefreshMaterializedViewStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	return nil
+}
+
+// execAt brings the view up to date with the snapshot of the transaction. Only the rows of the view
+// derived from source rows written since the previous refresh are recomputed
+func (stmt *RefreshMaterializedViewStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
+	if tx.currentDB == nil {
+		return nil, ErrNoDatabaseSelected
+	}
+
+	view, err := tx.currentDB.GetTableByName(stmt.view)
+	if err != nil {
+		return nil, err
+	}
+
+	if !view.IsMaterializedView() {
+		return nil, ErrMaterializedViewDoesNotExist
+	}
+
+	stmts, err := ParseString(view.view.sql)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stmts) != 1 {
+		return nil, ErrCorruptedData
+	}
+
+	selectStmt, ok := stmts[0].(*SelectStmt)
+	if !ok {
+		return nil, ErrCorruptedData
+	}
+
+	query, err := analyzeViewQuery(tx, selectStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	snapTx := tx.snapshotTx
+
+	if snapTx <= view.view.refreshedAt {
+		return tx, nil
+	}
+
+	changedPKs, err := query.changedPKs(tx, view.view.refreshedAt, snapTx)
+	if err != nil {
+		return nil, err
+	}
+
+	affectedKeys := make(map[string][]TypedValue)
+
+	for _, pkVals := range changedPKs {
+		// rows of the view the source row contributed to, before and after the changes
+		for _, asBefore := range []uint64{view.view.refreshedAt + 1, 0} {
+			keys, err := query.keysOf(tx, pkVals, asBefore)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, keyVals := range keys {
+				var encKey bytes.Buffer
+
+				for i, k := range query.keys {
+					encVal, err := EncodeAsKey(keyVals[i].Value(), k.col.colType, k.col.MaxLen())
+					if err != nil {
+						return nil, err
+					}
+
+					encKey.Write(encVal)
+				}
+
+				affectedKeys[encKey.String()] = keyVals
+			}
+		}
+	}
+
+	for _, keyVals := range affectedKeys {
+		found, err := query.writeRows(tx, view, query.restricted(query.keyConds(keyVals)), true)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			continue
+		}
+
+		// the row is no longer returned by the query
+		valuesByColID := make(map[uint32]TypedValue, len(query.keys))
+
+		for i, k := range query.keys {
+			valuesByColID[view.cols[k.pos].id] = keyVals[i]
+		}
+
+		currRow, err := tx.fetchPKRow(view, valuesByColID)
+		if err == ErrNoMoreRows {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, col := range view.cols {
+			valuesByColID[col.id] = currRow.Values[EncodeSelector("", view.db.name, view.name, col.colName)]
+		}
+
+		pkEncVals, err := encodedPK(view, valuesByColID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = tx.deleteIndexEntries(pkEncVals, valuesByColID, view)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.updatedRows++
+	}
+
+	view.view.refreshedAt = snapTx
+
+	err = persistMaterializedView(tx, view)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// changedPKs returns the primary keys of the source rows written by the txs in the range (fromTx, toTx]
+func (q *viewQuery) changedPKs(tx *SQLTx, fromTx, toTx uint64) ([][]TypedValue, error) {
+	prefix := mapKey(tx.sqlPrefix(), PIndexPrefix, EncodeID(q.table.db.id), EncodeID(q.table.id), EncodeID(PKIndexID))
+
+	txHolder := tx.engine.store.NewTxHolder()
+
+	seen := make(map[string]struct{})
+
+	var pks [][]TypedValue
+
+	for txID := fromTx + 1; txID <= toTx; txID++ {
+		err := tx.engine.store.ReadTx(txID, txHolder)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range txHolder.Entries() {
+			mkey := e.Key()
+
+			if !bytes.HasPrefix(mkey, prefix) {
+				continue
+			}
+
+			if _, ok := seen[string(mkey)]; ok {
+				continue
+			}
+
+			seen[string(mkey)] = struct{}{}
+
+			pkVals, err := q.table.DecodePrimaryKey(tx.sqlPrefix(), mkey)
+			if err != nil {
+				return nil, err
+			}
+
+			pks = append(pks, pkVals)
+		}
+	}
+
+	return pks, nil
+}
+
+type DropMaterializedViewStmt struct {
+	view     string
+	ifExists bool
+}
+
+func (stmt *DropMaterializedViewStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	return nil
+}
+
+func (stmt *DropMaterializedViewStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
+	if tx.currentDB == nil {
+		return nil, ErrNoDatabaseSelected
+	}
+
+	if stmt.ifExists && !tx.currentDB.ExistTable(stmt.view) {
+		return tx, nil
+	}
+
+	view, err := tx.currentDB.GetTableByName(stmt.view)
+	if err == ErrTableDoesNotExist {
+		return nil, ErrMaterializedViewDoesNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !view.IsMaterializedView() {
+		return nil, ErrMaterializedViewDoesNotExist
+	}
+
+	return (&DropTableStmt{table: stmt.view}).execAt(tx, params)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+type offsetRowReader struct {
+	rowReader RowReader
+
+	offset  int
+	skipped int
+}
+
+func newOffsetRowReader(rowReader RowReader, offset int) (*offsetRowReader, error) {
+	return &offsetRowReader{
+		rowReader: rowReader,
+		offset:    offset,
+	}, nil
+}
+
+func (or *offsetRowReader) onClose(callback func()) {
+	or.rowReader.onClose(callback)
+}
+
+func (or *offsetRowReader) Tx() *SQLTx {
+	return or.rowReader.Tx()
+}
+
+func (or *offsetRowReader) Database() *Database {
+	return or.rowReader.Database()
+}
+
+func (or *offsetRowReader) TableAlias() string {
+	return or.rowReader.TableAlias()
+}
+
+func (or *offsetRowReader) SetParameters(params map[string]interface{}) error {
+	return or.rowReader.SetParameters(params)
+}
+
+func (or *offsetRowReader) OrderBy() []ColDescriptor {
+	return or.rowReader.OrderBy()
+}
+
+func (or *offsetRowReader) ScanSpecs() *ScanSpecs {
+	return or.rowReader.ScanSpecs()
+}
+
+func (or *offsetRowReader) Columns() ([]ColDescriptor, error) {
+	return or.rowReader.Columns()
+}
+
+func (or *offsetRowReader) colsBySelector() (map[string]ColDescriptor, error) {
+	return or.rowReader.colsBySelector()
+}
+
+func (or *offsetRowReader) InferParameters(params map[string]SQLValueType) error {
+	return or.rowReader.InferParameters(params)
+}
+
+func (or *offsetRowReader) Read() (*Row, error) {
+	for or.skipped < or.offset {
+		_, err := or.rowReader.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		or.skipped++
+	}
+
+	return or.rowReader.Read()
+}
+
+func (or *offsetRowReader) Close() error {
+	return or.rowReader.Close()
+}
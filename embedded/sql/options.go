@@ -15,22 +15,62 @@ limitations under the License.
 */
 package sql
 
-var defultDistinctLimit = 1 << 20 // ~ 1mi rows
+import (
+	"time"
+
+	"github.com/codenotary/immudb/embedded/logger"
+)
+
+var defultDistinctLimit = 1 << 20   // ~ 1mi rows
+var defaultSortBufferSize = 1 << 24 // 16MB
+var defaultJoinBufferSize = 1 << 24 // 16MB
+var defaultCloseTimeout = 10 * time.Second
 
 type Options struct {
 	prefix        []byte
 	distinctLimit int
 	autocommit    bool
+
+	log logger.Logger
+
+	// rows being sorted are spilled to temporary files in sortDir once the buffer is full
+	sortBufferSize int
+	sortDir        string
+
+	// rows of hash joined data sources are spilled to temporary files in sortDir once the buffer is full
+	joinBufferSize int
+
+	// statement limits, zero means unlimited
+	maxStmtLength    int
+	maxJoins         int
+	maxInListSize    int
+	maxSubqueryDepth int
+
+	// time Close waits for open transactions before cancelling them
+	closeTimeout time.Duration
 }
 
 func DefaultOptions() *Options {
 	return &Options{
-		distinctLimit: defultDistinctLimit,
+		distinctLimit:  defultDistinctLimit,
+		sortBufferSize: defaultSortBufferSize,
+		joinBufferSize: defaultJoinBufferSize,
+		log:            logger.NewNopLogger(),
+		closeTimeout:   defaultCloseTimeout,
 	}
 }
 
 func ValidOpts(opts *Options) bool {
-	return opts != nil && opts.distinctLimit > 0
+	return opts != nil &&
+		opts.distinctLimit > 0 &&
+		opts.sortBufferSize > 0 &&
+		opts.joinBufferSize > 0 &&
+		opts.log != nil &&
+		opts.maxStmtLength >= 0 &&
+		opts.maxJoins >= 0 &&
+		opts.maxInListSize >= 0 &&
+		opts.maxSubqueryDepth >= 0 &&
+		opts.closeTimeout >= 0
 }
 
 func (opts *Options) WithPrefix(prefix []byte) *Options {
@@ -47,3 +87,61 @@ func (opts *Options) WithAutocommit(autocommit bool) *Options {
 	opts.autocommit = autocommit
 	return opts
 }
+
+// WithLogger sets the logger receiving the events of the engine, events are discarded by default
+func (opts *Options) WithLogger(log logger.Logger) *Options {
+	opts.log = log
+	return opts
+}
+
+// WithSortBufferSize sets the amount of memory (in bytes) used to sort rows not ordered by any index,
+// rows exceeding it are sorted using temporary files
+func (opts *Options) WithSortBufferSize(sortBufferSize int) *Options {
+	opts.sortBufferSize = sortBufferSize
+	return opts
+}
+
+// WithJoinBufferSize sets the amount of memory (in bytes) used to hold the rows of hash joined data sources,
+// rows exceeding it are kept in temporary files
+func (opts *Options) WithJoinBufferSize(joinBufferSize int) *Options {
+	opts.joinBufferSize = joinBufferSize
+	return opts
+}
+
+// WithSortDir sets the directory where temporary files used for sorting and hash joins are created,
+// the default directory for temporary files is used when empty
+func (opts *Options) WithSortDir(sortDir string) *Options {
+	opts.sortDir = sortDir
+	return opts
+}
+
+// WithMaxStmtLength limits the length (in bytes) of the SQL text accepted by the engine
+func (opts *Options) WithMaxStmtLength(maxStmtLength int) *Options {
+	opts.maxStmtLength = maxStmtLength
+	return opts
+}
+
+// WithMaxJoins limits the number of joins a single statement may contain
+func (opts *Options) WithMaxJoins(maxJoins int) *Options {
+	opts.maxJoins = maxJoins
+	return opts
+}
+
+// WithMaxInListSize limits the number of values in an IN list
+func (opts *Options) WithMaxInListSize(maxInListSize int) *Options {
+	opts.maxInListSize = maxInListSize
+	return opts
+}
+
+// WithCloseTimeout sets for how long Close waits for open transactions, including the ones
+// created to read rows, before cancelling them
+func (opts *Options) WithCloseTimeout(closeTimeout time.Duration) *Options {
+	opts.closeTimeout = closeTimeout
+	return opts
+}
+
+// WithMaxSubqueryDepth limits the nesting of subqueries and derived tables
+func (opts *Options) WithMaxSubqueryDepth(maxSubqueryDepth int) *Options {
+	opts.maxSubqueryDepth = maxSubqueryDepth
+	return opts
+}
@@ -17,7 +17,9 @@ package sql
 
 import (
 	"testing"
+	"time"
 
+	"github.com/codenotary/immudb/embedded/logger"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,5 +41,29 @@ func TestOptions(t *testing.T) {
 	opts.WithAutocommit(true)
 	require.True(t, opts.autocommit)
 
+	require.False(t, ValidOpts(opts))
+
+	opts.WithSortBufferSize(defaultSortBufferSize)
+	require.Equal(t, defaultSortBufferSize, opts.sortBufferSize)
+
+	opts.WithSortDir("sort")
+	require.Equal(t, "sort", opts.sortDir)
+
+	opts.WithJoinBufferSize(defaultJoinBufferSize)
+	require.Equal(t, defaultJoinBufferSize, opts.joinBufferSize)
+
+	require.False(t, ValidOpts(opts))
+
+	opts.WithLogger(logger.NewNopLogger())
+	require.NotNil(t, opts.log)
+
+	require.True(t, ValidOpts(opts))
+
+	opts.WithCloseTimeout(-1)
+	require.False(t, ValidOpts(opts))
+
+	opts.WithCloseTimeout(time.Second)
+	require.Equal(t, time.Second, opts.closeTimeout)
+
 	require.True(t, ValidOpts(opts))
 }
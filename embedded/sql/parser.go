@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -40,15 +41,21 @@ var reservedWords = map[string]int{
 	"PRIMARY":        PRIMARY,
 	"KEY":            KEY,
 	"UNIQUE":         UNIQUE,
+	"CHECK":          CHECK,
 	"INDEX":          INDEX,
 	"ON":             ON,
 	"ALTER":          ALTER,
 	"ADD":            ADD,
+	"DROP":           DROP,
+	"RENAME":         RENAME,
+	"SYNONYM":        SYNONYM,
+	"FOR":            FOR,
 	"COLUMN":         COLUMN,
 	"INSERT":         INSERT,
 	"CONFLICT":       CONFLICT,
 	"DO":             DO,
 	"NOTHING":        NOTHING,
+	"IGNORE":         IGNORE,
 	"UPSERT":         UPSERT,
 	"INTO":           INTO,
 	"VALUES":         VALUES,
@@ -70,6 +77,7 @@ var reservedWords = map[string]int{
 	"GROUP":          GROUP,
 	"BY":             BY,
 	"LIMIT":          LIMIT,
+	"OFFSET":         OFFSET,
 	"ORDER":          ORDER,
 	"AS":             AS,
 	"ASC":            ASC,
@@ -78,17 +86,34 @@ var reservedWords = map[string]int{
 	"LIKE":           LIKE,
 	"EXISTS":         EXISTS,
 	"IN":             IN,
+	"BETWEEN":        BETWEEN,
 	"AUTO_INCREMENT": AUTO_INCREMENT,
 	"NULL":           NULL,
 	"IF":             IF,
 	"IS":             IS,
 	"CAST":           CAST,
+	"CASE":           CASE,
+	"WHEN":           WHEN,
+	"THEN":           THEN,
+	"ELSE":           ELSE,
+	"INTERVAL":       INTERVAL,
+	"UNION":          UNION,
+	"INTERSECT":      INTERSECT,
+	"EXCEPT":         EXCEPT,
+	"ALL":            ALL,
+	"OUTER":          OUTER,
+	"END":            END,
+	"MATERIALIZED":   MATERIALIZED,
+	"VIEW":           VIEW,
+	"REFRESH":        REFRESH,
 }
 
 var joinTypes = map[string]JoinType{
 	"INNER": InnerJoin,
 	"LEFT":  LeftJoin,
 	"RIGHT": RightJoin,
+	"FULL":  FullJoin,
+	"CROSS": CrossJoin,
 }
 
 var types = map[string]SQLValueType{
@@ -97,6 +122,8 @@ var types = map[string]SQLValueType{
 	"VARCHAR":   VarcharType,
 	"BLOB":      BLOBType,
 	"TIMESTAMP": TimestampType,
+	"FLOAT":     FloatType,
+	"DECIMAL":   DecimalType,
 }
 
 var aggregateFns = map[string]AggregateFn{
@@ -152,6 +179,9 @@ type aheadByteReader struct {
 	nextErr   error
 	r         io.ByteReader
 	readCount int
+
+	recording bool
+	recorded  []byte // bytes read while recording, used to keep the source text of a statement
 }
 
 func newAheadByteReader(r io.ByteReader) *aheadByteReader {
@@ -169,6 +199,10 @@ func (ar *aheadByteReader) ReadByte() (byte, error) {
 
 	ar.readCount++
 
+	if ar.recording && ar.nextErr == nil {
+		ar.recorded = append(ar.recorded, ar.nextChar)
+	}
+
 	return ar.nextChar, ar.nextErr
 }
 
@@ -180,6 +214,34 @@ func (ar *aheadByteReader) NextByte() (byte, error) {
 	return ar.nextChar, ar.nextErr
 }
 
+// Keywords returns the reserved words, types and aggregate functions of the language, sorted alphabetically
+func Keywords() []string {
+	keywords := make([]string, 0, len(reservedWords)+len(types)+len(aggregateFns)+len(boolValues)+len(logicOps)+len(joinTypes))
+
+	for w := range reservedWords {
+		keywords = append(keywords, w)
+	}
+	for t := range types {
+		keywords = append(keywords, t)
+	}
+	for fn := range aggregateFns {
+		keywords = append(keywords, fn)
+	}
+	for b := range boolValues {
+		keywords = append(keywords, b)
+	}
+	for op := range logicOps {
+		keywords = append(keywords, op)
+	}
+	for j := range joinTypes {
+		keywords = append(keywords, j)
+	}
+
+	sort.Strings(keywords)
+
+	return keywords
+}
+
 func ParseString(sql string) ([]SQLStmt, error) {
 	return Parse(strings.NewReader(sql))
 }
@@ -206,6 +268,7 @@ func (l *lexer) Lex(lval *yySymType) int {
 	for {
 		ch, err = l.r.ReadByte()
 		if err == io.EOF {
+			l.r.recording = false
 			return 0
 		}
 		if err != nil {
@@ -252,6 +315,11 @@ func (l *lexer) Lex(lval *yySymType) int {
 	}
 
 	if isSeparator(ch) {
+		if l.r.recording {
+			l.r.recording = false
+			l.r.recorded = l.r.recorded[:len(l.r.recorded)-1]
+		}
+
 		return STMT_SEPARATOR
 	}
 
@@ -331,6 +399,25 @@ func (l *lexer) Lex(lval *yySymType) int {
 			return ERROR
 		}
 
+		if l.r.nextChar == '.' {
+			l.r.ReadByte() // consume decimal point
+
+			fraction, err := l.readNumber()
+			if err != nil {
+				lval.err = err
+				return ERROR
+			}
+
+			val, err := strconv.ParseFloat(fmt.Sprintf("%c%s.%s", ch, tail, fraction), 64)
+			if err != nil {
+				lval.err = err
+				return ERROR
+			}
+
+			lval.float = val
+			return FLOAT
+		}
+
 		val, err := strconv.ParseUint(fmt.Sprintf("%c%s", ch, tail), 10, 64)
 		if err != nil {
 			lval.err = err
@@ -341,6 +428,22 @@ func (l *lexer) Lex(lval *yySymType) int {
 		return NUMBER
 	}
 
+	// regular expression matching operators, ~ and !~
+	if ch == '~' {
+		lval.boolean = false
+		return MATCHES
+	}
+
+	if ch == '!' {
+		next, err := l.r.NextByte()
+		if err == nil && next == '~' {
+			l.r.ReadByte()
+
+			lval.boolean = true
+			return MATCHES
+		}
+	}
+
 	if isComparison(ch) {
 		tail, err := l.readComparison()
 		if err != nil {
@@ -443,6 +546,43 @@ func (l *lexer) Error(err string) {
 	l.err = fmt.Errorf("%s at position %d", err, l.r.ReadCount())
 }
 
+// captureSQL starts recording the source text, which is recorded up to the end of the current statement
+func (l *lexer) captureSQL() {
+	l.r.recording = true
+	l.r.recorded = l.r.recorded[:0]
+}
+
+// capturedSQL returns the source text recorded since captureSQL was called
+func (l *lexer) capturedSQL() string {
+	l.r.recording = false
+	return strings.TrimSpace(string(l.r.recorded))
+}
+
+// checkSQL returns the expression of a CHECK constraint out of its captured source text,
+// which is recorded up to the closing parenthesis
+func checkSQL(captured string) string {
+	return strings.TrimSpace(strings.TrimSuffix(captured, ")"))
+}
+
+// parseCheckExp parses the persisted expression of a CHECK constraint
+func parseCheckExp(sql string) (ValueExp, error) {
+	stmts, err := ParseString(fmt.Sprintf("SELECT * FROM t WHERE %s", sql))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stmts) != 1 {
+		return nil, ErrInvalidCheckConstraint
+	}
+
+	stmt, ok := stmts[0].(*SelectStmt)
+	if !ok || stmt.where == nil {
+		return nil, ErrInvalidCheckConstraint
+	}
+
+	return stmt.where, nil
+}
+
 func (l *lexer) readWord() (string, error) {
 	return l.readWhile(func(ch byte) bool {
 		return isLetter(ch) || isNumber(ch)
@@ -43,7 +43,7 @@ func TestCreateDatabaseStmt(t *testing.T) {
 		{
 			input:          "CREATE db1",
 			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting DATABASE or TABLE or UNIQUE or INDEX at position 10"),
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER at position 10"),
 		},
 	}
 
@@ -98,6 +98,20 @@ func TestUseSnapshotStmt(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			input: "USE SNAPSHOT SINCE TX 10 UP TO TX 20",
+			expectedOutput: []SQLStmt{
+				&UseSnapshotStmt{sinceTx: uint64(10), upToTx: uint64(20)},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "USE SNAPSHOT BEFORE TX 20",
+			expectedOutput: []SQLStmt{
+				&UseSnapshotStmt{asBefore: uint64(20)},
+			},
+			expectedError: nil,
+		},
 		{
 			input:          "USE SNAPSHOT SINCE 10",
 			expectedOutput: nil,
@@ -115,6 +129,93 @@ func TestUseSnapshotStmt(t *testing.T) {
 	}
 }
 
+func TestDropStmt(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedOutput []SQLStmt
+		expectedError  error
+	}{
+		{
+			input:          "DROP DATABASE db1",
+			expectedOutput: []SQLStmt{&DropDatabaseStmt{DB: "db1"}},
+			expectedError:  nil,
+		},
+		{
+			input:          "DROP DATABASE IF EXISTS db1",
+			expectedOutput: []SQLStmt{&DropDatabaseStmt{DB: "db1", ifExists: true}},
+			expectedError:  nil,
+		},
+		{
+			input:          "DROP TABLE table1",
+			expectedOutput: []SQLStmt{&DropTableStmt{table: "table1"}},
+			expectedError:  nil,
+		},
+		{
+			input:          "DROP TABLE IF EXISTS table1",
+			expectedOutput: []SQLStmt{&DropTableStmt{table: "table1", ifExists: true}},
+			expectedError:  nil,
+		},
+		{
+			input:          "DROP SYNONYM IF EXISTS syn1",
+			expectedOutput: []SQLStmt{&DropSynonymStmt{synonym: "syn1", ifExists: true}},
+			expectedError:  nil,
+		},
+		{
+			input:          "CREATE SYNONYM syn1 FOR table1",
+			expectedOutput: []SQLStmt{&CreateSynonymStmt{synonym: "syn1", table: "table1"}},
+			expectedError:  nil,
+		},
+		{
+			input:          "REFRESH MATERIALIZED VIEW view1",
+			expectedOutput: []SQLStmt{&RefreshMaterializedViewStmt{view: "view1"}},
+			expectedError:  nil,
+		},
+		{
+			input:          "DROP MATERIALIZED VIEW IF EXISTS view1",
+			expectedOutput: []SQLStmt{&DropMaterializedViewStmt{view: "view1", ifExists: true}},
+			expectedError:  nil,
+		},
+		{
+			input:          "DROP table1",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting DATABASE or TABLE or SYNONYM or MATERIALIZED at position 11"),
+		},
+	}
+
+	for i, tc := range testCases {
+		res, err := ParseString(tc.input)
+		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
+
+		if tc.expectedError == nil {
+			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
+		}
+	}
+}
+
+func TestCreateMaterializedViewStmt(t *testing.T) {
+	stmts, err := ParseString(`
+		CREATE MATERIALIZED VIEW IF NOT EXISTS totals AS
+			SELECT category, COUNT(*) AS total FROM orders WHERE amount > 10 GROUP BY category;
+		REFRESH MATERIALIZED VIEW totals`)
+	require.NoError(t, err)
+	require.Len(t, stmts, 2)
+
+	stmt, ok := stmts[0].(*CreateMaterializedViewStmt)
+	require.True(t, ok)
+	require.Equal(t, "totals", stmt.view)
+	require.True(t, stmt.ifNotExists)
+	require.Equal(t, "SELECT category, COUNT(*) AS total FROM orders WHERE amount > 10 GROUP BY category", stmt.sql)
+	require.Len(t, stmt.query.selectors, 2)
+
+	stmts, err = ParseString("CREATE MATERIALIZED VIEW recent AS SELECT * FROM orders ORDER BY id DESC")
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+	require.Equal(t, "SELECT * FROM orders ORDER BY id DESC", stmts[0].(*CreateMaterializedViewStmt).sql)
+
+	_, err = ParseString("CREATE MATERIALIZED VIEW recent SELECT * FROM orders")
+	require.Error(t, err)
+}
+
 func TestCreateTableStmt(t *testing.T) {
 	testCases := []struct {
 		input          string
@@ -143,6 +244,108 @@ func TestCreateTableStmt(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, email VARCHAR[64] NOT NULL UNIQUE, PRIMARY KEY id)",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table:       "table1",
+					ifNotExists: false,
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType, autoIncrement: true},
+						{colName: "email", colType: VarcharType, maxLen: 64, notNull: true, unique: true},
+					},
+					pkColNames: []string{"id"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER, amount INTEGER, CHECK (amount > 0), CHECK(amount <= 100 OR id = 1), PRIMARY KEY id)",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table:       "table1",
+					ifNotExists: false,
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+						{colName: "amount", colType: IntegerType},
+					},
+					checks: []*checkConstraint{
+						{
+							exp: &CmpBoolExp{op: GT, left: &ColSelector{col: "amount"}, right: &Number{val: 0}},
+							sql: "amount > 0",
+						},
+						{
+							exp: &BinBoolExp{
+								op:    OR,
+								left:  &CmpBoolExp{op: LE, left: &ColSelector{col: "amount"}, right: &Number{val: 100}},
+								right: &CmpBoolExp{op: EQ, left: &ColSelector{col: "id"}, right: &Number{val: 1}},
+							},
+							sql: "amount <= 100 OR id = 1",
+						},
+					},
+					pkColNames: []string{"id"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (CHECK (id > 0), id INTEGER, PRIMARY KEY id, amount INTEGER, CHECK (amount > 0))",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table: "table1",
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+						{colName: "amount", colType: IntegerType},
+					},
+					checks: []*checkConstraint{
+						{
+							exp: &CmpBoolExp{op: GT, left: &ColSelector{col: "id"}, right: &Number{val: 0}},
+							sql: "id > 0",
+						},
+						{
+							exp: &CmpBoolExp{op: GT, left: &ColSelector{col: "amount"}, right: &Number{val: 0}},
+							sql: "amount > 0",
+						},
+					},
+					pkColNames: []string{"id"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER CHECK (id > 0), amount INTEGER NOT NULL CHECK (amount <= 100), CHECK (amount > id), PRIMARY KEY id)",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table: "table1",
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+						{colName: "amount", colType: IntegerType, notNull: true},
+					},
+					checks: []*checkConstraint{
+						{
+							exp: &CmpBoolExp{op: GT, left: &ColSelector{col: "id"}, right: &Number{val: 0}},
+							sql: "id > 0",
+						},
+						{
+							exp: &CmpBoolExp{op: LE, left: &ColSelector{col: "amount"}, right: &Number{val: 100}},
+							sql: "amount <= 100",
+						},
+						{
+							exp: &CmpBoolExp{op: GT, left: &ColSelector{col: "amount"}, right: &ColSelector{col: "id"}},
+							sql: "amount > id",
+						},
+					},
+					pkColNames: []string{"id"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input:          "CREATE TABLE table1 (id INTEGER, CHECK (id > 0))",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: missing PRIMARY KEY at position 48"),
+		},
+		{
+			input:          "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id, PRIMARY KEY id)",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: multiple PRIMARY KEY at position 63"),
+		},
 		{
 			input: "CREATE TABLE xtable1 (xid INTEGER, PRIMARY KEY xid)",
 			expectedOutput: []SQLStmt{
@@ -182,10 +385,24 @@ func TestCreateTableStmt(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER, name VARCHAR(50), content BLOB(1024), PRIMARY KEY id)",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table: "table1",
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+						{colName: "name", colType: VarcharType, maxLen: 50},
+						{colName: "content", colType: BLOBType, maxLen: 1024},
+					},
+					pkColNames: []string{"id"},
+				}},
+			expectedError: nil,
+		},
 		{
 			input:          "CREATE table1",
 			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting DATABASE or TABLE or UNIQUE or INDEX at position 13"),
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER at position 13"),
 		},
 		{
 			input:          "CREATE TABLE table1",
@@ -195,7 +412,7 @@ func TestCreateTableStmt(t *testing.T) {
 		{
 			input:          "CREATE TABLE table1()",
 			expectedOutput: []SQLStmt{&CreateTableStmt{table: "table1"}},
-			expectedError:  errors.New("syntax error: unexpected ')', expecting IDENTIFIER at position 21"),
+			expectedError:  errors.New("syntax error: unexpected ')', expecting PRIMARY or CHECK or IDENTIFIER at position 21"),
 		},
 	}
 
@@ -271,10 +488,29 @@ func TestAlterTableStmt(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "ALTER TABLE table1 DROP COLUMN title",
+			expectedOutput: []SQLStmt{
+				&DropColumnStmt{
+					table:   "table1",
+					colName: "title",
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "ALTER TABLE table1 RENAME COLUMN title TO name",
+			expectedOutput: []SQLStmt{
+				&RenameColumnStmt{
+					table:   "table1",
+					oldName: "title",
+					newName: "name",
+				}},
+			expectedError: nil,
+		},
 		{
 			input:          "ALTER TABLE table1 COLUMN title VARCHAR",
 			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected COLUMN, expecting ADD at position 25"),
+			expectedError:  errors.New("syntax error: unexpected COLUMN, expecting ADD or DROP or RENAME at position 25"),
 		},
 	}
 
@@ -319,6 +555,43 @@ func TestInsertIntoStmt(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			input: "INSERT INTO readings(id, temp, amount) VALUES (1, 21.50, CAST('-0.1' AS DECIMAL))",
+			expectedOutput: []SQLStmt{
+				&UpsertIntoStmt{
+					isInsert: true,
+					tableRef: &tableRef{table: "readings"},
+					cols:     []string{"id", "temp", "amount"},
+					rows: []*RowSpec{
+						{Values: []ValueExp{
+							&Number{val: 1},
+							&Float{val: 21.5},
+							&Cast{val: &Varchar{val: "-0.1"}, t: DecimalType},
+						},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "INSERT INTO events(id, ts) VALUES (1, TIMESTAMP '2021-12-03 16:14:21')",
+			expectedOutput: []SQLStmt{
+				&UpsertIntoStmt{
+					isInsert: true,
+					tableRef: &tableRef{table: "events"},
+					cols:     []string{"id", "ts"},
+					rows: []*RowSpec{
+						{Values: []ValueExp{
+							&Number{val: 1},
+							&Cast{val: &Varchar{val: "2021-12-03 16:14:21"}, t: TimestampType},
+						},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
 		{
 			input: "UPSERT INTO table1(id, time, title, active, compressed, payload, note) VALUES (2, now(), '', TRUE, false, x'AED0393F', @param1)",
 			expectedOutput: []SQLStmt{
@@ -462,6 +735,49 @@ func TestInsertIntoStmt(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			input: "INSERT OR IGNORE INTO table1(id, title) VALUES (1, 'title1')",
+			expectedOutput: []SQLStmt{
+				&UpsertIntoStmt{
+					isInsert:   true,
+					tableRef:   &tableRef{table: "table1"},
+					cols:       []string{"id", "title"},
+					rows:       []*RowSpec{{Values: []ValueExp{&Number{val: 1}, &Varchar{val: "title1"}}}},
+					onConflict: &OnConflictDo{},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input:          "INSERT AND IGNORE INTO table1(id, title) VALUES (1, 'title1')",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected AND, expecting OR at position 62"),
+		},
+		{
+			input: "INSERT INTO table1(id, amount) VALUES (1, 10) ON CONFLICT DO UPDATE SET amount = amount + excluded.amount",
+			expectedOutput: []SQLStmt{
+				&UpsertIntoStmt{
+					isInsert: true,
+					tableRef: &tableRef{table: "table1"},
+					cols:     []string{"id", "amount"},
+					rows:     []*RowSpec{{Values: []ValueExp{&Number{val: 1}, &Number{val: 10}}}},
+					onConflict: &OnConflictDo{
+						updates: []*colUpdate{
+							{
+								col: "amount",
+								op:  EQ,
+								val: &NumExp{
+									op:    ADDOP,
+									left:  &ColSelector{col: "amount"},
+									right: &ColSelector{table: "excluded", col: "amount"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
 		{
 			input:          "UPSERT INTO table1() VALUES (2, 'untitled')",
 			expectedOutput: nil,
@@ -697,6 +1013,33 @@ func TestSelectStmt(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "SELECT id, CAST(age AS VARCHAR) AS age_str FROM table1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&Cast{val: &ColSelector{col: "age"}, t: VarcharType, as: "age_str"},
+					},
+					ds: &tableRef{table: "table1"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id, NOW() - INTERVAL '1 day' AS yesterday, age * 2 FROM table1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&NumExp{left: &SysFn{fn: "now"}, op: SUBSOP, right: &Interval{days: 1}, as: "yesterday"},
+						&NumExp{left: &ColSelector{col: "age"}, op: MULTOP, right: &Number{val: 2}},
+					},
+					ds: &tableRef{table: "table1"},
+				}},
+			expectedError: nil,
+		},
 		{
 			input: "SELECT id, title FROM db1.table1 AS t1",
 			expectedOutput: []SQLStmt{
@@ -710,6 +1053,34 @@ func TestSelectStmt(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "SELECT id FROM table1 BEFORE TIMESTAMP '2021-12-01' AS t1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &tableRef{
+						table:      "table1",
+						asBeforeTs: &Cast{val: &Varchar{val: "2021-12-01"}, t: TimestampType},
+						as:         "t1",
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM table1 SINCE TX 10 BEFORE TX 20 t1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &tableRef{table: "table1", sinceTx: 10, asBefore: 20, as: "t1"},
+				}},
+			expectedError: nil,
+		},
 		{
 			input: "SELECT t1.id, title FROM db1.table1 t1",
 			expectedOutput: []SQLStmt{
@@ -959,6 +1330,42 @@ func TestSelectStmt(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "SELECT id FROM table1 FULL OUTER JOIN table2 ON table1.id = table2.id",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{&ColSelector{col: "id"}},
+					ds:        &tableRef{table: "table1"},
+					joins: []*JoinSpec{
+						{
+							joinType: FullJoin,
+							ds:       &tableRef{table: "table2"},
+							cond: &CmpBoolExp{
+								op:    EQ,
+								left:  &ColSelector{table: "table1", col: "id"},
+								right: &ColSelector{table: "table2", col: "id"},
+							},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM table1 CROSS JOIN table2",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{&ColSelector{col: "id"}},
+					ds:        &tableRef{table: "table1"},
+					joins: []*JoinSpec{
+						{
+							joinType: CrossJoin,
+							ds:       &tableRef{table: "table2"},
+							cond:     &Bool{val: true},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
 		{
 			input: "SELECT id, title FROM (SELECT col1 AS id, col2 AS title FROM table2 LIMIT 100) LIMIT 10",
 			expectedOutput: []SQLStmt{
@@ -981,6 +1388,23 @@ func TestSelectStmt(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "SELECT id FROM table1 ORDER BY id LIMIT 10 OFFSET 20",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &tableRef{table: "table1"},
+					orderBy: []*OrdCol{
+						{sel: &ColSelector{col: "id"}},
+					},
+					limit:  10,
+					offset: 20,
+				}},
+			expectedError: nil,
+		},
 		{
 			input: "SELECT id, name, time FROM table1 WHERE time >= '20210101 00:00:00.000' AND time < '20210211 00:00:00.000'",
 			expectedOutput: []SQLStmt{
@@ -1012,6 +1436,36 @@ func TestSelectStmt(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "SELECT id FROM table1 UNION ALL SELECT id FROM table2 INTERSECT SELECT id FROM table3 ORDER BY id LIMIT 10",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					ds: &setOperation{
+						op:  UNIONOP,
+						all: true,
+						left: &SelectStmt{
+							selectors: []Selector{&ColSelector{col: "id"}},
+							ds:        &tableRef{table: "table1"},
+						},
+						right: &SelectStmt{
+							ds: &setOperation{
+								op: INTERSECTOP,
+								left: &SelectStmt{
+									selectors: []Selector{&ColSelector{col: "id"}},
+									ds:        &tableRef{table: "table2"},
+								},
+								right: &SelectStmt{
+									selectors: []Selector{&ColSelector{col: "id"}},
+									ds:        &tableRef{table: "table3"},
+								},
+							},
+						},
+					},
+					orderBy: []*OrdCol{{sel: &ColSelector{col: "id"}}},
+					limit:   10,
+				}},
+			expectedError: nil,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -1232,6 +1686,88 @@ func TestExpressions(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "SELECT id FROM table1 WHERE title ~ '^J.*O$' AND title !~ @param1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &tableRef{table: "table1"},
+					where: &BinBoolExp{
+						op: AND,
+						left: &RegexpBoolExp{
+							val:     &ColSelector{col: "title"},
+							pattern: &Varchar{val: "^J.*O$"},
+						},
+						right: &RegexpBoolExp{
+							val:      &ColSelector{col: "title"},
+							notMatch: true,
+							pattern:  &Param{id: "param1"},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id, CASE WHEN amount > 10 THEN 'high' WHEN amount > 5 THEN 'medium' ELSE 'low' END AS level FROM table1 WHERE CASE WHEN active THEN amount END > 0",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&CaseWhenExp{
+							whens: []*whenThen{
+								{
+									cond: &CmpBoolExp{op: GT, left: &ColSelector{col: "amount"}, right: &Number{val: 10}},
+									then: &Varchar{val: "high"},
+								},
+								{
+									cond: &CmpBoolExp{op: GT, left: &ColSelector{col: "amount"}, right: &Number{val: 5}},
+									then: &Varchar{val: "medium"},
+								},
+							},
+							elseExp: &Varchar{val: "low"},
+							as:      "level",
+						},
+					},
+					ds: &tableRef{table: "table1"},
+					where: &CmpBoolExp{
+						op: GT,
+						left: &CaseWhenExp{
+							whens: []*whenThen{
+								{
+									cond: &ColSelector{col: "active"},
+									then: &ColSelector{col: "amount"},
+								},
+							},
+						},
+						right: &Number{val: 0},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT UPPER(name) AS uname, SUBSTRING(name, 1, @len) FROM table1 WHERE LENGTH(CONCAT(name, 'x')) > 3",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&SysFn{fn: "upper", params: []ValueExp{&ColSelector{col: "name"}}, as: "uname"},
+						&SysFn{fn: "substring", params: []ValueExp{&ColSelector{col: "name"}, &Number{val: 1}, &Param{id: "len"}}},
+					},
+					ds: &tableRef{table: "table1"},
+					where: &CmpBoolExp{
+						op: GT,
+						left: &SysFn{fn: "length", params: []ValueExp{
+							&SysFn{fn: "concat", params: []ValueExp{&ColSelector{col: "name"}, &Varchar{val: "x"}}},
+						}},
+						right: &Number{val: 3},
+					},
+				}},
+			expectedError: nil,
+		},
 		{
 			input: "SELECT id FROM table1 WHERE table1.title LIKE @param1",
 			expectedOutput: []SQLStmt{
@@ -1363,6 +1899,57 @@ func TestExpressions(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "SELECT id FROM table1 WHERE id BETWEEN 1 AND @upper AND active",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &tableRef{table: "table1"},
+					where: &BinBoolExp{
+						op: AND,
+						left: &BinBoolExp{
+							op:    AND,
+							left:  &CmpBoolExp{op: GE, left: &ColSelector{col: "id"}, right: &Number{val: 1}},
+							right: &CmpBoolExp{op: LE, left: &ColSelector{col: "id"}, right: &Param{id: "upper"}},
+						},
+						right: &ColSelector{col: "active"},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM table1 WHERE (id + 1) NOT BETWEEN 1 AND 10",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &tableRef{table: "table1"},
+					where: &NotBoolExp{
+						exp: &BinBoolExp{
+							op: AND,
+							left: &CmpBoolExp{
+								op:    GE,
+								left:  &NumExp{op: ADDOP, left: &ColSelector{col: "id"}, right: &Number{val: 1}},
+								right: &Number{val: 1},
+							},
+							right: &CmpBoolExp{
+								op:    LE,
+								left:  &NumExp{op: ADDOP, left: &ColSelector{col: "id"}, right: &Number{val: 1}},
+								right: &Number{val: 10},
+							},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input:          "SELECT id FROM table1 WHERE id BETWEEN 1 OR 10",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected OR, expecting AND at position 46"),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -23,9 +23,14 @@ type projectedRowReader struct {
 	tableAlias string
 
 	selectors []Selector
+
+	params map[string]interface{}
+
+	// expression selectors with parameters substituted, by position
+	substituted map[int]ValueExp
 }
 
-func newProjectedRowReader(rowReader RowReader, tableAlias string, selectors []Selector) (*projectedRowReader, error) {
+func newProjectedRowReader(rowReader RowReader, tableAlias string, selectors []Selector, params map[string]interface{}) (*projectedRowReader, error) {
 	// case: SELECT *
 	if len(selectors) == 0 {
 		cols, err := rowReader.Columns()
@@ -44,9 +49,11 @@ func newProjectedRowReader(rowReader RowReader, tableAlias string, selectors []S
 	}
 
 	return &projectedRowReader{
-		rowReader:  rowReader,
-		tableAlias: tableAlias,
-		selectors:  selectors,
+		rowReader:   rowReader,
+		tableAlias:  tableAlias,
+		selectors:   selectors,
+		params:      params,
+		substituted: make(map[int]ValueExp),
 	}, nil
 }
 
@@ -70,8 +77,40 @@ func (pr *projectedRowReader) TableAlias() string {
 	return pr.tableAlias
 }
 
+// OrderBy returns the ordering of the underlying reader, naming the columns as projected
+// so a derived table keeps its ordering when referenced by its alias
 func (pr *projectedRowReader) OrderBy() []ColDescriptor {
-	return pr.rowReader.OrderBy()
+	orderBy := pr.rowReader.OrderBy()
+
+	cols := make([]ColDescriptor, len(orderBy))
+
+	for i, ordCol := range orderBy {
+		cols[i] = ordCol
+
+		for j, sel := range pr.selectors {
+			if isExpSelector(sel) {
+				continue
+			}
+
+			aggFn, db, table, col := sel.resolve(pr.rowReader.Database().Name(), pr.rowReader.TableAlias())
+			if aggFn != "" || EncodeSelector(aggFn, db, table, col) != ordCol.Selector() {
+				continue
+			}
+
+			db, table, col = pr.projectedCol(j, sel)
+
+			cols[i] = ColDescriptor{
+				Database: db,
+				Table:    table,
+				Column:   col,
+				Type:     ordCol.Type,
+			}
+
+			break
+		}
+	}
+
+	return cols
 }
 
 func (pr *projectedRowReader) ScanSpecs() *ScanSpecs {
@@ -87,27 +126,9 @@ func (pr *projectedRowReader) Columns() ([]ColDescriptor, error) {
 	colsByPos := make([]ColDescriptor, len(pr.selectors))
 
 	for i, sel := range pr.selectors {
-		aggFn, db, table, col := sel.resolve(pr.rowReader.Database().Name(), pr.rowReader.TableAlias())
-
-		if pr.tableAlias != "" {
-			db = pr.Database().Name()
-			table = pr.tableAlias
-		}
-
-		if aggFn == "" && sel.alias() != "" {
-			col = sel.alias()
-		}
-
-		if aggFn != "" {
-			aggFn = ""
-			col = sel.alias()
-			if col == "" {
-				col = fmt.Sprintf("col%d", i)
-			}
-		}
+		db, table, col := pr.projectedCol(i, sel)
 
 		colsByPos[i] = ColDescriptor{
-			AggFn:    aggFn,
 			Database: db,
 			Table:    table,
 			Column:   col,
@@ -130,38 +151,31 @@ func (pr *projectedRowReader) colsBySelector() (map[string]ColDescriptor, error)
 	colDescriptors := make(map[string]ColDescriptor, len(pr.selectors))
 
 	for i, sel := range pr.selectors {
-		aggFn, db, table, col := sel.resolve(pr.rowReader.Database().Name(), pr.rowReader.TableAlias())
-
-		encSel := EncodeSelector(aggFn, db, table, col)
+		var colType SQLValueType
 
-		colDesc, ok := dsColDescriptors[encSel]
-		if !ok {
-			return nil, ErrColumnDoesNotExist
-		}
+		if isExpSelector(sel) {
+			colType, err = sel.inferType(dsColDescriptors, make(map[string]SQLValueType), pr.rowReader.Database().Name(), pr.rowReader.TableAlias())
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			aggFn, db, table, col := sel.resolve(pr.rowReader.Database().Name(), pr.rowReader.TableAlias())
 
-		if pr.tableAlias != "" {
-			db = pr.Database().Name()
-			table = pr.tableAlias
-		}
+			colDesc, ok := dsColDescriptors[EncodeSelector(aggFn, db, table, col)]
+			if !ok {
+				return nil, ErrColumnDoesNotExist
+			}
 
-		if aggFn == "" && sel.alias() != "" {
-			col = sel.alias()
+			colType = colDesc.Type
 		}
 
-		if aggFn != "" {
-			aggFn = ""
-			col = sel.alias()
-			if col == "" {
-				col = fmt.Sprintf("col%d", i)
-			}
-		}
+		db, table, col := pr.projectedCol(i, sel)
 
 		des := ColDescriptor{
-			AggFn:    aggFn,
 			Database: db,
 			Table:    table,
 			Column:   col,
-			Type:     colDesc.Type,
+			Type:     colType,
 		}
 
 		colDescriptors[des.Selector()] = des
@@ -171,11 +185,40 @@ func (pr *projectedRowReader) colsBySelector() (map[string]ColDescriptor, error)
 }
 
 func (pr *projectedRowReader) InferParameters(params map[string]SQLValueType) error {
-	return pr.rowReader.InferParameters(params)
+	err := pr.rowReader.InferParameters(params)
+	if err != nil {
+		return err
+	}
+
+	cols, err := pr.rowReader.colsBySelector()
+	if err != nil {
+		return err
+	}
+
+	for _, sel := range pr.selectors {
+		if !isExpSelector(sel) {
+			continue
+		}
+
+		_, err = sel.inferType(cols, params, pr.rowReader.Database().Name(), pr.rowReader.TableAlias())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (pr *projectedRowReader) SetParameters(params map[string]interface{}) error {
-	return pr.rowReader.SetParameters(params)
+	err := pr.rowReader.SetParameters(params)
+	if err != nil {
+		return err
+	}
+
+	pr.params, err = normalizeParams(params)
+	pr.substituted = make(map[int]ValueExp)
+
+	return err
 }
 
 func (pr *projectedRowReader) Read() (*Row, error) {
@@ -189,36 +232,80 @@ func (pr *projectedRowReader) Read() (*Row, error) {
 	}
 
 	for i, sel := range pr.selectors {
-		aggFn, db, table, col := sel.resolve(pr.rowReader.Database().Name(), pr.rowReader.TableAlias())
+		var val TypedValue
 
-		encSel := EncodeSelector(aggFn, db, table, col)
+		if isExpSelector(sel) {
+			val, err = pr.reduceExpSelector(i, sel, row)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			aggFn, db, table, col := sel.resolve(pr.rowReader.Database().Name(), pr.rowReader.TableAlias())
 
-		val, ok := row.Values[encSel]
-		if !ok {
-			return nil, ErrColumnDoesNotExist
-		}
+			v, ok := row.Values[EncodeSelector(aggFn, db, table, col)]
+			if !ok {
+				return nil, ErrColumnDoesNotExist
+			}
 
-		if pr.tableAlias != "" {
-			db = pr.Database().Name()
-			table = pr.tableAlias
+			val = v
 		}
 
-		if aggFn == "" && sel.alias() != "" {
-			col = sel.alias()
-		}
+		db, table, col := pr.projectedCol(i, sel)
 
-		if aggFn != "" {
-			aggFn = ""
-			col = sel.alias()
-			if col == "" {
-				col = fmt.Sprintf("col%d", i)
-			}
+		prow.Values[EncodeSelector("", db, table, col)] = val
+	}
+
+	return prow, nil
+}
+
+// isExpSelector returns true when the selector is computed from the row instead of
+// being read from it
+func isExpSelector(sel Selector) bool {
+	switch sel.(type) {
+	case *CaseWhenExp, *SysFn, *Cast, *NumExp:
+		return true
+	}
+	return false
+}
+
+func (pr *projectedRowReader) reduceExpSelector(i int, sel Selector, row *Row) (TypedValue, error) {
+	exp, ok := pr.substituted[i]
+	if !ok {
+		var err error
+
+		exp, err = sel.substitute(pr.params)
+		if err != nil {
+			return nil, err
 		}
 
-		prow.Values[EncodeSelector(aggFn, db, table, col)] = val
+		pr.substituted[i] = exp
 	}
 
-	return prow, nil
+	return exp.reduce(pr.Tx(), row, pr.rowReader.Database().Name(), pr.rowReader.TableAlias())
+}
+
+// projectedCol returns how the i-th selector is named once projected, taking into account
+// the alias of the table and the one of the column, if any
+func (pr *projectedRowReader) projectedCol(i int, sel Selector) (db, table, col string) {
+	aggFn, db, table, col := sel.resolve(pr.rowReader.Database().Name(), pr.rowReader.TableAlias())
+
+	if pr.tableAlias != "" {
+		db = pr.Database().Name()
+		table = pr.tableAlias
+	}
+
+	if aggFn == "" && sel.alias() != "" {
+		col = sel.alias()
+	}
+
+	if aggFn != "" || isExpSelector(sel) {
+		col = sel.alias()
+		if col == "" {
+			col = fmt.Sprintf("col%d", i)
+		}
+	}
+
+	return db, table, col
 }
 
 func (pr *projectedRowReader) Close() error {
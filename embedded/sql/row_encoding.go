@@ -0,0 +1,414 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/codenotary/immudb/embedded/logger"
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// Row values are prefixed with a format byte so the encoding can evolve without breaking existing data.
+// Rows written before versioning was introduced start with the (big endian) number of encoded columns,
+// which makes their first byte zero and allows them to be decoded as RowFormatV0.
+const (
+	RowFormatV0 byte = iota // {count}({colID}{encVal})*
+	RowFormatV1             // {format}{count}({colID}{encVal})*
+	RowFormatV2             // {format}{uvarint count}({uvarint colID}{uvarint len}{val})*
+)
+
+const CurrentRowFormat = RowFormatV2
+
+const DefaultRowFormatUpgradeBatchSize = 1000
+
+// MaxRowFormatUpgradeRetries is the number of times a batch is retried due to concurrent writes
+// before the upgrade is given up
+const MaxRowFormatUpgradeRetries = 10
+
+func encodeRow(table *Table, valuesByColID map[uint32]TypedValue) ([]byte, error) {
+	return encodeRowAs(CurrentRowFormat, table, valuesByColID)
+}
+
+func encodeRowAs(format byte, table *Table, valuesByColID map[uint32]TypedValue) ([]byte, error) {
+	if format > CurrentRowFormat {
+		return nil, ErrUnsupportedRowFormat
+	}
+
+	valbuf := bytes.Buffer{}
+
+	if format != RowFormatV0 {
+		err := valbuf.WriteByte(format)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// null values are not serialized
+	encodedVals := 0
+	for _, v := range valuesByColID {
+		if !v.IsNull() {
+			encodedVals++
+		}
+	}
+
+	var uvarint [binary.MaxVarintLen64]byte
+
+	writeUint32 := func(n uint32) error {
+		if format == RowFormatV2 {
+			_, err := valbuf.Write(uvarint[:binary.PutUvarint(uvarint[:], uint64(n))])
+			return err
+		}
+
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, n)
+
+		_, err := valbuf.Write(b)
+		return err
+	}
+
+	err := writeUint32(uint32(encodedVals))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, col := range table.cols {
+		rval, specified := valuesByColID[col.id]
+		if !specified || rval.IsNull() {
+			continue
+		}
+
+		err = writeUint32(col.id)
+		if err != nil {
+			return nil, err
+		}
+
+		encVal, err := EncodeValue(rval.Value(), col.colType, col.MaxLen())
+		if err != nil {
+			return nil, err
+		}
+
+		if format == RowFormatV2 {
+			// fixed-size length prefix is replaced by a varint one
+			encVal = encVal[EncLenLen:]
+
+			err = writeUint32(uint32(len(encVal)))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = valbuf.Write(encVal)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return valbuf.Bytes(), nil
+}
+
+// RowFormat returns the format used to encode the row
+func RowFormat(encodedRow []byte) (byte, error) {
+	if len(encodedRow) == 0 {
+		return 0, ErrCorruptedData
+	}
+
+	if encodedRow[0] > CurrentRowFormat {
+		return 0, ErrUnsupportedRowFormat
+	}
+
+	return encodedRow[0], nil
+}
+
+// DecodeRow decodes the non-null values of an encoded row, regardless of the format used to encode it
+func DecodeRow(encodedRow []byte, colTypes map[uint32]SQLValueType) (map[uint32]TypedValue, error) {
+	return decodeRow(encodedRow, func(colID uint32) (SQLValueType, error) {
+		colType, ok := colTypes[colID]
+		if !ok {
+			return "", ErrCorruptedData
+		}
+		return colType, nil
+	})
+}
+
+// decodeRow returns the values of the columns of the table, values of dropped columns are discarded
+func (t *Table) decodeRow(encodedRow []byte) (map[uint32]TypedValue, error) {
+	values := make(map[uint32]TypedValue)
+
+	err := decodeRowWith(encodedRow, t.colTypeByID, func(colID uint32, val TypedValue) {
+		if _, ok := t.colsByID[colID]; ok {
+			values[colID] = val
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (t *Table) colTypeByID(colID uint32) (SQLValueType, error) {
+	col, ok := t.colsByID[colID]
+	if !ok {
+		col, ok = t.droppedColsByID[colID]
+	}
+	if !ok {
+		return "", ErrCorruptedData
+	}
+	return col.colType, nil
+}
+
+func decodeRow(encodedRow []byte, colTypeByID func(colID uint32) (SQLValueType, error)) (map[uint32]TypedValue, error) {
+	values := make(map[uint32]TypedValue)
+
+	err := decodeRowWith(encodedRow, colTypeByID, func(colID uint32, val TypedValue) {
+		values[colID] = val
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// decodeRowWith decodes the row without building intermediate structures, decoded values are passed to the callback
+func decodeRowWith(encodedRow []byte, colTypeByID func(colID uint32) (SQLValueType, error), fn func(colID uint32, val TypedValue)) error {
+	format, err := RowFormat(encodedRow)
+	if err != nil {
+		return err
+	}
+
+	voff := 0
+
+	if format != RowFormatV0 {
+		voff++
+	}
+
+	readUint32 := func() (uint32, error) {
+		if format == RowFormatV2 {
+			n, l := binary.Uvarint(encodedRow[voff:])
+			if l <= 0 || n > 0xffffffff {
+				return 0, ErrCorruptedData
+			}
+
+			voff += l
+			return uint32(n), nil
+		}
+
+		if len(encodedRow) < voff+4 {
+			return 0, ErrCorruptedData
+		}
+
+		n := binary.BigEndian.Uint32(encodedRow[voff:])
+		voff += 4
+
+		return n, nil
+	}
+
+	cols, err := readUint32()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < int(cols); i++ {
+		colID, err := readUint32()
+		if err != nil {
+			return err
+		}
+
+		colType, err := colTypeByID(colID)
+		if err != nil {
+			return err
+		}
+
+		if format == RowFormatV2 {
+			vlen, err := readUint32()
+			if err != nil {
+				return err
+			}
+
+			if len(encodedRow) < voff+int(vlen) {
+				return ErrCorruptedData
+			}
+
+			val, err := decodeValuePayload(encodedRow[voff:voff+int(vlen)], colType)
+			if err != nil {
+				return err
+			}
+
+			voff += int(vlen)
+			fn(colID, val)
+
+			continue
+		}
+
+		val, n, err := DecodeValue(encodedRow[voff:], colType)
+		if err != nil {
+			return err
+		}
+
+		voff += n
+		fn(colID, val)
+	}
+
+	if len(encodedRow)-voff > 0 {
+		return ErrCorruptedData
+	}
+
+	return nil
+}
+
+// UpgradeRowFormat rewrites the rows of the specified table which are not encoded using the current format.
+// Rows are rewritten in batches, each one committed in its own transaction, so it can be run in background
+// while the table is in use; rows decoded using older formats remain readable in the meantime.
+// The upgrade is interrupted once ctx is done, or with store.ErrTxReadConflict once a batch conflicted with
+// concurrent writes more than MaxRowFormatUpgradeRetries times in a row; it can then be resumed by calling it again.
+func (e *Engine) UpgradeRowFormat(ctx context.Context, dbName, tableName string, batchSize int) (upgraded int, err error) {
+	if ctx == nil || batchSize <= 0 {
+		return 0, ErrIllegalArguments
+	}
+
+	var seekKey []byte
+	retries := 0
+
+	for {
+		if ctx.Err() != nil {
+			return upgraded, ErrCancellationRequested
+		}
+
+		n, lastKey, err := e.upgradeRowFormatBatch(ctx, dbName, tableName, seekKey, batchSize)
+		if err == store.ErrTxReadConflict && retries < MaxRowFormatUpgradeRetries {
+			// concurrent writes, the batch is retried from the same position
+			retries++
+			e.log.Debug("Row format upgrade batch retried due to concurrent writes", logger.F("database", dbName), logger.F("table", tableName), logger.F("retries", retries))
+			continue
+		}
+		if err != nil {
+			return upgraded, err
+		}
+
+		upgraded += n
+		retries = 0
+
+		if lastKey == nil {
+			e.log.Info("Row format successfully upgraded", logger.F("database", dbName), logger.F("table", tableName), logger.F("rows", upgraded))
+			return upgraded, nil
+		}
+
+		seekKey = lastKey
+	}
+}
+
+func (e *Engine) upgradeRowFormatBatch(ctx context.Context, dbName, tableName string, seekKey []byte, batchSize int) (upgraded int, lastKey []byte, err error) {
+	tx, err := e.newTx(ctx, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() {
+		if !tx.closed {
+			tx.Cancel()
+		}
+	}()
+
+	table, err := tx.catalog.GetTableByName(dbName, tableName)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	rSpec := &store.KeyReaderSpec{
+		SeekKey: seekKey,
+		Prefix:  mapKey(tx.sqlPrefix(), PIndexPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(PKIndexID)),
+		Filter:  store.IgnoreDeleted,
+	}
+
+	r, err := tx.newKeyReader(rSpec)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var upgradedKeys, upgradedRows [][]byte
+
+	read := 0
+
+	for ; read < batchSize; read++ {
+		mkey, vref, err := r.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			r.Close()
+			return 0, nil, err
+		}
+
+		lastKey = mkey
+
+		v, err := vref.Resolve()
+		if err != nil {
+			r.Close()
+			return 0, nil, err
+		}
+
+		format, err := RowFormat(v)
+		if err != nil {
+			r.Close()
+			return 0, nil, err
+		}
+
+		if format == CurrentRowFormat {
+			continue
+		}
+
+		valuesByColID, err := table.decodeRow(v)
+		if err != nil {
+			r.Close()
+			return 0, nil, err
+		}
+
+		encodedRow, err := encodeRow(table, valuesByColID)
+		if err != nil {
+			r.Close()
+			return 0, nil, err
+		}
+
+		upgradedKeys = append(upgradedKeys, mkey)
+		upgradedRows = append(upgradedRows, encodedRow)
+	}
+
+	err = r.Close()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if read < batchSize {
+		lastKey = nil
+	}
+
+	for i, mkey := range upgradedKeys {
+		err = tx.set(mkey, nil, upgradedRows[i])
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	err = tx.commit()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return len(upgradedKeys), lastKey, nil
+}
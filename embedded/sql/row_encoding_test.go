@@ -0,0 +1,263 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowEncoding(t *testing.T) {
+	db, err := newCatalog().newDatabase(1, "db1")
+	require.NoError(t, err)
+
+	table, err := db.newTable("table1", []*ColSpec{
+		{colName: "id", colType: IntegerType},
+		{colName: "title", colType: VarcharType, maxLen: 10},
+		{colName: "active", colType: BooleanType},
+	})
+	require.NoError(t, err)
+
+	valuesByColID := map[uint32]TypedValue{
+		1: &Number{val: 10},
+		2: &Varchar{val: "title1"},
+		3: &NullValue{t: BooleanType},
+	}
+
+	encodedRow, err := encodeRow(table, valuesByColID)
+	require.NoError(t, err)
+
+	format, err := RowFormat(encodedRow)
+	require.NoError(t, err)
+	require.Equal(t, CurrentRowFormat, format)
+
+	decodedValues, err := table.decodeRow(encodedRow)
+	require.NoError(t, err)
+	require.Len(t, decodedValues, 2)
+	require.Equal(t, int64(10), decodedValues[1].Value())
+	require.Equal(t, "title1", decodedValues[2].Value())
+
+	_, err = DecodeRow(encodedRow, map[uint32]SQLValueType{1: IntegerType})
+	require.Equal(t, ErrCorruptedData, err)
+
+	decodedValues, err = DecodeRow(encodedRow, map[uint32]SQLValueType{1: IntegerType, 2: VarcharType})
+	require.NoError(t, err)
+	require.Len(t, decodedValues, 2)
+
+	t.Run("rows encoded with previous formats should be decoded", func(t *testing.T) {
+		for format := RowFormatV0; format <= CurrentRowFormat; format++ {
+			encodedRow, err := encodeRowAs(format, table, valuesByColID)
+			require.NoError(t, err)
+
+			f, err := RowFormat(encodedRow)
+			require.NoError(t, err)
+			require.Equal(t, format, f)
+
+			decodedValues, err := table.decodeRow(encodedRow)
+			require.NoError(t, err)
+			require.Len(t, decodedValues, 2)
+			require.Equal(t, int64(10), decodedValues[1].Value())
+			require.Equal(t, "title1", decodedValues[2].Value())
+		}
+
+		_, err := encodeRowAs(CurrentRowFormat+1, table, valuesByColID)
+		require.Equal(t, ErrUnsupportedRowFormat, err)
+	})
+
+	t.Run("compact format should reduce row size", func(t *testing.T) {
+		v1Row, err := encodeRowAs(RowFormatV1, table, valuesByColID)
+		require.NoError(t, err)
+
+		v2Row, err := encodeRowAs(RowFormatV2, table, valuesByColID)
+		require.NoError(t, err)
+
+		require.Less(t, len(v2Row), len(v1Row))
+	})
+
+	t.Run("invalid rows should be rejected", func(t *testing.T) {
+		_, err := RowFormat(nil)
+		require.Equal(t, ErrCorruptedData, err)
+
+		_, err = table.decodeRow([]byte{CurrentRowFormat + 1, 0, 0, 0, 0})
+		require.Equal(t, ErrUnsupportedRowFormat, err)
+
+		_, err = table.decodeRow([]byte{RowFormatV1, 0, 0})
+		require.Equal(t, ErrCorruptedData, err)
+
+		_, err = table.decodeRow([]byte{RowFormatV2, 0x80})
+		require.Equal(t, ErrCorruptedData, err)
+
+		_, err = table.decodeRow([]byte{RowFormatV2, 1, 1, 8, 0})
+		require.Equal(t, ErrCorruptedData, err)
+
+		_, err = table.decodeRow(encodedRow[:len(encodedRow)-1])
+		require.Error(t, err)
+
+		_, err = table.decodeRow(append(encodedRow, 0))
+		require.Equal(t, ErrCorruptedData, err)
+	})
+}
+
+func TestUpgradeRowFormat(t *testing.T) {
+	st, err := store.Open("sqldata_upgrade_row_format", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_upgrade_row_format")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, err = engine.UpgradeRowFormat(context.Background(), "db1", "table1", 0)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = engine.UpgradeRowFormat(context.Background(), "db1", "table2", 1)
+	require.Equal(t, ErrTableDoesNotExist, err)
+
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title) VALUES (@id, @title)", map[string]interface{}{"id": i, "title": fmt.Sprintf("title%d", i)}, nil)
+		require.NoError(t, err)
+	}
+
+	catalog, err := engine.Catalog(context.Background(), nil)
+	require.NoError(t, err)
+
+	table, err := catalog.GetTableByName("db1", "table1")
+	require.NoError(t, err)
+
+	pkKey := func(id int64) []byte {
+		encPK, err := EncodeAsKey(id, IntegerType, 8)
+		require.NoError(t, err)
+
+		return mapKey(sqlPrefix, PIndexPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(PKIndexID), encPK)
+	}
+
+	// rewrite even rows using legacy formats
+	tx, err := st.NewTx()
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount; i += 2 {
+		encodedRow, err := encodeRowAs(byte(i%4/2), table, map[uint32]TypedValue{
+			1: &Number{val: int64(i)},
+			2: &Varchar{val: fmt.Sprintf("title%d", i)},
+		})
+		require.NoError(t, err)
+
+		err = tx.Set(pkKey(int64(i)), nil, encodedRow)
+		require.NoError(t, err)
+	}
+
+	_, err = tx.Commit()
+	require.NoError(t, err)
+
+	assertRows := func() {
+		r, err := engine.Query(context.Background(), "SELECT id, title FROM table1", nil, nil)
+		require.NoError(t, err)
+
+		for i := 0; i < rowCount; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, int64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		}
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	}
+
+	assertRows()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = engine.UpgradeRowFormat(ctx, "db1", "table1", 1)
+	require.Equal(t, ErrCancellationRequested, err)
+
+	upgraded, err := engine.UpgradeRowFormat(context.Background(), "db1", "table1", 3)
+	require.NoError(t, err)
+	require.Equal(t, rowCount/2, upgraded)
+
+	for i := 0; i < rowCount; i++ {
+		vref, err := st.Get(pkKey(int64(i)))
+		require.NoError(t, err)
+
+		v, err := vref.Resolve()
+		require.NoError(t, err)
+
+		format, err := RowFormat(v)
+		require.NoError(t, err)
+		require.Equal(t, CurrentRowFormat, format)
+	}
+
+	assertRows()
+
+	upgraded, err = engine.UpgradeRowFormat(context.Background(), "db1", "table1", DefaultRowFormatUpgradeBatchSize)
+	require.NoError(t, err)
+	require.Zero(t, upgraded)
+}
+
+func BenchmarkDecodeRow(b *testing.B) {
+	db, err := newCatalog().newDatabase(1, "db1")
+	require.NoError(b, err)
+
+	table, err := db.newTable("table1", []*ColSpec{
+		{colName: "id", colType: IntegerType},
+		{colName: "title", colType: VarcharType},
+		{colName: "active", colType: BooleanType},
+		{colName: "payload", colType: BLOBType},
+	})
+	require.NoError(b, err)
+
+	valuesByColID := map[uint32]TypedValue{
+		1: &Number{val: 10},
+		2: &Varchar{val: "title1"},
+		3: &Bool{val: true},
+		4: &Blob{val: []byte("payload1")},
+	}
+
+	for format := RowFormatV0; format <= CurrentRowFormat; format++ {
+		encodedRow, err := encodeRowAs(format, table, valuesByColID)
+		require.NoError(b, err)
+
+		b.Run(fmt.Sprintf("format v%d", format), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				err := decodeRowWith(encodedRow, table.colTypeByID, func(colID uint32, val TypedValue) {})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ package sql
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"fmt"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/store"
 )
@@ -31,6 +33,9 @@ type RowReader interface {
 	Read() (*Row, error)
 	Close() error
 	Columns() ([]ColDescriptor, error)
+	// OrderBy returns the columns rows are sorted by. When no ordering is requested rows of a table
+	// are read in primary key order, joint rows follow the order of the first table and then the order
+	// of each joined table, and sorted rows keep the order they were read in as tie-breaker
 	OrderBy() []ColDescriptor
 	ScanSpecs() *ScanSpecs
 	InferParameters(params map[string]SQLValueType) error
@@ -42,6 +47,28 @@ type Row struct {
 	Values map[string]TypedValue
 }
 
+// Copy returns a deep copy of the row.
+// Rows produced by readers may share values, and their underlying buffers, with the rows they were derived from
+// so callers retaining rows and modifying blob values should work with a copy
+func (row *Row) Copy() *Row {
+	values := make(map[string]TypedValue, len(row.Values))
+
+	for sel, val := range row.Values {
+		blob, isBlob := val.(*Blob)
+		if isBlob {
+			b := make([]byte, len(blob.val))
+			copy(b, blob.val)
+
+			values[sel] = &Blob{val: b}
+			continue
+		}
+
+		values[sel] = val
+	}
+
+	return &Row{Values: values}
+}
+
 // rows are selector-compatible if both rows have the same assigned value for all specified selectors
 func (row *Row) compatible(aRow *Row, selectors []*ColSelector, db, table string) (bool, error) {
 	for _, sel := range selectors {
@@ -80,8 +107,7 @@ func (row *Row) digest(cols []ColDescriptor) (d [sha256.Size]byte, err error) {
 		binary.BigEndian.PutUint32(b[:], uint32(i))
 		h.Write(b[:])
 
-		_, isNull := v.(*NullValue)
-		if isNull {
+		if v.IsNull() {
 			continue
 		}
 
@@ -101,12 +127,17 @@ func (row *Row) digest(cols []ColDescriptor) (d [sha256.Size]byte, err error) {
 type rawRowReader struct {
 	tx              *SQLTx
 	table           *Table
+	sinceTx         uint64
 	asBefore        uint64
+	asBeforeTs      ValueExp
+	params          map[string]interface{}
 	tableAlias      string
 	colsByPos       []ColDescriptor
 	colsBySel       map[string]ColDescriptor
+	selsByColID     map[uint32]string
 	scanSpecs       *ScanSpecs
 	reader          *store.KeyReader
+	read            int
 	onCloseCallback func()
 }
 
@@ -143,6 +174,7 @@ func newRawRowReader(tx *SQLTx, table *Table, asBefore uint64, tableAlias string
 
 	colsByPos := make([]ColDescriptor, len(table.Cols()))
 	colsBySel := make(map[string]ColDescriptor, len(table.Cols()))
+	selsByColID := make(map[uint32]string, len(table.Cols()))
 
 	for i, c := range table.Cols() {
 		colDescriptor := ColDescriptor{
@@ -152,19 +184,23 @@ func newRawRowReader(tx *SQLTx, table *Table, asBefore uint64, tableAlias string
 			Type:     c.colType,
 		}
 
+		sel := colDescriptor.Selector()
+
 		colsByPos[i] = colDescriptor
-		colsBySel[colDescriptor.Selector()] = colDescriptor
+		colsBySel[sel] = colDescriptor
+		selsByColID[c.id] = sel
 	}
 
 	return &rawRowReader{
-		tx:         tx,
-		table:      table,
-		asBefore:   asBefore,
-		tableAlias: tableAlias,
-		colsByPos:  colsByPos,
-		colsBySel:  colsBySel,
-		scanSpecs:  scanSpecs,
-		reader:     r,
+		tx:          tx,
+		table:       table,
+		asBefore:    asBefore,
+		tableAlias:  tableAlias,
+		colsByPos:   colsByPos,
+		colsBySel:   colsBySel,
+		selsByColID: selsByColID,
+		scanSpecs:   scanSpecs,
+		reader:      r,
 	}, nil
 }
 
@@ -255,9 +291,11 @@ func (r *rawRowReader) TableAlias() string {
 }
 
 func (r *rawRowReader) OrderBy() []ColDescriptor {
-	cols := make([]ColDescriptor, len(r.scanSpecs.index.cols))
+	ordCols := r.scanSpecs.index.orderingCols()
 
-	for i, col := range r.scanSpecs.index.cols {
+	cols := make([]ColDescriptor, len(ordCols))
+
+	for i, col := range ordCols {
 		cols[i] = ColDescriptor{
 			Database: r.table.db.name,
 			Table:    r.tableAlias,
@@ -290,14 +328,109 @@ func (r *rawRowReader) colsBySelector() (map[string]ColDescriptor, error) {
 }
 
 func (r *rawRowReader) InferParameters(params map[string]SQLValueType) error {
-	return nil
+	if r.asBeforeTs == nil {
+		return nil
+	}
+
+	return r.asBeforeTs.requiresType(TimestampType, r.colsBySel, params, r.table.db.name, r.tableAlias)
 }
 
 func (r *rawRowReader) SetParameters(params map[string]interface{}) error {
 	return nil
 }
 
+// txBefore resolves the timestamp of the BEFORE clause into the first transaction committed since then,
+// so rows are read as they were before that transaction
+func (r *rawRowReader) txBefore() (uint64, error) {
+	val, err := r.asBeforeTs.substitute(r.params)
+	if err != nil {
+		return 0, err
+	}
+
+	ts, err := val.reduce(r.tx, nil, r.table.db.name, r.tableAlias)
+	if err != nil {
+		return 0, err
+	}
+
+	if ts.Type() != TimestampType {
+		return 0, fmt.Errorf("%w: BEFORE expects a %s value", ErrInvalidTypes, TimestampType)
+	}
+
+	if ts.IsNull() {
+		return 0, ErrIllegalArguments
+	}
+
+	hdr, err := r.tx.engine.store.FirstTxSince(ts.Value().(time.Time))
+	if err == store.ErrTxNotFound {
+		// every transaction was committed before the given time
+		return r.tx.engine.store.TxCount() + 1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return hdr.ID, nil
+}
+
 func (r *rawRowReader) Read() (row *Row, err error) {
+	if r.tx.cancelled() {
+		return nil, ErrCancellationRequested
+	}
+
+	if r.asBeforeTs != nil && r.asBefore == 0 {
+		r.asBefore, err = r.txBefore()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.scanSpecs.limit > 0 && r.read >= r.scanSpecs.limit {
+		return nil, ErrNoMoreRows
+	}
+
+	var v []byte
+
+	for {
+		var skip bool
+
+		v, skip, err = r.readRowValue()
+		if err != nil {
+			return nil, err
+		}
+		if !skip {
+			break
+		}
+	}
+
+	r.read++
+
+	if r.scanSpecs.countOnly {
+		return &Row{Values: make(map[string]TypedValue)}, nil
+	}
+
+	values := make(map[string]TypedValue, len(r.table.cols))
+
+	// selectors are resolved once per reader to avoid per-row allocations
+	for _, col := range r.table.cols {
+		values[r.selsByColID[col.id]] = &NullValue{t: col.colType}
+	}
+
+	err = decodeRowWith(v, r.table.colTypeByID, func(colID uint32, val TypedValue) {
+		sel, ok := r.selsByColID[colID]
+		if ok {
+			values[sel] = val
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Row{Values: values}, nil
+}
+
+// readRowValue returns the encoded row of the next entry, rows not written
+// since the tx specified in the SINCE clause are skipped
+func (r *rawRowReader) readRowValue() (v []byte, skip bool, err error) {
 	var mkey []byte
 	var vref store.ValueRef
 
@@ -307,23 +440,16 @@ func (r *rawRowReader) Read() (row *Row, err error) {
 		mkey, vref, err = r.reader.Read()
 	}
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	var v []byte
-
 	//decompose key, determine if it's pk, when it's pk, the value holds the actual row data
-	if r.scanSpecs.index.IsPrimary() {
-		v, err = vref.Resolve()
-		if err != nil {
-			return nil, err
-		}
-	} else {
+	if !r.scanSpecs.index.IsPrimary() {
 		var encPKVals []byte
 
 		v, err = vref.Resolve()
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		if r.scanSpecs.index.IsUnique() {
@@ -331,63 +457,56 @@ func (r *rawRowReader) Read() (row *Row, err error) {
 		} else {
 			encPKVals, err = unmapIndexEntry(r.scanSpecs.index, r.tx.engine.prefix, mkey)
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
 		}
 
-		vref, err = r.tx.get(mapKey(r.tx.engine.prefix, PIndexPrefix, EncodeID(r.table.db.id), EncodeID(r.table.id), EncodeID(PKIndexID), encPKVals))
-		if err != nil {
-			return nil, err
-		}
+		pkKey := mapKey(r.tx.engine.prefix, PIndexPrefix, EncodeID(r.table.db.id), EncodeID(r.table.id), EncodeID(PKIndexID), encPKVals)
 
-		v, err = vref.Resolve()
+		if r.asBefore > 0 {
+			vref, err = r.rowAsBefore(pkKey)
+		} else {
+			vref, err = r.tx.get(pkKey)
+		}
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 	}
 
-	values := make(map[string]TypedValue, len(r.table.Cols()))
-
-	for _, col := range r.table.Cols() {
-		values[EncodeSelector("", r.table.db.name, r.tableAlias, col.colName)] = &NullValue{t: col.colType}
+	if vref.Tx() < r.sinceTx {
+		return nil, true, nil
 	}
 
-	if len(v) < EncLenLen {
-		return nil, ErrCorruptedData
+	if r.scanSpecs.countOnly {
+		return nil, false, nil
 	}
 
-	voff := 0
-
-	cols := int(binary.BigEndian.Uint32(v[voff:]))
-	voff += EncLenLen
-
-	for i := 0; i < cols; i++ {
-		if len(v) < EncIDLen {
-			return nil, ErrCorruptedData
-		}
-
-		colID := binary.BigEndian.Uint32(v[voff:])
-		voff += EncIDLen
-
-		col, err := r.table.GetColumnByID(colID)
-		if err != nil {
-			return nil, ErrCorruptedData
-		}
-
-		val, n, err := DecodeValue(v[voff:], col.colType)
-		if err != nil {
-			return nil, err
-		}
+	v, err = vref.Resolve()
+	return v, false, err
+}
 
-		voff += n
-		values[EncodeSelector("", r.table.db.name, r.tableAlias, col.colName)] = val
+// rowAsBefore returns the row as it was before the tx the reader is positioned at,
+// so rows read through a secondary index are consistent with the index entries
+func (r *rawRowReader) rowAsBefore(pkKey []byte) (store.ValueRef, error) {
+	reader, err := r.tx.newKeyReader(&store.KeyReaderSpec{
+		SeekKey:       pkKey,
+		InclusiveSeek: true,
+		EndKey:        pkKey,
+		InclusiveEnd:  true,
+		Prefix:        pkKey,
+		Filter:        store.IgnoreDeleted,
+	})
+	if err != nil {
+		return nil, err
 	}
+	defer reader.Close()
 
-	if len(v)-voff > 0 {
+	_, vref, _, err := reader.ReadAsBefore(r.asBefore)
+	if err == store.ErrNoMoreEntries {
 		return nil, ErrCorruptedData
 	}
 
-	return &Row{Values: values}, nil
+	return vref, err
 }
 
 func (r *rawRowReader) Close() error {
@@ -17,8 +17,14 @@ limitations under the License.
 package sql
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/codenotary/immudb/embedded/store"
 	"github.com/stretchr/testify/require"
 )
 
@@ -74,3 +80,177 @@ func TestKeyReaderSpecFromCornerCases(t *testing.T) {
 		require.ErrorIs(t, err, ErrInvalidValue)
 	})
 }
+
+func TestRowCopy(t *testing.T) {
+	row := &Row{Values: map[string]TypedValue{
+		"(db1.table1.id)":   &Number{val: 1},
+		"(db1.table1.data)": &Blob{val: []byte{1, 2, 3}},
+	}}
+
+	rowCopy := row.Copy()
+	require.Equal(t, row.Values, rowCopy.Values)
+
+	row.Values["(db1.table1.data)"].(*Blob).val[0] = 0
+	require.Equal(t, []byte{1, 2, 3}, rowCopy.Values["(db1.table1.data)"].Value())
+}
+
+func TestQueryCancellation(t *testing.T) {
+	st, err := store.Open("sqldata_query_cancellation", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_query_cancellation")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", nil, nil)
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id) VALUES (1), (2), (3)", nil, nil)
+	require.NoError(t, err)
+
+	stmts, err := Parse(strings.NewReader("SELECT COUNT(*) AS c FROM table1"))
+	require.NoError(t, err)
+
+	t.Run("reading should fail once cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		r, err := engine.QueryPreparedStmt(ctx, stmts[0].(*SelectStmt), nil, nil)
+		require.NoError(t, err)
+
+		cancel()
+
+		_, err = r.Read()
+		require.Equal(t, ErrCancellationRequested, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("cancellation should not outlive the reader when using an explicit tx", func(t *testing.T) {
+		tx, _, err := engine.Exec(context.Background(), "BEGIN TRANSACTION", nil, nil)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		r, err := engine.QueryPreparedStmt(ctx, stmts[0].(*SelectStmt), nil, tx)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.Equal(t, ErrCancellationRequested, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+
+		r, err = engine.QueryPreparedStmt(context.Background(), stmts[0].(*SelectStmt), nil, tx)
+		require.NoError(t, err)
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(3), row.Values[EncodeSelector("", "db1", "table1", "c")].Value())
+
+		err = r.Close()
+		require.NoError(t, err)
+
+		err = tx.Cancel()
+		require.NoError(t, err)
+	})
+
+	t.Run("reading should fail once the deadline is exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		r, err := engine.QueryPreparedStmt(ctx, stmts[0].(*SelectStmt), nil, nil)
+		require.NoError(t, err)
+
+		<-ctx.Done()
+
+		_, err = r.Read()
+		require.Equal(t, ErrCancellationRequested, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("statements should not be executed once cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, err = engine.Exec(ctx, "DELETE FROM table1 WHERE id > 10", nil, nil)
+		require.ErrorIs(t, err, ErrCancellationRequested)
+
+		_, err = engine.NewTx(nil, true)
+		require.ErrorIs(t, err, ErrIllegalArguments)
+	})
+}
+
+func BenchmarkRawRowReader(b *testing.B) {
+	st, err := store.Open("sqldata_bench_raw_row_reader", store.DefaultOptions())
+	require.NoError(b, err)
+	defer os.RemoveAll("sqldata_bench_raw_row_reader")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(b, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(b, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(b, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (
+			id INTEGER,
+			title VARCHAR,
+			amount INTEGER,
+			active BOOLEAN,
+			payload BLOB,
+			PRIMARY KEY id
+		)`, nil, nil)
+	require.NoError(b, err)
+
+	rowCount := 1000
+
+	tx, _, err := engine.Exec(context.Background(), "BEGIN TRANSACTION", nil, nil)
+	require.NoError(b, err)
+
+	for i := 0; i < rowCount; i++ {
+		params := map[string]interface{}{
+			"id":      i,
+			"title":   fmt.Sprintf("title%d", i),
+			"amount":  i * 10,
+			"active":  i%2 == 0,
+			"payload": []byte(fmt.Sprintf("payload%d", i)),
+		}
+
+		tx, _, err = engine.Exec(context.Background(), "INSERT INTO table1 (id, title, amount, active, payload) VALUES (@id, @title, @amount, @active, @payload)", params, tx)
+		require.NoError(b, err)
+	}
+
+	_, _, err = engine.Exec(context.Background(), "COMMIT", nil, tx)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		r, err := engine.Query(context.Background(), "SELECT * FROM table1", nil, nil)
+		require.NoError(b, err)
+
+		for {
+			_, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(b, err)
+		}
+
+		r.Close()
+	}
+}
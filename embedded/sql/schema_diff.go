@@ -0,0 +1,190 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type SchemaDiffKind int
+
+const (
+	MissingTable SchemaDiffKind = iota
+	MissingColumn
+	ColumnTypeMismatch
+	ColumnMaxLenMismatch
+	ColumnNullabilityMismatch
+	ColumnAutoIncrementMismatch
+	PrimaryKeyMismatch
+)
+
+func (k SchemaDiffKind) String() string {
+	switch k {
+	case MissingTable:
+		return "missing table"
+	case MissingColumn:
+		return "missing column"
+	case ColumnTypeMismatch:
+		return "column type mismatch"
+	case ColumnMaxLenMismatch:
+		return "column max length mismatch"
+	case ColumnNullabilityMismatch:
+		return "column nullability mismatch"
+	case ColumnAutoIncrementMismatch:
+		return "column auto increment mismatch"
+	case PrimaryKeyMismatch:
+		return "primary key mismatch"
+	}
+	return "unknown"
+}
+
+// SchemaDiff describes a single difference between an expected table definition and the live catalog
+type SchemaDiff struct {
+	Kind     SchemaDiffKind
+	Table    string
+	Column   string
+	Expected string
+	Actual   string
+}
+
+func (d *SchemaDiff) String() string {
+	target := d.Table
+	if d.Column != "" {
+		target = fmt.Sprintf("%s.%s", d.Table, d.Column)
+	}
+
+	if d.Expected == "" && d.Actual == "" {
+		return fmt.Sprintf("%s: %s", d.Kind, target)
+	}
+
+	return fmt.Sprintf("%s: %s (expected %s, actual %s)", d.Kind, target, d.Expected, d.Actual)
+}
+
+// ValidateSchema compares the expected table definitions against the catalog of the current database.
+// An empty result means the live schema is compatible with the expected one.
+func (e *Engine) ValidateSchema(ctx context.Context, expected []*CreateTableStmt, tx *SQLTx) (diffs []*SchemaDiff, err error) {
+	qtx := tx
+
+	if qtx == nil {
+		qtx, err = e.newTx(ctx, false)
+		if err != nil {
+			return nil, err
+		}
+		defer qtx.Cancel()
+	}
+
+	if qtx.currentDB == nil {
+		return nil, ErrNoDatabaseSelected
+	}
+
+	for _, stmt := range expected {
+		if stmt == nil {
+			return nil, ErrIllegalArguments
+		}
+
+		table, err := qtx.currentDB.GetTableByName(stmt.table)
+		if err == ErrTableDoesNotExist {
+			diffs = append(diffs, &SchemaDiff{Kind: MissingTable, Table: stmt.table})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		diffs = append(diffs, diffTable(stmt, table)...)
+	}
+
+	return diffs, nil
+}
+
+func diffTable(stmt *CreateTableStmt, table *Table) (diffs []*SchemaDiff) {
+	for _, cs := range stmt.colsSpec {
+		col, err := table.GetColumnByName(cs.colName)
+		if err != nil {
+			diffs = append(diffs, &SchemaDiff{Kind: MissingColumn, Table: table.name, Column: cs.colName})
+			continue
+		}
+
+		if cs.colType != col.colType {
+			diffs = append(diffs, &SchemaDiff{
+				Kind:     ColumnTypeMismatch,
+				Table:    table.name,
+				Column:   col.colName,
+				Expected: cs.colType,
+				Actual:   col.colType,
+			})
+			// remaining properties are type-dependent
+			continue
+		}
+
+		if variableSized(cs.colType) && cs.maxLen != col.maxLen {
+			diffs = append(diffs, &SchemaDiff{
+				Kind:     ColumnMaxLenMismatch,
+				Table:    table.name,
+				Column:   col.colName,
+				Expected: fmt.Sprintf("%d", cs.maxLen),
+				Actual:   fmt.Sprintf("%d", col.maxLen),
+			})
+		}
+
+		if cs.notNull != col.notNull {
+			diffs = append(diffs, &SchemaDiff{
+				Kind:     ColumnNullabilityMismatch,
+				Table:    table.name,
+				Column:   col.colName,
+				Expected: nullability(cs.notNull),
+				Actual:   nullability(col.notNull),
+			})
+		}
+
+		if cs.autoIncrement != col.autoIncrement {
+			diffs = append(diffs, &SchemaDiff{
+				Kind:     ColumnAutoIncrementMismatch,
+				Table:    table.name,
+				Column:   col.colName,
+				Expected: fmt.Sprintf("%v", cs.autoIncrement),
+				Actual:   fmt.Sprintf("%v", col.autoIncrement),
+			})
+		}
+	}
+
+	actualPK := make([]string, len(table.primaryIndex.cols))
+	for i, col := range table.primaryIndex.cols {
+		actualPK[i] = col.colName
+	}
+
+	expectedPK := strings.Join(stmt.pkColNames, ",")
+
+	if expectedPK != strings.Join(actualPK, ",") {
+		diffs = append(diffs, &SchemaDiff{
+			Kind:     PrimaryKeyMismatch,
+			Table:    table.name,
+			Expected: expectedPK,
+			Actual:   strings.Join(actualPK, ","),
+		})
+	}
+
+	return diffs
+}
+
+func nullability(notNull bool) string {
+	if notNull {
+		return "NOT NULL"
+	}
+	return "NULL"
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/stretchr/testify/require"
+)
+
+func parseCreateTableStmts(t *testing.T, sql string) []*CreateTableStmt {
+	stmts, err := Parse(strings.NewReader(sql))
+	require.NoError(t, err)
+
+	tableStmts := make([]*CreateTableStmt, len(stmts))
+	for i, stmt := range stmts {
+		tableStmts[i] = stmt.(*CreateTableStmt)
+	}
+
+	return tableStmts
+}
+
+func TestValidateSchema(t *testing.T) {
+	st, err := store.Open("sqldata_validate_schema", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_validate_schema")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+
+	_, err = engine.ValidateSchema(context.Background(), nil, nil)
+	require.Equal(t, ErrNoDatabaseSelected, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), `
+		CREATE TABLE table1 (
+			id INTEGER AUTO_INCREMENT,
+			title VARCHAR[50] NOT NULL,
+			active BOOLEAN,
+			PRIMARY KEY id
+		)`, nil, nil)
+	require.NoError(t, err)
+
+	_, err = engine.ValidateSchema(context.Background(), []*CreateTableStmt{nil}, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	t.Run("compatible schema", func(t *testing.T) {
+		diffs, err := engine.ValidateSchema(context.Background(), parseCreateTableStmts(t, `
+			CREATE TABLE table1 (
+				id INTEGER AUTO_INCREMENT,
+				title VARCHAR[50] NOT NULL,
+				PRIMARY KEY id
+			)`), nil)
+		require.NoError(t, err)
+		require.Empty(t, diffs)
+	})
+
+	t.Run("incompatible schema", func(t *testing.T) {
+		diffs, err := engine.ValidateSchema(context.Background(), parseCreateTableStmts(t, `
+			CREATE TABLE table1 (
+				id INTEGER,
+				title VARCHAR[100],
+				active INTEGER,
+				amount INTEGER,
+				PRIMARY KEY (id, title)
+			);
+
+			CREATE TABLE table2 (id INTEGER, PRIMARY KEY id);
+		`), nil)
+		require.NoError(t, err)
+		require.Len(t, diffs, 7)
+
+		require.Equal(t, ColumnAutoIncrementMismatch, diffs[0].Kind)
+		require.Equal(t, "id", diffs[0].Column)
+
+		require.Equal(t, ColumnMaxLenMismatch, diffs[1].Kind)
+		require.Equal(t, "100", diffs[1].Expected)
+		require.Equal(t, "50", diffs[1].Actual)
+
+		require.Equal(t, ColumnNullabilityMismatch, diffs[2].Kind)
+		require.Equal(t, "title", diffs[2].Column)
+
+		require.Equal(t, ColumnTypeMismatch, diffs[3].Kind)
+		require.Equal(t, IntegerType, diffs[3].Expected)
+		require.Equal(t, BooleanType, diffs[3].Actual)
+		require.Equal(t, "column type mismatch: table1.active (expected INTEGER, actual BOOLEAN)", diffs[3].String())
+
+		require.Equal(t, MissingColumn, diffs[4].Kind)
+		require.Equal(t, "amount", diffs[4].Column)
+		require.Equal(t, "missing column: table1.amount", diffs[4].String())
+
+		require.Equal(t, PrimaryKeyMismatch, diffs[5].Kind)
+		require.Equal(t, "id,title", diffs[5].Expected)
+		require.Equal(t, "id", diffs[5].Actual)
+
+		require.Equal(t, MissingTable, diffs[6].Kind)
+		require.Equal(t, "table2", diffs[6].Table)
+	})
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"context"
+	"strings"
+)
+
+// LastInsertIDParam is bound after each statement with the last primary key it assigned, thus it's only
+// available to the statement following an insertion. The table-specific value, bound as "<table>_last_insert_id",
+// is kept along the script instead, until another primary key is assigned in the same table.
+const LastInsertIDParam = "last_insert_id"
+
+const lastInsertIDParamSuffix = "_" + LastInsertIDParam
+
+// ExecScript executes all the statements atomically in a single transaction.
+// Statements are executed in order and may refer to the primary keys inserted by previous ones
+// through the @last_insert_id and @<table>_last_insert_id parameters, which shadow the provided ones
+// while bound.
+func (e *Engine) ExecScript(ctx context.Context, sql string, params map[string]interface{}) (*SQLTx, error) {
+	stmts, err := e.parse(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.ExecPreparedScript(ctx, stmts, params)
+}
+
+func (e *Engine) ExecPreparedScript(ctx context.Context, stmts []SQLStmt, params map[string]interface{}) (*SQLTx, error) {
+	if len(stmts) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	for _, stmt := range stmts {
+		switch stmt.(type) {
+		case nil:
+			return nil, ErrIllegalArguments
+		case *BeginTransactionStmt, *CommitStmt, *RollbackStmt:
+			return nil, ErrTxStmtNotAllowed
+		}
+
+		err := e.checkLimits(stmt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nparams, err := normalizeParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := e.newTx(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	providedLastInsertID, isLastInsertIDProvided := nparams[LastInsertIDParam]
+
+	for _, stmt := range stmts {
+		var table *Table
+		var prevPK int64
+		var hadPrevPK bool
+
+		upsertStmt, isUpsert := stmt.(*UpsertIntoStmt)
+		if isUpsert {
+			table, err = upsertStmt.tableRef.referencedTable(tx)
+			if err != nil {
+				tx.Cancel()
+				return nil, err
+			}
+
+			// cleared so that primary keys assigned by the statement can be told apart
+			prevPK, hadPrevPK = tx.lastInsertedPKs[table.name]
+			delete(tx.lastInsertedPKs, table.name)
+		}
+
+		_, err = stmt.execAt(tx, nparams)
+		if err != nil {
+			tx.Cancel()
+			return nil, err
+		}
+
+		if isLastInsertIDProvided {
+			nparams[LastInsertIDParam] = providedLastInsertID
+		} else {
+			delete(nparams, LastInsertIDParam)
+		}
+
+		if !isUpsert {
+			continue
+		}
+
+		pk, assigned := tx.lastInsertedPKs[table.name]
+		if !assigned {
+			if hadPrevPK {
+				tx.lastInsertedPKs[table.name] = prevPK
+			}
+			continue
+		}
+
+		nparams[LastInsertIDParam] = pk
+		nparams[strings.ToLower(table.name)+lastInsertIDParamSuffix] = pk
+	}
+
+	err = tx.commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
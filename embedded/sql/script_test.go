@@ -0,0 +1,127 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecScript(t *testing.T) {
+	st, err := store.Open("sqldata_exec_script", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_exec_script")
+
+	engine, err := NewEngine(st, DefaultOptions().WithPrefix(sqlPrefix))
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	_, err = engine.ExecPreparedScript(context.Background(), nil, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = engine.ExecPreparedScript(context.Background(), []SQLStmt{nil}, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = engine.ExecScript(context.Background(), "BEGIN TRANSACTION; COMMIT;", nil)
+	require.Equal(t, ErrTxStmtNotAllowed, err)
+
+	_, err = engine.ExecScript(context.Background(), "CREATE TABLE", nil)
+	require.Error(t, err)
+
+	_, err = engine.ExecScript(context.Background(), "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", map[string]interface{}{"p": 1, "P": 2})
+	require.Equal(t, ErrDuplicatedParameters, err)
+
+	tx, err := engine.ExecScript(context.Background(), `
+		CREATE TABLE customers (id INTEGER AUTO_INCREMENT, name VARCHAR, PRIMARY KEY id);
+		CREATE TABLE orders (id INTEGER AUTO_INCREMENT, customer_id INTEGER, amount INTEGER, PRIMARY KEY id);
+
+		INSERT INTO customers (name) VALUES (@name);
+		INSERT INTO orders (customer_id, amount) VALUES (@last_insert_id, @amount);
+		INSERT INTO orders (customer_id, amount) VALUES (@customers_last_insert_id, @amount + 1);
+	`, map[string]interface{}{"name": "customer1", "amount": 10})
+	require.NoError(t, err)
+	require.NotNil(t, tx.TxHeader())
+	require.Equal(t, int64(1), tx.LastInsertedPKs()["customers"])
+	require.Equal(t, int64(2), tx.LastInsertedPKs()["orders"])
+
+	r, err := engine.Query(context.Background(), "SELECT customer_id, amount FROM orders", nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "orders", "customer_id")].Value())
+		require.Equal(t, int64(10+i), row.Values[EncodeSelector("", "db1", "orders", "amount")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	t.Run("scripts should be atomic", func(t *testing.T) {
+		_, err = engine.ExecScript(context.Background(), `
+			INSERT INTO customers (name) VALUES ('customer2');
+			INSERT INTO orders (customer_id, amount) VALUES (@last_insert_id, 'invalid');
+		`, nil)
+		require.Error(t, err)
+
+		r, err := engine.Query(context.Background(), "SELECT COUNT(*) AS c FROM customers", nil, nil)
+		require.NoError(t, err)
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "customers", "c")].Value())
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("@last_insert_id should only be bound to the previous statement", func(t *testing.T) {
+		_, err = engine.ExecScript(context.Background(), `
+			CREATE TABLE notes (id INTEGER, customer_id INTEGER, PRIMARY KEY id);
+
+			INSERT INTO customers (name) VALUES ('customer2');
+			INSERT INTO notes (id, customer_id) VALUES (1, @customers_last_insert_id);
+			INSERT INTO orders (customer_id, amount) VALUES (@last_insert_id, 1);
+		`, nil)
+		require.ErrorIs(t, err, ErrMissingParameter)
+
+		_, err = engine.ExecScript(context.Background(), `
+			INSERT INTO customers (name) VALUES ('customer2');
+			UPDATE customers SET name = 'customer2' WHERE id = @last_insert_id;
+			INSERT INTO orders (customer_id, amount) VALUES (@last_insert_id, 1);
+		`, map[string]interface{}{"last_insert_id": 1})
+		require.NoError(t, err)
+
+		r, err := engine.Query(context.Background(), "SELECT customer_id FROM orders ORDER BY id DESC LIMIT 1", nil, nil)
+		require.NoError(t, err)
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), row.Values[EncodeSelector("", "db1", "orders", "customer_id")].Value())
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+}
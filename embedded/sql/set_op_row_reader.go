@@ -0,0 +1,265 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/codenotary/immudb/embedded/multierr"
+)
+
+// setOpRowReader combines the rows of two readers returning the same number of columns of the same types.
+// Without ALL, duplicated rows are discarded. With ALL, INTERSECT returns each row as many times as
+// it's returned by both readers and EXCEPT as many times as it's returned by the left reader but not by the right one
+type setOpRowReader struct {
+	op  SetOperator
+	all bool
+
+	left  RowReader
+	right RowReader
+
+	cols      []ColDescriptor
+	rightCols []ColDescriptor
+
+	leftRead bool // UNION only: all the rows of the left reader were read
+
+	// rows returned by the right reader and the number of times they were returned,
+	// read before the left rows are read to compute INTERSECT and EXCEPT
+	rightRows map[[sha256.Size]byte]int
+
+	readRows map[[sha256.Size]byte]struct{}
+}
+
+func newSetOpRowReader(op SetOperator, all bool, left, right RowReader) (*setOpRowReader, error) {
+	cols, err := left.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	rightCols, err := right.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cols) != len(rightCols) {
+		return nil, fmt.Errorf("%w: queries combined by a set operation must return the same number of columns", ErrInvalidNumberOfValues)
+	}
+
+	for i := range cols {
+		if cols[i].Type != rightCols[i].Type && cols[i].Type != AnyType && rightCols[i].Type != AnyType {
+			return nil, fmt.Errorf("%w: column %d is of type %s in the left query but %s in the right one", ErrInvalidTypes, i+1, cols[i].Type, rightCols[i].Type)
+		}
+	}
+
+	return &setOpRowReader{
+		op:        op,
+		all:       all,
+		left:      left,
+		right:     right,
+		cols:      cols,
+		rightCols: rightCols,
+		readRows:  make(map[[sha256.Size]byte]struct{}),
+	}, nil
+}
+
+func (sr *setOpRowReader) onClose(callback func()) {
+	sr.left.onClose(callback)
+}
+
+func (sr *setOpRowReader) Tx() *SQLTx {
+	return sr.left.Tx()
+}
+
+func (sr *setOpRowReader) Database() *Database {
+	return sr.left.Database()
+}
+
+func (sr *setOpRowReader) TableAlias() string {
+	return sr.left.TableAlias()
+}
+
+func (sr *setOpRowReader) SetParameters(params map[string]interface{}) error {
+	err := sr.left.SetParameters(params)
+	if err != nil {
+		return err
+	}
+
+	return sr.right.SetParameters(params)
+}
+
+// OrderBy returns the ordering of the left reader as INTERSECT and EXCEPT return its rows in the order they're read,
+// while the rows of both readers are returned by UNION
+func (sr *setOpRowReader) OrderBy() []ColDescriptor {
+	if sr.op == UNIONOP {
+		return nil
+	}
+
+	return sr.left.OrderBy()
+}
+
+func (sr *setOpRowReader) ScanSpecs() *ScanSpecs {
+	return sr.left.ScanSpecs()
+}
+
+func (sr *setOpRowReader) Columns() ([]ColDescriptor, error) {
+	return sr.cols, nil
+}
+
+func (sr *setOpRowReader) colsBySelector() (map[string]ColDescriptor, error) {
+	return sr.left.colsBySelector()
+}
+
+func (sr *setOpRowReader) InferParameters(params map[string]SQLValueType) error {
+	err := sr.left.InferParameters(params)
+	if err != nil {
+		return err
+	}
+
+	return sr.right.InferParameters(params)
+}
+
+// readRight returns the next row of the right reader with its columns named as the ones of the left reader
+func (sr *setOpRowReader) readRight() (*Row, error) {
+	row, err := sr.right.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := &Row{Values: make(map[string]TypedValue, len(sr.cols))}
+
+	for i, col := range sr.cols {
+		mapped.Values[col.Selector()] = row.Values[sr.rightCols[i].Selector()]
+	}
+
+	return mapped, nil
+}
+
+func (sr *setOpRowReader) loadRightRows() error {
+	sr.rightRows = make(map[[sha256.Size]byte]int)
+
+	for {
+		if len(sr.rightRows) == sr.Tx().distinctLimit() {
+			return ErrTooManyRows
+		}
+
+		row, err := sr.readRight()
+		if errors.Is(err, ErrNoMoreRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		digest, err := row.digest(sr.cols)
+		if err != nil {
+			return err
+		}
+
+		sr.rightRows[digest]++
+	}
+}
+
+// firstRead returns true when the row was not returned before, rows are always taken as new when ALL is set
+func (sr *setOpRowReader) firstRead(digest [sha256.Size]byte) (bool, error) {
+	if sr.all {
+		return true, nil
+	}
+
+	_, ok := sr.readRows[digest]
+	if ok {
+		return false, nil
+	}
+
+	if len(sr.readRows) == sr.Tx().distinctLimit() {
+		return false, ErrTooManyRows
+	}
+
+	sr.readRows[digest] = struct{}{}
+
+	return true, nil
+}
+
+func (sr *setOpRowReader) Read() (*Row, error) {
+	if sr.op != UNIONOP && sr.rightRows == nil {
+		err := sr.loadRightRows()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		var row *Row
+		var err error
+
+		if sr.leftRead {
+			row, err = sr.readRight()
+		} else {
+			row, err = sr.left.Read()
+
+			if errors.Is(err, ErrNoMoreRows) && sr.op == UNIONOP {
+				sr.leftRead = true
+				continue
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		digest, err := row.digest(sr.cols)
+		if err != nil {
+			return nil, err
+		}
+
+		switch sr.op {
+		case INTERSECTOP:
+			if sr.rightRows[digest] == 0 {
+				continue
+			}
+
+			if sr.all {
+				sr.rightRows[digest]--
+			}
+		case EXCEPTOP:
+			if sr.rightRows[digest] > 0 {
+				if sr.all {
+					sr.rightRows[digest]--
+				}
+
+				continue
+			}
+		}
+
+		first, err := sr.firstRead(digest)
+		if err != nil {
+			return nil, err
+		}
+
+		if first {
+			return row, nil
+		}
+	}
+}
+
+func (sr *setOpRowReader) Close() error {
+	merr := multierr.NewMultiErr()
+
+	merr.Append(sr.right.Close())
+	merr.Append(sr.left.Close())
+
+	return merr.Reduce()
+}
@@ -0,0 +1,586 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/logger"
+	"github.com/codenotary/immudb/embedded/multierr"
+)
+
+// sortRowReader returns the rows of the underlying reader ordered by the specified columns.
+// Rows are sorted in memory while they fit into the sort buffer, otherwise sorted runs
+// are written into temporary files and merged while being read
+type sortRowReader struct {
+	rowReader RowReader
+
+	orderBy   []*OrdCol
+	selectors []string // encoded selectors of the ordering columns
+
+	bufferSize int
+	dir        string
+
+	log logger.Logger
+
+	buffer     []*Row
+	bufferUsed int // approximated size of the buffered rows
+	read       int // number of buffered rows already read
+
+	runs   []*sortedRun
+	merger *runMerger
+
+	sorted bool
+}
+
+type sortedRun struct {
+	f      *os.File
+	r      *bufio.Reader
+	curr   *Row
+	seqNum int // runs are merged in creation order when rows are equal, so sorting is stable
+}
+
+func newSortRowReader(rowReader RowReader, orderBy []*OrdCol) (*sortRowReader, error) {
+	if rowReader == nil || len(orderBy) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	colsBySel, err := rowReader.colsBySelector()
+	if err != nil {
+		return nil, err
+	}
+
+	selectors := make([]string, len(orderBy))
+
+	for i, col := range orderBy {
+		sel := EncodeSelector(col.sel.resolve(rowReader.Database().Name(), rowReader.TableAlias()))
+
+		_, ok := colsBySel[sel]
+		if !ok {
+			return nil, ErrColumnDoesNotExist
+		}
+
+		selectors[i] = sel
+	}
+
+	tx := rowReader.Tx()
+
+	sr := &sortRowReader{
+		rowReader:  rowReader,
+		orderBy:    orderBy,
+		selectors:  selectors,
+		bufferSize: defaultSortBufferSize,
+		log:        logger.NewNopLogger(),
+	}
+
+	if tx != nil {
+		sr.bufferSize = tx.sortBufferSize()
+		sr.dir = tx.sortDir()
+		sr.log = tx.log()
+	}
+
+	return sr, nil
+}
+
+func (sr *sortRowReader) onClose(callback func()) {
+	sr.rowReader.onClose(callback)
+}
+
+func (sr *sortRowReader) Tx() *SQLTx {
+	return sr.rowReader.Tx()
+}
+
+func (sr *sortRowReader) Database() *Database {
+	return sr.rowReader.Database()
+}
+
+func (sr *sortRowReader) TableAlias() string {
+	return sr.rowReader.TableAlias()
+}
+
+func (sr *sortRowReader) SetParameters(params map[string]interface{}) error {
+	return sr.rowReader.SetParameters(params)
+}
+
+func (sr *sortRowReader) OrderBy() []ColDescriptor {
+	colsBySel, err := sr.rowReader.colsBySelector()
+	if err != nil {
+		return nil
+	}
+
+	cols := make([]ColDescriptor, len(sr.selectors))
+
+	for i, sel := range sr.selectors {
+		cols[i] = colsBySel[sel]
+	}
+
+	// sorting is stable, thus rows with equal values are kept in the order they were read
+	for _, col := range sr.rowReader.OrderBy() {
+		if !sr.sortedBy(col.Selector()) {
+			cols = append(cols, col)
+		}
+	}
+
+	return cols
+}
+
+func (sr *sortRowReader) sortedBy(sel string) bool {
+	for _, s := range sr.selectors {
+		if s == sel {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (sr *sortRowReader) ScanSpecs() *ScanSpecs {
+	return sr.rowReader.ScanSpecs()
+}
+
+func (sr *sortRowReader) Columns() ([]ColDescriptor, error) {
+	return sr.rowReader.Columns()
+}
+
+func (sr *sortRowReader) colsBySelector() (map[string]ColDescriptor, error) {
+	return sr.rowReader.colsBySelector()
+}
+
+func (sr *sortRowReader) InferParameters(params map[string]SQLValueType) error {
+	return sr.rowReader.InferParameters(params)
+}
+
+func (sr *sortRowReader) Read() (*Row, error) {
+	if !sr.sorted {
+		err := sr.sort()
+		if err != nil {
+			return nil, err
+		}
+
+		sr.sorted = true
+	}
+
+	if sr.merger != nil {
+		return sr.merger.next()
+	}
+
+	if sr.read == len(sr.buffer) {
+		return nil, ErrNoMoreRows
+	}
+
+	row := sr.buffer[sr.read]
+	sr.read++
+
+	return row, nil
+}
+
+// sort reads all the rows from the underlying reader, rows are kept in memory
+// unless they don't fit into the buffer, in such case all of them are spilled
+func (sr *sortRowReader) sort() error {
+	for {
+		row, err := sr.rowReader.Read()
+		if errors.Is(err, ErrNoMoreRows) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		sr.buffer = append(sr.buffer, row)
+		sr.bufferUsed += rowSize(row)
+
+		if sr.bufferUsed >= sr.bufferSize {
+			err = sr.spill()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(sr.runs) == 0 {
+		return sr.sortBuffer()
+	}
+
+	if len(sr.buffer) > 0 {
+		err := sr.spill()
+		if err != nil {
+			return err
+		}
+	}
+
+	merger := &runMerger{sr: sr}
+
+	for _, run := range sr.runs {
+		err := merger.advance(run)
+		if errors.Is(err, ErrNoMoreRows) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	sr.merger = merger
+
+	return nil
+}
+
+func (sr *sortRowReader) sortBuffer() error {
+	var err error
+
+	sort.SliceStable(sr.buffer, func(i, j int) bool {
+		cmp, cmpErr := sr.compare(sr.buffer[i], sr.buffer[j])
+		if cmpErr != nil && err == nil {
+			err = cmpErr
+		}
+
+		return cmp < 0
+	})
+
+	return err
+}
+
+// spill writes the buffered rows, once sorted, into a new temporary file
+func (sr *sortRowReader) spill() error {
+	err := sr.sortBuffer()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(sr.dir, "immudb_sort_")
+	if err != nil {
+		return err
+	}
+
+	run := &sortedRun{f: f, seqNum: len(sr.runs)}
+
+	// the run is registered right away so its file gets removed on close
+	sr.runs = append(sr.runs, run)
+
+	w := bufio.NewWriter(f)
+
+	for _, row := range sr.buffer {
+		err = writeSortedRow(w, row)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	run.r = bufio.NewReader(f)
+
+	sr.log.Debug("Sorted rows spilled into a temporary file", logger.F("file", f.Name()), logger.F("rows", len(sr.buffer)))
+
+	sr.buffer = nil
+	sr.bufferUsed = 0
+
+	return nil
+}
+
+func (sr *sortRowReader) compare(row1, row2 *Row) (int, error) {
+	for i, sel := range sr.selectors {
+		val1, ok := row1.Values[sel]
+		if !ok {
+			return 0, ErrInvalidColumn
+		}
+
+		val2, ok := row2.Values[sel]
+		if !ok {
+			return 0, ErrInvalidColumn
+		}
+
+		cmp, err := val1.Compare(val2)
+		if err != nil {
+			return 0, err
+		}
+
+		if sr.orderBy[i].descOrder {
+			cmp = -cmp
+		}
+
+		if cmp != 0 {
+			return cmp, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func (sr *sortRowReader) Close() error {
+	merr := multierr.NewMultiErr()
+
+	for _, run := range sr.runs {
+		merr.Append(run.f.Close())
+		merr.Append(os.Remove(run.f.Name()))
+	}
+
+	merr.Append(sr.rowReader.Close())
+
+	return merr.Reduce()
+}
+
+// runMerger holds the runs with pending rows, being the run
+// with the smallest current row at the top of the heap
+type runMerger struct {
+	sr   *sortRowReader
+	runs []*sortedRun
+	err  error
+}
+
+func (m *runMerger) Len() int {
+	return len(m.runs)
+}
+
+func (m *runMerger) Less(i, j int) bool {
+	cmp, err := m.sr.compare(m.runs[i].curr, m.runs[j].curr)
+	if err != nil && m.err == nil {
+		m.err = err
+	}
+
+	if cmp == 0 {
+		return m.runs[i].seqNum < m.runs[j].seqNum
+	}
+
+	return cmp < 0
+}
+
+func (m *runMerger) Swap(i, j int) {
+	m.runs[i], m.runs[j] = m.runs[j], m.runs[i]
+}
+
+func (m *runMerger) Push(x interface{}) {
+	m.runs = append(m.runs, x.(*sortedRun))
+}
+
+func (m *runMerger) Pop() interface{} {
+	run := m.runs[len(m.runs)-1]
+	m.runs = m.runs[:len(m.runs)-1]
+	return run
+}
+
+// advance reads the next row of the run, pushing it back into the heap when there are rows left
+func (m *runMerger) advance(run *sortedRun) error {
+	row, err := readSortedRow(run.r)
+	if err != nil {
+		return err
+	}
+
+	run.curr = row
+	heap.Push(m, run)
+
+	return m.err
+}
+
+func (m *runMerger) next() (*Row, error) {
+	if len(m.runs) == 0 {
+		return nil, ErrNoMoreRows
+	}
+
+	run := heap.Pop(m).(*sortedRun)
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	row := run.curr
+
+	err := m.advance(run)
+	if err != nil && !errors.Is(err, ErrNoMoreRows) {
+		return nil, err
+	}
+
+	return row, nil
+}
+
+// rowSize returns an approximation of the memory taken by the row
+func rowSize(row *Row) int {
+	size := 0
+
+	for sel, val := range row.Values {
+		// map entry and the boxed value
+		size += len(sel) + 32
+
+		switch v := val.Value().(type) {
+		case string:
+			size += len(v)
+		case []byte:
+			size += len(v)
+		default:
+			size += 8
+		}
+	}
+
+	return size
+}
+
+// encodeSortedRow encodes every value of the row along with its selector and type,
+// so the row can be restored regardless of the reader it was produced by
+func encodeSortedRow(row *Row) ([]byte, error) {
+	var b []byte
+
+	var encLen [EncLenLen]byte
+	binary.BigEndian.PutUint32(encLen[:], uint32(len(row.Values)))
+	b = append(b, encLen[:]...)
+
+	for sel, val := range row.Values {
+		b = appendSortedString(b, sel)
+		b = appendSortedString(b, string(val.Type()))
+
+		if val.IsNull() {
+			b = append(b, 0)
+			continue
+		}
+
+		b = append(b, 1)
+
+		encVal, err := EncodeValue(val.Value(), val.Type(), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		b = append(b, encVal...)
+	}
+
+	return b, nil
+}
+
+func appendSortedString(b []byte, s string) []byte {
+	var encLen [EncLenLen]byte
+	binary.BigEndian.PutUint32(encLen[:], uint32(len(s)))
+
+	b = append(b, encLen[:]...)
+	return append(b, s...)
+}
+
+func writeSortedRow(w *bufio.Writer, row *Row) error {
+	encRow, err := encodeSortedRow(row)
+	if err != nil {
+		return err
+	}
+
+	var b [EncLenLen]byte
+	binary.BigEndian.PutUint32(b[:], uint32(len(encRow)))
+
+	_, err = w.Write(b[:])
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(encRow)
+
+	return err
+}
+
+func readSortedRow(r *bufio.Reader) (*Row, error) {
+	var encLen [EncLenLen]byte
+
+	_, err := io.ReadFull(r, encLen[:])
+	if errors.Is(err, io.EOF) {
+		return nil, ErrNoMoreRows
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, binary.BigEndian.Uint32(encLen[:]))
+
+	_, err = io.ReadFull(r, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeSortedRow(b)
+}
+
+func decodeSortedRow(b []byte) (*Row, error) {
+	if len(b) < EncLenLen {
+		return nil, ErrCorruptedData
+	}
+
+	valCount := int(binary.BigEndian.Uint32(b))
+	off := EncLenLen
+
+	values := make(map[string]TypedValue, valCount)
+
+	for i := 0; i < valCount; i++ {
+		sel, n, err := decodeSortedString(b[off:])
+		if err != nil {
+			return nil, err
+		}
+		off += n
+
+		valType, n, err := decodeSortedString(b[off:])
+		if err != nil {
+			return nil, err
+		}
+		off += n
+
+		if len(b) < off+1 {
+			return nil, ErrCorruptedData
+		}
+
+		isNull := b[off] == 0
+		off++
+
+		if isNull {
+			values[sel] = &NullValue{t: SQLValueType(valType)}
+			continue
+		}
+
+		val, n, err := DecodeValue(b[off:], SQLValueType(valType))
+		if err != nil {
+			return nil, err
+		}
+		off += n
+
+		values[sel] = val
+	}
+
+	if off != len(b) {
+		return nil, ErrCorruptedData
+	}
+
+	return &Row{Values: values}, nil
+}
+
+func decodeSortedString(b []byte) (string, int, error) {
+	if len(b) < EncLenLen {
+		return "", 0, ErrCorruptedData
+	}
+
+	sLen := int(binary.BigEndian.Uint32(b))
+
+	if len(b) < EncLenLen+sLen {
+		return "", 0, ErrCorruptedData
+	}
+
+	return string(b[EncLenLen : EncLenLen+sLen]), EncLenLen + sLen, nil
+}
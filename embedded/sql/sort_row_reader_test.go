@@ -0,0 +1,79 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortRowReader(t *testing.T) {
+	_, err := newSortRowReader(nil, []*OrdCol{{sel: &ColSelector{col: "id"}}})
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	dummyr := &dummyRowReader{}
+
+	_, err = newSortRowReader(dummyr, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = newSortRowReader(dummyr, []*OrdCol{{sel: &ColSelector{col: "id"}}})
+	require.ErrorIs(t, err, errDummy)
+}
+
+func TestSortedRowEncoding(t *testing.T) {
+	row := &Row{Values: map[string]TypedValue{
+		"(db1.table1.id)":      &Number{val: 1},
+		"(db1.table1.title)":   &Varchar{val: "title"},
+		"(db1.table1.active)":  &Bool{val: true},
+		"(db1.table1.payload)": &Blob{val: []byte{1, 2, 3}},
+		"(db1.table1.ts)":      &Timestamp{val: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"(db1.table1.amount)":  &NullValue{t: IntegerType},
+	}}
+
+	encRow, err := encodeSortedRow(row)
+	require.NoError(t, err)
+
+	decRow, err := decodeSortedRow(encRow)
+	require.NoError(t, err)
+	require.Len(t, decRow.Values, len(row.Values))
+
+	for sel, val := range row.Values {
+		decVal, ok := decRow.Values[sel]
+		require.True(t, ok)
+		require.Equal(t, val.Type(), decVal.Type())
+		require.Equal(t, val.IsNull(), decVal.IsNull())
+
+		if !val.IsNull() {
+			cmp, err := val.Compare(decVal)
+			require.NoError(t, err)
+			require.Zero(t, cmp)
+		}
+	}
+
+	_, err = decodeSortedRow(nil)
+	require.ErrorIs(t, err, ErrCorruptedData)
+
+	for i := 1; i < len(encRow); i++ {
+		_, err = decodeSortedRow(encRow[:i])
+		require.ErrorIs(t, err, ErrCorruptedData)
+	}
+
+	_, err = decodeSortedRow(append(encRow, 0))
+	require.ErrorIs(t, err, ErrCorruptedData)
+}
@@ -27,7 +27,7 @@ func setResult(l yyLexer, stmts []SQLStmt) {
 %union{
     stmts []SQLStmt
     stmt SQLStmt
-    colsSpec []*ColSpec
+    tableElems *CreateTableStmt
     colSpec *ColSpec
     cols []*ColSelector
     rows []*RowSpec
@@ -36,6 +36,7 @@ func setResult(l yyLexer, stmts []SQLStmt) {
     value ValueExp
     id string
     number uint64
+    float float64
     str string
     boolean bool
     blob []byte
@@ -62,21 +63,32 @@ func setResult(l yyLexer, stmts []SQLStmt) {
     update *colUpdate
     updates []*colUpdate
     onConflict *OnConflictDo
+    whens []*whenThen
+    setOp SetOperator
+    check *checkConstraint
 }
 
-%token CREATE USE DATABASE SNAPSHOT SINCE UP TO TABLE UNIQUE INDEX ON ALTER ADD COLUMN PRIMARY KEY
+%token CREATE USE DATABASE SNAPSHOT SINCE UP TO TABLE UNIQUE INDEX ON ALTER ADD DROP RENAME COLUMN PRIMARY KEY CHECK
 %token BEGIN TRANSACTION COMMIT ROLLBACK
-%token INSERT UPSERT INTO VALUES DELETE UPDATE SET CONFLICT DO NOTHING
-%token SELECT DISTINCT FROM BEFORE TX JOIN HAVING WHERE GROUP BY LIMIT ORDER ASC DESC AS
-%token NOT LIKE IF EXISTS IN IS
+%token INSERT UPSERT INTO VALUES DELETE UPDATE SET CONFLICT DO NOTHING IGNORE
+%token SELECT DISTINCT FROM BEFORE TX JOIN HAVING WHERE GROUP BY LIMIT OFFSET ORDER ASC DESC AS
+%token NOT LIKE IF EXISTS IN IS BETWEEN
+%token SYNONYM FOR
 %token AUTO_INCREMENT NULL NPARAM CAST
+%token CASE WHEN THEN ELSE END
+%token INTERVAL
+%token UNION INTERSECT EXCEPT ALL
+%token OUTER
+%token MATERIALIZED VIEW REFRESH
 %token <pparam> PPARAM
 %token <joinType> JOINTYPE
 %token <logicOp> LOP
 %token <cmpOp> CMPOP
+%token <boolean> MATCHES
 %token <id> IDENTIFIER
 %token <sqlType> TYPE
 %token <number> NUMBER
+%token <float> FLOAT
 %token <str> VARCHAR
 %token <boolean> BOOLEAN
 %token <blob> BLOB
@@ -86,7 +98,7 @@ func setResult(l yyLexer, stmts []SQLStmt) {
 %left  ','
 %right AS
 %left  LOP
-%right LIKE
+%right LIKE MATCHES
 %right NOT
 %left  CMPOP
 %left '+' '-'
@@ -96,8 +108,10 @@ func setResult(l yyLexer, stmts []SQLStmt) {
 %left IS
 
 %type <stmts> sql sqlstmts
-%type <stmt> sqlstmt ddlstmt dqlstmt dmlstmt
-%type <colsSpec> colsSpec
+%type <stmt> sqlstmt ddlstmt dqlstmt dmlstmt intersectstmt selectstmt
+%type <setOp> union_or_except
+%type <boolean> opt_all opt_outer
+%type <tableElems> table_elems
 %type <colSpec> colSpec
 %type <ids> ids one_or_more_ids opt_ids
 %type <cols> cols
@@ -105,7 +119,7 @@ func setResult(l yyLexer, stmts []SQLStmt) {
 %type <row> row
 %type <values> values opt_values
 %type <value> val
-%type <sel> selector
+%type <sel> selector proj_selector
 %type <sels> opt_selectors selectors
 %type <col> col
 %type <distinct> opt_distinct
@@ -115,18 +129,20 @@ func setResult(l yyLexer, stmts []SQLStmt) {
 %type <joins> opt_joins joins
 %type <join> join
 %type <joinType> opt_join_type
-%type <exp> exp opt_where opt_having boundexp
+%type <exp> exp opt_where opt_having boundexp opt_else
+%type <whens> whens
 %type <binExp> binExp
 %type <cols> opt_groupby
-%type <number> opt_limit opt_max_len
+%type <number> opt_limit opt_offset opt_max_len
 %type <id> opt_as
 %type <ordcols> ordcols opt_orderby
 %type <opt_ord> opt_ord
 %type <ids> opt_indexon
-%type <boolean> opt_if_not_exists opt_auto_increment opt_not_null opt_not
+%type <boolean> opt_if_not_exists opt_if_exists opt_auto_increment opt_not_null opt_not opt_unique
 %type <update> update
 %type <updates> updates
 %type <onConflict> opt_on_conflict
+%type <check> check opt_check
 
 %start sql
 
@@ -173,20 +189,43 @@ ddlstmt:
     {
         $$ = &CreateDatabaseStmt{DB: $3}
     }
+|
+    DROP DATABASE opt_if_exists IDENTIFIER
+    {
+        $$ = &DropDatabaseStmt{ifExists: $3, DB: $4}
+    }
 |
     USE DATABASE IDENTIFIER
     {
         $$ = &UseDatabaseStmt{DB: $3}
     }
 |
-    USE SNAPSHOT opt_since opt_as_before 
+    USE SNAPSHOT opt_since opt_as_before
     {
         $$ = &UseSnapshotStmt{sinceTx: $3, asBefore: $4}
     }
 |
-    CREATE TABLE opt_if_not_exists IDENTIFIER '(' colsSpec ',' PRIMARY KEY one_or_more_ids ')'
+    USE SNAPSHOT opt_since UP TO TX NUMBER
     {
-        $$ = &CreateTableStmt{ifNotExists: $3, table: $4, colsSpec: $6, pkColNames: $10}
+        $$ = &UseSnapshotStmt{sinceTx: $3, upToTx: $7}
+    }
+|
+    CREATE TABLE opt_if_not_exists IDENTIFIER '(' table_elems ')'
+    {
+        if len($6.pkColNames) == 0 {
+            yylex.Error("syntax error: missing PRIMARY KEY")
+            return 1
+        }
+
+        $6.ifNotExists = $3
+        $6.table = $4
+
+        $$ = $6
+    }
+|
+    DROP TABLE opt_if_exists IDENTIFIER
+    {
+        $$ = &DropTableStmt{ifExists: $3, table: $4}
     }
 |
     CREATE INDEX opt_if_not_exists ON IDENTIFIER '(' ids ')'
@@ -203,6 +242,46 @@ ddlstmt:
     {
         $$ = &AddColumnStmt{table: $3, colSpec: $6}
     }
+|
+    ALTER TABLE IDENTIFIER DROP COLUMN IDENTIFIER
+    {
+        $$ = &DropColumnStmt{table: $3, colName: $6}
+    }
+|
+    ALTER TABLE IDENTIFIER RENAME COLUMN IDENTIFIER TO IDENTIFIER
+    {
+        $$ = &RenameColumnStmt{table: $3, oldName: $6, newName: $8}
+    }
+|
+    CREATE SYNONYM IDENTIFIER FOR IDENTIFIER
+    {
+        $$ = &CreateSynonymStmt{synonym: $3, table: $5}
+    }
+|
+    DROP SYNONYM opt_if_exists IDENTIFIER
+    {
+        $$ = &DropSynonymStmt{ifExists: $3, synonym: $4}
+    }
+|
+    CREATE MATERIALIZED VIEW opt_if_not_exists IDENTIFIER AS capture_sql dqlstmt
+    {
+        $$ = &CreateMaterializedViewStmt{ifNotExists: $4, view: $5, query: $8.(*SelectStmt), sql: yylex.(*lexer).capturedSQL()}
+    }
+|
+    REFRESH MATERIALIZED VIEW IDENTIFIER
+    {
+        $$ = &RefreshMaterializedViewStmt{view: $4}
+    }
+|
+    DROP MATERIALIZED VIEW opt_if_exists IDENTIFIER
+    {
+        $$ = &DropMaterializedViewStmt{ifExists: $4, view: $5}
+    }
+
+capture_sql:
+    {
+        yylex.(*lexer).captureSQL()
+    }
 
 opt_since:
     {
@@ -224,6 +303,16 @@ opt_if_not_exists:
         $$ = true
     }
 
+opt_if_exists:
+    {
+        $$ = false
+    }
+|
+    IF EXISTS
+    {
+        $$ = true
+    }
+
 one_or_more_ids:
     IDENTIFIER
     {
@@ -240,6 +329,16 @@ dmlstmt:
     {
         $$ = &UpsertIntoStmt{isInsert: true, tableRef: $3, cols: $5, rows: $8, onConflict: $9}
     }
+|
+    INSERT LOP IGNORE INTO tableRef '(' opt_ids ')' VALUES rows
+    {
+        if $2 != OR {
+            yylex.Error("syntax error: unexpected AND, expecting OR")
+            return 1
+        }
+
+        $$ = &UpsertIntoStmt{isInsert: true, tableRef: $5, cols: $7, rows: $10, onConflict: &OnConflictDo{}}
+    }
 |
     UPSERT INTO tableRef '(' ids ')' VALUES rows
     {
@@ -265,6 +364,11 @@ opt_on_conflict:
     {
         $$ = &OnConflictDo{}
     }
+|
+    ON CONFLICT DO UPDATE SET updates
+    {
+        $$ = &OnConflictDo{updates: $6}
+    }
 
 updates:
     update
@@ -358,6 +462,11 @@ val:
     {
         $$ = &Number{val: int64($1)}
     }
+|
+    FLOAT
+    {
+        $$ = &Float{val: $1}
+    }
 |
     VARCHAR
     {
@@ -379,9 +488,25 @@ val:
         $$ = &Cast{val: $3, t: $5}
     }
 |
-    IDENTIFIER '(' ')'
+    TYPE VARCHAR
     {
-        $$ = &SysFn{fn: $1}
+        $$ = &Cast{val: &Varchar{val: $2}, t: $1}
+    }
+|
+    INTERVAL VARCHAR
+    {
+        interval, err := parseInterval($2)
+        if err != nil {
+            yylex.Error(err.Error())
+            return 1
+        }
+
+        $$ = interval
+    }
+|
+    IDENTIFIER '(' opt_values ')'
+    {
+        $$ = &SysFn{fn: $1, params: $3}
     }
 |
     NPARAM IDENTIFIER
@@ -399,21 +524,66 @@ val:
         $$ = &NullValue{t: AnyType}
     }
 
-colsSpec:
+table_elems:
     colSpec
     {
-        $$ = []*ColSpec{$1}
+        $$ = &CreateTableStmt{}
+        $$.addColSpec($1)
     }
 |
-    colsSpec ',' colSpec
+    check
     {
-        $$ = append($1, $3)
+        $$ = &CreateTableStmt{checks: []*checkConstraint{$1}}
+    }
+|
+    PRIMARY KEY one_or_more_ids
+    {
+        $$ = &CreateTableStmt{pkColNames: $3}
+    }
+|
+    table_elems ',' colSpec
+    {
+        $1.addColSpec($3)
+        $$ = $1
+    }
+|
+    table_elems ',' check
+    {
+        $1.checks = append($1.checks, $3)
+        $$ = $1
+    }
+|
+    table_elems ',' PRIMARY KEY one_or_more_ids
+    {
+        if len($1.pkColNames) > 0 {
+            yylex.Error("syntax error: multiple PRIMARY KEY")
+            return 1
+        }
+
+        $1.pkColNames = $5
+        $$ = $1
     }
 
 colSpec:
-    IDENTIFIER TYPE opt_max_len opt_not_null opt_auto_increment
+    IDENTIFIER TYPE opt_max_len opt_not_null opt_unique opt_auto_increment opt_check
+    {
+        $$ = &ColSpec{colName: $1, colType: $2, maxLen: int($3), notNull: $4, unique: $5, autoIncrement: $6, check: $7}
+    }
+
+check:
+    CHECK '(' capture_sql exp ')'
     {
-        $$ = &ColSpec{colName: $1, colType: $2, maxLen: int($3), notNull: $4, autoIncrement: $5}
+        $$ = &checkConstraint{exp: $4, sql: checkSQL(yylex.(*lexer).capturedSQL())}
+    }
+
+opt_check:
+    {
+        $$ = nil
+    }
+|
+    check
+    {
+        $$ = $1
     }
 
 opt_max_len:
@@ -425,6 +595,21 @@ opt_max_len:
     {
         $$ = $2
     }
+|
+    '(' NUMBER ')'
+    {
+        $$ = $2
+    }
+
+opt_unique:
+    {
+        $$ = false
+    }
+|
+    UNIQUE
+    {
+        $$ = true
+    }
 
 opt_auto_increment:
     {
@@ -452,7 +637,44 @@ opt_not_null:
     }
 
 dqlstmt:
-    SELECT opt_distinct opt_selectors FROM ds opt_indexon opt_joins opt_where opt_groupby opt_having opt_orderby opt_limit
+    intersectstmt
+|
+    dqlstmt union_or_except opt_all intersectstmt
+    {
+        $$ = newSetOperationStmt($2, $3, $1.(*SelectStmt), $4.(*SelectStmt))
+    }
+
+intersectstmt:
+    selectstmt
+|
+    intersectstmt INTERSECT opt_all selectstmt
+    {
+        $$ = newSetOperationStmt(INTERSECTOP, $3, $1.(*SelectStmt), $4.(*SelectStmt))
+    }
+
+union_or_except:
+    UNION
+    {
+        $$ = UNIONOP
+    }
+|
+    EXCEPT
+    {
+        $$ = EXCEPTOP
+    }
+
+opt_all:
+    {
+        $$ = false
+    }
+|
+    ALL
+    {
+        $$ = true
+    }
+
+selectstmt:
+    SELECT opt_distinct opt_selectors FROM ds opt_indexon opt_joins opt_where opt_groupby opt_having opt_orderby opt_limit opt_offset
     {
         $$ = &SelectStmt{
                 distinct: $2,
@@ -465,6 +687,7 @@ dqlstmt:
                 having: $10,
                 orderBy: $11,
                 limit: int($12),
+                offset: int($13),
             }
     }
 
@@ -490,18 +713,54 @@ opt_selectors:
     }
 
 selectors:
-    selector opt_as
+    proj_selector opt_as
     {
         $1.setAlias($2)
         $$ = []Selector{$1}
     }
 |
-    selectors ',' selector opt_as
+    selectors ',' proj_selector opt_as
     {
         $3.setAlias($4)
         $$ = append($1, $3)
     }
 
+proj_selector:
+    selector
+    {
+        $$ = $1
+    }
+|
+    IDENTIFIER '(' opt_values ')'
+    {
+        $$ = &SysFn{fn: $1, params: $3}
+    }
+|
+    CAST '(' exp AS TYPE ')'
+    {
+        $$ = &Cast{val: $3, t: $5}
+    }
+|
+    proj_selector '+' exp
+    {
+        $$ = &NumExp{left: $1, op: ADDOP, right: $3}
+    }
+|
+    proj_selector '-' exp
+    {
+        $$ = &NumExp{left: $1, op: SUBSOP, right: $3}
+    }
+|
+    proj_selector '/' exp
+    {
+        $$ = &NumExp{left: $1, op: DIVOP, right: $3}
+    }
+|
+    proj_selector '*' exp
+    {
+        $$ = &NumExp{left: $1, op: MULTOP, right: $3}
+    }
+
 selector:
     col
     {
@@ -517,6 +776,32 @@ selector:
     {
         $$ = &AggColSelector{aggFn: $1, db: $3.db, table: $3.table, col: $3.col}
     }
+|
+    CASE whens opt_else END
+    {
+        $$ = &CaseWhenExp{whens: $2, elseExp: $3}
+    }
+
+whens:
+    WHEN exp THEN exp
+    {
+        $$ = []*whenThen{{cond: $2, then: $4}}
+    }
+|
+    whens WHEN exp THEN exp
+    {
+        $$ = append($1, &whenThen{cond: $3, then: $5})
+    }
+
+opt_else:
+    {
+        $$ = nil
+    }
+|
+    ELSE exp
+    {
+        $$ = $2
+    }
 
 col:
     IDENTIFIER
@@ -541,6 +826,21 @@ ds:
         $1.as = $3
         $$ = $1
     }
+|
+    tableRef SINCE TX NUMBER opt_as_before opt_as
+    {
+        $1.sinceTx = $4
+        $1.asBefore = $5
+        $1.as = $6
+        $$ = $1
+    }
+|
+    tableRef BEFORE val opt_as
+    {
+        $1.asBeforeTs = $3
+        $1.as = $4
+        $$ = $1
+    }
 |
     '(' dqlstmt ')' opt_as
     {
@@ -593,19 +893,50 @@ joins:
 join:
     opt_join_type JOIN ds opt_indexon ON exp
     {
+        if $1 == CrossJoin {
+            yylex.Error("syntax error: unexpected ON")
+            return 1
+        }
+
         $$ = &JoinSpec{joinType: $1, ds: $3, indexOn: $4, cond: $6}
     }
+|
+    opt_join_type JOIN ds opt_indexon
+    {
+        if $1 != CrossJoin {
+            yylex.Error("syntax error: unexpected end of join, expecting ON")
+            return 1
+        }
+
+        // every pair of rows is joined
+        $$ = &JoinSpec{joinType: $1, ds: $3, indexOn: $4, cond: &Bool{val: true}}
+    }
 
 opt_join_type:
     {
         $$ = InnerJoin
     }
 |
-    JOINTYPE
+    JOINTYPE opt_outer
     {
+        if ($1 == InnerJoin || $1 == CrossJoin) && $2 {
+            yylex.Error("syntax error: unexpected OUTER, expecting JOIN")
+            return 1
+        }
+
         $$ = $1
     }
 
+opt_outer:
+    {
+        $$ = false
+    }
+|
+    OUTER
+    {
+        $$ = true
+    }
+
 opt_where:
     {
         $$ = nil
@@ -646,6 +977,16 @@ opt_limit:
         $$ = $2
     }
 
+opt_offset:
+    {
+        $$ = 0
+    }
+|
+    OFFSET NUMBER
+    {
+        $$ = $2
+    }
+
 opt_orderby:
     {
         $$ = nil
@@ -732,6 +1073,11 @@ exp:
     {
         $$ = &LikeBoolExp{val: $1, notLike: $2, pattern: $4}
     }
+|
+    boundexp MATCHES exp
+    {
+        $$ = &RegexpBoolExp{val: $1, notMatch: $2, pattern: $3}
+    }
 |
     EXISTS '(' dqlstmt ')'
     {
@@ -747,6 +1093,27 @@ exp:
     {
         $$ = &InListExp{val: $1, notIn: $2, values: $5}
     }
+|
+    boundexp opt_not BETWEEN boundexp LOP boundexp
+    {
+        if $5 != AND {
+            yylex.Error("syntax error: unexpected OR, expecting AND")
+            return 1
+        }
+
+        // bounds are translated into comparisons so ranges over indexed columns are scanned
+        var exp ValueExp = &BinBoolExp{
+            op:    AND,
+            left:  &CmpBoolExp{op: GE, left: $1, right: $4},
+            right: &CmpBoolExp{op: LE, left: $1, right: $6},
+        }
+
+        if $2 {
+            exp = &NotBoolExp{exp: exp}
+        }
+
+        $$ = exp
+    }
 
 boundexp:
     selector
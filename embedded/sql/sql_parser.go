@@ -13,7 +13,7 @@ type yySymType struct {
 	yys        int
 	stmts      []SQLStmt
 	stmt       SQLStmt
-	colsSpec   []*ColSpec
+	tableElems *CreateTableStmt
 	colSpec    *ColSpec
 	cols       []*ColSelector
 	rows       []*RowSpec
@@ -22,6 +22,7 @@ type yySymType struct {
 	value      ValueExp
 	id         string
 	number     uint64
+	float      float64
 	str        string
 	boolean    bool
 	blob       []byte
@@ -48,6 +49,9 @@ type yySymType struct {
 	update     *colUpdate
 	updates    []*colUpdate
 	onConflict *OnConflictDo
+	whens      []*whenThen
+	setOp      SetOperator
+	check      *checkConstraint
 }
 
 const CREATE = 57346
@@ -63,61 +67,85 @@ const INDEX = 57355
 const ON = 57356
 const ALTER = 57357
 const ADD = 57358
-const COLUMN = 57359
-const PRIMARY = 57360
-const KEY = 57361
-const BEGIN = 57362
-const TRANSACTION = 57363
-const COMMIT = 57364
-const ROLLBACK = 57365
-const INSERT = 57366
-const UPSERT = 57367
-const INTO = 57368
-const VALUES = 57369
-const DELETE = 57370
-const UPDATE = 57371
-const SET = 57372
-const CONFLICT = 57373
-const DO = 57374
-const NOTHING = 57375
-const SELECT = 57376
-const DISTINCT = 57377
-const FROM = 57378
-const BEFORE = 57379
-const TX = 57380
-const JOIN = 57381
-const HAVING = 57382
-const WHERE = 57383
-const GROUP = 57384
-const BY = 57385
-const LIMIT = 57386
-const ORDER = 57387
-const ASC = 57388
-const DESC = 57389
-const AS = 57390
-const NOT = 57391
-const LIKE = 57392
-const IF = 57393
-const EXISTS = 57394
-const IN = 57395
-const IS = 57396
-const AUTO_INCREMENT = 57397
-const NULL = 57398
-const NPARAM = 57399
-const CAST = 57400
-const PPARAM = 57401
-const JOINTYPE = 57402
-const LOP = 57403
-const CMPOP = 57404
-const IDENTIFIER = 57405
-const TYPE = 57406
-const NUMBER = 57407
-const VARCHAR = 57408
-const BOOLEAN = 57409
-const BLOB = 57410
-const AGGREGATE_FUNC = 57411
-const ERROR = 57412
-const STMT_SEPARATOR = 57413
+const DROP = 57359
+const RENAME = 57360
+const COLUMN = 57361
+const PRIMARY = 57362
+const KEY = 57363
+const CHECK = 57364
+const BEGIN = 57365
+const TRANSACTION = 57366
+const COMMIT = 57367
+const ROLLBACK = 57368
+const INSERT = 57369
+const UPSERT = 57370
+const INTO = 57371
+const VALUES = 57372
+const DELETE = 57373
+const UPDATE = 57374
+const SET = 57375
+const CONFLICT = 57376
+const DO = 57377
+const NOTHING = 57378
+const IGNORE = 57379
+const SELECT = 57380
+const DISTINCT = 57381
+const FROM = 57382
+const BEFORE = 57383
+const TX = 57384
+const JOIN = 57385
+const HAVING = 57386
+const WHERE = 57387
+const GROUP = 57388
+const BY = 57389
+const LIMIT = 57390
+const OFFSET = 57391
+const ORDER = 57392
+const ASC = 57393
+const DESC = 57394
+const AS = 57395
+const NOT = 57396
+const LIKE = 57397
+const IF = 57398
+const EXISTS = 57399
+const IN = 57400
+const IS = 57401
+const BETWEEN = 57402
+const SYNONYM = 57403
+const FOR = 57404
+const AUTO_INCREMENT = 57405
+const NULL = 57406
+const NPARAM = 57407
+const CAST = 57408
+const CASE = 57409
+const WHEN = 57410
+const THEN = 57411
+const ELSE = 57412
+const END = 57413
+const INTERVAL = 57414
+const UNION = 57415
+const INTERSECT = 57416
+const EXCEPT = 57417
+const ALL = 57418
+const OUTER = 57419
+const MATERIALIZED = 57420
+const VIEW = 57421
+const REFRESH = 57422
+const PPARAM = 57423
+const JOINTYPE = 57424
+const LOP = 57425
+const CMPOP = 57426
+const MATCHES = 57427
+const IDENTIFIER = 57428
+const TYPE = 57429
+const NUMBER = 57430
+const FLOAT = 57431
+const VARCHAR = 57432
+const BOOLEAN = 57433
+const BLOB = 57434
+const AGGREGATE_FUNC = 57435
+const ERROR = 57436
+const STMT_SEPARATOR = 57437
 
 var yyToknames = [...]string{
 	"$end",
@@ -136,9 +164,12 @@ var yyToknames = [...]string{
 	"ON",
 	"ALTER",
 	"ADD",
+	"DROP",
+	"RENAME",
 	"COLUMN",
 	"PRIMARY",
 	"KEY",
+	"CHECK",
 	"BEGIN",
 	"TRANSACTION",
 	"COMMIT",
@@ -153,6 +184,7 @@ var yyToknames = [...]string{
 	"CONFLICT",
 	"DO",
 	"NOTHING",
+	"IGNORE",
 	"SELECT",
 	"DISTINCT",
 	"FROM",
@@ -164,6 +196,7 @@ var yyToknames = [...]string{
 	"GROUP",
 	"BY",
 	"LIMIT",
+	"OFFSET",
 	"ORDER",
 	"ASC",
 	"DESC",
@@ -174,17 +207,36 @@ var yyToknames = [...]string{
 	"EXISTS",
 	"IN",
 	"IS",
+	"BETWEEN",
+	"SYNONYM",
+	"FOR",
 	"AUTO_INCREMENT",
 	"NULL",
 	"NPARAM",
 	"CAST",
+	"CASE",
+	"WHEN",
+	"THEN",
+	"ELSE",
+	"END",
+	"INTERVAL",
+	"UNION",
+	"INTERSECT",
+	"EXCEPT",
+	"ALL",
+	"OUTER",
+	"MATERIALIZED",
+	"VIEW",
+	"REFRESH",
 	"PPARAM",
 	"JOINTYPE",
 	"LOP",
 	"CMPOP",
+	"MATCHES",
 	"IDENTIFIER",
 	"TYPE",
 	"NUMBER",
+	"FLOAT",
 	"VARCHAR",
 	"BOOLEAN",
 	"BLOB",
@@ -210,196 +262,308 @@ const yyErrCode = 2
 const yyInitialStackSize = 16
 
 var yyExca = [...]int{
-	-1, 1,
+	-1, 14,
 	1, -1,
 	-2, 0,
-	-1, 94,
-	50, 123,
-	53, 123,
-	-2, 112,
-	-1, 154,
-	39, 90,
-	-2, 85,
-	-1, 187,
-	39, 90,
-	-2, 87,
+	-1, 170,
+	55, 180,
+	58, 180,
+	60, 180,
+	-2, 167,
+	-1, 252,
+	43, 141,
+	-2, 135,
+	-1, 299,
+	43, 141,
+	-2, 137,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 333
+const yyLast = 918
 
 var yyAct = [...]int{
-	226, 269, 54, 132, 91, 200, 203, 114, 225, 6,
-	75, 186, 67, 199, 123, 61, 70, 88, 17, 238,
-	242, 130, 130, 130, 196, 251, 130, 246, 245, 243,
-	220, 197, 244, 96, 131, 241, 98, 209, 191, 204,
-	110, 108, 106, 109, 183, 32, 159, 107, 158, 102,
-	103, 104, 105, 55, 205, 201, 96, 97, 129, 98,
-	116, 208, 101, 110, 108, 106, 109, 164, 148, 93,
-	107, 141, 102, 103, 104, 105, 55, 146, 139, 140,
-	97, 120, 111, 125, 90, 101, 141, 80, 78, 135,
-	136, 138, 137, 139, 140, 19, 181, 144, 145, 66,
-	65, 128, 147, 141, 135, 136, 138, 137, 219, 79,
-	160, 149, 79, 49, 153, 223, 151, 268, 263, 154,
-	168, 210, 242, 138, 137, 99, 156, 141, 157, 152,
-	222, 155, 161, 141, 139, 140, 170, 171, 172, 173,
-	174, 175, 141, 163, 68, 135, 136, 138, 137, 182,
-	140, 135, 136, 138, 137, 184, 180, 56, 56, 53,
-	135, 136, 138, 137, 55, 56, 190, 130, 119, 51,
-	74, 55, 222, 127, 112, 85, 194, 230, 77, 207,
-	193, 202, 198, 162, 56, 89, 192, 166, 71, 150,
-	124, 126, 121, 76, 118, 82, 72, 57, 211, 212,
-	117, 32, 214, 44, 41, 36, 113, 189, 218, 177,
-	237, 206, 236, 81, 38, 217, 176, 229, 228, 141,
-	143, 233, 234, 227, 178, 124, 58, 179, 239, 270,
-	271, 255, 133, 262, 249, 232, 68, 248, 250, 213,
-	84, 63, 62, 253, 73, 30, 37, 34, 17, 256,
-	260, 252, 258, 240, 48, 167, 165, 29, 261, 115,
-	264, 10, 11, 28, 20, 266, 267, 215, 86, 64,
-	39, 272, 12, 2, 273, 259, 31, 7, 169, 8,
-	9, 13, 14, 83, 59, 15, 16, 60, 45, 46,
-	47, 17, 21, 35, 134, 40, 27, 22, 24, 23,
-	43, 25, 26, 92, 18, 221, 69, 142, 216, 235,
-	254, 265, 195, 231, 95, 94, 247, 188, 187, 185,
-	42, 33, 52, 50, 100, 224, 257, 87, 122, 5,
-	4, 3, 1,
+	12, 241, 36, 73, 74, 28, 29, 225, 98, 99,
+	100, 35, 284, 73, 74, 285, 151, 286, 352, 152,
+	198, 396, 199, 75, 30, 397, 153, 154, 155, 74,
+	76, 226, 227, 75, 156, 77, 47, 225, 48, 95,
+	76, 225, 40, 157, 228, 229, 230, 231, 158, 159,
+	160, 161, 162, 163, 164, 76, 37, 151, 194, 165,
+	152, 226, 227, 39, 166, 226, 227, 153, 154, 155,
+	74, 96, 312, 225, 199, 156, 230, 231, 228, 229,
+	230, 231, 31, 242, 157, 274, 200, 32, 232, 158,
+	159, 160, 161, 162, 163, 164, 76, 226, 227, 353,
+	165, 153, 154, 155, 74, 166, 47, 225, 48, 156,
+	228, 229, 230, 231, 109, 118, 38, 119, 157, 233,
+	42, 1, 2, 158, 159, 160, 161, 162, 163, 164,
+	76, 179, 3, 180, 4, 141, 295, 33, 200, 166,
+	5, 186, 6, 7, 8, 9, 230, 231, 10, 11,
+	40, 225, 212, 187, 34, 12, 325, 153, 154, 155,
+	215, 292, 225, 44, 175, 156, 22, 118, 225, 221,
+	253, 23, 24, 25, 157, 226, 227, 225, 121, 302,
+	159, 160, 161, 162, 163, 164, 226, 227, 228, 229,
+	230, 231, 226, 227, 225, 45, 121, 13, 225, 228,
+	229, 230, 231, 254, 50, 228, 229, 230, 231, 51,
+	225, 122, 365, 54, 228, 229, 230, 231, 226, 227,
+	275, 26, 226, 227, 56, 47, 47, 48, 48, 122,
+	276, 228, 229, 230, 231, 227, 260, 212, 27, 123,
+	124, 125, 126, 305, 261, 316, 52, 228, 229, 230,
+	231, 309, 346, 310, 57, 324, 377, 58, 212, 306,
+	212, 59, 347, 247, 409, 410, 350, 61, 364, 68,
+	62, 378, 66, 71, 72, 83, 88, 85, 89, 91,
+	92, 94, 101, 102, 106, 103, 107, 108, 104, 109,
+	111, 112, 116, 115, 128, 130, 120, 12, 129, 133,
+	135, 136, 137, 138, 140, 141, 142, 139, 134, 143,
+	144, 172, 145, 146, 174, 182, 207, 189, 212, 204,
+	206, 235, 205, 208, 200, 236, 210, 245, 257, 211,
+	266, 213, 218, 268, 270, 220, 214, 222, 217, 219,
+	287, 301, 327, 225, 247, 339, 296, 259, 246, 349,
+	265, 118, 267, 248, 355, 249, 288, 290, 250, 293,
+	258, 318, 335, 262, 360, 368, 359, 369, 340, 373,
+	344, 319, 96, 381, 322, 326, 345, 380, 329, 356,
+	385, 387, 332, 221, 353, 393, 186, 391, 199, 399,
+	402, 14, 398, 406, 15, 16, 366, 17, 413, 372,
+	367, 376, 18, 19, 412, 20, 175, 21, 49, 86,
+	336, 201, 202, 147, 307, 148, 388, 320, 321, 183,
+	184, 168, 167, 79, 80, 81, 82, 43, 195, 196,
+	60, 297, 299, 298, 97, 300, 382, 110, 170, 181,
+	84, 41, 117, 171, 361, 237, 407, 311, 405, 392,
+	411, 53, 63, 386, 348, 173, 234, 370, 113, 114,
+	87, 354, 203, 127, 395, 78, 67, 46, 69, 70,
+	264, 0, 0, 0, 0, 0, 0, 55, 0, 0,
+	0, 0, 0, 0, 0, 64, 65, 0, 0, 0,
+	0, 0, 131, 0, 0, 132, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 90, 0, 0, 93,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 105,
+	0, 0, 150, 185, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 149, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 188, 0, 0,
+	0, 78, 176, 197, 0, 209, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 251, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 263,
+	271, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	269, 255, 240, 169, 239, 0, 0, 0, 0, 177,
+	178, 0, 273, 0, 0, 0, 0, 190, 191, 192,
+	193, 252, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 256, 0, 0, 0, 0, 0, 317, 0, 0,
+	0, 0, 0, 313, 0, 216, 315, 303, 0, 0,
+	0, 0, 0, 0, 0, 0, 289, 0, 323, 223,
+	224, 0, 0, 0, 0, 0, 0, 0, 238, 0,
+	0, 0, 331, 243, 244, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 304,
+	342, 0, 0, 314, 0, 330, 0, 0, 0, 308,
+	0, 0, 357, 338, 0, 337, 0, 0, 0, 0,
+	351, 0, 0, 272, 0, 0, 0, 0, 0, 358,
+	277, 278, 279, 280, 281, 282, 0, 283, 0, 334,
+	0, 0, 0, 0, 371, 0, 291, 341, 362, 0,
+	0, 294, 374, 375, 0, 363, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 379, 0,
+	0, 0, 0, 0, 0, 0, 0, 389, 328, 0,
+	0, 0, 0, 0, 0, 0, 333, 0, 383, 0,
+	0, 0, 0, 0, 0, 403, 404, 384, 0, 0,
+	0, 0, 343, 0, 0, 0, 0, 0, 400, 414,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 394,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 408, 0, 0, 415, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 390, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 401,
 }
 
 var yyPact = [...]int{
-	257, -1000, -1000, 18, -1000, -1000, -1000, 243, -1000, -1000,
-	286, 295, 285, 237, 231, 209, 138, 212, -1000, 257,
-	-1000, 142, 163, 163, 282, 141, 292, 140, 138, 138,
-	138, 224, 37, 95, -1000, -1000, -1000, 134, 177, 270,
-	163, -1000, 205, 203, 253, 22, 21, 195, 125, 133,
-	208, -1000, 99, 130, -1000, 10, 36, 9, 161, 132,
-	269, -1000, 202, 110, 251, 122, 122, 298, 7, 103,
-	-1000, 144, -1000, -18, 102, -1000, -1000, 131, 94, 129,
-	127, -1000, 5, 128, 108, -1000, 127, -21, 96, -1000,
-	-45, 188, 281, 32, 171, -1000, 7, 7, -1, -1000,
-	-1000, 7, -1000, -1000, -1000, -1000, -10, 33, 126, -1000,
-	-1000, 298, 125, 7, 298, 205, 214, 130, -1000, -31,
-	-33, 34, 61, -1000, 119, 122, -11, -1000, -1000, 229,
-	124, 228, -1000, 55, 264, 7, 7, 7, 7, 7,
-	7, 160, 174, -1000, 88, 49, 214, 17, 7, -35,
-	-1000, 188, -1000, 32, 147, 130, -41, -1000, -1000, -1000,
-	123, 162, -56, -48, 122, -23, -1000, -23, -1000, -24,
-	49, 49, 165, 165, 88, 79, -1000, 155, 7, -17,
-	-42, -1000, 73, -1000, -1000, 195, -1000, 147, 200, -1000,
-	-1000, 130, -1000, 248, -1000, 159, 43, -1000, -49, 101,
-	-1000, 7, 59, -1000, -1000, 122, -1000, 88, -16, -1000,
-	113, 193, -1000, -18, -1000, -24, 157, -1000, 154, -62,
-	-1000, -1000, -23, 222, -44, 51, 32, -50, -47, -51,
-	-52, 197, 191, 298, -54, -1000, -1000, -1000, -1000, -1000,
-	219, -1000, 7, -1000, -1000, -1000, -1000, 186, 7, 121,
-	261, -1000, 217, 32, 188, 190, 32, 47, -1000, 7,
-	-1000, -1000, 121, 121, 32, 46, 183, -1000, 121, -1000,
-	-1000, -1000, 183, -1000,
+	117, 160, -1, 13, 76, -13, -1000, -1000, -27, 87,
+	23, 64, 81, 85, -1000, -1000, 94, -1000, -37, -1000,
+	130, -1000, 123, 190, 200, 190, 138, 175, 171, 253,
+	181, 214, 214, 214, 193, -1000, 64, 232, 64, 64,
+	173, 241, -1000, -63, 196, 117, -1000, -1000, -1000, 201,
+	201, -1000, 222, 192, 190, 265, 218, 190, -1000, 239,
+	30, -8, 225, 197, 199, 202, 214, 182, 257, 185,
+	244, 204, 205, 191, 224, 15, 194, -1000, -1000, 143,
+	254, 203, -1000, 209, -1000, -1000, 259, 259, 242, 206,
+	286, 215, 216, 217, 219, 294, 263, -1000, 287, 290,
+	291, -1000, -1000, -1000, -1000, 226, 227, 64, 227, 3,
+	306, -1000, 230, -1000, 69, 3, 3, 63, 229, 3,
+	55, 231, -1000, 3, 3, 3, 3, -1000, -44, -53,
+	-1000, 130, -1000, -1000, 0, 233, 220, -1000, 267, -1000,
+	274, 235, 238, 240, 243, -1000, -1000, 223, 228, 234,
+	57, 3, 236, -1000, 246, 237, 245, -1000, 67, 247,
+	-1000, -1000, -1000, -1000, -1000, 3, 3, -1000, -1000, 135,
+	34, -1000, 308, 277, 3, 205, 306, -52, 14, 3,
+	3, 256, 248, 249, 250, 135, 251, 252, 255, -1000,
+	-22, -22, 139, 139, 259, 306, 162, 143, 307, 258,
+	260, 141, -1000, -1000, 261, 227, -1000, 262, -1000, -1000,
+	-1000, 320, 266, 303, 227, 304, 151, 259, -1000, 3,
+	-1000, 3, -1000, 48, -18, 166, 3, 3, 3, 3,
+	3, 3, -1000, 3, -43, 326, 268, -1000, 135, -1000,
+	277, 270, 3, 92, 135, -1000, 273, 3, -1000, -1000,
+	-1000, 33, 264, 299, 93, 125, -1000, 157, -1000, 149,
+	52, -1000, 227, 142, 259, -1000, 275, -1000, 269, 271,
+	269, 152, 103, 272, -1000, 278, -1000, 151, 118, 48,
+	48, 284, 284, 151, 3, 276, 37, 157, -1000, -1000,
+	279, 135, 3, -1000, 135, 125, 285, 244, -1000, 264,
+	302, 280, 281, 125, -1000, -1000, 227, -1000, 3, 282,
+	288, 198, 328, -1000, -1000, 163, -1000, -37, -1000, 3,
+	4, -1000, 324, 289, -1000, 292, -1000, -1000, 151, -38,
+	283, -1000, -1000, 135, -1000, -1000, -1000, 318, -1000, -44,
+	331, -1000, 165, 109, 293, 295, 301, -1000, 355, 157,
+	-1000, 296, 335, 269, -1000, 269, 298, 153, 168, 37,
+	330, 329, 306, 125, -1000, -1000, -1000, -1000, -1000, -1000,
+	317, -1000, -1000, 346, -1000, 289, -1000, -1000, -1000, -1000,
+	300, 3, 337, 371, -1000, -1000, 366, -11, 297, -1000,
+	135, 342, 277, 3, -1000, -1000, 357, -1000, 300, 300,
+	344, 135, 205, -1000, 213, 309, 310, -1000, 311, -1000,
+	-1000, -1000, 300, -1000, 213, -1000,
 }
 
 var yyPgo = [...]int{
-	0, 332, 273, 331, 330, 9, 329, 328, 14, 17,
-	6, 327, 326, 13, 5, 8, 325, 324, 125, 323,
-	322, 2, 321, 7, 259, 320, 15, 319, 11, 318,
-	317, 0, 12, 316, 315, 314, 313, 3, 312, 10,
-	311, 310, 1, 4, 246, 309, 308, 307, 16, 306,
-	305, 304,
+	0, 391, 394, 395, 397, 402, 403, 405, 407, 408,
+	409, 410, 411, 412, 413, 414, 415, 416, 417, 418,
+	419, 420, 422, 421, 423, 424, 425, 426, 427, 428,
+	429, 430, 434, 431, 433, 432, 435, 523, 437, 436,
+	438, 439, 442, 443, 444, 445, 446, 447, 463, 448,
+	449, 450, 455, 451, 452, 453, 454, 456, 457, 458,
+	459, 461, 462, 464, 467, 470,
 }
 
 var yyR1 = [...]int{
-	0, 1, 2, 2, 51, 51, 3, 3, 3, 4,
-	4, 4, 4, 4, 4, 4, 4, 4, 4, 25,
-	25, 44, 44, 10, 10, 6, 6, 6, 6, 50,
-	50, 49, 49, 48, 11, 11, 13, 13, 14, 9,
-	9, 12, 12, 16, 16, 15, 15, 17, 17, 17,
-	17, 17, 17, 17, 17, 17, 7, 7, 8, 38,
-	38, 45, 45, 46, 46, 46, 5, 22, 22, 19,
-	19, 20, 20, 18, 18, 18, 21, 21, 21, 23,
-	23, 24, 24, 26, 26, 27, 27, 28, 28, 29,
-	30, 30, 32, 32, 36, 36, 33, 33, 37, 37,
-	41, 41, 43, 43, 40, 40, 42, 42, 42, 39,
-	39, 39, 31, 31, 31, 31, 31, 31, 31, 31,
-	34, 34, 34, 47, 47, 35, 35, 35, 35, 35,
-	35, 35, 35,
+	0, 1, 2, 2, 64, 64, 3, 3, 3, 4,
+	4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+	4, 4, 4, 4, 4, 4, 4, 4, 4, 65,
+	31, 31, 53, 53, 54, 54, 15, 15, 6, 6,
+	6, 6, 6, 61, 61, 61, 60, 60, 59, 16,
+	16, 18, 18, 19, 14, 14, 17, 17, 21, 21,
+	20, 20, 22, 22, 22, 22, 22, 22, 22, 22,
+	22, 22, 22, 22, 12, 12, 12, 12, 12, 12,
+	13, 62, 63, 63, 47, 47, 47, 58, 58, 55,
+	55, 56, 56, 56, 5, 5, 7, 7, 9, 9,
+	10, 10, 8, 28, 28, 25, 25, 26, 26, 24,
+	24, 24, 24, 24, 24, 24, 23, 23, 23, 23,
+	42, 42, 41, 41, 27, 27, 27, 29, 29, 29,
+	29, 30, 30, 32, 32, 33, 33, 34, 34, 35,
+	35, 36, 36, 11, 11, 38, 38, 44, 44, 39,
+	39, 45, 45, 46, 46, 50, 50, 52, 52, 49,
+	49, 51, 51, 51, 48, 48, 48, 37, 37, 37,
+	37, 37, 37, 37, 37, 37, 37, 40, 40, 40,
+	57, 57, 43, 43, 43, 43, 43, 43, 43, 43,
 }
 
 var yyR2 = [...]int{
 	0, 1, 2, 3, 0, 1, 1, 1, 1, 2,
-	1, 1, 3, 3, 4, 11, 8, 9, 6, 0,
-	3, 0, 3, 1, 3, 9, 8, 6, 7, 0,
-	4, 1, 3, 3, 0, 1, 1, 3, 3, 1,
-	3, 1, 3, 0, 1, 1, 3, 1, 1, 1,
-	1, 6, 3, 2, 1, 1, 1, 3, 5, 0,
-	3, 0, 1, 0, 1, 2, 12, 0, 1, 1,
-	1, 2, 4, 1, 4, 4, 1, 3, 5, 3,
+	1, 1, 3, 4, 3, 4, 7, 7, 4, 8,
+	9, 6, 6, 8, 5, 4, 8, 4, 5, 0,
+	0, 3, 0, 3, 0, 2, 1, 3, 9, 10,
+	8, 6, 7, 0, 4, 6, 1, 3, 3, 0,
+	1, 1, 3, 3, 1, 3, 1, 3, 0, 1,
+	1, 3, 1, 1, 1, 1, 1, 6, 2, 2,
+	4, 2, 1, 1, 1, 1, 3, 3, 3, 5,
+	7, 5, 0, 1, 0, 3, 3, 0, 1, 0,
+	1, 0, 1, 2, 1, 4, 1, 4, 1, 1,
+	0, 1, 13, 0, 1, 1, 1, 2, 4, 1,
+	4, 6, 3, 3, 3, 3, 1, 4, 4, 4,
+	4, 5, 0, 2, 1, 3, 5, 3, 6, 4,
 	4, 1, 3, 0, 3, 0, 1, 1, 2, 6,
-	0, 1, 0, 2, 0, 3, 0, 2, 0, 2,
-	0, 3, 0, 4, 2, 4, 0, 1, 1, 0,
-	1, 2, 1, 1, 2, 2, 4, 4, 6, 6,
-	1, 1, 3, 0, 1, 3, 3, 3, 3, 3,
-	3, 3, 4,
+	4, 0, 2, 0, 1, 0, 2, 0, 3, 0,
+	2, 0, 2, 0, 2, 0, 3, 0, 4, 2,
+	4, 0, 1, 1, 0, 1, 2, 1, 1, 2,
+	2, 4, 3, 4, 6, 6, 6, 1, 1, 3,
+	0, 1, 3, 3, 3, 3, 3, 3, 3, 4,
 }
 
 var yyChk = [...]int{
-	-1000, -1, -2, -3, -4, -6, -5, 20, 22, 23,
-	4, 5, 15, 24, 25, 28, 29, 34, -51, 77,
-	21, 6, 11, 13, 12, 6, 7, 11, 26, 26,
-	36, -24, 63, -22, 35, -2, 63, -44, 51, -44,
-	13, 63, -25, 8, 63, -24, -24, -24, 30, 76,
-	-19, 74, -20, -18, -21, 69, 63, 63, 49, 14,
-	-44, -26, 37, 38, 16, 78, 78, -32, 41, -49,
-	-48, 63, 63, 36, 71, -39, 63, 48, 78, 76,
-	78, 52, 63, 14, 38, 65, 17, -11, -9, 63,
-	-9, -43, 5, -31, -34, -35, 49, 73, 52, -18,
-	-17, 78, 65, 66, 67, 68, 58, 63, 57, 59,
-	56, -32, 71, 62, -23, -24, 78, -18, 63, 74,
-	-21, 63, -7, -8, 63, 78, 63, 65, -8, 79,
-	71, 79, -37, 44, 13, 72, 73, 75, 74, 61,
-	62, 54, -47, 49, -31, -31, 78, -31, 78, 78,
-	63, -43, -48, -31, -43, -26, -5, -39, 79, 79,
-	76, 71, 64, -9, 78, 27, 63, 27, 65, 14,
-	-31, -31, -31, -31, -31, -31, 56, 49, 50, 53,
-	-5, 79, -31, 79, -37, -27, -28, -29, -30, 60,
-	-39, 79, 63, 18, -8, -38, 80, 79, -9, -13,
-	-14, 78, -13, -10, 63, 78, 56, -31, 78, 79,
-	48, -32, -28, 39, -39, 19, -46, 56, 49, 65,
-	79, -50, 71, 14, -16, -15, -31, -9, -5, -15,
-	64, -36, 42, -23, -10, -45, 55, 56, 81, -14,
-	31, 79, 71, 79, 79, 79, 79, -33, 40, 43,
-	-43, 79, 32, -31, -41, 45, -31, -12, -21, 14,
-	33, -37, 43, 71, -31, -40, -21, -21, 71, -42,
-	46, 47, -21, -42,
+	-1000, 4, 5, 15, 17, 23, 25, 26, 27, 28,
+	31, 32, 38, 80, -1, -2, -3, -4, -5, -6,
+	-7, -8, 6, 11, 12, 13, 61, 78, 6, 7,
+	11, 6, 11, 61, 78, 24, 29, 83, 29, 40,
+	86, -30, 39, -28, 78, 101, -64, 73, 75, -9,
+	74, 86, 56, -53, 13, -53, 86, 79, 86, 8,
+	-31, 86, 56, -54, -54, -54, 79, -30, 37, -30,
+	-30, 100, 33, 66, 67, 86, 93, 98, -23, -24,
+	-25, -26, -27, 79, -2, 76, -10, -10, 54, 86,
+	-53, 14, 62, -53, 42, 9, 41, -32, 16, 17,
+	18, 57, 86, 86, 86, -54, 102, 29, 102, 45,
+	-38, 86, 86, -59, -60, 102, 68, -42, 100, 102,
+	102, 53, 86, 96, 97, 98, 99, -48, 40, 95,
+	86, -7, -8, 57, 102, 14, 86, 86, 86, 88,
+	10, 42, 19, 19, 19, 86, 86, -14, -16, -30,
+	-14, 54, 57, 64, 65, 66, 72, 81, 86, 87,
+	88, 89, 90, 91, 92, 97, 102, -22, -23, -37,
+	-40, -43, 5, -52, 84, 95, -38, -37, -37, 68,
+	70, -41, 86, -20, -21, -37, 86, 98, -27, 86,
+	-37, -37, -37, -37, 102, -29, -30, -24, 20, 22,
+	86, -12, -13, -62, 86, 102, 53, 42, 88, -13,
+	86, 86, 95, 103, 102, 103, -37, 102, 86, 102,
+	90, 102, 90, -37, -37, 59, 83, 84, 96, 97,
+	98, 99, 54, 85, -57, 13, 48, -45, -37, -59,
+	-52, 53, 69, -37, -37, 71, 100, 95, 103, 103,
+	103, -5, -52, 8, 41, -32, -48, 21, 102, 87,
+	95, 103, 102, -14, -65, 88, 10, 86, 30, -16,
+	30, -5, -37, -21, 103, 54, 64, -37, -37, -37,
+	-37, -37, -37, -37, 55, 58, 60, 14, 88, -45,
+	87, -37, 69, 86, -37, 103, 82, -33, -34, -35,
+	-36, 42, 86, -22, -48, 86, 102, -15, -65, 102,
+	104, -47, 20, -13, -62, -14, 103, -5, 86, 102,
+	-18, -19, 103, -18, 103, 53, 103, 64, -37, 102,
+	-40, -15, 103, -37, -48, 77, -11, -38, -34, 43,
+	88, -48, -14, -37, 88, 88, 54, 64, -56, 21,
+	103, -21, 14, 95, -61, 30, 87, -5, -20, 83,
+	46, -44, -29, -32, 103, 103, 103, 105, 64, 12,
+	-58, -15, 103, 34, -19, -18, 103, 103, 103, -40,
+	47, 44, -39, -52, -48, 63, -55, 35, -17, -27,
+	-37, 50, -50, 14, -62, -63, 32, 36, 95, 47,
+	-45, -37, 33, -27, -27, -49, 49, -46, -60, 51,
+	52, -51, 95, 88, -27, -51,
 }
 
 var yyDef = [...]int{
-	0, -2, 1, 4, 6, 7, 8, 0, 10, 11,
-	0, 0, 0, 0, 0, 0, 0, 67, 2, 5,
-	9, 0, 21, 21, 0, 0, 19, 0, 0, 0,
-	0, 0, 81, 0, 68, 3, 12, 0, 0, 0,
-	21, 13, 83, 0, 0, 0, 0, 92, 0, 0,
-	0, 69, 70, 109, 73, 0, 76, 0, 0, 0,
-	0, 14, 0, 0, 0, 34, 0, 102, 0, 92,
-	31, 0, 82, 0, 0, 71, 110, 0, 0, 0,
-	0, 22, 0, 0, 0, 20, 0, 0, 35, 39,
-	0, 98, 0, 93, -2, 113, 0, 0, 0, 120,
-	121, 0, 47, 48, 49, 50, 0, 76, 0, 54,
-	55, 102, 0, 0, 102, 83, 0, 109, 111, 0,
-	0, 77, 0, 56, 0, 0, 0, 84, 18, 0,
-	0, 0, 27, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 124, 114, 115, 0, 0, 0, 0,
-	53, 98, 32, 33, -2, 109, 0, 72, 74, 75,
-	0, 0, 59, 0, 0, 0, 40, 0, 99, 0,
-	125, 126, 127, 128, 129, 130, 131, 0, 0, 0,
-	0, 122, 0, 52, 28, 92, 86, -2, 0, 91,
-	79, 109, 78, 0, 57, 63, 0, 16, 0, 29,
-	36, 43, 26, 103, 23, 0, 132, 116, 0, 117,
-	0, 94, 88, 0, 80, 0, 61, 64, 0, 0,
-	17, 25, 0, 0, 0, 44, 45, 0, 0, 0,
-	0, 96, 0, 102, 0, 58, 62, 65, 60, 37,
-	0, 38, 0, 24, 118, 119, 51, 100, 0, 0,
-	0, 15, 0, 46, 98, 0, 97, 95, 41, 0,
-	30, 66, 0, 0, 89, 101, 106, 42, 0, 104,
-	107, 108, 106, 105,
+	0, 0, 0, 0, 0, 0, 10, 11, 0, 0,
+	0, 0, 103, 0, -2, 1, 4, 6, 8, 7,
+	94, 96, 0, 32, 0, 32, 0, 0, 0, 30,
+	0, 34, 34, 34, 0, 9, 0, 0, 0, 0,
+	131, 0, 104, 0, 0, 5, 2, 98, 99, 100,
+	100, 12, 0, 0, 32, 0, 0, 32, 14, 0,
+	133, 0, 0, 0, 0, 0, 34, 0, 0, 0,
+	145, 0, 0, 0, 0, 124, 0, 105, 109, 164,
+	0, 106, 116, 0, 3, 101, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 15, 0, 0,
+	0, 35, 13, 18, 25, 0, 49, 0, 0, 0,
+	157, 132, 0, 46, 145, 0, 0, 122, 0, 58,
+	0, 0, 165, 0, 0, 0, 0, 107, 0, 0,
+	27, 95, 97, 33, 0, 0, 0, 24, 0, 31,
+	0, 0, 0, 0, 0, 28, 54, 50, 0, 0,
+	0, 0, 0, 73, 0, 0, 0, 72, 124, 0,
+	62, 63, 64, 65, 66, 0, 0, 178, 177, 146,
+	-2, 168, 0, 151, 0, 0, 157, 0, 0, 0,
+	0, 0, 125, 59, 0, 60, 124, 0, 0, 166,
+	112, 113, 115, 114, 0, 157, 133, 164, 0, 0,
+	0, 0, 74, 75, 0, 0, 29, 0, 134, 21,
+	22, 0, 0, 0, 49, 0, 169, 0, 71, 0,
+	69, 58, 68, 170, 0, 0, 0, 0, 0, 0,
+	0, 0, 181, 0, 0, 0, 0, 41, 48, 47,
+	151, 0, 0, 0, 123, 119, 0, 0, 110, 117,
+	118, 0, -2, 0, 0, 164, 108, 0, 29, 84,
+	0, 17, 0, 0, 0, 16, 0, 55, 0, 0,
+	0, 0, 0, 0, 179, 0, 188, 186, 187, 182,
+	183, 185, 184, 172, 0, 0, 0, 0, 152, 42,
+	0, 120, 0, 126, 61, 164, 143, 145, 136, -2,
+	0, 0, 0, 164, 127, 36, 0, 76, 0, 0,
+	0, 91, 0, 77, 78, 0, 19, 26, 23, 58,
+	43, 51, 0, 40, 173, 0, 70, 189, 171, 0,
+	0, 158, 111, 121, 130, 144, 142, 147, 138, 0,
+	133, 129, 0, 0, 0, 0, 0, 92, 87, 0,
+	20, 0, 0, 0, 38, 0, 0, 0, 0, 0,
+	0, 149, 157, 164, 37, 81, 86, 85, 93, 88,
+	89, 79, 53, 0, 52, 39, 67, 174, 175, 176,
+	0, 0, 155, 140, 128, 90, 82, 0, 148, 56,
+	150, 0, 151, 0, 83, 80, 0, 44, 0, 0,
+	153, 139, 0, 57, 161, 156, 0, 102, 45, 162,
+	163, 159, 0, 154, 161, 160,
 }
 
 var yyTok1 = [...]int{
@@ -407,12 +571,12 @@ var yyTok1 = [...]int{
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	78, 79, 74, 72, 71, 73, 76, 75, 3, 3,
+	102, 103, 98, 96, 95, 97, 100, 99, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 80, 3, 81,
+	3, 104, 3, 105,
 }
 
 var yyTok2 = [...]int{
@@ -422,7 +586,10 @@ var yyTok2 = [...]int{
 	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
 	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
 	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
-	62, 63, 64, 65, 66, 67, 68, 69, 70, 77,
+	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
+	72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
+	82, 83, 84, 85, 86, 87, 88, 89, 90, 91,
+	92, 93, 94, 101,
 }
 
 var yyTok3 = [...]int{
@@ -805,272 +972,470 @@ yydefault:
 			yyVAL.stmt = &CreateDatabaseStmt{DB: yyDollar[3].id}
 		}
 	case 13:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.stmt = &DropDatabaseStmt{ifExists: yyDollar[3].boolean, DB: yyDollar[4].id}
+		}
+	case 14:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.stmt = &UseDatabaseStmt{DB: yyDollar[3].id}
 		}
-	case 14:
+	case 15:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.stmt = &UseSnapshotStmt{sinceTx: yyDollar[3].number, asBefore: yyDollar[4].number}
 		}
-	case 15:
-		yyDollar = yyS[yypt-11 : yypt+1]
+	case 16:
+		yyDollar = yyS[yypt-7 : yypt+1]
 		{
-			yyVAL.stmt = &CreateTableStmt{ifNotExists: yyDollar[3].boolean, table: yyDollar[4].id, colsSpec: yyDollar[6].colsSpec, pkColNames: yyDollar[10].ids}
+			yyVAL.stmt = &UseSnapshotStmt{sinceTx: yyDollar[3].number, upToTx: yyDollar[7].number}
 		}
-	case 16:
+	case 17:
+		yyDollar = yyS[yypt-7 : yypt+1]
+		{
+			if len(yyDollar[6].tableElems.pkColNames) == 0 {
+				yylex.Error("syntax error: missing PRIMARY KEY")
+				return 1
+			}
+
+			yyDollar[6].tableElems.ifNotExists = yyDollar[3].boolean
+			yyDollar[6].tableElems.table = yyDollar[4].id
+
+			yyVAL.stmt = yyDollar[6].tableElems
+		}
+	case 18:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.stmt = &DropTableStmt{ifExists: yyDollar[3].boolean, table: yyDollar[4].id}
+		}
+	case 19:
 		yyDollar = yyS[yypt-8 : yypt+1]
 		{
 			yyVAL.stmt = &CreateIndexStmt{ifNotExists: yyDollar[3].boolean, table: yyDollar[5].id, cols: yyDollar[7].ids}
 		}
-	case 17:
+	case 20:
 		yyDollar = yyS[yypt-9 : yypt+1]
 		{
 			yyVAL.stmt = &CreateIndexStmt{unique: true, ifNotExists: yyDollar[4].boolean, table: yyDollar[6].id, cols: yyDollar[8].ids}
 		}
-	case 18:
+	case 21:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.stmt = &AddColumnStmt{table: yyDollar[3].id, colSpec: yyDollar[6].colSpec}
 		}
-	case 19:
+	case 22:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			yyVAL.stmt = &DropColumnStmt{table: yyDollar[3].id, colName: yyDollar[6].id}
+		}
+	case 23:
+		yyDollar = yyS[yypt-8 : yypt+1]
+		{
+			yyVAL.stmt = &RenameColumnStmt{table: yyDollar[3].id, oldName: yyDollar[6].id, newName: yyDollar[8].id}
+		}
+	case 24:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.stmt = &CreateSynonymStmt{synonym: yyDollar[3].id, table: yyDollar[5].id}
+		}
+	case 25:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.stmt = &DropSynonymStmt{ifExists: yyDollar[3].boolean, synonym: yyDollar[4].id}
+		}
+	case 26:
+		yyDollar = yyS[yypt-8 : yypt+1]
+		{
+			yyVAL.stmt = &CreateMaterializedViewStmt{ifNotExists: yyDollar[4].boolean, view: yyDollar[5].id, query: yyDollar[8].stmt.(*SelectStmt), sql: yylex.(*lexer).capturedSQL()}
+		}
+	case 27:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.stmt = &RefreshMaterializedViewStmt{view: yyDollar[4].id}
+		}
+	case 28:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.stmt = &DropMaterializedViewStmt{ifExists: yyDollar[4].boolean, view: yyDollar[5].id}
+		}
+	case 29:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yylex.(*lexer).captureSQL()
+		}
+	case 30:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.number = 0
 		}
-	case 20:
+	case 31:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.number = yyDollar[3].number
 		}
-	case 21:
+	case 32:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.boolean = false
 		}
-	case 22:
+	case 33:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.boolean = true
 		}
-	case 23:
+	case 34:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.boolean = false
+		}
+	case 35:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.boolean = true
+		}
+	case 36:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.ids = []string{yyDollar[1].id}
 		}
-	case 24:
+	case 37:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.ids = yyDollar[2].ids
 		}
-	case 25:
+	case 38:
 		yyDollar = yyS[yypt-9 : yypt+1]
 		{
 			yyVAL.stmt = &UpsertIntoStmt{isInsert: true, tableRef: yyDollar[3].tableRef, cols: yyDollar[5].ids, rows: yyDollar[8].rows, onConflict: yyDollar[9].onConflict}
 		}
-	case 26:
+	case 39:
+		yyDollar = yyS[yypt-10 : yypt+1]
+		{
+			if yyDollar[2].logicOp != OR {
+				yylex.Error("syntax error: unexpected AND, expecting OR")
+				return 1
+			}
+
+			yyVAL.stmt = &UpsertIntoStmt{isInsert: true, tableRef: yyDollar[5].tableRef, cols: yyDollar[7].ids, rows: yyDollar[10].rows, onConflict: &OnConflictDo{}}
+		}
+	case 40:
 		yyDollar = yyS[yypt-8 : yypt+1]
 		{
 			yyVAL.stmt = &UpsertIntoStmt{tableRef: yyDollar[3].tableRef, cols: yyDollar[5].ids, rows: yyDollar[8].rows}
 		}
-	case 27:
+	case 41:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.stmt = &DeleteFromStmt{tableRef: yyDollar[3].tableRef, where: yyDollar[4].exp, indexOn: yyDollar[5].ids, limit: int(yyDollar[6].number)}
 		}
-	case 28:
+	case 42:
 		yyDollar = yyS[yypt-7 : yypt+1]
 		{
 			yyVAL.stmt = &UpdateStmt{tableRef: yyDollar[2].tableRef, updates: yyDollar[4].updates, where: yyDollar[5].exp, indexOn: yyDollar[6].ids, limit: int(yyDollar[7].number)}
 		}
-	case 29:
+	case 43:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.onConflict = nil
 		}
-	case 30:
+	case 44:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.onConflict = &OnConflictDo{}
 		}
-	case 31:
+	case 45:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			yyVAL.onConflict = &OnConflictDo{updates: yyDollar[6].updates}
+		}
+	case 46:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.updates = []*colUpdate{yyDollar[1].update}
 		}
-	case 32:
+	case 47:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.updates = append(yyDollar[1].updates, yyDollar[3].update)
 		}
-	case 33:
+	case 48:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.update = &colUpdate{col: yyDollar[1].id, op: yyDollar[2].cmpOp, val: yyDollar[3].exp}
 		}
-	case 34:
+	case 49:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.ids = nil
 		}
-	case 35:
+	case 50:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.ids = yyDollar[1].ids
 		}
-	case 36:
+	case 51:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.rows = []*RowSpec{yyDollar[1].row}
 		}
-	case 37:
+	case 52:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.rows = append(yyDollar[1].rows, yyDollar[3].row)
 		}
-	case 38:
+	case 53:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.row = &RowSpec{Values: yyDollar[2].values}
 		}
-	case 39:
+	case 54:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.ids = []string{yyDollar[1].id}
 		}
-	case 40:
+	case 55:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.ids = append(yyDollar[1].ids, yyDollar[3].id)
 		}
-	case 41:
+	case 56:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.cols = []*ColSelector{yyDollar[1].col}
 		}
-	case 42:
+	case 57:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.cols = append(yyDollar[1].cols, yyDollar[3].col)
 		}
-	case 43:
+	case 58:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.values = nil
 		}
-	case 44:
+	case 59:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.values = yyDollar[1].values
 		}
-	case 45:
+	case 60:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.values = []ValueExp{yyDollar[1].exp}
 		}
-	case 46:
+	case 61:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.values = append(yyDollar[1].values, yyDollar[3].exp)
 		}
-	case 47:
+	case 62:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &Number{val: int64(yyDollar[1].number)}
 		}
-	case 48:
+	case 63:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.value = &Float{val: yyDollar[1].float}
+		}
+	case 64:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &Varchar{val: yyDollar[1].str}
 		}
-	case 49:
+	case 65:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &Bool{val: yyDollar[1].boolean}
 		}
-	case 50:
+	case 66:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &Blob{val: yyDollar[1].blob}
 		}
-	case 51:
+	case 67:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.value = &Cast{val: yyDollar[3].exp, t: yyDollar[5].sqlType}
 		}
-	case 52:
-		yyDollar = yyS[yypt-3 : yypt+1]
+	case 68:
+		yyDollar = yyS[yypt-2 : yypt+1]
 		{
-			yyVAL.value = &SysFn{fn: yyDollar[1].id}
+			yyVAL.value = &Cast{val: &Varchar{val: yyDollar[2].str}, t: yyDollar[1].sqlType}
 		}
-	case 53:
+	case 69:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			interval, err := parseInterval(yyDollar[2].str)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+
+			yyVAL.value = interval
+		}
+	case 70:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.value = &SysFn{fn: yyDollar[1].id, params: yyDollar[3].values}
+		}
+	case 71:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.value = &Param{id: yyDollar[2].id}
 		}
-	case 54:
+	case 72:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &Param{id: fmt.Sprintf("param%d", yyDollar[1].pparam), pos: yyDollar[1].pparam}
 		}
-	case 55:
+	case 73:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &NullValue{t: AnyType}
 		}
-	case 56:
+	case 74:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.colsSpec = []*ColSpec{yyDollar[1].colSpec}
+			yyVAL.tableElems = &CreateTableStmt{}
+			yyVAL.tableElems.addColSpec(yyDollar[1].colSpec)
 		}
-	case 57:
+	case 75:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.tableElems = &CreateTableStmt{checks: []*checkConstraint{yyDollar[1].check}}
+		}
+	case 76:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
-			yyVAL.colsSpec = append(yyDollar[1].colsSpec, yyDollar[3].colSpec)
+			yyVAL.tableElems = &CreateTableStmt{pkColNames: yyDollar[3].ids}
 		}
-	case 58:
+	case 77:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyDollar[1].tableElems.addColSpec(yyDollar[3].colSpec)
+			yyVAL.tableElems = yyDollar[1].tableElems
+		}
+	case 78:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyDollar[1].tableElems.checks = append(yyDollar[1].tableElems.checks, yyDollar[3].check)
+			yyVAL.tableElems = yyDollar[1].tableElems
+		}
+	case 79:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
-			yyVAL.colSpec = &ColSpec{colName: yyDollar[1].id, colType: yyDollar[2].sqlType, maxLen: int(yyDollar[3].number), notNull: yyDollar[4].boolean, autoIncrement: yyDollar[5].boolean}
+			if len(yyDollar[1].tableElems.pkColNames) > 0 {
+				yylex.Error("syntax error: multiple PRIMARY KEY")
+				return 1
+			}
+
+			yyDollar[1].tableElems.pkColNames = yyDollar[5].ids
+			yyVAL.tableElems = yyDollar[1].tableElems
+		}
+	case 80:
+		yyDollar = yyS[yypt-7 : yypt+1]
+		{
+			yyVAL.colSpec = &ColSpec{colName: yyDollar[1].id, colType: yyDollar[2].sqlType, maxLen: int(yyDollar[3].number), notNull: yyDollar[4].boolean, unique: yyDollar[5].boolean, autoIncrement: yyDollar[6].boolean, check: yyDollar[7].check}
 		}
-	case 59:
+	case 81:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.check = &checkConstraint{exp: yyDollar[4].exp, sql: checkSQL(yylex.(*lexer).capturedSQL())}
+		}
+	case 82:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.check = nil
+		}
+	case 83:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.check = yyDollar[1].check
+		}
+	case 84:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.number = 0
 		}
-	case 60:
+	case 85:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.number = yyDollar[2].number
 		}
-	case 61:
+	case 86:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.number = yyDollar[2].number
+		}
+	case 87:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.boolean = false
 		}
-	case 62:
+	case 88:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.boolean = true
 		}
-	case 63:
+	case 89:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.boolean = false
 		}
-	case 64:
+	case 90:
 		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.boolean = true
+		}
+	case 91:
+		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.boolean = false
 		}
-	case 65:
+	case 92:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.boolean = false
+		}
+	case 93:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.boolean = true
 		}
-	case 66:
-		yyDollar = yyS[yypt-12 : yypt+1]
+	case 95:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.stmt = newSetOperationStmt(yyDollar[2].setOp, yyDollar[3].boolean, yyDollar[1].stmt.(*SelectStmt), yyDollar[4].stmt.(*SelectStmt))
+		}
+	case 97:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.stmt = newSetOperationStmt(INTERSECTOP, yyDollar[3].boolean, yyDollar[1].stmt.(*SelectStmt), yyDollar[4].stmt.(*SelectStmt))
+		}
+	case 98:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.setOp = UNIONOP
+		}
+	case 99:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.setOp = EXCEPTOP
+		}
+	case 100:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.boolean = false
+		}
+	case 101:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.boolean = true
+		}
+	case 102:
+		yyDollar = yyS[yypt-13 : yypt+1]
 		{
 			yyVAL.stmt = &SelectStmt{
 				distinct:  yyDollar[2].distinct,
@@ -1083,339 +1448,482 @@ yydefault:
 				having:    yyDollar[10].exp,
 				orderBy:   yyDollar[11].ordcols,
 				limit:     int(yyDollar[12].number),
+				offset:    int(yyDollar[13].number),
 			}
 		}
-	case 67:
+	case 103:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.distinct = false
 		}
-	case 68:
+	case 104:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.distinct = true
 		}
-	case 69:
+	case 105:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.sels = nil
 		}
-	case 70:
+	case 106:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.sels = yyDollar[1].sels
 		}
-	case 71:
+	case 107:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyDollar[1].sel.setAlias(yyDollar[2].id)
 			yyVAL.sels = []Selector{yyDollar[1].sel}
 		}
-	case 72:
+	case 108:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyDollar[3].sel.setAlias(yyDollar[4].id)
 			yyVAL.sels = append(yyDollar[1].sels, yyDollar[3].sel)
 		}
-	case 73:
+	case 109:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.sel = yyDollar[1].sel
+		}
+	case 110:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.sel = &SysFn{fn: yyDollar[1].id, params: yyDollar[3].values}
+		}
+	case 111:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			yyVAL.sel = &Cast{val: yyDollar[3].exp, t: yyDollar[5].sqlType}
+		}
+	case 112:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.sel = &NumExp{left: yyDollar[1].sel, op: ADDOP, right: yyDollar[3].exp}
+		}
+	case 113:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.sel = &NumExp{left: yyDollar[1].sel, op: SUBSOP, right: yyDollar[3].exp}
+		}
+	case 114:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.sel = &NumExp{left: yyDollar[1].sel, op: DIVOP, right: yyDollar[3].exp}
+		}
+	case 115:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.sel = &NumExp{left: yyDollar[1].sel, op: MULTOP, right: yyDollar[3].exp}
+		}
+	case 116:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.sel = yyDollar[1].col
 		}
-	case 74:
+	case 117:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.sel = &AggColSelector{aggFn: yyDollar[1].aggFn, col: "*"}
 		}
-	case 75:
+	case 118:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.sel = &AggColSelector{aggFn: yyDollar[1].aggFn, db: yyDollar[3].col.db, table: yyDollar[3].col.table, col: yyDollar[3].col.col}
 		}
-	case 76:
+	case 119:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.sel = &CaseWhenExp{whens: yyDollar[2].whens, elseExp: yyDollar[3].exp}
+		}
+	case 120:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.whens = []*whenThen{{cond: yyDollar[2].exp, then: yyDollar[4].exp}}
+		}
+	case 121:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.whens = append(yyDollar[1].whens, &whenThen{cond: yyDollar[3].exp, then: yyDollar[5].exp})
+		}
+	case 122:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.exp = nil
+		}
+	case 123:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.exp = yyDollar[2].exp
+		}
+	case 124:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.col = &ColSelector{col: yyDollar[1].id}
 		}
-	case 77:
+	case 125:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.col = &ColSelector{table: yyDollar[1].id, col: yyDollar[3].id}
 		}
-	case 78:
+	case 126:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			yyVAL.col = &ColSelector{db: yyDollar[1].id, table: yyDollar[3].id, col: yyDollar[5].id}
 		}
-	case 79:
+	case 127:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].tableRef.asBefore = yyDollar[2].number
 			yyDollar[1].tableRef.as = yyDollar[3].id
 			yyVAL.ds = yyDollar[1].tableRef
 		}
-	case 80:
+	case 128:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			yyDollar[1].tableRef.sinceTx = yyDollar[4].number
+			yyDollar[1].tableRef.asBefore = yyDollar[5].number
+			yyDollar[1].tableRef.as = yyDollar[6].id
+			yyVAL.ds = yyDollar[1].tableRef
+		}
+	case 129:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyDollar[1].tableRef.asBeforeTs = yyDollar[3].value
+			yyDollar[1].tableRef.as = yyDollar[4].id
+			yyVAL.ds = yyDollar[1].tableRef
+		}
+	case 130:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyDollar[2].stmt.(*SelectStmt).as = yyDollar[4].id
 			yyVAL.ds = yyDollar[2].stmt.(DataSource)
 		}
-	case 81:
+	case 131:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.tableRef = &tableRef{table: yyDollar[1].id}
 		}
-	case 82:
+	case 132:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.tableRef = &tableRef{db: yyDollar[1].id, table: yyDollar[3].id}
 		}
-	case 83:
+	case 133:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.number = 0
 		}
-	case 84:
+	case 134:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.number = yyDollar[3].number
 		}
-	case 85:
+	case 135:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.joins = nil
 		}
-	case 86:
+	case 136:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.joins = yyDollar[1].joins
 		}
-	case 87:
+	case 137:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.joins = []*JoinSpec{yyDollar[1].join}
 		}
-	case 88:
+	case 138:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.joins = append([]*JoinSpec{yyDollar[1].join}, yyDollar[2].joins...)
 		}
-	case 89:
+	case 139:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
+			if yyDollar[1].joinType == CrossJoin {
+				yylex.Error("syntax error: unexpected ON")
+				return 1
+			}
+
 			yyVAL.join = &JoinSpec{joinType: yyDollar[1].joinType, ds: yyDollar[3].ds, indexOn: yyDollar[4].ids, cond: yyDollar[6].exp}
 		}
-	case 90:
+	case 140:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			if yyDollar[1].joinType != CrossJoin {
+				yylex.Error("syntax error: unexpected end of join, expecting ON")
+				return 1
+			}
+
+			// every pair of rows is joined
+			yyVAL.join = &JoinSpec{joinType: yyDollar[1].joinType, ds: yyDollar[3].ds, indexOn: yyDollar[4].ids, cond: &Bool{val: true}}
+		}
+	case 141:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.joinType = InnerJoin
 		}
-	case 91:
-		yyDollar = yyS[yypt-1 : yypt+1]
+	case 142:
+		yyDollar = yyS[yypt-2 : yypt+1]
 		{
+			if (yyDollar[1].joinType == InnerJoin || yyDollar[1].joinType == CrossJoin) && yyDollar[2].boolean {
+				yylex.Error("syntax error: unexpected OUTER, expecting JOIN")
+				return 1
+			}
+
 			yyVAL.joinType = yyDollar[1].joinType
 		}
-	case 92:
+	case 143:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.boolean = false
+		}
+	case 144:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.boolean = true
+		}
+	case 145:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.exp = nil
 		}
-	case 93:
+	case 146:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.exp = yyDollar[2].exp
 		}
-	case 94:
+	case 147:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.cols = nil
 		}
-	case 95:
+	case 148:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.cols = yyDollar[3].cols
 		}
-	case 96:
+	case 149:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.exp = nil
 		}
-	case 97:
+	case 150:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.exp = yyDollar[2].exp
 		}
-	case 98:
+	case 151:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.number = 0
 		}
-	case 99:
+	case 152:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.number = yyDollar[2].number
 		}
-	case 100:
+	case 153:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.number = 0
+		}
+	case 154:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.number = yyDollar[2].number
+		}
+	case 155:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.ordcols = nil
 		}
-	case 101:
+	case 156:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.ordcols = yyDollar[3].ordcols
 		}
-	case 102:
+	case 157:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.ids = nil
 		}
-	case 103:
+	case 158:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.ids = yyDollar[4].ids
 		}
-	case 104:
+	case 159:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.ordcols = []*OrdCol{{sel: yyDollar[1].col, descOrder: yyDollar[2].opt_ord}}
 		}
-	case 105:
+	case 160:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.ordcols = append(yyDollar[1].ordcols, &OrdCol{sel: yyDollar[3].col, descOrder: yyDollar[4].opt_ord})
 		}
-	case 106:
+	case 161:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.opt_ord = false
 		}
-	case 107:
+	case 162:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.opt_ord = false
 		}
-	case 108:
+	case 163:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.opt_ord = true
 		}
-	case 109:
+	case 164:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.id = ""
 		}
-	case 110:
+	case 165:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.id = yyDollar[1].id
 		}
-	case 111:
+	case 166:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.id = yyDollar[2].id
 		}
-	case 112:
+	case 167:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.exp = yyDollar[1].exp
 		}
-	case 113:
+	case 168:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.exp = yyDollar[1].binExp
 		}
-	case 114:
+	case 169:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.exp = &NotBoolExp{exp: yyDollar[2].exp}
 		}
-	case 115:
+	case 170:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.exp = &NumExp{left: &Number{val: 0}, op: SUBSOP, right: yyDollar[2].exp}
 		}
-	case 116:
+	case 171:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.exp = &LikeBoolExp{val: yyDollar[1].exp, notLike: yyDollar[2].boolean, pattern: yyDollar[4].exp}
 		}
-	case 117:
+	case 172:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.exp = &RegexpBoolExp{val: yyDollar[1].exp, notMatch: yyDollar[2].boolean, pattern: yyDollar[3].exp}
+		}
+	case 173:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.exp = &ExistsBoolExp{q: (yyDollar[3].stmt).(*SelectStmt)}
 		}
-	case 118:
+	case 174:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.exp = &InSubQueryExp{val: yyDollar[1].exp, notIn: yyDollar[2].boolean, q: yyDollar[5].stmt.(*SelectStmt)}
 		}
-	case 119:
+	case 175:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.exp = &InListExp{val: yyDollar[1].exp, notIn: yyDollar[2].boolean, values: yyDollar[5].values}
 		}
-	case 120:
+	case 176:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			if yyDollar[5].logicOp != AND {
+				yylex.Error("syntax error: unexpected OR, expecting AND")
+				return 1
+			}
+
+			// bounds are translated into comparisons so ranges over indexed columns are scanned
+			var exp ValueExp = &BinBoolExp{
+				op:    AND,
+				left:  &CmpBoolExp{op: GE, left: yyDollar[1].exp, right: yyDollar[4].exp},
+				right: &CmpBoolExp{op: LE, left: yyDollar[1].exp, right: yyDollar[6].exp},
+			}
+
+			if yyDollar[2].boolean {
+				exp = &NotBoolExp{exp: exp}
+			}
+
+			yyVAL.exp = exp
+		}
+	case 177:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.exp = yyDollar[1].sel
 		}
-	case 121:
+	case 178:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.exp = yyDollar[1].value
 		}
-	case 122:
+	case 179:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.exp = yyDollar[2].exp
 		}
-	case 123:
+	case 180:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.boolean = false
 		}
-	case 124:
+	case 181:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.boolean = true
 		}
-	case 125:
+	case 182:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &NumExp{left: yyDollar[1].exp, op: ADDOP, right: yyDollar[3].exp}
 		}
-	case 126:
+	case 183:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &NumExp{left: yyDollar[1].exp, op: SUBSOP, right: yyDollar[3].exp}
 		}
-	case 127:
+	case 184:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &NumExp{left: yyDollar[1].exp, op: DIVOP, right: yyDollar[3].exp}
 		}
-	case 128:
+	case 185:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &NumExp{left: yyDollar[1].exp, op: MULTOP, right: yyDollar[3].exp}
 		}
-	case 129:
+	case 186:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &BinBoolExp{left: yyDollar[1].exp, op: yyDollar[2].logicOp, right: yyDollar[3].exp}
 		}
-	case 130:
+	case 187:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &CmpBoolExp{left: yyDollar[1].exp, op: yyDollar[2].cmpOp, right: yyDollar[3].exp}
 		}
-	case 131:
+	case 188:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &CmpBoolExp{left: yyDollar[1].exp, op: EQ, right: &NullValue{t: AnyType}}
 		}
-	case 132:
+	case 189:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.binExp = &CmpBoolExp{left: yyDollar[1].exp, op: NE, right: &NullValue{t: AnyType}}
@@ -21,9 +21,13 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/codenotary/immudb/embedded/store"
 )
@@ -31,8 +35,11 @@ import (
 const (
 	catalogDatabasePrefix = "CTL.DATABASE." // (key=CTL.DATABASE.{dbID}, value={dbNAME})
 	catalogTablePrefix    = "CTL.TABLE."    // (key=CTL.TABLE.{dbID}{tableID}, value={tableNAME})
-	catalogColumnPrefix   = "CTL.COLUMN."   // (key=CTL.COLUMN.{dbID}{tableID}{colID}{colTYPE}, value={(auto_incremental | nullable){maxLen}{colNAME}})
+	catalogColumnPrefix   = "CTL.COLUMN."   // (key=CTL.COLUMN.{dbID}{tableID}{colID}{colTYPE}, value={(auto_incremental | nullable | dropped){maxLen}{colNAME}})
 	catalogIndexPrefix    = "CTL.INDEX."    // (key=CTL.INDEX.{dbID}{tableID}{indexID}, value={unique {colID1}(ASC|DESC)...{colIDN}(ASC|DESC)})
+	catalogSynonymPrefix  = "CTL.SYNONYM."  // (key=CTL.SYNONYM.{dbID}{synonymNAME}, value={tableID})
+	catalogViewPrefix     = "CTL.VIEW."     // (key=CTL.VIEW.{dbID}{tableID}, value={refreshedAtTX}{viewSQL})
+	catalogCheckPrefix    = "CTL.CHECK."    // (key=CTL.CHECK.{dbID}{tableID}{checkID}, value={checkSQL})
 	PIndexPrefix          = "R."            // (key=R.{dbID}{tableID}{0}({null}({pkVal}{padding}{pkValLen})?)+, value={count (colID valLen val)+})
 	SIndexPrefix          = "E."            // (key=E.{dbID}{tableID}{indexID}({null}({val}{padding}{valLen})?)+({pkVal}{padding}{pkValLen})+, value={})
 	UIndexPrefix          = "N."            // (key=N.{dbID}{tableID}{indexID}({null}({val}{padding}{valLen})?)+, value={({pkVal}{padding}{pkValLen})+})
@@ -55,6 +62,7 @@ const PKIndexID = uint32(0)
 const (
 	nullableFlag      byte = 1 << iota
 	autoIncrementFlag byte = 1 << iota
+	droppedFlag       byte = 1 << iota
 )
 
 type SQLValueType = string
@@ -65,6 +73,9 @@ const (
 	VarcharType   SQLValueType = "VARCHAR"
 	BLOBType      SQLValueType = "BLOB"
 	TimestampType SQLValueType = "TIMESTAMP"
+	IntervalType  SQLValueType = "INTERVAL"
+	FloatType     SQLValueType = "FLOAT"
+	DecimalType   SQLValueType = "DECIMAL"
 	AnyType       SQLValueType = "ANY"
 )
 
@@ -111,6 +122,16 @@ const (
 	InnerJoin JoinType = iota
 	LeftJoin
 	RightJoin
+	FullJoin
+	CrossJoin
+)
+
+type SetOperator = int
+
+const (
+	UNIONOP SetOperator = iota
+	INTERSECTOP
+	EXCEPTOP
 )
 
 type SQLStmt interface {
@@ -140,7 +161,7 @@ func (stmt *BeginTransactionStmt) execAt(tx *SQLTx, params map[string]interface{
 		return nil, err
 	}
 
-	return tx.engine.newTx(true)
+	return tx.engine.newTx(tx.ctx, true)
 }
 
 type CommitStmt struct {
@@ -182,7 +203,8 @@ func (stmt *CreateDatabaseStmt) inferParameters(tx *SQLTx, params map[string]SQL
 }
 
 func (stmt *CreateDatabaseStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
-	id := uint32(len(tx.catalog.dbsByID) + 1)
+	// ids of dropped databases are never reused
+	id := tx.catalog.maxDBID + 1
 
 	db, err := tx.catalog.newDatabase(id, stmt.DB)
 	if err != nil {
@@ -197,6 +219,42 @@ func (stmt *CreateDatabaseStmt) execAt(tx *SQLTx, params map[string]interface{})
 	return tx, nil
 }
 
+type DropDatabaseStmt struct {
+	DB       string
+	ifExists bool
+}
+
+func (stmt *DropDatabaseStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	return nil
+}
+
+// execAt marks the database as deleted in the catalog, its data is kept so history remains auditable
+func (stmt *DropDatabaseStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
+	if stmt.ifExists && !tx.catalog.ExistDatabase(stmt.DB) {
+		return tx, nil
+	}
+
+	if tx.currentDB != nil && tx.currentDB.name == stmt.DB {
+		return nil, ErrDatabaseInUse
+	}
+
+	db, err := tx.catalog.dropDatabase(stmt.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	md := store.NewKVMetadata()
+
+	md.AsDeleted(true)
+
+	err = tx.set(mapKey(tx.sqlPrefix(), catalogDatabasePrefix, EncodeID(db.id)), md, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
 type UseDatabaseStmt struct {
 	DB string
 }
@@ -212,23 +270,59 @@ func (stmt *UseDatabaseStmt) execAt(tx *SQLTx, params map[string]interface{}) (*
 type UseSnapshotStmt struct {
 	sinceTx  uint64
 	asBefore uint64
+	upToTx   uint64
 }
 
 func (stmt *UseSnapshotStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
 	return nil
 }
 
+// execAt selects the snapshot the following statements of the transaction read from. SINCE TX requires
+// the snapshot to include the given transaction while BEFORE TX and UP TO TX select a historical snapshot,
+// which can not be written
 func (stmt *UseSnapshotStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
-	return nil, ErrNoSupported
+	snapTx := tx.tx.SnapshotTs()
+
+	if stmt.sinceTx > snapTx {
+		return nil, fmt.Errorf("%w: tx %d is not yet included in the snapshot", ErrSnapshotNotAvailable, stmt.sinceTx)
+	}
+
+	asBefore := stmt.asBefore
+	if stmt.upToTx > 0 {
+		asBefore = stmt.upToTx + 1
+	}
+
+	if asBefore > snapTx+1 {
+		return nil, fmt.Errorf("%w: tx %d is not yet included in the snapshot", ErrSnapshotNotAvailable, asBefore-1)
+	}
+
+	if asBefore > 0 && asBefore <= stmt.sinceTx {
+		return nil, fmt.Errorf("%w: snapshot can not include tx %d", ErrIllegalArguments, stmt.sinceTx)
+	}
+
+	tx.snapshotAsBefore = asBefore
+
+	return tx, nil
 }
 
 type CreateTableStmt struct {
 	table       string
 	ifNotExists bool
 	colsSpec    []*ColSpec
+	checks      []*checkConstraint
 	pkColNames  []string
 }
 
+// addColSpec adds a column to the table, a CHECK declared along with the column becoming a constraint of the table
+func (stmt *CreateTableStmt) addColSpec(spec *ColSpec) {
+	stmt.colsSpec = append(stmt.colsSpec, spec)
+
+	if spec.check != nil {
+		stmt.checks = append(stmt.checks, spec.check)
+		spec.check = nil
+	}
+}
+
 func (stmt *CreateTableStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
 	return nil
 }
@@ -253,36 +347,39 @@ func (stmt *CreateTableStmt) execAt(tx *SQLTx, params map[string]interface{}) (*
 		return nil, err
 	}
 
-	for _, col := range table.Cols() {
-		//{auto_incremental | nullable}{maxLen}{colNAME})
-		v := make([]byte, 1+4+len(col.colName))
+	for _, cs := range stmt.colsSpec {
+		if !cs.unique {
+			continue
+		}
+
+		// a single-column primary key is already unique
+		createIndexStmt := &CreateIndexStmt{unique: true, ifNotExists: true, table: table.name, cols: []string{cs.colName}}
+		_, err = createIndexStmt.execAt(tx, params)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	for _, col := range table.Cols() {
 		if col.autoIncrement {
 			if len(table.primaryIndex.cols) > 1 || col.id != table.primaryIndex.cols[0].id {
 				return nil, ErrLimitedAutoIncrement
 			}
-
-			v[0] = v[0] | autoIncrementFlag
 		}
 
-		if col.notNull {
-			v[0] = v[0] | nullableFlag
+		err = persistColumn(tx, col)
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		binary.BigEndian.PutUint32(v[1:], uint32(col.MaxLen()))
-
-		copy(v[5:], []byte(col.Name()))
-
-		mappedKey := mapKey(
-			tx.sqlPrefix(),
-			catalogColumnPrefix,
-			EncodeID(tx.currentDB.id),
-			EncodeID(table.id),
-			EncodeID(col.id),
-			[]byte(col.colType),
-		)
+	for i, check := range stmt.checks {
+		err = table.addCheck(check)
+		if err != nil {
+			return nil, err
+		}
 
-		err = tx.set(mappedKey, nil, v)
+		err = persistCheck(tx, table, uint32(i+1), check)
 		if err != nil {
 			return nil, err
 		}
@@ -298,12 +395,91 @@ func (stmt *CreateTableStmt) execAt(tx *SQLTx, params map[string]interface{}) (*
 	return tx, nil
 }
 
+type DropTableStmt struct {
+	table    string
+	ifExists bool
+}
+
+func (stmt *DropTableStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	return nil
+}
+
+// execAt marks the table as deleted in the catalog, its rows are kept so history remains auditable
+func (stmt *DropTableStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
+	if tx.currentDB == nil {
+		return nil, ErrNoDatabaseSelected
+	}
+
+	if stmt.ifExists && !tx.currentDB.ExistTable(stmt.table) {
+		return tx, nil
+	}
+
+	table, err := tx.currentDB.dropTable(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+
+	md := store.NewKVMetadata()
+
+	md.AsDeleted(true)
+
+	err = tx.set(mapKey(tx.sqlPrefix(), catalogTablePrefix, EncodeID(tx.currentDB.id), EncodeID(table.id)), md, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if table.IsMaterializedView() {
+		err = tx.set(mapKey(tx.sqlPrefix(), catalogViewPrefix, EncodeID(tx.currentDB.id), EncodeID(table.id)), md, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
+
+func persistColumn(tx *SQLTx, col *Column) error {
+	//{auto_incremental | nullable | dropped}{maxLen}{colNAME})
+	v := make([]byte, 1+4+len(col.colName))
+
+	if col.autoIncrement {
+		v[0] = v[0] | autoIncrementFlag
+	}
+
+	if col.notNull {
+		v[0] = v[0] | nullableFlag
+	}
+
+	// dropped columns are kept in the catalog so rows encoded before can still be decoded
+	if col.dropped {
+		v[0] = v[0] | droppedFlag
+	}
+
+	binary.BigEndian.PutUint32(v[1:], uint32(col.MaxLen()))
+
+	copy(v[5:], []byte(col.Name()))
+
+	mappedKey := mapKey(
+		tx.sqlPrefix(),
+		catalogColumnPrefix,
+		EncodeID(col.table.db.id),
+		EncodeID(col.table.id),
+		EncodeID(col.id),
+		[]byte(col.colType),
+	)
+
+	return tx.set(mappedKey, nil, v)
+}
+
 type ColSpec struct {
 	colName       string
 	colType       SQLValueType
 	maxLen        int
 	autoIncrement bool
 	notNull       bool
+	unique        bool
+	dropped       bool
+	check         *checkConstraint
 }
 
 type CreateIndexStmt struct {
@@ -404,7 +580,192 @@ func (stmt *AddColumnStmt) inferParameters(tx *SQLTx, params map[string]SQLValue
 }
 
 func (stmt *AddColumnStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
-	return nil, ErrNoSupported
+	if tx.currentDB == nil {
+		return nil, ErrNoDatabaseSelected
+	}
+
+	table, err := tx.currentDB.GetTableByName(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+
+	if table.IsMaterializedView() {
+		return nil, ErrMaterializedViewIsReadOnly
+	}
+
+	// existing rows hold no value for the new column, they are read as NULL
+	if stmt.colSpec.notNull {
+		return nil, ErrNewColumnMustBeNullable
+	}
+
+	if stmt.colSpec.autoIncrement {
+		return nil, ErrLimitedAutoIncrement
+	}
+
+	// existing rows were never validated against it
+	if stmt.colSpec.check != nil {
+		return nil, fmt.Errorf("%w (%s): not allowed when adding a column", ErrInvalidCheckConstraint, stmt.colSpec.check.sql)
+	}
+
+	col, err := table.newColumn(stmt.colSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = persistColumn(tx, col)
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.colSpec.unique {
+		createIndexStmt := &CreateIndexStmt{unique: true, table: table.name, cols: []string{col.colName}}
+		_, err = createIndexStmt.execAt(tx, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
+
+type DropColumnStmt struct {
+	table   string
+	colName string
+}
+
+func (stmt *DropColumnStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	return nil
+}
+
+func (stmt *DropColumnStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
+	if tx.currentDB == nil {
+		return nil, ErrNoDatabaseSelected
+	}
+
+	table, err := tx.currentDB.GetTableByName(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+
+	if table.IsMaterializedView() {
+		return nil, ErrMaterializedViewIsReadOnly
+	}
+
+	col, err := table.dropColumn(stmt.colName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = persistColumn(tx, col)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+type RenameColumnStmt struct {
+	table   string
+	oldName string
+	newName string
+}
+
+func (stmt *RenameColumnStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	return nil
+}
+
+func (stmt *RenameColumnStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
+	if tx.currentDB == nil {
+		return nil, ErrNoDatabaseSelected
+	}
+
+	table, err := tx.currentDB.GetTableByName(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+
+	if table.IsMaterializedView() {
+		return nil, ErrMaterializedViewIsReadOnly
+	}
+
+	col, err := table.renameColumn(stmt.oldName, stmt.newName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = persistColumn(tx, col)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+type CreateSynonymStmt struct {
+	synonym string
+	table   string
+}
+
+func (stmt *CreateSynonymStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	return nil
+}
+
+func (stmt *CreateSynonymStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
+	if tx.currentDB == nil {
+		return nil, ErrNoDatabaseSelected
+	}
+
+	table, err := tx.currentDB.GetTableByName(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.currentDB.newSynonym(stmt.synonym, table)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.set(mapKey(tx.sqlPrefix(), catalogSynonymPrefix, EncodeID(tx.currentDB.id), []byte(stmt.synonym)), nil, EncodeID(table.id))
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+type DropSynonymStmt struct {
+	synonym  string
+	ifExists bool
+}
+
+func (stmt *DropSynonymStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	return nil
+}
+
+func (stmt *DropSynonymStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx, error) {
+	if tx.currentDB == nil {
+		return nil, ErrNoDatabaseSelected
+	}
+
+	if stmt.ifExists && !tx.currentDB.ExistSynonym(stmt.synonym) {
+		return tx, nil
+	}
+
+	err := tx.currentDB.dropSynonym(stmt.synonym)
+	if err != nil {
+		return nil, err
+	}
+
+	md := store.NewKVMetadata()
+
+	md.AsDeleted(true)
+
+	err = tx.set(mapKey(tx.sqlPrefix(), catalogSynonymPrefix, EncodeID(tx.currentDB.id), []byte(stmt.synonym)), md, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 type UpsertIntoStmt struct {
@@ -419,9 +780,16 @@ type RowSpec struct {
 	Values []ValueExp
 }
 
+// OnConflictDo specifies how rows conflicting with existing ones are handled. Conflicting rows are
+// skipped unless updates are specified, in which case they are applied to the existing row. Update
+// expressions may refer both to the columns of the existing row and to the inserted values as excluded.col
 type OnConflictDo struct {
+	updates []*colUpdate
 }
 
+// excludedTable names the inserted values in the updates applied on conflict
+const excludedTable = "excluded"
+
 func (stmt *UpsertIntoStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
 	if tx.currentDB == nil {
 		return ErrNoDatabaseSelected
@@ -450,14 +818,44 @@ func (stmt *UpsertIntoStmt) inferParameters(tx *SQLTx, params map[string]SQLValu
 		}
 	}
 
-	return nil
-}
+	if stmt.onConflict == nil || len(stmt.onConflict.updates) == 0 {
+		return nil
+	}
 
-func (stmt *UpsertIntoStmt) validate(table *Table) (map[uint32]int, error) {
-	selPosByColID := make(map[uint32]int, len(stmt.cols))
+	table, err := stmt.tableRef.referencedTable(tx)
+	if err != nil {
+		return err
+	}
 
-	for i, c := range stmt.cols {
-		col, err := table.GetColumnByName(c)
+	cols := make(map[string]ColDescriptor, 2*len(table.cols))
+
+	for _, col := range table.cols {
+		for _, t := range []string{table.name, excludedTable} {
+			des := ColDescriptor{Database: table.db.name, Table: t, Column: col.colName, Type: col.colType}
+			cols[des.Selector()] = des
+		}
+	}
+
+	for _, update := range stmt.onConflict.updates {
+		col, err := table.GetColumnByName(update.col)
+		if err != nil {
+			return err
+		}
+
+		err = update.val.requiresType(col.colType, cols, params, table.db.name, table.name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (stmt *UpsertIntoStmt) validate(table *Table) (map[uint32]int, error) {
+	selPosByColID := make(map[uint32]int, len(stmt.cols))
+
+	for i, c := range stmt.cols {
+		col, err := table.GetColumnByName(c)
 		if err != nil {
 			return nil, err
 		}
@@ -483,11 +881,22 @@ func (stmt *UpsertIntoStmt) execAt(tx *SQLTx, params map[string]interface{}) (*S
 		return nil, err
 	}
 
+	if table.IsMaterializedView() {
+		return nil, ErrMaterializedViewIsReadOnly
+	}
+
 	selPosByColID, err := stmt.validate(table)
 	if err != nil {
 		return nil, err
 	}
 
+	if stmt.onConflict != nil {
+		err = validateUpdates(table, stmt.onConflict.updates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for _, row := range stmt.rows {
 		if len(row.Values) != len(stmt.cols) {
 			return nil, ErrInvalidNumberOfValues
@@ -530,7 +939,7 @@ func (stmt *UpsertIntoStmt) execAt(tx *SQLTx, params map[string]interface{}) (*S
 				return nil, err
 			}
 
-			rval, err := val.reduce(tx.catalog, nil, tx.currentDB.name, table.name)
+			rval, err := val.reduce(tx, nil, tx.currentDB.name, table.name)
 			if err != nil {
 				return nil, err
 			}
@@ -558,6 +967,16 @@ func (stmt *UpsertIntoStmt) execAt(tx *SQLTx, params map[string]interface{}) (*S
 				tx.lastInsertedPKs[table.name] = nl
 			}
 
+			rval, err = col.coerce(rval)
+			if err != nil {
+				return nil, err
+			}
+
+			err = col.checkMaxLen(rval)
+			if err != nil {
+				return nil, err
+			}
+
 			valuesByColID[colID] = rval
 		}
 
@@ -570,26 +989,35 @@ func (stmt *UpsertIntoStmt) execAt(tx *SQLTx, params map[string]interface{}) (*S
 		mkey := mapKey(tx.sqlPrefix(), PIndexPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(table.primaryIndex.id), pkEncVals)
 
 		_, err = tx.get(mkey)
-		if err != nil && err != store.ErrKeyNotFound {
+		// expired rows e.g. the ones discarded by truncation are missing rows
+		if err != nil && !errors.Is(err, store.ErrKeyNotFound) {
 			return nil, err
 		}
 
-		if err == store.ErrKeyNotFound && pkMustExist {
+		if errors.Is(err, store.ErrKeyNotFound) && pkMustExist {
 			return nil, err
 		}
 
-		if stmt.isInsert {
-			if err == nil && stmt.onConflict == nil {
+		reuseIndex := !stmt.isInsert
+
+		if stmt.isInsert && err == nil {
+			if stmt.onConflict == nil {
 				return nil, store.ErrKeyAlreadyExists
 			}
 
-			if err == nil && stmt.onConflict != nil {
-				// TODO: conflict resolution may be extended. Currently only supports "ON CONFLICT DO NOTHING"
-				return tx, nil
+			if len(stmt.onConflict.updates) == 0 {
+				continue
+			}
+
+			valuesByColID, err = stmt.onConflict.updatedValues(tx, table, valuesByColID, params)
+			if err != nil {
+				return nil, err
 			}
+
+			reuseIndex = true
 		}
 
-		err = tx.doUpsert(pkEncVals, valuesByColID, table, !stmt.isInsert)
+		err = tx.doUpsert(pkEncVals, valuesByColID, table, reuseIndex)
 		if err != nil {
 			return nil, err
 		}
@@ -598,7 +1026,73 @@ func (stmt *UpsertIntoStmt) execAt(tx *SQLTx, params map[string]interface{}) (*S
 	return tx, nil
 }
 
+// updatedValues returns the values of the existing row conflicting with the inserted one, once updated
+func (oc *OnConflictDo) updatedValues(tx *SQLTx, table *Table, insertedValuesByColID map[uint32]TypedValue, params map[string]interface{}) (map[uint32]TypedValue, error) {
+	row, err := tx.fetchPKRow(table, insertedValuesByColID)
+	if err != nil {
+		return nil, err
+	}
+
+	valuesByColID := make(map[uint32]TypedValue, len(table.cols))
+
+	for _, col := range table.cols {
+		valuesByColID[col.id] = row.Values[EncodeSelector("", table.db.name, table.name, col.colName)]
+
+		excluded, specified := insertedValuesByColID[col.id]
+		if !specified {
+			excluded = &NullValue{t: col.colType}
+		}
+
+		row.Values[EncodeSelector("", table.db.name, excludedTable, col.colName)] = excluded
+	}
+
+	for _, update := range oc.updates {
+		col, err := table.GetColumnByName(update.col)
+		if err != nil {
+			return nil, err
+		}
+
+		sval, err := update.val.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		rval, err := sval.reduce(tx, row, table.db.name, table.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if rval.IsNull() && (col.notNull || col.autoIncrement) {
+			return nil, fmt.Errorf("%w (%s)", ErrNotNullableColumnCannotBeNull, col.colName)
+		}
+
+		rval, err = col.coerce(rval)
+		if err != nil {
+			return nil, err
+		}
+
+		err = rval.requiresType(col.colType, nil, nil, table.db.name, table.name)
+		if err != nil {
+			return nil, err
+		}
+
+		err = col.checkMaxLen(rval)
+		if err != nil {
+			return nil, err
+		}
+
+		valuesByColID[col.id] = rval
+	}
+
+	return valuesByColID, nil
+}
+
 func (tx *SQLTx) doUpsert(pkEncVals []byte, valuesByColID map[uint32]TypedValue, table *Table, reuseIndex bool) error {
+	err := table.checkRow(tx, valuesByColID)
+	if err != nil {
+		return err
+	}
+
 	var reusableIndexEntries map[uint32]struct{}
 
 	if reuseIndex && len(table.indexes) > 1 {
@@ -625,50 +1119,12 @@ func (tx *SQLTx) doUpsert(pkEncVals []byte, valuesByColID map[uint32]TypedValue,
 	// primary index entry
 	mkey := mapKey(tx.sqlPrefix(), PIndexPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(table.primaryIndex.id), pkEncVals)
 
-	valbuf := bytes.Buffer{}
-
-	// null values are not serialized
-	encodedVals := 0
-	for _, v := range valuesByColID {
-		if !v.IsNull() {
-			encodedVals++
-		}
-	}
-
-	b := make([]byte, EncLenLen)
-	binary.BigEndian.PutUint32(b, uint32(encodedVals))
-
-	_, err := valbuf.Write(b)
+	encodedRow, err := encodeRow(table, valuesByColID)
 	if err != nil {
 		return err
 	}
 
-	for _, col := range table.cols {
-		rval, specified := valuesByColID[col.id]
-		if !specified || rval.IsNull() {
-			continue
-		}
-
-		b := make([]byte, EncIDLen)
-		binary.BigEndian.PutUint32(b, uint32(col.id))
-
-		_, err = valbuf.Write(b)
-		if err != nil {
-			return err
-		}
-
-		encVal, err := EncodeValue(rval.Value(), col.colType, col.MaxLen())
-		if err != nil {
-			return err
-		}
-
-		_, err = valbuf.Write(encVal)
-		if err != nil {
-			return err
-		}
-	}
-
-	err = tx.set(mkey, nil, valbuf.Bytes())
+	err = tx.set(mkey, nil, encodedRow)
 	if err != nil {
 		return err
 	}
@@ -724,9 +1180,9 @@ func (tx *SQLTx) doUpsert(pkEncVals []byte, valuesByColID map[uint32]TypedValue,
 			// mkey must not exist
 			_, err := tx.get(mkey)
 			if err == nil {
-				return store.ErrKeyAlreadyExists
+				return ErrDuplicateUniqueValue
 			}
-			if err != store.ErrKeyNotFound {
+			if !errors.Is(err, store.ErrKeyNotFound) {
 				return err
 			}
 		}
@@ -917,9 +1373,13 @@ func (stmt *UpdateStmt) inferParameters(tx *SQLTx, params map[string]SQLValueTyp
 }
 
 func (stmt *UpdateStmt) validate(table *Table) error {
-	colIDs := make(map[uint32]struct{}, len(stmt.updates))
+	return validateUpdates(table, stmt.updates)
+}
 
-	for _, update := range stmt.updates {
+func validateUpdates(table *Table, updates []*colUpdate) error {
+	colIDs := make(map[uint32]struct{}, len(updates))
+
+	for _, update := range updates {
 		if update.op != EQ {
 			return ErrIllegalArguments
 		}
@@ -963,6 +1423,11 @@ func (stmt *UpdateStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx
 	defer rowReader.Close()
 
 	table := rowReader.ScanSpecs().index.table
+	tableAlias := rowReader.TableAlias()
+
+	if table.IsMaterializedView() {
+		return nil, ErrMaterializedViewIsReadOnly
+	}
 
 	err = stmt.validate(table)
 	if err != nil {
@@ -983,7 +1448,7 @@ func (stmt *UpdateStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx
 		valuesByColID := make(map[uint32]TypedValue, len(row.Values))
 
 		for _, col := range table.cols {
-			encSel := EncodeSelector("", table.db.name, table.name, col.colName)
+			encSel := EncodeSelector("", table.db.name, tableAlias, col.colName)
 			valuesByColID[col.id] = row.Values[encSel]
 		}
 
@@ -998,12 +1463,26 @@ func (stmt *UpdateStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx
 				return nil, err
 			}
 
-			rval, err := sval.reduce(tx.catalog, row, table.db.name, table.name)
+			rval, err := sval.reduce(tx, row, table.db.name, tableAlias)
+			if err != nil {
+				return nil, err
+			}
+
+			if rval.IsNull() && (col.notNull || col.autoIncrement) {
+				return nil, fmt.Errorf("%w (%s)", ErrNotNullableColumnCannotBeNull, col.colName)
+			}
+
+			rval, err = col.coerce(rval)
+			if err != nil {
+				return nil, err
+			}
+
+			err = rval.requiresType(col.colType, cols, nil, table.db.name, tableAlias)
 			if err != nil {
 				return nil, err
 			}
 
-			err = rval.requiresType(col.colType, cols, nil, table.db.name, table.name)
+			err = col.checkMaxLen(rval)
 			if err != nil {
 				return nil, err
 			}
@@ -1068,6 +1547,11 @@ func (stmt *DeleteFromStmt) execAt(tx *SQLTx, params map[string]interface{}) (*S
 	defer rowReader.Close()
 
 	table := rowReader.ScanSpecs().index.table
+	tableAlias := rowReader.TableAlias()
+
+	if table.IsMaterializedView() {
+		return nil, ErrMaterializedViewIsReadOnly
+	}
 
 	for {
 		row, err := rowReader.Read()
@@ -1081,7 +1565,7 @@ func (stmt *DeleteFromStmt) execAt(tx *SQLTx, params map[string]interface{}) (*S
 		valuesByColID := make(map[uint32]TypedValue, len(row.Values))
 
 		for _, col := range table.cols {
-			encSel := EncodeSelector("", table.db.name, table.name, col.colName)
+			encSel := EncodeSelector("", table.db.name, tableAlias, col.colName)
 			valuesByColID[col.id] = row.Values[encSel]
 		}
 
@@ -1152,7 +1636,7 @@ type ValueExp interface {
 	inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error)
 	requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error
 	substitute(params map[string]interface{}) (ValueExp, error)
-	reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error)
+	reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error)
 	reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp
 	isConstant() bool
 	selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error
@@ -1286,7 +1770,11 @@ func (n *NullValue) IsNull() bool {
 
 func (n *NullValue) Compare(val TypedValue) (int, error) {
 	if n.t != AnyType && val.Type() != AnyType && n.t != val.Type() {
-		return 0, ErrNotComparableValues
+		// numeric values of different types are comparable, as INTEGER ones get promoted
+		_, err := numericResultType(n.t, val.Type())
+		if err != nil {
+			return 0, ErrNotComparableValues
+		}
 	}
 
 	if val.Value() == nil {
@@ -1318,7 +1806,7 @@ func (v *NullValue) substitute(params map[string]interface{}) (ValueExp, error)
 	return v, nil
 }
 
-func (v *NullValue) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *NullValue) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return v, nil
 }
 
@@ -1362,7 +1850,7 @@ func (v *Number) substitute(params map[string]interface{}) (ValueExp, error) {
 	return v, nil
 }
 
-func (v *Number) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *Number) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return v, nil
 }
 
@@ -1388,7 +1876,7 @@ func (v *Number) Compare(val TypedValue) (int, error) {
 	}
 
 	if val.Type() != IntegerType {
-		return 0, ErrNotComparableValues
+		return compareNumeric(v, val)
 	}
 
 	rval := val.Value().(int64)
@@ -1404,6 +1892,141 @@ func (v *Number) Compare(val TypedValue) (int, error) {
 	return -1, nil
 }
 
+type Float struct {
+	val float64
+}
+
+func (v *Float) Type() SQLValueType {
+	return FloatType
+}
+
+func (v *Float) IsNull() bool {
+	return false
+}
+
+func (v *Float) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
+	return FloatType, nil
+}
+
+func (v *Float) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
+	if t != FloatType {
+		return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, FloatType, t)
+	}
+
+	return nil
+}
+
+func (v *Float) substitute(params map[string]interface{}) (ValueExp, error) {
+	return v, nil
+}
+
+func (v *Float) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	return v, nil
+}
+
+func (v *Float) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
+	return v
+}
+
+func (v *Float) isConstant() bool {
+	return true
+}
+
+func (v *Float) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
+	return nil
+}
+
+func (v *Float) Value() interface{} {
+	return v.val
+}
+
+func (v *Float) Compare(val TypedValue) (int, error) {
+	if val.IsNull() {
+		return 1, nil
+	}
+
+	if val.Type() != FloatType {
+		return compareNumeric(v, val)
+	}
+
+	rval := val.Value().(float64)
+
+	if v.val == rval {
+		return 0, nil
+	}
+
+	if v.val > rval {
+		return 1, nil
+	}
+
+	return -1, nil
+}
+
+type Decimal struct {
+	val *big.Rat
+}
+
+func (v *Decimal) Type() SQLValueType {
+	return DecimalType
+}
+
+func (v *Decimal) IsNull() bool {
+	return false
+}
+
+func (v *Decimal) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
+	return DecimalType, nil
+}
+
+func (v *Decimal) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
+	if t != DecimalType {
+		return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, DecimalType, t)
+	}
+
+	return nil
+}
+
+func (v *Decimal) substitute(params map[string]interface{}) (ValueExp, error) {
+	return v, nil
+}
+
+func (v *Decimal) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	return v, nil
+}
+
+func (v *Decimal) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
+	return v
+}
+
+func (v *Decimal) isConstant() bool {
+	return true
+}
+
+func (v *Decimal) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
+	return nil
+}
+
+func (v *Decimal) Value() interface{} {
+	return v.val
+}
+
+// String renders the decimal e.g. 2.25 instead of the 9/4 fraction of its value
+func (v *Decimal) String() string {
+	return FormatDecimal(v.val)
+}
+
+func (v *Decimal) Compare(val TypedValue) (int, error) {
+	if val.IsNull() {
+		return 1, nil
+	}
+
+	if val.Type() != DecimalType {
+		return compareNumeric(v, val)
+	}
+
+	return v.val.Cmp(val.Value().(*big.Rat)), nil
+}
+
 type Timestamp struct {
 	val time.Time
 }
@@ -1432,7 +2055,7 @@ func (v *Timestamp) substitute(params map[string]interface{}) (ValueExp, error)
 	return v, nil
 }
 
-func (v *Timestamp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *Timestamp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return v, nil
 }
 
@@ -1502,7 +2125,7 @@ func (v *Varchar) substitute(params map[string]interface{}) (ValueExp, error) {
 	return v, nil
 }
 
-func (v *Varchar) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *Varchar) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return v, nil
 }
 
@@ -1564,7 +2187,7 @@ func (v *Bool) substitute(params map[string]interface{}) (ValueExp, error) {
 	return v, nil
 }
 
-func (v *Bool) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *Bool) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return v, nil
 }
 
@@ -1634,7 +2257,7 @@ func (v *Blob) substitute(params map[string]interface{}) (ValueExp, error) {
 	return v, nil
 }
 
-func (v *Blob) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (v *Blob) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return v, nil
 }
 
@@ -1668,62 +2291,284 @@ func (v *Blob) Compare(val TypedValue) (int, error) {
 	return bytes.Compare(v.val, rval), nil
 }
 
+// SysFn is a call to a built-in function. Being also a selector, functions can be used both in the
+// list of selected values and in conditions
 type SysFn struct {
-	fn string
+	fn     string
+	params []ValueExp
+	as     string
 }
 
-func (v *SysFn) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
-	if strings.ToUpper(v.fn) == "NOW" {
-		return TimestampType, nil
-	}
+// sysFnSpec describes a built-in function, arguments not covered by argTypes take the type of the last one
+type sysFnSpec struct {
+	argTypes   []SQLValueType
+	minArgs    int
+	maxArgs    int // negative when the number of arguments is unbounded
+	resultType SQLValueType
+	eval       func(tx *SQLTx, args []TypedValue) (TypedValue, error)
+}
 
-	return AnyType, fmt.Errorf("%w: unkown function %s", ErrIllegalArguments, v.fn)
+func (spec *sysFnSpec) argType(i int) SQLValueType {
+	if i < len(spec.argTypes) {
+		return spec.argTypes[i]
+	}
+	return spec.argTypes[len(spec.argTypes)-1]
 }
 
-func (v *SysFn) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
-	if strings.ToUpper(v.fn) == "NOW" {
-		if t != TimestampType {
-			return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, TimestampType, t)
-		}
+var sysFns = map[string]*sysFnSpec{
+	"NOW": {
+		resultType: TimestampType,
+		eval: func(tx *SQLTx, args []TypedValue) (TypedValue, error) {
+			if tx == nil {
+				return &Timestamp{val: time.Now().UTC().Truncate(time.Microsecond)}, nil
+			}
 
-		return nil
-	}
+			// every call within the same transaction is evaluated to the same timestamp
+			return &Timestamp{val: tx.Timestamp()}, nil
+		},
+	},
+	"UPPER": varcharFn(strings.ToUpper),
+	"LOWER": varcharFn(strings.ToLower),
+	"TRIM":  varcharFn(strings.TrimSpace),
+	"SUBSTRING": {
+		argTypes:   []SQLValueType{VarcharType, IntegerType, IntegerType},
+		minArgs:    2,
+		maxArgs:    3,
+		resultType: VarcharType,
+		eval: func(tx *SQLTx, args []TypedValue) (TypedValue, error) {
+			s := []rune(args[0].Value().(string))
+
+			// positions are counted from 1 as in standard SQL, the substring being clamped to the string
+			start := args[1].Value().(int64) - 1
+			end := int64(len(s))
+
+			if len(args) == 3 {
+				l := args[2].Value().(int64)
+				if l < 0 {
+					return nil, fmt.Errorf("%w: negative substring length", ErrIllegalArguments)
+				}
+
+				end = start + l
+			}
+
+			if start < 0 {
+				start = 0
+			}
+			if end > int64(len(s)) {
+				end = int64(len(s))
+			}
+			if end < start {
+				end = start
+			}
+
+			return &Varchar{val: string(s[start:end])}, nil
+		},
+	},
+	"LENGTH": {
+		argTypes:   []SQLValueType{VarcharType},
+		minArgs:    1,
+		maxArgs:    1,
+		resultType: IntegerType,
+		eval: func(tx *SQLTx, args []TypedValue) (TypedValue, error) {
+			return &Number{val: int64(utf8.RuneCountInString(args[0].Value().(string)))}, nil
+		},
+	},
+	"CONCAT": {
+		argTypes:   []SQLValueType{VarcharType},
+		minArgs:    1,
+		maxArgs:    -1,
+		resultType: VarcharType,
+		eval: func(tx *SQLTx, args []TypedValue) (TypedValue, error) {
+			var sb strings.Builder
+
+			for _, arg := range args {
+				sb.WriteString(arg.Value().(string))
+			}
 
-	return fmt.Errorf("%w: unkown function %s", ErrIllegalArguments, v.fn)
+			return &Varchar{val: sb.String()}, nil
+		},
+	},
 }
 
-func (v *SysFn) substitute(params map[string]interface{}) (ValueExp, error) {
-	return v, nil
+func varcharFn(fn func(string) string) *sysFnSpec {
+	return &sysFnSpec{
+		argTypes:   []SQLValueType{VarcharType},
+		minArgs:    1,
+		maxArgs:    1,
+		resultType: VarcharType,
+		eval: func(tx *SQLTx, args []TypedValue) (TypedValue, error) {
+			return &Varchar{val: fn(args[0].Value().(string))}, nil
+		},
+	}
 }
 
-func (v *SysFn) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	if strings.ToUpper(v.fn) == "NOW" {
-		return &Timestamp{val: time.Now().UTC()}, nil
+func (v *SysFn) spec() (*sysFnSpec, error) {
+	spec, ok := sysFns[strings.ToUpper(v.fn)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unkown function %s", ErrIllegalArguments, v.fn)
+	}
+
+	if len(v.params) < spec.minArgs || (spec.maxArgs >= 0 && len(v.params) > spec.maxArgs) {
+		return nil, fmt.Errorf("%w: invalid number of arguments for function %s", ErrIllegalArguments, v.fn)
 	}
 
-	return nil, fmt.Errorf("%w: unkown function %s", ErrIllegalArguments, v.fn)
+	return spec, nil
 }
 
-func (v *SysFn) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
-	return v
+func (v *SysFn) resolve(implicitDB, implicitTable string) (aggFn, db, table, col string) {
+	return "", implicitDB, implicitTable, v.as
+}
+
+func (v *SysFn) alias() string {
+	return v.as
+}
+
+func (v *SysFn) setAlias(alias string) {
+	v.as = alias
+}
+
+func (v *SysFn) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
+	spec, err := v.spec()
+	if err != nil {
+		return AnyType, err
+	}
+
+	for i, p := range v.params {
+		err := p.requiresType(spec.argType(i), cols, params, implicitDB, implicitTable)
+		if err != nil {
+			return AnyType, err
+		}
+	}
+
+	return spec.resultType, nil
+}
+
+func (v *SysFn) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
+	rt, err := v.inferType(cols, params, implicitDB, implicitTable)
+	if err != nil {
+		return err
+	}
+
+	if t != rt {
+		return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, rt, t)
+	}
+
+	return nil
+}
+
+func (v *SysFn) substitute(params map[string]interface{}) (ValueExp, error) {
+	if len(v.params) == 0 {
+		return v, nil
+	}
+
+	rfn := &SysFn{
+		fn:     v.fn,
+		params: make([]ValueExp, len(v.params)),
+		as:     v.as,
+	}
+
+	for i, p := range v.params {
+		rp, err := p.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		rfn.params[i] = rp
+	}
+
+	return rfn, nil
+}
+
+// reduce evaluates the function, a NULL argument makes it evaluate to NULL
+func (v *SysFn) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	spec, err := v.spec()
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]TypedValue, len(v.params))
+
+	for i, p := range v.params {
+		arg, err := p.reduce(tx, row, implicitDB, implicitTable)
+		if err != nil {
+			return nil, err
+		}
+
+		if arg.IsNull() {
+			return &NullValue{t: spec.resultType}, nil
+		}
+
+		if arg.Type() != spec.argType(i) {
+			return nil, fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, arg.Type(), spec.argType(i))
+		}
+
+		args[i] = arg
+	}
+
+	return spec.eval(tx, args)
+}
+
+func (v *SysFn) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
+	if len(v.params) == 0 {
+		return v
+	}
+
+	rfn := &SysFn{
+		fn:     v.fn,
+		params: make([]ValueExp, len(v.params)),
+		as:     v.as,
+	}
+
+	for i, p := range v.params {
+		rfn.params[i] = p.reduceSelectors(row, implicitDB, implicitTable)
+	}
+
+	return rfn
 }
 
 func (v *SysFn) isConstant() bool {
-	return false
+	if len(v.params) == 0 {
+		// e.g. NOW()
+		return false
+	}
+
+	for _, p := range v.params {
+		if !p.isConstant() {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (v *SysFn) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
 	return nil
 }
 
+// Cast converts a value into the given type. Being also a selector, conversions can be used both in the
+// list of selected values and in conditions
 type Cast struct {
 	val ValueExp
 	t   SQLValueType
+	as  string
 }
 
 type converterFunc func(TypedValue) (TypedValue, error)
 
+// timestampFormat is how timestamps are cast as VARCHAR, the resulting string can be cast back as TIMESTAMP
+const timestampFormat = "2006-01-02 15:04:05.999999"
+
 func (c *Cast) getConverter(src, dst SQLValueType) (converterFunc, error) {
+	// NULL can be cast as any type
+	if src == dst || src == AnyType {
+		return func(val TypedValue) (TypedValue, error) {
+			if val.Value() == nil {
+				return &NullValue{t: dst}, nil
+			}
+			return val, nil
+		}, nil
+	}
+
 	if dst == TimestampType {
 
 		if src == IntegerType {
@@ -1743,6 +2588,7 @@ func (c *Cast) getConverter(src, dst SQLValueType) (converterFunc, error) {
 
 				str := val.Value().(string)
 				for _, layout := range []string{
+					time.RFC3339Nano,
 					"2006-01-02 15:04:05.999999",
 					"2006-01-02 15:04",
 					"2006-01-02",
@@ -1771,6 +2617,307 @@ func (c *Cast) getConverter(src, dst SQLValueType) (converterFunc, error) {
 		)
 	}
 
+	if dst == VarcharType {
+		var format func(v interface{}) (string, error)
+
+		switch src {
+		case IntegerType:
+			format = func(v interface{}) (string, error) {
+				return strconv.FormatInt(v.(int64), 10), nil
+			}
+		case FloatType:
+			format = func(v interface{}) (string, error) {
+				return strconv.FormatFloat(v.(float64), 'f', -1, 64), nil
+			}
+		case DecimalType:
+			format = func(v interface{}) (string, error) {
+				return FormatDecimal(v.(*big.Rat)), nil
+			}
+		case BooleanType:
+			format = func(v interface{}) (string, error) {
+				return strconv.FormatBool(v.(bool)), nil
+			}
+		case TimestampType:
+			format = func(v interface{}) (string, error) {
+				return v.(time.Time).Format(timestampFormat), nil
+			}
+		case BLOBType:
+			format = func(v interface{}) (string, error) {
+				if !utf8.Valid(v.([]byte)) {
+					return "", fmt.Errorf("%w: can not cast a BLOB not holding valid UTF-8 as a VARCHAR", ErrIllegalArguments)
+				}
+				return string(v.([]byte)), nil
+			}
+		default:
+			return nil, fmt.Errorf(
+				"%w: can not cast %s value as %s",
+				ErrUnsupportedCast,
+				src, dst,
+			)
+		}
+
+		return func(val TypedValue) (TypedValue, error) {
+			if val.Value() == nil {
+				return &NullValue{t: VarcharType}, nil
+			}
+
+			str, err := format(val.Value())
+			if err != nil {
+				return nil, err
+			}
+
+			return &Varchar{val: str}, nil
+		}, nil
+	}
+
+	if dst == BooleanType {
+
+		if src == IntegerType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: BooleanType}, nil
+				}
+				return &Bool{val: val.Value().(int64) != 0}, nil
+			}, nil
+		}
+
+		if src == VarcharType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: BooleanType}, nil
+				}
+
+				str := val.Value().(string)
+
+				b, err := strconv.ParseBool(str)
+				if err != nil {
+					if len(str) > 30 {
+						str = str[:30] + "..."
+					}
+
+					return nil, fmt.Errorf(
+						"%w: can not cast string '%s' as a BOOLEAN",
+						ErrIllegalArguments,
+						str,
+					)
+				}
+
+				return &Bool{val: b}, nil
+			}, nil
+		}
+
+		return nil, fmt.Errorf(
+			"%w: only INTEGER and VARCHAR types can be cast as BOOLEAN",
+			ErrUnsupportedCast,
+		)
+	}
+
+	if dst == BLOBType {
+
+		if src == VarcharType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: BLOBType}, nil
+				}
+				return &Blob{val: []byte(val.Value().(string))}, nil
+			}, nil
+		}
+
+		return nil, fmt.Errorf(
+			"%w: only VARCHAR type can be cast as BLOB",
+			ErrUnsupportedCast,
+		)
+	}
+
+	if dst == FloatType {
+
+		if src == IntegerType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: FloatType}, nil
+				}
+				return &Float{val: float64(val.Value().(int64))}, nil
+			}, nil
+		}
+
+		if src == DecimalType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: FloatType}, nil
+				}
+				f, _ := val.Value().(*big.Rat).Float64()
+				return &Float{val: f}, nil
+			}, nil
+		}
+
+		if src == VarcharType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: FloatType}, nil
+				}
+
+				str := val.Value().(string)
+
+				f, err := strconv.ParseFloat(str, 64)
+				if err != nil || math.IsNaN(f) {
+					if len(str) > 30 {
+						str = str[:30] + "..."
+					}
+
+					return nil, fmt.Errorf(
+						"%w: can not cast string '%s' as a FLOAT",
+						ErrIllegalArguments,
+						str,
+					)
+				}
+
+				return &Float{val: f}, nil
+			}, nil
+		}
+
+		return nil, fmt.Errorf(
+			"%w: only INTEGER, DECIMAL and VARCHAR types can be cast as FLOAT",
+			ErrUnsupportedCast,
+		)
+	}
+
+	if dst == DecimalType {
+
+		if src == IntegerType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: DecimalType}, nil
+				}
+				return &Decimal{val: new(big.Rat).SetInt64(val.Value().(int64))}, nil
+			}, nil
+		}
+
+		if src == FloatType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: DecimalType}, nil
+				}
+
+				f := val.Value().(float64)
+				if math.IsNaN(f) || math.IsInf(f, 0) {
+					return nil, fmt.Errorf("%w: can not cast %v as a DECIMAL", ErrIllegalArguments, f)
+				}
+
+				// shortest representation, so 0.1 is cast as 0.1 instead of its binary approximation
+				d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+				if err != nil {
+					return nil, err
+				}
+
+				return &Decimal{val: d}, nil
+			}, nil
+		}
+
+		if src == VarcharType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: DecimalType}, nil
+				}
+
+				d, err := ParseDecimal(val.Value().(string))
+				if err != nil {
+					return nil, fmt.Errorf("%w: %v", ErrIllegalArguments, err)
+				}
+
+				return &Decimal{val: d}, nil
+			}, nil
+		}
+
+		return nil, fmt.Errorf(
+			"%w: only INTEGER, FLOAT and VARCHAR types can be cast as DECIMAL",
+			ErrUnsupportedCast,
+		)
+	}
+
+	if dst == IntegerType {
+
+		if src == FloatType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: IntegerType}, nil
+				}
+
+				f := math.Trunc(val.Value().(float64))
+				if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+					return nil, fmt.Errorf("%w: can not cast %v as an INTEGER", ErrIllegalArguments, f)
+				}
+
+				return &Number{val: int64(f)}, nil
+			}, nil
+		}
+
+		if src == DecimalType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: IntegerType}, nil
+				}
+
+				d := val.Value().(*big.Rat)
+
+				// truncated towards zero
+				return &Number{val: new(big.Int).Quo(d.Num(), d.Denom()).Int64()}, nil
+			}, nil
+		}
+
+		if src == VarcharType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: IntegerType}, nil
+				}
+
+				str := val.Value().(string)
+
+				i, err := strconv.ParseInt(str, 10, 64)
+				if err != nil {
+					if len(str) > 30 {
+						str = str[:30] + "..."
+					}
+
+					return nil, fmt.Errorf(
+						"%w: can not cast string '%s' as an INTEGER",
+						ErrIllegalArguments,
+						str,
+					)
+				}
+
+				return &Number{val: i}, nil
+			}, nil
+		}
+
+		if src == BooleanType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: IntegerType}, nil
+				}
+
+				if val.Value().(bool) {
+					return &Number{val: 1}, nil
+				}
+
+				return &Number{val: 0}, nil
+			}, nil
+		}
+
+		if src == TimestampType {
+			return func(val TypedValue) (TypedValue, error) {
+				if val.Value() == nil {
+					return &NullValue{t: IntegerType}, nil
+				}
+				return &Number{val: val.Value().(time.Time).Unix()}, nil
+			}, nil
+		}
+
+		return nil, fmt.Errorf(
+			"%w: only FLOAT, DECIMAL, VARCHAR, BOOLEAN and TIMESTAMP types can be cast as INTEGER",
+			ErrUnsupportedCast,
+		)
+	}
+
 	return nil, fmt.Errorf(
 		"%w: can not cast %s value as %s",
 		ErrUnsupportedCast,
@@ -1805,17 +2952,29 @@ func (c *Cast) requiresType(t SQLValueType, cols map[string]ColDescriptor, param
 	return nil
 }
 
-func (c *Cast) substitute(params map[string]interface{}) (ValueExp, error) {
+func (c *Cast) resolve(implicitDB, implicitTable string) (aggFn, db, table, col string) {
+	return "", implicitDB, implicitTable, c.as
+}
+
+func (c *Cast) alias() string {
+	return c.as
+}
+
+func (c *Cast) setAlias(alias string) {
+	c.as = alias
+}
+
+func (c *Cast) substitute(params map[string]interface{}) (ValueExp, error) {
 	val, err := c.val.substitute(params)
 	if err != nil {
 		return nil, err
 	}
-	c.val = val
-	return c, nil
+
+	return &Cast{val: val, t: c.t, as: c.as}, nil
 }
 
-func (c *Cast) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	val, err := c.val.reduce(catalog, row, implicitDB, implicitTable)
+func (c *Cast) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	val, err := c.val.reduce(tx, row, implicitDB, implicitTable)
 	if err != nil {
 		return nil, err
 	}
@@ -1832,6 +2991,7 @@ func (c *Cast) reduceSelectors(row *Row, implicitDB, implicitTable string) Value
 	return &Cast{
 		val: c.val.reduceSelectors(row, implicitDB, implicitTable),
 		t:   c.t,
+		as:  c.as,
 	}
 }
 
@@ -1843,6 +3003,193 @@ func (c *Cast) selectorRanges(table *Table, asTable string, params map[string]in
 	return nil
 }
 
+type whenThen struct {
+	cond ValueExp
+	then ValueExp
+}
+
+// CaseWhenExp evaluates to the value of the first branch whose condition holds,
+// to the ELSE value when none does or to NULL when there is no ELSE branch.
+// It can be used as a selector, thus it may be aliased as any other projected column
+type CaseWhenExp struct {
+	whens   []*whenThen
+	elseExp ValueExp
+	as      string
+}
+
+func (c *CaseWhenExp) results() []ValueExp {
+	results := make([]ValueExp, 0, len(c.whens)+1)
+
+	for _, w := range c.whens {
+		results = append(results, w.then)
+	}
+
+	if c.elseExp != nil {
+		results = append(results, c.elseExp)
+	}
+
+	return results
+}
+
+func (c *CaseWhenExp) resolve(implicitDB, implicitTable string) (aggFn, db, table, col string) {
+	return "", implicitDB, implicitTable, c.as
+}
+
+func (c *CaseWhenExp) alias() string {
+	return c.as
+}
+
+func (c *CaseWhenExp) setAlias(alias string) {
+	c.as = alias
+}
+
+func (c *CaseWhenExp) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
+	for _, w := range c.whens {
+		err := w.cond.requiresType(BooleanType, cols, params, implicitDB, implicitTable)
+		if err != nil {
+			return AnyType, err
+		}
+	}
+
+	t := AnyType
+
+	for _, r := range c.results() {
+		rt, err := r.inferType(cols, params, implicitDB, implicitTable)
+		if err != nil {
+			return AnyType, err
+		}
+
+		if rt == AnyType || rt == t {
+			continue
+		}
+
+		if t != AnyType {
+			return AnyType, fmt.Errorf("%w: CASE results of types %v and %v", ErrInvalidTypes, t, rt)
+		}
+
+		t = rt
+	}
+
+	if t == AnyType {
+		return AnyType, nil
+	}
+
+	// results not typed by themselves e.g. parameters, take the type of the other ones
+	return t, c.requiresType(t, cols, params, implicitDB, implicitTable)
+}
+
+func (c *CaseWhenExp) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
+	for _, w := range c.whens {
+		err := w.cond.requiresType(BooleanType, cols, params, implicitDB, implicitTable)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, r := range c.results() {
+		err := r.requiresType(t, cols, params, implicitDB, implicitTable)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *CaseWhenExp) substitute(params map[string]interface{}) (ValueExp, error) {
+	rexp := &CaseWhenExp{
+		whens: make([]*whenThen, len(c.whens)),
+		as:    c.as,
+	}
+
+	for i, w := range c.whens {
+		cond, err := w.cond.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		then, err := w.then.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		rexp.whens[i] = &whenThen{cond: cond, then: then}
+	}
+
+	if c.elseExp != nil {
+		elseExp, err := c.elseExp.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		rexp.elseExp = elseExp
+	}
+
+	return rexp, nil
+}
+
+func (c *CaseWhenExp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	for _, w := range c.whens {
+		v, err := w.cond.reduce(tx, row, implicitDB, implicitTable)
+		if err != nil {
+			return nil, err
+		}
+
+		if v.IsNull() {
+			continue
+		}
+
+		satisfied, isBool := v.Value().(bool)
+		if !isBool {
+			return nil, ErrInvalidCondition
+		}
+
+		if satisfied {
+			return w.then.reduce(tx, row, implicitDB, implicitTable)
+		}
+	}
+
+	if c.elseExp == nil {
+		return &NullValue{t: AnyType}, nil
+	}
+
+	return c.elseExp.reduce(tx, row, implicitDB, implicitTable)
+}
+
+func (c *CaseWhenExp) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
+	rexp := &CaseWhenExp{
+		whens: make([]*whenThen, len(c.whens)),
+		as:    c.as,
+	}
+
+	for i, w := range c.whens {
+		rexp.whens[i] = &whenThen{
+			cond: w.cond.reduceSelectors(row, implicitDB, implicitTable),
+			then: w.then.reduceSelectors(row, implicitDB, implicitTable),
+		}
+	}
+
+	if c.elseExp != nil {
+		rexp.elseExp = c.elseExp.reduceSelectors(row, implicitDB, implicitTable)
+	}
+
+	return rexp
+}
+
+func (c *CaseWhenExp) isConstant() bool {
+	for _, w := range c.whens {
+		if !w.cond.isConstant() || !w.then.isConstant() {
+			return false
+		}
+	}
+
+	return c.elseExp == nil || c.elseExp.isConstant()
+}
+
+func (c *CaseWhenExp) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
+	return nil
+}
+
 type Param struct {
 	id  string
 	pos int
@@ -1912,12 +3259,29 @@ func (p *Param) substitute(params map[string]interface{}) (ValueExp, error) {
 		{
 			return &Timestamp{val: v}, nil
 		}
+	case float32:
+		{
+			return &Float{val: float64(v)}, nil
+		}
+	case float64:
+		{
+			return &Float{val: v}, nil
+		}
+	case *big.Rat:
+		{
+			d, err := NewDecimal(v)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Decimal{val: d}, nil
+		}
 	}
 
 	return nil, ErrUnsupportedParameter
 }
 
-func (p *Param) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (p *Param) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	return nil, ErrUnexpected
 }
 
@@ -1959,6 +3323,7 @@ type SelectStmt struct {
 	groupBy   []*ColSelector
 	having    ValueExp
 	limit     int
+	offset    int
 	orderBy   []*OrdCol
 	as        string
 }
@@ -1967,12 +3332,19 @@ type ScanSpecs struct {
 	index         *Index
 	rangesByColID map[uint32]*typedValueRange
 	descOrder     bool
+	countOnly     bool // rows are just counted, thus their values are not resolved
+	limit         int  // maximum number of rows to be read, zero meaning no limit
+	sortRequired  bool // rows are not read in the requested order, thus they're sorted once read
 }
 
 func (stmt *SelectStmt) Limit() int {
 	return stmt.limit
 }
 
+func (stmt *SelectStmt) Offset() int {
+	return stmt.offset
+}
+
 func (stmt *SelectStmt) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
 	_, err := stmt.execAt(tx, nil)
 	if err != nil {
@@ -2002,36 +3374,15 @@ func (stmt *SelectStmt) execAt(tx *SQLTx, params map[string]interface{}) (*SQLTx
 		return nil, ErrLimitedGroupBy
 	}
 
-	if len(stmt.orderBy) > 1 {
-		return nil, ErrLimitedOrderBy
-	}
-
-	if len(stmt.orderBy) > 0 {
-		tableRef, ok := stmt.ds.(*tableRef)
-		if !ok {
-			return nil, ErrLimitedOrderBy
-		}
-
-		table, err := tableRef.referencedTable(tx)
-		if err != nil {
-			return nil, err
-		}
-
-		col, err := table.GetColumnByName(stmt.orderBy[0].sel.col)
-		if err != nil {
-			return nil, err
-		}
-
-		_, indexed := table.indexesByColID[col.id]
-		if !indexed {
-			return nil, ErrLimitedOrderBy
-		}
-	}
-
 	return tx, nil
 }
 
 func (stmt *SelectStmt) Resolve(tx *SQLTx, params map[string]interface{}, _ *ScanSpecs) (rowReader RowReader, err error) {
+	err = stmt.checkScope()
+	if err != nil {
+		return nil, err
+	}
+
 	scanSpecs, err := stmt.genScanSpecs(tx, params)
 	if err != nil {
 		return nil, err
@@ -2056,6 +3407,13 @@ func (stmt *SelectStmt) Resolve(tx *SQLTx, params map[string]interface{}, _ *Sca
 		}
 	}
 
+	if stmt.orderBy != nil && (scanSpecs == nil || scanSpecs.sortRequired) {
+		rowReader, err = newSortRowReader(rowReader, stmt.orderBy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	containsAggregations := false
 	for _, sel := range stmt.selectors {
 		_, containsAggregations = sel.(*AggColSelector)
@@ -2083,7 +3441,7 @@ func (stmt *SelectStmt) Resolve(tx *SQLTx, params map[string]interface{}, _ *Sca
 		}
 	}
 
-	rowReader, err = newProjectedRowReader(rowReader, stmt.as, stmt.selectors)
+	rowReader, err = newProjectedRowReader(rowReader, stmt.as, stmt.selectors, params)
 	if err != nil {
 		return nil, err
 	}
@@ -2095,6 +3453,13 @@ func (stmt *SelectStmt) Resolve(tx *SQLTx, params map[string]interface{}, _ *Sca
 		}
 	}
 
+	if stmt.offset > 0 {
+		rowReader, err = newOffsetRowReader(rowReader, stmt.offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if stmt.limit > 0 {
 		return newLimitRowReader(rowReader, stmt.limit)
 	}
@@ -2110,6 +3475,73 @@ func (stmt *SelectStmt) Alias() string {
 	return stmt.as
 }
 
+// setOperation combines the rows of two queries, its columns are named after the ones of the left query
+type setOperation struct {
+	op    SetOperator
+	all   bool
+	left  *SelectStmt
+	right *SelectStmt
+}
+
+// newSetOperationStmt builds the query returning the combined rows of both queries.
+// ORDER BY, LIMIT and OFFSET clauses following the right query apply to the combined rows
+func newSetOperationStmt(op SetOperator, all bool, left, right *SelectStmt) *SelectStmt {
+	stmt := &SelectStmt{
+		orderBy: right.orderBy,
+		limit:   right.limit,
+		offset:  right.offset,
+	}
+
+	rightQuery := *right
+	rightQuery.orderBy = nil
+	rightQuery.limit = 0
+	rightQuery.offset = 0
+
+	stmt.ds = &setOperation{
+		op:    op,
+		all:   all,
+		left:  left,
+		right: &rightQuery,
+	}
+
+	return stmt
+}
+
+func (so *setOperation) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	err := so.left.inferParameters(tx, params)
+	if err != nil {
+		return err
+	}
+
+	return so.right.inferParameters(tx, params)
+}
+
+func (so *setOperation) Resolve(tx *SQLTx, params map[string]interface{}, _ *ScanSpecs) (RowReader, error) {
+	left, err := so.left.Resolve(tx, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := so.right.Resolve(tx, params, nil)
+	if err != nil {
+		left.Close()
+		return nil, err
+	}
+
+	rowReader, err := newSetOpRowReader(so.op, so.all, left, right)
+	if err != nil {
+		right.Close()
+		left.Close()
+		return nil, err
+	}
+
+	return rowReader, nil
+}
+
+func (so *setOperation) Alias() string {
+	return so.left.Alias()
+}
+
 func (stmt *SelectStmt) genScanSpecs(tx *SQLTx, params map[string]interface{}) (*ScanSpecs, error) {
 	tableRef, isTableRef := stmt.ds.(*tableRef)
 	if !isTableRef {
@@ -2122,7 +3554,9 @@ func (stmt *SelectStmt) genScanSpecs(tx *SQLTx, params map[string]interface{}) (
 	}
 
 	rangesByColID := make(map[uint32]*typedValueRange)
-	if stmt.where != nil {
+
+	// rows skipped by the ranges would be taken as not matching the rows of RIGHT and FULL joins
+	if stmt.where != nil && !hasUnmatchedRows(stmt.joins) {
 		err = stmt.where.selectorRanges(table, tableRef.Alias(), params, rangesByColID)
 		if err != nil {
 			return nil, err
@@ -2132,44 +3566,24 @@ func (stmt *SelectStmt) genScanSpecs(tx *SQLTx, params map[string]interface{}) (
 	var preferredIndex *Index
 
 	if len(stmt.indexOn) > 0 {
-		cols := make([]*Column, len(stmt.indexOn))
-
-		for i, colName := range stmt.indexOn {
-			col, err := table.GetColumnByName(colName)
-			if err != nil {
-				return nil, err
-			}
-
-			cols[i] = col
-		}
-
-		index, ok := table.indexes[indexKeyFrom(cols)]
-		if !ok {
-			return nil, ErrNoAvailableIndex
+		preferredIndex, err = table.indexOn(stmt.indexOn)
+		if err != nil {
+			return nil, err
 		}
-
-		preferredIndex = index
 	}
 
 	var sortingIndex *Index
 	var descOrder bool
 
-	if stmt.orderBy == nil {
-		if preferredIndex == nil {
-			sortingIndex = table.primaryIndex
-		} else {
-			sortingIndex = preferredIndex
-		}
+	// ordering by columns of the table in a single direction may be served by an index
+	orderByColIDs, err := stmt.indexableOrderBy(table, tableRef.Alias())
+	if err != nil {
+		return nil, err
 	}
 
-	if len(stmt.orderBy) > 0 {
-		col, err := table.GetColumnByName(stmt.orderBy[0].sel.col)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, idx := range table.indexesByColID[col.id] {
-			if idx.sortableUsing(col.id, rangesByColID) {
+	if len(orderByColIDs) > 0 {
+		for _, idx := range table.indexesByColID[orderByColIDs[0]] {
+			if idx.sortableUsing(orderByColIDs, rangesByColID) {
 				if preferredIndex == nil || idx.id == preferredIndex.id {
 					sortingIndex = idx
 					break
@@ -2180,67 +3594,438 @@ func (stmt *SelectStmt) genScanSpecs(tx *SQLTx, params map[string]interface{}) (
 		descOrder = stmt.orderBy[0].descOrder
 	}
 
+	// rows are sorted once read when ordering is not served by any index
+	sortRequired := stmt.orderBy != nil && sortingIndex == nil
+
 	if sortingIndex == nil {
-		return nil, ErrNoAvailableIndex
+		if preferredIndex == nil {
+			sortingIndex = table.primaryIndex
+		} else {
+			sortingIndex = preferredIndex
+		}
+
+		descOrder = false
 	}
 
-	return &ScanSpecs{
+	tx.engine.trackFullScan(table, sortingIndex, rangesByColID)
+
+	scanSpecs := &ScanSpecs{
 		index:         sortingIndex,
 		rangesByColID: rangesByColID,
 		descOrder:     descOrder,
-	}, nil
-}
+		sortRequired:  sortRequired,
+	}
 
-type tableRef struct {
-	db       string
-	table    string
-	asBefore uint64
-	as       string
+	// all rows must be read and resolved to be sorted
+	if !sortRequired {
+		scanSpecs.countOnly = stmt.countOnly()
+		scanSpecs.limit = stmt.scanLimit()
+	}
+
+	return scanSpecs, nil
 }
 
-func (stmt *tableRef) referencedTable(tx *SQLTx) (*Table, error) {
-	var db *Database
+// indexableOrderBy returns the ids of the ordering columns when all of them belong to the table
+// and are sorted in the same direction, as index entries can only be read in a single direction.
+// Nil is returned when the rows must be sorted once read
+func (stmt *SelectStmt) indexableOrderBy(table *Table, alias string) ([]uint32, error) {
+	colIDs := make([]uint32, 0, len(stmt.orderBy))
 
-	if stmt.db != "" {
-		rdb, err := tx.catalog.GetDatabaseByName(stmt.db)
+	for _, ordCol := range stmt.orderBy {
+		if ordCol.sel.table != "" && ordCol.sel.table != alias {
+			return nil, nil
+		}
+
+		if ordCol.descOrder != stmt.orderBy[0].descOrder {
+			return nil, nil
+		}
+
+		col, err := table.GetColumnByName(ordCol.sel.col)
 		if err != nil {
 			return nil, err
 		}
 
-		db = rdb
+		colIDs = append(colIDs, col.id)
 	}
 
-	if db == nil {
-		if tx.currentDB == nil {
-			return nil, ErrNoDatabaseSelected
+	return colIDs, nil
+}
+
+// scanLimit returns the number of rows to be read from the table when every read row is selected,
+// i.e. rows are neither filtered, joined, grouped nor deduplicated. Zero is returned otherwise
+// correlatedWith returns a copy of the query where the columns of the given row are replaced by their values.
+// Columns of the tables read by the query shadow the ones of the row having the same name
+func (stmt *SelectStmt) correlatedWith(row *Row, implicitDB string) *SelectStmt {
+	if so, ok := stmt.ds.(*setOperation); ok {
+		q := *stmt
+		q.ds = &setOperation{
+			op:    so.op,
+			all:   so.all,
+			left:  so.left.correlatedWith(row, implicitDB),
+			right: so.right.correlatedWith(row, implicitDB),
 		}
 
-		db = tx.currentDB
+		return &q
 	}
 
-	table, err := db.GetTableByName(stmt.table)
-	if err != nil {
-		return nil, err
+	shadowed := []string{stmt.ds.Alias()}
+	for _, j := range stmt.joins {
+		shadowed = append(shadowed, j.ds.Alias())
 	}
 
-	return table, nil
-}
+	outerRow := &Row{Values: make(map[string]TypedValue, len(row.Values))}
 
-func (stmt *tableRef) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
-	return nil
-}
+	for sel, val := range row.Values {
+		isShadowed := false
 
-func (stmt *tableRef) Resolve(tx *SQLTx, params map[string]interface{}, scanSpecs *ScanSpecs) (RowReader, error) {
-	if tx == nil {
-		return nil, ErrIllegalArguments
-	}
+		for _, alias := range shadowed {
+			encSel := EncodeSelector("", implicitDB, alias, "")
+
+			if strings.HasPrefix(sel, encSel[:len(encSel)-1]) {
+				isShadowed = true
+				break
+			}
+		}
+
+		if !isShadowed {
+			outerRow.Values[sel] = val
+		}
+	}
+
+	q := *stmt
+
+	alias := stmt.ds.Alias()
+
+	if stmt.where != nil {
+		q.where = stmt.where.reduceSelectors(outerRow, implicitDB, alias)
+	}
+
+	if stmt.having != nil {
+		q.having = stmt.having.reduceSelectors(outerRow, implicitDB, alias)
+	}
+
+	if len(stmt.joins) > 0 {
+		q.joins = make([]*JoinSpec, len(stmt.joins))
+
+		for i, j := range stmt.joins {
+			jspec := *j
+			jspec.cond = j.cond.reduceSelectors(outerRow, implicitDB, alias)
+
+			q.joins[i] = &jspec
+		}
+	}
+
+	return &q
+}
+
+// isCorrelated returns true when the conditions of the query may reference columns of an outer query,
+// selectors qualified by tables not read by the query itself are taken as such
+func (stmt *SelectStmt) isCorrelated() bool {
+	if so, ok := stmt.ds.(*setOperation); ok {
+		return so.left.isCorrelated() || so.right.isCorrelated()
+	}
+
+	aliases := map[string]struct{}{stmt.ds.Alias(): {}}
+	for _, j := range stmt.joins {
+		aliases[j.ds.Alias()] = struct{}{}
+	}
+
+	tables := make(map[string]struct{})
+
+	// nested subqueries are conservatively taken as correlated
+	complete := referencedTables(stmt.where, tables)
+	complete = referencedTables(stmt.having, tables) && complete
+
+	for _, sel := range stmt.selectors {
+		complete = referencedTables(sel, tables) && complete
+	}
+
+	for _, j := range stmt.joins {
+		complete = referencedTables(j.cond, tables) && complete
+	}
+
+	if !complete {
+		return true
+	}
+
+	for table := range tables {
+		_, isRead := aliases[table]
+		if table != "" && !isRead {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkScope validates the tables qualifying the columns referenced by the query. Every table read by the query
+// must be named after a distinct alias, and join conditions may only reference the tables read up to their join.
+// Columns of outer queries are replaced by their values before correlated subqueries are resolved
+func (stmt *SelectStmt) checkScope() error {
+	if _, ok := stmt.ds.(*setOperation); ok {
+		return nil
+	}
+
+	scope := map[string]struct{}{stmt.ds.Alias(): {}}
+
+	for _, j := range stmt.joins {
+		alias := j.ds.Alias()
+
+		_, exists := scope[alias]
+		if exists {
+			return fmt.Errorf(
+				"error resolving table '%s' in a join: %w, use aliasing to assign unique names for all tables, sub-queries and columns",
+				alias,
+				ErrAmbiguousSelector,
+			)
+		}
+
+		scope[alias] = struct{}{}
+
+		err := checkTablesInScope(scope, j.cond)
+		if err != nil {
+			return err
+		}
+	}
+
+	exps := []ValueExp{stmt.where, stmt.having}
+
+	for _, sel := range stmt.selectors {
+		exps = append(exps, sel)
+	}
+
+	for _, sel := range stmt.groupBy {
+		exps = append(exps, sel)
+	}
+
+	for _, col := range stmt.orderBy {
+		exps = append(exps, col.sel)
+	}
+
+	return checkTablesInScope(scope, exps...)
+}
+
+func checkTablesInScope(scope map[string]struct{}, exps ...ValueExp) error {
+	tables := make(map[string]struct{})
+
+	for _, exp := range exps {
+		referencedTables(exp, tables)
+	}
+
+	for table := range tables {
+		_, inScope := scope[table]
+		if table != "" && !inScope {
+			return fmt.Errorf("%w: '%s', tables must be referenced by the aliases they're read with", ErrTableNotInScope, table)
+		}
+	}
+
+	return nil
+}
+
+// referencedTables adds the tables qualifying the columns referenced by exp, columns of nested subqueries
+// are not added. False is returned when exp contains subqueries
+func referencedTables(exp ValueExp, tables map[string]struct{}) bool {
+	switch e := exp.(type) {
+	case nil, TypedValue, *Param:
+		return true
+	case *ColSelector:
+		tables[e.table] = struct{}{}
+		return true
+	case *AggColSelector:
+		tables[e.table] = struct{}{}
+		return true
+	case *SysFn:
+		complete := true
+		for _, p := range e.params {
+			complete = referencedTables(p, tables) && complete
+		}
+
+		return complete
+	case *NumExp:
+		complete := referencedTables(e.left, tables)
+		return referencedTables(e.right, tables) && complete
+	case *CmpBoolExp:
+		complete := referencedTables(e.left, tables)
+		return referencedTables(e.right, tables) && complete
+	case *BinBoolExp:
+		complete := referencedTables(e.left, tables)
+		return referencedTables(e.right, tables) && complete
+	case *NotBoolExp:
+		return referencedTables(e.exp, tables)
+	case *LikeBoolExp:
+		complete := referencedTables(e.val, tables)
+		return referencedTables(e.pattern, tables) && complete
+	case *RegexpBoolExp:
+		complete := referencedTables(e.val, tables)
+		return referencedTables(e.pattern, tables) && complete
+	case *Cast:
+		return referencedTables(e.val, tables)
+	case *CaseWhenExp:
+		complete := referencedTables(e.elseExp, tables)
+		for _, w := range e.whens {
+			complete = referencedTables(w.cond, tables) && complete
+			complete = referencedTables(w.then, tables) && complete
+		}
+
+		return complete
+	case *InListExp:
+		complete := referencedTables(e.val, tables)
+		for _, v := range e.values {
+			complete = referencedTables(v, tables) && complete
+		}
+
+		return complete
+	}
+
+	return false
+}
+
+// readValueSet reads the values of the single column returned by the query
+func (stmt *SelectStmt) readValueSet(tx *SQLTx, params map[string]interface{}) (*valueSet, error) {
+	_, err := stmt.execAt(tx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := stmt.Resolve(tx, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	cols, err := r.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cols) != 1 {
+		return nil, fmt.Errorf("%w: subquery must return a single column", ErrInvalidNumberOfValues)
+	}
+
+	members := newValueSet()
+
+	for {
+		row, err := r.Read()
+		if errors.Is(err, ErrNoMoreRows) {
+			return members, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = members.add(row.Values[cols[0].Selector()])
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (stmt *SelectStmt) scanLimit() int {
+	if stmt.limit == 0 || stmt.joins != nil || stmt.where != nil || stmt.groupBy != nil || stmt.distinct {
+		return 0
+	}
+
+	for _, sel := range stmt.selectors {
+		_, isAggregation := sel.(*AggColSelector)
+		if isAggregation {
+			return 0
+		}
+	}
+
+	return stmt.offset + stmt.limit
+}
+
+// countOnly returns true when the selected rows are only counted,
+// i.e. COUNT(*) is the only aggregation and no column is filtered nor grouped
+func (stmt *SelectStmt) countOnly() bool {
+	if stmt.joins != nil || stmt.where != nil || stmt.groupBy != nil {
+		return false
+	}
+
+	for _, sel := range stmt.selectors {
+		aggSel, isAggregation := sel.(*AggColSelector)
+		if !isAggregation || aggSel.aggFn != COUNT || aggSel.col != "*" {
+			return false
+		}
+	}
+
+	return len(stmt.selectors) > 0
+}
+
+type tableRef struct {
+	db         string
+	table      string
+	sinceTx    uint64 // only rows whose version was committed since this tx are read
+	asBefore   uint64
+	asBeforeTs ValueExp
+	as         string
+}
+
+func (stmt *tableRef) referencedTable(tx *SQLTx) (*Table, error) {
+	var db *Database
+
+	if stmt.db != "" {
+		rdb, err := tx.catalog.GetDatabaseByName(stmt.db)
+		if err != nil {
+			return nil, err
+		}
+
+		db = rdb
+	}
+
+	if db == nil {
+		if tx.currentDB == nil {
+			return nil, ErrNoDatabaseSelected
+		}
+
+		db = tx.currentDB
+	}
+
+	table, err := db.GetTableByName(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+func (stmt *tableRef) inferParameters(tx *SQLTx, params map[string]SQLValueType) error {
+	return nil
+}
+
+func (stmt *tableRef) Resolve(tx *SQLTx, params map[string]interface{}, scanSpecs *ScanSpecs) (RowReader, error) {
+	if tx == nil {
+		return nil, ErrIllegalArguments
+	}
 
 	table, err := stmt.referencedTable(tx)
 	if err != nil {
 		return nil, err
 	}
 
-	return newRawRowReader(tx, table, stmt.asBefore, stmt.as, scanSpecs)
+	if tx.tableFilter != nil && !tx.tableFilter(table.db.name, table.name) {
+		return nil, fmt.Errorf("%w: %s", ErrTableAccessDenied, table.name)
+	}
+
+	asBefore := stmt.asBefore
+	if asBefore == 0 && stmt.asBeforeTs == nil {
+		// rows are read from the snapshot selected with USE SNAPSHOT, if any
+		asBefore = tx.snapshotAsBefore
+	}
+
+	// rows read through a synonym are referenced by the synonym name
+	r, err := newRawRowReader(tx, table, asBefore, stmt.Alias(), scanSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	r.sinceTx = stmt.sinceTx
+
+	// the timestamp is resolved into a transaction once rows are read
+	r.asBeforeTs = stmt.asBeforeTs
+	r.params = params
+
+	return r, nil
 }
 
 func (stmt *tableRef) Alias() string {
@@ -2334,7 +4119,7 @@ func (sel *ColSelector) substitute(params map[string]interface{}) (ValueExp, err
 	return sel, nil
 }
 
-func (sel *ColSelector) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (sel *ColSelector) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	if row == nil {
 		return nil, ErrInvalidValue
 	}
@@ -2410,12 +4195,16 @@ func (sel *AggColSelector) inferType(cols map[string]ColDescriptor, params map[s
 	colSelector := &ColSelector{db: sel.db, table: sel.table, col: sel.col}
 
 	if sel.aggFn == SUM || sel.aggFn == AVG {
-		err := colSelector.requiresType(IntegerType, cols, params, implicitDB, implicitTable)
+		t, err := colSelector.inferType(cols, params, implicitDB, implicitTable)
 		if err != nil {
 			return AnyType, err
 		}
 
-		return IntegerType, nil
+		if !isNumericType(t) {
+			return AnyType, fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, t, IntegerType)
+		}
+
+		return t, nil
 	}
 
 	return colSelector.inferType(cols, params, implicitDB, implicitTable)
@@ -2432,7 +4221,11 @@ func (sel *AggColSelector) requiresType(t SQLValueType, cols map[string]ColDescr
 	colSelector := &ColSelector{db: sel.db, table: sel.table, col: sel.col}
 
 	if sel.aggFn == SUM || sel.aggFn == AVG {
-		return colSelector.requiresType(IntegerType, cols, params, implicitDB, implicitTable)
+		if !isNumericType(t) {
+			return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, IntegerType, t)
+		}
+
+		return colSelector.requiresType(t, cols, params, implicitDB, implicitTable)
 	}
 
 	return colSelector.requiresType(t, cols, params, implicitDB, implicitTable)
@@ -2442,7 +4235,7 @@ func (sel *AggColSelector) substitute(params map[string]interface{}) (ValueExp,
 	return sel, nil
 }
 
-func (sel *AggColSelector) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (sel *AggColSelector) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	v, ok := row.Values[EncodeSelector(sel.resolve(implicitDB, implicitTable))]
 	if !ok {
 		return nil, fmt.Errorf("%w (%s)", ErrColumnDoesNotExist, sel.col)
@@ -2462,112 +4255,351 @@ func (sel *AggColSelector) selectorRanges(table *Table, asTable string, params m
 	return nil
 }
 
+// NumExp is an arithmetic expression, it may be projected when its left operand is a selector
 type NumExp struct {
 	op          NumOperator
 	left, right ValueExp
+	as          string
 }
 
 func (bexp *NumExp) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
-	err := bexp.left.requiresType(IntegerType, cols, params, implicitDB, implicitTable)
+	tleft, err := bexp.left.inferType(cols, params, implicitDB, implicitTable)
 	if err != nil {
 		return AnyType, err
 	}
 
-	err = bexp.right.requiresType(IntegerType, cols, params, implicitDB, implicitTable)
+	tright, err := bexp.right.inferType(cols, params, implicitDB, implicitTable)
 	if err != nil {
 		return AnyType, err
 	}
 
-	return IntegerType, nil
-}
-
-func (bexp *NumExp) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
-	if t != IntegerType {
-		return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, IntegerType, t)
+	if isTimestampArith(tleft, tright) {
+		return timestampArithType(bexp.op, tleft, tright)
 	}
 
-	err := bexp.left.requiresType(IntegerType, cols, params, implicitDB, implicitTable)
-	if err != nil {
-		return err
+	// unification step, parameters are taken as integers unless the other operand says otherwise
+
+	if tleft == AnyType && tright == AnyType {
+		tleft = IntegerType
+		tright = IntegerType
 	}
 
-	err = bexp.right.requiresType(IntegerType, cols, params, implicitDB, implicitTable)
-	if err != nil {
-		return err
+	if tleft == AnyType {
+		tleft = tright
 	}
 
-	return nil
-}
+	if tright == AnyType {
+		tright = tleft
+	}
 
-func (bexp *NumExp) substitute(params map[string]interface{}) (ValueExp, error) {
-	rlexp, err := bexp.left.substitute(params)
+	err = bexp.left.requiresType(tleft, cols, params, implicitDB, implicitTable)
 	if err != nil {
-		return nil, err
+		return AnyType, err
 	}
 
-	rrexp, err := bexp.right.substitute(params)
+	err = bexp.right.requiresType(tright, cols, params, implicitDB, implicitTable)
 	if err != nil {
-		return nil, err
+		return AnyType, err
 	}
 
-	bexp.left = rlexp
-	bexp.right = rrexp
-
-	return bexp, nil
+	return numericResultType(tleft, tright)
 }
 
-func (bexp *NumExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	vl, err := bexp.left.reduce(catalog, row, implicitDB, implicitTable)
-	if err != nil {
-		return nil, err
-	}
+func (bexp *NumExp) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
+	if t == TimestampType {
+		rt, err := bexp.inferType(cols, params, implicitDB, implicitTable)
+		if err != nil {
+			return err
+		}
 
-	vr, err := bexp.right.reduce(catalog, row, implicitDB, implicitTable)
-	if err != nil {
-		return nil, err
-	}
+		if rt != TimestampType {
+			return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, rt, t)
+		}
 
-	nl, isNumber := vl.Value().(int64)
-	if !isNumber {
-		return nil, fmt.Errorf("%w (expecting numeric value)", ErrInvalidValue)
+		return nil
 	}
 
-	nr, isNumber := vr.Value().(int64)
-	if !isNumber {
-		return nil, fmt.Errorf("%w (expecting numeric value)", ErrInvalidValue)
+	if !isNumericType(t) {
+		return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, IntegerType, t)
 	}
 
-	switch bexp.op {
-	case ADDOP:
-		{
-			return &Number{val: nl + nr}, nil
-		}
-	case SUBSOP:
-		{
-			return &Number{val: nl - nr}, nil
+	for _, exp := range []ValueExp{bexp.left, bexp.right} {
+		et, err := exp.inferType(cols, params, implicitDB, implicitTable)
+		if err != nil {
+			return err
 		}
-	case DIVOP:
-		{
-			if nr == 0 {
-				return nil, ErrDivisionByZero
-			}
 
-			return &Number{val: nl / nr}, nil
+		// integer operands are promoted
+		if et == IntegerType {
+			continue
 		}
-	case MULTOP:
-		{
-			return &Number{val: nl * nr}, nil
+
+		err = exp.requiresType(t, cols, params, implicitDB, implicitTable)
+		if err != nil {
+			return err
 		}
 	}
 
-	return nil, ErrUnexpected
+	return nil
 }
 
-func (bexp *NumExp) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
-	return &NumExp{
-		op:    bexp.op,
-		left:  bexp.left.reduceSelectors(row, implicitDB, implicitTable),
-		right: bexp.right.reduceSelectors(row, implicitDB, implicitTable),
+func isNumericType(t SQLValueType) bool {
+	return t == IntegerType || t == FloatType || t == DecimalType
+}
+
+// numericResultType returns the type of an arithmetic operation,
+// INTEGER operands are promoted when combined with FLOAT or DECIMAL ones
+func numericResultType(tleft, tright SQLValueType) (SQLValueType, error) {
+	if !isNumericType(tleft) {
+		return AnyType, fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, tleft, IntegerType)
+	}
+
+	if !isNumericType(tright) {
+		return AnyType, fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, tright, IntegerType)
+	}
+
+	if tleft == tright || tright == IntegerType {
+		return tleft, nil
+	}
+
+	if tleft == IntegerType {
+		return tright, nil
+	}
+
+	return AnyType, fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, tright, tleft)
+}
+
+// compareNumeric compares numeric values of different types,
+// INTEGER values are promoted as in arithmetic operations
+func compareNumeric(vl, vr TypedValue) (int, error) {
+	t, err := numericResultType(vl.Type(), vr.Type())
+	if err != nil {
+		return 0, ErrNotComparableValues
+	}
+
+	if t == DecimalType {
+		return asDecimal(vl).Cmp(asDecimal(vr)), nil
+	}
+
+	fl := asFloat(vl)
+	fr := asFloat(vr)
+
+	if fl == fr {
+		return 0, nil
+	}
+
+	if fl > fr {
+		return 1, nil
+	}
+
+	return -1, nil
+}
+
+func (bexp *NumExp) substitute(params map[string]interface{}) (ValueExp, error) {
+	rlexp, err := bexp.left.substitute(params)
+	if err != nil {
+		return nil, err
+	}
+
+	rrexp, err := bexp.right.substitute(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NumExp{op: bexp.op, left: rlexp, right: rrexp, as: bexp.as}, nil
+}
+
+func (bexp *NumExp) resolve(implicitDB, implicitTable string) (aggFn, db, table, col string) {
+	return "", implicitDB, implicitTable, bexp.as
+}
+
+func (bexp *NumExp) alias() string {
+	return bexp.as
+}
+
+func (bexp *NumExp) setAlias(alias string) {
+	bexp.as = alias
+}
+
+func (bexp *NumExp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	vl, err := bexp.left.reduce(tx, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, err
+	}
+
+	vr, err := bexp.right.reduce(tx, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, err
+	}
+
+	if vl.IsNull() || vr.IsNull() {
+		return bexp.nullValue(vl.Type(), vr.Type())
+	}
+
+	if isTimestampArith(vl.Type(), vr.Type()) {
+		return bexp.reduceTimestamp(vl, vr)
+	}
+
+	t, err := numericResultType(vl.Type(), vr.Type())
+	if err != nil {
+		return nil, fmt.Errorf("%w (expecting numeric value)", ErrInvalidValue)
+	}
+
+	switch t {
+	case FloatType:
+		{
+			return bexp.reduceFloat(asFloat(vl), asFloat(vr))
+		}
+	case DecimalType:
+		{
+			return bexp.reduceDecimal(asDecimal(vl), asDecimal(vr))
+		}
+	}
+
+	nl := vl.Value().(int64)
+	nr := vr.Value().(int64)
+
+	switch bexp.op {
+	case ADDOP:
+		{
+			return &Number{val: nl + nr}, nil
+		}
+	case SUBSOP:
+		{
+			return &Number{val: nl - nr}, nil
+		}
+	case DIVOP:
+		{
+			if nr == 0 {
+				return nil, ErrDivisionByZero
+			}
+
+			return &Number{val: nl / nr}, nil
+		}
+	case MULTOP:
+		{
+			return &Number{val: nl * nr}, nil
+		}
+	}
+
+	return nil, ErrUnexpected
+}
+
+// nullValue returns the result of the operation when an operand is NULL, a NULL of the type of the result
+func (bexp *NumExp) nullValue(tleft, tright SQLValueType) (TypedValue, error) {
+	if tleft == AnyType && tright == AnyType {
+		return &NullValue{t: IntegerType}, nil
+	}
+
+	if tleft == AnyType {
+		tleft = tright
+	}
+
+	if tright == AnyType {
+		tright = tleft
+	}
+
+	if isTimestampArith(tleft, tright) {
+		t, err := timestampArithType(bexp.op, tleft, tright)
+		if err != nil {
+			return nil, fmt.Errorf("%w (expecting a timestamp and an interval)", ErrInvalidValue)
+		}
+
+		return &NullValue{t: t}, nil
+	}
+
+	t, err := numericResultType(tleft, tright)
+	if err != nil {
+		return nil, fmt.Errorf("%w (expecting numeric value)", ErrInvalidValue)
+	}
+
+	return &NullValue{t: t}, nil
+}
+
+func (bexp *NumExp) reduceFloat(fl, fr float64) (TypedValue, error) {
+	switch bexp.op {
+	case ADDOP:
+		{
+			return &Float{val: fl + fr}, nil
+		}
+	case SUBSOP:
+		{
+			return &Float{val: fl - fr}, nil
+		}
+	case DIVOP:
+		{
+			if fr == 0 {
+				return nil, ErrDivisionByZero
+			}
+
+			return &Float{val: fl / fr}, nil
+		}
+	case MULTOP:
+		{
+			return &Float{val: fl * fr}, nil
+		}
+	}
+
+	return nil, ErrUnexpected
+}
+
+func (bexp *NumExp) reduceDecimal(dl, dr *big.Rat) (TypedValue, error) {
+	r := new(big.Rat)
+
+	switch bexp.op {
+	case ADDOP:
+		{
+			r.Add(dl, dr)
+		}
+	case SUBSOP:
+		{
+			r.Sub(dl, dr)
+		}
+	case DIVOP:
+		{
+			if dr.Sign() == 0 {
+				return nil, ErrDivisionByZero
+			}
+
+			r.Quo(dl, dr)
+		}
+	case MULTOP:
+		{
+			r.Mul(dl, dr)
+		}
+	default:
+		{
+			return nil, ErrUnexpected
+		}
+	}
+
+	d, err := NewDecimal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Decimal{val: d}, nil
+}
+
+func asFloat(v TypedValue) float64 {
+	if n, ok := v.Value().(int64); ok {
+		return float64(n)
+	}
+	return v.Value().(float64)
+}
+
+func asDecimal(v TypedValue) *big.Rat {
+	if n, ok := v.Value().(int64); ok {
+		return new(big.Rat).SetInt64(n)
+	}
+	return v.Value().(*big.Rat)
+}
+
+func (bexp *NumExp) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
+	return &NumExp{
+		op:    bexp.op,
+		left:  bexp.left.reduceSelectors(row, implicitDB, implicitTable),
+		right: bexp.right.reduceSelectors(row, implicitDB, implicitTable),
 	}
 }
 
@@ -2589,154 +4621,422 @@ func (bexp *NotBoolExp) inferType(cols map[string]ColDescriptor, params map[stri
 		return AnyType, err
 	}
 
-	return BooleanType, nil
-}
+	return BooleanType, nil
+}
+
+func (bexp *NotBoolExp) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
+	if t != BooleanType {
+		return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, BooleanType, t)
+	}
+
+	return bexp.exp.requiresType(BooleanType, cols, params, implicitDB, implicitTable)
+}
+
+func (bexp *NotBoolExp) substitute(params map[string]interface{}) (ValueExp, error) {
+	rexp, err := bexp.exp.substitute(params)
+	if err != nil {
+		return nil, err
+	}
+
+	bexp.exp = rexp
+
+	return bexp, nil
+}
+
+func (bexp *NotBoolExp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	v, err := bexp.exp.reduce(tx, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, err
+	}
+
+	r, isBool := v.Value().(bool)
+	if !isBool {
+		return nil, ErrInvalidCondition
+	}
+
+	return &Bool{val: !r}, nil
+}
+
+func (bexp *NotBoolExp) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
+	return &NotBoolExp{
+		exp: bexp.exp.reduceSelectors(row, implicitDB, implicitTable),
+	}
+}
+
+func (bexp *NotBoolExp) isConstant() bool {
+	return bexp.exp.isConstant()
+}
+
+func (bexp *NotBoolExp) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
+	return nil
+}
+
+type LikeBoolExp struct {
+	val     ValueExp
+	notLike bool
+	pattern ValueExp
+}
+
+func (bexp *LikeBoolExp) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
+	if bexp.val == nil || bexp.pattern == nil {
+		return AnyType, fmt.Errorf("error in 'LIKE' clause: %w", ErrInvalidCondition)
+	}
+
+	err := bexp.pattern.requiresType(VarcharType, cols, params, implicitDB, implicitTable)
+	if err != nil {
+		return AnyType, fmt.Errorf("error in 'LIKE' clause: %w", err)
+	}
+
+	return BooleanType, nil
+}
+
+func (bexp *LikeBoolExp) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
+	if bexp.val == nil || bexp.pattern == nil {
+		return fmt.Errorf("error in 'LIKE' clause: %w", ErrInvalidCondition)
+	}
+
+	if t != BooleanType {
+		return fmt.Errorf("error using the value of the LIKE operator as %s: %w", t, ErrInvalidTypes)
+	}
+
+	err := bexp.pattern.requiresType(VarcharType, cols, params, implicitDB, implicitTable)
+	if err != nil {
+		return fmt.Errorf("error in 'LIKE' clause: %w", err)
+	}
+
+	return nil
+}
+
+func (bexp *LikeBoolExp) substitute(params map[string]interface{}) (ValueExp, error) {
+	if bexp.val == nil || bexp.pattern == nil {
+		return nil, fmt.Errorf("error in 'LIKE' clause: %w", ErrInvalidCondition)
+	}
+
+	val, err := bexp.val.substitute(params)
+	if err != nil {
+		return nil, fmt.Errorf("error in 'LIKE' clause: %w", err)
+	}
+
+	pattern, err := bexp.pattern.substitute(params)
+	if err != nil {
+		return nil, fmt.Errorf("error in 'LIKE' clause: %w", err)
+	}
+
+	return &LikeBoolExp{
+		val:     val,
+		notLike: bexp.notLike,
+		pattern: pattern,
+	}, nil
+}
+
+func (bexp *LikeBoolExp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	if bexp.val == nil || bexp.pattern == nil {
+		return nil, fmt.Errorf("error in 'LIKE' clause: %w", ErrInvalidCondition)
+	}
+
+	rval, err := bexp.val.reduce(tx, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, fmt.Errorf("error in 'LIKE' clause: %w", err)
+	}
+
+	if rval.Type() != VarcharType {
+		return nil, fmt.Errorf("error in 'LIKE' clause: %w (expecting %s)", ErrInvalidTypes, VarcharType)
+	}
+
+	rpattern, err := bexp.pattern.reduce(tx, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, fmt.Errorf("error in 'LIKE' clause: %w", err)
+	}
+
+	if rpattern.Type() != VarcharType {
+		return nil, fmt.Errorf("error evaluating 'LIKE' clause: %w", ErrInvalidTypes)
+	}
+
+	if rval.IsNull() || rpattern.IsNull() {
+		return &Bool{val: false}, nil
+	}
+
+	matched := likeMatches(rval.Value().(string), rpattern.Value().(string))
+
+	return &Bool{val: matched != bexp.notLike}, nil
+}
+
+func (bexp *LikeBoolExp) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
+	if bexp.val == nil || bexp.pattern == nil {
+		return bexp
+	}
+
+	return &LikeBoolExp{
+		val:     bexp.val.reduceSelectors(row, implicitDB, implicitTable),
+		notLike: bexp.notLike,
+		pattern: bexp.pattern.reduceSelectors(row, implicitDB, implicitTable),
+	}
+}
+
+func (bexp *LikeBoolExp) isConstant() bool {
+	return false
+}
+
+// selectorRanges narrows the scan to the values starting with the fixed prefix of the pattern,
+// the pattern is still evaluated against each row read
+func (bexp *LikeBoolExp) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
+	if bexp.val == nil || bexp.pattern == nil || bexp.notLike || !bexp.pattern.isConstant() {
+		return nil
+	}
+
+	sel, isSel := bexp.val.(*ColSelector)
+	if !isSel {
+		return nil
+	}
+
+	aggFn, db, t, col := sel.resolve(table.db.name, table.name)
+	if aggFn != "" || db != table.db.name || t != asTable {
+		return nil
+	}
+
+	column, err := table.GetColumnByName(col)
+	if err != nil {
+		return err
+	}
+
+	if column.colType != VarcharType {
+		return nil
+	}
+
+	pattern, err := bexp.pattern.substitute(params)
+	if err == ErrMissingParameter {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	rpattern, err := pattern.reduce(nil, nil, table.db.name, table.name)
+	if err != nil {
+		return err
+	}
+
+	if rpattern.Type() != VarcharType || rpattern.IsNull() {
+		return nil
+	}
+
+	prefix := likePrefix(rpattern.Value().(string))
+	if prefix == "" {
+		return nil
+	}
+
+	err = updateRangeFor(column.id, &Varchar{val: prefix}, GE, rangesByColID)
+	if err != nil {
+		return err
+	}
+
+	upper, bounded := prefixUpperBound(prefix)
+	if !bounded {
+		return nil
+	}
+
+	return updateRangeFor(column.id, &Varchar{val: upper}, LT, rangesByColID)
+}
+
+// likeMatches returns true when the value matches the LIKE pattern, where '%' matches any sequence
+// of characters, '_' matches a single character and any character escaped with '\' matches itself
+func likeMatches(val, pattern string) bool {
+	v := []rune(val)
+	p := []rune(pattern)
+
+	vi, pi := 0, 0
+
+	// position of the last '%' in the pattern and of the value being matched by it
+	lastWildcard, lastMatched := -1, 0
+
+	for vi < len(v) {
+		if pi < len(p) {
+			switch p[pi] {
+			case '%':
+				lastWildcard, lastMatched = pi, vi
+				pi++
+				continue
+			case '_':
+				vi++
+				pi++
+				continue
+			default:
+				ch, width := p[pi], 1
+				if ch == '\\' && pi+1 < len(p) {
+					ch, width = p[pi+1], 2
+				}
+
+				if ch == v[vi] {
+					vi++
+					pi += width
+					continue
+				}
+			}
+		}
+
+		if lastWildcard < 0 {
+			return false
+		}
+
+		// the last '%' takes one more character and matching is resumed after it
+		lastMatched++
+		vi = lastMatched
+		pi = lastWildcard + 1
+	}
 
-func (bexp *NotBoolExp) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
-	if t != BooleanType {
-		return fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, BooleanType, t)
+	for pi < len(p) && p[pi] == '%' {
+		pi++
 	}
 
-	return bexp.exp.requiresType(BooleanType, cols, params, implicitDB, implicitTable)
+	return pi == len(p)
 }
 
-func (bexp *NotBoolExp) substitute(params map[string]interface{}) (ValueExp, error) {
-	rexp, err := bexp.exp.substitute(params)
-	if err != nil {
-		return nil, err
-	}
+// likePrefix returns the characters every value matching the LIKE pattern starts with
+func likePrefix(pattern string) string {
+	var b strings.Builder
 
-	bexp.exp = rexp
+	p := []rune(pattern)
 
-	return bexp, nil
-}
+	for i := 0; i < len(p); i++ {
+		if p[i] == '%' || p[i] == '_' {
+			break
+		}
 
-func (bexp *NotBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	v, err := bexp.exp.reduce(catalog, row, implicitDB, implicitTable)
-	if err != nil {
-		return nil, err
-	}
+		if p[i] == '\\' && i+1 < len(p) {
+			i++
+		}
 
-	r, isBool := v.Value().(bool)
-	if !isBool {
-		return nil, ErrInvalidCondition
+		b.WriteRune(p[i])
 	}
 
-	return &Bool{val: !r}, nil
+	return b.String()
 }
 
-func (bexp *NotBoolExp) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
-	return &NotBoolExp{
-		exp: bexp.exp.reduceSelectors(row, implicitDB, implicitTable),
-	}
-}
+// prefixUpperBound returns the lowest value greater than every value starting with the prefix,
+// no such bound exists when the prefix only consists of 0xff bytes
+func prefixUpperBound(prefix string) (string, bool) {
+	b := []byte(prefix)
 
-func (bexp *NotBoolExp) isConstant() bool {
-	return bexp.exp.isConstant()
-}
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return string(b[:i+1]), true
+		}
+	}
 
-func (bexp *NotBoolExp) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
-	return nil
+	return "", false
 }
 
-type LikeBoolExp struct {
-	val     ValueExp
-	notLike bool
-	pattern ValueExp
+type RegexpBoolExp struct {
+	val      ValueExp
+	notMatch bool
+	pattern  ValueExp
 }
 
-func (bexp *LikeBoolExp) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
+func (bexp *RegexpBoolExp) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
 	if bexp.val == nil || bexp.pattern == nil {
-		return AnyType, fmt.Errorf("error in 'LIKE' clause: %w", ErrInvalidCondition)
+		return AnyType, fmt.Errorf("error in '~' clause: %w", ErrInvalidCondition)
 	}
 
 	err := bexp.pattern.requiresType(VarcharType, cols, params, implicitDB, implicitTable)
 	if err != nil {
-		return AnyType, fmt.Errorf("error in 'LIKE' clause: %w", err)
+		return AnyType, fmt.Errorf("error in '~' clause: %w", err)
 	}
 
 	return BooleanType, nil
 }
 
-func (bexp *LikeBoolExp) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
+func (bexp *RegexpBoolExp) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
 	if bexp.val == nil || bexp.pattern == nil {
-		return fmt.Errorf("error in 'LIKE' clause: %w", ErrInvalidCondition)
+		return fmt.Errorf("error in '~' clause: %w", ErrInvalidCondition)
 	}
 
 	if t != BooleanType {
-		return fmt.Errorf("error using the value of the LIKE operator as %s: %w", t, ErrInvalidTypes)
+		return fmt.Errorf("error using the value of the ~ operator as %s: %w", t, ErrInvalidTypes)
 	}
 
 	err := bexp.pattern.requiresType(VarcharType, cols, params, implicitDB, implicitTable)
 	if err != nil {
-		return fmt.Errorf("error in 'LIKE' clause: %w", err)
+		return fmt.Errorf("error in '~' clause: %w", err)
 	}
 
 	return nil
 }
 
-func (bexp *LikeBoolExp) substitute(params map[string]interface{}) (ValueExp, error) {
+func (bexp *RegexpBoolExp) substitute(params map[string]interface{}) (ValueExp, error) {
 	if bexp.val == nil || bexp.pattern == nil {
-		return nil, fmt.Errorf("error in 'LIKE' clause: %w", ErrInvalidCondition)
+		return nil, fmt.Errorf("error in '~' clause: %w", ErrInvalidCondition)
 	}
 
 	val, err := bexp.val.substitute(params)
 	if err != nil {
-		return nil, fmt.Errorf("error in 'LIKE' clause: %w", err)
+		return nil, fmt.Errorf("error in '~' clause: %w", err)
 	}
 
 	pattern, err := bexp.pattern.substitute(params)
 	if err != nil {
-		return nil, fmt.Errorf("error in 'LIKE' clause: %w", err)
+		return nil, fmt.Errorf("error in '~' clause: %w", err)
 	}
 
-	return &LikeBoolExp{
-		val:     val,
-		notLike: bexp.notLike,
-		pattern: pattern,
+	return &RegexpBoolExp{
+		val:      val,
+		notMatch: bexp.notMatch,
+		pattern:  pattern,
 	}, nil
 }
 
-func (bexp *LikeBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+func (bexp *RegexpBoolExp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	if bexp.val == nil || bexp.pattern == nil {
-		return nil, fmt.Errorf("error in 'LIKE' clause: %w", ErrInvalidCondition)
+		return nil, fmt.Errorf("error in '~' clause: %w", ErrInvalidCondition)
 	}
 
-	rval, err := bexp.val.reduce(catalog, row, implicitDB, implicitTable)
+	rval, err := bexp.val.reduce(tx, row, implicitDB, implicitTable)
 	if err != nil {
-		return nil, fmt.Errorf("error in 'LIKE' clause: %w", err)
+		return nil, fmt.Errorf("error in '~' clause: %w", err)
 	}
 
 	if rval.Type() != VarcharType {
-		return nil, fmt.Errorf("error in 'LIKE' clause: %w (expecting %s)", ErrInvalidTypes, VarcharType)
+		return nil, fmt.Errorf("error in '~' clause: %w (expecting %s)", ErrInvalidTypes, VarcharType)
 	}
 
-	rpattern, err := bexp.pattern.reduce(catalog, row, implicitDB, implicitTable)
+	rpattern, err := bexp.pattern.reduce(tx, row, implicitDB, implicitTable)
 	if err != nil {
-		return nil, fmt.Errorf("error in 'LIKE' clause: %w", err)
+		return nil, fmt.Errorf("error in '~' clause: %w", err)
 	}
 
 	if rpattern.Type() != VarcharType {
-		return nil, fmt.Errorf("error evaluating 'LIKE' clause: %w", ErrInvalidTypes)
+		return nil, fmt.Errorf("error evaluating '~' clause: %w", ErrInvalidTypes)
+	}
+
+	if rval.IsNull() || rpattern.IsNull() {
+		return &Bool{val: false}, nil
 	}
 
 	matched, err := regexp.MatchString(rpattern.Value().(string), rval.Value().(string))
 	if err != nil {
-		return nil, fmt.Errorf("error in 'LIKE' clause: %w", err)
+		return nil, fmt.Errorf("error in '~' clause: %w", err)
 	}
 
-	return &Bool{val: matched != bexp.notLike}, nil
+	return &Bool{val: matched != bexp.notMatch}, nil
 }
 
-func (bexp *LikeBoolExp) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
-	return bexp
+func (bexp *RegexpBoolExp) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
+	if bexp.val == nil || bexp.pattern == nil {
+		return bexp
+	}
+
+	return &RegexpBoolExp{
+		val:      bexp.val.reduceSelectors(row, implicitDB, implicitTable),
+		notMatch: bexp.notMatch,
+		pattern:  bexp.pattern.reduceSelectors(row, implicitDB, implicitTable),
+	}
 }
 
-func (bexp *LikeBoolExp) isConstant() bool {
+func (bexp *RegexpBoolExp) isConstant() bool {
 	return false
 }
 
-func (bexp *LikeBoolExp) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
+func (bexp *RegexpBoolExp) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
 	return nil
 }
 
@@ -2762,6 +5062,15 @@ func (bexp *CmpBoolExp) inferType(cols map[string]ColDescriptor, params map[stri
 		return BooleanType, nil
 	}
 
+	if isNumericType(tleft) && isNumericType(tright) {
+		_, err = numericResultType(tleft, tright)
+		if err != nil {
+			return AnyType, err
+		}
+
+		return BooleanType, nil
+	}
+
 	if tleft != AnyType && tright != AnyType {
 		return AnyType, fmt.Errorf("%w: %v can not be interpreted as type %v", ErrInvalidTypes, tleft, tright)
 	}
@@ -2810,17 +5119,23 @@ func (bexp *CmpBoolExp) substitute(params map[string]interface{}) (ValueExp, err
 	return bexp, nil
 }
 
-func (bexp *CmpBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	vl, err := bexp.left.reduce(catalog, row, implicitDB, implicitTable)
+func (bexp *CmpBoolExp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	vl, err := bexp.left.reduce(tx, row, implicitDB, implicitTable)
 	if err != nil {
 		return nil, err
 	}
 
-	vr, err := bexp.right.reduce(catalog, row, implicitDB, implicitTable)
+	vr, err := bexp.right.reduce(tx, row, implicitDB, implicitTable)
 	if err != nil {
 		return nil, err
 	}
 
+	// NULL being the lowest value, comparing the result of arithmetic over NULL operands
+	// would not reflect the operation
+	if isNullArithmetic(bexp.left, vl) || isNullArithmetic(bexp.right, vr) {
+		return nil, fmt.Errorf("%w (expecting numeric value)", ErrInvalidValue)
+	}
+
 	r, err := vl.Compare(vr)
 	if err != nil {
 		return nil, err
@@ -2883,9 +5198,26 @@ func (bexp *CmpBoolExp) selectorRanges(table *Table, asTable string, params map[
 		return err
 	}
 
+	if rval.Type() != column.colType && isNumericType(rval.Type()) {
+		if column.colType == IntegerType {
+			// e.g. a FLOAT value, not exactly representable as a bound of the range
+			return nil
+		}
+
+		rval, err = column.coerce(rval)
+		if err != nil {
+			return err
+		}
+	}
+
 	return updateRangeFor(column.id, rval, bexp.op, rangesByColID)
 }
 
+func isNullArithmetic(exp ValueExp, val TypedValue) bool {
+	_, isNumExp := exp.(*NumExp)
+	return isNumExp && val.IsNull()
+}
+
 func updateRangeFor(colID uint32, val TypedValue, cmp CmpOperator, rangesByColID map[uint32]*typedValueRange) error {
 	currRange, ranged := rangesByColID[colID]
 	var newRange *typedValueRange
@@ -3024,13 +5356,13 @@ func (bexp *BinBoolExp) substitute(params map[string]interface{}) (ValueExp, err
 	return bexp, nil
 }
 
-func (bexp *BinBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	vl, err := bexp.left.reduce(catalog, row, implicitDB, implicitTable)
+func (bexp *BinBoolExp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	vl, err := bexp.left.reduce(tx, row, implicitDB, implicitTable)
 	if err != nil {
 		return nil, err
 	}
 
-	vr, err := bexp.right.reduce(catalog, row, implicitDB, implicitTable)
+	vr, err := bexp.right.reduce(tx, row, implicitDB, implicitTable)
 	if err != nil {
 		return nil, err
 	}
@@ -3112,27 +5444,67 @@ func (bexp *BinBoolExp) selectorRanges(table *Table, asTable string, params map[
 }
 
 type ExistsBoolExp struct {
-	q *SelectStmt
+	q      *SelectStmt
+	params map[string]interface{}
 }
 
+// parameters only referenced within the subquery are not inferred, they're substituted once rows are read
 func (bexp *ExistsBoolExp) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
-	return AnyType, errors.New("not yet supported")
+	return BooleanType, nil
 }
 
 func (bexp *ExistsBoolExp) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
-	return errors.New("not yet supported")
+	if t != BooleanType {
+		return fmt.Errorf("error using the value of the EXISTS operator as %s: %w", t, ErrInvalidTypes)
+	}
+
+	return nil
 }
 
 func (bexp *ExistsBoolExp) substitute(params map[string]interface{}) (ValueExp, error) {
-	return bexp, nil
+	return &ExistsBoolExp{q: bexp.q, params: params}, nil
 }
 
-func (bexp *ExistsBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	return nil, errors.New("not yet supported")
+// reduce evaluates the subquery for the given row, columns of the outer query referenced
+// by the subquery (correlated references) are bound to the values of the row
+func (bexp *ExistsBoolExp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("error evaluating 'EXISTS' clause: %w", ErrIllegalArguments)
+	}
+
+	q := bexp.q
+
+	if row != nil {
+		q = q.correlatedWith(row, implicitDB)
+	}
+
+	_, err := q.execAt(tx, bexp.params)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := q.Resolve(tx, bexp.params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	_, err = r.Read()
+	if errors.Is(err, ErrNoMoreRows) {
+		return &Bool{val: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bool{val: true}, nil
 }
 
 func (bexp *ExistsBoolExp) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
-	return bexp
+	return &ExistsBoolExp{
+		q:      bexp.q.correlatedWith(row, implicitDB),
+		params: bexp.params,
+	}
 }
 
 func (bexp *ExistsBoolExp) isConstant() bool {
@@ -3144,29 +5516,100 @@ func (bexp *ExistsBoolExp) selectorRanges(table *Table, asTable string, params m
 }
 
 type InSubQueryExp struct {
-	val   ValueExp
-	notIn bool
-	q     *SelectStmt
+	val    ValueExp
+	notIn  bool
+	q      *SelectStmt
+	params map[string]interface{}
+
+	// values read from an uncorrelated subquery, they're read once and hashed
+	members *valueSet
 }
 
+// parameters only referenced within the subquery are not inferred, they're substituted once rows are read
 func (bexp *InSubQueryExp) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
-	return AnyType, fmt.Errorf("error inferring type in 'IN' clause: %w", ErrNoSupported)
+	_, err := bexp.val.inferType(cols, params, implicitDB, implicitTable)
+	if err != nil {
+		return AnyType, fmt.Errorf("error inferring type in 'IN' clause: %w", err)
+	}
+
+	return BooleanType, nil
 }
 
 func (bexp *InSubQueryExp) requiresType(t SQLValueType, cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) error {
-	return fmt.Errorf("error inferring type in 'IN' clause: %w", ErrNoSupported)
+	_, err := bexp.inferType(cols, params, implicitDB, implicitTable)
+	if err != nil {
+		return err
+	}
+
+	if t != BooleanType {
+		return fmt.Errorf("error inferring type in 'IN' clause: %w", ErrInvalidTypes)
+	}
+
+	return nil
 }
 
 func (bexp *InSubQueryExp) substitute(params map[string]interface{}) (ValueExp, error) {
-	return bexp, nil
+	val, err := bexp.val.substitute(params)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating 'IN' clause: %w", err)
+	}
+
+	return &InSubQueryExp{
+		val:    val,
+		notIn:  bexp.notIn,
+		q:      bexp.q,
+		params: params,
+	}, nil
 }
 
-func (bexp *InSubQueryExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	return nil, fmt.Errorf("error inferring type in 'IN' clause: %w", ErrNoSupported)
+// reduce checks the value is among the ones returned by the subquery. Values of correlated
+// subqueries are read for each row while the ones of uncorrelated subqueries are read just once
+func (bexp *InSubQueryExp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("error evaluating 'IN' clause: %w", ErrIllegalArguments)
+	}
+
+	rval, err := bexp.val.reduce(tx, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating 'IN' clause: %w", err)
+	}
+
+	members := bexp.members
+
+	if members == nil {
+		q := bexp.q
+
+		correlated := q.isCorrelated()
+
+		if correlated && row != nil {
+			q = q.correlatedWith(row, implicitDB)
+		}
+
+		members, err = q.readValueSet(tx, bexp.params)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating 'IN' clause: %w", err)
+		}
+
+		if !correlated {
+			bexp.members = members
+		}
+	}
+
+	found, err := members.contains(rval)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating 'IN' clause: %w", err)
+	}
+
+	return &Bool{val: found != bexp.notIn}, nil
 }
 
 func (bexp *InSubQueryExp) reduceSelectors(row *Row, implicitDB, implicitTable string) ValueExp {
-	return bexp
+	return &InSubQueryExp{
+		val:    bexp.val.reduceSelectors(row, implicitDB, implicitTable),
+		notIn:  bexp.notIn,
+		q:      bexp.q.correlatedWith(row, implicitDB),
+		params: bexp.params,
+	}
 }
 
 func (bexp *InSubQueryExp) isConstant() bool {
@@ -3177,11 +5620,13 @@ func (bexp *InSubQueryExp) selectorRanges(table *Table, asTable string, params m
 	return nil
 }
 
-// TODO: once InSubQueryExp is supported, this struct may become obsolete by creating a ListDataSource struct
 type InListExp struct {
 	val    ValueExp
 	notIn  bool
 	values []ValueExp
+
+	// values of the list hashed once they're all constant
+	members *valueSet
 }
 
 func (bexp *InListExp) inferType(cols map[string]ColDescriptor, params map[string]SQLValueType, implicitDB, implicitTable string) (SQLValueType, error) {
@@ -3213,6 +5658,8 @@ func (bexp *InListExp) requiresType(t SQLValueType, cols map[string]ColDescripto
 	return nil
 }
 
+// substitute hashes the values of the list when all of them are constant,
+// values of different types are kept unhashed so they're compared one by one
 func (bexp *InListExp) substitute(params map[string]interface{}) (ValueExp, error) {
 	val, err := bexp.val.substitute(params)
 	if err != nil {
@@ -3221,30 +5668,48 @@ func (bexp *InListExp) substitute(params map[string]interface{}) (ValueExp, erro
 
 	values := make([]ValueExp, len(bexp.values))
 
+	members := newValueSet()
+
 	for i, val := range bexp.values {
 		values[i], err = val.substitute(params)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating 'IN' clause: %w", err)
 		}
+
+		tval, isConstant := values[i].(TypedValue)
+
+		if members != nil && (!isConstant || members.add(tval) != nil) {
+			members = nil
+		}
 	}
 
 	return &InListExp{
-		val:    val,
-		notIn:  bexp.notIn,
-		values: values,
+		val:     val,
+		notIn:   bexp.notIn,
+		values:  values,
+		members: members,
 	}, nil
 }
 
-func (bexp *InListExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	rval, err := bexp.val.reduce(catalog, row, implicitDB, implicitTable)
+func (bexp *InListExp) reduce(tx *SQLTx, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	rval, err := bexp.val.reduce(tx, row, implicitDB, implicitTable)
 	if err != nil {
 		return nil, fmt.Errorf("error evaluating 'IN' clause: %w", err)
 	}
 
+	if bexp.members != nil {
+		found, err := bexp.members.contains(rval)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating 'IN' clause: %w", err)
+		}
+
+		return &Bool{val: found != bexp.notIn}, nil
+	}
+
 	var found bool
 
 	for _, v := range bexp.values {
-		rv, err := v.reduce(catalog, row, implicitDB, implicitTable)
+		rv, err := v.reduce(tx, row, implicitDB, implicitTable)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating 'IN' clause: %w", err)
 		}
@@ -3272,6 +5737,7 @@ func (bexp *InListExp) reduceSelectors(row *Row, implicitDB, implicitTable strin
 
 	return &InListExp{
 		val:    bexp.val.reduceSelectors(row, implicitDB, implicitTable),
+		notIn:  bexp.notIn,
 		values: values,
 	}
 }
@@ -3280,7 +5746,122 @@ func (bexp *InListExp) isConstant() bool {
 	return false
 }
 
+// selectorRanges bounds the range of the column by the smallest and biggest values in the list,
+// the index is then scanned from the first to the last of them
 func (bexp *InListExp) selectorRanges(table *Table, asTable string, params map[string]interface{}, rangesByColID map[uint32]*typedValueRange) error {
-	// TODO: may be determiined by smallest and bigggest value in the list
+	sel, isSel := bexp.val.(*ColSelector)
+	if !isSel || bexp.notIn || len(bexp.values) == 0 {
+		return nil
+	}
+
+	aggFn, db, t, col := sel.resolve(table.db.name, table.name)
+	if aggFn != "" || db != table.db.name || t != asTable {
+		return nil
+	}
+
+	// errors are left to be reported when the condition is evaluated
+	column, err := table.GetColumnByName(col)
+	if err != nil {
+		return nil
+	}
+
+	var listRange *typedValueRange
+
+	for _, v := range bexp.values {
+		if !v.isConstant() {
+			return nil
+		}
+
+		val, err := v.substitute(params)
+		if err != nil {
+			return nil
+		}
+
+		rval, err := val.reduce(nil, nil, table.db.name, table.name)
+		if err != nil || rval.IsNull() || rval.Type() != column.colType {
+			return nil
+		}
+
+		valRange := &typedValueRange{
+			lRange: &typedValueSemiRange{val: rval, inclusive: true},
+			hRange: &typedValueSemiRange{val: rval, inclusive: true},
+		}
+
+		if listRange == nil {
+			listRange = valRange
+			continue
+		}
+
+		err = listRange.extendWith(valRange)
+		if err != nil {
+			return nil
+		}
+	}
+
+	currRange, ranged := rangesByColID[column.id]
+	if !ranged {
+		rangesByColID[column.id] = listRange
+		return nil
+	}
+
+	return currRange.refineWith(listRange)
+}
+
+// valueSet holds values of a single type hashed, so membership is checked without comparing them one by one
+type valueSet struct {
+	t       SQLValueType
+	vals    map[interface{}]struct{}
+	hasNull bool
+}
+
+func newValueSet() *valueSet {
+	return &valueSet{
+		t:    AnyType,
+		vals: make(map[interface{}]struct{}),
+	}
+}
+
+func (s *valueSet) add(val TypedValue) error {
+	if val.IsNull() {
+		s.hasNull = true
+		return nil
+	}
+
+	if s.t != AnyType && s.t != val.Type() {
+		return ErrNotComparableValues
+	}
+
+	s.t = val.Type()
+	s.vals[hashableValue(val)] = struct{}{}
+
 	return nil
 }
+
+// contains follows the semantics of value comparisons, thus NULL is found if the set holds it
+func (s *valueSet) contains(val TypedValue) (bool, error) {
+	if val.IsNull() {
+		return s.hasNull, nil
+	}
+
+	if s.t != AnyType && s.t != val.Type() {
+		return false, ErrNotComparableValues
+	}
+
+	_, found := s.vals[hashableValue(val)]
+
+	return found, nil
+}
+
+// hashableValue returns a value usable as map key, being equal for values comparing as equal
+func hashableValue(val TypedValue) interface{} {
+	switch v := val.Value().(type) {
+	case []byte:
+		return string(v)
+	case *big.Rat:
+		return v.RatString()
+	case time.Time:
+		return v.UnixNano()
+	}
+
+	return val.Value()
+}
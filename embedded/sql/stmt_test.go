@@ -17,6 +17,7 @@ package sql
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -457,6 +458,51 @@ func TestRequiresTypeSysFnValueExp(t *testing.T) {
 			requiredType:  VarcharType,
 			expectedError: ErrIllegalArguments,
 		},
+		{
+			exp:           &SysFn{fn: "LOWER", params: []ValueExp{&ColSelector{col: "title"}}},
+			cols:          cols,
+			params:        params,
+			implicitDB:    "db1",
+			implicitTable: "mytable",
+			requiredType:  VarcharType,
+			expectedError: nil,
+		},
+		{
+			exp:           &SysFn{fn: "LENGTH", params: []ValueExp{&ColSelector{col: "title"}}},
+			cols:          cols,
+			params:        params,
+			implicitDB:    "db1",
+			implicitTable: "mytable",
+			requiredType:  IntegerType,
+			expectedError: nil,
+		},
+		{
+			exp:           &SysFn{fn: "UPPER", params: []ValueExp{&ColSelector{col: "id"}}},
+			cols:          cols,
+			params:        params,
+			implicitDB:    "db1",
+			implicitTable: "mytable",
+			requiredType:  VarcharType,
+			expectedError: ErrInvalidTypes,
+		},
+		{
+			exp:           &SysFn{fn: "SUBSTRING", params: []ValueExp{&ColSelector{col: "title"}}},
+			cols:          cols,
+			params:        params,
+			implicitDB:    "db1",
+			implicitTable: "mytable",
+			requiredType:  VarcharType,
+			expectedError: ErrIllegalArguments,
+		},
+		{
+			exp:           &SysFn{fn: "UNKNOWN"},
+			cols:          cols,
+			params:        params,
+			implicitDB:    "db1",
+			implicitTable: "mytable",
+			requiredType:  VarcharType,
+			expectedError: ErrIllegalArguments,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -576,52 +622,135 @@ func TestRequiresTypeBinValueExp(t *testing.T) {
 	}
 }
 
-func TestYetUnsupportedExistsBoolExp(t *testing.T) {
-	exp := &ExistsBoolExp{}
+func TestExistsBoolExpEdgeCases(t *testing.T) {
+	exp := &ExistsBoolExp{q: &SelectStmt{ds: &tableRef{table: "table1"}}}
 
-	_, err := exp.inferType(nil, nil, "", "")
-	require.Error(t, err)
+	it, err := exp.inferType(nil, nil, "", "")
+	require.NoError(t, err)
+	require.Equal(t, BooleanType, it)
 
 	err = exp.requiresType(BooleanType, nil, nil, "", "")
-	require.Error(t, err)
+	require.NoError(t, err)
+
+	err = exp.requiresType(IntegerType, nil, nil, "", "")
+	require.ErrorIs(t, err, ErrInvalidTypes)
 
-	rexp, err := exp.substitute(nil)
+	params := map[string]interface{}{"param1": 1}
+
+	rexp, err := exp.substitute(params)
 	require.NoError(t, err)
-	require.Equal(t, exp, rexp)
+	require.Equal(t, &ExistsBoolExp{q: exp.q, params: params}, rexp)
 
 	_, err = exp.reduce(nil, nil, "", "")
-	require.Error(t, err)
-
-	require.Equal(t, exp, exp.reduceSelectors(nil, "", ""))
+	require.ErrorIs(t, err, ErrIllegalArguments)
 
 	require.False(t, exp.isConstant())
 
 	require.Nil(t, exp.selectorRanges(nil, "", nil, nil))
 }
 
-func TestYetUnsupportedInSubQueryExp(t *testing.T) {
-	exp := &InSubQueryExp{}
+func TestInSubQueryExpEdgeCases(t *testing.T) {
+	exp := &InSubQueryExp{val: &Number{val: 1}, q: &SelectStmt{ds: &tableRef{table: "table1"}}}
 
-	_, err := exp.inferType(nil, nil, "", "")
-	require.ErrorIs(t, err, ErrNoSupported)
+	it, err := exp.inferType(nil, nil, "", "")
+	require.NoError(t, err)
+	require.Equal(t, BooleanType, it)
 
 	err = exp.requiresType(BooleanType, nil, nil, "", "")
-	require.ErrorIs(t, err, ErrNoSupported)
+	require.NoError(t, err)
+
+	err = exp.requiresType(IntegerType, nil, nil, "", "")
+	require.ErrorIs(t, err, ErrInvalidTypes)
+
+	_, err = (&InSubQueryExp{val: &Param{id: "param1"}, q: exp.q}).substitute(nil)
+	require.ErrorIs(t, err, ErrMissingParameter)
 
-	rexp, err := exp.substitute(nil)
+	params := map[string]interface{}{"param1": 1}
+
+	rexp, err := exp.substitute(params)
 	require.NoError(t, err)
-	require.Equal(t, exp, rexp)
+	require.Equal(t, &InSubQueryExp{val: exp.val, q: exp.q, params: params}, rexp)
 
 	_, err = exp.reduce(nil, nil, "", "")
-	require.ErrorIs(t, err, ErrNoSupported)
-
-	require.Equal(t, exp, exp.reduceSelectors(nil, "", ""))
+	require.ErrorIs(t, err, ErrIllegalArguments)
 
 	require.False(t, exp.isConstant())
 
 	require.Nil(t, exp.selectorRanges(nil, "", nil, nil))
 }
 
+func TestIsCorrelated(t *testing.T) {
+	testCases := []struct {
+		query      string
+		correlated bool
+	}{
+		{"SELECT id FROM table1", false},
+		{"SELECT id FROM table1 WHERE id > 1 AND title LIKE 'title'", false},
+		{"SELECT id FROM table1 WHERE title ~ table2.pattern", true},
+		{"SELECT id FROM table1 AS t WHERE t.id IN (1, 2)", false},
+		{"SELECT id FROM table1 WHERE table1.id = CAST(@param1 AS INTEGER)", false},
+		{"SELECT id FROM table1 INNER JOIN table2 ON table2.id = table1.id WHERE NOT table2.active", false},
+		{"SELECT id FROM table1 WHERE id = table2.id", true},
+		{"SELECT id FROM table1 INNER JOIN table2 ON table2.id = table3.id", true},
+		{"SELECT id FROM table1 WHERE id IN (1, table2.id)", true},
+		{"SELECT id FROM table1 WHERE EXISTS (SELECT id FROM table2)", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmts, err := Parse(strings.NewReader(tc.query))
+			require.NoError(t, err)
+			require.Len(t, stmts, 1)
+
+			require.Equal(t, tc.correlated, stmts[0].(*SelectStmt).isCorrelated())
+		})
+	}
+}
+
+func TestValueSet(t *testing.T) {
+	ts := time.Now()
+
+	s := newValueSet()
+
+	for _, v := range []TypedValue{&Timestamp{val: ts}, &NullValue{t: TimestampType}, &Timestamp{val: ts.Add(time.Second)}} {
+		err := s.add(v)
+		require.NoError(t, err)
+	}
+
+	err := s.add(&Number{val: 1})
+	require.ErrorIs(t, err, ErrNotComparableValues)
+
+	found, err := s.contains(&Timestamp{val: ts.Add(time.Second)})
+	require.NoError(t, err)
+	require.True(t, found)
+
+	found, err = s.contains(&Timestamp{val: ts.Add(time.Minute)})
+	require.NoError(t, err)
+	require.False(t, found)
+
+	found, err = s.contains(&NullValue{t: TimestampType})
+	require.NoError(t, err)
+	require.True(t, found)
+
+	_, err = s.contains(&Varchar{val: "title"})
+	require.ErrorIs(t, err, ErrNotComparableValues)
+
+	s = newValueSet()
+
+	for _, v := range []TypedValue{&Blob{val: []byte{1, 2}}, &Blob{val: []byte{3}}} {
+		err := s.add(v)
+		require.NoError(t, err)
+	}
+
+	found, err = s.contains(&Blob{val: []byte{1, 2}})
+	require.NoError(t, err)
+	require.True(t, found)
+
+	found, err = s.contains(&NullValue{t: BLOBType})
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
 func TestLikeBoolExpEdgeCases(t *testing.T) {
 	exp := &LikeBoolExp{}
 
@@ -656,6 +785,171 @@ func TestLikeBoolExpEdgeCases(t *testing.T) {
 
 }
 
+func TestLikeMatches(t *testing.T) {
+	testCases := []struct {
+		val     string
+		pattern string
+		matches bool
+	}{
+		{"", "", true},
+		{"", "%", true},
+		{"a", "", false},
+		{"title1", "title1", true},
+		{"title1", "title", false},
+		{"title1", "title%", true},
+		{"title1", "%1", true},
+		{"title1", "%itl%", true},
+		{"title1", "t_tle_", true},
+		{"title1", "t_tle", false},
+		{"title1", "%%t%%1%%", true},
+		{"aaab", "%ab", true},
+		{"abab", "%ab%ab", true},
+		{"abab", "%ab%b%ab", false},
+		{"100%", "100\\%", true},
+		{"1000", "100\\%", false},
+		{"a_b", "a\\_b", true},
+		{"axb", "a\\_b", false},
+		{"añb", "a_b", true},
+		{"Title1", "title%", false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.matches, likeMatches(tc.val, tc.pattern), "'%s' LIKE '%s'", tc.val, tc.pattern)
+	}
+}
+
+func TestLikePrefix(t *testing.T) {
+	require.Equal(t, "", likePrefix("%title"))
+	require.Equal(t, "t", likePrefix("t_tle"))
+	require.Equal(t, "title", likePrefix("title"))
+	require.Equal(t, "100%", likePrefix("100\\%%"))
+
+	upper, bounded := prefixUpperBound("title")
+	require.True(t, bounded)
+	require.Equal(t, "titlf", upper)
+
+	upper, bounded = prefixUpperBound("a\xff\xff")
+	require.True(t, bounded)
+	require.Equal(t, "b", upper)
+
+	_, bounded = prefixUpperBound("\xff")
+	require.False(t, bounded)
+}
+
+func TestRegexpBoolExpEdgeCases(t *testing.T) {
+	exp := &RegexpBoolExp{}
+
+	_, err := exp.inferType(nil, nil, "", "")
+	require.ErrorIs(t, err, ErrInvalidCondition)
+
+	err = exp.requiresType(BooleanType, nil, nil, "", "")
+	require.ErrorIs(t, err, ErrInvalidCondition)
+
+	_, err = exp.substitute(nil)
+	require.ErrorIs(t, err, ErrInvalidCondition)
+
+	_, err = exp.reduce(nil, nil, "", "")
+	require.ErrorIs(t, err, ErrInvalidCondition)
+
+	require.Equal(t, exp, exp.reduceSelectors(nil, "", ""))
+	require.False(t, exp.isConstant())
+	require.Nil(t, exp.selectorRanges(nil, "", nil, nil))
+
+	t.Run("regexp expression with invalid types", func(t *testing.T) {
+		exp := &RegexpBoolExp{val: &ColSelector{col: "col1"}, pattern: &Number{}}
+
+		_, err = exp.inferType(nil, nil, "", "")
+		require.ErrorIs(t, err, ErrInvalidTypes)
+
+		err = exp.requiresType(BooleanType, nil, nil, "", "")
+		require.ErrorIs(t, err, ErrInvalidTypes)
+
+		err = exp.requiresType(VarcharType, nil, nil, "", "")
+		require.ErrorIs(t, err, ErrInvalidTypes)
+
+		_, err = exp.reduce(nil, &Row{Values: map[string]TypedValue{"(db1.table1.col1)": &NullValue{}}}, "db1", "table1")
+		require.ErrorIs(t, err, ErrInvalidTypes)
+	})
+
+	t.Run("regexp expression with invalid pattern", func(t *testing.T) {
+		exp := &RegexpBoolExp{val: &Varchar{val: "title"}, pattern: &Varchar{val: "("}}
+
+		_, err = exp.reduce(nil, nil, "db1", "table1")
+		require.Error(t, err)
+	})
+
+	t.Run("null values should not match", func(t *testing.T) {
+		exp := &RegexpBoolExp{val: &NullValue{t: VarcharType}, notMatch: true, pattern: &Varchar{val: "t"}}
+
+		v, err := exp.reduce(nil, nil, "db1", "table1")
+		require.NoError(t, err)
+		require.Equal(t, &Bool{val: false}, v)
+	})
+}
+
+func TestCaseWhenExpEdgeCases(t *testing.T) {
+	exp := &CaseWhenExp{
+		whens: []*whenThen{
+			{cond: &NullValue{t: BooleanType}, then: &Number{val: 1}},
+			{cond: &Param{id: "cond"}, then: &Param{id: "then"}},
+		},
+		elseExp: &Number{val: 3},
+	}
+
+	params := make(map[string]SQLValueType)
+
+	typ, err := exp.inferType(nil, params, "", "")
+	require.NoError(t, err)
+	require.Equal(t, IntegerType, typ)
+	require.Equal(t, map[string]SQLValueType{"cond": BooleanType, "then": IntegerType}, params)
+
+	err = exp.requiresType(VarcharType, nil, make(map[string]SQLValueType), "", "")
+	require.ErrorIs(t, err, ErrInvalidTypes)
+
+	_, err = exp.substitute(nil)
+	require.ErrorIs(t, err, ErrMissingParameter)
+
+	rexp, err := exp.substitute(map[string]interface{}{"cond": true, "then": 2})
+	require.NoError(t, err)
+	require.True(t, rexp.isConstant())
+	require.Nil(t, exp.selectorRanges(nil, "", nil, nil))
+
+	v, err := rexp.reduce(nil, nil, "", "")
+	require.NoError(t, err)
+	require.Equal(t, &Number{val: 2}, v)
+
+	rexp, err = exp.substitute(map[string]interface{}{"cond": false, "then": 2})
+	require.NoError(t, err)
+
+	v, err = rexp.reduce(nil, nil, "", "")
+	require.NoError(t, err)
+	require.Equal(t, &Number{val: 3}, v)
+
+	t.Run("case expression with non-boolean conditions", func(t *testing.T) {
+		exp := &CaseWhenExp{whens: []*whenThen{{cond: &Number{val: 1}, then: &Number{val: 1}}}}
+
+		_, err := exp.inferType(nil, nil, "", "")
+		require.ErrorIs(t, err, ErrInvalidTypes)
+
+		err = exp.requiresType(IntegerType, nil, nil, "", "")
+		require.ErrorIs(t, err, ErrInvalidTypes)
+
+		_, err = exp.reduce(nil, nil, "", "")
+		require.ErrorIs(t, err, ErrInvalidCondition)
+	})
+
+	t.Run("case expression without else should be null when no condition holds", func(t *testing.T) {
+		exp := &CaseWhenExp{whens: []*whenThen{{cond: &ColSelector{col: "col1"}, then: &Number{val: 1}}}}
+
+		rexp := exp.reduceSelectors(&Row{Values: map[string]TypedValue{"(db1.table1.col1)": &Bool{val: false}}}, "db1", "table1")
+		require.True(t, rexp.isConstant())
+
+		v, err := rexp.reduce(nil, nil, "db1", "table1")
+		require.NoError(t, err)
+		require.True(t, v.IsNull())
+	})
+}
+
 func TestAliasing(t *testing.T) {
 	stmt := &SelectStmt{ds: &tableRef{table: "table1"}}
 	require.Equal(t, "table1", stmt.Alias())
@@ -693,6 +987,7 @@ func TestIsConstant(t *testing.T) {
 
 	require.True(t, (&NotBoolExp{exp: &Bool{}}).isConstant())
 	require.False(t, (&LikeBoolExp{}).isConstant())
+	require.False(t, (&RegexpBoolExp{}).isConstant())
 
 	require.True(t, (&CmpBoolExp{
 		op:    LE,
@@ -772,3 +1067,131 @@ func TestTimestmapType(t *testing.T) {
 	err = ts.selectorRanges(&Table{}, "", map[string]interface{}{}, map[uint32]*typedValueRange{})
 	require.NoError(t, err)
 }
+
+func TestCastConversions(t *testing.T) {
+	ts := time.Date(2021, 12, 3, 16, 14, 21, 123400000, time.UTC)
+
+	for _, d := range []struct {
+		val      TypedValue
+		t        SQLValueType
+		expected TypedValue
+	}{
+		{&Number{val: 10}, IntegerType, &Number{val: 10}},
+		{&NullValue{t: AnyType}, VarcharType, &NullValue{t: VarcharType}},
+
+		{&Varchar{val: "-42"}, IntegerType, &Number{val: -42}},
+		{&Bool{val: true}, IntegerType, &Number{val: 1}},
+		{&Bool{val: false}, IntegerType, &Number{val: 0}},
+		{&Timestamp{val: ts}, IntegerType, &Number{val: ts.Unix()}},
+		{&NullValue{t: BooleanType}, IntegerType, &NullValue{t: IntegerType}},
+
+		{&Number{val: -42}, VarcharType, &Varchar{val: "-42"}},
+		{&Float{val: 0.1}, VarcharType, &Varchar{val: "0.1"}},
+		{&Bool{val: true}, VarcharType, &Varchar{val: "true"}},
+		{&Timestamp{val: ts}, VarcharType, &Varchar{val: "2021-12-03 16:14:21.1234"}},
+		{&Blob{val: []byte("title")}, VarcharType, &Varchar{val: "title"}},
+		{&NullValue{t: IntegerType}, VarcharType, &NullValue{t: VarcharType}},
+
+		{&Number{val: 0}, BooleanType, &Bool{val: false}},
+		{&Number{val: -1}, BooleanType, &Bool{val: true}},
+		{&Varchar{val: "TRUE"}, BooleanType, &Bool{val: true}},
+		{&Varchar{val: "0"}, BooleanType, &Bool{val: false}},
+		{&NullValue{t: VarcharType}, BooleanType, &NullValue{t: BooleanType}},
+
+		{&Varchar{val: "title"}, BLOBType, &Blob{val: []byte("title")}},
+		{&NullValue{t: VarcharType}, BLOBType, &NullValue{t: BLOBType}},
+
+		{&Varchar{val: "2021-12-03 16:14:21.1234"}, TimestampType, &Timestamp{val: ts}},
+	} {
+		t.Run(fmt.Sprintf("cast %v value %v as %v", d.val.Type(), d.val.Value(), d.t), func(t *testing.T) {
+			cast := &Cast{val: d.val, t: d.t}
+
+			typ, err := cast.inferType(nil, nil, "", "")
+			require.NoError(t, err)
+			require.Equal(t, d.t, typ)
+
+			v, err := cast.reduce(nil, nil, "", "")
+			require.NoError(t, err)
+			require.Equal(t, d.expected, v)
+		})
+	}
+
+	t.Run("invalid values should not be cast", func(t *testing.T) {
+		for _, d := range []struct {
+			val TypedValue
+			t   SQLValueType
+		}{
+			{&Varchar{val: "1.5"}, IntegerType},
+			{&Varchar{val: strings.Repeat("9", 100)}, IntegerType},
+			{&Varchar{val: "yes"}, BooleanType},
+			{&Blob{val: []byte{0xff, 0xfe}}, VarcharType},
+		} {
+			_, err := (&Cast{val: d.val, t: d.t}).reduce(nil, nil, "", "")
+			require.ErrorIs(t, err, ErrIllegalArguments)
+			require.Less(t, len(err.Error()), 100)
+		}
+	})
+
+	t.Run("unsupported conversions should fail", func(t *testing.T) {
+		for _, d := range []struct {
+			val TypedValue
+			t   SQLValueType
+		}{
+			{&Blob{val: []byte{1}}, IntegerType},
+			{&Timestamp{val: ts}, BooleanType},
+			{&Number{val: 1}, BLOBType},
+			{&Bool{val: true}, TimestampType},
+		} {
+			_, err := (&Cast{val: d.val, t: d.t}).inferType(nil, nil, "", "")
+			require.ErrorIs(t, err, ErrUnsupportedCast)
+
+			_, err = (&Cast{val: d.val, t: d.t}).reduce(nil, nil, "", "")
+			require.ErrorIs(t, err, ErrUnsupportedCast)
+		}
+	})
+}
+
+func TestInterval(t *testing.T) {
+	ts := time.Date(2022, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	for _, c := range []struct {
+		interval string
+		expected time.Time
+	}{
+		{"1 day", time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC)},
+		{"2 HOURS 30 minutes", time.Date(2022, 1, 31, 12, 30, 0, 0, time.UTC)},
+		{"-1 week", time.Date(2022, 1, 24, 10, 0, 0, 0, time.UTC)},
+		{"1 year 1 month", time.Date(2023, 3, 3, 10, 0, 0, 0, time.UTC)},
+		{"1500 milliseconds 10 microseconds", ts.Add(1500*time.Millisecond + 10*time.Microsecond)},
+	} {
+		interval, err := parseInterval(c.interval)
+		require.NoError(t, err, c.interval)
+		require.Equal(t, c.expected, interval.addTo(ts), c.interval)
+	}
+
+	for _, invalid := range []string{"", "day", "1", "one day", "1 fortnight", "1 day 2"} {
+		_, err := parseInterval(invalid)
+		require.ErrorIs(t, err, ErrIllegalArguments, invalid)
+	}
+
+	interval, err := parseInterval("1 month 2 days 3 hours")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2021, 12, 29, 7, 0, 0, 0, time.UTC), interval.negate().addTo(ts))
+	require.Equal(t, IntervalType, interval.Type())
+	require.Equal(t, "1 months 2 days 3h0m0s", interval.Value())
+	require.Equal(t, "0s", (&Interval{}).String())
+	require.True(t, interval.isConstant())
+
+	cmp, err := interval.Compare(&Interval{months: 1, days: 2, dur: 3 * time.Hour})
+	require.NoError(t, err)
+	require.Zero(t, cmp)
+
+	_, err = interval.Compare(&Interval{days: 32})
+	require.ErrorIs(t, err, ErrNotComparableValues)
+
+	_, err = interval.Compare(&Number{val: 1})
+	require.ErrorIs(t, err, ErrNotComparableValues)
+
+	err = interval.requiresType(TimestampType, nil, nil, "db1", "table1")
+	require.ErrorIs(t, err, ErrInvalidTypes)
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/codenotary/immudb/embedded/appendable"
+	"github.com/codenotary/immudb/embedded/tbtree"
+)
+
+var ErrUnknownCollation = errors.New("unknown collation")
+var ErrCollationMismatch = errors.New("collation does not match the one the store was created with")
+
+const (
+	BytewiseCollationName        = "bytewise"
+	CaseInsensitiveCollationName = "case-insensitive"
+)
+
+// Collation defines the order in which keys are kept in the index.
+// Keys are ordered by their sort keys and, when they are equal, bytewise.
+// Sort keys must preserve prefixes, i.e. the sort key of a prefix of a key
+// must be a prefix of the sort key of the key, and must not be longer than the key.
+type Collation interface {
+	Name() string
+	SortKey(key []byte) []byte
+}
+
+type bytewiseCollation struct{}
+
+func (c bytewiseCollation) Name() string {
+	return BytewiseCollationName
+}
+
+func (c bytewiseCollation) SortKey(key []byte) []byte {
+	return key
+}
+
+type caseInsensitiveCollation struct{}
+
+func (c caseInsensitiveCollation) Name() string {
+	return CaseInsensitiveCollationName
+}
+
+// SortKey lowers the utf-8 encoded runes of the key, other bytes are kept as they are
+func (c caseInsensitiveCollation) SortKey(key []byte) []byte {
+	sk := make([]byte, 0, len(key))
+
+	var rb [utf8.UTFMax]byte
+
+	for i := 0; i < len(key); {
+		r, size := utf8.DecodeRune(key[i:])
+
+		lr := unicode.ToLower(r)
+
+		if r == utf8.RuneError || utf8.RuneLen(lr) > size {
+			sk = append(sk, key[i:i+size]...)
+		} else {
+			n := utf8.EncodeRune(rb[:], lr)
+			sk = append(sk, rb[:n]...)
+		}
+
+		i += size
+	}
+
+	return sk
+}
+
+var (
+	BytewiseCollation        Collation = bytewiseCollation{}
+	CaseInsensitiveCollation Collation = caseInsensitiveCollation{}
+)
+
+var collations = map[string]Collation{
+	BytewiseCollationName:        BytewiseCollation,
+	CaseInsensitiveCollationName: CaseInsensitiveCollation,
+}
+
+// CollationByName returns one of the built-in collations
+func CollationByName(name string) (Collation, error) {
+	c, ok := collations[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownCollation, name)
+	}
+
+	return c, nil
+}
+
+// keyCollation applies a collation to the keys starting with a given prefix.
+// Collated keys are indexed as prefix + escaped sort key + 0x00 0x00 + key suffix,
+// where 0x00 is escaped as 0x00 0xFF, thus shorter sort keys go first
+// and keys having the same sort key are ordered bytewise.
+type keyCollation struct {
+	prefix    []byte
+	collation Collation
+}
+
+// keyCollationFrom validates the collation in the options matches the one the store was created with
+func keyCollationFrom(metadata *appendable.Metadata, opts *Options) (*keyCollation, error) {
+	storedName := BytewiseCollationName
+
+	name, ok := metadata.Get(metaCollation)
+	if ok {
+		storedName = string(name)
+	}
+
+	storedPrefix, _ := metadata.Get(metaCollationPrefix)
+
+	optsName := BytewiseCollationName
+	if opts.Collation != nil {
+		optsName = opts.Collation.Name()
+	}
+
+	if storedName != optsName {
+		return nil, fmt.Errorf("%w: '%s' was expected but '%s' was provided", ErrCollationMismatch, storedName, optsName)
+	}
+
+	if storedName == BytewiseCollationName {
+		return nil, nil
+	}
+
+	if !bytes.Equal(storedPrefix, opts.CollationPrefix) {
+		return nil, fmt.Errorf("%w: the collated key prefix differs", ErrCollationMismatch)
+	}
+
+	return &keyCollation{prefix: opts.CollationPrefix, collation: opts.Collation}, nil
+}
+
+// collatedKeyLen is the max length of an indexed key when a collation is used
+func collatedKeyLen(maxKeyLen int) int {
+	return 3*maxKeyLen + 2
+}
+
+func (c *keyCollation) collates(key []byte) bool {
+	return c != nil && bytes.HasPrefix(key, c.prefix)
+}
+
+func (c *keyCollation) sortKey(suffix []byte) []byte {
+	sk := c.collation.SortKey(suffix)
+
+	if len(sk) > len(suffix) {
+		// collations are not meant to produce longer sort keys
+		sk = sk[:len(suffix)]
+	}
+
+	escaped := make([]byte, 0, len(c.prefix)+2*len(sk)+2+len(suffix))
+	escaped = append(escaped, c.prefix...)
+
+	for _, b := range sk {
+		if b == 0x00 {
+			escaped = append(escaped, 0x00, 0xFF)
+		} else {
+			escaped = append(escaped, b)
+		}
+	}
+
+	return escaped
+}
+
+// indexedKey maps a key to the one kept in the index
+func (c *keyCollation) indexedKey(key []byte) []byte {
+	if len(key) == 0 || !c.collates(key) {
+		return key
+	}
+
+	suffix := key[len(c.prefix):]
+
+	ikey := c.sortKey(suffix)
+	ikey = append(ikey, 0x00, 0x00)
+
+	return append(ikey, suffix...)
+}
+
+// key maps a key kept in the index back to the original one
+func (c *keyCollation) key(ikey []byte) ([]byte, error) {
+	if !c.collates(ikey) {
+		return ikey, nil
+	}
+
+	for i := len(c.prefix); i+1 < len(ikey); i++ {
+		if ikey[i] != 0x00 {
+			continue
+		}
+
+		if ikey[i+1] == 0x00 {
+			key := make([]byte, 0, len(c.prefix)+len(ikey)-i-2)
+			key = append(key, c.prefix...)
+			return append(key, ikey[i+2:]...), nil
+		}
+
+		i++
+	}
+
+	return nil, ErrCorruptedIndex
+}
+
+// indexedPrefix maps a key prefix to the prefix of the keys kept in the index.
+// As collations may map several prefixes to the same one, the returned bool tells
+// whether matched keys need to be checked against the original prefix.
+func (c *keyCollation) indexedPrefix(prefix []byte) ([]byte, bool) {
+	if !c.collates(prefix) || len(prefix) == len(c.prefix) {
+		return prefix, false
+	}
+
+	return c.sortKey(prefix[len(c.prefix):]), true
+}
+
+// existKeyWith is the collation-aware version of tbtree.Snapshot.ExistKeyWith
+func (c *keyCollation) existKeyWith(snap *tbtree.Snapshot, prefix []byte, neq []byte) (bool, error) {
+	iprefix, filtered := c.indexedPrefix(prefix)
+	if !filtered {
+		return snap.ExistKeyWith(iprefix, c.indexedKey(neq))
+	}
+
+	r, err := snap.NewReader(&tbtree.ReaderSpec{Prefix: iprefix})
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	for {
+		ikey, _, _, _, err := r.Read()
+		if err == tbtree.ErrNoMoreEntries {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		key, err := c.key(ikey)
+		if err != nil {
+			return false, err
+		}
+
+		if bytes.HasPrefix(key, prefix) && !bytes.Equal(key, neq) {
+			return true, nil
+		}
+	}
+}
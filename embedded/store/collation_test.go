@@ -0,0 +1,206 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollationByName(t *testing.T) {
+	c, err := CollationByName(BytewiseCollationName)
+	require.NoError(t, err)
+	require.Equal(t, BytewiseCollation, c)
+
+	c, err = CollationByName(CaseInsensitiveCollationName)
+	require.NoError(t, err)
+	require.Equal(t, CaseInsensitiveCollation, c)
+
+	_, err = CollationByName("unknown")
+	require.ErrorIs(t, err, ErrUnknownCollation)
+}
+
+func TestCaseInsensitiveSortKey(t *testing.T) {
+	require.Equal(t, []byte("abc"), CaseInsensitiveCollation.SortKey([]byte("AbC")))
+	require.Equal(t, []byte("ñandú"), CaseInsensitiveCollation.SortKey([]byte("ÑANDÚ")))
+	require.Equal(t, []byte{'a', 0xff, 0x00}, CaseInsensitiveCollation.SortKey([]byte{'A', 0xff, 0x00}))
+}
+
+func TestKeyCollation(t *testing.T) {
+	c := &keyCollation{prefix: []byte("k:"), collation: CaseInsensitiveCollation}
+
+	for _, key := range [][]byte{
+		[]byte("k:Key"),
+		[]byte("k:"),
+		{'k', ':', 0x00, 'A', 0x00},
+		[]byte("other"),
+	} {
+		ikey := c.indexedKey(key)
+
+		rkey, err := c.key(ikey)
+		require.NoError(t, err)
+		require.Equal(t, key, rkey)
+	}
+
+	require.Equal(t, []byte("other"), c.indexedKey([]byte("other")))
+	require.Empty(t, c.indexedKey(nil))
+
+	_, err := c.key([]byte("k:unterminated"))
+	require.ErrorIs(t, err, ErrCorruptedIndex)
+
+	prefix, filtered := c.indexedPrefix([]byte("k:Ke"))
+	require.True(t, filtered)
+	require.Equal(t, []byte("k:ke"), prefix)
+
+	_, filtered = c.indexedPrefix([]byte("k:"))
+	require.False(t, filtered)
+
+	var bytewise *keyCollation
+	require.Equal(t, []byte("k:Key"), bytewise.indexedKey([]byte("k:Key")))
+}
+
+func TestImmudbStoreWithCollation(t *testing.T) {
+	dir := t.TempDir()
+
+	opts := DefaultOptions().
+		WithSynced(false).
+		WithCollation([]byte("k:"), CaseInsensitiveCollation)
+
+	immuStore, err := Open(dir, opts)
+	require.NoError(t, err)
+
+	for _, key := range []string{"k:b", "k:C", "k:a", "k:B", "Z", "k:ab"} {
+		tx, err := immuStore.NewWriteOnlyTx()
+		require.NoError(t, err)
+
+		err = tx.Set([]byte(key), nil, []byte(key))
+		require.NoError(t, err)
+
+		_, err = tx.Commit()
+		require.NoError(t, err)
+	}
+
+	readKeys := func(spec *KeyReaderSpec) []string {
+		snap, err := immuStore.Snapshot()
+		require.NoError(t, err)
+		defer snap.Close()
+
+		reader, err := snap.NewKeyReader(spec)
+		require.NoError(t, err)
+		defer reader.Close()
+
+		var keys []string
+
+		for {
+			key, valRef, err := reader.Read()
+			if err == ErrNoMoreEntries {
+				break
+			}
+			require.NoError(t, err)
+
+			val, err := valRef.Resolve()
+			require.NoError(t, err)
+			require.Equal(t, key, val)
+
+			keys = append(keys, string(key))
+		}
+
+		return keys
+	}
+
+	t.Run("keys should be ordered by the collation", func(t *testing.T) {
+		require.Equal(t, []string{"Z", "k:a", "k:ab", "k:B", "k:b", "k:C"}, readKeys(&KeyReaderSpec{}))
+		require.Equal(t, []string{"k:a", "k:ab", "k:B", "k:b", "k:C"}, readKeys(&KeyReaderSpec{Prefix: []byte("k:")}))
+		require.Equal(t, []string{"k:C", "k:b", "k:B", "k:ab", "k:a"}, readKeys(&KeyReaderSpec{Prefix: []byte("k:"), DescOrder: true}))
+	})
+
+	t.Run("prefixes should match the original keys", func(t *testing.T) {
+		require.Equal(t, []string{"k:B"}, readKeys(&KeyReaderSpec{Prefix: []byte("k:B")}))
+		require.Equal(t, []string{"k:a", "k:ab"}, readKeys(&KeyReaderSpec{Prefix: []byte("k:a")}))
+	})
+
+	t.Run("seek and end keys should follow the collation", func(t *testing.T) {
+		require.Equal(t, []string{"k:B", "k:b"}, readKeys(&KeyReaderSpec{
+			Prefix:        []byte("k:"),
+			SeekKey:       []byte("k:B"),
+			InclusiveSeek: true,
+			EndKey:        []byte("k:b"),
+			InclusiveEnd:  true,
+		}))
+	})
+
+	t.Run("keys should be found as they were written", func(t *testing.T) {
+		valRef, err := immuStore.Get([]byte("k:B"))
+		require.NoError(t, err)
+
+		val, err := valRef.Resolve()
+		require.NoError(t, err)
+		require.Equal(t, []byte("k:B"), val)
+
+		_, err = immuStore.Get([]byte("k:c"))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+
+		txs, err := immuStore.History([]byte("k:C"), 0, false, 10)
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+
+		exists, err := immuStore.ExistKeyWith([]byte("k:a"), []byte("k:a"))
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		exists, err = immuStore.ExistKeyWith([]byte("k:C"), []byte("k:C"))
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		exists, err = immuStore.ExistKeyWith([]byte("k:c"), nil)
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+
+	t.Run("keys written in a tx should be read within it", func(t *testing.T) {
+		tx, err := immuStore.NewTx()
+		require.NoError(t, err)
+		defer tx.Cancel()
+
+		err = tx.Set([]byte("k:D"), nil, []byte("k:D"))
+		require.NoError(t, err)
+
+		valRef, err := tx.Get([]byte("k:D"))
+		require.NoError(t, err)
+
+		val, err := valRef.Resolve()
+		require.NoError(t, err)
+		require.Equal(t, []byte("k:D"), val)
+	})
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	t.Run("the store should not be opened with another collation", func(t *testing.T) {
+		_, err := Open(dir, DefaultOptions().WithSynced(false))
+		require.ErrorIs(t, err, ErrCollationMismatch)
+
+		_, err = Open(dir, DefaultOptions().WithSynced(false).WithCollation([]byte("x:"), CaseInsensitiveCollation))
+		require.ErrorIs(t, err, ErrCollationMismatch)
+	})
+
+	immuStore, err = Open(dir, opts)
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	require.Equal(t, []string{"k:a", "k:ab", "k:B", "k:b", "k:C"}, readKeys(&KeyReaderSpec{Prefix: []byte("k:")}))
+}
@@ -26,6 +26,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -38,7 +39,7 @@ import (
 	"github.com/codenotary/immudb/embedded/tbtree"
 	"github.com/codenotary/immudb/embedded/watchers"
 
-	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/embedded/logger"
 )
 
 var ErrIllegalArguments = errors.New("illegal arguments")
@@ -96,6 +97,9 @@ const (
 	metaMaxKeyLen    = "MAX_KEY_LEN"
 	metaMaxValueLen  = "MAX_VALUE_LEN"
 	metaFileSize     = "FILE_SIZE"
+
+	metaCollation       = "COLLATION"
+	metaCollationPrefix = "COLLATION_PREFIX"
 )
 
 const indexDirname = "index"
@@ -148,7 +152,10 @@ type ImmuStore struct {
 
 	wHub *watchers.WatchersHub
 
-	indexer *indexer
+	indexer   *indexer
+	collation *keyCollation // nil when keys are indexed bytewise
+
+	repairReport *RepairReport
 
 	closed bool
 	blDone chan (struct{})
@@ -161,6 +168,8 @@ type ImmuStore struct {
 type refVLog struct {
 	vLog        appendable.Appendable
 	unlockedRef *list.Element // unlockedRef == nil <-> vLog is locked
+
+	discardedUpto int64 // values before the offset were discarded by truncation, accessed atomically
 }
 
 func Open(path string, opts *Options) (*ImmuStore, error) {
@@ -182,6 +191,14 @@ func Open(path string, opts *Options) (*ImmuStore, error) {
 		return nil, ErrorPathIsNotADirectory
 	}
 
+	// appendables provided by a factory (e.g. remote storage) are not upgraded in place
+	if opts.appFactory == nil && !opts.ReadOnly {
+		err = migrate(path, opts.log)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	metadata := appendable.NewMetadata(nil)
 	metadata.PutInt(metaVersion, Version)
 	metadata.PutInt(metaMaxTxEntries, opts.MaxTxEntries)
@@ -189,6 +206,11 @@ func Open(path string, opts *Options) (*ImmuStore, error) {
 	metadata.PutInt(metaMaxValueLen, opts.MaxValueLen)
 	metadata.PutInt(metaFileSize, opts.FileSize)
 
+	if opts.Collation != nil && opts.Collation.Name() != BytewiseCollationName {
+		metadata.Put(metaCollation, []byte(opts.Collation.Name()))
+		metadata.Put(metaCollationPrefix, opts.CollationPrefix)
+	}
+
 	appendableOpts := multiapp.DefaultOptions().
 		WithReadOnly(opts.ReadOnly).
 		WithSynced(opts.Synced).
@@ -266,11 +288,18 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 
 	}
 
+	collation, err := keyCollationFrom(metadata, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	cLogSize, err := cLog.Size()
 	if err != nil {
 		return nil, fmt.Errorf("corrupted commit log: could not get size: %w", err)
 	}
 
+	repairReport := &RepairReport{}
+
 	rem := cLogSize % cLogEntrySize
 	if rem > 0 {
 		cLogSize -= rem
@@ -278,6 +307,17 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 		if err != nil {
 			return nil, fmt.Errorf("corrupted commit log: could not set offset: %w", err)
 		}
+
+		repairReport.DiscardedCLogBytes = rem
+	}
+
+	repair := !opts.ReadOnly && opts.MaxRepairedTxs > 0
+
+	if repair && cLogSize > 0 {
+		cLogSize, err = repairTail(vLogs, txLog, cLog, cLogSize, newTx(maxTxEntries, maxKeyLen), opts.MaxRepairedTxs, repairReport)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var committedTxLogSize int64
@@ -307,6 +347,22 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 		}
 	}
 
+	if repair {
+		txLogFileSize, err := txLog.Size()
+		if err != nil {
+			return nil, fmt.Errorf("corrupted transaction log: could not get size: %w", err)
+		}
+
+		if txLogFileSize > committedTxLogSize {
+			err = txLog.SetOffset(committedTxLogSize)
+			if err != nil {
+				return nil, fmt.Errorf("corrupted transaction log: could not set offset: %w", err)
+			}
+
+			repairReport.DiscardedTxLogBytes = txLogFileSize - committedTxLogSize
+		}
+	}
+
 	maxTxSize := maxTxSize(maxTxEntries, maxKeyLen, maxTxMetadataLen, maxKVMetadataLen)
 
 	txs := list.New()
@@ -338,6 +394,10 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 	for i, vLog := range vLogs {
 		e := vLogUnlockedList.PushBack(byte(i))
 		vLogsMap[byte(i)] = &refVLog{vLog: vLog, unlockedRef: e}
+
+		if dvLog, ok := vLog.(discardableAppendable); ok {
+			vLogsMap[byte(i)].discardedUpto = dvLog.DiscardedUpto()
+		}
 	}
 
 	ahtPath := filepath.Join(path, ahtDirname)
@@ -402,6 +462,8 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 
 		timeFunc: opts.TimeFunc,
 
+		collation: collation,
+
 		aht:      aht,
 		blBuffer: blBuffer,
 
@@ -422,12 +484,14 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 	indexOpts := tbtree.DefaultOptions().
 		WithReadOnly(opts.ReadOnly).
 		WithFileMode(opts.FileMode).
-		WithLog(opts.log).
+		WithLogger(opts.log).
 		WithFileSize(fileSize).
 		WithSynced(opts.IndexOpts.Synced). // index is built from derived data and can be re-generated
 		WithCacheSize(opts.IndexOpts.CacheSize).
+		WithBufferPool(opts.IndexOpts.BufferPool).
 		WithFlushThld(opts.IndexOpts.FlushThld).
 		WithSyncThld(opts.IndexOpts.SyncThld).
+		WithWAL(opts.IndexOpts.WAL).
 		WithMaxActiveSnapshots(opts.IndexOpts.MaxActiveSnapshots).
 		WithMaxNodeSize(opts.IndexOpts.MaxNodeSize).
 		WithNodesLogMaxOpenedFiles(opts.IndexOpts.NodesLogMaxOpenedFiles).
@@ -437,6 +501,10 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 		WithCompactionThld(opts.IndexOpts.CompactionThld).
 		WithDelayDuringCompaction(opts.IndexOpts.DelayDuringCompaction)
 
+	if collation != nil {
+		indexOpts.WithMaxKeyLen(collatedKeyLen(maxKeyLen))
+	}
+
 	if opts.appFactory != nil {
 		indexOpts.WithAppFactory(func(rootPath, subPath string, appOpts *multiapp.Options) (appendable.Appendable, error) {
 			return opts.appFactory(store.path, filepath.Join(indexDirname, subPath), appOpts)
@@ -445,11 +513,51 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 
 	indexPath := filepath.Join(store.path, indexDirname)
 
-	store.indexer, err = newIndexer(indexPath, store, indexOpts, opts.MaxWaitees)
+	store.indexer, err = newIndexer(indexPath, store, indexOpts, opts.MaxWaitees, opts.IndexOpts.CheckpointThld)
 	if err != nil {
 		return nil, fmt.Errorf("could not open indexer: %w", err)
 	}
 
+	if repairReport.repaired() && opts.appFactory == nil && store.indexer.Ts() > store.committedTxID {
+		// the index is built from derived data, thus it can be re-generated without the discarded txs
+		err = store.indexer.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not close indexer: %w", err)
+		}
+
+		err = os.RemoveAll(indexPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not discard index: %w", err)
+		}
+
+		store.indexer, err = newIndexer(indexPath, store, indexOpts, opts.MaxWaitees, opts.IndexOpts.CheckpointThld)
+		if err != nil {
+			return nil, fmt.Errorf("could not open indexer: %w", err)
+		}
+
+		repairReport.IndexRebuilt = true
+	}
+
+	if repairReport.repaired() {
+		repairReport.CommittedTxID = store.committedTxID
+		store.repairReport = repairReport
+
+		fields := []logger.Field{
+			logger.F("path", path),
+			logger.F("committed_tx", repairReport.CommittedTxID),
+			logger.F("discarded_txs", len(repairReport.DiscardedTxs)),
+			logger.F("discarded_clog_bytes", repairReport.DiscardedCLogBytes),
+			logger.F("discarded_txlog_bytes", repairReport.DiscardedTxLogBytes),
+			logger.F("index_rebuilt", repairReport.IndexRebuilt),
+		}
+
+		opts.log.Warn("Incomplete commits rolled back", fields...)
+
+		for _, dtx := range repairReport.DiscardedTxs {
+			opts.log.Warn("Transaction discarded", logger.F("path", path), logger.F("tx", dtx.ID), logger.F("reason", dtx.Reason))
+		}
+	}
+
 	if store.aht.Size() > store.committedTxID {
 		err = store.aht.ResetSize(store.committedTxID)
 		if err != nil {
@@ -486,7 +594,7 @@ const (
 	Error
 )
 
-func (s *ImmuStore) notify(nType NotificationType, mandatory bool, formattedMessage string, args ...interface{}) {
+func (s *ImmuStore) notify(nType NotificationType, mandatory bool, msg string, fields ...logger.Field) {
 	s.notifyMutex.Lock()
 	defer s.notifyMutex.Unlock()
 
@@ -494,15 +602,15 @@ func (s *ImmuStore) notify(nType NotificationType, mandatory bool, formattedMess
 		switch nType {
 		case Info:
 			{
-				s.log.Infof(formattedMessage, args...)
+				s.log.Info(msg, fields...)
 			}
 		case Warn:
 			{
-				s.log.Warningf(formattedMessage, args...)
+				s.log.Warn(msg, fields...)
 			}
 		case Error:
 			{
-				s.log.Errorf(formattedMessage, args...)
+				s.log.Error(msg, fields...)
 			}
 		}
 		s.lastNotification = time.Now()
@@ -513,6 +621,12 @@ func (s *ImmuStore) IndexInfo() uint64 {
 	return s.indexer.Ts()
 }
 
+// IndexingProgress returns how far the indexing of the committed transactions is,
+// and the checkpoint it would be resumed from after a restart
+func (s *ImmuStore) IndexingProgress() (*IndexingProgress, error) {
+	return s.indexer.Progress()
+}
+
 func (s *ImmuStore) ExistKeyWith(prefix []byte, neq []byte) (bool, error) {
 	return s.indexer.ExistKeyWith(prefix, neq)
 }
@@ -605,7 +719,7 @@ func (s *ImmuStore) binaryLinking() {
 				_, _, err := s.aht.Append(alh[:])
 				if err != nil {
 					s.SetBlErr(err)
-					s.log.Errorf("Binary linking at '%s' stopped due to error: %v", s.path, err)
+					s.log.Error("Binary linking stopped", logger.F("path", s.path), logger.F("error", err))
 					return
 				}
 			}
@@ -638,11 +752,11 @@ func (s *ImmuStore) BlInfo() (uint64, error) {
 
 func (s *ImmuStore) syncBinaryLinking() error {
 	if s.aht.Size() == s.committedTxID {
-		s.log.Infof("Binary Linking up to date at '%s'", s.path)
+		s.log.Info("Binary Linking up to date", logger.F("path", s.path))
 		return nil
 	}
 
-	s.log.Infof("Syncing Binary Linking at '%s'...", s.path)
+	s.log.Info("Syncing Binary Linking...", logger.F("path", s.path))
 
 	tx, err := s.fetchAllocTx()
 	if err != nil {
@@ -668,11 +782,11 @@ func (s *ImmuStore) syncBinaryLinking() error {
 		s.aht.Append(alh[:])
 
 		if tx.header.ID%1000 == 0 {
-			s.log.Infof("Binary linking at '%s' in progress: processing tx: %d", s.path, tx.header.ID)
+			s.log.Info("Binary linking in progress", logger.F("path", s.path), logger.F("tx", tx.header.ID))
 		}
 	}
 
-	s.log.Infof("Binary Linking up to date at '%s'", s.path)
+	s.log.Info("Binary Linking up to date", logger.F("path", s.path))
 
 	return nil
 }
@@ -857,7 +971,19 @@ func (s *ImmuStore) NewWriteOnlyTx() (*OngoingTx, error) {
 }
 
 func (s *ImmuStore) NewTx() (*OngoingTx, error) {
-	return newReadWriteTx(s)
+	return newReadWriteTx(s, true, nil)
+}
+
+// NewTxWithCancellation is the same as NewTx but waiting for committed transactions
+// to be indexed is interrupted once the cancellation channel is closed
+func (s *ImmuStore) NewTxWithCancellation(cancellation <-chan struct{}) (*OngoingTx, error) {
+	return newReadWriteTx(s, true, cancellation)
+}
+
+// NewTxNoWait creates a transaction reading from the latest indexed snapshot,
+// without waiting for committed transactions to be indexed
+func (s *ImmuStore) NewTxNoWait() (*OngoingTx, error) {
+	return newReadWriteTx(s, false, nil)
 }
 
 func (s *ImmuStore) commit(otx *OngoingTx, expectedHeader *TxHeader, waitForIndexing bool) (*TxHeader, error) {
@@ -1189,7 +1315,12 @@ func (s *ImmuStore) commitState() (txID uint64, txAlh [sha256.Size]byte, clogSiz
 }
 
 func (s *ImmuStore) CommitWith(callback func(txID uint64, index KeyIndex) ([]*EntrySpec, error), waitForIndexing bool) (*TxHeader, error) {
-	hdr, err := s.commitWith(callback)
+	return s.CommitWithMetadata(nil, callback, waitForIndexing)
+}
+
+// CommitWithMetadata is the same as CommitWith but the metadata is attached to the committed tx
+func (s *ImmuStore) CommitWithMetadata(md *TxMetadata, callback func(txID uint64, index KeyIndex) ([]*EntrySpec, error), waitForIndexing bool) (*TxHeader, error) {
+	hdr, err := s.commitWith(md, callback)
 	if err != nil {
 		return nil, err
 	}
@@ -1221,7 +1352,7 @@ func (index *unsafeIndex) GetWith(key []byte, filters ...FilterFn) (ValueRef, er
 	return index.st.GetWith(key, filters...)
 }
 
-func (s *ImmuStore) commitWith(callback func(txID uint64, index KeyIndex) ([]*EntrySpec, error)) (*TxHeader, error) {
+func (s *ImmuStore) commitWith(md *TxMetadata, callback func(txID uint64, index KeyIndex) ([]*EntrySpec, error)) (*TxHeader, error) {
 	if callback == nil {
 		return nil, ErrIllegalArguments
 	}
@@ -1260,6 +1391,7 @@ func (s *ImmuStore) commitWith(callback func(txID uint64, index KeyIndex) ([]*En
 	defer s.releaseAllocTx(tx)
 
 	tx.header.Version = TxHeaderVersion
+	tx.header.Metadata = md
 	tx.header.NEntries = len(entries)
 
 	for i, e := range entries {
@@ -1314,6 +1446,102 @@ func (s *ImmuStore) DualProof(sourceTx, targetTx *Tx) (proof *DualProof, err err
 		return nil, ErrIllegalArguments
 	}
 
+	return s.dualProof(sourceTx, targetTx, s.LinearProof)
+}
+
+// DualProofs returns the proofs from sourceTx to each of the targetTxs, which must be adjacent transactions
+// sorted by ID. Transactions are read only once to build all the linear proofs
+func (s *ImmuStore) DualProofs(sourceTx *Tx, targetTxs []*Tx) ([]*DualProof, error) {
+	if sourceTx == nil || len(targetTxs) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	for i, targetTx := range targetTxs {
+		if targetTx == nil || targetTx.header.ID != targetTxs[0].header.ID+uint64(i) {
+			return nil, ErrIllegalArguments
+		}
+	}
+
+	firstTarget := targetTxs[0].header
+	lastTarget := targetTxs[len(targetTxs)-1].header
+
+	if sourceTx.header.ID > firstTarget.ID {
+		return nil, ErrSourceTxNewerThanTargetTx
+	}
+
+	// binary linking only moves forward, so the first target holds the earliest linear proof
+	fromTxID := maxUint64(sourceTx.header.ID, firstTarget.BlTxID)
+
+	terms, err := s.linearProofTerms(fromTxID, lastTarget.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	linearProof := func(sourceTxID, targetTxID uint64) (*LinearProof, error) {
+		if s.maxLinearProofLen > 0 && int(targetTxID-sourceTxID+1) > s.maxLinearProofLen {
+			return nil, ErrLinearProofMaxLenExceeded
+		}
+
+		proof := make([][sha256.Size]byte, targetTxID-sourceTxID+1)
+		proof[0] = terms[sourceTxID-fromTxID].alh
+
+		for i := 1; i < len(proof); i++ {
+			proof[i] = terms[sourceTxID-fromTxID+uint64(i)].innerHash
+		}
+
+		return &LinearProof{
+			SourceTxID: sourceTxID,
+			TargetTxID: targetTxID,
+			Terms:      proof,
+		}, nil
+	}
+
+	proofs := make([]*DualProof, len(targetTxs))
+
+	for i, targetTx := range targetTxs {
+		proofs[i], err = s.dualProof(sourceTx, targetTx, linearProof)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return proofs, nil
+}
+
+type linearProofTerm struct {
+	alh       [sha256.Size]byte
+	innerHash [sha256.Size]byte
+}
+
+// linearProofTerms reads the transactions in the range, returning the hashes linear proofs are made of
+func (s *ImmuStore) linearProofTerms(fromTxID, toTxID uint64) ([]linearProofTerm, error) {
+	tx, err := s.fetchAllocTx()
+	if err != nil {
+		return nil, err
+	}
+	defer s.releaseAllocTx(tx)
+
+	r, err := s.NewTxReader(fromTxID, false, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := make([]linearProofTerm, toTxID-fromTxID+1)
+
+	for i := range terms {
+		tx, err := r.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		terms[i] = linearProofTerm{alh: tx.header.Alh(), innerHash: tx.header.innerHash()}
+	}
+
+	return terms, nil
+}
+
+func (s *ImmuStore) dualProof(sourceTx, targetTx *Tx, linearProof func(sourceTxID, targetTxID uint64) (*LinearProof, error)) (proof *DualProof, err error) {
+
 	if sourceTx.header.ID > targetTx.header.ID {
 		return nil, ErrSourceTxNewerThanTargetTx
 	}
@@ -1372,7 +1600,7 @@ func (s *ImmuStore) DualProof(sourceTx, targetTx *Tx) (proof *DualProof, err err
 		s.releaseAllocTx(targetBlTx)
 	}
 
-	lproof, err := s.LinearProof(maxUint64(sourceTx.header.ID, targetTx.header.BlTxID), targetTx.header.ID)
+	lproof, err := linearProof(maxUint64(sourceTx.header.ID, targetTx.header.BlTxID), targetTx.header.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -1455,6 +1683,11 @@ func (s *ImmuStore) txOffsetAndSize(txID uint64) (int64, int, error) {
 		return 0, 0, err
 	}
 
+	if txID > s.TxCount() {
+		// commit records beyond the committed state belong to txs discarded when the store was opened
+		return 0, 0, ErrTxNotFound
+	}
+
 	txOffset := int64(binary.BigEndian.Uint64(cb[:]))
 	txSize := int(binary.BigEndian.Uint32(cb[offsetSize:]))
 
@@ -1683,6 +1916,62 @@ func (s *ImmuStore) ReadTx(txID uint64, tx *Tx) error {
 	return err
 }
 
+// FirstTxSince returns the header of the first transaction committed at or after the given time.
+// Transaction timestamps have a resolution of seconds and are expected to be non-decreasing,
+// as it is when they are taken from the store clock
+func (s *ImmuStore) FirstTxSince(ts time.Time) (*TxHeader, error) {
+	return s.searchTxByTime(func(hdr *TxHeader) bool { return hdr.Ts >= ts.Unix() }, false)
+}
+
+// LastTxUntil returns the header of the last transaction committed at or before the given time
+func (s *ImmuStore) LastTxUntil(ts time.Time) (*TxHeader, error) {
+	return s.searchTxByTime(func(hdr *TxHeader) bool { return hdr.Ts > ts.Unix() }, true)
+}
+
+// searchTxByTime finds the first transaction satisfying cond by binary search, or the one preceding it when prev is set.
+// ErrTxNotFound is returned when there is no such transaction
+func (s *ImmuStore) searchTxByTime(cond func(hdr *TxHeader) bool, prev bool) (*TxHeader, error) {
+	committedTxID := s.TxCount()
+
+	tx := s.NewTxHolder()
+
+	var searchErr error
+
+	// smallest tx id in [1, committedTxID+1] satisfying cond, tx ids being found in order of their timestamps
+	i := sort.Search(int(committedTxID), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+
+		err := s.ReadTx(uint64(i+1), tx)
+		if err != nil {
+			searchErr = err
+			return true
+		}
+
+		return cond(tx.header)
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+
+	txID := uint64(i + 1)
+	if prev {
+		txID--
+	}
+
+	if txID == 0 || txID > committedTxID {
+		return nil, ErrTxNotFound
+	}
+
+	err := s.ReadTx(txID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.Header(), nil
+}
+
 // ReadValue returns the actual associated value to a key at a specific transaction
 // ErrExpiredEntry is be returned if the specified time has already elapsed
 func (s *ImmuStore) ReadValue(entry *TxEntry) ([]byte, error) {
@@ -1719,6 +2008,9 @@ func (s *ImmuStore) readValueAt(b []byte, off int64, hvalue [sha256.Size]byte) (
 		if err == multiapp.ErrAlreadyClosed || err == singleapp.ErrAlreadyClosed {
 			return n, ErrAlreadyClosed
 		}
+		if err == multiapp.ErrDiscarded {
+			return n, ErrExpiredEntry
+		}
 		if err != nil {
 			return n, err
 		}
@@ -1731,6 +2023,68 @@ func (s *ImmuStore) readValueAt(b []byte, off int64, hvalue [sha256.Size]byte) (
 	return len(b), nil
 }
 
+// VerifyTxs reads back the transactions in the range [fromTxID, toTxID] from the underlying storage,
+// checking the hash of each transaction, the digest of every value and the linear chaining between
+// consecutive transactions. It's meant to early detect data degraded at rest
+func (s *ImmuStore) VerifyTxs(fromTxID, toTxID uint64) error {
+	if fromTxID == 0 || fromTxID > toTxID {
+		return ErrIllegalArguments
+	}
+
+	committedTxID, committedAlh := s.Alh()
+	if toTxID > committedTxID {
+		return ErrTxNotFound
+	}
+
+	tx := s.NewTxHolder()
+
+	prevAlh := sha256.Sum256(nil)
+
+	if fromTxID > 1 {
+		err := s.ReadTx(fromTxID-1, tx)
+		if err != nil {
+			return err
+		}
+
+		prevAlh = tx.header.Alh()
+	}
+
+	for txID := fromTxID; txID <= toTxID; txID++ {
+		// the hash of the tx is checked while reading it
+		err := s.ReadTx(txID, tx)
+		if err != nil {
+			return err
+		}
+
+		if tx.header.PrevAlh != prevAlh {
+			return fmt.Errorf("%w: linear linking mismatch at tx %d", ErrorCorruptedTxData, txID)
+		}
+
+		for _, e := range tx.Entries() {
+			// values are read regardless of their expiration
+			_, err = s.readValueAt(make([]byte, e.vLen), e.vOff, e.hVal)
+			if errors.Is(err, ErrCorruptedData) {
+				return fmt.Errorf("%w: value digest mismatch at tx %d", ErrCorruptedData, txID)
+			}
+			if err == ErrExpiredEntry {
+				// discarded by truncation, its digest is still verified as part of the tx
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		prevAlh = tx.header.Alh()
+	}
+
+	if toTxID == committedTxID && prevAlh != committedAlh {
+		return fmt.Errorf("%w: ALH mismatch at tx %d", ErrorCorruptedTxData, toTxID)
+	}
+
+	return nil
+}
+
 func (s *ImmuStore) validateEntries(entries []*EntrySpec) error {
 	if len(entries) == 0 {
 		return ErrorNoEntriesProvided
@@ -1820,9 +2174,9 @@ func (s *ImmuStore) Close() error {
 	}
 
 	if s.blBuffer != nil && s.blErr == nil && s.blDone != nil {
-		s.log.Infof("Stopping Binary Linking at '%s'...", s.path)
+		s.log.Info("Stopping Binary Linking...", logger.F("path", s.path))
 		s.blDone <- struct{}{}
-		s.log.Infof("Binary linking gracefully stopped at '%s'", s.path)
+		s.log.Info("Binary linking gracefully stopped", logger.F("path", s.path))
 		close(s.blBuffer)
 	}
 
@@ -1846,7 +2200,7 @@ func (s *ImmuStore) Close() error {
 
 func (s *ImmuStore) wrapAppendableErr(err error, action string) error {
 	if err == singleapp.ErrAlreadyClosed || err == multiapp.ErrAlreadyClosed {
-		s.log.Warningf("Got '%v' while '%s'", err, action)
+		s.log.Warn("Appendable already closed", logger.F("action", action), logger.F("error", err))
 		return ErrAlreadyClosed
 	}
 
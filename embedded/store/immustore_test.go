@@ -36,6 +36,7 @@ import (
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
 	"github.com/codenotary/immudb/embedded/htree"
 	"github.com/codenotary/immudb/embedded/tbtree"
+	"github.com/codenotary/immudb/embedded/watchers"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -259,7 +260,7 @@ func TestImmudbStoreEdgeCases(t *testing.T) {
 	_, err = OpenWith("edge_cases", nil, nil, nil, nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	opts := DefaultOptions().WithMaxConcurrency(1)
+	opts := DefaultOptions().WithMaxConcurrency(1).WithMaxRepairedTxs(0)
 
 	_, err = OpenWith("edge_cases", nil, nil, nil, opts)
 	require.Equal(t, ErrIllegalArguments, err)
@@ -1192,6 +1193,57 @@ func TestImmudbStoreKVMetadata(t *testing.T) {
 	require.Equal(t, []byte{1, 1, 1}, v)
 }
 
+func TestImmudbStoreTxMetadata(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
+	immuStore, err := Open("data_tx_metadata", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_tx_metadata")
+
+	txmd := NewTxMetadata()
+	err = txmd.WithLabel("actor", "alice")
+	require.NoError(t, err)
+	err = txmd.WithLabel("reason", "migration")
+	require.NoError(t, err)
+
+	tx, err := immuStore.NewWriteOnlyTx()
+	require.NoError(t, err)
+
+	err = tx.WithMetadata(txmd).Set([]byte("key1"), nil, []byte("value1"))
+	require.NoError(t, err)
+
+	hdr, err := tx.Commit()
+	require.NoError(t, err)
+	require.True(t, txmd.Equal(hdr.Metadata))
+
+	txHolder := immuStore.NewTxHolder()
+
+	err = immuStore.ReadTx(hdr.ID, txHolder)
+	require.NoError(t, err)
+	require.Equal(t, txmd.Labels(), txHolder.Header().Metadata.Labels())
+	require.Equal(t, hdr.Alh(), txHolder.Header().Alh())
+
+	err = immuStore.WaitForIndexingUpto(hdr.ID, nil)
+	require.NoError(t, err)
+
+	valRef, err := immuStore.Get([]byte("key1"))
+	require.NoError(t, err)
+
+	actor, ok := valRef.TxMetadata().Label("actor")
+	require.True(t, ok)
+	require.Equal(t, "alice", actor)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	immuStore, err = Open("data_tx_metadata", opts)
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	err = immuStore.ReadTx(hdr.ID, txHolder)
+	require.NoError(t, err)
+	require.Equal(t, txmd.Labels(), txHolder.Header().Metadata.Labels())
+}
+
 func TestImmudbStoreCommitWith(t *testing.T) {
 	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
 	immuStore, err := Open("data_commit_with", opts)
@@ -1241,6 +1293,126 @@ func TestImmudbStoreCommitWith(t *testing.T) {
 	require.Equal(t, []byte("value"), val)
 }
 
+func TestImmudbStoreVerifyTxs(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
+	immuStore, err := Open("data_verify_txs", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_verify_txs")
+
+	for i := 0; i < 10; i++ {
+		tx, err := immuStore.NewWriteOnlyTx()
+		require.NoError(t, err)
+
+		err = tx.Set([]byte(fmt.Sprintf("key%d", i)), nil, []byte(fmt.Sprintf("immutable-value%d", i)))
+		require.NoError(t, err)
+
+		_, err = tx.Commit()
+		require.NoError(t, err)
+	}
+
+	err = immuStore.VerifyTxs(0, 10)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	err = immuStore.VerifyTxs(5, 4)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	err = immuStore.VerifyTxs(1, 11)
+	require.ErrorIs(t, err, ErrTxNotFound)
+
+	err = immuStore.VerifyTxs(1, 10)
+	require.NoError(t, err)
+
+	err = immuStore.VerifyTxs(6, 8)
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	// a value is degraded at rest
+	vLogFiles, err := filepath.Glob(filepath.Join("data_verify_txs", "val_0", "*.val"))
+	require.NoError(t, err)
+	require.Len(t, vLogFiles, 1)
+
+	content, err := ioutil.ReadFile(vLogFiles[0])
+	require.NoError(t, err)
+
+	i := bytes.Index(content, []byte("immutable-value7"))
+	require.Greater(t, i, 0)
+	content[i] ^= 0xff
+
+	err = ioutil.WriteFile(vLogFiles[0], content, 0644)
+	require.NoError(t, err)
+
+	immuStore, err = Open("data_verify_txs", opts)
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	err = immuStore.VerifyTxs(1, 10)
+	require.ErrorIs(t, err, ErrCorruptedData)
+
+	err = immuStore.VerifyTxs(8, 8)
+	require.ErrorIs(t, err, ErrCorruptedData)
+
+	err = immuStore.VerifyTxs(1, 7)
+	require.NoError(t, err)
+
+	err = immuStore.VerifyTxs(9, 10)
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreTxByTime(t *testing.T) {
+	immuStore, err := Open("data_tx_by_time", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_tx_by_time")
+	defer immuStore.Close()
+
+	_, err = immuStore.FirstTxSince(time.Unix(0, 0))
+	require.ErrorIs(t, err, ErrTxNotFound)
+
+	_, err = immuStore.LastTxUntil(time.Now())
+	require.ErrorIs(t, err, ErrTxNotFound)
+
+	// two transactions per second, from t=100 to t=109
+	for i := 0; i < 20; i++ {
+		ts := time.Unix(int64(100+i/2), 0)
+
+		err = immuStore.UseTimeFunc(func() time.Time { return ts })
+		require.NoError(t, err)
+
+		tx, err := immuStore.NewWriteOnlyTx()
+		require.NoError(t, err)
+
+		err = tx.Set([]byte(fmt.Sprintf("key%d", i)), nil, []byte("value"))
+		require.NoError(t, err)
+
+		_, err = tx.AsyncCommit()
+		require.NoError(t, err)
+	}
+
+	hdr, err := immuStore.FirstTxSince(time.Unix(0, 0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), hdr.ID)
+
+	hdr, err = immuStore.FirstTxSince(time.Unix(105, 0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), hdr.ID)
+	require.Equal(t, int64(105), hdr.Ts)
+
+	_, err = immuStore.FirstTxSince(time.Unix(110, 0))
+	require.ErrorIs(t, err, ErrTxNotFound)
+
+	hdr, err = immuStore.LastTxUntil(time.Unix(105, 0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), hdr.ID)
+
+	hdr, err = immuStore.LastTxUntil(time.Unix(1000, 0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), hdr.ID)
+
+	_, err = immuStore.LastTxUntil(time.Unix(99, 0))
+	require.ErrorIs(t, err, ErrTxNotFound)
+}
+
 func TestImmudbStoreHistoricalValues(t *testing.T) {
 	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
 	opts.WithIndexOptions(opts.IndexOpts.WithFlushThld(10))
@@ -1651,6 +1823,66 @@ func TestImmudbStoreConsistencyProof(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestImmudbStoreDualProofs(t *testing.T) {
+	immuStore, err := Open(t.TempDir(), DefaultOptions().WithSynced(false).WithMaxConcurrency(1))
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	txCount := 16
+
+	for i := 0; i < txCount; i++ {
+		tx, err := immuStore.NewWriteOnlyTx()
+		require.NoError(t, err)
+
+		err = tx.Set([]byte(fmt.Sprintf("key%d", i)), nil, []byte(fmt.Sprintf("value%d", i)))
+		require.NoError(t, err)
+
+		_, err = tx.Commit()
+		require.NoError(t, err)
+	}
+
+	readTx := func(txID uint64) *Tx {
+		tx := immuStore.NewTxHolder()
+
+		err := immuStore.ReadTx(txID, tx)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	sourceTx := readTx(3)
+
+	_, err = immuStore.DualProofs(nil, []*Tx{sourceTx})
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = immuStore.DualProofs(sourceTx, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = immuStore.DualProofs(sourceTx, []*Tx{readTx(5), readTx(7)})
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = immuStore.DualProofs(sourceTx, []*Tx{readTx(2), readTx(3)})
+	require.ErrorIs(t, err, ErrSourceTxNewerThanTargetTx)
+
+	var targetTxs []*Tx
+	for txID := uint64(3); txID <= uint64(txCount); txID++ {
+		targetTxs = append(targetTxs, readTx(txID))
+	}
+
+	proofs, err := immuStore.DualProofs(sourceTx, targetTxs)
+	require.NoError(t, err)
+	require.Len(t, proofs, len(targetTxs))
+
+	for i, targetTx := range targetTxs {
+		proof, err := immuStore.DualProof(sourceTx, targetTx)
+		require.NoError(t, err)
+		require.Equal(t, proof, proofs[i])
+
+		verifies := VerifyDualProof(proofs[i], sourceTx.header.ID, targetTx.header.ID, sourceTx.header.Alh(), targetTx.header.Alh())
+		require.True(t, verifies)
+	}
+}
+
 func TestImmudbStoreConsistencyProofAgainstLatest(t *testing.T) {
 	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
 	immuStore, err := Open("data_consistency_proof_latest", opts)
@@ -2299,3 +2531,188 @@ func TestImmudbStoreTruncatedCommitLog(t *testing.T) {
 	err = immuStore.Close()
 	require.NoError(t, err)
 }
+
+func TestImmudbStoreNewTxNoWait(t *testing.T) {
+	immuStore, err := Open(t.TempDir(), DefaultOptions())
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	tx, err := immuStore.NewWriteOnlyTx()
+	require.NoError(t, err)
+
+	err = tx.Set([]byte("key1"), nil, []byte("val1"))
+	require.NoError(t, err)
+
+	hdr, err := tx.Commit()
+	require.NoError(t, err)
+
+	err = immuStore.WaitForIndexingUpto(hdr.ID, nil)
+	require.NoError(t, err)
+
+	immuStore.indexer.Pause()
+	defer immuStore.indexer.Resume()
+
+	tx, err = immuStore.NewWriteOnlyTx()
+	require.NoError(t, err)
+
+	err = tx.Set([]byte("key2"), nil, []byte("val2"))
+	require.NoError(t, err)
+
+	_, err = tx.AsyncCommit()
+	require.NoError(t, err)
+
+	rtx, err := immuStore.NewTxNoWait()
+	require.NoError(t, err)
+
+	_, err = rtx.Get([]byte("key1"))
+	require.NoError(t, err)
+
+	_, err = rtx.Get([]byte("key2"))
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	// writing from an outdated snapshot is not possible
+	err = rtx.Set([]byte("key3"), nil, []byte("val3"))
+	require.NoError(t, err)
+
+	_, err = rtx.Commit()
+	require.ErrorIs(t, err, ErrTxReadConflict)
+}
+
+func TestImmudbStoreNewTxWithCancellation(t *testing.T) {
+	immuStore, err := Open(t.TempDir(), DefaultOptions())
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	immuStore.indexer.Pause()
+
+	tx, err := immuStore.NewWriteOnlyTx()
+	require.NoError(t, err)
+
+	err = tx.Set([]byte("key1"), nil, []byte("val1"))
+	require.NoError(t, err)
+
+	_, err = tx.AsyncCommit()
+	require.NoError(t, err)
+
+	cancellation := make(chan struct{})
+	close(cancellation)
+
+	_, err = immuStore.NewTxWithCancellation(cancellation)
+	require.ErrorIs(t, err, watchers.ErrCancellationRequested)
+
+	immuStore.indexer.Resume()
+
+	rtx, err := immuStore.NewTxWithCancellation(make(chan struct{}))
+	require.NoError(t, err)
+	defer rtx.Cancel()
+
+	_, err = rtx.Get([]byte("key1"))
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreNonIndexableEntries(t *testing.T) {
+	immuStore, err := Open(t.TempDir(), DefaultOptions())
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	nonIndexable := NewKVMetadata()
+	err = nonIndexable.AsNonIndexable(true)
+	require.NoError(t, err)
+
+	tx, err := immuStore.NewWriteOnlyTx()
+	require.NoError(t, err)
+
+	err = tx.Set([]byte("evidence1"), nonIndexable, []byte("value1"))
+	require.NoError(t, err)
+
+	hdr1, err := tx.Commit()
+	require.NoError(t, err)
+
+	tx, err = immuStore.NewWriteOnlyTx()
+	require.NoError(t, err)
+
+	err = tx.Set([]byte("evidence2"), nonIndexable, []byte("value2"))
+	require.NoError(t, err)
+
+	err = tx.Set([]byte("key1"), nil, []byte("value1"))
+	require.NoError(t, err)
+
+	hdr2, err := tx.Commit()
+	require.NoError(t, err)
+
+	_, err = immuStore.Get([]byte("evidence1"))
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = immuStore.Get([]byte("evidence2"))
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	valRef, err := immuStore.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, hdr2.ID, valRef.Tx())
+
+	// entries are still accessible through the tx
+	txHolder := immuStore.NewTxHolder()
+
+	err = immuStore.ReadTx(hdr1.ID, txHolder)
+	require.NoError(t, err)
+
+	entries := txHolder.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, []byte("evidence1"), entries[0].Key())
+	require.True(t, entries[0].Metadata().NonIndexable())
+
+	val, err := immuStore.ReadValue(entries[0])
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), val)
+
+	entrySpecDigest, err := EntrySpecDigestFor(txHolder.header.Version)
+	require.NoError(t, err)
+
+	inclusionProof, err := txHolder.Proof([]byte("evidence1"))
+	require.NoError(t, err)
+
+	eSpec := &EntrySpec{Key: []byte("evidence1"), Metadata: nonIndexable, Value: val}
+	require.True(t, htree.VerifyInclusion(inclusionProof, entrySpecDigest(eSpec), txHolder.header.Eh))
+}
+
+func TestImmudbStoreWithIndexWAL(t *testing.T) {
+	dir := t.TempDir()
+
+	indexOpts := DefaultIndexOptions().
+		WithSynced(false).
+		WithFlushThld(100).
+		WithSyncThld(1000).
+		WithWAL(true)
+
+	opts := DefaultOptions().WithSynced(false).WithIndexOptions(indexOpts)
+
+	immuStore, err := Open(dir, opts)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		tx, err := immuStore.NewWriteOnlyTx()
+		require.NoError(t, err)
+
+		err = tx.Set([]byte(fmt.Sprintf("key%d", i)), nil, []byte(fmt.Sprintf("value%d", i)))
+		require.NoError(t, err)
+
+		_, err = tx.Commit()
+		require.NoError(t, err)
+	}
+
+	err = immuStore.WaitForIndexingUpto(10, nil)
+	require.NoError(t, err)
+
+	require.DirExists(t, filepath.Join(dir, indexDirname, "wal"))
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	immuStore, err = Open(dir, opts)
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	valRef, err := immuStore.Get([]byte("key9"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), valRef.Tx())
+}
@@ -22,6 +22,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/codenotary/immudb/embedded/logger"
 	"github.com/codenotary/immudb/embedded/tbtree"
 	"github.com/codenotary/immudb/embedded/watchers"
 	"github.com/prometheus/client_golang/prometheus"
@@ -44,6 +45,12 @@ type indexer struct {
 
 	closed bool
 
+	// the index is synced every checkpointThld indexed transactions, zero disables checkpoints
+	checkpointThld  int
+	resumedFromTxID uint64
+	checkpointedAt  time.Time
+	progressMutex   sync.Mutex
+
 	compactionMutex sync.Mutex
 	mutex           sync.Mutex
 
@@ -74,7 +81,7 @@ var (
 	})
 )
 
-func newIndexer(path string, store *ImmuStore, indexOpts *tbtree.Options, maxWaitees int) (*indexer, error) {
+func newIndexer(path string, store *ImmuStore, indexOpts *tbtree.Options, maxWaitees int, checkpointThld int) (*indexer, error) {
 	index, err := tbtree.Open(path, indexOpts)
 	if err != nil {
 		return nil, err
@@ -91,13 +98,19 @@ func newIndexer(path string, store *ImmuStore, indexOpts *tbtree.Options, maxWai
 	}
 
 	indexer := &indexer{
-		store:     store,
-		tx:        tx,
-		path:      path,
-		index:     index,
-		wHub:      wHub,
-		state:     stopped,
-		stateCond: sync.NewCond(&sync.Mutex{}),
+		store:           store,
+		tx:              tx,
+		path:            path,
+		index:           index,
+		wHub:            wHub,
+		state:           stopped,
+		stateCond:       sync.NewCond(&sync.Mutex{}),
+		checkpointThld:  checkpointThld,
+		resumedFromTxID: index.Ts(),
+	}
+
+	if indexer.resumedFromTxID > 0 {
+		store.log.Info("Indexing resumed from checkpoint", logger.F("path", store.path), logger.F("tx", indexer.resumedFromTxID))
 	}
 
 	dbName := filepath.Base(store.path)
@@ -116,6 +129,40 @@ func (idx *indexer) Ts() uint64 {
 	return idx.index.Ts()
 }
 
+// IndexingProgress describes how far the indexing of the committed transactions is
+type IndexingProgress struct {
+	CommittedTxID uint64
+	IndexedTxID   uint64
+	// CheckpointTxID is the last indexed transaction persisted, indexing is resumed from it after a restart
+	CheckpointTxID uint64
+	// CheckpointedAt is when the last checkpoint was taken, it's zero if none was taken since the store was opened
+	CheckpointedAt time.Time
+	// ResumedFromTxID is the checkpoint indexing was resumed from when the store was opened
+	ResumedFromTxID uint64
+}
+
+func (idx *indexer) Progress() (*IndexingProgress, error) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	if idx.closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	committedTxID, _, _ := idx.store.commitState()
+
+	idx.progressMutex.Lock()
+	defer idx.progressMutex.Unlock()
+
+	return &IndexingProgress{
+		CommittedTxID:   committedTxID,
+		IndexedTxID:     idx.index.Ts(),
+		CheckpointTxID:  idx.index.SyncedTs(),
+		CheckpointedAt:  idx.checkpointedAt,
+		ResumedFromTxID: idx.resumedFromTxID,
+	}, nil
+}
+
 func (idx *indexer) Get(key []byte) (value []byte, tx uint64, hc uint64, err error) {
 	idx.mutex.Lock()
 	defer idx.mutex.Unlock()
@@ -124,7 +171,7 @@ func (idx *indexer) Get(key []byte) (value []byte, tx uint64, hc uint64, err err
 		return nil, 0, 0, ErrAlreadyClosed
 	}
 
-	return idx.index.Get(key)
+	return idx.index.Get(idx.store.collation.indexedKey(key))
 }
 
 func (idx *indexer) History(key []byte, offset uint64, descOrder bool, limit int) (txs []uint64, err error) {
@@ -135,7 +182,7 @@ func (idx *indexer) History(key []byte, offset uint64, descOrder bool, limit int
 		return nil, ErrAlreadyClosed
 	}
 
-	return idx.index.History(key, offset, descOrder, limit)
+	return idx.index.History(idx.store.collation.indexedKey(key), offset, descOrder, limit)
 }
 
 func (idx *indexer) Snapshot() (*tbtree.Snapshot, error) {
@@ -168,7 +215,17 @@ func (idx *indexer) ExistKeyWith(prefix []byte, neq []byte) (bool, error) {
 		return false, ErrAlreadyClosed
 	}
 
-	return idx.index.ExistKeyWith(prefix, neq)
+	if idx.store.collation == nil {
+		return idx.index.ExistKeyWith(prefix, neq)
+	}
+
+	snap, err := idx.index.Snapshot()
+	if err != nil {
+		return false, err
+	}
+	defer snap.Close()
+
+	return idx.store.collation.existKeyWith(snap, prefix, neq)
 }
 
 func (idx *indexer) Sync() error {
@@ -214,15 +271,15 @@ func (idx *indexer) CompactIndex() (err error) {
 	idx.compactionMutex.Lock()
 	defer idx.compactionMutex.Unlock()
 
-	idx.store.log.Infof("Compacting index '%s'...", idx.store.path)
+	idx.store.log.Info("Compacting index...", logger.F("path", idx.store.path))
 
 	defer func() {
 		if err == nil {
-			idx.store.log.Infof("Index '%s' sucessfully compacted", idx.store.path)
+			idx.store.log.Info("Index sucessfully compacted", logger.F("path", idx.store.path))
 		} else if err == tbtree.ErrCompactionThresholdNotReached {
-			idx.store.log.Infof("Compaction of index '%s' not needed: %v", idx.store.path, err)
+			idx.store.log.Info("Compaction of index not needed", logger.F("path", idx.store.path), logger.F("reason", err))
 		} else {
-			idx.store.log.Warningf("%v: while compacting index '%s'", idx.store.path, err)
+			idx.store.log.Warn("Compacting index failed", logger.F("path", idx.store.path), logger.F("error", err))
 		}
 	}()
 
@@ -241,7 +298,7 @@ func (idx *indexer) stop() {
 	idx.stateCond.L.Unlock()
 	idx.stateCond.Signal()
 
-	idx.store.notify(Info, true, "Indexing gracefully stopped at '%s'", idx.store.path)
+	idx.store.notify(Info, true, "Indexing gracefully stopped", logger.F("path", idx.store.path))
 }
 
 func (idx *indexer) resume() {
@@ -251,7 +308,7 @@ func (idx *indexer) resume() {
 	go idx.doIndexing(idx.cancellation)
 	idx.stateCond.L.Unlock()
 
-	idx.store.notify(Info, true, "Indexing in progress at '%s'", idx.store.path)
+	idx.store.notify(Info, true, "Indexing in progress", logger.F("path", idx.store.path))
 }
 
 func (idx *indexer) restartIndex() error {
@@ -313,7 +370,7 @@ func (idx *indexer) doIndexing(cancellation <-chan struct{}) {
 			return
 		}
 		if err != nil {
-			idx.store.log.Errorf("Indexing failed at '%s' due to error: %v", idx.store.path, err)
+			idx.store.log.Error("Indexing failed", logger.F("path", idx.store.path), logger.F("error", err))
 			time.Sleep(60 * time.Second)
 		}
 
@@ -321,7 +378,7 @@ func (idx *indexer) doIndexing(cancellation <-chan struct{}) {
 		idx.metricsLastCommittedTrx.Set(float64(committedTxID))
 
 		txsToIndex := committedTxID - lastIndexedTx
-		idx.store.notify(Info, false, "%d transaction/s to be indexed at '%s'", txsToIndex, idx.store.path)
+		idx.store.notify(Info, false, "Transactions to be indexed", logger.F("path", idx.store.path), logger.F("txs", txsToIndex))
 
 		idx.stateCond.L.Lock()
 		for {
@@ -341,7 +398,7 @@ func (idx *indexer) doIndexing(cancellation <-chan struct{}) {
 			return
 		}
 		if err != nil {
-			idx.store.log.Errorf("Indexing failed at '%s' due to error: %v", idx.store.path, err)
+			idx.store.log.Error("Indexing failed", logger.F("path", idx.store.path), logger.F("error", err))
 			time.Sleep(60 * time.Second)
 		}
 	}
@@ -363,7 +420,13 @@ func (idx *indexer) indexTX(txID uint64) error {
 
 	txmdLen := len(txmd)
 
-	for i, e := range txEntries {
+	indexableEntries := 0
+
+	for _, e := range txEntries {
+		if e.md != nil && e.md.NonIndexable() {
+			continue
+		}
+
 		// vLen + vOff + vHash + txmdLen + txmd + kvmdLen + kvmd
 		var b [lszSize + offsetSize + sha256.Size + sszSize + maxTxMetadataLen + sszSize + maxKVMetadataLen]byte
 		o := 0
@@ -397,16 +460,44 @@ func (idx *indexer) indexTX(txID uint64) error {
 		copy(b[o:], kvmd)
 		o += kvmdLen
 
-		idx.store._kvs[i].K = e.key()
-		idx.store._kvs[i].V = b[:o]
+		idx.store._kvs[indexableEntries].K = idx.store.collation.indexedKey(e.key())
+		idx.store._kvs[indexableEntries].V = b[:o]
+
+		indexableEntries++
 	}
 
-	err = idx.index.BulkInsert(idx.store._kvs[:len(txEntries)])
+	if indexableEntries == 0 {
+		// the index must still reflect the tx has been processed
+		err = idx.index.IncreaseTs(txID)
+	} else {
+		err = idx.index.BulkInsert(idx.store._kvs[:indexableEntries])
+	}
 	if err != nil {
 		return err
 	}
 
 	idx.metricsLastIndexedTrx.Set(float64(txID))
 
+	return idx.checkpoint(txID)
+}
+
+// checkpoint syncs the index once checkpointThld transactions were indexed since it was last synced,
+// so indexing is not restarted from far behind if the store is not gracefully closed
+func (idx *indexer) checkpoint(txID uint64) error {
+	if idx.checkpointThld == 0 || txID-idx.index.SyncedTs() < uint64(idx.checkpointThld) {
+		return nil
+	}
+
+	err := idx.index.Checkpoint()
+	if err != nil {
+		return err
+	}
+
+	idx.progressMutex.Lock()
+	idx.checkpointedAt = time.Now()
+	idx.progressMutex.Unlock()
+
+	idx.store.notify(Info, false, "Indexing checkpoint", logger.F("path", idx.store.path), logger.F("tx", txID))
+
 	return nil
 }
@@ -16,6 +16,7 @@ limitations under the License.
 package store
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -29,7 +30,7 @@ import (
 )
 
 func TestNewIndexerFailure(t *testing.T) {
-	indexer, err := newIndexer("data", nil, nil, 0)
+	indexer, err := newIndexer("data", nil, nil, 0, 0)
 	require.Nil(t, indexer)
 	require.Equal(t, tbtree.ErrIllegalArguments, err)
 }
@@ -207,7 +208,88 @@ func TestClosedIndexer(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, err, ErrAlreadyClosed)
 
+	_, err = i.Progress()
+	assert.Error(t, err)
+	assert.Equal(t, err, ErrAlreadyClosed)
+
 	err = i.Close()
 	assert.Error(t, err)
 	assert.Equal(t, err, ErrAlreadyClosed)
 }
+
+func TestIndexingProgress(t *testing.T) {
+	commitTxs := func(t *testing.T, immuStore *ImmuStore, from, to int) {
+		for i := from; i < to; i++ {
+			tx, err := immuStore.NewWriteOnlyTx()
+			require.NoError(t, err)
+
+			err = tx.Set([]byte(fmt.Sprintf("key%d", i)), nil, []byte(fmt.Sprintf("value%d", i)))
+			require.NoError(t, err)
+
+			_, err = tx.Commit()
+			require.NoError(t, err)
+		}
+
+		err := immuStore.WaitForIndexingUpto(uint64(to), nil)
+		require.NoError(t, err)
+	}
+
+	t.Run("indexing should be resumed from the last checkpoint", func(t *testing.T) {
+		dir := t.TempDir()
+
+		opts := DefaultOptions().WithIndexOptions(DefaultIndexOptions().WithCheckpointThld(10))
+
+		immuStore, err := Open(dir, opts)
+		require.NoError(t, err)
+
+		commitTxs(t, immuStore, 0, 25)
+
+		progress, err := immuStore.IndexingProgress()
+		require.NoError(t, err)
+		require.Equal(t, uint64(25), progress.CommittedTxID)
+		require.Equal(t, uint64(25), progress.IndexedTxID)
+		require.Equal(t, uint64(20), progress.CheckpointTxID)
+		require.False(t, progress.CheckpointedAt.IsZero())
+		require.Zero(t, progress.ResumedFromTxID)
+
+		// the store is opened again without being closed, as if the process crashed
+		reopenedStore, err := Open(dir, opts)
+		require.NoError(t, err)
+		defer reopenedStore.Close()
+
+		progress, err = reopenedStore.IndexingProgress()
+		require.NoError(t, err)
+		require.Equal(t, uint64(20), progress.ResumedFromTxID)
+
+		err = reopenedStore.WaitForIndexingUpto(25, nil)
+		require.NoError(t, err)
+
+		valRef, err := reopenedStore.Get([]byte("key24"))
+		require.NoError(t, err)
+		require.Equal(t, uint64(25), valRef.Tx())
+	})
+
+	t.Run("indexing should be restarted from scratch without checkpoints", func(t *testing.T) {
+		dir := t.TempDir()
+
+		opts := DefaultOptions().WithIndexOptions(DefaultIndexOptions().WithCheckpointThld(0))
+
+		immuStore, err := Open(dir, opts)
+		require.NoError(t, err)
+
+		commitTxs(t, immuStore, 0, 25)
+
+		progress, err := immuStore.IndexingProgress()
+		require.NoError(t, err)
+		require.Zero(t, progress.CheckpointTxID)
+		require.True(t, progress.CheckpointedAt.IsZero())
+
+		reopenedStore, err := Open(dir, opts)
+		require.NoError(t, err)
+		defer reopenedStore.Close()
+
+		progress, err = reopenedStore.IndexingProgress()
+		require.NoError(t, err)
+		require.Zero(t, progress.ResumedFromTxID)
+	})
+}
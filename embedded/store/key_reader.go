@@ -42,15 +42,22 @@ var (
 		return md != nil && md.Deleted()
 	}
 
+	// IgnoreExpired ignores values discarded by truncation as well
 	IgnoreExpired FilterFn = func(valRef ValueRef, t time.Time) bool {
 		md := valRef.KVMetadata()
-		return md != nil && md.ExpiredAt(t)
+		if md != nil && md.ExpiredAt(t) {
+			return true
+		}
+
+		vref, ok := valRef.(*valueRef)
+		return ok && vref.truncated()
 	}
 )
 
 type KeyReader struct {
 	snap           *Snapshot
 	reader         *tbtree.Reader
+	prefix         []byte // set when read keys need to be checked against the prefix, see keyCollation
 	filter         FilterFn
 	refInterceptor valueRefInterceptor
 	_tx            *Tx
@@ -67,7 +74,7 @@ type KeyReaderSpec struct {
 }
 
 func (s *Snapshot) set(key, value []byte) error {
-	return s.snap.Set(key, value)
+	return s.snap.Set(s.st.collation.indexedKey(key), value)
 }
 
 func (s *Snapshot) Get(key []byte) (valRef ValueRef, err error) {
@@ -75,7 +82,7 @@ func (s *Snapshot) Get(key []byte) (valRef ValueRef, err error) {
 }
 
 func (s *Snapshot) GetWith(key []byte, filters ...FilterFn) (valRef ValueRef, err error) {
-	indexedVal, tx, hc, err := s.snap.Get(key)
+	indexedVal, tx, hc, err := s.snap.Get(s.st.collation.indexedKey(key))
 	if err != nil {
 		return nil, err
 	}
@@ -103,11 +110,15 @@ func (s *Snapshot) GetWith(key []byte, filters ...FilterFn) (valRef ValueRef, er
 }
 
 func (s *Snapshot) ExistKeyWith(prefix []byte, neq []byte) (bool, error) {
-	return s.snap.ExistKeyWith(prefix, neq)
+	if s.st.collation == nil {
+		return s.snap.ExistKeyWith(prefix, neq)
+	}
+
+	return s.st.collation.existKeyWith(s.snap, prefix, neq)
 }
 
 func (s *Snapshot) History(key []byte, offset uint64, descOrder bool, limit int) (tss []uint64, err error) {
-	return s.snap.History(key, offset, descOrder, limit)
+	return s.snap.History(s.st.collation.indexedKey(key), offset, descOrder, limit)
 }
 
 func (s *Snapshot) Ts() uint64 {
@@ -123,10 +134,12 @@ func (s *Snapshot) NewKeyReader(spec *KeyReaderSpec) (*KeyReader, error) {
 		return nil, ErrIllegalArguments
 	}
 
+	prefix, filtered := s.st.collation.indexedPrefix(spec.Prefix)
+
 	r, err := s.snap.NewReader(&tbtree.ReaderSpec{
-		SeekKey:       spec.SeekKey,
-		EndKey:        spec.EndKey,
-		Prefix:        spec.Prefix,
+		SeekKey:       s.st.collation.indexedKey(spec.SeekKey),
+		EndKey:        s.st.collation.indexedKey(spec.EndKey),
+		Prefix:        prefix,
 		InclusiveSeek: spec.InclusiveSeek,
 		InclusiveEnd:  spec.InclusiveEnd,
 		DescOrder:     spec.DescOrder,
@@ -145,13 +158,19 @@ func (s *Snapshot) NewKeyReader(spec *KeyReaderSpec) (*KeyReader, error) {
 		refInterceptor = s.refInterceptor
 	}
 
-	return &KeyReader{
+	kr := &KeyReader{
 		snap:           s,
 		reader:         r,
 		filter:         spec.Filter,
 		refInterceptor: refInterceptor,
 		_tx:            s.st.NewTxHolder(),
-	}, nil
+	}
+
+	if filtered {
+		kr.prefix = spec.Prefix
+	}
+
+	return kr, nil
 }
 
 type ValueRef interface {
@@ -290,19 +309,85 @@ func (v *valueRef) Len() uint32 {
 }
 
 func (r *KeyReader) ReadAsBefore(txID uint64) (key []byte, val ValueRef, tx uint64, err error) {
-	key, ktxID, hc, err := r.reader.ReadAsBefore(txID)
-	if err != nil {
-		return nil, nil, 0, err
+	for {
+		ikey, ktxID, hc, err := r.reader.ReadAsBefore(txID)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		key, err := r.snap.st.collation.key(ikey)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		if r.prefix != nil && !bytes.HasPrefix(key, r.prefix) {
+			continue
+		}
+
+		val, err = r.valueRefAt(key, ktxID, hc)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		// as with Read, expired and filtered entries (e.g. deleted ones) are skipped
+		if IgnoreExpired(val, r.snap.ts) {
+			continue
+		}
+
+		if r.filter != nil && r.filter(val, r.snap.ts) {
+			continue
+		}
+
+		return key, r.refInterceptor(key, val), ktxID, nil
+	}
+}
+
+// ReadChanged returns the next key updated after sinceTx and up to untilTx, along with
+// the values it had at both transactions. prev is nil when the key did not exist at sinceTx.
+// Unlike Read, neither the filter nor expiration are applied, so deletions can be detected
+func (r *KeyReader) ReadChanged(sinceTx, untilTx uint64) (key []byte, prev, curr ValueRef, err error) {
+	for {
+		ikey, prevTx, prevHc, currTx, currHc, err := r.reader.ReadChanged(sinceTx, untilTx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		key, err := r.snap.st.collation.key(ikey)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if r.prefix != nil && !bytes.HasPrefix(key, r.prefix) {
+			continue
+		}
+
+		if prevTx > 0 {
+			prev, err = r.valueRefAt(key, prevTx, prevHc)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			prev = r.refInterceptor(key, prev)
+		}
+
+		curr, err = r.valueRefAt(key, currTx, currHc)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return key, prev, r.refInterceptor(key, curr), nil
 	}
+}
 
-	err = r.snap.st.ReadTx(ktxID, r._tx)
+func (r *KeyReader) valueRefAt(key []byte, txID, hc uint64) (ValueRef, error) {
+	err := r.snap.st.ReadTx(txID, r._tx)
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, err
 	}
 
 	for _, e := range r._tx.Entries() {
 		if bytes.Equal(e.key(), key) {
-			val = &valueRef{
+			return &valueRef{
 				tx:     r._tx.header.ID,
 				hc:     hc,
 				hVal:   e.hVal,
@@ -311,30 +396,29 @@ func (r *KeyReader) ReadAsBefore(txID uint64) (key []byte, val ValueRef, tx uint
 				txmd:   r._tx.header.Metadata,
 				kvmd:   e.md,
 				st:     r.snap.st,
-			}
-
-			if IgnoreExpired(val, r.snap.ts) {
-				return nil, nil, 0, ErrExpiredEntry
-			}
-
-			if r.filter != nil && r.filter(val, r.snap.ts) {
-				return nil, nil, 0, ErrKeyNotFound
-			}
-
-			return key, r.refInterceptor(key, val), ktxID, nil
+			}, nil
 		}
 	}
 
-	return nil, nil, 0, ErrUnexpectedError
+	return nil, ErrUnexpectedError
 }
 
 func (r *KeyReader) Read() (key []byte, val ValueRef, err error) {
 	for {
-		key, indexedVal, tx, hc, err := r.reader.Read()
+		ikey, indexedVal, tx, hc, err := r.reader.Read()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		key, err := r.snap.st.collation.key(ikey)
 		if err != nil {
 			return nil, nil, err
 		}
 
+		if r.prefix != nil && !bytes.HasPrefix(key, r.prefix) {
+			continue
+		}
+
 		val, err = r.snap.st.valueRefFrom(tx, hc, indexedVal)
 		if err != nil {
 			return nil, nil, err
@@ -142,3 +142,75 @@ func TestImmudbStoreReaderAsBefore(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestImmudbStoreReaderChanged(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(4)
+	immuStore, err := Open("data_store_reader_changed", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_store_reader_changed")
+
+	commit := func(f func(tx *OngoingTx)) {
+		tx, err := immuStore.NewTx()
+		require.NoError(t, err)
+
+		f(tx)
+
+		_, err = tx.Commit()
+		require.NoError(t, err)
+	}
+
+	commit(func(tx *OngoingTx) {
+		require.NoError(t, tx.Set([]byte("k1"), nil, []byte("v1")))
+		require.NoError(t, tx.Set([]byte("k2"), nil, []byte("v1")))
+		require.NoError(t, tx.Set([]byte("k3"), nil, []byte("v1")))
+	})
+
+	commit(func(tx *OngoingTx) {
+		require.NoError(t, tx.Set([]byte("k4"), nil, []byte("v2")))
+	})
+
+	commit(func(tx *OngoingTx) {
+		require.NoError(t, tx.Set([]byte("k1"), nil, []byte("v3")))
+		require.NoError(t, tx.Delete([]byte("k2")))
+	})
+
+	commit(func(tx *OngoingTx) {
+		require.NoError(t, tx.Set([]byte("k3"), nil, []byte("v4")))
+	})
+
+	err = immuStore.WaitForIndexingUpto(4, nil)
+	require.NoError(t, err)
+
+	snap, err := immuStore.SnapshotSince(4)
+	require.NoError(t, err)
+	defer snap.Close()
+
+	reader, err := snap.NewKeyReader(&KeyReaderSpec{Filter: IgnoreDeleted})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	key, prev, curr, err := reader.ReadChanged(1, 3)
+	require.NoError(t, err)
+	require.Equal(t, []byte("k1"), key)
+	require.Equal(t, uint64(1), prev.Tx())
+	require.Equal(t, uint64(3), curr.Tx())
+
+	val, err := curr.Resolve()
+	require.NoError(t, err)
+	require.Equal(t, []byte("v3"), val)
+
+	key, prev, curr, err = reader.ReadChanged(1, 3)
+	require.NoError(t, err)
+	require.Equal(t, []byte("k2"), key)
+	require.Equal(t, uint64(1), prev.Tx())
+	require.True(t, curr.KVMetadata().Deleted())
+
+	key, prev, curr, err = reader.ReadChanged(1, 3)
+	require.NoError(t, err)
+	require.Equal(t, []byte("k4"), key)
+	require.Nil(t, prev)
+	require.Equal(t, uint64(2), curr.Tx())
+
+	_, _, _, err = reader.ReadChanged(1, 3)
+	require.ErrorIs(t, err, ErrNoMoreEntries)
+}
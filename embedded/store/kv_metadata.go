@@ -27,14 +27,16 @@ var ErrNonExpirable = errors.New("non expirable")
 var ErrReadOnly = errors.New("read-only")
 
 const (
-	deletedAttrCode   attributeCode = 0
-	expiresAtAttrCode attributeCode = 1
+	deletedAttrCode      attributeCode = 0
+	expiresAtAttrCode    attributeCode = 1
+	nonIndexableAttrCode attributeCode = 2
 )
 
 const deletedAttrSize = 0
 const expiresAtAttrSize = tsSize
+const nonIndexableAttrSize = 0
 
-const maxKVMetadataLen = (attrCodeSize + deletedAttrSize) + (attrCodeSize + expiresAtAttrSize)
+const maxKVMetadataLen = (attrCodeSize + deletedAttrSize) + (attrCodeSize + expiresAtAttrSize) + (attrCodeSize + nonIndexableAttrSize)
 
 type KVMetadata struct {
 	attributes map[attributeCode]attribute
@@ -80,6 +82,21 @@ func (a *expiresAtAttribute) deserialize(b []byte) (int, error) {
 	return tsSize, nil
 }
 
+type nonIndexableAttribute struct {
+}
+
+func (a *nonIndexableAttribute) code() attributeCode {
+	return nonIndexableAttrCode
+}
+
+func (a *nonIndexableAttribute) serialize() []byte {
+	return nil
+}
+
+func (a *nonIndexableAttribute) deserialize(b []byte) (int, error) {
+	return 0, nil
+}
+
 func NewKVMetadata() *KVMetadata {
 	return &KVMetadata{
 		attributes: make(map[attributeCode]attribute),
@@ -116,6 +133,31 @@ func (md *KVMetadata) Deleted() bool {
 	return ok
 }
 
+// AsNonIndexable marks the entry to be excluded from the index,
+// so it can only be accessed through the transaction it was written in
+func (md *KVMetadata) AsNonIndexable(nonIndexable bool) error {
+	if md.readonly {
+		return ErrReadOnly
+	}
+
+	if !nonIndexable {
+		delete(md.attributes, nonIndexableAttrCode)
+		return nil
+	}
+
+	_, ok := md.attributes[nonIndexableAttrCode]
+	if !ok {
+		md.attributes[nonIndexableAttrCode] = &nonIndexableAttribute{}
+	}
+
+	return nil
+}
+
+func (md *KVMetadata) NonIndexable() bool {
+	_, ok := md.attributes[nonIndexableAttrCode]
+	return ok
+}
+
 func (md *KVMetadata) ExpiresAt(expiresAt time.Time) error {
 	if md.readonly {
 		return ErrReadOnly
@@ -163,7 +205,7 @@ func (md *KVMetadata) ExpiredAt(mtime time.Time) bool {
 func (md *KVMetadata) Bytes() []byte {
 	var b bytes.Buffer
 
-	for _, attrCode := range []attributeCode{deletedAttrCode, expiresAtAttrCode} {
+	for _, attrCode := range []attributeCode{deletedAttrCode, expiresAtAttrCode, nonIndexableAttrCode} {
 		attr, ok := md.attributes[attrCode]
 		if ok {
 			b.WriteByte(byte(attr.code()))
@@ -221,6 +263,10 @@ func newAttribute(attrCode attributeCode) (attribute, error) {
 		{
 			return &expiresAtAttribute{}, nil
 		}
+	case nonIndexableAttrCode:
+		{
+			return &nonIndexableAttribute{}, nil
+		}
 	default:
 		{
 			return nil, fmt.Errorf("error reading metadata attributes: %w", ErrCorruptedData)
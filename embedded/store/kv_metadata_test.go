@@ -55,6 +55,9 @@ func TestKVMetadata(t *testing.T) {
 
 		err = desmd.ExpiresAt(now)
 		require.ErrorIs(t, err, ErrReadOnly)
+
+		err = desmd.AsNonIndexable(true)
+		require.ErrorIs(t, err, ErrReadOnly)
 	})
 
 	desmd := NewKVMetadata()
@@ -83,6 +86,12 @@ func TestKVMetadata(t *testing.T) {
 	require.Equal(t, now, expTime)
 	require.True(t, desmd.ExpiredAt(now))
 
+	desmd.AsNonIndexable(false)
+	require.False(t, desmd.NonIndexable())
+
+	desmd.AsNonIndexable(true)
+	require.True(t, desmd.NonIndexable())
+
 	bs = desmd.Bytes()
 	require.NotNil(t, bs)
 	require.Len(t, bs, maxKVMetadataLen)
@@ -92,4 +101,5 @@ func TestKVMetadata(t *testing.T) {
 	require.True(t, desmd.Deleted())
 	require.True(t, desmd.IsExpirable())
 	require.True(t, desmd.ExpiredAt(now))
+	require.True(t, desmd.NonIndexable())
 }
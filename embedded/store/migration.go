@@ -0,0 +1,134 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/codenotary/immudb/embedded/appendable/singleapp"
+	"github.com/codenotary/immudb/embedded/logger"
+)
+
+const migrationsDirname = ".migrations"
+
+// layoutMigrations holds, indexed by the format version they upgrade from, the changes required by stores
+// created by previous versions. Nil entries stand for versions only differing in the header of appendables,
+// such headers are upgraded when read so existing files don't need to be rewritten
+var layoutMigrations = []func(path, backupPath string) error{
+	// 0 -> 1: the layout is unchanged, only the format version gets recorded
+	nil,
+}
+
+// migrate upgrades in place the layout of a store created by a previous version. The format version of the
+// layout is the one recorded in the first file of the commit log, which is the only file whose header gets
+// rewritten once all the migrations were applied, a copy of its superseded header is kept under the
+// migrations folder of the store. Thus an interrupted migration is resumed on the next opening
+func migrate(path string, log logger.Logger) error {
+	markerFileName := migrationMarker(path)
+
+	_, err := os.Stat(markerFileName)
+	if os.IsNotExist(err) {
+		// store without commits yet, there is nothing to upgrade
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	marker, err := singleapp.Open(markerFileName, singleapp.DefaultOptions().WithReadOnly(true))
+	if err != nil {
+		return fmt.Errorf("unable to read the format version of the store: %w", err)
+	}
+
+	version := marker.FormatVersion()
+
+	err = marker.Close()
+	if err != nil {
+		return err
+	}
+
+	if !requiresMigration(version) {
+		return nil
+	}
+
+	log.Info("Upgrading the layout...", logger.F("path", path), logger.F("from_version", version), logger.F("to_version", singleapp.FormatVersion))
+
+	backupPath := filepath.Join(path, migrationsDirname, fmt.Sprintf("v%d", version))
+
+	err = os.MkdirAll(backupPath, 0700)
+	if err != nil {
+		return err
+	}
+
+	for v := version; v < singleapp.FormatVersion; v++ {
+		if layoutMigrations[v] == nil {
+			continue
+		}
+
+		err = layoutMigrations[v](path, backupPath)
+		if err != nil {
+			return fmt.Errorf("unable to upgrade the layout from format version %d: %w", v, err)
+		}
+	}
+
+	err = upgradeAppendableHeader(path, markerFileName, backupPath)
+	if err != nil {
+		return fmt.Errorf("unable to upgrade '%s': %w", markerFileName, err)
+	}
+
+	log.Info("Layout successfully upgraded", logger.F("path", path), logger.F("version", singleapp.FormatVersion))
+
+	return nil
+}
+
+// requiresMigration returns true if the layout of a store with the format version has to be changed
+func requiresMigration(version int) bool {
+	for v := version; v < singleapp.FormatVersion; v++ {
+		if layoutMigrations[v] != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func migrationMarker(path string) string {
+	return filepath.Join(path, "commit", fmt.Sprintf("%08d.txi", 0))
+}
+
+func upgradeAppendableHeader(path, fileName, backupPath string) error {
+	relPath, err := filepath.Rel(path, fileName)
+	if err != nil {
+		return err
+	}
+
+	headerFileName := filepath.Join(backupPath, relPath+".header")
+
+	_, err = singleapp.Upgrade(fileName, filepath.Join(backupPath, "upgrading.tmp"), func(header []byte) error {
+		err := os.MkdirAll(filepath.Dir(headerFileName), 0700)
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(headerFileName, header, 0600)
+	})
+
+	return err
+}
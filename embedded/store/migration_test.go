@@ -0,0 +1,205 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/appendable"
+	"github.com/codenotary/immudb/embedded/appendable/singleapp"
+	"github.com/stretchr/testify/require"
+)
+
+// downgradeAppendableHeader rewrites the header of the file as written before the format version was recorded
+func downgradeAppendableHeader(t *testing.T, fileName string) {
+	bs, err := ioutil.ReadFile(fileName)
+	require.NoError(t, err)
+
+	mLen := binary.BigEndian.Uint32(bs)
+	m := appendable.NewMetadata(bs[4 : 4+mLen])
+
+	legacy := appendable.NewMetadata(nil)
+	for _, key := range []string{"COMPRESSION_FORMAT", "COMPRESSION_LEVEL", "WRAPPED_METADATA"} {
+		v, ok := m.Get(key)
+		require.True(t, ok)
+		legacy.Put(key, v)
+	}
+
+	legacyBs := legacy.Bytes()
+
+	header := make([]byte, 4+len(legacyBs))
+	binary.BigEndian.PutUint32(header, uint32(len(legacyBs)))
+	copy(header[4:], legacyBs)
+
+	err = ioutil.WriteFile(fileName, append(header, bs[4+mLen:]...), 0644)
+	require.NoError(t, err)
+}
+
+func formatVersionOf(t *testing.T, fileName string) int {
+	app, err := singleapp.Open(fileName, singleapp.DefaultOptions().WithReadOnly(true))
+	require.NoError(t, err)
+	defer app.Close()
+
+	return app.FormatVersion()
+}
+
+// appendableFileName matches the names of the files multi-file appendables are made of
+var appendableFileName = regexp.MustCompile(`^[0-9]{8}\.[a-z]+$`)
+
+// storeFiles returns the files of the appendables of the store
+func storeFiles(t *testing.T, path string) []string {
+	var fileNames []string
+
+	err := filepath.Walk(path, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if info.Name() == migrationsDirname {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if appendableFileName.MatchString(info.Name()) {
+			fileNames = append(fileNames, fileName)
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return fileNames
+}
+
+func TestImmudbStoreLayoutMigration(t *testing.T) {
+	path := t.TempDir()
+
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
+
+	immuStore, err := Open(path, opts)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		tx, err := immuStore.NewWriteOnlyTx()
+		require.NoError(t, err)
+
+		err = tx.Set([]byte(fmt.Sprintf("key%d", i)), nil, []byte(fmt.Sprintf("value%d", i)))
+		require.NoError(t, err)
+
+		_, err = tx.Commit()
+		require.NoError(t, err)
+	}
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	fileNames := storeFiles(t, path)
+	require.Contains(t, fileNames, migrationMarker(path))
+
+	for _, fileName := range fileNames {
+		downgradeAppendableHeader(t, fileName)
+		require.Equal(t, 0, formatVersionOf(t, fileName))
+	}
+
+	t.Run("legacy stores should be opened without rewriting their files", func(t *testing.T) {
+		immuStore, err := Open(path, opts)
+		require.NoError(t, err)
+
+		for i := 0; i < 10; i++ {
+			valRef, err := immuStore.Get([]byte(fmt.Sprintf("key%d", i)))
+			require.NoError(t, err)
+
+			v, err := valRef.Resolve()
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value%d", i)), v)
+		}
+
+		tx, err := immuStore.NewWriteOnlyTx()
+		require.NoError(t, err)
+
+		err = tx.Set([]byte("key10"), nil, []byte("value10"))
+		require.NoError(t, err)
+
+		hdr, err := tx.Commit()
+		require.NoError(t, err)
+		require.Equal(t, uint64(11), hdr.ID)
+
+		err = immuStore.Close()
+		require.NoError(t, err)
+
+		for _, fileName := range fileNames {
+			require.Equal(t, 0, formatVersionOf(t, fileName))
+		}
+
+		require.NoDirExists(t, filepath.Join(path, migrationsDirname))
+	})
+
+	var migrated int
+
+	layoutMigrations[0] = func(path, backupPath string) error {
+		migrated++
+
+		if migrated == 1 {
+			return errors.New("interrupted migration")
+		}
+
+		return nil
+	}
+	defer func() { layoutMigrations[0] = nil }()
+
+	t.Run("an interrupted migration should be resumed", func(t *testing.T) {
+		_, err := Open(path, opts)
+		require.Error(t, err)
+		require.Equal(t, 0, formatVersionOf(t, migrationMarker(path)))
+
+		immuStore, err := Open(path, opts)
+		require.NoError(t, err)
+
+		err = immuStore.Close()
+		require.NoError(t, err)
+
+		require.Equal(t, 2, migrated)
+		require.Equal(t, singleapp.FormatVersion, formatVersionOf(t, migrationMarker(path)))
+		require.FileExists(t, filepath.Join(path, migrationsDirname, "v0", "commit", "00000000.txi.header"))
+
+		// only the header of the marker is rewritten
+		for _, fileName := range fileNames {
+			if fileName != migrationMarker(path) {
+				require.Equal(t, 0, formatVersionOf(t, fileName))
+			}
+		}
+	})
+
+	t.Run("an upgraded store should not be migrated again", func(t *testing.T) {
+		immuStore, err := Open(path, opts)
+		require.NoError(t, err)
+
+		err = immuStore.Close()
+		require.NoError(t, err)
+
+		require.Equal(t, 2, migrated)
+	})
+}
@@ -46,18 +46,27 @@ func newWriteOnlyTx(s *ImmuStore) (*OngoingTx, error) {
 	}, nil
 }
 
-func newReadWriteTx(s *ImmuStore) (*OngoingTx, error) {
+func newReadWriteTx(s *ImmuStore, waitForIndexing bool, cancellation <-chan struct{}) (*OngoingTx, error) {
 	tx := &OngoingTx{
 		st:           s,
 		entriesByKey: make(map[[sha256.Size]byte]int),
 	}
 
-	err := s.WaitForIndexingUpto(s.committedTxID, nil)
-	if err != nil {
-		return nil, err
+	snapTx := s.committedTxID
+
+	if waitForIndexing {
+		err := s.WaitForIndexingUpto(snapTx, cancellation)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// freshest already indexed snapshot
+		snapTx = s.indexer.Ts()
 	}
 
-	tx.snap, err = s.SnapshotSince(s.committedTxID)
+	var err error
+
+	tx.snap, err = s.SnapshotSince(snapTx)
 	if err != nil {
 		return nil, err
 	}
@@ -122,9 +131,18 @@ func (tx *OngoingTx) IsWriteOnly() bool {
 	return tx.snap == nil
 }
 
+// SnapshotTs returns the id of the last transaction reflected by the snapshot the transaction reads from,
+// being zero for write-only transactions
+func (tx *OngoingTx) SnapshotTs() uint64 {
+	if tx.snap == nil {
+		return 0
+	}
+	return tx.snap.Ts()
+}
+
 func (tx *OngoingTx) WithMetadata(md *TxMetadata) *OngoingTx {
 	tx.metadata = md
-	return nil
+	return tx
 }
 
 func (tx *OngoingTx) Metadata() *TxMetadata {
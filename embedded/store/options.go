@@ -21,8 +21,9 @@ import (
 
 	"github.com/codenotary/immudb/embedded/appendable"
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
+	"github.com/codenotary/immudb/embedded/logger"
 	"github.com/codenotary/immudb/embedded/tbtree"
-	"github.com/codenotary/immudb/pkg/logger"
+	pkglogger "github.com/codenotary/immudb/pkg/logger"
 )
 
 const DefaultMaxConcurrency = 30
@@ -40,6 +41,8 @@ const DefaultMaxWaitees = 1000
 const DefaultVLogMaxOpenedFiles = 10
 const DefaultTxLogMaxOpenedFiles = 10
 const DefaultCommitLogMaxOpenedFiles = 10
+const DefaultMaxRepairedTxs = 1000
+const DefaultIndexCheckpointThld = 1000
 
 const MaxFileSize = (1 << 31) - 1 // 2Gb
 
@@ -72,6 +75,9 @@ type Options struct {
 
 	MaxWaitees int
 
+	// MaxRepairedTxs bounds the number of incomplete transactions rolled back when opening the store, 0 disables the repair
+	MaxRepairedTxs int
+
 	TimeFunc TimeFunc
 
 	// options below are only set during initialization and stored as metadata
@@ -82,6 +88,10 @@ type Options struct {
 	CompressionFormat int
 	CompressionLevel  int
 
+	// Collation orders the keys starting with CollationPrefix in the index, bytewise when it's not set
+	Collation       Collation
+	CollationPrefix []byte
+
 	// options below affect indexing
 	IndexOpts *IndexOptions
 }
@@ -99,6 +109,16 @@ type IndexOptions struct {
 	NodesLogMaxOpenedFiles   int
 	HistoryLogMaxOpenedFiles int
 	CommitLogMaxOpenedFiles  int
+
+	// WAL logs the indexed entries not yet synced, so they're not indexed again after a crash
+	WAL bool
+
+	// CheckpointThld is the number of indexed transactions after which the index is synced,
+	// indexing is resumed from the last checkpoint after a restart. Checkpoints are disabled when it's zero
+	CheckpointThld int
+
+	// BufferPool holds the index nodes within a memory budget shared with other stores, CacheSize is ignored when it's set
+	BufferPool *tbtree.BufferPool
 }
 
 func DefaultOptions() *Options {
@@ -106,7 +126,7 @@ func DefaultOptions() *Options {
 		ReadOnly: false,
 		Synced:   true,
 		FileMode: DefaultFileMode,
-		log:      logger.NewSimpleLogger("immudb ", os.Stderr),
+		log:      logger.NewPrintfLogger(pkglogger.NewSimpleLogger("immudb ", os.Stderr)),
 
 		MaxConcurrency:    DefaultMaxConcurrency,
 		MaxIOConcurrency:  DefaultMaxIOConcurrency,
@@ -120,6 +140,8 @@ func DefaultOptions() *Options {
 
 		MaxWaitees: DefaultMaxWaitees,
 
+		MaxRepairedTxs: DefaultMaxRepairedTxs,
+
 		TimeFunc: func() time.Time {
 			return time.Now()
 		},
@@ -149,6 +171,7 @@ func DefaultIndexOptions() *IndexOptions {
 		NodesLogMaxOpenedFiles:   tbtree.DefaultNodesLogMaxOpenedFiles,
 		HistoryLogMaxOpenedFiles: tbtree.DefaultHistoryLogMaxOpenedFiles,
 		CommitLogMaxOpenedFiles:  tbtree.DefaultCommitLogMaxOpenedFiles,
+		CheckpointThld:           DefaultIndexCheckpointThld,
 	}
 }
 
@@ -167,6 +190,8 @@ func validOptions(opts *Options) bool {
 
 		opts.MaxWaitees >= 0 &&
 
+		opts.MaxRepairedTxs >= 0 &&
+
 		opts.TimeFunc != nil &&
 
 		// options below are only set during initialization and stored as metadata
@@ -189,7 +214,8 @@ func validIndexOptions(opts *IndexOptions) bool {
 		opts.RenewSnapRootAfter >= 0 &&
 		opts.NodesLogMaxOpenedFiles > 0 &&
 		opts.HistoryLogMaxOpenedFiles > 0 &&
-		opts.CommitLogMaxOpenedFiles > 0
+		opts.CommitLogMaxOpenedFiles > 0 &&
+		opts.CheckpointThld >= 0
 }
 
 func (opts *Options) WithReadOnly(readOnly bool) *Options {
@@ -207,11 +233,23 @@ func (opts *Options) WithFileMode(fileMode os.FileMode) *Options {
 	return opts
 }
 
-func (opts *Options) WithLog(log logger.Logger) *Options {
+// WithLogger sets the logger receiving the events of the store and its index
+func (opts *Options) WithLogger(log logger.Logger) *Options {
 	opts.log = log
 	return opts
 }
 
+// WithLog is the same as WithLogger but events are written as formatted messages
+func (opts *Options) WithLog(log logger.PrintfLogger) *Options {
+	if log == nil {
+		opts.log = nil
+		return opts
+	}
+
+	opts.log = logger.NewPrintfLogger(log)
+	return opts
+}
+
 func (opts *Options) WithAppFactory(appFactory AppFactoryFunc) *Options {
 	opts.appFactory = appFactory
 	return opts
@@ -282,6 +320,11 @@ func (opts *Options) WithMaxWaitees(maxWaitees int) *Options {
 	return opts
 }
 
+func (opts *Options) WithMaxRepairedTxs(maxRepairedTxs int) *Options {
+	opts.MaxRepairedTxs = maxRepairedTxs
+	return opts
+}
+
 func (opts *Options) WithTimeFunc(timeFunc TimeFunc) *Options {
 	opts.TimeFunc = timeFunc
 	return opts
@@ -297,6 +340,13 @@ func (opts *Options) WithCompresionLevel(compressionLevel int) *Options {
 	return opts
 }
 
+// WithCollation sets the collation used to order in the index the keys starting with the given prefix
+func (opts *Options) WithCollation(prefix []byte, collation Collation) *Options {
+	opts.CollationPrefix = prefix
+	opts.Collation = collation
+	return opts
+}
+
 func (opts *Options) WithIndexOptions(indexOptions *IndexOptions) *Options {
 	opts.IndexOpts = indexOptions
 	return opts
@@ -309,6 +359,11 @@ func (opts *IndexOptions) WithCacheSize(cacheSize int) *IndexOptions {
 	return opts
 }
 
+func (opts *IndexOptions) WithBufferPool(bufferPool *tbtree.BufferPool) *IndexOptions {
+	opts.BufferPool = bufferPool
+	return opts
+}
+
 func (opts *IndexOptions) WithFlushThld(flushThld int) *IndexOptions {
 	opts.FlushThld = flushThld
 	return opts
@@ -349,6 +404,16 @@ func (opts *IndexOptions) WithSynced(synced bool) *IndexOptions {
 	return opts
 }
 
+func (opts *IndexOptions) WithWAL(wal bool) *IndexOptions {
+	opts.WAL = wal
+	return opts
+}
+
+func (opts *IndexOptions) WithCheckpointThld(checkpointThld int) *IndexOptions {
+	opts.CheckpointThld = checkpointThld
+	return opts
+}
+
 func (opts *IndexOptions) WithNodesLogMaxOpenedFiles(nodesLogMaxOpenedFiles int) *IndexOptions {
 	opts.NodesLogMaxOpenedFiles = nodesLogMaxOpenedFiles
 	return opts
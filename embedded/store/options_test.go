@@ -16,11 +16,13 @@ limitations under the License.
 package store
 
 import (
+	"os"
 	"testing"
 	"time"
 
 	"github.com/codenotary/immudb/embedded/appendable"
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
+	pkglogger "github.com/codenotary/immudb/pkg/logger"
 	"github.com/stretchr/testify/require"
 )
 
@@ -65,7 +67,10 @@ func TestValidOptions(t *testing.T) {
 
 	require.False(t, opts.IndexOpts.WithSynced(false).Synced)
 
-	require.NotNil(t, opts.WithLog(DefaultOptions().log))
+	require.NotNil(t, opts.WithLogger(DefaultOptions().log))
+	require.Nil(t, opts.WithLog(nil).log)
+	require.False(t, validOptions(opts))
+	require.NotNil(t, opts.WithLog(pkglogger.NewSimpleLogger("immudb ", os.Stderr)).log)
 
 	require.True(t, validOptions(opts))
 
@@ -109,4 +114,10 @@ func TestValidOptions(t *testing.T) {
 	require.Equal(t, true, indexOpts.WithSynced(true).Synced)
 
 	require.True(t, validOptions(opts))
+
+	require.Equal(t, -1, indexOpts.WithCheckpointThld(-1).CheckpointThld)
+	require.False(t, validOptions(opts))
+
+	require.Equal(t, 100, indexOpts.WithCheckpointThld(100).CheckpointThld)
+	require.True(t, validOptions(opts))
 }
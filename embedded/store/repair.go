@@ -0,0 +1,154 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/codenotary/immudb/embedded/appendable"
+)
+
+var ErrTooManyIncompleteTxs = errors.New("too many incomplete transactions to be repaired")
+
+// RepairReport describes the incomplete tail rolled back when the store was opened,
+// as left behind by a crash in the middle of a commit
+type RepairReport struct {
+	// CommittedTxID is the last transaction kept
+	CommittedTxID uint64
+	// DiscardedTxs are the transactions registered in the commit log but not fully written
+	DiscardedTxs []*DiscardedTx
+	// DiscardedCLogBytes and DiscardedTxLogBytes are the bytes truncated from each log
+	DiscardedCLogBytes  int64
+	DiscardedTxLogBytes int64
+	// IndexRebuilt is set when the index already included discarded transactions
+	IndexRebuilt bool
+}
+
+type DiscardedTx struct {
+	ID     uint64
+	Reason string
+}
+
+func (r *RepairReport) repaired() bool {
+	return len(r.DiscardedTxs) > 0 || r.DiscardedCLogBytes > 0 || r.DiscardedTxLogBytes > 0
+}
+
+// repairTail rolls back the commit log up to the last transaction fully written, i.e. its entry
+// in the transaction log and all of its values are present in the value logs.
+// Data fully written but degraded at rest is not repaired, but reported as corrupted.
+// At most maxRepairedTxs are rolled back, the size of the repaired commit log is returned.
+func repairTail(vLogs []appendable.Appendable, txLog, cLog appendable.Appendable, cLogSize int64, tx *Tx, maxRepairedTxs int, report *RepairReport) (int64, error) {
+	txLogSize, err := txLog.Size()
+	if err != nil {
+		return 0, fmt.Errorf("corrupted transaction log: could not get size: %w", err)
+	}
+
+	b := make([]byte, cLogEntrySize)
+
+	for cLogSize > 0 {
+		txID := uint64(cLogSize) / cLogEntrySize
+
+		_, err := cLog.ReadAt(b, cLogSize-cLogEntrySize)
+		if err != nil {
+			return 0, fmt.Errorf("corrupted commit log: could not read commit of tx %d: %w", txID, err)
+		}
+
+		txOffset := int64(binary.BigEndian.Uint64(b))
+		txSize := int(binary.BigEndian.Uint32(b[txIDSize:]))
+
+		reason, err := incompleteTxReason(vLogs, txLog, txLogSize, txID, txOffset, txSize, tx)
+		if err != nil {
+			return 0, err
+		}
+
+		if reason == "" {
+			break
+		}
+
+		if len(report.DiscardedTxs) == maxRepairedTxs {
+			return 0, fmt.Errorf("%w: more than %d transactions are not fully written", ErrTooManyIncompleteTxs, maxRepairedTxs)
+		}
+
+		report.DiscardedTxs = append(report.DiscardedTxs, &DiscardedTx{ID: txID, Reason: reason})
+
+		cLogSize -= cLogEntrySize
+	}
+
+	if len(report.DiscardedTxs) > 0 {
+		err = cLog.SetOffset(cLogSize)
+		if err != nil {
+			return 0, fmt.Errorf("corrupted commit log: could not set offset: %w", err)
+		}
+
+		report.DiscardedCLogBytes += int64(len(report.DiscardedTxs) * cLogEntrySize)
+	}
+
+	return cLogSize, nil
+}
+
+// incompleteTxReason returns why the tx was not fully written or an empty string when it's complete
+func incompleteTxReason(vLogs []appendable.Appendable, txLog appendable.Appendable, txLogSize int64, txID uint64, txOffset int64, txSize int, tx *Tx) (string, error) {
+	if txOffset+int64(txSize) > txLogSize {
+		return fmt.Sprintf("transaction log is %d bytes short", txOffset+int64(txSize)-txLogSize), nil
+	}
+
+	err := tx.readFrom(appendable.NewReaderFrom(txLog, txOffset, txSize))
+	if isIncompleteData(err) {
+		return fmt.Sprintf("transaction can not be read back: %v", err), nil
+	}
+	if err != nil {
+		// data fully written but not matching its digests is not a partial write,
+		// it's left to be reported as corrupted
+		return "", nil
+	}
+
+	for i, e := range tx.Entries() {
+		vLogID, vOff := decodeOffset(e.vOff)
+
+		if vLogID == 0 || e.vLen == 0 {
+			continue
+		}
+
+		if int(vLogID) > len(vLogs) {
+			return fmt.Sprintf("value of entry %d refers to a missing value log", i), nil
+		}
+
+		val := make([]byte, e.vLen)
+
+		_, err := vLogs[vLogID-1].ReadAt(val, vOff)
+		if isIncompleteData(err) {
+			return fmt.Sprintf("value of entry %d can not be read back: %v", i, err), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("could not read value of tx %d: %w", txID, err)
+		}
+
+	}
+
+	return "", nil
+}
+
+func isIncompleteData(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// RepairReport returns what was rolled back when the store was opened, nil if nothing was repaired
+func (s *ImmuStore) RepairReport() *RepairReport {
+	return s.repairReport
+}
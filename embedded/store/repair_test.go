@@ -0,0 +1,208 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreRepair(t *testing.T) {
+	createStore := func(t *testing.T, txCount int) string {
+		dir := t.TempDir()
+
+		immuStore, err := Open(dir, DefaultOptions().WithSynced(false))
+		require.NoError(t, err)
+
+		for i := 0; i < txCount; i++ {
+			tx, err := immuStore.NewWriteOnlyTx()
+			require.NoError(t, err)
+
+			err = tx.Set([]byte(fmt.Sprintf("key%d", i)), nil, []byte(fmt.Sprintf("value%d", i)))
+			require.NoError(t, err)
+
+			_, err = tx.Commit()
+			require.NoError(t, err)
+		}
+
+		err = immuStore.Close()
+		require.NoError(t, err)
+
+		return dir
+	}
+
+	truncate := func(t *testing.T, path string, n int64) {
+		finfo, err := os.Stat(path)
+		require.NoError(t, err)
+
+		err = os.Truncate(path, finfo.Size()-n)
+		require.NoError(t, err)
+	}
+
+	requireUsable := func(t *testing.T, immuStore *ImmuStore, committedTxID uint64) {
+		require.Equal(t, committedTxID, immuStore.TxCount())
+
+		err := immuStore.WaitForIndexingUpto(committedTxID, nil)
+		require.NoError(t, err)
+
+		_, err = immuStore.Get([]byte(fmt.Sprintf("key%d", committedTxID)))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+
+		tx, err := immuStore.NewWriteOnlyTx()
+		require.NoError(t, err)
+
+		err = tx.Set([]byte("key"), nil, []byte("value"))
+		require.NoError(t, err)
+
+		hdr, err := tx.Commit()
+		require.NoError(t, err)
+		require.Equal(t, committedTxID+1, hdr.ID)
+
+		valRef, err := immuStore.Get([]byte("key"))
+		require.NoError(t, err)
+
+		val, err := valRef.Resolve()
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), val)
+
+		err = immuStore.VerifyTxs(1, hdr.ID)
+		require.NoError(t, err)
+	}
+
+	t.Run("a store fully written should not be repaired", func(t *testing.T) {
+		dir := createStore(t, 3)
+
+		immuStore, err := Open(dir, DefaultOptions())
+		require.NoError(t, err)
+		defer immuStore.Close()
+
+		require.Nil(t, immuStore.RepairReport())
+	})
+
+	t.Run("a partially written tx should be rolled back", func(t *testing.T) {
+		dir := createStore(t, 3)
+
+		truncate(t, filepath.Join(dir, "tx", "00000000.tx"), 10)
+
+		immuStore, err := Open(dir, DefaultOptions())
+		require.NoError(t, err)
+		defer immuStore.Close()
+
+		report := immuStore.RepairReport()
+		require.NotNil(t, report)
+		require.Equal(t, uint64(2), report.CommittedTxID)
+		require.Len(t, report.DiscardedTxs, 1)
+		require.Equal(t, uint64(3), report.DiscardedTxs[0].ID)
+		require.Contains(t, report.DiscardedTxs[0].Reason, "transaction log")
+		require.Equal(t, int64(cLogEntrySize), report.DiscardedCLogBytes)
+		require.Positive(t, report.DiscardedTxLogBytes)
+		require.True(t, report.IndexRebuilt)
+
+		requireUsable(t, immuStore, 2)
+	})
+
+	t.Run("a tx with missing values should be rolled back", func(t *testing.T) {
+		dir := createStore(t, 3)
+
+		truncate(t, filepath.Join(dir, "val_0", "00000000.val"), 1)
+
+		immuStore, err := Open(dir, DefaultOptions())
+		require.NoError(t, err)
+		defer immuStore.Close()
+
+		report := immuStore.RepairReport()
+		require.NotNil(t, report)
+		require.Len(t, report.DiscardedTxs, 1)
+		require.Contains(t, report.DiscardedTxs[0].Reason, "value of entry 0")
+
+		requireUsable(t, immuStore, 2)
+	})
+
+	t.Run("a partially written commit should be discarded", func(t *testing.T) {
+		dir := createStore(t, 3)
+
+		truncate(t, filepath.Join(dir, "commit", "00000000.txi"), 1)
+
+		immuStore, err := Open(dir, DefaultOptions())
+		require.NoError(t, err)
+		defer immuStore.Close()
+
+		report := immuStore.RepairReport()
+		require.NotNil(t, report)
+		require.Empty(t, report.DiscardedTxs)
+		require.Equal(t, int64(cLogEntrySize-1), report.DiscardedCLogBytes)
+		require.Positive(t, report.DiscardedTxLogBytes)
+
+		requireUsable(t, immuStore, 2)
+	})
+
+	t.Run("values degraded at rest should not be repaired", func(t *testing.T) {
+		dir := createStore(t, 3)
+
+		vLogPath := filepath.Join(dir, "val_0", "00000000.val")
+
+		content, err := ioutil.ReadFile(vLogPath)
+		require.NoError(t, err)
+
+		content[len(content)-1] ^= 0xff
+
+		err = ioutil.WriteFile(vLogPath, content, 0644)
+		require.NoError(t, err)
+
+		immuStore, err := Open(dir, DefaultOptions())
+		require.NoError(t, err)
+		defer immuStore.Close()
+
+		require.Nil(t, immuStore.RepairReport())
+
+		err = immuStore.VerifyTxs(1, 3)
+		require.ErrorIs(t, err, ErrCorruptedData)
+	})
+
+	t.Run("the repair should be bounded", func(t *testing.T) {
+		dir := createStore(t, 3)
+
+		truncate(t, filepath.Join(dir, "val_0", "00000000.val"), 10)
+
+		_, err := Open(dir, DefaultOptions().WithMaxRepairedTxs(1))
+		require.ErrorIs(t, err, ErrTooManyIncompleteTxs)
+
+		immuStore, err := Open(dir, DefaultOptions().WithMaxRepairedTxs(2))
+		require.NoError(t, err)
+		defer immuStore.Close()
+
+		require.Len(t, immuStore.RepairReport().DiscardedTxs, 2)
+
+		requireUsable(t, immuStore, 1)
+	})
+
+	t.Run("the store should not be repaired when the repair is disabled", func(t *testing.T) {
+		dir := createStore(t, 3)
+
+		truncate(t, filepath.Join(dir, "tx", "00000000.tx"), 10)
+
+		_, err := Open(dir, DefaultOptions().WithMaxRepairedTxs(0))
+		require.ErrorIs(t, err, ErrorCorruptedTxData)
+
+		_, err = Open(dir, DefaultOptions().WithReadOnly(true))
+		require.ErrorIs(t, err, ErrorCorruptedTxData)
+	})
+}
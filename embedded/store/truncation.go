@@ -0,0 +1,154 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var ErrTruncationNotSupported = errors.New("truncation is not supported by the value logs")
+
+// discardableAppendable is implemented by the appendables whose leading data can be removed
+// e.g. multi-file appendables, where data is removed in whole chunks
+type discardableAppendable interface {
+	DiscardUpto(off int64) error
+	DiscardableUpto(off int64) int64
+	DiscardedUpto() int64
+}
+
+// TruncateUptoTx discards the values of the transactions before minTxID, returning how many bytes were removed.
+// Values are removed in whole chunks of the value logs, thus values of some of those transactions may be kept.
+// Transactions, their proofs and the index are kept, discarded values are read as expired entries
+func (s *ImmuStore) TruncateUptoTx(minTxID uint64) (int64, error) {
+	offsets, err := s.truncationOffsets(minTxID)
+	if err != nil {
+		return 0, err
+	}
+
+	var discarded int64
+
+	for vLogID, off := range offsets {
+		vLog := s.fetchVLog(vLogID)
+
+		dvLog := vLog.(discardableAppendable)
+
+		prevDiscardedUpto := dvLog.DiscardedUpto()
+
+		err := dvLog.DiscardUpto(off)
+
+		discardedUpto := dvLog.DiscardedUpto()
+		atomic.StoreInt64(&s.vLogs[vLogID-1].discardedUpto, discardedUpto)
+
+		s.releaseVLog(vLogID)
+
+		discarded += discardedUpto - prevDiscardedUpto
+
+		if err != nil {
+			return discarded, err
+		}
+	}
+
+	return discarded, nil
+}
+
+// TruncatableSize returns how many bytes of the value logs TruncateUptoTx(minTxID) would remove
+func (s *ImmuStore) TruncatableSize(minTxID uint64) (int64, error) {
+	offsets, err := s.truncationOffsets(minTxID)
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+
+	for vLogID, off := range offsets {
+		dvLog := s.vLogs[vLogID-1].vLog.(discardableAppendable)
+
+		size += dvLog.DiscardableUpto(off) - dvLog.DiscardedUpto()
+	}
+
+	return size, nil
+}
+
+// truncationOffsets returns, for each value log, the offset of the first value not to be discarded.
+// Transactions being committed concurrently may be written into the value logs out of order,
+// so values of the following transactions, as many as can be concurrently committed, are considered as well.
+// Value logs without values of those transactions are not truncated
+func (s *ImmuStore) truncationOffsets(minTxID uint64) (map[byte]int64, error) {
+	for _, ref := range s.vLogs {
+		if _, ok := ref.vLog.(discardableAppendable); !ok {
+			return nil, ErrTruncationNotSupported
+		}
+	}
+
+	committedTxID := s.TxCount()
+
+	if minTxID == 0 || minTxID > committedTxID {
+		return nil, ErrIllegalArguments
+	}
+
+	toTxID := minTxID + uint64(s.maxConcurrency)
+	if toTxID > committedTxID {
+		toTxID = committedTxID
+	}
+
+	offsets := make(map[byte]int64, len(s.vLogs))
+
+	tx := s.NewTxHolder()
+
+	for txID := minTxID; txID <= toTxID; txID++ {
+		err := s.ReadTx(txID, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range tx.Entries() {
+			if e.vLen == 0 {
+				continue
+			}
+
+			vLogID, off := decodeOffset(e.vOff)
+
+			minOff, ok := offsets[vLogID]
+			if !ok || off < minOff {
+				offsets[vLogID] = off
+			}
+		}
+	}
+
+	return offsets, nil
+}
+
+// discardedUpto returns the offset the value log was truncated up to
+func (s *ImmuStore) discardedUpto(vLogID byte) int64 {
+	ref, ok := s.vLogs[vLogID-1]
+	if !ok {
+		return 0
+	}
+
+	return atomic.LoadInt64(&ref.discardedUpto)
+}
+
+// truncated returns true when the value was discarded by a truncation of the value logs
+func (v *valueRef) truncated() bool {
+	if v.valLen == 0 {
+		return false
+	}
+
+	vLogID, off := decodeOffset(v.vOff)
+
+	return vLogID > 0 && off < v.st.discardedUpto(vLogID)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreTruncateUptoTx(t *testing.T) {
+	dir := t.TempDir()
+
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1).WithFileSize(64)
+
+	immuStore, err := Open(dir, opts)
+	require.NoError(t, err)
+
+	value := make([]byte, 32)
+
+	for i := 1; i <= 10; i++ {
+		tx, err := immuStore.NewWriteOnlyTx()
+		require.NoError(t, err)
+
+		err = tx.Set([]byte(fmt.Sprintf("key%d", i)), nil, value)
+		require.NoError(t, err)
+
+		_, err = tx.Commit()
+		require.NoError(t, err)
+	}
+
+	_, err = immuStore.TruncateUptoTx(0)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = immuStore.TruncateUptoTx(11)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	// values of tx 6 start in the third chunk
+	size, err := immuStore.TruncatableSize(6)
+	require.NoError(t, err)
+	require.Equal(t, int64(128), size)
+
+	discarded, err := immuStore.TruncateUptoTx(6)
+	require.NoError(t, err)
+	require.Equal(t, size, discarded)
+
+	size, err = immuStore.TruncatableSize(6)
+	require.NoError(t, err)
+	require.Zero(t, size)
+
+	checkTruncated := func(immuStore *ImmuStore) {
+		for i := 1; i <= 10; i++ {
+			key := []byte(fmt.Sprintf("key%d", i))
+
+			_, err := immuStore.Get(key)
+			if i <= 4 {
+				require.ErrorIs(t, err, ErrExpiredEntry)
+				continue
+			}
+			require.NoError(t, err)
+		}
+
+		tx := immuStore.NewTxHolder()
+
+		err := immuStore.ReadTx(1, tx)
+		require.NoError(t, err)
+
+		_, err = immuStore.ReadValue(tx.Entries()[0])
+		require.ErrorIs(t, err, ErrExpiredEntry)
+
+		snap, err := immuStore.Snapshot()
+		require.NoError(t, err)
+		defer snap.Close()
+
+		reader, err := snap.NewKeyReader(&KeyReaderSpec{Prefix: []byte("key")})
+		require.NoError(t, err)
+		defer reader.Close()
+
+		read := 0
+
+		for {
+			_, _, err := reader.Read()
+			if err == ErrNoMoreEntries {
+				break
+			}
+			require.NoError(t, err)
+			read++
+		}
+
+		require.Equal(t, 6, read)
+
+		err = immuStore.VerifyTxs(1, 10)
+		require.NoError(t, err)
+	}
+
+	checkTruncated(immuStore)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	immuStore, err = Open(dir, opts)
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	checkTruncated(immuStore)
+}
@@ -287,6 +287,11 @@ func (tx *Tx) TxEntryDigest() (TxEntryDigest, error) {
 }
 
 func (tx *Tx) BuildHashTree() error {
+	// the hash tree is not created when there are no entries
+	if tx.htree == nil {
+		return ErrIllegalArguments
+	}
+
 	digests := make([][sha256.Size]byte, tx.header.NEntries)
 
 	txEntryDigest, err := tx.TxEntryDigest()
@@ -429,10 +434,14 @@ func (tx *Tx) readFrom(r *appendable.Reader) error {
 		}
 	default:
 		{
-			panic(fmt.Errorf("missing tx deserialization method for version %d", tx.header.Version))
+			return fmt.Errorf("%w: unsupported tx version %d", ErrNewerVersionOrCorruptedData, tx.header.Version)
 		}
 	}
 
+	if tx.header.NEntries > len(tx.entries) {
+		return fmt.Errorf("%w: unexpected number of entries at tx %d", ErrorCorruptedTxData, tx.header.ID)
+	}
+
 	for i := 0; i < int(tx.header.NEntries); i++ {
 		// md is stored before key to ensure backward compatibility
 		mdLen, err := r.ReadUint16()
@@ -442,6 +451,10 @@ func (tx *Tx) readFrom(r *appendable.Reader) error {
 
 		var kvmd *KVMetadata
 
+		if mdLen > maxKVMetadataLen {
+			return fmt.Errorf("%w: unexpected metadata length at tx %d", ErrorCorruptedTxData, tx.header.ID)
+		}
+
 		if mdLen > 0 {
 			mdbs := make([]byte, mdLen)
 
@@ -464,6 +477,9 @@ func (tx *Tx) readFrom(r *appendable.Reader) error {
 		if err != nil {
 			return err
 		}
+		if int(kLen) > len(tx.entries[i].k) {
+			return fmt.Errorf("%w: unexpected key length at tx %d", ErrorCorruptedTxData, tx.header.ID)
+		}
 		tx.entries[i].kLen = int(kLen)
 
 		_, err = r.Read(tx.entries[i].k[:kLen])
@@ -15,26 +15,262 @@ limitations under the License.
 */
 package store
 
-const maxTxMetadataLen = 0
+import (
+	"bytes"
+	"errors"
+	"sort"
+)
 
+var ErrMaxTxMetadataLenExceeded = errors.New("max tx metadata length exceeded")
+var ErrInvalidTxLabel = errors.New("invalid tx label")
+
+const (
+	labelsAttrCode attributeCode = 0
+)
+
+// maxTxMetadataLen bounds the serialized metadata, it's kept small as it's
+// included in the header of the tx and in every indexed entry
+const maxTxMetadataLen = 256
+
+const maxTxLabelLen = 64
+
+// TxMetadata holds the application-supplied labels attached to a transaction
+// e.g. request id, actor or reason
 type TxMetadata struct {
+	attributes map[attributeCode]attribute
+}
+
+// labelsAttribute is serialized as the number of labels followed by each
+// label as len(key) + key + len(value) + value, sorted by key
+type labelsAttribute struct {
+	labels map[string]string
+}
+
+func (a *labelsAttribute) code() attributeCode {
+	return labelsAttrCode
+}
+
+func (a *labelsAttribute) serialize() []byte {
+	keys := make([]string, 0, len(a.labels))
+	for k := range a.labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+
+	b.WriteByte(byte(len(keys)))
+
+	for _, k := range keys {
+		v := a.labels[k]
+
+		b.WriteByte(byte(len(k)))
+		b.WriteString(k)
+		b.WriteByte(byte(len(v)))
+		b.WriteString(v)
+	}
+
+	return b.Bytes()
+}
+
+func (a *labelsAttribute) deserialize(b []byte) (int, error) {
+	if len(b) < 1 {
+		return 0, ErrCorruptedData
+	}
+
+	n := int(b[0])
+	i := 1
+
+	a.labels = make(map[string]string, n)
+
+	prevKey := ""
+
+	for j := 0; j < n; j++ {
+		k, kLen, err := readLabelString(b[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += kLen
+
+		v, vLen, err := readLabelString(b[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += vLen
+
+		// keys are serialized in strictly increasing order
+		if len(k) == 0 || (j > 0 && k <= prevKey) {
+			return 0, ErrCorruptedData
+		}
+		prevKey = k
+
+		a.labels[k] = v
+	}
+
+	return i, nil
+}
+
+func readLabelString(b []byte) (string, int, error) {
+	if len(b) < 1 || len(b) < 1+int(b[0]) {
+		return "", 0, ErrCorruptedData
+	}
+
+	l := int(b[0])
+
+	return string(b[1 : 1+l]), 1 + l, nil
 }
 
 func NewTxMetadata() *TxMetadata {
-	return &TxMetadata{}
+	return &TxMetadata{
+		attributes: make(map[attributeCode]attribute),
+	}
 }
 
+// WithLabel sets the label, failing if the key or value are invalid
+// or if the serialized metadata would exceed its max length
+func (md *TxMetadata) WithLabel(key, value string) error {
+	if len(key) == 0 || len(key) > maxTxLabelLen || len(value) > maxTxLabelLen {
+		return ErrInvalidTxLabel
+	}
+
+	if md.attributes == nil {
+		md.attributes = make(map[attributeCode]attribute)
+	}
+
+	attr, ok := md.attributes[labelsAttrCode]
+	if !ok {
+		attr = &labelsAttribute{labels: make(map[string]string)}
+	}
+
+	labels := attr.(*labelsAttribute).labels
+
+	prevValue, existed := labels[key]
+
+	labels[key] = value
+	md.attributes[labelsAttrCode] = attr
+
+	if len(md.Bytes()) > maxTxMetadataLen {
+		if existed {
+			labels[key] = prevValue
+		} else {
+			delete(labels, key)
+		}
+
+		if len(labels) == 0 {
+			delete(md.attributes, labelsAttrCode)
+		}
+
+		return ErrMaxTxMetadataLenExceeded
+	}
+
+	return nil
+}
+
+// Label returns the value of the label, if present
+func (md *TxMetadata) Label(key string) (string, bool) {
+	if md == nil {
+		return "", false
+	}
+
+	attr, ok := md.attributes[labelsAttrCode]
+	if !ok {
+		return "", false
+	}
+
+	v, ok := attr.(*labelsAttribute).labels[key]
+	return v, ok
+}
+
+// Labels returns a copy of the labels attached to the tx
+func (md *TxMetadata) Labels() map[string]string {
+	if md == nil {
+		return nil
+	}
+
+	attr, ok := md.attributes[labelsAttrCode]
+	if !ok {
+		return nil
+	}
+
+	labels := make(map[string]string, len(attr.(*labelsAttribute).labels))
+	for k, v := range attr.(*labelsAttribute).labels {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+// Equal returns true when both metadata hold the same attributes,
+// a nil metadata is considered equal to an empty one
 func (md *TxMetadata) Equal(amd *TxMetadata) bool {
-	return true
+	var b1, b2 []byte
+
+	if md != nil {
+		b1 = md.Bytes()
+	}
+
+	if amd != nil {
+		b2 = amd.Bytes()
+	}
+
+	return bytes.Equal(b1, b2)
 }
 
 func (md *TxMetadata) Bytes() []byte {
-	return nil
+	var b bytes.Buffer
+
+	for _, attrCode := range []attributeCode{labelsAttrCode} {
+		attr, ok := md.attributes[attrCode]
+		if ok {
+			b.WriteByte(byte(attr.code()))
+			b.Write(attr.serialize())
+		}
+	}
+
+	if b.Len() == 0 {
+		return nil
+	}
+
+	return b.Bytes()
 }
 
 func (md *TxMetadata) ReadFrom(b []byte) error {
-	if len(b) != 0 {
+	if len(b) > maxTxMetadataLen {
 		return ErrCorruptedData
 	}
-	return nil
+
+	md.attributes = make(map[attributeCode]attribute)
+
+	i := 0
+
+	for {
+		if len(b[i:]) == 0 {
+			return nil
+		}
+
+		attrCode := attributeCode(b[i])
+		i += attrCodeSize
+
+		var attr attribute
+
+		switch attrCode {
+		case labelsAttrCode:
+			attr = &labelsAttribute{}
+		default:
+			return ErrCorruptedData
+		}
+
+		_, ok := md.attributes[attrCode]
+		if ok {
+			return ErrCorruptedData
+		}
+
+		n, err := attr.deserialize(b[i:])
+		if err != nil {
+			return err
+		}
+		i += n
+
+		md.attributes[attrCode] = attr
+	}
 }
@@ -16,6 +16,7 @@ limitations under the License.
 package store
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -39,3 +40,69 @@ func TestTxMetadata(t *testing.T) {
 
 	require.True(t, md.Equal(desmd))
 }
+
+func TestTxMetadataLabels(t *testing.T) {
+	md := NewTxMetadata()
+
+	_, ok := md.Label("actor")
+	require.False(t, ok)
+	require.Nil(t, md.Labels())
+
+	err := md.WithLabel("", "value")
+	require.ErrorIs(t, err, ErrInvalidTxLabel)
+
+	err = md.WithLabel("actor", "alice")
+	require.NoError(t, err)
+
+	err = md.WithLabel("request-id", "1234")
+	require.NoError(t, err)
+
+	err = md.WithLabel("actor", "bob")
+	require.NoError(t, err)
+
+	v, ok := md.Label("actor")
+	require.True(t, ok)
+	require.Equal(t, "bob", v)
+	require.Equal(t, map[string]string{"actor": "bob", "request-id": "1234"}, md.Labels())
+
+	require.False(t, md.Equal(nil))
+	require.False(t, md.Equal(NewTxMetadata()))
+
+	desmd := NewTxMetadata()
+	err = desmd.ReadFrom(md.Bytes())
+	require.NoError(t, err)
+	require.True(t, md.Equal(desmd))
+	require.Equal(t, md.Labels(), desmd.Labels())
+
+	t.Run("max length should be enforced", func(t *testing.T) {
+		md := NewTxMetadata()
+
+		var err error
+
+		for i := 0; err == nil; i++ {
+			err = md.WithLabel(fmt.Sprintf("label%d", i), "some value")
+		}
+		require.ErrorIs(t, err, ErrMaxTxMetadataLenExceeded)
+		require.LessOrEqual(t, len(md.Bytes()), maxTxMetadataLen)
+
+		desmd := NewTxMetadata()
+		err = desmd.ReadFrom(md.Bytes())
+		require.NoError(t, err)
+		require.True(t, md.Equal(desmd))
+	})
+
+	t.Run("corrupted metadata should be rejected", func(t *testing.T) {
+		bs := md.Bytes()
+
+		for i := 1; i < len(bs); i++ {
+			err := NewTxMetadata().ReadFrom(bs[:i])
+			require.ErrorIs(t, err, ErrCorruptedData)
+		}
+
+		err := NewTxMetadata().ReadFrom([]byte{99})
+		require.ErrorIs(t, err, ErrCorruptedData)
+
+		err = NewTxMetadata().ReadFrom(append(bs, bs...))
+		require.ErrorIs(t, err, ErrCorruptedData)
+	})
+}
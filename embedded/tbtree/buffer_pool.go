@@ -0,0 +1,129 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tbtree
+
+import (
+	"sync"
+
+	"github.com/codenotary/immudb/embedded/cache"
+)
+
+// BufferPool keeps in memory the nodes most recently read or written by the btrees sharing it.
+// Once the pool is full the least recently used node is evicted regardless of the btree it belongs to,
+// thus memory usage is bounded by the size of the pool no matter how many btrees are opened
+type BufferPool struct {
+	cache *cache.LRUCache
+
+	// number of nodes held by each btree
+	residents map[*TBtree]int
+
+	mutex sync.Mutex
+}
+
+type bufferedNodeKey struct {
+	t   *TBtree
+	off int64
+}
+
+// NewBufferPool returns a pool holding up to size nodes
+func NewBufferPool(size int) (*BufferPool, error) {
+	c, err := cache.NewLRUCache(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BufferPool{
+		cache:     c,
+		residents: make(map[*TBtree]int),
+	}, nil
+}
+
+// Size returns the max number of nodes held by the pool
+func (p *BufferPool) Size() int {
+	return p.cache.Size()
+}
+
+// Resident returns the number of nodes currently held by the pool
+func (p *BufferPool) Resident() int {
+	return p.cache.EntriesCount()
+}
+
+func (p *BufferPool) get(t *TBtree, off int64) (node, bool) {
+	v, err := p.cache.Get(bufferedNodeKey{t: t, off: off})
+	if err != nil {
+		return nil, false
+	}
+
+	return v.(node), true
+}
+
+func (p *BufferPool) put(t *TBtree, n node) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	key := bufferedNodeKey{t: t, off: n.offset()}
+
+	_, err := p.cache.Get(key)
+	if err == nil {
+		p.cache.Replace(key, n)
+		return
+	}
+
+	p.residents[t]++
+
+	rkey, _, _ := p.cache.Put(key, n)
+	if rkey != nil {
+		evicted := rkey.(bufferedNodeKey).t
+
+		p.residents[evicted]--
+		if p.residents[evicted] == 0 {
+			delete(p.residents, evicted)
+		}
+
+		metricsCacheEvict.WithLabelValues(evicted.path).Inc()
+		metricsCacheSizeStats.WithLabelValues(evicted.path).Set(float64(p.residents[evicted]))
+	}
+
+	metricsCacheSizeStats.WithLabelValues(t.path).Set(float64(p.residents[t]))
+}
+
+// discard removes all the nodes of the btree from the pool
+func (p *BufferPool) discard(t *TBtree) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.residents[t] == 0 {
+		return
+	}
+
+	var keys []interface{}
+
+	p.cache.Apply(func(k, v interface{}) error {
+		if k.(bufferedNodeKey).t == t {
+			keys = append(keys, k)
+		}
+		return nil
+	})
+
+	for _, k := range keys {
+		p.cache.Pop(k)
+	}
+
+	delete(p.residents, t)
+
+	metricsCacheSizeStats.WithLabelValues(t.path).Set(0)
+}
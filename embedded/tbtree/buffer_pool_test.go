@@ -0,0 +1,93 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tbtree
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferPool(t *testing.T) {
+	_, err := NewBufferPool(0)
+	require.Error(t, err)
+
+	pool, err := NewBufferPool(10)
+	require.NoError(t, err)
+	require.Equal(t, 10, pool.Size())
+	require.Equal(t, 0, pool.Resident())
+
+	opts := DefaultOptions().
+		WithMaxNodeSize(MinNodeSize).
+		WithBufferPool(pool)
+
+	tree1, err := Open("test_buffer_pool1", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("test_buffer_pool1")
+
+	tree2, err := Open("test_buffer_pool2", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("test_buffer_pool2")
+
+	for _, tree := range []*TBtree{tree1, tree2} {
+		for i := 0; i < 100; i++ {
+			err = tree.Insert([]byte(fmt.Sprintf("key%03d", i)), []byte(fmt.Sprintf("value%d", i)))
+			require.NoError(t, err)
+		}
+
+		_, _, err = tree.Flush()
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, pool.Size(), pool.Resident())
+
+	// nodes evicted from the pool are read again from disk
+	for _, tree := range []*TBtree{tree1, tree2} {
+		for i := 0; i < 100; i++ {
+			v, _, _, err := tree.Get([]byte(fmt.Sprintf("key%03d", i)))
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value%d", i)), v)
+		}
+	}
+
+	require.Equal(t, pool.Size(), pool.Resident())
+	require.Equal(t, pool.Size(), pool.residents[tree1]+pool.residents[tree2])
+
+	err = tree2.Close()
+	require.NoError(t, err)
+
+	require.Equal(t, pool.residents[tree1], pool.Resident())
+	require.NotContains(t, pool.residents, tree2)
+
+	err = tree1.Close()
+	require.NoError(t, err)
+
+	require.Equal(t, 0, pool.Resident())
+	require.Equal(t, pool, tree1.GetOptions().bufferPool)
+}
+
+func TestBufferPoolPerTree(t *testing.T) {
+	tree, err := Open("test_buffer_pool_per_tree", DefaultOptions().WithCacheSize(5))
+	require.NoError(t, err)
+	defer os.RemoveAll("test_buffer_pool_per_tree")
+	defer tree.Close()
+
+	require.Equal(t, 5, tree.bufferPool.Size())
+	require.Nil(t, tree.GetOptions().bufferPool)
+}
@@ -21,7 +21,8 @@ import (
 
 	"github.com/codenotary/immudb/embedded/appendable"
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
-	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/embedded/logger"
+	pkglogger "github.com/codenotary/immudb/pkg/logger"
 )
 
 const DefaultMaxNodeSize = 4096
@@ -57,8 +58,10 @@ type Options struct {
 	maxActiveSnapshots int
 	renewSnapRootAfter time.Duration
 	cacheSize          int
+	bufferPool         *BufferPool
 	readOnly           bool
 	synced             bool
+	useWAL             bool
 	fileMode           os.FileMode
 
 	nodesLogMaxOpenedFiles   int
@@ -79,7 +82,7 @@ type Options struct {
 
 func DefaultOptions() *Options {
 	return &Options{
-		log:                   logger.NewSimpleLogger("immudb ", os.Stderr),
+		log:                   logger.NewPrintfLogger(pkglogger.NewSimpleLogger("immudb ", os.Stderr)),
 		flushThld:             DefaultFlushThld,
 		syncThld:              DefaultSyncThld,
 		maxActiveSnapshots:    DefaultMaxActiveSnapshots,
@@ -122,11 +125,23 @@ func validOptions(opts *Options) bool {
 		opts.log != nil
 }
 
-func (opts *Options) WithLog(log logger.Logger) *Options {
+// WithLogger sets the logger receiving the events of the index
+func (opts *Options) WithLogger(log logger.Logger) *Options {
 	opts.log = log
 	return opts
 }
 
+// WithLog is the same as WithLogger but events are written as formatted messages
+func (opts *Options) WithLog(log logger.PrintfLogger) *Options {
+	if log == nil {
+		opts.log = nil
+		return opts
+	}
+
+	opts.log = logger.NewPrintfLogger(log)
+	return opts
+}
+
 func (opts *Options) WithAppFactory(appFactory AppFactoryFunc) *Options {
 	opts.appFactory = appFactory
 	return opts
@@ -157,6 +172,13 @@ func (opts *Options) WithCacheSize(cacheSize int) *Options {
 	return opts
 }
 
+// WithBufferPool sets a pool shared by many btrees to hold their nodes.
+// When it's not set, nodes are held in a pool of its own sized as the cache
+func (opts *Options) WithBufferPool(bufferPool *BufferPool) *Options {
+	opts.bufferPool = bufferPool
+	return opts
+}
+
 func (opts *Options) WithReadOnly(readOnly bool) *Options {
 	opts.readOnly = readOnly
 	return opts
@@ -167,6 +189,13 @@ func (opts *Options) WithSynced(synced bool) *Options {
 	return opts
 }
 
+// WithWAL enables a write-ahead log of the insertions not yet included in a synced root,
+// so they don't need to be inserted again after a crash
+func (opts *Options) WithWAL(useWAL bool) *Options {
+	opts.useWAL = useWAL
+	return opts
+}
+
 func (opts *Options) WithFileMode(fileMode os.FileMode) *Options {
 	opts.fileMode = fileMode
 	return opts
@@ -16,11 +16,13 @@ limitations under the License.
 package tbtree
 
 import (
+	"os"
 	"testing"
 	"time"
 
 	"github.com/codenotary/immudb/embedded/appendable"
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
+	pkglogger "github.com/codenotary/immudb/pkg/logger"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,7 +49,10 @@ func TestValidOptions(t *testing.T) {
 	require.Equal(t, 256, opts.WithMaxKeyLen(256).maxKeyLen)
 	require.Equal(t, time.Duration(1)*time.Millisecond, opts.WithDelayDuringCompaction(time.Duration(1)*time.Millisecond).delayDuringCompaction)
 	require.False(t, opts.WithReadOnly(false).readOnly)
-	require.NotNil(t, opts.WithLog(DefaultOptions().log))
+	require.NotNil(t, opts.WithLogger(DefaultOptions().log))
+	require.Nil(t, opts.WithLog(nil).log)
+	require.False(t, validOptions(opts))
+	require.NotNil(t, opts.WithLog(pkglogger.NewSimpleLogger("immudb ", os.Stderr)).log)
 
 	require.Equal(t, 2, opts.WithNodesLogMaxOpenedFiles(2).nodesLogMaxOpenedFiles)
 	require.Equal(t, 3, opts.WithHistoryLogMaxOpenedFiles(3).historyLogMaxOpenedFiles)
@@ -57,17 +57,71 @@ func (r *Reader) Reset() error {
 }
 
 func (r *Reader) ReadAsBefore(beforeTs uint64) (key []byte, ts, hc uint64, err error) {
+	for {
+		leafValue, err := r.nextLeafValue()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		ts, hc, err := leafValue.asBefore(r.snapshot.t.hLog, beforeTs)
+		if err == nil {
+			return cp(leafValue.key), ts, hc, nil
+		}
+	}
+}
+
+// ReadChanged returns the next key which was updated after sinceTs and up to untilTs,
+// along with the versions it had at both points. prevTs is zero when the key did not exist at sinceTs
+func (r *Reader) ReadChanged(sinceTs, untilTs uint64) (key []byte, prevTs, prevHc, currTs, currHc uint64, err error) {
+	if sinceTs >= untilTs {
+		return nil, 0, 0, 0, 0, ErrIllegalArguments
+	}
+
+	for {
+		leafValue, err := r.nextLeafValue()
+		if err != nil {
+			return nil, 0, 0, 0, 0, err
+		}
+
+		if leafValue.ts <= sinceTs {
+			// not updated since then
+			continue
+		}
+
+		currTs, currHc, err := leafValue.asBefore(r.snapshot.t.hLog, untilTs+1)
+		if err == ErrKeyNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, 0, 0, 0, 0, err
+		}
+
+		if currTs <= sinceTs {
+			continue
+		}
+
+		prevTs, prevHc, err := leafValue.asBefore(r.snapshot.t.hLog, sinceTs+1)
+		if err != nil && err != ErrKeyNotFound {
+			return nil, 0, 0, 0, 0, err
+		}
+
+		return cp(leafValue.key), prevTs, prevHc, currTs, currHc, nil
+	}
+}
+
+// nextLeafValue moves the reader to the next leaf value within the seek, end and prefix boundaries
+func (r *Reader) nextLeafValue() (*leafValue, error) {
 	if r.closed {
-		return nil, 0, 0, ErrAlreadyClosed
+		return nil, ErrAlreadyClosed
 	}
 
 	if r.leafNode == nil {
 		path, startingLeaf, startingOffset, err := r.snapshot.root.findLeafNode(r.seekKey, nil, nil, r.descOrder)
 		if err == ErrKeyNotFound {
-			return nil, 0, 0, ErrNoMoreEntries
+			return nil, ErrNoMoreEntries
 		}
 		if err != nil {
-			return nil, 0, 0, err
+			return nil, err
 		}
 
 		r.path = path
@@ -79,7 +133,7 @@ func (r *Reader) ReadAsBefore(beforeTs uint64) (key []byte, ts, hc uint64, err e
 		if (!r.descOrder && len(r.leafNode.values) == r.offset) || (r.descOrder && r.offset < 0) {
 			for {
 				if len(r.path) == 0 {
-					return nil, 0, 0, ErrNoMoreEntries
+					return nil, ErrNoMoreEntries
 				}
 
 				parent := r.path[len(r.path)-1]
@@ -102,7 +156,7 @@ func (r *Reader) ReadAsBefore(beforeTs uint64) (key []byte, ts, hc uint64, err e
 				}
 
 				if err != nil {
-					return nil, 0, 0, err
+					return nil, err
 				}
 
 				r.path = path
@@ -128,31 +182,16 @@ func (r *Reader) ReadAsBefore(beforeTs uint64) (key []byte, ts, hc uint64, err e
 			cmp := bytes.Compare(r.endKey, leafValue.key)
 
 			if r.descOrder && (cmp > 0 || (cmp == 0 && !r.inclusiveEnd)) {
-				return nil, 0, 0, ErrNoMoreEntries
+				return nil, ErrNoMoreEntries
 			}
 
 			if !r.descOrder && (cmp < 0 || (cmp == 0 && !r.inclusiveEnd)) {
-				return nil, 0, 0, ErrNoMoreEntries
+				return nil, ErrNoMoreEntries
 			}
 		}
 
-		if len(r.prefix) == 0 {
-			ts, hc, err := leafValue.asBefore(r.snapshot.t.hLog, beforeTs)
-			if err == nil {
-				return cp(leafValue.key), ts, hc, nil
-			}
-		}
-
-		if len(r.prefix) > 0 && len(leafValue.key) >= len(r.prefix) {
-			leafPrefix := leafValue.key[:len(r.prefix)]
-
-			// prefix match
-			if bytes.Equal(r.prefix, leafPrefix) {
-				ts, hc, err := leafValue.asBefore(r.snapshot.t.hLog, beforeTs)
-				if err == nil {
-					return cp(leafValue.key), ts, hc, nil
-				}
-			}
+		if len(r.prefix) == 0 || bytes.HasPrefix(leafValue.key, r.prefix) {
+			return leafValue, nil
 		}
 	}
 }
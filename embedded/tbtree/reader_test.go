@@ -243,6 +243,55 @@ func TestReaderAsBefore(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestReaderChanged(t *testing.T) {
+	tbtree, err := Open("test_tree_changed", DefaultOptions().WithMaxNodeSize(MinNodeSize))
+	require.NoError(t, err)
+	defer os.RemoveAll("test_tree_changed")
+
+	for _, k := range []string{"k1", "k2", "k3", "k1", "k4", "k2"} {
+		err = tbtree.Insert([]byte(k), []byte(k))
+		require.NoError(t, err)
+	}
+
+	err = tbtree.Insert([]byte("other"), []byte("other"))
+	require.NoError(t, err)
+
+	_, _, err = tbtree.Flush()
+	require.NoError(t, err)
+
+	snapshot, err := tbtree.Snapshot()
+	require.NotNil(t, snapshot)
+	require.NoError(t, err)
+	defer func() {
+		err := snapshot.Close()
+		require.NoError(t, err)
+	}()
+
+	reader, err := snapshot.NewReader(&ReaderSpec{Prefix: []byte("k")})
+	require.NoError(t, err)
+
+	_, _, _, _, _, err = reader.ReadChanged(5, 5)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	k, prevTs, _, currTs, _, err := reader.ReadChanged(3, 5)
+	require.NoError(t, err)
+	require.Equal(t, []byte("k1"), k)
+	require.Equal(t, uint64(1), prevTs)
+	require.Equal(t, uint64(4), currTs)
+
+	k, prevTs, _, currTs, _, err = reader.ReadChanged(3, 5)
+	require.NoError(t, err)
+	require.Equal(t, []byte("k4"), k)
+	require.Zero(t, prevTs)
+	require.Equal(t, uint64(5), currTs)
+
+	_, _, _, _, _, err = reader.ReadChanged(3, 5)
+	require.ErrorIs(t, err, ErrNoMoreEntries)
+
+	err = reader.Close()
+	require.NoError(t, err)
+}
+
 func TestReaderAscendingScanWithoutSeekKey(t *testing.T) {
 	tbtree, err := Open("test_tree_rsasc", DefaultOptions().WithMaxNodeSize(MinNodeSize))
 	require.NoError(t, err)
@@ -32,9 +32,8 @@ import (
 
 	"github.com/codenotary/immudb/embedded/appendable"
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
-	"github.com/codenotary/immudb/embedded/cache"
+	"github.com/codenotary/immudb/embedded/logger"
 	"github.com/codenotary/immudb/embedded/multierr"
-	"github.com/codenotary/immudb/pkg/logger"
 )
 
 var ErrIllegalArguments = errors.New("illegal arguments")
@@ -74,14 +73,18 @@ type TBtree struct {
 	path string
 	log  logger.Logger
 
-	nLog   appendable.Appendable
-	cache  *cache.LRUCache
-	nmutex sync.Mutex // mutex for cache and file reading
+	nLog             appendable.Appendable
+	bufferPool       *BufferPool
+	sharedBufferPool bool
+	nmutex           sync.Mutex // mutex for file reading
 
 	hLog appendable.Appendable
 
 	cLog appendable.Appendable
 
+	// wal holds the insertions not yet included in a synced root, it's nil when not enabled
+	wal appendable.Appendable
+
 	root node
 
 	maxNodeSize              int
@@ -92,6 +95,7 @@ type TBtree struct {
 	renewSnapRootAfter       time.Duration
 	readOnly                 bool
 	synced                   bool
+	useWAL                   bool
 	cacheSize                int
 	fileSize                 int
 	fileMode                 os.FileMode
@@ -125,6 +129,7 @@ type path []*innerNode
 
 type node interface {
 	insertAt(key []byte, value []byte, ts uint64) (node, node, int, error)
+	setTs(ts uint64) (node, error)
 	get(key []byte) (value []byte, ts uint64, hc uint64, err error)
 	history(key []byte, offset uint64, descOrder bool, limit int) ([]uint64, error)
 	findLeafNode(keyPrefix []byte, path path, neqKey []byte, descOrder bool) (path, *leafNode, int, error)
@@ -243,13 +248,13 @@ func Open(path string, opts *Options) (*TBtree, error) {
 
 		snapPath := filepath.Join(path, cFolder)
 
-		opts.log.Infof("Reading snapshot at '%s'...", snapPath)
+		opts.log.Info("Reading snapshot...", logger.F("path", snapPath))
 
 		appendableOpts.WithFileExt("n")
 		appendableOpts.WithMaxOpenedFiles(opts.nodesLogMaxOpenedFiles)
 		nLog, err := appFactory(path, nFolder, appendableOpts)
 		if err != nil {
-			opts.log.Infof("Reading snapshot at '%s' returned: %v", snapPath, err)
+			opts.log.Info("Reading snapshot failed", logger.F("path", snapPath), logger.F("error", err))
 			continue
 		}
 
@@ -258,7 +263,7 @@ func Open(path string, opts *Options) (*TBtree, error) {
 		cLog, err := appFactory(path, cFolder, appendableOpts)
 		if err != nil {
 			nLog.Close()
-			opts.log.Infof("Reading snapshot at '%s' returned: %v", snapPath, err)
+			opts.log.Info("Reading snapshot failed", logger.F("path", snapPath), logger.F("error", err))
 			continue
 		}
 
@@ -267,7 +272,7 @@ func Open(path string, opts *Options) (*TBtree, error) {
 
 		cLogSize, err := cLog.Size()
 		if err == nil && cLogSize < cLogEntrySize {
-			opts.log.Infof("Reading snapshot at '%s' returned: %s", snapPath, "empty snapshot")
+			opts.log.Info("Reading snapshot failed", logger.F("path", snapPath), logger.F("error", "empty snapshot"))
 			discardSnapshot = true
 		}
 		if !discardSnapshot && cLogSize >= cLogEntrySize {
@@ -275,7 +280,7 @@ func Open(path string, opts *Options) (*TBtree, error) {
 			t, err = OpenWith(path, nLog, hLog, cLog, opts)
 		}
 		if err != nil {
-			opts.log.Infof("Reading snapshot at '%s' returned: %v", snapPath, err)
+			opts.log.Info("Reading snapshot failed", logger.F("path", snapPath), logger.F("error", err))
 			discardSnapshot = true
 		}
 
@@ -285,21 +290,21 @@ func Open(path string, opts *Options) (*TBtree, error) {
 
 			err = discardSnapshots(path, snapIDs[i-1:i], opts.log)
 			if err != nil {
-				opts.log.Warningf("Discarding snapshots at '%s' returned: %v", path, err)
+				opts.log.Warn("Discarding snapshots failed", logger.F("path", path), logger.F("error", err))
 			}
 
 			continue
 		}
 
-		opts.log.Infof("Successfully read snapshot at '%s'", snapPath)
+		opts.log.Info("Snapshot successfully read", logger.F("path", snapPath))
 
 		// Discard older snapshots upon sucessful validation
 		err = discardSnapshots(path, snapIDs[:i-1], opts.log)
 		if err != nil {
-			opts.log.Warningf("Discarding snapshots at '%s' returned: %v", path, err)
+			opts.log.Warn("Discarding snapshots failed", logger.F("path", path), logger.F("error", err))
 		}
 
-		return t, nil
+		return withWAL(t, path, appFactory, appendableOpts)
 	}
 
 	// No snapshot present or none was valid, fresh initialization
@@ -323,7 +328,26 @@ func Open(path string, opts *Options) (*TBtree, error) {
 		return nil, err
 	}
 
-	return OpenWith(path, nLog, hLog, cLog, opts)
+	t, err := OpenWith(path, nLog, hLog, cLog, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return withWAL(t, path, appFactory, appendableOpts)
+}
+
+func withWAL(t *TBtree, path string, appFactory AppFactoryFunc, appendableOpts *multiapp.Options) (*TBtree, error) {
+	if !t.useWAL || t.readOnly {
+		return t, nil
+	}
+
+	err := openWAL(t, path, appFactory, appendableOpts)
+	if err != nil {
+		t.Close()
+		return nil, fmt.Errorf("%w: while replaying index write-ahead log at '%s'", err, path)
+	}
+
+	return t, nil
 }
 
 func snapFolder(folder string, snapID uint64) string {
@@ -349,7 +373,7 @@ func recoverFullSnapshots(path, prefix string, log logger.Logger) (snapIDs []uin
 
 			id, err := strconv.ParseInt(strings.TrimPrefix(f.Name(), prefix), 10, 64)
 			if err != nil {
-				log.Warningf("Invalid folder found '%s'", f.Name())
+				log.Warn("Invalid folder found", logger.F("folder", f.Name()))
 				continue
 			}
 
@@ -368,7 +392,7 @@ func discardSnapshots(path string, snapIDs []uint64, log logger.Logger) error {
 		nPath := filepath.Join(path, nFolder)
 		cPath := filepath.Join(path, cFolder)
 
-		log.Infof("Discarding snapshot at '%s'...", cPath)
+		log.Info("Discarding snapshot...", logger.F("path", cPath))
 
 		err := os.RemoveAll(nPath) // TODO: nLog.Remove()
 		if err != nil {
@@ -380,7 +404,7 @@ func discardSnapshots(path string, snapIDs []uint64, log logger.Logger) error {
 			return err
 		}
 
-		log.Infof("Snapshot at '%s' has been discarded", cPath)
+		log.Info("Snapshot has been discarded", logger.F("path", cPath))
 	}
 
 	return nil
@@ -412,9 +436,13 @@ func OpenWith(path string, nLog, hLog, cLog appendable.Appendable, opts *Options
 		}
 	}
 
-	cache, err := cache.NewLRUCache(opts.cacheSize)
-	if err != nil {
-		return nil, err
+	bufferPool := opts.bufferPool
+	if bufferPool == nil {
+		// nodes are held in a pool of its own when it's not shared with other btrees
+		bufferPool, err = NewBufferPool(opts.cacheSize)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	t := &TBtree{
@@ -423,7 +451,8 @@ func OpenWith(path string, nLog, hLog, cLog appendable.Appendable, opts *Options
 		nLog:                     nLog,
 		hLog:                     hLog,
 		cLog:                     cLog,
-		cache:                    cache,
+		bufferPool:               bufferPool,
+		sharedBufferPool:         opts.bufferPool != nil,
 		maxNodeSize:              maxNodeSize,
 		flushThld:                opts.flushThld,
 		syncThld:                 opts.syncThld,
@@ -441,6 +470,7 @@ func OpenWith(path string, nLog, hLog, cLog appendable.Appendable, opts *Options
 		greatestKey:              greatestKeyOfSize(opts.maxKeyLen),
 		readOnly:                 opts.readOnly,
 		synced:                   opts.synced,
+		useWAL:                   opts.useWAL,
 		snapshots:                make(map[uint64]*Snapshot),
 	}
 
@@ -497,7 +527,7 @@ func OpenWith(path string, nLog, hLog, cLog appendable.Appendable, opts *Options
 		return nil, fmt.Errorf("%w: while loading index commit log", err)
 	}
 
-	opts.log.Infof("Index '%s' {ts=%d, discarded_snapshots=%d} successfully loaded", path, t.Ts(), discardedRoots)
+	opts.log.Info("Index successfully loaded", logger.F("path", path), logger.F("ts", t.Ts()), logger.F("discarded_snapshots", discardedRoots))
 
 	return t, nil
 }
@@ -511,14 +541,21 @@ func greatestKeyOfSize(size int) []byte {
 }
 
 func (t *TBtree) GetOptions() *Options {
+	var bufferPool *BufferPool
+	if t.sharedBufferPool {
+		bufferPool = t.bufferPool
+	}
+
 	return DefaultOptions().
 		WithReadOnly(t.readOnly).
 		WithFileMode(t.fileMode).
 		WithFileSize(t.fileSize).
 		WithMaxKeyLen(t.maxKeyLen).
 		WithSynced(t.synced).
-		WithLog(t.log).
+		WithWAL(t.useWAL).
+		WithLogger(t.log).
 		WithCacheSize(t.cacheSize).
+		WithBufferPool(bufferPool).
 		WithFlushThld(t.flushThld).
 		WithSyncThld(t.syncThld).
 		WithMaxActiveSnapshots(t.maxActiveSnapshots).
@@ -532,46 +569,29 @@ func (t *TBtree) GetOptions() *Options {
 }
 
 func (t *TBtree) cachePut(n node) {
-	t.nmutex.Lock()
-	defer t.nmutex.Unlock()
-
-	r, _, _ := t.cache.Put(n.offset(), n)
-	if r != nil {
-		metricsCacheEvict.WithLabelValues(t.path).Inc()
-	}
+	t.bufferPool.put(t, n)
 }
 
 func (t *TBtree) nodeAt(offset int64) (node, error) {
 	t.nmutex.Lock()
 	defer t.nmutex.Unlock()
 
-	size := t.cache.EntriesCount()
-	metricsCacheSizeStats.WithLabelValues(t.path).Set(float64(size))
-
-	v, err := t.cache.Get(offset)
-	if err == nil {
+	n, ok := t.bufferPool.get(t, offset)
+	if ok {
 		metricsCacheHit.WithLabelValues(t.path).Inc()
-		return v.(node), nil
+		return n, nil
 	}
 
-	if err == cache.ErrKeyNotFound {
-		metricsCacheMiss.WithLabelValues(t.path).Inc()
-
-		n, err := t.readNodeAt(offset)
-
-		if err != nil {
-			return nil, err
-		}
-
-		r, _, _ := t.cache.Put(n.offset(), n)
-		if r != nil {
-			metricsCacheEvict.WithLabelValues(t.path).Inc()
-		}
+	metricsCacheMiss.WithLabelValues(t.path).Inc()
 
-		return n, nil
+	n, err := t.readNodeAt(offset)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, err
+	t.bufferPool.put(t, n)
+
+	return n, nil
 }
 
 func (t *TBtree) readNodeAt(off int64) (node, error) {
@@ -882,6 +902,19 @@ func (t *TBtree) Flush() (wN, wH int64, err error) {
 	return t.flushTree(false)
 }
 
+// Checkpoint flushes and syncs the tree, so it's opened back from the current root
+func (t *TBtree) Checkpoint() error {
+	t.rwmutex.Lock()
+	defer t.rwmutex.Unlock()
+
+	if t.closed {
+		return ErrAlreadyClosed
+	}
+
+	_, _, err := t.flushTree(true)
+	return err
+}
+
 type appendableWriter struct {
 	appendable.Appendable
 }
@@ -892,17 +925,18 @@ func (aw *appendableWriter) Write(b []byte) (int, error) {
 }
 
 func (t *TBtree) wrapNwarn(formattedMessage string, args ...interface{}) error {
-	t.log.Warningf(formattedMessage, args)
-	return fmt.Errorf(formattedMessage, args...)
+	err := fmt.Errorf(formattedMessage, args...)
+	t.log.Warn(err.Error())
+	return err
 }
 
 func (t *TBtree) flushTree(ensureSync bool) (wN int64, wH int64, err error) {
-	t.log.Infof("Flushing index '%s' {ts=%d}...", t.path, t.root.ts())
+	t.log.Info("Flushing index...", logger.F("path", t.path), logger.F("ts", t.root.ts()))
 
 	metricsFlushingNodesProgress.WithLabelValues(t.path).Set(float64(0))
 
 	if !t.root.mutated() && !ensureSync {
-		t.log.Infof("Flushing not needed at '%s' {ts=%d}", t.path, t.root.ts())
+		t.log.Info("Flushing not needed", logger.F("path", t.path), logger.F("ts", t.root.ts()))
 		return 0, 0, nil
 	}
 
@@ -1003,11 +1037,16 @@ func (t *TBtree) flushTree(ensureSync bool) (wN int64, wH int64, err error) {
 		off:     t.root.offset(),
 	}
 
-	t.log.Infof("Index '%s' {ts=%d} successfully flushed", t.path, t.root.ts())
+	t.log.Info("Index successfully flushed", logger.F("path", t.path), logger.F("ts", t.root.ts()))
 
 	if t.synced || explicitSync {
 		t.lastSyncedAt = t.root.ts()
-		t.log.Infof("Index '%s' {ts=%d} successfully synced", t.path, t.lastSyncedAt)
+		t.log.Info("Index successfully synced", logger.F("path", t.path), logger.F("ts", t.lastSyncedAt))
+
+		err = t.resetWAL()
+		if err != nil {
+			return 0, 0, t.wrapNwarn("Resetting write-ahead log of index '%s' {ts=%d} returned: %v", t.path, t.root.ts(), err)
+		}
 	}
 
 	return wN, wH, nil
@@ -1069,14 +1108,14 @@ func (t *TBtree) Compact() (uint64, error) {
 	t.rwmutex.Unlock()
 	defer t.rwmutex.Lock()
 
-	t.log.Infof("Dumping index '%s' {ts=%d}...", t.path, snap.Ts())
+	t.log.Info("Dumping index...", logger.F("path", t.path), logger.F("ts", snap.Ts()))
 
 	err = t.fullDump(snap)
 	if err != nil {
 		return 0, t.wrapNwarn("Dumping index '%s' {ts=%d} returned: %v", t.path, snap.Ts(), err)
 	}
 
-	t.log.Infof("Index '%s' {ts=%d} successfully dumped", t.path, snap.Ts())
+	t.log.Info("Index successfully dumped", logger.F("path", t.path), logger.F("ts", snap.Ts()))
 
 	return snap.Ts(), nil
 }
@@ -1160,7 +1199,7 @@ func (t *TBtree) fullDumpTo(snapshot *Snapshot, nLog, cLog appendable.Appendable
 }
 
 func (t *TBtree) Close() error {
-	t.log.Infof("Closing index '%s' {ts=%d}...", t.path, t.root.ts())
+	t.log.Info("Closing index...", logger.F("path", t.path), logger.F("ts", t.root.ts()))
 
 	t.rwmutex.Lock()
 	defer t.rwmutex.Unlock()
@@ -1189,12 +1228,19 @@ func (t *TBtree) Close() error {
 	err = t.cLog.Close()
 	merrors.Append(err)
 
+	if t.wal != nil {
+		err = t.wal.Close()
+		merrors.Append(err)
+	}
+
+	t.bufferPool.discard(t)
+
 	err = merrors.Reduce()
 	if err != nil {
 		return t.wrapNwarn("Closing index '%s' {ts=%d} returned: %v", t.path, t.root.ts(), err)
 	}
 
-	t.log.Infof("Index '%s' {ts=%d} successfully closed", t.path, t.root.ts())
+	t.log.Info("Index successfully closed", logger.F("path", t.path), logger.F("ts", t.root.ts()))
 	return nil
 }
 
@@ -1247,6 +1293,27 @@ func (t *TBtree) BulkInsert(kvs []*KV) error {
 		return ErrAlreadyClosed
 	}
 
+	prevTs := t.root.ts()
+
+	err := t.bulkInsert(kvs)
+	if err != nil {
+		return err
+	}
+
+	err = t.appendToWAL(prevTs, t.root.ts(), kvs)
+	if err != nil {
+		return err
+	}
+
+	if t.insertionCount >= t.flushThld {
+		_, _, err := t.flushTree(false)
+		return err
+	}
+
+	return nil
+}
+
+func (t *TBtree) bulkInsert(kvs []*KV) error {
 	ts := t.root.ts() + 1
 
 	for _, kv := range kvs {
@@ -1283,11 +1350,40 @@ func (t *TBtree) BulkInsert(kvs []*KV) error {
 		t.insertionCount++
 	}
 
-	if t.insertionCount >= t.flushThld {
-		_, _, err := t.flushTree(false)
+	return nil
+}
+
+// IncreaseTs advances the timestamp of the tree without inserting any entry
+func (t *TBtree) IncreaseTs(ts uint64) error {
+	t.rwmutex.Lock()
+	defer t.rwmutex.Unlock()
+
+	if t.closed {
+		return ErrAlreadyClosed
+	}
+
+	if ts <= t.root.ts() {
+		return ErrIllegalArguments
+	}
+
+	prevTs := t.root.ts()
+
+	err := t.increaseTs(ts)
+	if err != nil {
 		return err
 	}
 
+	return t.appendToWAL(prevTs, ts, nil)
+}
+
+func (t *TBtree) increaseTs(ts uint64) error {
+	root, err := t.root.setTs(ts)
+	if err != nil {
+		return err
+	}
+
+	t.root = root
+
 	return nil
 }
 
@@ -1298,6 +1394,14 @@ func (t *TBtree) Ts() uint64 {
 	return t.root.ts()
 }
 
+// SyncedTs returns the timestamp of the last synced root, the tree is opened back from it
+func (t *TBtree) SyncedTs() uint64 {
+	t.rwmutex.RLock()
+	defer t.rwmutex.RUnlock()
+
+	return t.lastSyncedAt
+}
+
 func (t *TBtree) Snapshot() (*Snapshot, error) {
 	return t.SnapshotSince(0)
 }
@@ -1502,6 +1606,27 @@ func (n *innerNode) get(key []byte) (value []byte, ts uint64, hc uint64, err err
 	return n.nodes[i].get(key)
 }
 
+func (n *innerNode) setTs(ts uint64) (node, error) {
+	if n.mut {
+		n._ts = ts
+		return n, nil
+	}
+
+	newNode := &innerNode{
+		t:       n.t,
+		nodes:   make([]node, len(n.nodes)),
+		_minKey: n._minKey,
+		_maxKey: n._maxKey,
+		_ts:     ts,
+		maxSize: n.maxSize,
+		mut:     true,
+	}
+
+	copy(newNode.nodes, n.nodes)
+
+	return newNode, nil
+}
+
 func (n *innerNode) history(key []byte, offset uint64, descOrder bool, limit int) ([]uint64, error) {
 	i := n.indexOf(key)
 
@@ -1668,6 +1793,14 @@ func (r *nodeRef) get(key []byte) (value []byte, ts uint64, hc uint64, err error
 	return n.get(key)
 }
 
+func (r *nodeRef) setTs(ts uint64) (node, error) {
+	n, err := r.t.nodeAt(r.off)
+	if err != nil {
+		return nil, err
+	}
+	return n.setTs(ts)
+}
+
 func (r *nodeRef) history(key []byte, offset uint64, descOrder bool, limit int) ([]uint64, error) {
 	n, err := r.t.nodeAt(r.off)
 	if err != nil {
@@ -1878,6 +2011,36 @@ func (l *leafNode) get(key []byte) (value []byte, ts uint64, hc uint64, err erro
 	return leafValue.value, leafValue.ts, leafValue.hCount + uint64(len(leafValue.tss)), nil
 }
 
+func (l *leafNode) setTs(ts uint64) (node, error) {
+	if l.mut {
+		l._ts = ts
+		return l, nil
+	}
+
+	newLeaf := &leafNode{
+		t:       l.t,
+		values:  make([]*leafValue, len(l.values)),
+		_minKey: l._minKey,
+		_maxKey: l._maxKey,
+		_ts:     ts,
+		maxSize: l.maxSize,
+		mut:     true,
+	}
+
+	for i, lv := range l.values {
+		newLeaf.values[i] = &leafValue{
+			key:    lv.key,
+			value:  lv.value,
+			ts:     lv.ts,
+			tss:    lv.tss,
+			hOff:   lv.hOff,
+			hCount: lv.hCount,
+		}
+	}
+
+	return newLeaf, nil
+}
+
 func (l *leafNode) history(key []byte, offset uint64, desc bool, limit int) ([]uint64, error) {
 	i, found := l.indexOf(key)
 
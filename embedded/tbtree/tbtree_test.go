@@ -768,6 +768,97 @@ func TestTBTreeHistory(t *testing.T) {
 	require.Equal(t, 2, len(tss))
 }
 
+func TestTBTreeIncreaseTs(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxNodeSize(256).WithFlushThld(100)
+	tbtree, err := Open("test_tree_increase_ts", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("test_tree_increase_ts")
+
+	err = tbtree.IncreaseTs(0)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	err = tbtree.IncreaseTs(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), tbtree.Ts())
+
+	err = tbtree.BulkInsert([]*KV{{K: []byte("k0"), V: []byte("v0")}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), tbtree.Ts())
+
+	snap, err := tbtree.SnapshotSince(2)
+	require.NoError(t, err)
+
+	err = tbtree.IncreaseTs(3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), tbtree.Ts())
+
+	// snapshots are not affected
+	require.Equal(t, uint64(2), snap.Ts())
+
+	_, ts, _, err := snap.Get([]byte("k0"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), ts)
+
+	err = snap.Close()
+	require.NoError(t, err)
+
+	err = tbtree.IncreaseTs(3)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	for i := 0; i < 100; i++ {
+		err = tbtree.BulkInsert([]*KV{{K: []byte(fmt.Sprintf("k%d", i)), V: []byte("v1")}})
+		require.NoError(t, err)
+	}
+
+	_, _, err = tbtree.Flush()
+	require.NoError(t, err)
+
+	err = tbtree.IncreaseTs(1000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), tbtree.Ts())
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+
+	err = tbtree.IncreaseTs(1001)
+	require.ErrorIs(t, err, ErrAlreadyClosed)
+}
+
+func TestTBTreeCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+
+	opts := DefaultOptions().WithSynced(false).WithFlushThld(1000).WithSyncThld(1000)
+	tbtree, err := Open(dir, opts)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		err = tbtree.Insert([]byte(fmt.Sprintf("k%d", i)), []byte("v"))
+		require.NoError(t, err)
+	}
+
+	require.Zero(t, tbtree.SyncedTs())
+
+	err = tbtree.Checkpoint()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), tbtree.SyncedTs())
+
+	err = tbtree.Insert([]byte("k10"), []byte("v"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), tbtree.SyncedTs())
+
+	// the tree is left opened, the insertion after the checkpoint is not persisted
+	tbtree, err = Open(dir, opts)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), tbtree.Ts())
+	require.Equal(t, uint64(10), tbtree.SyncedTs())
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+
+	err = tbtree.Checkpoint()
+	require.ErrorIs(t, err, ErrAlreadyClosed)
+}
+
 func TestTBTreeInsertionInAscendingOrder(t *testing.T) {
 	opts := DefaultOptions().WithSynced(false).WithMaxNodeSize(256).WithFlushThld(100)
 	tbtree, err := Open("test_tree_iasc", opts)
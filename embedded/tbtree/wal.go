@@ -0,0 +1,218 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tbtree
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+
+	"github.com/codenotary/immudb/embedded/appendable/multiapp"
+	"github.com/codenotary/immudb/embedded/logger"
+)
+
+const walFolder = "wal"
+
+const walRecordHeaderSize = 4 + 4 // crc32 + payload length
+
+// the payload of a wal record is [prevTs][ts][nkvs]{[klen][k][vlen][v]}
+const walPayloadHeaderSize = 8 + 8 + 4
+
+// appendToWAL logs the insertions (or the timestamp increase when kvs is empty) moving the tree from prevTs to ts.
+// Records are kept until the tree is synced, as only synced roots are loaded back when the tree is opened.
+func (t *TBtree) appendToWAL(prevTs, ts uint64, kvs []*KV) error {
+	if t.wal == nil {
+		return nil
+	}
+
+	size := walRecordHeaderSize + walPayloadHeaderSize
+	for _, kv := range kvs {
+		size += 4 + len(kv.K) + 4 + len(kv.V)
+	}
+
+	b := make([]byte, size)
+
+	i := walRecordHeaderSize
+
+	binary.BigEndian.PutUint64(b[i:], prevTs)
+	i += 8
+
+	binary.BigEndian.PutUint64(b[i:], ts)
+	i += 8
+
+	binary.BigEndian.PutUint32(b[i:], uint32(len(kvs)))
+	i += 4
+
+	for _, kv := range kvs {
+		binary.BigEndian.PutUint32(b[i:], uint32(len(kv.K)))
+		i += 4
+
+		i += copy(b[i:], kv.K)
+
+		binary.BigEndian.PutUint32(b[i:], uint32(len(kv.V)))
+		i += 4
+
+		i += copy(b[i:], kv.V)
+	}
+
+	binary.BigEndian.PutUint32(b[4:], uint32(size-walRecordHeaderSize))
+	binary.BigEndian.PutUint32(b, crc32.ChecksumIEEE(b[walRecordHeaderSize:]))
+
+	_, _, err := t.wal.Append(b)
+	if err != nil {
+		return err
+	}
+
+	// records are not synced, the index can be rebuilt from the store if they are lost
+	return t.wal.Flush()
+}
+
+// resetWAL discards the records already included in a synced root
+func (t *TBtree) resetWAL() error {
+	if t.wal == nil {
+		return nil
+	}
+
+	return t.wal.SetOffset(0)
+}
+
+// replayWAL applies the logged insertions following the loaded root.
+// Replay stops at the first record which is partially written or does not follow the current timestamp.
+func (t *TBtree) replayWAL() error {
+	walSize, err := t.wal.Size()
+	if err != nil {
+		return err
+	}
+
+	var off int64
+	var replayed int
+
+	for off+walRecordHeaderSize+walPayloadHeaderSize <= walSize {
+		var hdr [walRecordHeaderSize]byte
+
+		_, err = t.wal.ReadAt(hdr[:], off)
+		if err != nil {
+			break
+		}
+
+		payloadLen := int64(binary.BigEndian.Uint32(hdr[4:]))
+		if payloadLen < walPayloadHeaderSize || off+walRecordHeaderSize+payloadLen > walSize {
+			break
+		}
+
+		payload := make([]byte, payloadLen)
+
+		_, err = t.wal.ReadAt(payload, off+walRecordHeaderSize)
+		if err != nil {
+			break
+		}
+
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(hdr[:]) {
+			break
+		}
+
+		prevTs, ts, kvs, ok := decodeWALPayload(payload)
+		if !ok {
+			break
+		}
+
+		if ts <= t.root.ts() {
+			// already included in the loaded root
+			off += walRecordHeaderSize + payloadLen
+			continue
+		}
+
+		if prevTs != t.root.ts() {
+			break
+		}
+
+		if len(kvs) == 0 {
+			err = t.increaseTs(ts)
+		} else {
+			err = t.bulkInsert(kvs)
+		}
+		if err != nil {
+			return err
+		}
+
+		off += walRecordHeaderSize + payloadLen
+		replayed++
+	}
+
+	// records not replayed are overwritten from here on
+	err = t.wal.SetOffset(off)
+	if err != nil {
+		return err
+	}
+
+	t.log.Info("Index write-ahead log replayed", logger.F("path", t.path), logger.F("ts", t.root.ts()), logger.F("replayed_records", replayed))
+
+	return nil
+}
+
+func decodeWALPayload(b []byte) (prevTs, ts uint64, kvs []*KV, ok bool) {
+	prevTs = binary.BigEndian.Uint64(b)
+	ts = binary.BigEndian.Uint64(b[8:])
+	nkvs := int(binary.BigEndian.Uint32(b[16:]))
+
+	i := walPayloadHeaderSize
+
+	for n := 0; n < nkvs; n++ {
+		k, j, ok := decodeWALBytes(b, i)
+		if !ok {
+			return 0, 0, nil, false
+		}
+
+		v, j, ok := decodeWALBytes(b, j)
+		if !ok {
+			return 0, 0, nil, false
+		}
+
+		kvs = append(kvs, &KV{K: k, V: v})
+		i = j
+	}
+
+	return prevTs, ts, kvs, i == len(b)
+}
+
+func decodeWALBytes(b []byte, i int) ([]byte, int, bool) {
+	if i+4 > len(b) {
+		return nil, 0, false
+	}
+
+	l := int(binary.BigEndian.Uint32(b[i:]))
+	i += 4
+
+	if l > len(b)-i {
+		return nil, 0, false
+	}
+
+	return b[i : i+l], i + l, true
+}
+
+// openWAL opens the write-ahead log of the tree and replays the records not included in the loaded root
+func openWAL(t *TBtree, path string, appFactory AppFactoryFunc, appendableOpts *multiapp.Options) error {
+	appendableOpts.WithFileExt("wal")
+	appendableOpts.WithMaxOpenedFiles(1)
+
+	wal, err := appFactory(path, walFolder, appendableOpts)
+	if err != nil {
+		return err
+	}
+
+	t.wal = wal
+
+	return t.replayWAL()
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tbtree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTBTreeWAL(t *testing.T) {
+	walPath := func(dir string) string {
+		return filepath.Join(dir, walFolder, "00000000.wal")
+	}
+
+	// the tree is left opened, as if the process crashed, without flushing the inserted entries
+	insertAndCrash := func(t *testing.T, dir string, opts *Options, from, to int) {
+		tree, err := Open(dir, opts)
+		require.NoError(t, err)
+
+		for i := from; i < to; i++ {
+			err = tree.Insert([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+			require.NoError(t, err)
+		}
+	}
+
+	requireKeys := func(t *testing.T, tree *TBtree, n int) {
+		for i := 0; i < n; i++ {
+			v, _, _, err := tree.Get([]byte(fmt.Sprintf("key%d", i)))
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value%d", i)), v)
+		}
+
+		_, _, _, err := tree.Get([]byte(fmt.Sprintf("key%d", n)))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	}
+
+	opts := DefaultOptions().WithFlushThld(1000).WithSyncThld(1000).WithWAL(true)
+
+	t.Run("insertions should be replayed after a crash", func(t *testing.T) {
+		dir := t.TempDir()
+
+		insertAndCrash(t, dir, opts, 0, 100)
+
+		tree, err := Open(dir, opts)
+		require.NoError(t, err)
+		defer tree.Close()
+
+		require.Equal(t, uint64(100), tree.Ts())
+		requireKeys(t, tree, 100)
+
+		err = tree.Insert([]byte("key100"), []byte("value100"))
+		require.NoError(t, err)
+		require.Equal(t, uint64(101), tree.Ts())
+	})
+
+	t.Run("insertions should be lost after a crash when the wal is disabled", func(t *testing.T) {
+		dir := t.TempDir()
+
+		insertAndCrash(t, dir, DefaultOptions().WithFlushThld(1000).WithSyncThld(1000), 0, 100)
+
+		tree, err := Open(dir, opts)
+		require.NoError(t, err)
+		defer tree.Close()
+
+		require.Zero(t, tree.Ts())
+	})
+
+	t.Run("insertions should be replayed on top of the synced root", func(t *testing.T) {
+		dir := t.TempDir()
+
+		tree, err := Open(dir, opts)
+		require.NoError(t, err)
+
+		for i := 0; i < 50; i++ {
+			err = tree.Insert([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+			require.NoError(t, err)
+		}
+
+		err = tree.Close()
+		require.NoError(t, err)
+
+		// records included in the synced root are not replayed again
+		insertAndCrash(t, dir, opts, 50, 100)
+
+		tree, err = Open(dir, opts)
+		require.NoError(t, err)
+		defer tree.Close()
+
+		require.Equal(t, uint64(100), tree.Ts())
+		requireKeys(t, tree, 100)
+	})
+
+	t.Run("timestamp increases should be replayed", func(t *testing.T) {
+		dir := t.TempDir()
+
+		insertAndCrash(t, dir, opts, 0, 10)
+
+		tree, err := Open(dir, opts)
+		require.NoError(t, err)
+
+		err = tree.IncreaseTs(20)
+		require.NoError(t, err)
+
+		tree, err = Open(dir, opts)
+		require.NoError(t, err)
+		defer tree.Close()
+
+		require.Equal(t, uint64(20), tree.Ts())
+		requireKeys(t, tree, 10)
+	})
+
+	t.Run("a partially written record should not be replayed", func(t *testing.T) {
+		dir := t.TempDir()
+
+		insertAndCrash(t, dir, opts, 0, 10)
+
+		finfo, err := os.Stat(walPath(dir))
+		require.NoError(t, err)
+
+		err = os.Truncate(walPath(dir), finfo.Size()-1)
+		require.NoError(t, err)
+
+		tree, err := Open(dir, opts)
+		require.NoError(t, err)
+		defer tree.Close()
+
+		require.Equal(t, uint64(9), tree.Ts())
+		requireKeys(t, tree, 9)
+	})
+
+	t.Run("the wal should be kept in the options", func(t *testing.T) {
+		tree, err := Open(t.TempDir(), opts)
+		require.NoError(t, err)
+		defer tree.Close()
+
+		require.True(t, tree.GetOptions().useWAL)
+	})
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"math/rand"
@@ -148,7 +149,7 @@ func main() {
 	}
 	log.Printf("SQL engine successfully initialized!\r\n")
 
-	_, _, err = engine.Exec("CREATE DATABASE defaultdb;", map[string]interface{}{}, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE defaultdb;", map[string]interface{}{}, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -159,7 +160,7 @@ func main() {
 	}
 
 	log.Printf("Creating tables\r\n")
-	_, _, err = engine.Exec("CREATE TABLE IF NOT EXISTS entries (id INTEGER, value BLOB, ts INTEGER, PRIMARY KEY id);", map[string]interface{}{}, nil)
+	_, _, err = engine.Exec(context.Background(), "CREATE TABLE IF NOT EXISTS entries (id INTEGER, value BLOB, ts INTEGER, PRIMARY KEY id);", map[string]interface{}{}, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -199,7 +200,7 @@ func main() {
 			log.Printf("Committer %d is inserting data...\r\n", id)
 			for i := 0; i < c.kvCount; i++ {
 				entry := <-entries
-				_, _, err = engine.Exec("INSERT INTO entries (id, value, ts) VALUES (@id, @value, now());",
+				_, _, err = engine.Exec(context.Background(), "INSERT INTO entries (id, value, ts) VALUES (@id, @value, now());",
 					map[string]interface{}{"id": entry.id, "value": entry.value}, nil)
 				if err != nil {
 					panic(err)
@@ -217,7 +218,7 @@ func main() {
 			}
 			log.Printf("Reader %d is reading data\n", id)
 			for i := 1; i <= c.rdCount; i++ {
-				r, err := engine.Query("SELECT count() FROM entries where id<=@i;", map[string]interface{}{"i": i}, nil)
+				r, err := engine.Query(context.Background(), "SELECT count() FROM entries where id<=@i;", map[string]interface{}{"i": i}, nil)
 				if err != nil {
 					log.Printf("Error querying val %d: %s", i, err.Error())
 					panic(err)
@@ -243,7 +244,7 @@ func main() {
 	wg.Wait()
 	log.Printf("All committers done...\r\n")
 
-	r, err := engine.Query("SELECT count() FROM  entries;", map[string]interface{}{}, nil)
+	r, err := engine.Query(context.Background(), "SELECT count() FROM  entries;", map[string]interface{}{}, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,35 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ContentKeyPrefix prefixes the keys of content-addressed values, which are followed by the hex encoded sha256 digest
+const ContentKeyPrefix = "sha256:"
+
+// ContentKey returns the key under which the value with the given digest is stored e.g. sha256:2cf24dba...
+func ContentKey(digest []byte) []byte {
+	return append([]byte(ContentKeyPrefix), hex.EncodeToString(digest)...)
+}
+
+// ContentDigest returns the sha256 digest of the value
+func ContentDigest(value []byte) []byte {
+	digest := sha256.Sum256(value)
+	return digest[:]
+}
@@ -17,6 +17,7 @@ package schema
 
 import (
 	"crypto/sha256"
+	"fmt"
 	"time"
 
 	"github.com/codenotary/immudb/embedded/htree"
@@ -49,7 +50,8 @@ func KVMetadataToProto(md *store.KVMetadata) *KVMetadata {
 	}
 
 	kvmd := &KVMetadata{
-		Deleted: md.Deleted(),
+		Deleted:      md.Deleted(),
+		NonIndexable: md.NonIndexable(),
 	}
 
 	if md.IsExpirable() {
@@ -71,13 +73,14 @@ func TxFromProto(stx *Tx) *store.Tx {
 
 	hdr := tx.Header()
 
-	hdr.ID = stx.Header.Id
-	hdr.Ts = stx.Header.Ts
-	hdr.PrevAlh = DigestFromProto(stx.Header.PrevAlh)
-	hdr.BlTxID = stx.Header.BlTxId
-	hdr.BlRoot = DigestFromProto(stx.Header.BlRoot)
-	hdr.Version = int(stx.Header.Version)
-	hdr.Metadata = TxMetadataFromProto(stx.Header.Metadata)
+	// getters are used as the header may be missing, making the verification of the tx fail
+	hdr.ID = stx.Header.GetId()
+	hdr.Ts = stx.Header.GetTs()
+	hdr.PrevAlh = DigestFromProto(stx.Header.GetPrevAlh())
+	hdr.BlTxID = stx.Header.GetBlTxId()
+	hdr.BlRoot = DigestFromProto(stx.Header.GetBlRoot())
+	hdr.Version = int(stx.Header.GetVersion())
+	hdr.Metadata = TxMetadataFromProto(stx.Header.GetMetadata())
 
 	tx.BuildHashTree()
 
@@ -93,6 +96,8 @@ func KVMetadataFromProto(md *KVMetadata) *store.KVMetadata {
 
 	kvmd.AsDeleted(md.Deleted)
 
+	kvmd.AsNonIndexable(md.NonIndexable)
+
 	if md.Expiration != nil {
 		kvmd.ExpiresAt(time.Unix(md.Expiration.ExpiresAt, 0))
 	}
@@ -109,6 +114,10 @@ func InclusionProofToProto(iproof *htree.InclusionProof) *InclusionProof {
 }
 
 func InclusionProofFromProto(iproof *InclusionProof) *htree.InclusionProof {
+	if iproof == nil {
+		return nil
+	}
+
 	return &htree.InclusionProof{
 		Leaf:  int(iproof.Leaf),
 		Width: int(iproof.Width),
@@ -151,7 +160,9 @@ func TxMetadataToProto(md *store.TxMetadata) *TxMetadata {
 		return nil
 	}
 
-	return &TxMetadata{}
+	return &TxMetadata{
+		Labels: md.Labels(),
+	}
 }
 
 func LinearProofToProto(linearProof *store.LinearProof) *LinearProof {
@@ -163,6 +174,10 @@ func LinearProofToProto(linearProof *store.LinearProof) *LinearProof {
 }
 
 func DualProofFromProto(dproof *DualProof) *store.DualProof {
+	if dproof == nil {
+		return nil
+	}
+
 	return &store.DualProof{
 		SourceTxHeader:     TxHeaderFromProto(dproof.SourceTxHeader),
 		TargetTxHeader:     TxHeaderFromProto(dproof.TargetTxHeader),
@@ -175,6 +190,10 @@ func DualProofFromProto(dproof *DualProof) *store.DualProof {
 }
 
 func TxHeaderFromProto(hdr *TxHeader) *store.TxHeader {
+	if hdr == nil {
+		return nil
+	}
+
 	return &store.TxHeader{
 		ID:       hdr.Id,
 		PrevAlh:  DigestFromProto(hdr.PrevAlh),
@@ -193,10 +212,40 @@ func TxMetadataFromProto(md *TxMetadata) *store.TxMetadata {
 		return nil
 	}
 
-	return &store.TxMetadata{}
+	txmd := store.NewTxMetadata()
+
+	for k, v := range md.Labels {
+		// invalid labels are left out, making the verification of the tx fail
+		txmd.WithLabel(k, v)
+	}
+
+	return txmd
+}
+
+// TxMetadataFromLabels returns the metadata holding the labels to be attached
+// to a new tx or nil if there are no labels
+func TxMetadataFromLabels(labels map[string]string) (*store.TxMetadata, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	txmd := store.NewTxMetadata()
+
+	for k, v := range labels {
+		err := txmd.WithLabel(k, v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: label '%s'", err, k)
+		}
+	}
+
+	return txmd, nil
 }
 
 func LinearProofFromProto(lproof *LinearProof) *store.LinearProof {
+	if lproof == nil {
+		return nil
+	}
+
 	return &store.LinearProof{
 		SourceTxID: lproof.SourceTxId,
 		TargetTxID: lproof.TargetTxId,
@@ -25,4 +25,6 @@ var (
 	ErrDuplicatedKeysNotSupported       = status.New(codes.InvalidArgument, "duplicated keys are not supported in single batch transaction").Err()
 	ErrDuplicatedZAddNotSupported       = status.New(codes.InvalidArgument, "duplicated index inside zAdd insertions are not supported in single batch transaction").Err()
 	ErrDuplicatedReferencesNotSupported = status.New(codes.InvalidArgument, "duplicated references insertions are not supported in single batch transaction").Err()
+	ErrInvalidNotarizationRecord        = status.New(codes.InvalidArgument, "notarization record must hold the sha256 digest and the size of the object").Err()
+	ErrContentDigestMismatch            = status.New(codes.FailedPrecondition, "the value stored under the content key does not match its digest").Err()
 )
@@ -0,0 +1,38 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// DatabaseTxHeadersDigest computes the digest binding together the transactions committed by ExecAllPerDatabase
+func DatabaseTxHeadersDigest(txHeaders []*DatabaseTxHeader) []byte {
+	digest := sha256.New()
+
+	for _, h := range txHeaders {
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], uint32(len(h.Database)))
+		digest.Write(b[:])
+		digest.Write([]byte(h.Database))
+
+		alh := TxHeaderFromProto(h.TxHeader).Alh()
+		digest.Write(alh[:])
+	}
+
+	return digest.Sum(nil)
+}
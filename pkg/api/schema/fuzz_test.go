@@ -0,0 +1,158 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/htree"
+	"github.com/codenotary/immudb/embedded/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// proofs and transactions are received from the server, thus any
+// content they hold must be either verified or rejected without panicking
+
+func FuzzVerifyDualProof(f *testing.F) {
+	alh := sha256.Sum256([]byte("alh"))
+
+	seed, err := proto.Marshal(&DualProof{
+		SourceTxHeader: &TxHeader{Id: 1, BlTxId: 0},
+		TargetTxHeader: &TxHeader{Id: 2, BlTxId: 1, BlRoot: alh[:]},
+		InclusionProof: [][]byte{alh[:]},
+		TargetBlTxAlh:  alh[:],
+		LinearProof:    &LinearProof{SourceTxId: 1, TargetTxId: 2, Terms: [][]byte{alh[:], alh[:]}},
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(seed, uint64(1), uint64(2), alh[:], alh[:])
+	f.Add([]byte{}, uint64(0), uint64(0), []byte{}, []byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte, sourceTxID, targetTxID uint64, sourceAlh, targetAlh []byte) {
+		var dproof DualProof
+
+		err := proto.Unmarshal(data, &dproof)
+		if err != nil {
+			return
+		}
+
+		store.VerifyDualProof(
+			DualProofFromProto(&dproof),
+			sourceTxID,
+			targetTxID,
+			DigestFromProto(sourceAlh),
+			DigestFromProto(targetAlh),
+		)
+	})
+}
+
+func FuzzVerifyLinearProof(f *testing.F) {
+	alh := sha256.Sum256([]byte("alh"))
+
+	seed, err := proto.Marshal(&LinearProof{SourceTxId: 1, TargetTxId: 2, Terms: [][]byte{alh[:], alh[:]}})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(seed, uint64(1), uint64(2), alh[:], alh[:])
+
+	f.Fuzz(func(t *testing.T, data []byte, sourceTxID, targetTxID uint64, sourceAlh, targetAlh []byte) {
+		var lproof LinearProof
+
+		err := proto.Unmarshal(data, &lproof)
+		if err != nil {
+			return
+		}
+
+		store.VerifyLinearProof(
+			LinearProofFromProto(&lproof),
+			sourceTxID,
+			targetTxID,
+			DigestFromProto(sourceAlh),
+			DigestFromProto(targetAlh),
+		)
+	})
+}
+
+func FuzzVerifyInclusion(f *testing.F) {
+	digest := sha256.Sum256([]byte("digest"))
+
+	seed, err := proto.Marshal(&InclusionProof{Leaf: 1, Width: 2, Terms: [][]byte{digest[:]}})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(seed, digest[:], digest[:])
+
+	f.Fuzz(func(t *testing.T, data []byte, entryDigest, root []byte) {
+		var iproof InclusionProof
+
+		err := proto.Unmarshal(data, &iproof)
+		if err != nil {
+			return
+		}
+
+		htree.VerifyInclusion(InclusionProofFromProto(&iproof), DigestFromProto(entryDigest), DigestFromProto(root))
+	})
+}
+
+func FuzzTxFromProto(f *testing.F) {
+	digest := sha256.Sum256([]byte("digest"))
+
+	seed, err := proto.Marshal(&Tx{
+		Header: &TxHeader{Id: 1, PrevAlh: digest[:], Nentries: 1},
+		Entries: []*TxEntry{
+			{Key: []byte("key1"), HValue: digest[:], VLen: 6},
+		},
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(seed)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var stx Tx
+
+		err := proto.Unmarshal(data, &stx)
+		if err != nil {
+			return
+		}
+
+		tx := TxFromProto(&stx)
+
+		txEntryDigest, err := tx.TxEntryDigest()
+		if err != nil {
+			return
+		}
+
+		for _, e := range tx.Entries() {
+			proof, err := tx.Proof(e.Key())
+			if err != nil {
+				continue
+			}
+
+			htree.VerifyInclusion(proof, txEntryDigest(e), tx.Header().Eh)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package schema
+
+import (
+	"crypto/sha256"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// NotarizationKeyPrefix prefixes the keys of the notarization records, which are followed by the object digest
+const NotarizationKeyPrefix = "notarization:"
+
+// NotarizationKey returns the key under which the record of the object with the given digest is stored
+func NotarizationKey(digest []byte) []byte {
+	return append([]byte(NotarizationKeyPrefix), digest...)
+}
+
+// Validate checks the record describes an object by its sha256 digest and size
+func (r *NotarizationRecord) Validate() error {
+	if r == nil || len(r.Digest) != sha256.Size || r.Size < 0 {
+		return ErrInvalidNotarizationRecord
+	}
+	return nil
+}
+
+// Encode returns the value stored for the record. Encoding is deterministic,
+// so receipts can be checked by encoding the record again
+func (r *NotarizationRecord) Encode() ([]byte, error) {
+	return proto.MarshalOptions{Deterministic: true}.Marshal(r)
+}
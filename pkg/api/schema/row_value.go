@@ -102,6 +102,56 @@ func (v *SQLValue_Ts) Equal(sqlv SqlValue) (bool, error) {
 	return v.Ts == ts.Ts, nil
 }
 
+func (v *SQLValue_F) Equal(sqlv SqlValue) (bool, error) {
+	_, isNull := sqlv.(*SQLValue_Null)
+	if isNull {
+		return false, nil
+	}
+
+	f, isFloat := sqlv.(*SQLValue_F)
+	if !isFloat {
+		return false, sql.ErrNotComparableValues
+	}
+	return v.F == f.F, nil
+}
+
+func (v *SQLValue_D) Equal(sqlv SqlValue) (bool, error) {
+	_, isNull := sqlv.(*SQLValue_Null)
+	if isNull {
+		return false, nil
+	}
+
+	d, isDecimal := sqlv.(*SQLValue_D)
+	if !isDecimal {
+		return false, sql.ErrNotComparableValues
+	}
+
+	dl, err := sql.ParseDecimal(v.D)
+	if err != nil {
+		return false, err
+	}
+
+	dr, err := sql.ParseDecimal(d.D)
+	if err != nil {
+		return false, err
+	}
+
+	return dl.Cmp(dr) == 0, nil
+}
+
+func (v *SQLValue_Json) Equal(sqlv SqlValue) (bool, error) {
+	_, isNull := sqlv.(*SQLValue_Null)
+	if isNull {
+		return false, nil
+	}
+
+	j, isJSON := sqlv.(*SQLValue_Json)
+	if !isJSON {
+		return false, sql.ErrNotComparableValues
+	}
+	return v.Json == j.Json, nil
+}
+
 func RenderValue(op isSQLValue_Value) string {
 	switch v := op.(type) {
 	case *SQLValue_Null:
@@ -129,6 +179,18 @@ func RenderValue(op isSQLValue_Value) string {
 			t := sql.TimeFromInt64(v.Ts)
 			return t.Format("2006-01-02 15:04:05.999999")
 		}
+	case *SQLValue_F:
+		{
+			return strconv.FormatFloat(v.F, 'g', -1, 64)
+		}
+	case *SQLValue_D:
+		{
+			return v.D
+		}
+	case *SQLValue_Json:
+		{
+			return v.Json
+		}
 	}
 
 	return fmt.Sprintf("%v", op)
@@ -161,6 +223,18 @@ func RenderValueAsByte(op isSQLValue_Value) []byte {
 			t := sql.TimeFromInt64(v.Ts)
 			return []byte(t.Format("2006-01-02 15:04:05.999999"))
 		}
+	case *SQLValue_F:
+		{
+			return []byte(strconv.FormatFloat(v.F, 'g', -1, 64))
+		}
+	case *SQLValue_D:
+		{
+			return []byte(v.D)
+		}
+	case *SQLValue_Json:
+		{
+			return []byte(v.Json)
+		}
 	}
 
 	return []byte(fmt.Sprintf("%v", op))
@@ -196,6 +270,23 @@ func RawValue(v *SQLValue) interface{} {
 		{
 			return sql.TimeFromInt64(tv.Ts)
 		}
+	case *SQLValue_F:
+		{
+			return tv.F
+		}
+	case *SQLValue_D:
+		{
+			d, err := sql.ParseDecimal(tv.D)
+			if err != nil {
+				// left as a string, it will be rejected as a value of the wrong type
+				return tv.D
+			}
+			return d
+		}
+	case *SQLValue_Json:
+		{
+			return tv.Json
+		}
 	}
 
 	return nil
@@ -184,4 +184,21 @@ func TestRowComparison(t *testing.T) {
 	rTimestampValue := RenderValue(tsv.GetValue())
 	require.Equal(t, "2021-12-08 13:46:23.012345", rTimestampValue)
 
+	jsonValue := &SQLValue_Json{Json: `{"name":"immudb"}`}
+
+	equals, err = jsonValue.Equal(&SQLValue_Json{Json: `{"name":"immudb"}`})
+	require.NoError(t, err)
+	require.True(t, equals)
+
+	equals, err = jsonValue.Equal(nullValue)
+	require.NoError(t, err)
+	require.False(t, equals)
+
+	_, err = jsonValue.Equal(stringValue1)
+	require.ErrorIs(t, err, sql.ErrNotComparableValues)
+
+	jv := &SQLValue{Value: jsonValue}
+	require.Equal(t, `{"name":"immudb"}`, RenderValue(jv.GetValue()))
+	require.Equal(t, []byte(`{"name":"immudb"}`), RenderValueAsByte(jv.GetValue()))
+	require.Equal(t, `{"name":"immudb"}`, RawValue(jv))
 }
@@ -16,6 +16,8 @@ limitations under the License.
 package schema
 
 import (
+	"encoding/json"
+	"math/big"
 	"time"
 
 	"github.com/codenotary/immudb/embedded/sql"
@@ -103,6 +105,25 @@ func asSQLValue(v interface{}) (*SQLValue, error) {
 		{
 			return &SQLValue{Value: &SQLValue_Ts{Ts: sql.TimeToInt64(tv)}}, nil
 		}
+	case float32:
+		{
+			return &SQLValue{Value: &SQLValue_F{F: float64(tv)}}, nil
+		}
+	case float64:
+		{
+			return &SQLValue{Value: &SQLValue_F{F: tv}}, nil
+		}
+	case *big.Rat:
+		{
+			return &SQLValue{Value: &SQLValue_D{D: sql.FormatDecimal(tv)}}, nil
+		}
+	case json.RawMessage:
+		{
+			if !json.Valid(tv) {
+				return nil, sql.ErrInvalidValue
+			}
+			return &SQLValue{Value: &SQLValue_Json{Json: string(tv)}}, nil
+		}
 	}
 	return nil, sql.ErrInvalidValue
 }
@@ -16,6 +16,7 @@ limitations under the License.
 package schema
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -103,6 +104,12 @@ func TestAsSQLValue(t *testing.T) {
 			"timestamp", time.Date(2021, 12, 7, 14, 12, 54, 12345, time.UTC),
 			&SQLValue{Value: &SQLValue_Ts{Ts: 1638886374000012}}, false,
 		},
+		{
+			"json", json.RawMessage(`{"name":"immudb"}`), &SQLValue{Value: &SQLValue_Json{Json: `{"name":"immudb"}`}}, false,
+		},
+		{
+			"invalid json", json.RawMessage(`{"name":`), nil, true,
+		},
 	} {
 		t.Run(d.n, func(t *testing.T) {
 			sqlVal, err := asSQLValue(d.val)
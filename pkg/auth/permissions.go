@@ -22,7 +22,9 @@ var maintenanceMethods = map[string]struct{}{
 	"StreamGet":           {},
 	"StreamVerifiableGet": {},
 	"GetAll":              {},
+	"VerifiableGetAll":    {},
 	"ZScan":               {},
+	"VerifiableZScan":     {},
 	"StreamZScan":         {},
 	"VerifiableTxByID":    {},
 	"IScan":               {},
@@ -30,6 +32,7 @@ var maintenanceMethods = map[string]struct{}{
 	"StreamScan":          {},
 	"History":             {},
 	"StreamHistory":       {},
+	"ComputeDiff":         {},
 	"TxByID":              {},
 	"TxScan":              {},
 	"ExportTx":            {},
@@ -42,12 +45,18 @@ var maintenanceMethods = map[string]struct{}{
 	"SQLQuery":            {},
 	"ListTables":          {},
 	"DescribeTable":       {},
+	"DescribeSchema":      {},
 	"VerifiableSQLGet":    {},
+	"SQLListen":           {},
 
 	// admin methods
-	"ListUsers":    {},
-	"Dump":         {},
-	"CompactIndex": {},
+	"ListUsers":        {},
+	"Dump":             {},
+	"CompactIndex":     {},
+	"ListQueries":      {},
+	"CancelQuery":      {},
+	"IndexSuggestions": {},
+	"IndexingProgress": {},
 }
 
 // PermissionSysAdmin the admin permission byte
@@ -68,6 +77,8 @@ var methodsPermissions = map[string][]uint32{
 	"Set":                    {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"Delete":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"VerifiableSet":          {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"NotarizeObject":         {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"VerifiableSetByDigest":  {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"StreamSet":              {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"StreamVerifiableSet":    {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"Get":                    {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
@@ -75,6 +86,8 @@ var methodsPermissions = map[string][]uint32{
 	"StreamGet":              {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"StreamVerifiableGet":    {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"GetAll":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"VerifiableGetAll":       {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"ExecAllPerDatabase":     {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"ExecAll":                {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"StreamExecAll":          {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"SetReference":           {PermissionSysAdmin, PermissionAdmin, PermissionRW},
@@ -82,6 +95,7 @@ var methodsPermissions = map[string][]uint32{
 	"ZAdd":                   {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"VerifiableZAdd":         {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"ZScan":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"VerifiableZScan":        {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"StreamZScan":            {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"VerifiableTxByID":       {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"IScan":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
@@ -89,6 +103,7 @@ var methodsPermissions = map[string][]uint32{
 	"StreamScan":             {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"History":                {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"StreamHistory":          {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"ComputeDiff":            {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"TxByID":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"TxScan":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"Count":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
@@ -97,26 +112,36 @@ var methodsPermissions = map[string][]uint32{
 	"CurrentState":           {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"DatabaseSettings":       {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"SQLExec":                {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"SQLExecScript":          {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"IngestJSON":             {PermissionSysAdmin, PermissionAdmin, PermissionRW},
 	"UseSnapshot":            {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"SQLQuery":               {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"ListTables":             {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"DescribeTable":          {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"DescribeSchema":         {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 	"VerifiableSQLGet":       {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"SQLListen":              {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 
 	// admin methods
-	"ListUsers":        {PermissionSysAdmin, PermissionAdmin},
-	"CreateUser":       {PermissionSysAdmin, PermissionAdmin},
-	"ChangePassword":   {PermissionSysAdmin, PermissionAdmin},
-	"SetPermission":    {PermissionSysAdmin, PermissionAdmin},
-	"DeactivateUser":   {PermissionSysAdmin, PermissionAdmin},
-	"SetActiveUser":    {PermissionSysAdmin, PermissionAdmin},
-	"UpdateAuthConfig": {PermissionSysAdmin},
-	"UpdateMTLSConfig": {PermissionSysAdmin},
-	"CreateDatabase":   {PermissionSysAdmin},
-	"Dump":             {PermissionSysAdmin, PermissionAdmin},
-	"CompactIndex":     {PermissionSysAdmin, PermissionAdmin},
-	"ExportTx":         {PermissionSysAdmin, PermissionAdmin},
-	"ReplicateTx":      {PermissionSysAdmin, PermissionAdmin},
+	"ListUsers":         {PermissionSysAdmin, PermissionAdmin},
+	"CreateUser":        {PermissionSysAdmin, PermissionAdmin},
+	"ChangePassword":    {PermissionSysAdmin, PermissionAdmin},
+	"SetPermission":     {PermissionSysAdmin, PermissionAdmin},
+	"DeactivateUser":    {PermissionSysAdmin, PermissionAdmin},
+	"SetActiveUser":     {PermissionSysAdmin, PermissionAdmin},
+	"UpdateAuthConfig":  {PermissionSysAdmin},
+	"UpdateMTLSConfig":  {PermissionSysAdmin},
+	"CreateDatabase":    {PermissionSysAdmin},
+	"RestoreDatabaseAt": {PermissionSysAdmin},
+	"Dump":              {PermissionSysAdmin, PermissionAdmin},
+	"CompactIndex":      {PermissionSysAdmin, PermissionAdmin},
+	"Forget":            {PermissionSysAdmin, PermissionAdmin},
+	"ListQueries":       {PermissionSysAdmin, PermissionAdmin},
+	"CancelQuery":       {PermissionSysAdmin, PermissionAdmin},
+	"IndexSuggestions":  {PermissionSysAdmin, PermissionAdmin},
+	"IndexingProgress":  {PermissionSysAdmin, PermissionAdmin},
+	"ExportTx":          {PermissionSysAdmin, PermissionAdmin},
+	"ReplicateTx":       {PermissionSysAdmin, PermissionAdmin},
 }
 
 //HasPermissionForMethod checks if userPermission can access method name
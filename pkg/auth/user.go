@@ -30,13 +30,15 @@ type Permission struct {
 
 // User ...
 type User struct {
-	Username       string       `json:"username"`
-	HashedPassword []byte       `json:"hashedpassword"`
-	Permissions    []Permission `json:"permissions"`
-	Active         bool         `json:"active"`
-	IsSysAdmin     bool         `json:"-"`         //for the sysadmin we'll use this instead of adding all db and permissions to Permissions, to save some cpu cycles
-	CreatedBy      string       `json:"createdBy"` //user which created this user
-	CreatedAt      time.Time    `json:"createdat"` //time in which this user is created/updated
+	Username        string       `json:"username"`
+	HashedPassword  []byte       `json:"hashedpassword"`
+	Permissions     []Permission `json:"permissions"`
+	Active          bool         `json:"active"`
+	IsSysAdmin      bool         `json:"-"`                         //for the sysadmin we'll use this instead of adding all db and permissions to Permissions, to save some cpu cycles
+	CreatedBy       string       `json:"createdBy"`                 //user which created this user
+	CreatedAt       time.Time    `json:"createdat"`                 //time in which this user is created/updated
+	Namespace       string       `json:"namespace,omitempty"`       //key prefix the user operations are confined to, if any
+	DefaultDatabase string       `json:"defaultDatabase,omitempty"` //database sessions of the user start with, if any
 }
 
 // SysAdminUsername the system admin username
@@ -66,6 +68,17 @@ func (u *User) ComparePasswords(plainPassword []byte) error {
 // IsValidUsername is a regexp function used to check username requirements
 var IsValidUsername = regexp.MustCompile(`^[a-zA-Z0-9_]+$`).MatchString
 
+// IsValidNamespace checks namespace requirements, the separator can not be used so no namespace is a prefix of another one
+var IsValidNamespace = regexp.MustCompile(`^[a-zA-Z0-9_]+$`).MatchString
+
+// NamespaceSeparator is appended to a namespace to build the prefix of the keys confined to it
+const NamespaceSeparator = "/"
+
+// NamespacePrefix returns the prefix of the keys written by users bound to the namespace
+func NamespacePrefix(namespace string) []byte {
+	return []byte(namespace + NamespaceSeparator)
+}
+
 //HasPermission checks if user has such permission for this database
 func (u *User) HasPermission(database string, permission uint32) bool {
 	for _, val := range u.Permissions {
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/proto"
+)
+
+type httpMirror struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPMirror returns a mirror storing each state as a protobuf encoded resource at {baseURL}/{serverUUID}/{db},
+// written with PUT and read with GET requests. A 404 response means there is no state for the database.
+// http.DefaultClient is used when no client is given
+func NewHTTPMirror(baseURL string, client *http.Client) StateMirror {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &httpMirror{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+	}
+}
+
+func (hm *httpMirror) stateURL(serverUUID, db string) string {
+	return hm.baseURL + "/" + url.PathEscape(serverUUID) + "/" + url.PathEscape(db)
+}
+
+func (hm *httpMirror) Get(serverUUID, db string) (*schema.ImmutableState, error) {
+	resp, err := hm.client.Get(hm.stateURL(serverUUID, db))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrPrevStateNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status reading state from mirror: %s", resp.Status)
+	}
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &schema.ImmutableState{}
+	if err = proto.Unmarshal(raw, state); err != nil {
+		return nil, ErrLocalStateCorrupted
+	}
+
+	return state, nil
+}
+
+func (hm *httpMirror) Set(serverUUID, db string, state *schema.ImmutableState) error {
+	raw, err := proto.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, hm.stateURL(serverUUID, db), bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := hm.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status writing state to mirror: %s", resp.Status)
+	}
+
+	return nil
+}
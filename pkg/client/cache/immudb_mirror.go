@@ -0,0 +1,76 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"strings"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/proto"
+)
+
+// ImmudbMirrorKeyPrefix is the prefix of the keys the immudb mirror stores the states under
+const ImmudbMirrorKeyPrefix = "immuclient.state:"
+
+type immudbMirror struct {
+	ctx    context.Context
+	client schema.ImmuServiceClient
+}
+
+// NewImmudbMirror returns a mirror storing the states in the database currently used by the given client,
+// under the key ImmudbMirrorKeyPrefix + serverUUID + ":" + db. The context must carry the authorization of the client
+func NewImmudbMirror(ctx context.Context, client schema.ImmuServiceClient) StateMirror {
+	return &immudbMirror{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+func immudbMirrorKey(serverUUID, db string) []byte {
+	return []byte(ImmudbMirrorKeyPrefix + serverUUID + ":" + db)
+}
+
+func (im *immudbMirror) Get(serverUUID, db string) (*schema.ImmutableState, error) {
+	entry, err := im.client.Get(im.ctx, &schema.KeyRequest{Key: immudbMirrorKey(serverUUID, db)})
+	if err != nil {
+		if strings.Contains(err.Error(), "key not found") {
+			return nil, ErrPrevStateNotFound
+		}
+		return nil, err
+	}
+
+	state := &schema.ImmutableState{}
+	if err = proto.Unmarshal(entry.Value, state); err != nil {
+		return nil, ErrLocalStateCorrupted
+	}
+
+	return state, nil
+}
+
+func (im *immudbMirror) Set(serverUUID, db string, state *schema.ImmutableState) error {
+	raw, err := proto.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	_, err = im.client.Set(im.ctx, &schema.SetRequest{
+		KVs: []*schema.KeyValue{{Key: immudbMirrorKey(serverUUID, db), Value: raw}},
+	})
+
+	return err
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// StateMirror is a secondary location where trusted states are copied to, so they can be
+// recovered when the primary cache is lost. Get fails with ErrPrevStateNotFound when
+// the mirror holds no state for the given server and database
+type StateMirror interface {
+	Get(serverUUID, db string) (*schema.ImmutableState, error)
+	Set(serverUUID, db string, state *schema.ImmutableState) error
+}
+
+type mirroredCache struct {
+	Cache
+	mirrors []StateMirror
+}
+
+// NewMirroredCache returns a cache copying every state stored in the primary cache to the given mirrors.
+// A state missing in the primary cache is recovered from the first mirror holding it
+func NewMirroredCache(primary Cache, mirrors ...StateMirror) Cache {
+	return &mirroredCache{
+		Cache:   primary,
+		mirrors: mirrors,
+	}
+}
+
+func (mc *mirroredCache) Get(serverUUID, db string) (*schema.ImmutableState, error) {
+	state, err := mc.Cache.Get(serverUUID, db)
+	if err != ErrPrevStateNotFound {
+		return state, err
+	}
+
+	for _, m := range mc.mirrors {
+		state, err := m.Get(serverUUID, db)
+		if err == ErrPrevStateNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = mc.Cache.Set(serverUUID, db, state)
+		if err != nil {
+			return nil, err
+		}
+
+		return state, nil
+	}
+
+	return nil, ErrPrevStateNotFound
+}
+
+// Set stores the state into the primary cache and then into every mirror,
+// failing as soon as one of them can not be updated
+func (mc *mirroredCache) Set(serverUUID, db string, state *schema.ImmutableState) error {
+	err := mc.Cache.Set(serverUUID, db, state)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range mc.mirrors {
+		err = m.Set(serverUUID, db, state)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type cacheMirror struct {
+	cache Cache
+}
+
+// NewFileMirror returns a mirror keeping the states in the state files of the given folder,
+// e.g. a mounted volume or a synced folder
+func NewFileMirror(dir string) StateMirror {
+	return &cacheMirror{cache: NewFileCache(dir)}
+}
+
+func (cm *cacheMirror) Get(serverUUID, db string) (*schema.ImmutableState, error) {
+	err := cm.cache.Lock(serverUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer cm.cache.Unlock()
+
+	return cm.cache.Get(serverUUID, db)
+}
+
+func (cm *cacheMirror) Set(serverUUID, db string, state *schema.ImmutableState) error {
+	err := cm.cache.Lock(serverUUID)
+	if err != nil {
+		return err
+	}
+	defer cm.cache.Unlock()
+
+	return cm.cache.Set(serverUUID, db, state)
+}
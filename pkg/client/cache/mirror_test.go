@@ -0,0 +1,159 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirroredCache(t *testing.T) {
+	mirror := NewFileMirror(t.TempDir())
+
+	mc := NewMirroredCache(NewFileCache(t.TempDir()), mirror)
+	err := mc.Lock("uuid")
+	require.NoError(t, err)
+
+	_, err = mc.Get("uuid", "dbName")
+	require.ErrorIs(t, err, ErrPrevStateNotFound)
+
+	state := &schema.ImmutableState{Db: "dbName", TxId: 1, TxHash: []byte(`hash`)}
+
+	err = mc.Set("uuid", "dbName", state)
+	require.NoError(t, err)
+
+	err = mc.Unlock()
+	require.NoError(t, err)
+
+	mirrored, err := mirror.Get("uuid", "dbName")
+	require.NoError(t, err)
+	require.Equal(t, state.TxId, mirrored.TxId)
+	require.Equal(t, state.TxHash, mirrored.TxHash)
+
+	t.Run("a state lost in the primary cache should be recovered from the mirror", func(t *testing.T) {
+		primary := NewFileCache(t.TempDir())
+
+		mc := NewMirroredCache(primary, NewFileMirror(t.TempDir()), mirror)
+		err := mc.Lock("uuid")
+		require.NoError(t, err)
+		defer mc.Unlock()
+
+		recovered, err := mc.Get("uuid", "dbName")
+		require.NoError(t, err)
+		require.Equal(t, state.TxId, recovered.TxId)
+		require.Equal(t, state.TxHash, recovered.TxHash)
+
+		recovered, err = primary.Get("uuid", "dbName")
+		require.NoError(t, err)
+		require.Equal(t, state.TxId, recovered.TxId)
+
+		_, err = mc.Get("uuid", "otherDb")
+		require.ErrorIs(t, err, ErrPrevStateNotFound)
+	})
+
+	t.Run("a failing mirror should fail the update", func(t *testing.T) {
+		mc := NewMirroredCache(NewFileCache(t.TempDir()), NewHTTPMirror("http://127.0.0.1:0", nil))
+		err := mc.Lock("uuid")
+		require.NoError(t, err)
+		defer mc.Unlock()
+
+		err = mc.Set("uuid", "dbName", state)
+		require.Error(t, err)
+	})
+}
+
+func TestHTTPMirror(t *testing.T) {
+	var mu sync.Mutex
+	states := map[string][]byte{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			raw, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			states[r.URL.Path] = raw
+		case http.MethodGet:
+			raw, ok := states[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(raw)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+
+	mirror := NewHTTPMirror(srv.URL+"/states/", nil)
+
+	_, err := mirror.Get("uuid", "dbName")
+	require.ErrorIs(t, err, ErrPrevStateNotFound)
+
+	err = mirror.Set("uuid", "dbName", &schema.ImmutableState{Db: "dbName", TxId: 1, TxHash: []byte(`hash`)})
+	require.NoError(t, err)
+
+	mu.Lock()
+	require.Contains(t, states, "/states/uuid/dbName")
+	mu.Unlock()
+
+	state, err := mirror.Get("uuid", "dbName")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), state.TxId)
+	require.Equal(t, []byte(`hash`), state.TxHash)
+
+	t.Run("unexpected responses should be reported", func(t *testing.T) {
+		mu.Lock()
+		states["/states/uuid/corrupted"] = []byte{0xff}
+		mu.Unlock()
+
+		_, err := mirror.Get("uuid", "corrupted")
+		require.ErrorIs(t, err, ErrLocalStateCorrupted)
+
+		failing := NewHTTPMirror(srv.URL+"/failing", &http.Client{
+			Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Status:     "500 Internal Server Error",
+					Body:       ioutil.NopCloser(strings.NewReader("")),
+				}, nil
+			}),
+		})
+
+		_, err = failing.Get("uuid", "dbName")
+		require.Error(t, err)
+
+		err = failing.Set("uuid", "dbName", state)
+		require.Error(t, err)
+	})
+}
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
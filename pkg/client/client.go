@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/sha256"
@@ -26,6 +27,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/codenotary/immudb/pkg/client/heartbeater"
@@ -40,7 +42,6 @@ import (
 
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/auth"
-	"github.com/codenotary/immudb/pkg/client/cache"
 	"github.com/codenotary/immudb/pkg/client/state"
 	"github.com/codenotary/immudb/pkg/database"
 	"github.com/codenotary/immudb/pkg/logger"
@@ -61,6 +62,7 @@ type ImmuClient interface {
 	// Deprecated: grpc retry mechanism can be implemented with WithConnectParams dialOption
 	WaitForHealthCheck(ctx context.Context) (err error)
 	HealthCheck(ctx context.Context) error
+	ServerInfo(ctx context.Context) (*schema.ServerInfoResponse, error)
 	// Deprecated: connection is handled in OpenSession
 	Connect(ctx context.Context) (clientConn *grpc.ClientConn, err error)
 
@@ -73,6 +75,7 @@ type ImmuClient interface {
 	CloseSession(ctx context.Context) error
 
 	CreateUser(ctx context.Context, user []byte, pass []byte, permission uint32, databasename string) error
+	CreateNamespacedUser(ctx context.Context, user []byte, pass []byte, permission uint32, databasename string, namespace string) error
 	ListUsers(ctx context.Context) (*schema.UserList, error)
 	ChangePassword(ctx context.Context, user []byte, oldPass []byte, newPass []byte) error
 	ChangePermission(ctx context.Context, action schema.PermissionAction, username string, database string, permissions uint32) error
@@ -80,6 +83,7 @@ type ImmuClient interface {
 	UpdateAuthConfig(ctx context.Context, kind auth.Kind) error
 	// Deprecated: will be removed in future versions
 	UpdateMTLSConfig(ctx context.Context, enabled bool) error
+	RotateTLSCertificate(ctx context.Context, certificate, privateKey []byte) (*schema.RotateTLSCertificateResponse, error)
 
 	WithOptions(options *Options) *immuClient
 	WithLogger(logger logger.Logger) *immuClient
@@ -94,24 +98,33 @@ type ImmuClient interface {
 
 	GetServiceClient() schema.ImmuServiceClient
 	GetOptions() *Options
+	ClockSkew() time.Duration
 	SetupDialOptions(options *Options) []grpc.DialOption
 
 	DatabaseList(ctx context.Context) (*schema.DatabaseListResponse, error)
 	CreateDatabase(ctx context.Context, d *schema.DatabaseSettings) error
 	UseDatabase(ctx context.Context, d *schema.Database) (*schema.UseDatabaseReply, error)
 	UpdateDatabase(ctx context.Context, settings *schema.DatabaseSettings) error
+	RestoreDatabaseAt(ctx context.Context, req *schema.RestoreDatabaseAtRequest) error
 	GetDatabaseSettings(ctx context.Context) (*schema.DatabaseSettings, error)
 
 	SetActiveUser(ctx context.Context, u *schema.SetActiveUserRequest) error
+	SetUserDefaultDatabase(ctx context.Context, username string, database string) error
 
 	CompactIndex(ctx context.Context, req *empty.Empty) error
 
+	ListQueries(ctx context.Context) (*schema.QueryInfoList, error)
+	IndexSuggestions(ctx context.Context) (*schema.IndexSuggestionList, error)
+	IndexingProgress(ctx context.Context) (*schema.IndexingProgressResponse, error)
+	CancelQuery(ctx context.Context, id uint64) error
+
 	CurrentState(ctx context.Context) (*schema.ImmutableState, error)
 
 	Set(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error)
 	VerifiedSet(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error)
 
 	ExpirableSet(ctx context.Context, key []byte, value []byte, expiresAt time.Time) (*schema.TxHeader, error)
+	NonIndexableSet(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error)
 
 	Get(ctx context.Context, key []byte) (*schema.Entry, error)
 	GetSince(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error)
@@ -123,6 +136,10 @@ type ImmuClient interface {
 
 	History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error)
 
+	// ComputeDiff opens a stream of the keys under the prefix which were added, updated or removed
+	// after fromTx and up to toTx
+	ComputeDiff(ctx context.Context, fromTx, toTx uint64, prefix []byte) (schema.ImmuService_ComputeDiffClient, error)
+
 	ZAdd(ctx context.Context, set []byte, score float64, key []byte) (*schema.TxHeader, error)
 	VerifiedZAdd(ctx context.Context, set []byte, score float64, key []byte) (*schema.TxHeader, error)
 
@@ -131,6 +148,7 @@ type ImmuClient interface {
 
 	Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error)
 	ZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error)
+	VerifiedZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error)
 
 	TxByID(ctx context.Context, tx uint64) (*schema.Tx, error)
 	VerifiedTxByID(ctx context.Context, tx uint64) (*schema.Tx, error)
@@ -141,10 +159,13 @@ type ImmuClient interface {
 
 	SetAll(ctx context.Context, kvList *schema.SetRequest) (*schema.TxHeader, error)
 	GetAll(ctx context.Context, keys [][]byte) (*schema.Entries, error)
+	VerifiedGetAll(ctx context.Context, keys [][]byte) (*schema.Entries, error)
 
 	Delete(ctx context.Context, req *schema.DeleteKeysRequest) (*schema.TxHeader, error)
+	Forget(ctx context.Context, key []byte) error
 
 	ExecAll(ctx context.Context, in *schema.ExecAllRequest) (*schema.TxHeader, error)
+	ExecAllPerDatabase(ctx context.Context, in *schema.ExecAllPerDatabaseRequest) (*schema.DatabaseTxHeaders, error)
 
 	SetReference(ctx context.Context, key []byte, referencedKey []byte) (*schema.TxHeader, error)
 	VerifiedSetReference(ctx context.Context, key []byte, referencedKey []byte) (*schema.TxHeader, error)
@@ -162,18 +183,39 @@ type ImmuClient interface {
 	StreamZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error)
 	StreamHistory(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error)
 	StreamExecAll(ctx context.Context, req *stream.ExecAllRequest) (*schema.TxHeader, error)
+	StreamSQLBlob(ctx context.Context, req *schema.SQLBlobRequest) (io.ReadCloser, error)
 
 	ExportTx(ctx context.Context, req *schema.TxRequest) (schema.ImmuService_ExportTxClient, error)
 	ReplicateTx(ctx context.Context) (schema.ImmuService_ReplicateTxClient, error)
 
 	SQLExec(ctx context.Context, sql string, params map[string]interface{}) (*schema.SQLExecResult, error)
+	SQLExecScript(ctx context.Context, sql string, params map[string]interface{}) (*schema.SQLExecResult, error)
+	IngestJSON(ctx context.Context, collection string, documents ...[]byte) (*schema.SQLExecResult, error)
 	SQLQuery(ctx context.Context, sql string, params map[string]interface{}, renewSnapshot bool) (*schema.SQLQueryResult, error)
+	SQLQueryWithIsolation(ctx context.Context, sql string, params map[string]interface{}, isolation schema.ReadIsolation) (*schema.SQLQueryResult, error)
 	ListTables(ctx context.Context) (*schema.SQLQueryResult, error)
 	DescribeTable(ctx context.Context, tableName string) (*schema.SQLQueryResult, error)
+	DescribeSchema(ctx context.Context) (*schema.SQLSchema, error)
 
 	VerifyRow(ctx context.Context, row *schema.Row, table string, pkVals []*schema.SQLValue) error
 
+	ExportEvidence(ctx context.Context, keys [][]byte, rows []*schema.SQLGetRequest, description string) (*schema.EvidenceArchive, error)
+
+	NotarizeObject(ctx context.Context, r io.Reader, record *schema.NotarizationRecord) (*schema.NotarizationReceipt, error)
+	NotarizeDigest(ctx context.Context, record *schema.NotarizationRecord) (*schema.NotarizationReceipt, error)
+	VerifyObject(ctx context.Context, r io.Reader, receipt *schema.NotarizationReceipt) error
+
+	ExportState(ctx context.Context) (*schema.ClientState, error)
+	ImportState(ctx context.Context, clientState *schema.ClientState) error
+
+	SetByDigest(ctx context.Context, value []byte) (*schema.VerifiableSetByDigestReply, error)
+	SetObjectByDigest(ctx context.Context, r io.Reader) (*schema.VerifiableSetByDigestReply, error)
+	GetByDigest(ctx context.Context, digest []byte) (*schema.Entry, error)
+
+	SQLListen(ctx context.Context, table string, initialTx uint64) (schema.ImmuService_SQLListenClient, error)
+
 	NewTx(ctx context.Context) (Tx, error)
+	NewReadOnlyTx(ctx context.Context, isolation schema.ReadIsolation) (Tx, error)
 }
 
 const DefaultDB = "defaultdb"
@@ -190,6 +232,13 @@ type immuClient struct {
 	StreamServiceFactory stream.ServiceFactory
 	SessionID            string
 	HeartBeater          heartbeater.HeartBeater
+	namespace            string
+
+	lastWrittenTxs      map[string]uint64 // last written tx by database, used for read-your-writes consistency
+	lastWrittenTxsMutex sync.Mutex
+
+	clockSkew      time.Duration
+	clockSkewMutex sync.Mutex
 }
 
 // NewClient ...
@@ -254,7 +303,7 @@ func NewImmuClient(options *Options) (*immuClient, error) {
 	stateProvider := state.NewStateProvider(serviceClient)
 	uuidProvider := state.NewUUIDProvider(serviceClient)
 
-	stateService, err := state.NewStateService(cache.NewFileCache(options.Dir), l, stateProvider, uuidProvider)
+	stateService, err := state.NewStateService(options.stateCache(), l, stateProvider, uuidProvider)
 	if err != nil {
 		return nil, logErr(l, "Unable to create state service: %s", err)
 	}
@@ -339,6 +388,19 @@ func (c *immuClient) SetupDialOptions(options *Options) []grpc.DialOption {
 	}
 	uic = append(uic, c.TokenInterceptor, c.SessionIDInjectorInterceptor)
 
+	if options.ReadYourWrites {
+		uic = append(uic, c.MinTxInterceptor)
+		opts = append(opts, grpc.WithChainStreamInterceptor(c.MinTxStreamInterceptor))
+	}
+
+	if options.MaxClockSkew > 0 {
+		uic = append(uic, c.ClockSkewInterceptor)
+	}
+
+	if options.IdempotencyKeys {
+		uic = append(uic, IdempotencyKeyInterceptor)
+	}
+
 	opts = append(opts, grpc.WithUnaryInterceptor(grpc_middleware.ChainUnaryClient(uic...)), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(options.MaxRecvMsgSize)))
 
 	return opts
@@ -434,6 +496,39 @@ func (c *immuClient) CreateUser(ctx context.Context, user []byte, pass []byte, p
 	return err
 }
 
+// CreateNamespacedUser creates a user whose key-value and SQL operations are confined under the specified namespace
+func (c *immuClient) CreateNamespacedUser(ctx context.Context, user []byte, pass []byte, permission uint32, databasename string, namespace string) error {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return errors.FromError(ErrNotConnected)
+	}
+
+	_, err := c.ServiceClient.CreateUser(ctx, &schema.CreateUserRequest{
+		User:       user,
+		Password:   pass,
+		Permission: permission,
+		Database:   databasename,
+		Namespace:  namespace,
+	})
+
+	c.Logger.Debugf("createnamespaceduser finished in %s", time.Since(start))
+
+	return err
+}
+
+// namespacedKey returns the key as stored by the server when the logged in user is bound to a namespace
+func (c *immuClient) namespacedKey(key []byte) []byte {
+	return namespacedKey(c.namespace, key)
+}
+
+func namespacedKey(namespace string, key []byte) []byte {
+	if namespace == "" {
+		return key
+	}
+	return append(auth.NamespacePrefix(namespace), key...)
+}
+
 // ChangePassword ...
 func (c *immuClient) ChangePassword(ctx context.Context, user []byte, oldPass []byte, newPass []byte) error {
 	start := time.Now()
@@ -485,6 +580,25 @@ func (c *immuClient) UpdateMTLSConfig(ctx context.Context, enabled bool) error {
 	return err
 }
 
+// RotateTLSCertificate replaces the certificate presented by the server on new connections with the provided
+// PEM encoded certificate chain and private key. The server reloads them from its files when none is provided
+func (c *immuClient) RotateTLSCertificate(ctx context.Context, certificate, privateKey []byte) (*schema.RotateTLSCertificateResponse, error) {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	res, err := c.ServiceClient.RotateTLSCertificate(ctx, &schema.RotateTLSCertificateRequest{
+		Certificate: certificate,
+		PrivateKey:  privateKey,
+	})
+
+	c.Logger.Debugf("rotatetlscertificate finished in %s", time.Since(start))
+
+	return res, err
+}
+
 // Login ...
 func (c *immuClient) Login(ctx context.Context, user []byte, pass []byte) (*schema.LoginResponse, error) {
 	start := time.Now()
@@ -502,11 +616,19 @@ func (c *immuClient) Login(ctx context.Context, user []byte, pass []byte) (*sche
 	}
 	c.Logger.Debugf("login finished in %s", time.Since(start))
 
-	err = c.Tkns.SetToken("defaultdb", result.Token)
+	database := "defaultdb"
+	if result.Database != "" {
+		database = result.Database
+		c.Options.CurrentDatabase = result.Database
+	}
+
+	err = c.Tkns.SetToken(database, result.Token)
 	if err != nil {
 		return nil, errors.FromError(err)
 	}
 
+	c.namespace = result.Namespace
+
 	return result, nil
 }
 
@@ -522,6 +644,8 @@ func (c *immuClient) Logout(ctx context.Context) error {
 		return err
 	}
 
+	c.namespace = ""
+
 	tokenFileExists, err := c.Tkns.IsTokenPresent()
 	if err != nil {
 		return fmt.Errorf("error checking if token file exists: %v", err)
@@ -634,11 +758,11 @@ func (c *immuClient) verifiedGet(ctx context.Context, kReq *schema.KeyRequest) (
 
 	if vEntry.Entry.ReferencedBy == nil {
 		vTx = vEntry.Entry.Tx
-		e = database.EncodeEntrySpec(kReq.Key, schema.KVMetadataFromProto(vEntry.Entry.Metadata), vEntry.Entry.Value)
+		e = database.EncodeEntry(c.namespacedKey(kReq.Key), vEntry.Entry)
 	} else {
 		ref := vEntry.Entry.ReferencedBy
 		vTx = ref.Tx
-		e = database.EncodeReference(ref.Key, schema.KVMetadataFromProto(ref.Metadata), vEntry.Entry.Key, ref.AtTx)
+		e = database.EncodeReference(c.namespacedKey(ref.Key), schema.KVMetadataFromProto(ref.Metadata), c.namespacedKey(vEntry.Entry.Key), ref.AtTx)
 	}
 
 	if state.TxId <= vTx {
@@ -674,7 +798,7 @@ func (c *immuClient) verifiedGet(ctx context.Context, kReq *schema.KeyRequest) (
 			targetAlh,
 		)
 		if !verifies {
-			return nil, store.ErrCorruptedData
+			return nil, c.stateRollbackError(state, sourceID, targetID, vEntry.VerifiableTx.DbUUID)
 		}
 	}
 
@@ -683,6 +807,7 @@ func (c *immuClient) verifiedGet(ctx context.Context, kReq *schema.KeyRequest) (
 		TxId:      targetID,
 		TxHash:    targetAlh[:],
 		Signature: vEntry.VerifiableTx.Signature,
+		DbUUID:    vEntry.VerifiableTx.DbUUID,
 	}
 
 	if c.serverSigningPubKey != nil {
@@ -745,6 +870,61 @@ func (c *immuClient) ZScan(ctx context.Context, req *schema.ZScanRequest) (*sche
 	return c.ServiceClient.ZScan(ctx, req)
 }
 
+// VerifiedZScan returns the same entries as ZScan, verifying each referenced entry was included
+// in its tx and that the txs are consistent with the local state
+func (c *immuClient) VerifiedZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error) {
+	start := time.Now()
+	defer func() { c.Logger.Debugf("VerifiedZScan finished in %s", time.Since(start)) }()
+
+	err := c.StateService.CacheLock()
+	if err != nil {
+		return nil, err
+	}
+	defer c.StateService.CacheUnlock()
+
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	state, err := c.StateService.GetState(ctx, c.Options.CurrentDatabase)
+	if err != nil {
+		return nil, err
+	}
+
+	vEntries, err := c.ServiceClient.VerifiableZScan(ctx, &schema.VerifiableZScanRequest{
+		ZScanRequest: req,
+		ProveSinceTx: state.TxId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vEntries.Entries) == 0 {
+		return &schema.ZEntries{}, nil
+	}
+
+	entries := make([]*schema.Entry, len(vEntries.Entries))
+
+	for i, ze := range vEntries.Entries {
+		if ze.Entry == nil || !bytes.Equal(ze.Set, req.Set) {
+			return nil, store.ErrCorruptedData
+		}
+		entries[i] = ze.Entry
+	}
+
+	err = c.verifyEntries(state, entries, vEntries.InclusionProofs, vEntries.TxProofs, vEntries.VerifiableTx,
+		func(i int, key []byte, txID uint64) bool {
+			ze := vEntries.Entries[i]
+			// entries not bound to a tx resolve to the latest value of the key
+			return bytes.Equal(key, ze.Key) && (ze.AtTx == 0 || txID == ze.AtTx)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.ZEntries{Entries: vEntries.Entries}, nil
+}
+
 // Count ...
 func (c *immuClient) Count(ctx context.Context, prefix []byte) (*schema.EntryCount, error) {
 	if !c.IsConnected() {
@@ -830,7 +1010,7 @@ func (c *immuClient) VerifiedSet(ctx context.Context, key []byte, value []byte)
 		return nil, err
 	}
 
-	inclusionProof, err := tx.Proof(database.EncodeKey(key))
+	inclusionProof, err := tx.Proof(database.EncodeKey(c.namespacedKey(key)))
 	if err != nil {
 		return nil, err
 	}
@@ -841,7 +1021,7 @@ func (c *immuClient) VerifiedSet(ctx context.Context, key []byte, value []byte)
 		return nil, store.ErrCorruptedData
 	}
 
-	e := database.EncodeEntrySpec(key, md, value)
+	e := database.EncodeEntrySpec(c.namespacedKey(key), md, value)
 
 	verifies := store.VerifyInclusion(inclusionProof, entrySpecDigest(e), tx.Header().Eh)
 	if !verifies {
@@ -870,7 +1050,7 @@ func (c *immuClient) VerifiedSet(ctx context.Context, key []byte, value []byte)
 		)
 
 		if !verifies {
-			return nil, store.ErrCorruptedData
+			return nil, c.stateRollbackError(state, sourceID, targetID, verifiableTx.DbUUID)
 		}
 	}
 
@@ -879,6 +1059,7 @@ func (c *immuClient) VerifiedSet(ctx context.Context, key []byte, value []byte)
 		TxId:      targetID,
 		TxHash:    targetAlh[:],
 		Signature: verifiableTx.Signature,
+		DbUUID:    verifiableTx.DbUUID,
 	}
 
 	if c.serverSigningPubKey != nil {
@@ -903,6 +1084,11 @@ func (c *immuClient) ExpirableSet(ctx context.Context, key []byte, value []byte,
 	return c.set(ctx, key, &schema.KVMetadata{Expiration: &schema.Expiration{ExpiresAt: expiresAt.Unix()}}, value)
 }
 
+// NonIndexableSet stores an entry which is not indexed, it can not be retrieved by key but only through its transaction
+func (c *immuClient) NonIndexableSet(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error) {
+	return c.set(ctx, key, &schema.KVMetadata{NonIndexable: true}, value)
+}
+
 func (c *immuClient) SetAll(ctx context.Context, req *schema.SetRequest) (*schema.TxHeader, error) {
 	if !c.IsConnected() {
 		return nil, errors.FromError(ErrNotConnected)
@@ -934,6 +1120,45 @@ func (c *immuClient) ExecAll(ctx context.Context, req *schema.ExecAllRequest) (*
 	return txhdr, nil
 }
 
+// ExecAllPerDatabase executes the operations of each request into its database, in a transaction per database.
+// Transactions are not atomic as a whole: some of them may be committed even if an error is returned
+func (c *immuClient) ExecAllPerDatabase(ctx context.Context, req *schema.ExecAllPerDatabaseRequest) (*schema.DatabaseTxHeaders, error) {
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	txHeaders, err := c.ServiceClient.ExecAllPerDatabase(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(txHeaders.TxHeaders) != len(req.Requests) {
+		return nil, store.ErrCorruptedData
+	}
+
+	opsCount := make(map[string]int, len(req.Requests))
+	for _, dbReq := range req.Requests {
+		opsCount[dbReq.Database] = len(dbReq.ExecAllRequest.GetOperations())
+	}
+
+	for _, h := range txHeaders.TxHeaders {
+		count, ok := opsCount[h.Database]
+		if !ok || h.TxHeader == nil || int(h.TxHeader.Nentries) != count {
+			return nil, store.ErrCorruptedData
+		}
+	}
+
+	if !bytes.Equal(schema.DatabaseTxHeadersDigest(txHeaders.TxHeaders), txHeaders.Digest) {
+		return nil, store.ErrCorruptedData
+	}
+
+	return txHeaders, nil
+}
+
 // GetAll ...
 func (c *immuClient) GetAll(ctx context.Context, keys [][]byte) (*schema.Entries, error) {
 	if !c.IsConnected() {
@@ -950,6 +1175,201 @@ func (c *immuClient) GetAll(ctx context.Context, keys [][]byte) (*schema.Entries
 	return c.ServiceClient.GetAll(ctx, keyList)
 }
 
+// VerifiedGetAll returns the entries of the keys verified with a single consistency proof
+// from the local state up to the newest tx holding any of them
+func (c *immuClient) VerifiedGetAll(ctx context.Context, keys [][]byte) (*schema.Entries, error) {
+	start := time.Now()
+	defer func() { c.Logger.Debugf("VerifiedGetAll finished in %s", time.Since(start)) }()
+
+	err := c.StateService.CacheLock()
+	if err != nil {
+		return nil, err
+	}
+	defer c.StateService.CacheUnlock()
+
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	state, err := c.StateService.GetState(ctx, c.Options.CurrentDatabase)
+	if err != nil {
+		return nil, err
+	}
+
+	vEntries, err := c.ServiceClient.VerifiableGetAll(ctx, &schema.VerifiableGetAllRequest{
+		KeyListRequest: &schema.KeyListRequest{Keys: keys},
+		ProveSinceTx:   state.TxId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vEntries.Entries) == 0 {
+		return &schema.Entries{}, nil
+	}
+
+	requested := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		requested[string(k)] = struct{}{}
+	}
+
+	err = c.verifyEntries(state, vEntries.Entries, vEntries.InclusionProofs, vEntries.TxProofs, vEntries.VerifiableTx,
+		func(i int, key []byte, txID uint64) bool {
+			_, ok := requested[string(key)]
+			return ok
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.Entries{Entries: vEntries.Entries}, nil
+}
+
+// verifyEntries checks the proofs of the entries against the local state, which gets updated afterwards.
+// accept tells whether the proven key and tx of the i-th entry were actually requested
+func (c *immuClient) verifyEntries(
+	state *schema.ImmutableState,
+	entries []*schema.Entry,
+	inclusionProofs []*schema.InclusionProof,
+	txProofs map[uint64]*schema.DualProof,
+	vTx *schema.VerifiableTx,
+	accept func(i int, key []byte, txID uint64) bool,
+) error {
+	if vTx == nil ||
+		vTx.Tx == nil ||
+		vTx.DualProof == nil ||
+		len(inclusionProofs) != len(entries) {
+		return store.ErrCorruptedData
+	}
+
+	vTxID := vTx.Tx.Header.Id
+
+	dualProof := schema.DualProofFromProto(vTx.DualProof)
+
+	var eh [sha256.Size]byte
+
+	var sourceID, targetID uint64
+	var sourceAlh, targetAlh [sha256.Size]byte
+
+	if state.TxId <= vTxID {
+		eh = dualProof.TargetTxHeader.Eh
+
+		sourceID = state.TxId
+		sourceAlh = schema.DigestFromProto(state.TxHash)
+		targetID = vTxID
+		targetAlh = dualProof.TargetTxHeader.Alh()
+	} else {
+		eh = dualProof.SourceTxHeader.Eh
+
+		sourceID = vTxID
+		sourceAlh = dualProof.SourceTxHeader.Alh()
+		targetID = state.TxId
+		targetAlh = schema.DigestFromProto(state.TxHash)
+	}
+
+	// alh of the tx all the entries are proven against
+	vTxAlh := targetAlh
+	if state.TxId > vTxID {
+		vTxAlh = sourceAlh
+	}
+
+	for i, entry := range entries {
+		var txID uint64
+		var e *store.EntrySpec
+
+		if entry.ReferencedBy == nil {
+			txID = entry.Tx
+			e = database.EncodeEntry(c.namespacedKey(entry.Key), entry)
+
+			if !accept(i, entry.Key, txID) {
+				return store.ErrCorruptedData
+			}
+		} else {
+			ref := entry.ReferencedBy
+			txID = ref.Tx
+			e = database.EncodeReference(c.namespacedKey(ref.Key), schema.KVMetadataFromProto(ref.Metadata), c.namespacedKey(entry.Key), ref.AtTx)
+
+			if !accept(i, ref.Key, txID) {
+				return store.ErrCorruptedData
+			}
+		}
+
+		if txID > vTxID {
+			return store.ErrCorruptedData
+		}
+
+		entryEh := eh
+		entryTxVersion := int(vTx.Tx.Header.Version)
+
+		if txID < vTxID {
+			txProof, ok := txProofs[txID]
+			if !ok || txProof.SourceTxHeader == nil || txProof.TargetTxHeader == nil {
+				return store.ErrCorruptedData
+			}
+
+			proof := schema.DualProofFromProto(txProof)
+
+			if proof.TargetTxHeader.Alh() != vTxAlh {
+				return store.ErrCorruptedData
+			}
+
+			verifies := store.VerifyDualProof(proof, txID, vTxID, proof.SourceTxHeader.Alh(), vTxAlh)
+			if !verifies {
+				return store.ErrCorruptedData
+			}
+
+			entryEh = proof.SourceTxHeader.Eh
+			entryTxVersion = proof.SourceTxHeader.Version
+		}
+
+		entrySpecDigest, err := store.EntrySpecDigestFor(entryTxVersion)
+		if err != nil {
+			return err
+		}
+
+		verifies := store.VerifyInclusion(
+			schema.InclusionProofFromProto(inclusionProofs[i]),
+			entrySpecDigest(e),
+			entryEh)
+		if !verifies {
+			return store.ErrCorruptedData
+		}
+	}
+
+	if state.TxId > 0 {
+		verifies := store.VerifyDualProof(
+			dualProof,
+			sourceID,
+			targetID,
+			sourceAlh,
+			targetAlh,
+		)
+		if !verifies {
+			return c.stateRollbackError(state, sourceID, targetID, vTx.DbUUID)
+		}
+	}
+
+	newState := &schema.ImmutableState{
+		Db:        c.currentDatabase(),
+		TxId:      targetID,
+		TxHash:    targetAlh[:],
+		Signature: vTx.Signature,
+		DbUUID:    vTx.DbUUID,
+	}
+
+	if c.serverSigningPubKey != nil {
+		ok, err := newState.CheckSignature(c.serverSigningPubKey)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return store.ErrCorruptedData
+		}
+	}
+
+	return c.StateService.SetState(c.Options.CurrentDatabase, newState)
+}
+
 func (c *immuClient) Delete(ctx context.Context, req *schema.DeleteKeysRequest) (*schema.TxHeader, error) {
 	if !c.IsConnected() {
 		return nil, errors.FromError(ErrNotConnected)
@@ -958,6 +1378,16 @@ func (c *immuClient) Delete(ctx context.Context, req *schema.DeleteKeysRequest)
 	return c.ServiceClient.Delete(ctx, req)
 }
 
+// Forget destroys the data key of the key, values set with encryption become unrecoverable
+func (c *immuClient) Forget(ctx context.Context, key []byte) error {
+	if !c.IsConnected() {
+		return errors.FromError(ErrNotConnected)
+	}
+
+	_, err := c.ServiceClient.Forget(ctx, &schema.ForgetRequest{Key: key})
+	return err
+}
+
 // TxByID ...
 func (c *immuClient) TxByID(ctx context.Context, tx uint64) (*schema.Tx, error) {
 	if !c.IsConnected() {
@@ -1034,7 +1464,7 @@ func (c *immuClient) VerifiedTxByID(ctx context.Context, tx uint64) (*schema.Tx,
 			targetAlh,
 		)
 		if !verifies {
-			return nil, store.ErrCorruptedData
+			return nil, c.stateRollbackError(state, sourceID, targetID, vTx.DbUUID)
 		}
 	}
 
@@ -1043,6 +1473,7 @@ func (c *immuClient) VerifiedTxByID(ctx context.Context, tx uint64) (*schema.Tx,
 		TxId:      targetID,
 		TxHash:    targetAlh[:],
 		Signature: vTx.Signature,
+		DbUUID:    vTx.DbUUID,
 	}
 
 	if c.serverSigningPubKey != nil {
@@ -1086,6 +1517,15 @@ func (c *immuClient) History(ctx context.Context, req *schema.HistoryRequest) (s
 	return c.ServiceClient.History(ctx, req)
 }
 
+// ComputeDiff ...
+func (c *immuClient) ComputeDiff(ctx context.Context, fromTx, toTx uint64, prefix []byte) (schema.ImmuService_ComputeDiffClient, error) {
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	return c.ServiceClient.ComputeDiff(ctx, &schema.DiffRequest{FromTx: fromTx, ToTx: toTx, Prefix: prefix})
+}
+
 // SetReference ...
 func (c *immuClient) SetReference(ctx context.Context, key []byte, referencedKey []byte) (*schema.TxHeader, error) {
 	return c.SetReferenceAt(ctx, key, referencedKey, 0)
@@ -1173,12 +1613,12 @@ func (c *immuClient) VerifiedSetReferenceAt(ctx context.Context, key []byte, ref
 		return nil, err
 	}
 
-	inclusionProof, err := tx.Proof(database.EncodeKey(key))
+	inclusionProof, err := tx.Proof(database.EncodeKey(c.namespacedKey(key)))
 	if err != nil {
 		return nil, err
 	}
 
-	e := database.EncodeReference(key, nil, referencedKey, atTx)
+	e := database.EncodeReference(c.namespacedKey(key), nil, c.namespacedKey(referencedKey), atTx)
 
 	verifies := store.VerifyInclusion(inclusionProof, entrySpecDigest(e), tx.Header().Eh)
 	if !verifies {
@@ -1206,7 +1646,7 @@ func (c *immuClient) VerifiedSetReferenceAt(ctx context.Context, key []byte, ref
 			targetAlh,
 		)
 		if !verifies {
-			return nil, store.ErrCorruptedData
+			return nil, c.stateRollbackError(state, sourceID, targetID, verifiableTx.DbUUID)
 		}
 	}
 
@@ -1215,6 +1655,7 @@ func (c *immuClient) VerifiedSetReferenceAt(ctx context.Context, key []byte, ref
 		TxId:      targetID,
 		TxHash:    targetAlh[:],
 		Signature: verifiableTx.Signature,
+		DbUUID:    verifiableTx.DbUUID,
 	}
 
 	if c.serverSigningPubKey != nil {
@@ -1324,9 +1765,9 @@ func (c *immuClient) VerifiedZAddAt(ctx context.Context, set []byte, score float
 		return nil, err
 	}
 
-	ekv := database.EncodeZAdd(req.ZAddRequest.Set,
+	ekv := database.EncodeZAdd(c.namespacedKey(req.ZAddRequest.Set),
 		req.ZAddRequest.Score,
-		database.EncodeKey(req.ZAddRequest.Key),
+		database.EncodeKey(c.namespacedKey(req.ZAddRequest.Key)),
 		req.ZAddRequest.AtTx,
 	)
 
@@ -1361,7 +1802,7 @@ func (c *immuClient) VerifiedZAddAt(ctx context.Context, set []byte, score float
 			targetAlh,
 		)
 		if !verifies {
-			return nil, store.ErrCorruptedData
+			return nil, c.stateRollbackError(state, sourceID, targetID, vtx.DbUUID)
 		}
 	}
 
@@ -1370,6 +1811,7 @@ func (c *immuClient) VerifiedZAddAt(ctx context.Context, set []byte, score float
 		TxId:      targetID,
 		TxHash:    targetAlh[:],
 		Signature: vtx.Signature,
+		DbUUID:    vtx.DbUUID,
 	}
 
 	if c.serverSigningPubKey != nil {
@@ -1423,6 +1865,21 @@ func (c *immuClient) currentDatabase() string {
 	return c.Options.CurrentDatabase
 }
 
+// stateRollbackError is returned when the proof between the trusted state and the tx presented by the server does not verify
+func (c *immuClient) stateRollbackError(trustedState *schema.ImmutableState, sourceID, targetID uint64, serverDbUUID string) error {
+	serverTxID := targetID
+	if targetID == trustedState.TxId {
+		serverTxID = sourceID
+	}
+
+	return &ErrServerStateRollback{
+		Db:           c.currentDatabase(),
+		TrustedState: trustedState,
+		ServerTxID:   serverTxID,
+		ServerDbUUID: serverDbUUID,
+	}
+}
+
 // CreateDatabase create a new database by making a grpc call
 func (c *immuClient) CreateDatabase(ctx context.Context, settings *schema.DatabaseSettings) error {
 	start := time.Now()
@@ -1464,6 +1921,21 @@ func (c *immuClient) UseDatabase(ctx context.Context, db *schema.Database) (*sch
 	return result, errors.FromError(err)
 }
 
+// RestoreDatabaseAt creates a new database with the state the source database had at the specified transaction
+func (c *immuClient) RestoreDatabaseAt(ctx context.Context, req *schema.RestoreDatabaseAtRequest) error {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+
+	_, err := c.ServiceClient.RestoreDatabaseAt(ctx, req)
+
+	c.Logger.Debugf("RestoreDatabaseAt finished in %s", time.Since(start))
+
+	return err
+}
+
 // UpdateDatabase updates database settings
 func (c *immuClient) UpdateDatabase(ctx context.Context, settings *schema.DatabaseSettings) error {
 	start := time.Now()
@@ -1501,6 +1973,66 @@ func (c *immuClient) CompactIndex(ctx context.Context, req *empty.Empty) error {
 	return err
 }
 
+// ListQueries returns the queries currently running on the selected database
+func (c *immuClient) ListQueries(ctx context.Context) (*schema.QueryInfoList, error) {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	res, err := c.ServiceClient.ListQueries(ctx, &empty.Empty{})
+
+	c.Logger.Debugf("ListQueries finished in %s", time.Since(start))
+
+	return res, err
+}
+
+// CancelQuery cancels a query running on the selected database
+func (c *immuClient) CancelQuery(ctx context.Context, id uint64) error {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return errors.FromError(ErrNotConnected)
+	}
+
+	_, err := c.ServiceClient.CancelQuery(ctx, &schema.CancelQueryRequest{Id: id})
+
+	c.Logger.Debugf("CancelQuery finished in %s", time.Since(start))
+
+	return err
+}
+
+// IndexSuggestions returns the indexes which would have avoided full scans on the selected database
+func (c *immuClient) IndexSuggestions(ctx context.Context) (*schema.IndexSuggestionList, error) {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	res, err := c.ServiceClient.IndexSuggestions(ctx, &empty.Empty{})
+
+	c.Logger.Debugf("IndexSuggestions finished in %s", time.Since(start))
+
+	return res, err
+}
+
+// IndexingProgress returns how far the indexing of the selected database is
+func (c *immuClient) IndexingProgress(ctx context.Context) (*schema.IndexingProgressResponse, error) {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	res, err := c.ServiceClient.IndexingProgress(ctx, &empty.Empty{})
+
+	c.Logger.Debugf("IndexingProgress finished in %s", time.Since(start))
+
+	return res, err
+}
+
 func (c *immuClient) ChangePermission(ctx context.Context, action schema.PermissionAction, username string, database string, permissions uint32) error {
 	start := time.Now()
 
@@ -1536,6 +2068,24 @@ func (c *immuClient) SetActiveUser(ctx context.Context, u *schema.SetActiveUserR
 	return err
 }
 
+// SetUserDefaultDatabase sets the database the sessions of the user start with, an empty database clears it
+func (c *immuClient) SetUserDefaultDatabase(ctx context.Context, username string, database string) error {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+
+	_, err := c.ServiceClient.SetUserDefaultDatabase(ctx, &schema.SetUserDefaultDatabaseRequest{
+		Username: username,
+		Database: database,
+	})
+
+	c.Logger.Debugf("SetUserDefaultDatabase finished in %s", time.Since(start))
+
+	return err
+}
+
 func (c *immuClient) DatabaseList(ctx context.Context) (*schema.DatabaseListResponse, error) {
 	start := time.Now()
 
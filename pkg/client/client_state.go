@@ -0,0 +1,173 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/errors"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// ExportState returns the trusted state of the current database. Being identified by the uuid
+// of the database, it can be imported by a client connected to any of its replicas.
+func (c *immuClient) ExportState(ctx context.Context) (*schema.ClientState, error) {
+	err := c.StateService.CacheLock()
+	if err != nil {
+		return nil, err
+	}
+	defer c.StateService.CacheUnlock()
+
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	start := time.Now()
+	defer func() { c.Logger.Debugf("ExportState finished in %s", time.Since(start)) }()
+
+	serverState, err := c.ServiceClient.CurrentState(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	if serverState.DbUUID == "" {
+		return nil, ErrNoDatabaseUUID
+	}
+
+	state, err := c.StateService.GetState(ctx, c.Options.CurrentDatabase)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.DbUUID != "" && state.DbUUID != serverState.DbUUID {
+		return nil, c.stateRollbackError(state, state.TxId, serverState.TxId, serverState.DbUUID)
+	}
+
+	return &schema.ClientState{
+		States: []*schema.ImmutableState{
+			{
+				Db:        state.Db,
+				TxId:      state.TxId,
+				TxHash:    state.TxHash,
+				Signature: state.Signature,
+				DbUUID:    serverState.DbUUID,
+			},
+		},
+	}, nil
+}
+
+// ImportState takes the trusted state of the current database from the ones exported by another client.
+// The state is matched by the uuid of the database and it's only taken once the server proves its history
+// includes it, thus a server not having reached the trusted state yet is refused with ErrServerStateIsOlder.
+// The trusted state previously held for the current database is replaced by the verified server state.
+func (c *immuClient) ImportState(ctx context.Context, clientState *schema.ClientState) error {
+	if clientState == nil {
+		return ErrIllegalArguments
+	}
+
+	err := c.StateService.CacheLock()
+	if err != nil {
+		return err
+	}
+	defer c.StateService.CacheUnlock()
+
+	if !c.IsConnected() {
+		return errors.FromError(ErrNotConnected)
+	}
+
+	start := time.Now()
+	defer func() { c.Logger.Debugf("ImportState finished in %s", time.Since(start)) }()
+
+	serverState, err := c.ServiceClient.CurrentState(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+
+	if serverState.DbUUID == "" {
+		return ErrNoDatabaseUUID
+	}
+
+	var trustedState *schema.ImmutableState
+
+	for _, s := range clientState.States {
+		if s.DbUUID == serverState.DbUUID {
+			trustedState = s
+			break
+		}
+	}
+
+	if trustedState == nil {
+		return ErrNoTrustedState
+	}
+
+	if trustedState.TxId > serverState.TxId {
+		return ErrServerStateIsOlder
+	}
+
+	vTx, err := c.ServiceClient.VerifiableTxById(ctx, &schema.VerifiableTxRequest{
+		Tx:           serverState.TxId,
+		ProveSinceTx: trustedState.TxId,
+	})
+	if err != nil {
+		return err
+	}
+
+	dualProof := schema.DualProofFromProto(vTx.DualProof)
+
+	targetID := vTx.Tx.Header.Id
+	targetAlh := dualProof.TargetTxHeader.Alh()
+
+	if targetID != serverState.TxId {
+		return store.ErrCorruptedData
+	}
+
+	if trustedState.TxId > 0 {
+		verifies := store.VerifyDualProof(
+			dualProof,
+			trustedState.TxId,
+			targetID,
+			schema.DigestFromProto(trustedState.TxHash),
+			targetAlh,
+		)
+		if !verifies {
+			return c.stateRollbackError(trustedState, trustedState.TxId, targetID, vTx.DbUUID)
+		}
+	}
+
+	newState := &schema.ImmutableState{
+		Db:        c.currentDatabase(),
+		TxId:      targetID,
+		TxHash:    targetAlh[:],
+		Signature: vTx.Signature,
+		DbUUID:    vTx.DbUUID,
+	}
+
+	if c.serverSigningPubKey != nil {
+		ok, err := newState.CheckSignature(c.serverSigningPubKey)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return store.ErrCorruptedData
+		}
+	}
+
+	return c.StateService.SetState(c.Options.CurrentDatabase, newState)
+}
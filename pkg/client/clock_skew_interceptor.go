@@ -0,0 +1,112 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// SERVER_TIME_HEADER carries the time, in unix nanoseconds, at which the server handled the request
+const SERVER_TIME_HEADER = "immudb-server-time"
+
+// ClockSkewInterceptor measures the skew between the client clock and the server one on every response.
+// A skew exceeding the max one is logged as a warning or, if the client is set to fail on it, the call
+// returns ErrClockSkewExceeded. Note the request has been served by the server anyway.
+func (c *immuClient) ClockSkewInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var header metadata.MD
+
+	sentAt := time.Now()
+
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+	if err != nil {
+		return err
+	}
+
+	receivedAt := time.Now()
+
+	serverTime, ok := serverTimeFromHeader(header)
+	if !ok {
+		return nil
+	}
+
+	// the server handled the request somewhere between sending it and receiving the response,
+	// taking the midpoint halves the error introduced by the network latency
+	skew := serverTime.Sub(sentAt.Add(receivedAt.Sub(sentAt) / 2))
+
+	if !c.trackClockSkew(skew) {
+		return nil
+	}
+
+	if c.Options.FailOnClockSkew {
+		return ErrClockSkewExceeded
+	}
+
+	return nil
+}
+
+// ClockSkew returns the skew between the server clock and the client one measured on the last response,
+// a positive skew means the server clock is ahead. It's only measured when a max clock skew is set
+func (c *immuClient) ClockSkew() time.Duration {
+	c.clockSkewMutex.Lock()
+	defer c.clockSkewMutex.Unlock()
+
+	return c.clockSkew
+}
+
+// trackClockSkew records the measured skew and tells whether it exceeds the max one,
+// the warning is only logged when the skew goes beyond it so that logs are not flooded
+func (c *immuClient) trackClockSkew(skew time.Duration) bool {
+	c.clockSkewMutex.Lock()
+	defer c.clockSkewMutex.Unlock()
+
+	wasExceeded := exceedsClockSkew(c.clockSkew, c.Options.MaxClockSkew)
+	exceeded := exceedsClockSkew(skew, c.Options.MaxClockSkew)
+
+	c.clockSkew = skew
+
+	if exceeded && !wasExceeded {
+		c.Logger.Warningf("clock skew of %s with the server exceeds the max allowed of %s", skew, c.Options.MaxClockSkew)
+	}
+
+	return exceeded
+}
+
+func exceedsClockSkew(skew, maxSkew time.Duration) bool {
+	if skew < 0 {
+		skew = -skew
+	}
+	return skew > maxSkew
+}
+
+func serverTimeFromHeader(header metadata.MD) (time.Time, bool) {
+	values := header.Get(SERVER_TIME_HEADER)
+	if len(values) == 0 {
+		return time.Time{}, false
+	}
+
+	ts, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, ts), true
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestClockSkewInterceptor(t *testing.T) {
+	c := NewClient()
+	c.Options.WithMaxClockSkew(time.Minute)
+
+	invoke := func(serverTime string, err error) error {
+		return c.ClockSkewInterceptor(context.Background(), "method", nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				for _, opt := range opts {
+					if h, ok := opt.(grpc.HeaderCallOption); ok && serverTime != "" {
+						*h.HeaderAddr = metadata.Pairs(SERVER_TIME_HEADER, serverTime)
+					}
+				}
+				return err
+			})
+	}
+
+	serverTimeIn := func(d time.Duration) string {
+		return strconv.FormatInt(time.Now().Add(d).UnixNano(), 10)
+	}
+
+	err := invoke(serverTimeIn(10*time.Second), nil)
+	require.NoError(t, err)
+	require.InDelta(t, 10*time.Second, c.ClockSkew(), float64(time.Second))
+
+	err = invoke(serverTimeIn(-2*time.Minute), nil)
+	require.NoError(t, err)
+	require.InDelta(t, -2*time.Minute, c.ClockSkew(), float64(time.Second))
+
+	t.Run("responses without server time should be ignored", func(t *testing.T) {
+		err = invoke("", nil)
+		require.NoError(t, err)
+
+		err = invoke("invalid", nil)
+		require.NoError(t, err)
+
+		require.InDelta(t, -2*time.Minute, c.ClockSkew(), float64(time.Second))
+	})
+
+	t.Run("failed requests should be returned as is", func(t *testing.T) {
+		errFailed := errors.New("failed")
+
+		err = invoke(serverTimeIn(0), errFailed)
+		require.Equal(t, errFailed, err)
+	})
+
+	c.Options.WithFailOnClockSkew(true)
+
+	err = invoke(serverTimeIn(30*time.Second), nil)
+	require.NoError(t, err)
+
+	err = invoke(serverTimeIn(2*time.Minute), nil)
+	require.Equal(t, ErrClockSkewExceeded, err)
+	require.InDelta(t, 2*time.Minute, c.ClockSkew(), float64(time.Second))
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/errors"
+	"github.com/codenotary/immudb/pkg/database"
+)
+
+// SetByDigest stores the value under its content key, sha256:<hex digest>, unless it was already stored.
+// The reply tells whether the value existed and the transaction holding it, which is verified against
+// the local state as VerifiedSet does
+func (c *immuClient) SetByDigest(ctx context.Context, value []byte) (*schema.VerifiableSetByDigestReply, error) {
+	if len(value) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	err := c.StateService.CacheLock()
+	if err != nil {
+		return nil, err
+	}
+	defer c.StateService.CacheUnlock()
+
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	start := time.Now()
+	defer func() { c.Logger.Debugf("SetByDigest finished in %s", time.Since(start)) }()
+
+	state, err := c.StateService.GetState(ctx, c.Options.CurrentDatabase)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := c.ServiceClient.VerifiableSetByDigest(ctx, &schema.VerifiableSetByDigestRequest{
+		Value:        value,
+		ProveSinceTx: state.TxId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	digest := schema.ContentDigest(value)
+
+	if !bytes.Equal(digest, reply.Digest) {
+		return nil, store.ErrCorruptedData
+	}
+
+	verifiableTx := reply.GetVerifiableTx()
+
+	if verifiableTx.GetTx().GetHeader() == nil || verifiableTx.GetDualProof().GetTargetTxHeader() == nil ||
+		verifiableTx.Tx.Header.Id != reply.Tx {
+		return nil, store.ErrCorruptedData
+	}
+
+	tx := schema.TxFromProto(verifiableTx.Tx)
+
+	err = verifyTxEntry(tx, c.namespacedKey(schema.ContentKey(digest)), value)
+	if err != nil {
+		return nil, err
+	}
+
+	dualProof := schema.DualProofFromProto(verifiableTx.DualProof)
+
+	var sourceID, targetID uint64
+	var sourceAlh, targetAlh [sha256.Size]byte
+
+	// a value already stored may be held by a transaction preceding the local state
+	if state.TxId <= reply.Tx {
+		if tx.Header().Eh != dualProof.TargetTxHeader.Eh {
+			return nil, store.ErrCorruptedData
+		}
+
+		sourceID = state.TxId
+		sourceAlh = schema.DigestFromProto(state.TxHash)
+		targetID = reply.Tx
+		targetAlh = tx.Header().Alh()
+	} else {
+		if tx.Header().Eh != dualProof.SourceTxHeader.Eh {
+			return nil, store.ErrCorruptedData
+		}
+
+		sourceID = reply.Tx
+		sourceAlh = tx.Header().Alh()
+		targetID = state.TxId
+		targetAlh = schema.DigestFromProto(state.TxHash)
+	}
+
+	if state.TxId > 0 {
+		verifies := store.VerifyDualProof(
+			dualProof,
+			sourceID,
+			targetID,
+			sourceAlh,
+			targetAlh,
+		)
+		if !verifies {
+			return nil, c.stateRollbackError(state, sourceID, targetID, verifiableTx.DbUUID)
+		}
+	}
+
+	newState := &schema.ImmutableState{
+		Db:        c.currentDatabase(),
+		TxId:      targetID,
+		TxHash:    targetAlh[:],
+		Signature: verifiableTx.Signature,
+		DbUUID:    verifiableTx.DbUUID,
+	}
+
+	if c.serverSigningPubKey != nil {
+		ok, err := newState.CheckSignature(c.serverSigningPubKey)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, store.ErrCorruptedData
+		}
+	}
+
+	err = c.StateService.SetState(c.Options.CurrentDatabase, newState)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// SetObjectByDigest stores the content read from r as SetByDigest does
+func (c *immuClient) SetObjectByDigest(ctx context.Context, r io.Reader) (*schema.VerifiableSetByDigestReply, error) {
+	if r == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	value, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SetByDigest(ctx, value)
+}
+
+// GetByDigest returns the verified entry of the value with the given sha256 digest
+func (c *immuClient) GetByDigest(ctx context.Context, digest []byte) (*schema.Entry, error) {
+	if len(digest) != sha256.Size {
+		return nil, ErrIllegalArguments
+	}
+
+	entry, err := c.VerifiedGet(ctx, schema.ContentKey(digest))
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(schema.ContentDigest(entry.Value), digest) {
+		return nil, store.ErrCorruptedData
+	}
+
+	return entry, nil
+}
+
+// verifyTxEntry checks the transaction holds the entry of the given key and value
+func verifyTxEntry(tx *store.Tx, key, value []byte) error {
+	encKey := database.EncodeKey(key)
+
+	kindex, err := tx.IndexOf(encKey)
+	if err != nil {
+		return store.ErrCorruptedData
+	}
+
+	inclusionProof, err := tx.Proof(encKey)
+	if err != nil {
+		return err
+	}
+
+	md := tx.Entries()[kindex].Metadata()
+
+	if md != nil && md.Deleted() {
+		return store.ErrCorruptedData
+	}
+
+	entrySpecDigest, err := store.EntrySpecDigestFor(tx.Header().Version)
+	if err != nil {
+		return err
+	}
+
+	e := database.EncodeEntrySpec(key, md, value)
+
+	verifies := store.VerifyInclusion(inclusionProof, entrySpecDigest(e), tx.Header().Eh)
+	if !verifies {
+		return store.ErrCorruptedData
+	}
+
+	return nil
+}
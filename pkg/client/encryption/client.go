@@ -0,0 +1,301 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encryption encrypts values on the client side, so the server only ever stores and returns ciphertexts, e.g.
+//
+//	keys, err := encryption.KeysFromFile("keys.txt")
+//	ec := encryption.NewClient(client, keys).WithKeyEncryption(true)
+//
+//	hdr, err := ec.VerifiedSet(ctx, []byte("patient:1"), []byte("personal data"))
+//	entry, err := ec.VerifiedGet(ctx, []byte("patient:1"))
+//
+// Values are encrypted with AES-256-GCM and bound to the key they're set to. Keys are optionally encrypted too,
+// deterministically so they can still be looked up, at the cost of scans no longer supporting key ranges or prefixes.
+// Proofs are verified over the stored ciphertexts, thus verified operations keep their guarantees.
+package encryption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrNoKeys                       = errors.New("no encryption keys provided")
+	ErrInvalidKey                   = errors.New("invalid encryption key")
+	ErrUnknownKey                   = errors.New("value encrypted with an unknown key")
+	ErrInvalidEnvelope              = errors.New("invalid or tampered encrypted value")
+	ErrUnsupportedWithEncryptedKeys = errors.New("key ranges and prefixes are not supported when keys are encrypted")
+)
+
+// KVClient stores the encrypted values, it's implemented by client.ImmuClient
+type KVClient interface {
+	Set(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error)
+	VerifiedSet(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error)
+	Get(ctx context.Context, key []byte) (*schema.Entry, error)
+	GetAt(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error)
+	VerifiedGet(ctx context.Context, key []byte) (*schema.Entry, error)
+	History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error)
+	Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error)
+}
+
+// Client encrypts the values set through it and decrypts the entries it reads
+type Client struct {
+	c           KVClient
+	keys        KeyProvider
+	encryptKeys bool
+}
+
+func NewClient(c KVClient, keys KeyProvider) *Client {
+	return &Client{c: c, keys: keys}
+}
+
+// WithKeyEncryption sets whether keys are encrypted as well. Keys are written encrypted with the first key of the provider,
+// reads try each key of the provider in turn, so that entries written before a key rotation can still be looked up
+func (c *Client) WithKeyEncryption(encryptKeys bool) *Client {
+	c.encryptKeys = encryptKeys
+	return c
+}
+
+func (c *Client) Set(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error) {
+	storedKey, envelope, err := c.encrypt(key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.c.Set(ctx, storedKey, envelope)
+}
+
+func (c *Client) VerifiedSet(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error) {
+	storedKey, envelope, err := c.encrypt(key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.c.VerifiedSet(ctx, storedKey, envelope)
+}
+
+func (c *Client) Get(ctx context.Context, key []byte) (*schema.Entry, error) {
+	return c.read(key, func(storedKey []byte) (*schema.Entry, error) {
+		return c.c.Get(ctx, storedKey)
+	})
+}
+
+func (c *Client) GetAt(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error) {
+	return c.read(key, func(storedKey []byte) (*schema.Entry, error) {
+		return c.c.GetAt(ctx, storedKey, tx)
+	})
+}
+
+func (c *Client) VerifiedGet(ctx context.Context, key []byte) (*schema.Entry, error) {
+	return c.read(key, func(storedKey []byte) (*schema.Entry, error) {
+		return c.c.VerifiedGet(ctx, storedKey)
+	})
+}
+
+// History returns the decrypted history of the key. When keys are encrypted, the history kept under each encryption key
+// is read and merged by transaction, offset and limit are thus applied to the history kept under each encryption key
+func (c *Client) History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error) {
+	storedKeys, err := c.storedKeys(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &schema.Entries{}
+	found := false
+
+	for i, storedKey := range storedKeys {
+		sreq := proto.Clone(req).(*schema.HistoryRequest)
+		sreq.Key = storedKey
+
+		entries, err := c.c.History(ctx, sreq)
+		if isKeyNotFound(err) && (found || i < len(storedKeys)-1) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		history.Entries = append(history.Entries, entries.Entries...)
+		found = true
+	}
+
+	if len(storedKeys) > 1 {
+		sort.SliceStable(history.Entries, func(i, j int) bool {
+			if req.Desc {
+				return history.Entries[i].Tx > history.Entries[j].Tx
+			}
+			return history.Entries[i].Tx < history.Entries[j].Tx
+		})
+	}
+
+	return c.DecryptEntries(history)
+}
+
+// Scan returns the decrypted entries of the scan. As encrypted keys are not sorted as the keys they encrypt,
+// neither prefixes nor key ranges are supported when keys are encrypted
+func (c *Client) Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error) {
+	if c.encryptKeys && (len(req.Prefix) > 0 || len(req.SeekKey) > 0) {
+		return nil, ErrUnsupportedWithEncryptedKeys
+	}
+
+	entries, err := c.c.Scan(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.DecryptEntries(entries)
+}
+
+// DecryptEntry returns a copy of the entry read from the server with its key and value decrypted
+func (c *Client) DecryptEntry(entry *schema.Entry) (*schema.Entry, error) {
+	keys, err := c.keys.Keys()
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted := proto.Clone(entry).(*schema.Entry)
+
+	decrypted.Value, err = open(keys, entry.Value, entry.Key)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting the value of the entry at tx %d: %w", entry.Tx, err)
+	}
+
+	if c.encryptKeys {
+		decrypted.Key, err = open(keys, entry.Key, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting the key of the entry at tx %d: %w", entry.Tx, err)
+		}
+	}
+
+	return decrypted, nil
+}
+
+// DecryptEntries returns a copy of the entries read from the server with their keys and values decrypted
+func (c *Client) DecryptEntries(entries *schema.Entries) (*schema.Entries, error) {
+	decrypted := &schema.Entries{Entries: make([]*schema.Entry, len(entries.Entries))}
+
+	for i, entry := range entries.Entries {
+		e, err := c.DecryptEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		decrypted.Entries[i] = e
+	}
+
+	return decrypted, nil
+}
+
+// read returns the decrypted entry of the key, trying each key it may be stored as until it's found
+func (c *Client) read(key []byte, get func(storedKey []byte) (*schema.Entry, error)) (*schema.Entry, error) {
+	storedKeys, err := c.storedKeys(key)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, storedKey := range storedKeys {
+		entry, err := get(storedKey)
+		if isKeyNotFound(err) && i < len(storedKeys)-1 {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return c.DecryptEntry(entry)
+	}
+
+	return nil, ErrNoKeys
+}
+
+func isKeyNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "key not found")
+}
+
+// encrypt returns the key as stored in the server and the envelope of the value, bound to the stored key
+func (c *Client) encrypt(key, value []byte) ([]byte, []byte, error) {
+	keys, err := c.keys.Keys()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, nil, ErrNoKeys
+	}
+
+	storedKey, err := c.storedKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	envelope, err := seal(keys[0], value, storedKey, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return storedKey, envelope, nil
+}
+
+// storedKey returns the key as written to the server, keys are deterministically encrypted so they can be looked up
+func (c *Client) storedKey(key []byte) ([]byte, error) {
+	if !c.encryptKeys {
+		return key, nil
+	}
+
+	keys, err := c.keys.Keys()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	return seal(keys[0], key, nil, true)
+}
+
+// storedKeys returns the keys the key may be stored as, one for each encryption key in the order of the provider
+func (c *Client) storedKeys(key []byte) ([][]byte, error) {
+	if !c.encryptKeys {
+		return [][]byte{key}, nil
+	}
+
+	keys, err := c.keys.Keys()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	storedKeys := make([][]byte, len(keys))
+
+	for i, k := range keys {
+		storedKeys[i], err = seal(k, key, nil, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return storedKeys, nil
+}
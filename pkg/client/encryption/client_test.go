@@ -0,0 +1,199 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package encryption
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/internal/clienttest"
+	"github.com/stretchr/testify/require"
+)
+
+func newKeys(t *testing.T, n int) [][]byte {
+	keys := make([][]byte, n)
+
+	for i := range keys {
+		k, err := GenerateKey()
+		require.NoError(t, err)
+
+		keys[i] = k
+	}
+
+	return keys
+}
+
+func TestKeyProviders(t *testing.T) {
+	_, err := StaticKeys()
+	require.ErrorIs(t, err, ErrNoKeys)
+
+	_, err = StaticKeys([]byte("short"))
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	keys := newKeys(t, 2)
+
+	path := filepath.Join(t.TempDir(), "keys.txt")
+	err = ioutil.WriteFile(path, []byte(hex.EncodeToString(keys[0])+"\n\n"+hex.EncodeToString(keys[1])+"\n"), 0600)
+	require.NoError(t, err)
+
+	kp, err := KeysFromFile(path)
+	require.NoError(t, err)
+
+	read, err := kp.Keys()
+	require.NoError(t, err)
+	require.Equal(t, keys, read)
+
+	err = ioutil.WriteFile(path, []byte("not hex"), 0600)
+	require.NoError(t, err)
+
+	_, err = KeysFromFile(path)
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = KeysFromFile(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestEncryptedClient(t *testing.T) {
+	c, ctx := clienttest.SetupClient(t)
+
+	keys := newKeys(t, 2)
+
+	kp, err := StaticKeys(keys[0])
+	require.NoError(t, err)
+
+	ec := NewClient(c, kp)
+
+	t.Run("values should be stored encrypted", func(t *testing.T) {
+		hdr, err := ec.VerifiedSet(ctx, []byte("patient:1"), []byte("personal data"))
+		require.NoError(t, err)
+
+		raw, err := c.Get(ctx, []byte("patient:1"))
+		require.NoError(t, err)
+		require.NotContains(t, string(raw.Value), "personal data")
+
+		entry, err := ec.VerifiedGet(ctx, []byte("patient:1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("patient:1"), entry.Key)
+		require.Equal(t, []byte("personal data"), entry.Value)
+
+		_, err = ec.Set(ctx, []byte("patient:1"), []byte("updated data"))
+		require.NoError(t, err)
+
+		entry, err = ec.GetAt(ctx, []byte("patient:1"), hdr.Id)
+		require.NoError(t, err)
+		require.Equal(t, []byte("personal data"), entry.Value)
+
+		entry, err = ec.Get(ctx, []byte("patient:1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("updated data"), entry.Value)
+
+		history, err := ec.History(ctx, &schema.HistoryRequest{Key: []byte("patient:1")})
+		require.NoError(t, err)
+		require.Len(t, history.Entries, 2)
+		require.Equal(t, []byte("personal data"), history.Entries[0].Value)
+		require.Equal(t, []byte("updated data"), history.Entries[1].Value)
+
+		entries, err := ec.Scan(ctx, &schema.ScanRequest{Prefix: []byte("patient:")})
+		require.NoError(t, err)
+		require.Len(t, entries.Entries, 1)
+		require.Equal(t, []byte("updated data"), entries.Entries[0].Value)
+	})
+
+	t.Run("values moved to another key should not be decrypted", func(t *testing.T) {
+		raw, err := c.Get(ctx, []byte("patient:1"))
+		require.NoError(t, err)
+
+		_, err = c.Set(ctx, []byte("patient:2"), raw.Value)
+		require.NoError(t, err)
+
+		_, err = ec.Get(ctx, []byte("patient:2"))
+		require.ErrorIs(t, err, ErrInvalidEnvelope)
+	})
+
+	t.Run("values should be decrypted with rotated keys", func(t *testing.T) {
+		rotated, err := StaticKeys(keys[1], keys[0])
+		require.NoError(t, err)
+
+		rc := NewClient(c, rotated)
+
+		entry, err := rc.Get(ctx, []byte("patient:1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("updated data"), entry.Value)
+
+		_, err = rc.Set(ctx, []byte("patient:3"), []byte("new data"))
+		require.NoError(t, err)
+
+		_, err = ec.Get(ctx, []byte("patient:3"))
+		require.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("keys should be deterministically encrypted", func(t *testing.T) {
+		kc := NewClient(c, kp).WithKeyEncryption(true)
+
+		_, err := kc.VerifiedSet(ctx, []byte("secret:1"), []byte("secret data"))
+		require.NoError(t, err)
+
+		_, err = c.Get(ctx, []byte("secret:1"))
+		require.Error(t, err)
+
+		entry, err := kc.VerifiedGet(ctx, []byte("secret:1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("secret:1"), entry.Key)
+		require.Equal(t, []byte("secret data"), entry.Value)
+
+		_, err = kc.Scan(ctx, &schema.ScanRequest{Prefix: []byte("secret:")})
+		require.ErrorIs(t, err, ErrUnsupportedWithEncryptedKeys)
+	})
+
+	t.Run("encrypted keys should be looked up with rotated keys", func(t *testing.T) {
+		kc := NewClient(c, kp).WithKeyEncryption(true)
+
+		hdr, err := kc.Set(ctx, []byte("secret:2"), []byte("old data"))
+		require.NoError(t, err)
+
+		rotated, err := StaticKeys(keys[1], keys[0])
+		require.NoError(t, err)
+
+		rc := NewClient(c, rotated).WithKeyEncryption(true)
+
+		entry, err := rc.VerifiedGet(ctx, []byte("secret:2"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("old data"), entry.Value)
+
+		_, err = rc.Set(ctx, []byte("secret:2"), []byte("new data"))
+		require.NoError(t, err)
+
+		entry, err = rc.Get(ctx, []byte("secret:2"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("new data"), entry.Value)
+
+		entry, err = rc.GetAt(ctx, []byte("secret:2"), hdr.Id)
+		require.NoError(t, err)
+		require.Equal(t, []byte("old data"), entry.Value)
+
+		history, err := rc.History(ctx, &schema.HistoryRequest{Key: []byte("secret:2"), Desc: true})
+		require.NoError(t, err)
+		require.Len(t, history.Entries, 2)
+		require.Equal(t, []byte("new data"), history.Entries[0].Value)
+		require.Equal(t, []byte("old data"), history.Entries[1].Value)
+
+		_, err = rc.Get(ctx, []byte("secret:3"))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+const KeySize = 32
+
+const fingerprintSize = 8
+
+// KeyProvider provides the keys values are encrypted with, keys never leave the client
+type KeyProvider interface {
+	// Keys returns the keys values may have been encrypted with, the first one being the one new values are encrypted with
+	Keys() ([][]byte, error)
+}
+
+type staticKeys [][]byte
+
+// StaticKeys returns a provider of the given keys, the first one being the one new values are encrypted with.
+// Keys following it are only used to decrypt values encrypted with them, e.g. once the first key is rotated
+func StaticKeys(keys ...[]byte) (KeyProvider, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	for _, k := range keys {
+		if len(k) != KeySize {
+			return nil, fmt.Errorf("%w: keys must be %d bytes long", ErrInvalidKey, KeySize)
+		}
+	}
+
+	return staticKeys(keys), nil
+}
+
+func (keys staticKeys) Keys() ([][]byte, error) {
+	return keys, nil
+}
+
+// KeysFromFile returns a provider of the keys written in the file, hex encoded one per line.
+// The first key is the one new values are encrypted with
+func KeysFromFile(path string) (KeyProvider, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys [][]byte
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		k, err := hex.DecodeString(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+
+		keys = append(keys, k)
+	}
+
+	return StaticKeys(keys...)
+}
+
+// GenerateKey returns a new random key
+func GenerateKey() ([]byte, error) {
+	k := make([]byte, KeySize)
+
+	_, err := rand.Read(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+func fingerprint(key []byte) []byte {
+	h := sha256.Sum256(key)
+	return h[:fingerprintSize]
+}
+
+// seal returns the envelope {fingerprint}{nonce}{ciphertext} of the plaintext, the fingerprint identifies the key it's encrypted with.
+// Random nonces are used unless deterministic, in which case the nonce is derived from the plaintext
+// so the same plaintext is always sealed into the same envelope
+func seal(key, plaintext, additionalData []byte, deterministic bool) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := make([]byte, fingerprintSize+aead.NonceSize(), fingerprintSize+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	copy(envelope, fingerprint(key))
+
+	nonce := envelope[fingerprintSize:]
+
+	if deterministic {
+		mac := hmac.New(sha256.New, derivedKey(key, "nonce"))
+		mac.Write(additionalData)
+		mac.Write(plaintext)
+		copy(nonce, mac.Sum(nil))
+	} else {
+		_, err = rand.Read(nonce)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return aead.Seal(envelope, nonce, plaintext, additionalData), nil
+}
+
+func open(keys [][]byte, envelope, additionalData []byte) ([]byte, error) {
+	if len(envelope) < fingerprintSize {
+		return nil, ErrInvalidEnvelope
+	}
+
+	for _, k := range keys {
+		if !bytes.Equal(fingerprint(k), envelope[:fingerprintSize]) {
+			continue
+		}
+
+		aead, err := newAEAD(k)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(envelope) < fingerprintSize+aead.NonceSize() {
+			return nil, ErrInvalidEnvelope
+		}
+
+		nonce := envelope[fingerprintSize : fingerprintSize+aead.NonceSize()]
+
+		plaintext, err := aead.Open(nil, nonce, envelope[fingerprintSize+aead.NonceSize():], additionalData)
+		if err != nil {
+			return nil, ErrInvalidEnvelope
+		}
+
+		return plaintext, nil
+	}
+
+	return nil, ErrUnknownKey
+}
+
+// derivedKey returns a key for a single purpose, so the same key is never used by different constructions
+func derivedKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -18,6 +18,7 @@ package client
 
 import (
 	"github.com/codenotary/immudb/pkg/client/errors"
+	"github.com/codenotary/immudb/pkg/client/state"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -31,8 +32,30 @@ var (
 	ErrHealthCheckFailed  = errors.New("health check failed")
 	ErrServerStateIsOlder = errors.New("server state is older than the client one")
 	ErrSessionAlreadyOpen = errors.New("session already opened")
+	ErrClockSkewExceeded  = errors.New("clock skew between client and server exceeds the max allowed")
 )
 
+// Errors related to evidence archives
+var (
+	ErrUnsupportedEvidenceVersion = errors.New("unsupported evidence archive version")
+	ErrEvidenceNotSigned          = errors.New("evidence archive state is not signed")
+)
+
+// Errors related to object notarization
+var (
+	ErrObjectMismatch = errors.New("object does not match the notarization receipt")
+)
+
+// Errors related to client state synchronization
+var (
+	ErrNoDatabaseUUID = errors.New("database uuid is not published by the server")
+	ErrNoTrustedState = errors.New("no trusted state found for the database")
+)
+
+// ErrServerStateRollback is returned when the server presents a state which is not an extension
+// of the one previously verified by the client e.g. when a different database is presented
+type ErrServerStateRollback = state.ErrServerStateRollback
+
 // Server errors mapping
 var (
 	ErrSrvIllegalArguments   = status.Error(codes.InvalidArgument, "illegal arguments")
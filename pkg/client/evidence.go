@@ -0,0 +1,340 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/errors"
+	"github.com/codenotary/immudb/pkg/client/state"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// EvidenceArchiveVersion is the version of the archive format produced by ExportEvidence
+const EvidenceArchiveVersion = 1
+
+const EvidenceProducer = "immuclient"
+
+// ExportEvidence builds an archive holding the current value of the specified keys and rows together with
+// the proofs linking each of them to a single database state, signed by the server when a signing key is configured.
+// The archive can be checked with VerifyEvidence without connecting to the server.
+func (c *immuClient) ExportEvidence(ctx context.Context, keys [][]byte, rows []*schema.SQLGetRequest, description string) (*schema.EvidenceArchive, error) {
+	start := time.Now()
+
+	if len(keys) == 0 && len(rows) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	// the transaction of each entry is resolved before taking the state, so every entry can be proven against it
+	keyReqs := make([]*schema.KeyRequest, len(keys))
+
+	for i, key := range keys {
+		entry, err := c.ServiceClient.Get(ctx, &schema.KeyRequest{Key: key})
+		if err != nil {
+			return nil, err
+		}
+
+		atTx := entry.Tx
+		if entry.ReferencedBy != nil {
+			atTx = entry.ReferencedBy.Tx
+		}
+
+		keyReqs[i] = &schema.KeyRequest{Key: key, AtTx: atTx}
+	}
+
+	rowReqs := make([]*schema.SQLGetRequest, len(rows))
+
+	for i, row := range rows {
+		if row == nil {
+			return nil, ErrIllegalArguments
+		}
+
+		vEntry, err := c.ServiceClient.VerifiableSQLGet(ctx, &schema.VerifiableSQLGetRequest{
+			SqlGetRequest: &schema.SQLGetRequest{Table: row.Table, PkValues: row.PkValues, AtTx: row.AtTx},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		rowReqs[i] = &schema.SQLGetRequest{Table: row.Table, PkValues: row.PkValues, AtTx: vEntry.SqlEntry.Tx}
+	}
+
+	st, err := c.ServiceClient.CurrentState(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	// servers not publishing their identifier can still produce archives
+	serverUUID, err := state.NewUUIDProvider(c.ServiceClient).CurrentUUID(ctx)
+	if err != nil && err != state.ErrNoServerUuid {
+		return nil, err
+	}
+
+	archive := &schema.EvidenceArchive{
+		Version: EvidenceArchiveVersion,
+		Metadata: &schema.EvidenceMetadata{
+			Producer:    EvidenceProducer,
+			ServerUUID:  serverUUID,
+			User:        c.Options.Username,
+			CreatedAt:   time.Now().Unix(),
+			Description: description,
+			Namespace:   c.namespace,
+		},
+		State: st,
+	}
+
+	for _, kReq := range keyReqs {
+		vEntry, err := c.ServiceClient.VerifiableGet(ctx, &schema.VerifiableGetRequest{
+			KeyRequest:   kReq,
+			ProveSinceTx: st.TxId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		archive.Entries = append(archive.Entries, vEntry)
+	}
+
+	for _, rowReq := range rowReqs {
+		vEntry, err := c.ServiceClient.VerifiableSQLGet(ctx, &schema.VerifiableSQLGetRequest{
+			SqlGetRequest: rowReq,
+			ProveSinceTx:  st.TxId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		archive.Rows = append(archive.Rows, &schema.EvidenceRow{SqlGetRequest: rowReq, VerifiableSQLEntry: vEntry})
+	}
+
+	// the archive is checked before handing it over, a signature is only required if the client expects one
+	err = VerifyEvidence(archive, c.serverSigningPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Logger.Debugf("ExportEvidence finished in %s", time.Since(start))
+
+	return archive, nil
+}
+
+// MarshalEvidence encodes the archive in its binary format
+func MarshalEvidence(archive *schema.EvidenceArchive) ([]byte, error) {
+	if archive == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	return proto.Marshal(archive)
+}
+
+// UnmarshalEvidence decodes an archive previously encoded with MarshalEvidence
+func UnmarshalEvidence(data []byte) (*schema.EvidenceArchive, error) {
+	archive := &schema.EvidenceArchive{}
+
+	err := proto.Unmarshal(data, archive)
+	if err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// VerifyEvidence checks every entry and row of the archive is included in the archived state.
+// When a public key is provided, the state must also be signed with the corresponding private key.
+func VerifyEvidence(archive *schema.EvidenceArchive, serverSigningPubKey *ecdsa.PublicKey) error {
+	if archive == nil || archive.State == nil {
+		return ErrIllegalArguments
+	}
+
+	if archive.Version != EvidenceArchiveVersion {
+		return ErrUnsupportedEvidenceVersion
+	}
+
+	st := archive.State
+
+	var namespace string
+	if archive.Metadata != nil {
+		namespace = archive.Metadata.Namespace
+	}
+
+	if serverSigningPubKey != nil {
+		if st.Signature == nil {
+			return ErrEvidenceNotSigned
+		}
+
+		ok, err := st.CheckSignature(serverSigningPubKey)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return store.ErrCorruptedData
+		}
+	}
+
+	for _, vEntry := range archive.Entries {
+		if vEntry == nil || vEntry.Entry == nil {
+			return store.ErrCorruptedData
+		}
+
+		var vTx uint64
+		var e *store.EntrySpec
+
+		if vEntry.Entry.ReferencedBy == nil {
+			vTx = vEntry.Entry.Tx
+			e = database.EncodeEntry(namespacedKey(namespace, vEntry.Entry.Key), vEntry.Entry)
+		} else {
+			ref := vEntry.Entry.ReferencedBy
+			vTx = ref.Tx
+			e = database.EncodeReference(namespacedKey(namespace, ref.Key), schema.KVMetadataFromProto(ref.Metadata), namespacedKey(namespace, vEntry.Entry.Key), ref.AtTx)
+		}
+
+		err := verifyEvidenceEntry(st, vTx, e, vEntry.VerifiableTx, vEntry.InclusionProof)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, row := range archive.Rows {
+		if row == nil || row.SqlGetRequest == nil || row.VerifiableSQLEntry == nil || row.VerifiableSQLEntry.SqlEntry == nil {
+			return store.ErrCorruptedData
+		}
+
+		vEntry := row.VerifiableSQLEntry
+
+		// the key must match the primary key values of the row
+		pkKey, err := evidenceRowKey(row.SqlGetRequest.PkValues, vEntry)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(pkKey, vEntry.SqlEntry.Key) {
+			return store.ErrCorruptedData
+		}
+
+		_, err = decodeRow(vEntry.SqlEntry.Value, vEntry.ColTypesById)
+		if err != nil {
+			return err
+		}
+
+		e := &store.EntrySpec{
+			Key:      vEntry.SqlEntry.Key,
+			Metadata: schema.KVMetadataFromProto(vEntry.SqlEntry.Metadata),
+			Value:    vEntry.SqlEntry.Value,
+		}
+
+		err = verifyEvidenceEntry(st, vEntry.SqlEntry.Tx, e, vEntry.VerifiableTx, vEntry.InclusionProof)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func evidenceRowKey(pkVals []*schema.SQLValue, vEntry *schema.VerifiableSQLEntry) ([]byte, error) {
+	if len(pkVals) == 0 || len(vEntry.PKIDs) != len(pkVals) {
+		return nil, store.ErrCorruptedData
+	}
+
+	valbuf := bytes.Buffer{}
+
+	for i, pkVal := range pkVals {
+		pkID := vEntry.PKIDs[i]
+
+		pkType, ok := vEntry.ColTypesById[pkID]
+		if !ok {
+			return nil, sql.ErrCorruptedData
+		}
+
+		pkLen, ok := vEntry.ColLenById[pkID]
+		if !ok {
+			return nil, sql.ErrCorruptedData
+		}
+
+		pkEncVal, err := sql.EncodeAsKey(schema.RawValue(pkVal), pkType, int(pkLen))
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = valbuf.Write(pkEncVal)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sql.MapKey(
+		[]byte{SQLPrefix},
+		sql.PIndexPrefix,
+		sql.EncodeID(vEntry.DatabaseId),
+		sql.EncodeID(vEntry.TableId),
+		sql.EncodeID(sql.PKIndexID),
+		valbuf.Bytes()), nil
+}
+
+// verifyEvidenceEntry checks the entry is included in tx vTx and that vTx is consistent with the archived state
+func verifyEvidenceEntry(st *schema.ImmutableState, vTx uint64, e *store.EntrySpec, vtx *schema.VerifiableTx, inclusionProof *schema.InclusionProof) error {
+	if vtx == nil ||
+		vtx.Tx == nil ||
+		vtx.Tx.Header == nil ||
+		vtx.DualProof == nil ||
+		vtx.DualProof.SourceTxHeader == nil ||
+		vtx.DualProof.TargetTxHeader == nil ||
+		inclusionProof == nil ||
+		vTx > st.TxId {
+		return store.ErrCorruptedData
+	}
+
+	entrySpecDigest, err := store.EntrySpecDigestFor(int(vtx.Tx.Header.Version))
+	if err != nil {
+		return err
+	}
+
+	dualProof := schema.DualProofFromProto(vtx.DualProof)
+
+	verifies := store.VerifyInclusion(
+		schema.InclusionProofFromProto(inclusionProof),
+		entrySpecDigest(e),
+		dualProof.SourceTxHeader.Eh)
+	if !verifies {
+		return store.ErrCorruptedData
+	}
+
+	verifies = store.VerifyDualProof(
+		dualProof,
+		vTx,
+		st.TxId,
+		dualProof.SourceTxHeader.Alh(),
+		schema.DigestFromProto(st.TxHash),
+	)
+	if !verifies {
+		return store.ErrCorruptedData
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/rs/xid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// IDEMPOTENCY_KEY_HEADER carries the key identifying a write, writes retried with the same key
+// within the same session are applied once by the server
+const IDEMPOTENCY_KEY_HEADER = "immudb-idempotency-key"
+
+// WithIdempotencyKey returns a context sending the idempotency key along the requests made with it.
+// Retrying a write with the same key returns the result of the write originally applied, e.g.
+//
+//	ctx := client.WithIdempotencyKey(context.Background(), "order-42")
+//	hdr, err := c.Set(ctx, []byte("order:42"), []byte("paid"))
+//	if err != nil {
+//		hdr, err = c.Set(ctx, []byte("order:42"), []byte("paid"))
+//	}
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, IDEMPOTENCY_KEY_HEADER, key)
+}
+
+// IdempotencyKeyInterceptor sends a new idempotency key along the request, unless it already carries one.
+// The key is kept by the retries of the request made by grpc, the server ignores it on requests not writing data
+func IdempotencyKeyInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if len(md.Get(IDEMPOTENCY_KEY_HEADER)) == 0 {
+		ctx = WithIdempotencyKey(ctx, xid.New().String())
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
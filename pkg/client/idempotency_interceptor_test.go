@@ -0,0 +1,53 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestIdempotencyKeyInterceptor(t *testing.T) {
+	sentKey := func(ctx context.Context) string {
+		var key string
+
+		err := IdempotencyKeyInterceptor(ctx, "method", nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				require.Len(t, md.Get(IDEMPOTENCY_KEY_HEADER), 1)
+
+				key = md.Get(IDEMPOTENCY_KEY_HEADER)[0]
+				return nil
+			})
+		require.NoError(t, err)
+
+		return key
+	}
+
+	key1 := sentKey(context.Background())
+	require.NotEmpty(t, key1)
+
+	key2 := sentKey(context.Background())
+	require.NotEmpty(t, key2)
+	require.NotEqual(t, key1, key2)
+
+	require.Equal(t, "order-42", sentKey(WithIdempotencyKey(context.Background(), "order-42")))
+}
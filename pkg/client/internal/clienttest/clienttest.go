@@ -0,0 +1,52 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clienttest provides the fixtures shared by the tests of the client packages
+package clienttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// SetupClient starts a server in a temporary directory and returns a client with a session
+// opened on the default database. Both are stopped when the test completes.
+func SetupClient(t *testing.T) (client.ImmuClient, context.Context) {
+	options := server.DefaultOptions().WithDir(t.TempDir())
+	bs := servertest.NewBufconnServer(options)
+
+	err := bs.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() { bs.Stop() })
+
+	opts := client.DefaultOptions().
+		WithDir(t.TempDir()).
+		WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()})
+
+	c := client.NewClient().WithOptions(opts)
+
+	err = c.OpenSession(context.Background(), []byte(`immudb`), []byte(`immudb`), "defaultdb")
+	require.NoError(t, err)
+	t.Cleanup(func() { c.CloseSession(context.Background()) })
+
+	return c, context.Background()
+}
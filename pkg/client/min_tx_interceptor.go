@@ -0,0 +1,99 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MIN_TX_HEADER carries the last transaction written by the client
+const MIN_TX_HEADER = "immudb-min-tx"
+
+// MinTxInterceptor sends the last transaction written on the selected database along the request
+// and keeps track of the transactions committed by it
+func (c *immuClient) MinTxInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	db := c.Options.CurrentDatabase
+
+	err := invoker(c.withMinTx(ctx, db), method, req, reply, cc, opts...)
+	if err != nil {
+		return err
+	}
+
+	c.trackWrittenTx(db, writtenTxID(reply))
+
+	return nil
+}
+
+// MinTxStreamInterceptor sends the last transaction written on the selected database along the stream
+func (c *immuClient) MinTxStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(c.withMinTx(ctx, c.Options.CurrentDatabase), desc, cc, method, opts...)
+}
+
+func (c *immuClient) withMinTx(ctx context.Context, db string) context.Context {
+	c.lastWrittenTxsMutex.Lock()
+	txID := c.lastWrittenTxs[db]
+	c.lastWrittenTxsMutex.Unlock()
+
+	if txID == 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, MIN_TX_HEADER, strconv.FormatUint(txID, 10))
+}
+
+func (c *immuClient) trackWrittenTx(db string, txID uint64) {
+	if txID == 0 {
+		return
+	}
+
+	c.lastWrittenTxsMutex.Lock()
+	defer c.lastWrittenTxsMutex.Unlock()
+
+	if c.lastWrittenTxs == nil {
+		c.lastWrittenTxs = make(map[string]uint64)
+	}
+
+	if txID > c.lastWrittenTxs[db] {
+		c.lastWrittenTxs[db] = txID
+	}
+}
+
+func writtenTxID(reply interface{}) uint64 {
+	switch r := reply.(type) {
+	case *schema.TxHeader:
+		return r.GetId()
+	case *schema.VerifiableTx:
+		return r.GetTx().GetHeader().GetId()
+	case *schema.CommittedSQLTx:
+		return r.GetHeader().GetId()
+	case *schema.SQLExecResult:
+		var txID uint64
+		for _, tx := range r.Txs {
+			if tx.GetHeader().GetId() > txID {
+				txID = tx.GetHeader().GetId()
+			}
+		}
+		return txID
+	}
+
+	return 0
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMinTxInterceptor(t *testing.T) {
+	c := NewClient()
+	c.Options.CurrentDatabase = "db1"
+
+	var sentMinTx []string
+
+	invoke := func(reply interface{}, err error) error {
+		return c.MinTxInterceptor(context.Background(), "method", nil, reply, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				sentMinTx = md.Get(MIN_TX_HEADER)
+				return err
+			})
+	}
+
+	err := invoke(&schema.TxHeader{Id: 3}, nil)
+	require.NoError(t, err)
+	require.Empty(t, sentMinTx)
+
+	err = invoke(&schema.Entry{Tx: 10}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"3"}, sentMinTx)
+
+	err = invoke(&schema.SQLExecResult{Txs: []*schema.CommittedSQLTx{
+		{Header: &schema.TxHeader{Id: 5}},
+		{Header: &schema.TxHeader{Id: 4}},
+	}}, nil)
+	require.NoError(t, err)
+
+	errFailed := errors.New("failed")
+
+	err = invoke(&schema.TxHeader{Id: 7}, errFailed)
+	require.Equal(t, errFailed, err)
+	require.Equal(t, []string{"5"}, sentMinTx)
+
+	err = invoke(&schema.VerifiableTx{Tx: &schema.Tx{Header: &schema.TxHeader{Id: 6}}}, nil)
+	require.NoError(t, err)
+
+	err = invoke(&schema.Entry{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"6"}, sentMinTx)
+
+	// transactions are tracked by database
+	c.Options.CurrentDatabase = "db2"
+
+	err = invoke(&schema.Entry{}, nil)
+	require.NoError(t, err)
+	require.Empty(t, sentMinTx)
+}
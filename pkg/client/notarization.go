@@ -0,0 +1,219 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// NotarizeObject notarizes the object read from r. Only the digest of the object is sent to the server,
+// along with the source, content type and attributes of the record if provided
+func (c *immuClient) NotarizeObject(ctx context.Context, r io.Reader, record *schema.NotarizationRecord) (*schema.NotarizationReceipt, error) {
+	if r == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	rec := &schema.NotarizationRecord{}
+	if record != nil {
+		rec = proto.Clone(record).(*schema.NotarizationRecord)
+	}
+
+	digest, size, err := objectDigest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rec.Digest = digest
+	rec.Size = size
+
+	return c.NotarizeDigest(ctx, rec)
+}
+
+// NotarizeDigest notarizes the object described by the record, which must hold its sha256 digest and size.
+// The returned receipt is verified against the local state, which gets updated, as VerifiedSet does
+func (c *immuClient) NotarizeDigest(ctx context.Context, record *schema.NotarizationRecord) (*schema.NotarizationReceipt, error) {
+	err := record.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.StateService.CacheLock()
+	if err != nil {
+		return nil, err
+	}
+	defer c.StateService.CacheUnlock()
+
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	start := time.Now()
+	defer func() { c.Logger.Debugf("NotarizeDigest finished in %s", time.Since(start)) }()
+
+	state, err := c.StateService.GetState(ctx, c.Options.CurrentDatabase)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := c.ServiceClient.NotarizeObject(ctx, &schema.NotarizeObjectRequest{
+		Record:       record,
+		ProveSinceTx: state.TxId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !proto.Equal(record, receipt.Record) {
+		return nil, store.ErrCorruptedData
+	}
+
+	hdr, err := c.verifyNotarizationTx(receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	verifiableTx := receipt.VerifiableTx
+
+	targetAlh := hdr.Alh()
+
+	if state.TxId > 0 {
+		verifies := store.VerifyDualProof(
+			schema.DualProofFromProto(verifiableTx.DualProof),
+			state.TxId,
+			hdr.ID,
+			schema.DigestFromProto(state.TxHash),
+			targetAlh,
+		)
+		if !verifies {
+			return nil, c.stateRollbackError(state, state.TxId, hdr.ID, verifiableTx.DbUUID)
+		}
+	}
+
+	newState := &schema.ImmutableState{
+		Db:        c.currentDatabase(),
+		TxId:      hdr.ID,
+		TxHash:    targetAlh[:],
+		Signature: verifiableTx.Signature,
+		DbUUID:    verifiableTx.DbUUID,
+	}
+
+	if c.serverSigningPubKey != nil {
+		ok, err := newState.CheckSignature(c.serverSigningPubKey)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, store.ErrCorruptedData
+		}
+	}
+
+	err = c.StateService.SetState(c.Options.CurrentDatabase, newState)
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// VerifyObject checks the object read from r is the one notarized by the receipt, and that the transaction
+// of the receipt is part of the database history, as proven against the local state
+func (c *immuClient) VerifyObject(ctx context.Context, r io.Reader, receipt *schema.NotarizationReceipt) error {
+	if r == nil || receipt == nil {
+		return ErrIllegalArguments
+	}
+
+	digest, size, err := objectDigest(r)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(digest, receipt.Record.GetDigest()) || size != receipt.Record.GetSize() {
+		return ErrObjectMismatch
+	}
+
+	hdr, err := c.verifyNotarizationTx(receipt)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.VerifiedTxByID(ctx, hdr.ID)
+	if err != nil {
+		return err
+	}
+
+	if schema.TxHeaderFromProto(tx.Header).Alh() != hdr.Alh() {
+		return store.ErrCorruptedData
+	}
+
+	return nil
+}
+
+// verifyNotarizationTx checks the transaction of the receipt holds its record, returning the transaction header
+func (c *immuClient) verifyNotarizationTx(receipt *schema.NotarizationReceipt) (*store.TxHeader, error) {
+	verifiableTx := receipt.GetVerifiableTx()
+
+	if verifiableTx.GetTx().GetHeader() == nil || verifiableTx.GetDualProof().GetTargetTxHeader() == nil {
+		return nil, store.ErrCorruptedData
+	}
+
+	err := receipt.Record.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if verifiableTx.Tx.Header.Nentries != 1 || len(verifiableTx.Tx.Entries) != 1 {
+		return nil, store.ErrCorruptedData
+	}
+
+	tx := schema.TxFromProto(verifiableTx.Tx)
+
+	value, err := receipt.Record.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	err = verifyTxEntry(tx, c.namespacedKey(schema.NotarizationKey(receipt.Record.Digest)), value)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Header().Eh != schema.DigestFromProto(verifiableTx.DualProof.TargetTxHeader.EH) {
+		return nil, store.ErrCorruptedData
+	}
+
+	return tx.Header(), nil
+}
+
+func objectDigest(r io.Reader) ([]byte, int64, error) {
+	h := sha256.New()
+
+	size, err := io.Copy(h, r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return h.Sum(nil), size, nil
+}
@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/codenotary/immudb/pkg/client/cache"
 	"github.com/codenotary/immudb/pkg/stream"
 
 	c "github.com/codenotary/immudb/cmd/helper"
@@ -56,6 +57,11 @@ type Options struct {
 	ServerSigningPubKey string
 	StreamChunkSize     int
 	HeartBeatFrequency  time.Duration
+	ReadYourWrites      bool
+	StateMirrors        []cache.StateMirror
+	MaxClockSkew        time.Duration
+	FailOnClockSkew     bool
+	IdempotencyKeys     bool
 }
 
 // DefaultOptions ...
@@ -213,6 +219,41 @@ func (o *Options) WithHeartBeatFrequency(heartBeatFrequency time.Duration) *Opti
 	return o
 }
 
+// WithReadYourWrites sends the last transaction written by the client along every request,
+// so the server only serves it after indexing that transaction e.g. when reads are balanced across replicas
+func (o *Options) WithReadYourWrites(readYourWrites bool) *Options {
+	o.ReadYourWrites = readYourWrites
+	return o
+}
+
+// WithStateMirrors copies every trusted state to the given mirrors as soon as it's updated. A state missing
+// in the local folder is recovered from the mirrors, so the trust isn't reset when the local folder is lost
+func (o *Options) WithStateMirrors(mirrors ...cache.StateMirror) *Options {
+	o.StateMirrors = mirrors
+	return o
+}
+
+// WithMaxClockSkew sets the max skew tolerated between the client clock and the server one,
+// it's measured on every response and the check is disabled when set to zero
+func (o *Options) WithMaxClockSkew(maxClockSkew time.Duration) *Options {
+	o.MaxClockSkew = maxClockSkew
+	return o
+}
+
+// WithFailOnClockSkew makes requests fail with ErrClockSkewExceeded when the clock skew exceeds
+// the max one, instead of just logging a warning
+func (o *Options) WithFailOnClockSkew(failOnClockSkew bool) *Options {
+	o.FailOnClockSkew = failOnClockSkew
+	return o
+}
+
+// WithIdempotencyKeys sends a new idempotency key along every request not already carrying one,
+// so writes resent by the grpc retry policy set through the dial options are applied once
+func (o *Options) WithIdempotencyKeys(idempotencyKeys bool) *Options {
+	o.IdempotencyKeys = idempotencyKeys
+	return o
+}
+
 func (o *Options) String() string {
 	optionsJSON, err := json.Marshal(o)
 	if err != nil {
@@ -220,3 +261,11 @@ func (o *Options) String() string {
 	}
 	return string(optionsJSON)
 }
+
+// stateCache returns the cache holding the trusted states of the client
+func (o *Options) stateCache() cache.Cache {
+	if len(o.StateMirrors) == 0 {
+		return cache.NewFileCache(o.Dir)
+	}
+	return cache.NewMirroredCache(cache.NewFileCache(o.Dir), o.StateMirrors...)
+}
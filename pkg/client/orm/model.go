@@ -0,0 +1,359 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package orm
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/codenotary/immudb/embedded/sql"
+)
+
+// tagName is the key of the struct tags describing the columns fields are mapped to, e.g.
+//
+//	ID    int64  `sql:"id,primary_key,auto_increment"`
+//	Title string `sql:"title,not_null,max_length=50"`
+//
+// Columns are named after the fields in snake case when the tag has no name, fields tagged with "-" are not mapped
+const tagName = "sql"
+
+// Tabler is implemented by the structs naming their own table, tables are otherwise named after the struct in snake case
+type Tabler interface {
+	TableName() string
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	ratType  = reflect.TypeOf(&big.Rat{})
+)
+
+type column struct {
+	name  string
+	field int
+
+	sqlType   sql.SQLValueType
+	maxLength int
+
+	primaryKey    bool
+	autoIncrement bool
+	notNull       bool
+	unique        bool
+}
+
+type model struct {
+	table  string
+	cols   []*column
+	byName map[string]*column
+}
+
+var models sync.Map // reflect.Type -> *model
+
+// modelOf returns the mapping of the struct type of v, which must be a struct or a pointer to a struct
+func modelOf(v interface{}) (*model, reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, reflect.Value{}, fmt.Errorf("%w: %T", ErrNotAStruct, v)
+	}
+
+	m, err := modelOfType(rv.Type())
+	if err != nil {
+		return nil, reflect.Value{}, err
+	}
+
+	return m, rv, nil
+}
+
+func modelOfType(t reflect.Type) (*model, error) {
+	if m, ok := models.Load(t); ok {
+		return m.(*model), nil
+	}
+
+	m := &model{
+		table:  snakeCase(t.Name()),
+		byName: make(map[string]*column),
+	}
+
+	if tabler, ok := reflect.New(t).Interface().(Tabler); ok {
+		m.table = tabler.TableName()
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get(tagName)
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		col, err := newColumn(f, i, tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", f.Name, t.Name(), err)
+		}
+
+		if _, ok := m.byName[col.name]; ok {
+			return nil, fmt.Errorf("%w: column %s is mapped by more than one field of %s", ErrInvalidTag, col.name, t.Name())
+		}
+
+		m.cols = append(m.cols, col)
+		m.byName[col.name] = col
+	}
+
+	if len(m.primaryKey()) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoPrimaryKey, t.Name())
+	}
+
+	models.Store(t, m)
+
+	return m, nil
+}
+
+func newColumn(f reflect.StructField, i int, tag string) (*column, error) {
+	opts := strings.Split(tag, ",")
+
+	col := &column{name: opts[0], field: i}
+	if col.name == "" {
+		col.name = snakeCase(f.Name)
+	}
+
+	sqlType, err := sqlTypeOf(f.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	col.sqlType = sqlType
+
+	for _, opt := range opts[1:] {
+		switch {
+		case opt == "primary_key":
+			col.primaryKey = true
+		case opt == "auto_increment":
+			col.autoIncrement = true
+		case opt == "not_null":
+			col.notNull = true
+		case opt == "unique":
+			col.unique = true
+		case strings.HasPrefix(opt, "max_length="):
+			col.maxLength, err = strconv.Atoi(strings.TrimPrefix(opt, "max_length="))
+			if err != nil || col.maxLength <= 0 {
+				return nil, fmt.Errorf("%w: invalid length in '%s'", ErrInvalidTag, opt)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown option '%s'", ErrInvalidTag, opt)
+		}
+	}
+
+	if col.autoIncrement && col.sqlType != sql.IntegerType {
+		return nil, fmt.Errorf("%w: only integer columns can be auto incremented", ErrInvalidTag)
+	}
+
+	return col, nil
+}
+
+func sqlTypeOf(t reflect.Type) (sql.SQLValueType, error) {
+	if t == ratType {
+		return sql.DecimalType, nil
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return sql.TimestampType, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return sql.IntegerType, nil
+	case reflect.Bool:
+		return sql.BooleanType, nil
+	case reflect.String:
+		return sql.VarcharType, nil
+	case reflect.Float32, reflect.Float64:
+		return sql.FloatType, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return sql.BLOBType, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedFieldType, t)
+}
+
+func (m *model) primaryKey() []*column {
+	var pk []*column
+
+	for _, col := range m.cols {
+		if col.primaryKey {
+			pk = append(pk, col)
+		}
+	}
+
+	return pk
+}
+
+// value returns the field mapped to the column as a value accepted as a statement parameter, nil pointers are NULL
+func (col *column) value(obj reflect.Value) interface{} {
+	v := obj.Field(col.field)
+
+	if v.Type() == ratType {
+		if v.IsNil() {
+			return nil
+		}
+		return v.Interface()
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Slice:
+		return v.Bytes()
+	}
+
+	return v.Interface()
+}
+
+// isZero returns true when the field mapped to the column is not set, auto incremented columns are then left to be assigned
+func (col *column) isZero(obj reflect.Value) bool {
+	return obj.Field(col.field).IsZero()
+}
+
+// set assigns to the field mapped to the column a value as returned by schema.RawValue
+func (col *column) set(obj reflect.Value, raw interface{}) error {
+	f := obj.Field(col.field)
+
+	if raw == nil {
+		f.Set(reflect.Zero(f.Type()))
+		return nil
+	}
+
+	if f.Type() == ratType {
+		rat, ok := raw.(*big.Rat)
+		if !ok {
+			return fmt.Errorf("%w: %T can not be assigned to column %s", ErrInvalidValue, raw, col.name)
+		}
+		f.Set(reflect.ValueOf(rat))
+		return nil
+	}
+
+	if f.Kind() == reflect.Ptr {
+		if f.IsNil() {
+			f.Set(reflect.New(f.Type().Elem()))
+		}
+		f = f.Elem()
+	}
+
+	rv := reflect.ValueOf(raw)
+
+	switch f.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := raw.(int64)
+		if !ok || f.OverflowInt(n) {
+			return fmt.Errorf("%w: %v can not be assigned to column %s", ErrInvalidValue, raw, col.name)
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := raw.(int64)
+		if !ok || n < 0 || f.OverflowUint(uint64(n)) {
+			return fmt.Errorf("%w: %v can not be assigned to column %s", ErrInvalidValue, raw, col.name)
+		}
+		f.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		n, ok := raw.(float64)
+		if !ok {
+			return fmt.Errorf("%w: %v can not be assigned to column %s", ErrInvalidValue, raw, col.name)
+		}
+		f.SetFloat(n)
+	default:
+		if !rv.Type().ConvertibleTo(f.Type()) || rv.Kind() != f.Kind() {
+			return fmt.Errorf("%w: %T can not be assigned to column %s", ErrInvalidValue, raw, col.name)
+		}
+		f.Set(rv.Convert(f.Type()))
+	}
+
+	return nil
+}
+
+func (col *column) spec() string {
+	var b strings.Builder
+
+	b.WriteString(col.name)
+	b.WriteString(" ")
+	b.WriteString(col.sqlType)
+
+	if col.maxLength > 0 {
+		fmt.Fprintf(&b, "[%d]", col.maxLength)
+	}
+
+	if col.notNull {
+		b.WriteString(" NOT NULL")
+	}
+
+	if col.unique {
+		b.WriteString(" UNIQUE")
+	}
+
+	if col.autoIncrement {
+		b.WriteString(" AUTO_INCREMENT")
+	}
+
+	return b.String()
+}
+
+func snakeCase(name string) string {
+	var b strings.Builder
+
+	runes := []rune(name)
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// a new word starts after a lower case letter or before one when leaving an acronym, e.g. HTTPServer
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package orm maps Go structs to the rows of SQL tables.
+//
+// Fields are mapped to columns as described by their struct tags, e.g.
+//
+//	type Product struct {
+//		ID    int64   `sql:"id,primary_key,auto_increment"`
+//		Name  string  `sql:"name,not_null,max_length=50"`
+//		Price float64 `sql:"price"`
+//		Notes *string `sql:"notes"`
+//	}
+//
+//	err := orm.CreateTableFor(ctx, client, Product{})
+//	err = orm.Insert(ctx, client, &Product{Name: "coffee", Price: 3.5})
+//
+//	var products []Product
+//	err = orm.Query(ctx, client, &products, "SELECT id, name, price, notes FROM product WHERE price < @max", map[string]interface{}{"max": 5})
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+var (
+	ErrNotAStruct           = errors.New("not a struct")
+	ErrNoPrimaryKey         = errors.New("no field is mapped to a primary key column")
+	ErrUnsupportedFieldType = errors.New("unsupported field type")
+	ErrInvalidTag           = errors.New("invalid struct tag")
+	ErrInvalidValue         = errors.New("invalid value")
+	ErrInvalidDestination   = errors.New("destination must be a pointer to a slice of structs or of pointers to structs")
+	ErrColumnNotMapped      = errors.New("column not mapped by any field")
+)
+
+// Client executes the statements built from the mapped structs, it's implemented by client.ImmuClient
+type Client interface {
+	SQLExec(ctx context.Context, sql string, params map[string]interface{}) (*schema.SQLExecResult, error)
+	SQLQuery(ctx context.Context, sql string, params map[string]interface{}, renewSnapshot bool) (*schema.SQLQueryResult, error)
+}
+
+// CreateTableFor creates, if it does not exist yet, the table the struct type of obj is mapped to
+func CreateTableFor(ctx context.Context, c Client, obj interface{}) error {
+	m, _, err := modelOf(obj)
+	if err != nil {
+		return err
+	}
+
+	specs := make([]string, len(m.cols))
+	for i, col := range m.cols {
+		specs[i] = col.spec()
+	}
+
+	pk := m.primaryKey()
+
+	pkNames := make([]string, len(pk))
+	for i, col := range pk {
+		pkNames[i] = col.name
+	}
+
+	_, err = c.SQLExec(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s, PRIMARY KEY (%s))",
+		m.table, strings.Join(specs, ", "), strings.Join(pkNames, ", ")), nil)
+
+	return err
+}
+
+// Insert adds a row with the fields of obj, a struct or a pointer to a struct.
+// Auto incremented columns left to zero are assigned by the database and set back into obj when it's a pointer.
+func Insert(ctx context.Context, c Client, obj interface{}) error {
+	return write(ctx, c, "INSERT", obj)
+}
+
+// Upsert adds a row with the fields of obj or replaces the row with the same primary key.
+// Auto incremented columns are handled as by Insert.
+func Upsert(ctx context.Context, c Client, obj interface{}) error {
+	return write(ctx, c, "UPSERT", obj)
+}
+
+func write(ctx context.Context, c Client, op string, obj interface{}) error {
+	m, rv, err := modelOf(obj)
+	if err != nil {
+		return err
+	}
+
+	var names, values []string
+	params := make(map[string]interface{}, len(m.cols))
+
+	var assigned *column
+
+	for _, col := range m.cols {
+		if col.autoIncrement && col.isZero(rv) {
+			assigned = col
+			continue
+		}
+
+		names = append(names, col.name)
+		values = append(values, "@"+col.name)
+		params[col.name] = col.value(rv)
+	}
+
+	res, err := c.SQLExec(ctx, fmt.Sprintf("%s INTO %s (%s) VALUES (%s)",
+		op, m.table, strings.Join(names, ", "), strings.Join(values, ", ")), params)
+	if err != nil {
+		return err
+	}
+
+	if assigned == nil || !rv.CanSet() || len(res.Txs) == 0 {
+		return nil
+	}
+
+	pk, ok := res.Txs[0].LastInsertedPKs[m.table]
+	if !ok {
+		return nil
+	}
+
+	return assigned.set(rv, schema.RawValue(pk))
+}
+
+// Query runs the query and appends the rows it returns to dest, a pointer to a slice of structs or of pointers to structs.
+// Columns are assigned to the fields they're mapped to, fields without a returned column are left to zero.
+func Query(ctx context.Context, c Client, dest interface{}, sql string, params map[string]interface{}) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return ErrInvalidDestination
+	}
+
+	slice := rv.Elem()
+
+	elemType := slice.Type().Elem()
+
+	ptrElems := elemType.Kind() == reflect.Ptr
+	if ptrElems {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+
+	m, err := modelOfType(elemType)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.SQLQuery(ctx, sql, params, false)
+	if err != nil {
+		return err
+	}
+
+	cols := make([]*column, len(res.Columns))
+
+	for i, c := range res.Columns {
+		name := columnName(c.Name)
+
+		col, ok := m.byName[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrColumnNotMapped, name)
+		}
+
+		cols[i] = col
+	}
+
+	for _, row := range res.Rows {
+		obj := reflect.New(elemType)
+
+		for i, v := range row.Values {
+			err = cols[i].set(obj.Elem(), schema.RawValue(v))
+			if err != nil {
+				return err
+			}
+		}
+
+		if ptrElems {
+			slice = reflect.Append(slice, obj)
+		} else {
+			slice = reflect.Append(slice, obj.Elem())
+		}
+	}
+
+	rv.Elem().Set(slice)
+
+	return nil
+}
+
+// columnName returns the name of the column out of its selector e.g. (defaultdb.product.name)
+func columnName(selector string) string {
+	return strings.TrimSuffix(selector[strings.LastIndex(selector, ".")+1:], ")")
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package orm
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/client/internal/clienttest"
+	"github.com/stretchr/testify/require"
+)
+
+type Product struct {
+	ID        int64     `sql:"id,primary_key,auto_increment"`
+	Name      string    `sql:"name,not_null,max_length=50"`
+	Price     float64   `sql:"price"`
+	InStock   bool      `sql:"in_stock"`
+	Notes     *string   `sql:"notes"`
+	Image     []byte    `sql:"image"`
+	CreatedAt time.Time `sql:"created_at"`
+	Discount  *big.Rat  `sql:"discount"`
+	Quantity  uint16
+	Internal  string `sql:"-"`
+}
+
+type Tag struct {
+	Product int64  `sql:"product,primary_key"`
+	Label   string `sql:"label,primary_key,max_length=20"`
+}
+
+func (Tag) TableName() string {
+	return "product_tags"
+}
+
+func TestORM(t *testing.T) {
+	c, ctx := clienttest.SetupClient(t)
+
+	err := CreateTableFor(ctx, c, Product{})
+	require.NoError(t, err)
+
+	// tables already created are left as they are
+	err = CreateTableFor(ctx, c, &Product{})
+	require.NoError(t, err)
+
+	err = CreateTableFor(ctx, c, Tag{})
+	require.NoError(t, err)
+
+	notes := "fair trade"
+	createdAt := time.Date(2022, 4, 1, 10, 30, 0, 0, time.UTC)
+
+	coffee := &Product{
+		Name:      "coffee",
+		Price:     3.5,
+		InStock:   true,
+		Notes:     &notes,
+		Image:     []byte{1, 2, 3},
+		CreatedAt: createdAt,
+		Discount:  big.NewRat(1, 10),
+		Quantity:  12,
+		Internal:  "not stored",
+	}
+
+	err = Insert(ctx, c, coffee)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), coffee.ID)
+
+	tea := &Product{Name: "tea", Price: 2}
+
+	err = Insert(ctx, c, tea)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), tea.ID)
+
+	err = Insert(ctx, c, Tag{Product: coffee.ID, Label: "hot"})
+	require.NoError(t, err)
+
+	tea.Price = 2.5
+
+	err = Upsert(ctx, c, tea)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), tea.ID)
+
+	t.Run("rows should be scanned into structs", func(t *testing.T) {
+		var products []Product
+
+		err = Query(ctx, c, &products, "SELECT * FROM product ORDER BY id", nil)
+		require.NoError(t, err)
+		require.Len(t, products, 2)
+
+		require.Equal(t, int64(1), products[0].ID)
+		require.Equal(t, "coffee", products[0].Name)
+		require.Equal(t, 3.5, products[0].Price)
+		require.True(t, products[0].InStock)
+		require.Equal(t, "fair trade", *products[0].Notes)
+		require.Equal(t, []byte{1, 2, 3}, products[0].Image)
+		require.True(t, createdAt.Equal(products[0].CreatedAt))
+		require.Zero(t, big.NewRat(1, 10).Cmp(products[0].Discount))
+		require.Equal(t, uint16(12), products[0].Quantity)
+		require.Empty(t, products[0].Internal)
+
+		require.Equal(t, int64(2), products[1].ID)
+		require.Equal(t, 2.5, products[1].Price)
+		require.Nil(t, products[1].Notes)
+		require.Nil(t, products[1].Discount)
+	})
+
+	t.Run("rows should be scanned into pointers to structs", func(t *testing.T) {
+		var tags []*Tag
+
+		err = Query(ctx, c, &tags, "SELECT label, product FROM product_tags WHERE product = @product", map[string]interface{}{"product": coffee.ID})
+		require.NoError(t, err)
+		require.Equal(t, []*Tag{{Product: 1, Label: "hot"}}, tags)
+	})
+
+	t.Run("the primary key should be enforced", func(t *testing.T) {
+		err = Insert(ctx, c, Tag{Product: coffee.ID, Label: "hot"})
+		require.Error(t, err)
+	})
+
+	t.Run("columns should be mapped by the fields", func(t *testing.T) {
+		var products []Product
+
+		err = Query(ctx, c, &products, "SELECT id, name AS title FROM product", nil)
+		require.ErrorIs(t, err, ErrColumnNotMapped)
+	})
+
+	t.Run("destinations should be slices of structs", func(t *testing.T) {
+		var products []Product
+
+		err = Query(ctx, c, products, "SELECT * FROM product", nil)
+		require.ErrorIs(t, err, ErrInvalidDestination)
+
+		var names []string
+
+		err = Query(ctx, c, &names, "SELECT name FROM product", nil)
+		require.ErrorIs(t, err, ErrInvalidDestination)
+	})
+}
+
+func TestModelOf(t *testing.T) {
+	m, _, err := modelOf(&Product{})
+	require.NoError(t, err)
+	require.Equal(t, "product", m.table)
+	require.Len(t, m.cols, 9)
+	require.Equal(t, "quantity", m.cols[8].name)
+	require.Equal(t, "id INTEGER AUTO_INCREMENT", m.cols[0].spec())
+	require.Equal(t, "name VARCHAR[50] NOT NULL", m.cols[1].spec())
+	require.Equal(t, "discount DECIMAL", m.cols[7].spec())
+
+	m, _, err = modelOf(Tag{})
+	require.NoError(t, err)
+	require.Equal(t, "product_tags", m.table)
+	require.Len(t, m.primaryKey(), 2)
+
+	_, _, err = modelOf(1)
+	require.ErrorIs(t, err, ErrNotAStruct)
+
+	_, _, err = modelOf(struct{ ID int64 }{})
+	require.ErrorIs(t, err, ErrNoPrimaryKey)
+
+	_, _, err = modelOf(struct {
+		ID    int64 `sql:"id,primary_key"`
+		Items []int
+	}{})
+	require.ErrorIs(t, err, ErrUnsupportedFieldType)
+
+	_, _, err = modelOf(struct {
+		ID int64 `sql:"id,primary_key,indexed"`
+	}{})
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	_, _, err = modelOf(struct {
+		ID string `sql:"id,primary_key,auto_increment"`
+	}{})
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	_, _, err = modelOf(struct {
+		ID   int64 `sql:"id,primary_key"`
+		Name int64 `sql:"id"`
+	}{})
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	require.Equal(t, "http_server_id", snakeCase("HTTPServerID"))
+	require.Equal(t, "created_at", snakeCase("CreatedAt"))
+}
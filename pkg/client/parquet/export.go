@@ -0,0 +1,85 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"context"
+	"io"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/errors"
+)
+
+// ExportQuery writes the rows returned by the query as a parquet file. Results are requested page by page
+// thus rows are streamed into the file without fetching the whole result first, returns the number of rows written
+func ExportQuery(ctx context.Context, c client.ImmuClient, w io.Writer, sql string, params map[string]interface{}) (int64, error) {
+	if c == nil || w == nil || sql == "" {
+		return 0, ErrIllegalArguments
+	}
+
+	if !c.IsConnected() {
+		return 0, errors.FromError(client.ErrNotConnected)
+	}
+
+	namedParams, err := schema.EncodeParams(params)
+	if err != nil {
+		return 0, err
+	}
+
+	req := &schema.SQLQueryRequest{Sql: sql, Params: namedParams}
+
+	var pw *Writer
+
+	for {
+		res, err := c.GetServiceClient().SQLQuery(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+
+		if pw == nil {
+			pw, err = NewWriter(w, res.Columns)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		for _, row := range res.Rows {
+			err = pw.Write(row)
+			if err != nil {
+				return pw.NumRows(), err
+			}
+		}
+
+		if len(res.ContinuationToken) == 0 {
+			break
+		}
+
+		req.ContinuationToken = res.ContinuationToken
+	}
+
+	return pw.NumRows(), pw.Close()
+}
+
+// ExportTable writes all the rows of the table as a parquet file, returns the number of rows written
+func ExportTable(ctx context.Context, c client.ImmuClient, w io.Writer, table string) (int64, error) {
+	if table == "" {
+		return 0, ErrIllegalArguments
+	}
+
+	return ExportQuery(ctx, c, w, "SELECT * FROM "+table, nil)
+}
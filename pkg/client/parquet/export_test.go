@@ -0,0 +1,115 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestExport(t *testing.T) {
+	options := server.DefaultOptions().WithDir(t.TempDir()).WithDefaultPageSize(7)
+	bs := servertest.NewBufconnServer(options)
+
+	bs.Start()
+	defer bs.Stop()
+
+	defer os.Remove(".state-")
+
+	opts := client.DefaultOptions().
+		WithDir(t.TempDir()).
+		WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()})
+
+	c := client.NewClient().WithOptions(opts)
+
+	_, err := ExportTable(context.Background(), c, &bytes.Buffer{}, "audit")
+	require.ErrorIs(t, err, client.ErrNotConnected)
+
+	err = c.OpenSession(context.Background(), []byte(`immudb`), []byte(`immudb`), "defaultdb")
+	require.NoError(t, err)
+	defer c.CloseSession(context.Background())
+
+	ctx := context.Background()
+
+	_, err = c.SQLExec(ctx, `
+		CREATE TABLE audit (
+			id INTEGER AUTO_INCREMENT,
+			action VARCHAR NOT NULL,
+			payload BLOB,
+			ts TIMESTAMP,
+			amount DECIMAL,
+			PRIMARY KEY id
+		)`, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		_, err = c.SQLExec(ctx, fmt.Sprintf("INSERT INTO audit(action, payload, ts, amount) VALUES (@action, @payload, NOW(), CAST('%d.25' AS DECIMAL))", i), map[string]interface{}{
+			"action":  fmt.Sprintf("action%d", i),
+			"payload": []byte{byte(i)},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("tables should be exported across pages", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		n, err := ExportTable(ctx, c, &buf, "audit")
+		require.NoError(t, err)
+		require.Equal(t, int64(20), n)
+
+		f := readParquet(t, buf.Bytes())
+		require.Equal(t, int64(20), f.meta[3])
+
+		require.Len(t, f.values["id"], 20)
+		require.Len(t, f.values["ts"], 20)
+
+		for i := 0; i < 20; i++ {
+			require.Equal(t, int64(i+1), f.values["id"][i])
+			require.Equal(t, []byte(fmt.Sprintf("action%d", i)), f.values["action"][i])
+			require.Equal(t, []byte{byte(i)}, f.values["payload"][i])
+			require.Equal(t, fmt.Sprintf("%d.25", i), f.values["amount"][i])
+			require.NotNil(t, f.values["ts"][i])
+		}
+	})
+
+	t.Run("query results should be exported", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		n, err := ExportQuery(ctx, c, &buf, "SELECT COUNT(*) AS total, MAX(amount) AS top FROM audit WHERE id > @id", map[string]interface{}{"id": 10})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), n)
+
+		f := readParquet(t, buf.Bytes())
+		require.Equal(t, []interface{}{int64(10)}, f.values["total"])
+		require.Equal(t, []interface{}{"19.25"}, f.values["top"])
+	})
+
+	_, err = ExportQuery(ctx, c, &bytes.Buffer{}, "", nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = ExportTable(ctx, c, &bytes.Buffer{}, "missing")
+	require.Error(t, err)
+}
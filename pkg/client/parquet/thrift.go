@@ -0,0 +1,144 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// thrift compact protocol types
+const (
+	thriftBoolTrue  = 1
+	thriftBoolFalse = 2
+	thriftI32       = 5
+	thriftI64       = 6
+	thriftBinary    = 8
+	thriftList      = 9
+	thriftStruct    = 12
+)
+
+// thriftWriter encodes the parquet metadata structures using the thrift compact protocol,
+// only the subset of the protocol required by the file metadata and page headers is supported
+type thriftWriter struct {
+	buf         bytes.Buffer
+	lastFieldID []int16 // of each struct being written
+}
+
+func (w *thriftWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	w.buf.Write(b[:n])
+}
+
+func (w *thriftWriter) zigzag(v int64) {
+	w.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &w.lastFieldID[len(w.lastFieldID)-1]
+
+	delta := id - *last
+	if delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.zigzag(int64(id))
+	}
+
+	*last = id
+}
+
+func (w *thriftWriter) structBegin() {
+	w.lastFieldID = append(w.lastFieldID, 0)
+}
+
+func (w *thriftWriter) structEnd() {
+	w.buf.WriteByte(0)
+	w.lastFieldID = w.lastFieldID[:len(w.lastFieldID)-1]
+}
+
+func (w *thriftWriter) listBegin(size int, elemType byte) {
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+		return
+	}
+
+	w.buf.WriteByte(0xf0 | elemType)
+	w.varint(uint64(size))
+}
+
+func (w *thriftWriter) fieldBool(id int16, v bool) {
+	if v {
+		w.fieldHeader(id, thriftBoolTrue)
+	} else {
+		w.fieldHeader(id, thriftBoolFalse)
+	}
+}
+
+func (w *thriftWriter) fieldI32(id int16, v int32) {
+	w.fieldHeader(id, thriftI32)
+	w.zigzag(int64(v))
+}
+
+func (w *thriftWriter) fieldI64(id int16, v int64) {
+	w.fieldHeader(id, thriftI64)
+	w.zigzag(v)
+}
+
+func (w *thriftWriter) fieldString(id int16, v string) {
+	w.fieldHeader(id, thriftBinary)
+	w.varint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+func (w *thriftWriter) fieldStruct(id int16, f func()) {
+	w.fieldHeader(id, thriftStruct)
+	w.structBegin()
+	f()
+	w.structEnd()
+}
+
+func (w *thriftWriter) fieldI32List(id int16, vs []int32) {
+	w.fieldHeader(id, thriftList)
+	w.listBegin(len(vs), thriftI32)
+
+	for _, v := range vs {
+		w.zigzag(int64(v))
+	}
+}
+
+func (w *thriftWriter) fieldStringList(id int16, vs []string) {
+	w.fieldHeader(id, thriftList)
+	w.listBegin(len(vs), thriftBinary)
+
+	for _, v := range vs {
+		w.varint(uint64(len(v)))
+		w.buf.WriteString(v)
+	}
+}
+
+func (w *thriftWriter) fieldStructList(id int16, size int, f func(i int)) {
+	w.fieldHeader(id, thriftList)
+	w.listBegin(size, thriftStruct)
+
+	for i := 0; i < size; i++ {
+		w.structBegin()
+		f(i)
+		w.structEnd()
+	}
+}
@@ -0,0 +1,609 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+var ErrIllegalArguments = errors.New("illegal arguments")
+var ErrUnsupportedType = errors.New("column type can not be exported to parquet")
+var ErrInvalidValue = errors.New("value does not match the column type")
+var ErrAlreadyClosed = errors.New("writer already closed")
+
+// DefaultRowGroupSize is the number of rows buffered before being written as a row group
+const DefaultRowGroupSize = 10_000
+
+// DecimalPrecision is the number of digits of the DECIMAL values written, enough to hold
+// the int64 integer part and the sql.DecimalScale fractional digits of immudb decimals
+const DecimalPrecision = 38
+
+const decimalLen = 16 // bytes holding a DECIMAL(38, 18) value
+
+const creator = "immudb"
+
+var magic = []byte("PAR1")
+
+// parquet physical types
+const (
+	typeBoolean           = 0
+	typeInt64             = 2
+	typeDouble            = 5
+	typeByteArray         = 6
+	typeFixedLenByteArray = 7
+)
+
+// parquet converted types
+const (
+	convertedNone            = -1
+	convertedUTF8            = 0
+	convertedDecimal         = 5
+	convertedTimestampMicros = 10
+)
+
+const (
+	encodingPlain = 0
+	encodingRLE   = 3
+)
+
+const repetitionOptional = 1
+
+const pageTypeData = 0
+
+const codecUncompressed = 0
+
+var decimalUnit = new(big.Int).Exp(big.NewInt(10), big.NewInt(sql.DecimalScale), nil)
+
+var decimalModulus = new(big.Int).Lsh(big.NewInt(1), decimalLen*8)
+
+// Writer writes SQL rows as a parquet file. Columns are all optional as query results may hold NULL values,
+// and are mapped as follows:
+//
+//	INTEGER   -> INT64
+//	BOOLEAN   -> BOOLEAN
+//	VARCHAR   -> BYTE_ARRAY (UTF8)
+//	BLOB      -> BYTE_ARRAY
+//	TIMESTAMP -> INT64 (TIMESTAMP_MICROS)
+//	FLOAT     -> DOUBLE
+//	DECIMAL   -> FIXED_LEN_BYTE_ARRAY (DECIMAL(38, 18))
+//
+// Rows are buffered in memory until a row group is complete, values are PLAIN encoded without compression.
+type Writer struct {
+	w      io.Writer
+	offset int64
+
+	columns []*column
+
+	rowGroupSize int
+	rowGroups    []*rowGroup
+	bufferedRows int
+	numRows      int64
+
+	closed bool
+}
+
+type column struct {
+	name          string
+	sqlType       sql.SQLValueType
+	physicalType  int32
+	typeLength    int32
+	convertedType int32
+
+	defLevels []byte // 0 when the value is NULL
+	values    bytes.Buffer
+	bools     []bool
+}
+
+type rowGroup struct {
+	numRows int64
+	size    int64
+	chunks  []*columnChunk
+}
+
+type columnChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+// NewWriter writes the header of a parquet file holding the given columns,
+// column names are taken from the selectors of the query results e.g. (defaultdb.mytable.id) is named id
+func NewWriter(w io.Writer, columns []*schema.Column) (*Writer, error) {
+	if w == nil || len(columns) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	pw := &Writer{
+		w:            w,
+		rowGroupSize: DefaultRowGroupSize,
+	}
+
+	names := make(map[string]struct{}, len(columns))
+
+	for _, c := range columns {
+		if c == nil {
+			return nil, ErrIllegalArguments
+		}
+
+		col := &column{
+			name:          columnName(c.Name, names),
+			sqlType:       sql.SQLValueType(c.Type),
+			convertedType: convertedNone,
+		}
+
+		switch col.sqlType {
+		case sql.IntegerType:
+			col.physicalType = typeInt64
+		case sql.BooleanType:
+			col.physicalType = typeBoolean
+		case sql.VarcharType:
+			col.physicalType = typeByteArray
+			col.convertedType = convertedUTF8
+		case sql.BLOBType:
+			col.physicalType = typeByteArray
+		case sql.TimestampType:
+			col.physicalType = typeInt64
+			col.convertedType = convertedTimestampMicros
+		case sql.FloatType:
+			col.physicalType = typeDouble
+		case sql.DecimalType:
+			col.physicalType = typeFixedLenByteArray
+			col.typeLength = decimalLen
+			col.convertedType = convertedDecimal
+		default:
+			return nil, fmt.Errorf("%w: column %s of type %s", ErrUnsupportedType, c.Name, c.Type)
+		}
+
+		pw.columns = append(pw.columns, col)
+	}
+
+	err := pw.write(magic)
+	if err != nil {
+		return nil, err
+	}
+
+	return pw, nil
+}
+
+// WithRowGroupSize sets the number of rows of each row group
+func (pw *Writer) WithRowGroupSize(rowGroupSize int) *Writer {
+	pw.rowGroupSize = rowGroupSize
+	return pw
+}
+
+// NumRows returns the number of rows written so far
+func (pw *Writer) NumRows() int64 {
+	return pw.numRows
+}
+
+// columnName strips the selector down to the column name, the table is kept when needed to make it unique
+// e.g. (db.customers.id) is named id, COUNT(db.customers.*) is named count and MAX(db.customers.age) max_age
+func columnName(selector string, names map[string]struct{}) string {
+	name := selector
+	table := ""
+
+	open := strings.Index(selector, "(")
+
+	if open >= 0 && strings.HasSuffix(selector, ")") {
+		aggFn := strings.ToLower(selector[:open])
+		path := strings.Split(selector[open+1:len(selector)-1], ".")
+
+		col := path[len(path)-1]
+		if len(path) > 1 {
+			table = path[len(path)-2]
+		}
+
+		switch {
+		case aggFn == "":
+			name = col
+		case col == "*":
+			name = aggFn
+		default:
+			name = aggFn + "_" + col
+		}
+	}
+
+	candidate := name
+
+	for i := 1; ; i++ {
+		if _, exists := names[candidate]; !exists {
+			break
+		}
+
+		if i == 1 && table != "" {
+			candidate = table + "_" + name
+		} else {
+			candidate = fmt.Sprintf("%s_%d", name, i)
+		}
+	}
+
+	names[candidate] = struct{}{}
+
+	return candidate
+}
+
+// Write buffers the row, a row group is written once rowGroupSize rows are buffered
+func (pw *Writer) Write(row *schema.Row) error {
+	if pw.closed {
+		return ErrAlreadyClosed
+	}
+
+	if row == nil || len(row.Values) != len(pw.columns) {
+		return ErrIllegalArguments
+	}
+
+	for i, v := range row.Values {
+		err := pw.columns[i].append(v)
+		if err != nil {
+			// values of the previous columns are discarded so the row is not partially written
+			for _, col := range pw.columns[:i] {
+				col.truncate(pw.bufferedRows)
+			}
+
+			return err
+		}
+	}
+
+	pw.bufferedRows++
+	pw.numRows++
+
+	if pw.bufferedRows >= pw.rowGroupSize {
+		return pw.flushRowGroup()
+	}
+
+	return nil
+}
+
+func (col *column) append(v *schema.SQLValue) error {
+	if v == nil || v.Value == nil {
+		col.defLevels = append(col.defLevels, 0)
+		return nil
+	}
+
+	if _, isNull := v.Value.(*schema.SQLValue_Null); isNull {
+		col.defLevels = append(col.defLevels, 0)
+		return nil
+	}
+
+	var b [8]byte
+
+	switch tv := v.Value.(type) {
+	case *schema.SQLValue_N:
+		if col.sqlType != sql.IntegerType {
+			return col.invalidValue(v)
+		}
+
+		binary.LittleEndian.PutUint64(b[:], uint64(tv.N))
+		col.values.Write(b[:])
+	case *schema.SQLValue_B:
+		if col.sqlType != sql.BooleanType {
+			return col.invalidValue(v)
+		}
+
+		col.bools = append(col.bools, tv.B)
+	case *schema.SQLValue_S:
+		if col.sqlType != sql.VarcharType {
+			return col.invalidValue(v)
+		}
+
+		binary.LittleEndian.PutUint32(b[:], uint32(len(tv.S)))
+		col.values.Write(b[:4])
+		col.values.WriteString(tv.S)
+	case *schema.SQLValue_Bs:
+		if col.sqlType != sql.BLOBType {
+			return col.invalidValue(v)
+		}
+
+		binary.LittleEndian.PutUint32(b[:], uint32(len(tv.Bs)))
+		col.values.Write(b[:4])
+		col.values.Write(tv.Bs)
+	case *schema.SQLValue_Ts:
+		if col.sqlType != sql.TimestampType {
+			return col.invalidValue(v)
+		}
+
+		binary.LittleEndian.PutUint64(b[:], uint64(tv.Ts))
+		col.values.Write(b[:])
+	case *schema.SQLValue_F:
+		if col.sqlType != sql.FloatType {
+			return col.invalidValue(v)
+		}
+
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(tv.F))
+		col.values.Write(b[:])
+	case *schema.SQLValue_D:
+		if col.sqlType != sql.DecimalType {
+			return col.invalidValue(v)
+		}
+
+		d, err := decimalBytes(tv.D)
+		if err != nil {
+			return err
+		}
+
+		col.values.Write(d)
+	default:
+		return col.invalidValue(v)
+	}
+
+	col.defLevels = append(col.defLevels, 1)
+
+	return nil
+}
+
+func (col *column) invalidValue(v *schema.SQLValue) error {
+	return fmt.Errorf("%w: %s of column %s (%s)", ErrInvalidValue, schema.RenderValue(v.Value), col.name, col.sqlType)
+}
+
+// truncate discards the last value appended when the column already holds one for the row being written
+func (col *column) truncate(rows int) {
+	if len(col.defLevels) == rows {
+		return
+	}
+
+	defined := col.defLevels[rows] == 1
+	col.defLevels = col.defLevels[:rows]
+
+	if !defined {
+		return
+	}
+
+	switch col.physicalType {
+	case typeBoolean:
+		col.bools = col.bools[:len(col.bools)-1]
+	case typeInt64, typeDouble:
+		col.values.Truncate(col.values.Len() - 8)
+	case typeFixedLenByteArray:
+		col.values.Truncate(col.values.Len() - decimalLen)
+	case typeByteArray:
+		// byte arrays are length prefixed, the buffer is scanned up to the last one
+		b := col.values.Bytes()
+		last := 0
+
+		for off := 0; off < len(b); {
+			last = off
+			off += 4 + int(binary.LittleEndian.Uint32(b[off:]))
+		}
+
+		col.values.Truncate(last)
+	}
+}
+
+// decimalBytes encodes the decimal as the big-endian two's complement of its unscaled value
+func decimalBytes(s string) ([]byte, error) {
+	r, err := sql.ParseDecimal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	unscaled := new(big.Int).Mul(r.Num(), decimalUnit)
+	unscaled.Quo(unscaled, r.Denom())
+
+	if unscaled.Sign() < 0 {
+		unscaled.Add(unscaled, decimalModulus)
+	}
+
+	b := make([]byte, decimalLen)
+
+	ub := unscaled.Bytes()
+	copy(b[decimalLen-len(ub):], ub)
+
+	return b, nil
+}
+
+func (pw *Writer) write(b []byte) error {
+	n, err := pw.w.Write(b)
+	pw.offset += int64(n)
+
+	return err
+}
+
+// flushRowGroup writes a single data page for each column holding the buffered rows
+func (pw *Writer) flushRowGroup() error {
+	rg := &rowGroup{numRows: int64(pw.bufferedRows)}
+
+	for _, col := range pw.columns {
+		var page bytes.Buffer
+
+		page.Write(encodeLevels(col.defLevels))
+
+		if col.physicalType == typeBoolean {
+			page.Write(packBools(col.bools))
+		} else {
+			page.Write(col.values.Bytes())
+		}
+
+		var th thriftWriter
+
+		th.structBegin()
+		th.fieldI32(1, pageTypeData)
+		th.fieldI32(2, int32(page.Len()))
+		th.fieldI32(3, int32(page.Len()))
+		th.fieldStruct(5, func() {
+			th.fieldI32(1, int32(len(col.defLevels)))
+			th.fieldI32(2, encodingPlain)
+			th.fieldI32(3, encodingRLE)
+			th.fieldI32(4, encodingRLE)
+		})
+		th.structEnd()
+
+		chunk := &columnChunk{
+			offset:    pw.offset,
+			size:      int64(th.buf.Len() + page.Len()),
+			numValues: int64(len(col.defLevels)),
+		}
+
+		err := pw.write(th.buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		err = pw.write(page.Bytes())
+		if err != nil {
+			return err
+		}
+
+		rg.chunks = append(rg.chunks, chunk)
+		rg.size += chunk.size
+
+		col.defLevels = col.defLevels[:0]
+		col.values.Reset()
+		col.bools = col.bools[:0]
+	}
+
+	pw.rowGroups = append(pw.rowGroups, rg)
+	pw.bufferedRows = 0
+
+	return nil
+}
+
+// encodeLevels encodes definition levels of bit width 1 as length-prefixed RLE runs
+func encodeLevels(levels []byte) []byte {
+	var th thriftWriter
+
+	th.buf.Write(make([]byte, 4))
+
+	for i := 0; i < len(levels); {
+		j := i + 1
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+
+		th.varint(uint64(j-i) << 1)
+		th.buf.WriteByte(levels[i])
+
+		i = j
+	}
+
+	b := th.buf.Bytes()
+	binary.LittleEndian.PutUint32(b, uint32(len(b)-4))
+
+	return b
+}
+
+// packBools encodes booleans one bit each, least significant bit first
+func packBools(bools []bool) []byte {
+	b := make([]byte, (len(bools)+7)/8)
+
+	for i, v := range bools {
+		if v {
+			b[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	return b
+}
+
+// Close writes the pending rows and the file metadata, the underlying writer is not closed
+func (pw *Writer) Close() error {
+	if pw.closed {
+		return ErrAlreadyClosed
+	}
+
+	pw.closed = true
+
+	if pw.bufferedRows > 0 {
+		err := pw.flushRowGroup()
+		if err != nil {
+			return err
+		}
+	}
+
+	var th thriftWriter
+
+	th.structBegin()
+
+	th.fieldI32(1, 1)
+	th.fieldStructList(2, len(pw.columns)+1, func(i int) {
+		if i == 0 {
+			th.fieldString(4, "schema")
+			th.fieldI32(5, int32(len(pw.columns)))
+			return
+		}
+
+		col := pw.columns[i-1]
+
+		th.fieldI32(1, col.physicalType)
+
+		if col.typeLength > 0 {
+			th.fieldI32(2, col.typeLength)
+		}
+
+		th.fieldI32(3, repetitionOptional)
+		th.fieldString(4, col.name)
+
+		if col.convertedType != convertedNone {
+			th.fieldI32(6, col.convertedType)
+		}
+
+		if col.convertedType == convertedDecimal {
+			th.fieldI32(7, sql.DecimalScale)
+			th.fieldI32(8, DecimalPrecision)
+		}
+	})
+	th.fieldI64(3, pw.numRows)
+	th.fieldStructList(4, len(pw.rowGroups), func(i int) {
+		rg := pw.rowGroups[i]
+
+		th.fieldStructList(1, len(rg.chunks), func(j int) {
+			chunk := rg.chunks[j]
+			col := pw.columns[j]
+
+			th.fieldI64(2, chunk.offset)
+			th.fieldStruct(3, func() {
+				th.fieldI32(1, col.physicalType)
+				th.fieldI32List(2, []int32{encodingPlain, encodingRLE})
+				th.fieldStringList(3, []string{col.name})
+				th.fieldI32(4, codecUncompressed)
+				th.fieldI64(5, chunk.numValues)
+				th.fieldI64(6, chunk.size)
+				th.fieldI64(7, chunk.size)
+				th.fieldI64(9, chunk.offset)
+			})
+		})
+		th.fieldI64(2, rg.size)
+		th.fieldI64(3, rg.numRows)
+	})
+	th.fieldString(6, creator)
+
+	th.structEnd()
+
+	var footer [4]byte
+	binary.LittleEndian.PutUint32(footer[:], uint32(th.buf.Len()))
+
+	err := pw.write(th.buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	err = pw.write(footer[:])
+	if err != nil {
+		return err
+	}
+
+	return pw.write(magic)
+}
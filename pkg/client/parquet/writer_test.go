@@ -0,0 +1,429 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// thriftReader decodes thrift compact structs into maps of field ids to values
+type thriftReader struct {
+	b []byte
+	i int
+}
+
+type tstruct map[int16]interface{}
+
+func (r *thriftReader) varint() uint64 {
+	v, n := binary.Uvarint(r.b[r.i:])
+	r.i += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case thriftBoolTrue:
+		return true
+	case thriftBoolFalse:
+		return false
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		l := int(r.varint())
+		r.i += l
+		return string(r.b[r.i-l : r.i])
+	case thriftList:
+		h := r.b[r.i]
+		r.i++
+
+		size := int(h >> 4)
+		if size == 15 {
+			size = int(r.varint())
+		}
+
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(h & 0x0f)
+		}
+
+		return list
+	case thriftStruct:
+		return r.readStruct()
+	}
+
+	panic("unexpected thrift type")
+}
+
+func (r *thriftReader) readStruct() tstruct {
+	s := tstruct{}
+
+	var id int16
+
+	for {
+		h := r.b[r.i]
+		r.i++
+
+		if h == 0 {
+			return s
+		}
+
+		if h>>4 == 0 {
+			id = int16(r.zigzag())
+		} else {
+			id += int16(h >> 4)
+		}
+
+		s[id] = r.value(h & 0x0f)
+	}
+}
+
+type parquetFile struct {
+	meta    tstruct
+	columns []tstruct
+	values  map[string][]interface{}
+}
+
+// readParquet decodes the files written by Writer
+func readParquet(t *testing.T, b []byte) *parquetFile {
+	require.Equal(t, magic, b[:4])
+	require.Equal(t, magic, b[len(b)-4:])
+
+	footerLen := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	footer := &thriftReader{b: b[len(b)-8-footerLen : len(b)-8]}
+
+	f := &parquetFile{
+		meta:   footer.readStruct(),
+		values: make(map[string][]interface{}),
+	}
+	require.Equal(t, len(footer.b), footer.i)
+
+	elements := f.meta[2].([]interface{})
+	require.Equal(t, int64(len(elements)-1), elements[0].(tstruct)[5])
+
+	for _, e := range elements[1:] {
+		f.columns = append(f.columns, e.(tstruct))
+	}
+
+	var numRows int64
+
+	for _, rg := range f.meta[4].([]interface{}) {
+		chunks := rg.(tstruct)[1].([]interface{})
+		require.Len(t, chunks, len(f.columns))
+
+		for j, c := range chunks {
+			col := f.columns[j]
+			md := c.(tstruct)[3].(tstruct)
+
+			require.Equal(t, col[1], md[1])
+			require.Equal(t, []interface{}{col[4]}, md[3])
+
+			pr := &thriftReader{b: b, i: int(md[9].(int64))}
+			ph := pr.readStruct()
+			require.Equal(t, int64(pageTypeData), ph[1])
+
+			page := b[pr.i : pr.i+int(ph[3].(int64))]
+			require.Equal(t, md[6], int64(pr.i-int(md[9].(int64))+len(page)))
+
+			numValues := int(ph[5].(tstruct)[1].(int64))
+			require.Equal(t, md[5], int64(numValues))
+
+			levelsLen := int(binary.LittleEndian.Uint32(page))
+			lr := &thriftReader{b: page[4 : 4+levelsLen]}
+
+			var levels []byte
+			for lr.i < len(lr.b) {
+				run := int(lr.varint())
+				require.Zero(t, run&1)
+
+				for k := 0; k < run>>1; k++ {
+					levels = append(levels, lr.b[lr.i])
+				}
+				lr.i++
+			}
+			require.Len(t, levels, numValues)
+
+			data := page[4+levelsLen:]
+			defined := 0
+
+			name := col[4].(string)
+
+			for _, l := range levels {
+				if l == 0 {
+					f.values[name] = append(f.values[name], nil)
+					continue
+				}
+
+				var v interface{}
+
+				switch col[1].(int64) {
+				case typeBoolean:
+					v = data[defined/8]&(1<<(defined%8)) != 0
+				case typeInt64:
+					v = int64(binary.LittleEndian.Uint64(data))
+					data = data[8:]
+				case typeDouble:
+					v = math.Float64frombits(binary.LittleEndian.Uint64(data))
+					data = data[8:]
+				case typeByteArray:
+					l := int(binary.LittleEndian.Uint32(data))
+					v = data[4 : 4+l]
+					data = data[4+l:]
+				case typeFixedLenByteArray:
+					unscaled := new(big.Int).SetBytes(data[:decimalLen])
+					if data[0]&0x80 != 0 {
+						unscaled.Sub(unscaled, decimalModulus)
+					}
+					v = sql.FormatDecimal(new(big.Rat).SetFrac(unscaled, decimalUnit))
+					data = data[decimalLen:]
+				}
+
+				f.values[name] = append(f.values[name], v)
+				defined++
+			}
+		}
+
+		numRows += rg.(tstruct)[3].(int64)
+	}
+
+	require.Equal(t, f.meta[3], numRows)
+
+	return f
+}
+
+func TestWriter(t *testing.T) {
+	columns := []*schema.Column{
+		{Name: "(defaultdb.audit.id)", Type: sql.IntegerType},
+		{Name: "(defaultdb.audit.active)", Type: sql.BooleanType},
+		{Name: "(defaultdb.audit.title)", Type: sql.VarcharType},
+		{Name: "(defaultdb.audit.payload)", Type: sql.BLOBType},
+		{Name: "(defaultdb.audit.ts)", Type: sql.TimestampType},
+		{Name: "(defaultdb.audit.score)", Type: sql.FloatType},
+		{Name: "(defaultdb.audit.amount)", Type: sql.DecimalType},
+	}
+
+	row := func(i int) *schema.Row {
+		if i%3 == 2 {
+			values := make([]*schema.SQLValue, len(columns))
+			for j := range values {
+				values[j] = &schema.SQLValue{Value: &schema.SQLValue_Null{}}
+			}
+
+			return &schema.Row{Values: values}
+		}
+
+		return &schema.Row{Values: []*schema.SQLValue{
+			{Value: &schema.SQLValue_N{N: int64(i)}},
+			{Value: &schema.SQLValue_B{B: i%2 == 0}},
+			{Value: &schema.SQLValue_S{S: "title"}},
+			{Value: &schema.SQLValue_Bs{Bs: []byte{byte(i)}}},
+			{Value: &schema.SQLValue_Ts{Ts: int64(i) * 1e6}},
+			{Value: &schema.SQLValue_F{F: float64(i) / 4}},
+			{Value: &schema.SQLValue_D{D: "-12.5"}},
+		}}
+	}
+
+	var buf bytes.Buffer
+
+	pw, err := NewWriter(&buf, columns)
+	require.NoError(t, err)
+
+	pw.WithRowGroupSize(4)
+
+	for i := 0; i < 10; i++ {
+		err = pw.Write(row(i))
+		require.NoError(t, err)
+	}
+
+	err = pw.Write(&schema.Row{Values: []*schema.SQLValue{{Value: &schema.SQLValue_N{N: 1}}}})
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	invalid := row(0)
+	invalid.Values[5] = &schema.SQLValue{Value: &schema.SQLValue_S{S: "not a float"}}
+
+	err = pw.Write(invalid)
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	err = pw.Close()
+	require.NoError(t, err)
+
+	err = pw.Close()
+	require.ErrorIs(t, err, ErrAlreadyClosed)
+
+	err = pw.Write(row(0))
+	require.ErrorIs(t, err, ErrAlreadyClosed)
+
+	f := readParquet(t, buf.Bytes())
+	require.Equal(t, int64(10), f.meta[3])
+	require.Len(t, f.meta[4], 3)
+	require.Equal(t, creator, f.meta[6])
+
+	require.Len(t, f.columns, len(columns))
+
+	for _, col := range f.columns {
+		require.Equal(t, int64(repetitionOptional), col[3])
+	}
+
+	require.Equal(t, "id", f.columns[0][4])
+	require.Equal(t, int64(typeInt64), f.columns[0][1])
+
+	require.Equal(t, int64(typeBoolean), f.columns[1][1])
+
+	require.Equal(t, int64(typeByteArray), f.columns[2][1])
+	require.Equal(t, int64(convertedUTF8), f.columns[2][6])
+
+	require.Equal(t, int64(typeByteArray), f.columns[3][1])
+	require.NotContains(t, f.columns[3], int16(6))
+
+	require.Equal(t, int64(typeInt64), f.columns[4][1])
+	require.Equal(t, int64(convertedTimestampMicros), f.columns[4][6])
+
+	require.Equal(t, int64(typeDouble), f.columns[5][1])
+
+	require.Equal(t, int64(typeFixedLenByteArray), f.columns[6][1])
+	require.Equal(t, int64(decimalLen), f.columns[6][2])
+	require.Equal(t, int64(convertedDecimal), f.columns[6][6])
+	require.Equal(t, int64(sql.DecimalScale), f.columns[6][7])
+	require.Equal(t, int64(DecimalPrecision), f.columns[6][8])
+
+	for i := 0; i < 10; i++ {
+		if i%3 == 2 {
+			for _, col := range f.columns {
+				require.Nil(t, f.values[col[4].(string)][i])
+			}
+
+			continue
+		}
+
+		require.Equal(t, int64(i), f.values["id"][i])
+		require.Equal(t, i%2 == 0, f.values["active"][i])
+		require.Equal(t, []byte("title"), f.values["title"][i])
+		require.Equal(t, []byte{byte(i)}, f.values["payload"][i])
+		require.Equal(t, int64(i)*1e6, f.values["ts"][i])
+		require.Equal(t, float64(i)/4, f.values["score"][i])
+		require.Equal(t, "-12.5", f.values["amount"][i])
+	}
+}
+
+func TestWriterEdgeCases(t *testing.T) {
+	_, err := NewWriter(nil, []*schema.Column{{Name: "(db.t.id)", Type: sql.IntegerType}})
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = NewWriter(&bytes.Buffer{}, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = NewWriter(&bytes.Buffer{}, []*schema.Column{{Name: "(db.t.i)", Type: sql.IntervalType}})
+	require.ErrorIs(t, err, ErrUnsupportedType)
+
+	t.Run("empty files should be readable", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		pw, err := NewWriter(&buf, []*schema.Column{{Name: "(db.t.id)", Type: sql.IntegerType}})
+		require.NoError(t, err)
+
+		err = pw.Close()
+		require.NoError(t, err)
+
+		f := readParquet(t, buf.Bytes())
+		require.Equal(t, int64(0), f.meta[3])
+		require.Empty(t, f.meta[4])
+	})
+
+	t.Run("large decimals should be preserved", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		pw, err := NewWriter(&buf, []*schema.Column{{Name: "(db.t.amount)", Type: sql.DecimalType}})
+		require.NoError(t, err)
+
+		for _, d := range []string{"9223372036854775807.999999999999999999", "-9223372036854775808", "0.000000000000000001"} {
+			err = pw.Write(&schema.Row{Values: []*schema.SQLValue{{Value: &schema.SQLValue_D{D: d}}}})
+			require.NoError(t, err)
+		}
+
+		err = pw.Write(&schema.Row{Values: []*schema.SQLValue{{Value: &schema.SQLValue_D{D: "nan"}}}})
+		require.Error(t, err)
+
+		err = pw.Close()
+		require.NoError(t, err)
+
+		f := readParquet(t, buf.Bytes())
+		require.Equal(t, []interface{}{"9223372036854775807.999999999999999999", "-9223372036854775808", "0.000000000000000001"}, f.values["amount"])
+	})
+
+	t.Run("rows should not be partially written", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		pw, err := NewWriter(&buf, []*schema.Column{
+			{Name: "(db.t.title)", Type: sql.VarcharType},
+			{Name: "(db.t.active)", Type: sql.BooleanType},
+			{Name: "(db.t.id)", Type: sql.IntegerType},
+		})
+		require.NoError(t, err)
+
+		err = pw.Write(&schema.Row{Values: []*schema.SQLValue{
+			{Value: &schema.SQLValue_S{S: "first"}},
+			{Value: &schema.SQLValue_B{B: true}},
+			{Value: &schema.SQLValue_N{N: 1}},
+		}})
+		require.NoError(t, err)
+
+		err = pw.Write(&schema.Row{Values: []*schema.SQLValue{
+			{Value: &schema.SQLValue_S{S: "invalid"}},
+			{Value: &schema.SQLValue_B{B: false}},
+			{Value: &schema.SQLValue_S{S: "invalid"}},
+		}})
+		require.ErrorIs(t, err, ErrInvalidValue)
+
+		err = pw.Write(&schema.Row{Values: []*schema.SQLValue{
+			{Value: &schema.SQLValue_S{S: "second"}},
+			nil,
+			{Value: &schema.SQLValue_N{N: 2}},
+		}})
+		require.NoError(t, err)
+
+		err = pw.Close()
+		require.NoError(t, err)
+
+		f := readParquet(t, buf.Bytes())
+		require.Equal(t, []interface{}{[]byte("first"), []byte("second")}, f.values["title"])
+		require.Equal(t, []interface{}{true, nil}, f.values["active"])
+		require.Equal(t, []interface{}{int64(1), int64(2)}, f.values["id"])
+	})
+}
+
+func TestColumnName(t *testing.T) {
+	names := make(map[string]struct{})
+
+	require.Equal(t, "id", columnName("(defaultdb.customers.id)", names))
+	require.Equal(t, "orders_id", columnName("(defaultdb.orders.id)", names))
+	require.Equal(t, "id_2", columnName("(defaultdb.orders.id)", names))
+	require.Equal(t, "count", columnName("COUNT(defaultdb.customers.*)", names))
+	require.Equal(t, "max_age", columnName("MAX(defaultdb.customers.age)", names))
+	require.Equal(t, "name", columnName("name", names))
+}
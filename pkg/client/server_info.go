@@ -0,0 +1,61 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServerInfo returns the version of the server and the features it supports, so workflows relying on
+// features not supported by the server can be avoided up front. Servers predating the negotiation are
+// reported by their version alone, none of their features being taken for granted
+func (c *immuClient) ServerInfo(ctx context.Context) (*schema.ServerInfoResponse, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+	defer func() { c.Logger.Debugf("ServerInfo finished in %s", time.Since(start)) }()
+
+	info, err := c.ServiceClient.ServerInfo(ctx, &empty.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		health, err := c.ServiceClient.Health(ctx, &empty.Empty{})
+		if err != nil {
+			return nil, err
+		}
+
+		return &schema.ServerInfoResponse{
+			Version:      health.Version,
+			Capabilities: &schema.ServerCapabilities{},
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Capabilities == nil {
+		info.Capabilities = &schema.ServerCapabilities{}
+	}
+
+	return info, nil
+}
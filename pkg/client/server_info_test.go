@@ -0,0 +1,83 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestImmuClient_ServerInfo(t *testing.T) {
+	c := NewClient()
+
+	_, err := c.ServerInfo(context.Background())
+	require.ErrorIs(t, err, ErrNotConnected)
+
+	c.clientConn = &grpc.ClientConn{}
+
+	t.Run("capabilities should be reported by the server", func(t *testing.T) {
+		c.ServiceClient = &immuServiceClientMock{
+			ServerInfoF: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.ServerInfoResponse, error) {
+				return &schema.ServerInfoResponse{
+					Version:      "1.2.3",
+					Capabilities: &schema.ServerCapabilities{Sql: true, StreamChunkSize: 4096},
+				}, nil
+			},
+		}
+
+		info, err := c.ServerInfo(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "1.2.3", info.Version)
+		require.True(t, info.Capabilities.Sql)
+		require.False(t, info.Capabilities.Replication)
+		require.Equal(t, int32(4096), info.Capabilities.StreamChunkSize)
+	})
+
+	t.Run("servers predating the negotiation should be reported by their version", func(t *testing.T) {
+		c.ServiceClient = &immuServiceClientMock{
+			ServerInfoF: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.ServerInfoResponse, error) {
+				return nil, status.Error(codes.Unimplemented, "unknown method ServerInfo")
+			},
+			HealthF: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.HealthResponse, error) {
+				return &schema.HealthResponse{Status: true, Version: "1.1.0"}, nil
+			},
+		}
+
+		info, err := c.ServerInfo(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "1.1.0", info.Version)
+		require.Equal(t, &schema.ServerCapabilities{}, info.Capabilities)
+	})
+
+	t.Run("other errors should be returned", func(t *testing.T) {
+		c.ServiceClient = &immuServiceClientMock{
+			ServerInfoF: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.ServerInfoResponse, error) {
+				return nil, status.Error(codes.Unavailable, "connection refused")
+			},
+		}
+
+		_, err := c.ServerInfo(context.Background())
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
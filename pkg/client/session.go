@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/codenotary/immudb/pkg/api/schema"
-	"github.com/codenotary/immudb/pkg/client/cache"
 	"github.com/codenotary/immudb/pkg/client/errors"
 	"github.com/codenotary/immudb/pkg/client/heartbeater"
 	"github.com/codenotary/immudb/pkg/client/state"
@@ -56,7 +55,7 @@ func (c *immuClient) OpenSession(ctx context.Context, user []byte, pass []byte,
 
 	stateProvider := state.NewStateProvider(c.ServiceClient)
 
-	stateService, err := state.NewStateServiceWithUUID(cache.NewFileCache(c.Options.Dir), c.Logger, stateProvider, resp.GetServerUUID())
+	stateService, err := state.NewStateServiceWithUUID(c.Options.stateCache(), c.Logger, stateProvider, resp.GetServerUUID())
 	if err != nil {
 		return errors.FromError(fmt.Errorf("unable to create state service: %v", err))
 	}
@@ -64,6 +63,9 @@ func (c *immuClient) OpenSession(ctx context.Context, user []byte, pass []byte,
 	c.WithStateService(stateService)
 
 	c.Options.CurrentDatabase = database
+	if resp.GetDatabase() != "" {
+		c.Options.CurrentDatabase = resp.GetDatabase()
+	}
 
 	return nil
 }
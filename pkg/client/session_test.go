@@ -84,6 +84,8 @@ type immuServiceClientMock struct {
 	schema.ImmuServiceClient
 	OpenSessionF func(ctx context.Context, in *schema.OpenSessionRequest, opts ...grpc.CallOption) (*schema.OpenSessionResponse, error)
 	KeepAliveF   func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	HealthF      func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.HealthResponse, error)
+	ServerInfoF  func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.ServerInfoResponse, error)
 }
 
 func (icm *immuServiceClientMock) OpenSession(ctx context.Context, in *schema.OpenSessionRequest, opts ...grpc.CallOption) (*schema.OpenSessionResponse, error) {
@@ -92,3 +94,9 @@ func (icm *immuServiceClientMock) OpenSession(ctx context.Context, in *schema.Op
 func (icm *immuServiceClientMock) KeepAlive(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return icm.KeepAliveF(ctx, in, opts...)
 }
+func (icm *immuServiceClientMock) Health(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.HealthResponse, error) {
+	return icm.HealthF(ctx, in, opts...)
+}
+func (icm *immuServiceClientMock) ServerInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.ServerInfoResponse, error) {
+	return icm.ServerInfoF(ctx, in, opts...)
+}
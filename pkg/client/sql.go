@@ -19,7 +19,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
-	"encoding/binary"
+	"math/big"
 	"time"
 
 	"github.com/codenotary/immudb/pkg/client/errors"
@@ -45,6 +45,31 @@ func (c *immuClient) SQLExec(ctx context.Context, sql string, params map[string]
 	return c.ServiceClient.SQLExec(ctx, &schema.SQLExecRequest{Sql: sql, Params: namedParams})
 }
 
+// SQLExecScript executes all the statements atomically in a single round trip,
+// inserted primary keys can be referenced by following statements as @last_insert_id or @<table>_last_insert_id
+func (c *immuClient) SQLExecScript(ctx context.Context, sql string, params map[string]interface{}) (*schema.SQLExecResult, error) {
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	namedParams, err := schema.EncodeParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ServiceClient.SQLExecScript(ctx, &schema.SQLExecRequest{Sql: sql, Params: namedParams})
+}
+
+// IngestJSON upserts the JSON documents as rows of the table named after the collection,
+// columns are added to the table as new fields appear in the documents
+func (c *immuClient) IngestJSON(ctx context.Context, collection string, documents ...[]byte) (*schema.SQLExecResult, error) {
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	return c.ServiceClient.IngestJSON(ctx, &schema.IngestJSONRequest{Collection: collection, Documents: documents})
+}
+
 func (c *immuClient) SQLQuery(ctx context.Context, sql string, params map[string]interface{}, renewSnapshot bool) (*schema.SQLQueryResult, error) {
 	if !c.IsConnected() {
 		return nil, errors.FromError(ErrNotConnected)
@@ -58,6 +83,20 @@ func (c *immuClient) SQLQuery(ctx context.Context, sql string, params map[string
 	return c.ServiceClient.SQLQuery(ctx, &schema.SQLQueryRequest{Sql: sql, Params: namedParams, ReuseSnapshot: !renewSnapshot})
 }
 
+// SQLQueryWithIsolation runs the query without waiting for indexing when ReadCommittedNoWait isolation is requested
+func (c *immuClient) SQLQueryWithIsolation(ctx context.Context, sql string, params map[string]interface{}, isolation schema.ReadIsolation) (*schema.SQLQueryResult, error) {
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	namedParams, err := schema.EncodeParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ServiceClient.SQLQuery(ctx, &schema.SQLQueryRequest{Sql: sql, Params: namedParams, Isolation: isolation})
+}
+
 func (c *immuClient) ListTables(ctx context.Context) (*schema.SQLQueryResult, error) {
 	if !c.IsConnected() {
 		return nil, errors.FromError(ErrNotConnected)
@@ -72,6 +111,24 @@ func (c *immuClient) DescribeTable(ctx context.Context, tableName string) (*sche
 	return c.ServiceClient.DescribeTable(ctx, &schema.Table{TableName: tableName})
 }
 
+// DescribeSchema returns the tables of the current database along with their columns and indexes
+func (c *immuClient) DescribeSchema(ctx context.Context) (*schema.SQLSchema, error) {
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+	return c.ServiceClient.DescribeSchema(ctx, &emptypb.Empty{})
+}
+
+// SQLListen opens a stream of notifications, one for each committed transaction affecting rows of the table.
+// Notification starts from initialTx or from the next committed transaction when zero
+func (c *immuClient) SQLListen(ctx context.Context, table string, initialTx uint64) (schema.ImmuService_SQLListenClient, error) {
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	return c.ServiceClient.SQLListen(ctx, &schema.SQLListenRequest{Table: table, InitialTx: initialTx})
+}
+
 func (c *immuClient) VerifyRow(ctx context.Context, row *schema.Row, table string, pkVals []*schema.SQLValue) error {
 	if row == nil || len(table) == 0 || len(pkVals) == 0 {
 		return ErrIllegalArguments
@@ -205,7 +262,7 @@ func (c *immuClient) VerifyRow(ctx context.Context, row *schema.Row, table strin
 			targetAlh,
 		)
 		if !verifies {
-			return store.ErrCorruptedData
+			return c.stateRollbackError(state, sourceID, targetID, vEntry.VerifiableTx.DbUUID)
 		}
 	}
 
@@ -214,6 +271,7 @@ func (c *immuClient) VerifyRow(ctx context.Context, row *schema.Row, table strin
 		TxId:      targetID,
 		TxHash:    targetAlh[:],
 		Signature: vEntry.VerifiableTx.Signature,
+		DbUUID:    vEntry.VerifiableTx.DbUUID,
 	}
 
 	if c.serverSigningPubKey != nil {
@@ -273,37 +331,15 @@ func verifyRowAgainst(row *schema.Row, decodedRow map[uint32]*schema.SQLValue, c
 }
 
 func decodeRow(encodedRow []byte, colTypes map[uint32]sql.SQLValueType) (map[uint32]*schema.SQLValue, error) {
-	off := 0
-
-	if len(encodedRow) < off+sql.EncLenLen {
-		return nil, sql.ErrCorruptedData
+	decodedValues, err := sql.DecodeRow(encodedRow, colTypes)
+	if err != nil {
+		return nil, err
 	}
 
-	colsCount := binary.BigEndian.Uint32(encodedRow[off:])
-	off += sql.EncLenLen
-
-	values := make(map[uint32]*schema.SQLValue, colsCount)
-
-	for i := 0; i < int(colsCount); i++ {
-		if len(encodedRow) < off+sql.EncIDLen {
-			return nil, sql.ErrCorruptedData
-		}
-
-		colID := binary.BigEndian.Uint32(encodedRow[off:])
-		off += sql.EncIDLen
-
-		colType, ok := colTypes[colID]
-		if !ok {
-			return nil, sql.ErrCorruptedData
-		}
-
-		val, n, err := sql.DecodeValue(encodedRow[off:], colType)
-		if err != nil {
-			return nil, err
-		}
+	values := make(map[uint32]*schema.SQLValue, len(decodedValues))
 
+	for colID, val := range decodedValues {
 		values[colID] = typedValueToRowValue(val)
-		off += n
 	}
 
 	return values, nil
@@ -331,6 +367,14 @@ func typedValueToRowValue(tv sql.TypedValue) *schema.SQLValue {
 		{
 			return &schema.SQLValue{Value: &schema.SQLValue_Ts{Ts: sql.TimeToInt64(tv.Value().(time.Time))}}
 		}
+	case sql.FloatType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_F{F: tv.Value().(float64)}}
+		}
+	case sql.DecimalType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_D{D: sql.FormatDecimal(tv.Value().(*big.Rat))}}
+		}
 	}
 	return nil
 }
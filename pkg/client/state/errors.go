@@ -0,0 +1,49 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// ErrServerStateRollback is returned when the server presents a state which is not an extension of the
+// one previously verified by the client, either because the database was replaced by a different one
+// or because its history was rolled back or forked. Being a verification failure,
+// errors.Is(err, store.ErrCorruptedData) holds as well
+type ErrServerStateRollback struct {
+	Db           string
+	TrustedState *schema.ImmutableState
+	ServerTxID   uint64
+	ServerDbUUID string
+}
+
+func (e *ErrServerStateRollback) Error() string {
+	if e.TrustedState.DbUUID != "" && e.TrustedState.DbUUID != e.ServerDbUUID {
+		return fmt.Sprintf("server state rollback detected on database '%s': database uuid '%s' does not match the trusted one '%s'",
+			e.Db, e.ServerDbUUID, e.TrustedState.DbUUID)
+	}
+
+	return fmt.Sprintf("server state rollback detected on database '%s': state at tx %d is not an extension of the trusted state at tx %d",
+		e.Db, e.ServerTxID, e.TrustedState.TxId)
+}
+
+func (e *ErrServerStateRollback) Is(target error) bool {
+	return target == store.ErrCorruptedData
+}
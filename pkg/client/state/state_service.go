@@ -17,6 +17,7 @@ limitations under the License.
 package state
 
 import (
+	"bytes"
 	"context"
 	"sync"
 
@@ -107,13 +108,46 @@ func (r *stateService) GetState(ctx context.Context, db string) (*schema.Immutab
 	return state, nil
 }
 
+// SetState replaces the trusted state of the database, failing with ErrServerStateRollback when the
+// new state belongs to a different database or it's behind the trusted one
 func (r *stateService) SetState(db string, state *schema.ImmutableState) error {
 	r.Lock()
 	defer r.Unlock()
 
+	trustedState, err := r.cache.Get(r.serverUUID, db)
+	if err != nil && err != cache.ErrPrevStateNotFound {
+		return err
+	}
+
+	if err == nil {
+		err = checkStateExtension(db, trustedState, state)
+		if err != nil {
+			return err
+		}
+	}
+
 	return r.cache.Set(r.serverUUID, db, state)
 }
 
+// checkStateExtension makes the checks which do not require a proof: a state can't be extended
+// by a state of another database nor by an older state or a different state at the same tx
+func checkStateExtension(db string, trustedState, state *schema.ImmutableState) error {
+	rollback := trustedState.DbUUID != "" && trustedState.DbUUID != state.DbUUID ||
+		state.TxId < trustedState.TxId ||
+		state.TxId == trustedState.TxId && !bytes.Equal(state.TxHash, trustedState.TxHash)
+
+	if rollback {
+		return &ErrServerStateRollback{
+			Db:           db,
+			TrustedState: trustedState,
+			ServerTxID:   state.TxId,
+			ServerDbUUID: state.DbUUID,
+		}
+	}
+
+	return nil
+}
+
 func (r *stateService) CacheLock() error {
 	return r.cache.Lock(r.serverUUID)
 }
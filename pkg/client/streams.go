@@ -74,6 +74,54 @@ func (c *immuClient) StreamExecAll(ctx context.Context, req *stream.ExecAllReque
 	return txhdr, errors.FromError(err)
 }
 
+// StreamSQLBlob returns a reader over the value of a BLOB column of the row addressed by its primary key.
+// Contents are received from the server as they are read, closing the reader cancels the stream
+func (c *immuClient) StreamSQLBlob(ctx context.Context, req *schema.SQLBlobRequest) (io.ReadCloser, error) {
+	if !c.IsConnected() {
+		return nil, errors.FromError(ErrNotConnected)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	s, err := c.ServiceClient.StreamSQLBlob(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, errors.FromError(err)
+	}
+
+	return &sqlBlobReader{s: s, cancel: cancel}, nil
+}
+
+type sqlBlobReader struct {
+	s      schema.ImmuService_StreamSQLBlobClient
+	cancel context.CancelFunc
+	buf    []byte
+}
+
+func (r *sqlBlobReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.s.Recv()
+		if err == io.EOF {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, errors.FromError(err)
+		}
+
+		r.buf = chunk.Content
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
+
+func (r *sqlBlobReader) Close() error {
+	r.cancel()
+	return nil
+}
+
 // StreamSet set an array of *stream.KeyValue in immudb streaming contents on a fixed size channel
 func (c *immuClient) _streamSet(ctx context.Context, kvs []*stream.KeyValue) (*schema.TxHeader, error) {
 	s, err := c.streamSet(ctx)
@@ -212,13 +260,13 @@ func (c *immuClient) _streamVerifiedSet(ctx context.Context, kvs []*stream.KeyVa
 	var verifies bool
 
 	for i, kv := range stdKVs {
-		inclusionProof, err := tx.Proof(database.EncodeKey(kv.Key))
+		inclusionProof, err := tx.Proof(database.EncodeKey(c.namespacedKey(kv.Key)))
 		if err != nil {
 			return nil, err
 		}
 
 		md := tx.Entries()[i].Metadata()
-		e := database.EncodeEntrySpec(kv.Key, md, kv.Value)
+		e := database.EncodeEntrySpec(c.namespacedKey(kv.Key), md, kv.Value)
 
 		verifies = store.VerifyInclusion(inclusionProof, entrySpecDigest(e), tx.Header().Eh)
 		if !verifies {
@@ -248,7 +296,7 @@ func (c *immuClient) _streamVerifiedSet(ctx context.Context, kvs []*stream.KeyVa
 		)
 
 		if !verifies {
-			return nil, store.ErrCorruptedData
+			return nil, c.stateRollbackError(state, sourceID, targetID, verifiableTx.DbUUID)
 		}
 	}
 
@@ -257,6 +305,7 @@ func (c *immuClient) _streamVerifiedSet(ctx context.Context, kvs []*stream.KeyVa
 		TxId:      targetID,
 		TxHash:    targetAlh[:],
 		Signature: verifiableTx.Signature,
+		DbUUID:    verifiableTx.DbUUID,
 	}
 
 	if c.serverSigningPubKey != nil {
@@ -326,11 +375,11 @@ func (c *immuClient) _streamVerifiedGet(ctx context.Context, req *schema.Verifia
 
 	if vEntry.Entry.ReferencedBy == nil {
 		vTx = vEntry.Entry.Tx
-		e = database.EncodeEntrySpec(req.KeyRequest.Key, schema.KVMetadataFromProto(vEntry.Entry.Metadata), vEntry.Entry.Value)
+		e = database.EncodeEntrySpec(c.namespacedKey(req.KeyRequest.Key), schema.KVMetadataFromProto(vEntry.Entry.Metadata), vEntry.Entry.Value)
 	} else {
 		ref := vEntry.Entry.ReferencedBy
 		vTx = ref.Tx
-		e = database.EncodeReference(ref.Key, schema.KVMetadataFromProto(ref.Metadata), vEntry.Entry.Key, ref.AtTx)
+		e = database.EncodeReference(c.namespacedKey(ref.Key), schema.KVMetadataFromProto(ref.Metadata), c.namespacedKey(vEntry.Entry.Key), ref.AtTx)
 	}
 
 	if state.TxId <= vTx {
@@ -361,7 +410,7 @@ func (c *immuClient) _streamVerifiedGet(ctx context.Context, req *schema.Verifia
 			targetAlh,
 		)
 		if !verifies {
-			return nil, store.ErrCorruptedData
+			return nil, c.stateRollbackError(state, sourceID, targetID, vEntry.VerifiableTx.DbUUID)
 		}
 	}
 
@@ -370,6 +419,7 @@ func (c *immuClient) _streamVerifiedGet(ctx context.Context, req *schema.Verifia
 		TxId:      targetID,
 		TxHash:    targetAlh[:],
 		Signature: vEntry.VerifiableTx.Signature,
+		DbUUID:    vEntry.VerifiableTx.DbUUID,
 	}
 
 	if c.serverSigningPubKey != nil {
@@ -48,9 +48,22 @@ func (c *tx) Rollback(ctx context.Context) error {
 }
 
 func (c *immuClient) NewTx(ctx context.Context) (Tx, error) {
-	r, err := c.ServiceClient.NewTx(ctx, &schema.NewTxRequest{
+	return c.newTx(ctx, &schema.NewTxRequest{
 		Mode: schema.TxMode_ReadWrite,
 	})
+}
+
+// NewReadOnlyTx creates a transaction whose queries are served from the same snapshot,
+// taken without waiting for indexing when ReadCommittedNoWait isolation is requested
+func (c *immuClient) NewReadOnlyTx(ctx context.Context, isolation schema.ReadIsolation) (Tx, error) {
+	return c.newTx(ctx, &schema.NewTxRequest{
+		Mode:      schema.TxMode_ReadOnly,
+		Isolation: isolation,
+	})
+}
+
+func (c *immuClient) newTx(ctx context.Context, req *schema.NewTxRequest) (Tx, error) {
+	r, err := c.ServiceClient.NewTx(ctx, req)
 	if err != nil {
 		return nil, errors.FromError(err)
 	}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package typed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrNotAProtoMessage = errors.New("value is not a protobuf message")
+
+// Codec encodes the typed values as the values stored in the database
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(b []byte, v interface{}) error
+}
+
+// NewCodec returns a codec built on marshalling functions with the signatures of the ones of encoding/json,
+// other encodings are plugged in this way, e.g. NewCodec(msgpack.Marshal, msgpack.Unmarshal)
+func NewCodec(marshal func(v interface{}) ([]byte, error), unmarshal func(b []byte, v interface{}) error) Codec {
+	return &funcCodec{marshal: marshal, unmarshal: unmarshal}
+}
+
+type funcCodec struct {
+	marshal   func(v interface{}) ([]byte, error)
+	unmarshal func(b []byte, v interface{}) error
+}
+
+func (c *funcCodec) Marshal(v interface{}) ([]byte, error) {
+	return c.marshal(v)
+}
+
+func (c *funcCodec) Unmarshal(b []byte, v interface{}) error {
+	return c.unmarshal(b, v)
+}
+
+// JSON stores the values encoded as JSON documents
+var JSON Codec = NewCodec(json.Marshal, json.Unmarshal)
+
+// Protobuf stores the values encoded in the protobuf wire format, values must be protobuf messages
+var Protobuf Codec = NewCodec(marshalProto, unmarshalProto)
+
+func marshalProto(v interface{}) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNotAProtoMessage, v)
+	}
+
+	return proto.Marshal(m)
+}
+
+func unmarshalProto(b []byte, v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrNotAProtoMessage, v)
+	}
+
+	return proto.Unmarshal(b, m)
+}
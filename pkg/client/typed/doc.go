@@ -0,0 +1,23 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package typed stores typed values as the values of keys, encoded by pluggable codecs, e.g.
+//
+//	hdr, err := typed.Set(ctx, client, typed.JSON, []byte("user:1"), User{Name: "Alice"})
+//	user, entry, err := typed.VerifiedGet[User](ctx, client, typed.JSON, []byte("user:1"))
+//
+// The helpers are built with Go 1.18 or later, as they rely on type parameters.
+package typed
@@ -0,0 +1,106 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package typed
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// Client reads and writes the encoded values, it's implemented by client.ImmuClient
+type Client interface {
+	Set(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error)
+	VerifiedSet(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error)
+	Get(ctx context.Context, key []byte) (*schema.Entry, error)
+	GetAt(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error)
+	VerifiedGet(ctx context.Context, key []byte) (*schema.Entry, error)
+}
+
+// Set stores the value encoded by the codec as the value of the key
+func Set[T any](ctx context.Context, c Client, codec Codec, key []byte, value T) (*schema.TxHeader, error) {
+	b, err := codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Set(ctx, key, b)
+}
+
+// VerifiedSet stores the value as Set does, and verifies the inclusion of the transaction it's written in
+func VerifiedSet[T any](ctx context.Context, c Client, codec Codec, key []byte, value T) (*schema.TxHeader, error) {
+	b, err := codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.VerifiedSet(ctx, key, b)
+}
+
+// Get returns the current value of the key decoded by the codec, together with the entry it was read from
+func Get[T any](ctx context.Context, c Client, codec Codec, key []byte) (T, *schema.Entry, error) {
+	entry, err := c.Get(ctx, key)
+	return decodeEntry[T](codec, entry, err)
+}
+
+// GetAt returns the value of the key as it was set in the transaction tx
+func GetAt[T any](ctx context.Context, c Client, codec Codec, key []byte, tx uint64) (T, *schema.Entry, error) {
+	entry, err := c.GetAt(ctx, key, tx)
+	return decodeEntry[T](codec, entry, err)
+}
+
+// VerifiedGet returns the value as Get does, once the entry it was read from is verified
+func VerifiedGet[T any](ctx context.Context, c Client, codec Codec, key []byte) (T, *schema.Entry, error) {
+	entry, err := c.VerifiedGet(ctx, key)
+	return decodeEntry[T](codec, entry, err)
+}
+
+func decodeEntry[T any](codec Codec, entry *schema.Entry, err error) (T, *schema.Entry, error) {
+	var zero T
+
+	if err != nil {
+		return zero, nil, err
+	}
+
+	v, err := decode[T](codec, entry.Value)
+	if err != nil {
+		return zero, nil, err
+	}
+
+	return v, entry, nil
+}
+
+// decode unmarshals into the value T points to when it's a pointer type, e.g. a protobuf message,
+// and into a new T otherwise
+func decode[T any](codec Codec, b []byte) (T, error) {
+	var v T
+
+	t := reflect.TypeOf(&v).Elem()
+
+	if t.Kind() == reflect.Ptr {
+		v = reflect.New(t.Elem()).Interface().(T)
+
+		err := codec.Unmarshal(b, v)
+		return v, err
+	}
+
+	err := codec.Unmarshal(b, &v)
+	return v, err
+}
@@ -0,0 +1,118 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package typed
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/internal/clienttest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+type user struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+func TestTypedKV(t *testing.T) {
+	c, ctx := clienttest.SetupClient(t)
+
+	t.Run("values should be encoded as JSON", func(t *testing.T) {
+		alice := user{Name: "Alice", Roles: []string{"admin"}}
+
+		hdr, err := Set(ctx, c, JSON, []byte("user:1"), alice)
+		require.NoError(t, err)
+
+		v, entry, err := Get[user](ctx, c, JSON, []byte("user:1"))
+		require.NoError(t, err)
+		require.Equal(t, alice, v)
+		require.Equal(t, hdr.Id, entry.Tx)
+		require.JSONEq(t, `{"name":"Alice","roles":["admin"]}`, string(entry.Value))
+
+		_, err = VerifiedSet(ctx, c, JSON, []byte("user:1"), &user{Name: "Alice"})
+		require.NoError(t, err)
+
+		p, _, err := VerifiedGet[*user](ctx, c, JSON, []byte("user:1"))
+		require.NoError(t, err)
+		require.Equal(t, &user{Name: "Alice"}, p)
+
+		v, _, err = GetAt[user](ctx, c, JSON, []byte("user:1"), hdr.Id)
+		require.NoError(t, err)
+		require.Equal(t, alice, v)
+	})
+
+	t.Run("values should be encoded as protobuf messages", func(t *testing.T) {
+		kv := &schema.KeyValue{Key: []byte("key"), Value: []byte("value")}
+
+		_, err := VerifiedSet(ctx, c, Protobuf, []byte("kv:1"), kv)
+		require.NoError(t, err)
+
+		v, entry, err := VerifiedGet[*schema.KeyValue](ctx, c, Protobuf, []byte("kv:1"))
+		require.NoError(t, err)
+		require.True(t, proto.Equal(kv, v))
+
+		b, err := proto.Marshal(kv)
+		require.NoError(t, err)
+		require.Equal(t, b, entry.Value)
+
+		_, err = Set(ctx, c, Protobuf, []byte("kv:2"), "not a message")
+		require.ErrorIs(t, err, ErrNotAProtoMessage)
+
+		_, _, err = Get[string](ctx, c, Protobuf, []byte("kv:1"))
+		require.ErrorIs(t, err, ErrNotAProtoMessage)
+	})
+
+	t.Run("codecs should be pluggable", func(t *testing.T) {
+		decimal := NewCodec(
+			func(v interface{}) ([]byte, error) {
+				return []byte(strconv.Itoa(*v.(*int))), nil
+			},
+			func(b []byte, v interface{}) error {
+				n, err := strconv.Atoi(string(b))
+				*v.(*int) = n
+				return err
+			},
+		)
+
+		n := 42
+
+		_, err := Set(ctx, c, decimal, []byte("counter"), &n)
+		require.NoError(t, err)
+
+		v, entry, err := Get[int](ctx, c, decimal, []byte("counter"))
+		require.NoError(t, err)
+		require.Equal(t, 42, v)
+		require.Equal(t, []byte("42"), entry.Value)
+	})
+
+	t.Run("decoding errors should be returned", func(t *testing.T) {
+		_, err := c.Set(ctx, []byte("invalid"), []byte("{"))
+		require.NoError(t, err)
+
+		_, entry, err := Get[user](ctx, c, JSON, []byte("invalid"))
+		require.Error(t, err)
+		require.Nil(t, entry)
+
+		_, _, err = Get[user](ctx, c, JSON, []byte("missing"))
+		require.Error(t, err)
+	})
+}
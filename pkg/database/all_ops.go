@@ -35,11 +35,17 @@ func (d *db) ExecAll(req *schema.ExecAllRequest) (*schema.TxHeader, error) {
 		return nil, err
 	}
 
+	txmd, err := schema.TxMetadataFromLabels(req.TxLabels)
+	if err != nil {
+		return nil, err
+	}
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	if d.isReplica() {
-		return nil, ErrIsReplica
+	err = d.checkWritable()
+	if err != nil {
+		return nil, err
 	}
 
 	if !req.NoWait {
@@ -50,7 +56,16 @@ func (d *db) ExecAll(req *schema.ExecAllRequest) (*schema.TxHeader, error) {
 		}
 	}
 
-	callback := func(txID uint64, index store.KeyIndex) ([]*store.EntrySpec, error) {
+	hdr, err := d.st.CommitWithMetadata(txmd, d.execAllCallback(req), !req.NoWait)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.TxHeaderToProto(hdr), nil
+}
+
+func (d *db) execAllCallback(req *schema.ExecAllRequest) func(txID uint64, index store.KeyIndex) ([]*store.EntrySpec, error) {
+	return func(txID uint64, index store.KeyIndex) ([]*store.EntrySpec, error) {
 		entries := make([]*store.EntrySpec, len(req.Operations))
 
 		// In order to:
@@ -183,11 +198,93 @@ func (d *db) ExecAll(req *schema.ExecAllRequest) (*schema.TxHeader, error) {
 
 		return entries, nil
 	}
+}
+
+// PreparedTx is a transaction validated against the current state of a database and ready to be committed.
+// The database remains locked for writing until Release is called, letting a coordinator commit
+// transactions in several databases with no other write getting in between.
+type PreparedTx interface {
+	Commit() (*schema.TxHeader, error)
+	Release()
+}
+
+type preparedTx struct {
+	db       *db
+	req      *schema.ExecAllRequest
+	txmd     *store.TxMetadata
+	callback func(txID uint64, index store.KeyIndex) ([]*store.EntrySpec, error)
+
+	committed bool
+	released  bool
+}
+
+// PrepareExecAll validates the operations as ExecAll would do it
+// and locks the database until the returned transaction is released
+func (d *db) PrepareExecAll(req *schema.ExecAllRequest) (PreparedTx, error) {
+	if req == nil {
+		return nil, store.ErrIllegalArguments
+	}
 
-	hdr, err := d.st.CommitWith(callback, !req.NoWait)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	txmd, err := schema.TxMetadataFromLabels(req.TxLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.Lock()
+
+	err = d.checkWritable()
+	if err != nil {
+		d.mutex.Unlock()
+		return nil, err
+	}
+
+	lastTxID, _ := d.st.Alh()
+
+	if !req.NoWait {
+		err := d.st.WaitForIndexingUpto(lastTxID, nil)
+		if err != nil {
+			d.mutex.Unlock()
+			return nil, err
+		}
+	}
+
+	callback := d.execAllCallback(req)
+
+	// no other write may be committed while the database is locked,
+	// thus the outcome of the validation holds until the transaction is committed
+	_, err = callback(lastTxID+1, d.st)
+	if err != nil {
+		d.mutex.Unlock()
+		return nil, err
+	}
+
+	return &preparedTx{db: d, req: req, txmd: txmd, callback: callback}, nil
+}
+
+func (tx *preparedTx) Commit() (*schema.TxHeader, error) {
+	if tx.committed || tx.released {
+		return nil, ErrIllegalState
+	}
+
+	tx.committed = true
+
+	hdr, err := tx.db.st.CommitWithMetadata(tx.txmd, tx.callback, !tx.req.NoWait)
 	if err != nil {
 		return nil, err
 	}
 
 	return schema.TxHeaderToProto(hdr), nil
 }
+
+func (tx *preparedTx) Release() {
+	if tx.released {
+		return
+	}
+
+	tx.released = true
+	tx.db.mutex.Unlock()
+}
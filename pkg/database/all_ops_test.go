@@ -467,6 +467,67 @@ func TestExecAllOpsZAddKeyNotFound(t *testing.T) {
 	require.Equal(t, store.ErrTxNotFound, err)
 }
 
+func TestPrepareExecAll(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.PrepareExecAll(nil)
+	require.Equal(t, store.ErrIllegalArguments, err)
+
+	_, err = db.PrepareExecAll(&schema.ExecAllRequest{
+		Operations: []*schema.Op{
+			{
+				Operation: &schema.Op_Ref{
+					Ref: &schema.ReferenceRequest{Key: []byte("ref"), ReferencedKey: []byte("missing")},
+				},
+			},
+		},
+	})
+	require.Equal(t, store.ErrKeyNotFound, err)
+
+	// database is not left locked after a failed preparation
+	txhdr, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	ptx, err := db.PrepareExecAll(&schema.ExecAllRequest{
+		Operations: []*schema.Op{
+			{Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte("key2"), Value: []byte("value2")}}},
+			{Operation: &schema.Op_Ref{Ref: &schema.ReferenceRequest{Key: []byte("ref"), ReferencedKey: []byte("key1")}}},
+		},
+	})
+	require.NoError(t, err)
+
+	hdr, err := ptx.Commit()
+	require.NoError(t, err)
+	require.Equal(t, txhdr.Id+1, hdr.Id)
+	require.Equal(t, int32(2), hdr.Nentries)
+
+	_, err = ptx.Commit()
+	require.Equal(t, ErrIllegalState, err)
+
+	ptx.Release()
+	ptx.Release()
+
+	entry, err := db.Get(&schema.KeyRequest{Key: []byte("ref")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+
+	ptx, err = db.PrepareExecAll(&schema.ExecAllRequest{
+		Operations: []*schema.Op{
+			{Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte("key3"), Value: []byte("value3")}}},
+		},
+	})
+	require.NoError(t, err)
+
+	ptx.Release()
+
+	_, err = ptx.Commit()
+	require.Equal(t, ErrIllegalState, err)
+
+	_, err = db.Get(&schema.KeyRequest{Key: []byte("key3")})
+	require.Equal(t, store.ErrKeyNotFound, err)
+}
+
 func TestExecAllOpsNilElementFound(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
@@ -17,6 +17,7 @@ limitations under the License.
 package database
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/binary"
 	"errors"
@@ -24,12 +25,16 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/codenotary/immudb/embedded/cache"
+	elogger "github.com/codenotary/immudb/embedded/logger"
 	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/embedded/store"
 
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/rs/xid"
 )
 
 const MaxKeyResolutionLimit = 1
@@ -37,12 +42,17 @@ const MaxKeyScanLimit = 1000
 
 const dbInstanceName = "dbinstance"
 
+// DatabaseUUIDLabel is the label of the first tx of a database holding its uuid,
+// being part of the history of the database it's shared by all of its replicas
+const DatabaseUUIDLabel = "immudb.db.uuid"
+
 var ErrMaxKeyResolutionLimitReached = errors.New("max key resolution limit reached. It may be due to cyclic references")
 var ErrMaxKeyScanLimitExceeded = errors.New("max key scan limit exceeded")
 var ErrIllegalArguments = store.ErrIllegalArguments
 var ErrIllegalState = store.ErrIllegalState
 var ErrIsReplica = errors.New("database is read-only because it's a replica")
 var ErrNotReplica = errors.New("database is NOT a replica")
+var ErrIsReadOnly = errors.New("database is read-only because of insufficient disk space")
 
 type DB interface {
 	GetName() string
@@ -53,6 +63,9 @@ type DB interface {
 	AsReplica(asReplica bool)
 	IsReplica() bool
 
+	AsReadOnly(readOnly bool)
+	IsReadOnly() bool
+
 	UseTimeFunc(timeFunc store.TimeFunc) error
 
 	// State
@@ -66,8 +79,10 @@ type DB interface {
 	Get(req *schema.KeyRequest) (*schema.Entry, error)
 	VerifiableGet(req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error)
 	GetAll(req *schema.KeyListRequest) (*schema.Entries, error)
+	VerifiableGetAll(req *schema.VerifiableGetAllRequest) (*schema.VerifiableEntries, error)
 
 	Delete(req *schema.DeleteKeysRequest) (*schema.TxHeader, error)
+	Forget(req *schema.ForgetRequest) error
 
 	SetReference(req *schema.ReferenceRequest) (*schema.TxHeader, error)
 	VerifiableSetReference(req *schema.VerifiableReferenceRequest) (*schema.VerifiableTx, error)
@@ -76,7 +91,10 @@ type DB interface {
 
 	History(req *schema.HistoryRequest) (*schema.Entries, error)
 
+	ComputeDiff(req *schema.DiffRequest, cancellation <-chan struct{}, send func(*schema.DiffEntry) error) error
+
 	ExecAll(operations *schema.ExecAllRequest) (*schema.TxHeader, error)
+	PrepareExecAll(operations *schema.ExecAllRequest) (PreparedTx, error)
 
 	Count(prefix *schema.KeyPrefix) (*schema.EntryCount, error)
 	CountAll() (*schema.EntryCount, error)
@@ -84,22 +102,35 @@ type DB interface {
 	ZAdd(req *schema.ZAddRequest) (*schema.TxHeader, error)
 	VerifiableZAdd(req *schema.VerifiableZAddRequest) (*schema.VerifiableTx, error)
 	ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error)
+	VerifiableZScan(req *schema.VerifiableZScanRequest) (*schema.VerifiableZEntries, error)
 
 	// SQL-related
 	SQLExec(req *schema.SQLExecRequest, tx *sql.SQLTx) (ntx *sql.SQLTx, ctxs []*sql.SQLTx, err error)
 	SQLExecPrepared(stmts []sql.SQLStmt, namedParams []*schema.NamedParam, tx *sql.SQLTx) (ntx *sql.SQLTx, ctxs []*sql.SQLTx, err error)
+	SQLExecScript(req *schema.SQLExecRequest) (*sql.SQLTx, error)
+	InferJSONIngest(req *schema.IngestJSONRequest) (*schema.SQLExecRequest, error)
 
 	InferParameters(sql string, tx *sql.SQLTx) (map[string]sql.SQLValueType, error)
 	InferParametersPrepared(stmt sql.SQLStmt, tx *sql.SQLTx) (map[string]sql.SQLValueType, error)
 
 	SQLQuery(req *schema.SQLQueryRequest, tx *sql.SQLTx) (*schema.SQLQueryResult, error)
+	SQLQueryWithCancellation(req *schema.SQLQueryRequest, tx *sql.SQLTx, cancellation <-chan struct{}) (*schema.SQLQueryResult, error)
 	SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedParam, tx *sql.SQLTx) (*schema.SQLQueryResult, error)
 	SQLQueryRowReader(stmt *sql.SelectStmt, tx *sql.SQLTx) (sql.RowReader, error)
+	NewSQLTx(waitForIndexing bool) (*sql.SQLTx, error)
 
 	VerifiableSQLGet(req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error)
 
+	SQLListen(req *schema.SQLListenRequest, cancellation <-chan struct{}, notify func(*schema.SQLNotification) error) error
+
+	SQLBlob(req *schema.SQLBlobRequest, tx *sql.SQLTx) ([]byte, error)
+
 	ListTables(tx *sql.SQLTx) (*schema.SQLQueryResult, error)
 	DescribeTable(table string, tx *sql.SQLTx) (*schema.SQLQueryResult, error)
+	DescribeSchema(tx *sql.SQLTx) (*schema.SQLSchema, error)
+	IndexSuggestions() (*schema.IndexSuggestionList, error)
+
+	SQLNamespace(prefix []byte) (DB, error)
 
 	// Transactional layer
 	WaitForTx(txID uint64, cancellation <-chan struct{}) error
@@ -113,6 +144,10 @@ type DB interface {
 
 	// Maintenance
 	CompactIndex() error
+	IndexingProgress() (*schema.IndexingProgressResponse, error)
+	CopyStateAt(txID uint64, dst DB) error
+	VerifyTxs(fromTxID, toTxID uint64) error
+	Truncate(before time.Time, dryRun bool) (*TruncationReport, error)
 
 	Close() error
 }
@@ -125,12 +160,29 @@ type db struct {
 	sqlInitCancel chan (struct{})
 	sqlInit       sync.WaitGroup
 
-	mutex sync.RWMutex
+	// shared with the SQL namespaces of the database
+	mutex *sync.RWMutex
 
 	Logger  logger.Logger
 	options *Options
 
+	keys *keyStore
+
+	proofCache *cache.LRUCache
+
 	name string
+
+	// writes are rejected while the disk the database is stored in runs out of space
+	readOnly bool
+
+	uuidMutex    sync.Mutex
+	uuid         string
+	uuidResolved bool
+
+	sqlNamespacesMutex sync.Mutex
+	sqlNamespaces      map[string]*sql.Engine
+	// set in the views returned by SQLNamespace
+	sqlNamespace bool
 }
 
 // OpenDB Opens an existing Database from disk
@@ -140,13 +192,24 @@ func OpenDB(op *Options, log logger.Logger) (DB, error) {
 	var err error
 
 	dbi := &db{
-		Logger:  log,
-		options: op,
-		name:    op.dbName,
+		mutex:         &sync.RWMutex{},
+		Logger:        log,
+		options:       op,
+		name:          op.dbName,
+		sqlNamespaces: make(map[string]*sql.Engine),
 	}
 
 	dbDir := dbi.path()
 
+	dbi.keys = newKeyStore(filepath.Join(dbDir, keyStoreDir))
+
+	if op.proofCacheSize > 0 {
+		dbi.proofCache, err = cache.NewLRUCache(op.proofCacheSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	_, dbErr := os.Stat(dbDir)
 	if os.IsNotExist(dbErr) {
 		return nil, fmt.Errorf("missing database directories: %s", dbDir)
@@ -157,7 +220,7 @@ func OpenDB(op *Options, log logger.Logger) (DB, error) {
 		return nil, logErr(dbi.Logger, "Unable to open database: %s", err)
 	}
 
-	dbi.sqlEngine, err = sql.NewEngine(dbi.st, sql.DefaultOptions().WithPrefix([]byte{SQLPrefix}))
+	dbi.sqlEngine, err = sql.NewEngine(dbi.st, sql.DefaultOptions().WithPrefix([]byte{SQLPrefix}).WithLogger(elogger.NewPrintfLogger(log)))
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +281,7 @@ func (d *db) initSQLEngine() error {
 	}
 
 	if err == sql.ErrDatabaseDoesNotExist {
-		_, _, err = d.sqlEngine.ExecPreparedStmts([]sql.SQLStmt{&sql.CreateDatabaseStmt{DB: dbInstanceName}}, nil, nil)
+		_, _, err = d.sqlEngine.ExecPreparedStmts(context.Background(), []sql.SQLStmt{&sql.CreateDatabaseStmt{DB: dbInstanceName}}, nil, nil)
 		if err != nil {
 			return logErr(d.Logger, "Unable to open store: %s", err)
 		}
@@ -239,13 +302,24 @@ func NewDB(op *Options, log logger.Logger) (DB, error) {
 	var err error
 
 	dbi := &db{
-		Logger:  log,
-		options: op,
-		name:    op.dbName,
+		mutex:         &sync.RWMutex{},
+		Logger:        log,
+		options:       op,
+		name:          op.dbName,
+		sqlNamespaces: make(map[string]*sql.Engine),
 	}
 
 	dbDir := filepath.Join(op.GetDBRootPath(), op.GetDBName())
 
+	dbi.keys = newKeyStore(filepath.Join(dbDir, keyStoreDir))
+
+	if op.proofCacheSize > 0 {
+		dbi.proofCache, err = cache.NewLRUCache(op.proofCacheSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if _, dbErr := os.Stat(dbDir); dbErr == nil {
 		return nil, fmt.Errorf("Database directories already exist: %s", dbDir)
 	}
@@ -259,13 +333,20 @@ func NewDB(op *Options, log logger.Logger) (DB, error) {
 		return nil, logErr(dbi.Logger, "Unable to open database: %s", err)
 	}
 
-	dbi.sqlEngine, err = sql.NewEngine(dbi.st, sql.DefaultOptions().WithPrefix([]byte{SQLPrefix}))
+	dbi.sqlEngine, err = sql.NewEngine(dbi.st, sql.DefaultOptions().WithPrefix([]byte{SQLPrefix}).WithLogger(elogger.NewPrintfLogger(log)))
 	if err != nil {
 		return nil, logErr(dbi.Logger, "Unable to open database: %s", err)
 	}
 
 	if !op.replica {
-		_, _, err = dbi.sqlEngine.ExecPreparedStmts([]sql.SQLStmt{&sql.CreateDatabaseStmt{DB: dbInstanceName}}, nil, nil)
+		txmd := store.NewTxMetadata()
+
+		err = txmd.WithLabel(DatabaseUUIDLabel, xid.New().String())
+		if err != nil {
+			return nil, logErr(dbi.Logger, "Unable to open database: %s", err)
+		}
+
+		_, _, err = dbi.sqlEngine.ExecPreparedStmtsWithMetadata(context.Background(), []sql.SQLStmt{&sql.CreateDatabaseStmt{DB: dbInstanceName}}, nil, txmd, nil)
 		if err != nil {
 			return nil, logErr(dbi.Logger, "Unable to open database: %s", err)
 		}
@@ -285,6 +366,19 @@ func (d *db) isReplica() bool {
 	return d.options.replica
 }
 
+// checkWritable returns the reason why writes are rejected, if they are
+func (d *db) checkWritable() error {
+	if d.isReplica() {
+		return ErrIsReplica
+	}
+
+	if d.readOnly {
+		return ErrIsReadOnly
+	}
+
+	return nil
+}
+
 // UseTimeFunc ...
 func (d *db) UseTimeFunc(timeFunc store.TimeFunc) error {
 	return d.st.UseTimeFunc(timeFunc)
@@ -295,13 +389,43 @@ func (d *db) CompactIndex() error {
 	return d.st.CompactIndex()
 }
 
+// IndexingProgress returns how far the indexing of the database is,
+// and the checkpoint it would be resumed from after a restart
+func (d *db) IndexingProgress() (*schema.IndexingProgressResponse, error) {
+	progress, err := d.st.IndexingProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpointedAt int64
+	if !progress.CheckpointedAt.IsZero() {
+		checkpointedAt = progress.CheckpointedAt.Unix()
+	}
+
+	return &schema.IndexingProgressResponse{
+		CommittedTxId:   progress.CommittedTxID,
+		IndexedTxId:     progress.IndexedTxID,
+		CheckpointTxId:  progress.CheckpointTxID,
+		CheckpointedAt:  checkpointedAt,
+		ResumedFromTxId: progress.ResumedFromTxID,
+	}, nil
+}
+
+// VerifyTxs reads back the transactions in the range [fromTxID, toTxID] checking they are intact in the
+// underlying storage, i.e. both transaction hashes and values match and every transaction is linked to
+// the previous one
+func (d *db) VerifyTxs(fromTxID, toTxID uint64) error {
+	return d.st.VerifyTxs(fromTxID, toTxID)
+}
+
 // Set ...
 func (d *db) Set(req *schema.SetRequest) (*schema.TxHeader, error) {
 	d.mutex.RLock()
 	defer d.mutex.RUnlock()
 
-	if d.isReplica() {
-		return nil, ErrIsReplica
+	err := d.checkWritable()
+	if err != nil {
+		return nil, err
 	}
 
 	return d.set(req)
@@ -312,12 +436,19 @@ func (d *db) set(req *schema.SetRequest) (*schema.TxHeader, error) {
 		return nil, ErrIllegalArguments
 	}
 
+	txmd, err := schema.TxMetadataFromLabels(req.TxLabels)
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := d.st.NewWriteOnlyTx()
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Cancel()
 
+	tx.WithMetadata(txmd)
+
 	keys := make(map[[sha256.Size]byte]struct{}, len(req.KVs))
 
 	for _, kv := range req.KVs {
@@ -334,6 +465,15 @@ func (d *db) set(req *schema.SetRequest) (*schema.TxHeader, error) {
 
 		e := EncodeEntrySpec(kv.Key, schema.KVMetadataFromProto(kv.Metadata), kv.Value)
 
+		if req.Encrypt {
+			envelope, err := d.keys.encrypt(kv.Key, kv.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			e = EncodeEncryptedEntrySpec(kv.Key, e.Metadata, envelope)
+		}
+
 		err = tx.Set(e.Key, e.Metadata, e.Value)
 		if err != nil {
 			return nil, err
@@ -449,12 +589,45 @@ func (d *db) getAt(key []byte, atTx uint64, resolved int, index store.KeyIndex,
 		return entry, nil
 	}
 
-	return &schema.Entry{
+	entry = &schema.Entry{
 		Tx:       txID,
 		Key:      TrimPrefix(key),
 		Metadata: schema.KVMetadataToProto(md),
 		Value:    TrimPrefix(val),
-	}, err
+	}
+
+	if val[0] == EncryptedValuePrefix {
+		err = d.decryptEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
+// decryptEntry replaces the envelope of an encrypted entry with its plain value.
+// Forgotten entries are kept with an empty value, so they can still be verified and scanned.
+// Data keys are not replicated, so replicas fail explicitly instead of reporting values as forgotten.
+func (d *db) decryptEntry(entry *schema.Entry) error {
+	entry.EncryptedValue = entry.Value
+
+	value, err := d.keys.decrypt(entry.Key, entry.EncryptedValue)
+	if err == ErrForgottenEntry {
+		entry.Value = nil
+		entry.Forgotten = true
+		return nil
+	}
+	if err == ErrEncryptionKeyNotFound && d.isReplica() {
+		return ErrEncryptionKeyNotReplicated
+	}
+	if err != nil {
+		return err
+	}
+
+	entry.Value = value
+
+	return nil
 }
 
 func (d *db) readMetadataAndValue(key []byte, atTx uint64, tx *store.Tx) (*store.KVMetadata, []byte, error) {
@@ -480,9 +653,58 @@ func (d *db) readMetadataAndValue(key []byte, atTx uint64, tx *store.Tx) (*store
 func (d *db) CurrentState() (*schema.ImmutableState, error) {
 	lastTxID, lastTxAlh := d.st.Alh()
 
+	dbUUID, err := d.dbUUID()
+	if err != nil {
+		return nil, err
+	}
+
 	return &schema.ImmutableState{
 		TxId:   lastTxID,
 		TxHash: lastTxAlh[:],
+		DbUUID: dbUUID,
+	}, nil
+}
+
+// dbUUID returns the uuid recorded in the first tx of the database. It's empty for
+// databases created by previous versions and for replicas not holding any tx yet
+func (d *db) dbUUID() (string, error) {
+	d.uuidMutex.Lock()
+	defer d.uuidMutex.Unlock()
+
+	if d.uuidResolved {
+		return d.uuid, nil
+	}
+
+	lastTxID, _ := d.st.Alh()
+	if lastTxID == 0 {
+		return "", nil
+	}
+
+	tx := d.st.NewTxHolder()
+
+	err := d.st.ReadTx(1, tx)
+	if err != nil {
+		return "", err
+	}
+
+	d.uuid, _ = tx.Header().Metadata.Label(DatabaseUUIDLabel)
+	d.uuidResolved = true
+
+	return d.uuid, nil
+}
+
+// verifiableTx includes the uuid of the database together with the proof,
+// letting clients detect when they get served by a different database
+func (d *db) verifiableTx(tx *store.Tx, dualProof *store.DualProof) (*schema.VerifiableTx, error) {
+	dbUUID, err := d.dbUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.VerifiableTx{
+		Tx:        schema.TxToProto(tx),
+		DualProof: schema.DualProofToProto(dualProof),
+		DbUUID:    dbUUID,
 	}, nil
 }
 
@@ -532,15 +754,12 @@ func (d *db) VerifiableSet(req *schema.VerifiableSetRequest) (*schema.Verifiable
 		}
 	}
 
-	dualProof, err := d.st.DualProof(prevTx, lastTx)
+	dualProof, err := d.dualProof(prevTx, lastTx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &schema.VerifiableTx{
-		Tx:        schema.TxToProto(lastTx),
-		DualProof: schema.DualProofToProto(dualProof),
-	}, nil
+	return d.verifiableTx(lastTx, dualProof)
 }
 
 //VerifiableGet ...
@@ -606,14 +825,14 @@ func (d *db) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.Verifiable
 		targetTx = rootTx
 	}
 
-	dualProof, err := d.st.DualProof(sourceTx, targetTx)
+	dualProof, err := d.dualProof(sourceTx, targetTx)
 	if err != nil {
 		return nil, err
 	}
 
-	verifiableTx := &schema.VerifiableTx{
-		Tx:        schema.TxToProto(tx),
-		DualProof: schema.DualProofToProto(dualProof),
+	verifiableTx, err := d.verifiableTx(tx, dualProof)
+	if err != nil {
+		return nil, err
 	}
 
 	return &schema.VerifiableEntry{
@@ -623,6 +842,24 @@ func (d *db) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.Verifiable
 	}, nil
 }
 
+// Forget destroys the data key used to encrypt the values of the key.
+// Encrypted values become unrecoverable while the digests of the entries remain valid.
+func (d *db) Forget(req *schema.ForgetRequest) error {
+	if req == nil || len(req.Key) == 0 {
+		return ErrIllegalArguments
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
+	return d.keys.forget(req.Key)
+}
+
 func (d *db) Delete(req *schema.DeleteKeysRequest) (*schema.TxHeader, error) {
 	if req == nil {
 		return nil, ErrIllegalArguments
@@ -631,8 +868,9 @@ func (d *db) Delete(req *schema.DeleteKeysRequest) (*schema.TxHeader, error) {
 	d.mutex.RLock()
 	defer d.mutex.RUnlock()
 
-	if d.isReplica() {
-		return nil, ErrIsReplica
+	err := d.checkWritable()
+	if err != nil {
+		return nil, err
 	}
 
 	currTxID, _ := d.st.Alh()
@@ -646,7 +884,7 @@ func (d *db) Delete(req *schema.DeleteKeysRequest) (*schema.TxHeader, error) {
 		waitUntilTx = currTxID
 	}
 
-	err := d.WaitForIndexingUpto(waitUntilTx, nil)
+	err = d.WaitForIndexingUpto(waitUntilTx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -729,6 +967,138 @@ func (d *db) GetAll(req *schema.KeyListRequest) (*schema.Entries, error) {
 	return list, nil
 }
 
+// VerifiableGetAll returns the entries of the keys proven against the newest tx holding any of them,
+// proofs of older txs are computed once per tx no matter how many entries they hold
+func (d *db) VerifiableGetAll(req *schema.VerifiableGetAllRequest) (*schema.VerifiableEntries, error) {
+	if req == nil || req.KeyListRequest == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	lastTxID, _ := d.st.Alh()
+	if lastTxID < req.ProveSinceTx {
+		return nil, ErrIllegalState
+	}
+
+	list, err := d.GetAll(req.KeyListRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	inclusionProofs, txProofs, vTx, err := d.proveEntries(list.Entries, req.ProveSinceTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.VerifiableEntries{
+		Entries:         list.Entries,
+		InclusionProofs: inclusionProofs,
+		TxProofs:        txProofs,
+		VerifiableTx:    vTx,
+	}, nil
+}
+
+// proveEntries computes the inclusion proofs of the entries together with the dual proofs linking
+// their txs to the newest one, which is in turn proven against proveSinceTx
+func (d *db) proveEntries(entries []*schema.Entry, proveSinceTx uint64) ([]*schema.InclusionProof, map[uint64]*schema.DualProof, *schema.VerifiableTx, error) {
+	txProofs := make(map[uint64]*schema.DualProof)
+
+	if len(entries) == 0 {
+		return nil, txProofs, nil, nil
+	}
+
+	vTxIDs := make([]uint64, len(entries))
+	vKeys := make([][]byte, len(entries))
+
+	var targetTxID uint64
+
+	for i, e := range entries {
+		if e.ReferencedBy == nil {
+			vTxIDs[i] = e.Tx
+			vKeys[i] = e.Key
+		} else {
+			vTxIDs[i] = e.ReferencedBy.Tx
+			vKeys[i] = e.ReferencedBy.Key
+		}
+
+		if vTxIDs[i] > targetTxID {
+			targetTxID = vTxIDs[i]
+		}
+	}
+
+	targetTx := d.st.NewTxHolder()
+
+	err := d.st.ReadTx(targetTxID, targetTx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	txs := map[uint64]*store.Tx{targetTxID: targetTx}
+
+	var inclusionProofs []*schema.InclusionProof
+
+	for i, vTxID := range vTxIDs {
+		tx, ok := txs[vTxID]
+		if !ok {
+			tx = d.st.NewTxHolder()
+
+			err = d.st.ReadTx(vTxID, tx)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			txProof, err := d.dualProof(tx, targetTx)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			txs[vTxID] = tx
+			txProofs[vTxID] = schema.DualProofToProto(txProof)
+		}
+
+		inclusionProof, err := tx.Proof(EncodeKey(vKeys[i]))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		inclusionProofs = append(inclusionProofs, schema.InclusionProofToProto(inclusionProof))
+	}
+
+	var rootTx *store.Tx
+
+	if proveSinceTx == 0 {
+		rootTx = targetTx
+	} else {
+		rootTx = d.st.NewTxHolder()
+
+		err = d.st.ReadTx(proveSinceTx, rootTx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	var sourceTx, proofTargetTx *store.Tx
+
+	if proveSinceTx <= targetTxID {
+		sourceTx = rootTx
+		proofTargetTx = targetTx
+	} else {
+		sourceTx = targetTx
+		proofTargetTx = rootTx
+	}
+
+	dualProof, err := d.dualProof(sourceTx, proofTargetTx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vTx, err := d.verifiableTx(targetTx, dualProof)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return inclusionProofs, txProofs, vTx, nil
+}
+
 //Size ...
 func (d *db) Size() (uint64, error) {
 	return d.st.TxCount(), nil
@@ -777,6 +1147,10 @@ func (d *db) ReplicateTx(exportedTx []byte) (*schema.TxHeader, error) {
 		return nil, ErrNotReplica
 	}
 
+	if d.readOnly {
+		return nil, ErrIsReadOnly
+	}
+
 	hdr, err := d.st.ReplicateTx(exportedTx, false)
 	if err != nil {
 		return nil, err
@@ -819,23 +1193,24 @@ func (d *db) VerifiableTxByID(req *schema.VerifiableTxRequest) (*schema.Verifiab
 		}
 	}
 
+	var dualProof *store.DualProof
+
 	if req.ProveSinceTx <= req.Tx {
 		sourceTx = rootTx
 		targetTx = reqTx
+
+		dualProof, err = d.batchedDualProof(sourceTx, targetTx)
 	} else {
 		sourceTx = reqTx
 		targetTx = rootTx
-	}
 
-	dualProof, err := d.st.DualProof(sourceTx, targetTx)
+		dualProof, err = d.dualProof(sourceTx, targetTx)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &schema.VerifiableTx{
-		Tx:        schema.TxToProto(reqTx),
-		DualProof: schema.DualProofToProto(dualProof),
-	}, nil
+	return d.verifiableTx(reqTx, dualProof)
 }
 
 //TxScan ...
@@ -861,7 +1236,7 @@ func (d *db) TxScan(req *schema.TxScanRequest) (*schema.TxList, error) {
 
 	txList := &schema.TxList{}
 
-	for i := 0; i < limit; i++ {
+	for len(txList.Txs) < limit {
 		tx, err := txReader.Read()
 		if err == store.ErrNoMoreEntries {
 			break
@@ -870,22 +1245,34 @@ func (d *db) TxScan(req *schema.TxScanRequest) (*schema.TxList, error) {
 			return nil, err
 		}
 
+		if !hasLabels(tx.Header().Metadata, req.Labels) {
+			continue
+		}
+
 		txList.Txs = append(txList.Txs, schema.TxToProto(tx))
 	}
 
 	return txList, nil
 }
 
+// hasLabels returns true if the tx metadata holds all of the labels
+func hasLabels(txmd *store.TxMetadata, labels map[string]string) bool {
+	for k, v := range labels {
+		tv, ok := txmd.Label(k)
+		if !ok || tv != v {
+			return false
+		}
+	}
+
+	return true
+}
+
 //History ...
 func (d *db) History(req *schema.HistoryRequest) (*schema.Entries, error) {
 	if req == nil {
 		return nil, ErrIllegalArguments
 	}
 
-	if req.Limit > MaxKeyScanLimit {
-		return nil, ErrMaxKeyScanLimitExceeded
-	}
-
 	currTxID, _ := d.st.Alh()
 
 	if req.SinceTx > currTxID {
@@ -897,20 +1284,40 @@ func (d *db) History(req *schema.HistoryRequest) (*schema.Entries, error) {
 		waitUntilTx = currTxID
 	}
 
-	err := d.WaitForIndexingUpto(waitUntilTx, nil)
+	txRange, err := d.txRangeByTime(req.StartTime, req.EndTime)
 	if err != nil {
 		return nil, err
 	}
 
-	limit := int(req.Limit)
+	err = d.WaitForIndexingUpto(waitUntilTx, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	if req.Limit == 0 {
-		limit = MaxKeyScanLimit
+	if req.Limit < 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	limit := int(d.pageSize(uint64(req.Limit)))
+
+	offset := req.Offset
+
+	if len(req.ContinuationToken) > 0 {
+		offset, err = offsetFromToken(historyPage, req.ContinuationToken)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	key := EncodeKey(req.Key)
 
-	txs, err := d.st.History(key, req.Offset, req.Desc, limit)
+	var txs []uint64
+
+	if txRange == nil {
+		txs, err = d.st.History(key, offset, req.Desc, limit)
+	} else {
+		txs, err = d.historyWithin(key, txRange, offset, req.Desc, limit)
+	}
 	if err != nil && err != store.ErrOffsetOutOfRange {
 		return nil, err
 	}
@@ -936,6 +1343,8 @@ func (d *db) History(req *schema.HistoryRequest) (*schema.Entries, error) {
 		if err != nil && err != store.ErrExpiredEntry {
 			return nil, err
 		}
+		encrypted := len(val) > 0 && val[0] == EncryptedValuePrefix
+
 		if len(val) > 0 {
 			val = TrimPrefix(val)
 		}
@@ -947,13 +1356,74 @@ func (d *db) History(req *schema.HistoryRequest) (*schema.Entries, error) {
 			Value:    val,
 			Expired:  err == store.ErrExpiredEntry,
 		}
+
+		if encrypted {
+			err = d.decryptEntry(list.Entries[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(txs) == limit {
+		list.ContinuationToken = newOffsetToken(historyPage, offset+uint64(limit))
 	}
 
 	return list, nil
 }
 
+// historyWithin returns the transactions of the key history found within the given range,
+// the offset being applied to the transactions within the range
+func (d *db) historyWithin(key []byte, txRange *txRange, offset uint64, desc bool, limit int) ([]uint64, error) {
+	var txs []uint64
+
+	skipped := uint64(0)
+
+	for histOffset := uint64(0); ; {
+		page, err := d.st.History(key, histOffset, desc, MaxKeyScanLimit)
+		if err == store.ErrOffsetOutOfRange {
+			return txs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, txID := range page {
+			if !txRange.contains(txID) {
+				// transactions are found in order, no further match is possible once past the range
+				if (!desc && txID > txRange.lastTx) || (desc && txID < txRange.firstTx) {
+					return txs, nil
+				}
+				continue
+			}
+
+			if skipped < offset {
+				skipped++
+				continue
+			}
+
+			txs = append(txs, txID)
+
+			if len(txs) == limit {
+				return txs, nil
+			}
+		}
+
+		if len(page) < MaxKeyScanLimit {
+			return txs, nil
+		}
+
+		histOffset += uint64(len(page))
+	}
+}
+
 //Close ...
 func (d *db) Close() error {
+	if d.sqlNamespace {
+		// the engine of a namespace is closed together with the database
+		return nil
+	}
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
@@ -963,6 +1433,21 @@ func (d *db) Close() error {
 
 	d.sqlInit.Wait() // Wait for SQL Engine initialization to conclude
 
+	err := d.sqlEngine.Close()
+	if err != nil {
+		d.Logger.Warningf("Unable to gracefully close the SQL engine of database '%s': %v", d.name, err)
+	}
+
+	d.sqlNamespacesMutex.Lock()
+	defer d.sqlNamespacesMutex.Unlock()
+
+	for prefix, engine := range d.sqlNamespaces {
+		err := engine.Close()
+		if err != nil {
+			d.Logger.Warningf("Unable to gracefully close the SQL engine of namespace '%s' of database '%s': %v", prefix, d.name, err)
+		}
+	}
+
 	return d.st.Close()
 }
 
@@ -996,6 +1481,22 @@ func (d *db) IsReplica() bool {
 	return d.options.replica
 }
 
+// AsReadOnly sets whether writes are rejected with ErrIsReadOnly. Ongoing writes are completed
+// before the database is switched to read-only
+func (d *db) AsReadOnly(readOnly bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.readOnly = readOnly
+}
+
+func (d *db) IsReadOnly() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.readOnly
+}
+
 func logErr(log logger.Logger, formattedMessage string, err error) error {
 	if err != nil {
 		log.Errorf(formattedMessage, err)
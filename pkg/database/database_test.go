@@ -17,6 +17,7 @@ package database
 
 import (
 	"crypto/sha256"
+	"fmt"
 	"log"
 	"os"
 	"path"
@@ -183,6 +184,57 @@ func TestOpenDB(t *testing.T) {
 	os.RemoveAll(options.GetDBRootPath())
 }
 
+func TestDatabaseUUID(t *testing.T) {
+	options := DefaultOption().WithDBName("db").WithDBRootPath("data_uuid")
+	defer os.RemoveAll(options.GetDBRootPath())
+
+	db, err := NewDB(options, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+
+	state, err := db.CurrentState()
+	require.NoError(t, err)
+	require.NotEmpty(t, state.DbUUID)
+
+	dbUUID := state.DbUUID
+
+	_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	db, err = OpenDB(options, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer db.Close()
+
+	state, err = db.CurrentState()
+	require.NoError(t, err)
+	require.Equal(t, dbUUID, state.DbUUID)
+
+	replica, closer := makeDbWith(DefaultOption().WithDBName("replica").WithDBRootPath("data_uuid_replica").AsReplica(true))
+	defer closer()
+
+	state, err = replica.CurrentState()
+	require.NoError(t, err)
+	require.Empty(t, state.DbUUID)
+
+	// the uuid is taken from the first replicated tx
+	for i := uint64(1); i <= 2; i++ {
+		etx, err := db.ExportTxByID(&schema.TxRequest{Tx: i})
+		require.NoError(t, err)
+
+		// as done by the replicator, it's retried until the binary linking of the replica catches up
+		require.Eventually(t, func() bool {
+			_, err = replica.ReplicateTx(etx)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	state, err = replica.CurrentState()
+	require.NoError(t, err)
+	require.Equal(t, dbUUID, state.DbUUID)
+}
+
 func TestOpenV1_0_1_DB(t *testing.T) {
 	copier := fs.NewStandardCopier()
 	require.NoError(t, copier.CopyDir("../../test/data_v1.1.0", "data_v1.1.0"))
@@ -497,6 +549,25 @@ func TestSetGetAll(t *testing.T) {
 	}
 }
 
+func TestIndexingProgress(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	hdr, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key"), Value: []byte("value")}}})
+	require.NoError(t, err)
+
+	err = db.WaitForIndexingUpto(hdr.Id, nil)
+	require.NoError(t, err)
+
+	progress, err := db.IndexingProgress()
+	require.NoError(t, err)
+	require.Equal(t, hdr.Id, progress.CommittedTxId)
+	require.Equal(t, hdr.Id, progress.IndexedTxId)
+	require.LessOrEqual(t, progress.CheckpointTxId, hdr.Id)
+	require.Zero(t, progress.CheckpointedAt)
+	require.Zero(t, progress.ResumedFromTxId)
+}
+
 func TestTxByID(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
@@ -514,6 +585,29 @@ func TestTxByID(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNonIndexableEntries(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	txhdr, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{
+		Key:      []byte("evidence"),
+		Value:    []byte("value"),
+		Metadata: &schema.KVMetadata{NonIndexable: true},
+	}}})
+	require.NoError(t, err)
+
+	_, err = db.Get(&schema.KeyRequest{Key: []byte("evidence")})
+	require.ErrorIs(t, err, store.ErrKeyNotFound)
+
+	_, err = db.Get(&schema.KeyRequest{Key: []byte("evidence"), SinceTx: txhdr.Id})
+	require.ErrorIs(t, err, store.ErrKeyNotFound)
+
+	tx, err := db.TxByID(&schema.TxRequest{Tx: txhdr.Id})
+	require.NoError(t, err)
+	require.Len(t, tx.Entries, 1)
+	require.True(t, tx.Entries[0].Metadata.NonIndexable)
+}
+
 func TestVerifiableTxByID(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
@@ -533,6 +627,68 @@ func TestVerifiableTxByID(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestVerifiableTxByIDWithProofCache(t *testing.T) {
+	rootPath := "data_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	idb, closer := makeDbWith(DefaultOption().WithDBRootPath(rootPath).WithDBName("db").WithProofCacheSize(1))
+	defer closer()
+
+	for _, val := range kvs {
+		_, err := idb.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
+		require.NoError(t, err)
+	}
+
+	vtx1, err := idb.VerifiableTxByID(&schema.VerifiableTxRequest{Tx: 1, ProveSinceTx: uint64(len(kvs))})
+	require.NoError(t, err)
+	require.Equal(t, 1, idb.(*db).proofCache.EntriesCount())
+
+	vtx2, err := idb.VerifiableTxByID(&schema.VerifiableTxRequest{Tx: 1, ProveSinceTx: uint64(len(kvs))})
+	require.NoError(t, err)
+	require.Equal(t, vtx1.DualProof, vtx2.DualProof)
+
+	_, err = idb.VerifiableTxByID(&schema.VerifiableTxRequest{Tx: 2, ProveSinceTx: uint64(len(kvs))})
+	require.NoError(t, err)
+	require.Equal(t, 1, idb.(*db).proofCache.EntriesCount())
+
+	_, err = idb.(*db).proofCache.Get(proofKey{sourceTxID: 2, targetTxID: uint64(len(kvs))})
+	require.NoError(t, err)
+}
+
+func TestVerifiableTxByIDWithBatchedProofs(t *testing.T) {
+	rootPath := "data_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	idb, closer := makeDbWith(DefaultOption().WithDBRootPath(rootPath).WithDBName("db").WithProofCacheSize(100))
+	defer closer()
+
+	for i := 0; i < 2*proofBatchSize; i++ {
+		_, err := idb.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte("value")}}})
+		require.NoError(t, err)
+	}
+
+	state, err := idb.CurrentState()
+	require.NoError(t, err)
+
+	_, err = idb.VerifiableTxByID(&schema.VerifiableTxRequest{Tx: 3, ProveSinceTx: 2})
+	require.NoError(t, err)
+	require.Equal(t, proofBatchSize, idb.(*db).proofCache.EntriesCount())
+
+	for txID := uint64(3); txID < 3+proofBatchSize; txID++ {
+		cachedProof, err := idb.(*db).proofCache.Get(proofKey{sourceTxID: 2, targetTxID: txID})
+		require.NoError(t, err)
+
+		vtx, err := idb.VerifiableTxByID(&schema.VerifiableTxRequest{Tx: txID, ProveSinceTx: 2})
+		require.NoError(t, err)
+		require.Equal(t, schema.DualProofToProto(cachedProof.(*store.DualProof)), vtx.DualProof)
+	}
+	require.Equal(t, proofBatchSize, idb.(*db).proofCache.EntriesCount())
+
+	t.Run("batches should not go beyond the last transaction", func(t *testing.T) {
+		_, err := idb.VerifiableTxByID(&schema.VerifiableTxRequest{Tx: state.TxId - 1, ProveSinceTx: 2})
+		require.NoError(t, err)
+		require.Equal(t, proofBatchSize+2, idb.(*db).proofCache.EntriesCount())
+	})
+}
+
 func TestTxScan(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
@@ -592,7 +748,8 @@ func TestHistory(t *testing.T) {
 		SinceTx: lastTx,
 		Limit:   MaxKeyScanLimit + 1,
 	})
-	require.Equal(t, ErrMaxKeyScanLimitExceeded, err)
+	// requests exceeding the max page size are truncated
+	require.NoError(t, err)
 
 	inc, err := db.History(&schema.HistoryRequest{
 		Key:     kvs[0].Key,
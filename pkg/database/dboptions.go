@@ -28,14 +28,22 @@ type Options struct {
 	replica bool
 
 	corruptionChecker bool
+
+	proofCacheSize int
+
+	defaultPageSize int
+	maxPageSize     int
 }
 
 // DefaultOption Initialise Db Optionts to default values
 func DefaultOption() *Options {
 	return &Options{
-		dbRootPath: "./data",
-		dbName:     "db_name",
-		storeOpts:  store.DefaultOptions(),
+		dbRootPath:      "./data",
+		dbName:          "db_name",
+		storeOpts:       store.DefaultOptions(),
+		proofCacheSize:  DefaultProofCacheSize,
+		defaultPageSize: DefaultPageSize,
+		maxPageSize:     MaxKeyScanLimit,
 	}
 }
 
@@ -88,3 +96,38 @@ func (o *Options) AsReplica(replica bool) *Options {
 	o.replica = replica
 	return o
 }
+
+// WithProofCacheSize sets the number of recently generated proofs kept in memory, zero disables the cache
+func (o *Options) WithProofCacheSize(size int) *Options {
+	o.proofCacheSize = size
+	return o
+}
+
+// GetProofCacheSize returns the number of recently generated proofs kept in memory
+func (o *Options) GetProofCacheSize() int {
+	return o.proofCacheSize
+}
+
+// WithDefaultPageSize sets the number of results returned by scans, histories and SQL queries
+// when the request does not set a limit
+func (o *Options) WithDefaultPageSize(size int) *Options {
+	o.defaultPageSize = size
+	return o
+}
+
+// GetDefaultPageSize returns the number of results returned when the request does not set a limit
+func (o *Options) GetDefaultPageSize() int {
+	return o.defaultPageSize
+}
+
+// WithMaxPageSize sets the max number of results returned at once, requests asking
+// for more results are truncated and a continuation token is returned
+func (o *Options) WithMaxPageSize(size int) *Options {
+	o.maxPageSize = size
+	return o
+}
+
+// GetMaxPageSize returns the max number of results returned at once
+func (o *Options) GetMaxPageSize() int {
+	return o.maxPageSize
+}
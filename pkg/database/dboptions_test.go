@@ -44,7 +44,8 @@ func TestDefaultOptions(t *testing.T) {
 		WithDBName(DbName).
 		WithDBRootPath(rootpath).
 		WithCorruptionChecker(true).
-		WithStoreOptions(storeOpts)
+		WithStoreOptions(storeOpts).
+		WithProofCacheSize(10)
 
 	if op.GetDBName() != DbName {
 		t.Errorf("db name not set correctly , expected %s got %s", DbName, op.GetDBName())
@@ -58,4 +59,5 @@ func TestDefaultOptions(t *testing.T) {
 	}
 
 	require.Equal(t, storeOpts, op.storeOpts)
+	require.Equal(t, 10, op.GetProofCacheSize())
 }
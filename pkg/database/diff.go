@@ -0,0 +1,119 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// ComputeDiff sends the keys under the prefix which were added, updated or removed after fromTx and up to toTx.
+// Only the keys updated within the range are visited, as their history is resolved by the index
+func (d *db) ComputeDiff(req *schema.DiffRequest, cancellation <-chan struct{}, send func(*schema.DiffEntry) error) error {
+	if req == nil || send == nil {
+		return ErrIllegalArguments
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	currTxID, _ := d.st.Alh()
+
+	if req.FromTx >= req.ToTx || req.ToTx > currTxID {
+		return store.ErrIllegalArguments
+	}
+
+	err := d.st.WaitForIndexingUpto(req.ToTx, cancellation)
+	if err != nil {
+		return err
+	}
+
+	snap, err := d.st.SnapshotSince(req.ToTx)
+	if err != nil {
+		return err
+	}
+	defer snap.Close()
+
+	// deleted entries are not filtered out so removals can be detected
+	r, err := snap.NewKeyReader(&store.KeyReaderSpec{Prefix: EncodeKey(req.Prefix)})
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	tx := d.st.NewTxHolder()
+
+	for {
+		key, prev, curr, err := r.ReadChanged(req.FromTx, req.ToTx)
+		if err == store.ErrNoMoreEntries {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		existed := prev != nil && !isDeleted(prev)
+		exists := !isDeleted(curr)
+
+		entry := &schema.DiffEntry{
+			Key: TrimPrefix(key),
+			Tx:  curr.Tx(),
+		}
+
+		if existed {
+			entry.PrevTx = prev.Tx()
+		}
+
+		switch {
+		case !existed && !exists:
+			continue
+		case existed && !exists:
+			entry.Action = schema.DiffAction_REMOVED
+		default:
+			if existed && prev.HVal() == curr.HVal() {
+				// the same value was written again
+				continue
+			}
+
+			e, err := d.getAt(key, curr.Tx(), 0, snap, tx)
+			if err == store.ErrKeyNotFound {
+				// referenced key may have been deleted
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			entry.Value = e.Value
+
+			if existed {
+				entry.Action = schema.DiffAction_UPDATED
+			} else {
+				entry.Action = schema.DiffAction_ADDED
+			}
+		}
+
+		err = send(entry)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func isDeleted(valRef store.ValueRef) bool {
+	md := valRef.KVMetadata()
+	return md != nil && md.Deleted()
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDiff(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	set := func(kvs ...string) uint64 {
+		req := &schema.SetRequest{}
+		for i := 0; i < len(kvs); i += 2 {
+			req.KVs = append(req.KVs, &schema.KeyValue{Key: []byte(kvs[i]), Value: []byte(kvs[i+1])})
+		}
+
+		hdr, err := db.Set(req)
+		require.NoError(t, err)
+
+		return hdr.Id
+	}
+
+	tx1 := set("acc:1", "10", "acc:2", "20", "acc:3", "30", "other", "1")
+	tx2 := set("acc:1", "15", "acc:4", "40", "other", "2")
+
+	hdr, err := db.Delete(&schema.DeleteKeysRequest{Keys: [][]byte{[]byte("acc:2")}})
+	require.NoError(t, err)
+	tx3 := hdr.Id
+
+	tx4 := set("acc:3", "30", "acc:5", "50")
+
+	_, err = db.SetReference(&schema.ReferenceRequest{Key: []byte("acc:6"), ReferencedKey: []byte("acc:1")})
+	require.NoError(t, err)
+
+	diff := func(fromTx, toTx uint64, prefix string) []*schema.DiffEntry {
+		var entries []*schema.DiffEntry
+
+		err := db.ComputeDiff(&schema.DiffRequest{FromTx: fromTx, ToTx: toTx, Prefix: []byte(prefix)}, nil, func(e *schema.DiffEntry) error {
+			entries = append(entries, e)
+			return nil
+		})
+		require.NoError(t, err)
+
+		return entries
+	}
+
+	t.Run("invalid requests should fail", func(t *testing.T) {
+		err := db.ComputeDiff(nil, nil, func(*schema.DiffEntry) error { return nil })
+		require.ErrorIs(t, err, ErrIllegalArguments)
+
+		err = db.ComputeDiff(&schema.DiffRequest{FromTx: tx2, ToTx: tx1}, nil, func(*schema.DiffEntry) error { return nil })
+		require.ErrorIs(t, err, store.ErrIllegalArguments)
+
+		err = db.ComputeDiff(&schema.DiffRequest{FromTx: tx1, ToTx: tx4 + 10}, nil, func(*schema.DiffEntry) error { return nil })
+		require.ErrorIs(t, err, store.ErrIllegalArguments)
+	})
+
+	t.Run("changes under the prefix should be sent", func(t *testing.T) {
+		require.Equal(t, []*schema.DiffEntry{
+			{Key: []byte("acc:1"), Action: schema.DiffAction_UPDATED, Tx: tx2, Value: []byte("15"), PrevTx: tx1},
+			{Key: []byte("acc:2"), Action: schema.DiffAction_REMOVED, Tx: tx3, PrevTx: tx1},
+			{Key: []byte("acc:4"), Action: schema.DiffAction_ADDED, Tx: tx2, Value: []byte("40")},
+			{Key: []byte("acc:5"), Action: schema.DiffAction_ADDED, Tx: tx4, Value: []byte("50")},
+		}, diff(tx1, tx4, "acc:"))
+	})
+
+	t.Run("changes after the upper bound should be ignored", func(t *testing.T) {
+		require.Equal(t, []*schema.DiffEntry{
+			{Key: []byte("acc:1"), Action: schema.DiffAction_UPDATED, Tx: tx2, Value: []byte("15"), PrevTx: tx1},
+			{Key: []byte("acc:4"), Action: schema.DiffAction_ADDED, Tx: tx2, Value: []byte("40")},
+			{Key: []byte("other"), Action: schema.DiffAction_UPDATED, Tx: tx2, Value: []byte("2"), PrevTx: tx1},
+		}, diff(tx1, tx2, ""))
+	})
+
+	t.Run("references should be resolved", func(t *testing.T) {
+		entries := diff(tx4, tx4+1, "acc:")
+		require.Len(t, entries, 1)
+		require.Equal(t, []byte("acc:6"), entries[0].Key)
+		require.Equal(t, schema.DiffAction_ADDED, entries[0].Action)
+		require.Equal(t, []byte("15"), entries[0].Value)
+	})
+
+	t.Run("sending errors should be returned", func(t *testing.T) {
+		errSend := errors.New("send error")
+
+		err := db.ComputeDiff(&schema.DiffRequest{FromTx: tx1, ToTx: tx4}, nil, func(*schema.DiffEntry) error { return errSend })
+		require.ErrorIs(t, err, errSend)
+	})
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+var ErrInvalidJSONDocument = errors.New("invalid JSON document")
+var ErrInvalidJSONField = errors.New("JSON field can not be used as column name")
+var ErrJSONFieldTypeMismatch = errors.New("JSON field value does not match the column type")
+var ErrJSONDocumentIDMissing = errors.New("JSON document without id")
+
+// documentIDField is the field identifying the documents, it's the primary key of the tables created on ingestion
+const documentIDField = "id"
+
+// maxDocumentIDLen is the max length of string ids, as it's part of the primary key
+const maxDocumentIDLen = 256
+
+// InferJSONIngest returns the script upserting the documents as rows of the table named after the collection.
+// The table is created when it doesn't exist yet and new columns are added for the fields not seen before,
+// column types are inferred from the first value of each field: numbers become INTEGER columns, strings VARCHAR
+// columns and booleans BOOLEAN columns, nested objects and arrays are stored as JSON in VARCHAR columns.
+// Null fields are set to NULL.
+func (d *db) InferJSONIngest(req *schema.IngestJSONRequest) (*schema.SQLExecRequest, error) {
+	if req == nil || len(req.Documents) == 0 || !isValidIdentifier(req.Collection) {
+		return nil, ErrIllegalArguments
+	}
+
+	collection := strings.ToLower(req.Collection)
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	catalog, err := d.sqlEngine.Catalog(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	colTypes := make(map[string]sql.SQLValueType)
+
+	table, err := catalog.GetTableByName(dbInstanceName, collection)
+	if err == nil {
+		for _, c := range table.Cols() {
+			colTypes[c.Name()] = c.Type()
+		}
+	} else if err != sql.ErrTableDoesNotExist {
+		return nil, err
+	}
+
+	createTable := table == nil
+
+	var script strings.Builder
+	var upserts strings.Builder
+	var params []*schema.NamedParam
+
+	for _, doc := range req.Documents {
+		fields, err := decodeJSONDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		id := fields[documentIDField]
+		if id == nil {
+			return nil, ErrJSONDocumentIDMissing
+		}
+
+		if createTable {
+			// the table gets created with the id column inferred from the first document
+			idType, err := jsonValueType(id)
+			if err != nil {
+				return nil, err
+			}
+			if idType != sql.IntegerType && idType != sql.VarcharType {
+				return nil, fmt.Errorf("%w: %s", ErrJSONFieldTypeMismatch, documentIDField)
+			}
+
+			if idType == sql.VarcharType {
+				fmt.Fprintf(&script, "CREATE TABLE %s (%s VARCHAR[%d], PRIMARY KEY %s);\n", collection, documentIDField, maxDocumentIDLen, documentIDField)
+			} else {
+				fmt.Fprintf(&script, "CREATE TABLE %s (%s INTEGER, PRIMARY KEY %s);\n", collection, documentIDField, documentIDField)
+			}
+
+			colTypes[documentIDField] = idType
+			createTable = false
+		}
+
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var cols, values []string
+
+		for _, name := range names {
+			v := fields[name]
+
+			if v == nil {
+				continue
+			}
+
+			vType, err := jsonValueType(v)
+			if err != nil {
+				return nil, err
+			}
+
+			colType, ok := colTypes[name]
+			if !ok {
+				colTypes[name] = vType
+				colType = vType
+
+				fmt.Fprintf(&script, "ALTER TABLE %s ADD COLUMN %s %s;\n", collection, name, vType)
+			}
+
+			if colType != vType {
+				return nil, fmt.Errorf("%w: %s", ErrJSONFieldTypeMismatch, name)
+			}
+
+			sqlValue, err := jsonToSQLValue(v)
+			if err != nil {
+				return nil, err
+			}
+
+			param := fmt.Sprintf("p%d", len(params))
+
+			params = append(params, &schema.NamedParam{Name: param, Value: sqlValue})
+			cols = append(cols, name)
+			values = append(values, "@"+param)
+		}
+
+		fmt.Fprintf(&upserts, "UPSERT INTO %s (%s) VALUES (%s);\n", collection, strings.Join(cols, ", "), strings.Join(values, ", "))
+	}
+
+	script.WriteString(upserts.String())
+
+	return &schema.SQLExecRequest{Sql: script.String(), Params: params}, nil
+}
+
+func decodeJSONDocument(doc []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	var raw map[string]interface{}
+
+	err := dec.Decode(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSONDocument, err)
+	}
+	if dec.More() {
+		return nil, ErrInvalidJSONDocument
+	}
+
+	// identifiers are case-insensitive
+	fields := make(map[string]interface{}, len(raw))
+
+	for name, v := range raw {
+		if !isValidIdentifier(name) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidJSONField, name)
+		}
+
+		colName := strings.ToLower(name)
+
+		if _, ok := fields[colName]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidJSONField, name)
+		}
+
+		fields[colName] = v
+	}
+
+	return fields, nil
+}
+
+func jsonValueType(v interface{}) (sql.SQLValueType, error) {
+	switch n := v.(type) {
+	case bool:
+		return sql.BooleanType, nil
+	case string:
+		return sql.VarcharType, nil
+	case json.Number:
+		_, err := n.Int64()
+		if err != nil {
+			return "", fmt.Errorf("%w: only integer numbers are supported", ErrJSONFieldTypeMismatch)
+		}
+		return sql.IntegerType, nil
+	}
+
+	// objects and arrays
+	return sql.VarcharType, nil
+}
+
+func jsonToSQLValue(v interface{}) (*schema.SQLValue, error) {
+	switch tv := v.(type) {
+	case bool:
+		return &schema.SQLValue{Value: &schema.SQLValue_B{B: tv}}, nil
+	case string:
+		return &schema.SQLValue{Value: &schema.SQLValue_S{S: tv}}, nil
+	case json.Number:
+		n, err := tv.Int64()
+		if err != nil {
+			return nil, err
+		}
+		return &schema.SQLValue{Value: &schema.SQLValue_N{N: n}}, nil
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.SQLValue{Value: &schema.SQLValue_S{S: string(encoded)}}, nil
+}
+
+// isValidIdentifier tells if the name can be used as table or column name
+func isValidIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, ch := range name {
+		isLetter := 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+		isNumber := '0' <= ch && ch <= '9'
+
+		if !isLetter && (i == 0 || !isNumber) {
+			return false
+		}
+	}
+
+	upperName := strings.ToUpper(name)
+
+	for _, kw := range sql.Keywords() {
+		if kw == upperName {
+			return false
+		}
+	}
+
+	return true
+}
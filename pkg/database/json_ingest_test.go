@@ -0,0 +1,90 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferJSONIngest(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	ingest := func(collection string, docs ...string) error {
+		req := &schema.IngestJSONRequest{Collection: collection}
+		for _, doc := range docs {
+			req.Documents = append(req.Documents, []byte(doc))
+		}
+
+		sqlReq, err := db.InferJSONIngest(req)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.SQLExecScript(sqlReq)
+		return err
+	}
+
+	_, err := db.InferJSONIngest(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = ingest("select", `{"id": 1}`)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = ingest("people", `{"id": 1`)
+	require.True(t, errors.Is(err, ErrInvalidJSONDocument))
+
+	err = ingest("people", `{"name": "John"}`)
+	require.Equal(t, ErrJSONDocumentIDMissing, err)
+
+	err = ingest("people", `{"id": 1, "from": "Rome"}`)
+	require.True(t, errors.Is(err, ErrInvalidJSONField))
+
+	err = ingest("people", `{"id": 1.5}`)
+	require.True(t, errors.Is(err, ErrJSONFieldTypeMismatch))
+
+	err = ingest("people",
+		`{"id": 1, "name": "John", "active": true}`,
+		`{"id": 2, "name": "Jane", "age": 30, "tags": ["a", "b"]}`,
+	)
+	require.NoError(t, err)
+
+	res, err := db.DescribeTable("people", nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 5)
+
+	// new fields are added as columns and existing rows are replaced
+	err = ingest("people", `{"id": 1, "name": "John", "nickname": null, "city": "Rome"}`)
+	require.NoError(t, err)
+
+	res, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT id, name, age, tags, city FROM people ORDER BY id"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+	require.Equal(t, "Rome", res.Rows[0].Values[4].GetS())
+	require.Equal(t, int64(30), res.Rows[1].Values[2].GetN())
+	require.Equal(t, `["a","b"]`, res.Rows[1].Values[3].GetS())
+
+	err = ingest("people", `{"id": 3, "age": "old"}`)
+	require.True(t, errors.Is(err, ErrJSONFieldTypeMismatch))
+
+	err = ingest("people", `{"id": "p3"}`)
+	require.True(t, errors.Is(err, ErrJSONFieldTypeMismatch))
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+const keyStoreDir = "keystore"
+
+// forgottenSuffix names the marker left behind once a data key is destroyed
+const forgottenSuffix = ".forgotten"
+
+const dataKeySize = 32
+const dataKeyFingerprintSize = 8
+
+var ErrForgottenEntry = errors.New("entry was forgotten")
+var ErrEncryptionKeyNotFound = errors.New("no encryption key found for the key")
+var ErrEncryptionKeyNotReplicated = errors.New("encrypted values can not be read from replicas, data keys are not replicated")
+
+// keyStore holds the data keys used to encrypt values, one per key.
+// Unlike the values themselves, data keys are kept outside the immutable store so they can be destroyed;
+// once the data key of a key is destroyed, all its encrypted values become unrecoverable.
+// A marker is kept for every destroyed data key so forgotten values are not mistaken for values
+// whose data key was never available, e.g. in a replica or in a copy of the store without its keystore.
+type keyStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+func newKeyStore(path string) *keyStore {
+	return &keyStore{path: path}
+}
+
+func (ks *keyStore) fileName(key []byte) string {
+	kid := sha256.Sum256(key)
+	return filepath.Join(ks.path, hex.EncodeToString(kid[:]))
+}
+
+func (ks *keyStore) dataKeyFor(key []byte, create bool) ([]byte, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	fileName := ks.fileName(key)
+
+	dataKey, err := ioutil.ReadFile(fileName)
+	if err == nil {
+		return dataKey, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if !create {
+		_, err = os.Stat(fileName + forgottenSuffix)
+		if os.IsNotExist(err) {
+			return nil, ErrEncryptionKeyNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, ErrForgottenEntry
+	}
+
+	err = os.MkdirAll(ks.path, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey = make([]byte, dataKeySize)
+
+	_, err = rand.Read(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ioutil.WriteFile(fileName, dataKey, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataKey, nil
+}
+
+func (ks *keyStore) forget(key []byte) error {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	fileName := ks.fileName(key)
+
+	f, err := os.OpenFile(fileName, os.O_WRONLY, 0600)
+	if os.IsNotExist(err) {
+		return ErrEncryptionKeyNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	// the key is overwritten before removing the file so it's not left behind on disk
+	_, err = f.Write(make([]byte, dataKeySize))
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	err = f.Sync()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(fileName+forgottenSuffix, nil, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(fileName)
+}
+
+// copyTo copies the data key of the key into the destination keystore,
+// or the marker of its destruction if it was already destroyed
+func (ks *keyStore) copyTo(dst *keyStore, key []byte) error {
+	dataKey, err := ks.dataKeyFor(key, false)
+	if err == ErrEncryptionKeyNotFound {
+		return nil
+	}
+	if err != nil && err != ErrForgottenEntry {
+		return err
+	}
+
+	dst.mutex.Lock()
+	defer dst.mutex.Unlock()
+
+	err = os.MkdirAll(dst.path, 0700)
+	if err != nil {
+		return err
+	}
+
+	if dataKey == nil {
+		return ioutil.WriteFile(dst.fileName(key)+forgottenSuffix, nil, 0600)
+	}
+
+	return ioutil.WriteFile(dst.fileName(key), dataKey, 0600)
+}
+
+// encrypt returns the envelope {fingerprint}{nonce}{ciphertext} of the value.
+// The fingerprint identifies the data key so envelopes created with a destroyed key remain recognizable
+// even after a new data key is created for the same key.
+func (ks *keyStore) encrypt(key, value []byte) ([]byte, error) {
+	dataKey, err := ks.dataKeyFor(key, true)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := sha256.Sum256(dataKey)
+
+	envelope := make([]byte, dataKeyFingerprintSize+aead.NonceSize(), dataKeyFingerprintSize+aead.NonceSize()+len(value)+aead.Overhead())
+	copy(envelope, fingerprint[:dataKeyFingerprintSize])
+
+	_, err = rand.Read(envelope[dataKeyFingerprintSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(envelope, envelope[dataKeyFingerprintSize:], value, key), nil
+}
+
+func (ks *keyStore) decrypt(key, envelope []byte) ([]byte, error) {
+	dataKey, err := ks.dataKeyFor(key, false)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(envelope) < dataKeyFingerprintSize+aead.NonceSize() {
+		return nil, store.ErrCorruptedData
+	}
+
+	fingerprint := sha256.Sum256(dataKey)
+	if !bytes.Equal(fingerprint[:dataKeyFingerprintSize], envelope[:dataKeyFingerprintSize]) {
+		// encrypted with a data key which has been destroyed since then
+		return nil, ErrForgottenEntry
+	}
+
+	nonce := envelope[dataKeyFingerprintSize : dataKeyFingerprintSize+aead.NonceSize()]
+
+	value, err := aead.Open(nil, nonce, envelope[dataKeyFingerprintSize+aead.NonceSize():], key)
+	if err != nil {
+		return nil, store.ErrCorruptedData
+	}
+
+	return value, nil
+}
+
+func newAEAD(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
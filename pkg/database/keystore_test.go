@@ -0,0 +1,178 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForget(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	err := db.Forget(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = db.Forget(&schema.ForgetRequest{Key: []byte("key1")})
+	require.Equal(t, ErrEncryptionKeyNotFound, err)
+
+	hdr1, err := db.Set(&schema.SetRequest{
+		KVs:     []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("personal data")}},
+		Encrypt: true,
+	})
+	require.NoError(t, err)
+
+	_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key2"), Value: []byte("value2")}}})
+	require.NoError(t, err)
+
+	entry, err := db.Get(&schema.KeyRequest{Key: []byte("key1")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("personal data"), entry.Value)
+	require.NotEmpty(t, entry.EncryptedValue)
+	require.NotContains(t, string(entry.EncryptedValue), "personal data")
+	require.False(t, entry.Forgotten)
+
+	entry, err = db.Get(&schema.KeyRequest{Key: []byte("key2")})
+	require.NoError(t, err)
+	require.Nil(t, entry.EncryptedValue)
+
+	verifyEntry := func(key []byte) *schema.Entry {
+		vEntry, err := db.VerifiableGet(&schema.VerifiableGetRequest{KeyRequest: &schema.KeyRequest{Key: key}})
+		require.NoError(t, err)
+
+		entrySpecDigest, err := store.EntrySpecDigestFor(int(vEntry.VerifiableTx.Tx.Header.Version))
+		require.NoError(t, err)
+
+		verifies := store.VerifyInclusion(
+			schema.InclusionProofFromProto(vEntry.InclusionProof),
+			entrySpecDigest(EncodeEntry(key, vEntry.Entry)),
+			schema.DigestFromProto(vEntry.VerifiableTx.Tx.Header.EH),
+		)
+		require.True(t, verifies)
+
+		return vEntry.Entry
+	}
+
+	verifyEntry([]byte("key1"))
+
+	err = db.Forget(&schema.ForgetRequest{Key: []byte("key1")})
+	require.NoError(t, err)
+
+	err = db.Forget(&schema.ForgetRequest{Key: []byte("key1")})
+	require.Equal(t, ErrEncryptionKeyNotFound, err)
+
+	t.Run("forgotten entries should remain verifiable", func(t *testing.T) {
+		entry := verifyEntry([]byte("key1"))
+		require.True(t, entry.Forgotten)
+		require.Nil(t, entry.Value)
+
+		entry, err = db.Get(&schema.KeyRequest{Key: []byte("key1"), AtTx: hdr1.Id})
+		require.NoError(t, err)
+		require.True(t, entry.Forgotten)
+
+		entries, err := db.Scan(&schema.ScanRequest{Prefix: []byte("key")})
+		require.NoError(t, err)
+		require.Len(t, entries.Entries, 2)
+		require.True(t, entries.Entries[0].Forgotten)
+		require.Equal(t, []byte("value2"), entries.Entries[1].Value)
+	})
+
+	t.Run("new data keys should not disclose forgotten values", func(t *testing.T) {
+		_, err = db.Set(&schema.SetRequest{
+			KVs:     []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("new personal data")}},
+			Encrypt: true,
+		})
+		require.NoError(t, err)
+
+		entry := verifyEntry([]byte("key1"))
+		require.Equal(t, []byte("new personal data"), entry.Value)
+
+		history, err := db.History(&schema.HistoryRequest{Key: []byte("key1")})
+		require.NoError(t, err)
+		require.Len(t, history.Entries, 2)
+		require.True(t, history.Entries[0].Forgotten)
+		require.Nil(t, history.Entries[0].Value)
+		require.Equal(t, []byte("new personal data"), history.Entries[1].Value)
+	})
+
+	t.Run("restored databases should keep encrypted values readable", func(t *testing.T) {
+		state, err := db.CurrentState()
+		require.NoError(t, err)
+
+		dst, dstCloser := makeDb()
+		defer dstCloser()
+
+		err = db.CopyStateAt(state.TxId, dst)
+		require.NoError(t, err)
+
+		entry, err := dst.Get(&schema.KeyRequest{Key: []byte("key1")})
+		require.NoError(t, err)
+		require.Equal(t, []byte("new personal data"), entry.Value)
+
+		err = db.Forget(&schema.ForgetRequest{Key: []byte("key1")})
+		require.NoError(t, err)
+
+		entry, err = dst.Get(&schema.KeyRequest{Key: []byte("key1")})
+		require.NoError(t, err)
+		require.Equal(t, []byte("new personal data"), entry.Value)
+	})
+
+	t.Run("restored databases should keep forgotten values forgotten", func(t *testing.T) {
+		state, err := db.CurrentState()
+		require.NoError(t, err)
+
+		dst, dstCloser := makeDb()
+		defer dstCloser()
+
+		err = db.CopyStateAt(state.TxId, dst)
+		require.NoError(t, err)
+
+		entry, err := dst.Get(&schema.KeyRequest{Key: []byte("key1")})
+		require.NoError(t, err)
+		require.True(t, entry.Forgotten)
+	})
+
+	t.Run("replicas should not report values as forgotten", func(t *testing.T) {
+		replica, replicaCloser := makeDbWith(DefaultOption().WithDBName("replica").WithDBRootPath("data_forget_replica").AsReplica(true))
+		defer replicaCloser()
+
+		state, err := db.CurrentState()
+		require.NoError(t, err)
+
+		for i := uint64(1); i <= state.TxId; i++ {
+			etx, err := db.ExportTxByID(&schema.TxRequest{Tx: i})
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool {
+				_, err = replica.ReplicateTx(etx)
+				return err == nil
+			}, 5*time.Second, 10*time.Millisecond)
+		}
+
+		_, err = replica.Get(&schema.KeyRequest{Key: []byte("key1"), SinceTx: state.TxId})
+		require.Equal(t, ErrEncryptionKeyNotReplicated, err)
+
+		entry, err := replica.Get(&schema.KeyRequest{Key: []byte("key2"), SinceTx: state.TxId})
+		require.NoError(t, err)
+		require.Equal(t, []byte("value2"), entry.Value)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"math"
 
 	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
 )
 
 const (
@@ -32,6 +33,7 @@ const (
 const (
 	PlainValuePrefix = iota
 	ReferenceValuePrefix
+	EncryptedValuePrefix
 )
 
 //WrapWithPrefix ...
@@ -58,6 +60,26 @@ func EncodeEntrySpec(key []byte, md *store.KVMetadata, value []byte) *store.Entr
 	}
 }
 
+// EncodeEncryptedEntrySpec encodes an entry whose value is stored as an envelope encrypted with a deletable data key
+func EncodeEncryptedEntrySpec(key []byte, md *store.KVMetadata, envelope []byte) *store.EntrySpec {
+	return &store.EntrySpec{
+		Key:      WrapWithPrefix(key, SetKeyPrefix),
+		Metadata: md,
+		Value:    WrapWithPrefix(envelope, EncryptedValuePrefix),
+	}
+}
+
+// EncodeEntry encodes the plain or encrypted entry as it was stored
+func EncodeEntry(key []byte, entry *schema.Entry) *store.EntrySpec {
+	md := schema.KVMetadataFromProto(entry.Metadata)
+
+	if entry.EncryptedValue != nil {
+		return EncodeEncryptedEntrySpec(key, md, entry.EncryptedValue)
+	}
+
+	return EncodeEntrySpec(key, md, entry.Value)
+}
+
 func EncodeReference(key []byte, md *store.KVMetadata, referencedKey []byte, atTx uint64) *store.EntrySpec {
 	// Note: metadata record may be used as reference holder, reference resolution would be faster
 	// It may be introduced in a backward-compatible way i.e. if not present in metadata then resolve by reading value
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// DefaultPageSize is the number of results returned when the request does not set a limit
+const DefaultPageSize = MaxKeyScanLimit
+
+var ErrInvalidContinuationToken = errors.New("invalid continuation token")
+
+// continuation tokens are opaque to clients, they're made of the kind of request
+// they were issued for followed by the position the next page is read from
+type pageKind byte
+
+const (
+	scanPage pageKind = iota + 1
+	zScanPage
+	historyPage
+	sqlPage
+)
+
+func newContinuationToken(kind pageKind, position []byte) []byte {
+	token := make([]byte, 1+len(position))
+	token[0] = byte(kind)
+	copy(token[1:], position)
+	return token
+}
+
+func positionFromToken(kind pageKind, token []byte) ([]byte, error) {
+	if len(token) == 0 || token[0] != byte(kind) {
+		return nil, ErrInvalidContinuationToken
+	}
+	return token[1:], nil
+}
+
+func newOffsetToken(kind pageKind, offset uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], offset)
+	return newContinuationToken(kind, b[:])
+}
+
+func offsetFromToken(kind pageKind, token []byte) (uint64, error) {
+	position, err := positionFromToken(kind, token)
+	if err != nil {
+		return 0, err
+	}
+	if len(position) != 8 {
+		return 0, ErrInvalidContinuationToken
+	}
+	return binary.BigEndian.Uint64(position), nil
+}
+
+// zScanPosition is the sorted set entry the next page of a zscan is read after
+type zScanPosition struct {
+	score float64
+	atTx  uint64
+	key   []byte
+}
+
+func newZScanToken(p *zScanPosition) []byte {
+	b := make([]byte, 8+8+len(p.key))
+	binary.BigEndian.PutUint64(b, math.Float64bits(p.score))
+	binary.BigEndian.PutUint64(b[8:], p.atTx)
+	copy(b[16:], p.key)
+	return newContinuationToken(zScanPage, b)
+}
+
+func zScanPositionFromToken(token []byte) (*zScanPosition, error) {
+	position, err := positionFromToken(zScanPage, token)
+	if err != nil {
+		return nil, err
+	}
+	if len(position) <= 16 {
+		return nil, ErrInvalidContinuationToken
+	}
+	return &zScanPosition{
+		score: math.Float64frombits(binary.BigEndian.Uint64(position)),
+		atTx:  binary.BigEndian.Uint64(position[8:]),
+		key:   position[16:],
+	}, nil
+}
+
+// pageSize returns the number of results to be returned for the requested limit,
+// requests asking for more results than the max page size are truncated
+func (d *db) pageSize(limit uint64) uint64 {
+	if limit == 0 {
+		limit = uint64(d.options.defaultPageSize)
+	}
+
+	if limit > uint64(d.options.maxPageSize) {
+		return uint64(d.options.maxPageSize)
+	}
+
+	return limit
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagination(t *testing.T) {
+	rootPath := "data_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	options := DefaultOption().WithDBRootPath(rootPath).WithDBName("db").
+		WithDefaultPageSize(3).
+		WithMaxPageSize(4)
+
+	db, closer := makeDbWith(options)
+	defer closer()
+
+	for i := 0; i < 10; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+
+		_, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{
+			{Key: key, Value: []byte(fmt.Sprintf("value%d", i))},
+			{Key: []byte("counter"), Value: []byte(fmt.Sprintf("%d", i))},
+		}})
+		require.NoError(t, err)
+
+		_, err = db.ZAdd(&schema.ZAddRequest{Set: []byte("set"), Score: float64(i), Key: key})
+		require.NoError(t, err)
+	}
+
+	t.Run("scan pages should cover all the entries", func(t *testing.T) {
+		list, err := db.Scan(&schema.ScanRequest{Prefix: []byte("key")})
+		require.NoError(t, err)
+		require.Len(t, list.Entries, 3)
+		require.NotEmpty(t, list.ContinuationToken)
+
+		var keys []string
+		for _, e := range list.Entries {
+			keys = append(keys, string(e.Key))
+		}
+
+		for len(list.ContinuationToken) > 0 {
+			list, err = db.Scan(&schema.ScanRequest{Prefix: []byte("key"), Limit: 100, ContinuationToken: list.ContinuationToken})
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(list.Entries), 4)
+
+			for _, e := range list.Entries {
+				keys = append(keys, string(e.Key))
+			}
+		}
+
+		require.Len(t, keys, 10)
+		for i, k := range keys {
+			require.Equal(t, fmt.Sprintf("key%d", i), k)
+		}
+	})
+
+	t.Run("requests within the page size should not be truncated", func(t *testing.T) {
+		list, err := db.Scan(&schema.ScanRequest{Prefix: []byte("key"), Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, list.Entries, 2)
+
+		list, err = db.Scan(&schema.ScanRequest{Prefix: []byte("key"), SeekKey: []byte("key7"), Limit: 4})
+		require.NoError(t, err)
+		require.Len(t, list.Entries, 2)
+		require.Empty(t, list.ContinuationToken)
+	})
+
+	t.Run("zscan pages should cover all the entries", func(t *testing.T) {
+		var scores []float64
+
+		req := &schema.ZScanRequest{Set: []byte("set"), Desc: true}
+
+		for {
+			list, err := db.ZScan(req)
+			require.NoError(t, err)
+
+			for _, e := range list.Entries {
+				scores = append(scores, e.Score)
+			}
+
+			if len(list.ContinuationToken) == 0 {
+				break
+			}
+			req.ContinuationToken = list.ContinuationToken
+		}
+
+		require.Equal(t, []float64{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, scores)
+	})
+
+	t.Run("history pages should cover all the entries", func(t *testing.T) {
+		var values []string
+
+		req := &schema.HistoryRequest{Key: []byte("counter"), Limit: 1000}
+
+		for {
+			list, err := db.History(req)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(list.Entries), 4)
+
+			for _, e := range list.Entries {
+				values = append(values, string(e.Value))
+			}
+
+			if len(list.ContinuationToken) == 0 {
+				break
+			}
+			req.ContinuationToken = list.ContinuationToken
+		}
+
+		require.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, values)
+	})
+
+	t.Run("sql query pages should cover all the rows", func(t *testing.T) {
+		_, _, err := db.SQLExec(&schema.SQLExecRequest{Sql: "CREATE TABLE mytable(id INTEGER, PRIMARY KEY id)"}, nil)
+		require.NoError(t, err)
+
+		for i := 0; i < 10; i++ {
+			_, _, err = db.SQLExec(&schema.SQLExecRequest{
+				Sql:    "INSERT INTO mytable(id) VALUES (@id)",
+				Params: []*schema.NamedParam{{Name: "id", Value: &schema.SQLValue{Value: &schema.SQLValue_N{N: int64(i)}}}},
+			}, nil)
+			require.NoError(t, err)
+		}
+
+		var ids []int64
+
+		req := &schema.SQLQueryRequest{Sql: "SELECT id FROM mytable"}
+
+		for {
+			res, err := db.SQLQuery(req, nil)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(res.Rows), 3)
+
+			for _, row := range res.Rows {
+				ids = append(ids, row.Values[0].GetN())
+			}
+
+			if len(res.ContinuationToken) == 0 {
+				break
+			}
+			req.ContinuationToken = res.ContinuationToken
+		}
+
+		require.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, ids)
+
+		res, err := db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT id FROM mytable LIMIT 6"}, nil)
+		require.NoError(t, err)
+		require.Len(t, res.Rows, 4)
+
+		res, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT id FROM mytable LIMIT 6", ContinuationToken: res.ContinuationToken}, nil)
+		require.NoError(t, err)
+		require.Len(t, res.Rows, 2)
+		require.Empty(t, res.ContinuationToken)
+	})
+
+	t.Run("tokens should not be accepted by other requests", func(t *testing.T) {
+		list, err := db.Scan(&schema.ScanRequest{Prefix: []byte("key")})
+		require.NoError(t, err)
+
+		_, err = db.History(&schema.HistoryRequest{Key: []byte("counter"), ContinuationToken: list.ContinuationToken})
+		require.ErrorIs(t, err, ErrInvalidContinuationToken)
+
+		_, err = db.ZScan(&schema.ZScanRequest{Set: []byte("set"), ContinuationToken: list.ContinuationToken})
+		require.ErrorIs(t, err, ErrInvalidContinuationToken)
+
+		_, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT id FROM mytable", ContinuationToken: []byte{byte(sqlPage)}}, nil)
+		require.ErrorIs(t, err, ErrInvalidContinuationToken)
+	})
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"github.com/codenotary/immudb/embedded/cache"
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+const DefaultProofCacheSize = 1000
+
+type proofKey struct {
+	sourceTxID uint64
+	targetTxID uint64
+}
+
+// proofBatchSize is the number of adjacent transactions whose proofs are generated together,
+// see batchedDualProof
+const proofBatchSize = 16
+
+// dualProof returns the proof between both transactions, recently generated proofs
+// are served from the cache as they never change once both transactions are committed
+func (d *db) dualProof(sourceTx, targetTx *store.Tx) (*store.DualProof, error) {
+	return d.cachedDualProof(sourceTx, targetTx, func() ([]*store.DualProof, error) {
+		proof, err := d.st.DualProof(sourceTx, targetTx)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*store.DualProof{proof}, nil
+	})
+}
+
+// batchedDualProof is like dualProof but, as auditors usually poll the transactions following the one
+// they last verified against the same state, on cache misses the proofs of the following transactions
+// are generated in the same pass
+func (d *db) batchedDualProof(sourceTx, targetTx *store.Tx) (*store.DualProof, error) {
+	return d.cachedDualProof(sourceTx, targetTx, func() ([]*store.DualProof, error) {
+		targetTxs, err := d.adjacentTxs(targetTx)
+		if err != nil {
+			return nil, err
+		}
+
+		proofs, err := d.st.DualProofs(sourceTx, targetTxs)
+		if err == store.ErrLinearProofMaxLenExceeded {
+			// the proof of the transaction may be short enough even if the ones of the following transactions are not
+			proof, err := d.st.DualProof(sourceTx, targetTx)
+			if err != nil {
+				return nil, err
+			}
+
+			return []*store.DualProof{proof}, nil
+		}
+
+		return proofs, err
+	})
+}
+
+// cachedDualProof returns the proof from the cache or generates it, together with any other proof
+// from the same source transaction, the requested proof is expected to be the first generated one
+func (d *db) cachedDualProof(sourceTx, targetTx *store.Tx, generate func() ([]*store.DualProof, error)) (*store.DualProof, error) {
+	if d.proofCache == nil || sourceTx == nil || targetTx == nil {
+		return d.st.DualProof(sourceTx, targetTx)
+	}
+
+	key := proofKey{sourceTxID: sourceTx.Header().ID, targetTxID: targetTx.Header().ID}
+
+	cachedProof, err := d.proofCache.Get(key)
+	if err == nil {
+		return cachedProof.(*store.DualProof), nil
+	}
+	if err != cache.ErrKeyNotFound {
+		return nil, err
+	}
+
+	proofs, err := generate()
+	if err != nil {
+		return nil, err
+	}
+
+	// the requested proof is the last one being cached, thus the most recently used one
+	for i := len(proofs) - 1; i >= 0; i-- {
+		proof := proofs[i]
+		key := proofKey{sourceTxID: proof.SourceTxHeader.ID, targetTxID: proof.TargetTxHeader.ID}
+
+		_, _, err = d.proofCache.Put(key, proof)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return proofs[0], nil
+}
+
+// adjacentTxs returns the transaction followed by the committed ones, up to the size of a batch of proofs
+func (d *db) adjacentTxs(tx *store.Tx) ([]*store.Tx, error) {
+	lastTxID, _ := d.st.Alh()
+
+	txs := []*store.Tx{tx}
+
+	for txID := tx.Header().ID + 1; txID <= lastTxID && len(txs) < proofBatchSize; txID++ {
+		adjacentTx := d.st.NewTxHolder()
+
+		err := d.st.ReadTx(txID, adjacentTx)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, adjacentTx)
+	}
+
+	return txs, nil
+}
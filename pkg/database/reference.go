@@ -41,12 +41,13 @@ func (d *db) SetReference(req *schema.ReferenceRequest) (*schema.TxHeader, error
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	if d.isReplica() {
-		return nil, ErrIsReplica
+	err := d.checkWritable()
+	if err != nil {
+		return nil, err
 	}
 
 	lastTxID, _ := d.st.Alh()
-	err := d.st.WaitForIndexingUpto(lastTxID, nil)
+	err = d.st.WaitForIndexingUpto(lastTxID, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -134,13 +135,10 @@ func (d *db) VerifiableSetReference(req *schema.VerifiableReferenceRequest) (*sc
 		}
 	}
 
-	dualProof, err := d.st.DualProof(prevTx, lastTx)
+	dualProof, err := d.dualProof(prevTx, lastTx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &schema.VerifiableTx{
-		Tx:        schema.TxToProto(lastTx),
-		DualProof: schema.DualProofToProto(dualProof),
-	}, nil
+	return d.verifiableTx(lastTx, dualProof)
 }
@@ -127,3 +127,56 @@ func TestSwitchToReplica(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestReadOnlyDatabase(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: "CREATE TABLE mytable(id INTEGER, title VARCHAR, PRIMARY KEY id)"}, nil)
+	require.NoError(t, err)
+
+	require.False(t, db.IsReadOnly())
+
+	db.AsReadOnly(true)
+	require.True(t, db.IsReadOnly())
+
+	_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key2"), Value: []byte("value2")}}})
+	require.ErrorIs(t, err, ErrIsReadOnly)
+
+	_, err = db.ExecAll(&schema.ExecAllRequest{
+		Operations: []*schema.Op{
+			{Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte("key2"), Value: []byte("value2")}}},
+		}},
+	)
+	require.ErrorIs(t, err, ErrIsReadOnly)
+
+	_, err = db.SetReference(&schema.ReferenceRequest{Key: []byte("ref"), ReferencedKey: []byte("key1")})
+	require.ErrorIs(t, err, ErrIsReadOnly)
+
+	_, err = db.ZAdd(&schema.ZAddRequest{Set: []byte("set"), Score: 1, Key: []byte("key1")})
+	require.ErrorIs(t, err, ErrIsReadOnly)
+
+	_, err = db.Delete(&schema.DeleteKeysRequest{Keys: [][]byte{[]byte("key1")}})
+	require.ErrorIs(t, err, ErrIsReadOnly)
+
+	_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: "INSERT INTO mytable(id, title) VALUES (1, 'title1')"}, nil)
+	require.ErrorIs(t, err, ErrIsReadOnly)
+
+	t.Run("reads should not be affected", func(t *testing.T) {
+		entry, err := db.Get(&schema.KeyRequest{Key: []byte("key1")})
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), entry.Value)
+
+		_, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT * FROM mytable"}, nil)
+		require.NoError(t, err)
+	})
+
+	db.AsReadOnly(false)
+	require.False(t, db.IsReadOnly())
+
+	_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key2"), Value: []byte("value2")}}})
+	require.NoError(t, err)
+}
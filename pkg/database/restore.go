@@ -0,0 +1,248 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+var ErrPinnedEntryNotRestorable = errors.New("entry pinned to a version not included in the restored state")
+
+// pinnedEntry is a reference or sorted set entry bound to a specific tx,
+// the tx is remapped once the referenced key has been copied into the destination
+type pinnedEntry struct {
+	key   []byte
+	md    *store.KVMetadata
+	value []byte
+
+	refKey []byte
+	atTx   uint64
+	txOff  int // offset of the tx id within the key (sorted sets) or the value (references)
+	inKey  bool
+}
+
+// CopyStateAt writes into dst the state of the database as of the specified tx.
+// Only the latest version of each key is copied, thus history is not preserved and tx ids in dst differ
+// from the original ones. References and sorted set entries pinned to a tx are remapped to the tx in which
+// the referenced key was copied, it fails with ErrPinnedEntryNotRestorable when the pinned version is not
+// the one included in the restored state.
+func (d *db) CopyStateAt(txID uint64, dst DB) error {
+	dstDB, ok := dst.(*db)
+	if !ok || dstDB == d || txID == 0 {
+		return ErrIllegalArguments
+	}
+
+	lastTxID, _ := d.st.Alh()
+	if txID > lastTxID {
+		return ErrIllegalState
+	}
+
+	err := dstDB.checkWritable()
+	if err != nil {
+		return err
+	}
+
+	err = d.st.WaitForIndexingUpto(txID, nil)
+	if err != nil {
+		return err
+	}
+
+	snap, err := d.st.SnapshotSince(txID)
+	if err != nil {
+		return err
+	}
+	defer snap.Close()
+
+	r, err := snap.NewKeyReader(&store.KeyReaderSpec{Filter: store.IgnoreDeleted})
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	var pinned []*pinnedEntry
+
+	batchSize := dstDB.st.MaxTxEntries()
+	batch := make([]*store.EntrySpec, 0, batchSize)
+
+	for {
+		key, valRef, _, err := r.ReadAsBefore(txID + 1)
+		if err == store.ErrKeyNotFound || err == store.ErrExpiredEntry {
+			// deleted or expired as of the snapshot
+			continue
+		}
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		val, err := valRef.Resolve()
+		if err != nil {
+			return err
+		}
+
+		e := pinnedEntryFrom(key, valRef.KVMetadata(), val)
+		if e != nil {
+			pinned = append(pinned, e)
+			continue
+		}
+
+		if key[0] == SetKeyPrefix && len(val) > 0 && val[0] == EncryptedValuePrefix {
+			// data keys are kept outside the store, encrypted values are only readable if their key is copied as well
+			err = d.keys.copyTo(dstDB.keys, TrimPrefix(key))
+			if err != nil {
+				return err
+			}
+		}
+
+		batch = append(batch, &store.EntrySpec{Key: key, Metadata: valRef.KVMetadata(), Value: val})
+
+		if len(batch) == batchSize {
+			err = dstDB.commitEntries(batch)
+			if err != nil {
+				return err
+			}
+
+			batch = batch[:0]
+		}
+	}
+
+	err = dstDB.commitEntries(batch)
+	if err != nil {
+		return err
+	}
+
+	if len(pinned) == 0 {
+		return nil
+	}
+
+	dstLastTxID, _ := dstDB.st.Alh()
+
+	err = dstDB.st.WaitForIndexingUpto(dstLastTxID, nil)
+	if err != nil {
+		return err
+	}
+
+	batch = batch[:0]
+
+	for _, e := range pinned {
+		newTx, err := d.remappedTx(snap, dstDB, txID, e)
+		if err != nil {
+			return err
+		}
+
+		if e.inKey {
+			binary.BigEndian.PutUint64(e.key[e.txOff:], newTx)
+		} else {
+			binary.BigEndian.PutUint64(e.value[e.txOff:], newTx)
+		}
+
+		batch = append(batch, &store.EntrySpec{Key: e.key, Metadata: e.md, Value: e.value})
+
+		if len(batch) == batchSize {
+			err = dstDB.commitEntries(batch)
+			if err != nil {
+				return err
+			}
+
+			batch = batch[:0]
+		}
+	}
+
+	return dstDB.commitEntries(batch)
+}
+
+func pinnedEntryFrom(key []byte, md *store.KVMetadata, val []byte) *pinnedEntry {
+	switch {
+	case key[0] == SetKeyPrefix && len(val) >= 1+txIDLen && val[0] == ReferenceValuePrefix:
+		atTx := binary.BigEndian.Uint64(val[1:])
+		if atTx == 0 {
+			return nil
+		}
+
+		return &pinnedEntry{key: key, md: md, value: val, refKey: val[1+txIDLen:], atTx: atTx, txOff: 1}
+	case key[0] == SortedSetKeyPrefix && len(key) >= 1+setLenLen+scoreLen+keyLenLen+txIDLen:
+		atTx := binary.BigEndian.Uint64(key[len(key)-txIDLen:])
+		if atTx == 0 {
+			return nil
+		}
+
+		setLen := binary.BigEndian.Uint64(key[1:])
+		keyOff := 1 + setLenLen + int(setLen) + scoreLen + keyLenLen
+
+		return &pinnedEntry{key: key, md: md, value: val, refKey: key[keyOff : len(key)-txIDLen], atTx: atTx, txOff: len(key) - txIDLen, inKey: true}
+	}
+
+	return nil
+}
+
+// remappedTx returns the tx in dst holding the pinned version of the referenced key
+func (d *db) remappedTx(snap *store.Snapshot, dst *db, txID uint64, e *pinnedEntry) (uint64, error) {
+	r, err := snap.NewKeyReader(&store.KeyReaderSpec{
+		SeekKey:       e.refKey,
+		EndKey:        e.refKey,
+		InclusiveSeek: true,
+		InclusiveEnd:  true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	_, _, restoredTx, err := r.ReadAsBefore(txID + 1)
+	if err != nil && err != store.ErrNoMoreEntries {
+		return 0, err
+	}
+	if err == store.ErrNoMoreEntries || restoredTx != e.atTx {
+		return 0, fmt.Errorf("%w (key '%s' at tx %d)", ErrPinnedEntryNotRestorable, e.refKey[1:], e.atTx)
+	}
+
+	valRef, err := dst.st.Get(e.refKey)
+	if err != nil {
+		return 0, err
+	}
+
+	return valRef.Tx(), nil
+}
+
+func (d *db) commitEntries(entries []*store.EntrySpec) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	tx, err := d.st.NewWriteOnlyTx()
+	if err != nil {
+		return err
+	}
+	defer tx.Cancel()
+
+	for _, e := range entries {
+		err = tx.Set(e.Key, e.Metadata, e.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Commit()
+
+	return err
+}
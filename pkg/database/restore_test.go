@@ -0,0 +1,113 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyStateAt(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, _, err := db.SQLExec(&schema.SQLExecRequest{Sql: "CREATE TABLE table1(id INTEGER, title VARCHAR, PRIMARY KEY id)"}, nil)
+	require.NoError(t, err)
+
+	_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: "INSERT INTO table1(id, title) VALUES (1, 'title1'), (2, 'title2')"}, nil)
+	require.NoError(t, err)
+
+	_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	hdr, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key2"), Value: []byte("value2")}}})
+	require.NoError(t, err)
+
+	_, err = db.SetReference(&schema.ReferenceRequest{Key: []byte("ref1"), ReferencedKey: []byte("key2"), AtTx: hdr.Id, BoundRef: true})
+	require.NoError(t, err)
+
+	_, err = db.ZAdd(&schema.ZAddRequest{Set: []byte("set1"), Score: 1, Key: []byte("key2"), AtTx: hdr.Id, BoundRef: true})
+	require.NoError(t, err)
+
+	_, err = db.Delete(&schema.DeleteKeysRequest{Keys: [][]byte{[]byte("key1")}})
+	require.NoError(t, err)
+
+	restoredTx, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key3"), Value: []byte("value3")}}})
+	require.NoError(t, err)
+
+	// changes after the restored tx
+	_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key3"), Value: []byte("value3-updated")}}})
+	require.NoError(t, err)
+
+	_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: "UPDATE table1 SET title = 'title3' WHERE id = 1"}, nil)
+	require.NoError(t, err)
+
+	dst, dstCloser := makeDb()
+	defer dstCloser()
+
+	err = db.CopyStateAt(0, dst)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = db.CopyStateAt(restoredTx.Id, db)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = db.CopyStateAt(restoredTx.Id+100, dst)
+	require.Equal(t, ErrIllegalState, err)
+
+	err = db.CopyStateAt(restoredTx.Id, dst)
+	require.NoError(t, err)
+
+	_, err = dst.Get(&schema.KeyRequest{Key: []byte("key1")})
+	require.Equal(t, store.ErrKeyNotFound, err)
+
+	entry, err := dst.Get(&schema.KeyRequest{Key: []byte("key3")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value3"), entry.Value)
+
+	entry, err = dst.Get(&schema.KeyRequest{Key: []byte("ref1")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), entry.Value)
+	require.Equal(t, []byte("ref1"), entry.ReferencedBy.Key)
+
+	zentries, err := dst.ZScan(&schema.ZScanRequest{Set: []byte("set1")})
+	require.NoError(t, err)
+	require.Len(t, zentries.Entries, 1)
+	require.Equal(t, []byte("value2"), zentries.Entries[0].Entry.Value)
+
+	res, err := dst.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT id, title FROM table1"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+	require.Equal(t, "title1", res.Rows[0].Values[1].GetS())
+
+	t.Run("pinned versions not included in the restored state should not be restored", func(t *testing.T) {
+		_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key2"), Value: []byte("value2-updated")}}})
+		require.NoError(t, err)
+
+		lastTx, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key4"), Value: []byte("value4")}}})
+		require.NoError(t, err)
+
+		dst, dstCloser := makeDb()
+		defer dstCloser()
+
+		err = db.CopyStateAt(lastTx.Id, dst)
+		require.True(t, errors.Is(err, ErrPinnedEntryNotRestorable))
+	})
+}
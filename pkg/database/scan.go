@@ -31,10 +31,6 @@ func (d *db) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
 		return nil, store.ErrIllegalArguments
 	}
 
-	if req.Limit > MaxKeyScanLimit {
-		return nil, ErrMaxKeyScanLimitExceeded
-	}
-
 	waitUntilTx := req.SinceTx
 	if waitUntilTx == 0 {
 		waitUntilTx = currTxID
@@ -47,13 +43,15 @@ func (d *db) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
 		}
 	}
 
-	limit := req.Limit
-
-	if req.Limit == 0 {
-		limit = MaxKeyScanLimit
+	txs, err := d.txRangeByTime(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
 	}
 
+	limit := d.pageSize(req.Limit)
+
 	var entries []*schema.Entry
+	var lastKey []byte
 	i := uint64(0)
 
 	snap, err := d.st.SnapshotSince(waitUntilTx)
@@ -64,8 +62,15 @@ func (d *db) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
 
 	seekKey := req.SeekKey
 
+	if len(req.ContinuationToken) > 0 {
+		seekKey, err = positionFromToken(scanPage, req.ContinuationToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if len(seekKey) > 0 {
-		seekKey = EncodeKey(req.SeekKey)
+		seekKey = EncodeKey(seekKey)
 	}
 
 	r, err := snap.NewKeyReader(
@@ -91,6 +96,10 @@ func (d *db) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
 			return nil, err
 		}
 
+		if !txs.contains(valRef.Tx()) {
+			continue
+		}
+
 		e, err := d.getAt(key, valRef.Tx(), 0, snap, tx)
 		if err == store.ErrKeyNotFound {
 			// ignore deleted ones (referenced key may have been deleted)
@@ -101,12 +110,21 @@ func (d *db) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
 		}
 
 		entries = append(entries, e)
+		// references are resolved, so the next page is positioned by the scanned key
+		lastKey = TrimPrefix(key)
+
 		if i++; i == limit {
 			break
 		}
 	}
 
-	return &schema.Entries{
+	list := &schema.Entries{
 		Entries: entries,
-	}, nil
+	}
+
+	if i == limit {
+		list.ContinuationToken = newContinuationToken(scanPage, lastKey)
+	}
+
+	return list, nil
 }
@@ -39,8 +39,9 @@ func TestStoreScan(t *testing.T) {
 		Desc:    true,
 	}
 
+	// requests exceeding the max page size are truncated
 	_, err = db.Scan(&scanOptions)
-	require.Equal(t, ErrMaxKeyScanLimitExceeded, err)
+	require.NoError(t, err)
 
 	scanOptions = schema.ScanRequest{
 		SeekKey: []byte(`b`),
@@ -44,12 +44,13 @@ func (d *db) ZAdd(req *schema.ZAddRequest) (*schema.TxHeader, error) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	if d.isReplica() {
-		return nil, ErrIsReplica
+	err := d.checkWritable()
+	if err != nil {
+		return nil, err
 	}
 
 	lastTxID, _ := d.st.Alh()
-	err := d.st.WaitForIndexingUpto(lastTxID, nil)
+	err = d.st.WaitForIndexingUpto(lastTxID, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -98,14 +99,20 @@ func (d *db) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
 		return nil, store.ErrIllegalArguments
 	}
 
-	if req.Limit > MaxKeyScanLimit {
-		return nil, ErrMaxKeyScanLimitExceeded
-	}
+	limit := d.pageSize(req.Limit)
 
-	limit := req.Limit
+	seekKey := req.SeekKey
+	seekScore := req.SeekScore
+	seekAtTx := req.SeekAtTx
+	inclusiveSeek := req.InclusiveSeek
 
-	if req.Limit == 0 {
-		limit = MaxKeyScanLimit
+	if len(req.ContinuationToken) > 0 {
+		pos, err := zScanPositionFromToken(req.ContinuationToken)
+		if err != nil {
+			return nil, err
+		}
+
+		seekKey, seekScore, seekAtTx, inclusiveSeek = pos.key, pos.score, pos.atTx, false
 	}
 
 	d.mutex.RLock()
@@ -140,14 +147,14 @@ func (d *db) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
 	binary.BigEndian.PutUint64(prefix[1:], uint64(len(req.Set)))
 	copy(prefix[1+setLenLen:], req.Set)
 
-	var seekKey []byte
+	var zSeekKey []byte
 
-	if len(req.SeekKey) == 0 {
-		seekKey = make([]byte, len(prefix)+scoreLen)
-		copy(seekKey, prefix)
+	if len(seekKey) == 0 {
+		zSeekKey = make([]byte, len(prefix)+scoreLen+keyLenLen)
+		copy(zSeekKey, prefix)
 		// here we compose the offset if Min score filter is provided only if is not reversed order
 		if req.MinScore != nil && !req.Desc {
-			binary.BigEndian.PutUint64(seekKey[len(prefix):], math.Float64bits(req.MinScore.Score))
+			binary.BigEndian.PutUint64(zSeekKey[len(prefix):], math.Float64bits(req.MinScore.Score))
 		}
 		// here we compose the offset if Max score filter is provided only if is reversed order
 		if req.Desc {
@@ -159,22 +166,24 @@ func (d *db) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
 				maxScore = req.MaxScore.Score
 			}
 
-			binary.BigEndian.PutUint64(seekKey[len(prefix):], math.Float64bits(maxScore))
+			binary.BigEndian.PutUint64(zSeekKey[len(prefix):], math.Float64bits(maxScore))
+			// entries holding the max score are sorted after it, hence the highest key length is used
+			binary.BigEndian.PutUint64(zSeekKey[len(prefix)+scoreLen:], math.MaxUint64)
 		}
 	} else {
-		seekKey = make([]byte, len(prefix)+scoreLen+keyLenLen+1+len(req.SeekKey)+txIDLen)
-		copy(seekKey, prefix)
-		binary.BigEndian.PutUint64(seekKey[len(prefix):], math.Float64bits(req.SeekScore))
-		binary.BigEndian.PutUint64(seekKey[len(prefix)+scoreLen:], uint64(1+len(req.SeekKey)))
-		copy(seekKey[len(prefix)+scoreLen+keyLenLen:], EncodeKey(req.SeekKey))
-		binary.BigEndian.PutUint64(seekKey[len(prefix)+scoreLen+keyLenLen+1+len(req.SeekKey):], req.SeekAtTx)
+		zSeekKey = make([]byte, len(prefix)+scoreLen+keyLenLen+1+len(seekKey)+txIDLen)
+		copy(zSeekKey, prefix)
+		binary.BigEndian.PutUint64(zSeekKey[len(prefix):], math.Float64bits(seekScore))
+		binary.BigEndian.PutUint64(zSeekKey[len(prefix)+scoreLen:], uint64(1+len(seekKey)))
+		copy(zSeekKey[len(prefix)+scoreLen+keyLenLen:], EncodeKey(seekKey))
+		binary.BigEndian.PutUint64(zSeekKey[len(prefix)+scoreLen+keyLenLen+1+len(seekKey):], seekAtTx)
 	}
 
 	r, err := snap.NewKeyReader(
 		&store.KeyReaderSpec{
-			SeekKey:       seekKey,
+			SeekKey:       zSeekKey,
 			Prefix:        prefix,
-			InclusiveSeek: req.InclusiveSeek,
+			InclusiveSeek: inclusiveSeek,
 			DescOrder:     req.Desc,
 			Filter:        store.IgnoreDeleted,
 		})
@@ -202,11 +211,18 @@ func (d *db) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
 		scoreB := binary.BigEndian.Uint64(zKey[scoreOff:])
 		score := math.Float64frombits(scoreB)
 
-		// Guard to ensure that score match the filter range if filter is provided
+		// Guard to ensure that score match the filter range if filter is provided,
+		// no further entry can match once the bound in scan order has been passed
 		if req.MinScore != nil && score < req.MinScore.Score {
+			if req.Desc {
+				break
+			}
 			continue
 		}
 		if req.MaxScore != nil && score > req.MaxScore.Score {
+			if !req.Desc {
+				break
+			}
 			continue
 		}
 
@@ -243,9 +259,48 @@ func (d *db) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
 		Entries: entries,
 	}
 
+	if i == limit {
+		last := entries[len(entries)-1]
+		list.ContinuationToken = newZScanToken(&zScanPosition{score: last.Score, atTx: last.AtTx, key: last.Key})
+	}
+
 	return list, nil
 }
 
+// VerifiableZScan returns the same entries as ZScan together with the proofs of the referenced entries
+func (d *db) VerifiableZScan(req *schema.VerifiableZScanRequest) (*schema.VerifiableZEntries, error) {
+	if req == nil {
+		return nil, store.ErrIllegalArguments
+	}
+
+	lastTxID, _ := d.st.Alh()
+	if lastTxID < req.ProveSinceTx {
+		return nil, store.ErrIllegalArguments
+	}
+
+	list, err := d.ZScan(req.ZScanRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*schema.Entry, len(list.Entries))
+	for i, ze := range list.Entries {
+		entries[i] = ze.Entry
+	}
+
+	inclusionProofs, txProofs, vTx, err := d.proveEntries(entries, req.ProveSinceTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.VerifiableZEntries{
+		Entries:         list.Entries,
+		InclusionProofs: inclusionProofs,
+		TxProofs:        txProofs,
+		VerifiableTx:    vTx,
+	}, nil
+}
+
 //VerifiableZAdd ...
 func (d *db) VerifiableZAdd(req *schema.VerifiableZAddRequest) (*schema.VerifiableTx, error) {
 	if req == nil {
@@ -282,13 +337,10 @@ func (d *db) VerifiableZAdd(req *schema.VerifiableZAddRequest) (*schema.Verifiab
 		}
 	}
 
-	dualProof, err := d.st.DualProof(prevTx, lastTx)
+	dualProof, err := d.dualProof(prevTx, lastTx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &schema.VerifiableTx{
-		Tx:        schema.TxToProto(lastTx),
-		DualProof: schema.DualProofToProto(dualProof),
-	}, nil
+	return d.verifiableTx(lastTx, dualProof)
 }
@@ -16,6 +16,7 @@ limitations under the License.
 package database
 
 import (
+	"fmt"
 	"math"
 	"testing"
 
@@ -82,8 +83,9 @@ func TestStoreIndexExists(t *testing.T) {
 		Limit: MaxKeyScanLimit + 1,
 	}
 
+	// requests exceeding the max page size are truncated
 	_, err = db.ZScan(zscanOpts)
-	require.Equal(t, ErrMaxKeyScanLimitExceeded, err)
+	require.NoError(t, err)
 
 	//try to retrieve directly the value or full scan to debug
 
@@ -717,3 +719,65 @@ func TestStoreVerifiableZAdd(t *testing.T) {
 	require.Equal(t, req.Key, itemList1.Entries[0].Entry.Key)
 	require.Equal(t, req.Score, itemList1.Entries[0].Score)
 }
+
+func TestStoreVerifiableZScan(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.VerifiableZScan(nil)
+	require.Equal(t, store.ErrIllegalArguments, err)
+
+	var hdr *schema.TxHeader
+
+	for i := 1; i <= 4; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+
+		_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: key, Value: key}}})
+		require.NoError(t, err)
+
+		hdr, err = db.ZAdd(&schema.ZAddRequest{Set: []byte("set1"), Key: key, Score: float64(i)})
+		require.NoError(t, err)
+	}
+
+	_, err = db.VerifiableZScan(&schema.VerifiableZScanRequest{
+		ZScanRequest: &schema.ZScanRequest{Set: []byte("set1")},
+		ProveSinceTx: hdr.Id + 1,
+	})
+	require.Equal(t, store.ErrIllegalArguments, err)
+
+	vEntries, err := db.VerifiableZScan(&schema.VerifiableZScanRequest{
+		ZScanRequest: &schema.ZScanRequest{
+			Set:      []byte("set1"),
+			MinScore: &schema.Score{Score: 2},
+			MaxScore: &schema.Score{Score: 3},
+			Desc:     true,
+		},
+		ProveSinceTx: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, vEntries.Entries, 2)
+	require.Len(t, vEntries.InclusionProofs, 2)
+	require.Equal(t, []byte("key3"), vEntries.Entries[0].Key)
+	require.Equal(t, []byte("key2"), vEntries.Entries[1].Key)
+	require.Equal(t, vEntries.Entries[0].Entry.Tx, vEntries.VerifiableTx.Tx.Header.Id)
+	require.Contains(t, vEntries.TxProofs, vEntries.Entries[1].Entry.Tx)
+
+	tx := schema.TxFromProto(vEntries.VerifiableTx.Tx)
+
+	entrySpecDigest, err := store.EntrySpecDigestFor(tx.Header().Version)
+	require.NoError(t, err)
+
+	verifies := store.VerifyInclusion(
+		schema.InclusionProofFromProto(vEntries.InclusionProofs[0]),
+		entrySpecDigest(EncodeEntry(vEntries.Entries[0].Key, vEntries.Entries[0].Entry)),
+		tx.Header().Eh,
+	)
+	require.True(t, verifies)
+
+	vEntries, err = db.VerifiableZScan(&schema.VerifiableZScanRequest{
+		ZScanRequest: &schema.ZScanRequest{Set: []byte("set1"), MinScore: &schema.Score{Score: 5}},
+	})
+	require.NoError(t, err)
+	require.Empty(t, vEntries.Entries)
+	require.Nil(t, vEntries.VerifiableTx)
+}
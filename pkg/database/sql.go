@@ -17,8 +17,11 @@ package database
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"sort"
 	"strings"
 	"time"
 
@@ -28,6 +31,8 @@ import (
 )
 
 var ErrSQLNotReady = errors.New("SQL catalog not yet replicated")
+var ErrPinnedSnapshotRequiresTx = errors.New("pinned snapshot requires an ongoing transaction")
+var ErrNotBlobColumn = errors.New("column is not of BLOB type")
 
 func (d *db) reloadSQLCatalog() error {
 	err := d.sqlEngine.SetDefaultDatabase(dbInstanceName)
@@ -58,7 +63,7 @@ func (d *db) VerifiableSQLGet(req *schema.VerifiableSQLGetRequest) (*schema.Veri
 		}
 	}
 
-	catalog, err := d.sqlEngine.Catalog(nil)
+	catalog, err := d.sqlEngine.Catalog(context.Background(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -132,14 +137,14 @@ func (d *db) VerifiableSQLGet(req *schema.VerifiableSQLGetRequest) (*schema.Veri
 		targetTx = rootTx
 	}
 
-	dualProof, err := d.st.DualProof(sourceTx, targetTx)
+	dualProof, err := d.dualProof(sourceTx, targetTx)
 	if err != nil {
 		return nil, err
 	}
 
-	verifiableTx := &schema.VerifiableTx{
-		Tx:        schema.TxToProto(tx),
-		DualProof: schema.DualProofToProto(dualProof),
+	verifiableTx, err := d.verifiableTx(tx, dualProof)
+	if err != nil {
+		return nil, err
 	}
 
 	colNamesByID := make(map[uint32]string, len(table.Cols()))
@@ -221,7 +226,7 @@ func (d *db) ListTables(tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
 		}
 	}
 
-	catalog, err := d.sqlEngine.Catalog(tx)
+	catalog, err := d.sqlEngine.Catalog(context.Background(), tx)
 	if err != nil {
 		return nil, err
 	}
@@ -240,6 +245,123 @@ func (d *db) ListTables(tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
 	return res, nil
 }
 
+// IndexSuggestions returns the indexes which would have avoided full scans in the queries executed so far
+func (d *db) IndexSuggestions() (*schema.IndexSuggestionList, error) {
+	suggestions, err := d.sqlEngine.IndexSuggestions(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &schema.IndexSuggestionList{}
+
+	for _, s := range suggestions {
+		if s.Database != dbInstanceName {
+			continue
+		}
+
+		res.Suggestions = append(res.Suggestions, &schema.IndexSuggestion{
+			Table:     s.Table,
+			Column:    s.Column,
+			FullScans: s.FullScans,
+			Stmt:      s.Stmt(),
+		})
+	}
+
+	return res, nil
+}
+
+// SQLBlob returns the value of a BLOB column of the row addressed by its primary key, a NULL value is returned as empty
+func (d *db) SQLBlob(req *schema.SQLBlobRequest, tx *sql.SQLTx) ([]byte, error) {
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	table, err := d.sqlTable(req.Table, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	col, err := table.GetColumnByName(req.Column)
+	if err != nil {
+		return nil, err
+	}
+
+	if col.Type() != sql.BLOBType {
+		return nil, fmt.Errorf("%w: '%s'", ErrNotBlobColumn, col.Name())
+	}
+
+	pkCols := table.PrimaryIndex().Cols()
+
+	if len(req.PkValues) != len(pkCols) {
+		return nil, ErrIllegalArguments
+	}
+
+	conds := make([]string, len(pkCols))
+	params := make(map[string]interface{}, len(pkCols))
+
+	for i, pkCol := range pkCols {
+		param := fmt.Sprintf("pk%d", i)
+
+		conds[i] = fmt.Sprintf("%s = @%s", pkCol.Name(), param)
+		params[param] = schema.RawValue(req.PkValues[i])
+	}
+
+	stmts, err := sql.Parse(strings.NewReader(fmt.Sprintf("SELECT %s FROM %s WHERE %s", col.Name(), table.Name(), strings.Join(conds, " AND "))))
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := d.sqlQueryRowReader(context.Background(), stmts[0].(*sql.SelectStmt), tx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	err = r.SetParameters(params)
+	if err != nil {
+		return nil, err
+	}
+
+	cols, err := r.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.Read()
+	if errors.Is(err, sql.ErrNoMoreRows) {
+		return nil, store.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	val := row.Values[cols[0].Selector()]
+	if val.IsNull() {
+		return nil, nil
+	}
+
+	return val.Value().([]byte), nil
+}
+
+func (d *db) sqlTable(tableName string, tx *sql.SQLTx) (*sql.Table, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if d.isReplica() {
+		err := d.reloadSQLCatalog()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	catalog, err := d.sqlEngine.Catalog(context.Background(), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return catalog.GetTableByName(dbInstanceName, tableName)
+}
+
 func (d *db) DescribeTable(tableName string, tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
 	d.mutex.RLock()
 	defer d.mutex.RUnlock()
@@ -251,7 +373,7 @@ func (d *db) DescribeTable(tableName string, tx *sql.SQLTx) (*schema.SQLQueryRes
 		}
 	}
 
-	catalog, err := d.sqlEngine.Catalog(tx)
+	catalog, err := d.sqlEngine.Catalog(context.Background(), tx)
 	if err != nil {
 		return nil, err
 	}
@@ -314,6 +436,64 @@ func (d *db) DescribeTable(tableName string, tx *sql.SQLTx) (*schema.SQLQueryRes
 	return res, nil
 }
 
+// DescribeSchema returns the tables of the database sorted by name, along with their columns and indexes
+func (d *db) DescribeSchema(tx *sql.SQLTx) (*schema.SQLSchema, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if d.isReplica() {
+		err := d.reloadSQLCatalog()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	catalog, err := d.sqlEngine.Catalog(context.Background(), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := catalog.GetDatabaseByName(dbInstanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &schema.SQLSchema{}
+
+	for _, t := range db.GetTables() {
+		table := &schema.TableSchema{Name: t.Name()}
+
+		for _, c := range t.Cols() {
+			table.Columns = append(table.Columns, &schema.ColumnDescriptor{
+				Name:          c.Name(),
+				Type:          c.Type(),
+				MaxLen:        uint32(c.MaxLen()),
+				Nullable:      c.IsNullable(),
+				AutoIncrement: c.IsAutoIncremental(),
+			})
+		}
+
+		for _, index := range t.GetIndexes() {
+			descriptor := &schema.IndexDescriptor{
+				Primary: index.IsPrimary(),
+				Unique:  index.IsUnique(),
+			}
+
+			for _, c := range index.Cols() {
+				descriptor.Columns = append(descriptor.Columns, c.Name())
+			}
+
+			table.Indexes = append(table.Indexes, descriptor)
+		}
+
+		res.Tables = append(res.Tables, table)
+	}
+
+	sort.Slice(res.Tables, func(i, j int) bool { return res.Tables[i].Name < res.Tables[j].Name })
+
+	return res, nil
+}
+
 func (d *db) SQLExec(req *schema.SQLExecRequest, tx *sql.SQLTx) (ntx *sql.SQLTx, ctxs []*sql.SQLTx, err error) {
 	if req == nil {
 		return nil, nil, ErrIllegalArguments
@@ -324,23 +504,79 @@ func (d *db) SQLExec(req *schema.SQLExecRequest, tx *sql.SQLTx) (ntx *sql.SQLTx,
 		return nil, nil, err
 	}
 
+	err = checkSupportedStmts(stmts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txmd, err := schema.TxMetadataFromLabels(req.TxLabels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d.sqlExecPrepared(stmts, req.Params, txmd, tx)
+}
+
+func checkSupportedStmts(stmts []sql.SQLStmt) error {
 	for _, stmt := range stmts {
 		switch stmt.(type) {
 		case *sql.UseDatabaseStmt:
 			{
-				return nil, nil, errors.New("SQL statement not supported. Please use `UseDatabase` operation instead")
+				return errors.New("SQL statement not supported. Please use `UseDatabase` operation instead")
 			}
 		case *sql.CreateDatabaseStmt:
 			{
-				return nil, nil, errors.New("SQL statement not supported. Please use `CreateDatabase` operation instead")
+				return errors.New("SQL statement not supported. Please use `CreateDatabase` operation instead")
+			}
+		case *sql.DropDatabaseStmt:
+			{
+				return errors.New("SQL statement not supported")
 			}
 		}
 	}
 
-	return d.SQLExecPrepared(stmts, req.Params, tx)
+	return nil
+}
+
+// SQLExecScript executes all the statements atomically in a single transaction,
+// primary keys inserted by a statement can be referenced by the following ones as @last_insert_id
+func (d *db) SQLExecScript(req *schema.SQLExecRequest) (*sql.SQLTx, error) {
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	stmts, err := sql.Parse(strings.NewReader(req.Sql))
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkSupportedStmts(stmts)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	err = d.checkWritable()
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]interface{})
+
+	for _, p := range req.Params {
+		params[p.Name] = schema.RawValue(p.Value)
+	}
+
+	return d.sqlEngine.ExecPreparedScript(context.Background(), stmts, params)
 }
 
 func (d *db) SQLExecPrepared(stmts []sql.SQLStmt, namedParams []*schema.NamedParam, tx *sql.SQLTx) (ntx *sql.SQLTx, ctxs []*sql.SQLTx, err error) {
+	return d.sqlExecPrepared(stmts, namedParams, nil, tx)
+}
+
+func (d *db) sqlExecPrepared(stmts []sql.SQLStmt, namedParams []*schema.NamedParam, txmd *store.TxMetadata, tx *sql.SQLTx) (ntx *sql.SQLTx, ctxs []*sql.SQLTx, err error) {
 	if len(stmts) == 0 {
 		return nil, nil, ErrIllegalArguments
 	}
@@ -348,8 +584,9 @@ func (d *db) SQLExecPrepared(stmts []sql.SQLStmt, namedParams []*schema.NamedPar
 	d.mutex.RLock()
 	defer d.mutex.RUnlock()
 
-	if d.isReplica() {
-		return nil, nil, ErrIsReplica
+	err = d.checkWritable()
+	if err != nil {
+		return nil, nil, err
 	}
 
 	params := make(map[string]interface{})
@@ -358,10 +595,15 @@ func (d *db) SQLExecPrepared(stmts []sql.SQLStmt, namedParams []*schema.NamedPar
 		params[p.Name] = schema.RawValue(p.Value)
 	}
 
-	return d.sqlEngine.ExecPreparedStmts(stmts, params, tx)
+	return d.sqlEngine.ExecPreparedStmtsWithMetadata(context.Background(), stmts, params, txmd, tx)
 }
 
 func (d *db) SQLQuery(req *schema.SQLQueryRequest, tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
+	return d.SQLQueryWithCancellation(req, tx, nil)
+}
+
+// SQLQueryWithCancellation fails with sql.ErrCancellationRequested if the cancellation channel is closed before completion
+func (d *db) SQLQueryWithCancellation(req *schema.SQLQueryRequest, tx *sql.SQLTx, cancellation <-chan struct{}) (*schema.SQLQueryResult, error) {
 	if req == nil {
 		return nil, ErrIllegalArguments
 	}
@@ -376,11 +618,83 @@ func (d *db) SQLQuery(req *schema.SQLQueryRequest, tx *sql.SQLTx) (*schema.SQLQu
 		return nil, sql.ErrExpectingDQLStmt
 	}
 
-	return d.SQLQueryPrepared(stmt, req.Params, tx)
+	if tx == nil {
+		switch req.Isolation {
+		case schema.ReadIsolation_PinnedSnapshot:
+			return nil, ErrPinnedSnapshotRequiresTx
+		case schema.ReadIsolation_ReadCommittedNoWait:
+			tx, err = d.NewSQLTx(false)
+			if err != nil {
+				return nil, err
+			}
+			defer tx.Cancel()
+		}
+	}
+
+	var skip uint64
+
+	if len(req.ContinuationToken) > 0 {
+		skip, err = offsetFromToken(sqlPage, req.ContinuationToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := cancellationContext(cancellation)
+	defer cancel()
+
+	return d.sqlQueryPrepared(ctx, stmt, req.Params, tx, skip)
+}
+
+// cancellationContext returns a context which is done once the cancellation channel is closed
+func cancellationContext(cancellation <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	select {
+	case <-cancellation:
+		// already closed, reading should not even start
+		cancel()
+		return ctx, cancel
+	default:
+	}
+
+	if cancellation != nil {
+		go func() {
+			select {
+			case <-cancellation:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	return ctx, cancel
+}
+
+// NewSQLTx creates a transaction on which several queries can be run using the same snapshot.
+// When waitForIndexing is false, the freshest already indexed snapshot is used
+func (d *db) NewSQLTx(waitForIndexing bool) (*sql.SQLTx, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if d.isReplica() {
+		err := d.reloadSQLCatalog()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return d.sqlEngine.NewTx(context.Background(), waitForIndexing)
 }
 
 func (d *db) SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedParam, tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
-	r, err := d.SQLQueryRowReader(stmt, tx)
+	return d.sqlQueryPrepared(context.Background(), stmt, namedParams, tx, 0)
+}
+
+// sqlQueryPrepared returns a page of rows, starting after the skipped ones. As the query is
+// evaluated again for each page, rows may be shifted by the writes made in between
+func (d *db) sqlQueryPrepared(ctx context.Context, stmt *sql.SelectStmt, namedParams []*schema.NamedParam, tx *sql.SQLTx, skip uint64) (*schema.SQLQueryResult, error) {
+	r, err := d.sqlQueryRowReader(ctx, stmt, tx)
 	if err != nil {
 		return nil, err
 	}
@@ -414,11 +728,19 @@ func (d *db) SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedP
 
 	res := &schema.SQLQueryResult{Columns: cols}
 
-	for l := 0; ; l++ {
-		if l == MaxKeyScanLimit {
-			return res, ErrMaxKeyScanLimitExceeded
+	pageSize := d.pageSize(uint64(stmt.Limit()))
+
+	for i := uint64(0); i < skip; i++ {
+		_, err := r.Read()
+		if err == sql.ErrNoMoreRows {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
 		}
+	}
 
+	for uint64(len(res.Rows)) < pageSize {
 		row, err := r.Read()
 		if err == sql.ErrNoMoreRows {
 			break
@@ -437,8 +759,7 @@ func (d *db) SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedP
 
 			v := row.Values[c.Selector()]
 
-			_, isNull := v.(*sql.NullValue)
-			if isNull {
+			if v.IsNull() {
 				rrow.Values[i] = &schema.SQLValue{Value: &schema.SQLValue_Null{}}
 			} else {
 				rrow.Values[i] = typedValueToRowValue(v)
@@ -448,16 +769,27 @@ func (d *db) SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedP
 		res.Rows = append(res.Rows, rrow)
 	}
 
+	read := skip + uint64(len(res.Rows))
+
+	// no more rows follow once the limit of the query is reached
+	if uint64(len(res.Rows)) == pageSize && (stmt.Limit() == 0 || read < uint64(stmt.Limit())) {
+		res.ContinuationToken = newOffsetToken(sqlPage, read)
+	}
+
 	return res, nil
 }
 
 func (d *db) SQLQueryRowReader(stmt *sql.SelectStmt, tx *sql.SQLTx) (sql.RowReader, error) {
-	if stmt == nil {
-		return nil, ErrIllegalArguments
+	if stmt != nil && stmt.Limit() > d.options.maxPageSize {
+		return nil, ErrMaxKeyScanLimitExceeded
 	}
 
-	if stmt.Limit() > MaxKeyScanLimit {
-		return nil, ErrMaxKeyScanLimitExceeded
+	return d.sqlQueryRowReader(context.Background(), stmt, tx)
+}
+
+func (d *db) sqlQueryRowReader(ctx context.Context, stmt *sql.SelectStmt, tx *sql.SQLTx) (sql.RowReader, error) {
+	if stmt == nil {
+		return nil, ErrIllegalArguments
 	}
 
 	d.mutex.RLock()
@@ -470,7 +802,7 @@ func (d *db) SQLQueryRowReader(stmt *sql.SelectStmt, tx *sql.SQLTx) (sql.RowRead
 		}
 	}
 
-	return d.sqlEngine.QueryPreparedStmt(stmt, nil, tx)
+	return d.sqlEngine.QueryPreparedStmt(ctx, stmt, nil, tx)
 }
 
 func (d *db) InferParameters(sql string, tx *sql.SQLTx) (map[string]sql.SQLValueType, error) {
@@ -484,7 +816,7 @@ func (d *db) InferParameters(sql string, tx *sql.SQLTx) (map[string]sql.SQLValue
 		}
 	}
 
-	return d.sqlEngine.InferParameters(sql, tx)
+	return d.sqlEngine.InferParameters(context.Background(), sql, tx)
 }
 
 func (d *db) InferParametersPrepared(stmt sql.SQLStmt, tx *sql.SQLTx) (map[string]sql.SQLValueType, error) {
@@ -498,7 +830,107 @@ func (d *db) InferParametersPrepared(stmt sql.SQLStmt, tx *sql.SQLTx) (map[strin
 		}
 	}
 
-	return d.sqlEngine.InferParametersPreparedStmts([]sql.SQLStmt{stmt}, tx)
+	return d.sqlEngine.InferParametersPreparedStmts(context.Background(), []sql.SQLStmt{stmt}, tx)
+}
+
+// SQLListen notifies the primary keys of the rows of a table affected by each committed transaction.
+// Transactions are processed starting from the initial one (or the next one when unspecified)
+// until notify returns an error or the cancellation channel is closed
+func (d *db) SQLListen(req *schema.SQLListenRequest, cancellation <-chan struct{}, notify func(*schema.SQLNotification) error) error {
+	if req == nil || notify == nil {
+		return ErrIllegalArguments
+	}
+
+	d.mutex.RLock()
+
+	if d.isReplica() {
+		err := d.reloadSQLCatalog()
+		if err != nil {
+			d.mutex.RUnlock()
+			return err
+		}
+	}
+
+	catalog, err := d.sqlEngine.Catalog(context.Background(), nil)
+	d.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	table, err := catalog.GetTableByName(dbInstanceName, req.Table)
+	if err != nil {
+		return err
+	}
+
+	pkPrefix := sql.MapKey(
+		[]byte{SQLPrefix},
+		sql.PIndexPrefix,
+		sql.EncodeID(table.Database().ID()),
+		sql.EncodeID(table.ID()),
+		sql.EncodeID(sql.PKIndexID),
+	)
+
+	pkCols := make([]string, len(table.PrimaryIndex().Cols()))
+	for i, col := range table.PrimaryIndex().Cols() {
+		pkCols[i] = col.Name()
+	}
+
+	txID := req.InitialTx
+	if txID == 0 {
+		lastTxID, _ := d.st.Alh()
+		txID = lastTxID + 1
+	}
+
+	tx := d.st.NewTxHolder()
+
+	for ; ; txID++ {
+		err = d.st.WaitForTx(txID, cancellation)
+		if err != nil {
+			return err
+		}
+
+		err = d.st.ReadTx(txID, tx)
+		if err != nil {
+			return err
+		}
+
+		var pks []*schema.Row
+
+		for _, e := range tx.Entries() {
+			if !bytes.HasPrefix(e.Key(), pkPrefix) {
+				continue
+			}
+
+			pkVals, err := table.DecodePrimaryKey([]byte{SQLPrefix}, e.Key())
+			if err != nil {
+				return err
+			}
+
+			row := &schema.Row{
+				Columns: pkCols,
+				Values:  make([]*schema.SQLValue, len(pkVals)),
+			}
+
+			for i, v := range pkVals {
+				row.Values[i] = typedValueToRowValue(v)
+			}
+
+			pks = append(pks, row)
+		}
+
+		if len(pks) == 0 {
+			continue
+		}
+
+		err = notify(&schema.SQLNotification{
+			TxId:  txID,
+			Table: table.Name(),
+			Pks:   pks,
+		})
+		if err != nil {
+			return err
+		}
+	}
 }
 
 func typedValueToRowValue(tv sql.TypedValue) *schema.SQLValue {
@@ -523,6 +955,14 @@ func typedValueToRowValue(tv sql.TypedValue) *schema.SQLValue {
 		{
 			return &schema.SQLValue{Value: &schema.SQLValue_Ts{Ts: sql.TimeToInt64(tv.Value().(time.Time))}}
 		}
+	case sql.FloatType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_F{F: tv.Value().(float64)}}
+		}
+	case sql.DecimalType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_D{D: sql.FormatDecimal(tv.Value().(*big.Rat))}}
+		}
 	}
 	return nil
 }
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+
+	elogger "github.com/codenotary/immudb/embedded/logger"
+	"github.com/codenotary/immudb/embedded/sql"
+)
+
+// SQLNamespace returns a view of the database whose SQL catalog and rows are kept under the prefix,
+// thus isolated from the ones of the database and of any other namespace. Only SQL operations are
+// affected, key-value operations of the view are the ones of the database.
+func (d *db) SQLNamespace(prefix []byte) (DB, error) {
+	if len(prefix) == 0 || d.sqlNamespace {
+		return nil, ErrIllegalArguments
+	}
+
+	engine, err := d.sqlNamespaceEngine(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return &db{
+		st:           d.st,
+		sqlEngine:    engine,
+		mutex:        d.mutex,
+		Logger:       d.Logger,
+		options:      d.options,
+		keys:         d.keys,
+		proofCache:   d.proofCache,
+		name:         d.name,
+		readOnly:     d.readOnly,
+		sqlNamespace: true,
+	}, nil
+}
+
+// sqlNamespaceEngine returns the SQL engine of the namespace, the engine is created on first use
+// together with its SQL database, replicas get it from the primary instead
+func (d *db) sqlNamespaceEngine(prefix []byte) (*sql.Engine, error) {
+	d.sqlNamespacesMutex.Lock()
+	defer d.sqlNamespacesMutex.Unlock()
+
+	engine, ok := d.sqlNamespaces[string(prefix)]
+	if ok {
+		return engine, nil
+	}
+
+	sqlPrefix := append([]byte{SQLPrefix}, prefix...)
+
+	engine, err := sql.NewEngine(d.st, sql.DefaultOptions().WithPrefix(sqlPrefix).WithLogger(elogger.NewPrintfLogger(d.Logger)))
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.initSQLNamespace(engine)
+	if err != nil {
+		engine.Close()
+		return nil, err
+	}
+
+	d.sqlNamespaces[string(prefix)] = engine
+
+	return engine, nil
+}
+
+func (d *db) initSQLNamespace(engine *sql.Engine) error {
+	err := engine.SetDefaultDatabase(dbInstanceName)
+	if err != sql.ErrDatabaseDoesNotExist {
+		return err
+	}
+
+	if d.isReplica() {
+		// loaded once replicated, as done with the catalog of the database
+		return nil
+	}
+
+	err = d.checkWritable()
+	if err != nil {
+		return err
+	}
+
+	_, _, err = engine.ExecPreparedStmts(context.Background(), []sql.SQLStmt{&sql.CreateDatabaseStmt{DB: dbInstanceName}}, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return engine.SetDefaultDatabase(dbInstanceName)
+}
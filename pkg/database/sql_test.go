@@ -16,6 +16,7 @@ limitations under the License.
 package database
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/codenotary/immudb/embedded/sql"
@@ -43,6 +44,9 @@ func TestSQLExecAndQuery(t *testing.T) {
 	_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: "USE DATABASE db1"}, nil)
 	require.Error(t, err)
 
+	_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: "DROP DATABASE defaultdb"}, nil)
+	require.Error(t, err)
+
 	ntx, ctxs, err := db.SQLExec(&schema.SQLExecRequest{Sql: `
 		CREATE TABLE table1(id INTEGER AUTO_INCREMENT, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)
 	`}, nil)
@@ -172,3 +176,325 @@ func TestSQLExecAndQuery(t *testing.T) {
 	require.Equal(t, store.ErrKeyNotFound, err)
 
 }
+
+func TestSQLQueryWithCancellation(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, _, err := db.SQLExec(&schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, PRIMARY KEY id);
+		INSERT INTO table1(id) VALUES (1), (2);
+	`}, nil)
+	require.NoError(t, err)
+
+	cancellation := make(chan struct{})
+
+	res, err := db.SQLQueryWithCancellation(&schema.SQLQueryRequest{Sql: "SELECT id FROM table1"}, nil, cancellation)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+
+	close(cancellation)
+
+	_, err = db.SQLQueryWithCancellation(&schema.SQLQueryRequest{Sql: "SELECT id FROM table1"}, nil, cancellation)
+	require.Equal(t, sql.ErrCancellationRequested, err)
+}
+
+func TestIndexSuggestions(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, _, err := db.SQLExec(&schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, amount INTEGER, PRIMARY KEY id);
+		INSERT INTO table1(id, amount) VALUES (1, 10), (2, 20);
+	`}, nil)
+	require.NoError(t, err)
+
+	res, err := db.IndexSuggestions()
+	require.NoError(t, err)
+	require.Empty(t, res.Suggestions)
+
+	_, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT id FROM table1 WHERE amount > 10"}, nil)
+	require.NoError(t, err)
+
+	res, err = db.IndexSuggestions()
+	require.NoError(t, err)
+	require.Len(t, res.Suggestions, 1)
+	require.Equal(t, "table1", res.Suggestions[0].Table)
+	require.Equal(t, "amount", res.Suggestions[0].Column)
+	require.Equal(t, uint64(1), res.Suggestions[0].FullScans)
+	require.Equal(t, "CREATE INDEX ON table1(amount)", res.Suggestions[0].Stmt)
+}
+
+func TestSQLQueryIsolation(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, _, err := db.SQLExec(&schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, PRIMARY KEY id);
+		INSERT INTO table1(id) VALUES (1), (2);
+	`}, nil)
+	require.NoError(t, err)
+
+	res, err := db.SQLQuery(&schema.SQLQueryRequest{
+		Sql:       "SELECT id FROM table1",
+		Isolation: schema.ReadIsolation_ReadCommittedNoWait,
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+
+	_, err = db.SQLQuery(&schema.SQLQueryRequest{
+		Sql:       "SELECT id FROM table1",
+		Isolation: schema.ReadIsolation_PinnedSnapshot,
+	}, nil)
+	require.ErrorIs(t, err, ErrPinnedSnapshotRequiresTx)
+
+	tx, err := db.NewSQLTx(false)
+	require.NoError(t, err)
+	defer tx.Cancel()
+
+	_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: "INSERT INTO table1(id) VALUES (3)"}, nil)
+	require.NoError(t, err)
+
+	res, err = db.SQLQuery(&schema.SQLQueryRequest{
+		Sql:       "SELECT id FROM table1",
+		Isolation: schema.ReadIsolation_PinnedSnapshot,
+	}, tx)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+}
+
+func TestSQLExecScript(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLExecScript(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.SQLExecScript(&schema.SQLExecRequest{Sql: "invalid sql statement"})
+	require.Error(t, err)
+
+	_, err = db.SQLExecScript(&schema.SQLExecRequest{Sql: "CREATE DATABASE db1"})
+	require.Error(t, err)
+
+	tx, err := db.SQLExecScript(&schema.SQLExecRequest{
+		Sql: `
+			CREATE TABLE table1(id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id);
+			CREATE TABLE table2(id INTEGER AUTO_INCREMENT, table1_id INTEGER, PRIMARY KEY id);
+			INSERT INTO table1(title) VALUES (@title);
+			INSERT INTO table2(table1_id) VALUES (@table1_last_insert_id);
+		`,
+		Params: []*schema.NamedParam{{Name: "title", Value: &schema.SQLValue{Value: &schema.SQLValue_S{S: "title1"}}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), tx.LastInsertedPKs()["table2"])
+
+	res, err := db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT table1_id FROM table2"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	require.Equal(t, int64(1), res.Rows[0].Values[0].GetN())
+}
+
+func TestSQLListen(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	err := db.SQLListen(nil, nil, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id);
+		CREATE TABLE table2(id INTEGER, PRIMARY KEY id);
+	`}, nil)
+	require.NoError(t, err)
+
+	err = db.SQLListen(&schema.SQLListenRequest{Table: "table3"}, nil, func(*schema.SQLNotification) error { return nil })
+	require.Equal(t, sql.ErrTableDoesNotExist, err)
+
+	state, err := db.CurrentState()
+	require.NoError(t, err)
+
+	for _, stmt := range []string{
+		"INSERT INTO table1(title) VALUES ('title1'), ('title2')",
+		"INSERT INTO table2(id) VALUES (1)",
+		"UPDATE table1 SET title = 'title3' WHERE id = 2",
+		"DELETE FROM table1 WHERE id = 1",
+	} {
+		_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: stmt}, nil)
+		require.NoError(t, err)
+	}
+
+	errDone := errors.New("done")
+
+	var notifications []*schema.SQLNotification
+
+	err = db.SQLListen(&schema.SQLListenRequest{Table: "table1", InitialTx: state.TxId + 1}, nil, func(n *schema.SQLNotification) error {
+		notifications = append(notifications, n)
+
+		if len(notifications) == 3 {
+			return errDone
+		}
+
+		return nil
+	})
+	require.Equal(t, errDone, err)
+
+	require.Equal(t, state.TxId+1, notifications[0].TxId)
+	require.Equal(t, "table1", notifications[0].Table)
+	require.Len(t, notifications[0].Pks, 2)
+	require.Equal(t, []string{"id"}, notifications[0].Pks[0].Columns)
+	require.Equal(t, int64(1), notifications[0].Pks[0].Values[0].GetN())
+	require.Equal(t, int64(2), notifications[0].Pks[1].Values[0].GetN())
+
+	// tx updating table2 is not notified
+	require.Equal(t, state.TxId+3, notifications[1].TxId)
+	require.Len(t, notifications[1].Pks, 1)
+	require.Equal(t, int64(2), notifications[1].Pks[0].Values[0].GetN())
+
+	require.Equal(t, state.TxId+4, notifications[2].TxId)
+	require.Len(t, notifications[2].Pks, 1)
+	require.Equal(t, int64(1), notifications[2].Pks[0].Values[0].GetN())
+
+	t.Run("listening should be cancellable", func(t *testing.T) {
+		cancellation := make(chan struct{})
+		close(cancellation)
+
+		err = db.SQLListen(&schema.SQLListenRequest{Table: "table1"}, cancellation, func(n *schema.SQLNotification) error {
+			return nil
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestDescribeSchema(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	sqlSchema, err := db.DescribeSchema(nil)
+	require.NoError(t, err)
+	require.Empty(t, sqlSchema.Tables)
+
+	_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id);
+		CREATE TABLE table0(id INTEGER, code VARCHAR[16] NOT NULL, PRIMARY KEY id);
+		CREATE UNIQUE INDEX ON table0(code);
+	`}, nil)
+	require.NoError(t, err)
+
+	sqlSchema, err = db.DescribeSchema(nil)
+	require.NoError(t, err)
+	require.Len(t, sqlSchema.Tables, 2)
+	require.Equal(t, "table0", sqlSchema.Tables[0].Name)
+	require.Equal(t, "table1", sqlSchema.Tables[1].Name)
+	require.Equal(t, &schema.ColumnDescriptor{Name: "code", Type: sql.VarcharType, MaxLen: 16}, sqlSchema.Tables[0].Columns[1])
+	require.Equal(t, []*schema.IndexDescriptor{
+		{Columns: []string{"id"}, Primary: true, Unique: true},
+		{Columns: []string{"code"}, Unique: true},
+	}, sqlSchema.Tables[0].Indexes)
+	require.Len(t, sqlSchema.Tables[1].Columns, 2)
+	require.True(t, sqlSchema.Tables[1].Columns[0].AutoIncrement)
+	require.True(t, sqlSchema.Tables[1].Columns[1].Nullable)
+}
+
+func TestSQLBlob(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLBlob(nil, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, _, err = db.SQLExec(&schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, code VARCHAR[16], payload BLOB, PRIMARY KEY (id, code));
+		INSERT INTO table1(id, code, payload) VALUES (1, 'a', x'0102030405'), (2, 'b', NULL);
+	`}, nil)
+	require.NoError(t, err)
+
+	pk := func(id int64, code string) []*schema.SQLValue {
+		return []*schema.SQLValue{
+			{Value: &schema.SQLValue_N{N: id}},
+			{Value: &schema.SQLValue_S{S: code}},
+		}
+	}
+
+	blob, err := db.SQLBlob(&schema.SQLBlobRequest{Table: "table1", PkValues: pk(1, "a"), Column: "payload"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3, 4, 5}, blob)
+
+	blob, err = db.SQLBlob(&schema.SQLBlobRequest{Table: "table1", PkValues: pk(2, "b"), Column: "payload"}, nil)
+	require.NoError(t, err)
+	require.Empty(t, blob)
+
+	_, err = db.SQLBlob(&schema.SQLBlobRequest{Table: "table1", PkValues: pk(3, "c"), Column: "payload"}, nil)
+	require.ErrorIs(t, err, store.ErrKeyNotFound)
+
+	_, err = db.SQLBlob(&schema.SQLBlobRequest{Table: "table1", PkValues: pk(1, "a"), Column: "code"}, nil)
+	require.ErrorIs(t, err, ErrNotBlobColumn)
+
+	_, err = db.SQLBlob(&schema.SQLBlobRequest{Table: "table1", PkValues: pk(1, "a")[:1], Column: "payload"}, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = db.SQLBlob(&schema.SQLBlobRequest{Table: "table1", PkValues: pk(1, "a"), Column: "unknown"}, nil)
+	require.ErrorIs(t, err, sql.ErrColumnDoesNotExist)
+
+	_, err = db.SQLBlob(&schema.SQLBlobRequest{Table: "table2", PkValues: pk(1, "a"), Column: "payload"}, nil)
+	require.ErrorIs(t, err, sql.ErrTableDoesNotExist)
+}
+
+func TestSQLNamespace(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLNamespace(nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	ns1, err := db.SQLNamespace([]byte("ns1/"))
+	require.NoError(t, err)
+
+	_, err = ns1.SQLNamespace([]byte("ns2/"))
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	ns2, err := db.SQLNamespace([]byte("ns2/"))
+	require.NoError(t, err)
+
+	_, _, err = ns1.SQLExec(&schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, title VARCHAR, PRIMARY KEY id);
+		INSERT INTO table1(id, title) VALUES (1, 'title1');
+	`}, nil)
+	require.NoError(t, err)
+
+	res, err := ns1.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT title FROM table1"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+
+	for _, other := range []DB{db, ns2} {
+		res, err = other.ListTables(nil)
+		require.NoError(t, err)
+		require.Empty(t, res.Rows)
+
+		_, err = other.DescribeTable("table1", nil)
+		require.ErrorIs(t, err, sql.ErrTableDoesNotExist)
+	}
+
+	t.Run("engines of namespaces should be reused", func(t *testing.T) {
+		ns1, err := db.SQLNamespace([]byte("ns1/"))
+		require.NoError(t, err)
+
+		res, err := ns1.ListTables(nil)
+		require.NoError(t, err)
+		require.Len(t, res.Rows, 1)
+
+		err = ns1.Close()
+		require.NoError(t, err)
+
+		_, err = db.SQLNamespace([]byte("ns1/"))
+		require.NoError(t, err)
+	})
+
+	t.Run("key-value operations should not be affected", func(t *testing.T) {
+		_, err := ns1.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+		require.NoError(t, err)
+
+		entry, err := db.Get(&schema.KeyRequest{Key: []byte("key1")})
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), entry.Value)
+	})
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// TruncationReport describes the values removed by a truncation, or the ones to be removed in dry-run mode
+type TruncationReport struct {
+	Database string    `json:"database"`
+	Before   time.Time `json:"before"`
+	DryRun   bool      `json:"dryRun"`
+	// values of the transactions before it are the ones removed
+	MinTxID uint64 `json:"minTxId"`
+	// bytes of the value logs removed, or to be removed in dry-run mode
+	DiscardedBytes int64 `json:"discardedBytes"`
+	// catalog entries of the SQL engines copied to outlive the truncation
+	CopiedCatalogEntries int `json:"copiedCatalogEntries"`
+}
+
+// Truncate removes the values of the transactions committed before the given time. Values are removed
+// in whole chunks of the value logs, thus values committed before that time may be kept. Transactions,
+// their proofs and the index are kept, removed values are read as expired entries.
+// SQL catalogs are copied beforehand so tables outlive the transactions they were created in.
+// In dry-run mode nothing is removed, the report tells what would be
+func (d *db) Truncate(before time.Time, dryRun bool) (*TruncationReport, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	err := d.checkWritable()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TruncationReport{
+		Database: d.name,
+		Before:   before,
+		DryRun:   dryRun,
+	}
+
+	if d.st.TxCount() == 0 {
+		return report, nil
+	}
+
+	// values of the last transaction are kept anyway
+	report.MinTxID = d.st.TxCount()
+
+	hdr, err := d.st.FirstTxSince(before)
+	if err == nil {
+		report.MinTxID = hdr.ID
+	} else if err != store.ErrTxNotFound {
+		return nil, err
+	}
+
+	report.DiscardedBytes, err = d.st.TruncatableSize(report.MinTxID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun || report.DiscardedBytes == 0 {
+		return report, nil
+	}
+
+	report.CopiedCatalogEntries, err = sql.CopyCatalogs(d.st, []byte{SQLPrefix})
+	if err != nil {
+		return nil, err
+	}
+
+	report.DiscardedBytes, err = d.st.TruncateUptoTx(report.MinTxID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Logger.Infof("Database '%s' truncated up to tx %d, %d bytes discarded", d.name, report.MinTxID, report.DiscardedBytes)
+
+	return report, nil
+}
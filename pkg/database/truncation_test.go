@@ -0,0 +1,114 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncate(t *testing.T) {
+	options := DefaultOption().WithDBRootPath(t.TempDir()).WithDBName("db").WithCorruptionChecker(false)
+	options.storeOpts.WithFileSize(256)
+
+	idb, closer := makeDbWith(options)
+	defer closer()
+
+	// one transaction per minute, starting after the ones made on creation
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+	now := start
+
+	err := idb.UseTimeFunc(func() time.Time { return now })
+	require.NoError(t, err)
+
+	_, _, err = idb.SQLExec(&schema.SQLExecRequest{Sql: "CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id)"}, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		now = now.Add(time.Minute)
+
+		_, err := idb.Set(&schema.SetRequest{KVs: []*schema.KeyValue{
+			{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte("a value long enough to fill the chunks of the value log")},
+		}})
+		require.NoError(t, err)
+
+		_, _, err = idb.SQLExec(&schema.SQLExecRequest{Sql: "INSERT INTO table1(title) VALUES('a title long enough to fill the chunks')"}, nil)
+		require.NoError(t, err)
+	}
+
+	before := start.Add(8 * time.Minute)
+
+	t.Run("dry-run should report without removing values", func(t *testing.T) {
+		report, err := idb.Truncate(before, true)
+		require.NoError(t, err)
+		require.True(t, report.DryRun)
+		require.Equal(t, "db", report.Database)
+		require.Positive(t, report.DiscardedBytes)
+		require.Zero(t, report.CopiedCatalogEntries)
+
+		_, err = idb.Get(&schema.KeyRequest{Key: []byte("key0")})
+		require.NoError(t, err)
+	})
+
+	t.Run("values committed before the time should be removed", func(t *testing.T) {
+		dryRunReport, err := idb.Truncate(before, true)
+		require.NoError(t, err)
+
+		report, err := idb.Truncate(before, false)
+		require.NoError(t, err)
+		require.False(t, report.DryRun)
+		require.Equal(t, dryRunReport.MinTxID, report.MinTxID)
+		require.Equal(t, dryRunReport.DiscardedBytes, report.DiscardedBytes)
+		require.Positive(t, report.CopiedCatalogEntries)
+
+		_, err = idb.Get(&schema.KeyRequest{Key: []byte("key0")})
+		require.ErrorIs(t, err, store.ErrExpiredEntry)
+
+		_, err = idb.Get(&schema.KeyRequest{Key: []byte("key9")})
+		require.NoError(t, err)
+
+		report, err = idb.Truncate(before, false)
+		require.NoError(t, err)
+		require.Zero(t, report.DiscardedBytes)
+	})
+
+	t.Run("tables should outlive the truncation", func(t *testing.T) {
+		_, _, err := idb.SQLExec(&schema.SQLExecRequest{Sql: "INSERT INTO table1(title) VALUES('a title')"}, nil)
+		require.NoError(t, err)
+
+		res, err := idb.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT id FROM table1"}, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, res.Rows)
+		require.Less(t, len(res.Rows), 11)
+
+		err = idb.VerifyTxs(1, idb.(*db).st.TxCount())
+		require.NoError(t, err)
+	})
+
+	t.Run("replicas should not be truncated", func(t *testing.T) {
+		idb.AsReplica(true)
+		defer idb.AsReplica(false)
+
+		_, err := idb.Truncate(before, true)
+		require.ErrorIs(t, err, ErrIsReplica)
+	})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxLabels(t *testing.T) {
+	idb, closer := makeDb()
+	defer closer()
+
+	hdr1, err := idb.Set(&schema.SetRequest{
+		KVs:      []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}},
+		TxLabels: map[string]string{"actor": "alice", "reason": "onboarding"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"actor": "alice", "reason": "onboarding"}, hdr1.Metadata.Labels)
+
+	hdr2, err := idb.ExecAll(&schema.ExecAllRequest{
+		Operations: []*schema.Op{
+			{Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte("key2"), Value: []byte("value2")}}},
+		},
+		TxLabels: map[string]string{"actor": "bob"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"actor": "bob"}, hdr2.Metadata.Labels)
+
+	_, err = idb.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key3"), Value: []byte("value3")}}})
+	require.NoError(t, err)
+
+	_, ctxs, err := idb.SQLExec(&schema.SQLExecRequest{
+		Sql:      "CREATE TABLE mytable(id INTEGER, PRIMARY KEY id)",
+		TxLabels: map[string]string{"actor": "alice"},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, ctxs, 1)
+
+	actor, ok := ctxs[0].TxHeader().Metadata.Label("actor")
+	require.True(t, ok)
+	require.Equal(t, "alice", actor)
+
+	t.Run("labels must be returned when the tx is read", func(t *testing.T) {
+		tx, err := idb.TxByID(&schema.TxRequest{Tx: hdr1.Id})
+		require.NoError(t, err)
+		require.Equal(t, hdr1.Metadata.Labels, tx.Header.Metadata.Labels)
+
+		entry, err := idb.Get(&schema.KeyRequest{Key: []byte("key2")})
+		require.NoError(t, err)
+		require.Equal(t, hdr2.Id, entry.Tx)
+
+		txs, err := idb.TxScan(&schema.TxScanRequest{InitialTx: hdr1.Id, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, txs.Txs, 1)
+		require.Equal(t, hdr1.Metadata.Labels, txs.Txs[0].Header.Metadata.Labels)
+	})
+
+	t.Run("txscan must only return txs holding all the labels", func(t *testing.T) {
+		txs, err := idb.TxScan(&schema.TxScanRequest{InitialTx: 1, Labels: map[string]string{"actor": "alice"}})
+		require.NoError(t, err)
+		require.Len(t, txs.Txs, 2)
+		require.Equal(t, hdr1.Id, txs.Txs[0].Header.Id)
+
+		txs, err = idb.TxScan(&schema.TxScanRequest{InitialTx: 1, Labels: map[string]string{"actor": "alice"}, Limit: 1, Desc: false})
+		require.NoError(t, err)
+		require.Len(t, txs.Txs, 1)
+		require.Equal(t, hdr1.Id, txs.Txs[0].Header.Id)
+
+		txs, err = idb.TxScan(&schema.TxScanRequest{InitialTx: 1, Labels: map[string]string{"actor": "alice", "reason": "onboarding"}})
+		require.NoError(t, err)
+		require.Len(t, txs.Txs, 1)
+		require.Equal(t, hdr1.Id, txs.Txs[0].Header.Id)
+
+		txs, err = idb.TxScan(&schema.TxScanRequest{InitialTx: 1, Labels: map[string]string{"actor": "carol"}})
+		require.NoError(t, err)
+		require.Empty(t, txs.Txs)
+	})
+
+	t.Run("invalid labels must be rejected", func(t *testing.T) {
+		_, err := idb.Set(&schema.SetRequest{
+			KVs:      []*schema.KeyValue{{Key: []byte("key4"), Value: []byte("value4")}},
+			TxLabels: map[string]string{"": "value"},
+		})
+		require.ErrorIs(t, err, store.ErrInvalidTxLabel)
+
+		_, err = idb.Set(&schema.SetRequest{
+			KVs:      []*schema.KeyValue{{Key: []byte("key4"), Value: []byte("value4")}},
+			TxLabels: map[string]string{"reason": strings.Repeat("x", 100)},
+		})
+		require.ErrorIs(t, err, store.ErrInvalidTxLabel)
+	})
+}
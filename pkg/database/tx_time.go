@@ -0,0 +1,75 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"math"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// txRange is the range of transactions committed within some time bounds
+type txRange struct {
+	firstTx uint64
+	lastTx  uint64
+}
+
+func (r *txRange) contains(txID uint64) bool {
+	return r == nil || (txID >= r.firstTx && txID <= r.lastTx)
+}
+
+// txRangeByTime resolves the commit time bounds, as unix seconds, into the range of transactions
+// committed within them. A nil range is returned when there are no bounds, zero values leaving the range open,
+// while an empty range is returned when no transaction was committed within the bounds
+func (d *db) txRangeByTime(startTime, endTime int64) (*txRange, error) {
+	if startTime < 0 || endTime < 0 || (endTime > 0 && endTime < startTime) {
+		return nil, ErrIllegalArguments
+	}
+
+	if startTime == 0 && endTime == 0 {
+		return nil, nil
+	}
+
+	r := &txRange{firstTx: 1, lastTx: math.MaxUint64}
+
+	if startTime > 0 {
+		hdr, err := d.st.FirstTxSince(time.Unix(startTime, 0))
+		if err == store.ErrTxNotFound {
+			return &txRange{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		r.firstTx = hdr.ID
+	}
+
+	if endTime > 0 {
+		hdr, err := d.st.LastTxUntil(time.Unix(endTime, 0))
+		if err == store.ErrTxNotFound {
+			return &txRange{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		r.lastTx = hdr.ID
+	}
+
+	return r, nil
+}
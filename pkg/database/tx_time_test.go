@@ -0,0 +1,112 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanAndHistoryByTime(t *testing.T) {
+	idb, closer := makeDb()
+	defer closer()
+
+	// one transaction per minute, starting after the ones made on creation
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+	now := start
+
+	err := idb.(*db).st.UseTimeFunc(func() time.Time { return now })
+	require.NoError(t, err)
+
+	var txIDs []uint64
+
+	for i := 0; i < 6; i++ {
+		hdr, err := idb.Set(&schema.SetRequest{KVs: []*schema.KeyValue{
+			{Key: []byte("counter"), Value: []byte(fmt.Sprintf("%d", i))},
+			{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte("value")},
+		}})
+		require.NoError(t, err)
+
+		txIDs = append(txIDs, hdr.Id)
+
+		now = now.Add(time.Minute)
+	}
+
+	minute := func(i int) int64 {
+		return start.Add(time.Duration(i) * time.Minute).Unix()
+	}
+
+	t.Run("scan must only return entries committed within the bounds", func(t *testing.T) {
+		list, err := idb.Scan(&schema.ScanRequest{Prefix: []byte("key"), StartTime: minute(1), EndTime: minute(3)})
+		require.NoError(t, err)
+		require.Len(t, list.Entries, 3)
+		require.Equal(t, []byte("key1"), list.Entries[0].Key)
+		require.Equal(t, []byte("key3"), list.Entries[2].Key)
+
+		list, err = idb.Scan(&schema.ScanRequest{Prefix: []byte("key"), StartTime: minute(4), Limit: 1, Desc: true})
+		require.NoError(t, err)
+		require.Len(t, list.Entries, 1)
+		require.Equal(t, []byte("key5"), list.Entries[0].Key)
+
+		list, err = idb.Scan(&schema.ScanRequest{Prefix: []byte("key"), EndTime: minute(0)})
+		require.NoError(t, err)
+		require.Len(t, list.Entries, 1)
+		require.Equal(t, []byte("key0"), list.Entries[0].Key)
+
+		// the counter was last updated after the bounds
+		list, err = idb.Scan(&schema.ScanRequest{Prefix: []byte("counter"), EndTime: minute(3)})
+		require.NoError(t, err)
+		require.Empty(t, list.Entries)
+
+		list, err = idb.Scan(&schema.ScanRequest{Prefix: []byte("key"), StartTime: minute(10)})
+		require.NoError(t, err)
+		require.Empty(t, list.Entries)
+
+		_, err = idb.Scan(&schema.ScanRequest{StartTime: minute(3), EndTime: minute(1)})
+		require.ErrorIs(t, err, ErrIllegalArguments)
+	})
+
+	t.Run("history must only return entries committed within the bounds", func(t *testing.T) {
+		list, err := idb.History(&schema.HistoryRequest{Key: []byte("counter"), StartTime: minute(2), EndTime: minute(4)})
+		require.NoError(t, err)
+		require.Len(t, list.Entries, 3)
+		require.Equal(t, txIDs[2], list.Entries[0].Tx)
+		require.Equal(t, []byte("2"), list.Entries[0].Value)
+		require.Equal(t, txIDs[4], list.Entries[2].Tx)
+
+		list, err = idb.History(&schema.HistoryRequest{Key: []byte("counter"), StartTime: minute(2), Offset: 1, Limit: 2, Desc: true})
+		require.NoError(t, err)
+		require.Len(t, list.Entries, 2)
+		require.Equal(t, []byte("4"), list.Entries[0].Value)
+		require.Equal(t, []byte("3"), list.Entries[1].Value)
+
+		list, err = idb.History(&schema.HistoryRequest{Key: []byte("counter"), EndTime: start.Unix() - 1})
+		require.NoError(t, err)
+		require.Empty(t, list.Entries)
+
+		list, err = idb.History(&schema.HistoryRequest{Key: []byte("counter"), StartTime: minute(5), Offset: 1})
+		require.NoError(t, err)
+		require.Empty(t, list.Entries)
+
+		_, err = idb.History(&schema.HistoryRequest{Key: []byte("counter"), StartTime: -1})
+		require.ErrorIs(t, err, ErrIllegalArguments)
+	})
+}
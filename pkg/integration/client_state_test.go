@@ -0,0 +1,171 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestClientStateAcrossReplicas(t *testing.T) {
+	masterServerOpts := server.DefaultOptions().
+		WithMetricsServer(false).
+		WithWebServer(false).
+		WithPgsqlServer(false).
+		WithPort(0).
+		WithDir("master-state-data")
+
+	masterServer := server.DefaultServer().WithOptions(masterServerOpts).(*server.ImmuServer)
+	defer os.RemoveAll(masterServerOpts.Dir)
+
+	err := masterServer.Initialize()
+	require.NoError(t, err)
+
+	followerServerOpts := server.DefaultOptions().
+		WithMetricsServer(false).
+		WithWebServer(false).
+		WithPgsqlServer(false).
+		WithPort(0).
+		WithDir("follower-state-data")
+
+	followerServer := server.DefaultServer().WithOptions(followerServerOpts).(*server.ImmuServer)
+	defer os.RemoveAll(followerServerOpts.Dir)
+
+	err = followerServer.Initialize()
+	require.NoError(t, err)
+
+	go func() {
+		masterServer.Start()
+	}()
+
+	go func() {
+		followerServer.Start()
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	defer func() {
+		masterServer.Stop()
+
+		time.Sleep(500 * time.Millisecond)
+
+		followerServer.Stop()
+	}()
+
+	masterPort := masterServer.Listener.Addr().(*net.TCPAddr).Port
+	masterClient, err := ic.NewImmuClient(ic.DefaultOptions().WithPort(masterPort).WithDir(t.TempDir()))
+	require.NoError(t, err)
+
+	mlr, err := masterClient.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	mctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", mlr.Token))
+
+	err = masterClient.CreateUser(mctx, []byte("follower"), []byte("follower1Pwd!"), auth.PermissionAdmin, "defaultdb")
+	require.NoError(t, err)
+
+	followerPort := followerServer.Listener.Addr().(*net.TCPAddr).Port
+	followerClient, err := ic.NewImmuClient(ic.DefaultOptions().WithPort(followerPort).WithDir(t.TempDir()))
+	require.NoError(t, err)
+
+	flr, err := followerClient.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	fctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", flr.Token))
+
+	err = followerClient.CreateDatabase(fctx, &schema.DatabaseSettings{
+		DatabaseName:     "replicateddb",
+		Replica:          true,
+		MasterDatabase:   "defaultdb",
+		MasterAddress:    "127.0.0.1",
+		MasterPort:       uint32(masterPort),
+		FollowerUsername: "follower",
+		FollowerPassword: "follower1Pwd!",
+	})
+	require.NoError(t, err)
+
+	_, err = masterClient.VerifiedSet(mctx, []byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	clientState, err := masterClient.ExportState(mctx)
+	require.NoError(t, err)
+	require.Len(t, clientState.States, 1)
+	require.NotEmpty(t, clientState.States[0].DbUUID)
+
+	t.Run("the state of another database should not be taken", func(t *testing.T) {
+		err = followerClient.ImportState(fctx, clientState)
+		require.ErrorIs(t, err, ic.ErrNoTrustedState)
+	})
+
+	fdb, err := followerClient.UseDatabase(fctx, &schema.Database{DatabaseName: "replicateddb"})
+	require.NoError(t, err)
+
+	fctx = metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", fdb.Token))
+
+	require.Eventually(t, func() bool {
+		return followerClient.ImportState(fctx, clientState) == nil
+	}, 5*time.Second, 100*time.Millisecond)
+
+	t.Run("a trusted state ahead of the follower should not be taken", func(t *testing.T) {
+		aheadState := &schema.ClientState{
+			States: []*schema.ImmutableState{
+				{
+					Db:     clientState.States[0].Db,
+					TxId:   clientState.States[0].TxId + 100,
+					TxHash: clientState.States[0].TxHash,
+					DbUUID: clientState.States[0].DbUUID,
+				},
+			},
+		}
+
+		err = followerClient.ImportState(fctx, aheadState)
+		require.ErrorIs(t, err, ic.ErrServerStateIsOlder)
+	})
+
+	t.Run("verified reads should succeed in the follower", func(t *testing.T) {
+		entry, err := followerClient.VerifiedGet(fctx, []byte("key1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), entry.Value)
+	})
+
+	t.Run("a trusted state diverging from the follower history should not be taken", func(t *testing.T) {
+		divergingState := &schema.ClientState{
+			States: []*schema.ImmutableState{
+				{
+					Db:     clientState.States[0].Db,
+					TxId:   clientState.States[0].TxId,
+					TxHash: make([]byte, len(clientState.States[0].TxHash)),
+					DbUUID: clientState.States[0].DbUUID,
+				},
+			},
+		}
+
+		err = followerClient.ImportState(fctx, divergingState)
+		require.ErrorIs(t, err, store.ErrCorruptedData)
+	})
+}
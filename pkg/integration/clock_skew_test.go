@@ -0,0 +1,84 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestClientClockSkew(t *testing.T) {
+	serverOpts := server.DefaultOptions().
+		WithMetricsServer(false).
+		WithWebServer(false).
+		WithPgsqlServer(false).
+		WithPort(0).
+		WithDir("clock-skew-data")
+
+	srv := server.DefaultServer().WithOptions(serverOpts).(*server.ImmuServer)
+	defer os.RemoveAll(serverOpts.Dir)
+
+	err := srv.Initialize()
+	require.NoError(t, err)
+
+	go func() {
+		srv.Start()
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	defer srv.Stop()
+
+	port := srv.Listener.Addr().(*net.TCPAddr).Port
+
+	connect := func(opts *ic.Options) (ic.ImmuClient, context.Context) {
+		client, err := ic.NewImmuClient(opts.WithPort(port).WithDir(t.TempDir()))
+		require.NoError(t, err)
+
+		lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+		require.NoError(t, err)
+
+		return client, metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+	}
+
+	t.Run("the skew should be measured on every response", func(t *testing.T) {
+		client, ctx := connect(ic.DefaultOptions().WithMaxClockSkew(time.Minute).WithFailOnClockSkew(true))
+
+		_, err := client.Set(ctx, []byte("key1"), []byte("value1"))
+		require.NoError(t, err)
+
+		require.NotZero(t, client.ClockSkew())
+		require.InDelta(t, 0, client.ClockSkew(), float64(time.Second))
+	})
+
+	t.Run("a skew beyond the max one should fail the request", func(t *testing.T) {
+		client, ctx := connect(ic.DefaultOptions().WithMaxClockSkew(time.Minute).WithFailOnClockSkew(true))
+
+		client.GetOptions().WithMaxClockSkew(time.Nanosecond)
+
+		_, err := client.Set(ctx, []byte("key2"), []byte("value2"))
+		require.ErrorIs(t, err, ic.ErrClockSkewExceeded)
+	})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestImmuClient_SetByDigest(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true).WithSigningKey("./../../test/signer/ec1.key")
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	clientOpts := ic.DefaultOptions().
+		WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}).
+		WithServerSigningPubKey("./../../test/signer/ec1.pub").
+		WithUsername("immudb")
+
+	client, err := ic.NewImmuClient(clientOpts)
+	require.NoError(t, err)
+
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	artifact := []byte("artifact content")
+	digest := sha256.Sum256(artifact)
+
+	_, err = client.SetByDigest(ctx, nil)
+	require.Equal(t, ic.ErrIllegalArguments, err)
+
+	_, err = client.SetObjectByDigest(ctx, nil)
+	require.Equal(t, ic.ErrIllegalArguments, err)
+
+	_, err = client.GetByDigest(ctx, []byte("short"))
+	require.Equal(t, ic.ErrIllegalArguments, err)
+
+	reply, err := client.SetByDigest(ctx, artifact)
+	require.NoError(t, err)
+	require.False(t, reply.Existed)
+	require.Equal(t, digest[:], reply.Digest)
+	require.Equal(t, reply.VerifiableTx.Tx.Header.Id, reply.Tx)
+
+	require.Equal(t, "sha256:"+hex.EncodeToString(digest[:]), string(schema.ContentKey(digest[:])))
+
+	_, err = client.Set(ctx, []byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	// storing the same content again is detected, the tx of the first write being returned
+	again, err := client.SetObjectByDigest(ctx, strings.NewReader("artifact content"))
+	require.NoError(t, err)
+	require.True(t, again.Existed)
+	require.Equal(t, reply.Tx, again.Tx)
+
+	state, err := client.CurrentState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, reply.Tx+1, state.TxId)
+
+	other, err := client.SetByDigest(ctx, []byte("other artifact"))
+	require.NoError(t, err)
+	require.False(t, other.Existed)
+	require.Equal(t, reply.Tx+2, other.Tx)
+
+	entry, err := client.GetByDigest(ctx, digest[:])
+	require.NoError(t, err)
+	require.Equal(t, artifact, entry.Value)
+	require.Equal(t, reply.Tx, entry.Tx)
+
+	// a value not matching its content key is reported
+	_, err = client.Set(ctx, schema.ContentKey(schema.ContentDigest([]byte("forged"))), []byte("something else"))
+	require.NoError(t, err)
+
+	_, err = client.SetByDigest(ctx, []byte("forged"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match its digest")
+}
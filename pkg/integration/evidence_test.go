@@ -0,0 +1,164 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/codenotary/immudb/pkg/signer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestImmuClient_Evidence(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true).WithSigningKey("./../../test/signer/ec1.key")
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	clientOpts := ic.DefaultOptions().
+		WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}).
+		WithServerSigningPubKey("./../../test/signer/ec1.pub").
+		WithUsername("immudb")
+
+	client, err := ic.NewImmuClient(clientOpts)
+	require.NoError(t, err)
+
+	_, err = client.ExportEvidence(context.TODO(), nil, nil, "")
+	require.Equal(t, ic.ErrIllegalArguments, err)
+
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err = client.Set(ctx, []byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	_, err = client.SetReference(ctx, []byte("ref1"), []byte("key1"))
+	require.NoError(t, err)
+
+	_, err = client.SQLExec(ctx, "CREATE TABLE table1(id INTEGER, title VARCHAR, PRIMARY KEY id)", nil)
+	require.NoError(t, err)
+
+	_, err = client.SQLExec(ctx, "INSERT INTO table1(id, title) VALUES (1, 'title1'), (2, 'title2')", nil)
+	require.NoError(t, err)
+
+	_, err = client.Set(ctx, []byte("key2"), []byte("value2"))
+	require.NoError(t, err)
+
+	_, err = client.ExportEvidence(ctx, [][]byte{[]byte("missing")}, nil, "")
+	require.Error(t, err)
+
+	archive, err := client.ExportEvidence(ctx,
+		[][]byte{[]byte("key1"), []byte("ref1"), []byte("key2")},
+		[]*schema.SQLGetRequest{{Table: "table1", PkValues: []*schema.SQLValue{{Value: &schema.SQLValue_N{N: 2}}}}},
+		"case 42",
+	)
+	require.NoError(t, err)
+	require.Equal(t, uint32(ic.EvidenceArchiveVersion), archive.Version)
+	require.Equal(t, "case 42", archive.Metadata.Description)
+	require.Equal(t, "immudb", archive.Metadata.User)
+	require.Len(t, archive.Entries, 3)
+	require.Len(t, archive.Rows, 1)
+
+	_, err = client.Set(ctx, []byte("key1"), []byte("value3"))
+	require.NoError(t, err)
+
+	data, err := ic.MarshalEvidence(archive)
+	require.NoError(t, err)
+
+	pk, err := signer.ParsePublicKeyFile("./../../test/signer/ec1.pub")
+	require.NoError(t, err)
+
+	t.Run("archives should be verifiable without a connection", func(t *testing.T) {
+		archive, err := ic.UnmarshalEvidence(data)
+		require.NoError(t, err)
+
+		err = ic.VerifyEvidence(archive, pk)
+		require.NoError(t, err)
+
+		err = ic.VerifyEvidence(archive, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, []byte("value1"), archive.Entries[0].Entry.Value)
+		require.Equal(t, []byte("value1"), archive.Entries[1].Entry.Value)
+	})
+
+	t.Run("tampered archives should be rejected", func(t *testing.T) {
+		err = ic.VerifyEvidence(nil, pk)
+		require.Equal(t, ic.ErrIllegalArguments, err)
+
+		archive, err := ic.UnmarshalEvidence(data)
+		require.NoError(t, err)
+		archive.Entries[0].Entry.Value = []byte("value3")
+
+		err = ic.VerifyEvidence(archive, pk)
+		require.Equal(t, store.ErrCorruptedData, err)
+
+		archive, err = ic.UnmarshalEvidence(data)
+		require.NoError(t, err)
+		archive.Rows[0].SqlGetRequest.PkValues = []*schema.SQLValue{{Value: &schema.SQLValue_N{N: 1}}}
+
+		err = ic.VerifyEvidence(archive, pk)
+		require.Equal(t, store.ErrCorruptedData, err)
+
+		archive, err = ic.UnmarshalEvidence(data)
+		require.NoError(t, err)
+		archive.State.TxId--
+
+		err = ic.VerifyEvidence(archive, pk)
+		require.Equal(t, store.ErrCorruptedData, err)
+
+		err = ic.VerifyEvidence(archive, nil)
+		require.Equal(t, store.ErrCorruptedData, err)
+
+		archive, err = ic.UnmarshalEvidence(data)
+		require.NoError(t, err)
+		archive.State.Signature = nil
+
+		err = ic.VerifyEvidence(archive, pk)
+		require.Equal(t, ic.ErrEvidenceNotSigned, err)
+
+		otherPk, err := signer.ParsePublicKeyFile("./../../test/signer/ec3.pub")
+		require.NoError(t, err)
+
+		archive, err = ic.UnmarshalEvidence(data)
+		require.NoError(t, err)
+
+		err = ic.VerifyEvidence(archive, otherPk)
+		require.Equal(t, store.ErrCorruptedData, err)
+
+		archive.Version++
+
+		err = ic.VerifyEvidence(archive, pk)
+		require.Equal(t, ic.ErrUnsupportedEvidenceVersion, err)
+	})
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/tokenservice"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestImmuClient_ExecAllPerDatabase(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	login := func(user, password string) (ic.ImmuClient, context.Context) {
+		client, err := ic.NewImmuClient(ic.DefaultOptions().
+			WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+		require.NoError(t, err)
+		client.WithTokenService(tokenservice.NewInmemoryTokenService())
+
+		lr, err := client.Login(context.TODO(), []byte(user), []byte(password))
+		require.NoError(t, err)
+
+		return client, metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+	}
+
+	client, ctx := login("immudb", "immudb")
+
+	err := client.CreateDatabase(ctx, &schema.DatabaseSettings{DatabaseName: "auditdb"})
+	require.NoError(t, err)
+
+	kvOp := func(key, value string) *schema.Op {
+		return &schema.Op{Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte(key), Value: []byte(value)}}}
+	}
+
+	get := func(db string, key string) (*schema.Entry, error) {
+		dbResp, err := client.UseDatabase(ctx, &schema.Database{DatabaseName: db})
+		require.NoError(t, err)
+
+		dbCtx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", dbResp.Token))
+
+		return client.Get(dbCtx, []byte(key))
+	}
+
+	t.Run("writes into all the databases should be committed", func(t *testing.T) {
+		txHeaders, err := client.ExecAllPerDatabase(ctx, &schema.ExecAllPerDatabaseRequest{
+			Requests: []*schema.DatabaseExecAllRequest{
+				{Database: server.DefaultDBName, ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{kvOp("key1", "value1")}}},
+				{Database: "auditdb", ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{kvOp("audit1", "key1 set"), kvOp("audit2", "key1 set")}}},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, txHeaders.TxHeaders, 2)
+		require.Equal(t, "auditdb", txHeaders.TxHeaders[0].Database)
+		require.Equal(t, int32(2), txHeaders.TxHeaders[0].TxHeader.Nentries)
+		require.Equal(t, server.DefaultDBName, txHeaders.TxHeaders[1].Database)
+		require.Equal(t, schema.DatabaseTxHeadersDigest(txHeaders.TxHeaders), txHeaders.Digest)
+
+		entry, err := get(server.DefaultDBName, "key1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), entry.Value)
+		require.Equal(t, txHeaders.TxHeaders[1].TxHeader.Id, entry.Tx)
+
+		entry, err = get("auditdb", "audit1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("key1 set"), entry.Value)
+		require.Equal(t, txHeaders.TxHeaders[0].TxHeader.Id, entry.Tx)
+	})
+
+	t.Run("nothing should be written if any of the databases rejects the writes", func(t *testing.T) {
+		_, err := client.ExecAllPerDatabase(ctx, &schema.ExecAllPerDatabaseRequest{
+			Requests: []*schema.DatabaseExecAllRequest{
+				{Database: server.DefaultDBName, ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{kvOp("key2", "value2")}}},
+				{Database: "auditdb", ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{
+					{Operation: &schema.Op_Ref{Ref: &schema.ReferenceRequest{Key: []byte("ref1"), ReferencedKey: []byte("missing")}}},
+				}}},
+			},
+		})
+		require.Error(t, err)
+
+		_, err = get(server.DefaultDBName, "key2")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid requests should be rejected", func(t *testing.T) {
+		_, err := client.ExecAllPerDatabase(ctx, &schema.ExecAllPerDatabaseRequest{})
+		require.Error(t, err)
+
+		_, err = client.ExecAllPerDatabase(ctx, &schema.ExecAllPerDatabaseRequest{
+			Requests: []*schema.DatabaseExecAllRequest{
+				{Database: "auditdb", ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{kvOp("key3", "value3")}}},
+				{Database: "auditdb", ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{kvOp("key4", "value4")}}},
+			},
+		})
+		require.Error(t, err)
+
+		_, err = client.ExecAllPerDatabase(ctx, &schema.ExecAllPerDatabaseRequest{
+			Requests: []*schema.DatabaseExecAllRequest{
+				{Database: "missingdb", ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{kvOp("key3", "value3")}}},
+			},
+		})
+		require.Error(t, err)
+
+		_, err = client.ExecAllPerDatabase(ctx, &schema.ExecAllPerDatabaseRequest{
+			Requests: []*schema.DatabaseExecAllRequest{
+				{Database: server.SystemDBName, ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{kvOp("key3", "value3")}}},
+			},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("write permission should be required on every database", func(t *testing.T) {
+		err := client.CreateUser(ctx, []byte("user1"), []byte("Pa$$w0rd"), auth.PermissionRW, server.DefaultDBName)
+		require.NoError(t, err)
+
+		err = client.ChangePermission(ctx, schema.PermissionAction_GRANT, "user1", "auditdb", auth.PermissionR)
+		require.NoError(t, err)
+
+		client1, ctx1 := login("user1", "Pa$$w0rd")
+
+		_, err = client1.ExecAllPerDatabase(ctx1, &schema.ExecAllPerDatabaseRequest{
+			Requests: []*schema.DatabaseExecAllRequest{
+				{Database: server.DefaultDBName, ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{kvOp("key5", "value5")}}},
+				{Database: "auditdb", ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{kvOp("audit5", "key5 set")}}},
+			},
+		})
+		require.Error(t, err)
+
+		_, err = client1.ExecAllPerDatabase(ctx1, &schema.ExecAllPerDatabaseRequest{
+			Requests: []*schema.DatabaseExecAllRequest{
+				{Database: server.DefaultDBName, ExecAllRequest: &schema.ExecAllRequest{Operations: []*schema.Op{kvOp("key5", "value5")}}},
+			},
+		})
+		require.NoError(t, err)
+	})
+}
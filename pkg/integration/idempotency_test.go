@@ -0,0 +1,92 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIdempotentWrites(t *testing.T) {
+	bs := servertest.NewBufconnServer(server.DefaultOptions().WithDir(t.TempDir()))
+
+	bs.Start()
+	defer bs.Stop()
+
+	opts := ic.DefaultOptions().
+		WithDir(t.TempDir()).
+		WithIdempotencyKeys(true).
+		WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()})
+
+	client := ic.NewClient().WithOptions(opts)
+
+	err := client.OpenSession(context.Background(), []byte(`immudb`), []byte(`immudb`), "defaultdb")
+	require.NoError(t, err)
+	defer client.CloseSession(context.Background())
+
+	t.Run("writes retried with the same key should be applied once", func(t *testing.T) {
+		ctx := ic.WithIdempotencyKey(context.Background(), "order-42")
+
+		hdr1, err := client.Set(ctx, []byte("order:42"), []byte("paid"))
+		require.NoError(t, err)
+
+		hdr2, err := client.Set(ctx, []byte("order:42"), []byte("paid"))
+		require.NoError(t, err)
+		require.Equal(t, hdr1.Id, hdr2.Id)
+
+		_, err = client.Set(ctx, []byte("order:42"), []byte("refunded"))
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		history, err := client.History(context.Background(), &schema.HistoryRequest{Key: []byte("order:42")})
+		require.NoError(t, err)
+		require.Len(t, history.Entries, 1)
+	})
+
+	t.Run("writes without an explicit key should get a new one", func(t *testing.T) {
+		hdr1, err := client.Set(context.Background(), []byte("order:43"), []byte("paid"))
+		require.NoError(t, err)
+
+		hdr2, err := client.Set(context.Background(), []byte("order:43"), []byte("paid"))
+		require.NoError(t, err)
+		require.Greater(t, hdr2.Id, hdr1.Id)
+	})
+
+	t.Run("sql writes retried with the same key should be applied once", func(t *testing.T) {
+		_, err := client.SQLExec(context.Background(), "CREATE TABLE orders (id INTEGER AUTO_INCREMENT, PRIMARY KEY id)", nil)
+		require.NoError(t, err)
+
+		ctx := ic.WithIdempotencyKey(context.Background(), "insert-order")
+
+		for i := 0; i < 2; i++ {
+			_, err = client.SQLExec(ctx, "INSERT INTO orders () VALUES ()", nil)
+			require.NoError(t, err)
+		}
+
+		res, err := client.SQLQuery(context.Background(), "SELECT COUNT(*) FROM orders", nil, true)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), res.Rows[0].Values[0].GetN())
+	})
+}
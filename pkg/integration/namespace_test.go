@@ -0,0 +1,233 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/tokenservice"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestImmuClient_Namespaces(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	login := func(user, password string) (ic.ImmuClient, context.Context) {
+		client, err := ic.NewImmuClient(ic.DefaultOptions().
+			WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+		require.NoError(t, err)
+		client.WithTokenService(tokenservice.NewInmemoryTokenService())
+
+		lr, err := client.Login(context.TODO(), []byte(user), []byte(password))
+		require.NoError(t, err)
+
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+		dbResp, err := client.UseDatabase(ctx, &schema.Database{DatabaseName: server.DefaultDBName})
+		require.NoError(t, err)
+
+		return client, metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", dbResp.Token))
+	}
+
+	admin, ctx := login("immudb", "immudb")
+
+	err := admin.CreateNamespacedUser(ctx, []byte("tenant_admin"), []byte("Pa$$w0rd"), auth.PermissionAdmin, server.DefaultDBName, "tenant1")
+	require.Error(t, err)
+
+	err = admin.CreateNamespacedUser(ctx, []byte("tenant_user"), []byte("Pa$$w0rd"), auth.PermissionRW, server.DefaultDBName, "tenant/1")
+	require.Error(t, err)
+
+	err = admin.CreateNamespacedUser(ctx, []byte("user1"), []byte("Pa$$w0rd"), auth.PermissionRW, server.DefaultDBName, "tenant1")
+	require.NoError(t, err)
+
+	err = admin.CreateNamespacedUser(ctx, []byte("user2"), []byte("Pa$$w0rd"), auth.PermissionRW, server.DefaultDBName, "tenant2")
+	require.NoError(t, err)
+
+	users, err := admin.ListUsers(ctx)
+	require.NoError(t, err)
+
+	for _, u := range users.Users {
+		if string(u.User) == "user1" {
+			require.Equal(t, "tenant1", u.Namespace)
+		}
+	}
+
+	err = admin.ChangePermission(ctx, schema.PermissionAction_GRANT, "user1", server.DefaultDBName, auth.PermissionAdmin)
+	require.Error(t, err)
+
+	_, err = admin.Set(ctx, []byte("key1"), []byte("admin value"))
+	require.NoError(t, err)
+
+	client1, ctx1 := login("user1", "Pa$$w0rd")
+	client2, ctx2 := login("user2", "Pa$$w0rd")
+
+	hdr1, err := client1.VerifiedSet(ctx1, []byte("key1"), []byte("tenant1 value"))
+	require.NoError(t, err)
+
+	hdr2, err := client2.Set(ctx2, []byte("key1"), []byte("tenant2 value"))
+	require.NoError(t, err)
+
+	t.Run("keys should be confined to the namespace", func(t *testing.T) {
+		entry, err := client1.VerifiedGet(ctx1, []byte("key1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("key1"), entry.Key)
+		require.Equal(t, []byte("tenant1 value"), entry.Value)
+
+		entry, err = client2.Get(ctx2, []byte("key1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("tenant2 value"), entry.Value)
+
+		entry, err = admin.Get(ctx, []byte("key1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("admin value"), entry.Value)
+
+		entry, err = admin.Get(ctx, []byte("tenant1/key1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("tenant1 value"), entry.Value)
+
+		entries, err := client1.Scan(ctx1, &schema.ScanRequest{})
+		require.NoError(t, err)
+		require.Len(t, entries.Entries, 1)
+		require.Equal(t, []byte("key1"), entries.Entries[0].Key)
+
+		history, err := client2.History(ctx2, &schema.HistoryRequest{Key: []byte("key1")})
+		require.NoError(t, err)
+		require.Len(t, history.Entries, 1)
+		require.Equal(t, []byte("key1"), history.Entries[0].Key)
+
+		stream, err := client1.ComputeDiff(ctx1, hdr1.Id-1, hdr2.Id, nil)
+		require.NoError(t, err)
+
+		diff, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, []byte("key1"), diff.Key)
+		require.Equal(t, schema.DiffAction_ADDED, diff.Action)
+		require.Equal(t, []byte("tenant1 value"), diff.Value)
+
+		_, err = stream.Recv()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("references and sorted sets should be confined to the namespace", func(t *testing.T) {
+		_, err := client1.VerifiedSetReference(ctx1, []byte("ref1"), []byte("key1"))
+		require.NoError(t, err)
+
+		entry, err := client1.VerifiedGet(ctx1, []byte("ref1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("key1"), entry.Key)
+		require.Equal(t, []byte("ref1"), entry.ReferencedBy.Key)
+		require.Equal(t, []byte("tenant1 value"), entry.Value)
+
+		_, err = client2.Get(ctx2, []byte("ref1"))
+		require.Error(t, err)
+
+		entries, err := client1.VerifiedGetAll(ctx1, [][]byte{[]byte("key1"), []byte("ref1")})
+		require.NoError(t, err)
+		require.Len(t, entries.Entries, 2)
+		require.Equal(t, []byte("ref1"), entries.Entries[1].ReferencedBy.Key)
+
+		_, err = client1.VerifiedZAdd(ctx1, []byte("set1"), 1, []byte("key1"))
+		require.NoError(t, err)
+
+		zentries, err := client1.ZScan(ctx1, &schema.ZScanRequest{Set: []byte("set1")})
+		require.NoError(t, err)
+		require.Len(t, zentries.Entries, 1)
+		require.Equal(t, []byte("set1"), zentries.Entries[0].Set)
+		require.Equal(t, []byte("key1"), zentries.Entries[0].Key)
+		require.Equal(t, []byte("key1"), zentries.Entries[0].Entry.Key)
+
+		zentries, err = client2.ZScan(ctx2, &schema.ZScanRequest{Set: []byte("set1")})
+		require.NoError(t, err)
+		require.Empty(t, zentries.Entries)
+	})
+
+	t.Run("SQL should be confined to the namespace", func(t *testing.T) {
+		_, err := admin.SQLExec(ctx, "CREATE TABLE table1(id INTEGER, title VARCHAR, PRIMARY KEY id); INSERT INTO table1(id, title) VALUES (1, 'admin row')", nil)
+		require.NoError(t, err)
+
+		_, err = client1.SQLExec(ctx1, "CREATE TABLE table1(id INTEGER, title VARCHAR, PRIMARY KEY id); INSERT INTO table1(id, title) VALUES (1, 'tenant1 row')", nil)
+		require.NoError(t, err)
+
+		res, err := client1.SQLQuery(ctx1, "SELECT title FROM table1", nil, true)
+		require.NoError(t, err)
+		require.Len(t, res.Rows, 1)
+		require.Equal(t, "tenant1 row", res.Rows[0].Values[0].GetS())
+
+		res, err = admin.SQLQuery(ctx, "SELECT title FROM table1", nil, true)
+		require.NoError(t, err)
+		require.Len(t, res.Rows, 1)
+		require.Equal(t, "admin row", res.Rows[0].Values[0].GetS())
+
+		tables, err := client2.ListTables(ctx2)
+		require.NoError(t, err)
+		require.Empty(t, tables.Rows)
+
+		_, err = client2.SQLQuery(ctx2, "SELECT title FROM table1", nil, true)
+		require.Error(t, err)
+	})
+
+	t.Run("interactive transactions should be confined to the namespace", func(t *testing.T) {
+		client := ic.NewClient().WithOptions(ic.DefaultOptions().
+			WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+
+		err := client.OpenSession(context.Background(), []byte("user1"), []byte("Pa$$w0rd"), server.DefaultDBName)
+		require.NoError(t, err)
+		defer client.CloseSession(context.Background())
+
+		tx, err := client.NewTx(context.Background())
+		require.NoError(t, err)
+
+		err = tx.SQLExec(context.Background(), "INSERT INTO table1(id, title) VALUES (2, 'tenant1 tx row')", nil)
+		require.NoError(t, err)
+
+		res, err := tx.SQLQuery(context.Background(), "SELECT title FROM table1", nil)
+		require.NoError(t, err)
+		require.Len(t, res.Rows, 2)
+
+		_, err = tx.Commit(context.Background())
+		require.NoError(t, err)
+
+		res, err = admin.SQLQuery(ctx, "SELECT title FROM table1", nil, true)
+		require.NoError(t, err)
+		require.Len(t, res.Rows, 1)
+	})
+
+	t.Run("operations out of the namespace should be rejected", func(t *testing.T) {
+		_, err := client1.TxByID(ctx1, 1)
+		require.Error(t, err)
+
+		_, err = client1.TxScan(ctx1, &schema.TxScanRequest{InitialTx: 1})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestImmuClient_Notarization(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true).WithSigningKey("./../../test/signer/ec1.key")
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	clientOpts := ic.DefaultOptions().
+		WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}).
+		WithServerSigningPubKey("./../../test/signer/ec1.pub").
+		WithUsername("immudb")
+
+	client, err := ic.NewImmuClient(clientOpts)
+	require.NoError(t, err)
+
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	object := []byte("object content")
+
+	_, err = client.NotarizeObject(ctx, nil, nil)
+	require.Equal(t, ic.ErrIllegalArguments, err)
+
+	_, err = client.NotarizeDigest(ctx, &schema.NotarizationRecord{Digest: []byte("short"), Size: 1})
+	require.Equal(t, schema.ErrInvalidNotarizationRecord, err)
+
+	receipt, err := client.NotarizeObject(ctx, bytes.NewReader(object), &schema.NotarizationRecord{
+		Source:      "s3://bucket/object",
+		ContentType: "text/plain",
+		Attributes:  map[string]string{"etag": "abc", "owner": "team"},
+	})
+	require.NoError(t, err)
+
+	digest := sha256.Sum256(object)
+	require.Equal(t, digest[:], receipt.Record.Digest)
+	require.Equal(t, int64(len(object)), receipt.Record.Size)
+	require.Equal(t, "s3://bucket/object", receipt.Record.Source)
+	require.NotZero(t, receipt.VerifiableTx.Tx.Header.Ts)
+
+	// the record is stored under a key derived from the digest
+	entry, err := client.Get(ctx, schema.NotarizationKey(digest[:]))
+	require.NoError(t, err)
+	require.Equal(t, receipt.VerifiableTx.Tx.Header.Id, entry.Tx)
+
+	_, err = client.Set(ctx, []byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	err = client.VerifyObject(ctx, bytes.NewReader(object), receipt)
+	require.NoError(t, err)
+
+	err = client.VerifyObject(ctx, strings.NewReader("tampered content"), receipt)
+	require.Equal(t, ic.ErrObjectMismatch, err)
+
+	tampered := proto.Clone(receipt).(*schema.NotarizationReceipt)
+	tampered.Record.Source = "s3://bucket/other"
+
+	err = client.VerifyObject(ctx, bytes.NewReader(object), tampered)
+	require.Equal(t, store.ErrCorruptedData, err)
+
+	// objects may also be notarized by the server given their content
+	serverReceipt, err := client.NotarizeObject(ctx, strings.NewReader("other content"), nil)
+	require.NoError(t, err)
+
+	sReceipt, err := client.GetServiceClient().NotarizeObject(ctx, &schema.NotarizeObjectRequest{Content: []byte("other content")})
+	require.NoError(t, err)
+	require.True(t, proto.Equal(serverReceipt.Record, sReceipt.Record))
+
+	_, err = client.GetServiceClient().NotarizeObject(ctx, &schema.NotarizeObjectRequest{
+		Content: []byte("other content"),
+		Record:  &schema.NotarizationRecord{Digest: digest[:]},
+	})
+	require.Error(t, err)
+
+	err = client.VerifyObject(ctx, strings.NewReader("other content"), sReceipt)
+	require.NoError(t, err)
+}
@@ -164,6 +164,18 @@ func TestImmuClient_SQL(t *testing.T) {
 	res, err = client.SQLQuery(ctx, fmt.Sprintf("SELECT title FROM table1 BEFORE TX %d WHERE id=2", tx2.Txs[0].Header.Id), nil, true)
 	require.NoError(t, err)
 	require.Equal(t, "title2", res.Rows[0].Values[0].GetS())
+
+	_, err = client.IngestJSON(ctx, "docs", []byte(`{"id": "doc1", "title": "title1"}`))
+	require.NoError(t, err)
+
+	_, err = client.IngestJSON(ctx, "docs", []byte(`{"id": "doc2", "title": "title2", "pages": 10}`))
+	require.NoError(t, err)
+
+	res, err = client.SQLQuery(ctx, "SELECT id, pages FROM docs ORDER BY id", nil, true)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+	require.IsType(t, &schema.SQLValue_Null{}, res.Rows[0].Values[1].GetValue())
+	require.Equal(t, int64(10), res.Rows[1].Values[1].GetN())
 }
 
 func TestImmuClient_SQL_Errors(t *testing.T) {
@@ -189,6 +201,11 @@ func TestImmuClient_SQL_Errors(t *testing.T) {
 	}, false)
 	require.True(t, errors.Is(err, sql.ErrInvalidValue))
 
+	_, err = client.SQLExecScript(context.Background(), "", map[string]interface{}{
+		"param1": struct{}{},
+	})
+	require.True(t, errors.Is(err, sql.ErrInvalidValue))
+
 	err = client.VerifyRow(context.Background(), &schema.Row{
 		Columns: []string{"col1"},
 		Values:  []*schema.SQLValue{},
@@ -204,6 +221,12 @@ func TestImmuClient_SQL_Errors(t *testing.T) {
 	_, err = client.SQLExec(context.Background(), "", nil)
 	require.True(t, errors.Is(err, ic.ErrNotConnected))
 
+	_, err = client.SQLExecScript(context.Background(), "", nil)
+	require.True(t, errors.Is(err, ic.ErrNotConnected))
+
+	_, err = client.IngestJSON(context.Background(), "docs")
+	require.True(t, errors.Is(err, ic.ErrNotConnected))
+
 	_, err = client.SQLQuery(context.Background(), "", nil, false)
 	require.True(t, errors.Is(err, ic.ErrNotConnected))
 
@@ -213,9 +236,61 @@ func TestImmuClient_SQL_Errors(t *testing.T) {
 	_, err = client.DescribeTable(context.Background(), "")
 	require.True(t, errors.Is(err, ic.ErrNotConnected))
 
+	_, err = client.SQLListen(context.Background(), "table1", 0)
+	require.True(t, errors.Is(err, ic.ErrNotConnected))
+
 	err = client.VerifyRow(context.Background(), &schema.Row{
 		Columns: []string{"col1"},
 		Values:  []*schema.SQLValue{{Value: &schema.SQLValue_N{N: 1}}},
 	}, "table1", []*schema.SQLValue{{Value: &schema.SQLValue_N{N: 1}}})
 	require.True(t, errors.Is(err, ic.ErrNotConnected))
 }
+
+func TestImmuClient_SQLListen(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := ic.NewImmuClient(ic.DefaultOptions().WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+	require.NoError(t, err)
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err = client.SQLExec(ctx, "CREATE TABLE table1(id INTEGER, title VARCHAR, PRIMARY KEY id)", nil)
+	require.NoError(t, err)
+
+	res, err := client.SQLExec(ctx, "INSERT INTO table1(id, title) VALUES (1, 'title1'), (2, 'title2')", nil)
+	require.NoError(t, err)
+
+	lctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.SQLListen(lctx, "table1", res.Txs[0].Header.Id)
+	require.NoError(t, err)
+
+	notification, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, res.Txs[0].Header.Id, notification.TxId)
+	require.Equal(t, "table1", notification.Table)
+	require.Len(t, notification.Pks, 2)
+	require.Equal(t, []string{"id"}, notification.Pks[0].Columns)
+	require.Equal(t, int64(1), notification.Pks[0].Values[0].GetN())
+	require.Equal(t, int64(2), notification.Pks[1].Values[0].GetN())
+
+	res, err = client.SQLExec(ctx, "UPDATE table1 SET title = 'title3' WHERE id = 2", nil)
+	require.NoError(t, err)
+
+	notification, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, res.Txs[0].Header.Id, notification.TxId)
+	require.Len(t, notification.Pks, 1)
+	require.Equal(t, int64(2), notification.Pks[0].Values[0].GetN())
+}
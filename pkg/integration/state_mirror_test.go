@@ -0,0 +1,117 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/cache"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestStateMirroredIntoImmudb(t *testing.T) {
+	serverOpts := server.DefaultOptions().
+		WithMetricsServer(false).
+		WithWebServer(false).
+		WithPgsqlServer(false).
+		WithPort(0).
+		WithDir("state-mirror-data")
+
+	srv := server.DefaultServer().WithOptions(serverOpts).(*server.ImmuServer)
+	defer os.RemoveAll(serverOpts.Dir)
+
+	err := srv.Initialize()
+	require.NoError(t, err)
+
+	go func() {
+		srv.Start()
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	defer srv.Stop()
+
+	port := srv.Listener.Addr().(*net.TCPAddr).Port
+
+	login := func(client ic.ImmuClient, db string) context.Context {
+		lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+		require.NoError(t, err)
+
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+		udr, err := client.UseDatabase(ctx, &schema.Database{DatabaseName: db})
+		require.NoError(t, err)
+
+		return metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", udr.Token))
+	}
+
+	mirrorClient, err := ic.NewImmuClient(ic.DefaultOptions().WithPort(port).WithDir(t.TempDir()))
+	require.NoError(t, err)
+
+	mctx := login(mirrorClient, "defaultdb")
+
+	err = mirrorClient.CreateDatabase(mctx, &schema.DatabaseSettings{DatabaseName: "statesdb"})
+	require.NoError(t, err)
+
+	mctx = login(mirrorClient, "statesdb")
+
+	mirror := cache.NewImmudbMirror(mctx, mirrorClient.GetServiceClient())
+
+	client, err := ic.NewImmuClient(ic.DefaultOptions().WithPort(port).WithDir(t.TempDir()).WithStateMirrors(mirror))
+	require.NoError(t, err)
+
+	ctx := login(client, "defaultdb")
+
+	_, err = client.VerifiedSet(ctx, []byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	trustedState, err := client.ExportState(ctx)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = client.Set(ctx, []byte("key2"), []byte("value2"))
+		require.NoError(t, err)
+	}
+
+	// a client starting from an empty folder takes the mirrored state instead of trusting the server state
+	client, err = ic.NewImmuClient(ic.DefaultOptions().WithPort(port).WithDir(t.TempDir()).WithStateMirrors(mirror))
+	require.NoError(t, err)
+
+	ctx = login(client, "defaultdb")
+
+	recoveredState, err := client.ExportState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, trustedState.States[0].TxId, recoveredState.States[0].TxId)
+	require.Equal(t, trustedState.States[0].TxHash, recoveredState.States[0].TxHash)
+
+	entry, err := client.VerifiedGet(ctx, []byte("key2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), entry.Value)
+
+	currentState, err := client.ExportState(ctx)
+	require.NoError(t, err)
+	require.Greater(t, currentState.States[0].TxId, trustedState.States[0].TxId)
+
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestServerStateRollbackDetection(t *testing.T) {
+	serverOpts := server.DefaultOptions().
+		WithMetricsServer(false).
+		WithWebServer(false).
+		WithPgsqlServer(false).
+		WithPort(0).
+		WithDir("rollback-data")
+
+	defer os.RemoveAll(serverOpts.Dir)
+
+	startServer := func() *server.ImmuServer {
+		srv := server.DefaultServer().WithOptions(serverOpts).(*server.ImmuServer)
+
+		err := srv.Initialize()
+		require.NoError(t, err)
+
+		go func() {
+			srv.Start()
+		}()
+
+		time.Sleep(500 * time.Millisecond)
+
+		return srv
+	}
+
+	clientDir := t.TempDir()
+
+	connect := func(srv *server.ImmuServer) (ic.ImmuClient, context.Context) {
+		port := srv.Listener.Addr().(*net.TCPAddr).Port
+
+		client, err := ic.NewImmuClient(ic.DefaultOptions().WithPort(port).WithDir(clientDir))
+		require.NoError(t, err)
+
+		lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+		require.NoError(t, err)
+
+		return client, metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+	}
+
+	srv := startServer()
+
+	client, ctx := connect(srv)
+
+	_, err := client.VerifiedSet(ctx, []byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	srv.Stop()
+
+	// the default database is replaced by a new one, keeping the identity of the server
+	err = os.RemoveAll(filepath.Join(serverOpts.Dir, serverOpts.GetDefaultDBName()))
+	require.NoError(t, err)
+
+	srv = startServer()
+	defer srv.Stop()
+
+	client, ctx = connect(srv)
+
+	for i := 0; i < 5; i++ {
+		_, err = client.Set(ctx, []byte(fmt.Sprintf("key%d", i)), []byte("value"))
+		require.NoError(t, err)
+	}
+
+	_, err = client.VerifiedGet(ctx, []byte("key1"))
+	require.ErrorIs(t, err, store.ErrCorruptedData)
+
+	var rollbackErr *ic.ErrServerStateRollback
+	require.True(t, errors.As(err, &rollbackErr))
+	require.Equal(t, serverOpts.GetDefaultDBName(), rollbackErr.Db)
+	require.NotEmpty(t, rollbackErr.TrustedState.DbUUID)
+	require.NotEmpty(t, rollbackErr.ServerDbUUID)
+	require.NotEqual(t, rollbackErr.TrustedState.DbUUID, rollbackErr.ServerDbUUID)
+	require.Contains(t, err.Error(), "database uuid")
+
+	_, err = client.VerifiedSet(ctx, []byte("key2"), []byte("value2"))
+	require.ErrorIs(t, err, store.ErrCorruptedData)
+}
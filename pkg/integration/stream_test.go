@@ -1248,3 +1248,65 @@ func TestImmuClient_SessionSetGetStream(t *testing.T) {
 
 	require.Equal(t, oriSha, newSha[:])
 }
+
+func TestImmuClient_StreamSQLBlob(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true).WithStreamChunkSize(stream.MinChunkSize)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := ic.NewImmuClient(ic.DefaultOptions().WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+	require.NoError(t, err)
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	payload := make([]byte, 10*stream.MinChunkSize+1)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+
+	_, err = client.SQLExec(ctx, "CREATE TABLE docs(id INTEGER, content BLOB, PRIMARY KEY id)", nil)
+	require.NoError(t, err)
+
+	_, err = client.SQLExec(ctx, "INSERT INTO docs(id, content) VALUES (1, @content), (2, NULL)", map[string]interface{}{"content": payload})
+	require.NoError(t, err)
+
+	blobRequest := func(id int64) *schema.SQLBlobRequest {
+		return &schema.SQLBlobRequest{
+			Table:    "docs",
+			PkValues: []*schema.SQLValue{{Value: &schema.SQLValue_N{N: id}}},
+			Column:   "content",
+		}
+	}
+
+	r, err := client.StreamSQLBlob(ctx, blobRequest(1))
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, payload, content)
+	require.NoError(t, r.Close())
+
+	r, err = client.StreamSQLBlob(ctx, blobRequest(2))
+	require.NoError(t, err)
+
+	content, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.Empty(t, content)
+	require.NoError(t, r.Close())
+
+	r, err = client.StreamSQLBlob(ctx, blobRequest(3))
+	require.NoError(t, err)
+	defer r.Close()
+
+	_, err = io.ReadAll(r)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "key not found")
+}
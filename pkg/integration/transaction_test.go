@@ -361,3 +361,58 @@ func TestTransaction_HandlingReadConflict(t *testing.T) {
 	err = client.CloseSession(ctx)
 	require.NoError(t, err)
 }
+
+func TestTransaction_ReadOnlyIsolation(t *testing.T) {
+	options := server.DefaultOptions()
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client := ic.NewClient().WithOptions(ic.DefaultOptions().WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+
+	err := client.OpenSession(context.TODO(), []byte(`immudb`), []byte(`immudb`), "defaultdb")
+	require.NoError(t, err)
+
+	_, err = client.SQLExec(context.TODO(), `
+		CREATE TABLE table1(id INTEGER, PRIMARY KEY id);
+		INSERT INTO table1(id) VALUES (1);
+	`, nil)
+	require.NoError(t, err)
+
+	for _, isolation := range []schema.ReadIsolation{schema.ReadIsolation_ReadLatest, schema.ReadIsolation_ReadCommittedNoWait} {
+		res, err := client.SQLQueryWithIsolation(context.TODO(), "SELECT id FROM table1", nil, isolation)
+		require.NoError(t, err)
+		before := len(res.Rows)
+
+		roTx, err := client.NewReadOnlyTx(context.TODO(), isolation)
+		require.NoError(t, err)
+
+		err = roTx.SQLExec(context.TODO(), "INSERT INTO table1(id) VALUES (100)", nil)
+		require.Error(t, err)
+
+		_, err = client.SQLExec(context.TODO(), "INSERT INTO table1(id) VALUES (@id)", map[string]interface{}{"id": before + 1})
+		require.NoError(t, err)
+
+		// the snapshot of the read only transaction is pinned
+		res, err = roTx.SQLQuery(context.TODO(), "SELECT id FROM table1", nil)
+		require.NoError(t, err)
+		require.Len(t, res.Rows, before)
+
+		res, err = client.SQLQuery(context.TODO(), "SELECT id FROM table1", nil, true)
+		require.NoError(t, err)
+		require.Len(t, res.Rows, before+1)
+
+		_, err = roTx.Commit(context.TODO())
+		require.NoError(t, err)
+	}
+
+	_, err = client.SQLQueryWithIsolation(context.TODO(), "SELECT id FROM table1", nil, schema.ReadIsolation_PinnedSnapshot)
+	require.Error(t, err)
+
+	err = client.CloseSession(context.TODO())
+	require.NoError(t, err)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestImmuClient_TxLabels(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	clientOpts := ic.DefaultOptions().
+		WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()})
+
+	client, err := ic.NewImmuClient(clientOpts)
+	require.NoError(t, err)
+
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	labels := map[string]string{"request-id": "7f3a", "actor": "alice"}
+
+	hdr, err := client.SetAll(ctx, &schema.SetRequest{
+		KVs:      []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}},
+		TxLabels: labels,
+	})
+	require.NoError(t, err)
+	require.Equal(t, labels, hdr.Metadata.Labels)
+
+	_, err = client.Set(ctx, []byte("key2"), []byte("value2"))
+	require.NoError(t, err)
+
+	// labels are part of the tx header thus covered by the verification
+	tx, err := client.VerifiedTxByID(ctx, hdr.Id)
+	require.NoError(t, err)
+	require.Equal(t, labels, tx.Header.Metadata.Labels)
+
+	txs, err := client.TxScan(ctx, &schema.TxScanRequest{InitialTx: 1, Labels: map[string]string{"actor": "alice"}})
+	require.NoError(t, err)
+	require.Len(t, txs.Txs, 1)
+	require.Equal(t, hdr.Id, txs.Txs[0].Header.Id)
+}
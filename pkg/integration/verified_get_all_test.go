@@ -0,0 +1,99 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/tokenservice"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestImmuClient_VerifiedGetAll(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true).WithSigningKey("./../../test/signer/ec1.key")
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := ic.NewImmuClient(ic.DefaultOptions().
+		WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}).
+		WithServerSigningPubKey("./../../test/signer/ec1.pub"))
+	require.NoError(t, err)
+	client.WithTokenService(tokenservice.NewInmemoryTokenService())
+
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	entries, err := client.VerifiedGetAll(ctx, [][]byte{[]byte("key1")})
+	require.NoError(t, err)
+	require.Empty(t, entries.Entries)
+
+	_, err = client.Set(ctx, []byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	_, err = client.Set(ctx, []byte("key2"), []byte("value2"))
+	require.NoError(t, err)
+
+	_, err = client.SetAll(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{
+		{Key: []byte("key3"), Value: []byte("value3")},
+		{Key: []byte("key4"), Value: []byte("value4")},
+	}})
+	require.NoError(t, err)
+
+	_, err = client.SetReference(ctx, []byte("ref1"), []byte("key1"))
+	require.NoError(t, err)
+
+	keys := [][]byte{[]byte("key1"), []byte("key2"), []byte("key3"), []byte("key4"), []byte("ref1"), []byte("missing")}
+
+	entries, err = client.VerifiedGetAll(ctx, keys)
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 5)
+	require.Equal(t, []byte("value1"), entries.Entries[0].Value)
+	require.Equal(t, []byte("value4"), entries.Entries[3].Value)
+	require.Equal(t, []byte("ref1"), entries.Entries[4].ReferencedBy.Key)
+	require.Equal(t, []byte("value1"), entries.Entries[4].Value)
+
+	state, err := client.CurrentState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, entries.Entries[4].ReferencedBy.Tx, state.TxId)
+
+	_, err = client.Set(ctx, []byte("key5"), []byte("value5"))
+	require.NoError(t, err)
+
+	_, err = client.VerifiedGet(ctx, []byte("key5"))
+	require.NoError(t, err)
+
+	// local state is newer than all the entries
+	entries, err = client.VerifiedGetAll(ctx, keys[:2])
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 2)
+	require.Equal(t, []byte("value2"), entries.Entries[1].Value)
+}
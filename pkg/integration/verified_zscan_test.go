@@ -0,0 +1,109 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	ic "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/tokenservice"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestImmuClient_VerifiedZScan(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true).WithSigningKey("./../../test/signer/ec1.key")
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := ic.NewImmuClient(ic.DefaultOptions().
+		WithDialOptions([]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}).
+		WithServerSigningPubKey("./../../test/signer/ec1.pub"))
+	require.NoError(t, err)
+	client.WithTokenService(tokenservice.NewInmemoryTokenService())
+
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	entries, err := client.VerifiedZScan(ctx, &schema.ZScanRequest{Set: []byte("set1")})
+	require.NoError(t, err)
+	require.Empty(t, entries.Entries)
+
+	for i := 1; i <= 5; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+
+		hdr, err := client.Set(ctx, key, []byte(fmt.Sprintf("value%d", i)))
+		require.NoError(t, err)
+
+		_, err = client.ZAddAt(ctx, []byte("set1"), float64(i), key, hdr.Id)
+		require.NoError(t, err)
+	}
+
+	entries, err = client.VerifiedZScan(ctx, &schema.ZScanRequest{
+		Set:      []byte("set1"),
+		MinScore: &schema.Score{Score: 2},
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 2)
+	require.Equal(t, []byte("key2"), entries.Entries[0].Key)
+	require.Equal(t, []byte("value3"), entries.Entries[1].Entry.Value)
+
+	// continue from the last returned entry
+	last := entries.Entries[1]
+
+	entries, err = client.VerifiedZScan(ctx, &schema.ZScanRequest{
+		Set:       []byte("set1"),
+		SeekKey:   last.Key,
+		SeekScore: last.Score,
+		SeekAtTx:  last.AtTx,
+		MaxScore:  &schema.Score{Score: 4},
+	})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 1)
+	require.Equal(t, []byte("key4"), entries.Entries[0].Key)
+
+	entries, err = client.VerifiedZScan(ctx, &schema.ZScanRequest{
+		Set:      []byte("set1"),
+		MaxScore: &schema.Score{Score: 5},
+		Desc:     true,
+		Limit:    1,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 1)
+	require.Equal(t, []byte("value5"), entries.Entries[0].Entry.Value)
+
+	// requests exceeding the max page size are truncated
+	entries, err = client.VerifiedZScan(ctx, &schema.ZScanRequest{Set: []byte("set1"), Limit: 1_000_000})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 5)
+	require.Nil(t, entries.ContinuationToken)
+}
@@ -19,6 +19,9 @@ package bmessages
 import (
 	"bytes"
 	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/codenotary/immudb/pkg/api/schema"
 )
@@ -86,6 +89,16 @@ func DataRow(rows []*schema.Row, colNumb int, ResultColumnFormatCodes []int16) [
 						value = make([]byte, len(tv.Bs))
 						value = tv.Bs
 					}
+				case *schema.SQLValue_F:
+					{
+						binary.BigEndian.PutUint32(valueLength, uint32(8))
+						value = make([]byte, 8)
+						binary.BigEndian.PutUint64(value, math.Float64bits(tv.F))
+					}
+				case *schema.SQLValue_D:
+					{
+						value = numericAsBinary(tv.D)
+					}
 				}
 			} else {
 				// only text format is allowed in simple query
@@ -109,3 +122,56 @@ func DataRow(rows []*schema.Row, colNumb int, ResultColumnFormatCodes []int16) [
 	}
 	return rowsB
 }
+
+// numericAsBinary encodes a decimal such as "-123.45" as a numeric in binary format, made of the number
+// of digits, the weight of the first digit, the sign and the scale, followed by the digits in base 10000
+func numericAsBinary(d string) []byte {
+	var sign uint16
+
+	if strings.HasPrefix(d, "-") {
+		sign = 0x4000
+		d = d[1:]
+	}
+
+	ip, fp, _ := strings.Cut(d, ".")
+	dscale := len(fp)
+
+	// base 10000 digits are aligned on the decimal point
+	ip = strings.Repeat("0", (4-len(ip)%4)%4) + ip
+	fp = fp + strings.Repeat("0", (4-len(fp)%4)%4)
+
+	weight := len(ip)/4 - 1
+
+	var digits []uint16
+
+	for s := ip + fp; len(s) > 0; s = s[4:] {
+		digit, _ := strconv.ParseUint(s[:4], 10, 16)
+		digits = append(digits, uint16(digit))
+	}
+
+	for len(digits) > 0 && digits[0] == 0 {
+		digits = digits[1:]
+		weight--
+	}
+
+	for len(digits) > 0 && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+
+	if len(digits) == 0 {
+		sign = 0
+		weight = 0
+	}
+
+	value := make([]byte, 8+2*len(digits))
+	binary.BigEndian.PutUint16(value[0:], uint16(len(digits)))
+	binary.BigEndian.PutUint16(value[2:], uint16(int16(weight)))
+	binary.BigEndian.PutUint16(value[4:], sign)
+	binary.BigEndian.PutUint16(value[6:], uint16(dscale))
+
+	for i, digit := range digits {
+		binary.BigEndian.PutUint16(value[8+2*i:], digit)
+	}
+
+	return value
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"github.com/codenotary/immudb/pkg/auth"
 	"github.com/codenotary/immudb/pkg/database"
 	pserr "github.com/codenotary/immudb/pkg/pgsql/errors"
 	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
@@ -123,7 +124,7 @@ func (s *session) InitializeSession() (err error) {
 }
 
 // HandleStartup errors are returned and handled in the caller
-func (s *session) HandleStartup(dbList database.DatabaseList) (err error) {
+func (s *session) HandleStartup(dbList database.DatabaseList, wrapDB DatabaseWrapper) (err error) {
 	defer func() {
 		if err != nil {
 			s.ErrorHandle(err)
@@ -170,6 +171,18 @@ func (s *session) HandleStartup(dbList database.DatabaseList) (err error) {
 			return err
 		}
 		s.log.Debugf("authentication successful for %s", s.username)
+
+		if wrapDB != nil {
+			s.database = wrapDB(s.database, s.username)
+		}
+
+		if usr.Namespace != "" {
+			// users bound to a namespace are confined to its SQL catalog
+			s.database, err = s.database.SQLNamespace(auth.NamespacePrefix(usr.Namespace))
+			if err != nil {
+				return err
+			}
+		}
 		if _, err := s.writeMessage(bm.AuthenticationOk()); err != nil {
 			return err
 		}
@@ -48,6 +48,15 @@ func DatabaseList(dbList database.DatabaseList) Option {
 	}
 }
 
+// DatabaseWrapper wraps the database selected by an authenticated user, e.g. to validate its writes
+type DatabaseWrapper func(db database.DB, username string) database.DB
+
+func WrapDatabase(wrapDB DatabaseWrapper) Option {
+	return func(args *srv) {
+		args.wrapDB = wrapDB
+	}
+}
+
 func SysDb(sysdb database.DB) Option {
 	return func(args *srv) {
 		args.sysDb = sysdb
@@ -686,6 +686,42 @@ func TestPgsqlServer_ExtendedQueryPGxMultiFieldsPreparedStatements(t *testing.T)
 	require.Equal(t, true, isPresent)
 }
 
+func TestPgsqlServer_ExtendedQueryPGxDecimals(t *testing.T) {
+	td, _ := ioutil.TempDir("", "_pgsql")
+	options := server.DefaultOptions().WithDir(td).WithPgsqlServer(true).WithPgsqlServerPort(0)
+	bs := servertest.NewBufconnServer(options)
+
+	bs.Start()
+	defer bs.Stop()
+
+	defer os.RemoveAll(td)
+	defer os.Remove(".state-")
+
+	bs.WaitForPgsqlListener()
+
+	db, err := pgx.Connect(context.Background(), fmt.Sprintf("host=localhost port=%d sslmode=disable user=immudb dbname=defaultdb password=immudb", bs.Server.Srv.PgsqlSrv.GetPort()))
+	require.NoError(t, err)
+	defer db.Close(context.Background())
+
+	table := getRandomTableName()
+	_, err = db.Exec(context.Background(), fmt.Sprintf("CREATE TABLE %s (id INTEGER, amount DECIMAL, PRIMARY KEY id)", table))
+	require.NoError(t, err)
+
+	amounts := []float64{2.25, -12345.0001, 0.000001, 0, 100000000}
+
+	for i, amount := range amounts {
+		_, err = db.Exec(context.Background(), fmt.Sprintf("INSERT INTO %s (id, amount) VALUES (%d, CAST('%v' AS DECIMAL))", table, i, amount))
+		require.NoError(t, err)
+	}
+
+	for i, expected := range amounts {
+		var amount float64
+		err = db.QueryRow(context.Background(), fmt.Sprintf("SELECT amount FROM %s WHERE id = %d", table, i)).Scan(&amount)
+		require.NoError(t, err)
+		require.Equal(t, expected, amount)
+	}
+}
+
 func TestPgsqlServer_ExtendedQueryPGMultiFieldsPreparedStatements(t *testing.T) {
 	td, _ := ioutil.TempDir("", "_pgsql")
 	options := server.DefaultOptions().WithDir(td).WithPgsqlServer(true).WithPgsqlServerPort(0)
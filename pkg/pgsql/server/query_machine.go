@@ -331,6 +331,8 @@ func (s *session) inferParamAndResultCols(statement string) ([]*schema.Column, [
 		if err != nil {
 			return nil, nil, err
 		}
+		defer rr.Close()
+
 		cols, err := rr.Columns()
 		if err != nil {
 			return nil, nil, err
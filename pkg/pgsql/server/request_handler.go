@@ -29,7 +29,7 @@ func (s *srv) handleRequest(conn net.Conn) (err error) {
 		return err
 	}
 	// authentication
-	err = ss.HandleStartup(s.dbList)
+	err = ss.HandleStartup(s.dbList, s.wrapDB)
 	if err != nil {
 		return err
 	}
@@ -38,6 +38,7 @@ type srv struct {
 	Address        string
 	Port           int
 	dbList         database.DatabaseList
+	wrapDB         DatabaseWrapper
 	sysDb          database.DB
 	listener       net.Listener
 }
@@ -47,7 +47,7 @@ type session struct {
 
 type Session interface {
 	InitializeSession() error
-	HandleStartup(dbList database.DatabaseList) error
+	HandleStartup(dbList database.DatabaseList, wrapDB DatabaseWrapper) error
 	QueriesMachine() (err error)
 	ErrorHandle(err error)
 }
@@ -49,7 +49,7 @@ func (s *sessionMock) QueriesMachine() error {
 	return s.QueryMachineF()
 }
 
-func (s *sessionMock) HandleStartup(dbList database.DatabaseList) error {
+func (s *sessionMock) HandleStartup(dbList database.DatabaseList, wrapDB DatabaseWrapper) error {
 	return s.HandleStartupF()
 }
 
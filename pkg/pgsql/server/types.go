@@ -20,7 +20,9 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/pkg/api/schema"
+	"math"
 	"strconv"
 )
 
@@ -47,6 +49,18 @@ func buildNamedParams(paramsType []*schema.Column, paramsVal []interface{}) ([]*
 					return nil, err
 				}
 				pMap[param.Name] = d
+			case "FLOAT":
+				f, err := strconv.ParseFloat(p, 64)
+				if err != nil {
+					return nil, err
+				}
+				pMap[param.Name] = f
+			case "DECIMAL":
+				d, err := sql.ParseDecimal(p)
+				if err != nil {
+					return nil, err
+				}
+				pMap[param.Name] = d
 			}
 		}
 		// binary param
@@ -68,6 +82,12 @@ func buildNamedParams(paramsType []*schema.Column, paramsVal []interface{}) ([]*
 				pMap[param.Name] = v
 			case "BLOB":
 				pMap[param.Name] = p
+			case "FLOAT":
+				f, err := getFloat64(p)
+				if err != nil {
+					return nil, err
+				}
+				pMap[param.Name] = f
 			}
 		}
 	}
@@ -87,3 +107,14 @@ func getInt64(p []byte) (int64, error) {
 		return 0, fmt.Errorf("cannot convert a slice of %d byte in an INTEGER parameter", len(p))
 	}
 }
+
+func getFloat64(p []byte) (float64, error) {
+	switch len(p) {
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(p)), nil
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(p))), nil
+	default:
+		return 0, fmt.Errorf("cannot convert a slice of %d byte in a FLOAT parameter", len(p))
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repl
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client"
+)
+
+type engineExecutor struct {
+	engine *sql.Engine
+	tx     *sql.SQLTx // ongoing transaction started with BEGIN TRANSACTION, if any
+}
+
+// NewEngineExecutor runs the statements on the default database of the engine
+func NewEngineExecutor(engine *sql.Engine) Executor {
+	return &engineExecutor{engine: engine}
+}
+
+func (e *engineExecutor) Exec(src string) (int, error) {
+	ntx, committedTxs, err := e.engine.Exec(context.Background(), src, nil, e.tx)
+	e.tx = ntx
+	if err != nil {
+		return 0, err
+	}
+
+	var updatedRows int
+
+	for _, tx := range committedTxs {
+		updatedRows += tx.UpdatedRows()
+	}
+
+	return updatedRows, nil
+}
+
+func (e *engineExecutor) Query(src string) (*schema.SQLQueryResult, error) {
+	r, err := e.engine.Query(context.Background(), src, nil, e.tx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	colDescriptors, err := r.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &schema.SQLQueryResult{Columns: make([]*schema.Column, len(colDescriptors))}
+
+	for i, c := range colDescriptors {
+		res.Columns[i] = &schema.Column{Name: c.Selector(), Type: c.Type}
+	}
+
+	for {
+		row, err := r.Read()
+		if err == sql.ErrNoMoreRows {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rrow := &schema.Row{
+			Columns: make([]string, len(res.Columns)),
+			Values:  make([]*schema.SQLValue, len(res.Columns)),
+		}
+
+		for i, c := range colDescriptors {
+			rrow.Columns[i] = res.Columns[i].Name
+			rrow.Values[i] = typedValueToRowValue(row.Values[c.Selector()])
+		}
+
+		res.Rows = append(res.Rows, rrow)
+	}
+
+	return res, nil
+}
+
+func (e *engineExecutor) Tables() ([]string, error) {
+	catalog, err := e.engine.Catalog(context.Background(), e.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := catalog.GetDatabaseByName(e.engine.DefaultDatabase())
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []string
+
+	for _, t := range db.GetTables() {
+		tables = append(tables, t.Name())
+	}
+
+	return tables, nil
+}
+
+type clientExecutor struct {
+	ctx    context.Context
+	client client.ImmuClient
+}
+
+// NewClientExecutor runs the statements on the database selected by the client
+func NewClientExecutor(ctx context.Context, client client.ImmuClient) Executor {
+	return &clientExecutor{ctx: ctx, client: client}
+}
+
+func (e *clientExecutor) Exec(src string) (int, error) {
+	res, err := e.client.SQLExec(e.ctx, src, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var updatedRows int
+
+	for _, tx := range res.Txs {
+		updatedRows += int(tx.UpdatedRows)
+	}
+
+	return updatedRows, nil
+}
+
+func (e *clientExecutor) Query(src string) (*schema.SQLQueryResult, error) {
+	return e.client.SQLQuery(e.ctx, src, nil, true)
+}
+
+func (e *clientExecutor) Tables() ([]string, error) {
+	res, err := e.client.ListTables(e.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []string
+
+	for _, row := range res.Rows {
+		tables = append(tables, row.Values[0].GetS())
+	}
+
+	return tables, nil
+}
+
+func typedValueToRowValue(tv sql.TypedValue) *schema.SQLValue {
+	if tv == nil || tv.IsNull() {
+		return &schema.SQLValue{Value: &schema.SQLValue_Null{}}
+	}
+
+	switch tv.Type() {
+	case sql.IntegerType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_N{N: tv.Value().(int64)}}
+		}
+	case sql.VarcharType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_S{S: tv.Value().(string)}}
+		}
+	case sql.BooleanType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_B{B: tv.Value().(bool)}}
+		}
+	case sql.BLOBType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_Bs{Bs: tv.Value().([]byte)}}
+		}
+	case sql.TimestampType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_Ts{Ts: sql.TimeToInt64(tv.Value().(time.Time))}}
+		}
+	case sql.FloatType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_F{F: tv.Value().(float64)}}
+		}
+	case sql.DecimalType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_D{D: sql.FormatDecimal(tv.Value().(*big.Rat))}}
+		}
+	}
+	return nil
+}
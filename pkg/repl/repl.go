@@ -0,0 +1,283 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package repl provides an interactive SQL console which can be embedded into applications,
+// either on top of an embedded sql engine or an immudb client
+package repl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/olekukonko/tablewriter"
+	"github.com/peterh/liner"
+)
+
+const DefaultPrompt = "sql> "
+
+const continuationPrompt = "  -> "
+
+const helpMessage = `Statements are terminated by ';' and may span several lines.
+help      show this message
+tables    list the tables of the selected database
+exit      leave the console
+`
+
+// Executor runs the statements entered into the console
+type Executor interface {
+	Exec(sql string) (updatedRows int, err error)
+	Query(sql string) (*schema.SQLQueryResult, error)
+	Tables() ([]string, error)
+}
+
+type Console struct {
+	executor Executor
+	prompt   string
+	out      io.Writer
+
+	pending strings.Builder
+}
+
+func New(executor Executor) *Console {
+	return &Console{
+		executor: executor,
+		prompt:   DefaultPrompt,
+		out:      os.Stdout,
+	}
+}
+
+func (c *Console) WithPrompt(prompt string) *Console {
+	c.prompt = prompt
+	return c
+}
+
+func (c *Console) WithOutput(out io.Writer) *Console {
+	c.out = out
+	return c
+}
+
+// Run starts an interactive session on the terminal, it returns when exit is entered or the input is closed
+func (c *Console) Run() error {
+	l := liner.NewLiner()
+	defer l.Close()
+
+	l.SetCtrlCAborts(true)
+	l.SetCompleter(c.Complete)
+
+	for {
+		prompt := c.prompt
+		if c.pending.Len() > 0 {
+			prompt = continuationPrompt
+		}
+
+		line, err := l.Prompt(prompt)
+		if err == liner.ErrPromptAborted {
+			c.pending.Reset()
+			continue
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(line) != "" {
+			l.AppendHistory(line)
+		}
+
+		if c.feed(line) {
+			return nil
+		}
+	}
+}
+
+// RunScript evaluates the lines read from r as if they were entered into the console
+func (c *Console) RunScript(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		if c.feed(scanner.Text()) {
+			return nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// last statement may not be terminated
+	if c.pending.Len() > 0 {
+		c.flush()
+	}
+
+	return nil
+}
+
+// feed appends the line to the pending statement, which gets evaluated once terminated.
+// It returns true when the console should be closed
+func (c *Console) feed(line string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	if c.pending.Len() == 0 {
+		switch strings.ToLower(strings.TrimSuffix(trimmed, ";")) {
+		case "":
+			return false
+		case "exit", "quit":
+			return true
+		case "help":
+			fmt.Fprint(c.out, helpMessage)
+			return false
+		case "tables":
+			c.printTables()
+			return false
+		}
+	}
+
+	c.pending.WriteString(line)
+	c.pending.WriteString("\n")
+
+	if strings.HasSuffix(trimmed, ";") {
+		c.flush()
+	}
+
+	return false
+}
+
+func (c *Console) flush() {
+	stmt := c.pending.String()
+	c.pending.Reset()
+
+	err := c.Eval(stmt)
+	if err != nil {
+		fmt.Fprintf(c.out, "ERROR: %s\n", err)
+	}
+}
+
+// Eval runs the given statements and prints their outcome
+func (c *Console) Eval(src string) error {
+	stmts, err := sql.ParseString(src)
+	if err != nil {
+		return err
+	}
+
+	if len(stmts) == 1 {
+		if _, isQuery := stmts[0].(*sql.SelectStmt); isQuery {
+			res, err := c.executor.Query(src)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprint(c.out, FormatResult(res))
+			fmt.Fprintf(c.out, "%d row(s)\n", len(res.Rows))
+			return nil
+		}
+	}
+
+	updatedRows, err := c.executor.Exec(src)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "Updated rows: %d\n", updatedRows)
+	return nil
+}
+
+func (c *Console) printTables() {
+	tables, err := c.executor.Tables()
+	if err != nil {
+		fmt.Fprintf(c.out, "ERROR: %s\n", err)
+		return
+	}
+
+	for _, t := range tables {
+		fmt.Fprintln(c.out, t)
+	}
+}
+
+// Complete returns the candidate lines for the last word of the line, based on keywords and table names
+func (c *Console) Complete(line string) []string {
+	start := strings.LastIndexAny(line, " \t\n(,") + 1
+
+	prefix := line[start:]
+	if prefix == "" {
+		return nil
+	}
+
+	upperPrefix := strings.ToUpper(prefix)
+	lowerCase := prefix == strings.ToLower(prefix)
+
+	var completions []string
+
+	for _, kw := range sql.Keywords() {
+		if strings.HasPrefix(kw, upperPrefix) {
+			if lowerCase {
+				kw = strings.ToLower(kw)
+			}
+			completions = append(completions, line[:start]+kw)
+		}
+	}
+
+	tables, err := c.executor.Tables()
+	if err == nil {
+		for _, t := range tables {
+			if strings.HasPrefix(strings.ToUpper(t), upperPrefix) {
+				completions = append(completions, line[:start]+t)
+			}
+		}
+	}
+
+	sort.Strings(completions)
+
+	return completions
+}
+
+// FormatResult renders the result as a table
+func FormatResult(res *schema.SQLQueryResult) string {
+	if res == nil {
+		return ""
+	}
+
+	result := bytes.NewBuffer([]byte{})
+	consoleTable := tablewriter.NewWriter(result)
+
+	cols := make([]string, len(res.Columns))
+	for i, c := range res.Columns {
+		cols[i] = c.Name
+	}
+	consoleTable.SetHeader(cols)
+
+	for _, r := range res.Rows {
+		row := make([]string, len(r.Values))
+
+		for i, v := range r.Values {
+			row[i] = schema.RenderValue(v.Value)
+		}
+
+		consoleTable.Append(row)
+	}
+
+	consoleTable.Render()
+
+	return result.String()
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repl
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConsole(t *testing.T) (*Console, *bytes.Buffer) {
+	dir := t.TempDir()
+
+	st, err := store.Open(dir, store.DefaultOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { st.Close() })
+
+	engine, err := sql.NewEngine(st, sql.DefaultOptions().WithPrefix([]byte{2}))
+	require.NoError(t, err)
+
+	_, _, err = engine.Exec(context.Background(), "CREATE DATABASE db1", nil, nil)
+	require.NoError(t, err)
+
+	err = engine.SetDefaultDatabase("db1")
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+
+	return New(NewEngineExecutor(engine)).WithOutput(out), out
+}
+
+func TestConsoleRunScript(t *testing.T) {
+	console, out := newTestConsole(t)
+
+	err := console.RunScript(strings.NewReader(`
+		CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id);
+		INSERT INTO table1 (id, title)
+		VALUES (1, 'title1'), (2, 'title2');
+		tables
+		SELECT id, title FROM table1 WHERE id > 1;
+		SELECT missing FROM table1;
+		BEGIN TRANSACTION;
+		INSERT INTO table1 (id, title) VALUES (3, 'title3');
+		COMMIT;
+		SELECT COUNT(*) FROM table1
+	`))
+	require.NoError(t, err)
+
+	output := out.String()
+	require.Contains(t, output, "Updated rows: 2\n")
+	require.Contains(t, output, "table1\n")
+	require.Contains(t, output, "\"title2\"")
+	require.NotContains(t, output, "\"title1\"")
+	require.Contains(t, output, "1 row(s)\n")
+	require.Contains(t, output, "ERROR: ")
+	require.Contains(t, output, "Updated rows: 1\n")
+	require.Contains(t, output, " 3 |")
+}
+
+func TestConsoleExit(t *testing.T) {
+	console, out := newTestConsole(t)
+
+	err := console.RunScript(strings.NewReader("help\nexit\nCREATE TABLE table1 (id INTEGER, PRIMARY KEY id);"))
+	require.NoError(t, err)
+	require.Equal(t, helpMessage, out.String())
+}
+
+func TestConsoleEval(t *testing.T) {
+	console, _ := newTestConsole(t)
+
+	err := console.Eval("invalid statement")
+	require.Error(t, err)
+
+	err = console.Eval("SELECT id FROM table1")
+	require.Error(t, err)
+}
+
+func TestConsoleComplete(t *testing.T) {
+	console, _ := newTestConsole(t)
+
+	err := console.Eval("CREATE TABLE customers (id INTEGER, PRIMARY KEY id)")
+	require.NoError(t, err)
+
+	require.Empty(t, console.Complete("SELECT "))
+	require.Equal(t, []string{"SELECT"}, console.Complete("SEL"))
+	require.Equal(t, []string{"select * from"}, console.Complete("select * fr"))
+	require.Equal(t, []string{"SELECT * FROM customers"}, console.Complete("SELECT * FROM cu"))
+}
+
+func TestFormatResult(t *testing.T) {
+	require.Empty(t, FormatResult(nil))
+
+	console, _ := newTestConsole(t)
+
+	err := console.Eval("CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)")
+	require.NoError(t, err)
+
+	err = console.Eval("INSERT INTO table1 (id) VALUES (1)")
+	require.NoError(t, err)
+
+	res, err := console.executor.Query("SELECT id, title FROM table1")
+	require.NoError(t, err)
+
+	formatted := FormatResult(res)
+	require.Contains(t, formatted, "(DB1 TABLE1 ID)")
+	require.Contains(t, formatted, "NULL")
+}
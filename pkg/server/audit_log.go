@@ -0,0 +1,304 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrInvalidAuditLogOptions = errors.New("invalid audit log options")
+
+// AuditScrubAction is how a request field is scrubbed before the request is audited
+type AuditScrubAction string
+
+const (
+	// AuditScrubHash replaces the field by its sha256 digest, so requests on the same e.g. key
+	// can still be correlated without their content being stored
+	AuditScrubHash AuditScrubAction = "hash"
+	// AuditScrubDrop removes the field
+	AuditScrubDrop AuditScrubAction = "drop"
+)
+
+// auditRecord is what is logged for every audited request
+type auditRecord struct {
+	Time       string      `json:"time"`
+	Method     string      `json:"method"`
+	User       string      `json:"user,omitempty"`
+	Database   string      `json:"database,omitempty"`
+	DurationMs float64     `json:"durationMs"`
+	Error      string      `json:"error,omitempty"`
+	Request    interface{} `json:"request,omitempty"`
+}
+
+// AuditLogInterceptor audits the requests served, as sampled and scrubbed by the audit log options
+func (s *ImmuServer) AuditLogInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !s.Options.AuditLogOptions.isEnabled() {
+		return handler(ctx, req)
+	}
+
+	start := time.Now()
+
+	res, err := handler(ctx, req)
+
+	if s.isAudited(info.FullMethod, err) {
+		s.audit(ctx, info.FullMethod, req, start, err)
+	}
+
+	return res, err
+}
+
+// AuditLogStreamInterceptor audits the streams served, their messages are not included
+func (s *ImmuServer) AuditLogStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !s.Options.AuditLogOptions.isEnabled() {
+		return handler(srv, ss)
+	}
+
+	start := time.Now()
+
+	err := handler(srv, ss)
+
+	if s.isAudited(info.FullMethod, err) {
+		s.audit(ss.Context(), info.FullMethod, nil, start, err)
+	}
+
+	return err
+}
+
+func (opts *AuditLogOptions) isEnabled() bool {
+	return opts != nil && opts.Enabled
+}
+
+// isAudited samples the successful requests, failed ones are always audited
+func (s *ImmuServer) isAudited(fullMethod string, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	opts := s.Options.AuditLogOptions
+
+	rate := opts.SampleRate
+
+	if methodRate, ok := opts.MethodSampleRates[methodName(fullMethod)]; ok {
+		rate = methodRate
+	}
+
+	return rate >= 1 || (rate > 0 && s.auditSampler() < rate)
+}
+
+func (s *ImmuServer) audit(ctx context.Context, fullMethod string, req interface{}, start time.Time, err error) {
+	rec := &auditRecord{
+		Time:       start.UTC().Format(time.RFC3339Nano),
+		Method:     methodName(fullMethod),
+		DurationMs: float64(time.Since(start)) / float64(time.Millisecond),
+	}
+
+	dbIndex, user, uerr := s.getLoggedInUserdataFromCtx(ctx)
+	if uerr == nil && user != nil {
+		rec.User = user.Username
+
+		if dbIndex >= 0 && dbIndex < int64(s.dbList.Length()) {
+			rec.Database = s.dbList.GetByIndex(dbIndex).GetName()
+		}
+	}
+
+	if err != nil {
+		rec.Error = err.Error()
+	}
+
+	if msg, ok := req.(proto.Message); ok {
+		rec.Request = scrubAuditRequest(msg, s.Options.AuditLogOptions.ScrubRules)
+	}
+
+	s.logAuditRecord(rec)
+}
+
+func (s *ImmuServer) logAuditRecord(rec *auditRecord) {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		s.Logger.Errorf("unable to audit %s request: %v", rec.Method, err)
+		return
+	}
+
+	s.Logger.Infof("audit: %s", b)
+}
+
+// scrubAuditRequest returns the fields of the request once scrubbed. Whatever the rules are,
+// passwords are always dropped
+func scrubAuditRequest(msg proto.Message, rules map[string]AuditScrubAction) interface{} {
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+
+	var fields interface{}
+
+	err = json.Unmarshal(b, &fields)
+	if err != nil {
+		return nil
+	}
+
+	return scrubAuditFields(fields, rules)
+}
+
+func scrubAuditFields(v interface{}, rules map[string]AuditScrubAction) interface{} {
+	switch fv := v.(type) {
+	case map[string]interface{}:
+		for name, f := range fv {
+			switch auditScrubActionFor(name, rules) {
+			case AuditScrubDrop:
+				delete(fv, name)
+			case AuditScrubHash:
+				fv[name] = hashAuditField(f)
+			default:
+				fv[name] = scrubAuditFields(f, rules)
+			}
+		}
+	case []interface{}:
+		for i, f := range fv {
+			fv[i] = scrubAuditFields(f, rules)
+		}
+	}
+
+	return v
+}
+
+func auditScrubActionFor(field string, rules map[string]AuditScrubAction) AuditScrubAction {
+	if strings.Contains(strings.ToLower(field), "password") {
+		return AuditScrubDrop
+	}
+
+	for name, action := range rules {
+		if strings.EqualFold(name, field) {
+			return action
+		}
+	}
+
+	return ""
+}
+
+func hashAuditField(v interface{}) interface{} {
+	switch fv := v.(type) {
+	case []interface{}:
+		hashed := make([]interface{}, len(fv))
+		for i, f := range fv {
+			hashed[i] = hashAuditField(f)
+		}
+		return hashed
+	default:
+		b, _ := json.Marshal(fv)
+		h := sha256.Sum256(b)
+		return hex.EncodeToString(h[:])
+	}
+}
+
+// methodName returns the name of the method out of its full name e.g. /immudb.schema.ImmuService/Set
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func validateAuditLogOptions(opts *AuditLogOptions) error {
+	if !opts.isEnabled() {
+		return nil
+	}
+
+	if opts.SampleRate < 0 || opts.SampleRate > 1 {
+		return fmt.Errorf("%w: sample rate must be between 0 and 1", ErrInvalidAuditLogOptions)
+	}
+
+	for method, rate := range opts.MethodSampleRates {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%w: sample rate of method %s must be between 0 and 1", ErrInvalidAuditLogOptions, method)
+		}
+	}
+
+	for field, action := range opts.ScrubRules {
+		if action != AuditScrubHash && action != AuditScrubDrop {
+			return fmt.Errorf("%w: unknown scrub action '%s' for field %s", ErrInvalidAuditLogOptions, action, field)
+		}
+	}
+
+	return nil
+}
+
+// ParseAuditSampleRates parses sample rates by method, given as a comma separated list e.g. "Set=0.01,Get=0.1"
+func ParseAuditSampleRates(s string) (map[string]float64, error) {
+	pairs, err := parseAuditPairs(s)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]float64, len(pairs))
+
+	for method, v := range pairs {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid sample rate '%s' for method %s", ErrInvalidAuditLogOptions, v, method)
+		}
+
+		rates[method] = rate
+	}
+
+	return rates, nil
+}
+
+// ParseAuditScrubRules parses scrub rules by field, given as a comma separated list e.g. "key=hash,value=drop"
+func ParseAuditScrubRules(s string) (map[string]AuditScrubAction, error) {
+	pairs, err := parseAuditPairs(s)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make(map[string]AuditScrubAction, len(pairs))
+
+	for field, action := range pairs {
+		rules[field] = AuditScrubAction(strings.ToLower(action))
+	}
+
+	return rules, nil
+}
+
+func parseAuditPairs(s string) (map[string]string, error) {
+	pairs := make(map[string]string)
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("%w: '%s' is not a name=value pair", ErrInvalidAuditLogOptions, pair)
+		}
+
+		pairs[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestServerAuditLog(t *testing.T) {
+	auditOpts := DefaultAuditLogOptions().
+		WithEnabled(true).
+		WithSampleRate(0.5).
+		WithMethodSampleRates(map[string]float64{"Login": 1, "Health": 0}).
+		WithScrubRules(map[string]AuditScrubAction{"key": AuditScrubHash, "value": AuditScrubDrop})
+
+	serverOptions := DefaultOptions().
+		WithDir(t.TempDir()).
+		WithPort(0).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithAuditLogOptions(auditOpts)
+
+	var logs bytes.Buffer
+
+	s := DefaultServer().WithOptions(serverOptions).WithLogger(logger.NewSimpleLoggerWithLevel("immudb", &logs, logger.LogInfo)).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	sample := 0.0
+	s.auditSampler = func() float64 { return sample }
+
+	audited := func() []map[string]interface{} {
+		var recs []map[string]interface{}
+
+		for _, line := range strings.Split(logs.String(), "\n") {
+			i := strings.Index(line, "audit: ")
+			if i < 0 {
+				continue
+			}
+
+			var rec map[string]interface{}
+			err := json.Unmarshal([]byte(line[i+len("audit: "):]), &rec)
+			require.NoError(t, err)
+
+			recs = append(recs, rec)
+		}
+
+		logs.Reset()
+
+		return recs
+	}
+
+	call := func(ctx context.Context, method string, req interface{}, handler grpc.UnaryHandler) error {
+		_, err := s.AuditLogInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/immudb.schema.ImmuService/" + method}, handler)
+		return err
+	}
+
+	audited()
+
+	var token string
+
+	err = call(context.Background(), "Login", &schema.LoginRequest{User: []byte(auth.SysAdminUsername), Password: []byte(auth.SysAdminPassword)},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			lr, err := s.Login(ctx, req.(*schema.LoginRequest))
+			if err == nil {
+				token = lr.Token
+			}
+			return lr, err
+		})
+	require.NoError(t, err)
+
+	t.Run("passwords should never be audited", func(t *testing.T) {
+		recs := audited()
+		require.Len(t, recs, 1)
+		require.Equal(t, "Login", recs[0]["method"])
+
+		req := recs[0]["request"].(map[string]interface{})
+		require.Contains(t, req, "user")
+		require.NotContains(t, req, "password")
+	})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", token))
+
+	setReq := &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}}
+	setHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.Set(ctx, req.(*schema.SetRequest))
+	}
+
+	t.Run("sampled requests should be audited once scrubbed", func(t *testing.T) {
+		err = call(ctx, "Set", setReq, setHandler)
+		require.NoError(t, err)
+
+		err = call(ctx, "Set", setReq, setHandler)
+		require.NoError(t, err)
+
+		recs := audited()
+		require.Len(t, recs, 2)
+
+		require.Equal(t, "Set", recs[0]["method"])
+		require.Equal(t, auth.SysAdminUsername, recs[0]["user"])
+		require.Equal(t, DefaultDBName, recs[0]["database"])
+		require.NotContains(t, recs[0], "error")
+
+		kv := recs[0]["request"].(map[string]interface{})["KVs"].([]interface{})[0].(map[string]interface{})
+		require.NotContains(t, kv, "value")
+		require.Len(t, kv["key"], 64)
+
+		// the same key is hashed the same way, thus requests can be correlated
+		require.Equal(t, recs[0]["request"], recs[1]["request"])
+	})
+
+	t.Run("requests not sampled should not be audited unless they fail", func(t *testing.T) {
+		sample = 0.7
+
+		err = call(ctx, "Set", setReq, setHandler)
+		require.NoError(t, err)
+
+		err = call(ctx, "Health", nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		require.NoError(t, err)
+
+		require.Empty(t, audited())
+
+		errFailed := errors.New("failed")
+
+		err = call(ctx, "Health", nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errFailed
+		})
+		require.Equal(t, errFailed, err)
+
+		recs := audited()
+		require.Len(t, recs, 1)
+		require.Equal(t, "Health", recs[0]["method"])
+		require.Equal(t, "failed", recs[0]["error"])
+	})
+
+	t.Run("streams should be audited without their messages", func(t *testing.T) {
+		sample = 0
+
+		err = s.AuditLogStreamInterceptor(nil, &mockServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/immudb.schema.ImmuService/StreamGet"},
+			func(srv interface{}, stream grpc.ServerStream) error {
+				return nil
+			})
+		require.NoError(t, err)
+
+		recs := audited()
+		require.Len(t, recs, 1)
+		require.Equal(t, "StreamGet", recs[0]["method"])
+		require.NotContains(t, recs[0], "request")
+	})
+
+	t.Run("disabling the audit log should stop auditing", func(t *testing.T) {
+		auditOpts.WithEnabled(false)
+		defer auditOpts.WithEnabled(true)
+
+		err = call(ctx, "Set", setReq, setHandler)
+		require.NoError(t, err)
+
+		require.Empty(t, audited())
+	})
+}
+
+func TestAuditLogOptions(t *testing.T) {
+	rates, err := ParseAuditSampleRates(" Set=0.01, Login=1,")
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"Set": 0.01, "Login": 1}, rates)
+
+	_, err = ParseAuditSampleRates("Set=often")
+	require.ErrorIs(t, err, ErrInvalidAuditLogOptions)
+
+	rules, err := ParseAuditScrubRules("key=HASH,value=drop")
+	require.NoError(t, err)
+	require.Equal(t, map[string]AuditScrubAction{"key": AuditScrubHash, "value": AuditScrubDrop}, rules)
+
+	_, err = ParseAuditScrubRules("key")
+	require.ErrorIs(t, err, ErrInvalidAuditLogOptions)
+
+	require.NoError(t, validateAuditLogOptions(nil))
+	require.NoError(t, validateAuditLogOptions(DefaultAuditLogOptions().WithSampleRate(2)))
+
+	for _, opts := range []*AuditLogOptions{
+		DefaultAuditLogOptions().WithEnabled(true).WithSampleRate(-0.1),
+		DefaultAuditLogOptions().WithEnabled(true).WithMethodSampleRates(map[string]float64{"Set": 1.5}),
+		DefaultAuditLogOptions().WithEnabled(true).WithScrubRules(map[string]AuditScrubAction{"key": "encrypt"}),
+	} {
+		err = validateAuditLogOptions(opts)
+		require.ErrorIs(t, err, ErrInvalidAuditLogOptions)
+	}
+
+	s := DefaultServer().WithOptions(DefaultOptions().
+		WithDir(t.TempDir()).
+		WithMetricsServer(false).
+		WithAuditLogOptions(DefaultAuditLogOptions().WithEnabled(true).WithSampleRate(3)),
+	).(*ImmuServer)
+
+	err = s.Initialize()
+	require.ErrorIs(t, err, ErrInvalidAuditLogOptions)
+}
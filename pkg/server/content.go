@@ -0,0 +1,68 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// VerifiableSetByDigest stores the value under a key derived from its sha256 digest. Values already stored are
+// not written again, the reply then holds the transaction in which they were stored by the first request
+func (s *ImmuServer) VerifiableSetByDigest(ctx context.Context, req *schema.VerifiableSetByDigestRequest) (*schema.VerifiableSetByDigestReply, error) {
+	if req == nil || len(req.Value) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	digest := schema.ContentDigest(req.Value)
+	key := schema.ContentKey(digest)
+
+	// the lookup and the write are serialized so the same content is not stored twice by concurrent requests
+	s.contentMux.Lock()
+	defer s.contentMux.Unlock()
+
+	entry, err := s.Get(ctx, &schema.KeyRequest{Key: key})
+	if err != nil && err != store.ErrKeyNotFound {
+		return nil, err
+	}
+
+	if err == nil {
+		if !bytes.Equal(entry.Value, req.Value) {
+			return nil, schema.ErrContentDigestMismatch
+		}
+
+		vtx, err := s.VerifiableTxById(ctx, &schema.VerifiableTxRequest{Tx: entry.Tx, ProveSinceTx: req.ProveSinceTx})
+		if err != nil {
+			return nil, err
+		}
+
+		return &schema.VerifiableSetByDigestReply{Digest: digest, Existed: true, Tx: entry.Tx, VerifiableTx: vtx}, nil
+	}
+
+	vtx, err := s.VerifiableSet(ctx, &schema.VerifiableSetRequest{
+		SetRequest:   &schema.SetRequest{KVs: []*schema.KeyValue{{Key: key, Value: req.Value}}},
+		ProveSinceTx: req.ProveSinceTx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.VerifiableSetByDigestReply{Digest: digest, Tx: vtx.Tx.Header.Id, VerifiableTx: vtx}, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestDatabaseWithCollation(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithDir(t.TempDir()).
+		WithPort(0).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.CreateDatabaseWith(ctx, &schema.DatabaseSettings{
+		DatabaseName: "unknowncollation",
+		Collation:    "unknown",
+	})
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = s.CreateDatabaseWith(ctx, &schema.DatabaseSettings{
+		DatabaseName: "collateddb",
+		Collation:    store.CaseInsensitiveCollationName,
+	})
+	require.NoError(t, err)
+
+	_, err = s.UpdateDatabase(ctx, &schema.DatabaseSettings{
+		DatabaseName: "collateddb",
+		Collation:    store.BytewiseCollationName,
+	})
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	ur, err := s.UseDatabase(ctx, &schema.Database{DatabaseName: "collateddb"})
+	require.NoError(t, err)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	for _, key := range []string{"b", "C", "a", "B"} {
+		_, err = s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(key), Value: []byte(key)}}})
+		require.NoError(t, err)
+	}
+
+	entries, err := s.Scan(ctx, &schema.ScanRequest{})
+	require.NoError(t, err)
+
+	var keys []string
+	for _, e := range entries.Entries {
+		keys = append(keys, string(e.Key))
+	}
+	require.Equal(t, []string{"a", "B", "b", "C"}, keys)
+
+	settings, err := s.GetDatabaseSettings(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, store.CaseInsensitiveCollationName, settings.Collation)
+}
@@ -208,6 +208,20 @@ func (s *ImmuServer) History(ctx context.Context, req *schema.HistoryRequest) (*
 	return db.History(req)
 }
 
+// ComputeDiff streams the keys added, updated or removed between two transactions
+func (s *ImmuServer) ComputeDiff(req *schema.DiffRequest, str schema.ImmuService_ComputeDiffServer) error {
+	if req == nil {
+		return ErrIllegalArguments
+	}
+
+	db, err := s.getDBFromCtx(str.Context(), "ComputeDiff")
+	if err != nil {
+		return err
+	}
+
+	return db.ComputeDiff(req, str.Context().Done(), str.Send)
+}
+
 // SetReference ...
 func (s *ImmuServer) SetReference(ctx context.Context, req *schema.ReferenceRequest) (*schema.TxHeader, error) {
 	if s.Options.GetMaintenance() {
@@ -283,6 +297,39 @@ func (s *ImmuServer) ZScan(ctx context.Context, req *schema.ZScanRequest) (*sche
 	return db.ZScan(req)
 }
 
+// VerifiableZScan ...
+func (s *ImmuServer) VerifiableZScan(ctx context.Context, req *schema.VerifiableZScanRequest) (*schema.VerifiableZEntries, error) {
+	db, err := s.getDBFromCtx(ctx, "VerifiableZScan")
+	if err != nil {
+		return nil, err
+	}
+
+	vEntries, err := db.VerifiableZScan(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.StateSigner != nil && vEntries.VerifiableTx != nil {
+		hdr := schema.TxHeaderFromProto(vEntries.VerifiableTx.DualProof.TargetTxHeader)
+		alh := hdr.Alh()
+
+		newState := &schema.ImmutableState{
+			Db:     db.GetOptions().GetDBName(),
+			TxId:   hdr.ID,
+			TxHash: alh[:],
+		}
+
+		err = s.StateSigner.Sign(newState)
+		if err != nil {
+			return nil, err
+		}
+
+		vEntries.VerifiableTx.Signature = newState.Signature
+	}
+
+	return vEntries, nil
+}
+
 // VerifiableZAdd ...
 func (s *ImmuServer) VerifiableZAdd(ctx context.Context, req *schema.VerifiableZAddRequest) (*schema.VerifiableTx, error) {
 	if s.Options.GetMaintenance() {
@@ -330,6 +377,26 @@ func (s *ImmuServer) CompactIndex(ctx context.Context, _ *empty.Empty) (*empty.E
 	return &empty.Empty{}, err
 }
 
+// IndexSuggestions reports the indexes which would have avoided full scans on the selected database
+func (s *ImmuServer) IndexSuggestions(ctx context.Context, _ *empty.Empty) (*schema.IndexSuggestionList, error) {
+	db, err := s.getDBFromCtx(ctx, "IndexSuggestions")
+	if err != nil {
+		return nil, err
+	}
+
+	return db.IndexSuggestions()
+}
+
+// IndexingProgress reports how far the indexing of the selected database is
+func (s *ImmuServer) IndexingProgress(ctx context.Context, _ *empty.Empty) (*schema.IndexingProgressResponse, error) {
+	db, err := s.getDBFromCtx(ctx, "IndexingProgress")
+	if err != nil {
+		return nil, err
+	}
+
+	return db.IndexingProgress()
+}
+
 // GetAll ...
 func (s *ImmuServer) GetAll(ctx context.Context, req *schema.KeyListRequest) (*schema.Entries, error) {
 	if req == nil {
@@ -344,6 +411,39 @@ func (s *ImmuServer) GetAll(ctx context.Context, req *schema.KeyListRequest) (*s
 	return db.GetAll(req)
 }
 
+// VerifiableGetAll ...
+func (s *ImmuServer) VerifiableGetAll(ctx context.Context, req *schema.VerifiableGetAllRequest) (*schema.VerifiableEntries, error) {
+	db, err := s.getDBFromCtx(ctx, "VerifiableGetAll")
+	if err != nil {
+		return nil, err
+	}
+
+	vEntries, err := db.VerifiableGetAll(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.StateSigner != nil && vEntries.VerifiableTx != nil {
+		hdr := schema.TxHeaderFromProto(vEntries.VerifiableTx.DualProof.TargetTxHeader)
+		alh := hdr.Alh()
+
+		newState := &schema.ImmutableState{
+			Db:     db.GetOptions().GetDBName(),
+			TxId:   hdr.ID,
+			TxHash: alh[:],
+		}
+
+		err = s.StateSigner.Sign(newState)
+		if err != nil {
+			return nil, err
+		}
+
+		vEntries.VerifiableTx.Signature = newState.Signature
+	}
+
+	return vEntries, nil
+}
+
 func (s *ImmuServer) Delete(ctx context.Context, req *schema.DeleteKeysRequest) (*schema.TxHeader, error) {
 	if req == nil {
 		return nil, store.ErrIllegalArguments
@@ -357,6 +457,29 @@ func (s *ImmuServer) Delete(ctx context.Context, req *schema.DeleteKeysRequest)
 	return db.Delete(req)
 }
 
+// Forget destroys the data key of the specified key, making its encrypted values unrecoverable
+func (s *ImmuServer) Forget(ctx context.Context, req *schema.ForgetRequest) (*empty.Empty, error) {
+	if req == nil {
+		return nil, store.ErrIllegalArguments
+	}
+
+	if s.Options.GetMaintenance() {
+		return nil, ErrNotAllowedInMaintenanceMode
+	}
+
+	db, err := s.getDBFromCtx(ctx, "Forget")
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Forget(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
 func (s *ImmuServer) ExecAll(ctx context.Context, req *schema.ExecAllRequest) (*schema.TxHeader, error) {
 	if s.Options.GetMaintenance() {
 		return nil, ErrNotAllowedInMaintenanceMode
@@ -46,6 +46,8 @@ type dbOptions struct {
 	MaxValueLen  int `json:"maxValueLen"`  // permanent
 	MaxTxEntries int `json:"maxTxEntries"` // permanent
 
+	Collation string `json:"collation"` // permanent, orders the keys of key-value entries in the index
+
 	ExcludeCommitTime bool `json:"excludeCommitTime"`
 
 	MaxConcurrency   int `json:"maxConcurrency"`
@@ -58,6 +60,10 @@ type dbOptions struct {
 
 	IndexOptions *indexOptions `json:"indexOptions"`
 
+	// retention options
+	RetentionPeriod int64 `json:"retentionPeriod"` // ms, values of older transactions are truncated, 0 to keep them all
+	RetentionDryRun bool  `json:"retentionDryRun"` // truncations are only reported
+
 	CreatedBy string    `json:"createdBy"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedBy string    `json:"updatedBy"`
@@ -77,6 +83,8 @@ type indexOptions struct {
 	NodesLogMaxOpenedFiles   int   `json:"nodesLogMaxOpenedFiles"`
 	HistoryLogMaxOpenedFiles int   `json:"historyLogMaxOpenedFiles"`
 	CommitLogMaxOpenedFiles  int   `json:"commitLogMaxOpenedFiles"`
+	WAL                      bool  `json:"wal"`
+	CheckpointThreshold      int   `json:"checkpointThreshold"`
 }
 
 const DefaultMaxValueLen = 1 << 25   //32Mb
@@ -124,15 +132,24 @@ func (s *ImmuServer) defaultIndexOptions() *indexOptions {
 		NodesLogMaxOpenedFiles:   tbtree.DefaultNodesLogMaxOpenedFiles,
 		HistoryLogMaxOpenedFiles: tbtree.DefaultHistoryLogMaxOpenedFiles,
 		CommitLogMaxOpenedFiles:  tbtree.DefaultCommitLogMaxOpenedFiles,
+		CheckpointThreshold:      store.DefaultIndexCheckpointThld,
 	}
 }
 
 func (s *ImmuServer) databaseOptionsFrom(opts *dbOptions) *database.Options {
+	stOpts := opts.storeOptions()
+
+	if s.indexBufferPool != nil {
+		stOpts.IndexOpts.WithBufferPool(s.indexBufferPool)
+	}
+
 	return database.DefaultOption().
 		WithDBName(opts.Database).
 		WithDBRootPath(s.Options.Dir).
-		WithStoreOptions(s.storeOptionsForDB(opts.Database, s.remoteStorage, opts.storeOptions())).
-		AsReplica(opts.Replica)
+		WithStoreOptions(s.storeOptionsForDB(opts.Database, s.remoteStorage, stOpts)).
+		AsReplica(opts.Replica).
+		WithDefaultPageSize(s.Options.DefaultPageSize).
+		WithMaxPageSize(s.Options.MaxPageSize)
 }
 
 func (opts *dbOptions) storeOptions() *store.Options {
@@ -150,7 +167,9 @@ func (opts *dbOptions) storeOptions() *store.Options {
 			WithDelayDuringCompaction(time.Millisecond * time.Duration(opts.IndexOptions.DelayDuringCompaction)).
 			WithNodesLogMaxOpenedFiles(opts.IndexOptions.NodesLogMaxOpenedFiles).
 			WithHistoryLogMaxOpenedFiles(opts.IndexOptions.HistoryLogMaxOpenedFiles).
-			WithCommitLogMaxOpenedFiles(opts.IndexOptions.CommitLogMaxOpenedFiles)
+			WithCommitLogMaxOpenedFiles(opts.IndexOptions.CommitLogMaxOpenedFiles).
+			WithWAL(opts.IndexOptions.WAL).
+			WithCheckpointThld(opts.IndexOptions.CheckpointThreshold)
 	}
 
 	stOpts := store.DefaultOptions().
@@ -168,6 +187,14 @@ func (opts *dbOptions) storeOptions() *store.Options {
 		WithMaxLinearProofLen(0). // fixed no limitation, it may be customized in the future
 		WithIndexOptions(indexOpts)
 
+	if opts.Collation != "" {
+		// collation names are validated when the settings are taken
+		collation, err := store.CollationByName(opts.Collation)
+		if err == nil {
+			stOpts.WithCollation([]byte{database.SetKeyPrefix}, collation)
+		}
+	}
+
 	if opts.ExcludeCommitTime {
 		stOpts.WithTimeFunc(func() time.Time { return time.Unix(0, 0) })
 	} else {
@@ -193,6 +220,8 @@ func (opts *dbOptions) databaseSettings() *schema.DatabaseSettings {
 		MaxValueLen:  uint32(opts.MaxValueLen),
 		MaxTxEntries: uint32(opts.MaxTxEntries),
 
+		Collation: opts.Collation,
+
 		ExcludeCommitTime: opts.ExcludeCommitTime,
 
 		MaxConcurrency:   uint32(opts.MaxConcurrency),
@@ -216,7 +245,12 @@ func (opts *dbOptions) databaseSettings() *schema.DatabaseSettings {
 			NodesLogMaxOpenedFiles:   uint32(opts.IndexOptions.NodesLogMaxOpenedFiles),
 			HistoryLogMaxOpenedFiles: uint32(opts.IndexOptions.HistoryLogMaxOpenedFiles),
 			CommitLogMaxOpenedFiles:  uint32(opts.IndexOptions.CommitLogMaxOpenedFiles),
+			Wal:                      opts.IndexOptions.WAL,
+			CheckpointThreshold:      uint32(opts.IndexOptions.CheckpointThreshold),
 		},
+
+		RetentionPeriod: uint64(opts.RetentionPeriod),
+		RetentionDryRun: opts.RetentionDryRun,
 	}
 }
 
@@ -237,6 +271,16 @@ func (s *ImmuServer) overwriteWith(opts *dbOptions, settings *schema.DatabaseSet
 		return fmt.Errorf("%w: invalid replication options for database '%s'", ErrIllegalArguments, opts.Database)
 	}
 
+	// replicas can not be truncated, catalogs copied beforehand would diverge from the master ones
+	if settings.Replica && settings.RetentionPeriod > 0 {
+		return fmt.Errorf("%w: retention period can not be set on replicas ('%s')", ErrIllegalArguments, opts.Database)
+	}
+
+	// transactions are found by their commit time when truncating
+	if settings.ExcludeCommitTime && settings.RetentionPeriod > 0 {
+		return fmt.Errorf("%w: retention period can not be set when commit time is excluded ('%s')", ErrIllegalArguments, opts.Database)
+	}
+
 	if existentDB {
 		// permanent settings can not be changed after database is created
 		// in the future, some settings may turn into non-permanent
@@ -258,6 +302,10 @@ func (s *ImmuServer) overwriteWith(opts *dbOptions, settings *schema.DatabaseSet
 				"max number of entries per transaction", opts.Database)
 		}
 
+		if settings.Collation != "" && settings.Collation != opts.Collation {
+			return fmt.Errorf("%w: %s can not be changed after database creation ('%s')", ErrIllegalArguments, "collation", opts.Database)
+		}
+
 		if settings.IndexSettings != nil && settings.IndexSettings.MaxNodeSize > 0 {
 			return fmt.Errorf("%w: %s can not be changed after database creation ('%s')", ErrIllegalArguments, "max node size", opts.Database)
 		}
@@ -279,6 +327,15 @@ func (s *ImmuServer) overwriteWith(opts *dbOptions, settings *schema.DatabaseSet
 	conditionalSet(settings.MaxValueLen > 0, func() { opts.MaxValueLen = int(settings.MaxValueLen) })
 	conditionalSet(settings.MaxTxEntries > 0, func() { opts.MaxTxEntries = int(settings.MaxTxEntries) })
 
+	if settings.Collation != "" {
+		_, err := store.CollationByName(settings.Collation)
+		if err != nil {
+			return fmt.Errorf("%w: %v ('%s')", ErrIllegalArguments, err, opts.Database)
+		}
+
+		opts.Collation = settings.Collation
+	}
+
 	// store options
 	opts.ExcludeCommitTime = settings.ExcludeCommitTime
 
@@ -297,6 +354,7 @@ func (s *ImmuServer) overwriteWith(opts *dbOptions, settings *schema.DatabaseSet
 		}
 
 		opts.IndexOptions.Synced = settings.IndexSettings.Synced
+		opts.IndexOptions.WAL = settings.IndexSettings.Wal
 
 		conditionalSet(settings.IndexSettings.FlushThreshold > 0, func() {
 			opts.IndexOptions.FlushThreshold = int(settings.IndexSettings.FlushThreshold)
@@ -331,8 +389,15 @@ func (s *ImmuServer) overwriteWith(opts *dbOptions, settings *schema.DatabaseSet
 		conditionalSet(settings.IndexSettings.CommitLogMaxOpenedFiles > 0, func() {
 			opts.IndexOptions.CommitLogMaxOpenedFiles = int(settings.IndexSettings.CommitLogMaxOpenedFiles)
 		})
+		conditionalSet(settings.IndexSettings.CheckpointThreshold > 0, func() {
+			opts.IndexOptions.CheckpointThreshold = int(settings.IndexSettings.CheckpointThreshold)
+		})
 	}
 
+	// retention options
+	opts.RetentionPeriod = int64(settings.RetentionPeriod)
+	opts.RetentionDryRun = settings.RetentionDryRun
+
 	return nil
 }
 
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/database"
+)
+
+func (s *ImmuServer) diskSpaceWatchdogEnabled() bool {
+	return s.Options.MinFreeDiskSpace > 0
+}
+
+// startDiskSpaceWatchdog periodically checks the free space of the disks databases are stored in
+// until the server is stopped
+func (s *ImmuServer) startDiskSpaceWatchdog() {
+	s.diskSpaceWatchdogDone = make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(s.Options.DiskSpaceCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			s.checkDiskSpace()
+
+			select {
+			case <-s.diskSpaceWatchdogDone:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	s.Logger.Infof("Disk space watchdog started {min free space = %d bytes}", s.Options.MinFreeDiskSpace)
+}
+
+func (s *ImmuServer) stopDiskSpaceWatchdog() {
+	if s.diskSpaceWatchdogDone == nil {
+		return
+	}
+
+	close(s.diskSpaceWatchdogDone)
+	s.diskSpaceWatchdogDone = nil
+}
+
+// checkDiskSpace switches to read-only the databases stored in a disk whose free space fell under
+// the threshold, so writes are rejected before appendables are left partially written by a full disk.
+// Databases are made writable again once enough space is freed
+func (s *ImmuServer) checkDiskSpace() {
+	dbs := []database.DB{s.sysDB}
+	for i := 0; i < s.dbList.Length(); i++ {
+		dbs = append(dbs, s.dbList.GetByIndex(int64(i)))
+	}
+
+	for _, db := range dbs {
+		if db == nil {
+			continue
+		}
+
+		dbName := db.GetName()
+
+		free, err := s.freeDiskSpace(filepath.Join(s.Options.Dir, dbName))
+		if err != nil {
+			s.Logger.Errorf("Error checking free disk space of database '%s': %v", dbName, err)
+			continue
+		}
+
+		Metrics.DiskFreeGauges.WithLabelValues(dbName).Set(float64(free))
+
+		lowDiskSpace := free < s.Options.MinFreeDiskSpace
+
+		if lowDiskSpace {
+			Metrics.ReadOnlyGauges.WithLabelValues(dbName).Set(1)
+		} else {
+			Metrics.ReadOnlyGauges.WithLabelValues(dbName).Set(0)
+		}
+
+		if lowDiskSpace == db.IsReadOnly() {
+			continue
+		}
+
+		db.AsReadOnly(lowDiskSpace)
+
+		if lowDiskSpace {
+			s.Logger.Errorf("Database '%s' switched to read-only, free disk space is %d bytes (min %d bytes)", dbName, free, s.Options.MinFreeDiskSpace)
+		} else {
+			s.Logger.Infof("Database '%s' is writable again, free disk space is %d bytes", dbName, free)
+		}
+	}
+}
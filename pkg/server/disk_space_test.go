@@ -0,0 +1,134 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestServerDiskSpaceWatchdog(t *testing.T) {
+	dir := t.TempDir()
+
+	serverOptions := DefaultOptions().
+		WithDir(filepath.Join(dir, "data")).
+		WithMetricsServer(false).
+		WithWebServer(false).
+		WithPgsqlServer(false).
+		WithPort(0).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithMinFreeDiskSpace(1024).
+		WithDiskSpaceCheckInterval(10 * time.Millisecond)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	var free uint64 = 2048
+
+	s.freeDiskSpace = func(path string) (uint64, error) {
+		return atomic.LoadUint64(&free), nil
+	}
+
+	err := s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	set := func() error {
+		_, err := s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+		return err
+	}
+
+	s.startDiskSpaceWatchdog()
+	defer s.stopDiskSpaceWatchdog()
+
+	err = set()
+	require.NoError(t, err)
+
+	atomic.StoreUint64(&free, 512)
+
+	require.Eventually(t, func() bool {
+		return s.sysDB.IsReadOnly() && s.dbList.GetByIndex(defaultDbIndex).IsReadOnly()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	err = set()
+	require.ErrorIs(t, err, database.ErrIsReadOnly)
+
+	_, err = s.Get(ctx, &schema.KeyRequest{Key: []byte("key1")})
+	require.NoError(t, err)
+
+	atomic.StoreUint64(&free, 4096)
+
+	require.Eventually(t, func() bool {
+		return !s.sysDB.IsReadOnly() && !s.dbList.GetByIndex(defaultDbIndex).IsReadOnly()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	err = set()
+	require.NoError(t, err)
+}
+
+func TestServerDiskSpaceCheckFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	serverOptions := DefaultOptions().
+		WithDir(filepath.Join(dir, "data")).
+		WithMetricsServer(false).
+		WithWebServer(false).
+		WithPgsqlServer(false).
+		WithPort(0).
+		WithMinFreeDiskSpace(1024)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	t.Run("databases should stay writable when the free space can not be read", func(t *testing.T) {
+		s.freeDiskSpace = func(path string) (uint64, error) {
+			return 0, errors.New("statfs failure")
+		}
+
+		s.checkDiskSpace()
+
+		require.False(t, s.sysDB.IsReadOnly())
+	})
+
+	t.Run("free space of the data dir should be read", func(t *testing.T) {
+		s.freeDiskSpace = freeDiskSpace
+
+		free, err := s.freeDiskSpace(s.Options.Dir)
+		require.NoError(t, err)
+		require.Greater(t, free, uint64(0))
+	})
+}
@@ -50,6 +50,14 @@ var (
 	ErrTxNotProperlyClosed         = errors.New("tx not properly closed")
 	ErrReadWriteTxNotOngoing       = errors.New("read write transaction not ongoing")
 	ErrTxReadConflict              = errors.New(store.ErrTxReadConflict.Error()).WithCode(errors.CodInFailedSqlTransaction)
+	ErrQueryNotFound               = errors.New("query not found")
+	ErrNotAllowedInNamespace       = errors.New("operation not allowed for users bound to a namespace")
+	ErrInvalidNamespace            = status.Error(codes.InvalidArgument, "namespace can only contain letters, digits and underscores")
+	ErrNamespacedAdmin             = status.Error(codes.InvalidArgument, "users bound to a namespace can not be granted admin permission")
+	ErrPartiallyCommitted          = errors.New("operations committed into some databases only").WithCode(errors.CodInternalError)
+	ErrMinTxNotReached             = errors.New("database has not reached the last transaction written by the client")
+	ErrSelfCheckFailed             = errors.New("startup self-check failed, data may be corrupted")
+	ErrIdempotencyKeyReused        = status.Error(codes.InvalidArgument, "idempotency key already used for a different request")
 )
 
 func mapServerError(err error) error {
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+)
+
+type execAllParticipant struct {
+	name string
+	db   database.DB
+	req  *schema.ExecAllRequest
+	ptx  database.PreparedTx
+}
+
+// ExecAllPerDatabase writes into several databases of the server, committing a transaction in each of them.
+// Every write is first validated and the databases are locked, then transactions get committed while
+// no other write can get in between. Commits are not atomic as a whole: if one of them fails after others
+// succeeded, those transactions are kept and ErrPartiallyCommitted is returned naming the databases written.
+// Databases are always locked following the order of their names so concurrent calls can not deadlock.
+func (s *ImmuServer) ExecAllPerDatabase(ctx context.Context, req *schema.ExecAllPerDatabaseRequest) (*schema.DatabaseTxHeaders, error) {
+	if req == nil || len(req.Requests) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	if s.Options.GetMaintenance() {
+		return nil, ErrNotAllowedInMaintenanceMode
+	}
+
+	user := &auth.User{IsSysAdmin: true}
+
+	if s.Options.auth {
+		var err error
+
+		_, user, err = s.getLoggedInUserdataFromCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if user.Namespace != "" {
+			return nil, ErrNotAllowedInNamespace
+		}
+	}
+
+	participants := make([]*execAllParticipant, len(req.Requests))
+	names := make(map[string]struct{}, len(req.Requests))
+
+	for i, dbReq := range req.Requests {
+		if dbReq == nil || dbReq.ExecAllRequest == nil {
+			return nil, ErrIllegalArguments
+		}
+
+		_, duplicated := names[dbReq.Database]
+		if duplicated {
+			return nil, fmt.Errorf("%w: database '%s' was specified more than once", database.ErrIllegalArguments, dbReq.Database)
+		}
+		names[dbReq.Database] = struct{}{}
+
+		// systemdb is always read-only from external access
+		if dbReq.Database == SystemDBName {
+			return nil, ErrPermissionDenied
+		}
+
+		db, err := s.dbList.GetByName(dbReq.Database)
+		if err != nil {
+			return nil, err
+		}
+
+		if !user.IsSysAdmin && !auth.HasPermissionForMethod(user.WhichPermission(dbReq.Database), "ExecAllPerDatabase") {
+			return nil, ErrPermissionDenied
+		}
+
+		participants[i] = &execAllParticipant{
+			name: dbReq.Database,
+			db:   s.withWriteValidation(db, user.Username),
+			req:  dbReq.ExecAllRequest,
+		}
+	}
+
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].name < participants[j].name
+	})
+
+	defer func() {
+		for _, p := range participants {
+			if p.ptx != nil {
+				p.ptx.Release()
+			}
+		}
+	}()
+
+	// validate and lock every participant, nothing is written if any of them fails
+	for _, p := range participants {
+		ptx, err := p.db.PrepareExecAll(p.req)
+		if err != nil {
+			return nil, fmt.Errorf("database '%s': %w", p.name, err)
+		}
+
+		p.ptx = ptx
+	}
+
+	// commit every participant, a failure leaves the previous commits in place
+	res := &schema.DatabaseTxHeaders{TxHeaders: make([]*schema.DatabaseTxHeader, len(participants))}
+
+	for i, p := range participants {
+		hdr, err := p.ptx.Commit()
+		if err != nil {
+			if i > 0 {
+				committed := make([]string, i)
+				for j := range committed {
+					committed[j] = participants[j].name
+				}
+
+				s.Logger.Errorf("commit into database '%s' failed after committing into '%s': %v", p.name, strings.Join(committed, "', '"), err)
+				return nil, fmt.Errorf("%w: commit into database '%s' failed after committing into '%s': %v",
+					ErrPartiallyCommitted, p.name, strings.Join(committed, "', '"), err)
+			}
+			return nil, fmt.Errorf("database '%s': %w", p.name, err)
+		}
+
+		res.TxHeaders[i] = &schema.DatabaseTxHeader{Database: p.name, TxHeader: hdr}
+	}
+
+	res.Digest = schema.DatabaseTxHeadersDigest(res.TxHeaders)
+
+	return res, nil
+}
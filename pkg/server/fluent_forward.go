@@ -0,0 +1,422 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"time"
+)
+
+var ErrMalformedFluentMessage = errors.New("malformed fluent forward message")
+
+// maxFluentChunkLen is the max length of strings, binaries and collections in a Fluent Forward message
+const maxFluentChunkLen = 32 * 1024 * 1024
+
+const maxMsgpackDepth = 32
+
+// msgpackExt is an extension value e.g. the Fluent EventTime (type 0)
+type msgpackExt struct {
+	typ  int8
+	data []byte
+}
+
+// fluentMessage holds the entries of a Fluent Forward message, in any of its
+// Message, Forward, PackedForward and CompressedPackedForward modes
+type fluentMessage struct {
+	records []*logRecord
+	chunk   string
+}
+
+// readFluentMessage reads the next Fluent Forward message, an array [tag, entries..., option]
+func readFluentMessage(r *bufio.Reader) (*fluentMessage, error) {
+	v, err := readMsgpack(r, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	arr, ok := v.([]interface{})
+	if !ok || len(arr) < 2 {
+		return nil, fmt.Errorf("%w: array expected", ErrMalformedFluentMessage)
+	}
+
+	tag, ok := arr[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid tag", ErrMalformedFluentMessage)
+	}
+
+	msg := &fluentMessage{}
+
+	var option map[string]interface{}
+	var entries [][]interface{}
+
+	switch e := arr[1].(type) {
+	case []interface{}:
+		// Forward mode: [tag, [[time, record], ...], option]
+		for _, entry := range e {
+			entryArr, ok := entry.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%w: invalid entry", ErrMalformedFluentMessage)
+			}
+			entries = append(entries, entryArr)
+		}
+		option = optionalMap(arr, 2)
+	case string, []byte:
+		// PackedForward mode: [tag, msgpack stream of [time, record], option]
+		option = optionalMap(arr, 2)
+
+		packed := []byte(toString(e))
+
+		if c, ok := option["compressed"]; ok && toString(c) == "gzip" {
+			gz, err := gzip.NewReader(bytes.NewReader(packed))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrMalformedFluentMessage, err)
+			}
+
+			packed, err = ioutil.ReadAll(io.LimitReader(gz, maxFluentChunkLen+1))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrMalformedFluentMessage, err)
+			}
+			if len(packed) > maxFluentChunkLen {
+				return nil, fmt.Errorf("%w: message too large", ErrMalformedFluentMessage)
+			}
+		}
+
+		pr := bufio.NewReader(bytes.NewReader(packed))
+
+		for {
+			_, err := pr.Peek(1)
+			if err == io.EOF {
+				break
+			}
+
+			entry, err := readMsgpack(pr, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			entryArr, ok := entry.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%w: invalid entry", ErrMalformedFluentMessage)
+			}
+			entries = append(entries, entryArr)
+		}
+	default:
+		// Message mode: [tag, time, record, option]
+		if len(arr) < 3 {
+			return nil, fmt.Errorf("%w: record is missing", ErrMalformedFluentMessage)
+		}
+		entries = append(entries, arr[1:3])
+		option = optionalMap(arr, 3)
+	}
+
+	for _, entry := range entries {
+		if len(entry) < 2 {
+			return nil, fmt.Errorf("%w: invalid entry", ErrMalformedFluentMessage)
+		}
+
+		rec, err := fluentRecord(tag, entry[0], entry[1])
+		if err != nil {
+			return nil, err
+		}
+
+		msg.records = append(msg.records, rec)
+	}
+
+	if chunk, ok := option["chunk"]; ok {
+		msg.chunk = toString(chunk)
+	}
+
+	return msg, nil
+}
+
+// fluentRecord maps the event into a log record. The message is taken from the first of the
+// message, log or msg fields while the remaining fields are kept as they are
+func fluentRecord(tag string, eventTime interface{}, record interface{}) (*logRecord, error) {
+	fields, ok := record.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: record must be a map", ErrMalformedFluentMessage)
+	}
+
+	var ts time.Time
+
+	switch t := eventTime.(type) {
+	case int64:
+		ts = time.Unix(t, 0)
+	case uint64:
+		ts = time.Unix(int64(t), 0)
+	case float64:
+		sec, frac := math.Modf(t)
+		ts = time.Unix(int64(sec), int64(frac*1e9))
+	case msgpackExt:
+		if t.typ != 0 || len(t.data) != 8 {
+			return nil, fmt.Errorf("%w: invalid event time", ErrMalformedFluentMessage)
+		}
+		ts = time.Unix(int64(binary.BigEndian.Uint32(t.data)), int64(binary.BigEndian.Uint32(t.data[4:])))
+	default:
+		return nil, fmt.Errorf("%w: invalid event time", ErrMalformedFluentMessage)
+	}
+
+	rec := &logRecord{
+		ts:       ts.UnixNano() / int64(time.Millisecond),
+		protocol: "fluent",
+		app:      tag,
+	}
+
+	for _, name := range []string{"message", "log", "msg"} {
+		if m, ok := fields[name]; ok {
+			rec.message = toString(m)
+			delete(fields, name)
+			break
+		}
+	}
+
+	for _, name := range []string{"host", "hostname"} {
+		if h, ok := fields[name]; ok {
+			rec.hostname = toString(h)
+			delete(fields, name)
+			break
+		}
+	}
+
+	if len(fields) > 0 {
+		rec.fields = fields
+	}
+
+	return rec, nil
+}
+
+func optionalMap(arr []interface{}, i int) map[string]interface{} {
+	if i >= len(arr) {
+		return nil
+	}
+
+	m, _ := arr[i].(map[string]interface{})
+	return m
+}
+
+func toString(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case []byte:
+		return string(s)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// fluentAck is the response sent when the client asks for an acknowledgment: {"ack": chunk}
+func fluentAck(chunk string) []byte {
+	b := []byte{0x81, 0xa3, 'a', 'c', 'k'}
+
+	switch {
+	case len(chunk) < 32:
+		b = append(b, 0xa0|byte(len(chunk)))
+	case len(chunk) <= math.MaxUint8:
+		b = append(b, 0xd9, byte(len(chunk)))
+	default:
+		b = append(b, 0xda, byte(len(chunk)>>8), byte(len(chunk)))
+	}
+
+	return append(b, chunk...)
+}
+
+// readMsgpack decodes the next msgpack value. Maps are decoded as map[string]interface{},
+// integers as int64 (or uint64 when positive and too large) and floats as float64
+func readMsgpack(r *bufio.Reader, depth int) (interface{}, error) {
+	if depth > maxMsgpackDepth {
+		return nil, fmt.Errorf("%w: max nesting depth exceeded", ErrMalformedFluentMessage)
+	}
+
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xf0 == 0x80:
+		return readMsgpackMap(r, int(c&0x0f), depth)
+	case c&0xf0 == 0x90:
+		return readMsgpackArray(r, int(c&0x0f), depth)
+	case c&0xe0 == 0xa0:
+		b, err := readMsgpackBytes(r, int(c&0x1f))
+		return string(b), err
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := readMsgpackUint(r, 1<<(c-0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackBytes(r, int(n))
+	case 0xc7, 0xc8, 0xc9:
+		n, err := readMsgpackUint(r, 1<<(c-0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackExt(r, int(n))
+	case 0xca:
+		n, err := readMsgpackUint(r, 4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 0xcb:
+		n, err := readMsgpackUint(r, 8)
+		return math.Float64frombits(n), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := readMsgpackUint(r, 1<<(c-0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+
+		n, err := readMsgpackUint(r, size)
+		if err != nil {
+			return nil, err
+		}
+
+		shift := uint(64 - 8*size)
+		return int64(n<<shift) >> shift, nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return readMsgpackExt(r, 1<<(c-0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := readMsgpackUint(r, 1<<(c-0xd9))
+		if err != nil {
+			return nil, err
+		}
+		b, err := readMsgpackBytes(r, int(n))
+		return string(b), err
+	case 0xdc, 0xdd:
+		n, err := readMsgpackUint(r, 2<<(c-0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackArray(r, int(n), depth)
+	case 0xde, 0xdf:
+		n, err := readMsgpackUint(r, 2<<(c-0xde))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackMap(r, int(n), depth)
+	}
+
+	return nil, fmt.Errorf("%w: unsupported type 0x%x", ErrMalformedFluentMessage, c)
+}
+
+func readMsgpackUint(r *bufio.Reader, size int) (uint64, error) {
+	var b [8]byte
+
+	_, err := io.ReadFull(r, b[8-size:])
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+func readMsgpackBytes(r *bufio.Reader, n int) ([]byte, error) {
+	if n < 0 || n > maxFluentChunkLen {
+		return nil, fmt.Errorf("%w: value too large", ErrMalformedFluentMessage)
+	}
+
+	b := make([]byte, n)
+
+	_, err := io.ReadFull(r, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func readMsgpackExt(r *bufio.Reader, n int) (interface{}, error) {
+	typ, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readMsgpackBytes(r, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return msgpackExt{typ: int8(typ), data: data}, nil
+}
+
+func readMsgpackArray(r *bufio.Reader, n int, depth int) ([]interface{}, error) {
+	if n < 0 || n > maxFluentChunkLen {
+		return nil, fmt.Errorf("%w: array too large", ErrMalformedFluentMessage)
+	}
+
+	var arr []interface{}
+
+	for i := 0; i < n; i++ {
+		v, err := readMsgpack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+	}
+
+	return arr, nil
+}
+
+func readMsgpackMap(r *bufio.Reader, n int, depth int) (map[string]interface{}, error) {
+	if n < 0 || n > maxFluentChunkLen {
+		return nil, fmt.Errorf("%w: map too large", ErrMalformedFluentMessage)
+	}
+
+	m := make(map[string]interface{})
+
+	for i := 0; i < n; i++ {
+		k, err := readMsgpack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := readMsgpack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		m[toString(k)] = v
+	}
+
+	return m, nil
+}
@@ -0,0 +1,563 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+var ErrInvalidGrafanaTables = errors.New("invalid grafana tables")
+
+// ParseGrafanaTables parses the tables exposed to Grafana, given as a comma separated list
+// of db.table=column pairs naming the column holding the time of the rows e.g. "defaultdb.metrics=ts"
+func ParseGrafanaTables(s string) (map[string]string, error) {
+	tables := make(map[string]string)
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: '%s' is not a db.table=column pair", ErrInvalidGrafanaTables, pair)
+		}
+
+		table := strings.TrimSpace(kv[0])
+		col := strings.TrimSpace(kv[1])
+
+		if strings.Count(table, ".") != 1 || strings.HasPrefix(table, ".") || strings.HasSuffix(table, ".") || col == "" {
+			return nil, fmt.Errorf("%w: '%s' is not a db.table=column pair", ErrInvalidGrafanaTables, pair)
+		}
+
+		tables[table] = col
+	}
+
+	return tables, nil
+}
+
+type grafanaRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type grafanaTarget struct {
+	Target string `json:"target"`
+	RefID  string `json:"refId"`
+	Type   string `json:"type"`
+}
+
+type grafanaSearchRequest struct {
+	Target string `json:"target"`
+}
+
+type grafanaQueryRequest struct {
+	Range   grafanaRange     `json:"range"`
+	Targets []*grafanaTarget `json:"targets"`
+}
+
+type grafanaSeries struct {
+	Target     string           `json:"target"`
+	Datapoints [][2]interface{} `json:"datapoints"`
+}
+
+type grafanaColumn struct {
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+type grafanaTable struct {
+	Type    string           `json:"type"`
+	Columns []*grafanaColumn `json:"columns"`
+	Rows    [][]interface{}  `json:"rows"`
+}
+
+type grafanaAnnotation struct {
+	Name       string          `json:"name"`
+	Datasource json.RawMessage `json:"datasource,omitempty"`
+	Enable     bool            `json:"enable"`
+	IconColor  string          `json:"iconColor,omitempty"`
+	Query      string          `json:"query"`
+}
+
+type grafanaAnnotationsRequest struct {
+	Range      grafanaRange       `json:"range"`
+	Annotation *grafanaAnnotation `json:"annotation"`
+}
+
+type grafanaAnnotationEvent struct {
+	Annotation *grafanaAnnotation `json:"annotation"`
+	Time       int64              `json:"time"`
+	Title      string             `json:"title,omitempty"`
+	Text       string             `json:"text,omitempty"`
+	Tags       []string           `json:"tags"`
+}
+
+// grafanaHandler implements the simple JSON datasource contract at /grafana/<db>/, requests are
+// authenticated with the API keys of the query server and read the database with the same restrictions
+//
+// Metrics are named as table.column, for the numeric columns of the tables configured in the server options,
+// any other query target is run as a SQL query with the time range given in the @time_from and @time_to parameters
+type grafanaHandler struct {
+	q *queryHandler
+}
+
+func (h *grafanaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.q.s.Options.GetMaintenance() {
+		http.Error(w, ErrNotAllowedInMaintenanceMode.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	scope, ok := h.q.authorize(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="immudb"`)
+		http.Error(w, "invalid API key", http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.SplitN(strings.Trim(strings.TrimPrefix(r.URL.Path, "/grafana/"), "/"), "/", 2)
+
+	db := path[0]
+	if db == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !scope.allowsDatabase(db) {
+		http.Error(w, fmt.Sprintf("database '%s' is not readable with the API key", db), http.StatusForbidden)
+		return
+	}
+
+	// the root of the datasource is requested by grafana to test the connection
+	if len(path) == 1 {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var res interface{}
+	var status int
+	var err error
+
+	body := io.LimitReader(r.Body, int64(h.q.s.Options.MaxRecvMsgSize))
+
+	switch path[1] {
+	case "search":
+		req := &grafanaSearchRequest{}
+		if err = json.NewDecoder(body).Decode(req); err != nil && err != io.EOF {
+			break
+		}
+
+		res, status, err = h.search(scope, db, req)
+	case "query":
+		req := &grafanaQueryRequest{}
+		if err = json.NewDecoder(body).Decode(req); err != nil {
+			break
+		}
+
+		res, status, err = h.query(r, scope, db, req)
+	case "annotations":
+		req := &grafanaAnnotationsRequest{}
+		if err = json.NewDecoder(body).Decode(req); err != nil {
+			break
+		}
+
+		res, status, err = h.annotations(r, scope, db, req)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if err != nil {
+		if status == 0 {
+			status = http.StatusBadRequest
+			err = fmt.Errorf("malformed grafana request: %v", err)
+		}
+
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		h.q.s.Logger.Warningf("grafana response could not be written: %v", err)
+	}
+}
+
+// metrics returns the numeric columns of the table but its primary key, along with the type of its time column
+func (h *grafanaHandler) metrics(db, table string) (cols map[string]struct{}, timeType string, err error) {
+	timeCol := h.q.s.Options.GrafanaTables[db+"."+table]
+
+	database, err := h.q.s.dbList.GetByName(db)
+	if err != nil {
+		return nil, "", err
+	}
+
+	res, err := database.DescribeTable(table, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cols = make(map[string]struct{})
+
+	for _, row := range res.Rows {
+		name := row.Values[0].GetS()
+		typ := row.Values[1].GetS()
+
+		if name == timeCol {
+			timeType = typ
+			continue
+		}
+
+		// primary keys identify rows rather than measure anything
+		if row.Values[3].GetS() == "PRIMARY KEY" {
+			continue
+		}
+
+		switch typ {
+		case sql.IntegerType, sql.FloatType, sql.DecimalType:
+			cols[name] = struct{}{}
+		}
+	}
+
+	if timeType != sql.TimestampType && timeType != sql.IntegerType {
+		return nil, "", fmt.Errorf("time column '%s' of table '%s' is neither a TIMESTAMP nor an INTEGER", timeCol, table)
+	}
+
+	return cols, timeType, nil
+}
+
+// search lists the metrics of the configured tables in scope containing the requested target
+func (h *grafanaHandler) search(scope *queryScope, db string, req *grafanaSearchRequest) ([]string, int, error) {
+	metrics := []string{}
+
+	for name := range h.q.s.Options.GrafanaTables {
+		if !strings.HasPrefix(name, db+".") {
+			continue
+		}
+
+		table := strings.TrimPrefix(name, db+".")
+
+		if !scope.allowsTable(db, table) {
+			continue
+		}
+
+		cols, _, err := h.metrics(db, table)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		for col := range cols {
+			metric := table + "." + col
+
+			if strings.Contains(metric, req.Target) {
+				metrics = append(metrics, metric)
+			}
+		}
+	}
+
+	sort.Strings(metrics)
+
+	return metrics, http.StatusOK, nil
+}
+
+// metricQuery returns the query reading the points of a metric in the time range, or false if the target does not name one
+func (h *grafanaHandler) metricQuery(scope *queryScope, db string, rng grafanaRange, target string) (*queryRequest, bool, error) {
+	dot := strings.Index(target, ".")
+	if dot < 0 {
+		return nil, false, nil
+	}
+
+	table := target[:dot]
+	col := target[dot+1:]
+
+	timeCol, ok := h.q.s.Options.GrafanaTables[db+"."+table]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if !scope.allowsTable(db, table) {
+		return nil, true, fmt.Errorf("%w: %s", sql.ErrTableAccessDenied, table)
+	}
+
+	cols, timeType, err := h.metrics(db, table)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if _, ok := cols[col]; !ok {
+		return nil, true, fmt.Errorf("%w: %s is not a metric", sql.ErrColumnDoesNotExist, target)
+	}
+
+	req := &queryRequest{
+		Database: db,
+		SQL: fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s >= @time_from AND %s <= @time_to ORDER BY %s",
+			timeCol, col, table, timeCol, timeCol, timeCol),
+		Params: map[string]interface{}{"time_from": rng.From, "time_to": rng.To},
+	}
+
+	// integer time columns hold milliseconds since the epoch
+	if timeType == sql.IntegerType {
+		req.Params = map[string]interface{}{"time_from": rng.From.UnixMilli(), "time_to": rng.To.UnixMilli()}
+	}
+
+	return req, true, nil
+}
+
+// query returns a time series, or a table if requested so, per target. Results of SQL targets hold the time
+// in their first column, the value in the second one and optionally the name of the series in a third one.
+// Only the first page of rows of each query is returned
+func (h *grafanaHandler) query(r *http.Request, scope *queryScope, db string, req *grafanaQueryRequest) ([]interface{}, int, error) {
+	res := []interface{}{}
+
+	for _, target := range req.Targets {
+		qreq, ok, err := h.metricQuery(scope, db, req.Range, target.Target)
+		if errors.Is(err, sql.ErrTableAccessDenied) {
+			return nil, http.StatusForbidden, err
+		}
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+
+		if !ok {
+			qreq = &queryRequest{
+				Database: db,
+				SQL:      target.Target,
+				Params:   map[string]interface{}{"time_from": req.Range.From, "time_to": req.Range.To},
+			}
+		}
+
+		qres, status, err := h.q.query(r, scope, qreq)
+		if err != nil {
+			return nil, status, err
+		}
+
+		if target.Type == "table" {
+			res = append(res, newGrafanaTable(qres))
+			continue
+		}
+
+		name := target.Target
+		if !ok && target.RefID != "" {
+			name = target.RefID
+		}
+
+		series, err := newGrafanaSeries(name, qres)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+
+		for _, s := range series {
+			res = append(res, s)
+		}
+	}
+
+	return res, http.StatusOK, nil
+}
+
+// annotations runs the query of the annotation, the time of the events is read from the time column
+// while their title, text and comma separated tags are read from the columns named so, if any
+func (h *grafanaHandler) annotations(r *http.Request, scope *queryScope, db string, req *grafanaAnnotationsRequest) ([]*grafanaAnnotationEvent, int, error) {
+	if req.Annotation == nil || req.Annotation.Query == "" {
+		return nil, http.StatusBadRequest, errors.New("missing annotation query")
+	}
+
+	qres, status, err := h.q.query(r, scope, &queryRequest{
+		Database: db,
+		SQL:      req.Annotation.Query,
+		Params:   map[string]interface{}{"time_from": req.Range.From, "time_to": req.Range.To},
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	cols := make(map[string]int)
+
+	for i, col := range qres.Columns {
+		cols[queryColumnName(col.Name)] = i
+	}
+
+	timeIdx, ok := cols["time"]
+	if !ok {
+		return nil, http.StatusBadRequest, errors.New("annotation query should select a time column")
+	}
+
+	events := make([]*grafanaAnnotationEvent, 0, len(qres.Rows))
+
+	for _, row := range qres.Rows {
+		ms, err := grafanaTime(row.Values[timeIdx])
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+
+		event := &grafanaAnnotationEvent{
+			Annotation: req.Annotation,
+			Time:       ms,
+			Tags:       []string{},
+		}
+
+		if i, ok := cols["title"]; ok {
+			event.Title = row.Values[i].GetS()
+		}
+
+		if i, ok := cols["text"]; ok {
+			event.Text = row.Values[i].GetS()
+		}
+
+		if i, ok := cols["tags"]; ok {
+			for _, tag := range strings.Split(row.Values[i].GetS(), ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					event.Tags = append(event.Tags, tag)
+				}
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	return events, http.StatusOK, nil
+}
+
+func newGrafanaSeries(name string, res *schema.SQLQueryResult) ([]*grafanaSeries, error) {
+	if len(res.Columns) < 2 || len(res.Columns) > 3 {
+		return nil, errors.New("time series queries should select the time, the value and optionally the name of the series")
+	}
+
+	var series []*grafanaSeries
+	byName := make(map[string]*grafanaSeries)
+
+	for _, row := range res.Rows {
+		ms, err := grafanaTime(row.Values[0])
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := grafanaNumber(row.Values[1])
+		if err != nil {
+			return nil, err
+		}
+
+		seriesName := name
+		if len(row.Values) == 3 {
+			seriesName = fmt.Sprint(queryValue(row.Values[2]))
+		}
+
+		s, ok := byName[seriesName]
+		if !ok {
+			s = &grafanaSeries{Target: seriesName, Datapoints: [][2]interface{}{}}
+			byName[seriesName] = s
+			series = append(series, s)
+		}
+
+		s.Datapoints = append(s.Datapoints, [2]interface{}{value, ms})
+	}
+
+	if len(series) == 0 && len(res.Columns) == 2 {
+		series = append(series, &grafanaSeries{Target: name, Datapoints: [][2]interface{}{}})
+	}
+
+	return series, nil
+}
+
+func newGrafanaTable(res *schema.SQLQueryResult) *grafanaTable {
+	table := &grafanaTable{
+		Type:    "table",
+		Columns: make([]*grafanaColumn, len(res.Columns)),
+		Rows:    make([][]interface{}, len(res.Rows)),
+	}
+
+	for i, col := range res.Columns {
+		typ := "string"
+
+		switch col.Type {
+		case sql.TimestampType:
+			typ = "time"
+		case sql.IntegerType, sql.FloatType, sql.DecimalType:
+			typ = "number"
+		}
+
+		table.Columns[i] = &grafanaColumn{Text: queryColumnName(col.Name), Type: typ}
+	}
+
+	for i, row := range res.Rows {
+		table.Rows[i] = make([]interface{}, len(row.Values))
+
+		for j, v := range row.Values {
+			switch tv := v.Value.(type) {
+			case *schema.SQLValue_Ts:
+				table.Rows[i][j] = tv.Ts / 1e3
+			case *schema.SQLValue_D:
+				table.Rows[i][j], _ = strconv.ParseFloat(tv.D, 64)
+			default:
+				table.Rows[i][j] = queryValue(v)
+			}
+		}
+	}
+
+	return table
+}
+
+// grafanaTime returns the milliseconds since the epoch of a timestamp, integers are assumed to already hold them
+func grafanaTime(v *schema.SQLValue) (int64, error) {
+	switch tv := v.Value.(type) {
+	case *schema.SQLValue_Ts:
+		return tv.Ts / 1e3, nil
+	case *schema.SQLValue_N:
+		return tv.N, nil
+	}
+
+	return 0, errors.New("time column should be either a TIMESTAMP or an INTEGER")
+}
+
+// grafanaNumber returns the value as a float, null values are kept as nil
+func grafanaNumber(v *schema.SQLValue) (interface{}, error) {
+	switch tv := v.Value.(type) {
+	case *schema.SQLValue_Null:
+		return nil, nil
+	case *schema.SQLValue_N:
+		return float64(tv.N), nil
+	case *schema.SQLValue_F:
+		return tv.F, nil
+	case *schema.SQLValue_D:
+		return strconv.ParseFloat(tv.D, 64)
+	}
+
+	return nil, errors.New("value column should be either an INTEGER, a FLOAT or a DECIMAL")
+}
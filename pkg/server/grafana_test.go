@@ -0,0 +1,239 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrafanaDatasource(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithDir("grafana_data").
+		WithPort(0).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithQueryAPIKeys(map[string][]string{
+			"all":    {DefaultDBName},
+			"events": {DefaultDBName + ".events"},
+		}).
+		WithGrafanaTables(map[string]string{
+			DefaultDBName + ".metrics": "ts",
+			DefaultDBName + ".events":  "at",
+		})
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	db, err := s.dbList.GetByName(DefaultDBName)
+	require.NoError(t, err)
+
+	_, err = db.SQLExecScript(&schema.SQLExecRequest{Sql: `
+		CREATE TABLE metrics (id INTEGER AUTO_INCREMENT, ts TIMESTAMP, host VARCHAR, cpu FLOAT, mem INTEGER, PRIMARY KEY id);
+		CREATE TABLE events (id INTEGER AUTO_INCREMENT, at INTEGER, title VARCHAR, tags VARCHAR, failures INTEGER, PRIMARY KEY id);
+		INSERT INTO metrics (ts, host, cpu, mem) VALUES
+			(CAST('2022-10-01 10:00:00' AS TIMESTAMP), 'a', 0.5, 100),
+			(CAST('2022-10-01 10:01:00' AS TIMESTAMP), 'b', 0.25, 200),
+			(CAST('2022-10-01 12:00:00' AS TIMESTAMP), 'a', 0.75, 300);
+		INSERT INTO events (at, title, tags, failures) VALUES
+			(1664618400000, 'deploy', 'prod, api', 1),
+			(1664625600000, 'rollback', '', 2);
+	`})
+	require.NoError(t, err)
+
+	handler := &grafanaHandler{q: &queryHandler{s: s}}
+
+	post := func(path string, body interface{}, key string) *httptest.ResponseRecorder {
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/grafana/"+DefaultDBName+path, bytes.NewReader(b))
+		req.Header.Set("Authorization", "Bearer "+key)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rng := grafanaRange{
+		From: time.Date(2022, 10, 1, 9, 0, 0, 0, time.UTC),
+		To:   time.Date(2022, 10, 1, 11, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("connection should be tested with an API key", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/grafana/"+DefaultDBName+"/", nil)
+		req.Header.Set("X-API-Key", "all")
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		require.Equal(t, http.StatusUnauthorized, post("/search", &grafanaSearchRequest{}, "wrong").Code)
+		require.Equal(t, http.StatusNotFound, post("/unknown", &grafanaSearchRequest{}, "all").Code)
+	})
+
+	t.Run("metrics of the tables in scope should be listed", func(t *testing.T) {
+		var metrics []string
+
+		rec := post("/search", &grafanaSearchRequest{}, "all")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &metrics))
+		require.Equal(t, []string{"events.failures", "metrics.cpu", "metrics.mem"}, metrics)
+
+		rec = post("/search", &grafanaSearchRequest{Target: "cpu"}, "all")
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &metrics))
+		require.Equal(t, []string{"metrics.cpu"}, metrics)
+
+		rec = post("/search", &grafanaSearchRequest{}, "events")
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &metrics))
+		require.Equal(t, []string{"events.failures"}, metrics)
+	})
+
+	t.Run("metrics should be queried as time series", func(t *testing.T) {
+		rec := post("/query", &grafanaQueryRequest{
+			Range: rng,
+			Targets: []*grafanaTarget{
+				{Target: "metrics.cpu", RefID: "A"},
+				{Target: "events.failures", RefID: "B"},
+				{Target: "SELECT ts, mem, host FROM metrics WHERE ts >= @time_from AND ts <= @time_to ORDER BY ts", RefID: "C"},
+			},
+		}, "all")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var series []*grafanaSeries
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &series))
+
+		require.Equal(t, []*grafanaSeries{
+			{Target: "metrics.cpu", Datapoints: [][2]interface{}{{0.5, float64(1664618400000)}, {0.25, float64(1664618460000)}}},
+			{Target: "events.failures", Datapoints: [][2]interface{}{{float64(1), float64(1664618400000)}}},
+			{Target: "a", Datapoints: [][2]interface{}{{float64(100), float64(1664618400000)}}},
+			{Target: "b", Datapoints: [][2]interface{}{{float64(200), float64(1664618460000)}}},
+		}, series)
+	})
+
+	t.Run("series should be named after decimal values as decimal strings", func(t *testing.T) {
+		rec := post("/query", &grafanaQueryRequest{
+			Range:   rng,
+			Targets: []*grafanaTarget{{Target: "SELECT ts, mem, CAST(cpu AS DECIMAL) FROM metrics WHERE ts >= @time_from AND ts <= @time_to ORDER BY ts"}},
+		}, "all")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var series []*grafanaSeries
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &series))
+
+		require.Equal(t, []*grafanaSeries{
+			{Target: "0.5", Datapoints: [][2]interface{}{{float64(100), float64(1664618400000)}}},
+			{Target: "0.25", Datapoints: [][2]interface{}{{float64(200), float64(1664618460000)}}},
+		}, series)
+	})
+
+	t.Run("queries should be returned as tables", func(t *testing.T) {
+		rec := post("/query", &grafanaQueryRequest{
+			Range:   rng,
+			Targets: []*grafanaTarget{{Target: "SELECT ts, host FROM metrics WHERE ts >= @time_from AND ts <= @time_to", Type: "table"}},
+		}, "all")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var tables []*grafanaTable
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tables))
+
+		require.Equal(t, []*grafanaTable{{
+			Type:    "table",
+			Columns: []*grafanaColumn{{Text: "ts", Type: "time"}, {Text: "host", Type: "string"}},
+			Rows:    [][]interface{}{{float64(1664618400000), "a"}, {float64(1664618460000), "b"}},
+		}}, tables)
+	})
+
+	t.Run("only tables in scope should be queried", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, post("/query", &grafanaQueryRequest{
+			Range:   rng,
+			Targets: []*grafanaTarget{{Target: "metrics.cpu"}},
+		}, "events").Code)
+
+		require.Equal(t, http.StatusForbidden, post("/query", &grafanaQueryRequest{
+			Range:   rng,
+			Targets: []*grafanaTarget{{Target: "SELECT ts, cpu FROM metrics"}},
+		}, "events").Code)
+
+		require.Equal(t, http.StatusBadRequest, post("/query", &grafanaQueryRequest{
+			Range:   rng,
+			Targets: []*grafanaTarget{{Target: "metrics.host"}},
+		}, "all").Code)
+
+		require.Equal(t, http.StatusBadRequest, post("/query", &grafanaQueryRequest{
+			Range:   rng,
+			Targets: []*grafanaTarget{{Target: "DELETE FROM metrics"}},
+		}, "all").Code)
+	})
+
+	t.Run("annotations should be read from a query", func(t *testing.T) {
+		annotation := &grafanaAnnotation{
+			Name:   "deploys",
+			Enable: true,
+			Query:  "SELECT at AS time, title, tags FROM events",
+		}
+
+		rec := post("/annotations", &grafanaAnnotationsRequest{Range: rng, Annotation: annotation}, "events")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var events []*grafanaAnnotationEvent
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &events))
+
+		require.Equal(t, []*grafanaAnnotationEvent{
+			{Annotation: annotation, Time: 1664618400000, Title: "deploy", Tags: []string{"prod", "api"}},
+			{Annotation: annotation, Time: 1664625600000, Title: "rollback", Tags: []string{}},
+		}, events)
+
+		annotation.Query = "SELECT title FROM events"
+		require.Equal(t, http.StatusBadRequest, post("/annotations", &grafanaAnnotationsRequest{Range: rng, Annotation: annotation}, "events").Code)
+	})
+}
+
+func TestParseGrafanaTables(t *testing.T) {
+	tables, err := ParseGrafanaTables("defaultdb.metrics=ts, sales.orders = created_at")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"defaultdb.metrics": "ts",
+		"sales.orders":      "created_at",
+	}, tables)
+
+	tables, err = ParseGrafanaTables("")
+	require.NoError(t, err)
+	require.Empty(t, tables)
+
+	_, err = ParseGrafanaTables("defaultdb.metrics")
+	require.ErrorIs(t, err, ErrInvalidGrafanaTables)
+
+	_, err = ParseGrafanaTables("metrics=ts")
+	require.ErrorIs(t, err, ErrInvalidGrafanaTables)
+
+	_, err = ParseGrafanaTables("defaultdb.metrics=")
+	require.ErrorIs(t, err, ErrInvalidGrafanaTables)
+}
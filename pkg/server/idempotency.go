@@ -0,0 +1,216 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/cache"
+	"github.com/codenotary/immudb/pkg/server/sessions"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// IDEMPOTENCY_KEY_HEADER carries the key identifying a write requested by the client, writes retried with
+// the same key are applied once, retries get the result of the write originally applied
+const IDEMPOTENCY_KEY_HEADER = "immudb-idempotency-key"
+
+// idempotentMethods are the methods whose results are remembered by idempotency key
+var idempotentMethods = map[string]struct{}{
+	"/immudb.schema.ImmuService/Set":                    {},
+	"/immudb.schema.ImmuService/VerifiableSet":          {},
+	"/immudb.schema.ImmuService/VerifiableSetByDigest":  {},
+	"/immudb.schema.ImmuService/Delete":                 {},
+	"/immudb.schema.ImmuService/ExecAll":                {},
+	"/immudb.schema.ImmuService/ExecAllPerDatabase":     {},
+	"/immudb.schema.ImmuService/SetReference":           {},
+	"/immudb.schema.ImmuService/VerifiableSetReference": {},
+	"/immudb.schema.ImmuService/ZAdd":                   {},
+	"/immudb.schema.ImmuService/VerifiableZAdd":         {},
+	"/immudb.schema.ImmuService/SQLExec":                {},
+	"/immudb.schema.ImmuService/SQLExecScript":          {},
+	"/immudb.schema.ImmuService/TxSQLExec":              {},
+	"/immudb.schema.ImmuService/IngestJSON":             {},
+}
+
+// idempotentResult is the result of a write, pending until done is closed
+type idempotentResult struct {
+	digest    [sha256.Size]byte // method and request the key was used for
+	appliedAt time.Time
+	done      chan struct{}
+	res       interface{}
+	err       error
+}
+
+// idempotencyRegistry remembers the results of the writes recently applied, by idempotency key
+type idempotencyRegistry struct {
+	mutex   sync.Mutex
+	results *cache.LRUCache
+}
+
+// IdempotencyInterceptor applies once the writes sharing an idempotency key within the same session and database.
+// Retries are served the result of the original write, or wait for it while it's being applied.
+// Failed writes are not remembered so they can be retried, and a key reused for a different request is rejected
+func (s *ImmuServer) IdempotencyInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if s.Options.IdempotencyCacheSize <= 0 {
+		return handler(ctx, req)
+	}
+
+	_, idempotent := idempotentMethods[info.FullMethod]
+	if !idempotent {
+		return handler(ctx, req)
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(IDEMPOTENCY_KEY_HEADER)) == 0 || md.Get(IDEMPOTENCY_KEY_HEADER)[0] == "" {
+		return handler(ctx, req)
+	}
+
+	digest, err := idempotencyDigest(info.FullMethod, req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := s.idempotencyScope(ctx) + md.Get(IDEMPOTENCY_KEY_HEADER)[0]
+
+	for {
+		result, applied, err := s.idempotentResults.lookup(key, digest, s.Options.IdempotencyKeyTTL, s.Options.IdempotencyCacheSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if !applied {
+			result.res, result.err = handler(ctx, req)
+			s.idempotentResults.complete(key, result)
+
+			return result.res, result.err
+		}
+
+		select {
+		case <-result.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// the original write failed thus it's forgotten, the retry is applied instead
+		if result.err != nil {
+			continue
+		}
+
+		if msg, ok := result.res.(proto.Message); ok {
+			return proto.Clone(msg), nil
+		}
+
+		return result.res, nil
+	}
+}
+
+// idempotencyScope identifies the session, or the user when sessions are not in use, and its selected database
+func (s *ImmuServer) idempotencyScope(ctx context.Context) string {
+	sessionID, _ := sessions.GetSessionIDFromContext(ctx)
+
+	var username string
+
+	dbIndex, user, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err == nil && user != nil {
+		username = user.Username
+	}
+
+	return fmt.Sprintf("%s/%s/%d/", sessionID, username, dbIndex)
+}
+
+func idempotencyDigest(method string, req interface{}) ([sha256.Size]byte, error) {
+	var digest [sha256.Size]byte
+
+	h := sha256.New()
+	h.Write([]byte(method))
+
+	if msg, ok := req.(proto.Message); ok {
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return digest, err
+		}
+
+		h.Write(b)
+	}
+
+	copy(digest[:], h.Sum(nil))
+
+	return digest, nil
+}
+
+// lookup returns the result registered with the key, or registers a pending one returning false
+// when the key was not used within the ttl i.e. the write needs to be applied
+func (r *idempotencyRegistry) lookup(key string, digest [sha256.Size]byte, ttl time.Duration, size int) (*idempotentResult, bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.results == nil {
+		results, err := cache.NewLRUCache(size)
+		if err != nil {
+			return nil, false, err
+		}
+
+		r.results = results
+	}
+
+	v, err := r.results.Get(key)
+	if err == nil {
+		result := v.(*idempotentResult)
+
+		if ttl <= 0 || time.Since(result.appliedAt) < ttl {
+			if result.digest != digest {
+				return nil, false, ErrIdempotencyKeyReused
+			}
+
+			return result, true, nil
+		}
+	}
+
+	result := &idempotentResult{
+		digest:    digest,
+		appliedAt: time.Now(),
+		done:      make(chan struct{}),
+	}
+
+	_, _, err = r.results.Put(key, result)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return result, false, nil
+}
+
+// complete wakes up the retries waiting for the result, failed writes are forgotten
+func (r *idempotencyRegistry) complete(key string, result *idempotentResult) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if result.err != nil {
+		v, err := r.results.Get(key)
+		if err == nil && v == result {
+			r.results.Pop(key)
+		}
+	}
+
+	close(result.done)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestIdempotencyInterceptor(t *testing.T) {
+	s := DefaultServer()
+
+	setInfo := &grpc.UnaryServerInfo{FullMethod: "/immudb.schema.ImmuService/Set"}
+
+	var txID uint64
+
+	set := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &schema.TxHeader{Id: atomic.AddUint64(&txID, 1)}, nil
+	}
+
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(IDEMPOTENCY_KEY_HEADER, key))
+	}
+
+	req := &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}}
+
+	t.Run("writes without idempotency key should always be applied", func(t *testing.T) {
+		res1, err := s.IdempotencyInterceptor(context.Background(), req, setInfo, set)
+		require.NoError(t, err)
+
+		res2, err := s.IdempotencyInterceptor(context.Background(), req, setInfo, set)
+		require.NoError(t, err)
+
+		require.NotEqual(t, res1.(*schema.TxHeader).Id, res2.(*schema.TxHeader).Id)
+	})
+
+	t.Run("writes retried with the same key should be applied once", func(t *testing.T) {
+		res1, err := s.IdempotencyInterceptor(withKey("key1"), req, setInfo, set)
+		require.NoError(t, err)
+
+		applied := atomic.LoadUint64(&txID)
+
+		res2, err := s.IdempotencyInterceptor(withKey("key1"), req, setInfo, set)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(res1.(proto.Message), res2.(proto.Message)))
+		require.Equal(t, applied, atomic.LoadUint64(&txID))
+
+		res3, err := s.IdempotencyInterceptor(withKey("key2"), req, setInfo, set)
+		require.NoError(t, err)
+		require.Equal(t, applied+1, res3.(*schema.TxHeader).Id)
+	})
+
+	t.Run("keys reused for a different request should be rejected", func(t *testing.T) {
+		otherReq := &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value2")}}}
+
+		_, err := s.IdempotencyInterceptor(withKey("key1"), otherReq, setInfo, set)
+		require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+		_, err = s.IdempotencyInterceptor(withKey("key1"), req, &grpc.UnaryServerInfo{FullMethod: "/immudb.schema.ImmuService/VerifiableSet"}, set)
+		require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+	})
+
+	t.Run("failed writes should not be remembered", func(t *testing.T) {
+		errFailed := errors.New("failed")
+
+		_, err := s.IdempotencyInterceptor(withKey("key3"), req, setInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+
+		res, err := s.IdempotencyInterceptor(withKey("key3"), req, setInfo, set)
+		require.NoError(t, err)
+		require.Equal(t, atomic.LoadUint64(&txID), res.(*schema.TxHeader).Id)
+	})
+
+	t.Run("retries should wait for the write being applied", func(t *testing.T) {
+		applying := make(chan struct{})
+		release := make(chan struct{})
+
+		go func() {
+			s.IdempotencyInterceptor(withKey("key4"), req, setInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+				close(applying)
+				<-release
+				return set(ctx, req)
+			})
+		}()
+
+		<-applying
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(release)
+		}()
+
+		res, err := s.IdempotencyInterceptor(withKey("key4"), req, setInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			require.Fail(t, "the write should not be applied twice")
+			return nil, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, atomic.LoadUint64(&txID), res.(*schema.TxHeader).Id)
+	})
+
+	t.Run("keys should expire", func(t *testing.T) {
+		s.Options.WithIdempotencyKeyTTL(time.Millisecond)
+		defer s.Options.WithIdempotencyKeyTTL(DefaultOptions().IdempotencyKeyTTL)
+
+		res1, err := s.IdempotencyInterceptor(withKey("key5"), req, setInfo, set)
+		require.NoError(t, err)
+
+		time.Sleep(5 * time.Millisecond)
+
+		res2, err := s.IdempotencyInterceptor(withKey("key5"), req, setInfo, set)
+		require.NoError(t, err)
+		require.NotEqual(t, res1.(*schema.TxHeader).Id, res2.(*schema.TxHeader).Id)
+	})
+
+	t.Run("keys should be ignored on methods not writing data", func(t *testing.T) {
+		getInfo := &grpc.UnaryServerInfo{FullMethod: "/immudb.schema.ImmuService/Get"}
+
+		res1, err := s.IdempotencyInterceptor(withKey("key6"), req, getInfo, set)
+		require.NoError(t, err)
+
+		res2, err := s.IdempotencyInterceptor(withKey("key6"), req, getInfo, set)
+		require.NoError(t, err)
+		require.NotEqual(t, res1.(*schema.TxHeader).Id, res2.(*schema.TxHeader).Id)
+	})
+
+	t.Run("keys should be ignored when disabled", func(t *testing.T) {
+		s.Options.WithIdempotencyCacheSize(0)
+
+		res1, err := s.IdempotencyInterceptor(withKey("key7"), req, setInfo, set)
+		require.NoError(t, err)
+
+		res2, err := s.IdempotencyInterceptor(withKey("key7"), req, setInfo, set)
+		require.NoError(t, err)
+		require.NotEqual(t, res1.(*schema.TxHeader).Id, res2.(*schema.TxHeader).Id)
+	})
+}
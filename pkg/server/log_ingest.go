@@ -0,0 +1,609 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/codenotary/immudb/pkg/logger"
+)
+
+// LogTable is the table holding the log records received through syslog and Fluent Forward.
+// Records are only appended, each one identified by an increasing id
+const LogTable = "logs"
+
+// LogTermsTable indexes the words of the log messages, so records can be found by term e.g.
+//
+//	SELECT logs.id, logs.message FROM log_terms INNER JOIN logs ON logs.id = log_terms.log_id WHERE log_terms.term = 'timeout'
+const LogTermsTable = "log_terms"
+
+const logTablesDDL = `CREATE TABLE IF NOT EXISTS ` + LogTable + ` (
+	id INTEGER,
+	ts INTEGER,
+	received INTEGER,
+	source VARCHAR,
+	protocol VARCHAR,
+	facility INTEGER,
+	severity INTEGER,
+	hostname VARCHAR,
+	app VARCHAR,
+	message VARCHAR,
+	fields VARCHAR,
+	PRIMARY KEY id
+);
+CREATE INDEX IF NOT EXISTS ON ` + LogTable + `(ts);
+CREATE TABLE IF NOT EXISTS ` + LogTermsTable + ` (
+	term VARCHAR[64],
+	log_id INTEGER,
+	PRIMARY KEY (term, log_id)
+);`
+
+// maxLogTermLen is the max length of the indexed terms, longer words are not indexed
+const maxLogTermLen = 64
+
+// maxLogTermsPerRecord is the max number of distinct terms indexed for a single message
+const maxLogTermsPerRecord = 64
+
+// maxLogMessageLen and maxLogFieldsLen bound the stored values, longer ones get truncated
+const maxLogMessageLen = 2048
+const maxLogFieldsLen = 1024
+
+// maxLogTxEntries is the max number of entries (rows and index entries) written by a single transaction
+const maxLogTxEntries = 512
+
+// logIngestQueueLen is the number of pending batches before receivers get blocked
+const logIngestQueueLen = 1024
+
+type logRecord struct {
+	ts          int64
+	received    int64
+	source      string
+	protocol    string
+	hasPriority bool
+	facility    int64
+	severity    int64
+	hostname    string
+	app         string
+	message     string
+	fields      map[string]interface{}
+}
+
+type logBatch struct {
+	records []*logRecord
+	done    chan error
+}
+
+// LogIngestServer receives log records through syslog (TCP and UDP) and Fluent Forward (TCP)
+// and appends them into the log table of the database
+type LogIngestServer struct {
+	s      *ImmuServer
+	dbName string
+	l      logger.Logger
+
+	syslogTCP net.Listener
+	syslogUDP net.PacketConn
+	fluentTCP net.Listener
+
+	queue chan *logBatch
+	quit  chan struct{}
+	wg    sync.WaitGroup
+
+	lastID    int64
+	lastIDSet bool
+}
+
+// StartLogIngestServer starts the syslog listeners at syslogAddr and the Fluent Forward listener at fluentAddr,
+// any of them being disabled when its address is empty
+func StartLogIngestServer(syslogAddr, fluentAddr string, dbName string, s *ImmuServer, l logger.Logger) (*LogIngestServer, error) {
+	srv := &LogIngestServer{
+		s:      s,
+		dbName: dbName,
+		l:      l,
+		queue:  make(chan *logBatch, logIngestQueueLen),
+		quit:   make(chan struct{}),
+	}
+
+	var err error
+
+	if syslogAddr != "" {
+		srv.syslogTCP, err = net.Listen("tcp", syslogAddr)
+		if err != nil {
+			srv.Close()
+			return nil, err
+		}
+
+		srv.syslogUDP, err = net.ListenPacket("udp", syslogAddr)
+		if err != nil {
+			srv.Close()
+			return nil, err
+		}
+
+		l.Infof("Syslog server enabled on %s (tcp and udp)", syslogAddr)
+
+		srv.serve(srv.syslogTCP, srv.handleSyslogConn)
+
+		srv.wg.Add(1)
+		go srv.serveSyslogUDP()
+	}
+
+	if fluentAddr != "" {
+		srv.fluentTCP, err = net.Listen("tcp", fluentAddr)
+		if err != nil {
+			srv.Close()
+			return nil, err
+		}
+
+		l.Infof("Fluent Forward server enabled on %s", fluentAddr)
+
+		srv.serve(srv.fluentTCP, srv.handleFluentConn)
+	}
+
+	srv.wg.Add(1)
+	go srv.writeBatches()
+
+	return srv, nil
+}
+
+// Close stops the listeners, waiting for the records already received to be written
+func (srv *LogIngestServer) Close() error {
+	var errs []string
+
+	for _, c := range []interface{ Close() error }{srv.syslogTCP, srv.syslogUDP, srv.fluentTCP} {
+		if c == nil {
+			continue
+		}
+		if err := c.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	close(srv.quit)
+	srv.wg.Wait()
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+func (srv *LogIngestServer) serve(ln net.Listener, handle func(conn net.Conn)) {
+	srv.wg.Add(1)
+
+	go func() {
+		defer srv.wg.Done()
+
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				select {
+				case <-srv.quit:
+				default:
+					srv.l.Errorf("log ingestion listener on %s stopped: %v", ln.Addr(), err)
+				}
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+}
+
+func (srv *LogIngestServer) handleSyslogConn(conn net.Conn) {
+	r := bufio.NewReaderSize(conn, maxSyslogFrameLen)
+
+	for {
+		frame, err := readSyslogFrame(r)
+		if err != nil {
+			if errors.Is(err, ErrMalformedSyslogMessage) {
+				srv.l.Warningf("syslog connection from %s closed: %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+
+		srv.receiveSyslog(frame, conn.RemoteAddr())
+	}
+}
+
+func (srv *LogIngestServer) serveSyslogUDP() {
+	defer srv.wg.Done()
+
+	buf := make([]byte, maxSyslogFrameLen)
+
+	for {
+		n, addr, err := srv.syslogUDP.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-srv.quit:
+			default:
+				srv.l.Errorf("syslog listener on %s stopped: %v", srv.syslogUDP.LocalAddr(), err)
+			}
+			return
+		}
+
+		srv.receiveSyslog(string(buf[:n]), addr)
+	}
+}
+
+func (srv *LogIngestServer) receiveSyslog(msg string, addr net.Addr) {
+	if strings.TrimSpace(msg) == "" {
+		return
+	}
+
+	received := time.Now()
+
+	rec, err := parseSyslogMessage(msg, received)
+	if err != nil {
+		srv.l.Warningf("syslog message from %s discarded: %v", addr, err)
+		return
+	}
+
+	rec.received = received.UnixNano() / int64(time.Millisecond)
+	rec.source = hostOf(addr)
+
+	srv.enqueue(&logBatch{records: []*logRecord{rec}})
+}
+
+func (srv *LogIngestServer) handleFluentConn(conn net.Conn) {
+	r := bufio.NewReader(conn)
+
+	for {
+		msg, err := readFluentMessage(r)
+		if err != nil {
+			if errors.Is(err, ErrMalformedFluentMessage) {
+				srv.l.Warningf("fluent forward connection from %s closed: %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+
+		received := time.Now().UnixNano() / int64(time.Millisecond)
+
+		for _, rec := range msg.records {
+			rec.received = received
+			rec.source = hostOf(conn.RemoteAddr())
+		}
+
+		batch := &logBatch{records: msg.records}
+
+		if msg.chunk == "" {
+			srv.enqueue(batch)
+			continue
+		}
+
+		// the acknowledgment is only sent once the records are stored,
+		// otherwise the client will send them again
+		batch.done = make(chan error, 1)
+
+		if !srv.enqueue(batch) {
+			return
+		}
+
+		select {
+		case err = <-batch.done:
+		case <-srv.quit:
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		_, err = conn.Write(fluentAck(msg.chunk))
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (srv *LogIngestServer) enqueue(batch *logBatch) bool {
+	select {
+	case srv.queue <- batch:
+		return true
+	case <-srv.quit:
+		return false
+	}
+}
+
+// writeBatches writes the received records, the ones pending when writing are grouped into the same transactions
+func (srv *LogIngestServer) writeBatches() {
+	defer srv.wg.Done()
+
+	for {
+		var batches []*logBatch
+
+		select {
+		case b := <-srv.queue:
+			batches = append(batches, b)
+		case <-srv.quit:
+			// records already received are written before stopping
+			select {
+			case b := <-srv.queue:
+				batches = append(batches, b)
+			default:
+				return
+			}
+		}
+
+	pending:
+		for len(batches) < logIngestQueueLen {
+			select {
+			case b := <-srv.queue:
+				batches = append(batches, b)
+			default:
+				break pending
+			}
+		}
+
+		var records []*logRecord
+		for _, b := range batches {
+			records = append(records, b.records...)
+		}
+
+		err := srv.write(records)
+		if err != nil {
+			srv.l.Errorf("log ingestion into '%s' failed: %v", srv.dbName, err)
+		}
+
+		for _, b := range batches {
+			if b.done != nil {
+				b.done <- err
+			}
+		}
+	}
+}
+
+func (srv *LogIngestServer) write(records []*logRecord) error {
+	if srv.s.Options.GetMaintenance() {
+		return ErrNotAllowedInMaintenanceMode
+	}
+
+	db, err := srv.s.dbList.GetByName(srv.dbName)
+	if err != nil {
+		return err
+	}
+
+	db = srv.s.withWriteValidation(db, "")
+
+	if !srv.lastIDSet {
+		srv.lastID, err = lastLogRecordID(db)
+		if err != nil {
+			return err
+		}
+		srv.lastIDSet = true
+	}
+
+	lastID, err := storeLogRecords(db, srv.lastID, records)
+	if err != nil {
+		// the id of the last record is read again as some of the transactions may have been committed
+		srv.lastIDSet = false
+		return err
+	}
+
+	srv.lastID = lastID
+
+	return nil
+}
+
+// lastLogRecordID creates the log tables if missing and returns the id of the last record stored
+func lastLogRecordID(db database.DB) (int64, error) {
+	_, err := db.SQLExecScript(&schema.SQLExecRequest{Sql: logTablesDDL})
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT id FROM " + LogTable + " ORDER BY id DESC LIMIT 1"}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(res.Rows) == 0 {
+		return 0, nil
+	}
+
+	return res.Rows[0].Values[0].GetN(), nil
+}
+
+// storeLogRecords appends the records after the one identified by lastID, along with the terms of their messages.
+// Records are written in batches, each one committed as a single transaction. The id of the last record is returned
+func storeLogRecords(db database.DB, lastID int64, records []*logRecord) (int64, error) {
+	var logRows, termRows []string
+	var params []*schema.NamedParam
+
+	entries := 0
+	id := lastID
+
+	paramRef := func(v *schema.SQLValue) string {
+		name := fmt.Sprintf("p%d", len(params))
+		params = append(params, &schema.NamedParam{Name: name, Value: v})
+		return "@" + name
+	}
+
+	flush := func() error {
+		if len(logRows) == 0 {
+			return nil
+		}
+
+		var stmts strings.Builder
+
+		fmt.Fprintf(&stmts, "INSERT INTO %s (id, ts, received, source, protocol, facility, severity, hostname, app, message, fields) VALUES %s; ",
+			LogTable, strings.Join(logRows, ", "))
+
+		if len(termRows) > 0 {
+			fmt.Fprintf(&stmts, "INSERT INTO %s (term, log_id) VALUES %s;", LogTermsTable, strings.Join(termRows, ", "))
+		}
+
+		_, err := db.SQLExecScript(&schema.SQLExecRequest{Sql: stmts.String(), Params: params})
+		if err != nil {
+			return err
+		}
+
+		lastID = id
+		logRows, termRows, params = nil, nil, nil
+		entries = 0
+
+		return nil
+	}
+
+	for _, rec := range records {
+		terms := logTerms(rec.message)
+
+		// the record and its entry in the ts index, followed by its terms
+		recEntries := 2 + len(terms)
+
+		if entries+recEntries > maxLogTxEntries {
+			err := flush()
+			if err != nil {
+				return lastID, err
+			}
+		}
+
+		id++
+
+		idRef := paramRef(&schema.SQLValue{Value: &schema.SQLValue_N{N: id}})
+
+		facility := &schema.SQLValue{Value: &schema.SQLValue_Null{}}
+		severity := &schema.SQLValue{Value: &schema.SQLValue_Null{}}
+		if rec.hasPriority {
+			facility = &schema.SQLValue{Value: &schema.SQLValue_N{N: rec.facility}}
+			severity = &schema.SQLValue{Value: &schema.SQLValue_N{N: rec.severity}}
+		}
+
+		fields := &schema.SQLValue{Value: &schema.SQLValue_Null{}}
+		if len(rec.fields) > 0 {
+			b, err := json.Marshal(jsonCompatible(rec.fields))
+			if err != nil {
+				return lastID, err
+			}
+			fields = &schema.SQLValue{Value: &schema.SQLValue_S{S: truncateUTF8(string(b), maxLogFieldsLen)}}
+		}
+
+		logRows = append(logRows, "("+strings.Join([]string{
+			idRef,
+			paramRef(&schema.SQLValue{Value: &schema.SQLValue_N{N: rec.ts}}),
+			paramRef(&schema.SQLValue{Value: &schema.SQLValue_N{N: rec.received}}),
+			paramRef(&schema.SQLValue{Value: &schema.SQLValue_S{S: rec.source}}),
+			paramRef(&schema.SQLValue{Value: &schema.SQLValue_S{S: rec.protocol}}),
+			paramRef(facility),
+			paramRef(severity),
+			paramRef(&schema.SQLValue{Value: &schema.SQLValue_S{S: rec.hostname}}),
+			paramRef(&schema.SQLValue{Value: &schema.SQLValue_S{S: rec.app}}),
+			paramRef(&schema.SQLValue{Value: &schema.SQLValue_S{S: truncateUTF8(rec.message, maxLogMessageLen)}}),
+			paramRef(fields),
+		}, ", ")+")")
+
+		for _, term := range terms {
+			termRows = append(termRows, fmt.Sprintf("(%s, %s)", paramRef(&schema.SQLValue{Value: &schema.SQLValue_S{S: term}}), idRef))
+		}
+
+		entries += recEntries
+	}
+
+	err := flush()
+	if err != nil {
+		return lastID, err
+	}
+
+	return lastID, nil
+}
+
+// logTerms returns the distinct lowercase words of the message, made of letters and digits
+func logTerms(msg string) []string {
+	var terms []string
+
+	seen := make(map[string]struct{})
+
+	words := strings.FieldsFunc(strings.ToLower(truncateUTF8(msg, maxLogMessageLen)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, w := range words {
+		if len(w) > maxLogTermLen {
+			continue
+		}
+
+		if _, ok := seen[w]; ok {
+			continue
+		}
+
+		seen[w] = struct{}{}
+		terms = append(terms, w)
+
+		if len(terms) == maxLogTermsPerRecord {
+			break
+		}
+	}
+
+	return terms
+}
+
+// jsonCompatible converts the values decoded from msgpack so they are rendered as expected in json
+func jsonCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []byte:
+		return string(v)
+	case msgpackExt:
+		return hex.EncodeToString(v.data)
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i, e := range v {
+			arr[i] = jsonCompatible(e)
+		}
+		return arr
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = jsonCompatible(e)
+		}
+		return m
+	default:
+		return v
+	}
+}
+
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
+
+func hostOf(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSyslogMessage(t *testing.T) {
+	received := time.Date(2021, time.January, 2, 10, 0, 0, 0, time.UTC)
+
+	rec, err := parseSyslogMessage(`<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3" eventSource="Application"] An application event`+"\n", received)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), rec.facility)
+	require.Equal(t, int64(5), rec.severity)
+	require.Equal(t, time.Date(2003, time.October, 11, 22, 14, 15, 3*int(time.Millisecond), time.UTC).UnixNano()/int64(time.Millisecond), rec.ts)
+	require.Equal(t, "mymachine.example.com", rec.hostname)
+	require.Equal(t, "evntslog", rec.app)
+	require.Equal(t, "An application event", rec.message)
+	require.Equal(t, map[string]interface{}{"msgid": "ID47", "sd": `[exampleSDID@32473 iut="3" eventSource="Application"]`}, rec.fields)
+
+	rec, err = parseSyslogMessage(`<13>1 - - - - - -`, received)
+	require.NoError(t, err)
+	require.Equal(t, received.UnixNano()/int64(time.Millisecond), rec.ts)
+	require.Empty(t, rec.hostname)
+	require.Empty(t, rec.message)
+	require.Nil(t, rec.fields)
+
+	rec, err = parseSyslogMessage(`<34>Oct 11 22:14:15 mymachine su[230]: 'su root' failed for lonvick on /dev/pts/8`, received)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), rec.facility)
+	require.Equal(t, int64(2), rec.severity)
+	require.Equal(t, time.Date(2020, time.October, 11, 22, 14, 15, 0, time.UTC).UnixNano()/int64(time.Millisecond), rec.ts)
+	require.Equal(t, "mymachine", rec.hostname)
+	require.Equal(t, "su", rec.app)
+	require.Equal(t, map[string]interface{}{"procid": "230"}, rec.fields)
+	require.Equal(t, "'su root' failed for lonvick on /dev/pts/8", rec.message)
+
+	rec, err = parseSyslogMessage(`<14>just a message`, received)
+	require.NoError(t, err)
+	require.Equal(t, "just a message", rec.message)
+
+	for _, msg := range []string{
+		"no priority",
+		"<>1 - - - - - -",
+		"<192>message",
+		"<13>1 not-a-timestamp - - - - -",
+		"<13>1 - - -",
+		`<13>1 - - - - - [unterminated sd="]"`,
+	} {
+		_, err = parseSyslogMessage(msg, received)
+		require.ErrorIs(t, err, ErrMalformedSyslogMessage, msg)
+	}
+}
+
+func TestReadSyslogFrame(t *testing.T) {
+	r := bufio.NewReaderSize(strings.NewReader("<13>first\n17 <13>octet\ncounted<13>last"), maxSyslogFrameLen)
+
+	for _, expected := range []string{"<13>first\n", "<13>octet\ncounted", "<13>last"} {
+		frame, err := readSyslogFrame(r)
+		require.NoError(t, err)
+		require.Equal(t, expected, frame)
+	}
+
+	_, err := readSyslogFrame(r)
+	require.Error(t, err)
+
+	r = bufio.NewReaderSize(strings.NewReader(fmt.Sprintf("%d <13>too large", maxSyslogFrameLen+1)), maxSyslogFrameLen)
+	_, err = readSyslogFrame(r)
+	require.ErrorIs(t, err, ErrMalformedSyslogMessage)
+}
+
+// msgpack encoding of the few types used by the tests
+func msgpack(v interface{}) []byte {
+	switch v := v.(type) {
+	case int:
+		return []byte{0xd3, byte(v >> 56), byte(v >> 48), byte(v >> 40), byte(v >> 32), byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+	case string:
+		return append([]byte{0xda, byte(len(v) >> 8), byte(len(v))}, v...)
+	case []byte:
+		return append([]byte{0xc6, byte(len(v) >> 24), byte(len(v) >> 16), byte(len(v) >> 8), byte(len(v))}, v...)
+	case msgpackExt:
+		return append([]byte{0xd7, byte(v.typ)}, v.data...)
+	case []interface{}:
+		b := []byte{0xdc, byte(len(v) >> 8), byte(len(v))}
+		for _, e := range v {
+			b = append(b, msgpack(e)...)
+		}
+		return b
+	case map[string]interface{}:
+		b := []byte{0xde, byte(len(v) >> 8), byte(len(v))}
+		for k, e := range v {
+			b = append(b, msgpack(k)...)
+			b = append(b, msgpack(e)...)
+		}
+		return b
+	}
+
+	panic("unsupported type")
+}
+
+func TestReadFluentMessage(t *testing.T) {
+	eventTime := msgpackExt{typ: 0, data: []byte{0, 0, 0, 10, 0, 0, 0, 1}}
+
+	record := func() map[string]interface{} {
+		return map[string]interface{}{"log": "started", "host": "node1", "pid": 42}
+	}
+
+	entries := append(msgpack([]interface{}{1, record()}), msgpack([]interface{}{eventTime, record()})...)
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write(entries)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	var stream []byte
+	stream = append(stream, msgpack([]interface{}{"app.message", 1, record()})...)
+	stream = append(stream, msgpack([]interface{}{"app.forward", []interface{}{[]interface{}{1, record()}, []interface{}{2, record()}}, map[string]interface{}{"chunk": "c1"}})...)
+	stream = append(stream, msgpack([]interface{}{"app.packed", entries})...)
+	stream = append(stream, msgpack([]interface{}{"app.compressed", compressed.Bytes(), map[string]interface{}{"compressed": "gzip"}})...)
+
+	r := bufio.NewReader(bytes.NewReader(stream))
+
+	msg, err := readFluentMessage(r)
+	require.NoError(t, err)
+	require.Len(t, msg.records, 1)
+	require.Empty(t, msg.chunk)
+	require.Equal(t, "app.message", msg.records[0].app)
+	require.Equal(t, "fluent", msg.records[0].protocol)
+	require.Equal(t, int64(1000), msg.records[0].ts)
+	require.Equal(t, "started", msg.records[0].message)
+	require.Equal(t, "node1", msg.records[0].hostname)
+	require.Equal(t, map[string]interface{}{"pid": int64(42)}, msg.records[0].fields)
+
+	msg, err = readFluentMessage(r)
+	require.NoError(t, err)
+	require.Len(t, msg.records, 2)
+	require.Equal(t, "c1", msg.chunk)
+	require.Equal(t, int64(2000), msg.records[1].ts)
+
+	for _, tag := range []string{"app.packed", "app.compressed"} {
+		msg, err = readFluentMessage(r)
+		require.NoError(t, err)
+		require.Len(t, msg.records, 2)
+		require.Equal(t, tag, msg.records[1].app)
+		require.Equal(t, int64(10000), msg.records[1].ts)
+	}
+
+	for _, b := range [][]byte{
+		msgpack("not an array"),
+		msgpack([]interface{}{1, 2}),
+		msgpack([]interface{}{"tag", 1}),
+		msgpack([]interface{}{"tag", 1, "not a map"}),
+		msgpack([]interface{}{"tag", "bad time", record()}),
+		{0xc1},
+	} {
+		_, err = readFluentMessage(bufio.NewReader(bytes.NewReader(b)))
+		require.ErrorIs(t, err, ErrMalformedFluentMessage)
+	}
+
+	require.Equal(t, append([]byte{0x81, 0xa3, 'a', 'c', 'k', 0xa2}, "c1"...), fluentAck("c1"))
+}
+
+func TestStoreLogRecords(t *testing.T) {
+	options := database.DefaultOption().WithDBRootPath("log_ingest_data").WithDBName("db")
+	db, err := database.NewDB(options, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer os.RemoveAll(options.GetDBRootPath())
+	defer db.Close()
+
+	lastID, err := lastLogRecordID(db)
+	require.NoError(t, err)
+	require.Zero(t, lastID)
+
+	records := []*logRecord{
+		{ts: 1, protocol: "syslog", hasPriority: true, facility: 4, severity: 2, app: "sshd", message: "Connection timeout from 10.0.0.1"},
+		{ts: 2, protocol: "fluent", app: "app.web", message: "Request served", fields: map[string]interface{}{"status": int64(200), "raw": []byte("x")}},
+	}
+
+	// enough records to need several transactions
+	for i := 0; i < maxLogTxEntries; i++ {
+		records = append(records, &logRecord{ts: int64(3 + i), protocol: "syslog", message: fmt.Sprintf("Heartbeat %d", i)})
+	}
+
+	lastID, err = storeLogRecords(db, lastID, records)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(records)), lastID)
+
+	lastID, err = lastLogRecordID(db)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(records)), lastID)
+
+	res, err := db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT logs.id, logs.message FROM log_terms INNER JOIN logs ON logs.id = log_terms.log_id WHERE log_terms.term = 'timeout'"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	require.Equal(t, int64(1), res.Rows[0].Values[0].GetN())
+	require.Equal(t, "Connection timeout from 10.0.0.1", res.Rows[0].Values[1].GetS())
+
+	res, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT COUNT(*) FROM log_terms WHERE term = 'heartbeat'"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(maxLogTxEntries), res.Rows[0].Values[0].GetN())
+
+	res, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT facility, severity, fields FROM logs WHERE id = 2"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	require.IsType(t, &schema.SQLValue_Null{}, res.Rows[0].Values[0].Value)
+	require.IsType(t, &schema.SQLValue_Null{}, res.Rows[0].Values[1].Value)
+	require.Equal(t, `{"raw":"x","status":200}`, res.Rows[0].Values[2].GetS())
+
+	// records are only appended
+	_, err = storeLogRecords(db, 0, records[:1])
+	require.Error(t, err)
+}
+
+func TestLogTerms(t *testing.T) {
+	require.Equal(t, []string{"user", "john", "logged", "in", "from", "10", "0", "1"}, logTerms("User John logged in from 10.0.0.1, user john"))
+	require.Empty(t, logTerms(" -- "))
+	require.Len(t, logTerms(strings.Repeat("a ", 10)+strings.Repeat("b", maxLogTermLen+1)), 1)
+
+	var words []string
+	for i := 0; i < 2*maxLogTermsPerRecord; i++ {
+		words = append(words, fmt.Sprintf("w%d", i))
+	}
+	require.Len(t, logTerms(strings.Join(words, " ")), maxLogTermsPerRecord)
+
+	require.Equal(t, "ab", truncateUTF8("abè", 3))
+}
+
+func TestLogIngestServer(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithDir("log_ingest_server_data").
+		WithPort(0).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	srv, err := StartLogIngestServer("127.0.0.1:0", "127.0.0.1:0", DefaultDBName, s, s.Logger)
+	require.NoError(t, err)
+
+	udp, err := net.Dial("udp", srv.syslogUDP.LocalAddr().String())
+	require.NoError(t, err)
+	defer udp.Close()
+
+	_, err = udp.Write([]byte("<14>udp: sent over udp"))
+	require.NoError(t, err)
+
+	tcp, err := net.Dial("tcp", srv.syslogTCP.Addr().String())
+	require.NoError(t, err)
+	defer tcp.Close()
+
+	_, err = tcp.Write([]byte("<14>tcp: sent over tcp\n"))
+	require.NoError(t, err)
+
+	fluent, err := net.Dial("tcp", srv.fluentTCP.Addr().String())
+	require.NoError(t, err)
+	defer fluent.Close()
+
+	_, err = fluent.Write(msgpack([]interface{}{"app", 1, map[string]interface{}{"message": "sent over fluent"}, map[string]interface{}{"chunk": "abc"}}))
+	require.NoError(t, err)
+
+	// the acknowledgment is received once the record is stored
+	ack := make([]byte, len(fluentAck("abc")))
+	fluent.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = fluent.Read(ack)
+	require.NoError(t, err)
+	require.Equal(t, fluentAck("abc"), ack)
+
+	db, err := s.dbList.GetByName(DefaultDBName)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		res, err := db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT COUNT(*) FROM " + LogTable}, nil)
+		return err == nil && res.Rows[0].Values[0].GetN() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	res, err := db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT source, protocol, app FROM logs INNER JOIN log_terms ON logs.id = log_terms.log_id WHERE log_terms.term = 'fluent'"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	require.Equal(t, "127.0.0.1", res.Rows[0].Values[0].GetS())
+	require.Equal(t, "fluent", res.Rows[0].Values[1].GetS())
+	require.Equal(t, "app", res.Rows[0].Values[2].GetS())
+
+	err = srv.Close()
+	require.NoError(t, err)
+}
@@ -53,8 +53,14 @@ type MetricsCollection struct {
 	computeDBEntries func() map[string]float64
 	DBEntriesGauges  *prometheus.GaugeVec
 
+	computeIndexBufferPoolUsage func() (size, resident int)
+	IndexBufferPoolGauges       *prometheus.GaugeVec
+
 	RPCsPerClientCounters        *prometheus.CounterVec
 	LastMessageAtPerClientGauges *prometheus.GaugeVec
+
+	DiskFreeGauges *prometheus.GaugeVec
+	ReadOnlyGauges *prometheus.GaugeVec
 }
 
 var metricsNamespace = "immudb"
@@ -93,6 +99,11 @@ func (mc *MetricsCollection) WithComputeDBEntries(f func() map[string]float64) {
 	mc.computeDBEntries = f
 }
 
+// WithComputeIndexBufferPoolUsage sets how the capacity and the residency of the index buffer pool are computed
+func (mc *MetricsCollection) WithComputeIndexBufferPoolUsage(f func() (size, resident int)) {
+	mc.computeIndexBufferPoolUsage = f
+}
+
 // UpdateDBMetrics ...
 func (mc *MetricsCollection) UpdateDBMetrics() {
 	if mc.computeDBSizes != nil {
@@ -105,6 +116,11 @@ func (mc *MetricsCollection) UpdateDBMetrics() {
 			mc.DBEntriesGauges.WithLabelValues(db).Set(nbEntries)
 		}
 	}
+	if mc.computeIndexBufferPoolUsage != nil {
+		size, resident := mc.computeIndexBufferPoolUsage()
+		mc.IndexBufferPoolGauges.WithLabelValues("size").Set(float64(size))
+		mc.IndexBufferPoolGauges.WithLabelValues("resident").Set(float64(resident))
+	}
 }
 
 // Metrics immudb Prometheus metrics collection
@@ -133,6 +149,14 @@ var Metrics = MetricsCollection{
 		},
 		[]string{"db"},
 	),
+	IndexBufferPoolGauges: promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "index_buffer_pool_nodes",
+			Help:      "Capacity and number of index nodes currently held by the buffer pool shared by all databases.",
+		},
+		[]string{"stat"},
+	),
 	LastMessageAtPerClientGauges: promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: metricsNamespace,
@@ -141,6 +165,22 @@ var Metrics = MetricsCollection{
 		},
 		[]string{"ip"},
 	),
+	DiskFreeGauges: promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "disk_free_bytes",
+			Help:      "Free space of the disk the database is stored in, in bytes.",
+		},
+		[]string{"db"},
+	),
+	ReadOnlyGauges: promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "db_read_only",
+			Help:      "Set to 1 while the database rejects writes because of insufficient disk space.",
+		},
+		[]string{"db"},
+	),
 }
 
 // StartMetrics listens and servers the HTTP metrics server in a new goroutine.
@@ -0,0 +1,85 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/watchers"
+	"github.com/codenotary/immudb/pkg/database"
+	"google.golang.org/grpc/metadata"
+)
+
+// MIN_TX_HEADER carries the last transaction written by the client,
+// requests are served once the database has indexed it
+const MIN_TX_HEADER = "immudb-min-tx"
+
+// waitForMinTx provides read-your-writes consistency, mostly useful when reads are served by replicas
+func (s *ImmuServer) waitForMinTx(ctx context.Context, db database.DB) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(MIN_TX_HEADER)) == 0 {
+		return nil
+	}
+
+	minTx, err := strconv.ParseUint(md.Get(MIN_TX_HEADER)[0], 10, 64)
+	if err != nil {
+		return ErrIllegalArguments
+	}
+
+	if minTx == 0 {
+		return nil
+	}
+
+	cancellation := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		timer := time.NewTimer(s.Options.MinTxWaitTimeout)
+		defer timer.Stop()
+
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+
+		close(cancellation)
+	}()
+
+	err = db.WaitForIndexingUpto(minTx, cancellation)
+	if err == watchers.ErrCancellationRequested {
+		return s.minTxNotReachedError(db, minTx)
+	}
+
+	return err
+}
+
+func (s *ImmuServer) minTxNotReachedError(db database.DB, minTx uint64) error {
+	if db.IsReplica() {
+		dbOpts, err := s.loadDBOptions(db.GetName(), false)
+		if err == nil && dbOpts.MasterAddress != "" {
+			return fmt.Errorf("%w: tx %d, retry on the primary at %s:%d", ErrMinTxNotReached, minTx, dbOpts.MasterAddress, dbOpts.MasterPort)
+		}
+	}
+
+	return fmt.Errorf("%w: tx %d", ErrMinTxNotReached, minTx)
+}
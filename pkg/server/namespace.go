@@ -0,0 +1,611 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"google.golang.org/protobuf/proto"
+)
+
+// namespacedDB confines key-value operations under the prefix of a namespace.
+// Keys, references and sorted sets are transparently prefixed in requests and unprefixed in responses,
+// SQL is run in a catalog of its own kept under the same prefix, see database.DB.SQLNamespace.
+// Operations which can not be confined (transaction scans, replication, row proofs, etc.) are rejected.
+type namespacedDB struct {
+	database.DB
+	prefix []byte
+}
+
+func newNamespacedDB(db database.DB, namespace string) *namespacedDB {
+	return &namespacedDB{DB: db, prefix: auth.NamespacePrefix(namespace)}
+}
+
+func (d *namespacedDB) sqlDB() (database.DB, error) {
+	return d.DB.SQLNamespace(d.prefix)
+}
+
+func (d *namespacedDB) wrap(key []byte) []byte {
+	k := make([]byte, len(d.prefix)+len(key))
+	copy(k, d.prefix)
+	copy(k[len(d.prefix):], key)
+	return k
+}
+
+func (d *namespacedDB) unwrap(key []byte) []byte {
+	if !bytes.HasPrefix(key, d.prefix) {
+		return key
+	}
+	return key[len(d.prefix):]
+}
+
+func (d *namespacedDB) unwrapEntry(entry *schema.Entry) *schema.Entry {
+	if entry == nil {
+		return nil
+	}
+
+	entry.Key = d.unwrap(entry.Key)
+
+	if entry.ReferencedBy != nil {
+		entry.ReferencedBy.Key = d.unwrap(entry.ReferencedBy.Key)
+	}
+
+	return entry
+}
+
+func (d *namespacedDB) unwrapEntries(entries *schema.Entries) *schema.Entries {
+	if entries == nil {
+		return nil
+	}
+
+	for _, e := range entries.Entries {
+		d.unwrapEntry(e)
+	}
+
+	return entries
+}
+
+func (d *namespacedDB) wrapKVs(kvs []*schema.KeyValue) []*schema.KeyValue {
+	nkvs := make([]*schema.KeyValue, len(kvs))
+
+	for i, kv := range kvs {
+		if kv == nil {
+			continue
+		}
+		nkvs[i] = &schema.KeyValue{Key: d.wrap(kv.Key), Value: kv.Value, Metadata: kv.Metadata}
+	}
+
+	return nkvs
+}
+
+func (d *namespacedDB) wrapKeys(keys [][]byte) [][]byte {
+	nkeys := make([][]byte, len(keys))
+
+	for i, k := range keys {
+		nkeys[i] = d.wrap(k)
+	}
+
+	return nkeys
+}
+
+func (d *namespacedDB) wrapKeyRequest(req *schema.KeyRequest) *schema.KeyRequest {
+	if req == nil {
+		return nil
+	}
+
+	nreq := proto.Clone(req).(*schema.KeyRequest)
+	nreq.Key = d.wrap(req.Key)
+
+	return nreq
+}
+
+func (d *namespacedDB) wrapSetRequest(req *schema.SetRequest) *schema.SetRequest {
+	if req == nil {
+		return nil
+	}
+
+	nreq := proto.Clone(req).(*schema.SetRequest)
+	nreq.KVs = d.wrapKVs(req.KVs)
+
+	return nreq
+}
+
+func (d *namespacedDB) wrapReferenceRequest(req *schema.ReferenceRequest) *schema.ReferenceRequest {
+	if req == nil {
+		return nil
+	}
+
+	nreq := proto.Clone(req).(*schema.ReferenceRequest)
+	nreq.Key = d.wrap(req.Key)
+	nreq.ReferencedKey = d.wrap(req.ReferencedKey)
+
+	return nreq
+}
+
+func (d *namespacedDB) wrapZAddRequest(req *schema.ZAddRequest) *schema.ZAddRequest {
+	if req == nil {
+		return nil
+	}
+
+	nreq := proto.Clone(req).(*schema.ZAddRequest)
+	nreq.Set = d.wrap(req.Set)
+	nreq.Key = d.wrap(req.Key)
+
+	return nreq
+}
+
+func (d *namespacedDB) Set(req *schema.SetRequest) (*schema.TxHeader, error) {
+	return d.DB.Set(d.wrapSetRequest(req))
+}
+
+func (d *namespacedDB) VerifiableSet(req *schema.VerifiableSetRequest) (*schema.VerifiableTx, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	return d.DB.VerifiableSet(&schema.VerifiableSetRequest{
+		SetRequest:   d.wrapSetRequest(req.SetRequest),
+		ProveSinceTx: req.ProveSinceTx,
+	})
+}
+
+func (d *namespacedDB) Get(req *schema.KeyRequest) (*schema.Entry, error) {
+	entry, err := d.DB.Get(d.wrapKeyRequest(req))
+	return d.unwrapEntry(entry), err
+}
+
+func (d *namespacedDB) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	vEntry, err := d.DB.VerifiableGet(&schema.VerifiableGetRequest{
+		KeyRequest:   d.wrapKeyRequest(req.KeyRequest),
+		ProveSinceTx: req.ProveSinceTx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.unwrapEntry(vEntry.Entry)
+
+	return vEntry, nil
+}
+
+func (d *namespacedDB) GetAll(req *schema.KeyListRequest) (*schema.Entries, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	entries, err := d.DB.GetAll(&schema.KeyListRequest{Keys: d.wrapKeys(req.Keys), SinceTx: req.SinceTx})
+	return d.unwrapEntries(entries), err
+}
+
+func (d *namespacedDB) VerifiableGetAll(req *schema.VerifiableGetAllRequest) (*schema.VerifiableEntries, error) {
+	if req == nil || req.KeyListRequest == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	vEntries, err := d.DB.VerifiableGetAll(&schema.VerifiableGetAllRequest{
+		KeyListRequest: &schema.KeyListRequest{Keys: d.wrapKeys(req.KeyListRequest.Keys), SinceTx: req.KeyListRequest.SinceTx},
+		ProveSinceTx:   req.ProveSinceTx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range vEntries.Entries {
+		d.unwrapEntry(e)
+	}
+
+	return vEntries, nil
+}
+
+func (d *namespacedDB) Delete(req *schema.DeleteKeysRequest) (*schema.TxHeader, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	nreq := proto.Clone(req).(*schema.DeleteKeysRequest)
+	nreq.Keys = d.wrapKeys(req.Keys)
+
+	return d.DB.Delete(nreq)
+}
+
+func (d *namespacedDB) Forget(req *schema.ForgetRequest) error {
+	if req == nil {
+		return database.ErrIllegalArguments
+	}
+
+	return d.DB.Forget(&schema.ForgetRequest{Key: d.wrap(req.Key)})
+}
+
+func (d *namespacedDB) SetReference(req *schema.ReferenceRequest) (*schema.TxHeader, error) {
+	return d.DB.SetReference(d.wrapReferenceRequest(req))
+}
+
+func (d *namespacedDB) VerifiableSetReference(req *schema.VerifiableReferenceRequest) (*schema.VerifiableTx, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	return d.DB.VerifiableSetReference(&schema.VerifiableReferenceRequest{
+		ReferenceRequest: d.wrapReferenceRequest(req.ReferenceRequest),
+		ProveSinceTx:     req.ProveSinceTx,
+	})
+}
+
+func (d *namespacedDB) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	nreq := proto.Clone(req).(*schema.ScanRequest)
+	nreq.Prefix = d.wrap(req.Prefix)
+
+	if len(req.SeekKey) > 0 {
+		nreq.SeekKey = d.wrap(req.SeekKey)
+	}
+
+	entries, err := d.DB.Scan(nreq)
+	return d.unwrapEntries(entries), err
+}
+
+func (d *namespacedDB) History(req *schema.HistoryRequest) (*schema.Entries, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	nreq := proto.Clone(req).(*schema.HistoryRequest)
+	nreq.Key = d.wrap(req.Key)
+
+	entries, err := d.DB.History(nreq)
+	return d.unwrapEntries(entries), err
+}
+
+func (d *namespacedDB) ComputeDiff(req *schema.DiffRequest, cancellation <-chan struct{}, send func(*schema.DiffEntry) error) error {
+	if req == nil || send == nil {
+		return database.ErrIllegalArguments
+	}
+
+	nreq := proto.Clone(req).(*schema.DiffRequest)
+	nreq.Prefix = d.wrap(req.Prefix)
+
+	return d.DB.ComputeDiff(nreq, cancellation, func(entry *schema.DiffEntry) error {
+		entry.Key = d.unwrap(entry.Key)
+		return send(entry)
+	})
+}
+
+func (d *namespacedDB) ExecAll(req *schema.ExecAllRequest) (*schema.TxHeader, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	ops := make([]*schema.Op, len(req.Operations))
+
+	for i, op := range req.Operations {
+		if op == nil {
+			continue
+		}
+
+		switch x := op.Operation.(type) {
+		case *schema.Op_Kv:
+			ops[i] = &schema.Op{Operation: &schema.Op_Kv{Kv: d.wrapKVs([]*schema.KeyValue{x.Kv})[0]}}
+		case *schema.Op_Ref:
+			ops[i] = &schema.Op{Operation: &schema.Op_Ref{Ref: d.wrapReferenceRequest(x.Ref)}}
+		case *schema.Op_ZAdd:
+			ops[i] = &schema.Op{Operation: &schema.Op_ZAdd{ZAdd: d.wrapZAddRequest(x.ZAdd)}}
+		default:
+			ops[i] = op
+		}
+	}
+
+	return d.DB.ExecAll(&schema.ExecAllRequest{Operations: ops, NoWait: req.NoWait})
+}
+
+func (d *namespacedDB) Count(prefix *schema.KeyPrefix) (*schema.EntryCount, error) {
+	if prefix == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	return d.DB.Count(&schema.KeyPrefix{Prefix: d.wrap(prefix.Prefix)})
+}
+
+func (d *namespacedDB) CountAll() (*schema.EntryCount, error) {
+	return d.DB.Count(&schema.KeyPrefix{Prefix: d.prefix})
+}
+
+func (d *namespacedDB) ZAdd(req *schema.ZAddRequest) (*schema.TxHeader, error) {
+	return d.DB.ZAdd(d.wrapZAddRequest(req))
+}
+
+func (d *namespacedDB) VerifiableZAdd(req *schema.VerifiableZAddRequest) (*schema.VerifiableTx, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	return d.DB.VerifiableZAdd(&schema.VerifiableZAddRequest{
+		ZAddRequest:  d.wrapZAddRequest(req.ZAddRequest),
+		ProveSinceTx: req.ProveSinceTx,
+	})
+}
+
+func (d *namespacedDB) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	nreq := proto.Clone(req).(*schema.ZScanRequest)
+	nreq.Set = d.wrap(req.Set)
+
+	if len(req.SeekKey) > 0 {
+		nreq.SeekKey = d.wrap(req.SeekKey)
+	}
+
+	zentries, err := d.DB.ZScan(nreq)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ze := range zentries.Entries {
+		ze.Set = d.unwrap(ze.Set)
+		ze.Key = d.unwrap(ze.Key)
+		d.unwrapEntry(ze.Entry)
+	}
+
+	return zentries, nil
+}
+
+func (d *namespacedDB) VerifiableZScan(req *schema.VerifiableZScanRequest) (*schema.VerifiableZEntries, error) {
+	if req == nil || req.ZScanRequest == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	nreq := proto.Clone(req.ZScanRequest).(*schema.ZScanRequest)
+	nreq.Set = d.wrap(req.ZScanRequest.Set)
+
+	if len(req.ZScanRequest.SeekKey) > 0 {
+		nreq.SeekKey = d.wrap(req.ZScanRequest.SeekKey)
+	}
+
+	vEntries, err := d.DB.VerifiableZScan(&schema.VerifiableZScanRequest{
+		ZScanRequest: nreq,
+		ProveSinceTx: req.ProveSinceTx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ze := range vEntries.Entries {
+		ze.Set = d.unwrap(ze.Set)
+		ze.Key = d.unwrap(ze.Key)
+		d.unwrapEntry(ze.Entry)
+	}
+
+	return vEntries, nil
+}
+
+func (d *namespacedDB) SQLExec(req *schema.SQLExecRequest, tx *sql.SQLTx) (*sql.SQLTx, []*sql.SQLTx, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db.SQLExec(req, tx)
+}
+
+func (d *namespacedDB) SQLExecPrepared(stmts []sql.SQLStmt, namedParams []*schema.NamedParam, tx *sql.SQLTx) (*sql.SQLTx, []*sql.SQLTx, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db.SQLExecPrepared(stmts, namedParams, tx)
+}
+
+func (d *namespacedDB) SQLExecScript(req *schema.SQLExecRequest) (*sql.SQLTx, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.SQLExecScript(req)
+}
+
+func (d *namespacedDB) InferJSONIngest(req *schema.IngestJSONRequest) (*schema.SQLExecRequest, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.InferJSONIngest(req)
+}
+
+func (d *namespacedDB) InferParameters(query string, tx *sql.SQLTx) (map[string]sql.SQLValueType, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.InferParameters(query, tx)
+}
+
+func (d *namespacedDB) InferParametersPrepared(stmt sql.SQLStmt, tx *sql.SQLTx) (map[string]sql.SQLValueType, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.InferParametersPrepared(stmt, tx)
+}
+
+func (d *namespacedDB) SQLQuery(req *schema.SQLQueryRequest, tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.SQLQuery(req, tx)
+}
+
+func (d *namespacedDB) SQLQueryWithCancellation(req *schema.SQLQueryRequest, tx *sql.SQLTx, cancellation <-chan struct{}) (*schema.SQLQueryResult, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.SQLQueryWithCancellation(req, tx, cancellation)
+}
+
+func (d *namespacedDB) SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedParam, tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.SQLQueryPrepared(stmt, namedParams, tx)
+}
+
+func (d *namespacedDB) SQLQueryRowReader(stmt *sql.SelectStmt, tx *sql.SQLTx) (sql.RowReader, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.SQLQueryRowReader(stmt, tx)
+}
+
+func (d *namespacedDB) NewSQLTx(waitForIndexing bool) (*sql.SQLTx, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.NewSQLTx(waitForIndexing)
+}
+
+func (d *namespacedDB) SQLListen(req *schema.SQLListenRequest, cancellation <-chan struct{}, notify func(*schema.SQLNotification) error) error {
+	db, err := d.sqlDB()
+	if err != nil {
+		return err
+	}
+
+	return db.SQLListen(req, cancellation, notify)
+}
+
+func (d *namespacedDB) SQLBlob(req *schema.SQLBlobRequest, tx *sql.SQLTx) ([]byte, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.SQLBlob(req, tx)
+}
+
+func (d *namespacedDB) ListTables(tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.ListTables(tx)
+}
+
+func (d *namespacedDB) DescribeTable(table string, tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.DescribeTable(table, tx)
+}
+
+func (d *namespacedDB) DescribeSchema(tx *sql.SQLTx) (*schema.SQLSchema, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.DescribeSchema(tx)
+}
+
+func (d *namespacedDB) IndexSuggestions() (*schema.IndexSuggestionList, error) {
+	db, err := d.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.IndexSuggestions()
+}
+
+// row proofs are verified by clients against keys built with the prefix of the database SQL catalog
+
+func (d *namespacedDB) VerifiableSQLGet(req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error) {
+	return nil, ErrNotAllowedInNamespace
+}
+
+// transactions may include entries out of the namespace
+
+func (d *namespacedDB) TxByID(req *schema.TxRequest) (*schema.Tx, error) {
+	return nil, ErrNotAllowedInNamespace
+}
+
+func (d *namespacedDB) ExportTxByID(req *schema.TxRequest) ([]byte, error) {
+	return nil, ErrNotAllowedInNamespace
+}
+
+func (d *namespacedDB) ReplicateTx(exportedTx []byte) (*schema.TxHeader, error) {
+	return nil, ErrNotAllowedInNamespace
+}
+
+func (d *namespacedDB) VerifiableTxByID(req *schema.VerifiableTxRequest) (*schema.VerifiableTx, error) {
+	return nil, ErrNotAllowedInNamespace
+}
+
+func (d *namespacedDB) TxScan(req *schema.TxScanRequest) (*schema.TxList, error) {
+	return nil, ErrNotAllowedInNamespace
+}
+
+func (d *namespacedDB) PrepareExecAll(req *schema.ExecAllRequest) (database.PreparedTx, error) {
+	return nil, ErrNotAllowedInNamespace
+}
+
+func (d *namespacedDB) CompactIndex() error {
+	return ErrNotAllowedInNamespace
+}
+
+func (d *namespacedDB) IndexingProgress() (*schema.IndexingProgressResponse, error) {
+	return nil, ErrNotAllowedInNamespace
+}
+
+func (d *namespacedDB) CopyStateAt(txID uint64, dst database.DB) error {
+	return ErrNotAllowedInNamespace
+}
+
+func (d *namespacedDB) VerifyTxs(fromTxID, toTxID uint64) error {
+	return ErrNotAllowedInNamespace
+}
+
+func (d *namespacedDB) Truncate(before time.Time, dryRun bool) (*database.TruncationReport, error) {
+	return nil, ErrNotAllowedInNamespace
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"google.golang.org/protobuf/proto"
+)
+
+// NotarizeObject stores the record of the object under a key derived from its digest and returns the verifiable
+// transaction holding it. When the content is provided its digest and size are computed, and must match the
+// record ones if they were set as well
+func (s *ImmuServer) NotarizeObject(ctx context.Context, req *schema.NotarizeObjectRequest) (*schema.NotarizationReceipt, error) {
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	record := &schema.NotarizationRecord{}
+	if req.Record != nil {
+		record = proto.Clone(req.Record).(*schema.NotarizationRecord)
+	}
+
+	if len(req.Content) > 0 {
+		digest := sha256.Sum256(req.Content)
+
+		if len(record.Digest) > 0 && !bytes.Equal(record.Digest, digest[:]) {
+			return nil, schema.ErrInvalidNotarizationRecord
+		}
+		if record.Size > 0 && record.Size != int64(len(req.Content)) {
+			return nil, schema.ErrInvalidNotarizationRecord
+		}
+
+		record.Digest = digest[:]
+		record.Size = int64(len(req.Content))
+	}
+
+	err := record.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := record.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	vtx, err := s.VerifiableSet(ctx, &schema.VerifiableSetRequest{
+		SetRequest:   &schema.SetRequest{KVs: []*schema.KeyValue{{Key: schema.NotarizationKey(record.Digest), Value: value}}},
+		ProveSinceTx: req.ProveSinceTx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.NotarizationReceipt{Record: record, VerifiableTx: vtx}, nil
+}
@@ -22,7 +22,9 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/codenotary/immudb/pkg/database"
 	"github.com/codenotary/immudb/pkg/server/sessions"
 
 	"github.com/codenotary/immudb/pkg/stream"
@@ -35,38 +37,76 @@ const DefaultDBName = "defaultdb"
 
 // Options server options list
 type Options struct {
-	Dir                  string
-	Network              string
-	Address              string
-	Port                 int
-	MetricsPort          int
-	Config               string
-	Pidfile              string
-	Logfile              string
-	TLSConfig            *tls.Config
-	auth                 bool
-	MaxRecvMsgSize       int
-	NoHistograms         bool
-	Detached             bool
-	MetricsServer        bool
-	WebServer            bool
-	WebServerPort        int
-	DevMode              bool
-	AdminPassword        string `json:"-"`
-	systemAdminDBName    string
-	defaultDBName        string
-	listener             net.Listener
-	usingCustomListener  bool
-	maintenance          bool
-	SigningKey           string
-	synced               bool
-	RemoteStorageOptions *RemoteStorageOptions
-	StreamChunkSize      int
-	TokenExpiryTimeMin   int
-	PgsqlServer          bool
-	PgsqlServerPort      int
-	ReplicationOptions   *ReplicationOptions
-	SessionsOptions      *sessions.Options
+	Dir                     string
+	Network                 string
+	Address                 string
+	Port                    int
+	MetricsPort             int
+	Config                  string
+	Pidfile                 string
+	Logfile                 string
+	TLSConfig               *tls.Config
+	TLSCertificateFile      string
+	TLSKeyFile              string
+	auth                    bool
+	MaxRecvMsgSize          int
+	NoHistograms            bool
+	Detached                bool
+	MetricsServer           bool
+	WebServer               bool
+	WebServerPort           int
+	DevMode                 bool
+	AdminPassword           string `json:"-"`
+	systemAdminDBName       string
+	defaultDBName           string
+	listener                net.Listener
+	usingCustomListener     bool
+	maintenance             bool
+	SigningKey              string
+	synced                  bool
+	RemoteStorageOptions    *RemoteStorageOptions
+	StreamChunkSize         int
+	TokenExpiryTimeMin      int
+	PgsqlServer             bool
+	PgsqlServerPort         int
+	PromRemoteWriteServer   bool
+	PromRemoteWritePort     int
+	PromRemoteWriteDatabase string
+	QueryServer             bool
+	QueryServerPort         int
+	QueryAPIKeys            map[string][]string `json:"-"` // databases or db.table names readable with each API key
+	GrafanaTables           map[string]string   // time column of the db.table names exposed to Grafana
+	SyslogServer            bool
+	SyslogPort              int
+	FluentForwardServer     bool
+	FluentForwardPort       int
+	LogIngestDatabase       string
+	ReplicationOptions      *ReplicationOptions
+	SessionsOptions         *sessions.Options
+	MinTxWaitTimeout        time.Duration
+	DefaultPageSize         int
+	MaxPageSize             int
+	SelfCheckTxs            int
+	SelfCheckFull           bool
+	SelfCheckDatabase       string
+	IndexBufferPoolSize     int
+	MinFreeDiskSpace        uint64
+	DiskSpaceCheckInterval  time.Duration
+	RetentionCheckInterval  time.Duration
+	AuditLogOptions         *AuditLogOptions
+	IdempotencyKeyTTL       time.Duration
+	IdempotencyCacheSize    int
+}
+
+// AuditLogOptions sets which requests are audited and how their content is scrubbed before being logged
+type AuditLogOptions struct {
+	Enabled bool
+	// fraction of the successful requests being audited, failed ones are always audited
+	SampleRate float64
+	// sample rates overriding the default one, by method name e.g. Set or Login
+	MethodSampleRates map[string]float64
+	// actions applied to the request fields, by field name e.g. key or value
+	ScrubRules map[string]AuditScrubAction
 }
 
 type RemoteStorageOptions struct {
@@ -89,35 +129,66 @@ type ReplicationOptions struct {
 // DefaultOptions returns default server options
 func DefaultOptions() *Options {
 	return &Options{
-		Dir:                  "./data",
-		Network:              "tcp",
-		Address:              "0.0.0.0",
-		Port:                 3322,
-		MetricsPort:          9497,
-		WebServerPort:        8080,
-		Config:               "configs/immudb.toml",
-		Pidfile:              "",
-		Logfile:              "",
-		TLSConfig:            nil,
-		auth:                 true,
-		MaxRecvMsgSize:       1024 * 1024 * 32, // 32Mb
-		NoHistograms:         false,
-		Detached:             false,
-		MetricsServer:        true,
-		WebServer:            true,
-		DevMode:              false,
-		AdminPassword:        auth.SysAdminPassword,
-		systemAdminDBName:    SystemDBName,
-		defaultDBName:        DefaultDBName,
-		usingCustomListener:  false,
-		maintenance:          false,
-		synced:               true,
-		RemoteStorageOptions: DefaultRemoteStorageOptions(),
-		StreamChunkSize:      stream.DefaultChunkSize,
-		TokenExpiryTimeMin:   1440,
-		PgsqlServer:          false,
-		PgsqlServerPort:      5432,
-		SessionsOptions:      sessions.DefaultOptions(),
+		Dir:                     "./data",
+		Network:                 "tcp",
+		Address:                 "0.0.0.0",
+		Port:                    3322,
+		MetricsPort:             9497,
+		WebServerPort:           8080,
+		Config:                  "configs/immudb.toml",
+		Pidfile:                 "",
+		Logfile:                 "",
+		TLSConfig:               nil,
+		auth:                    true,
+		MaxRecvMsgSize:          1024 * 1024 * 32, // 32Mb
+		NoHistograms:            false,
+		Detached:                false,
+		MetricsServer:           true,
+		WebServer:               true,
+		DevMode:                 false,
+		AdminPassword:           auth.SysAdminPassword,
+		systemAdminDBName:       SystemDBName,
+		defaultDBName:           DefaultDBName,
+		usingCustomListener:     false,
+		maintenance:             false,
+		synced:                  true,
+		RemoteStorageOptions:    DefaultRemoteStorageOptions(),
+		StreamChunkSize:         stream.DefaultChunkSize,
+		TokenExpiryTimeMin:      1440,
+		PgsqlServer:             false,
+		PgsqlServerPort:         5432,
+		PromRemoteWriteServer:   false,
+		PromRemoteWritePort:     9201,
+		PromRemoteWriteDatabase: DefaultDBName,
+		QueryServer:             false,
+		QueryServerPort:         9202,
+		SyslogServer:            false,
+		SyslogPort:              5514,
+		FluentForwardServer:     false,
+		FluentForwardPort:       24224,
+		LogIngestDatabase:       DefaultDBName,
+		SessionsOptions:         sessions.DefaultOptions(),
+		MinTxWaitTimeout:        5 * time.Second,
+		DefaultPageSize:         database.DefaultPageSize,
+		MaxPageSize:             database.MaxKeyScanLimit,
+		SelfCheckTxs:            0,
+		SelfCheckFull:           false,
+		SelfCheckDatabase:       SystemDBName,
+		IndexBufferPoolSize:     0,
+		MinFreeDiskSpace:        0,
+		DiskSpaceCheckInterval:  10 * time.Second,
+		RetentionCheckInterval:  1 * time.Hour,
+		AuditLogOptions:         DefaultAuditLogOptions(),
+		IdempotencyKeyTTL:       10 * time.Minute,
+		IdempotencyCacheSize:    10000,
+	}
+}
+
+// DefaultAuditLogOptions returns the audit log options, being disabled by default
+func DefaultAuditLogOptions() *AuditLogOptions {
+	return &AuditLogOptions{
+		Enabled:    false,
+		SampleRate: 1,
 	}
 }
 
@@ -175,6 +246,13 @@ func (o *Options) WithTLS(tls *tls.Config) *Options {
 	return o
 }
 
+// WithTLSCertificateFiles sets the files the tls certificate and private key are reloaded from when rotated
+func (o *Options) WithTLSCertificateFiles(certificateFile, keyFile string) *Options {
+	o.TLSCertificateFile = certificateFile
+	o.TLSKeyFile = keyFile
+	return o
+}
+
 // WithAuth sets auth
 // Deprecated: WithAuth will be removed in future release
 func (o *Options) WithAuth(authEnabled bool) *Options {
@@ -220,6 +298,32 @@ func (o *Options) WebBind() string {
 	return o.Address + ":" + strconv.Itoa(o.WebServerPort)
 }
 
+// PromRemoteWriteBind return bind address for the Prometheus remote-write server
+func (o *Options) PromRemoteWriteBind() string {
+	return o.Address + ":" + strconv.Itoa(o.PromRemoteWritePort)
+}
+
+// QueryServerBind return bind address for the read-only HTTP query server
+func (o *Options) QueryServerBind() string {
+	return o.Address + ":" + strconv.Itoa(o.QueryServerPort)
+}
+
+// SyslogBind return bind address for the syslog server, empty when disabled
+func (o *Options) SyslogBind() string {
+	if !o.SyslogServer {
+		return ""
+	}
+	return o.Address + ":" + strconv.Itoa(o.SyslogPort)
+}
+
+// FluentForwardBind return bind address for the Fluent Forward server, empty when disabled
+func (o *Options) FluentForwardBind() string {
+	if !o.FluentForwardServer {
+		return ""
+	}
+	return o.Address + ":" + strconv.Itoa(o.FluentForwardPort)
+}
+
 // String print options
 func (o *Options) String() string {
 	rightPad := func(k string, v interface{}) string {
@@ -239,6 +343,35 @@ func (o *Options) String() string {
 	if o.MetricsServer {
 		opts = append(opts, rightPad("Metrics address", fmt.Sprintf("%s:%d/metrics", o.Address, o.MetricsPort)))
 	}
+	if o.PromRemoteWriteServer {
+		opts = append(opts, rightPad("Remote-write", fmt.Sprintf("%s:%d/api/v1/write into %s", o.Address, o.PromRemoteWritePort, o.PromRemoteWriteDatabase)))
+	}
+	if o.QueryServer {
+		opts = append(opts, rightPad("Query API", fmt.Sprintf("%s:%d/api/v1/query with %d API keys", o.Address, o.QueryServerPort, len(o.QueryAPIKeys))))
+		if len(o.GrafanaTables) > 0 {
+			opts = append(opts, rightPad("Grafana datasource", fmt.Sprintf("%s:%d/grafana/<db> with %d tables", o.Address, o.QueryServerPort, len(o.GrafanaTables))))
+		}
+	}
+	if o.SyslogServer {
+		opts = append(opts, rightPad("Syslog", fmt.Sprintf("%s:%d into %s", o.Address, o.SyslogPort, o.LogIngestDatabase)))
+	}
+	if o.FluentForwardServer {
+		opts = append(opts, rightPad("Fluent Forward", fmt.Sprintf("%s:%d into %s", o.Address, o.FluentForwardPort, o.LogIngestDatabase)))
+	}
+	if o.SelfCheckFull {
+		opts = append(opts, rightPad("Self-check", fmt.Sprintf("all txs into %s", o.SelfCheckDatabase)))
+	} else if o.SelfCheckTxs > 0 {
+		opts = append(opts, rightPad("Self-check", fmt.Sprintf("last %d txs into %s", o.SelfCheckTxs, o.SelfCheckDatabase)))
+	}
+	if o.IndexBufferPoolSize > 0 {
+		opts = append(opts, rightPad("Index buffer pool", fmt.Sprintf("%d nodes", o.IndexBufferPoolSize)))
+	}
+	if o.MinFreeDiskSpace > 0 {
+		opts = append(opts, rightPad("Min free disk space", fmt.Sprintf("%d bytes", o.MinFreeDiskSpace)))
+	}
+	if o.AuditLogOptions != nil && o.AuditLogOptions.Enabled {
+		opts = append(opts, rightPad("Audit log", fmt.Sprintf("sampling %g of requests", o.AuditLogOptions.SampleRate)))
+	}
 	if o.Config != "" {
 		opts = append(opts, rightPad("Config file", o.Config))
 	}
@@ -375,6 +508,80 @@ func (o *Options) WithPgsqlServerPort(port int) *Options {
 	return o
 }
 
+// WithPromRemoteWriteServer enable or disable the Prometheus remote-write server
+func (o *Options) WithPromRemoteWriteServer(enable bool) *Options {
+	o.PromRemoteWriteServer = enable
+	return o
+}
+
+// WithPromRemoteWritePort sets the Prometheus remote-write server port
+func (o *Options) WithPromRemoteWritePort(port int) *Options {
+	o.PromRemoteWritePort = port
+	return o
+}
+
+// WithPromRemoteWriteDatabase sets the database receiving the Prometheus remote-write samples
+func (o *Options) WithPromRemoteWriteDatabase(dbName string) *Options {
+	o.PromRemoteWriteDatabase = dbName
+	return o
+}
+
+// WithQueryServer enable or disable the read-only HTTP query server
+func (o *Options) WithQueryServer(enable bool) *Options {
+	o.QueryServer = enable
+	return o
+}
+
+// WithQueryServerPort sets the read-only HTTP query server port
+func (o *Options) WithQueryServerPort(port int) *Options {
+	o.QueryServerPort = port
+	return o
+}
+
+// WithQueryAPIKeys sets the API keys accepted by the query server, each one mapped to the databases
+// and tables it can read. Tables are named as db.table while a database name grants access to all its tables
+func (o *Options) WithQueryAPIKeys(keys map[string][]string) *Options {
+	o.QueryAPIKeys = keys
+	return o
+}
+
+// WithGrafanaTables sets the tables exposed through the Grafana datasource endpoints of the query server,
+// each db.table name is mapped to the column holding the time of its rows
+func (o *Options) WithGrafanaTables(tables map[string]string) *Options {
+	o.GrafanaTables = tables
+	return o
+}
+
+// WithSyslogServer enable or disable the syslog server
+func (o *Options) WithSyslogServer(enable bool) *Options {
+	o.SyslogServer = enable
+	return o
+}
+
+// WithSyslogPort sets the syslog server port, used for both tcp and udp
+func (o *Options) WithSyslogPort(port int) *Options {
+	o.SyslogPort = port
+	return o
+}
+
+// WithFluentForwardServer enable or disable the Fluent Forward server
+func (o *Options) WithFluentForwardServer(enable bool) *Options {
+	o.FluentForwardServer = enable
+	return o
+}
+
+// WithFluentForwardPort sets the Fluent Forward server port
+func (o *Options) WithFluentForwardPort(port int) *Options {
+	o.FluentForwardPort = port
+	return o
+}
+
+// WithLogIngestDatabase sets the database receiving the syslog and Fluent Forward records
+func (o *Options) WithLogIngestDatabase(dbName string) *Options {
+	o.LogIngestDatabase = dbName
+	return o
+}
+
 func (o *Options) WithRemoteStorageOptions(remoteStorageOptions *RemoteStorageOptions) *Options {
 	o.RemoteStorageOptions = remoteStorageOptions
 	return o
@@ -390,6 +597,119 @@ func (o *Options) WithSessionOptions(options *sessions.Options) *Options {
 	return o
 }
 
+// WithMinTxWaitTimeout sets how long requests carrying the last transaction written by the client
+// wait for the database to reach it
+func (o *Options) WithMinTxWaitTimeout(timeout time.Duration) *Options {
+	o.MinTxWaitTimeout = timeout
+	return o
+}
+
+// WithDefaultPageSize sets the number of results returned by scans, histories and SQL queries
+// when the request does not set a limit
+func (o *Options) WithDefaultPageSize(size int) *Options {
+	o.DefaultPageSize = size
+	return o
+}
+
+// WithMaxPageSize sets the max number of results returned at once, larger requests are
+// truncated and a continuation token is returned to read the following ones
+func (o *Options) WithMaxPageSize(size int) *Options {
+	o.MaxPageSize = size
+	return o
+}
+
+// WithSelfCheckTxs sets how many of the last transactions of each database are verified on startup,
+// the self-check is disabled when set to 0
+func (o *Options) WithSelfCheckTxs(txs int) *Options {
+	o.SelfCheckTxs = txs
+	return o
+}
+
+// WithSelfCheckFull sets whether all the transactions of each database are verified on startup
+func (o *Options) WithSelfCheckFull(full bool) *Options {
+	o.SelfCheckFull = full
+	return o
+}
+
+// WithSelfCheckDatabase sets the database self-check reports are written into
+func (o *Options) WithSelfCheckDatabase(dbName string) *Options {
+	o.SelfCheckDatabase = dbName
+	return o
+}
+
+// WithIndexBufferPoolSize sets the max number of index nodes kept in memory, the pool is shared by
+// all the databases. Each database keeps its own cache of nodes when it's set to 0
+func (o *Options) WithIndexBufferPoolSize(size int) *Options {
+	o.IndexBufferPoolSize = size
+	return o
+}
+
+// WithMinFreeDiskSpace sets the free disk space, in bytes, under which databases are switched to read-only.
+// The disk space is not watched when it's set to 0
+func (o *Options) WithMinFreeDiskSpace(bytes uint64) *Options {
+	o.MinFreeDiskSpace = bytes
+	return o
+}
+
+// WithDiskSpaceCheckInterval sets how often the free disk space is checked
+func (o *Options) WithDiskSpaceCheckInterval(interval time.Duration) *Options {
+	o.DiskSpaceCheckInterval = interval
+	return o
+}
+
+// WithRetentionCheckInterval sets how often the databases are truncated as their retention periods require.
+// Databases are not truncated when it's set to 0
+func (o *Options) WithRetentionCheckInterval(interval time.Duration) *Options {
+	o.RetentionCheckInterval = interval
+	return o
+}
+
+// WithAuditLogOptions sets the audit log options
+func (o *Options) WithAuditLogOptions(auditLogOptions *AuditLogOptions) *Options {
+	o.AuditLogOptions = auditLogOptions
+	return o
+}
+
+// WithIdempotencyKeyTTL sets for how long the result of a write is returned to the retries sharing its idempotency key,
+// results are kept until evicted by newer ones when it's set to 0
+func (o *Options) WithIdempotencyKeyTTL(ttl time.Duration) *Options {
+	o.IdempotencyKeyTTL = ttl
+	return o
+}
+
+// WithIdempotencyCacheSize sets the max number of write results remembered by idempotency key,
+// idempotency keys are ignored when it's set to 0
+func (o *Options) WithIdempotencyCacheSize(size int) *Options {
+	o.IdempotencyCacheSize = size
+	return o
+}
+
+// AuditLogOptions
+
+// WithEnabled enable or disable the audit log
+func (opts *AuditLogOptions) WithEnabled(enabled bool) *AuditLogOptions {
+	opts.Enabled = enabled
+	return opts
+}
+
+// WithSampleRate sets the fraction, between 0 and 1, of the successful requests being audited
+func (opts *AuditLogOptions) WithSampleRate(sampleRate float64) *AuditLogOptions {
+	opts.SampleRate = sampleRate
+	return opts
+}
+
+// WithMethodSampleRates sets the sample rates of specific methods, overriding the default one
+func (opts *AuditLogOptions) WithMethodSampleRates(sampleRates map[string]float64) *AuditLogOptions {
+	opts.MethodSampleRates = sampleRates
+	return opts
+}
+
+// WithScrubRules sets how the request fields are scrubbed, by field name
+func (opts *AuditLogOptions) WithScrubRules(rules map[string]AuditScrubAction) *AuditLogOptions {
+	opts.ScrubRules = rules
+	return opts
+}
+
 // RemoteStorageOptions
 
 func (opts *RemoteStorageOptions) WithS3Storage(S3Storage bool) *RemoteStorageOptions {
@@ -0,0 +1,137 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+type runningQuery struct {
+	id        uint64
+	user      string
+	database  string
+	sql       string
+	startedAt time.Time
+	cancel    context.CancelFunc
+}
+
+// queryRegistry keeps track of running queries so they can be listed and cancelled
+type queryRegistry struct {
+	mutex   sync.Mutex
+	lastID  uint64
+	queries map[uint64]*runningQuery
+}
+
+func (r *queryRegistry) register(user, database, sql string, cancel context.CancelFunc) uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.queries == nil {
+		r.queries = make(map[uint64]*runningQuery)
+	}
+
+	r.lastID++
+
+	r.queries[r.lastID] = &runningQuery{
+		id:        r.lastID,
+		user:      user,
+		database:  database,
+		sql:       sql,
+		startedAt: time.Now(),
+		cancel:    cancel,
+	}
+
+	return r.lastID
+}
+
+func (r *queryRegistry) unregister(id uint64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.queries, id)
+}
+
+func (r *queryRegistry) list(database string) []*schema.QueryInfo {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+
+	queries := make([]*schema.QueryInfo, 0, len(r.queries))
+
+	for _, q := range r.queries {
+		if q.database != database {
+			continue
+		}
+
+		queries = append(queries, &schema.QueryInfo{
+			Id:       q.id,
+			User:     q.user,
+			Database: q.database,
+			Sql:      q.sql,
+			Elapsed:  now.Sub(q.startedAt).Milliseconds(),
+		})
+	}
+
+	sort.Slice(queries, func(i, j int) bool { return queries[i].Id < queries[j].Id })
+
+	return queries
+}
+
+func (r *queryRegistry) cancel(database string, id uint64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	q, ok := r.queries[id]
+	if !ok || q.database != database {
+		return ErrQueryNotFound
+	}
+
+	q.cancel()
+
+	return nil
+}
+
+// ListQueries returns the queries currently running on the selected database
+func (s *ImmuServer) ListQueries(ctx context.Context, _ *empty.Empty) (*schema.QueryInfoList, error) {
+	db, err := s.getDBFromCtx(ctx, "ListQueries")
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.QueryInfoList{Queries: s.queries.list(db.GetName())}, nil
+}
+
+// CancelQuery cancels a query running on the selected database
+func (s *ImmuServer) CancelQuery(ctx context.Context, req *schema.CancelQueryRequest) (*empty.Empty, error) {
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	db, err := s.getDBFromCtx(ctx, "CancelQuery")
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, s.queries.cancel(db.GetName(), req.Id)
+}
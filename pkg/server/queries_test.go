@@ -0,0 +1,101 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestQueryCancellation(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithMetricsServer(false)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	s.Initialize()
+
+	ctx := context.Background()
+
+	_, err := s.ListQueries(ctx, &emptypb.Empty{})
+	require.Error(t, err)
+
+	_, err = s.CancelQuery(ctx, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	r := &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	}
+
+	lr, err := s.Login(ctx, r)
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx = metadata.NewIncomingContext(context.Background(), md)
+
+	_, err = s.SQLExec(ctx, &schema.SQLExecRequest{Sql: "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)"})
+	require.NoError(t, err)
+
+	res, err := s.ListQueries(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Empty(t, res.Queries)
+
+	qctx, cancel := context.WithCancel(ctx)
+	queryID := s.queries.register(auth.SysAdminUsername, DefaultDBName, "SELECT id FROM table1", cancel)
+	s.queries.register(auth.SysAdminUsername, "db2", "SELECT id FROM table2", func() {})
+
+	res, err = s.ListQueries(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, res.Queries, 1)
+	require.Equal(t, queryID, res.Queries[0].Id)
+	require.Equal(t, auth.SysAdminUsername, res.Queries[0].User)
+	require.Equal(t, DefaultDBName, res.Queries[0].Database)
+	require.Equal(t, "SELECT id FROM table1", res.Queries[0].Sql)
+
+	_, err = s.CancelQuery(ctx, &schema.CancelQueryRequest{Id: queryID + 1})
+	require.Equal(t, ErrQueryNotFound, err)
+
+	_, err = s.CancelQuery(ctx, &schema.CancelQueryRequest{Id: queryID})
+	require.NoError(t, err)
+	require.Error(t, qctx.Err())
+
+	s.queries.unregister(queryID)
+
+	_, err = s.CancelQuery(ctx, &schema.CancelQueryRequest{Id: queryID})
+	require.Equal(t, ErrQueryNotFound, err)
+
+	t.Run("queries should be interrupted when their context is cancelled", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := s.SQLQuery(cctx, &schema.SQLQueryRequest{Sql: "SELECT id FROM table1"})
+		require.Error(t, err)
+
+		res, err := s.ListQueries(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		require.Empty(t, res.Queries)
+	})
+}
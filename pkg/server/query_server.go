@@ -0,0 +1,423 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/logger"
+)
+
+var ErrInvalidQueryAPIKeys = errors.New("invalid query API keys")
+
+// ParseQueryAPIKeys parses the scopes of the API keys, given as a comma separated list of key=scopes pairs.
+// Scopes are separated by semicolons, each one being a database or a db.table name e.g. "k1=defaultdb,k2=sales.orders;sales.customers"
+func ParseQueryAPIKeys(s string) (map[string][]string, error) {
+	keys := make(map[string][]string)
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("%w: '%s' is not a key=scopes pair", ErrInvalidQueryAPIKeys, pair)
+		}
+
+		var scopes []string
+
+		for _, scope := range strings.Split(kv[1], ";") {
+			scope = strings.TrimSpace(scope)
+
+			if scope == "" || strings.Count(scope, ".") > 1 {
+				return nil, fmt.Errorf("%w: invalid scope '%s'", ErrInvalidQueryAPIKeys, scope)
+			}
+
+			scopes = append(scopes, scope)
+		}
+
+		keys[strings.TrimSpace(kv[0])] = scopes
+	}
+
+	return keys, nil
+}
+
+// queryScope holds the databases and tables readable with an API key
+type queryScope struct {
+	dbs    map[string]struct{}
+	tables map[string]struct{} // named as db.table
+}
+
+func newQueryScope(scopes []string) *queryScope {
+	scope := &queryScope{
+		dbs:    make(map[string]struct{}),
+		tables: make(map[string]struct{}),
+	}
+
+	for _, s := range scopes {
+		if strings.Contains(s, ".") {
+			scope.tables[s] = struct{}{}
+		} else {
+			scope.dbs[s] = struct{}{}
+		}
+	}
+
+	return scope
+}
+
+func (sc *queryScope) allowsDatabase(db string) bool {
+	if _, ok := sc.dbs[db]; ok {
+		return true
+	}
+
+	for t := range sc.tables {
+		if strings.HasPrefix(t, db+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (sc *queryScope) allowsTable(db, table string) bool {
+	if _, ok := sc.dbs[db]; ok {
+		return true
+	}
+
+	_, ok := sc.tables[db+"."+table]
+	return ok
+}
+
+// StartQueryServer serves read-only SQL queries at /api/v1/query, and the Grafana datasource endpoints at /grafana/<db>/.
+// Requests are authenticated by the API keys of the server options and can only read the databases and tables in their scope
+func StartQueryServer(addr string, tlsConfig *tls.Config, s *ImmuServer, l logger.Logger) (*http.Server, error) {
+	q := &queryHandler{s: s}
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/query", q)
+	mux.Handle("/grafana/", &grafanaHandler{q: q})
+
+	httpServer := &http.Server{Addr: addr, Handler: mux}
+	httpServer.TLSConfig = tlsConfig
+
+	go func() {
+		var err error
+		if tlsConfig != nil && len(tlsConfig.Certificates) > 0 {
+			l.Infof("Query server enabled on %s/api/v1/query (https)", addr)
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			l.Infof("Query server enabled on %s/api/v1/query (http)", addr)
+			err = httpServer.ListenAndServe()
+		}
+
+		if err == http.ErrServerClosed {
+			l.Debugf("Query server closed")
+		} else {
+			l.Errorf("Query server error: %s", err)
+		}
+	}()
+
+	return httpServer, nil
+}
+
+type queryRequest struct {
+	Database          string                 `json:"database"`
+	SQL               string                 `json:"sql"`
+	Params            map[string]interface{} `json:"params,omitempty"`
+	ContinuationToken []byte                 `json:"continuationToken,omitempty"`
+}
+
+type queryColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type queryResponse struct {
+	Columns           []*queryColumn  `json:"columns"`
+	Rows              [][]interface{} `json:"rows"`
+	ContinuationToken []byte          `json:"continuationToken,omitempty"`
+}
+
+type queryHandler struct {
+	s *ImmuServer
+}
+
+// authorize returns the scope of the API key sent either as a bearer token or in the X-API-Key header
+func (h *queryHandler) authorize(r *http.Request) (*queryScope, bool) {
+	key := r.Header.Get("X-API-Key")
+	if key == "" {
+		key = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+
+	if key == "" {
+		return nil, false
+	}
+
+	var scopes []string
+	found := false
+
+	// all the keys are compared so the time taken does not depend on which one matches
+	for k, s := range h.s.Options.QueryAPIKeys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			scopes = s
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, false
+	}
+
+	return newQueryScope(scopes), true
+}
+
+func (h *queryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.s.Options.GetMaintenance() {
+		http.Error(w, ErrNotAllowedInMaintenanceMode.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	scope, ok := h.authorize(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="immudb"`)
+		http.Error(w, "invalid API key", http.StatusUnauthorized)
+		return
+	}
+
+	req, err := h.decodeRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	qres, status, err := h.query(r, scope, req)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	res := newQueryResponse(qres)
+
+	if r.URL.Query().Get("format") == "csv" || strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		writeQueryCSV(w, res)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		h.s.Logger.Warningf("query response could not be written: %v", err)
+	}
+}
+
+// decodeRequest reads the query from the JSON body of POST requests, or from the db and sql parameters of GET ones
+func (h *queryHandler) decodeRequest(r *http.Request) (*queryRequest, error) {
+	req := &queryRequest{}
+
+	if r.Method == http.MethodGet {
+		req.Database = r.URL.Query().Get("db")
+		req.SQL = r.URL.Query().Get("sql")
+	} else {
+		dec := json.NewDecoder(io.LimitReader(r.Body, int64(h.s.Options.MaxRecvMsgSize)))
+		dec.UseNumber()
+
+		err := dec.Decode(req)
+		if err != nil {
+			return nil, fmt.Errorf("malformed query request: %v", err)
+		}
+	}
+
+	if req.SQL == "" {
+		return nil, errors.New("missing sql query")
+	}
+
+	if req.Database == "" {
+		req.Database = h.s.Options.GetDefaultDBName()
+	}
+
+	// numbers are decoded as integers whenever possible
+	for name, v := range req.Params {
+		n, ok := v.(json.Number)
+		if !ok {
+			continue
+		}
+
+		if i, err := n.Int64(); err == nil {
+			req.Params[name] = i
+			continue
+		}
+
+		f, err := n.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("malformed query request: invalid number %s", n)
+		}
+
+		req.Params[name] = f
+	}
+
+	return req, nil
+}
+
+// query runs the query on a transaction restricted to the tables in scope, only SELECT statements are accepted
+func (h *queryHandler) query(r *http.Request, scope *queryScope, req *queryRequest) (*schema.SQLQueryResult, int, error) {
+	if !scope.allowsDatabase(req.Database) {
+		return nil, http.StatusForbidden, fmt.Errorf("database '%s' is not readable with the API key", req.Database)
+	}
+
+	stmts, err := sql.ParseString(req.SQL)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if len(stmts) != 1 {
+		return nil, http.StatusBadRequest, sql.ErrExpectingDQLStmt
+	}
+
+	db, err := h.s.dbList.GetByName(req.Database)
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	params, err := schema.EncodeParams(req.Params)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	tx, err := db.NewSQLTx(true)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	defer tx.Cancel()
+
+	tx.SetTableFilter(func(_, table string) bool {
+		return scope.allowsTable(req.Database, table)
+	})
+
+	res, err := db.SQLQueryWithCancellation(&schema.SQLQueryRequest{
+		Sql:               req.SQL,
+		Params:            params,
+		ContinuationToken: req.ContinuationToken,
+	}, tx, r.Context().Done())
+	if errors.Is(err, sql.ErrTableAccessDenied) {
+		return nil, http.StatusForbidden, err
+	}
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	return res, http.StatusOK, nil
+}
+
+func newQueryResponse(res *schema.SQLQueryResult) *queryResponse {
+	qres := &queryResponse{
+		Columns:           make([]*queryColumn, len(res.Columns)),
+		Rows:              make([][]interface{}, len(res.Rows)),
+		ContinuationToken: res.ContinuationToken,
+	}
+
+	for i, col := range res.Columns {
+		qres.Columns[i] = &queryColumn{Name: queryColumnName(col.Name), Type: col.Type}
+	}
+
+	for i, row := range res.Rows {
+		qres.Rows[i] = make([]interface{}, len(row.Values))
+
+		for j, v := range row.Values {
+			qres.Rows[i][j] = queryValue(v)
+		}
+	}
+
+	return qres
+}
+
+// queryColumnName returns the name of the column out of its selector e.g. (defaultdb.orders.id)
+func queryColumnName(selector string) string {
+	return strings.TrimSuffix(selector[strings.LastIndex(selector, ".")+1:], ")")
+}
+
+// queryValue returns the value as encoded into JSON, timestamps are formatted as RFC 3339
+// and decimals are kept as strings so no precision is lost
+func queryValue(v *schema.SQLValue) interface{} {
+	switch tv := v.Value.(type) {
+	case *schema.SQLValue_Ts:
+		return sql.TimeFromInt64(tv.Ts).UTC().Format(time.RFC3339Nano)
+	case *schema.SQLValue_D:
+		return tv.D
+	}
+
+	return schema.RawValue(v)
+}
+
+// writeQueryCSV writes the rows with a header naming the columns, the continuation token
+// to read the following rows is sent in the X-Continuation-Token header
+func writeQueryCSV(w http.ResponseWriter, res *queryResponse) {
+	w.Header().Set("Content-Type", "text/csv")
+
+	if len(res.ContinuationToken) > 0 {
+		w.Header().Set("X-Continuation-Token", base64.StdEncoding.EncodeToString(res.ContinuationToken))
+	}
+
+	cw := csv.NewWriter(w)
+
+	record := make([]string, len(res.Columns))
+
+	for i, col := range res.Columns {
+		record[i] = col.Name
+	}
+
+	cw.Write(record)
+
+	for _, row := range res.Rows {
+		for i, v := range row {
+			switch tv := v.(type) {
+			case nil:
+				record[i] = ""
+			case []byte:
+				record[i] = base64.StdEncoding.EncodeToString(tv)
+			case float64:
+				record[i] = strconv.FormatFloat(tv, 'g', -1, 64)
+			default:
+				record[i] = fmt.Sprint(tv)
+			}
+		}
+
+		cw.Write(record)
+	}
+
+	cw.Flush()
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryServer(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithDir("query_server_data").
+		WithPort(0).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithQueryAPIKeys(map[string][]string{
+			"all":    {DefaultDBName},
+			"orders": {DefaultDBName + ".orders"},
+		})
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	db, err := s.dbList.GetByName(DefaultDBName)
+	require.NoError(t, err)
+
+	_, err = db.SQLExecScript(&schema.SQLExecRequest{Sql: `
+		CREATE TABLE orders (id INTEGER AUTO_INCREMENT, item VARCHAR, amount DECIMAL, PRIMARY KEY id);
+		CREATE TABLE customers (id INTEGER AUTO_INCREMENT, name VARCHAR, PRIMARY KEY id);
+		INSERT INTO orders (item, amount) VALUES ('book', CAST('10.5' AS DECIMAL)), ('pen', CAST('1' AS DECIMAL));
+		INSERT INTO customers (name) VALUES ('alice');
+	`})
+	require.NoError(t, err)
+
+	handler := &queryHandler{s: s}
+
+	send := func(req *http.Request, key string) *httptest.ResponseRecorder {
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	post := func(body interface{}, key string) *httptest.ResponseRecorder {
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		return send(httptest.NewRequest(http.MethodPost, "/api/v1/query", bytes.NewReader(b)), key)
+	}
+
+	get := func(params url.Values, key string) *httptest.ResponseRecorder {
+		return send(httptest.NewRequest(http.MethodGet, "/api/v1/query?"+params.Encode(), nil), key)
+	}
+
+	t.Run("requests should be authenticated with an API key", func(t *testing.T) {
+		require.Equal(t, http.StatusMethodNotAllowed, send(httptest.NewRequest(http.MethodPut, "/api/v1/query", nil), "all").Code)
+		require.Equal(t, http.StatusUnauthorized, post(&queryRequest{SQL: "SELECT * FROM orders"}, "").Code)
+		require.Equal(t, http.StatusUnauthorized, post(&queryRequest{SQL: "SELECT * FROM orders"}, "wrong").Code)
+	})
+
+	t.Run("rows should be returned as json", func(t *testing.T) {
+		rec := post(&queryRequest{SQL: "SELECT id, item, amount FROM orders WHERE id >= @id", Params: map[string]interface{}{"id": 1}}, "orders")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var res queryResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &res)
+		require.NoError(t, err)
+
+		require.Equal(t, []*queryColumn{{Name: "id", Type: "INTEGER"}, {Name: "item", Type: "VARCHAR"}, {Name: "amount", Type: "DECIMAL"}}, res.Columns)
+		require.Equal(t, [][]interface{}{{float64(1), "book", "10.5"}, {float64(2), "pen", "1"}}, res.Rows)
+	})
+
+	t.Run("rows should be returned as csv", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/query?"+url.Values{"sql": {"SELECT item FROM orders"}}.Encode(), nil)
+		req.Header.Set("Accept", "text/csv")
+		req.Header.Set("X-API-Key", "all")
+
+		rec := send(req, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		require.Equal(t, "item\nbook\npen\n", rec.Body.String())
+	})
+
+	t.Run("only tables in scope should be read", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, get(url.Values{"sql": {"SELECT name FROM customers"}}, "all").Code)
+		require.Equal(t, http.StatusForbidden, get(url.Values{"sql": {"SELECT name FROM customers"}}, "orders").Code)
+		require.Equal(t, http.StatusForbidden, get(url.Values{"sql": {"SELECT o.id FROM orders o INNER JOIN customers c ON o.id = c.id"}}, "orders").Code)
+		require.Equal(t, http.StatusForbidden, get(url.Values{"db": {"otherdb"}, "sql": {"SELECT * FROM orders"}}, "orders").Code)
+	})
+
+	t.Run("only queries should be accepted", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, get(url.Values{"sql": {"DELETE FROM orders"}}, "all").Code)
+		require.Equal(t, http.StatusBadRequest, get(url.Values{"sql": {"SELECT * FROM orders; DELETE FROM orders"}}, "all").Code)
+		require.Equal(t, http.StatusBadRequest, get(url.Values{"sql": {""}}, "all").Code)
+
+		res, err := db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT COUNT(*) FROM orders"}, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), res.Rows[0].Values[0].GetN())
+	})
+}
+
+func TestParseQueryAPIKeys(t *testing.T) {
+	keys, err := ParseQueryAPIKeys("k1=defaultdb, k2=sales.orders;sales.customers")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		"k1": {"defaultdb"},
+		"k2": {"sales.orders", "sales.customers"},
+	}, keys)
+
+	scope := newQueryScope(keys["k2"])
+	require.True(t, scope.allowsDatabase("sales"))
+	require.False(t, scope.allowsDatabase("defaultdb"))
+	require.True(t, scope.allowsTable("sales", "orders"))
+	require.False(t, scope.allowsTable("sales", "products"))
+
+	keys, err = ParseQueryAPIKeys("")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	_, err = ParseQueryAPIKeys("k1")
+	require.ErrorIs(t, err, ErrInvalidQueryAPIKeys)
+
+	_, err = ParseQueryAPIKeys("k1=")
+	require.ErrorIs(t, err, ErrInvalidQueryAPIKeys)
+
+	_, err = ParseQueryAPIKeys("k1=a.b.c")
+	require.ErrorIs(t, err, ErrInvalidQueryAPIKeys)
+}
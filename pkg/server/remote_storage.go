@@ -11,6 +11,7 @@ import (
 	"github.com/codenotary/immudb/embedded/appendable"
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
 	"github.com/codenotary/immudb/embedded/appendable/remoteapp"
+	elogger "github.com/codenotary/immudb/embedded/logger"
 	"github.com/codenotary/immudb/embedded/remotestorage"
 	"github.com/codenotary/immudb/embedded/remotestorage/s3"
 	"github.com/codenotary/immudb/embedded/store"
@@ -116,6 +117,7 @@ func (s *ImmuServer) storeOptionsForDB(name string, remoteStorage remotestorage.
 
 			remoteAppOpts := remoteapp.DefaultOptions()
 			remoteAppOpts.Options = *opts
+			remoteAppOpts.WithLogger(elogger.NewPrintfLogger(s.Logger))
 
 			fsPath, err := filepath.Abs(filepath.Join(rootPath, subPath))
 			if err != nil {
@@ -0,0 +1,402 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var ErrMalformedRemoteWriteRequest = errors.New("malformed remote-write request")
+
+// RemoteWriteTable is the table holding the samples received through Prometheus remote-write.
+// Rows are keyed by day, series and timestamp, so reading a time range of a series only scans
+// the days involved
+const RemoteWriteTable = "prom_samples"
+
+const remoteWriteTableDDL = `CREATE TABLE IF NOT EXISTS ` + RemoteWriteTable + ` (
+	day INTEGER,
+	series VARCHAR[64],
+	ts INTEGER,
+	metric VARCHAR,
+	labels VARCHAR,
+	value VARCHAR,
+	PRIMARY KEY (day, series, ts)
+);`
+
+// remoteWriteBatchSize is the max number of samples upserted by a single transaction
+const remoteWriteBatchSize = 512
+
+const msPerDay = 24 * 60 * 60 * 1000
+
+type promSample struct {
+	value float64
+	ts    int64
+}
+
+type promSeries struct {
+	labels  map[string]string
+	samples []promSample
+}
+
+// StartRemoteWriteServer serves Prometheus remote-write requests at /api/v1/write,
+// storing the received samples into the remote-write table of the database
+func StartRemoteWriteServer(addr string, tlsConfig *tls.Config, s *ImmuServer, l logger.Logger) (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/write", &remoteWriteHandler{s: s})
+
+	httpServer := &http.Server{Addr: addr, Handler: mux}
+	httpServer.TLSConfig = tlsConfig
+
+	go func() {
+		var err error
+		if tlsConfig != nil && len(tlsConfig.Certificates) > 0 {
+			l.Infof("Prometheus remote-write server enabled on %s/api/v1/write (https)", addr)
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			l.Infof("Prometheus remote-write server enabled on %s/api/v1/write (http)", addr)
+			err = httpServer.ListenAndServe()
+		}
+
+		if err == http.ErrServerClosed {
+			l.Debugf("Prometheus remote-write server closed")
+		} else {
+			l.Errorf("Prometheus remote-write server error: %s", err)
+		}
+	}()
+
+	return httpServer, nil
+}
+
+type remoteWriteHandler struct {
+	s *ImmuServer
+}
+
+func (h *remoteWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.s.Options.GetMaintenance() {
+		http.Error(w, ErrNotAllowedInMaintenanceMode.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	dbName := h.s.Options.PromRemoteWriteDatabase
+
+	var username string
+
+	if h.s.Options.GetAuth() {
+		var password string
+		var ok bool
+
+		username, password, ok = r.BasicAuth()
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="immudb"`)
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+
+		user, err := h.s.getValidatedUser([]byte(username), []byte(password))
+		if err != nil || !user.Active {
+			http.Error(w, "invalid user name or password", http.StatusUnauthorized)
+			return
+		}
+		if user.Username == auth.SysAdminUsername {
+			user.IsSysAdmin = true
+		}
+
+		permission := user.WhichPermission(dbName)
+		if permission != auth.PermissionSysAdmin && permission != auth.PermissionAdmin && permission != auth.PermissionRW {
+			http.Error(w, "not enough permissions to write into the database", http.StatusForbidden)
+			return
+		}
+	}
+
+	compressed, err := ioutil.ReadAll(io.LimitReader(r.Body, int64(h.s.Options.MaxRecvMsgSize)+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(compressed) > h.s.Options.MaxRecvMsgSize {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	payload, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("%s: %v", ErrMalformedRemoteWriteRequest, err), http.StatusBadRequest)
+		return
+	}
+
+	series, err := decodeRemoteWriteRequest(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	db, err := h.s.dbList.GetByName(dbName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	err = storeRemoteWriteSeries(h.s.withWriteValidation(db, username), series)
+	if err != nil {
+		h.s.Logger.Errorf("prometheus remote-write into '%s' failed: %v", dbName, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// storeRemoteWriteSeries upserts the samples into the remote-write table, which gets created if missing.
+// Samples are loaded in batches, each one committed as a single transaction
+func storeRemoteWriteSeries(db database.DB, series []*promSeries) error {
+	var stmts strings.Builder
+	var params []*schema.NamedParam
+
+	stmts.WriteString(remoteWriteTableDDL)
+
+	pending := 0
+
+	flush := func() error {
+		if pending == 0 {
+			return nil
+		}
+
+		stmts.WriteString(";")
+
+		_, err := db.SQLExecScript(&schema.SQLExecRequest{Sql: stmts.String(), Params: params})
+		if err != nil {
+			return err
+		}
+
+		stmts.Reset()
+		params = nil
+		pending = 0
+
+		return nil
+	}
+
+	for _, s := range series {
+		labels := canonicalLabels(s.labels)
+		digest := sha256.Sum256([]byte(labels))
+		seriesID := hex.EncodeToString(digest[:])
+
+		for _, sample := range s.samples {
+			if pending == 0 {
+				fmt.Fprintf(&stmts, "UPSERT INTO %s (day, series, ts, metric, labels, value) VALUES ", RemoteWriteTable)
+			} else {
+				stmts.WriteString(", ")
+			}
+
+			p := len(params)
+			fmt.Fprintf(&stmts, "(@p%d, @p%d, @p%d, @p%d, @p%d, @p%d)", p, p+1, p+2, p+3, p+4, p+5)
+
+			params = append(params,
+				&schema.NamedParam{Name: fmt.Sprintf("p%d", p), Value: &schema.SQLValue{Value: &schema.SQLValue_N{N: sampleDay(sample.ts)}}},
+				&schema.NamedParam{Name: fmt.Sprintf("p%d", p+1), Value: &schema.SQLValue{Value: &schema.SQLValue_S{S: seriesID}}},
+				&schema.NamedParam{Name: fmt.Sprintf("p%d", p+2), Value: &schema.SQLValue{Value: &schema.SQLValue_N{N: sample.ts}}},
+				&schema.NamedParam{Name: fmt.Sprintf("p%d", p+3), Value: &schema.SQLValue{Value: &schema.SQLValue_S{S: s.labels["__name__"]}}},
+				&schema.NamedParam{Name: fmt.Sprintf("p%d", p+4), Value: &schema.SQLValue{Value: &schema.SQLValue_S{S: labels}}},
+				&schema.NamedParam{Name: fmt.Sprintf("p%d", p+5), Value: &schema.SQLValue{Value: &schema.SQLValue_S{S: strconv.FormatFloat(sample.value, 'g', -1, 64)}}},
+			)
+
+			pending++
+
+			if pending == remoteWriteBatchSize {
+				err := flush()
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return flush()
+}
+
+// sampleDay returns the partition of the sample, as the number of days since the unix epoch
+func sampleDay(tsMs int64) int64 {
+	d := tsMs / msPerDay
+	if tsMs < 0 && tsMs%msPerDay != 0 {
+		d--
+	}
+	return d
+}
+
+// canonicalLabels renders the labels sorted by name as in the Prometheus exposition format, e.g. {job="node",le="0.5"}
+func canonicalLabels(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+
+	b.WriteString("{")
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(name)
+		b.WriteString("=")
+		b.WriteString(strconv.Quote(labels[name]))
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
+
+// decodeRemoteWriteRequest decodes the Prometheus WriteRequest message. Only time series
+// are decoded, metadata and exemplars are skipped:
+//
+//	WriteRequest { repeated TimeSeries timeseries = 1; }
+//	TimeSeries   { repeated Label labels = 1; repeated Sample samples = 2; }
+//	Label        { string name = 1; string value = 2; }
+//	Sample       { double value = 1; int64 timestamp = 2; }
+func decodeRemoteWriteRequest(b []byte) ([]*promSeries, error) {
+	var series []*promSeries
+
+	err := decodeMessage(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if num != 1 || typ != protowire.BytesType {
+			return nil
+		}
+
+		s, err := decodeTimeSeries(v)
+		if err != nil {
+			return err
+		}
+
+		series = append(series, s)
+		return nil
+	})
+
+	return series, err
+}
+
+func decodeTimeSeries(b []byte) (*promSeries, error) {
+	s := &promSeries{labels: make(map[string]string)}
+
+	err := decodeMessage(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+
+		switch num {
+		case 1:
+			var name, value string
+
+			err := decodeMessage(v, func(num protowire.Number, typ protowire.Type, v []byte) error {
+				if typ == protowire.BytesType && num == 1 {
+					name = string(v)
+				}
+				if typ == protowire.BytesType && num == 2 {
+					value = string(v)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			s.labels[name] = value
+		case 2:
+			var sample promSample
+
+			err := decodeMessage(v, func(num protowire.Number, typ protowire.Type, v []byte) error {
+				if num == 1 && typ == protowire.Fixed64Type {
+					bits, _ := protowire.ConsumeFixed64(v)
+					sample.value = math.Float64frombits(bits)
+				}
+				if num == 2 && typ == protowire.VarintType {
+					n, _ := protowire.ConsumeVarint(v)
+					sample.ts = int64(n)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			s.samples = append(s.samples, sample)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.labels["__name__"] == "" {
+		return nil, fmt.Errorf("%w: time series without metric name", ErrMalformedRemoteWriteRequest)
+	}
+
+	return s, nil
+}
+
+// decodeMessage calls fn with each field of the message, values of length-delimited fields
+// are passed without their length prefix while the rest are passed as encoded
+func decodeMessage(b []byte, fn func(num protowire.Number, typ protowire.Type, v []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return ErrMalformedRemoteWriteRequest
+		}
+		b = b[n:]
+
+		m := protowire.ConsumeFieldValue(num, typ, b)
+		if m < 0 {
+			return ErrMalformedRemoteWriteRequest
+		}
+
+		v := b[:m]
+		if typ == protowire.BytesType {
+			v, _ = protowire.ConsumeBytes(v)
+		}
+
+		err := fn(num, typ, v)
+		if err != nil {
+			return err
+		}
+
+		b = b[m:]
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func encodeWriteRequest(series []*promSeries) []byte {
+	var req []byte
+
+	for _, s := range series {
+		var ts []byte
+
+		for name, value := range s.labels {
+			var label []byte
+			label = protowire.AppendTag(label, 1, protowire.BytesType)
+			label = protowire.AppendString(label, name)
+			label = protowire.AppendTag(label, 2, protowire.BytesType)
+			label = protowire.AppendString(label, value)
+
+			ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, label)
+		}
+
+		for _, sample := range s.samples {
+			var smp []byte
+			smp = protowire.AppendTag(smp, 1, protowire.Fixed64Type)
+			smp = protowire.AppendFixed64(smp, math.Float64bits(sample.value))
+			smp = protowire.AppendTag(smp, 2, protowire.VarintType)
+			smp = protowire.AppendVarint(smp, uint64(sample.ts))
+
+			ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, smp)
+		}
+
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendBytes(req, ts)
+	}
+
+	return snappy.Encode(nil, req)
+}
+
+func TestRemoteWrite(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithDir("remote_write_data").
+		WithPort(0).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	handler := &remoteWriteHandler{s: s}
+
+	send := func(method string, body []byte, user, password string) int {
+		req := httptest.NewRequest(method, "/api/v1/write", bytes.NewReader(body))
+		if user != "" {
+			req.SetBasicAuth(user, password)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	series := []*promSeries{
+		{
+			labels: map[string]string{"__name__": "up", "job": "node"},
+			samples: []promSample{
+				{value: 1, ts: 1000},
+				{value: 0, ts: msPerDay + 1000},
+			},
+		},
+		{
+			labels:  map[string]string{"__name__": "temperature", "room": "kitchen"},
+			samples: []promSample{{value: 21.5, ts: 2000}},
+		},
+	}
+
+	body := encodeWriteRequest(series)
+
+	require.Equal(t, http.StatusMethodNotAllowed, send(http.MethodGet, nil, "", ""))
+	require.Equal(t, http.StatusUnauthorized, send(http.MethodPost, body, "", ""))
+	require.Equal(t, http.StatusUnauthorized, send(http.MethodPost, body, auth.SysAdminUsername, "wrong"))
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPost, []byte("not snappy"), auth.SysAdminUsername, auth.SysAdminPassword))
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPost, encodeWriteRequest([]*promSeries{{labels: map[string]string{"job": "node"}}}), auth.SysAdminUsername, auth.SysAdminPassword))
+
+	require.Equal(t, http.StatusNoContent, send(http.MethodPost, body, auth.SysAdminUsername, auth.SysAdminPassword))
+
+	// samples already received are replaced
+	require.Equal(t, http.StatusNoContent, send(http.MethodPost, body, auth.SysAdminUsername, auth.SysAdminPassword))
+
+	db, err := s.dbList.GetByName(DefaultDBName)
+	require.NoError(t, err)
+
+	res, err := db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT day, ts, metric, labels, value FROM " + RemoteWriteTable + " WHERE day = 0 ORDER BY day"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+
+	res, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT ts, labels, value FROM " + RemoteWriteTable + " WHERE metric = 'up' AND day = 1"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	require.Equal(t, int64(msPerDay+1000), res.Rows[0].Values[0].GetN())
+	require.Equal(t, `{__name__="up",job="node"}`, res.Rows[0].Values[1].GetS())
+	require.Equal(t, "0", res.Rows[0].Values[2].GetS())
+}
+
+func TestRemoteWriteBatches(t *testing.T) {
+	options := database.DefaultOption().WithDBRootPath("remote_write_data").WithDBName("db")
+	db, err := database.NewDB(options, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer os.RemoveAll(options.GetDBRootPath())
+	defer db.Close()
+
+	samples := make([]promSample, 2*remoteWriteBatchSize+1)
+	for i := range samples {
+		samples[i] = promSample{value: float64(i), ts: int64(i)}
+	}
+
+	err = storeRemoteWriteSeries(db, []*promSeries{{labels: map[string]string{"__name__": "counter"}, samples: samples}})
+	require.NoError(t, err)
+
+	res, err := db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT COUNT(*) FROM " + RemoteWriteTable}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(samples)), res.Rows[0].Values[0].GetN())
+}
+
+func TestSampleDay(t *testing.T) {
+	require.Equal(t, int64(0), sampleDay(0))
+	require.Equal(t, int64(0), sampleDay(msPerDay-1))
+	require.Equal(t, int64(1), sampleDay(msPerDay))
+	require.Equal(t, int64(-1), sampleDay(-1))
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestServerRestoreDatabaseAt(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	s.Initialize()
+
+	ctx := context.Background()
+
+	_, err := s.RestoreDatabaseAt(ctx, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = s.RestoreDatabaseAt(ctx, &schema.RestoreDatabaseAtRequest{SourceDatabase: DefaultDBName, TargetDatabase: "restored", TxId: 1})
+	require.Error(t, err)
+
+	r := &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	}
+
+	lr, err := s.Login(ctx, r)
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx = metadata.NewIncomingContext(context.Background(), md)
+
+	hdr1, err := s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	_, err = s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value2")}}})
+	require.NoError(t, err)
+
+	_, err = s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key2"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	_, err = s.RestoreDatabaseAt(ctx, &schema.RestoreDatabaseAtRequest{SourceDatabase: SystemDBName, TargetDatabase: "restored", TxId: hdr1.Id})
+	require.Equal(t, ErrReservedDatabase, err)
+
+	_, err = s.RestoreDatabaseAt(ctx, &schema.RestoreDatabaseAtRequest{SourceDatabase: DefaultDBName, TargetDatabase: "Restored", TxId: hdr1.Id})
+	require.Error(t, err)
+
+	_, err = s.RestoreDatabaseAt(ctx, &schema.RestoreDatabaseAtRequest{SourceDatabase: DefaultDBName, TargetDatabase: DefaultDBName, TxId: hdr1.Id})
+	require.Error(t, err)
+
+	_, err = s.RestoreDatabaseAt(ctx, &schema.RestoreDatabaseAtRequest{SourceDatabase: "nodb", TargetDatabase: "restored", TxId: hdr1.Id})
+	require.Error(t, err)
+
+	_, err = s.RestoreDatabaseAt(ctx, &schema.RestoreDatabaseAtRequest{SourceDatabase: DefaultDBName, TargetDatabase: "restored", TxId: hdr1.Id + 100})
+	require.Error(t, err)
+
+	_, err = os.Stat(filepath.Join(s.Options.Dir, "restored"))
+	require.True(t, os.IsNotExist(err))
+
+	_, err = s.RestoreDatabaseAt(ctx, &schema.RestoreDatabaseAtRequest{SourceDatabase: DefaultDBName, TargetDatabase: "restored", TxId: hdr1.Id})
+	require.NoError(t, err)
+
+	ur, err := s.UseDatabase(ctx, &schema.Database{DatabaseName: "restored"})
+	require.NoError(t, err)
+
+	md = metadata.Pairs("authorization", ur.Token)
+	ctx = metadata.NewIncomingContext(context.Background(), md)
+
+	entry, err := s.Get(ctx, &schema.KeyRequest{Key: []byte("key1")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+
+	_, err = s.Get(ctx, &schema.KeyRequest{Key: []byte("key2")})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), store.ErrKeyNotFound.Error())
+
+	dbOpts, err := s.loadDBOptions("restored", false)
+	require.NoError(t, err)
+	require.False(t, dbOpts.Replica)
+}
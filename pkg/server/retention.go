@@ -0,0 +1,135 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/database"
+)
+
+// startRetention periodically truncates the databases whose retention period is set
+// until the server is stopped
+func (s *ImmuServer) startRetention() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	s.retentionDone = done
+	s.retentionStopped = stopped
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(s.Options.RetentionCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			s.enforceRetention()
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	s.Logger.Infof("Retention started {check interval = %s}", s.Options.RetentionCheckInterval)
+}
+
+// stopRetention waits for an ongoing truncation to complete, so that databases are not closed meanwhile
+func (s *ImmuServer) stopRetention() {
+	if s.retentionDone == nil {
+		return
+	}
+
+	close(s.retentionDone)
+	<-s.retentionStopped
+
+	s.retentionDone = nil
+	s.retentionStopped = nil
+}
+
+// enforceRetention truncates the values of the transactions older than the retention period of each database.
+// In dry-run mode what would be removed is only reported
+func (s *ImmuServer) enforceRetention() {
+	for i := 0; i < s.dbList.Length(); i++ {
+		db := s.dbList.GetByIndex(int64(i))
+		if db == nil || db.IsReplica() {
+			continue
+		}
+
+		dbOpts, err := s.loadDBOptions(db.GetName(), false)
+		if err == store.ErrKeyNotFound {
+			// databases created before settings were stored keep all their values
+			continue
+		}
+		if err != nil {
+			s.Logger.Errorf("Error loading settings of database '%s': %v", db.GetName(), err)
+			continue
+		}
+
+		if dbOpts.RetentionPeriod <= 0 {
+			continue
+		}
+
+		s.truncate(db, time.Now().Add(-time.Duration(dbOpts.RetentionPeriod)*time.Millisecond), dbOpts.RetentionDryRun)
+	}
+}
+
+// truncate truncates the database as its retention period requires. Truncations are always audited,
+// whatever the audit log options are, as they remove data
+func (s *ImmuServer) truncate(db database.DB, before time.Time, dryRun bool) {
+	start := time.Now()
+
+	report, err := db.Truncate(before, dryRun)
+
+	if dryRun {
+		if err != nil {
+			s.Logger.Errorf("Error checking the retention of database '%s': %v", db.GetName(), err)
+			return
+		}
+
+		s.Logger.Infof("Retention of database '%s' would truncate values before tx %d, %d bytes would be discarded (dry-run)",
+			db.GetName(), report.MinTxID, report.DiscardedBytes)
+		return
+	}
+
+	if err == nil && report.DiscardedBytes == 0 {
+		return
+	}
+
+	rec := &auditRecord{
+		Time:       start.UTC().Format(time.RFC3339Nano),
+		Method:     "Truncate",
+		Database:   db.GetName(),
+		DurationMs: float64(time.Since(start)) / float64(time.Millisecond),
+	}
+
+	if report != nil {
+		rec.Request = report
+	}
+
+	if err != nil {
+		rec.Error = err.Error()
+
+		s.Logger.Errorf("Error truncating database '%s': %v", db.GetName(), err)
+	}
+
+	s.logAuditRecord(rec)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestServerRetention(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithDir(t.TempDir()).
+		WithPort(0).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithRetentionCheckInterval(0)
+
+	var logs bytes.Buffer
+
+	s := DefaultServer().WithOptions(serverOptions).WithLogger(logger.NewSimpleLoggerWithLevel("immudb", &logs, logger.LogInfo)).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.CreateDatabaseWith(ctx, &schema.DatabaseSettings{
+		DatabaseName:    "db1",
+		FileSize:        256,
+		RetentionPeriod: uint64(time.Second.Milliseconds()),
+		RetentionDryRun: true,
+	})
+	require.NoError(t, err)
+
+	settings, err := s.loadDBOptions("db1", false)
+	require.NoError(t, err)
+	require.Equal(t, time.Second.Milliseconds(), settings.RetentionPeriod)
+	require.True(t, settings.RetentionDryRun)
+	require.Equal(t, uint64(time.Second.Milliseconds()), settings.databaseSettings().RetentionPeriod)
+
+	db, err := s.dbList.GetByName("db1")
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		// all but the last transaction are older than the retention period
+		if i == 9 {
+			time.Sleep(2 * time.Second)
+		}
+
+		_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{
+			{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte("a value long enough to fill the chunks of the value log")},
+		}})
+		require.NoError(t, err)
+	}
+
+	audited := func() []map[string]interface{} {
+		var recs []map[string]interface{}
+
+		for _, line := range strings.Split(logs.String(), "\n") {
+			i := strings.Index(line, "audit: ")
+			if i < 0 {
+				continue
+			}
+
+			var rec map[string]interface{}
+			err := json.Unmarshal([]byte(line[i+len("audit: "):]), &rec)
+			require.NoError(t, err)
+
+			recs = append(recs, rec)
+		}
+
+		return recs
+	}
+
+	t.Run("dry-run should only report what would be removed", func(t *testing.T) {
+		logs.Reset()
+
+		s.enforceRetention()
+
+		require.Contains(t, logs.String(), "Retention of database 'db1' would truncate values before tx 11")
+		require.NotContains(t, logs.String(), " 0 bytes would be discarded")
+		require.Empty(t, audited())
+
+		_, err = db.Get(&schema.KeyRequest{Key: []byte("key0")})
+		require.NoError(t, err)
+	})
+
+	t.Run("retention should not be set on replicas", func(t *testing.T) {
+		_, err = s.UpdateDatabase(ctx, &schema.DatabaseSettings{
+			DatabaseName:    "db1",
+			Replica:         true,
+			RetentionPeriod: uint64(time.Second.Milliseconds()),
+		})
+		require.ErrorIs(t, err, ErrIllegalArguments)
+	})
+
+	t.Run("retention should not be set when commit time is excluded", func(t *testing.T) {
+		_, err = s.UpdateDatabase(ctx, &schema.DatabaseSettings{
+			DatabaseName:      "db1",
+			ExcludeCommitTime: true,
+			RetentionPeriod:   uint64(time.Second.Milliseconds()),
+		})
+		require.ErrorIs(t, err, ErrIllegalArguments)
+	})
+
+	t.Run("truncations should be audited", func(t *testing.T) {
+		_, err = s.UpdateDatabase(ctx, &schema.DatabaseSettings{
+			DatabaseName:    "db1",
+			RetentionPeriod: uint64(time.Second.Milliseconds()),
+		})
+		require.NoError(t, err)
+
+		logs.Reset()
+
+		s.enforceRetention()
+
+		recs := audited()
+		require.Len(t, recs, 1)
+		require.Equal(t, "Truncate", recs[0]["method"])
+		require.Equal(t, "db1", recs[0]["database"])
+		require.NotContains(t, recs[0], "error")
+
+		report := recs[0]["request"].(map[string]interface{})
+		require.Equal(t, false, report["dryRun"])
+		require.Positive(t, report["discardedBytes"])
+
+		_, err = db.Get(&schema.KeyRequest{Key: []byte("key0")})
+		require.ErrorIs(t, err, store.ErrExpiredEntry)
+
+		_, err = db.Get(&schema.KeyRequest{Key: []byte("key9")})
+		require.NoError(t, err)
+	})
+
+	t.Run("truncations removing nothing should not be audited", func(t *testing.T) {
+		logs.Reset()
+
+		s.enforceRetention()
+
+		require.Empty(t, audited())
+	})
+
+	t.Run("stopping the retention should wait for the ongoing truncation", func(t *testing.T) {
+		s.Options.RetentionCheckInterval = time.Hour
+
+		s.startRetention()
+
+		stopped := s.retentionStopped
+
+		s.stopRetention()
+
+		select {
+		case <-stopped:
+		default:
+			require.Fail(t, "retention still running")
+		}
+
+		require.Nil(t, s.retentionDone)
+	})
+}
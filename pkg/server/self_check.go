@@ -0,0 +1,171 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/codenotary/immudb/pkg/signer"
+)
+
+// SelfCheckReportKey is the key self-check reports are written under, previous reports are kept in its history
+const SelfCheckReportKey = "immudb.selfcheck.report"
+
+// SelfCheckReport summarizes the verification of the transactions of every database done on startup.
+// The report is signed when the server has a signing key
+type SelfCheckReport struct {
+	ServerUUID string               `json:"serverUUID"`
+	Ts         int64                `json:"ts"`
+	Full       bool                 `json:"full"`
+	Databases  []*DatabaseSelfCheck `json:"databases"`
+	Signature  []byte               `json:"signature,omitempty"`
+	PublicKey  []byte               `json:"publicKey,omitempty"`
+}
+
+// DatabaseSelfCheck holds the range of transactions verified in a database and the failure found, if any
+type DatabaseSelfCheck struct {
+	Database string `json:"database"`
+	FromTx   uint64 `json:"fromTx"`
+	ToTx     uint64 `json:"toTx"`
+	TxHash   []byte `json:"txHash,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Payload returns the bytes the signature of the report is calculated on
+func (r *SelfCheckReport) Payload() ([]byte, error) {
+	unsigned := *r
+	unsigned.Signature = nil
+	unsigned.PublicKey = nil
+
+	return json.Marshal(&unsigned)
+}
+
+func (s *ImmuServer) selfCheckEnabled() bool {
+	return s.Options.SelfCheckFull || s.Options.SelfCheckTxs > 0
+}
+
+// selfCheck verifies the last transactions of every database, or all of them in full mode, so data degraded
+// at rest is early detected. The report is written into the self-check database even when the check fails
+func (s *ImmuServer) selfCheck(reportSigner signer.Signer) error {
+	report := &SelfCheckReport{
+		ServerUUID: s.UUID.String(),
+		Ts:         time.Now().Unix(),
+		Full:       s.Options.SelfCheckFull,
+	}
+
+	dbs := []database.DB{s.sysDB}
+	for i := 0; i < s.dbList.Length(); i++ {
+		dbs = append(dbs, s.dbList.GetByIndex(int64(i)))
+	}
+
+	failed := false
+
+	for _, db := range dbs {
+		check := s.selfCheckDB(db)
+
+		if check.Error != "" {
+			s.Logger.Errorf("Self-check of database '%s' failed: %s", check.Database, check.Error)
+			failed = true
+		} else {
+			s.Logger.Infof("Self-check of database '%s' succeeded, txs verified: %d", check.Database, check.ToTx-check.FromTx+1)
+		}
+
+		report.Databases = append(report.Databases, check)
+	}
+
+	if reportSigner != nil {
+		payload, err := report.Payload()
+		if err != nil {
+			return err
+		}
+
+		report.Signature, report.PublicKey, err = reportSigner.Sign(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := s.writeSelfCheckReport(report)
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return ErrSelfCheckFailed
+	}
+
+	return nil
+}
+
+func (s *ImmuServer) selfCheckDB(db database.DB) *DatabaseSelfCheck {
+	check := &DatabaseSelfCheck{Database: db.GetName()}
+
+	state, err := db.CurrentState()
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+
+	if state.TxId == 0 {
+		return check
+	}
+
+	check.FromTx = 1
+	check.ToTx = state.TxId
+	check.TxHash = state.TxHash
+
+	if !s.Options.SelfCheckFull && state.TxId > uint64(s.Options.SelfCheckTxs) {
+		check.FromTx = state.TxId - uint64(s.Options.SelfCheckTxs) + 1
+	}
+
+	err = db.VerifyTxs(check.FromTx, check.ToTx)
+	if err != nil {
+		check.Error = err.Error()
+	}
+
+	return check
+}
+
+func (s *ImmuServer) writeSelfCheckReport(report *SelfCheckReport) error {
+	db := s.sysDB
+
+	if s.Options.SelfCheckDatabase != SystemDBName {
+		var err error
+
+		db, err = s.dbList.GetByName(s.Options.SelfCheckDatabase)
+		if err != nil {
+			return err
+		}
+	}
+
+	if db.IsReplica() {
+		s.Logger.Warningf("Self-check report not written, database '%s' is a replica", db.GetName())
+		return nil
+	}
+
+	value, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(SelfCheckReportKey), Value: value}}})
+
+	return err
+}
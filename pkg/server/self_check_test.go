@@ -0,0 +1,142 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerSelfCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithPort(0).
+		WithMetricsServer(false).
+		WithWebServer(false).
+		WithPgsqlServer(false).
+		WithSigningKey("./../../test/signer/ec1.key").
+		WithSelfCheckTxs(2)
+
+	initialize := func() (*ImmuServer, error) {
+		s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+		err := s.Initialize()
+		if s.Listener != nil {
+			s.Listener.Close()
+		}
+
+		return s, err
+	}
+
+	s, err := initialize()
+	require.NoError(t, err)
+
+	defaultDB := s.dbList.GetByIndex(defaultDbIndex)
+
+	for _, v := range []string{"value1", "value2", "immutable-value3"} {
+		_, err = defaultDB.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte(v)}}})
+		require.NoError(t, err)
+	}
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+
+	readReport := func(s *ImmuServer) *SelfCheckReport {
+		entry, err := s.sysDB.Get(&schema.KeyRequest{Key: []byte(SelfCheckReportKey)})
+		require.NoError(t, err)
+
+		var report SelfCheckReport
+		err = json.Unmarshal(entry.Value, &report)
+		require.NoError(t, err)
+
+		return &report
+	}
+
+	t.Run("the self-check should verify the last txs of each database", func(t *testing.T) {
+		s, err := initialize()
+		require.NoError(t, err)
+		defer s.CloseDatabases()
+
+		report := readReport(s)
+		require.Equal(t, s.UUID.String(), report.ServerUUID)
+		require.False(t, report.Full)
+		require.Len(t, report.Databases, 2)
+
+		check := report.Databases[1]
+		require.Equal(t, DefaultDBName, check.Database)
+		require.Equal(t, check.ToTx-1, check.FromTx)
+		require.Empty(t, check.Error)
+
+		payload, err := report.Payload()
+		require.NoError(t, err)
+
+		publicKey, err := signer.UnmarshalKey(report.PublicKey)
+		require.NoError(t, err)
+
+		ok, err := signer.Verify(payload, report.Signature, publicKey)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	// the last value written into the default database is degraded at rest
+	vLogFiles, err := filepath.Glob(filepath.Join(dir, DefaultDBName, "val_0", "*.val"))
+	require.NoError(t, err)
+	require.Len(t, vLogFiles, 1)
+
+	content, err := ioutil.ReadFile(vLogFiles[0])
+	require.NoError(t, err)
+
+	i := bytes.Index(content, []byte("immutable-value3"))
+	require.Greater(t, i, 0)
+	content[i] ^= 0xff
+
+	err = ioutil.WriteFile(vLogFiles[0], content, 0644)
+	require.NoError(t, err)
+
+	t.Run("the self-check should detect corrupted data", func(t *testing.T) {
+		s, err := initialize()
+		require.ErrorIs(t, err, ErrSelfCheckFailed)
+		defer s.CloseDatabases()
+
+		report := readReport(s)
+		require.Equal(t, DefaultDBName, report.Databases[1].Database)
+		require.Contains(t, report.Databases[1].Error, "corrupted")
+		require.Empty(t, report.Databases[0].Error)
+	})
+
+	t.Run("the full self-check should verify all txs", func(t *testing.T) {
+		serverOptions.WithSelfCheckTxs(0).WithSelfCheckFull(true)
+
+		s, err := initialize()
+		require.ErrorIs(t, err, ErrSelfCheckFailed)
+		defer s.CloseDatabases()
+
+		report := readReport(s)
+		require.True(t, report.Full)
+		require.Equal(t, uint64(1), report.Databases[0].FromTx)
+		require.Equal(t, uint64(1), report.Databases[1].FromTx)
+	})
+}
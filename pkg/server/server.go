@@ -33,6 +33,7 @@ import (
 
 	"github.com/codenotary/immudb/embedded/remotestorage"
 	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/embedded/tbtree"
 	"github.com/codenotary/immudb/pkg/errors"
 	"github.com/codenotary/immudb/pkg/replication"
 
@@ -87,6 +88,10 @@ func (s *ImmuServer) Initialize() error {
 		return ErrAuthMustBeDisabled
 	}
 
+	if err = validateAuditLogOptions(s.Options.AuditLogOptions); err != nil {
+		return logErr(s.Logger, "%v", err)
+	}
+
 	adminPassword, err := auth.DecodeBase64Password(s.Options.AdminPassword)
 	if err != nil {
 		return logErr(s.Logger, "%v", err)
@@ -114,6 +119,13 @@ func (s *ImmuServer) Initialize() error {
 		return logErr(s.Logger, "Unable to initialize remote storage: %v", err)
 	}
 
+	if s.Options.IndexBufferPoolSize > 0 {
+		s.indexBufferPool, err = tbtree.NewBufferPool(s.Options.IndexBufferPoolSize)
+		if err != nil {
+			return logErr(s.Logger, "Unable to create the index buffer pool: %v", err)
+		}
+	}
+
 	if err = s.loadSystemDatabase(dataDir, remoteStorage, adminPassword); err != nil {
 		return logErr(s.Logger, "Unable to load system database: %v", err)
 	}
@@ -142,16 +154,21 @@ func (s *ImmuServer) Initialize() error {
 		return ErrAuthMustBeEnabled
 	}
 
+	s.setUpCertificateRotation()
+
 	grpcSrvOpts := []grpc.ServerOption{}
 	if s.Options.TLSConfig != nil {
 		grpcSrvOpts = []grpc.ServerOption{grpc.Creds(credentials.NewTLS(s.Options.TLSConfig))}
 	}
 
+	var reportSigner signer.Signer
+
 	if s.Options.SigningKey != "" {
 		if signer, err := signer.NewSigner(s.Options.SigningKey); err != nil {
 			return logErr(s.Logger, "Unable to configure the cryptographic signer: %v", err)
 		} else {
 			s.StateSigner = NewStateSigner(signer)
+			reportSigner = signer
 		}
 	}
 
@@ -176,6 +193,12 @@ func (s *ImmuServer) Initialize() error {
 		}
 	}
 
+	if s.selfCheckEnabled() {
+		if err = s.selfCheck(reportSigner); err != nil {
+			return logErr(s.Logger, "Unable to complete the self-check: %v", err)
+		}
+	}
+
 	auth.AuthEnabled = s.Options.GetAuth()
 	auth.DevMode = s.Options.DevMode
 	auth.UpdateMetrics = func(ctx context.Context) { Metrics.UpdateClientMetrics(ctx) }
@@ -205,16 +228,21 @@ func (s *ImmuServer) Initialize() error {
 	uis := []grpc.UnaryServerInterceptor{
 		ErrorMapper, // converts errors in gRPC ones. Need to be the first
 		s.KeepAliveSessionInterceptor,
+		ServerTimeSetter,
 		uuidContext.UUIDContextSetter,
 		grpc_prometheus.UnaryServerInterceptor,
+		s.AuditLogInterceptor, // before authentication, so rejected requests get audited
 		auth.ServerUnaryInterceptor,
 		s.SessionAuthInterceptor,
+		s.IdempotencyInterceptor, // once the session is validated, as keys are scoped by session
 	}
 	sss := []grpc.StreamServerInterceptor{
 		ErrorMapperStream, // converts errors in gRPC ones. Need to be the first
 		s.KeepALiveSessionStreamInterceptor,
+		ServerTimeStreamSetter,
 		uuidContext.UUIDStreamContextSetter,
 		grpc_prometheus.StreamServerInterceptor,
+		s.AuditLogStreamInterceptor,
 		auth.ServerStreamInterceptor,
 	}
 	grpcSrvOpts = append(
@@ -233,7 +261,7 @@ func (s *ImmuServer) Initialize() error {
 		return err
 	}
 
-	s.PgsqlSrv = pgsqlsrv.New(pgsqlsrv.Address(s.Options.Address), pgsqlsrv.Port(s.Options.PgsqlServerPort), pgsqlsrv.DatabaseList(s.dbList), pgsqlsrv.SysDb(s.sysDB), pgsqlsrv.TlsConfig(s.Options.TLSConfig), pgsqlsrv.Logger(s.Logger))
+	s.PgsqlSrv = pgsqlsrv.New(pgsqlsrv.Address(s.Options.Address), pgsqlsrv.Port(s.Options.PgsqlServerPort), pgsqlsrv.DatabaseList(s.dbList), pgsqlsrv.WrapDatabase(s.withWriteValidation), pgsqlsrv.SysDb(s.sysDB), pgsqlsrv.TlsConfig(s.Options.TLSConfig), pgsqlsrv.Logger(s.Logger))
 	if s.Options.PgsqlServer {
 		if err = s.PgsqlSrv.Initialize(); err != nil {
 			return err
@@ -299,6 +327,47 @@ func (s *ImmuServer) Start() (err error) {
 		}()
 	}
 
+	if s.Options.PromRemoteWriteServer {
+		if err := s.setUpRemoteWriteServer(); err != nil {
+			log.Fatal(fmt.Sprintf("Failed to setup Prometheus remote-write server: %v", err))
+		}
+		defer func() {
+			if err := s.remoteWriteServer.Close(); err != nil {
+				s.Logger.Errorf("Failed to shutdown Prometheus remote-write server: %s", err)
+			}
+		}()
+	}
+
+	if s.Options.QueryServer {
+		if err := s.setUpQueryServer(); err != nil {
+			log.Fatal(fmt.Sprintf("Failed to setup query server: %v", err))
+		}
+		defer func() {
+			if err := s.queryServer.Close(); err != nil {
+				s.Logger.Errorf("Failed to shutdown query server: %s", err)
+			}
+		}()
+	}
+
+	if s.Options.SyslogServer || s.Options.FluentForwardServer {
+		if err := s.setUpLogIngestServer(); err != nil {
+			log.Fatal(fmt.Sprintf("Failed to setup log ingestion server: %v", err))
+		}
+		defer func() {
+			if err := s.logIngestServer.Close(); err != nil {
+				s.Logger.Errorf("Failed to shutdown log ingestion server: %s", err)
+			}
+		}()
+	}
+
+	if s.diskSpaceWatchdogEnabled() {
+		s.startDiskSpaceWatchdog()
+	}
+
+	if s.Options.RetentionCheckInterval > 0 {
+		s.startRetention()
+	}
+
 	go s.printUsageCallToAction()
 
 	s.mux.Unlock()
@@ -326,6 +395,12 @@ func (s *ImmuServer) setupPidFile() error {
 }
 
 func (s *ImmuServer) setUpMetricsServer() error {
+	if s.indexBufferPool != nil {
+		Metrics.WithComputeIndexBufferPoolUsage(func() (int, int) {
+			return s.indexBufferPool.Size(), s.indexBufferPool.Resident()
+		})
+	}
+
 	s.metricsServer = StartMetrics(
 		1*time.Minute,
 		s.Options.MetricsBind(),
@@ -351,6 +426,49 @@ func (s *ImmuServer) setUpWebServer() error {
 	return nil
 }
 
+func (s *ImmuServer) setUpRemoteWriteServer() error {
+	server, err := StartRemoteWriteServer(
+		s.Options.PromRemoteWriteBind(),
+		s.Options.TLSConfig,
+		s,
+		s.Logger,
+	)
+	if err != nil {
+		return err
+	}
+	s.remoteWriteServer = server
+	return nil
+}
+
+func (s *ImmuServer) setUpQueryServer() error {
+	server, err := StartQueryServer(
+		s.Options.QueryServerBind(),
+		s.Options.TLSConfig,
+		s,
+		s.Logger,
+	)
+	if err != nil {
+		return err
+	}
+	s.queryServer = server
+	return nil
+}
+
+func (s *ImmuServer) setUpLogIngestServer() error {
+	server, err := StartLogIngestServer(
+		s.Options.SyslogBind(),
+		s.Options.FluentForwardBind(),
+		s.Options.LogIngestDatabase,
+		s,
+		s.Logger,
+	)
+	if err != nil {
+		return err
+	}
+	s.logIngestServer = server
+	return nil
+}
+
 func (s *ImmuServer) printUsageCallToAction() {
 	time.Sleep(200 * time.Millisecond)
 	immuadminCLI := helper.Blue + "immuadmin" + helper.Green
@@ -416,7 +534,7 @@ func (s *ImmuServer) loadSystemDatabase(dataDir string, remoteStorage remotestor
 
 	//sys admin can have an empty array of databases as it has full access
 	if !s.sysDB.IsReplica() {
-		adminUsername, _, err := s.insertNewUser([]byte(auth.SysAdminUsername), []byte(adminPassword), auth.PermissionSysAdmin, "*", false, "")
+		adminUsername, _, err := s.insertNewUser([]byte(auth.SysAdminUsername), []byte(adminPassword), auth.PermissionSysAdmin, "*", "", false, "")
 		if err != nil {
 			return logErr(s.Logger, "%v", err)
 		}
@@ -633,6 +751,10 @@ func (s *ImmuServer) Stop() error {
 
 	s.SessManager.StopSessionsGuard()
 
+	s.stopDiskSpaceWatchdog()
+
+	s.stopRetention()
+
 	s.stopReplication()
 
 	return s.CloseDatabases()
@@ -706,6 +828,21 @@ func (s *ImmuServer) Health(ctx context.Context, _ *empty.Empty) (*schema.Health
 	return &schema.HealthResponse{Status: true, Version: fmt.Sprintf("%s", Version.Version)}, nil
 }
 
+// ServerInfo returns the version of the server and the features it supports
+func (s *ImmuServer) ServerInfo(ctx context.Context, _ *empty.Empty) (*schema.ServerInfoResponse, error) {
+	return &schema.ServerInfoResponse{
+		Version: Version.Version,
+		Capabilities: &schema.ServerCapabilities{
+			Sql:             true,
+			Sessions:        true,
+			Streaming:       true,
+			Replication:     true,
+			StreamChunkSize: int32(s.Options.StreamChunkSize),
+			MaxRecvMsgSize:  int32(s.Options.MaxRecvMsgSize),
+		},
+	}, nil
+}
+
 func (s *ImmuServer) installShutdownHandler() {
 	c := make(chan os.Signal)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -802,6 +939,88 @@ func (s *ImmuServer) CreateDatabaseWith(ctx context.Context, req *schema.Databas
 	return &empty.Empty{}, nil
 }
 
+// RestoreDatabaseAt creates a new database holding the state of the source database as of the specified tx
+func (s *ImmuServer) RestoreDatabaseAt(ctx context.Context, req *schema.RestoreDatabaseAtRequest) (*empty.Empty, error) {
+	s.Logger.Debugf("restoredatabaseat")
+
+	if req == nil || req.TxId == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	if s.Options.GetMaintenance() {
+		return nil, ErrNotAllowedInMaintenanceMode
+	}
+
+	if !s.Options.GetAuth() {
+		return nil, ErrAuthMustBeEnabled
+	}
+
+	_, user, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get loggedin user data")
+	}
+
+	if !user.IsSysAdmin {
+		return nil, fmt.Errorf("logged In user does not have permissions for this operation")
+	}
+
+	if req.SourceDatabase == SystemDBName || req.TargetDatabase == SystemDBName {
+		return nil, ErrReservedDatabase
+	}
+
+	if strings.ToLower(req.TargetDatabase) != req.TargetDatabase {
+		return nil, fmt.Errorf("provide a lowercase database name")
+	}
+
+	if err = isValidDBName(req.TargetDatabase); err != nil {
+		return nil, err
+	}
+
+	if s.dbList.GetId(req.TargetDatabase) >= 0 {
+		return nil, fmt.Errorf("database '%s' already exists", req.TargetDatabase)
+	}
+
+	srcDB, err := s.dbList.GetByName(req.SourceDatabase)
+	if err != nil {
+		return nil, err
+	}
+
+	dbOpts := s.defaultDBOptions(req.TargetDatabase)
+	dbOpts.Replica = false
+
+	db, err := database.NewDB(s.databaseOptionsFrom(dbOpts), s.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srcDB.CopyStateAt(req.TxId, db)
+	if err != nil {
+		s.Logger.Errorf("Error restoring database '%s' at tx %d into '%s'. Reason: %v", req.SourceDatabase, req.TxId, req.TargetDatabase, err)
+
+		if cerr := db.Close(); cerr != nil {
+			s.Logger.Errorf("Error closing database '%s'. Reason: %v", req.TargetDatabase, cerr)
+		}
+
+		if rerr := os.RemoveAll(filepath.Join(s.Options.Dir, req.TargetDatabase)); rerr != nil {
+			s.Logger.Errorf("Error removing database '%s'. Reason: %v", req.TargetDatabase, rerr)
+		}
+
+		return nil, err
+	}
+
+	err = s.saveDBOptions(dbOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dbList.Append(db)
+	s.multidbmode = true
+
+	s.Logger.Infof("Database '%s' restored from '%s' at tx %d", req.TargetDatabase, req.SourceDatabase, req.TxId)
+
+	return &empty.Empty{}, nil
+}
+
 // UpdateDatabase Updates database settings
 func (s *ImmuServer) UpdateDatabase(ctx context.Context, req *schema.DatabaseSettings) (*empty.Empty, error) {
 	s.Logger.Debugf("updatedatabase")
@@ -1003,9 +1222,23 @@ func (s *ImmuServer) UseDatabase(ctx context.Context, req *schema.Database) (*sc
 // getDBFromCtx checks if user (loggedin from context) has access to methodName.
 // returns selected database
 func (s *ImmuServer) getDBFromCtx(ctx context.Context, methodName string) (database.DB, error) {
+	db, err := s.resolveDBFromCtx(ctx, methodName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.waitForMinTx(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func (s *ImmuServer) resolveDBFromCtx(ctx context.Context, methodName string) (database.DB, error) {
 	//if auth is disabled and there is not user created databases returns defaultdb
 	if !s.Options.auth && !s.multidbmode && !s.Options.GetMaintenance() {
-		return s.dbList.GetByIndex(defaultDbIndex), nil
+		return s.withWriteValidation(s.dbList.GetByIndex(defaultDbIndex), ""), nil
 	}
 
 	if s.Options.GetMaintenance() && !auth.IsMaintenanceMethod(methodName) {
@@ -1040,6 +1273,8 @@ func (s *ImmuServer) getDBFromCtx(ctx context.Context, methodName string) (datab
 		db = s.dbList.GetByIndex(ind)
 	}
 
+	db = s.withWriteValidation(db, usr.Username)
+
 	if usr.IsSysAdmin {
 		return db, nil
 	}
@@ -1048,9 +1283,20 @@ func (s *ImmuServer) getDBFromCtx(ctx context.Context, methodName string) (datab
 		return nil, ErrPermissionDenied
 	}
 
+	if usr.Namespace != "" {
+		return newNamespacedDB(db, usr.Namespace), nil
+	}
+
 	return db, nil
 }
 
+func (s *ImmuServer) withWriteValidation(db database.DB, username string) database.DB {
+	if s.WriteValidator == nil {
+		return db
+	}
+	return newValidatedDB(db, s.WriteValidator, username)
+}
+
 // isValidDBName checks if the provided database name meets the requirements
 func isValidDBName(dbName string) error {
 	if len(dbName) < 1 || len(dbName) > 128 {
@@ -597,6 +597,10 @@ func testServerSetGetBatch(ctx context.Context, s *ImmuServer, t *testing.T) {
 	_, err = s.CompactIndex(ctx, &emptypb.Empty{})
 	require.ErrorIs(t, err, tbtree.ErrCompactionThresholdNotReached)
 
+	progress, err := s.IndexingProgress(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, ind.Id, progress.CommittedTxId)
+
 	_, err = s.GetAll(ctx, nil)
 	require.Equal(t, store.ErrIllegalArguments, err)
 
@@ -750,6 +754,16 @@ func testServerHealthError(ctx context.Context, s *ImmuServer, t *testing.T) {
 	}
 }
 
+func testServerInfo(ctx context.Context, s *ImmuServer, t *testing.T) {
+	info, err := s.ServerInfo(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, Version.Version, info.Version)
+	require.True(t, info.Capabilities.Sql)
+	require.True(t, info.Capabilities.Streaming)
+	require.Equal(t, int32(s.Options.StreamChunkSize), info.Capabilities.StreamChunkSize)
+	require.Equal(t, int32(s.Options.MaxRecvMsgSize), info.Capabilities.MaxRecvMsgSize)
+}
+
 func testServerReference(ctx context.Context, s *ImmuServer, t *testing.T) {
 	_, err := s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{kvs[0]}})
 	require.NoError(t, err)
@@ -1036,6 +1050,7 @@ func TestServerDbOperations(t *testing.T) {
 	testServerBySafeIndexError(ctx, s, t)
 	testServerHealth(ctx, s, t)
 	testServerHealthError(ctx, s, t)
+	testServerInfo(ctx, s, t)
 	testServerReference(ctx, s, t)
 	testServerReferenceError(ctx, s, t)
 	testServerZAdd(ctx, s, t)
@@ -1129,7 +1144,7 @@ func TestServerErrors(t *testing.T) {
 	adminCtx = metadata.NewIncomingContext(context.Background(), md)
 
 	// insertNewUser errors
-	_, _, err = s.insertNewUser([]byte("%"), nil, 1, DefaultDBName, true, auth.SysAdminUsername)
+	_, _, err = s.insertNewUser([]byte("%"), nil, 1, DefaultDBName, "", true, auth.SysAdminUsername)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "username can only contain letters, digits and underscores")
 
@@ -1137,7 +1152,7 @@ func TestServerErrors(t *testing.T) {
 	usernameBytes := []byte(username)
 	password := "$omePassword1"
 	passwordBytes := []byte(password)
-	_, _, err = s.insertNewUser(usernameBytes, []byte("a"), 1, DefaultDBName, true, auth.SysAdminUsername)
+	_, _, err = s.insertNewUser(usernameBytes, []byte("a"), 1, DefaultDBName, "", true, auth.SysAdminUsername)
 	require.Error(t, err)
 	require.Contains(
 		t,
@@ -1145,7 +1160,7 @@ func TestServerErrors(t *testing.T) {
 		"password must have between 8 and 32 letters, digits and special characters "+
 			"of which at least 1 uppercase letter, 1 digit and 1 special character")
 
-	_, _, err = s.insertNewUser(usernameBytes, passwordBytes, 99, DefaultDBName, false, auth.SysAdminUsername)
+	_, _, err = s.insertNewUser(usernameBytes, passwordBytes, 99, DefaultDBName, "", false, auth.SysAdminUsername)
 	require.Equal(t, errors.New("unknown permission"), err)
 
 	// getLoggedInUserDataFromUsername errors
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// SERVER_TIME_HEADER carries the time, in unix nanoseconds, at which the server handled the request.
+// It lets clients detect a skew between their clock and the server one
+const SERVER_TIME_HEADER = "immudb-server-time"
+
+// ServerTimeSetter sets the server time header. Headers are only merged here, thus it needs to precede
+// the interceptors sending them
+func ServerTimeSetter(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	err := grpc.SetHeader(ctx, serverTimeHeader())
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// ServerTimeStreamSetter sets the server time header in a stream
+func ServerTimeStreamSetter(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := ss.SetHeader(serverTimeHeader())
+	if err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func serverTimeHeader() metadata.MD {
+	return metadata.Pairs(SERVER_TIME_HEADER, strconv.FormatInt(time.Now().UnixNano(), 10))
+}
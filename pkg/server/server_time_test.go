@@ -0,0 +1,87 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestServerTimeSetter(t *testing.T) {
+	requireServerTime := func(md metadata.MD, before, after time.Time) {
+		require.Len(t, md.Get(SERVER_TIME_HEADER), 1)
+
+		ts, err := strconv.ParseInt(md.Get(SERVER_TIME_HEADER)[0], 10, 64)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, ts, before.UnixNano())
+		require.LessOrEqual(t, ts, after.UnixNano())
+	}
+
+	t.Run("unary", func(t *testing.T) {
+		transportStream := &headerRecordingTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), transportStream)
+
+		before := time.Now()
+
+		res, err := ServerTimeSetter(ctx, "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return req, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "req", res)
+
+		requireServerTime(transportStream.header, before, time.Now())
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		ss := &headerRecordingStream{}
+
+		before := time.Now()
+
+		err := ServerTimeStreamSetter(nil, ss, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+			return nil
+		})
+		require.NoError(t, err)
+
+		requireServerTime(ss.header, before, time.Now())
+	})
+}
+
+type headerRecordingTransportStream struct {
+	mockServerTransportStream
+	header metadata.MD
+}
+
+func (r *headerRecordingTransportStream) SetHeader(md metadata.MD) error {
+	r.header = metadata.Join(r.header, md)
+	return nil
+}
+
+type headerRecordingStream struct {
+	mockServerStream
+	header metadata.MD
+}
+
+func (r *headerRecordingStream) SetHeader(md metadata.MD) error {
+	r.header = metadata.Join(r.header, md)
+	return nil
+}
@@ -159,6 +159,14 @@ func (s *ServerMock) VerifiableSet(ctx context.Context, req *schema.VerifiableSe
 	return s.PostVerifiableSetFn(ctx, req, rsp, err)
 }
 
+func (s *ServerMock) NotarizeObject(ctx context.Context, req *schema.NotarizeObjectRequest) (*schema.NotarizationReceipt, error) {
+	return s.Srv.NotarizeObject(ctx, req)
+}
+
+func (s *ServerMock) VerifiableSetByDigest(ctx context.Context, req *schema.VerifiableSetByDigestRequest) (*schema.VerifiableSetByDigestReply, error) {
+	return s.Srv.VerifiableSetByDigest(ctx, req)
+}
+
 func (s *ServerMock) Get(ctx context.Context, req *schema.KeyRequest) (*schema.Entry, error) {
 	return s.Srv.Get(ctx, req)
 }
@@ -171,10 +179,22 @@ func (s *ServerMock) GetAll(ctx context.Context, req *schema.KeyListRequest) (*s
 	return s.Srv.GetAll(ctx, req)
 }
 
+func (s *ServerMock) VerifiableGetAll(ctx context.Context, req *schema.VerifiableGetAllRequest) (*schema.VerifiableEntries, error) {
+	return s.Srv.VerifiableGetAll(ctx, req)
+}
+
+func (s *ServerMock) ExecAllPerDatabase(ctx context.Context, req *schema.ExecAllPerDatabaseRequest) (*schema.DatabaseTxHeaders, error) {
+	return s.Srv.ExecAllPerDatabase(ctx, req)
+}
+
 func (s *ServerMock) Delete(ctx context.Context, req *schema.DeleteKeysRequest) (*schema.TxHeader, error) {
 	return s.Srv.Delete(ctx, req)
 }
 
+func (s *ServerMock) Forget(ctx context.Context, req *schema.ForgetRequest) (*empty.Empty, error) {
+	return s.Srv.Forget(ctx, req)
+}
+
 func (s *ServerMock) ExecAll(ctx context.Context, req *schema.ExecAllRequest) (*schema.TxHeader, error) {
 	if s.PostExecAllFn == nil {
 		return s.Srv.ExecAll(ctx, req)
@@ -212,6 +232,18 @@ func (s *ServerMock) History(ctx context.Context, req *schema.HistoryRequest) (*
 	return s.Srv.History(ctx, req)
 }
 
+func (s *ServerMock) ComputeDiff(req *schema.DiffRequest, diffServer schema.ImmuService_ComputeDiffServer) error {
+	return s.Srv.ComputeDiff(req, diffServer)
+}
+
+func (s *ServerMock) RotateTLSCertificate(ctx context.Context, req *schema.RotateTLSCertificateRequest) (*schema.RotateTLSCertificateResponse, error) {
+	return s.Srv.RotateTLSCertificate(ctx, req)
+}
+
+func (s *ServerMock) ServerInfo(ctx context.Context, req *empty.Empty) (*schema.ServerInfoResponse, error) {
+	return s.Srv.ServerInfo(ctx, req)
+}
+
 func (s *ServerMock) Health(ctx context.Context, req *empty.Empty) (*schema.HealthResponse, error) {
 	return s.Srv.Health(ctx, req)
 }
@@ -260,6 +292,10 @@ func (s *ServerMock) ZScan(ctx context.Context, req *schema.ZScanRequest) (*sche
 	return s.Srv.ZScan(ctx, req)
 }
 
+func (s *ServerMock) VerifiableZScan(ctx context.Context, req *schema.VerifiableZScanRequest) (*schema.VerifiableZEntries, error) {
+	return s.Srv.VerifiableZScan(ctx, req)
+}
+
 func (s *ServerMock) CreateDatabase(ctx context.Context, req *schema.Database) (*empty.Empty, error) {
 	return s.Srv.CreateDatabase(ctx, req)
 }
@@ -296,6 +332,10 @@ func (s *ServerMock) SetActiveUser(ctx context.Context, req *schema.SetActiveUse
 	return s.Srv.SetActiveUser(ctx, req)
 }
 
+func (s *ServerMock) SetUserDefaultDatabase(ctx context.Context, req *schema.SetUserDefaultDatabaseRequest) (*empty.Empty, error) {
+	return s.Srv.SetUserDefaultDatabase(ctx, req)
+}
+
 func (s *ServerMock) getDbIndexFromCtx(ctx context.Context, methodname string) (int64, error) {
 	return s.GetDbIndexFromCtx(ctx, methodname)
 }
@@ -312,6 +352,14 @@ func (s *ServerMock) SQLExec(ctx context.Context, req *schema.SQLExecRequest) (*
 	return s.Srv.SQLExec(ctx, req)
 }
 
+func (s *ServerMock) SQLExecScript(ctx context.Context, req *schema.SQLExecRequest) (*schema.SQLExecResult, error) {
+	return s.Srv.SQLExecScript(ctx, req)
+}
+
+func (s *ServerMock) IngestJSON(ctx context.Context, req *schema.IngestJSONRequest) (*schema.SQLExecResult, error) {
+	return s.Srv.IngestJSON(ctx, req)
+}
+
 func (s *ServerMock) SQLQuery(ctx context.Context, req *schema.SQLQueryRequest) (*schema.SQLQueryResult, error) {
 	return s.Srv.SQLQuery(ctx, req)
 }
@@ -324,6 +372,38 @@ func (s *ServerMock) DescribeTable(ctx context.Context, req *schema.Table) (*sch
 	return s.Srv.DescribeTable(ctx, req)
 }
 
+func (s *ServerMock) DescribeSchema(ctx context.Context, req *empty.Empty) (*schema.SQLSchema, error) {
+	return s.Srv.DescribeSchema(ctx, req)
+}
+
+func (s *ServerMock) RestoreDatabaseAt(ctx context.Context, req *schema.RestoreDatabaseAtRequest) (*empty.Empty, error) {
+	return s.Srv.RestoreDatabaseAt(ctx, req)
+}
+
+func (s *ServerMock) IndexSuggestions(ctx context.Context, req *empty.Empty) (*schema.IndexSuggestionList, error) {
+	return s.Srv.IndexSuggestions(ctx, req)
+}
+
+func (s *ServerMock) IndexingProgress(ctx context.Context, req *empty.Empty) (*schema.IndexingProgressResponse, error) {
+	return s.Srv.IndexingProgress(ctx, req)
+}
+
+func (s *ServerMock) ListQueries(ctx context.Context, req *empty.Empty) (*schema.QueryInfoList, error) {
+	return s.Srv.ListQueries(ctx, req)
+}
+
+func (s *ServerMock) CancelQuery(ctx context.Context, req *schema.CancelQueryRequest) (*empty.Empty, error) {
+	return s.Srv.CancelQuery(ctx, req)
+}
+
+func (s *ServerMock) SQLListen(req *schema.SQLListenRequest, listenServer schema.ImmuService_SQLListenServer) error {
+	return s.Srv.SQLListen(req, listenServer)
+}
+
+func (s *ServerMock) StreamSQLBlob(req *schema.SQLBlobRequest, str schema.ImmuService_StreamSQLBlobServer) error {
+	return s.Srv.StreamSQLBlob(req, str)
+}
+
 func (s *ServerMock) VerifiableSQLGet(ctx context.Context, req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error) {
 	return s.Srv.VerifiableSQLGet(ctx, req)
 }
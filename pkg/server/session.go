@@ -32,6 +32,10 @@ func (s *ImmuServer) OpenSession(ctx context.Context, r *schema.OpenSessionReque
 		return nil, errors.New(ErrUserNotActive)
 	}
 
+	if r.DatabaseName == "" {
+		r.DatabaseName = u.DefaultDatabase
+	}
+
 	databaseID := sysDBIndex
 	if r.DatabaseName != SystemDBName {
 		databaseID = s.dbList.GetId(r.DatabaseName)
@@ -40,15 +44,17 @@ func (s *ImmuServer) OpenSession(ctx context.Context, r *schema.OpenSessionReque
 		}
 	}
 
-	if (!u.IsSysAdmin) &&
-		(!u.HasPermission(r.DatabaseName, auth.PermissionAdmin)) &&
-		(!u.HasPermission(r.DatabaseName, auth.PermissionR)) &&
-		(!u.HasPermission(r.DatabaseName, auth.PermissionRW)) {
-
+	if !hasDatabaseAccess(u, r.DatabaseName) {
 		return nil, status.Errorf(codes.PermissionDenied, "Logged in user does not have permission on this database")
 	}
 
-	session, err := s.SessManager.NewSession(u, s.dbList.GetByIndex(databaseID))
+	db := s.withWriteValidation(s.dbList.GetByIndex(databaseID), u.Username)
+
+	if u.Namespace != "" {
+		db = newNamespacedDB(db, u.Namespace)
+	}
+
+	session, err := s.SessManager.NewSession(u, db)
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +62,7 @@ func (s *ImmuServer) OpenSession(ctx context.Context, r *schema.OpenSessionReque
 	return &schema.OpenSessionResponse{
 		SessionID:  session.GetID(),
 		ServerUUID: s.UUID.String(),
+		Database:   r.DatabaseName,
 	}, nil
 }
 
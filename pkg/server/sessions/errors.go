@@ -34,4 +34,3 @@ var ErrGuardAlreadyRunning = errors.New("session guard already launched")
 var ErrGuardNotRunning = errors.New("session guard not running")
 var ErrMaxSessionsReached = errors.New("max sessions reached")
 var ErrWriteOnlyTXNotAllowed = errors.New("write only transaction not allowed")
-var ErrReadOnlyTXNotAllowed = errors.New("read only transaction not allowed")
@@ -78,6 +78,10 @@ func (tx *transaction) Commit() ([]*sql.SQLTx, error) {
 	tx.mutex.Lock()
 	defer tx.mutex.Unlock()
 	defer func() { tx.sqlTx = nil }()
+	if tx.txMode == schema.TxMode_ReadOnly {
+		// nothing to be committed, the snapshot is just released
+		return nil, tx.sqlTx.Cancel()
+	}
 	_, cTxs, err := tx.db.SQLExec(&schema.SQLExecRequest{Sql: "COMMIT;"}, tx.sqlTx)
 	if err != nil {
 		return nil, err
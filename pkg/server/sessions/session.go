@@ -19,6 +19,7 @@ package sessions
 import (
 	"context"
 	"github.com/codenotary/immudb/embedded/multierr"
+	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/codenotary/immudb/pkg/auth"
 	"github.com/codenotary/immudb/pkg/database"
@@ -65,24 +66,30 @@ func NewSession(sessionID string, user *auth.User, db database.DB, log logger.Lo
 	}
 }
 
-func (s *Session) NewTransaction(mode schema.TxMode) (transactions.Transaction, error) {
+// NewTransaction creates a transaction of the given mode. Read only transactions are pinned to the snapshot
+// taken at creation, without waiting for indexing when ReadCommittedNoWait isolation is requested
+func (s *Session) NewTransaction(mode schema.TxMode, isolation schema.ReadIsolation) (transactions.Transaction, error) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 	if mode == schema.TxMode_WriteOnly {
 		// only in key-value mode, in sql we read catalog and write to it
 		return nil, ErrWriteOnlyTXNotAllowed
 	}
+	var sqlTx *sql.SQLTx
+	var err error
 	if mode == schema.TxMode_ReadOnly {
-		return nil, ErrReadOnlyTXNotAllowed
-	}
-	sqlTx, _, err := s.database.SQLExec(&schema.SQLExecRequest{Sql: "BEGIN TRANSACTION;"}, nil)
-	if err != nil {
-		return nil, err
-	}
-	if mode == schema.TxMode_ReadWrite {
+		sqlTx, err = s.database.NewSQLTx(isolation != schema.ReadIsolation_ReadCommittedNoWait)
+		if err != nil {
+			return nil, err
+		}
+	} else {
 		if s.readWriteTxOngoing {
 			return nil, ErrOngoingReadWriteTx
 		}
+		sqlTx, _, err = s.database.SQLExec(&schema.SQLExecRequest{Sql: "BEGIN TRANSACTION;"}, nil)
+		if err != nil {
+			return nil, err
+		}
 		s.readWriteTxOngoing = true
 	}
 	transactionID := xid.New().String()
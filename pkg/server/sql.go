@@ -18,6 +18,7 @@ package server
 import (
 	"context"
 
+	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/golang/protobuf/ptypes/empty"
 )
@@ -80,25 +81,71 @@ func (s *ImmuServer) SQLExec(ctx context.Context, req *schema.SQLExecRequest) (*
 	}
 
 	for i, ctx := range ctxs {
-		firstPKs := make(map[string]*schema.SQLValue, len(ctx.FirstInsertedPKs()))
-		lastPKs := make(map[string]*schema.SQLValue, len(ctx.LastInsertedPKs()))
+		res.Txs[i] = committedSQLTxToProto(ctx)
+	}
 
-		for k, n := range ctx.LastInsertedPKs() {
-			lastPKs[k] = &schema.SQLValue{Value: &schema.SQLValue_N{N: n}}
-		}
-		for k, n := range ctx.FirstInsertedPKs() {
-			firstPKs[k] = &schema.SQLValue{Value: &schema.SQLValue_N{N: n}}
-		}
+	return res, err
+}
 
-		res.Txs[i] = &schema.CommittedSQLTx{
-			Header:           schema.TxHeaderToProto(ctx.TxHeader()),
-			UpdatedRows:      uint32(ctx.UpdatedRows()),
-			LastInsertedPKs:  lastPKs,
-			FirstInsertedPKs: firstPKs,
-		}
+func committedSQLTxToProto(ctx *sql.SQLTx) *schema.CommittedSQLTx {
+	firstPKs := make(map[string]*schema.SQLValue, len(ctx.FirstInsertedPKs()))
+	lastPKs := make(map[string]*schema.SQLValue, len(ctx.LastInsertedPKs()))
+
+	for k, n := range ctx.LastInsertedPKs() {
+		lastPKs[k] = &schema.SQLValue{Value: &schema.SQLValue_N{N: n}}
+	}
+	for k, n := range ctx.FirstInsertedPKs() {
+		firstPKs[k] = &schema.SQLValue{Value: &schema.SQLValue_N{N: n}}
 	}
 
-	return res, err
+	return &schema.CommittedSQLTx{
+		Header:           schema.TxHeaderToProto(ctx.TxHeader()),
+		UpdatedRows:      uint32(ctx.UpdatedRows()),
+		LastInsertedPKs:  lastPKs,
+		FirstInsertedPKs: firstPKs,
+	}
+}
+
+func (s *ImmuServer) SQLExecScript(ctx context.Context, req *schema.SQLExecRequest) (*schema.SQLExecResult, error) {
+	if s.Options.GetMaintenance() {
+		return nil, ErrNotAllowedInMaintenanceMode
+	}
+
+	db, err := s.getDBFromCtx(ctx, "SQLExecScript")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.SQLExecScript(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.SQLExecResult{Txs: []*schema.CommittedSQLTx{committedSQLTxToProto(tx)}}, nil
+}
+
+// IngestJSON upserts the documents into the table of the collection, which schema is inferred from the documents
+func (s *ImmuServer) IngestJSON(ctx context.Context, req *schema.IngestJSONRequest) (*schema.SQLExecResult, error) {
+	if s.Options.GetMaintenance() {
+		return nil, ErrNotAllowedInMaintenanceMode
+	}
+
+	db, err := s.getDBFromCtx(ctx, "IngestJSON")
+	if err != nil {
+		return nil, err
+	}
+
+	sqlReq, err := db.InferJSONIngest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.SQLExecScript(sqlReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.SQLExecResult{Txs: []*schema.CommittedSQLTx{committedSQLTxToProto(tx)}}, nil
 }
 
 func (s *ImmuServer) SQLQuery(ctx context.Context, req *schema.SQLQueryRequest) (*schema.SQLQueryResult, error) {
@@ -107,7 +154,24 @@ func (s *ImmuServer) SQLQuery(ctx context.Context, req *schema.SQLQueryRequest)
 		return nil, err
 	}
 
-	return db.SQLQuery(req, nil)
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	var username string
+
+	_, user, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err == nil {
+		username = user.Username
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	queryID := s.queries.register(username, db.GetName(), req.Sql, cancel)
+	defer s.queries.unregister(queryID)
+
+	return db.SQLQueryWithCancellation(req, nil, ctx.Done())
 }
 
 func (s *ImmuServer) ListTables(ctx context.Context, _ *empty.Empty) (*schema.SQLQueryResult, error) {
@@ -131,3 +195,25 @@ func (s *ImmuServer) DescribeTable(ctx context.Context, req *schema.Table) (*sch
 
 	return db.DescribeTable(req.TableName, nil)
 }
+
+func (s *ImmuServer) DescribeSchema(ctx context.Context, _ *empty.Empty) (*schema.SQLSchema, error) {
+	db, err := s.getDBFromCtx(ctx, "DescribeSchema")
+	if err != nil {
+		return nil, err
+	}
+
+	return db.DescribeSchema(nil)
+}
+
+func (s *ImmuServer) SQLListen(req *schema.SQLListenRequest, str schema.ImmuService_SQLListenServer) error {
+	if req == nil {
+		return ErrIllegalArguments
+	}
+
+	db, err := s.getDBFromCtx(str.Context(), "SQLListen")
+	if err != nil {
+		return err
+	}
+
+	return db.SQLListen(req, str.Context().Done(), str.Send)
+}
@@ -55,6 +55,9 @@ func TestSQLInteraction(t *testing.T) {
 	_, err = s.DescribeTable(ctx, &schema.Table{})
 	require.Error(t, err)
 
+	_, err = s.DescribeSchema(ctx, &emptypb.Empty{})
+	require.Error(t, err)
+
 	_, err = s.VerifiableSQLGet(ctx, nil)
 	require.Error(t, err)
 
@@ -84,6 +87,11 @@ func TestSQLInteraction(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, res.Rows, 1)
 
+	sqlSchema, err := s.DescribeSchema(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, sqlSchema.Tables, 1)
+	require.Equal(t, "table1", sqlSchema.Tables[0].Name)
+
 	xres, err = s.SQLExec(ctx, &schema.SQLExecRequest{Sql: "INSERT INTO table1 (id) VALUES (1),(2),(3)"})
 	require.NoError(t, err)
 	require.Len(t, xres.Txs, 1)
@@ -138,3 +146,50 @@ func TestSQLExecResult(t *testing.T) {
 	require.Equal(t, map[string]*schema.SQLValue{"table2": {Value: &schema.SQLValue_N{N: 1}}}, xres.FirstInsertedPks())
 	require.Equal(t, map[string]*schema.SQLValue{"table2": {Value: &schema.SQLValue_N{N: 3}}}, xres.LastInsertedPk())
 }
+
+func TestSQLExecScript(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithMetricsServer(false)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	s.Initialize()
+
+	ctx := context.Background()
+
+	_, err := s.SQLExecScript(ctx, nil)
+	require.Error(t, err)
+
+	r := &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	}
+
+	lr, err := s.Login(ctx, r)
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx = metadata.NewIncomingContext(context.Background(), md)
+
+	_, err = s.SQLExecScript(ctx, nil)
+	require.Error(t, err)
+
+	xres, err := s.SQLExecScript(ctx, &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, name VARCHAR, PRIMARY KEY id);
+		CREATE TABLE table2 (id INTEGER AUTO_INCREMENT, ref INTEGER, PRIMARY KEY id);
+		INSERT INTO table1 (name) VALUES ('first'), ('second');
+		INSERT INTO table2 (ref) VALUES (@last_insert_id);
+	`})
+	require.NoError(t, err)
+	require.Len(t, xres.Txs, 1)
+	require.Equal(t, map[string]*schema.SQLValue{
+		"table1": {Value: &schema.SQLValue_N{N: 2}},
+		"table2": {Value: &schema.SQLValue_N{N: 1}},
+	}, xres.LastInsertedPk())
+
+	res, err := s.SQLQuery(ctx, &schema.SQLQueryRequest{Sql: "SELECT ref FROM table2"})
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	require.Equal(t, int64(2), res.Rows[0].Values[0].GetN())
+}
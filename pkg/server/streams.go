@@ -479,3 +479,37 @@ func (s *ImmuServer) StreamExecAll(str schema.ImmuService_StreamExecAllServer) e
 
 	return nil
 }
+
+// StreamSQLBlob sends the value of a BLOB column in chunks of at most StreamChunkSize bytes,
+// the stream is closed with no chunk at all if the value is NULL or empty
+func (s *ImmuServer) StreamSQLBlob(req *schema.SQLBlobRequest, str schema.ImmuService_StreamSQLBlobServer) error {
+	if req == nil {
+		return ErrIllegalArguments
+	}
+
+	db, err := s.getDBFromCtx(str.Context(), "StreamSQLBlob")
+	if err != nil {
+		return err
+	}
+
+	blob, err := db.SQLBlob(req, nil)
+	if err != nil {
+		return err
+	}
+
+	for len(blob) > 0 {
+		chunkSize := s.Options.StreamChunkSize
+		if len(blob) < chunkSize {
+			chunkSize = len(blob)
+		}
+
+		err = str.Send(&schema.Chunk{Content: blob[:chunkSize]})
+		if err != nil {
+			return err
+		}
+
+		blob = blob[chunkSize:]
+	}
+
+	return nil
+}
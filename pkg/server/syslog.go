@@ -0,0 +1,242 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrMalformedSyslogMessage = errors.New("malformed syslog message")
+
+// maxSyslogFrameLen is the max length accepted for a single syslog message received over TCP
+const maxSyslogFrameLen = 64 * 1024
+
+const syslogNilValue = "-"
+
+// parseSyslogMessage parses syslog messages in both RFC 5424 and RFC 3164 (BSD) formats.
+// Messages not carrying a timestamp are stamped with the time they were received
+func parseSyslogMessage(msg string, received time.Time) (*logRecord, error) {
+	msg = strings.TrimRight(msg, "\r\n\x00")
+
+	if !strings.HasPrefix(msg, "<") {
+		return nil, fmt.Errorf("%w: priority is missing", ErrMalformedSyslogMessage)
+	}
+
+	end := strings.IndexByte(msg, '>')
+	if end < 2 || end > 4 {
+		return nil, fmt.Errorf("%w: invalid priority", ErrMalformedSyslogMessage)
+	}
+
+	pri, err := strconv.Atoi(msg[1:end])
+	if err != nil || pri > 191 {
+		return nil, fmt.Errorf("%w: invalid priority", ErrMalformedSyslogMessage)
+	}
+
+	rec := &logRecord{
+		ts:          received.UnixNano() / int64(time.Millisecond),
+		protocol:    "syslog",
+		hasPriority: true,
+		facility:    int64(pri / 8),
+		severity:    int64(pri % 8),
+	}
+
+	msg = msg[end+1:]
+
+	if strings.HasPrefix(msg, "1 ") {
+		err = parseRFC5424(rec, msg[2:])
+	} else {
+		parseRFC3164(rec, msg, received)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+// parseRFC5424 parses TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG]
+func parseRFC5424(rec *logRecord, msg string) error {
+	fields := strings.SplitN(msg, " ", 6)
+	if len(fields) < 6 {
+		return fmt.Errorf("%w: incomplete header", ErrMalformedSyslogMessage)
+	}
+
+	if fields[0] != syslogNilValue {
+		ts, err := time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			return fmt.Errorf("%w: invalid timestamp", ErrMalformedSyslogMessage)
+		}
+		rec.ts = ts.UnixNano() / int64(time.Millisecond)
+	}
+
+	rec.hostname = nilValueAsEmpty(fields[1])
+	rec.app = nilValueAsEmpty(fields[2])
+
+	sd, message, err := splitStructuredData(fields[5])
+	if err != nil {
+		return err
+	}
+
+	rec.message = strings.TrimPrefix(message, "\ufeff")
+
+	if fields[3] != syslogNilValue || fields[4] != syslogNilValue || sd != "" {
+		extra := make(map[string]interface{})
+		if fields[3] != syslogNilValue {
+			extra["procid"] = fields[3]
+		}
+		if fields[4] != syslogNilValue {
+			extra["msgid"] = fields[4]
+		}
+		if sd != "" {
+			extra["sd"] = sd
+		}
+		rec.fields = extra
+	}
+
+	return nil
+}
+
+// splitStructuredData splits the structured data from the message that follows it
+func splitStructuredData(s string) (sd string, msg string, err error) {
+	if strings.HasPrefix(s, syslogNilValue) {
+		return "", strings.TrimPrefix(s[1:], " "), nil
+	}
+
+	inElement := false
+	inValue := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case inValue && c == '\\':
+			i++
+		case c == '"' && inElement:
+			inValue = !inValue
+		case c == '[' && !inValue:
+			inElement = true
+		case c == ']' && !inValue:
+			inElement = false
+
+			if i+1 == len(s) || s[i+1] == ' ' {
+				if i+1 < len(s) {
+					msg = s[i+2:]
+				}
+				return s[:i+1], msg, nil
+			}
+		}
+	}
+
+	return "", "", fmt.Errorf("%w: invalid structured data", ErrMalformedSyslogMessage)
+}
+
+// parseRFC3164 parses the BSD format "Mmm dd hh:mm:ss HOSTNAME TAG: MSG". Being loosely specified
+// the header is only taken when it is found, otherwise the whole content is taken as the message
+func parseRFC3164(rec *logRecord, msg string, received time.Time) {
+	const stampLen = len(time.Stamp)
+
+	if len(msg) > stampLen && msg[stampLen] == ' ' {
+		ts, err := time.ParseInLocation(time.Stamp, msg[:stampLen], received.Location())
+		if err == nil {
+			ts = ts.AddDate(received.Year(), 0, 0)
+			// messages sent at the end of the year may be received at the beginning of the next one
+			if ts.After(received.AddDate(0, 1, 0)) {
+				ts = ts.AddDate(-1, 0, 0)
+			}
+
+			rec.ts = ts.UnixNano() / int64(time.Millisecond)
+			msg = msg[stampLen+1:]
+
+			sp := strings.IndexByte(msg, ' ')
+			if sp > 0 {
+				rec.hostname = msg[:sp]
+				msg = msg[sp+1:]
+			}
+		}
+	}
+
+	// the tag is made of alphanumeric chars, optionally followed by the pid e.g. sshd[123]:
+	if tagEnd := strings.Index(msg, ": "); tagEnd > 0 && tagEnd <= 48 && !strings.ContainsAny(msg[:tagEnd], " \t") {
+		tag := msg[:tagEnd]
+
+		if b := strings.IndexByte(tag, '['); b > 0 && strings.HasSuffix(tag, "]") {
+			rec.fields = map[string]interface{}{"procid": tag[b+1 : len(tag)-1]}
+			tag = tag[:b]
+		}
+
+		rec.app = tag
+		msg = msg[tagEnd+2:]
+	}
+
+	rec.message = msg
+}
+
+func nilValueAsEmpty(s string) string {
+	if s == syslogNilValue {
+		return ""
+	}
+	return s
+}
+
+// readSyslogFrame reads the next message of a syslog TCP stream, where messages are either
+// prefixed by their length (octet counting, RFC 6587) or delimited by a new line.
+// The reader is expected to buffer up to maxSyslogFrameLen bytes
+func readSyslogFrame(r *bufio.Reader) (string, error) {
+	c, err := r.Peek(1)
+	if err != nil {
+		return "", err
+	}
+
+	if c[0] < '1' || c[0] > '9' {
+		line, err := r.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			return "", fmt.Errorf("%w: message too large", ErrMalformedSyslogMessage)
+		}
+		if err == io.EOF && len(line) > 0 {
+			return string(line), nil
+		}
+		return string(line), err
+	}
+
+	lenStr, err := r.ReadSlice(' ')
+	if err == bufio.ErrBufferFull {
+		return "", fmt.Errorf("%w: invalid frame length", ErrMalformedSyslogMessage)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	n, err := strconv.Atoi(string(lenStr[:len(lenStr)-1]))
+	if err != nil || n > maxSyslogFrameLen {
+		return "", fmt.Errorf("%w: invalid frame length", ErrMalformedSyslogMessage)
+	}
+
+	frame := make([]byte, n)
+
+	_, err = io.ReadFull(r, frame)
+	if err != nil {
+		return "", err
+	}
+
+	return string(frame), nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/errors"
+)
+
+var ErrTLSNotEnabled = errors.New("tls is not enabled")
+var ErrInvalidCertificate = errors.New("invalid tls certificate")
+
+// rotatingCertificate holds the certificate presented on new tls handshakes,
+// connections already established keep using the certificate they were negotiated with
+type rotatingCertificate struct {
+	mutex sync.RWMutex
+
+	config *tls.Config
+	cert   tls.Certificate
+}
+
+// configForClient serves each handshake with the current certificate. Certificates listed in the config
+// take precedence over GetCertificate when clients don't send a server name, thus the config is replaced
+func (rc *rotatingCertificate) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+
+	config := rc.config.Clone()
+	config.Certificates = []tls.Certificate{rc.cert}
+
+	return config, nil
+}
+
+func (rc *rotatingCertificate) set(cert *tls.Certificate) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.cert = *cert
+}
+
+// setUpCertificateRotation makes the certificate of the tls config replaceable,
+// the config is shared by the grpc, web and pgsql servers thus all of them are rotated at once
+func (s *ImmuServer) setUpCertificateRotation() {
+	if s.Options.TLSConfig == nil || len(s.Options.TLSConfig.Certificates) == 0 {
+		return
+	}
+
+	s.tlsCert = &rotatingCertificate{
+		config: s.Options.TLSConfig.Clone(),
+		cert:   s.Options.TLSConfig.Certificates[0],
+	}
+
+	tlsConfig := s.Options.TLSConfig.Clone()
+	tlsConfig.GetConfigForClient = s.tlsCert.configForClient
+
+	s.Options.TLSConfig = tlsConfig
+}
+
+// RotateTLSCertificate replaces the certificate presented by the server on new connections.
+// The certificate is either provided in the request or reloaded from the files the server was started with,
+// and it's verified before being taken so a faulty certificate never replaces a working one
+func (s *ImmuServer) RotateTLSCertificate(ctx context.Context, req *schema.RotateTLSCertificateRequest) (*schema.RotateTLSCertificateResponse, error) {
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	_, user, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsSysAdmin {
+		return nil, ErrPermissionDenied
+	}
+
+	if s.tlsCert == nil {
+		return nil, ErrTLSNotEnabled
+	}
+
+	certPEM, keyPEM := req.Certificate, req.PrivateKey
+
+	if len(certPEM) == 0 && len(keyPEM) == 0 {
+		if s.Options.TLSCertificateFile == "" || s.Options.TLSKeyFile == "" {
+			return nil, fmt.Errorf("%w: no certificate provided and the server was not started from certificate files", ErrIllegalArguments)
+		}
+
+		certPEM, err = ioutil.ReadFile(s.Options.TLSCertificateFile)
+		if err != nil {
+			return nil, err
+		}
+
+		keyPEM, err = ioutil.ReadFile(s.Options.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cert, err := verifiedCertificate(certPEM, keyPEM, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.tlsCert.set(cert)
+
+	s.Logger.Infof("tls certificate rotated: subject '%s', serial number %s, valid until %s",
+		cert.Leaf.Subject, cert.Leaf.SerialNumber, cert.Leaf.NotAfter.Format(time.RFC3339))
+
+	return &schema.RotateTLSCertificateResponse{
+		Subject:      cert.Leaf.Subject.String(),
+		SerialNumber: cert.Leaf.SerialNumber.String(),
+		NotAfter:     cert.Leaf.NotAfter.Unix(),
+	}, nil
+}
+
+// verifiedCertificate parses the certificate chain and its private key, the key must match the leaf certificate,
+// every certificate must be valid at the specified time and signed by the following one in the chain
+func verifiedCertificate(certPEM, keyPEM []byte, now time.Time) (*tls.Certificate, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+	}
+
+	chain := make([]*x509.Certificate, len(cert.Certificate))
+
+	for i, der := range cert.Certificate {
+		chain[i], err = x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+		}
+
+		if now.Before(chain[i].NotBefore) || now.After(chain[i].NotAfter) {
+			return nil, fmt.Errorf("%w: certificate '%s' is not valid at %s", ErrInvalidCertificate, chain[i].Subject, now.Format(time.RFC3339))
+		}
+	}
+
+	for i := 0; i < len(chain)-1; i++ {
+		err = chain[i].CheckSignatureFrom(chain[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: certificate '%s' is not signed by '%s': %v", ErrInvalidCertificate, chain[i].Subject, chain[i+1].Subject, err)
+		}
+	}
+
+	cert.Leaf = chain[0]
+
+	return &cert, nil
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCertificate(t *testing.T, serial int64, notAfter time.Time, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+		DNSNames:              []string{"localhost"},
+	}
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if parent != nil {
+		certPEM = append(certPEM, parent.certPEM...)
+	}
+
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: certPEM,
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func TestServerRotateTLSCertificate(t *testing.T) {
+	ca := newTestCertificate(t, 1, time.Now().Add(time.Hour), nil)
+	initialCert := newTestCertificate(t, 2, time.Now().Add(time.Hour), ca)
+
+	tlsCert, err := tls.X509KeyPair(initialCert.certPEM, initialCert.keyPEM)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.pem")
+	keyFile := filepath.Join(dir, "server.key")
+
+	serverOptions := DefaultOptions().
+		WithDir(filepath.Join(dir, "data")).
+		WithMetricsServer(false).
+		WithWebServer(false).
+		WithPgsqlServer(false).
+		WithPort(0).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithTLS(&tls.Config{Certificates: []tls.Certificate{tlsCert}}).
+		WithTLSCertificateFiles(certFile, keyFile)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err = s.Initialize()
+	require.NoError(t, err)
+
+	go func() {
+		s.Start()
+	}()
+	defer s.Stop()
+
+	time.Sleep(500 * time.Millisecond)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	addr := s.Listener.Addr().(*net.TCPAddr).String()
+
+	dial := func() *grpc.ClientConn {
+		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+		require.NoError(t, err)
+		return conn
+	}
+
+	servedSerial := func(conn *grpc.ClientConn) int64 {
+		var p peer.Peer
+
+		_, err := schema.NewImmuServiceClient(conn).Health(context.Background(), &emptypb.Empty{}, grpc.Peer(&p))
+		require.NoError(t, err)
+
+		return p.AuthInfo.(credentials.TLSInfo).State.PeerCertificates[0].SerialNumber.Int64()
+	}
+
+	initialConn := dial()
+	defer initialConn.Close()
+
+	require.Equal(t, int64(2), servedSerial(initialConn))
+
+	t.Run("the certificate should be rotated for new connections", func(t *testing.T) {
+		newCert := newTestCertificate(t, 3, time.Now().Add(time.Hour), ca)
+
+		res, err := s.RotateTLSCertificate(ctx, &schema.RotateTLSCertificateRequest{
+			Certificate: newCert.certPEM,
+			PrivateKey:  newCert.keyPEM,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "3", res.SerialNumber)
+		require.Equal(t, "CN=localhost", res.Subject)
+		require.Equal(t, newCert.cert.NotAfter.Unix(), res.NotAfter)
+
+		conn := dial()
+		defer conn.Close()
+
+		require.Equal(t, int64(3), servedSerial(conn))
+
+		// established connections are kept alive with the certificate they were negotiated with
+		require.Equal(t, int64(2), servedSerial(initialConn))
+	})
+
+	t.Run("the certificate should be reloaded from the server files", func(t *testing.T) {
+		newCert := newTestCertificate(t, 4, time.Now().Add(time.Hour), ca)
+
+		err := ioutil.WriteFile(certFile, newCert.certPEM, 0600)
+		require.NoError(t, err)
+
+		err = ioutil.WriteFile(keyFile, newCert.keyPEM, 0600)
+		require.NoError(t, err)
+
+		res, err := s.RotateTLSCertificate(ctx, &schema.RotateTLSCertificateRequest{})
+		require.NoError(t, err)
+		require.Equal(t, "4", res.SerialNumber)
+
+		conn := dial()
+		defer conn.Close()
+
+		require.Equal(t, int64(4), servedSerial(conn))
+	})
+
+	t.Run("invalid certificates should not be taken", func(t *testing.T) {
+		expiredCert := newTestCertificate(t, 5, time.Now().Add(-time.Minute), ca)
+		otherCert := newTestCertificate(t, 6, time.Now().Add(time.Hour), ca)
+		otherCA := newTestCertificate(t, 7, time.Now().Add(time.Hour), nil)
+
+		_, err = s.RotateTLSCertificate(ctx, &schema.RotateTLSCertificateRequest{
+			Certificate: expiredCert.certPEM,
+			PrivateKey:  expiredCert.keyPEM,
+		})
+		require.ErrorIs(t, err, ErrInvalidCertificate)
+
+		_, err = s.RotateTLSCertificate(ctx, &schema.RotateTLSCertificateRequest{
+			Certificate: otherCert.certPEM,
+			PrivateKey:  expiredCert.keyPEM,
+		})
+		require.ErrorIs(t, err, ErrInvalidCertificate)
+
+		brokenChain := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: otherCert.cert.Raw}), otherCA.certPEM...)
+
+		_, err = s.RotateTLSCertificate(ctx, &schema.RotateTLSCertificateRequest{
+			Certificate: brokenChain,
+			PrivateKey:  otherCert.keyPEM,
+		})
+		require.ErrorIs(t, err, ErrInvalidCertificate)
+
+		conn := dial()
+		defer conn.Close()
+
+		require.Equal(t, int64(4), servedSerial(conn))
+	})
+
+	t.Run("the certificate should not be rotated without a logged in sysadmin", func(t *testing.T) {
+		_, err = s.RotateTLSCertificate(context.Background(), &schema.RotateTLSCertificateRequest{})
+		require.Error(t, err)
+
+		_, err = s.RotateTLSCertificate(ctx, nil)
+		require.ErrorIs(t, err, ErrIllegalArguments)
+	})
+}
+
+func TestServerRotateTLSCertificateWithoutTLS(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithDir(t.TempDir()).
+		WithMetricsServer(false).
+		WithPort(0).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.RotateTLSCertificate(ctx, &schema.RotateTLSCertificateRequest{})
+	require.ErrorIs(t, err, ErrTLSNotEnabled)
+}
@@ -36,7 +36,7 @@ func (s *ImmuServer) NewTx(ctx context.Context, request *schema.NewTxRequest) (*
 		return nil, err
 	}
 
-	tx, err := sess.NewTransaction(request.Mode)
+	tx, err := sess.NewTransaction(request.Mode, request.Isolation)
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +59,11 @@ func (s *ImmuServer) Commit(ctx context.Context, _ *empty.Empty) (*schema.Commit
 		return nil, err
 	}
 
+	if len(cTxs) == 0 {
+		// read only transaction
+		return &schema.CommittedSQLTx{}, nil
+	}
+
 	cTx := cTxs[0]
 	lastPKs := make(map[string]*schema.SQLValue, len(cTx.LastInsertedPKs()))
 	for k, n := range cTx.LastInsertedPKs() {
@@ -19,12 +19,14 @@ package server
 import (
 	"github.com/codenotary/immudb/pkg/server/sessions"
 	"math"
+	"math/rand"
 	"net"
 	"net/http"
 	"os"
 	"sync"
 
 	"github.com/codenotary/immudb/embedded/remotestorage"
+	"github.com/codenotary/immudb/embedded/tbtree"
 	pgsqlsrv "github.com/codenotary/immudb/pkg/pgsql/server"
 	"github.com/codenotary/immudb/pkg/replication"
 	"github.com/codenotary/immudb/pkg/stream"
@@ -45,7 +47,7 @@ type usernameToUserdataMap struct {
 	sync.RWMutex
 }
 
-//defaultDbIndex systemdb should always be in index 0
+// defaultDbIndex systemdb should always be in index 0
 const defaultDbIndex = 0
 const sysDBIndex = int64(math.MaxInt64)
 
@@ -71,15 +73,39 @@ type ImmuServer struct {
 	sysDB                database.DB
 	metricsServer        *http.Server
 	webServer            *http.Server
+	remoteWriteServer    *http.Server
+	queryServer          *http.Server
+	logIngestServer      *LogIngestServer
 	mux                  sync.Mutex
 	pgsqlMux             sync.Mutex
+	contentMux           sync.Mutex
 	StateSigner          StateSigner
+	WriteValidator       WriteValidator
 	StreamServiceFactory stream.ServiceFactory
 	PgsqlSrv             pgsqlsrv.Server
 
 	remoteStorage remotestorage.Storage
 
+	// index nodes of all the databases are held in this pool, when it's set
+	indexBufferPool *tbtree.BufferPool
+
 	SessManager sessions.Manager
+
+	queries queryRegistry
+
+	// certificate presented on new tls connections, nil when tls is not enabled
+	tlsCert *rotatingCertificate
+
+	freeDiskSpace         func(path string) (uint64, error)
+	diskSpaceWatchdogDone chan struct{}
+
+	retentionDone    chan struct{}
+	retentionStopped chan struct{}
+
+	// returns a random number in [0,1) used to sample the audited requests
+	auditSampler func() float64
+
+	idempotentResults idempotencyRegistry
 }
 
 // DefaultServer ...
@@ -94,6 +120,8 @@ func DefaultServer() *ImmuServer {
 		userdata:             &usernameToUserdataMap{Userdata: make(map[string]*auth.User)},
 		GrpcServer:           grpc.NewServer(),
 		StreamServiceFactory: stream.NewStreamServiceFactory(DefaultOptions().StreamChunkSize),
+		freeDiskSpace:        freeDiskSpace,
+		auditSampler:         rand.Float64,
 	}
 }
 
@@ -104,6 +132,7 @@ type ImmuServerIf interface {
 	WithOptions(options *Options) ImmuServerIf
 	WithLogger(logger.Logger) ImmuServerIf
 	WithStateSigner(stateSigner StateSigner) ImmuServerIf
+	WithWriteValidator(writeValidator WriteValidator) ImmuServerIf
 	WithStreamServiceFactory(ssf stream.ServiceFactory) ImmuServerIf
 	WithPgsqlServer(psrv pgsqlsrv.Server) ImmuServerIf
 }
@@ -120,6 +149,12 @@ func (s *ImmuServer) WithStateSigner(stateSigner StateSigner) ImmuServerIf {
 	return s
 }
 
+// WithWriteValidator ...
+func (s *ImmuServer) WithWriteValidator(writeValidator WriteValidator) ImmuServerIf {
+	s.WriteValidator = writeValidator
+	return s
+}
+
 func (s *ImmuServer) WithStreamServiceFactory(ssf stream.ServiceFactory) ImmuServerIf {
 	s.StreamServiceFactory = ssf
 	return s
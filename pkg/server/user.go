@@ -30,27 +30,34 @@ func (s *ImmuServer) Login(ctx context.Context, r *schema.LoginRequest) (*schema
 		return nil, errors.New(ErrUserNotActive)
 	}
 
+	if u.Username == auth.SysAdminUsername {
+		u.IsSysAdmin = true
+	}
+
 	var token string
+	var database string
 
 	if s.multidbmode {
 		//-1 no database yet, must exec the "use" (UseDatabase) command first
-		token, err = auth.GenerateToken(*u, -1, s.Options.TokenExpiryTimeMin)
+		//unless the user has a default database to start with
+		dbid := s.defaultDatabaseIndex(u)
+		if dbid >= 0 {
+			database = u.DefaultDatabase
+		}
+		token, err = auth.GenerateToken(*u, dbid, s.Options.TokenExpiryTimeMin)
 	} else {
+		database = s.dbList.GetByIndex(defaultDbIndex).GetName()
 		token, err = auth.GenerateToken(*u, defaultDbIndex, s.Options.TokenExpiryTimeMin)
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	loginResponse := &schema.LoginResponse{Token: token}
+	loginResponse := &schema.LoginResponse{Token: token, Namespace: u.Namespace, Database: database}
 	if u.Username == auth.SysAdminUsername && string(r.GetPassword()) == auth.SysAdminPassword {
 		loginResponse.Warning = []byte(auth.WarnDefaultAdminPassword)
 	}
 
-	if u.Username == auth.SysAdminUsername {
-		u.IsSysAdmin = true
-	}
-
 	//add user to loggedin list
 	s.addUserToLoginList(u)
 
@@ -131,12 +138,21 @@ func (s *ImmuServer) CreateUser(ctx context.Context, r *schema.CreateUserRequest
 		return nil, fmt.Errorf("can not create another system admin")
 	}
 
+	//admins could otherwise create users out of the namespace
+	if r.Namespace != "" && r.Permission == auth.PermissionAdmin {
+		return nil, ErrNamespacedAdmin
+	}
+
+	if r.Namespace != "" && !auth.IsValidNamespace(r.Namespace) {
+		return nil, ErrInvalidNamespace
+	}
+
 	_, err = s.getUser(r.User, true)
 	if err == nil {
 		return nil, fmt.Errorf("user already exists")
 	}
 
-	_, _, err = s.insertNewUser(r.User, r.Password, r.GetPermission(), r.Database, true, loggedInuser.Username)
+	_, _, err = s.insertNewUser(r.User, r.Password, r.GetPermission(), r.Database, r.Namespace, true, loggedInuser.Username)
 	if err != nil {
 		return nil, err
 	}
@@ -195,11 +211,13 @@ func (s *ImmuServer) ListUsers(ctx context.Context, req *empty.Empty) (*schema.U
 			}
 
 			u := schema.User{
-				User:        []byte(user.Username),
-				Createdat:   user.CreatedAt.String(),
-				Createdby:   user.CreatedBy,
-				Permissions: permissions,
-				Active:      user.Active,
+				User:            []byte(user.Username),
+				Createdat:       user.CreatedAt.String(),
+				Createdby:       user.CreatedBy,
+				Permissions:     permissions,
+				Active:          user.Active,
+				Namespace:       user.Namespace,
+				DefaultDatabase: user.DefaultDatabase,
 			}
 
 			userlist.Users = append(userlist.Users, &u)
@@ -240,11 +258,13 @@ func (s *ImmuServer) ListUsers(ctx context.Context, req *empty.Empty) (*schema.U
 
 			if include {
 				u := schema.User{
-					User:        []byte(user.Username),
-					Createdat:   user.CreatedAt.String(),
-					Createdby:   user.CreatedBy,
-					Permissions: permissions,
-					Active:      user.Active,
+					User:            []byte(user.Username),
+					Createdat:       user.CreatedAt.String(),
+					Createdby:       user.CreatedBy,
+					Permissions:     permissions,
+					Active:          user.Active,
+					Namespace:       user.Namespace,
+					DefaultDatabase: user.DefaultDatabase,
 				}
 
 				userlist.Users = append(userlist.Users, &u)
@@ -406,6 +426,10 @@ func (s *ImmuServer) ChangePermission(ctx context.Context, r *schema.ChangePermi
 		}
 	}
 
+	if targetUser.Namespace != "" && r.Action == schema.PermissionAction_GRANT && r.Permission == auth.PermissionAdmin {
+		return nil, ErrNamespacedAdmin
+	}
+
 	if r.Action == schema.PermissionAction_REVOKE {
 		targetUser.RevokePermission(r.Database)
 	} else {
@@ -483,10 +507,95 @@ func (s *ImmuServer) SetActiveUser(ctx context.Context, r *schema.SetActiveUserR
 	return new(empty.Empty), nil
 }
 
+// SetUserDefaultDatabase sets the database sessions of a user start with, an empty database clears it.
+// Users can set their own default database, admins the one of the users they created
+func (s *ImmuServer) SetUserDefaultDatabase(ctx context.Context, r *schema.SetUserDefaultDatabaseRequest) (*empty.Empty, error) {
+	s.Logger.Debugf("SetUserDefaultDatabase")
+
+	if r == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	if s.Options.GetMaintenance() {
+		return nil, ErrNotAllowedInMaintenanceMode
+	}
+
+	if !s.Options.GetAuth() {
+		return nil, fmt.Errorf("this command is available only with authentication on")
+	}
+
+	if len(r.Username) == 0 {
+		return nil, fmt.Errorf("username can not be empty")
+	}
+
+	_, user, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targetUser, err := s.getUser([]byte(r.Username), true)
+	if err != nil {
+		return nil, fmt.Errorf("user %s not found", r.Username)
+	}
+
+	if r.Username != user.Username && !user.IsSysAdmin {
+		if !user.HasAtLeastOnePermission(auth.PermissionAdmin) {
+			return nil, fmt.Errorf("user is not system admin nor admin in any of the databases")
+		}
+
+		if user.Username != targetUser.CreatedBy {
+			return nil, fmt.Errorf("%s was not created by you", r.Username)
+		}
+	}
+
+	if targetUser.Username == auth.SysAdminUsername {
+		targetUser.IsSysAdmin = true
+	}
+
+	if r.Database != "" {
+		if r.Database != SystemDBName && s.dbList.GetId(r.Database) < 0 {
+			return nil, errors.New(fmt.Sprintf("'%s' does not exist", r.Database)).WithCode(errors.CodInvalidDatabaseName)
+		}
+
+		if !hasDatabaseAccess(targetUser, r.Database) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s does not have permission on this database", r.Username)
+		}
+	}
+
+	targetUser.DefaultDatabase = r.Database
+
+	if err := s.saveUser(targetUser); err != nil {
+		return nil, err
+	}
+
+	return new(empty.Empty), nil
+}
+
+// defaultDatabaseIndex returns the index of the default database of the user, -1 if there is none
+// or if it can not be used anymore because it was deleted or the permission on it was revoked
+func (s *ImmuServer) defaultDatabaseIndex(u *auth.User) int64 {
+	if u.DefaultDatabase == "" || !hasDatabaseAccess(u, u.DefaultDatabase) {
+		return -1
+	}
+
+	if u.DefaultDatabase == SystemDBName {
+		return sysDBIndex
+	}
+
+	return s.dbList.GetId(u.DefaultDatabase)
+}
+
+func hasDatabaseAccess(u *auth.User, database string) bool {
+	return u.IsSysAdmin ||
+		u.HasPermission(database, auth.PermissionAdmin) ||
+		u.HasPermission(database, auth.PermissionR) ||
+		u.HasPermission(database, auth.PermissionRW)
+}
+
 // insertNewUser inserts a new user to the system database and returns username and plain text password
 // A new password is generated automatically if passed parameter is empty
 // If enforceStrongAuth is true it checks if username and password meet security criteria
-func (s *ImmuServer) insertNewUser(username []byte, plainPassword []byte, permission uint32, database string, enforceStrongAuth bool, createdBy string) ([]byte, []byte, error) {
+func (s *ImmuServer) insertNewUser(username []byte, plainPassword []byte, permission uint32, database string, namespace string, enforceStrongAuth bool, createdBy string) ([]byte, []byte, error) {
 	if enforceStrongAuth {
 		if !auth.IsValidUsername(string(username)) {
 			return nil, nil, status.Errorf(
@@ -512,6 +621,7 @@ func (s *ImmuServer) insertNewUser(username []byte, plainPassword []byte, permis
 	userdata.Permissions = append(userdata.Permissions, auth.Permission{Permission: permission, Database: database})
 	userdata.CreatedBy = createdBy
 	userdata.CreatedAt = time.Now()
+	userdata.Namespace = namespace
 
 	if permission == auth.PermissionSysAdmin {
 		userdata.IsSysAdmin = true
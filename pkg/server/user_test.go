@@ -360,3 +360,132 @@ func testServerChangePassword(ctx context.Context, s *ImmuServer, t *testing.T)
 		t.Fatalf("ChangePassword error %v", err)
 	}
 }
+
+func TestServerUserDefaultDatabase(t *testing.T) {
+	serverOptions := DefaultOptions().WithMetricsServer(false).WithPort(0).WithAdminPassword(auth.SysAdminPassword)
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+	require.Equal(t, DefaultDBName, lr.Database)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.CreateDatabaseWith(ctx, &schema.DatabaseSettings{DatabaseName: testDatabase})
+	require.NoError(t, err)
+
+	_, err = s.CreateUser(ctx, &schema.CreateUserRequest{
+		User:       testUsername,
+		Password:   testPassword,
+		Database:   testDatabase,
+		Permission: auth.PermissionRW,
+	})
+	require.NoError(t, err)
+
+	login := func() *schema.LoginResponse {
+		lr, err := s.Login(context.Background(), &schema.LoginRequest{User: testUsername, Password: testPassword})
+		require.NoError(t, err)
+		return lr
+	}
+
+	require.Empty(t, login().Database)
+
+	_, err = s.SetUserDefaultDatabase(ctx, nil)
+	require.ErrorIs(t, err, ErrIllegalArguments)
+
+	_, err = s.SetUserDefaultDatabase(ctx, &schema.SetUserDefaultDatabaseRequest{Database: testDatabase})
+	require.Error(t, err)
+
+	_, err = s.SetUserDefaultDatabase(ctx, &schema.SetUserDefaultDatabaseRequest{Username: "unknown", Database: testDatabase})
+	require.Error(t, err)
+
+	_, err = s.SetUserDefaultDatabase(ctx, &schema.SetUserDefaultDatabaseRequest{Username: string(testUsername), Database: "unknown"})
+	require.Error(t, err)
+
+	_, err = s.SetUserDefaultDatabase(ctx, &schema.SetUserDefaultDatabaseRequest{Username: string(testUsername), Database: DefaultDBName})
+	require.Error(t, err)
+
+	_, err = s.SetUserDefaultDatabase(ctx, &schema.SetUserDefaultDatabaseRequest{Username: string(testUsername), Database: SystemDBName})
+	require.Error(t, err)
+
+	_, err = s.SetUserDefaultDatabase(ctx, &schema.SetUserDefaultDatabaseRequest{Username: string(testUsername), Database: testDatabase})
+	require.NoError(t, err)
+
+	users, err := s.ListUsers(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+
+	for _, u := range users.Users {
+		if string(u.User) == string(testUsername) {
+			require.Equal(t, testDatabase, u.DefaultDatabase)
+		}
+	}
+
+	t.Run("login should select the default database", func(t *testing.T) {
+		lr := login()
+		require.Equal(t, testDatabase, lr.Database)
+
+		uctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+		state, err := s.CurrentState(uctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		require.Equal(t, testDatabase, state.Db)
+
+		_, err = s.SetUserDefaultDatabase(uctx, &schema.SetUserDefaultDatabaseRequest{Username: auth.SysAdminUsername, Database: testDatabase})
+		require.Error(t, err)
+	})
+
+	t.Run("sessions should start with the default database", func(t *testing.T) {
+		resp, err := s.OpenSession(context.Background(), &schema.OpenSessionRequest{
+			Username: testUsername,
+			Password: testPassword,
+		})
+		require.NoError(t, err)
+		require.Equal(t, testDatabase, resp.Database)
+
+		resp, err = s.OpenSession(context.Background(), &schema.OpenSessionRequest{
+			Username:     []byte(auth.SysAdminUsername),
+			Password:     []byte(auth.SysAdminPassword),
+			DatabaseName: DefaultDBName,
+		})
+		require.NoError(t, err)
+		require.Equal(t, DefaultDBName, resp.Database)
+	})
+
+	t.Run("a revoked default database should not be selected", func(t *testing.T) {
+		_, err = s.ChangePermission(ctx, &schema.ChangePermissionRequest{
+			Action:     schema.PermissionAction_REVOKE,
+			Database:   testDatabase,
+			Permission: auth.PermissionRW,
+			Username:   string(testUsername),
+		})
+		require.NoError(t, err)
+
+		require.Empty(t, login().Database)
+
+		_, err = s.ChangePermission(ctx, &schema.ChangePermissionRequest{
+			Action:     schema.PermissionAction_GRANT,
+			Database:   testDatabase,
+			Permission: auth.PermissionR,
+			Username:   string(testUsername),
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, testDatabase, login().Database)
+	})
+
+	t.Run("users should be able to clear their own default database", func(t *testing.T) {
+		uctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", login().Token))
+
+		_, err = s.SetUserDefaultDatabase(uctx, &schema.SetUserDefaultDatabaseRequest{Username: string(testUsername)})
+		require.NoError(t, err)
+
+		require.Empty(t, login().Database)
+	})
+}
@@ -0,0 +1,468 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+)
+
+// WriteRequest holds the writes of a transaction about to be committed.
+// Keys are provided as stored, including the namespace of the user if any.
+// SQL statements are provided either as text (SQL) or already parsed (SQLStmts),
+// the latter when received through the pgsql wire protocol.
+type WriteRequest struct {
+	Database   string
+	Username   string
+	KVs        []*schema.KeyValue
+	References []*schema.ReferenceRequest
+	ZAdds      []*schema.ZAddRequest
+	Deleted    [][]byte
+	Forgotten  [][]byte
+	SQL        *schema.SQLExecRequest
+	SQLStmts   []sql.SQLStmt
+	SQLParams  []*schema.NamedParam
+}
+
+// WriteValidator is invoked before committing writes received through the gRPC API or the pgsql wire protocol.
+// Returning an error rejects the whole transaction, the error is returned to the client.
+type WriteValidator interface {
+	ValidateWrite(req *WriteRequest) error
+}
+
+// WriteValidatorFunc adapts a function to the WriteValidator interface
+type WriteValidatorFunc func(req *WriteRequest) error
+
+func (f WriteValidatorFunc) ValidateWrite(req *WriteRequest) error {
+	return f(req)
+}
+
+// validatedDB submits writes to the validator before handing them over to the database.
+// The database is not embedded on purpose: every method of database.DB is implemented explicitly,
+// so that new write operations can not silently bypass the validation.
+// Replicated transactions and maintenance operations are not validated.
+type validatedDB struct {
+	db        database.DB
+	validator WriteValidator
+	username  string
+}
+
+func newValidatedDB(db database.DB, validator WriteValidator, username string) *validatedDB {
+	return &validatedDB{db: db, validator: validator, username: username}
+}
+
+func (d *validatedDB) validate(req *WriteRequest) error {
+	req.Database = d.db.GetName()
+	req.Username = d.username
+
+	return d.validator.ValidateWrite(req)
+}
+
+func (d *validatedDB) Set(req *schema.SetRequest) (*schema.TxHeader, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(&WriteRequest{KVs: req.KVs})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.db.Set(req)
+}
+
+func (d *validatedDB) VerifiableSet(req *schema.VerifiableSetRequest) (*schema.VerifiableTx, error) {
+	if req == nil || req.SetRequest == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(&WriteRequest{KVs: req.SetRequest.KVs})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.db.VerifiableSet(req)
+}
+
+func (d *validatedDB) SetReference(req *schema.ReferenceRequest) (*schema.TxHeader, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(&WriteRequest{References: []*schema.ReferenceRequest{req}})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.db.SetReference(req)
+}
+
+func (d *validatedDB) VerifiableSetReference(req *schema.VerifiableReferenceRequest) (*schema.VerifiableTx, error) {
+	if req == nil || req.ReferenceRequest == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(&WriteRequest{References: []*schema.ReferenceRequest{req.ReferenceRequest}})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.db.VerifiableSetReference(req)
+}
+
+func (d *validatedDB) ZAdd(req *schema.ZAddRequest) (*schema.TxHeader, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(&WriteRequest{ZAdds: []*schema.ZAddRequest{req}})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.db.ZAdd(req)
+}
+
+func (d *validatedDB) VerifiableZAdd(req *schema.VerifiableZAddRequest) (*schema.VerifiableTx, error) {
+	if req == nil || req.ZAddRequest == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(&WriteRequest{ZAdds: []*schema.ZAddRequest{req.ZAddRequest}})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.db.VerifiableZAdd(req)
+}
+
+func (d *validatedDB) Delete(req *schema.DeleteKeysRequest) (*schema.TxHeader, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(&WriteRequest{Deleted: req.Keys})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.db.Delete(req)
+}
+
+func (d *validatedDB) ExecAll(req *schema.ExecAllRequest) (*schema.TxHeader, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(execAllWriteRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return d.db.ExecAll(req)
+}
+
+func (d *validatedDB) PrepareExecAll(req *schema.ExecAllRequest) (database.PreparedTx, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(execAllWriteRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return d.db.PrepareExecAll(req)
+}
+
+func execAllWriteRequest(req *schema.ExecAllRequest) *WriteRequest {
+	wreq := &WriteRequest{}
+
+	for _, op := range req.Operations {
+		if op == nil {
+			continue
+		}
+
+		switch x := op.Operation.(type) {
+		case *schema.Op_Kv:
+			wreq.KVs = append(wreq.KVs, x.Kv)
+		case *schema.Op_Ref:
+			wreq.References = append(wreq.References, x.Ref)
+		case *schema.Op_ZAdd:
+			wreq.ZAdds = append(wreq.ZAdds, x.ZAdd)
+		}
+	}
+
+	return wreq
+}
+
+func (d *validatedDB) SQLExec(req *schema.SQLExecRequest, tx *sql.SQLTx) (*sql.SQLTx, []*sql.SQLTx, error) {
+	if req == nil {
+		return nil, nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(&WriteRequest{SQL: req})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d.db.SQLExec(req, tx)
+}
+
+func (d *validatedDB) SQLExecScript(req *schema.SQLExecRequest) (*sql.SQLTx, error) {
+	if req == nil {
+		return nil, database.ErrIllegalArguments
+	}
+
+	err := d.validate(&WriteRequest{SQL: req})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.db.SQLExecScript(req)
+}
+
+func (d *validatedDB) SQLExecPrepared(stmts []sql.SQLStmt, namedParams []*schema.NamedParam, tx *sql.SQLTx) (*sql.SQLTx, []*sql.SQLTx, error) {
+	err := d.validate(&WriteRequest{SQLStmts: stmts, SQLParams: namedParams})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d.db.SQLExecPrepared(stmts, namedParams, tx)
+}
+
+func (d *validatedDB) Forget(req *schema.ForgetRequest) error {
+	if req == nil {
+		return database.ErrIllegalArguments
+	}
+
+	err := d.validate(&WriteRequest{Forgotten: [][]byte{req.Key}})
+	if err != nil {
+		return err
+	}
+
+	return d.db.Forget(req)
+}
+
+// SQLNamespace returns a view of the namespace which is validated as well
+func (d *validatedDB) SQLNamespace(prefix []byte) (database.DB, error) {
+	db, err := d.db.SQLNamespace(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return newValidatedDB(db, d.validator, d.username), nil
+}
+
+// NewSQLTx does not write by itself, statements run within the transaction
+// are submitted through SQLExec or SQLExecPrepared and validated there
+func (d *validatedDB) NewSQLTx(waitForIndexing bool) (*sql.SQLTx, error) {
+	return d.db.NewSQLTx(waitForIndexing)
+}
+
+// ReplicateTx is not validated, replicated transactions were already validated by the primary database
+func (d *validatedDB) ReplicateTx(exportedTx []byte) (*schema.TxHeader, error) {
+	return d.db.ReplicateTx(exportedTx)
+}
+
+func (d *validatedDB) GetName() string {
+	return d.db.GetName()
+}
+
+func (d *validatedDB) GetOptions() *database.Options {
+	return d.db.GetOptions()
+}
+
+func (d *validatedDB) AsReplica(asReplica bool) {
+	d.db.AsReplica(asReplica)
+}
+
+func (d *validatedDB) IsReplica() bool {
+	return d.db.IsReplica()
+}
+
+func (d *validatedDB) AsReadOnly(readOnly bool) {
+	d.db.AsReadOnly(readOnly)
+}
+
+func (d *validatedDB) IsReadOnly() bool {
+	return d.db.IsReadOnly()
+}
+
+func (d *validatedDB) UseTimeFunc(timeFunc store.TimeFunc) error {
+	return d.db.UseTimeFunc(timeFunc)
+}
+
+func (d *validatedDB) CurrentState() (*schema.ImmutableState, error) {
+	return d.db.CurrentState()
+}
+
+func (d *validatedDB) Size() (uint64, error) {
+	return d.db.Size()
+}
+
+func (d *validatedDB) Get(req *schema.KeyRequest) (*schema.Entry, error) {
+	return d.db.Get(req)
+}
+
+func (d *validatedDB) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error) {
+	return d.db.VerifiableGet(req)
+}
+
+func (d *validatedDB) GetAll(req *schema.KeyListRequest) (*schema.Entries, error) {
+	return d.db.GetAll(req)
+}
+
+func (d *validatedDB) VerifiableGetAll(req *schema.VerifiableGetAllRequest) (*schema.VerifiableEntries, error) {
+	return d.db.VerifiableGetAll(req)
+}
+
+func (d *validatedDB) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
+	return d.db.Scan(req)
+}
+
+func (d *validatedDB) History(req *schema.HistoryRequest) (*schema.Entries, error) {
+	return d.db.History(req)
+}
+
+func (d *validatedDB) ComputeDiff(req *schema.DiffRequest, cancellation <-chan struct{}, send func(*schema.DiffEntry) error) error {
+	return d.db.ComputeDiff(req, cancellation, send)
+}
+
+func (d *validatedDB) Count(prefix *schema.KeyPrefix) (*schema.EntryCount, error) {
+	return d.db.Count(prefix)
+}
+
+func (d *validatedDB) CountAll() (*schema.EntryCount, error) {
+	return d.db.CountAll()
+}
+
+func (d *validatedDB) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
+	return d.db.ZScan(req)
+}
+
+func (d *validatedDB) VerifiableZScan(req *schema.VerifiableZScanRequest) (*schema.VerifiableZEntries, error) {
+	return d.db.VerifiableZScan(req)
+}
+
+func (d *validatedDB) InferJSONIngest(req *schema.IngestJSONRequest) (*schema.SQLExecRequest, error) {
+	return d.db.InferJSONIngest(req)
+}
+
+func (d *validatedDB) InferParameters(sql string, tx *sql.SQLTx) (map[string]sql.SQLValueType, error) {
+	return d.db.InferParameters(sql, tx)
+}
+
+func (d *validatedDB) InferParametersPrepared(stmt sql.SQLStmt, tx *sql.SQLTx) (map[string]sql.SQLValueType, error) {
+	return d.db.InferParametersPrepared(stmt, tx)
+}
+
+func (d *validatedDB) SQLQuery(req *schema.SQLQueryRequest, tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
+	return d.db.SQLQuery(req, tx)
+}
+
+func (d *validatedDB) SQLQueryWithCancellation(req *schema.SQLQueryRequest, tx *sql.SQLTx, cancellation <-chan struct{}) (*schema.SQLQueryResult, error) {
+	return d.db.SQLQueryWithCancellation(req, tx, cancellation)
+}
+
+func (d *validatedDB) SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedParam, tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
+	return d.db.SQLQueryPrepared(stmt, namedParams, tx)
+}
+
+func (d *validatedDB) SQLQueryRowReader(stmt *sql.SelectStmt, tx *sql.SQLTx) (sql.RowReader, error) {
+	return d.db.SQLQueryRowReader(stmt, tx)
+}
+
+func (d *validatedDB) VerifiableSQLGet(req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error) {
+	return d.db.VerifiableSQLGet(req)
+}
+
+func (d *validatedDB) SQLListen(req *schema.SQLListenRequest, cancellation <-chan struct{}, notify func(*schema.SQLNotification) error) error {
+	return d.db.SQLListen(req, cancellation, notify)
+}
+
+func (d *validatedDB) SQLBlob(req *schema.SQLBlobRequest, tx *sql.SQLTx) ([]byte, error) {
+	return d.db.SQLBlob(req, tx)
+}
+
+func (d *validatedDB) ListTables(tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
+	return d.db.ListTables(tx)
+}
+
+func (d *validatedDB) DescribeTable(table string, tx *sql.SQLTx) (*schema.SQLQueryResult, error) {
+	return d.db.DescribeTable(table, tx)
+}
+
+func (d *validatedDB) DescribeSchema(tx *sql.SQLTx) (*schema.SQLSchema, error) {
+	return d.db.DescribeSchema(tx)
+}
+
+func (d *validatedDB) IndexSuggestions() (*schema.IndexSuggestionList, error) {
+	return d.db.IndexSuggestions()
+}
+
+func (d *validatedDB) WaitForTx(txID uint64, cancellation <-chan struct{}) error {
+	return d.db.WaitForTx(txID, cancellation)
+}
+
+func (d *validatedDB) WaitForIndexingUpto(txID uint64, cancellation <-chan struct{}) error {
+	return d.db.WaitForIndexingUpto(txID, cancellation)
+}
+
+func (d *validatedDB) TxByID(req *schema.TxRequest) (*schema.Tx, error) {
+	return d.db.TxByID(req)
+}
+
+func (d *validatedDB) ExportTxByID(req *schema.TxRequest) ([]byte, error) {
+	return d.db.ExportTxByID(req)
+}
+
+func (d *validatedDB) VerifiableTxByID(req *schema.VerifiableTxRequest) (*schema.VerifiableTx, error) {
+	return d.db.VerifiableTxByID(req)
+}
+
+func (d *validatedDB) TxScan(req *schema.TxScanRequest) (*schema.TxList, error) {
+	return d.db.TxScan(req)
+}
+
+func (d *validatedDB) CompactIndex() error {
+	return d.db.CompactIndex()
+}
+
+func (d *validatedDB) IndexingProgress() (*schema.IndexingProgressResponse, error) {
+	return d.db.IndexingProgress()
+}
+
+func (d *validatedDB) CopyStateAt(txID uint64, dst database.DB) error {
+	return d.db.CopyStateAt(txID, dst)
+}
+
+func (d *validatedDB) VerifyTxs(fromTxID, toTxID uint64) error {
+	return d.db.VerifyTxs(fromTxID, toTxID)
+}
+
+func (d *validatedDB) Truncate(before time.Time, dryRun bool) (*database.TruncationReport, error) {
+	return d.db.Truncate(before, dryRun)
+}
+
+func (d *validatedDB) Close() error {
+	return d.db.Close()
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	gosql "database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestServerWriteValidator(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithPort(0).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	errForbidden := errors.New("forbidden")
+
+	var validated []*WriteRequest
+
+	validator := WriteValidatorFunc(func(req *WriteRequest) error {
+		validated = append(validated, req)
+
+		for _, kv := range req.KVs {
+			if bytes.HasPrefix(kv.Key, []byte("forbidden")) {
+				return errForbidden
+			}
+		}
+
+		for _, ref := range req.References {
+			if bytes.HasPrefix(ref.Key, []byte("forbidden")) {
+				return errForbidden
+			}
+		}
+
+		if req.SQL != nil && strings.Contains(req.SQL.Sql, "DROP") {
+			return errForbidden
+		}
+
+		for _, stmt := range req.SQLStmts {
+			if _, ok := stmt.(*sql.CreateIndexStmt); ok {
+				return errForbidden
+			}
+		}
+
+		return nil
+	})
+
+	s := DefaultServer().WithOptions(serverOptions).WithWriteValidator(validator).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	require.Len(t, validated, 1)
+	require.Equal(t, DefaultDBName, validated[0].Database)
+	require.Equal(t, auth.SysAdminUsername, validated[0].Username)
+	require.Equal(t, []byte("key1"), validated[0].KVs[0].Key)
+
+	_, err = s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{
+		{Key: []byte("key2"), Value: []byte("value2")},
+		{Key: []byte("forbidden1"), Value: []byte("value")},
+	}})
+	require.Equal(t, errForbidden, err)
+
+	_, err = s.Get(ctx, &schema.KeyRequest{Key: []byte("key2")})
+	require.Error(t, err)
+
+	_, err = s.ExecAll(ctx, &schema.ExecAllRequest{Operations: []*schema.Op{
+		{Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte("key2"), Value: []byte("value2")}}},
+		{Operation: &schema.Op_Ref{Ref: &schema.ReferenceRequest{Key: []byte("forbidden2"), ReferencedKey: []byte("key1")}}},
+	}})
+	require.Equal(t, errForbidden, err)
+
+	_, err = s.SetReference(ctx, &schema.ReferenceRequest{Key: []byte("ref1"), ReferencedKey: []byte("key1")})
+	require.NoError(t, err)
+
+	_, err = s.SQLExec(ctx, &schema.SQLExecRequest{Sql: "CREATE TABLE table1(id INTEGER, PRIMARY KEY id)"})
+	require.NoError(t, err)
+
+	_, err = s.SQLExec(ctx, &schema.SQLExecRequest{Sql: "DROP TABLE table1"})
+	require.Equal(t, errForbidden, err)
+
+	_, err = s.Delete(ctx, &schema.DeleteKeysRequest{Keys: [][]byte{[]byte("key1")}})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("key1")}, validated[len(validated)-1].Deleted)
+
+	t.Run("prepared SQL statements should be validated", func(t *testing.T) {
+		db, err := s.getDBFromCtx(ctx, "SQLExec")
+		require.NoError(t, err)
+
+		stmts, err := sql.Parse(strings.NewReader("CREATE INDEX ON table1(id)"))
+		require.NoError(t, err)
+
+		_, _, err = db.SQLExecPrepared(stmts, nil, nil)
+		require.Equal(t, errForbidden, err)
+		require.Equal(t, stmts, validated[len(validated)-1].SQLStmts)
+
+		tx, err := db.NewSQLTx(false)
+		require.NoError(t, err)
+		defer tx.Cancel()
+
+		_, _, err = db.SQLExecPrepared(stmts, nil, tx)
+		require.Equal(t, errForbidden, err)
+	})
+
+	t.Run("SQL run within interactive transactions should be validated", func(t *testing.T) {
+		resp, err := s.OpenSession(context.Background(), &schema.OpenSessionRequest{
+			Username:     []byte(auth.SysAdminUsername),
+			Password:     []byte(auth.SysAdminPassword),
+			DatabaseName: DefaultDBName,
+		})
+		require.NoError(t, err)
+
+		sctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("sessionid", resp.SessionID))
+
+		txResp, err := s.NewTx(sctx, &schema.NewTxRequest{Mode: schema.TxMode_ReadWrite})
+		require.NoError(t, err)
+
+		tctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("sessionid", resp.SessionID, "transactionid", txResp.TransactionID))
+
+		_, err = s.TxSQLExec(tctx, &schema.SQLExecRequest{Sql: "INSERT INTO table1(id) VALUES (1)"})
+		require.NoError(t, err)
+		require.Equal(t, auth.SysAdminUsername, validated[len(validated)-1].Username)
+
+		_, err = s.TxSQLExec(tctx, &schema.SQLExecRequest{Sql: "DROP TABLE table1"})
+		require.Equal(t, errForbidden, err)
+
+		_, err = s.Rollback(tctx, &emptypb.Empty{})
+		require.NoError(t, err)
+	})
+}
+
+func TestServerWriteValidatorPgsql(t *testing.T) {
+	dir := t.TempDir()
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithPort(0).
+		WithMetricsServer(false).
+		WithPgsqlServer(true).
+		WithPgsqlServerPort(0).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	errForbidden := errors.New("forbidden")
+
+	var validated []*WriteRequest
+
+	validator := WriteValidatorFunc(func(req *WriteRequest) error {
+		validated = append(validated, req)
+
+		for _, stmt := range req.SQLStmts {
+			if _, ok := stmt.(*sql.CreateIndexStmt); ok {
+				return errForbidden
+			}
+		}
+
+		return nil
+	})
+
+	s := DefaultServer().WithOptions(serverOptions).WithWriteValidator(validator).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	go s.PgsqlSrv.Serve()
+	defer s.PgsqlSrv.Stop()
+	defer s.CloseDatabases()
+
+	db, err := gosql.Open("postgres", fmt.Sprintf("host=localhost port=%d sslmode=disable user=immudb dbname=defaultdb password=%s", s.PgsqlSrv.GetPort(), auth.SysAdminPassword))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE table1(id INTEGER, PRIMARY KEY id)")
+	require.NoError(t, err)
+	require.NotEmpty(t, validated)
+	require.Equal(t, DefaultDBName, validated[len(validated)-1].Database)
+	require.Equal(t, auth.SysAdminUsername, validated[len(validated)-1].Username)
+
+	_, err = db.Exec("CREATE INDEX ON table1(id)")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), errForbidden.Error())
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM table1").Scan(&count)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
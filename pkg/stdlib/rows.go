@@ -53,6 +53,8 @@ func (r *Rows) Columns() []string {
 //	VarcharType   SQLValueType = "VARCHAR"
 //	BLOBType      SQLValueType = "BLOB"
 //	TimestampType SQLValueType = "TIMESTAMP"
+//	FloatType     SQLValueType = "FLOAT"
+//	DecimalType   SQLValueType = "DECIMAL"
 //	AnyType       SQLValueType = "ANY"
 func (r *Rows) ColumnTypeDatabaseTypeName(index int) string {
 	if len(r.rows) <= 0 || len(r.rows[0].Values)-1 < index {
@@ -85,6 +87,18 @@ func (r *Rows) ColumnTypeDatabaseTypeName(index int) string {
 		{
 			return "TIMESTAMP"
 		}
+	case *schema.SQLValue_F:
+		{
+			return "FLOAT"
+		}
+	case *schema.SQLValue_D:
+		{
+			return "DECIMAL"
+		}
+	case *schema.SQLValue_Json:
+		{
+			return "JSON"
+		}
 	default:
 		return "ANY"
 	}
@@ -123,6 +137,18 @@ func (r *Rows) ColumnTypeLength(index int) (int64, bool) {
 		{
 			return math.MaxInt64, true
 		}
+	case *schema.SQLValue_F:
+		{
+			return 8, false
+		}
+	case *schema.SQLValue_D:
+		{
+			return 16, false
+		}
+	case *schema.SQLValue_Json:
+		{
+			return math.MaxInt64, true
+		}
 	default:
 		return math.MaxInt64, true
 	}
@@ -131,7 +157,17 @@ func (r *Rows) ColumnTypeLength(index int) (int64, bool) {
 // ColumnTypePrecisionScale should return the precision and scale for decimal
 // types. If not applicable, variableLength should be false.
 func (r *Rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
-	return 0, 0, false
+	if len(r.rows) <= 0 || len(r.rows[0].Values)-1 < index {
+		return 0, 0, false
+	}
+
+	_, isDecimal := r.rows[0].Values[index].Value.(*schema.SQLValue_D)
+	if !isDecimal {
+		return 0, 0, false
+	}
+
+	// int64 integer part
+	return 19 + sql.DecimalScale, sql.DecimalScale, true
 }
 
 // ColumnTypeScanType returns the value type that can be used to scan types into.
@@ -167,6 +203,18 @@ func (r *Rows) ColumnTypeScanType(index int) reflect.Type {
 		{
 			return reflect.TypeOf(time.Time{})
 		}
+	case *schema.SQLValue_F:
+		{
+			return reflect.TypeOf(float64(0))
+		}
+	case *schema.SQLValue_D:
+		{
+			return reflect.TypeOf("")
+		}
+	case *schema.SQLValue_Json:
+		{
+			return reflect.TypeOf("")
+		}
 	default:
 		return reflect.TypeOf("")
 	}
@@ -322,6 +370,18 @@ func RenderValue(op interface{}) interface{} {
 		{
 			return sql.TimeFromInt64(v.Ts)
 		}
+	case *schema.SQLValue_F:
+		{
+			return v.F
+		}
+	case *schema.SQLValue_D:
+		{
+			return v.D
+		}
+	case *schema.SQLValue_Json:
+		{
+			return v.Json
+		}
 	}
 	return []byte(fmt.Sprintf("%v", op))
 }